@@ -28,6 +28,15 @@
 // This releases the task from one instance and claims it for another,
 // publishing a [event.TaskReassignedEvent].
 //
+// # Preemption
+//
+// With [WithPreemption], each rebalance tick also looks for a ready task
+// that has waited too long behind running tasks of much lower priority. The
+// Lead returns the lowest-priority running task to the queue and publishes
+// an [event.TaskPreemptedEvent], on which the executor pauses the task's
+// instance; the freed slot then goes to the waiting task. A task is
+// preempted at most once.
+//
 // # Basic Usage
 //
 //	lead := adaptive.NewLead(queue, bus,
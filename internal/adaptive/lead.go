@@ -33,6 +33,9 @@ type Lead struct {
 	staleClaimTimeout   time.Duration
 	rebalanceInterval   time.Duration
 	maxTasksPerInstance int
+	preemptionEnabled   bool
+	preemptionWait      time.Duration
+	preemptionMinGap    int
 }
 
 // NewLead creates a Lead that monitors queue events on the given bus.
@@ -273,6 +276,7 @@ func (l *Lead) rebalanceLoop(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
+			l.checkPreemption()
 			l.checkRebalance()
 		}
 	}
@@ -325,3 +329,31 @@ func (l *Lead) checkRebalance() {
 	taskToMove := tasks[len(tasks)-1]
 	l.Reassign(taskToMove.ID, maxID, minID) //nolint:errcheck // best-effort rebalance
 }
+
+// checkPreemption pauses a low-priority running task when a much more urgent
+// task has waited too long for an instance. The paused task goes back to the
+// queue, and the TaskPreemptedEvent tells the executor to stop its instance;
+// the slot that frees up is then claimed by the waiting task, which outranks
+// everything else that is ready. At most one task is preempted per check.
+func (l *Lead) checkPreemption() {
+	if !l.preemptionEnabled {
+		return
+	}
+
+	p, ok := l.queue.PreemptionCandidate(l.preemptionWait, l.preemptionMinGap)
+	if !ok {
+		return
+	}
+	if err := l.queue.Preempt(p.VictimID); err != nil {
+		return // the task finished or was released since the check
+	}
+
+	l.mu.Lock()
+	l.workloads[p.InstanceID]--
+	if l.workloads[p.InstanceID] <= 0 {
+		delete(l.workloads, p.InstanceID)
+	}
+	l.mu.Unlock()
+
+	l.bus.Publish(event.NewTaskPreemptedEvent(p.VictimID, p.InstanceID, p.TaskID, "priority"))
+}
@@ -19,6 +19,8 @@ type mockQueue struct {
 	claimErr      error
 	releaseErr    error
 	instanceTasks map[string][]*taskqueue.QueuedTask
+	preemption    *taskqueue.Preemption
+	preempted     []string
 }
 
 func newMockQueue() *mockQueue {
@@ -58,6 +60,22 @@ func (m *mockQueue) GetInstanceTasks(instanceID string) []*taskqueue.QueuedTask
 	return m.instanceTasks[instanceID]
 }
 
+func (m *mockQueue) PreemptionCandidate(time.Duration, int) (taskqueue.Preemption, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.preemption == nil {
+		return taskqueue.Preemption{}, false
+	}
+	return *m.preemption, true
+}
+
+func (m *mockQueue) Preempt(taskID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.preempted = append(m.preempted, taskID)
+	return nil
+}
+
 func (m *mockQueue) setStatus(s taskqueue.QueueStatus) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -183,7 +201,7 @@ func TestHandleTaskCompleted(t *testing.T) {
 
 	bus.Publish(event.NewTaskClaimedEvent("task-1", "inst-1"))
 	bus.Publish(event.NewTaskClaimedEvent("task-2", "inst-1"))
-	bus.Publish(event.NewTaskCompletedEvent("task-1", "inst-1", true, "done"))
+	bus.Publish(event.NewTaskCompletedEvent("task-1", "inst-1", true, "done", ""))
 
 	dist := lead.GetWorkloadDistribution()
 	if dist["inst-1"] != 1 {
@@ -199,7 +217,7 @@ func TestHandleTaskCompletedRemovesIdleInstance(t *testing.T) {
 	defer lead.Stop()
 
 	bus.Publish(event.NewTaskClaimedEvent("task-1", "inst-1"))
-	bus.Publish(event.NewTaskCompletedEvent("task-1", "inst-1", true, "done"))
+	bus.Publish(event.NewTaskCompletedEvent("task-1", "inst-1", true, "done", ""))
 
 	dist := lead.GetWorkloadDistribution()
 	if _, exists := dist["inst-1"]; exists {
@@ -215,7 +233,7 @@ func TestHandleTaskCompletedEmptyInstance(t *testing.T) {
 	defer lead.Stop()
 
 	// task.completed with empty InstanceID should be ignored.
-	bus.Publish(event.NewTaskCompletedEvent("task-1", "", true, "done"))
+	bus.Publish(event.NewTaskCompletedEvent("task-1", "", true, "done", ""))
 
 	dist := lead.GetWorkloadDistribution()
 	if len(dist) != 0 {
@@ -765,9 +783,61 @@ func TestHandleWrongEventType(t *testing.T) {
 	lead.handleTaskCompleted(event.NewInstanceStartedEvent("", "", "", ""))
 }
 
+func TestCheckPreemption(t *testing.T) {
+	candidate := &taskqueue.Preemption{TaskID: "urgent", VictimID: "docs", InstanceID: "inst-1"}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		mq := newMockQueue()
+		mq.preemption = candidate
+		lead := NewLead(mq, event.NewBus())
+
+		lead.checkPreemption()
+
+		if len(mq.preempted) != 0 {
+			t.Errorf("preempted %v with preemption disabled", mq.preempted)
+		}
+	})
+
+	t.Run("preempts the candidate", func(t *testing.T) {
+		mq := newMockQueue()
+		mq.preemption = candidate
+		bus := event.NewBus()
+		var got []event.TaskPreemptedEvent
+		bus.Subscribe("adaptive.task_preempted", func(e event.Event) {
+			got = append(got, e.(event.TaskPreemptedEvent))
+		})
+		lead := NewLead(mq, bus, WithPreemption(time.Minute, 2))
+		lead.workloads["inst-1"] = 1
+
+		lead.checkPreemption()
+
+		if len(mq.preempted) != 1 || mq.preempted[0] != "docs" {
+			t.Errorf("preempted = %v, want [docs]", mq.preempted)
+		}
+		if len(got) != 1 || got[0].TaskID != "docs" || got[0].InstanceID != "inst-1" || got[0].PreemptingTaskID != "urgent" {
+			t.Errorf("events = %+v, want one preempting docs for urgent", got)
+		}
+		if _, ok := lead.GetWorkloadDistribution()["inst-1"]; ok {
+			t.Error("inst-1 still has a workload after its task was preempted")
+		}
+	})
+
+	t.Run("no candidate", func(t *testing.T) {
+		mq := newMockQueue()
+		lead := NewLead(mq, event.NewBus(), WithPreemption(time.Minute, 2))
+
+		lead.checkPreemption()
+
+		if len(mq.preempted) != 0 {
+			t.Errorf("preempted %v without a candidate", mq.preempted)
+		}
+	})
+}
+
 // Compile-time interface checks.
 var (
 	_ TaskQueue   = (*mockQueue)(nil)
 	_ event.Event = event.ScalingSignalEvent{}
 	_ event.Event = event.TaskReassignedEvent{}
+	_ event.Event = event.TaskPreemptedEvent{}
 )
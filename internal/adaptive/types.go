@@ -56,6 +56,8 @@ type TaskQueue interface {
 	Release(taskID, reason string) error
 	ClaimNext(instanceID string) (*taskqueue.QueuedTask, error)
 	GetInstanceTasks(instanceID string) []*taskqueue.QueuedTask
+	PreemptionCandidate(wait time.Duration, minGap int) (taskqueue.Preemption, bool)
+	Preempt(taskID string) error
 }
 
 // Option configures a Lead.
@@ -84,3 +86,15 @@ func WithMaxTasksPerInstance(n int) Option {
 		l.maxTasksPerInstance = n
 	}
 }
+
+// WithPreemption enables preemption: when a ready task has waited at least
+// wait for an instance and a running task's priority is worse by at least
+// minGap levels, the lead pauses the running task and returns it to the
+// queue so the waiting task gets its instance. Preemption is off by default.
+func WithPreemption(wait time.Duration, minGap int) Option {
+	return func(l *Lead) {
+		l.preemptionEnabled = true
+		l.preemptionWait = wait
+		l.preemptionMinGap = minGap
+	}
+}
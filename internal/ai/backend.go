@@ -2,6 +2,7 @@ package ai
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/Iron-Ham/claudio/internal/config"
@@ -67,6 +68,35 @@ type StartOptions struct {
 	// Worktree enables Claude Code's native --worktree flag for isolated git worktree execution.
 	// When true, Claude Code creates and manages the worktree internally.
 	Worktree bool
+	// Env is exported before the backend command runs (e.g., the endpoint of
+	// the worker node a self-hosted instance is placed on). See CommandWithEnv.
+	Env map[string]string
+}
+
+// CommandWithEnv prefixes cmd with export statements for env so the variables
+// apply to the whole command line, including compound commands. Keys are
+// sorted for deterministic output. Returns cmd unchanged when env is empty.
+func CommandWithEnv(env map[string]string, cmd string) string {
+	if len(env) == 0 {
+		return cmd
+	}
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString("export")
+	for _, k := range keys {
+		sb.WriteString(" ")
+		sb.WriteString(k)
+		sb.WriteString("=")
+		sb.WriteString(shellQuote(env[k]))
+	}
+	sb.WriteString("; ")
+	sb.WriteString(cmd)
+	return sb.String()
 }
 
 // Backend provides backend-specific behavior for running AI sessions.
@@ -99,6 +129,7 @@ type Backend interface {
 	Detector() detect.StateDetector
 
 	// MetricsParser returns a parser for extracting token usage metrics from backend output.
+	// Returns nil for backends that do not use tokens (e.g., the tool runner).
 	MetricsParser() *metrics.MetricsParser
 
 	// EstimateCost calculates the estimated cost for the given token usage.
@@ -110,10 +141,22 @@ type Backend interface {
 	LocalConfigFiles() []string
 }
 
+// DetectorFor returns the state detector for output b produces in format.
+// Stream-json output is read as structured events, which avoids the false
+// positives of matching rendered text; other formats use b's own detector.
+func DetectorFor(b Backend, format OutputFormat) detect.StateDetector {
+	if format == OutputFormatStreamJSON {
+		return detect.NewJSONDetector()
+	}
+	return b.Detector()
+}
+
 // ErrUnknownBackend is returned when the configured backend is unsupported.
 var ErrUnknownBackend = fmt.Errorf("unknown AI backend")
 
-// NewFromConfig builds a Backend from configuration.
+// NewFromConfig builds the session's default Backend from configuration.
+// The tool runner is not a valid session default, since it only runs plan
+// tasks that carry a command; use [NewForTask] for those.
 func NewFromConfig(cfg *config.Config) (Backend, error) {
 	if cfg == nil {
 		return nil, fmt.Errorf("missing config")
@@ -122,13 +165,32 @@ func NewFromConfig(cfg *config.Config) (Backend, error) {
 	switch strings.ToLower(cfg.AI.Backend) {
 	case string(BackendClaude), "":
 		return NewClaudeBackend(cfg.AI.Claude), nil
+	case string(BackendToolRunner):
+		return nil, fmt.Errorf("the %s backend runs plan tasks only; select it in a task's backend field, not ai.backend", BackendToolRunner)
 	case "codex":
 		return nil, fmt.Errorf("codex backend has been removed; update ai.backend to \"claude\" in your config")
 	default:
+		if b, ok := findCLIBackend(cfg, cfg.AI.Backend); ok {
+			return NewCLIBackend(b), nil
+		}
 		return nil, fmt.Errorf("%w: %s", ErrUnknownBackend, cfg.AI.Backend)
 	}
 }
 
+// NewForTask builds the Backend a plan task or instance selects by name,
+// which may be the tool runner as well as any session backend.
+func NewForTask(cfg *config.Config, name string) (Backend, error) {
+	if strings.EqualFold(name, string(BackendToolRunner)) {
+		return NewToolRunnerBackend(), nil
+	}
+	if cfg == nil {
+		return nil, fmt.Errorf("missing config")
+	}
+	taskCfg := *cfg
+	taskCfg.AI.Backend = name
+	return NewFromConfig(&taskCfg)
+}
+
 // DefaultBackend returns a Claude backend with default settings.
 func DefaultBackend() Backend {
 	return NewClaudeBackend(config.ClaudeBackendConfig{
@@ -226,6 +288,10 @@ func (c *ClaudeBackend) BuildStartCommand(opts StartOptions) (string, error) {
 		if opts.OutputFormat != "" && opts.OutputFormat != OutputFormatText {
 			cmd += fmt.Sprintf(" --output-format %s", string(opts.OutputFormat))
 		}
+		// --print only streams events with --verbose
+		if opts.OutputFormat == OutputFormatStreamJSON {
+			cmd += " --verbose"
+		}
 	}
 
 	// Permission mode: per-invocation overrides backend config.
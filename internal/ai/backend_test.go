@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/instance/detect"
 )
 
 func TestNewFromConfig(t *testing.T) {
@@ -552,6 +553,9 @@ func TestClaudeBackend_OutputFormat(t *testing.T) {
 		if !strings.Contains(cmd, "--output-format stream-json") {
 			t.Errorf("missing --output-format stream-json: %s", cmd)
 		}
+		if !strings.Contains(cmd, "--verbose") {
+			t.Errorf("missing --verbose: %s", cmd)
+		}
 	})
 
 	t.Run("json with print", func(t *testing.T) {
@@ -597,6 +601,19 @@ func TestClaudeBackend_OutputFormat(t *testing.T) {
 	})
 }
 
+func TestDetectorFor(t *testing.T) {
+	b := DefaultBackend()
+
+	if _, ok := DetectorFor(b, OutputFormatStreamJSON).(*detect.JSONDetector); !ok {
+		t.Error("DetectorFor(stream-json) should return a JSONDetector")
+	}
+	for _, format := range []OutputFormat{"", OutputFormatText, OutputFormatJSON} {
+		if _, ok := DetectorFor(b, format).(*detect.Detector); !ok {
+			t.Errorf("DetectorFor(%q) should return the backend's text detector", format)
+		}
+	}
+}
+
 func TestClaudeBackend_CombinedFlags(t *testing.T) {
 	// Test that all flags work together in a realistic configuration.
 	backend := NewClaudeBackend(config.ClaudeBackendConfig{
@@ -718,3 +735,18 @@ func TestClaudeBackendConfig_ResolvedPermissionMode(t *testing.T) {
 		})
 	}
 }
+
+func TestCommandWithEnv(t *testing.T) {
+	if got := CommandWithEnv(nil, "claude"); got != "claude" {
+		t.Errorf("CommandWithEnv(nil) = %q, want command unchanged", got)
+	}
+
+	got := CommandWithEnv(map[string]string{
+		"ANTHROPIC_BASE_URL": "http://gpu-1:8000",
+		"A_TOKEN":            "it's",
+	}, "claude --print")
+	want := `export ANTHROPIC_BASE_URL='http://gpu-1:8000' A_TOKEN='it'"'"'s'; claude --print`
+	if got != want {
+		t.Errorf("CommandWithEnv() = %q, want %q", got, want)
+	}
+}
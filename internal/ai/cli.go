@@ -0,0 +1,136 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/instance/detect"
+	"github.com/Iron-Ham/claudio/internal/instance/metrics"
+)
+
+// defaultCLIPromptFile is the prompt file name for CLI backends that do not
+// configure one.
+const defaultCLIPromptFile = ".claudio-prompt"
+
+// CLIBackend implements Backend for an agent CLI described in configuration
+// (ai.cli_backends), such as Aider, OpenHands, or Cursor CLI.
+//
+// The lifecycle, capture, and detection stack treats it like Claude Code:
+// the command runs in the instance's tmux session, its output is matched
+// against the configured state patterns, and token usage is parsed when the
+// CLI reports it in a known format.
+type CLIBackend struct {
+	cfg config.CLIBackendConfig
+}
+
+// NewCLIBackend creates a backend from a CLI backend definition.
+func NewCLIBackend(cfg config.CLIBackendConfig) *CLIBackend {
+	return &CLIBackend{cfg: cfg}
+}
+
+// findCLIBackend returns the CLI backend definition named name.
+func findCLIBackend(cfg *config.Config, name string) (config.CLIBackendConfig, bool) {
+	for _, b := range cfg.AI.CLIBackends {
+		if strings.EqualFold(b.Name, name) {
+			return b, true
+		}
+	}
+	return config.CLIBackendConfig{}, false
+}
+
+func (c *CLIBackend) Name() BackendName { return BackendName(c.cfg.Name) }
+
+func (c *CLIBackend) DisplayName() string {
+	return firstNonEmpty(c.cfg.DisplayName, c.cfg.Name)
+}
+
+func (c *CLIBackend) PromptFileName() string {
+	return firstNonEmpty(c.cfg.PromptFile, defaultCLIPromptFile)
+}
+
+// BuildStartCommand expands the command template. The prompt file is removed
+// once the command exits, since {prompt_file} lets the CLI read it at any
+// time. With the "exit" completion convention the shell exits too, ending
+// the tmux session so the instance is marked completed.
+func (c *CLIBackend) BuildStartCommand(opts StartOptions) (string, error) {
+	if opts.PromptFile == "" {
+		return "", fmt.Errorf("prompt file required")
+	}
+	if strings.Contains(c.cfg.Command, "{session_id}") && opts.SessionID == "" {
+		return "", fmt.Errorf("%s backend command requires a session id", c.cfg.Name)
+	}
+
+	cmd := strings.NewReplacer(
+		"{prompt_file}", shellQuote(opts.PromptFile),
+		"{prompt}", fmt.Sprintf("\"$(cat %s)\"", shellQuote(opts.PromptFile)),
+		"{session_id}", shellQuote(opts.SessionID),
+	).Replace(c.cfg.Command)
+
+	cmd = fmt.Sprintf("%s; rm -f %s", cmd, shellQuote(opts.PromptFile))
+	if c.cfg.Completion == "exit" {
+		cmd += "; exit"
+	}
+	return cmd, nil
+}
+
+func (c *CLIBackend) BuildResumeCommand(sessionID string) (string, error) {
+	if !c.SupportsResume() {
+		return "", fmt.Errorf("%s backend does not support resume", c.cfg.Name)
+	}
+	if sessionID == "" {
+		return "", fmt.Errorf("session id required for resume")
+	}
+	return strings.ReplaceAll(c.cfg.ResumeCommand, "{session_id}", shellQuote(sessionID)), nil
+}
+
+func (c *CLIBackend) SupportsResume() bool { return c.cfg.ResumeCommand != "" }
+
+// SupportsExplicitSessionID reports whether the command template takes the
+// session ID Claudio generates.
+func (c *CLIBackend) SupportsExplicitSessionID() bool {
+	return strings.Contains(c.cfg.Command, "{session_id}")
+}
+
+// Detector matches the configured state patterns. States without configured
+// patterns use the Claude Code defaults.
+func (c *CLIBackend) Detector() detect.StateDetector {
+	patterns := detect.DefaultPatternSet()
+	if len(c.cfg.ReadyPatterns) > 0 {
+		patterns.InputWaitingPatterns = c.cfg.ReadyPatterns
+	}
+	if len(c.cfg.WorkingPatterns) > 0 {
+		patterns.WorkingPatterns = c.cfg.WorkingPatterns
+	}
+	if len(c.cfg.CompletionPatterns) > 0 {
+		patterns.CompletionPatterns = c.cfg.CompletionPatterns
+	}
+	if len(c.cfg.ErrorPatterns) > 0 {
+		patterns.ErrorPatterns = c.cfg.ErrorPatterns
+	}
+	if len(c.cfg.PermissionPatterns) > 0 {
+		patterns.PermissionPatterns = c.cfg.PermissionPatterns
+	}
+	if len(c.cfg.QuestionPatterns) > 0 {
+		patterns.QuestionPatterns = c.cfg.QuestionPatterns
+	}
+	return detect.NewDetectorWithPatterns(patterns)
+}
+
+// MetricsParser returns the Claude Code status line parser for CLIs
+// configured with the "claude" metrics format, and nil otherwise, so output
+// from other CLIs is never mistaken for token usage.
+func (c *CLIBackend) MetricsParser() *metrics.MetricsParser {
+	if c.cfg.Metrics == "claude" {
+		return metrics.NewMetricsParser()
+	}
+	return nil
+}
+
+// EstimateCost is unsupported: the CLI may run any model, so Claude pricing
+// would be a guess. Costs the CLI reports itself are still parsed.
+func (c *CLIBackend) EstimateCost(inputTokens, outputTokens, cacheRead, cacheWrite int64) (float64, bool) {
+	return 0, false
+}
+
+func (c *CLIBackend) LocalConfigFiles() []string { return c.cfg.LocalConfigFiles }
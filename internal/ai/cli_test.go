@@ -0,0 +1,151 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/instance/detect"
+)
+
+func aiderConfig() config.CLIBackendConfig {
+	return config.CLIBackendConfig{
+		Name:          "aider",
+		DisplayName:   "Aider",
+		Command:       "aider --yes --message-file {prompt_file}",
+		ReadyPatterns: []string{`(?m)^aider>\s*$`},
+	}
+}
+
+func TestNewFromConfig_CLIBackend(t *testing.T) {
+	cfg := config.Default()
+	cfg.AI.CLIBackends = []config.CLIBackendConfig{aiderConfig()}
+	cfg.AI.Backend = "aider"
+
+	backend, err := NewFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewFromConfig returned error: %v", err)
+	}
+	if backend.Name() != "aider" || backend.DisplayName() != "Aider" {
+		t.Errorf("backend = %q (%q), want aider (Aider)", backend.Name(), backend.DisplayName())
+	}
+
+	cfg.AI.Backend = "openhands"
+	if _, err := NewFromConfig(cfg); err == nil {
+		t.Error("NewFromConfig should fail for an undefined CLI backend")
+	}
+}
+
+func TestCLIBackend_BuildStartCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*config.CLIBackendConfig)
+		opts    StartOptions
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "prompt file placeholder",
+			opts: StartOptions{PromptFile: "/wt/.claudio-prompt"},
+			want: "aider --yes --message-file '/wt/.claudio-prompt'; rm -f '/wt/.claudio-prompt'",
+		},
+		{
+			name:   "prompt text placeholder",
+			modify: func(c *config.CLIBackendConfig) { c.Command = "cursor-agent {prompt}" },
+			opts:   StartOptions{PromptFile: "/wt/p"},
+			want:   `cursor-agent "$(cat '/wt/p')"; rm -f '/wt/p'`,
+		},
+		{
+			name:   "exit completion",
+			modify: func(c *config.CLIBackendConfig) { c.Completion = "exit" },
+			opts:   StartOptions{PromptFile: "/wt/p"},
+			want:   "aider --yes --message-file '/wt/p'; rm -f '/wt/p'; exit",
+		},
+		{
+			name:   "session id",
+			modify: func(c *config.CLIBackendConfig) { c.Command = "agent --session {session_id} {prompt}" },
+			opts:   StartOptions{PromptFile: "/wt/p", SessionID: "abc"},
+			want:   `agent --session 'abc' "$(cat '/wt/p')"; rm -f '/wt/p'`,
+		},
+		{
+			name:    "session id missing",
+			modify:  func(c *config.CLIBackendConfig) { c.Command = "agent --session {session_id} {prompt}" },
+			opts:    StartOptions{PromptFile: "/wt/p"},
+			wantErr: true,
+		},
+		{
+			name:    "no prompt file",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := aiderConfig()
+			if tt.modify != nil {
+				tt.modify(&cfg)
+			}
+			got, err := NewCLIBackend(cfg).BuildStartCommand(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildStartCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("BuildStartCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCLIBackend_Resume(t *testing.T) {
+	b := NewCLIBackend(aiderConfig())
+	if b.SupportsResume() || b.SupportsExplicitSessionID() {
+		t.Error("backend without session placeholders should not support resume or explicit session IDs")
+	}
+	if _, err := b.BuildResumeCommand("abc"); err == nil {
+		t.Error("BuildResumeCommand should fail without a resume command")
+	}
+
+	cfg := aiderConfig()
+	cfg.Command = "agent --session {session_id} {prompt}"
+	cfg.ResumeCommand = "agent --resume {session_id}"
+	b = NewCLIBackend(cfg)
+	if !b.SupportsResume() || !b.SupportsExplicitSessionID() {
+		t.Error("backend with session placeholders should support resume and explicit session IDs")
+	}
+	got, err := b.BuildResumeCommand("abc")
+	if err != nil {
+		t.Fatalf("BuildResumeCommand() error = %v", err)
+	}
+	if got != "agent --resume 'abc'" {
+		t.Errorf("BuildResumeCommand() = %q", got)
+	}
+}
+
+func TestCLIBackend_DetectorAndMetrics(t *testing.T) {
+	b := NewCLIBackend(aiderConfig())
+
+	if got := b.Detector().Detect([]byte("Applied edit to main.go\naider> ")); got != detect.StateWaitingInput {
+		t.Errorf("Detect(ready prompt) = %v, want %v", got, detect.StateWaitingInput)
+	}
+	if b.MetricsParser() != nil {
+		t.Error("MetricsParser should be nil without a metrics format")
+	}
+	if _, ok := b.EstimateCost(100, 100, 0, 0); ok {
+		t.Error("EstimateCost should report no estimate")
+	}
+	if b.PromptFileName() != defaultCLIPromptFile {
+		t.Errorf("PromptFileName() = %q, want %q", b.PromptFileName(), defaultCLIPromptFile)
+	}
+
+	cfg := aiderConfig()
+	cfg.Metrics = "claude"
+	if NewCLIBackend(cfg).MetricsParser() == nil {
+		t.Error("MetricsParser should parse the claude metrics format")
+	}
+}
+
+func TestValidTaskBackends_IncludesBuiltins(t *testing.T) {
+	got := strings.Join(ValidTaskBackends(), ",")
+	if !strings.HasPrefix(got, "claude,tool") {
+		t.Errorf("ValidTaskBackends() = %q, want claude and tool first", got)
+	}
+}
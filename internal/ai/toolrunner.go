@@ -0,0 +1,134 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/instance/detect"
+	"github.com/Iron-Ham/claudio/internal/instance/metrics"
+)
+
+// BackendToolRunner runs a plan task's shell command instead of an LLM.
+// It is selected per task in a plan (never as the session default) for
+// mechanical work such as codemods, renames, or formatter runs.
+const BackendToolRunner BackendName = "tool"
+
+// ValidTaskBackends returns the backend names a plan task may select: the
+// built-in backends and the CLI backends in the current configuration.
+// An empty backend means the session's default backend.
+func ValidTaskBackends() []string {
+	return append([]string{string(BackendClaude), string(BackendToolRunner)}, config.Get().AI.CLIBackendNames()...)
+}
+
+// IsDeterministic reports whether the named backend is a non-LLM executor
+// whose result does not change when the same task is run again.
+func IsDeterministic(name string) bool {
+	return strings.EqualFold(name, string(BackendToolRunner))
+}
+
+// ToolRunnerBackend implements Backend for scripted, non-LLM task execution.
+//
+// Its "prompt" is a shell script (see BuildToolScript) that the instance runs
+// in its worktree. The backend has no sessions to resume and reports no token
+// usage, so cost tracking treats its instances as free.
+type ToolRunnerBackend struct{}
+
+// NewToolRunnerBackend creates a tool runner backend.
+func NewToolRunnerBackend() *ToolRunnerBackend {
+	return &ToolRunnerBackend{}
+}
+
+func (t *ToolRunnerBackend) Name() BackendName { return BackendToolRunner }
+
+func (t *ToolRunnerBackend) DisplayName() string { return "Tool Runner" }
+
+func (t *ToolRunnerBackend) PromptFileName() string { return ".claudio-tool-script" }
+
+// BuildStartCommand runs the script in the prompt file. The file is removed
+// before the script runs so the script's own commit never picks it up.
+func (t *ToolRunnerBackend) BuildStartCommand(opts StartOptions) (string, error) {
+	if opts.PromptFile == "" {
+		return "", fmt.Errorf("prompt file required")
+	}
+	return fmt.Sprintf("script=\"$(cat %q)\" && rm %q && sh -c \"$script\"", opts.PromptFile, opts.PromptFile), nil
+}
+
+func (t *ToolRunnerBackend) BuildResumeCommand(sessionID string) (string, error) {
+	return "", fmt.Errorf("%s backend does not support resume", BackendToolRunner)
+}
+
+func (t *ToolRunnerBackend) SupportsResume() bool { return false }
+
+func (t *ToolRunnerBackend) SupportsExplicitSessionID() bool { return false }
+
+func (t *ToolRunnerBackend) Detector() detect.StateDetector {
+	return detect.NewDetector()
+}
+
+// MetricsParser returns nil: scripted commands produce no token usage, and
+// parsing their output for "$1.23"-style costs would only invent spend.
+func (t *ToolRunnerBackend) MetricsParser() *metrics.MetricsParser { return nil }
+
+func (t *ToolRunnerBackend) EstimateCost(inputTokens, outputTokens, cacheRead, cacheWrite int64) (float64, bool) {
+	return 0, false
+}
+
+func (t *ToolRunnerBackend) LocalConfigFiles() []string { return nil }
+
+// ToolScript describes a plan task executed by the tool runner.
+type ToolScript struct {
+	TaskID         string // Plan task ID recorded in the completion report
+	Title          string // Task title, used as the commit message
+	Command        string // Shell command run in the worktree root
+	CompletionFile string // Sentinel file written when the command finishes
+}
+
+// toolCompletion is the subset of the task completion report the tool
+// runner can fill in without an LLM summarizing its work.
+type toolCompletion struct {
+	TaskID  string `json:"task_id"`
+	Status  string `json:"status"`
+	Summary string `json:"summary"`
+}
+
+// BuildToolScript returns the shell script the tool runner executes for a
+// task. The script runs the command, commits whatever it changed with the
+// task title as the message, and then writes the completion file with status
+// "complete", or "failed" when the command or commit exits non-zero. The
+// completion file is written last so completion detection never races the commit.
+func BuildToolScript(s ToolScript) string {
+	complete, _ := json.Marshal(toolCompletion{
+		TaskID:  s.TaskID,
+		Status:  "complete",
+		Summary: "Ran tool command: " + s.Command,
+	})
+	failed, _ := json.Marshal(toolCompletion{
+		TaskID:  s.TaskID,
+		Status:  "failed",
+		Summary: "Tool command failed: " + s.Command,
+	})
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# claudio tool task %s\n", s.TaskID)
+	fmt.Fprintf(&sb, "printf '%%s\\n' %s\n", shellQuote("==> "+s.Command))
+	fmt.Fprintf(&sb, "sh -c %s\n", shellQuote(s.Command))
+	sb.WriteString("status=$?\n")
+	sb.WriteString("if [ \"$status\" -eq 0 ]; then\n")
+	fmt.Fprintf(&sb, "  git add -A && { git diff --cached --quiet || git commit -q -m %s; }\n", shellQuote(s.Title))
+	sb.WriteString("  status=$?\n")
+	sb.WriteString("fi\n")
+	sb.WriteString("if [ \"$status\" -eq 0 ]; then\n")
+	fmt.Fprintf(&sb, "  printf '%%s\\n' %s > %s\n", shellQuote(string(complete)), shellQuote(s.CompletionFile))
+	sb.WriteString("else\n")
+	fmt.Fprintf(&sb, "  printf '%%s\\n' %s > %s\n", shellQuote(string(failed)), shellQuote(s.CompletionFile))
+	sb.WriteString("fi\n")
+	sb.WriteString("printf 'tool task finished with status %s\\n' \"$status\"\n")
+	return sb.String()
+}
+
+// shellQuote wraps s in single quotes for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
@@ -0,0 +1,126 @@
+package ai
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+)
+
+func TestNewFromConfig_ToolRunner(t *testing.T) {
+	cfg := config.Default()
+	cfg.AI.Backend = "tool"
+	if _, err := NewFromConfig(cfg); err == nil {
+		t.Error("NewFromConfig should reject the tool runner as the session backend")
+	}
+}
+
+func TestNewForTask(t *testing.T) {
+	cfg := config.Default()
+
+	backend, err := NewForTask(cfg, "tool")
+	if err != nil {
+		t.Fatalf("NewForTask(tool) returned error: %v", err)
+	}
+	if backend.Name() != BackendToolRunner {
+		t.Errorf("backend.Name() = %q, want %q", backend.Name(), BackendToolRunner)
+	}
+
+	backend, err = NewForTask(cfg, "claude")
+	if err != nil {
+		t.Fatalf("NewForTask(claude) returned error: %v", err)
+	}
+	if backend.Name() != BackendClaude {
+		t.Errorf("backend.Name() = %q, want %q", backend.Name(), BackendClaude)
+	}
+	if cfg.AI.Backend != "claude" {
+		t.Errorf("NewForTask changed the config's backend to %q", cfg.AI.Backend)
+	}
+
+	if _, err := NewForTask(cfg, "missing"); err == nil {
+		t.Error("NewForTask should fail for an unknown backend")
+	}
+}
+
+func TestToolRunnerBackend(t *testing.T) {
+	b := NewToolRunnerBackend()
+
+	if b.SupportsResume() || b.SupportsExplicitSessionID() {
+		t.Error("tool runner should not support resume or explicit session IDs")
+	}
+	if _, err := b.BuildResumeCommand("abc"); err == nil {
+		t.Error("BuildResumeCommand should fail")
+	}
+	if b.MetricsParser() != nil {
+		t.Error("MetricsParser should be nil for a non-LLM backend")
+	}
+	if _, ok := b.EstimateCost(100, 100, 0, 0); ok {
+		t.Error("EstimateCost should report no estimate")
+	}
+
+	if _, err := b.BuildStartCommand(StartOptions{}); err == nil {
+		t.Error("BuildStartCommand without a prompt file should fail")
+	}
+	cmd, err := b.BuildStartCommand(StartOptions{PromptFile: "/tmp/wt/.claudio-tool-script", Model: "opus"})
+	if err != nil {
+		t.Fatalf("BuildStartCommand returned error: %v", err)
+	}
+	if !strings.Contains(cmd, `rm "/tmp/wt/.claudio-tool-script"`) || !strings.HasSuffix(cmd, `sh -c "$script"`) {
+		t.Errorf("BuildStartCommand() = %q, want script read, removed, then run", cmd)
+	}
+	if strings.Contains(cmd, "opus") {
+		t.Errorf("BuildStartCommand() = %q, should ignore LLM options", cmd)
+	}
+}
+
+func TestIsDeterministic(t *testing.T) {
+	tests := map[string]bool{"tool": true, "TOOL": true, "claude": false, "": false}
+	for name, want := range tests {
+		if got := IsDeterministic(name); got != want {
+			t.Errorf("IsDeterministic(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestBuildToolScript_FailedCommand(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	dir := t.TempDir()
+	script := BuildToolScript(ToolScript{
+		TaskID:         "task-1",
+		Title:          "Rename 'Foo' to Bar",
+		Command:        "echo 'it''s here' > out.txt && exit 3",
+		CompletionFile: ".claudio-task-complete.json",
+	})
+
+	cmd := exec.Command("sh", "-c", script)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("script exited with error: %v\n%s", err, out)
+	}
+
+	out, err := os.ReadFile(filepath.Join(dir, "out.txt"))
+	if err != nil || strings.TrimSpace(string(out)) != "its here" {
+		t.Errorf("command output = %q, %v; want the command to run with its quoting intact", out, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ".claudio-task-complete.json"))
+	if err != nil {
+		t.Fatalf("completion file not written: %v", err)
+	}
+	var completion struct {
+		TaskID string `json:"task_id"`
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(data, &completion); err != nil {
+		t.Fatalf("completion file is not valid JSON: %v\n%s", err, data)
+	}
+	if completion.TaskID != "task-1" || completion.Status != "failed" {
+		t.Errorf("completion = %+v, want task-1 failed", completion)
+	}
+}
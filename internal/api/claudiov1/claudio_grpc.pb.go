@@ -0,0 +1,382 @@
+// Control API for a running Claudio session.
+//
+// Regenerate the Go code after editing with `go generate ./internal/api/claudiov1`
+// (requires protoc, protoc-gen-go and protoc-gen-go-grpc).
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: claudio.proto
+
+package claudiov1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Claudio_ListSessions_FullMethodName    = "/claudio.v1.Claudio/ListSessions"
+	Claudio_GetSessionState_FullMethodName = "/claudio.v1.Claudio/GetSessionState"
+	Claudio_ApproveTask_FullMethodName     = "/claudio.v1.Claudio/ApproveTask"
+	Claudio_PauseInstance_FullMethodName   = "/claudio.v1.Claudio/PauseInstance"
+	Claudio_ResumeInstance_FullMethodName  = "/claudio.v1.Claudio/ResumeInstance"
+	Claudio_SendInput_FullMethodName       = "/claudio.v1.Claudio/SendInput"
+	Claudio_StreamOutput_FullMethodName    = "/claudio.v1.Claudio/StreamOutput"
+)
+
+// ClaudioClient is the client API for Claudio service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Claudio controls the session a Claudio process is running.
+type ClaudioClient interface {
+	// ListSessions lists the sessions of the repository, including ones not
+	// run by this process.
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	// GetSessionState returns the instances and ultraplan progress of the
+	// running session.
+	GetSessionState(ctx context.Context, in *GetSessionStateRequest, opts ...grpc.CallOption) (*SessionState, error)
+	// ApproveTask releases a task that is waiting for human approval.
+	ApproveTask(ctx context.Context, in *ApproveTaskRequest, opts ...grpc.CallOption) (*ApproveTaskResponse, error)
+	// PauseInstance pauses an instance's output capture.
+	PauseInstance(ctx context.Context, in *PauseInstanceRequest, opts ...grpc.CallOption) (*PauseInstanceResponse, error)
+	// ResumeInstance resumes an instance paused with PauseInstance.
+	ResumeInstance(ctx context.Context, in *ResumeInstanceRequest, opts ...grpc.CallOption) (*ResumeInstanceResponse, error)
+	// SendInput types text into an instance, optionally pressing Enter.
+	SendInput(ctx context.Context, in *SendInputRequest, opts ...grpc.CallOption) (*SendInputResponse, error)
+	// StreamOutput streams an instance's captured output. Each message holds
+	// the full capture and is sent whenever it changes.
+	StreamOutput(ctx context.Context, in *StreamOutputRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OutputChunk], error)
+}
+
+type claudioClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewClaudioClient(cc grpc.ClientConnInterface) ClaudioClient {
+	return &claudioClient{cc}
+}
+
+func (c *claudioClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, Claudio_ListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *claudioClient) GetSessionState(ctx context.Context, in *GetSessionStateRequest, opts ...grpc.CallOption) (*SessionState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SessionState)
+	err := c.cc.Invoke(ctx, Claudio_GetSessionState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *claudioClient) ApproveTask(ctx context.Context, in *ApproveTaskRequest, opts ...grpc.CallOption) (*ApproveTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveTaskResponse)
+	err := c.cc.Invoke(ctx, Claudio_ApproveTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *claudioClient) PauseInstance(ctx context.Context, in *PauseInstanceRequest, opts ...grpc.CallOption) (*PauseInstanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseInstanceResponse)
+	err := c.cc.Invoke(ctx, Claudio_PauseInstance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *claudioClient) ResumeInstance(ctx context.Context, in *ResumeInstanceRequest, opts ...grpc.CallOption) (*ResumeInstanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeInstanceResponse)
+	err := c.cc.Invoke(ctx, Claudio_ResumeInstance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *claudioClient) SendInput(ctx context.Context, in *SendInputRequest, opts ...grpc.CallOption) (*SendInputResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendInputResponse)
+	err := c.cc.Invoke(ctx, Claudio_SendInput_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *claudioClient) StreamOutput(ctx context.Context, in *StreamOutputRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OutputChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Claudio_ServiceDesc.Streams[0], Claudio_StreamOutput_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamOutputRequest, OutputChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Claudio_StreamOutputClient = grpc.ServerStreamingClient[OutputChunk]
+
+// ClaudioServer is the server API for Claudio service.
+// All implementations must embed UnimplementedClaudioServer
+// for forward compatibility.
+//
+// Claudio controls the session a Claudio process is running.
+type ClaudioServer interface {
+	// ListSessions lists the sessions of the repository, including ones not
+	// run by this process.
+	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	// GetSessionState returns the instances and ultraplan progress of the
+	// running session.
+	GetSessionState(context.Context, *GetSessionStateRequest) (*SessionState, error)
+	// ApproveTask releases a task that is waiting for human approval.
+	ApproveTask(context.Context, *ApproveTaskRequest) (*ApproveTaskResponse, error)
+	// PauseInstance pauses an instance's output capture.
+	PauseInstance(context.Context, *PauseInstanceRequest) (*PauseInstanceResponse, error)
+	// ResumeInstance resumes an instance paused with PauseInstance.
+	ResumeInstance(context.Context, *ResumeInstanceRequest) (*ResumeInstanceResponse, error)
+	// SendInput types text into an instance, optionally pressing Enter.
+	SendInput(context.Context, *SendInputRequest) (*SendInputResponse, error)
+	// StreamOutput streams an instance's captured output. Each message holds
+	// the full capture and is sent whenever it changes.
+	StreamOutput(*StreamOutputRequest, grpc.ServerStreamingServer[OutputChunk]) error
+	mustEmbedUnimplementedClaudioServer()
+}
+
+// UnimplementedClaudioServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedClaudioServer struct{}
+
+func (UnimplementedClaudioServer) ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedClaudioServer) GetSessionState(context.Context, *GetSessionStateRequest) (*SessionState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSessionState not implemented")
+}
+func (UnimplementedClaudioServer) ApproveTask(context.Context, *ApproveTaskRequest) (*ApproveTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveTask not implemented")
+}
+func (UnimplementedClaudioServer) PauseInstance(context.Context, *PauseInstanceRequest) (*PauseInstanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseInstance not implemented")
+}
+func (UnimplementedClaudioServer) ResumeInstance(context.Context, *ResumeInstanceRequest) (*ResumeInstanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeInstance not implemented")
+}
+func (UnimplementedClaudioServer) SendInput(context.Context, *SendInputRequest) (*SendInputResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendInput not implemented")
+}
+func (UnimplementedClaudioServer) StreamOutput(*StreamOutputRequest, grpc.ServerStreamingServer[OutputChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamOutput not implemented")
+}
+func (UnimplementedClaudioServer) mustEmbedUnimplementedClaudioServer() {}
+func (UnimplementedClaudioServer) testEmbeddedByValue()                 {}
+
+// UnsafeClaudioServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ClaudioServer will
+// result in compilation errors.
+type UnsafeClaudioServer interface {
+	mustEmbedUnimplementedClaudioServer()
+}
+
+func RegisterClaudioServer(s grpc.ServiceRegistrar, srv ClaudioServer) {
+	// If the following call pancis, it indicates UnimplementedClaudioServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Claudio_ServiceDesc, srv)
+}
+
+func _Claudio_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClaudioServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Claudio_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClaudioServer).ListSessions(ctx, req.(*ListSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Claudio_GetSessionState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSessionStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClaudioServer).GetSessionState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Claudio_GetSessionState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClaudioServer).GetSessionState(ctx, req.(*GetSessionStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Claudio_ApproveTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClaudioServer).ApproveTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Claudio_ApproveTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClaudioServer).ApproveTask(ctx, req.(*ApproveTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Claudio_PauseInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClaudioServer).PauseInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Claudio_PauseInstance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClaudioServer).PauseInstance(ctx, req.(*PauseInstanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Claudio_ResumeInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClaudioServer).ResumeInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Claudio_ResumeInstance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClaudioServer).ResumeInstance(ctx, req.(*ResumeInstanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Claudio_SendInput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendInputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClaudioServer).SendInput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Claudio_SendInput_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClaudioServer).SendInput(ctx, req.(*SendInputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Claudio_StreamOutput_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamOutputRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ClaudioServer).StreamOutput(m, &grpc.GenericServerStream[StreamOutputRequest, OutputChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Claudio_StreamOutputServer = grpc.ServerStreamingServer[OutputChunk]
+
+// Claudio_ServiceDesc is the grpc.ServiceDesc for Claudio service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Claudio_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "claudio.v1.Claudio",
+	HandlerType: (*ClaudioServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSessions",
+			Handler:    _Claudio_ListSessions_Handler,
+		},
+		{
+			MethodName: "GetSessionState",
+			Handler:    _Claudio_GetSessionState_Handler,
+		},
+		{
+			MethodName: "ApproveTask",
+			Handler:    _Claudio_ApproveTask_Handler,
+		},
+		{
+			MethodName: "PauseInstance",
+			Handler:    _Claudio_PauseInstance_Handler,
+		},
+		{
+			MethodName: "ResumeInstance",
+			Handler:    _Claudio_ResumeInstance_Handler,
+		},
+		{
+			MethodName: "SendInput",
+			Handler:    _Claudio_SendInput_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamOutput",
+			Handler:       _Claudio_StreamOutput_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "claudio.proto",
+}
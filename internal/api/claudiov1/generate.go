@@ -0,0 +1,6 @@
+// Package claudiov1 holds the protocol buffer messages and gRPC service of
+// Claudio's control API, generated from claudio.proto. See package api for
+// the server.
+package claudiov1
+
+//go:generate protoc -I . --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative claudio.proto
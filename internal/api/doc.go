@@ -0,0 +1,30 @@
+// Package api serves the gRPC control API, through which external tools
+// inspect and steer a running session.
+//
+// The service is defined in claudiov1/claudio.proto; the generated code lives
+// in package claudiov1. A [Server] answers calls for a [Controller], which
+// the orchestrator implements from its state snapshot, so no call takes
+// orchestrator locks to read state:
+//
+//	ListSessions      sessions of the repository, marking the current one
+//	GetSessionState   instances, ultraplan progress, pending approvals
+//	ApproveTask       release a task waiting for human approval
+//	PauseInstance     pause an instance
+//	ResumeInstance    resume a paused instance
+//	SendInput         type text into an instance, optionally pressing Enter
+//	StreamOutput      stream an instance's captured output as it changes
+//
+// Controller errors wrapping [ErrNotFound] or [ErrFailedPrecondition] are
+// returned with the matching gRPC code. When a token is set with
+// [WithToken], every call must carry "authorization: Bearer <token>"
+// metadata. Reflection is registered, so grpcurl works without the .proto
+// file.
+//
+// # Usage
+//
+//	srv := api.New(ctrl, api.WithToken(cfg.API.Token), api.WithLogger(logger))
+//	if err := srv.Start(cfg.API.Address); err != nil {
+//	    return err
+//	}
+//	defer srv.Stop(ctx)
+package api
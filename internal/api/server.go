@@ -0,0 +1,349 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Iron-Ham/claudio/internal/api/claudiov1"
+	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/Iron-Ham/claudio/internal/session"
+)
+
+// DefaultOutputInterval is how often StreamOutput checks an instance's
+// output for changes.
+const DefaultOutputInterval = 250 * time.Millisecond
+
+var (
+	// ErrNotFound is returned by a Controller for an unknown instance or
+	// task. It maps to codes.NotFound.
+	ErrNotFound = errors.New("not found")
+
+	// ErrFailedPrecondition is returned by a Controller when the instance or
+	// task is not in a state that allows the operation, such as input to a
+	// stopped instance. It maps to codes.FailedPrecondition.
+	ErrFailedPrecondition = errors.New("failed precondition")
+)
+
+// Controller is the running session a Server exposes. The orchestrator
+// implements it; errors wrapping ErrNotFound or ErrFailedPrecondition are
+// reported with the matching gRPC code.
+type Controller interface {
+	// BaseDir is the repository whose sessions ListSessions reports.
+	BaseDir() string
+
+	// SessionState returns the state of the running session, or nil if no
+	// session is running.
+	SessionState() *pb.SessionState
+
+	ApproveTask(taskID string) error
+	PauseInstance(instanceID string) error
+	ResumeInstance(instanceID string) error
+	SendInput(instanceID, text string, submit bool) error
+
+	// InstanceOutput returns the instance's current captured output.
+	InstanceOutput(instanceID string) ([]byte, error)
+}
+
+// Server serves the Claudio gRPC service for a Controller.
+type Server struct {
+	pb.UnimplementedClaudioServer
+
+	ctrl           Controller
+	logger         *logging.Logger
+	token          string
+	outputInterval time.Duration
+
+	mu   sync.Mutex
+	grpc *grpc.Server
+	addr net.Addr
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithToken requires every call to carry "authorization: Bearer <token>"
+// metadata. An empty token allows unauthenticated calls.
+func WithToken(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// WithLogger sets the logger for calls that change the session and for
+// server errors.
+func WithLogger(logger *logging.Logger) Option {
+	return func(s *Server) {
+		s.logger = logger
+	}
+}
+
+// WithOutputInterval sets how often StreamOutput checks for new output.
+func WithOutputInterval(d time.Duration) Option {
+	return func(s *Server) {
+		if d > 0 {
+			s.outputInterval = d
+		}
+	}
+}
+
+// New creates a Server for ctrl. It serves nothing until Start.
+func New(ctrl Controller, opts ...Option) *Server {
+	s := &Server{
+		ctrl:           ctrl,
+		logger:         logging.NopLogger(),
+		outputInterval: DefaultOutputInterval,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Start serves the API on addr (host:port). It returns once the listener is
+// open; the address actually bound, useful with port 0, is available from
+// Addr.
+func (s *Server) Start(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("api server: listen on %s: %w", addr, err)
+	}
+
+	s.mu.Lock()
+	if s.grpc != nil {
+		s.mu.Unlock()
+		_ = ln.Close()
+		return errors.New("api server: already started")
+	}
+	srv := s.newGRPCServer()
+	s.grpc = srv
+	s.addr = ln.Addr()
+	s.mu.Unlock()
+
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			s.logger.Warn("api server stopped", "error", err)
+		}
+	}()
+	return nil
+}
+
+// newGRPCServer creates a gRPC server with the service, authentication and
+// reflection registered, so tools like grpcurl work without the .proto file.
+func (s *Server) newGRPCServer() *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.authUnary),
+		grpc.ChainStreamInterceptor(s.authStream),
+	)
+	pb.RegisterClaudioServer(srv, s)
+	reflection.Register(srv)
+	return srv
+}
+
+// Addr returns the address the server listens on, or nil before Start.
+func (s *Server) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addr
+}
+
+// Stop closes the listener and waits for calls to finish until ctx is done,
+// then ends the remaining calls, such as open output streams.
+func (s *Server) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	srv := s.grpc
+	s.mu.Unlock()
+	if srv == nil {
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		srv.Stop()
+		return ctx.Err()
+	}
+}
+
+// authorize checks the bearer token in ctx's metadata.
+func (s *Server) authorize(ctx context.Context) error {
+	if s.token == "" {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get("authorization") {
+		token, ok := strings.CutPrefix(v, "Bearer ")
+		if ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "missing or invalid bearer token")
+}
+
+func (s *Server) authUnary(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) authStream(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.authorize(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// toStatus converts a Controller error to a gRPC status error.
+func toStatus(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrFailedPrecondition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// requireID rejects an empty ID argument.
+func requireID(name, value string) error {
+	if value == "" {
+		return status.Errorf(codes.InvalidArgument, "%s is required", name)
+	}
+	return nil
+}
+
+// ListSessions implements pb.ClaudioServer.
+func (s *Server) ListSessions(context.Context, *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	infos, err := session.ListSessions(s.ctrl.BaseDir())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list sessions: %v", err)
+	}
+	var current string
+	if state := s.ctrl.SessionState(); state != nil {
+		current = state.GetId()
+	}
+	resp := &pb.ListSessionsResponse{}
+	for _, info := range infos {
+		resp.Sessions = append(resp.Sessions, &pb.SessionSummary{
+			Id:            info.ID,
+			Name:          info.Name,
+			Created:       timestamppb.New(info.Created),
+			InstanceCount: int32(info.InstanceCount),
+			Locked:        info.IsLocked,
+			Current:       info.ID == current,
+		})
+	}
+	return resp, nil
+}
+
+// GetSessionState implements pb.ClaudioServer.
+func (s *Server) GetSessionState(context.Context, *pb.GetSessionStateRequest) (*pb.SessionState, error) {
+	state := s.ctrl.SessionState()
+	if state == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no session is running")
+	}
+	return state, nil
+}
+
+// ApproveTask implements pb.ClaudioServer.
+func (s *Server) ApproveTask(_ context.Context, req *pb.ApproveTaskRequest) (*pb.ApproveTaskResponse, error) {
+	if err := requireID("task_id", req.GetTaskId()); err != nil {
+		return nil, err
+	}
+	if err := s.ctrl.ApproveTask(req.GetTaskId()); err != nil {
+		return nil, toStatus(err)
+	}
+	s.logger.Info("task approved over api", "task_id", req.GetTaskId())
+	return &pb.ApproveTaskResponse{}, nil
+}
+
+// PauseInstance implements pb.ClaudioServer.
+func (s *Server) PauseInstance(_ context.Context, req *pb.PauseInstanceRequest) (*pb.PauseInstanceResponse, error) {
+	if err := requireID("instance_id", req.GetInstanceId()); err != nil {
+		return nil, err
+	}
+	if err := s.ctrl.PauseInstance(req.GetInstanceId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.PauseInstanceResponse{}, nil
+}
+
+// ResumeInstance implements pb.ClaudioServer.
+func (s *Server) ResumeInstance(_ context.Context, req *pb.ResumeInstanceRequest) (*pb.ResumeInstanceResponse, error) {
+	if err := requireID("instance_id", req.GetInstanceId()); err != nil {
+		return nil, err
+	}
+	if err := s.ctrl.ResumeInstance(req.GetInstanceId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ResumeInstanceResponse{}, nil
+}
+
+// SendInput implements pb.ClaudioServer.
+func (s *Server) SendInput(_ context.Context, req *pb.SendInputRequest) (*pb.SendInputResponse, error) {
+	if err := requireID("instance_id", req.GetInstanceId()); err != nil {
+		return nil, err
+	}
+	if req.GetText() == "" && !req.GetSubmit() {
+		return nil, status.Error(codes.InvalidArgument, "text is required unless submit is set")
+	}
+	if err := s.ctrl.SendInput(req.GetInstanceId(), req.GetText(), req.GetSubmit()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.SendInputResponse{}, nil
+}
+
+// StreamOutput implements pb.ClaudioServer. It sends the current output at
+// once, then again each time it changes, until the client goes away or the
+// instance is removed.
+func (s *Server) StreamOutput(req *pb.StreamOutputRequest, stream pb.Claudio_StreamOutputServer) error {
+	id := req.GetInstanceId()
+	if err := requireID("instance_id", id); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(s.outputInterval)
+	defer ticker.Stop()
+	var last []byte
+	for first := true; ; first = false {
+		out, err := s.ctrl.InstanceOutput(id)
+		if err != nil {
+			return toStatus(err)
+		}
+		if first || !bytes.Equal(out, last) {
+			last = bytes.Clone(out)
+			chunk := &pb.OutputChunk{InstanceId: id, Output: last, Time: timestamppb.Now()}
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
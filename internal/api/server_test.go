@@ -0,0 +1,270 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Iron-Ham/claudio/internal/api/claudiov1"
+	"github.com/Iron-Ham/claudio/internal/session"
+)
+
+// fakeController is an in-memory session with one instance, "i1".
+type fakeController struct {
+	baseDir string
+
+	mu       sync.Mutex
+	output   []byte
+	paused   bool
+	input    []string
+	approved []string
+}
+
+func (f *fakeController) BaseDir() string { return f.baseDir }
+
+func (f *fakeController) SessionState() *pb.SessionState {
+	return &pb.SessionState{Id: "current", Instances: []*pb.Instance{{Id: "i1"}}, PendingApprovals: []string{"t1"}}
+}
+
+func (f *fakeController) check(id string) error {
+	if id != "i1" {
+		return fmt.Errorf("instance %s: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+func (f *fakeController) ApproveTask(taskID string) error {
+	if taskID != "t1" {
+		return fmt.Errorf("task %s: %w", taskID, ErrNotFound)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.approved = append(f.approved, taskID)
+	return nil
+}
+
+func (f *fakeController) PauseInstance(id string) error {
+	if err := f.check(id); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.paused = true
+	return nil
+}
+
+func (f *fakeController) ResumeInstance(id string) error {
+	if err := f.check(id); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.paused {
+		return fmt.Errorf("instance %s is not paused: %w", id, ErrFailedPrecondition)
+	}
+	f.paused = false
+	return nil
+}
+
+func (f *fakeController) SendInput(id, text string, submit bool) error {
+	if err := f.check(id); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.input = append(f.input, fmt.Sprintf("%s/%v", text, submit))
+	return nil
+}
+
+func (f *fakeController) InstanceOutput(id string) ([]byte, error) {
+	if err := f.check(id); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.output, nil
+}
+
+func (f *fakeController) setOutput(s string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.output = []byte(s)
+}
+
+// startServer serves ctrl on a free loopback port and returns a client.
+func startServer(t *testing.T, ctrl Controller, opts ...Option) pb.ClaudioClient {
+	t.Helper()
+	srv := New(ctrl, append([]Option{WithOutputInterval(10 * time.Millisecond)}, opts...)...)
+	if err := srv.Start("127.0.0.1:0"); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+
+	conn, err := grpc.NewClient(srv.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return pb.NewClaudioClient(conn)
+}
+
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestServer_Unary(t *testing.T) {
+	base := t.TempDir()
+	for _, id := range []string{"current", "other"} {
+		dir := session.GetSessionDir(base, id)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		data := fmt.Sprintf(`{"id":%q,"name":"n-%s","instances":[{},{}]}`, id, id)
+		if err := os.WriteFile(filepath.Join(dir, session.SessionFileName), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctrl := &fakeController{baseDir: base}
+	client := startServer(t, ctrl)
+	ctx := testContext(t)
+
+	list, err := client.ListSessions(ctx, &pb.ListSessionsRequest{})
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	if len(list.GetSessions()) != 2 {
+		t.Fatalf("ListSessions() = %v, want 2 sessions", list.GetSessions())
+	}
+	for _, s := range list.GetSessions() {
+		if s.GetCurrent() != (s.GetId() == "current") || s.GetInstanceCount() != 2 {
+			t.Errorf("session %s: current = %v, instances = %d", s.GetId(), s.GetCurrent(), s.GetInstanceCount())
+		}
+	}
+
+	state, err := client.GetSessionState(ctx, &pb.GetSessionStateRequest{})
+	if err != nil || state.GetId() != "current" {
+		t.Errorf("GetSessionState() = %v, %v", state, err)
+	}
+
+	if _, err := client.ApproveTask(ctx, &pb.ApproveTaskRequest{TaskId: "t1"}); err != nil {
+		t.Errorf("ApproveTask() error = %v", err)
+	}
+	if _, err := client.SendInput(ctx, &pb.SendInputRequest{InstanceId: "i1", Text: "yes", Submit: true}); err != nil {
+		t.Errorf("SendInput() error = %v", err)
+	}
+	if _, err := client.PauseInstance(ctx, &pb.PauseInstanceRequest{InstanceId: "i1"}); err != nil {
+		t.Errorf("PauseInstance() error = %v", err)
+	}
+	if _, err := client.ResumeInstance(ctx, &pb.ResumeInstanceRequest{InstanceId: "i1"}); err != nil {
+		t.Errorf("ResumeInstance() error = %v", err)
+	}
+	if len(ctrl.approved) != 1 || len(ctrl.input) != 1 || ctrl.input[0] != "yes/true" || ctrl.paused {
+		t.Errorf("controller state: approved = %v, input = %v, paused = %v", ctrl.approved, ctrl.input, ctrl.paused)
+	}
+}
+
+func TestServer_ErrorCodes(t *testing.T) {
+	client := startServer(t, &fakeController{baseDir: t.TempDir()})
+	ctx := testContext(t)
+
+	tests := []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{"unknown task", func() error {
+			_, err := client.ApproveTask(ctx, &pb.ApproveTaskRequest{TaskId: "nope"})
+			return err
+		}, codes.NotFound},
+		{"missing task id", func() error {
+			_, err := client.ApproveTask(ctx, &pb.ApproveTaskRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"unknown instance", func() error {
+			_, err := client.PauseInstance(ctx, &pb.PauseInstanceRequest{InstanceId: "nope"})
+			return err
+		}, codes.NotFound},
+		{"resume running instance", func() error {
+			_, err := client.ResumeInstance(ctx, &pb.ResumeInstanceRequest{InstanceId: "i1"})
+			return err
+		}, codes.FailedPrecondition},
+		{"empty input", func() error {
+			_, err := client.SendInput(ctx, &pb.SendInputRequest{InstanceId: "i1"})
+			return err
+		}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(tt.call()); got != tt.want {
+				t.Errorf("code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_StreamOutput(t *testing.T) {
+	ctrl := &fakeController{baseDir: t.TempDir()}
+	ctrl.setOutput("first")
+	client := startServer(t, ctrl)
+
+	ctx, cancel := context.WithCancel(testContext(t))
+	defer cancel()
+	stream, err := client.StreamOutput(ctx, &pb.StreamOutputRequest{InstanceId: "i1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunk, err := stream.Recv()
+	if err != nil || string(chunk.GetOutput()) != "first" || chunk.GetInstanceId() != "i1" {
+		t.Fatalf("first chunk = %v, %v", chunk, err)
+	}
+
+	ctrl.setOutput("second")
+	chunk, err = stream.Recv()
+	if err != nil || string(chunk.GetOutput()) != "second" {
+		t.Fatalf("second chunk = %v, %v", chunk, err)
+	}
+
+	missing, err := client.StreamOutput(ctx, &pb.StreamOutputRequest{InstanceId: "nope"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := missing.Recv(); status.Code(err) != codes.NotFound {
+		t.Errorf("stream of unknown instance error = %v, want NotFound", err)
+	}
+}
+
+func TestServer_Token(t *testing.T) {
+	client := startServer(t, &fakeController{baseDir: t.TempDir()}, WithToken("s3cret"))
+	ctx := testContext(t)
+
+	if _, err := client.GetSessionState(ctx, &pb.GetSessionStateRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("call without token error = %v, want Unauthenticated", err)
+	}
+	bad := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer wrong")
+	if _, err := client.GetSessionState(bad, &pb.GetSessionStateRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("call with wrong token error = %v, want Unauthenticated", err)
+	}
+	good := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer s3cret")
+	if _, err := client.GetSessionState(good, &pb.GetSessionStateRequest{}); err != nil {
+		t.Errorf("call with token error = %v", err)
+	}
+	stream, err := client.StreamOutput(ctx, &pb.StreamOutputRequest{InstanceId: "i1"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("stream without token error = %v, want Unauthenticated", err)
+	}
+}
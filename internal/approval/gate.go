@@ -146,6 +146,11 @@ func (g *Gate) ClaimNext(instanceID string) (*taskqueue.QueuedTask, error) {
 	return g.eq.ClaimNext(instanceID)
 }
 
+// ClaimNextMatching delegates to the underlying EventQueue.
+func (g *Gate) ClaimNextMatching(instanceID string, accept func(*taskqueue.QueuedTask) bool) (*taskqueue.QueuedTask, error) {
+	return g.eq.ClaimNextMatching(instanceID, accept)
+}
+
 // Complete delegates to the underlying EventQueue.
 func (g *Gate) Complete(taskID string) ([]string, error) {
 	return g.eq.Complete(taskID)
@@ -246,3 +251,27 @@ func (g *Gate) publishDepth() {
 		s.Pending, claimed, s.Running, s.Completed, s.Failed, s.Total,
 	))
 }
+
+// Heartbeat delegates to the underlying EventQueue.
+func (g *Gate) Heartbeat(taskID, commitSHA string) error {
+	return g.eq.Heartbeat(taskID, commitSHA)
+}
+
+// RecordInstance delegates to the underlying EventQueue.
+func (g *Gate) RecordInstance(taskID, instanceID string) error {
+	return g.eq.RecordInstance(taskID, instanceID)
+}
+
+// ResumeFromCheckpoint delegates to the underlying EventQueue and cleans up
+// any pending approvals for released tasks.
+func (g *Gate) ResumeFromCheckpoint(alive func(taskqueue.Checkpoint) bool) taskqueue.ResumeResult {
+	result := g.eq.ResumeFromCheckpoint(alive)
+
+	g.mu.Lock()
+	for _, id := range result.Released {
+		delete(g.pending, id)
+	}
+	g.mu.Unlock()
+
+	return result
+}
@@ -0,0 +1,245 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileName is the audit log file within a session directory.
+const FileName = "audit.jsonl"
+
+// maxDetailLength bounds Entry.Detail so a large paste cannot bloat the log.
+const maxDetailLength = 2000
+
+// Action identifies the kind of intervention an entry records.
+type Action string
+
+const (
+	// ActionInput is text or a key sent to an instance from input mode.
+	ActionInput Action = "input"
+
+	// ActionApprove is a human approving a plan, synthesis, or result.
+	ActionApprove Action = "approve"
+
+	// ActionReject is a human rejecting a plan, synthesis, or result.
+	ActionReject Action = "reject"
+
+	// ActionOverride is a human command that changes the course of the
+	// session: starting, stopping, restarting, or removing instances,
+	// cancelling workflows, and the like.
+	ActionOverride Action = "override"
+
+	// ActionTaskEdit is a change made to a plan's tasks before execution.
+	ActionTaskEdit Action = "task_edit"
+
+	// ActionAutoApprove is an approval granted by a configured policy rather
+	// than a human, recorded so reviewers can see what was never looked at.
+	ActionAutoApprove Action = "auto_approve"
+
+	// ActionTaskStarted is the orchestrator starting a task on an instance.
+	ActionTaskStarted Action = "task_started"
+
+	// ActionTaskRetried is the orchestrator scheduling another attempt at a
+	// task that did not succeed.
+	ActionTaskRetried Action = "task_retried"
+
+	// ActionTaskFailed is the orchestrator giving up on a task.
+	ActionTaskFailed Action = "task_failed"
+
+	// ActionTaskReassigned is a task moved from one instance to another.
+	ActionTaskReassigned Action = "task_reassigned"
+
+	// ActionTaskPreempted is a running task paused and returned to the queue
+	// so a more urgent task could have its instance.
+	ActionTaskPreempted Action = "task_preempted"
+
+	// ActionScale is a change to how many instances run at once.
+	ActionScale Action = "scale"
+
+	// ActionNudge is a message sent to an instance that stalled.
+	ActionNudge Action = "nudge"
+
+	// ActionConflictResolved is a merge conflict resolved during
+	// consolidation without a person.
+	ActionConflictResolved Action = "conflict_resolved"
+)
+
+// PolicyActor is the Entry.Actor of actions taken by a policy.
+const PolicyActor = "policy"
+
+// OrchestratorActor is the Entry.Actor of the orchestrator's own decisions.
+const OrchestratorActor = "orchestrator"
+
+// Automatic reports whether the action is taken by a policy, not a human.
+func (a Action) Automatic() bool {
+	return a == ActionAutoApprove
+}
+
+// Decision reports whether the action is a decision the orchestrator makes
+// on its own, rather than an intervention.
+func (a Action) Decision() bool {
+	switch a {
+	case ActionTaskStarted, ActionTaskRetried, ActionTaskFailed, ActionTaskReassigned,
+		ActionTaskPreempted, ActionScale, ActionNudge, ActionConflictResolved:
+		return true
+	}
+	return false
+}
+
+// Entry is one recorded intervention or decision.
+type Entry struct {
+	Time       time.Time         `json:"time"`
+	Action     Action            `json:"action"`
+	Actor      string            `json:"actor"`                 // Operator's user name, PolicyActor, or OrchestratorActor
+	InstanceID string            `json:"instance_id,omitempty"` // Affected instance, if any
+	TaskID     string            `json:"task_id,omitempty"`     // Affected plan task, if any
+	Detail     string            `json:"detail,omitempty"`      // What was done, e.g. the command or text sent
+	Reason     string            `json:"reason,omitempty"`      // Why a decision was made
+	Inputs     map[string]string `json:"inputs,omitempty"`      // What a decision was based on
+}
+
+// Log is a session's append-only audit log.
+type Log struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewLog creates a Log kept in the given session directory. The file is
+// created on first write.
+func NewLog(sessionDir string) *Log {
+	return &Log{path: filepath.Join(sessionDir, FileName)}
+}
+
+// Path returns the location of the log file.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append records an entry. Action is required; a zero Time is replaced with
+// the current time and a long Detail is truncated.
+func (l *Log) Append(e Entry) error {
+	if e.Action == "" {
+		return errors.New("audit: entry Action is required")
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	e.Detail = truncate(e.Detail, maxDetailLength)
+	e.Reason = truncate(e.Reason, maxDetailLength)
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("audit: marshal entry: %w", err)
+	}
+	data = append(data, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("audit: create directory: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("audit: open log: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("audit: write entry: %w", err)
+	}
+	return f.Close()
+}
+
+// Entries returns every entry in append order. A missing log yields no
+// entries and no error; malformed lines (e.g. a torn write after a crash)
+// are skipped.
+func (l *Log) Entries() ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.Open(l.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("audit: open log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var out []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil || e.Action == "" {
+			continue
+		}
+		out = append(out, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("audit: read log: %w", err)
+	}
+	return out, nil
+}
+
+// csvHeader is the first row written by WriteCSV.
+var csvHeader = []string{"time", "action", "actor", "instance_id", "task_id", "detail", "reason", "inputs"}
+
+// WriteCSV writes entries as CSV with a header row, for spreadsheets and
+// change-management tooling.
+func WriteCSV(w io.Writer, entries []Entry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("audit: write csv: %w", err)
+	}
+	for _, e := range entries {
+		row := []string{e.Time.Format(time.RFC3339), string(e.Action), e.Actor, e.InstanceID, e.TaskID, e.Detail, e.Reason, FormatInputs(e.Inputs)}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("audit: write csv: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("audit: write csv: %w", err)
+	}
+	return nil
+}
+
+// FormatInputs renders a decision's inputs as space-separated key=value
+// pairs in key order, or "" for none.
+func FormatInputs(inputs map[string]string) string {
+	keys := make([]string, 0, len(inputs))
+	for k := range inputs {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + "=" + inputs[k]
+	}
+	return strings.Join(parts, " ")
+}
+
+// truncate shortens s to at most max bytes, marking the cut.
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	const marker = "… [truncated]"
+	cut := max - len(marker)
+	// Back up to a rune boundary
+	for cut > 0 && s[cut]&0xC0 == 0x80 {
+		cut--
+	}
+	return s[:cut] + marker
+}
@@ -0,0 +1,156 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+)
+
+func TestLog_AppendAndEntries(t *testing.T) {
+	log := NewLog(t.TempDir())
+
+	entries, err := log.Entries()
+	if err != nil || entries != nil {
+		t.Fatalf("Entries() on missing log = %v, %v; want nil, nil", entries, err)
+	}
+
+	if err := log.Append(Entry{Action: ActionInput, Actor: "alice", InstanceID: "inst-1", Detail: "run the tests"}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := log.Append(Entry{Action: ActionTaskEdit, Actor: "alice", TaskID: "task-2", Detail: strings.Repeat("x", 3*maxDetailLength)}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := log.Append(Entry{Actor: "alice"}); err == nil {
+		t.Error("Append() without an action should fail")
+	}
+
+	entries, err = log.Entries()
+	if err != nil {
+		t.Fatalf("Entries() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Entries() returned %d entries, want 2", len(entries))
+	}
+	if entries[0].InstanceID != "inst-1" || entries[0].Time.IsZero() {
+		t.Errorf("entries[0] = %+v, want inst-1 with a time", entries[0])
+	}
+	if len(entries[1].Detail) > maxDetailLength || !strings.HasSuffix(entries[1].Detail, "[truncated]") {
+		t.Errorf("long detail was not truncated: %d bytes", len(entries[1].Detail))
+	}
+
+	info, err := os.Stat(log.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("log permissions = %o, want 600", perm)
+	}
+}
+
+func TestTruncate_RuneBoundary(t *testing.T) {
+	s := strings.Repeat("é", 100)
+	got := truncate(s, 51)
+	if !strings.HasSuffix(got, "[truncated]") || len(got) > 51 {
+		t.Fatalf("truncate() = %q", got)
+	}
+	if !strings.HasPrefix(got, "éé") || strings.ContainsRune(got, '�') {
+		t.Errorf("truncate() split a rune: %q", got)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	at := time.Date(2026, 5, 1, 9, 30, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	err := WriteCSV(&buf, []Entry{{
+		Time: at, Action: ActionOverride, Actor: "bob", InstanceID: "inst-3", Detail: `:kill ("a, b")`,
+		Reason: "stuck", Inputs: map[string]string{"b": "2", "a": "1"},
+	}})
+	if err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(rows) != 2 || rows[0][0] != "time" {
+		t.Fatalf("rows = %q, want a header and one entry", rows)
+	}
+	want := []string{"2026-05-01T09:30:00Z", "override", "bob", "inst-3", "", `:kill ("a, b")`, "stuck", "a=1 b=2"}
+	for i := range want {
+		if rows[1][i] != want[i] {
+			t.Errorf("column %d = %q, want %q", i, rows[1][i], want[i])
+		}
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	bus := event.NewBus()
+	log := NewLog(t.TempDir())
+	rec := NewRecorder(log, bus, nil)
+	rec.actor = "carol"
+
+	bus.Publish(event.NewOperatorActionEvent(string(ActionApprove), "", "", "approved plan (3 tasks)"))
+	bus.Publish(event.NewOperatorActionEvent(string(ActionAutoApprove), "inst-2", "task-1", "gated task started"))
+	rec.Stop()
+	rec.Stop()
+	bus.Publish(event.NewOperatorActionEvent(string(ActionInput), "inst-1", "", "ignored"))
+
+	entries, err := log.Entries()
+	if err != nil {
+		t.Fatalf("Entries() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("recorded %d entries, want 2 (none after Stop)", len(entries))
+	}
+	if entries[0].Actor != "carol" || entries[0].Action != ActionApprove {
+		t.Errorf("entries[0] = %+v, want an approval by carol", entries[0])
+	}
+	if entries[1].Actor != PolicyActor || entries[1].TaskID != "task-1" {
+		t.Errorf("entries[1] = %+v, want a policy action on task-1", entries[1])
+	}
+}
+
+func TestRecorder_Decisions(t *testing.T) {
+	bus := event.NewBus()
+	log := NewLog(t.TempDir())
+	rec := NewRecorder(log, bus, nil)
+	defer rec.Stop()
+
+	bus.Publish(event.NewOrchestratorDecisionEvent(string(ActionTaskRetried), "inst-1", "task-1", "task produced no commits",
+		map[string]string{"attempt": "2", "max_retries": "3"}))
+	bus.Publish(event.NewInstanceNudgedEvent("inst-2", 1, 3, "Are you still working?"))
+	bus.Publish(event.NewTaskCompletedEvent("task-2", "inst-2", true, "", "Add API"))
+	bus.Publish(event.NewTaskCompletedEvent("task-3", "inst-3", false, "no commits after 3 retries", "Add docs"))
+	bus.Publish(event.NewScalingDecisionEvent("scale_up", 2, "queue depth 8", 2))
+	bus.Publish(event.NewTaskReassignedEvent("task-4", "inst-1", "inst-2", "rebalance"))
+	bus.Publish(event.NewTaskPreemptedEvent("task-5", "inst-3", "task-6", "priority"))
+
+	entries, err := log.Entries()
+	if err != nil {
+		t.Fatalf("Entries() error = %v", err)
+	}
+	var got []string
+	for _, e := range entries {
+		if e.Actor != OrchestratorActor || !e.Action.Decision() || e.Time.IsZero() {
+			t.Errorf("entry %+v is not an orchestrator decision", e)
+		}
+		got = append(got, fmt.Sprintf("%s %s %s | %s | %s", e.Action, e.InstanceID, e.TaskID, e.Reason, FormatInputs(e.Inputs)))
+	}
+	want := []string{
+		"task_retried inst-1 task-1 | task produced no commits | attempt=2 max_retries=3",
+		"nudge inst-2  | instance stalled | attempt=1 max_attempts=3",
+		"task_failed inst-3 task-3 | no commits after 3 retries | ",
+		"scale   | queue depth 8 | current_instances=2 delta=2",
+		"task_reassigned inst-2 task-4 | rebalance | from_instance=inst-1 to_instance=inst-2",
+		"task_preempted inst-3 task-5 | priority | preempting_task=task-6",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("entries:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
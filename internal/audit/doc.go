@@ -0,0 +1,42 @@
+// Package audit records human interventions and orchestrator decisions in a
+// Claudio session.
+//
+// Every approval, rejection, override command, plan edit, and line of text
+// typed into an instance is appended to an operator audit log kept in the
+// session directory, so a reviewer can reconstruct who steered an
+// autonomous run and when. Approvals granted by a configured policy rather
+// than a person are recorded too, attributed to [PolicyActor], so it is clear
+// what was never looked at. Decisions the orchestrator makes on its own —
+// starting, retrying, failing, and reassigning tasks, scaling, nudging, and
+// resolving merge conflicts — are recorded alongside, attributed to
+// [OrchestratorActor] with the reason and the inputs behind them.
+//
+//	.claudio/sessions/{sessionID}/audit.jsonl
+//
+// # Main Types
+//
+//   - [Action]: The kind of intervention (input, approve, reject, override,
+//     task_edit, auto_approve) or decision (task_started, task_retried,
+//     task_failed, task_reassigned, task_preempted, scale, nudge,
+//     conflict_resolved)
+//   - [Entry]: One recorded intervention or decision with time, actor,
+//     target, and for decisions the reason and inputs
+//   - [Log]: Append-only JSONL file of entries for one session
+//   - [Recorder]: Subscribes to operator actions and decision events and
+//     appends to a Log
+//
+// # Usage
+//
+// Interventions are published as events so callers (the TUI, the bridge)
+// need no handle on the log itself:
+//
+//	bus.Publish(event.NewOperatorActionEvent(string(audit.ActionApprove), "", "", "approved plan"))
+//
+// Decisions that already publish an event of their own (nudges, scaling,
+// reassignment, failures) are recorded from it. Others are published as an
+// event.OrchestratorDecisionEvent, which the orchestrator wraps as
+// Orchestrator.RecordDecision.
+//
+// The orchestrator starts a [Recorder] for each session. The log is exported
+// with "claudio audit" as text, JSON, or CSV ([WriteCSV]).
+package audit
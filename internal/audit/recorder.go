@@ -0,0 +1,169 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/logging"
+)
+
+// recordedEvents are the event types a Recorder turns into entries: operator
+// actions, and the orchestrator decisions published as events of their own.
+var recordedEvents = []string{
+	"operator.action",
+	"orchestrator.decision",
+	"instance.nudged",
+	"task.completed",
+	"bridge.task_started",
+	"bridge.task_completed",
+	"adaptive.task_reassigned",
+	"adaptive.task_preempted",
+	"scaling.decision",
+	"team.scaled",
+}
+
+// Recorder appends every OperatorActionEvent published on a bus to a Log,
+// along with the orchestrator's decisions: tasks started, retried, failed,
+// reassigned, and preempted, scaling, nudges, and resolved conflicts.
+type Recorder struct {
+	log    *Log
+	bus    *event.Bus
+	logger *logging.Logger
+	actor  string
+	subIDs []string
+}
+
+// NewRecorder subscribes to operator actions and decisions on bus and
+// records them to log until Stop is called. Human actions are attributed to
+// the current OS user.
+func NewRecorder(log *Log, bus *event.Bus, logger *logging.Logger) *Recorder {
+	r := &Recorder{log: log, bus: bus, logger: logger, actor: CurrentActor()}
+	for _, eventType := range recordedEvents {
+		r.subIDs = append(r.subIDs, bus.Subscribe(eventType, r.handle))
+	}
+	return r
+}
+
+// Stop unsubscribes the recorder. It is safe to call more than once.
+func (r *Recorder) Stop() {
+	for _, id := range r.subIDs {
+		r.bus.Unsubscribe(id)
+	}
+	r.subIDs = nil
+}
+
+// handle records one operator action or decision. Write failures are
+// logged, never returned: a broken audit file must not block the operator.
+func (r *Recorder) handle(e event.Event) {
+	entry, ok := r.entryFor(e)
+	if !ok {
+		return
+	}
+	entry.Time = e.Timestamp()
+	if err := r.log.Append(entry); err != nil && r.logger != nil {
+		r.logger.Warn("failed to record audit entry", "action", string(entry.Action), "error", err)
+	}
+}
+
+// entryFor returns the entry recording e, or false if e is not recorded.
+func (r *Recorder) entryFor(e event.Event) (Entry, bool) {
+	switch ev := e.(type) {
+	case event.OperatorActionEvent:
+		entry := Entry{
+			Action:     Action(ev.Action),
+			Actor:      r.actor,
+			InstanceID: ev.InstanceID,
+			TaskID:     ev.TaskID,
+			Detail:     ev.Detail,
+		}
+		if entry.Action.Automatic() {
+			entry.Actor = PolicyActor
+		}
+		return entry, true
+
+	case event.OrchestratorDecisionEvent:
+		return decision(Action(ev.Decision), ev.InstanceID, ev.TaskID, "", ev.Reason, ev.Inputs), true
+
+	case event.InstanceNudgedEvent:
+		return decision(ActionNudge, ev.InstanceID, "", ev.Message, "instance stalled", map[string]string{
+			"attempt":      strconv.Itoa(ev.Attempt),
+			"max_attempts": strconv.Itoa(ev.MaxAttempts),
+		}), true
+
+	case event.TaskCompletedEvent:
+		if ev.Success {
+			return Entry{}, false
+		}
+		return decision(ActionTaskFailed, ev.InstanceID, ev.TaskID, ev.Title, ev.Reason, nil), true
+
+	case event.BridgeTaskStartedEvent:
+		return decision(ActionTaskStarted, ev.InstanceID, ev.TaskID, "", "task ready", map[string]string{
+			"team_id": ev.TeamID,
+		}), true
+
+	case event.BridgeTaskCompletedEvent:
+		if ev.Success {
+			return Entry{}, false
+		}
+		return decision(ActionTaskFailed, ev.InstanceID, ev.TaskID, "", ev.Error, map[string]string{
+			"team_id":      ev.TeamID,
+			"commit_count": strconv.Itoa(ev.CommitCount),
+		}), true
+
+	case event.TaskReassignedEvent:
+		return decision(ActionTaskReassigned, ev.ToInstance, ev.TaskID,
+			fmt.Sprintf("moved from %s to %s", ev.FromInstance, ev.ToInstance), ev.Reason, map[string]string{
+				"from_instance": ev.FromInstance,
+				"to_instance":   ev.ToInstance,
+			}), true
+
+	case event.TaskPreemptedEvent:
+		return decision(ActionTaskPreempted, ev.InstanceID, ev.TaskID,
+			"paused for "+ev.PreemptingTaskID, ev.Reason, map[string]string{
+				"preempting_task": ev.PreemptingTaskID,
+			}), true
+
+	case event.ScalingDecisionEvent:
+		return decision(ActionScale, "", "", fmt.Sprintf("%s by %d", ev.Action, ev.Delta), ev.Reason, map[string]string{
+			"delta":             strconv.Itoa(ev.Delta),
+			"current_instances": strconv.Itoa(ev.CurrentInstances),
+		}), true
+
+	case event.TeamScaledEvent:
+		return decision(ActionScale, "", "",
+			fmt.Sprintf("team %s: %d → %d instances", ev.TeamID, ev.PrevInstances, ev.NewInstances), ev.Reason, map[string]string{
+				"team_id":        ev.TeamID,
+				"prev_instances": strconv.Itoa(ev.PrevInstances),
+				"new_instances":  strconv.Itoa(ev.NewInstances),
+			}), true
+	}
+	return Entry{}, false
+}
+
+// decision returns the entry for a decision the orchestrator made.
+func decision(action Action, instanceID, taskID, detail, reason string, inputs map[string]string) Entry {
+	return Entry{
+		Action:     action,
+		Actor:      OrchestratorActor,
+		InstanceID: instanceID,
+		TaskID:     taskID,
+		Detail:     detail,
+		Reason:     reason,
+		Inputs:     inputs,
+	}
+}
+
+// CurrentActor returns the name human actions are attributed to: the OS
+// user, falling back to $USER and then "operator".
+func CurrentActor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "operator"
+}
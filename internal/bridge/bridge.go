@@ -4,15 +4,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/Iron-Ham/claudio/internal/ai"
+	"github.com/Iron-Ham/claudio/internal/audit"
 	"github.com/Iron-Ham/claudio/internal/event"
 	"github.com/Iron-Ham/claudio/internal/filelock"
 	"github.com/Iron-Ham/claudio/internal/logging"
 	"github.com/Iron-Ham/claudio/internal/mailbox"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/contextpack"
+	"github.com/Iron-Ham/claudio/internal/taskqueue"
 	"github.com/Iron-Ham/claudio/internal/team"
+	"github.com/Iron-Ham/claudio/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Bridge connects a single team's Hub to real Claude Code instances.
@@ -21,12 +30,18 @@ import (
 // InstanceFactory, monitors them for completion, and reports outcomes
 // back through the Gate and SessionRecorder.
 type Bridge struct {
-	team     *team.Team
-	factory  InstanceFactory
-	checker  CompletionChecker
-	recorder SessionRecorder
-	bus      *event.Bus
-	logger   *logging.Logger
+	team      *team.Team
+	factory   InstanceFactory
+	checker   CompletionChecker
+	recorder  SessionRecorder
+	contracts ContractPublisher
+	stealer   WorkStealer
+	transform PromptTransform
+	planHash  string
+	placer    *Placer
+	taskModel func(taskID string) string
+	bus       *event.Bus
+	logger    *logging.Logger
 
 	pollInterval time.Duration
 	sem          *dynamicSemaphore
@@ -38,6 +53,19 @@ type Bridge struct {
 	mu      sync.RWMutex
 	running map[string]string // taskID → instanceID
 	started bool
+
+	// preempt holds a channel per running task, closed to make its monitor
+	// pause the instance and give up the task's slot
+	preempt map[string]chan struct{}
+
+	// removed holds a channel per running task, closed when the task is
+	// removed from the queue so its monitor gives up the slot without
+	// reporting an outcome
+	removed map[string]chan struct{}
+
+	// spans holds the span of each running task, from instance start to
+	// verification (tracing.enabled)
+	spans tracing.Spans
 }
 
 // New creates a Bridge for the given team.
@@ -80,11 +108,19 @@ func New(t *team.Team, factory InstanceFactory, checker CompletionChecker, recor
 		factory:      factory,
 		checker:      checker,
 		recorder:     recorder,
+		contracts:    cfg.contracts,
+		stealer:      cfg.stealer,
+		transform:    cfg.transform,
+		planHash:     cfg.planHash,
+		placer:       cfg.placer,
+		taskModel:    cfg.taskModel,
 		bus:          bus,
 		logger:       cfg.logger,
 		pollInterval: cfg.pollInterval,
 		sem:          newDynamicSemaphore(cfg.maxConcurrency),
 		running:      make(map[string]string),
+		preempt:      make(map[string]chan struct{}),
+		removed:      make(map[string]chan struct{}),
 	}
 }
 
@@ -143,7 +179,8 @@ func (b *Bridge) Running() map[string]string {
 }
 
 // claimLoop continuously claims tasks from the team's Gate and spawns
-// monitor goroutines for each one. It exits when the context is cancelled.
+// monitor goroutines for each one. It exits when the context is cancelled,
+// or when the team's queue is complete and there is no work to steal.
 func (b *Bridge) claimLoop() {
 	// Subscribe to queue depth changes so we can wake up when new tasks appear.
 	wake := make(chan struct{}, 1)
@@ -155,6 +192,37 @@ func (b *Bridge) claimLoop() {
 	})
 	defer b.bus.Unsubscribe(subID)
 
+	// A team that steals work also waits for blocked teams to start.
+	if b.stealer != nil {
+		phaseSubID := b.bus.Subscribe("team.phase_changed", func(_ event.Event) {
+			select {
+			case wake <- struct{}{}:
+			default:
+			}
+		})
+		defer b.bus.Unsubscribe(phaseSubID)
+	}
+
+	// Use the team ID as a claim identifier for traceability.
+	// The real instance ID is recorded after CreateInstance.
+	claimID := fmt.Sprintf("bridge-%s", b.team.Spec().ID)
+
+	preemptSubID := b.bus.Subscribe("adaptive.task_preempted", func(e event.Event) {
+		if ev, ok := e.(event.TaskPreemptedEvent); ok && ev.InstanceID == claimID {
+			b.signalPreempt(ev.TaskID)
+		}
+	})
+	defer b.bus.Unsubscribe(preemptSubID)
+
+	removedSubID := b.bus.Subscribe("queue.task_removed", func(e event.Event) {
+		if ev, ok := e.(event.TaskRemovedEvent); ok {
+			b.signalRemoved(ev.TaskID)
+		}
+	})
+	defer b.bus.Unsubscribe(removedSubID)
+
+	b.resumeTasks()
+
 	for {
 		if err := b.ctx.Err(); err != nil {
 			return
@@ -169,10 +237,6 @@ func (b *Bridge) claimLoop() {
 
 		gate := b.team.Hub().Gate()
 
-		// Use the team ID as a claim identifier for traceability.
-		// The real instance ID is recorded after CreateInstance.
-		claimID := fmt.Sprintf("bridge-%s", b.team.Spec().ID)
-
 		task, err := gate.ClaimNext(claimID)
 		if err != nil {
 			b.sem.Release()
@@ -181,16 +245,26 @@ func (b *Bridge) claimLoop() {
 			continue
 		}
 
+		// Once the team's own queue is done, help the other teams.
+		home := b.team
+		if task == nil && b.stealer != nil && gate.IsComplete() {
+			home, task = b.steal(claimID)
+		}
+
 		if task == nil {
 			b.sem.Release()
-			if gate.IsComplete() {
+			if gate.IsComplete() && (b.stealer == nil || !b.stealer.CanSteal(b.team.Spec().ID)) {
 				return
 			}
 			b.waitForWake(wake)
 			continue
 		}
 
-		hub := b.team.Hub()
+		// A stolen task stays in its home team's queue, file locks, and
+		// mailbox; only the instance running it is this team's.
+		hub := home.Hub()
+		gate = hub.Gate()
+		teamID := home.Spec().ID
 
 		// Claim file locks to prevent concurrent edits.
 		if len(task.Files) > 0 {
@@ -201,14 +275,14 @@ func (b *Bridge) claimLoop() {
 					// queue without burning a retry.  The task will be
 					// re-claimed once the lock holder finishes.
 					b.logger.Debug("bridge: file lock conflict, releasing task",
-						"team", b.team.Spec().ID, "task", task.ID, "error", err)
+						"team", teamID, "task", task.ID, "error", err)
 					if relErr := gate.Release(task.ID, "file lock conflict"); relErr != nil {
 						b.logger.Error("bridge: gate.Release failed",
 							"task", task.ID, "error", relErr)
 					}
 				} else {
 					b.logger.Error("bridge: file lock claim failed",
-						"team", b.team.Spec().ID, "task", task.ID, "error", err)
+						"team", teamID, "task", task.ID, "error", err)
 					if failErr := gate.Fail(task.ID, fmt.Sprintf("file lock: %v", err)); failErr != nil {
 						b.logger.Error("bridge: gate.Fail also failed",
 							"task", task.ID, "error", failErr)
@@ -219,18 +293,25 @@ func (b *Bridge) claimLoop() {
 			}
 		}
 
-		// Retrieve prior discoveries for context injection.
-		prompt := BuildTaskPromptWithContext(
-			task.ID, task.Title, task.Description, task.Files,
-			b.getInstanceContext(task.ID),
-		)
+		node, placed := b.placeTask(home, task, wake)
+		if !placed {
+			b.sem.Release()
+			hub.FileLockRegistry().ReleaseAll(task.ID) //nolint:errcheck // best-effort cleanup
+			continue
+		}
 
-		inst, err := b.factory.CreateInstance(prompt)
+		_, startSpan := tracing.Tracer().Start(b.ctx, "bridge.start_instance", trace.WithAttributes(
+			tracing.TeamID.String(teamID),
+			tracing.TaskID.String(task.ID),
+		))
+		inst, reused, err := b.createTaskInstance(home, task, node)
 		if err != nil {
+			tracing.End(startSpan, err)
 			b.sem.Release()
+			b.releasePlacement(task.ID)
 			hub.FileLockRegistry().ReleaseAll(task.ID) //nolint:errcheck // best-effort cleanup
 			b.logger.Error("bridge: failed to create instance",
-				"team", b.team.Spec().ID, "task", task.ID, "error", err)
+				"team", teamID, "task", task.ID, "error", err)
 			if failErr := gate.Fail(task.ID, fmt.Sprintf("create instance: %v", err)); failErr != nil {
 				b.logger.Error("bridge: gate.Fail also failed",
 					"task", task.ID, "error", failErr)
@@ -238,24 +319,41 @@ func (b *Bridge) claimLoop() {
 			continue
 		}
 
-		if err := b.factory.StartInstance(inst); err != nil {
+		// A replayed task whose earlier run already finished is handed
+		// straight to the monitor, which verifies the existing work.
+		if reused && b.alreadyComplete(inst) {
+			b.logger.Info("bridge: reattached to completed task, skipping start",
+				"team", teamID, "task", task.ID, "instance", inst.ID(), "branch", inst.Branch())
+		} else if err := b.startTaskInstance(home, task, inst); err != nil {
+			tracing.End(startSpan, err)
 			b.sem.Release()
+			b.releasePlacement(task.ID)
 			hub.FileLockRegistry().ReleaseAll(task.ID) //nolint:errcheck // best-effort cleanup
 			b.logger.Error("bridge: failed to start instance",
-				"team", b.team.Spec().ID, "task", task.ID, "error", err)
+				"team", teamID, "task", task.ID, "error", err)
 			if failErr := gate.Fail(task.ID, fmt.Sprintf("start instance: %v", err)); failErr != nil {
 				b.logger.Error("bridge: gate.Fail also failed",
 					"task", task.ID, "error", failErr)
 			}
 			continue
 		}
+		startSpan.SetAttributes(tracing.InstanceID.String(inst.ID()), attribute.Bool("claudio.reused", reused))
+		tracing.End(startSpan, nil)
+
+		// The claim is held under claimID; record the instance so a
+		// resumed session can find it.
+		if err := gate.RecordInstance(task.ID, inst.ID()); err != nil {
+			b.logger.Debug("bridge: failed to record task instance",
+				"task", task.ID, "instance", inst.ID(), "error", err)
+		}
 
 		// Transition the task to running.
 		if err := gate.MarkRunning(task.ID); err != nil {
 			b.sem.Release()
+			b.releasePlacement(task.ID)
 			hub.FileLockRegistry().ReleaseAll(task.ID) //nolint:errcheck // best-effort cleanup
 			b.logger.Error("bridge: failed to mark running",
-				"team", b.team.Spec().ID, "task", task.ID, "error", err)
+				"team", teamID, "task", task.ID, "error", err)
 			if failErr := gate.Fail(task.ID, fmt.Sprintf("mark running: %v", err)); failErr != nil {
 				b.logger.Error("bridge: gate.Fail also failed",
 					"task", task.ID, "error", failErr)
@@ -267,9 +365,10 @@ func (b *Bridge) claimLoop() {
 		if gate.IsAwaitingApproval(task.ID) {
 			if approveErr := gate.Approve(task.ID); approveErr != nil {
 				b.sem.Release()
+				b.releasePlacement(task.ID)
 				hub.FileLockRegistry().ReleaseAll(task.ID) //nolint:errcheck // best-effort cleanup
 				b.logger.Error("bridge: failed to auto-approve gated task",
-					"team", b.team.Spec().ID, "task", task.ID, "error", approveErr)
+					"team", teamID, "task", task.ID, "error", approveErr)
 				if failErr := gate.Fail(task.ID, fmt.Sprintf("auto-approve: %v", approveErr)); failErr != nil {
 					b.logger.Error("bridge: gate.Fail also failed",
 						"task", task.ID, "error", failErr)
@@ -277,28 +376,337 @@ func (b *Bridge) claimLoop() {
 				continue
 			}
 			b.logger.Debug("bridge: auto-approved gated task",
-				"team", b.team.Spec().ID, "task", task.ID)
+				"team", teamID, "task", task.ID)
+			b.bus.Publish(event.NewOperatorActionEvent(string(audit.ActionAutoApprove), inst.ID(), task.ID,
+				"approval-gated task started without review"))
 		}
 
-		// Record assignment and publish event.
-		b.recorder.AssignTask(task.ID, inst.ID())
+		b.runTask(home, task, inst)
+	}
+}
 
-		b.mu.Lock()
-		b.running[task.ID] = inst.ID()
-		b.mu.Unlock()
+// runTask records a running task's assignment, publishes its start, and
+// spawns the goroutine that monitors its instance. The caller holds a
+// semaphore slot for the task, which monitorInstance releases when the task
+// completes or fails.
+func (b *Bridge) runTask(home *team.Team, task *taskqueue.QueuedTask, inst Instance) {
+	teamID := home.Spec().ID
 
-		b.bus.Publish(event.NewBridgeTaskStartedEvent(
-			b.team.Spec().ID, task.ID, inst.ID(),
-		))
+	// Record assignment and publish event.
+	b.recorder.AssignTask(task.ID, inst.ID())
+	b.saveQueueState(home)
+
+	preempt := make(chan struct{})
+	removed := make(chan struct{})
+	b.mu.Lock()
+	b.running[task.ID] = inst.ID()
+	b.preempt[task.ID] = preempt
+	b.removed[task.ID] = removed
+	b.mu.Unlock()
+
+	b.spans.Start(b.ctx, task.ID, "bridge.task",
+		tracing.TeamID.String(teamID),
+		tracing.TaskID.String(task.ID),
+		tracing.TaskTitle.String(task.Title),
+		tracing.InstanceID.String(inst.ID()),
+	)
+
+	b.bus.Publish(event.NewBridgeTaskStartedEvent(
+		teamID, task.ID, inst.ID(),
+	))
+
+	b.wg.Add(1)
+	go func(taskID string, tool bool) {
+		defer b.wg.Done()
+		b.monitorInstance(home, taskID, inst, tool, preempt, removed)
+	}(task.ID, task.IsDeterministic())
+}
+
+// resumeTasks reconciles the claimed tasks of a queue restored from its
+// saved state (see coordination.WithQueueResume) with the instances that
+// survived the restart. Tasks whose checkpointed instance the
+// InstanceFinder reports alive are monitored again, holding a semaphore
+// slot each; the rest are released back to the queue. On a fresh queue
+// there are no claimed tasks and nothing happens.
+func (b *Bridge) resumeTasks() {
+	hub := b.team.Hub()
+	gate := hub.Gate()
+	teamID := b.team.Spec().ID
+
+	finder, _ := b.factory.(InstanceFinder)
+	found := make(map[string]Instance)
+	result := gate.ResumeFromCheckpoint(func(cp taskqueue.Checkpoint) bool {
+		if finder == nil {
+			return false
+		}
+		inst, alive := finder.FindInstance(cp.InstanceID)
+		if alive {
+			found[cp.InstanceID] = inst
+		}
+		return alive
+	})
+	if len(result.Released) > 0 {
+		b.logger.Info("bridge: released tasks whose instance is gone",
+			"team", teamID, "tasks", result.Released)
+	}
+
+	for _, taskID := range result.Reattached {
+		task := hub.TaskQueue().GetTask(taskID)
+		if task == nil || task.Checkpoint == nil {
+			continue
+		}
+		inst := found[task.Checkpoint.InstanceID]
+
+		if err := b.sem.Acquire(b.ctx); err != nil {
+			return // context cancelled
+		}
+
+		// File locks are not persisted; take them again so new tasks
+		// keep away from the files this one is editing.
+		if len(task.Files) > 0 {
+			if err := hub.FileLockRegistry().ClaimMultiple(task.ID, task.Files); err != nil {
+				b.logger.Warn("bridge: failed to reclaim file locks for resumed task",
+					"team", teamID, "task", task.ID, "error", err)
+			}
+		}
+
+		// A task claimed but not yet running when the session stopped
+		// goes through the same transition as a fresh claim. The gate's
+		// approval holds are not persisted, so gated tasks are held and
+		// approved again.
+		if task.Status == taskqueue.TaskClaimed {
+			err := gate.MarkRunning(task.ID)
+			if err == nil && gate.IsAwaitingApproval(task.ID) {
+				err = gate.Approve(task.ID)
+			}
+			if err != nil {
+				b.sem.Release()
+				hub.FileLockRegistry().ReleaseAll(task.ID) //nolint:errcheck // best-effort cleanup
+				b.logger.Error("bridge: failed to mark resumed task running",
+					"team", teamID, "task", task.ID, "error", err)
+				if failErr := gate.Fail(task.ID, fmt.Sprintf("mark running: %v", err)); failErr != nil {
+					b.logger.Error("bridge: gate.Fail also failed",
+						"task", task.ID, "error", failErr)
+				}
+				continue
+			}
+		}
+
+		b.logger.Info("bridge: resumed task",
+			"team", teamID, "task", task.ID, "instance", inst.ID())
+		b.runTask(b.team, task, inst)
+	}
+}
 
-		// Spawn a monitor goroutine for this task. The semaphore slot
-		// is released by monitorInstance when the task completes or fails.
-		b.wg.Add(1)
-		go func(taskID string, inst Instance) {
-			defer b.wg.Done()
-			b.monitorInstance(taskID, inst)
-		}(task.ID, inst)
+// saveQueueState saves home's queue so a restarted session can resume it.
+// Failures are logged; the queue is saved again on the next change.
+func (b *Bridge) saveQueueState(home *team.Team) {
+	if err := home.Hub().SaveQueueState(); err != nil {
+		b.logger.Debug("bridge: failed to save queue state",
+			"team", home.Spec().ID, "error", err)
+	}
+}
+
+// headCommit returns the commit inst's worktree is at, or "" when the
+// checker cannot read it.
+func (b *Bridge) headCommit(inst Instance) string {
+	hr, ok := b.checker.(HeadReader)
+	if !ok || inst.WorktreePath() == "" {
+		return ""
+	}
+	sha, err := hr.HeadCommit(inst.WorktreePath())
+	if err != nil {
+		b.logger.Debug("bridge: failed to read worktree HEAD",
+			"instance", inst.ID(), "error", err)
+		return ""
+	}
+	return sha
+}
+
+// placeTask reserves a worker node for a task claimed from home's queue when
+// the bridge has a Placer. It reports false when the task was not placed; the
+// task has then been released back to the queue (after waiting for capacity)
+// or failed, and the caller must release its other resources. Tool tasks run
+// locally and are never placed.
+func (b *Bridge) placeTask(home *team.Team, task *taskqueue.QueuedTask, wake <-chan struct{}) (*Node, bool) {
+	if b.placer == nil || task.IsDeterministic() {
+		return nil, true
+	}
+
+	freed := b.placer.capacityFreed()
+	node, err := b.placer.Place(task.ID, task.Backend)
+	if err == nil {
+		b.logger.Info("bridge: placed task",
+			"team", home.Spec().ID, "task", task.ID, "node", node.ID)
+		return &node, true
+	}
+
+	teamID := home.Spec().ID
+	gate := home.Hub().Gate()
+	waiting := errors.Is(err, ErrNoCapacity)
+	b.bus.Publish(event.NewBridgePlacementFailedEvent(teamID, task.ID, task.Backend, err.Error(), waiting))
+
+	if !waiting {
+		b.logger.Error("bridge: no node can host task",
+			"team", teamID, "task", task.ID, "backend", task.Backend, "error", err)
+		if failErr := gate.Fail(task.ID, fmt.Sprintf("placement: %v", err)); failErr != nil {
+			b.logger.Error("bridge: gate.Fail also failed",
+				"task", task.ID, "error", failErr)
+		}
+		return nil, false
+	}
+
+	// Every eligible node is full. Wait for a slot before returning the
+	// task to the queue: releasing first would wake this loop immediately
+	// and spin on the same task.
+	b.logger.Debug("bridge: no node capacity, waiting",
+		"team", teamID, "task", task.ID, "backend", task.Backend)
+	select {
+	case <-b.ctx.Done():
+	case <-freed:
+	}
+	if relErr := gate.Release(task.ID, "no node capacity"); relErr != nil {
+		b.logger.Error("bridge: gate.Release failed",
+			"task", task.ID, "error", relErr)
+	}
+	// Drain the wake our own release produced.
+	select {
+	case <-wake:
+	default:
 	}
+	return nil, false
+}
+
+// releasePlacement frees the node slot held by a task, if any.
+func (b *Bridge) releasePlacement(taskID string) {
+	if b.placer != nil {
+		b.placer.Release(taskID)
+	}
+}
+
+// createTaskInstance creates the instance for a claimed task. Tasks on the
+// session's default backend get the context-enriched task prompt; tasks that
+// select a backend are created through BackendInstanceFactory, and tool tasks
+// get a script that runs their command instead of a prompt. With a plan hash
+// and a ReplayableInstanceFactory, the instance is named deterministically and
+// reused reports whether it adopted a previous run's work. A placed task is
+// created through PlacedInstanceFactory on its node. Prior discoveries come
+// from the mailbox of home, the team whose queue the task belongs to.
+func (b *Bridge) createTaskInstance(home *team.Team, task *taskqueue.QueuedTask, node *Node) (inst Instance, reused bool, err error) {
+	var prompt, model string
+	if task.IsDeterministic() {
+		prompt = ai.BuildToolScript(ai.ToolScript{
+			TaskID:         task.ID,
+			Title:          task.Title,
+			Command:        task.Command,
+			CompletionFile: completionFileName,
+		})
+	} else {
+		// Retrieve prior discoveries for context injection.
+		prompt = BuildTaskPromptWithContext(
+			task.ID, task.Title, task.Description, task.Files,
+			b.getInstanceContext(home, task.ID),
+		)
+		if b.transform != nil {
+			prompt = b.transform(task.ID, task.Title, prompt)
+		}
+		if b.taskModel != nil {
+			model = b.taskModel(task.ID)
+		}
+	}
+
+	spec := TaskInstanceSpec{
+		PlanHash: b.planHash,
+		TaskID:   task.ID,
+		Attempt:  task.RetryCount,
+		Prompt:   prompt,
+		Backend:  task.Backend,
+		Model:    model,
+		Node:     node,
+	}
+	if node != nil {
+		pf, ok := b.factory.(PlacedInstanceFactory)
+		if !ok {
+			return nil, false, fmt.Errorf("instance factory does not support node placement")
+		}
+		return pf.CreatePlacedInstance(spec)
+	}
+	if rf, ok := b.factory.(ReplayableInstanceFactory); ok && b.planHash != "" {
+		return rf.CreateTaskInstance(spec)
+	}
+	if task.Backend != "" {
+		inst, err = b.createInstanceWithBackend(prompt, task.Backend)
+		return inst, false, err
+	}
+	inst, err = b.factory.CreateInstance(prompt)
+	return inst, false, err
+}
+
+// startTaskInstance copies the task's context pack into the instance's
+// worktree and starts the instance. A pack that cannot be written is logged
+// and the task starts without it; the pack only saves it from finding the
+// code itself. Tool tasks get no pack, so it never lands in their commit.
+// When the hub enforces file claims, the worktree also gets the pre-commit
+// hook that checks them; a hook that cannot be installed is logged too.
+func (b *Bridge) startTaskInstance(home *team.Team, task *taskqueue.QueuedTask, inst Instance) error {
+	if !task.IsDeterministic() && !task.ContextPack.IsEmpty() && inst.WorktreePath() != "" {
+		missing, err := contextpack.Write(inst.WorktreePath(), task.ContextPack)
+		switch {
+		case err != nil:
+			b.logger.Warn("bridge: failed to write context pack",
+				"team", home.Spec().ID, "task", task.ID, "error", err)
+		case missing > 0:
+			b.logger.Warn("bridge: context pack has missing entries",
+				"team", home.Spec().ID, "task", task.ID, "missing", missing)
+		}
+	}
+	if socket := home.Hub().ClaimSocket(); socket != "" && inst.WorktreePath() != "" {
+		// File locks are claimed under the task ID, so the worktree commits as it.
+		if err := filelock.InstallHook(inst.WorktreePath(), filelock.HookConfig{Socket: socket, Owner: task.ID}); err != nil {
+			b.logger.Warn("bridge: failed to install file claim hook",
+				"team", home.Spec().ID, "task", task.ID, "error", err)
+		}
+	}
+	return b.factory.StartInstance(inst)
+}
+
+// createInstanceWithBackend creates an instance on a task-selected backend.
+func (b *Bridge) createInstanceWithBackend(prompt, backend string) (Instance, error) {
+	bf, ok := b.factory.(BackendInstanceFactory)
+	if !ok {
+		return nil, fmt.Errorf("instance factory does not support per-task backend %q", backend)
+	}
+	return bf.CreateInstanceWithBackend(prompt, backend)
+}
+
+// alreadyComplete reports whether a reattached instance's worktree already
+// holds a completion sentinel. Check errors count as incomplete so the task
+// simply runs again.
+func (b *Bridge) alreadyComplete(inst Instance) bool {
+	done, err := b.checker.CheckCompletion(inst.WorktreePath())
+	if err != nil {
+		b.logger.Warn("bridge: completion check on reattached instance failed",
+			"instance", inst.ID(), "error", err)
+		return false
+	}
+	return done
+}
+
+// steal claims a ready task from another team's queue and returns it with
+// the team it belongs to. Steal errors are logged and leave nothing to run.
+func (b *Bridge) steal(claimID string) (*team.Team, *taskqueue.QueuedTask) {
+	teamID := b.team.Spec().ID
+	home, task, err := b.stealer.StealTask(teamID, claimID)
+	if err != nil {
+		b.logger.Warn("bridge: work steal failed", "team", teamID, "error", err)
+		return b.team, nil
+	}
+	if task == nil {
+		return b.team, nil
+	}
+	b.logger.Info("bridge: stole task",
+		"team", teamID, "from", home.Spec().ID, "task", task.ID)
+	return home, task
 }
 
 // waitForWake blocks until either the wake channel fires or the context is cancelled.
@@ -314,17 +722,59 @@ func (b *Bridge) waitForWake(wake <-chan struct{}) {
 // when the worktree path is invalid or the filesystem is unhealthy.
 const maxCheckErrors = 10
 
+// signalPreempt tells the monitor of a running task that the adaptive lead
+// preempted it. Tasks not running here are ignored.
+func (b *Bridge) signalPreempt(taskID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if ch, ok := b.preempt[taskID]; ok {
+		close(ch)
+		delete(b.preempt, taskID)
+	}
+}
+
+// signalRemoved tells the monitor of a running task that the task was
+// removed from the queue. Tasks not running here are ignored.
+func (b *Bridge) signalRemoved(taskID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if ch, ok := b.removed[taskID]; ok {
+		close(ch)
+		delete(b.removed, taskID)
+	}
+}
+
 // monitorInstance polls for instance completion and reports the result.
-func (b *Bridge) monitorInstance(taskID string, inst Instance) {
+// Tool tasks are verified with ToolWorkVerifier when the checker supports it.
+// When preempt is closed the task has already been returned to the queue;
+// the monitor pauses the instance and frees the task's slot. When removed is
+// closed the task is gone from the queue, typically because a plan revision
+// dropped or redefined it; whoever removed it stops the instance, and the
+// monitor only frees the slot. The outcome is reported to home, the team
+// whose queue the task belongs to.
+func (b *Bridge) monitorInstance(home *team.Team, taskID string, inst Instance, tool bool, preempt, removed <-chan struct{}) {
 	defer b.sem.Release()
+	defer b.releasePlacement(taskID)
+	defer func() {
+		b.mu.Lock()
+		if b.preempt[taskID] == preempt {
+			delete(b.preempt, taskID)
+		}
+		if b.removed[taskID] == removed {
+			delete(b.removed, taskID)
+		}
+		b.mu.Unlock()
+	}()
 
 	ticker := time.NewTicker(b.pollInterval)
 	defer ticker.Stop()
 
 	consecutiveErrors := 0
+	lastHead := ""
 
-	hub := b.team.Hub()
+	hub := home.Hub()
 	reg := hub.FileLockRegistry()
+	defer b.saveQueueState(home)
 
 	for {
 		select {
@@ -335,6 +785,38 @@ func (b *Bridge) monitorInstance(taskID string, inst Instance) {
 			delete(b.running, taskID)
 			b.mu.Unlock()
 			reg.ReleaseAll(taskID) //nolint:errcheck // best-effort cleanup
+			b.spans.Fail(taskID, "cancelled")
+			return
+		case <-preempt:
+			b.logger.Info("bridge: task preempted, pausing instance",
+				"team", home.Spec().ID, "task", taskID, "instance", inst.ID())
+			if p, ok := b.factory.(InstancePauser); ok {
+				if err := p.PauseInstance(inst); err != nil {
+					b.logger.Warn("bridge: failed to pause preempted instance",
+						"task", taskID, "instance", inst.ID(), "error", err)
+				}
+			}
+			// The task is pending again and may already have been claimed
+			// by a new run, whose entry must be left alone.
+			b.mu.Lock()
+			if b.running[taskID] == inst.ID() {
+				delete(b.running, taskID)
+			}
+			b.mu.Unlock()
+			reg.ReleaseAll(taskID) //nolint:errcheck // best-effort cleanup
+			b.spans.Fail(taskID, "preempted")
+			return
+		case <-removed:
+			b.logger.Info("bridge: task removed from the queue, no longer monitoring",
+				"team", home.Spec().ID, "task", taskID, "instance", inst.ID())
+			// A redefined task may already have been claimed by a new run.
+			b.mu.Lock()
+			if b.running[taskID] == inst.ID() {
+				delete(b.running, taskID)
+			}
+			b.mu.Unlock()
+			reg.ReleaseAll(taskID) //nolint:errcheck // best-effort cleanup
+			b.spans.Fail(taskID, "removed")
 			return
 		case <-ticker.C:
 		}
@@ -361,6 +843,7 @@ func (b *Bridge) monitorInstance(taskID string, inst Instance) {
 				b.mu.Unlock()
 				b.recorder.RecordFailure(taskID, reason)
 				reg.ReleaseAll(taskID) //nolint:errcheck // best-effort cleanup
+				b.spans.Fail(taskID, reason)
 				return
 			}
 			continue
@@ -368,20 +851,34 @@ func (b *Bridge) monitorInstance(taskID string, inst Instance) {
 		consecutiveErrors = 0
 
 		if !done {
+			// Keep the task's checkpoint fresh so a restarted coordinator
+			// can tell this instance was alive and how far it got.
+			head := b.headCommit(inst)
+			if err := hub.Gate().Heartbeat(taskID, head); err != nil {
+				b.logger.Debug("bridge: heartbeat failed", "task", taskID, "error", err)
+			} else if head != "" && head != lastHead {
+				lastHead = head
+				b.saveQueueState(home)
+			}
 			continue
 		}
 
 		// Instance wrote its sentinel file — notify recorder so the UI
 		// can transition to "finishing" while verification runs.
 		b.recorder.RecordSentinelDetected(taskID, inst.ID())
+		b.spans.AddEvent(taskID, "sentinel detected")
 
 		// Verify the work.
-		success, commitCount, verifyErr := b.checker.VerifyWork(
+		verifyWork := b.checker.VerifyWork
+		if tv, ok := b.checker.(ToolWorkVerifier); ok && tool {
+			verifyWork = tv.VerifyToolWork
+		}
+		success, commitCount, verifyErr := verifyWork(
 			taskID, inst.ID(), inst.WorktreePath(), inst.Branch(),
 		)
 
 		gate := hub.Gate()
-		teamID := b.team.Spec().ID
+		teamID := home.Spec().ID
 
 		// Clean up running map before recording/publishing so observers see
 		// consistent state when callbacks or event handlers fire.
@@ -401,11 +898,14 @@ func (b *Bridge) monitorInstance(taskID string, inst Instance) {
 			reg.ReleaseAll(taskID) //nolint:errcheck // best-effort cleanup
 
 			// Share completion as a discovery for context propagation.
-			b.shareCompletion(taskID, inst)
+			b.shareCompletion(home, taskID, inst)
+			b.publishContracts(home, taskID, inst)
+			b.shareProposals(home, taskID, inst)
 
 			b.bus.Publish(event.NewBridgeTaskCompletedEvent(
 				teamID, taskID, inst.ID(), true, commitCount, "",
 			))
+			b.spans.End(taskID, attribute.Int("claudio.commits", commitCount))
 		} else {
 			reason := "verification failed"
 			if verifyErr != nil {
@@ -422,6 +922,7 @@ func (b *Bridge) monitorInstance(taskID string, inst Instance) {
 			b.bus.Publish(event.NewBridgeTaskCompletedEvent(
 				teamID, taskID, inst.ID(), false, commitCount, reason,
 			))
+			b.spans.Fail(taskID, reason, attribute.Int("claudio.commits", commitCount))
 		}
 
 		return
@@ -510,11 +1011,13 @@ func writeCompletionProtocol(sb *strings.Builder, taskID string) {
 	sb.WriteString("  \"notes\": \"Any implementation notes for the consolidation phase\",\n")
 	sb.WriteString("  \"issues\": [\"Any concerns or blocking issues found\"],\n")
 	sb.WriteString("  \"suggestions\": [\"Suggestions for integration with other tasks\"],\n")
-	sb.WriteString("  \"dependencies\": [\"Any new runtime dependencies added\"]\n")
+	sb.WriteString("  \"dependencies\": [\"Any new runtime dependencies added\"],\n")
+	sb.WriteString("  \"proposed_tasks\": [{\"title\": \"Follow-up task\", \"description\": \"What to do and why\", \"files\": [\"files/it/touches\"]}]\n")
 	sb.WriteString("}\n")
 	sb.WriteString("```\n\n")
 	sb.WriteString("3. Use status \"blocked\" if you cannot complete (explain in issues), or \"failed\" if something broke\n")
-	sb.WriteString("4. This file signals that your work is done and provides context for consolidation\n\n")
+	sb.WriteString("4. This file signals that your work is done and provides context for consolidation\n")
+	sb.WriteString("5. Only add proposed_tasks for follow-up work you found outside your task's scope (e.g. dead code to remove); each one runs only if the user approves it\n\n")
 	sb.WriteString("**REMEMBER**: Your task is NOT complete until you write this file. Do it NOW after finishing your work.\n")
 }
 
@@ -535,8 +1038,8 @@ const maxContextMessages = 50
 
 // getInstanceContext retrieves prior discoveries from the context propagator.
 // Returns an empty string if no relevant context exists or on error.
-func (b *Bridge) getInstanceContext(taskID string) string {
-	ctx, err := b.team.Hub().Propagator().GetContextForInstance(taskID, mailbox.FilterOptions{
+func (b *Bridge) getInstanceContext(home *team.Team, taskID string) string {
+	ctx, err := home.Hub().Propagator().GetContextForInstance(taskID, mailbox.FilterOptions{
 		Types:       []mailbox.MessageType{mailbox.MessageDiscovery, mailbox.MessageWarning},
 		MaxMessages: maxContextMessages,
 	})
@@ -550,11 +1053,72 @@ func (b *Bridge) getInstanceContext(taskID string) string {
 
 // shareCompletion broadcasts a completion discovery so future instances have
 // awareness of what has been done. Only called on success paths.
-func (b *Bridge) shareCompletion(taskID string, inst Instance) {
+func (b *Bridge) shareCompletion(home *team.Team, taskID string, inst Instance) {
 	body := fmt.Sprintf("Task completed: %s (instance: %s, worktree: %s)",
 		taskID, inst.ID(), inst.WorktreePath())
-	if err := b.team.Hub().Propagator().ShareDiscovery(taskID, body, nil); err != nil {
+	if err := home.Hub().Propagator().ShareDiscovery(taskID, body, nil); err != nil {
 		b.logger.Warn("bridge: failed to share completion discovery",
 			"task", taskID, "error", err)
 	}
 }
+
+// shareProposals forwards the follow-up tasks the instance proposed to the
+// coordinator as MessageTaskProposal messages in home's mailbox. Only called
+// on success paths.
+func (b *Bridge) shareProposals(home *team.Team, taskID string, inst Instance) {
+	reader, ok := b.checker.(TaskProposalReader)
+	if !ok {
+		return
+	}
+	proposals, err := reader.ReadTaskProposals(inst.WorktreePath())
+	if err != nil {
+		b.logger.Warn("bridge: failed to read task proposals",
+			"task", taskID, "error", err)
+		return
+	}
+	for _, p := range proposals {
+		msg, err := mailbox.NewTaskProposalMessage(taskID, p)
+		if err == nil {
+			err = home.Hub().Mailbox().Send(msg)
+		}
+		if err != nil {
+			b.logger.Warn("bridge: failed to share task proposal",
+				"task", taskID, "error", err)
+		}
+	}
+}
+
+// publishContracts reads the task's declared contract artifacts from the
+// instance worktree and forwards them to dependent teams. Missing or
+// unreadable files are logged and skipped so one absent schema does not
+// suppress the rest. Only called on success paths.
+func (b *Bridge) publishContracts(home *team.Team, taskID string, inst Instance) {
+	if b.contracts == nil {
+		return
+	}
+	task := home.Hub().TaskQueue().GetTask(taskID)
+	if task == nil || len(task.Contracts) == 0 {
+		return
+	}
+
+	spec := home.Spec()
+	artifacts := make([]team.ContractArtifact, 0, len(task.Contracts))
+	for _, rel := range task.Contracts {
+		data, err := os.ReadFile(filepath.Join(inst.WorktreePath(), rel))
+		if err != nil {
+			b.logger.Warn("bridge: failed to read contract artifact",
+				"task", taskID, "path", rel, "error", err)
+			continue
+		}
+		artifacts = append(artifacts, team.ContractArtifact{
+			Repo:    spec.Repo,
+			Path:    rel,
+			Content: string(data),
+		})
+	}
+
+	if err := b.contracts.PublishContracts(spec.ID, artifacts); err != nil {
+		b.logger.Warn("bridge: failed to publish contracts",
+			"task", taskID, "error", err)
+	}
+}
@@ -3,16 +3,28 @@ package bridge_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
 	"github.com/Iron-Ham/claudio/internal/bridge"
+	"github.com/Iron-Ham/claudio/internal/config"
 	"github.com/Iron-Ham/claudio/internal/coordination"
 	"github.com/Iron-Ham/claudio/internal/event"
 	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/Iron-Ham/claudio/internal/mailbox"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+	"github.com/Iron-Ham/claudio/internal/taskqueue"
 	"github.com/Iron-Ham/claudio/internal/team"
+	"github.com/Iron-Ham/claudio/internal/tracing"
 	"github.com/Iron-Ham/claudio/internal/ultraplan"
 )
 
@@ -34,6 +46,7 @@ type mockFactory struct {
 	instances map[string]*mockInstance
 	createErr error
 	startErr  error
+	rootDir   string // when set, worktree paths are created under this directory
 }
 
 func newMockFactory() *mockFactory {
@@ -56,6 +69,9 @@ func (f *mockFactory) CreateInstance(prompt string) (bridge.Instance, error) {
 		worktreePath: "/tmp/wt-" + id,
 		branch:       "branch-" + id,
 	}
+	if f.rootDir != "" {
+		inst.worktreePath = filepath.Join(f.rootDir, fmt.Sprintf("wt-%d", len(f.created)))
+	}
 	f.created = append(f.created, prompt)
 	f.instances[inst.id] = inst
 	return inst, nil
@@ -981,6 +997,63 @@ func TestBridge_SentinelDetectedBeforeCompletion(t *testing.T) {
 	}
 }
 
+func TestBridge_TraceSpans(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	p, err := tracing.Start(context.Background(), config.TracingConfig{Enabled: true, SampleRatio: 1}, "", tracing.WithExporter(exp))
+	if err != nil {
+		t.Fatalf("tracing.Start: %v", err)
+	}
+	defer func() { _ = p.Shutdown(context.Background()) }()
+
+	bus := event.NewBus()
+	tt := newTestTeam(t, bus, []ultraplan.PlannedTask{{ID: "t1", Title: "Task 1", Description: "Do thing 1"}})
+	factory := newMockFactory()
+	checker := newMockChecker()
+	checker.verifyOK = true
+	checker.commitCount = 2
+
+	b := bridge.New(tt, factory, checker, newMockRecorder(), bus,
+		bridge.WithPollInterval(10*time.Millisecond),
+	)
+	if err := b.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	waitForEvent(t, bus, "bridge.task_started", 2*time.Second)
+	factory.mu.Lock()
+	for _, inst := range factory.instances {
+		checker.MarkComplete(inst.worktreePath)
+	}
+	factory.mu.Unlock()
+	waitForEvent(t, bus, "bridge.task_completed", 2*time.Second)
+	b.Stop()
+
+	spans := make(map[string]tracetest.SpanStub)
+	for _, s := range exp.GetSpans() {
+		spans[s.Name] = s
+	}
+	if _, ok := spans["bridge.start_instance"]; !ok {
+		t.Error("missing bridge.start_instance span")
+	}
+	task, ok := spans["bridge.task"]
+	if !ok {
+		t.Fatalf("missing bridge.task span, got %v", exp.GetSpans().Snapshots())
+	}
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, a := range task.Attributes {
+		attrs[a.Key] = a.Value
+	}
+	if attrs[tracing.TaskID].AsString() != "t1" || attrs[tracing.TaskTitle].AsString() != "Task 1" {
+		t.Errorf("task span attributes = %v", task.Attributes)
+	}
+	if attrs["claudio.commits"].AsInt64() != 2 {
+		t.Errorf("claudio.commits = %v, want 2", attrs["claudio.commits"])
+	}
+	if len(task.Events) != 1 || task.Events[0].Name != "sentinel detected" {
+		t.Errorf("task span events = %+v, want sentinel detected", task.Events)
+	}
+}
+
 func TestBuildTaskPrompt(t *testing.T) {
 	prompt := bridge.BuildTaskPrompt(
 		"task-1",
@@ -1033,3 +1106,942 @@ func TestBuildTaskPrompt_EmptyTaskID(t *testing.T) {
 		t.Error("prompt should not contain completion protocol when taskID is empty")
 	}
 }
+
+type mockContractPublisher struct {
+	mu        sync.Mutex
+	fromTeam  string
+	artifacts []team.ContractArtifact
+	calls     int
+}
+
+func (p *mockContractPublisher) PublishContracts(fromTeam string, artifacts []team.ContractArtifact) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	p.fromTeam = fromTeam
+	p.artifacts = artifacts
+	return nil
+}
+
+func TestBridge_PublishesContractsOnSuccess(t *testing.T) {
+	bus := event.NewBus()
+	tasks := []ultraplan.PlannedTask{
+		{ID: "t1", Title: "Export schema", Description: "d", Contracts: []string{"api/schema.json", "missing.json"}},
+	}
+	tt := newTestTeam(t, bus, tasks)
+
+	factory := newMockFactory()
+	factory.rootDir = t.TempDir()
+	checker := newMockChecker()
+	publisher := &mockContractPublisher{}
+
+	b := bridge.New(tt, factory, checker, newMockRecorder(), bus,
+		bridge.WithPollInterval(10*time.Millisecond),
+		bridge.WithContractPublisher(publisher),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := b.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer b.Stop()
+
+	waitForEvent(t, bus, "bridge.task_started", 2*time.Second)
+
+	wt := filepath.Join(factory.rootDir, "wt-0")
+	if err := os.MkdirAll(filepath.Join(wt, "api"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(wt, "api", "schema.json"), []byte(`{"v":1}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	checker.MarkComplete(wt)
+
+	waitForEvent(t, bus, "bridge.task_completed", 2*time.Second)
+
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+	if publisher.calls != 1 {
+		t.Fatalf("PublishContracts calls = %d, want 1", publisher.calls)
+	}
+	if publisher.fromTeam != "test-team" {
+		t.Errorf("fromTeam = %q, want %q", publisher.fromTeam, "test-team")
+	}
+	// The missing artifact is skipped; the readable one is forwarded.
+	if len(publisher.artifacts) != 1 {
+		t.Fatalf("artifacts = %d, want 1", len(publisher.artifacts))
+	}
+	if got := publisher.artifacts[0]; got.Path != "api/schema.json" || got.Content != `{"v":1}` {
+		t.Errorf("artifact = %+v", got)
+	}
+}
+
+type proposalChecker struct {
+	*mockChecker
+	proposals []mailbox.TaskProposal
+}
+
+func (c *proposalChecker) ReadTaskProposals(string) ([]mailbox.TaskProposal, error) {
+	return c.proposals, nil
+}
+
+func TestBridge_SharesTaskProposalsOnSuccess(t *testing.T) {
+	bus := event.NewBus()
+	tt := newTestTeam(t, bus, []ultraplan.PlannedTask{{ID: "t1", Title: "Add API", Description: "d"}})
+
+	var mu sync.Mutex
+	var proposals []event.MailboxMessageEvent
+	bus.Subscribe("mailbox.message", func(e event.Event) {
+		if me := e.(event.MailboxMessageEvent); me.MessageType == string(mailbox.MessageTaskProposal) {
+			mu.Lock()
+			proposals = append(proposals, me)
+			mu.Unlock()
+		}
+	})
+
+	factory := newMockFactory()
+	factory.rootDir = t.TempDir()
+	checker := &proposalChecker{
+		mockChecker: newMockChecker(),
+		proposals:   []mailbox.TaskProposal{{Title: "Remove dead code", Files: []string{"old.go"}}, {Title: ""}},
+	}
+	b := bridge.New(tt, factory, checker, newMockRecorder(), bus, bridge.WithPollInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := b.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer b.Stop()
+
+	waitForEvent(t, bus, "bridge.task_started", 2*time.Second)
+	checker.MarkComplete(filepath.Join(factory.rootDir, "wt-0"))
+	waitForEvent(t, bus, "bridge.task_completed", 2*time.Second)
+
+	mu.Lock()
+	defer mu.Unlock()
+	// The untitled proposal is skipped
+	if len(proposals) != 1 {
+		t.Fatalf("proposals = %d, want 1", len(proposals))
+	}
+	if me := proposals[0]; me.From != "t1" || me.To != mailbox.CoordinatorRecipient {
+		t.Errorf("proposal = %+v, want one from t1 to the coordinator", me)
+	}
+	if p, err := mailbox.ParseTaskProposal(proposals[0].Body); err != nil || p.Title != "Remove dead code" {
+		t.Errorf("ParseTaskProposal() = %+v, %v", p, err)
+	}
+}
+
+func TestBridge_PromptTransform(t *testing.T) {
+	bus := event.NewBus()
+	tasks := []ultraplan.PlannedTask{
+		{ID: "t1", Title: "Task 1", Description: "Do thing 1"},
+	}
+	tt := newTestTeam(t, bus, tasks)
+
+	factory := newMockFactory()
+	checker := newMockChecker()
+	recorder := newMockRecorder()
+
+	var gotID, gotTitle string
+	b := bridge.New(tt, factory, checker, recorder, bus,
+		bridge.WithPollInterval(10*time.Millisecond),
+		bridge.WithPromptTransform(func(taskID, title, prompt string) string {
+			gotID, gotTitle = taskID, title
+			return "VARIANT\n" + prompt
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := b.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer b.Stop()
+
+	waitForEvent(t, bus, "bridge.task_started", 2*time.Second)
+
+	created := factory.Created()
+	if len(created) != 1 {
+		t.Fatalf("factory.Created() = %d prompts, want 1", len(created))
+	}
+	if !strings.HasPrefix(created[0], "VARIANT\n") {
+		t.Errorf("prompt was not transformed: %q", created[0][:min(40, len(created[0]))])
+	}
+	if !strings.Contains(created[0], "Do thing 1") {
+		t.Error("transformed prompt lost the original task description")
+	}
+	if gotID != "t1" || gotTitle != "Task 1" {
+		t.Errorf("transform got (%q, %q), want (%q, %q)", gotID, gotTitle, "t1", "Task 1")
+	}
+}
+
+func TestBridge_WritesContextPack(t *testing.T) {
+	bus := event.NewBus()
+	tasks := []ultraplan.PlannedTask{
+		{ID: "t1", Title: "Task 1", Description: "d", ContextPack: &types.ContextPack{
+			Excerpts: []types.Excerpt{{Path: "store.go", StartLine: 3, EndLine: 3}},
+		}},
+	}
+	tt := newTestTeam(t, bus, tasks)
+
+	factory := newMockFactory()
+	factory.rootDir = t.TempDir()
+	wt := filepath.Join(factory.rootDir, "wt-0")
+	if err := os.MkdirAll(wt, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(wt, "store.go"), []byte("package store\n\ntype Store struct{}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	b := bridge.New(tt, factory, newMockChecker(), newMockRecorder(), bus,
+		bridge.WithPollInterval(10*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := b.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer b.Stop()
+
+	waitForEvent(t, bus, "bridge.task_started", 2*time.Second)
+
+	data, err := os.ReadFile(filepath.Join(wt, types.ContextPackFileName))
+	if err != nil {
+		t.Fatalf("context pack not written before start: %v", err)
+	}
+	if !strings.Contains(string(data), "### store.go (lines 3-3)\n\n```go\ntype Store struct{}\n```") {
+		t.Errorf("context pack = %q, want the store.go excerpt", data)
+	}
+}
+
+// backendFactory is a mockFactory that also supports per-task backends.
+type backendFactory struct {
+	*mockFactory
+	backends []string
+}
+
+func (f *backendFactory) CreateInstanceWithBackend(prompt, backend string) (bridge.Instance, error) {
+	f.mu.Lock()
+	f.backends = append(f.backends, backend)
+	f.mu.Unlock()
+	return f.CreateInstance(prompt)
+}
+
+// toolChecker is a mockChecker that records VerifyToolWork calls.
+type toolChecker struct {
+	*mockChecker
+	toolVerified atomic.Int32
+}
+
+func (c *toolChecker) VerifyToolWork(_, _, _, _ string) (bool, int, error) {
+	c.toolVerified.Add(1)
+	return true, 0, nil
+}
+
+func TestBridge_ToolTask(t *testing.T) {
+	bus := event.NewBus()
+	tasks := []ultraplan.PlannedTask{
+		{ID: "t1", Title: "Format code", Backend: "tool", Command: "gofmt -w ."},
+	}
+	tt := newTestTeam(t, bus, tasks)
+
+	factory := &backendFactory{mockFactory: newMockFactory()}
+	checker := &toolChecker{mockChecker: newMockChecker()}
+	recorder := newMockRecorder()
+
+	b := bridge.New(tt, factory, checker, recorder, bus,
+		bridge.WithPollInterval(10*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := b.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer b.Stop()
+
+	waitForEvent(t, bus, "bridge.task_started", 2*time.Second)
+
+	created := factory.Created()
+	if len(created) != 1 {
+		t.Fatalf("factory.Created() = %d prompts, want 1", len(created))
+	}
+	if !strings.Contains(created[0], "gofmt -w .") {
+		t.Errorf("tool script does not run the task command:\n%s", created[0])
+	}
+	factory.mu.Lock()
+	backends := append([]string(nil), factory.backends...)
+	factory.mu.Unlock()
+	if len(backends) != 1 || backends[0] != "tool" {
+		t.Errorf("backends = %v, want [tool]", backends)
+	}
+
+	factory.mu.Lock()
+	var wtp string
+	for _, inst := range factory.instances {
+		wtp = inst.worktreePath
+	}
+	factory.mu.Unlock()
+	checker.MarkComplete(wtp)
+
+	ce := waitForEvent(t, bus, "bridge.task_completed", 2*time.Second)
+	completed := ce.(event.BridgeTaskCompletedEvent)
+	if !completed.Success {
+		t.Errorf("completed.Success = false, want true (error %q)", completed.Error)
+	}
+	if got := checker.toolVerified.Load(); got != 1 {
+		t.Errorf("VerifyToolWork calls = %d, want 1", got)
+	}
+}
+
+func TestBridge_ToolTaskWithoutBackendFactory(t *testing.T) {
+	bus := event.NewBus()
+	tasks := []ultraplan.PlannedTask{
+		{ID: "t1", Title: "Format code", Backend: "tool", Command: "gofmt -w ."},
+	}
+	tt := newTestTeam(t, bus, tasks)
+
+	factory := newMockFactory()
+	recorder := newMockRecorder()
+
+	b := bridge.New(tt, factory, newMockChecker(), recorder, bus,
+		bridge.WithPollInterval(10*time.Millisecond),
+	)
+
+	if err := b.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	stopWithTimeout(t, b, 3*time.Second)
+
+	if created := factory.Created(); len(created) != 0 {
+		t.Errorf("factory.Created() = %d prompts, want 0", len(created))
+	}
+}
+
+// replayFactory is a mockFactory that implements ReplayableInstanceFactory
+// and reports every instance as reattached.
+type replayFactory struct {
+	*mockFactory
+	specs   []bridge.TaskInstanceSpec
+	started atomic.Int32
+}
+
+func (f *replayFactory) CreateTaskInstance(spec bridge.TaskInstanceSpec) (bridge.Instance, bool, error) {
+	f.mu.Lock()
+	f.specs = append(f.specs, spec)
+	inst := &mockInstance{
+		id:           "inst-" + spec.TaskID,
+		worktreePath: "/tmp/wt-" + spec.TaskID,
+		branch:       "plan-" + spec.PlanHash + "-" + spec.TaskID,
+	}
+	f.instances[inst.id] = inst
+	f.mu.Unlock()
+	return inst, true, nil
+}
+
+func (f *replayFactory) StartInstance(inst bridge.Instance) error {
+	f.started.Add(1)
+	return f.mockFactory.StartInstance(inst)
+}
+
+func TestBridge_ReplaySkipsCompletedTask(t *testing.T) {
+	bus := event.NewBus()
+	tasks := []ultraplan.PlannedTask{
+		{ID: "t1", Title: "Task 1", Description: "Do thing 1"},
+	}
+	tt := newTestTeam(t, bus, tasks)
+
+	factory := &replayFactory{mockFactory: newMockFactory()}
+	checker := newMockChecker()
+	// The previous run already wrote the sentinel in the reattached worktree.
+	checker.MarkComplete("/tmp/wt-t1")
+	recorder := newMockRecorder()
+
+	b := bridge.New(tt, factory, checker, recorder, bus,
+		bridge.WithPollInterval(10*time.Millisecond),
+		bridge.WithPlanHash("abc123"),
+	)
+
+	// Subscribe before starting: the task completes without ever running.
+	completedCh := make(chan event.BridgeTaskCompletedEvent, 1)
+	subID := bus.Subscribe("bridge.task_completed", func(e event.Event) {
+		completedCh <- e.(event.BridgeTaskCompletedEvent)
+	})
+	defer bus.Unsubscribe(subID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := b.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer b.Stop()
+
+	select {
+	case completed := <-completedCh:
+		if !completed.Success {
+			t.Errorf("completed.Success = false, want true (error %q)", completed.Error)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for bridge.task_completed")
+	}
+	if got := factory.started.Load(); got != 0 {
+		t.Errorf("StartInstance calls = %d, want 0 for an already-complete task", got)
+	}
+
+	factory.mu.Lock()
+	specs := append([]bridge.TaskInstanceSpec(nil), factory.specs...)
+	factory.mu.Unlock()
+	if len(specs) != 1 || specs[0].PlanHash != "abc123" || specs[0].TaskID != "t1" || specs[0].Attempt != 0 {
+		t.Errorf("specs = %+v, want one attempt-0 spec for t1 with the plan hash", specs)
+	}
+}
+
+func TestBridge_ReplayStartsIncompleteTask(t *testing.T) {
+	bus := event.NewBus()
+	tasks := []ultraplan.PlannedTask{
+		{ID: "t1", Title: "Task 1", Description: "Do thing 1"},
+	}
+	tt := newTestTeam(t, bus, tasks)
+
+	factory := &replayFactory{mockFactory: newMockFactory()}
+	b := bridge.New(tt, factory, newMockChecker(), newMockRecorder(), bus,
+		bridge.WithPollInterval(10*time.Millisecond),
+		bridge.WithPlanHash("abc123"),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := b.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer b.Stop()
+
+	waitForEvent(t, bus, "bridge.task_started", 2*time.Second)
+	if got := factory.started.Load(); got != 1 {
+		t.Errorf("StartInstance calls = %d, want 1 for an incomplete reattached task", got)
+	}
+}
+
+func TestBridge_TaskModel(t *testing.T) {
+	bus := event.NewBus()
+	tasks := []ultraplan.PlannedTask{
+		{ID: "t1", Title: "Task 1", Description: "Do thing 1"},
+	}
+	tt := newTestTeam(t, bus, tasks)
+
+	factory := &replayFactory{mockFactory: newMockFactory()}
+	b := bridge.New(tt, factory, newMockChecker(), newMockRecorder(), bus,
+		bridge.WithPollInterval(10*time.Millisecond),
+		bridge.WithPlanHash("abc123"),
+		bridge.WithTaskModel(func(taskID string) string { return "opus-for-" + taskID }),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := b.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer b.Stop()
+
+	waitForEvent(t, bus, "bridge.task_started", 2*time.Second)
+	factory.mu.Lock()
+	specs := append([]bridge.TaskInstanceSpec(nil), factory.specs...)
+	factory.mu.Unlock()
+	if len(specs) != 1 || specs[0].Model != "opus-for-t1" {
+		t.Errorf("specs = %+v, want one spec with model opus-for-t1", specs)
+	}
+}
+
+// --- Placement -----------------------------------------------------------
+
+type placedFactory struct {
+	*mockFactory
+	specs []bridge.TaskInstanceSpec
+}
+
+func (f *placedFactory) CreatePlacedInstance(spec bridge.TaskInstanceSpec) (bridge.Instance, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.specs = append(f.specs, spec)
+	inst := &mockInstance{
+		id:           "inst-" + spec.TaskID,
+		worktreePath: "/tmp/wt-" + spec.TaskID,
+		branch:       "branch-" + spec.TaskID,
+	}
+	f.instances[inst.id] = inst
+	return inst, false, nil
+}
+
+func (f *placedFactory) Specs() []bridge.TaskInstanceSpec {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]bridge.TaskInstanceSpec(nil), f.specs...)
+}
+
+func TestBridge_PlacementWaitsForCapacity(t *testing.T) {
+	bus := event.NewBus()
+	tasks := []ultraplan.PlannedTask{
+		{ID: "t1", Title: "Task 1", Description: "Do thing 1"},
+		{ID: "t2", Title: "Task 2", Description: "Do thing 2"},
+	}
+	tt := newTestTeam(t, bus, tasks)
+
+	placer, err := bridge.NewPlacer([]bridge.Node{
+		{ID: "gpu-1", Capacity: 1, Env: map[string]string{"ANTHROPIC_BASE_URL": "http://gpu-1:8000"}},
+	}, bridge.PlacementSpread)
+	if err != nil {
+		t.Fatalf("NewPlacer: %v", err)
+	}
+
+	factory := &placedFactory{mockFactory: newMockFactory()}
+	checker := newMockChecker()
+	b := bridge.New(tt, factory, checker, newMockRecorder(), bus,
+		bridge.WithPollInterval(10*time.Millisecond),
+		bridge.WithPlacer(placer),
+	)
+
+	waitingCh := make(chan event.BridgePlacementFailedEvent, 4)
+	subID := bus.Subscribe("bridge.placement_failed", func(e event.Event) {
+		select {
+		case waitingCh <- e.(event.BridgePlacementFailedEvent):
+		default:
+		}
+	})
+	defer bus.Unsubscribe(subID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := b.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer b.Stop()
+
+	var failed event.BridgePlacementFailedEvent
+	select {
+	case failed = <-waitingCh:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for bridge.placement_failed")
+	}
+	if !failed.Waiting {
+		t.Errorf("placement failure Waiting = false, want true")
+	}
+
+	specs := factory.Specs()
+	if len(specs) != 1 {
+		t.Fatalf("created %d instances while node full, want 1", len(specs))
+	}
+	first := specs[0]
+	if first.Node == nil || first.Node.ID != "gpu-1" {
+		t.Fatalf("spec.Node = %+v, want gpu-1", first.Node)
+	}
+	if first.Node.Env["ANTHROPIC_BASE_URL"] != "http://gpu-1:8000" {
+		t.Errorf("spec.Node.Env = %v, want node endpoint", first.Node.Env)
+	}
+
+	// Finishing the first task frees the slot for the second.
+	checker.MarkComplete("/tmp/wt-" + first.TaskID)
+
+	deadline := time.After(2 * time.Second)
+	for len(factory.Specs()) < 2 {
+		select {
+		case <-deadline:
+			t.Fatal("second task was not placed after capacity was freed")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if second := factory.Specs()[1]; second.TaskID == first.TaskID || second.Node == nil {
+		t.Errorf("second spec = %+v, want the other task on a node", second)
+	}
+}
+
+func TestBridge_PlacementNoEligibleNodeFailsTask(t *testing.T) {
+	bus := event.NewBus()
+	tasks := []ultraplan.PlannedTask{
+		{ID: "t1", Title: "Task 1", Description: "Do thing 1", Backend: "claude"},
+	}
+	tt := newTestTeam(t, bus, tasks)
+
+	placer, err := bridge.NewPlacer([]bridge.Node{
+		{ID: "gpu-1", Capacity: 1, Backends: []string{"vllm"}},
+	}, bridge.PlacementSpread)
+	if err != nil {
+		t.Fatalf("NewPlacer: %v", err)
+	}
+
+	factory := &placedFactory{mockFactory: newMockFactory()}
+	recorder := newMockRecorder()
+	b := bridge.New(tt, factory, newMockChecker(), recorder, bus,
+		bridge.WithPollInterval(10*time.Millisecond),
+		bridge.WithPlacer(placer),
+	)
+
+	failedCh := make(chan event.BridgePlacementFailedEvent, 1)
+	subID := bus.Subscribe("bridge.placement_failed", func(e event.Event) {
+		select {
+		case failedCh <- e.(event.BridgePlacementFailedEvent):
+		default:
+		}
+	})
+	defer bus.Unsubscribe(subID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := b.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	select {
+	case failed := <-failedCh:
+		if failed.Waiting {
+			t.Error("Waiting = true, want false when no node serves the backend")
+		}
+		if failed.Backend != "claude" || failed.TaskID != "t1" {
+			t.Errorf("event = %+v, want t1 on backend claude", failed)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for bridge.placement_failed")
+	}
+
+	stopWithTimeout(t, b, 2*time.Second)
+	if len(factory.Specs()) != 0 {
+		t.Error("no instance should be created for an unplaceable task")
+	}
+}
+
+// pausingFactory is a mockFactory that implements bridge.InstancePauser.
+type pausingFactory struct {
+	*mockFactory
+	paused chan string
+}
+
+func (f *pausingFactory) PauseInstance(inst bridge.Instance) error {
+	f.paused <- inst.ID()
+	return nil
+}
+
+func TestBridge_PreemptionPausesInstance(t *testing.T) {
+	bus := event.NewBus()
+	tasks := []ultraplan.PlannedTask{
+		{ID: "docs", Title: "Docs", Description: "Write docs", Files: []string{"README.md"}, Priority: 5},
+	}
+	tt := newTestTeam(t, bus, tasks)
+
+	factory := &pausingFactory{mockFactory: newMockFactory(), paused: make(chan string, 1)}
+	b := bridge.New(tt, factory, newMockChecker(), newMockRecorder(), bus,
+		bridge.WithPollInterval(10*time.Millisecond),
+		bridge.WithMaxConcurrency(1),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := b.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer b.Stop()
+
+	started := waitForEvent(t, bus, "bridge.task_started", 2*time.Second).(event.BridgeTaskStartedEvent)
+
+	// Preempt the task the way the adaptive lead does: return it to the
+	// queue, then announce it.
+	restarted := make(chan event.BridgeTaskStartedEvent, 1)
+	subID := bus.Subscribe("bridge.task_started", func(e event.Event) {
+		restarted <- e.(event.BridgeTaskStartedEvent)
+	})
+	defer bus.Unsubscribe(subID)
+	if err := tt.Hub().EventQueue().Preempt("docs"); err != nil {
+		t.Fatalf("Preempt: %v", err)
+	}
+	bus.Publish(event.NewTaskPreemptedEvent("docs", "bridge-test-team", "urgent", "priority"))
+
+	select {
+	case id := <-factory.paused:
+		if id != started.InstanceID {
+			t.Errorf("paused %q, want %q", id, started.InstanceID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("preempted instance was not paused")
+	}
+
+	// The freed slot lets the bridge claim again.
+	select {
+	case e := <-restarted:
+		if e.TaskID != "docs" {
+			t.Errorf("restarted task = %q, want docs", e.TaskID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("slot was not freed after preemption")
+	}
+}
+
+func TestBridge_RemovedTaskFreesSlot(t *testing.T) {
+	bus := event.NewBus()
+	tasks := []ultraplan.PlannedTask{
+		{ID: "docs", Title: "Docs", Description: "Write docs", Files: []string{"README.md"}},
+		{ID: "lint", Title: "Lint", Description: "Fix lint", Files: []string{"main.go"}, Priority: 1},
+	}
+	tt := newTestTeam(t, bus, tasks)
+
+	recorder := newMockRecorder()
+	b := bridge.New(tt, newMockFactory(), newMockChecker(), recorder, bus,
+		bridge.WithPollInterval(10*time.Millisecond),
+		bridge.WithMaxConcurrency(1),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := b.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer b.Stop()
+
+	started := waitForEvent(t, bus, "bridge.task_started", 2*time.Second).(event.BridgeTaskStartedEvent)
+	if started.TaskID != "docs" {
+		t.Fatalf("started %q, want docs", started.TaskID)
+	}
+
+	next := make(chan event.BridgeTaskStartedEvent, 1)
+	subID := bus.Subscribe("bridge.task_started", func(e event.Event) {
+		next <- e.(event.BridgeTaskStartedEvent)
+	})
+	defer bus.Unsubscribe(subID)
+	if err := tt.Hub().EventQueue().RemoveTask("docs"); err != nil {
+		t.Fatalf("RemoveTask: %v", err)
+	}
+
+	// The freed slot goes to the next task, and the removed one has no outcome.
+	select {
+	case e := <-next:
+		if e.TaskID != "lint" {
+			t.Errorf("next task = %q, want lint", e.TaskID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("slot was not freed after removal")
+	}
+	if _, ok := b.Running()["docs"]; ok {
+		t.Error("removed task is still tracked as running")
+	}
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	if _, ok := recorder.failed["docs"]; ok {
+		t.Error("removed task was recorded as failed")
+	}
+}
+
+func TestBridge_WorkStealing(t *testing.T) {
+	bus := event.NewBus()
+	mgr, err := team.NewManager(team.ManagerConfig{
+		Bus:     bus,
+		BaseDir: t.TempDir(),
+	}, team.WithHubOptions(coordination.WithRebalanceInterval(-1)))
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	for _, spec := range []team.Spec{
+		{ID: "idle", Name: "Idle", Role: team.RoleExecution, TeamSize: 1,
+			Tasks: []ultraplan.PlannedTask{{ID: "i1", Title: "Own task", Description: "d"}}},
+		{ID: "busy", Name: "Busy", Role: team.RoleExecution, TeamSize: 1,
+			Tasks: []ultraplan.PlannedTask{{ID: "b1", Title: "Other task", Description: "d"}}},
+	} {
+		if err := mgr.AddTeam(spec); err != nil {
+			t.Fatalf("AddTeam(%s): %v", spec.ID, err)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := mgr.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer func() { _ = mgr.Stop() }()
+
+	started := make(chan event.BridgeTaskStartedEvent, 4)
+	bus.Subscribe("bridge.task_started", func(e event.Event) {
+		started <- e.(event.BridgeTaskStartedEvent)
+	})
+	steals := make(chan event.InterTeamMessageEvent, 4)
+	bus.Subscribe("team.message", func(e event.Event) {
+		steals <- e.(event.InterTeamMessageEvent)
+	})
+	busyDone := make(chan struct{})
+	bus.Subscribe("team.completed", func(e event.Event) {
+		if e.(event.TeamCompletedEvent).TeamID == "busy" {
+			close(busyDone)
+		}
+	})
+
+	// Only the idle team has a bridge, so busy's task waits for a steal.
+	factory := newMockFactory()
+	factory.rootDir = t.TempDir()
+	checker := newMockChecker()
+	b := bridge.New(mgr.Team("idle"), factory, checker, newMockRecorder(), bus,
+		bridge.WithPollInterval(10*time.Millisecond),
+		bridge.WithMaxConcurrency(1),
+		bridge.WithWorkStealing(mgr),
+	)
+	if err := b.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	next := func() event.BridgeTaskStartedEvent {
+		t.Helper()
+		select {
+		case e := <-started:
+			return e
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for a task to start")
+			return event.BridgeTaskStartedEvent{}
+		}
+	}
+
+	if e := next(); e.TeamID != "idle" || e.TaskID != "i1" {
+		t.Fatalf("first task = %s/%s, want idle/i1", e.TeamID, e.TaskID)
+	}
+	checker.MarkComplete(filepath.Join(factory.rootDir, "wt-0"))
+
+	if e := next(); e.TeamID != "busy" || e.TaskID != "b1" {
+		t.Fatalf("second task = %s/%s, want the stolen busy/b1", e.TeamID, e.TaskID)
+	}
+	select {
+	case e := <-steals:
+		if e.FromTeam != "idle" || e.ToTeam != "busy" || e.MessageType != string(team.MessageTypeWorkSteal) {
+			t.Errorf("message = %+v, want a work_steal from idle to busy", e)
+		}
+	default:
+		t.Error("steal was not announced")
+	}
+	checker.MarkComplete(filepath.Join(factory.rootDir, "wt-1"))
+
+	// The stolen task completes in its home queue.
+	select {
+	case <-busyDone:
+	case <-time.After(2 * time.Second):
+		t.Fatal("busy team did not complete")
+	}
+	if task := mgr.Team("busy").Hub().TaskQueue().GetTask("b1"); task == nil || task.Status != taskqueue.TaskCompleted {
+		t.Errorf("b1 = %+v, want completed in busy's queue", task)
+	}
+	stopWithTimeout(t, b, 2*time.Second)
+}
+
+// headChecker is a mockChecker that implements bridge.HeadReader.
+type headChecker struct {
+	*mockChecker
+	head string
+}
+
+func (c *headChecker) HeadCommit(string) (string, error) { return c.head, nil }
+
+func TestBridge_RecordsInstanceAndHeadInCheckpoint(t *testing.T) {
+	bus := event.NewBus()
+	tt := newTestTeam(t, bus, []ultraplan.PlannedTask{{ID: "t1", Title: "Task 1", Description: "d"}})
+
+	checker := &headChecker{mockChecker: newMockChecker(), head: "abc123"}
+	b := bridge.New(tt, newMockFactory(), checker, newMockRecorder(), bus,
+		bridge.WithPollInterval(10*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := b.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer b.Stop()
+
+	started := waitForEvent(t, bus, "bridge.task_started", 2*time.Second).(event.BridgeTaskStartedEvent)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		cp := tt.Hub().TaskQueue().GetTask("t1").Checkpoint
+		if cp != nil && cp.InstanceID == started.InstanceID && cp.CommitSHA == "abc123" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("checkpoint = %+v, want instance %q at abc123", cp, started.InstanceID)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// finderFactory is a mockFactory that implements bridge.InstanceFinder.
+type finderFactory struct {
+	*mockFactory
+	alive map[string]*mockInstance
+}
+
+func (f *finderFactory) FindInstance(id string) (bridge.Instance, bool) {
+	inst, ok := f.alive[id]
+	return inst, ok
+}
+
+func TestBridge_ResumesCheckpointedTasks(t *testing.T) {
+	bus := event.NewBus()
+	tt := newTestTeam(t, bus, []ultraplan.PlannedTask{
+		{ID: "t1", Title: "Task 1", Description: "d"},
+		{ID: "t2", Title: "Task 2", Description: "d", Priority: 1},
+	})
+
+	// The state a restored queue has: both tasks claimed by the bridge of
+	// the interrupted run, one of them with an instance still running.
+	gate := tt.Hub().Gate()
+	for _, rec := range []struct{ task, inst string }{{"t1", "inst-live"}, {"t2", "inst-gone"}} {
+		task, err := gate.ClaimNext("bridge-test-team")
+		if err != nil || task == nil || task.ID != rec.task {
+			t.Fatalf("ClaimNext = %v, %v; want %s", task, err, rec.task)
+		}
+		if err := gate.RecordInstance(task.ID, rec.inst); err != nil {
+			t.Fatalf("RecordInstance: %v", err)
+		}
+	}
+	if err := gate.MarkRunning("t1"); err != nil {
+		t.Fatalf("MarkRunning: %v", err)
+	}
+
+	live := &mockInstance{id: "inst-live", worktreePath: "/tmp/wt-live", branch: "branch-live"}
+	factory := &finderFactory{mockFactory: newMockFactory(), alive: map[string]*mockInstance{"inst-live": live}}
+	checker := newMockChecker()
+	recorder := newMockRecorder()
+	b := bridge.New(tt, factory, checker, recorder, bus,
+		bridge.WithPollInterval(10*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := b.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer b.Stop()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		running := b.Running()
+		if running["t1"] == "inst-live" && running["t2"] != "" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Running() = %v, want t1 on inst-live and t2 restarted", running)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if created := factory.Created(); len(created) != 1 || !strings.Contains(created[0], "Task 2") {
+		t.Errorf("created %d instances, want only a new one for t2", len(created))
+	}
+
+	checker.MarkComplete(live.worktreePath)
+	deadline = time.Now().Add(2 * time.Second)
+	for recorder.Completed()["t1"] == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("resumed task t1 was not completed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -6,7 +6,8 @@
 // outcome back to the team's task queue.
 //
 // One Bridge is created per team. Each Bridge runs independently, claiming
-// from its own team's queue. The [bridgewire.PipelineExecutor] attaches
+// from its own team's queue; with [WithWorkStealing] it then runs ready tasks
+// from other teams' queues once its own is done. The [bridgewire.PipelineExecutor] attaches
 // Bridges to teams when the pipeline transitions to an execution phase.
 //
 // The Bridge uses narrow interfaces ([InstanceFactory], [CompletionChecker],
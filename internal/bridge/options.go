@@ -16,6 +16,12 @@ type config struct {
 	pollInterval   time.Duration
 	logger         *logging.Logger
 	maxConcurrency int
+	contracts      ContractPublisher
+	stealer        WorkStealer
+	transform      PromptTransform
+	planHash       string
+	placer         *Placer
+	taskModel      func(taskID string) string
 }
 
 // WithPollInterval sets the polling interval for completion checking.
@@ -40,3 +46,66 @@ func WithMaxConcurrency(n int) Option {
 		c.maxConcurrency = n
 	}
 }
+
+// WithContractPublisher sets the publisher used to forward a task's declared
+// contract artifacts (PlannedTask.Contracts) to dependent teams after the task
+// completes successfully. When unset, contracts are not forwarded.
+func WithContractPublisher(p ContractPublisher) Option {
+	return func(c *config) {
+		c.contracts = p
+	}
+}
+
+// WithWorkStealing lets the bridge run other teams' tasks once its own team's
+// queue is complete. Stolen tasks are claimed from the other team's queue
+// through s and reported back to it; the bridge keeps running until s reports
+// no work is left to steal. When unset, the bridge stops with its queue.
+func WithWorkStealing(s WorkStealer) Option {
+	return func(c *config) {
+		c.stealer = s
+	}
+}
+
+// PromptTransform rewrites a task prompt after the bridge builds it and
+// before the instance is created. Used by prompt experiments to apply
+// variant templates.
+type PromptTransform func(taskID, title, prompt string) string
+
+// WithPromptTransform sets a function applied to every task prompt before
+// instance creation. When unset, prompts are used as built.
+func WithPromptTransform(fn PromptTransform) Option {
+	return func(c *config) {
+		c.transform = fn
+	}
+}
+
+// WithPlanHash sets the content hash of the plan the bridge executes. When
+// set and the factory implements ReplayableInstanceFactory, task instances
+// are named from the hash, and a replayed task whose earlier run already
+// wrote its completion sentinel is verified without starting it again.
+func WithPlanHash(hash string) Option {
+	return func(c *config) {
+		c.planHash = hash
+	}
+}
+
+// WithPlacer schedules task instances onto worker nodes. Each LLM task gets a
+// slot on a node that serves its backend before its instance is created;
+// tasks wait while every eligible node is full, and fail when no node serves
+// their backend. Tool tasks run locally and are not placed. Share one Placer
+// across all bridges of a pipeline so capacity holds across teams.
+func WithPlacer(p *Placer) Option {
+	return func(c *config) {
+		c.placer = p
+	}
+}
+
+// WithTaskModel sets a function that selects the model each task attempt
+// runs on, passed to the factory as TaskInstanceSpec.Model. An empty result,
+// or leaving it unset, keeps the backend's configured model. Tool tasks run
+// no model and are not asked.
+func WithTaskModel(fn func(taskID string) string) Option {
+	return func(c *config) {
+		c.taskModel = fn
+	}
+}
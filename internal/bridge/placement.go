@@ -0,0 +1,214 @@
+package bridge
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Node is a worker node that hosts instances of a self-hosted backend, such
+// as a GPU box serving a model behind an Anthropic-compatible endpoint.
+type Node struct {
+	ID       string            // Unique node name shown in the TUI and events
+	Capacity int               // Maximum concurrent instances placed on the node
+	Backends []string          // Backends the node serves (empty = any backend)
+	Env      map[string]string // Environment an instance needs to reach the node
+}
+
+// serves reports whether the node can host an instance of backend. An empty
+// backend means the session default and matches any node.
+func (n Node) serves(backend string) bool {
+	if len(n.Backends) == 0 || backend == "" {
+		return true
+	}
+	return slices.ContainsFunc(n.Backends, func(b string) bool {
+		return strings.EqualFold(b, backend)
+	})
+}
+
+// PlacementPolicy selects a node among those with free capacity.
+type PlacementPolicy string
+
+const (
+	// PlacementSpread places each instance on the least-loaded node, relative
+	// to capacity, so load is balanced across the inventory.
+	PlacementSpread PlacementPolicy = "spread"
+
+	// PlacementPack places each instance on the most-loaded node that still
+	// has room, keeping other nodes free for large bursts or other sessions.
+	PlacementPack PlacementPolicy = "pack"
+)
+
+// ValidPlacementPolicies returns the placement policy names.
+func ValidPlacementPolicies() []string {
+	return []string{string(PlacementSpread), string(PlacementPack)}
+}
+
+var (
+	// ErrNoCapacity means every node that serves the backend is full. The
+	// task can be placed once a running instance on one of them finishes.
+	ErrNoCapacity = errors.New("bridge: no node has free capacity")
+
+	// ErrNoEligibleNode means no node in the inventory serves the backend,
+	// so the task can never be placed.
+	ErrNoEligibleNode = errors.New("bridge: no node serves backend")
+)
+
+// NodeLoad is a snapshot of one node's placement state.
+type NodeLoad struct {
+	NodeID   string
+	Capacity int
+	InUse    int
+}
+
+// Placer assigns task instances to worker nodes within their capacity. A
+// single Placer is shared by every bridge in a pipeline so capacity is
+// enforced across teams. It is safe for concurrent use.
+type Placer struct {
+	policy PlacementPolicy
+
+	mu       sync.Mutex
+	nodes    []Node
+	inUse    map[string]int    // nodeID → placed instances
+	assigned map[string]string // taskID → nodeID
+	freed    chan struct{}     // closed and replaced whenever a slot is released
+}
+
+// NewPlacer creates a Placer over the given node inventory. Nodes without an
+// ID or with a non-positive capacity are rejected, as are duplicate IDs. An
+// empty policy defaults to PlacementSpread.
+func NewPlacer(nodes []Node, policy PlacementPolicy) (*Placer, error) {
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("bridge: placement requires at least one node")
+	}
+	switch policy {
+	case "":
+		policy = PlacementSpread
+	case PlacementSpread, PlacementPack:
+	default:
+		return nil, fmt.Errorf("bridge: unknown placement policy %q", policy)
+	}
+
+	seen := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		if n.ID == "" {
+			return nil, fmt.Errorf("bridge: placement node requires an ID")
+		}
+		if seen[n.ID] {
+			return nil, fmt.Errorf("bridge: duplicate placement node %q", n.ID)
+		}
+		if n.Capacity <= 0 {
+			return nil, fmt.Errorf("bridge: placement node %q requires a positive capacity", n.ID)
+		}
+		seen[n.ID] = true
+	}
+
+	return &Placer{
+		policy:   policy,
+		nodes:    slices.Clone(nodes),
+		inUse:    make(map[string]int, len(nodes)),
+		assigned: make(map[string]string),
+		freed:    make(chan struct{}),
+	}, nil
+}
+
+// Place reserves a slot for taskID on a node that serves backend and returns
+// the node. Placing a task that already holds a slot returns its node again.
+// The error matches ErrNoEligibleNode or ErrNoCapacity when no node fits.
+func (p *Placer) Place(taskID, backend string) (Node, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if nodeID, ok := p.assigned[taskID]; ok {
+		return p.nodeLocked(nodeID), nil
+	}
+
+	best := -1
+	eligible := false
+	for i, n := range p.nodes {
+		if !n.serves(backend) {
+			continue
+		}
+		eligible = true
+		if p.inUse[n.ID] >= n.Capacity {
+			continue
+		}
+		if best < 0 || p.better(n, p.nodes[best]) {
+			best = i
+		}
+	}
+
+	if !eligible {
+		return Node{}, fmt.Errorf("%w %q", ErrNoEligibleNode, backend)
+	}
+	if best < 0 {
+		return Node{}, ErrNoCapacity
+	}
+
+	n := p.nodes[best]
+	p.inUse[n.ID]++
+	p.assigned[taskID] = n.ID
+	return n, nil
+}
+
+// better reports whether candidate should be preferred over current under
+// the placement policy. Ties keep the earlier node in the inventory.
+func (p *Placer) better(candidate, current Node) bool {
+	// Compare load fractions without division: a/b < c/d ⇔ a*d < c*b.
+	cand := p.inUse[candidate.ID] * current.Capacity
+	cur := p.inUse[current.ID] * candidate.Capacity
+	if p.policy == PlacementPack {
+		return cand > cur
+	}
+	return cand < cur
+}
+
+// Release frees the slot held by taskID. Releasing a task without a slot is a no-op.
+func (p *Placer) Release(taskID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	nodeID, ok := p.assigned[taskID]
+	if !ok {
+		return
+	}
+	delete(p.assigned, taskID)
+	if p.inUse[nodeID] > 0 {
+		p.inUse[nodeID]--
+	}
+	close(p.freed)
+	p.freed = make(chan struct{})
+}
+
+// capacityFreed returns a channel that is closed the next time any slot is
+// released. Fetch it before calling Place so a release between a failed
+// placement and the wait is not missed.
+func (p *Placer) capacityFreed() <-chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.freed
+}
+
+// Load returns the current placement state of every node, in inventory order.
+func (p *Placer) Load() []NodeLoad {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := make([]NodeLoad, len(p.nodes))
+	for i, n := range p.nodes {
+		out[i] = NodeLoad{NodeID: n.ID, Capacity: n.Capacity, InUse: p.inUse[n.ID]}
+	}
+	return out
+}
+
+// nodeLocked returns the node with the given ID. Must be called with p.mu held.
+func (p *Placer) nodeLocked(id string) Node {
+	for _, n := range p.nodes {
+		if n.ID == id {
+			return n
+		}
+	}
+	return Node{}
+}
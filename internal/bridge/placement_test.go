@@ -0,0 +1,148 @@
+package bridge
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewPlacer_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		nodes  []Node
+		policy PlacementPolicy
+	}{
+		{"no nodes", nil, PlacementSpread},
+		{"missing ID", []Node{{Capacity: 1}}, PlacementSpread},
+		{"zero capacity", []Node{{ID: "gpu-1"}}, PlacementSpread},
+		{"duplicate ID", []Node{{ID: "gpu-1", Capacity: 1}, {ID: "gpu-1", Capacity: 2}}, PlacementSpread},
+		{"unknown policy", []Node{{ID: "gpu-1", Capacity: 1}}, "random"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewPlacer(tt.nodes, tt.policy); err == nil {
+				t.Error("NewPlacer() expected error, got nil")
+			}
+		})
+	}
+
+	p, err := NewPlacer([]Node{{ID: "gpu-1", Capacity: 1}}, "")
+	if err != nil {
+		t.Fatalf("NewPlacer() error = %v", err)
+	}
+	if p.policy != PlacementSpread {
+		t.Errorf("default policy = %q, want %q", p.policy, PlacementSpread)
+	}
+}
+
+func TestPlacer_Spread(t *testing.T) {
+	p, err := NewPlacer([]Node{
+		{ID: "a", Capacity: 2},
+		{ID: "b", Capacity: 2},
+	}, PlacementSpread)
+	if err != nil {
+		t.Fatalf("NewPlacer() error = %v", err)
+	}
+
+	var got []string
+	for _, task := range []string{"t1", "t2", "t3", "t4"} {
+		n, err := p.Place(task, "")
+		if err != nil {
+			t.Fatalf("Place(%s) error = %v", task, err)
+		}
+		got = append(got, n.ID)
+	}
+	want := []string{"a", "b", "a", "b"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("placements = %v, want %v", got, want)
+		}
+	}
+
+	if _, err := p.Place("t5", ""); !errors.Is(err, ErrNoCapacity) {
+		t.Errorf("Place() on full inventory error = %v, want ErrNoCapacity", err)
+	}
+}
+
+func TestPlacer_Pack(t *testing.T) {
+	p, err := NewPlacer([]Node{
+		{ID: "a", Capacity: 2},
+		{ID: "b", Capacity: 2},
+	}, PlacementPack)
+	if err != nil {
+		t.Fatalf("NewPlacer() error = %v", err)
+	}
+
+	var got []string
+	for _, task := range []string{"t1", "t2", "t3"} {
+		n, err := p.Place(task, "")
+		if err != nil {
+			t.Fatalf("Place(%s) error = %v", task, err)
+		}
+		got = append(got, n.ID)
+	}
+	if got[0] != "a" || got[1] != "a" || got[2] != "b" {
+		t.Errorf("placements = %v, want [a a b]", got)
+	}
+}
+
+func TestPlacer_Backends(t *testing.T) {
+	p, err := NewPlacer([]Node{
+		{ID: "gpu-1", Capacity: 1, Backends: []string{"vllm"}},
+		{ID: "any", Capacity: 1},
+	}, PlacementSpread)
+	if err != nil {
+		t.Fatalf("NewPlacer() error = %v", err)
+	}
+
+	n, err := p.Place("t1", "VLLM")
+	if err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	if n.ID != "gpu-1" {
+		t.Errorf("Place() node = %q, want gpu-1", n.ID)
+	}
+
+	restricted, err := NewPlacer([]Node{{ID: "gpu-1", Capacity: 1, Backends: []string{"vllm"}}}, PlacementSpread)
+	if err != nil {
+		t.Fatalf("NewPlacer() error = %v", err)
+	}
+	if _, err := restricted.Place("t1", "claude"); !errors.Is(err, ErrNoEligibleNode) {
+		t.Errorf("Place() error = %v, want ErrNoEligibleNode", err)
+	}
+}
+
+func TestPlacer_ReleaseAndIdempotentPlace(t *testing.T) {
+	p, err := NewPlacer([]Node{{ID: "a", Capacity: 1}}, PlacementSpread)
+	if err != nil {
+		t.Fatalf("NewPlacer() error = %v", err)
+	}
+
+	if _, err := p.Place("t1", ""); err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	// Re-placing the same task returns its existing slot.
+	if n, err := p.Place("t1", ""); err != nil || n.ID != "a" {
+		t.Fatalf("Place() again = (%q, %v), want (a, nil)", n.ID, err)
+	}
+	if load := p.Load(); load[0].InUse != 1 {
+		t.Errorf("InUse = %d, want 1", load[0].InUse)
+	}
+
+	freed := p.capacityFreed()
+	p.Release("t1")
+	select {
+	case <-freed:
+	default:
+		t.Error("capacityFreed channel not closed on Release")
+	}
+	if load := p.Load(); load[0].InUse != 0 {
+		t.Errorf("InUse after release = %d, want 0", load[0].InUse)
+	}
+
+	// Releasing an unplaced task is a no-op.
+	p.Release("unknown")
+	if _, err := p.Place("t2", ""); err != nil {
+		t.Errorf("Place() after release error = %v", err)
+	}
+}
@@ -1,5 +1,11 @@
 package bridge
 
+import (
+	"github.com/Iron-Ham/claudio/internal/mailbox"
+	"github.com/Iron-Ham/claudio/internal/taskqueue"
+	"github.com/Iron-Ham/claudio/internal/team"
+)
+
 // InstanceFactory creates and starts Claude Code instances.
 type InstanceFactory interface {
 	// CreateInstance creates a new instance (worktree + branch) for the given task prompt.
@@ -10,6 +16,71 @@ type InstanceFactory interface {
 	StartInstance(inst Instance) error
 }
 
+// BackendInstanceFactory is an optional InstanceFactory extension for plans
+// that route individual tasks to a non-default backend, such as the "tool"
+// runner for scripted codemods. A task that selects a backend fails when the
+// bridge's factory does not implement it.
+type BackendInstanceFactory interface {
+	// CreateInstanceWithBackend creates an instance that runs the named backend.
+	CreateInstanceWithBackend(taskPrompt, backend string) (Instance, error)
+}
+
+// TaskInstanceSpec identifies the instance a bridge needs for one attempt at
+// a plan task.
+type TaskInstanceSpec struct {
+	PlanHash string // Content hash of the plan being executed
+	TaskID   string // Plan task ID
+	Attempt  int    // Zero-based retry attempt
+	Prompt   string // Task prompt (a script for tool tasks)
+	Backend  string // Task-selected backend ("" = session default)
+	Model    string // Model for the attempt ("" = backend default)
+	Node     *Node  // Worker node the instance must run on (nil = unplaced)
+}
+
+// ReplayableInstanceFactory is an optional InstanceFactory extension that
+// names task instances deterministically from the plan hash, task ID, and
+// attempt. It is used when the bridge is configured with WithPlanHash, so
+// replaying a plan after a partial failure reuses earlier work instead of
+// creating duplicate instances and branches.
+type ReplayableInstanceFactory interface {
+	// CreateTaskInstance creates the instance for spec, or reattaches to the
+	// branch and worktree a previous run left for the same task attempt.
+	// reused reports whether existing work was adopted.
+	CreateTaskInstance(spec TaskInstanceSpec) (inst Instance, reused bool, err error)
+}
+
+// PlacedInstanceFactory is an optional InstanceFactory extension for
+// self-hosted backends whose instances run against specific worker nodes.
+// It is required when the bridge is configured with WithPlacer.
+type PlacedInstanceFactory interface {
+	// CreatePlacedInstance creates the instance for spec so that it runs on
+	// spec.Node, applying the node's environment when the instance starts.
+	// When spec.PlanHash is set it names and reattaches the instance like
+	// ReplayableInstanceFactory.CreateTaskInstance.
+	CreatePlacedInstance(spec TaskInstanceSpec) (inst Instance, reused bool, err error)
+}
+
+// InstancePauser is an optional InstanceFactory extension for preemption.
+// When the adaptive lead preempts a running task, the bridge pauses the
+// task's instance through it; without it, the instance is left running and
+// only its slot is freed.
+type InstancePauser interface {
+	// PauseInstance stops the instance's backend but keeps its worktree and
+	// branch, so a later attempt at the task can pick up its work.
+	PauseInstance(inst Instance) error
+}
+
+// InstanceFinder is an optional InstanceFactory extension for resuming a
+// session. When the team's queue was restored with claimed tasks, the
+// bridge looks up the instance recorded in each task's checkpoint and
+// resumes monitoring the ones still running; without it, every restored
+// claim is released and its task starts again.
+type InstanceFinder interface {
+	// FindInstance returns the instance with the given ID and whether its
+	// backend is still running.
+	FindInstance(id string) (inst Instance, alive bool)
+}
+
 // Instance represents a running (or created) Claude Code backend.
 type Instance interface {
 	// ID returns the unique instance identifier.
@@ -31,6 +102,33 @@ type CompletionChecker interface {
 	VerifyWork(taskID, instanceID, worktreePath, baseBranch string) (success bool, commitCount int, err error)
 }
 
+// ToolWorkVerifier is an optional CompletionChecker extension for tasks run
+// by a deterministic, non-LLM executor. Their completion report decides the
+// outcome, even when commit verification is disabled. Without it, the bridge
+// verifies tool tasks with VerifyWork.
+type ToolWorkVerifier interface {
+	// VerifyToolWork validates the output of a tool task.
+	VerifyToolWork(taskID, instanceID, worktreePath, baseBranch string) (success bool, commitCount int, err error)
+}
+
+// HeadReader is an optional CompletionChecker extension that reads the
+// commit an instance's worktree is at. The bridge records it in the task's
+// checkpoint while the instance works, so a resumed session knows how far
+// the task got.
+type HeadReader interface {
+	// HeadCommit returns the SHA of the worktree's HEAD commit.
+	HeadCommit(worktreePath string) (string, error)
+}
+
+// TaskProposalReader is an optional CompletionChecker extension that reads
+// the follow-up tasks an instance proposed in its completion report. The
+// bridge forwards them to the coordinator through the mailbox.
+type TaskProposalReader interface {
+	// ReadTaskProposals returns the tasks proposed in the worktree's
+	// completion report.
+	ReadTaskProposals(worktreePath string) ([]mailbox.TaskProposal, error)
+}
+
 // SessionRecorder keeps session state in sync with bridge operations.
 type SessionRecorder interface {
 	// AssignTask records that a task has been assigned to an instance.
@@ -46,3 +144,22 @@ type SessionRecorder interface {
 	// RecordFailure records a task failure with the given reason.
 	RecordFailure(taskID, reason string)
 }
+
+// ContractPublisher forwards contract artifacts produced by a team to the
+// teams that depend on it. team.Manager satisfies this interface.
+type ContractPublisher interface {
+	// PublishContracts delivers the artifacts to every direct dependent of fromTeam.
+	PublishContracts(fromTeam string, artifacts []team.ContractArtifact) error
+}
+
+// WorkStealer finds work in other teams' queues for a bridge whose own team
+// has finished its tasks. team.Manager satisfies this interface.
+type WorkStealer interface {
+	// StealTask claims a ready task from another team's queue for thiefID
+	// under claimID and returns it with the team whose queue it belongs to.
+	// The task is nil when there is nothing to steal.
+	StealTask(thiefID, claimID string) (*team.Team, *taskqueue.QueuedTask, error)
+
+	// CanSteal reports whether another team may still have work for thiefID.
+	CanSteal(thiefID string) bool
+}
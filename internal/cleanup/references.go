@@ -0,0 +1,72 @@
+package cleanup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/Iron-Ham/claudio/internal/session"
+)
+
+// References holds what the session files of a repository still point at.
+// A worktree, branch or tmux session whose instance no session file
+// references was left behind by a session that crashed or was removed.
+type References struct {
+	Sessions  map[string]bool // IDs of sessions with a session file
+	Instances map[string]bool // IDs of the instances in those sessions
+}
+
+// referencedSession is the part of a session file References reads.
+type referencedSession struct {
+	ID        string `json:"id"`
+	Instances []struct {
+		ID string `json:"id"`
+	} `json:"instances"`
+}
+
+// LoadReferences reads every session file under baseDir/.claudio, the legacy
+// single-session file included. It fails on a session file it cannot parse,
+// since treating that session's resources as unreferenced could delete live
+// work.
+func LoadReferences(baseDir string) (*References, error) {
+	refs := &References{
+		Sessions:  make(map[string]bool),
+		Instances: make(map[string]bool),
+	}
+
+	files := []string{filepath.Join(baseDir, ".claudio", session.SessionFileName)}
+	entries, err := os.ReadDir(session.GetSessionsDir(baseDir))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			files = append(files, filepath.Join(session.GetSessionsDir(baseDir), entry.Name(), session.SessionFileName))
+		}
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		var sess referencedSession
+		if err := json.Unmarshal(data, &sess); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		if sess.ID != "" {
+			refs.Sessions[sess.ID] = true
+		}
+		for _, inst := range sess.Instances {
+			refs.Instances[inst.ID] = true
+		}
+	}
+
+	return refs, nil
+}
@@ -0,0 +1,65 @@
+package cleanup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/session"
+)
+
+func writeSessionFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadReferences(t *testing.T) {
+	baseDir := t.TempDir()
+
+	refs, err := LoadReferences(baseDir)
+	if err != nil {
+		t.Fatalf("LoadReferences() with no sessions error = %v", err)
+	}
+	if len(refs.Sessions) != 0 || len(refs.Instances) != 0 {
+		t.Errorf("LoadReferences() with no sessions = %+v, want empty", refs)
+	}
+
+	writeSessionFile(t, filepath.Join(baseDir, ".claudio", session.SessionFileName),
+		`{"id":"legacy","instances":[{"id":"inst-legacy"}]}`)
+	writeSessionFile(t, filepath.Join(session.GetSessionDir(baseDir, "sess-a"), session.SessionFileName),
+		`{"id":"sess-a","instances":[{"id":"inst-a1"},{"id":"inst-a2"}]}`)
+	// A session directory without a session file references nothing
+	if err := os.MkdirAll(session.GetSessionDir(baseDir, "sess-empty"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	refs, err = LoadReferences(baseDir)
+	if err != nil {
+		t.Fatalf("LoadReferences() error = %v", err)
+	}
+	for _, id := range []string{"legacy", "sess-a"} {
+		if !refs.Sessions[id] {
+			t.Errorf("session %s not referenced", id)
+		}
+	}
+	for _, id := range []string{"inst-legacy", "inst-a1", "inst-a2"} {
+		if !refs.Instances[id] {
+			t.Errorf("instance %s not referenced", id)
+		}
+	}
+	if len(refs.Instances) != 3 {
+		t.Errorf("referenced instances = %v, want 3", refs.Instances)
+	}
+
+	t.Run("corrupt session file", func(t *testing.T) {
+		writeSessionFile(t, filepath.Join(session.GetSessionDir(baseDir, "sess-bad"), session.SessionFileName), `{"id":`)
+		if _, err := LoadReferences(baseDir); err == nil {
+			t.Error("LoadReferences() should fail on a session file it cannot parse")
+		}
+	})
+}
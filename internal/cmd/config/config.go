@@ -207,9 +207,9 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		}
 		typedValue = value
 	case "backend":
-		if !slices.Contains(appconfig.ValidAIBackends(), value) {
+		if backends := appconfig.Get().AI.BackendNames(); !slices.Contains(backends, value) {
 			return fmt.Errorf("invalid value for %s: %s\nValid options: %s",
-				key, value, strings.Join(appconfig.ValidAIBackends(), ", "))
+				key, value, strings.Join(backends, ", "))
 		}
 		typedValue = value
 	case "theme":
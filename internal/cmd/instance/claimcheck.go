@@ -0,0 +1,78 @@
+package instance
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/Iron-Ham/claudio/internal/filelock"
+	"github.com/spf13/cobra"
+)
+
+var claimCheckCmd = &cobra.Command{
+	Use:   "claim-check",
+	Short: "Check staged files against other instances' file claims",
+	Long: `Reads NUL-separated file paths from stdin (as printed by
+"git diff --cached --name-only -z") and exits non-zero if another instance
+has claimed any of them. Run by the pre-commit hook installed when
+ultraplan.enforce_file_claims is enabled; set ` + filelock.OverrideEnv + `=1 to
+commit anyway.
+
+If the claim server cannot be reached (the session has ended), the check
+passes with a warning.`,
+	Args:   cobra.NoArgs,
+	Hidden: true,
+	RunE:   runClaimCheck,
+}
+
+var (
+	claimCheckSocket string
+	claimCheckOwner  string
+)
+
+func init() {
+	claimCheckCmd.Flags().StringVar(&claimCheckSocket, "socket", "", "Claim server socket path")
+	claimCheckCmd.Flags().StringVar(&claimCheckOwner, "owner", "", "Claim owner the commit is made as")
+	_ = claimCheckCmd.MarkFlagRequired("socket")
+	_ = claimCheckCmd.MarkFlagRequired("owner")
+}
+
+// RegisterClaimCheckCmd registers the claim-check command with the given parent command.
+func RegisterClaimCheckCmd(parent *cobra.Command) {
+	parent.AddCommand(claimCheckCmd)
+}
+
+func runClaimCheck(cmd *cobra.Command, _ []string) error {
+	input, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return fmt.Errorf("failed to read staged files: %w", err)
+	}
+	var paths []string
+	for p := range bytes.SplitSeq(input, []byte{0}) {
+		if p = bytes.TrimSpace(p); len(p) > 0 {
+			paths = append(paths, string(p))
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	conflicts, err := filelock.Check(claimCheckSocket, claimCheckOwner, paths)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "claudio: skipping file claim check: %v\n", err)
+		return nil
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	errOut := cmd.ErrOrStderr()
+	fmt.Fprintln(errOut, "claudio: commit touches files claimed by other instances:")
+	for _, c := range conflicts {
+		fmt.Fprintf(errOut, "  %s (claimed by %s)\n", c.Path, c.Owner)
+	}
+	fmt.Fprintf(errOut, "Leave these files to their owners, or set %s=1 to commit anyway.\n", filelock.OverrideEnv)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return fmt.Errorf("commit blocked by %d claimed file(s)", len(conflicts))
+}
@@ -10,4 +10,5 @@ func Register(parent *cobra.Command) {
 	RegisterRemoveCmd(parent)
 	RegisterStatusCmd(parent)
 	RegisterStatsCmd(parent)
+	RegisterClaimCheckCmd(parent)
 }
@@ -3,12 +3,15 @@ package instance
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/Iron-Ham/claudio/internal/config"
 	instmetrics "github.com/Iron-Ham/claudio/internal/instance/metrics"
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/stats"
 	"github.com/spf13/cobra"
 )
 
@@ -122,6 +125,8 @@ func printStatsText(session *orchestrator.Session, metrics *orchestrator.Session
 	}
 	fmt.Println()
 
+	printBootstrapStats(session)
+
 	// Per-instance breakdown
 	fmt.Println("TOP INSTANCES BY COST")
 	fmt.Println(strings.Repeat("─", 50))
@@ -179,6 +184,57 @@ func printStatsText(session *orchestrator.Session, metrics *orchestrator.Session
 	return nil
 }
 
+// printBootstrapStats summarizes worktree bootstrap timings for the session,
+// alongside the historical average from the stats store, so the startup cost
+// of bootstrapping can be compared with a cold build. Prints nothing when no
+// instance in the session was bootstrapped.
+func printBootstrapStats(session *orchestrator.Session) {
+	var count, failed int
+	var linkMs, commandMs int64
+	for _, inst := range session.Instances {
+		if inst.Bootstrap == nil {
+			continue
+		}
+		count++
+		linkMs += inst.Bootstrap.LinkMs
+		commandMs += inst.Bootstrap.CommandMs
+		if inst.Bootstrap.Error != "" {
+			failed++
+		}
+	}
+	if count == 0 {
+		return
+	}
+
+	fmt.Println("WORKTREE BOOTSTRAP")
+	fmt.Println(strings.Repeat("─", 50))
+	fmt.Printf("Bootstrapped: %d instance(s)", count)
+	if failed > 0 {
+		fmt.Printf(" (%d failed)", failed)
+	}
+	fmt.Println()
+	fmt.Printf("Avg link time:    %s\n", formatMs(linkMs/int64(count)))
+	fmt.Printf("Avg command time: %s\n", formatMs(commandMs/int64(count)))
+
+	if cwd, err := os.Getwd(); err == nil {
+		records, err := stats.NewStore(filepath.Join(cwd, ".claudio")).Query(orchestrator.BootstrapRecordKind, nil)
+		if err == nil && len(records) > count {
+			var total float64
+			for _, r := range records {
+				total += r.Values["link_seconds"] + r.Values["command_seconds"]
+			}
+			avg := time.Duration(total / float64(len(records)) * float64(time.Second))
+			fmt.Printf("All sessions:     %d bootstrap(s), avg %s\n", len(records), avg.Round(time.Millisecond))
+		}
+	}
+	fmt.Println()
+}
+
+// formatMs formats a millisecond count as a rounded duration.
+func formatMs(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).Round(time.Millisecond).String()
+}
+
 func printStatsJSON(session *orchestrator.Session, metrics *orchestrator.SessionMetrics, cfg *config.Config) error {
 	// Build JSON output manually to control formatting
 	fmt.Printf(`{
@@ -221,11 +277,15 @@ func printStatsJSON(session *orchestrator.Session, metrics *orchestrator.Session
 		cost := 0.0
 		inputTokens := int64(0)
 		outputTokens := int64(0)
+		bootstrapMs := int64(0)
 		if inst.Metrics != nil {
 			cost = inst.Metrics.Cost
 			inputTokens = inst.Metrics.InputTokens
 			outputTokens = inst.Metrics.OutputTokens
 		}
+		if inst.Bootstrap != nil {
+			bootstrapMs = inst.Bootstrap.Duration().Milliseconds()
+		}
 		fmt.Printf(`
     {
       "id": "%s",
@@ -233,7 +293,8 @@ func printStatsJSON(session *orchestrator.Session, metrics *orchestrator.Session
       "status": "%s",
       "input_tokens": %d,
       "output_tokens": %d,
-      "cost": %.4f
+      "cost": %.4f,
+      "bootstrap_ms": %d
     }`,
 			inst.ID,
 			strings.ReplaceAll(inst.Task, `"`, `\"`),
@@ -241,6 +302,7 @@ func printStatsJSON(session *orchestrator.Session, metrics *orchestrator.Session
 			inputTokens,
 			outputTokens,
 			cost,
+			bootstrapMs,
 		)
 		if i < len(session.Instances)-1 {
 			fmt.Print(",")
@@ -0,0 +1,177 @@
+package observability
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/audit"
+	"github.com/Iron-Ham/claudio/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditSessionID string
+	auditFormat    string
+	auditOutput    string
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Export the log of human interventions and orchestrator decisions in a session",
+	Long: `Export a session's audit log: every human intervention and every
+decision the orchestrator made, with its time, who acted, and the affected
+instance or plan task. Decisions also record the reason and the inputs that
+led to them.
+
+Operator actions:
+  input         Text submitted to an instance in input mode, and interrupt keys
+  approve       Plan and synthesis approvals
+  reject        Plan change requests sent back to the planner
+  override      Commands that change the session (:start, :exit, :kill, :restart,
+                :remove, :cancel, :pr, ...) and ultraplan recovery decisions
+  task_edit     Plan editor changes to tasks before execution
+  auto_approve  Plans and gated tasks started without review by policy
+
+Orchestrator decisions (actor "orchestrator"):
+  task_started       A task's instance was started once its dependencies completed
+  task_retried       A task was retried after failing verification
+  task_failed        A task failed or was given up on
+  task_reassigned    A task was moved to another instance by adaptive lead
+  task_preempted     A task was paused by adaptive lead for a more urgent one
+  scale              Instances were added or removed by a scaling policy
+  nudge              A stalled instance was sent a nudge
+  conflict_resolved  The merge queue or a conflict resolver resolved conflicts during consolidation
+
+The log is kept at .claudio/sessions/<id>/audit.jsonl.
+
+Examples:
+  # Show the audit log of the only session
+  claudio audit
+
+  # Export a session's log as CSV for a change record
+  claudio audit -s abc123 --format csv -o audit.csv`,
+	Args: cobra.NoArgs,
+	RunE: runAudit,
+}
+
+func init() {
+	auditCmd.Flags().StringVarP(&auditSessionID, "session", "s", "", "Session ID (default: the only session with an audit log)")
+	auditCmd.Flags().StringVarP(&auditFormat, "format", "f", "text", "Output format: text, json, or csv")
+	auditCmd.Flags().StringVarP(&auditOutput, "output", "o", "", "Write the log to this file (default: stdout)")
+}
+
+// RegisterAuditCmd registers the audit command with the given parent command.
+func RegisterAuditCmd(parent *cobra.Command) {
+	parent.AddCommand(auditCmd)
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	switch auditFormat {
+	case "text", "json", "csv":
+	default:
+		return fmt.Errorf("unknown format %q (want text, json, or csv)", auditFormat)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	sessionDir, err := findAuditedSession(cwd, auditSessionID)
+	if err != nil {
+		return err
+	}
+	entries, err := audit.NewLog(sessionDir).Entries()
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = cmd.OutOrStdout()
+	if auditOutput != "" {
+		f, err := os.Create(auditOutput)
+		if err != nil {
+			return fmt.Errorf("create output file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		out = f
+	}
+
+	switch auditFormat {
+	case "json":
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if entries == nil {
+			entries = []audit.Entry{}
+		}
+		return enc.Encode(entries)
+	case "csv":
+		return audit.WriteCSV(out, entries)
+	}
+	printAuditLog(out, entries)
+	return nil
+}
+
+// findAuditedSession returns the directory of the session with the given ID,
+// or of the only session with an audit log when id is empty.
+func findAuditedSession(baseDir, id string) (string, error) {
+	if id != "" {
+		if !session.SessionExists(baseDir, id) {
+			return "", fmt.Errorf("session %s not found", id)
+		}
+		return session.GetSessionDir(baseDir, id), nil
+	}
+
+	sessions, err := session.ListSessions(baseDir)
+	if err != nil {
+		return "", err
+	}
+	var audited []*session.Info
+	for _, s := range sessions {
+		if _, err := os.Stat(audit.NewLog(s.SessionDir).Path()); err == nil {
+			audited = append(audited, s)
+		}
+	}
+	switch len(audited) {
+	case 0:
+		return "", fmt.Errorf("no session in %s has an audit log", baseDir)
+	case 1:
+		return audited[0].SessionDir, nil
+	}
+	ids := make([]string, len(audited))
+	for i, s := range audited {
+		ids[i] = s.ID
+	}
+	return "", fmt.Errorf("%d sessions have audit logs (%s); choose one with --session", len(audited), strings.Join(ids, ", "))
+}
+
+// printAuditLog writes entries as a table.
+func printAuditLog(w io.Writer, entries []audit.Entry) {
+	if len(entries) == 0 {
+		_, _ = fmt.Fprintln(w, "No actions or decisions recorded.")
+		return
+	}
+	_, _ = fmt.Fprintf(w, "%-19s %-17s %-12s %-12s %-12s %s\n", "TIME", "ACTION", "ACTOR", "INSTANCE", "TASK", "DETAIL")
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", 105))
+	for _, e := range entries {
+		_, _ = fmt.Fprintf(w, "%-19s %-17s %-12s %-12s %-12s %s\n",
+			e.Time.Local().Format("2006-01-02 15:04:05"), e.Action,
+			orDash(e.Actor), orDash(e.InstanceID), orDash(e.TaskID),
+			strings.ReplaceAll(e.Detail, "\n", " "))
+		if e.Reason != "" {
+			_, _ = fmt.Fprintf(w, "%20s reason: %s\n", "", strings.ReplaceAll(e.Reason, "\n", " "))
+		}
+		if len(e.Inputs) > 0 {
+			_, _ = fmt.Fprintf(w, "%20s inputs: %s\n", "", audit.FormatInputs(e.Inputs))
+		}
+	}
+}
+
+// orDash returns s, or "-" when it is empty.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
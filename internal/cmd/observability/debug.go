@@ -0,0 +1,197 @@
+package observability
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/diag"
+	"github.com/Iron-Ham/claudio/internal/session"
+	"github.com/spf13/cobra"
+)
+
+// profileResponseGrace is how long to wait for a bundle beyond the CPU
+// profile itself, for the heap profile, goroutine dump, and compression.
+const profileResponseGrace = 30 * time.Second
+
+var (
+	profileSessionID string
+	profileDuration  time.Duration
+	profileOutput    string
+	profileTop       int
+)
+
+var debugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Diagnostic tools for troubleshooting Claudio itself",
+}
+
+var debugProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Capture a performance profile bundle from a running session",
+	Long: `Capture a performance profile from a running Claudio session for a bug report.
+
+The running session's process captures a bundle and writes it to the
+session's profiles directory. The bundle is a .tar.gz holding:
+
+  cpu.pprof       CPU profile over --duration
+  heap.pprof      Heap profile
+  goroutines.txt  Stack dump of every goroutine
+  timings.json    Event bus handler latency, tmux capture-polling timing,
+                  and TUI update/render timing since the session started
+  runtime.json    Go runtime statistics and session size
+
+Inspect profiles with "go tool pprof", e.g. go tool pprof -top cpu.pprof.
+Not supported on Windows.
+
+Examples:
+  # Profile the running session for 10 seconds
+  claudio debug profile
+
+  # Profile a specific session for 30 seconds and copy the bundle here
+  claudio debug profile -s abc123 -d 30s -o .`,
+	Args: cobra.NoArgs,
+	RunE: runDebugProfile,
+}
+
+func init() {
+	debugProfileCmd.Flags().StringVarP(&profileSessionID, "session", "s", "", "Session ID (default: the only running session)")
+	debugProfileCmd.Flags().DurationVarP(&profileDuration, "duration", "d", diag.DefaultCPUDuration, "How long to run the CPU profile")
+	debugProfileCmd.Flags().StringVarP(&profileOutput, "output", "o", "", "Copy the bundle to this file or directory")
+	debugProfileCmd.Flags().IntVarP(&profileTop, "top", "n", 5, "Slowest timings to summarize (0 = none)")
+	debugCmd.AddCommand(debugProfileCmd)
+}
+
+// RegisterDebugCmd registers the debug command with the given parent command.
+func RegisterDebugCmd(parent *cobra.Command) {
+	parent.AddCommand(debugCmd)
+}
+
+func runDebugProfile(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	info, err := findRunningSession(cwd, profileSessionID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Profiling session %s (PID %d) for %s...\n", info.ID, info.LockInfo.PID, profileDuration)
+	ctx, cancel := context.WithTimeout(cmd.Context(), profileDuration+profileResponseGrace)
+	defer cancel()
+	path, err := diag.RequestProfile(ctx, info.SessionDir, info.LockInfo.PID, profileDuration)
+	if err != nil {
+		return err
+	}
+
+	if profileOutput != "" {
+		if path, err = copyBundle(path, profileOutput); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Profile bundle: %s\n", path)
+
+	if profileTop > 0 {
+		stats, err := diag.ReadBundleTimings(path)
+		if err != nil {
+			return err
+		}
+		printSlowestTimings(os.Stdout, stats, profileTop)
+	}
+	return nil
+}
+
+// findRunningSession returns the session to profile: the one with the given
+// ID, or the only running session when id is empty.
+func findRunningSession(baseDir, id string) (*session.Info, error) {
+	if id != "" {
+		info, err := session.GetSessionInfo(baseDir, id)
+		if err != nil {
+			return nil, fmt.Errorf("session %s not found: %w", id, err)
+		}
+		if !info.IsLocked || info.LockInfo == nil {
+			return nil, fmt.Errorf("session %s is not running", id)
+		}
+		return info, nil
+	}
+
+	sessions, err := session.ListSessions(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	var running []*session.Info
+	for _, s := range sessions {
+		if s.IsLocked && s.LockInfo != nil {
+			running = append(running, s)
+		}
+	}
+	switch len(running) {
+	case 0:
+		return nil, fmt.Errorf("no running session found in %s", baseDir)
+	case 1:
+		return running[0], nil
+	}
+	ids := make([]string, len(running))
+	for i, s := range running {
+		ids[i] = s.ID
+	}
+	return nil, fmt.Errorf("%d sessions are running (%s); choose one with --session", len(running), strings.Join(ids, ", "))
+}
+
+// copyBundle copies the bundle at src to dst, which may be a directory, and
+// returns the copy's path.
+func copyBundle(src, dst string) (string, error) {
+	if fi, err := os.Stat(dst); err == nil && fi.IsDir() {
+		dst = filepath.Join(dst, filepath.Base(src))
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = in.Close() }()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return "", err
+	}
+	return dst, out.Close()
+}
+
+// printSlowestTimings prints the n timings with the highest p95.
+func printSlowestTimings(w io.Writer, stats []diag.TimingStats, n int) {
+	if len(stats) == 0 {
+		return
+	}
+	slices.SortFunc(stats, func(a, b diag.TimingStats) int { return cmp.Compare(b.P95, a.P95) })
+	stats = stats[:min(n, len(stats))]
+
+	fmt.Fprintln(w, "\nSlowest timings (by p95):")
+	fmt.Fprintf(w, "  %-40s %8s %10s %10s %10s\n", "NAME", "COUNT", "P50", "P95", "MAX")
+	for _, s := range stats {
+		fmt.Fprintf(w, "  %-40s %8d %10s %10s %10s\n", s.Name, s.Count,
+			roundDuration(s.P50), roundDuration(s.P95), roundDuration(s.Max))
+	}
+}
+
+// roundDuration rounds d to a precision that reads well in a table.
+func roundDuration(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	default:
+		return d.Round(time.Microsecond)
+	}
+}
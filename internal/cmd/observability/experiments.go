@@ -0,0 +1,99 @@
+package observability
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/experiment"
+	"github.com/Iron-Ham/claudio/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+var experimentsCmd = &cobra.Command{
+	Use:   "experiments [name]",
+	Short: "Compare outcomes of prompt template experiments",
+	Long: `Report per-variant outcomes for prompt experiments configured under
+the "experiments" config key.
+
+Outcomes are read from the stats store in .claudio/stats.jsonl. Each variant
+is compared against the control (unmodified prompt) on retries per task,
+verification failures, success rate, and cost per successful attempt.
+
+Examples:
+  # Compare every recorded experiment
+  claudio experiments
+
+  # Compare a single experiment
+  claudio experiments terse-instructions`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExperiments,
+}
+
+// RegisterExperimentsCmd registers the experiments command with the given parent command.
+func RegisterExperimentsCmd(parent *cobra.Command) {
+	parent.AddCommand(experimentsCmd)
+}
+
+func runExperiments(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	store := stats.NewStore(filepath.Join(cwd, ".claudio"))
+
+	names := args
+	if len(names) == 0 {
+		names, err = experiment.Names(store)
+		if err != nil {
+			return fmt.Errorf("failed to read stats: %w", err)
+		}
+	}
+	if len(names) == 0 {
+		fmt.Println("No experiment outcomes recorded yet.")
+		return nil
+	}
+
+	for i, name := range names {
+		summaries, err := experiment.Report(store, name)
+		if err != nil {
+			return fmt.Errorf("failed to build report for %q: %w", name, err)
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		printExperimentReport(name, summaries)
+	}
+	return nil
+}
+
+// printExperimentReport writes a per-variant comparison table for one experiment.
+func printExperimentReport(name string, summaries []experiment.VariantSummary) {
+	fmt.Printf("Experiment: %s\n", name)
+	if len(summaries) == 0 {
+		fmt.Println("  No outcomes recorded.")
+		return
+	}
+
+	fmt.Printf("%-20s %6s %9s %13s %9s %13s %12s\n",
+		"VARIANT", "TASKS", "ATTEMPTS", "RETRIES/TASK", "SUCCESS", "VERIFY FAILS", "COST/SUCCESS")
+	fmt.Println(strings.Repeat("-", 88))
+	for _, s := range summaries {
+		costPerSuccess := "-"
+		if s.Successes > 0 {
+			costPerSuccess = fmt.Sprintf("$%.4f", s.CostPerSuccess)
+		}
+		fmt.Printf("%-20s %6d %9d %13.2f %8.0f%% %12.0f%% %12s\n",
+			truncateVariant(s.Variant, 20), s.Tasks, s.Attempts, s.RetriesPerTask,
+			s.SuccessRate*100, s.VerifyFailRate*100, costPerSuccess)
+	}
+}
+
+// truncateVariant shortens a variant name to fit its table column.
+func truncateVariant(name string, width int) string {
+	if len(name) <= width {
+		return name
+	}
+	return name[:width-3] + "..."
+}
@@ -0,0 +1,173 @@
+package observability
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/flake"
+	"github.com/Iron-Ham/claudio/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flakyRepo  string
+	flakyLimit int
+	flakyAll   bool
+	flakyTests bool
+)
+
+var flakyCmd = &cobra.Command{
+	Use:   "flaky",
+	Short: "Report the flakiest verification commands",
+	Long: `Report verification commands that failed and then passed when re-run.
+
+When a group consolidator reports a failed verification step, claudio re-runs
+the step's command once on its own. A step that passes on the re-run is
+marked "flaky-pass" instead of failing the group. Outcomes are read from the
+stats store in .claudio/stats.jsonl and ranked per repository by flake rate
+(flaky passes / classified runs).
+
+For go test commands, only the failed tests are re-run, and each test that
+passes on the re-run is recorded as flaky. A test that flaked earlier in the
+session is quarantined: when it fails again, it no longer fails the step.
+--tests ranks those tests instead of commands.
+
+Examples:
+  # Show the flakiest commands across all recorded repositories
+  claudio flaky
+
+  # Limit the report to one repository
+  claudio flaky --repo /path/to/repo
+
+  # Include commands that never flaked
+  claudio flaky --all
+
+  # Show the flakiest Go tests
+  claudio flaky --tests`,
+	Args: cobra.NoArgs,
+	RunE: runFlaky,
+}
+
+func init() {
+	flakyCmd.Flags().StringVar(&flakyRepo, "repo", "", "Only report commands recorded for this repository path")
+	flakyCmd.Flags().IntVarP(&flakyLimit, "limit", "n", 10, "Maximum commands to show per repository (0 = no limit)")
+	flakyCmd.Flags().BoolVar(&flakyAll, "all", false, "Include commands that never flaked")
+	flakyCmd.Flags().BoolVar(&flakyTests, "tests", false, "Report flaky Go tests instead of commands")
+}
+
+// RegisterFlakyCmd registers the flaky command with the given parent command.
+func RegisterFlakyCmd(parent *cobra.Command) {
+	parent.AddCommand(flakyCmd)
+}
+
+func runFlaky(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	store := stats.NewStore(filepath.Join(cwd, ".claudio"))
+	if flakyTests {
+		return runFlakyTests(store)
+	}
+
+	summaries, err := flake.Report(store, flakyRepo)
+	if err != nil {
+		return fmt.Errorf("failed to read stats: %w", err)
+	}
+
+	byRepo := make(map[string][]flake.CommandSummary)
+	var repos []string
+	for _, s := range summaries {
+		if !flakyAll && s.FlakyPasses == 0 {
+			continue
+		}
+		if _, ok := byRepo[s.Repo]; !ok {
+			repos = append(repos, s.Repo)
+		}
+		byRepo[s.Repo] = append(byRepo[s.Repo], s)
+	}
+	if len(repos) == 0 {
+		fmt.Println("No flaky verification commands recorded.")
+		return nil
+	}
+
+	for i, repo := range repos {
+		if i > 0 {
+			fmt.Println()
+		}
+		rows := byRepo[repo]
+		if flakyLimit > 0 && len(rows) > flakyLimit {
+			rows = rows[:flakyLimit]
+		}
+		printFlakyReport(repo, rows)
+	}
+	return nil
+}
+
+// printFlakyReport writes the flakiest commands of one repository as a table.
+func printFlakyReport(repo string, rows []flake.CommandSummary) {
+	if repo == "" {
+		repo = "(unknown repository)"
+	}
+	fmt.Printf("Repository: %s\n", repo)
+	fmt.Printf("%-40s %6s %7s %7s %7s %7s  %s\n",
+		"COMMAND", "RUNS", "PASS", "FLAKY", "FAIL", "RATE", "LAST FLAKY")
+	fmt.Println(strings.Repeat("-", 100))
+	for _, s := range rows {
+		last := "-"
+		if !s.LastFlaky.IsZero() {
+			last = s.LastFlaky.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Printf("%-40s %6d %7d %7d %7d %6.0f%%  %s\n",
+			truncateVariant(s.Command, 40), s.Runs, s.Passes, s.FlakyPasses, s.Failures,
+			s.FlakeRate*100, last)
+	}
+}
+
+// runFlakyTests prints the flakiest Go tests of each repository.
+func runFlakyTests(store *stats.Store) error {
+	summaries, err := flake.ReportTests(store, flakyRepo)
+	if err != nil {
+		return fmt.Errorf("failed to read stats: %w", err)
+	}
+
+	byRepo := make(map[string][]flake.TestSummary)
+	var repos []string
+	for _, s := range summaries {
+		if _, ok := byRepo[s.Repo]; !ok {
+			repos = append(repos, s.Repo)
+		}
+		byRepo[s.Repo] = append(byRepo[s.Repo], s)
+	}
+	if len(repos) == 0 {
+		fmt.Println("No flaky tests recorded.")
+		return nil
+	}
+
+	for i, repo := range repos {
+		if i > 0 {
+			fmt.Println()
+		}
+		rows := byRepo[repo]
+		if flakyLimit > 0 && len(rows) > flakyLimit {
+			rows = rows[:flakyLimit]
+		}
+		if repo == "" {
+			repo = "(unknown repository)"
+		}
+		fmt.Printf("Repository: %s\n", repo)
+		fmt.Printf("%-60s %7s %11s %8s  %s\n", "TEST", "FLAKY", "QUARANTINED", "SESSIONS", "LAST FLAKY")
+		fmt.Println(strings.Repeat("-", 110))
+		for _, s := range rows {
+			last := "-"
+			if !s.LastFlaky.IsZero() {
+				last = s.LastFlaky.Local().Format("2006-01-02 15:04")
+			}
+			fmt.Printf("%-60s %7d %11d %8d  %s\n",
+				truncateVariant(s.Test, 60), s.FlakyPasses, s.Quarantined, s.Sessions, last)
+		}
+	}
+	return nil
+}
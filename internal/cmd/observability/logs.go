@@ -4,7 +4,6 @@ package observability
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -40,6 +39,9 @@ Examples:
   # Filter by log level
   claudio logs --level warn
 
+  # Show one instance's logs
+  claudio logs --instance abc123
+
   # Show logs from the last hour
   claudio logs --since 1h
 
@@ -55,6 +57,8 @@ var (
 	logsLevel     string
 	logsSince     string
 	logsGrep      string
+	logsInstance  string
+	logsPhase     string
 )
 
 func init() {
@@ -64,6 +68,8 @@ func init() {
 	logsCmd.Flags().StringVar(&logsLevel, "level", "", "Filter by minimum level (debug/info/warn/error)")
 	logsCmd.Flags().StringVar(&logsSince, "since", "", "Show logs since duration ago (e.g., 1h, 30m)")
 	logsCmd.Flags().StringVar(&logsGrep, "grep", "", "Filter logs matching pattern (regex)")
+	logsCmd.Flags().StringVar(&logsInstance, "instance", "", "Filter logs by instance ID")
+	logsCmd.Flags().StringVar(&logsPhase, "phase", "", "Filter logs by phase")
 }
 
 // RegisterLogsCmd registers the logs command with the given parent command.
@@ -71,51 +77,6 @@ func RegisterLogsCmd(parent *cobra.Command) {
 	parent.AddCommand(logsCmd)
 }
 
-// logEntry represents a parsed JSON log line
-type logEntry struct {
-	Time       time.Time      `json:"time"`
-	Level      string         `json:"level"`
-	Msg        string         `json:"msg"`
-	SessionID  string         `json:"session_id,omitempty"`
-	InstanceID string         `json:"instance_id,omitempty"`
-	Phase      string         `json:"phase,omitempty"`
-	Extra      map[string]any `json:"-"` // Captures additional fields
-}
-
-// UnmarshalJSON implements custom unmarshaling to capture extra fields
-func (e *logEntry) UnmarshalJSON(data []byte) error {
-	// First, unmarshal known fields using a type alias to avoid recursion
-	type Alias logEntry
-	aux := &struct {
-		*Alias
-	}{
-		Alias: (*Alias)(e),
-	}
-	if err := json.Unmarshal(data, aux); err != nil {
-		return err
-	}
-
-	// Then unmarshal all fields to capture extras
-	var all map[string]any
-	if err := json.Unmarshal(data, &all); err != nil {
-		return err
-	}
-
-	// Remove known fields, keep the rest as extra
-	delete(all, "time")
-	delete(all, "level")
-	delete(all, "msg")
-	delete(all, "session_id")
-	delete(all, "instance_id")
-	delete(all, "phase")
-
-	if len(all) > 0 {
-		e.Extra = all
-	}
-
-	return nil
-}
-
 // ANSI color codes for terminal output
 const (
 	colorReset  = "\033[0m"
@@ -142,24 +103,8 @@ func levelColor(level string) string {
 	}
 }
 
-// levelPriority returns the priority of a log level for filtering
-func levelPriority(level string) int {
-	switch strings.ToUpper(level) {
-	case logging.LevelDebug:
-		return 0
-	case logging.LevelInfo:
-		return 1
-	case logging.LevelWarn:
-		return 2
-	case logging.LevelError:
-		return 3
-	default:
-		return -1
-	}
-}
-
 // formatLogEntry formats a log entry for terminal output
-func formatLogEntry(entry *logEntry) string {
+func formatLogEntry(entry *logging.Entry) string {
 	var sb strings.Builder
 
 	// Timestamp
@@ -238,7 +183,7 @@ func runLogs(cmd *cobra.Command, args []string) error {
 
 	// Locate the log file
 	sessionDir := session.GetSessionDir(cwd, sessionID)
-	logPath := filepath.Join(sessionDir, "debug.log")
+	logPath := filepath.Join(sessionDir, logging.LogFileName)
 
 	if _, err := os.Stat(logPath); os.IsNotExist(err) {
 		fmt.Printf("No logs found for session %s\n", sessionID)
@@ -247,25 +192,22 @@ func runLogs(cmd *cobra.Command, args []string) error {
 	}
 
 	// Parse filter options
-	var minLevel = -1
+	filter := logging.LogFilter{InstanceID: logsInstance, Phase: logsPhase}
 	if logsLevel != "" {
-		validLevel := logging.ParseLevel(logsLevel)
-		minLevel = levelPriority(validLevel)
+		filter.MinLevel = logging.ParseLevel(logsLevel)
 	}
 
-	var sinceTime time.Time
 	if logsSince != "" {
 		duration, err := time.ParseDuration(logsSince)
 		if err != nil {
 			return fmt.Errorf("invalid duration format: %w", err)
 		}
-		sinceTime = time.Now().Add(-duration)
+		filter.Since = time.Now().Add(-duration)
 	}
 
-	var grepRegex *regexp.Regexp
 	if logsGrep != "" {
 		var err error
-		grepRegex, err = regexp.Compile(logsGrep)
+		filter.Pattern, err = regexp.Compile(logsGrep)
 		if err != nil {
 			return fmt.Errorf("invalid grep pattern: %w", err)
 		}
@@ -273,15 +215,15 @@ func runLogs(cmd *cobra.Command, args []string) error {
 
 	// Follow mode
 	if logsFollow {
-		return followLogs(logPath, minLevel, sinceTime, grepRegex)
+		return followLogs(logPath, filter)
 	}
 
 	// Non-follow mode: read and display logs
-	return displayLogs(logPath, logsTail, minLevel, sinceTime, grepRegex)
+	return displayLogs(logPath, logsTail, filter)
 }
 
 // displayLogs reads the log file and displays filtered entries
-func displayLogs(logPath string, tail int, minLevel int, sinceTime time.Time, grepRegex *regexp.Regexp) error {
+func displayLogs(logPath string, tail int, filter logging.LogFilter) error {
 	file, err := os.Open(logPath)
 	if err != nil {
 		return fmt.Errorf("failed to open log file: %w", err)
@@ -301,15 +243,15 @@ func displayLogs(logPath string, tail int, minLevel int, sinceTime time.Time, gr
 			continue
 		}
 
-		var entry logEntry
-		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		entry, err := logging.ParseEntry([]byte(line))
+		if err != nil {
 			// If we can't parse as JSON, display raw line
 			entries = append(entries, line)
 			continue
 		}
 
 		// Apply filters
-		if !passesFilters(&entry, minLevel, sinceTime, grepRegex) {
+		if !filter.Match(&entry) {
 			continue
 		}
 
@@ -338,7 +280,7 @@ func displayLogs(logPath string, tail int, minLevel int, sinceTime time.Time, gr
 }
 
 // followLogs implements tail -f behavior for the log file
-func followLogs(logPath string, minLevel int, sinceTime time.Time, grepRegex *regexp.Regexp) error {
+func followLogs(logPath string, filter logging.LogFilter) error {
 	file, err := os.Open(logPath)
 	if err != nil {
 		return fmt.Errorf("failed to open log file: %w", err)
@@ -370,44 +312,18 @@ func followLogs(logPath string, minLevel int, sinceTime time.Time, grepRegex *re
 			continue
 		}
 
-		var entry logEntry
-		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		entry, err := logging.ParseEntry([]byte(line))
+		if err != nil {
 			// If we can't parse as JSON, display raw line
 			fmt.Println(line)
 			continue
 		}
 
 		// Apply filters
-		if !passesFilters(&entry, minLevel, sinceTime, grepRegex) {
+		if !filter.Match(&entry) {
 			continue
 		}
 
 		fmt.Println(formatLogEntry(&entry))
 	}
 }
-
-// passesFilters checks if a log entry passes all filter criteria
-func passesFilters(entry *logEntry, minLevel int, sinceTime time.Time, grepRegex *regexp.Regexp) bool {
-	// Level filter
-	if minLevel >= 0 && levelPriority(entry.Level) < minLevel {
-		return false
-	}
-
-	// Time filter
-	if !sinceTime.IsZero() && entry.Time.Before(sinceTime) {
-		return false
-	}
-
-	// Grep filter - search in message and extra fields
-	if grepRegex != nil {
-		searchText := entry.Msg
-		for _, v := range entry.Extra {
-			searchText += " " + fmt.Sprintf("%v", v)
-		}
-		if !grepRegex.MatchString(searchText) {
-			return false
-		}
-	}
-
-	return true
-}
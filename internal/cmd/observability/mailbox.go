@@ -0,0 +1,206 @@
+package observability
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/mailbox"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mailboxTypes  []string
+	mailboxFrom   string
+	mailboxSince  time.Duration
+	mailboxMax    int
+	mailboxOutput string
+	mailboxSource string
+	mailboxInto   string
+	mailboxIDs    []string
+)
+
+var mailboxCmd = &cobra.Command{
+	Use:   "mailbox",
+	Short: "Export and import inter-instance mailbox messages",
+}
+
+var mailboxExportCmd = &cobra.Command{
+	Use:   "export [dir]",
+	Short: "Export a session's mailbox messages to a JSON file",
+	Long: `Export the mailbox messages of a session so that what its instances
+learned (discoveries, warnings, answers) can be carried into a new session.
+
+dir is a session directory, or any directory above one: every mailbox found
+below it is exported, which covers pipeline runs that keep one mailbox per
+execution team. It defaults to the current directory. Messages still held for
+review are never exported.
+
+Examples:
+  # Export every warning and discovery from the last run in this repository
+  claudio mailbox export --type warning,discovery -o lessons.json
+
+  # Export the last 2 hours of one instance's messages
+  claudio mailbox export execution/exec-0 --from inst-3 --since 2h`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMailboxExport,
+}
+
+var mailboxImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Forward exported mailbox messages into a session",
+	Long: `Forward messages from a file written by "claudio mailbox export" into
+the mailbox of another session.
+
+Forwarded messages are broadcast to every instance and carry provenance
+metadata: the source session, the original message ID and recipient, and
+when it was first sent. Prompts show them as forwarded. Importing the same
+file twice does not duplicate messages.
+
+Examples:
+  # Forward every exported message into a new run's first team
+  claudio mailbox import lessons.json --into execution/exec-0
+
+  # Forward only the warnings, or specific messages by ID
+  claudio mailbox import lessons.json --into execution/exec-0 --type warning
+  claudio mailbox import lessons.json --into execution/exec-0 --id msg-1718000000000000000-4242-7`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMailboxImport,
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{mailboxExportCmd, mailboxImportCmd} {
+		cmd.Flags().StringSliceVarP(&mailboxTypes, "type", "t", nil, "Only these message types (comma-separated)")
+		cmd.Flags().StringVar(&mailboxFrom, "from", "", "Only messages from this instance")
+	}
+	mailboxExportCmd.Flags().DurationVar(&mailboxSince, "since", 0, "Only messages sent within this long (e.g. 2h)")
+	mailboxExportCmd.Flags().IntVarP(&mailboxMax, "max", "n", 0, "Keep only the most recent N messages (0 = all)")
+	mailboxExportCmd.Flags().StringVarP(&mailboxOutput, "output", "o", "", "Write the export to this file (default: stdout)")
+	mailboxExportCmd.Flags().StringVar(&mailboxSource, "source", "", "Name recorded as the messages' source (default: the directory path)")
+	mailboxImportCmd.Flags().StringVar(&mailboxInto, "into", "", "Session directory to forward the messages into (required)")
+	mailboxImportCmd.Flags().StringSliceVar(&mailboxIDs, "id", nil, "Only these message IDs (comma-separated)")
+	_ = mailboxImportCmd.MarkFlagRequired("into")
+
+	mailboxCmd.AddCommand(mailboxExportCmd)
+	mailboxCmd.AddCommand(mailboxImportCmd)
+}
+
+// RegisterMailboxCmd registers the mailbox command with the given parent command.
+func RegisterMailboxCmd(parent *cobra.Command) {
+	parent.AddCommand(mailboxCmd)
+}
+
+// mailboxFilter builds the filter shared by export and import from flags.
+func mailboxFilter() (mailbox.FilterOptions, error) {
+	opts := mailbox.FilterOptions{From: mailboxFrom, MaxMessages: mailboxMax}
+	for _, t := range mailboxTypes {
+		mt := mailbox.MessageType(t)
+		if !mailbox.ValidateMessageType(mt) {
+			return opts, fmt.Errorf("unknown message type %q", t)
+		}
+		opts.Types = append(opts.Types, mt)
+	}
+	if mailboxSince > 0 {
+		opts.Since = time.Now().Add(-mailboxSince)
+	}
+	return opts, nil
+}
+
+func runMailboxExport(cmd *cobra.Command, args []string) error {
+	opts, err := mailboxFilter()
+	if err != nil {
+		return err
+	}
+	root := "."
+	if len(args) > 0 {
+		root = args[0]
+	}
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+
+	dirs, err := mailbox.FindSessionDirs(root)
+	if err != nil {
+		return err
+	}
+	if len(dirs) == 0 {
+		return fmt.Errorf("no mailboxes found under %s", root)
+	}
+
+	// Filter per mailbox without a limit, then apply the limit across all of
+	// them so --max keeps the most recent messages overall.
+	perDir := opts
+	perDir.MaxMessages = 0
+	var messages []mailbox.Message
+	for _, dir := range dirs {
+		found, err := mailbox.NewMailbox(dir).Export(perDir)
+		if err != nil {
+			return fmt.Errorf("export %s: %w", dir, err)
+		}
+		messages = append(messages, found...)
+	}
+	slices.SortStableFunc(messages, func(a, b mailbox.Message) int { return a.Timestamp.Compare(b.Timestamp) })
+	if opts.MaxMessages > 0 && len(messages) > opts.MaxMessages {
+		messages = messages[len(messages)-opts.MaxMessages:]
+	}
+
+	source := mailboxSource
+	if source == "" {
+		source = root
+	}
+	export := mailbox.Export{Source: source, Messages: messages}
+
+	var out io.Writer = cmd.OutOrStdout()
+	if mailboxOutput != "" {
+		f, err := os.Create(mailboxOutput)
+		if err != nil {
+			return fmt.Errorf("create export file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		out = f
+	}
+	if err := mailbox.WriteExport(out, export); err != nil {
+		return err
+	}
+	if mailboxOutput != "" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d message(s) from %d mailbox(es) to %s\n", len(messages), len(dirs), mailboxOutput)
+	}
+	return nil
+}
+
+func runMailboxImport(cmd *cobra.Command, args []string) error {
+	opts, err := mailboxFilter()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("open export file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	export, err := mailbox.ReadExport(f)
+	if err != nil {
+		return err
+	}
+
+	selected := mailbox.FilterMessages(export.Messages, opts)
+	if len(mailboxIDs) > 0 {
+		selected = slices.DeleteFunc(selected, func(m mailbox.Message) bool {
+			return !slices.Contains(mailboxIDs, m.ID)
+		})
+	}
+
+	mb := mailbox.NewMailbox(mailboxInto, mailbox.WithGuard(mailbox.DefaultGuardPolicy()))
+	n, err := mb.Forward(export.Source, selected)
+	if err != nil {
+		return fmt.Errorf("forward messages: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Forwarded %d of %d selected message(s) from %s into %s\n",
+		n, len(selected), export.Source, mailboxInto)
+	return nil
+}
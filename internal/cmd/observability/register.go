@@ -8,4 +8,9 @@ import "github.com/spf13/cobra"
 func Register(parent *cobra.Command) {
 	RegisterLogsCmd(parent)
 	RegisterHarvestCmd(parent)
+	RegisterExperimentsCmd(parent)
+	RegisterFlakyCmd(parent)
+	RegisterDebugCmd(parent)
+	RegisterMailboxCmd(parent)
+	RegisterAuditCmd(parent)
 }
@@ -2,7 +2,6 @@ package planning
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -63,6 +62,26 @@ Plan Editor:
   Use --review to always open the plan editor, even with --auto-approve.
   Use --auto-approve without --review to skip the editor entirely.
 
+Headless Mode:
+  Use --headless to run without the TUI, e.g. on a remote server. The plan and
+  synthesis are approved automatically, progress is printed and, with --listen,
+  served as JSON (GET /status, GET /report), and a final report is written when
+  the run ends. The run stops and leaves the session for 'claudio sessions
+  attach' when a group partly fails (unless --continue-on-failure) or
+  consolidation hits a conflict.
+
+Objective Templates:
+  Use --template to wrap the objective in a reusable template that adds
+  constraints, verification requirements, planning hints, and a suitable
+  consolidation mode. Templates with a goal expand to a full objective from
+  typed variables given with --var, and need no objective argument.
+  Built-in templates are "feature" (feature with tests and docs), "rename"
+  (large mechanical rename), "upgrade" (dependency upgrade), "coverage" (test
+  coverage for a package), and "migrate" (library migration). Define your own
+  under 'ultraplan.templates' in config.yaml, or as YAML files in
+  ~/.config/claudio/templates or .claudio/templates; use --list-templates to
+  see all.
+
 Configuration options can be set in config.yaml under 'ultraplan:' or via flags:
 - max_parallel: Maximum concurrent child sessions (default: 3)
 - multi_pass: Enable multi-pass planning (default: false)
@@ -75,12 +94,18 @@ Examples:
   # Start with a pre-existing plan file
   claudio ultraplan --plan plan.json
 
+  # Start from a plan written or reviewed outside claudio (see 'ultraplan export')
+  claudio ultraplan --plan plan.yaml
+
   # Review and edit a plan before execution
   claudio ultraplan --plan plan.json --review
 
-  # Dry run - only generate the plan, don't execute
+  # Dry run - plan, build every task prompt, and estimate cost without executing
   claudio ultraplan --dry-run "Refactor the API layer"
 
+  # Sanity-check an existing plan file cheaply
+  claudio ultraplan --plan plan.json --dry-run
+
   # Auto-approve but still review the plan first
   claudio ultraplan --auto-approve --review "Add comprehensive test coverage"
 
@@ -96,6 +121,15 @@ Examples:
   # Enable adversarial review for higher quality task completion
   claudio ultraplan --adversarial "Implement critical security features"
 
+  # Run on a server without the TUI, serving progress on a local port
+  claudio ultraplan --headless --listen 127.0.0.1:7879 "Migrate the API to v2"
+
+  # Upgrade a dependency using the built-in upgrade template
+  claudio ultraplan --template upgrade "lodash from v4 to v5"
+
+  # Expand a template from its variables alone
+  claudio ultraplan --template coverage --var package=internal/auth --var target=90
+
   # Convert an existing Notion spec into an ultraplan (requires Notion MCP configured)
   claudio ultraplan --spec "https://notion.so/team/My-Feature-Spec-abc123"
 
@@ -115,18 +149,42 @@ var (
 	ultraplanReview      bool
 	ultraplanMultiPass   bool
 	ultraplanAdversarial bool
+	ultraplanTemplate    string
+	ultraplanVars        []string
+	ultraplanListTmpl    bool
+	ultraplanFreshVerify bool
+	ultraplanMergeQueue  bool
+	ultraplanResolver    bool
+	ultraplanReviewers   int
+	ultraplanGroupGate   bool
+	ultraplanHeadless    bool
+	ultraplanListen      string
+	ultraplanReport      string
+	ultraplanContinue    bool
 )
 
 func init() {
 	cfg := config.Get()
-	ultraplanCmd.Flags().StringVar(&ultraplanPlanFile, "plan", "", "Use existing plan file instead of planning phase")
+	ultraplanCmd.Flags().StringVar(&ultraplanPlanFile, "plan", "", "Use an existing plan file (JSON, or YAML with a .yaml/.yml extension) instead of the planning phase")
 	ultraplanCmd.Flags().StringVar(&ultraplanSpecURL, "spec", "", "URL or path to existing spec — planning agent converts it to a plan instead of open-ended exploration")
 	ultraplanCmd.Flags().IntVar(&ultraplanMaxParallel, "max-parallel", cfg.Ultraplan.MaxParallel, "Maximum concurrent child sessions (0 = unlimited)")
-	ultraplanCmd.Flags().BoolVar(&ultraplanDryRun, "dry-run", false, "Run planning only, output plan without executing")
+	ultraplanCmd.Flags().BoolVar(&ultraplanDryRun, "dry-run", false, "Plan without executing: build every task prompt and estimate cost and duration, then write a report")
 	ultraplanCmd.Flags().BoolVar(&ultraplanNoSynthesis, "no-synthesis", false, "Skip synthesis phase after execution")
 	ultraplanCmd.Flags().BoolVar(&ultraplanAutoApprove, "auto-approve", false, "Auto-approve spawned tasks without confirmation")
 	ultraplanCmd.Flags().BoolVar(&ultraplanReview, "review", false, "Review and edit plan before execution (opens plan editor)")
 	ultraplanCmd.Flags().BoolVar(&ultraplanMultiPass, "multi-pass", cfg.Ultraplan.MultiPass, "Enable multi-pass planning with 3 strategic approaches (maximize-parallelism, minimize-complexity, balanced) - best plan is selected or merged")
+	ultraplanCmd.Flags().StringVar(&ultraplanTemplate, "template", "", "Objective template that adds constraints, verification requirements, and consolidation mode (see --list-templates)")
+	ultraplanCmd.Flags().StringArrayVar(&ultraplanVars, "var", nil, "Set an objective template variable as name=value (repeatable)")
+	ultraplanCmd.Flags().BoolVar(&ultraplanListTmpl, "list-templates", false, "List available objective templates and their variables and exit")
+	ultraplanCmd.Flags().BoolVar(&ultraplanFreshVerify, "fresh-verification", cfg.Ultraplan.FreshVerification, "Re-run verification commands instead of reusing results cached for an identical tree")
+	ultraplanCmd.Flags().BoolVar(&ultraplanMergeQueue, "merge-queue", cfg.Ultraplan.MergeQueue, "Consolidate with rerere, resolve conflicts where both branches only added lines, and retry conflicting branches in another order")
+	ultraplanCmd.Flags().BoolVar(&ultraplanResolver, "conflict-resolver", cfg.Ultraplan.ConflictResolver, "Start an instance to resolve cherry-pick conflicts during group consolidation instead of failing the group")
+	ultraplanCmd.Flags().IntVar(&ultraplanReviewers, "synthesis-reviewers", cfg.Ultraplan.SynthesisReviewers, "Parallel synthesis reviewers focused on correctness, tests, and architecture, whose issues are merged by vote (1-3)")
+	ultraplanCmd.Flags().BoolVar(&ultraplanGroupGate, "group-approval", cfg.Ultraplan.GroupApproval, "Pause after each execution group is consolidated until the next group is approved")
+	ultraplanCmd.Flags().BoolVar(&ultraplanHeadless, "headless", false, "Run without the TUI: approve the plan and synthesis automatically and write a final report")
+	ultraplanCmd.Flags().StringVar(&ultraplanListen, "listen", "", "With --headless, serve progress as JSON on this address (e.g. 127.0.0.1:7879)")
+	ultraplanCmd.Flags().StringVar(&ultraplanReport, "report", "", "With --headless, write the final report here (default <session-dir>/"+headlessReportName+")")
+	ultraplanCmd.Flags().BoolVar(&ultraplanContinue, "continue-on-failure", false, "With --headless, continue with the succeeded tasks when a group partly fails instead of stopping")
 	ultraplanCmd.Flags().BoolVar(&ultraplanAdversarial, "adversarial", cfg.Ultraplan.Adversarial, "[EXPERIMENTAL] Enable adversarial review mode where each task must pass reviewer approval (NOTE: infrastructure-only, workflow integration not yet implemented)")
 }
 
@@ -141,6 +199,16 @@ func runUltraplan(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
+	cfg := config.Get()
+	templates, templateErrs := ultraplan.LoadTemplates(cfg, cwd)
+	for _, err := range templateErrs {
+		fmt.Fprintf(os.Stderr, "Warning: skipping objective template: %v\n", err)
+	}
+	if ultraplanListTmpl {
+		printObjectiveTemplates(templates)
+		return nil
+	}
+
 	// Validate mutually exclusive flags
 	if ultraplanPlanFile != "" && ultraplanSpecURL != "" {
 		return fmt.Errorf("--plan and --spec cannot be used together: --plan skips planning, --spec guides it")
@@ -148,13 +216,42 @@ func runUltraplan(cmd *cobra.Command, args []string) error {
 	if ultraplanSpecURL != "" && ultraplanMultiPass {
 		return fmt.Errorf("--spec and --multi-pass cannot be used together: spec conversion uses a single planning pass")
 	}
+	if ultraplanTemplate != "" && (ultraplanPlanFile != "" || ultraplanSpecURL != "") {
+		return fmt.Errorf("--template cannot be used with --plan or --spec: templates shape a new objective")
+	}
+	if len(ultraplanVars) > 0 && ultraplanTemplate == "" {
+		return fmt.Errorf("--var requires --template")
+	}
+	if err := validateHeadlessFlags(args); err != nil {
+		return err
+	}
 
-	// Get objective from args or prompt
+	var template *ultraplan.ObjectiveTemplate
+	var templateValues map[string]string
+	if ultraplanTemplate != "" {
+		t, err := ultraplan.FindTemplate(templates, ultraplanTemplate)
+		if err != nil {
+			return err
+		}
+		if ultraplanHeadless && len(args) == 0 && t.Goal == "" {
+			return fmt.Errorf("--headless needs an objective: template %s has no goal", t.Name)
+		}
+		template = &t
+		if templateValues, err = parseTemplateVars(ultraplanVars); err != nil {
+			return err
+		}
+	}
+
+	// Get objective from args or prompt. A template with a goal needs no
+	// objective.
 	var objective string
 	if len(args) > 0 {
 		objective = args[0]
-	} else if ultraplanPlanFile == "" && ultraplanSpecURL == "" {
+	} else if ultraplanPlanFile == "" && ultraplanSpecURL == "" && (template == nil || template.Goal == "") {
 		// Prompt for objective if not provided and no plan file or spec URL
+		if template != nil {
+			fmt.Printf("\nTemplate: %s - %s\n", template.Name, template.Description)
+		}
 		objective, err = promptUltraplanObjective()
 		if err != nil {
 			return err
@@ -164,12 +261,24 @@ func runUltraplan(cmd *cobra.Command, args []string) error {
 		objective = "Convert spec to ultraplan: " + ultraplanSpecURL
 	}
 
+	// Expand the template before anything starts, so bad variable values
+	// fail early; the session is still named after what the user typed
+	// rather than the template's text
+	fullObjective := objective
+	if template != nil {
+		if fullObjective, err = template.Expand(objective, templateValues); err != nil {
+			return err
+		}
+	}
+
 	// Generate a new session ID for this ultraplan
 	sessionID := orchsession.GenerateID()
-	cfg := config.Get()
 
-	// Build ultraplan config from app config, then apply CLI flag overrides
+	// Build ultraplan config from app config, then apply the template and CLI flag overrides
 	ultraConfig := ultraplan.BuildConfigFromAppConfig(cfg)
+	if template != nil {
+		template.Apply(&ultraConfig)
+	}
 	applyUltraplanFlagOverrides(cmd, &ultraConfig)
 
 	// Create logger if enabled - we need session dir which requires session ID
@@ -195,6 +304,8 @@ func runUltraplan(cmd *cobra.Command, args []string) error {
 			words = words[:3]
 		}
 		sessionName = "ultraplan-" + SlugifyWords(words)
+	} else if template != nil {
+		sessionName = "ultraplan-" + template.Name
 	}
 
 	session, err := orch.StartSession(sessionName)
@@ -202,6 +313,8 @@ func runUltraplan(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to start session: %w", err)
 	}
 
+	objective = fullObjective
+
 	// Load plan file if provided
 	var plan *orchestrator.PlanSpec
 	if ultraplanPlanFile != "" {
@@ -244,8 +357,14 @@ func runUltraplan(cmd *cobra.Command, args []string) error {
 		"adversarial", initResult.Config.Adversarial,
 		"dry_run", initResult.Config.DryRun,
 		"auto_approve", initResult.Config.AutoApprove,
+		"template", ultraplanTemplate,
 	)
 
+	// A dry run has nothing to show in the TUI once planning is done
+	if ultraplanHeadless || initResult.Config.DryRun {
+		return runUltraplanHeadless(orch, initResult.Coordinator, sessionID, sessionDir, logger.WithSession(session.ID))
+	}
+
 	// Get terminal dimensions
 	if termWidth, termHeight, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
 		contentWidth, contentHeight := tui.CalculateContentDimensions(termWidth, termHeight)
@@ -275,16 +394,68 @@ func applyUltraplanFlagOverrides(cmd *cobra.Command, cfg *orchestrator.UltraPlan
 	if cmd.Flags().Changed("adversarial") {
 		cfg.Adversarial = ultraplanAdversarial
 	}
+	if cmd.Flags().Changed("fresh-verification") {
+		cfg.FreshVerification = ultraplanFreshVerify
+	}
+	if cmd.Flags().Changed("merge-queue") {
+		cfg.MergeQueue = ultraplanMergeQueue
+	}
+	if cmd.Flags().Changed("conflict-resolver") {
+		cfg.ConflictResolver = ultraplanResolver
+	}
+	if cmd.Flags().Changed("synthesis-reviewers") {
+		cfg.SynthesisReviewers = ultraplanReviewers
+	}
+	if cmd.Flags().Changed("group-approval") {
+		cfg.GroupApproval = ultraplanGroupGate
+	}
 	// These flags always apply (no "changed" check needed since they have sensible defaults)
 	cfg.DryRun = ultraplanDryRun
 	cfg.NoSynthesis = ultraplanNoSynthesis
 	cfg.AutoApprove = ultraplanAutoApprove
 	cfg.Review = ultraplanReview
+	if ultraplanHeadless {
+		// Nobody is there to approve the plan
+		cfg.AutoApprove = true
+	}
 	if ultraplanSpecURL != "" {
 		cfg.SpecURL = ultraplanSpecURL
 	}
 }
 
+// printObjectiveTemplates lists objective templates and their variables for
+// --list-templates.
+func printObjectiveTemplates(templates []ultraplan.ObjectiveTemplate) {
+	fmt.Printf("%-16s %-10s %-10s %s\n", "TEMPLATE", "MODE", "SOURCE", "DESCRIPTION")
+	for _, t := range templates {
+		mode := t.ConsolidationMode
+		if mode == "" {
+			mode = "-"
+		}
+		source := t.Source
+		if source != "built-in" && source != "config" {
+			source = "file"
+		}
+		fmt.Printf("%-16s %-10s %-10s %s\n", t.Name, mode, source, t.Description)
+		for _, v := range t.Variables {
+			fmt.Printf("    --var %s  %s\n", v.Usage(), v.Description)
+		}
+	}
+}
+
+// parseTemplateVars parses --var name=value flags.
+func parseTemplateVars(vars []string) (map[string]string, error) {
+	values := make(map[string]string, len(vars))
+	for _, v := range vars {
+		name, value, ok := strings.Cut(v, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --var %q: want name=value", v)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
 // promptUltraplanObjective prompts the user to enter an objective
 func promptUltraplanObjective() (string, error) {
 	fmt.Println("\nUltra-Plan Mode")
@@ -308,27 +479,9 @@ func promptUltraplanObjective() (string, error) {
 	return input, nil
 }
 
-// loadUltraplanFile loads a plan from a JSON file
+// loadUltraplanFile imports and validates a plan from a JSON or YAML file
 func loadUltraplanFile(path string) (*orchestrator.PlanSpec, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
-	}
-
-	var plan orchestrator.PlanSpec
-	if err := json.Unmarshal(data, &plan); err != nil {
-		return nil, fmt.Errorf("failed to parse plan JSON: %w", err)
-	}
-
-	// Compute DependencyGraph and ExecutionOrder if they weren't in the JSON
-	// (e.g., plan files that only have tasks with depends_on fields)
-	orchestrator.EnsurePlanComputed(&plan)
-
-	if err := orchestrator.ValidatePlan(&plan); err != nil {
-		return nil, err
-	}
-
-	return &plan, nil
+	return orchestrator.ReadPlanFile(path)
 }
 
 // SlugifyWords creates a slug from words.
@@ -363,13 +516,26 @@ func createLogger(sessionDir string, cfg *config.Config) *logging.Logger {
 		Compress:   false, // Not exposed in config yet
 	}
 
+	sinks := make([]logging.SinkConfig, 0, len(cfg.Logging.Sinks))
+	for _, sink := range cfg.Logging.Sinks {
+		sinks = append(sinks, logging.SinkConfig(sink))
+	}
+
 	// Create the logger with rotation support
-	logger, err := logging.NewLoggerWithRotation(sessionDir, cfg.Logging.Level, rotationConfig)
+	logger, err := logging.NewLoggerWithRotation(sessionDir, cfg.Logging.Level, rotationConfig, sinks...)
 	if err != nil {
 		// Log creation failure shouldn't prevent the application from starting
 		fmt.Fprintf(os.Stderr, "Warning: failed to create logger: %v\n", err)
 		return logging.NopLogger()
 	}
 
-	return logger
+	limits := make([]logging.RateLimit, 0, len(cfg.Logging.RateLimits))
+	for _, limit := range cfg.Logging.RateLimits {
+		limits = append(limits, logging.RateLimit{
+			Msg:      limit.Message,
+			Interval: limit.Interval(),
+			Sample:   limit.Sample,
+		})
+	}
+	return logger.WithRateLimits(limits...)
 }
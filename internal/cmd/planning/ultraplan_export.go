@@ -0,0 +1,127 @@
+package planning
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/spf13/cobra"
+)
+
+var ultraplanExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export an ultraplan's plan as JSON, YAML, or Graphviz DOT",
+	Long: `Export writes the execution plan of an ultraplan session, or of a plan file,
+so it can be reviewed or edited outside claudio.
+
+JSON and YAML exports hold the tasks, summary, insights, constraints, and
+environment; the dependency graph and execution order are left out and
+recomputed from each task's depends_on when the plan is imported again with
+'claudio ultraplan --plan'. DOT renders the dependency graph for Graphviz,
+with tasks clustered by execution group and filled by estimated complexity;
+it can't be imported.
+
+The format defaults to the output file's extension (.yaml/.yml, .dot/.gv,
+otherwise JSON), or to YAML when writing to stdout.
+
+Examples:
+  # Export the only session's plan as YAML to stdout
+  claudio ultraplan export
+
+  # Edit a session's plan by hand, then run it
+  claudio ultraplan export --session abc123 -o plan.yaml
+  claudio validate plan.yaml
+  claudio ultraplan --plan plan.yaml
+
+  # Render a plan file's dependency graph
+  claudio ultraplan export --plan .claudio-plan.json --format dot | dot -Tsvg > plan.svg`,
+	Args: cobra.NoArgs,
+	RunE: runUltraplanExport,
+}
+
+var (
+	exportSessionID string
+	exportPlanFile  string
+	exportFormat    string
+	exportOutput    string
+)
+
+func init() {
+	ultraplanExportCmd.Flags().StringVarP(&exportSessionID, "session", "s", "", "Session ID or prefix (default: the only session)")
+	ultraplanExportCmd.Flags().StringVar(&exportPlanFile, "plan", "", "Export this plan file instead of a session's plan")
+	ultraplanExportCmd.Flags().StringVarP(&exportFormat, "format", "f", "", "Output format: json, yaml, or dot (default: from --output, else yaml)")
+	ultraplanExportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Write to this file instead of stdout")
+
+	ultraplanCmd.AddCommand(ultraplanExportCmd)
+}
+
+func runUltraplanExport(cmd *cobra.Command, args []string) error {
+	if exportPlanFile != "" && exportSessionID != "" {
+		return fmt.Errorf("--plan and --session cannot be used together")
+	}
+
+	format := orchestrator.PlanFormatYAML
+	if exportOutput != "" {
+		format = orchestrator.PlanFormatForPath(exportOutput)
+	}
+	if exportFormat != "" {
+		var err error
+		if format, err = orchestrator.ParsePlanFormat(exportFormat); err != nil {
+			return err
+		}
+	}
+
+	plan, err := loadExportPlan()
+	if err != nil {
+		return err
+	}
+	data, err := orchestrator.MarshalPlan(plan, format)
+	if err != nil {
+		return err
+	}
+
+	if exportOutput == "" {
+		_, err := cmd.OutOrStdout().Write(data)
+		return err
+	}
+	if err := os.WriteFile(exportOutput, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", exportOutput, err)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d task(s) to %s\n", len(plan.Tasks), exportOutput)
+	return nil
+}
+
+// loadExportPlan returns the plan to export: the --plan file's, or the
+// session's.
+func loadExportPlan() (*orchestrator.PlanSpec, error) {
+	if exportPlanFile != "" {
+		plan, err := orchestrator.ParsePlanFromFile(exportPlanFile, "")
+		if err != nil {
+			return nil, err
+		}
+		orchestrator.EnsurePlanComputed(plan)
+		return plan, nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+	sessionID, err := resolveRestartSession(cwd, exportSessionID)
+	if err != nil {
+		return nil, err
+	}
+	orch, err := orchestrator.NewWithSession(cwd, sessionID, config.Get())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create orchestrator: %w", err)
+	}
+	sess, err := orch.LoadSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session %s: %w", sessionID, err)
+	}
+	if sess.UltraPlan == nil || sess.UltraPlan.Plan == nil {
+		return nil, fmt.Errorf("session %s has no ultraplan execution plan", sessionID)
+	}
+	return sess.UltraPlan.Plan, nil
+}
@@ -0,0 +1,135 @@
+package planning
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/headless"
+	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+)
+
+// headlessReportName is the default report file inside the session directory.
+const headlessReportName = "headless-report.json"
+
+// validateHeadlessFlags rejects flag combinations that need a terminal. A
+// dry run also runs without the TUI, so it accepts --report.
+func validateHeadlessFlags(args []string) error {
+	if ultraplanDryRun && ultraplanReview {
+		return fmt.Errorf("--review cannot be used with --dry-run: a dry run never executes the plan")
+	}
+	if !ultraplanHeadless {
+		if ultraplanListen != "" || ultraplanContinue || (ultraplanReport != "" && !ultraplanDryRun) {
+			return fmt.Errorf("--listen, --report and --continue-on-failure require --headless")
+		}
+		return nil
+	}
+	if ultraplanReview {
+		return fmt.Errorf("--review cannot be used with --headless: there is no plan editor without a terminal")
+	}
+	// A template is checked for a goal once it is found
+	if len(args) == 0 && ultraplanPlanFile == "" && ultraplanSpecURL == "" && ultraplanTemplate == "" {
+		return fmt.Errorf("--headless needs an objective, --plan, --spec or --template")
+	}
+	return nil
+}
+
+// runUltraplanHeadless drives the ultraplan to completion without the TUI,
+// serving progress on --listen and writing the final report.
+func runUltraplanHeadless(orch *orchestrator.Orchestrator, coord *orchestrator.Coordinator, sessionID, sessionDir string, logger *logging.Logger) error {
+	// Shutdown stops instances but preserves session state, as the TUI does
+	defer func() { _ = orch.Shutdown() }()
+
+	reportPath := ultraplanReport
+	if reportPath == "" {
+		reportPath = filepath.Join(sessionDir, headlessReportName)
+	}
+	runner := headless.New(orch, coord, headless.Config{
+		SessionID:                sessionID,
+		ReportPath:               reportPath,
+		ContinueOnPartialFailure: ultraplanContinue,
+		Output:                   os.Stdout,
+		Logger:                   logger,
+	})
+
+	if ultraplanListen != "" {
+		if err := runner.Serve(ultraplanListen); err != nil {
+			return err
+		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = runner.Close(ctx)
+		}()
+		fmt.Printf("Status API: http://%s/status\n", runner.Addr())
+	}
+	fmt.Printf("Session %s running headless; report: %s\n", sessionID, reportPath)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	report, err := runner.Run(ctx)
+	if report != nil {
+		printHeadlessReport(report)
+		if report.DryRun != nil {
+			printDryRunReport(report.DryRun, reportPath)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	switch report.Outcome {
+	case headless.OutcomeComplete, headless.OutcomePlanned:
+		return nil
+	case headless.OutcomeNeedsAttention:
+		return fmt.Errorf("ultraplan needs attention: %s (attach with 'claudio sessions attach %s')", report.Reason, sessionID)
+	default:
+		return fmt.Errorf("ultraplan %s: %s", report.Outcome, report.Reason)
+	}
+}
+
+// printHeadlessReport prints a short summary of report.
+func printHeadlessReport(report *headless.Report) {
+	fmt.Println()
+	fmt.Printf("Outcome:  %s\n", report.Outcome)
+	fmt.Printf("Tasks:    %d/%d completed\n", report.Completed, report.Total)
+	fmt.Printf("Duration: %s\n", report.Duration)
+	for _, url := range report.PRURLs {
+		fmt.Printf("PR:       %s\n", url)
+	}
+	if report.Reason != "" {
+		fmt.Printf("Reason:   %s\n", report.Reason)
+	}
+}
+
+// printDryRunReport prints the execution groups and estimates of a dry run.
+// Task prompts are only in the report file, at reportPath.
+func printDryRunReport(report *orchestrator.DryRunReport, reportPath string) {
+	fmt.Println()
+	for _, group := range report.Groups {
+		fmt.Printf("Group %d (%s)\n", group.Index, group.Estimate)
+		for _, task := range report.Tasks {
+			if task.Group != group.Index {
+				continue
+			}
+			complexity := string(task.Complexity)
+			if complexity == "" {
+				complexity = "unknown"
+			}
+			fmt.Printf("  %-12s %-7s %-15s %s\n", task.ID, complexity, task.Estimate, task.Title)
+		}
+	}
+	fmt.Println()
+	basis := "default estimates"
+	if report.HistorySamples > 0 {
+		basis = fmt.Sprintf("from %d past tasks", report.HistorySamples)
+	}
+	fmt.Printf("Estimate: %s (%s)\n", report.Estimate, basis)
+	if reportPath != "" {
+		fmt.Printf("Prompts:  %s\n", reportPath)
+	}
+}
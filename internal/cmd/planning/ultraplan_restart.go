@@ -0,0 +1,168 @@
+package planning
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	sessutil "github.com/Iron-Ham/claudio/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var ultraplanRestartCmd = &cobra.Command{
+	Use:   "restart",
+	Short: "Restart an ultraplan's execution from a given group",
+	Long: `Restart discards the results of execution group N and every later group,
+keeping the consolidated work of earlier groups intact, so execution can be
+re-run after fixing a systemic problem (e.g. a wrong base branch or a broken
+prompt).
+
+For the discarded groups this:
+- Stops and removes task, consolidator, synthesis, and consolidation instances
+  along with their worktrees and branches
+- Deletes the groups' consolidated branches
+- Clears task completion, failure, and retry state
+
+Groups are numbered from 1, as shown in the ultraplan sidebar. The session must
+not be attached in another terminal. Afterwards, resume execution with
+'claudio sessions attach <session-id>'.
+
+Examples:
+  # Re-run group 2 onwards in the only session
+  claudio ultraplan restart --from-group 2
+
+  # Pick a session and skip the confirmation prompt
+  claudio ultraplan restart --from-group 3 --session abc123 --yes`,
+	Args: cobra.NoArgs,
+	RunE: runUltraplanRestart,
+}
+
+var (
+	restartFromGroup int
+	restartSessionID string
+	restartYes       bool
+)
+
+func init() {
+	ultraplanRestartCmd.Flags().IntVar(&restartFromGroup, "from-group", 0, "First execution group to discard and re-run (1-based)")
+	ultraplanRestartCmd.Flags().StringVarP(&restartSessionID, "session", "s", "", "Session ID or prefix (default: the only session)")
+	ultraplanRestartCmd.Flags().BoolVarP(&restartYes, "yes", "y", false, "Skip the confirmation prompt")
+	_ = ultraplanRestartCmd.MarkFlagRequired("from-group")
+
+	ultraplanCmd.AddCommand(ultraplanRestartCmd)
+}
+
+func runUltraplanRestart(cmd *cobra.Command, args []string) error {
+	if restartFromGroup < 1 {
+		return fmt.Errorf("--from-group must be 1 or greater")
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	sessionID, err := resolveRestartSession(cwd, restartSessionID)
+	if err != nil {
+		return err
+	}
+
+	orch, err := orchestrator.NewWithSession(cwd, sessionID, config.Get())
+	if err != nil {
+		return fmt.Errorf("failed to create orchestrator: %w", err)
+	}
+	sess, err := orch.LoadSessionWithLock()
+	if err != nil {
+		return fmt.Errorf("failed to load session %s (is it attached in another terminal?): %w", sessionID, err)
+	}
+	defer func() { _ = orch.ReleaseLock() }()
+
+	ultraSession := sess.UltraPlan
+	if ultraSession == nil || ultraSession.Plan == nil {
+		return fmt.Errorf("session %s has no ultraplan execution plan", sessionID)
+	}
+	numGroups := len(ultraSession.Plan.ExecutionOrder)
+	if restartFromGroup > numGroups {
+		return fmt.Errorf("--from-group %d is out of range: plan has %d group(s)", restartFromGroup, numGroups)
+	}
+
+	printRestartPlan(ultraSession, restartFromGroup-1)
+	if !restartYes {
+		fmt.Print("\nProceed with restart? [y/N] ")
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Restart cancelled.")
+			return nil
+		}
+	}
+
+	coordinator := orchestrator.NewCoordinator(orch, sess, ultraSession, nil)
+	result, err := coordinator.RestartFromGroup(restartFromGroup - 1)
+	if result == nil {
+		return fmt.Errorf("failed to restart: %w", err)
+	}
+
+	fmt.Printf("\nReset %d task(s), removed %d instance(s), deleted %d branch(es).\n",
+		len(result.ResetTasks), len(result.RemovedInstances), len(result.DeletedBranches))
+	if len(result.KeptBranches) > 0 {
+		fmt.Printf("Kept consolidated branches: %s\n", strings.Join(result.KeptBranches, ", "))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: some cleanup steps failed:\n%v\n", err)
+	}
+	fmt.Printf("\nResume execution with: claudio sessions attach %s\n", sess.ID)
+	return nil
+}
+
+// resolveRestartSession maps a session ID or prefix to a full session ID.
+// With no ID, it returns the only existing session.
+func resolveRestartSession(baseDir, idOrPrefix string) (string, error) {
+	sessions, err := sessutil.ListSessions(baseDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	if idOrPrefix == "" {
+		switch len(sessions) {
+		case 0:
+			return "", fmt.Errorf("no sessions found")
+		case 1:
+			return sessions[0].ID, nil
+		default:
+			return "", fmt.Errorf("multiple sessions found; pick one with --session (see 'claudio sessions list')")
+		}
+	}
+
+	for _, s := range sessions {
+		if s.ID == idOrPrefix || strings.HasPrefix(s.ID, idOrPrefix) {
+			return s.ID, nil
+		}
+	}
+	return "", fmt.Errorf("session not found: %s", idOrPrefix)
+}
+
+// printRestartPlan lists the groups and tasks a restart from fromGroup (0-based) discards.
+func printRestartPlan(ultraSession *orchestrator.UltraPlanSession, fromGroup int) {
+	plan := ultraSession.Plan
+	fmt.Printf("Restarting ultraplan %q from group %d of %d.\n",
+		ultraSession.Objective, fromGroup+1, len(plan.ExecutionOrder))
+	if fromGroup > 0 {
+		fmt.Printf("Groups 1-%d and their consolidated work are kept.\n", fromGroup)
+	}
+	fmt.Println("The following groups will be discarded and re-run:")
+	for groupIdx := fromGroup; groupIdx < len(plan.ExecutionOrder); groupIdx++ {
+		fmt.Printf("\n  Group %d:\n", groupIdx+1)
+		for _, taskID := range plan.ExecutionOrder[groupIdx] {
+			title := taskID
+			if task := ultraSession.GetTask(taskID); task != nil {
+				title = task.Title
+			}
+			fmt.Printf("    - %s\n", title)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package planning
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sessutil "github.com/Iron-Ham/claudio/internal/session"
+)
+
+// writeTestSession creates a minimal session.json for the given ID under baseDir.
+func writeTestSession(t *testing.T, baseDir, id string) {
+	t.Helper()
+	dir := sessutil.GetSessionDir(baseDir, id)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte(`{"id":"` + id + `","name":"test"}`)
+	if err := os.WriteFile(filepath.Join(dir, sessutil.SessionFileName), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolveRestartSession(t *testing.T) {
+	t.Run("no sessions", func(t *testing.T) {
+		_, err := resolveRestartSession(t.TempDir(), "")
+		if err == nil || !strings.Contains(err.Error(), "no sessions found") {
+			t.Errorf("error = %v, want 'no sessions found'", err)
+		}
+	})
+
+	t.Run("only session is the default", func(t *testing.T) {
+		baseDir := t.TempDir()
+		writeTestSession(t, baseDir, "abc123")
+
+		got, err := resolveRestartSession(baseDir, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "abc123" {
+			t.Errorf("resolveRestartSession() = %q, want %q", got, "abc123")
+		}
+	})
+
+	t.Run("multiple sessions require an ID", func(t *testing.T) {
+		baseDir := t.TempDir()
+		writeTestSession(t, baseDir, "abc123")
+		writeTestSession(t, baseDir, "def456")
+
+		_, err := resolveRestartSession(baseDir, "")
+		if err == nil || !strings.Contains(err.Error(), "multiple sessions") {
+			t.Errorf("error = %v, want 'multiple sessions'", err)
+		}
+	})
+
+	t.Run("prefix match", func(t *testing.T) {
+		baseDir := t.TempDir()
+		writeTestSession(t, baseDir, "abc123")
+		writeTestSession(t, baseDir, "def456")
+
+		got, err := resolveRestartSession(baseDir, "def")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "def456" {
+			t.Errorf("resolveRestartSession() = %q, want %q", got, "def456")
+		}
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		baseDir := t.TempDir()
+		writeTestSession(t, baseDir, "abc123")
+
+		_, err := resolveRestartSession(baseDir, "zzz")
+		if err == nil || !strings.Contains(err.Error(), "session not found") {
+			t.Errorf("error = %v, want 'session not found'", err)
+		}
+	})
+}
@@ -211,6 +211,55 @@ func TestSpecFlagMutualExclusion(t *testing.T) {
 	})
 }
 
+func TestValidateHeadlessFlags(t *testing.T) {
+	oldHeadless, oldReview, oldListen, oldPlan := ultraplanHeadless, ultraplanReview, ultraplanListen, ultraplanPlanFile
+	oldDryRun, oldReport, oldTemplate := ultraplanDryRun, ultraplanReport, ultraplanTemplate
+	defer func() {
+		ultraplanHeadless, ultraplanReview, ultraplanListen, ultraplanPlanFile = oldHeadless, oldReview, oldListen, oldPlan
+		ultraplanDryRun, ultraplanReport, ultraplanTemplate = oldDryRun, oldReport, oldTemplate
+	}()
+
+	tests := []struct {
+		name     string
+		headless bool
+		review   bool
+		dryRun   bool
+		listen   string
+		report   string
+		plan     string
+		template string
+		args     []string
+		wantErr  string
+	}{
+		{name: "interactive", args: nil},
+		{name: "listen without headless", listen: "127.0.0.1:0", wantErr: "require --headless"},
+		{name: "headless with objective", headless: true, args: []string{"do it"}},
+		{name: "headless with plan", headless: true, plan: "plan.json"},
+		{name: "headless without objective", headless: true, wantErr: "needs an objective"},
+		{name: "headless with template", headless: true, template: "coverage"},
+		{name: "headless with review", headless: true, review: true, args: []string{"do it"}, wantErr: "--review cannot be used"},
+		{name: "report without headless", report: "out.json", wantErr: "require --headless"},
+		{name: "dry run with report", dryRun: true, report: "out.json", args: []string{"do it"}},
+		{name: "dry run with review", dryRun: true, review: true, wantErr: "--review cannot be used with --dry-run"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ultraplanHeadless, ultraplanReview, ultraplanListen, ultraplanPlanFile = tt.headless, tt.review, tt.listen, tt.plan
+			ultraplanDryRun, ultraplanReport, ultraplanTemplate = tt.dryRun, tt.report, tt.template
+			err := validateHeadlessFlags(tt.args)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateHeadlessFlags() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateHeadlessFlags() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestApplyUltraplanFlagOverrides_SpecURL(t *testing.T) {
 	t.Run("spec URL is applied when set", func(t *testing.T) {
 		cfg := &orchestrator.UltraPlanConfig{}
@@ -251,3 +300,19 @@ func boolToString(b bool) string {
 	}
 	return "false"
 }
+
+func TestParseTemplateVars(t *testing.T) {
+	values, err := parseTemplateVars([]string{"package=internal/auth", "note=a=b", "empty="})
+	if err != nil {
+		t.Fatalf("parseTemplateVars() error = %v", err)
+	}
+	if values["package"] != "internal/auth" || values["note"] != "a=b" || values["empty"] != "" {
+		t.Errorf("parseTemplateVars() = %v", values)
+	}
+
+	for _, bad := range []string{"package", "=x"} {
+		if _, err := parseTemplateVars([]string{bad}); err == nil {
+			t.Errorf("parseTemplateVars(%q) did not fail", bad)
+		}
+	}
+}
@@ -13,11 +13,12 @@ import (
 
 var validateCmd = &cobra.Command{
 	Use:   "validate [plan-file]",
-	Short: "Validate an ultraplan JSON file",
-	Long: `Validate an ultraplan JSON file for structural issues and correctness.
+	Short: "Validate an ultraplan JSON or YAML file",
+	Long: `Validate an ultraplan JSON or YAML file for structural issues and correctness.
+Files ending in .yaml or .yml are read as YAML.
 
 This command checks:
-  - Valid JSON syntax
+  - Valid JSON or YAML syntax
   - Required fields (summary, tasks, etc.)
   - Task dependency validity (no cycles, no missing references)
   - File conflict detection between parallel tasks
@@ -34,6 +35,9 @@ Examples:
   # Validate a specific plan file
   claudio validate .claudio-plan.json
 
+  # Validate a hand-written YAML plan
+  claudio validate plan.yaml
+
   # Validate with JSON output
   claudio validate --json my-plan.json`,
 	Args: cobra.MaximumNArgs(1),
@@ -105,17 +109,20 @@ func runValidate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to read file: %w", err)
 	}
 
-	// Validate JSON syntax before attempting semantic parsing
-	var jsonCheck any
-	if err := json.Unmarshal(data, &jsonCheck); err != nil {
-		if validateJSON {
-			return outputJSON(ValidationOutput{
-				Valid:      false,
-				FilePath:   filePath,
-				ParseError: fmt.Sprintf("invalid JSON: %v", err),
-			})
+	// Validate JSON syntax before attempting semantic parsing (YAML syntax
+	// errors are reported by the parser)
+	if orchestrator.PlanFormatForPath(filePath) == orchestrator.PlanFormatJSON {
+		var jsonCheck any
+		if err := json.Unmarshal(data, &jsonCheck); err != nil {
+			if validateJSON {
+				return outputJSON(ValidationOutput{
+					Valid:      false,
+					FilePath:   filePath,
+					ParseError: fmt.Sprintf("invalid JSON: %v", err),
+				})
+			}
+			return fmt.Errorf("invalid JSON: %w", err)
 		}
-		return fmt.Errorf("invalid JSON: %w", err)
 	}
 
 	// Parse plan using orchestrator's parser (supports alternative field names
@@ -291,8 +298,13 @@ func convertToUltraplanSpec(plan *orchestrator.PlanSpec) *ultraplan.PlanSpec {
 			DependsOn:     t.DependsOn,
 			Priority:      t.Priority,
 			EstComplexity: ultraplan.TaskComplexity(t.EstComplexity),
+			TimeoutPolicy: t.TimeoutPolicy,
 			IssueURL:      t.IssueURL,
 			NoCode:        t.NoCode,
+			Backend:       t.Backend,
+			Command:       t.Command,
+			Criteria:      t.Criteria.Clone(),
+			ContextPack:   t.ContextPack.Clone(),
 		}
 	}
 
@@ -305,6 +317,7 @@ func convertToUltraplanSpec(plan *orchestrator.PlanSpec) *ultraplan.PlanSpec {
 		ExecutionOrder:  plan.ExecutionOrder,
 		Insights:        plan.Insights,
 		Constraints:     plan.Constraints,
+		Env:             plan.Env,
 		CreatedAt:       plan.CreatedAt,
 	}
 }
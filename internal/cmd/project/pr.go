@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/Iron-Ham/claudio/internal/ai"
 	"github.com/Iron-Ham/claudio/internal/config"
@@ -37,16 +38,35 @@ var (
 	prCloses    []string
 )
 
+var prCreateCmd = &cobra.Command{
+	Use:   "create [instance-id]",
+	Short: "Create a pull request, or the pull requests left pending",
+	Long: `Create a pull request for a Claudio instance, like 'claudio pr'.
+
+With --pending, create the pull requests whose branches were pushed while the PR
+provider's CLI (gh, glab) or credentials were missing instead. Each one that is created
+is removed from the session. If the provider is still unavailable, the command and web
+link for each pending pull request are printed.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPRCreate,
+}
+
+var prPending bool
+
 func init() {
-	prCmd.Flags().BoolVarP(&prDraft, "draft", "d", false, "Create as a draft PR")
-	prCmd.Flags().BoolVar(&prNoPush, "no-push", false, "Don't push the branch before creating the PR")
-	prCmd.Flags().BoolVar(&prNoAI, "no-ai", false, "Skip AI generation, use simple defaults")
-	prCmd.Flags().BoolVar(&prNoRebase, "no-rebase", false, "Skip rebasing on main before creating PR")
-	prCmd.Flags().StringVarP(&prTitle, "title", "t", "", "Override the PR title")
-	prCmd.Flags().StringVarP(&prBody, "body", "b", "", "Override the PR body")
-	prCmd.Flags().StringSliceVarP(&prReviewers, "reviewer", "r", nil, "Add reviewers (can be specified multiple times)")
-	prCmd.Flags().StringSliceVarP(&prLabels, "label", "l", nil, "Add labels (can be specified multiple times)")
-	prCmd.Flags().StringSliceVar(&prCloses, "closes", nil, "Link issues to close (e.g., --closes 42)")
+	flags := prCmd.PersistentFlags()
+	flags.BoolVarP(&prDraft, "draft", "d", false, "Create as a draft PR")
+	flags.BoolVar(&prNoPush, "no-push", false, "Don't push the branch before creating the PR")
+	flags.BoolVar(&prNoAI, "no-ai", false, "Skip AI generation, use simple defaults")
+	flags.BoolVar(&prNoRebase, "no-rebase", false, "Skip rebasing on main before creating PR")
+	flags.StringVarP(&prTitle, "title", "t", "", "Override the PR title")
+	flags.StringVarP(&prBody, "body", "b", "", "Override the PR body")
+	flags.StringSliceVarP(&prReviewers, "reviewer", "r", nil, "Add reviewers (can be specified multiple times)")
+	flags.StringSliceVarP(&prLabels, "label", "l", nil, "Add labels (can be specified multiple times)")
+	flags.StringSliceVar(&prCloses, "closes", nil, "Link issues to close (e.g., --closes 42)")
+
+	prCreateCmd.Flags().BoolVar(&prPending, "pending", false, "Create the pull requests left pending because the PR CLI was missing")
+	prCmd.AddCommand(prCreateCmd)
 }
 
 // RegisterPRCmd registers the pr command with the given parent command.
@@ -84,6 +104,14 @@ func runPR(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create orchestrator: %w", err)
 	}
 
+	// Check up front whether the PR provider can create the PR, so "fail"
+	// mode stops before anything is rebased or pushed
+	provider := orch.PRProvider()
+	cliErr := provider.CheckCLI()
+	if cliErr != nil && cfg.PR.MissingCLI == "fail" {
+		return fmt.Errorf("cannot create pull request: %w (set pr.missing_cli to \"degrade\" to push the branch and create the PR later)", cliErr)
+	}
+
 	session, err := orch.LoadSession()
 	if err != nil {
 		return fmt.Errorf("no active session found: %w", err)
@@ -304,16 +332,41 @@ func runPR(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Labels: %s\n", strings.Join(labels, ", "))
 	}
 
-	// Create the PR using the unified Create function
-	fmt.Println("\nCreating pull request...")
-	prURL, err := pr.Create(pr.PROptions{
+	opts := pr.PROptions{
 		Title:     title,
 		Body:      body,
 		Branch:    inst.Branch,
 		Draft:     useDraft,
 		Reviewers: reviewers,
 		Labels:    labels,
-	})
+	}
+
+	// Without the PR CLI, record the PR so it can be created once it is installed
+	if cliErr != nil {
+		pending := pr.Pending{
+			Branch:     opts.Branch,
+			Base:       wt.FindMainBranch(),
+			Title:      opts.Title,
+			Body:       opts.Body,
+			Draft:      opts.Draft,
+			Reviewers:  opts.Reviewers,
+			Labels:     opts.Labels,
+			InstanceID: inst.ID,
+			Reason:     cliErr.Error(),
+			SkippedAt:  time.Now(),
+		}
+		if err := orch.RecordPendingPRs(pending); err != nil {
+			return fmt.Errorf("failed to record pending PR: %w", err)
+		}
+		fmt.Printf("\nPull request not created: %v\n", cliErr)
+		printPendingPR(orch, pending)
+		fmt.Println("\nRun 'claudio pr create --pending' once the PR CLI is available.")
+		return nil
+	}
+
+	// Create the PR with the configured provider
+	fmt.Println("\nCreating pull request...")
+	prURL, err := provider.Create(opts)
 	if err != nil {
 		return fmt.Errorf("failed to create PR: %w", err)
 	}
@@ -322,6 +375,72 @@ func runPR(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runPRCreate(cmd *cobra.Command, args []string) error {
+	if !prPending {
+		return runPR(cmd, args)
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("--pending creates every pending pull request and takes no instance ID")
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	orch, err := orchestrator.New(cwd)
+	if err != nil {
+		return fmt.Errorf("failed to create orchestrator: %w", err)
+	}
+	if _, err := orch.LoadSession(); err != nil {
+		return fmt.Errorf("no active session found: %w", err)
+	}
+
+	pending := orch.PendingPRs()
+	if len(pending) == 0 {
+		fmt.Println("No pending pull requests.")
+		return nil
+	}
+
+	provider := orch.PRProvider()
+	if err := provider.CheckCLI(); err != nil {
+		fmt.Printf("%d pending pull request(s); create them with these commands or links:\n", len(pending))
+		for _, p := range pending {
+			fmt.Println()
+			printPendingPR(orch, p)
+		}
+		return fmt.Errorf("cannot create pull requests: %w", err)
+	}
+
+	var failed int
+	for _, p := range pending {
+		fmt.Printf("Creating pull request for %s...\n", p.Branch)
+		url, err := provider.Create(p.Options())
+		if err != nil {
+			fmt.Printf("  %v\n", err)
+			failed++
+			continue
+		}
+		if err := orch.ResolvePendingPR(p.Branch, url); err != nil {
+			fmt.Printf("  Warning: failed to update session: %v\n", err)
+		}
+		fmt.Printf("  Pull request created: %s\n", url)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d pending pull request(s) could not be created", failed, len(pending))
+	}
+	return nil
+}
+
+// printPendingPR prints the PR provider's command that creates p and, for
+// recognized remotes, the page that opens it in the browser.
+func printPendingPR(orch *orchestrator.Orchestrator, p pr.Pending) {
+	fmt.Printf("Branch: %s\n", p.Branch)
+	if link := orch.PRCompareURL(p.Base, p.Branch); link != "" {
+		fmt.Printf("Open:   %s\n", link)
+	}
+	fmt.Printf("Run:    %s\n", orch.PRProvider().Command(p.Options()))
+}
+
 func containsString(slice []string, s string) bool {
 	for _, item := range slice {
 		if item == s {
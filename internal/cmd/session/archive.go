@@ -0,0 +1,152 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	orchsession "github.com/Iron-Ham/claudio/internal/orchestrator/session"
+	"github.com/spf13/cobra"
+)
+
+var sessionsArchiveCmd = &cobra.Command{
+	Use:   "archive [session-id...]",
+	Short: "Archive completed sessions",
+	Long: `Compress session directories (session state, logs, mailbox, queue state,
+transcripts) into .claudio/archive/<session-id>.tar.zst and remove them.
+
+With session IDs, archives those sessions. Without, applies session.archive:
+archives sessions that were stopped cleanly and have been idle for after_days,
+then prunes archives beyond keep_last and max_total_mb. Claudio also applies
+the policy on start. Use --list to show existing archives.`,
+	RunE: runSessionsArchive,
+}
+
+var sessionsUnarchiveCmd = &cobra.Command{
+	Use:   "unarchive <session-id>",
+	Short: "Unpack a session archive for inspection",
+	Long: `Unpack a session's archive into a directory where its logs, mailbox and state
+can be read. The archive is kept, and the unpacked session is not attached to.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSessionsUnarchive,
+}
+
+var (
+	archiveList    bool
+	unarchiveToDir string
+)
+
+func init() {
+	sessionsCmd.AddCommand(sessionsArchiveCmd)
+	sessionsCmd.AddCommand(sessionsUnarchiveCmd)
+	sessionsArchiveCmd.Flags().BoolVar(&archiveList, "list", false, "List session archives instead of archiving")
+	sessionsUnarchiveCmd.Flags().StringVar(&unarchiveToDir, "to", "", "Directory to unpack into (default .claudio/archive/restored)")
+}
+
+// newArchiver returns an archiver for the repository at baseDir that applies
+// the configured session.archive policy.
+func newArchiver(baseDir string) *orchsession.Archiver {
+	ac := config.Get().Session.Archive
+	return orchsession.NewArchiver(baseDir, orchsession.ArchivePolicy{
+		After:         time.Duration(ac.AfterDays) * 24 * time.Hour,
+		KeepLast:      ac.KeepLast,
+		MaxTotalBytes: int64(ac.MaxTotalMB) << 20,
+	}, nil)
+}
+
+func runSessionsArchive(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	archiver := newArchiver(cwd)
+
+	if archiveList {
+		archives, err := archiver.Archives()
+		if err != nil {
+			return err
+		}
+		if len(archives) == 0 {
+			fmt.Println("No session archives.")
+			return nil
+		}
+		for _, a := range archives {
+			fmt.Printf("  %s  %s  %s\n", a.SessionID, a.ArchivedAt.Local().Format(time.DateTime), formatArchiveSize(a.Size))
+		}
+		return nil
+	}
+
+	if len(args) > 0 {
+		for _, id := range args {
+			info, err := archiver.Archive(id)
+			if err != nil {
+				return fmt.Errorf("failed to archive session: %w", err)
+			}
+			fmt.Printf("Archived session %s to %s (%s)\n", id, info.Path, formatArchiveSize(info.Size))
+		}
+		return nil
+	}
+
+	run, err := archiver.Run()
+	if err != nil {
+		return fmt.Errorf("failed to archive sessions: %w", err)
+	}
+	for _, a := range run.Archived {
+		fmt.Printf("Archived session %s (%s)\n", a.SessionID, formatArchiveSize(a.Size))
+	}
+	for _, a := range run.Pruned {
+		fmt.Printf("Pruned archive %s\n", a.SessionID)
+	}
+	if len(run.Archived) == 0 && len(run.Pruned) == 0 {
+		fmt.Println("Nothing to archive or prune.")
+	}
+	return nil
+}
+
+func runSessionsUnarchive(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	dest := unarchiveToDir
+	if dest == "" {
+		dest = filepath.Join(cwd, ".claudio", orchsession.ArchiveDir, "restored")
+	}
+
+	dir, err := newArchiver(cwd).Restore(args[0], dest)
+	if errors.Is(err, orchsession.ErrArchiveNotFound) {
+		return fmt.Errorf("%w; list archives with 'claudio sessions archive --list'", err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to unpack archive: %w", err)
+	}
+	fmt.Printf("Unpacked session %s to %s\n", args[0], dir)
+	return nil
+}
+
+// formatArchiveSize formats an archive size in KB or MB.
+func formatArchiveSize(n int64) string {
+	if n < 1<<20 {
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+}
+
+// runStartupArchive applies the session.archive policy when Claudio starts.
+// Failures are reported but never stop the start.
+func runStartupArchive(baseDir string) {
+	ac := config.Get().Session.Archive
+	if ac.AfterDays == 0 && ac.KeepLast == 0 && ac.MaxTotalMB == 0 {
+		return
+	}
+	run, err := newArchiver(baseDir).Run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: session archival failed: %v\n", err)
+	}
+	if run != nil && len(run.Archived)+len(run.Pruned) > 0 {
+		fmt.Printf("Archived %d completed session(s) and pruned %d old archive(s).\n\n", len(run.Archived), len(run.Pruned))
+	}
+}
@@ -0,0 +1,166 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/session"
+	"github.com/Iron-Ham/claudio/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var sessionsCheckpointCmd = &cobra.Command{
+	Use:   "checkpoint <session-id>",
+	Short: "Checkpoint a session to session storage",
+	Long: `Copy a local session's directory (session state, queue state, logs) to the
+store configured by session.storage. Running sessions checkpoint on their own
+every session.storage.checkpoint_interval_seconds; use this for a final
+checkpoint at the end of a CI job.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSessionsCheckpoint,
+}
+
+var sessionsCheckpointsCmd = &cobra.Command{
+	Use:   "checkpoints",
+	Short: "List sessions checkpointed to session storage",
+	Args:  cobra.NoArgs,
+	RunE:  runSessionsCheckpoints,
+}
+
+var sessionsRestoreCmd = &cobra.Command{
+	Use:   "restore <session-id> | --from <snapshot>",
+	Short: "Restore a session from session storage or a snapshot",
+	Long: `Download a session's latest checkpoint from the store configured by
+session.storage into .claudio/sessions/<session-id>, where it can be inspected
+(claudio logs, claudio audit) or attached to.
+
+With --from, unpack a tarball written by 'claudio sessions snapshot' instead.
+Paths under the repository the snapshot was taken in are rewritten to point
+into this one.
+
+Worktrees are not part of a checkpoint or snapshot. Instances whose worktrees do
+not exist on this machine cannot be resumed, but their state and output are kept.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if restoreFrom != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: runSessionsRestore,
+}
+
+var (
+	restoreForce bool
+	restoreFrom  string
+)
+
+// openSessionStore returns the configured session store, or an error when
+// session.storage is unset.
+func openSessionStore() (storage.Store, error) {
+	store, err := storage.New(config.Get().Session.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if store == nil {
+		return nil, fmt.Errorf("session storage is not configured; set session.storage.backend to local or s3")
+	}
+	return store, nil
+}
+
+func runSessionsCheckpoint(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	sessionID := args[0]
+	if !session.SessionExists(cwd, sessionID) {
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+	store, err := openSessionStore()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	cp := storage.NewCheckpointer(store, sessionID, session.GetSessionDir(cwd, sessionID))
+	if err := cp.Checkpoint(ctx); err != nil {
+		return fmt.Errorf("failed to checkpoint session: %w", err)
+	}
+	fmt.Printf("Checkpointed session %s\n", sessionID)
+	return nil
+}
+
+func runSessionsCheckpoints(cmd *cobra.Command, args []string) error {
+	store, err := openSessionStore()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	manifests, err := storage.ListCheckpoints(ctx, store)
+	if err != nil {
+		return fmt.Errorf("failed to list checkpoints: %w", err)
+	}
+
+	fmt.Println(strings.Repeat("─", 70))
+	fmt.Println("Session Checkpoints")
+	fmt.Println(strings.Repeat("─", 70))
+	if len(manifests) == 0 {
+		fmt.Println("\nNo checkpoints found.")
+		return nil
+	}
+	fmt.Printf("\nFound %d checkpoint(s):\n\n", len(manifests))
+	for _, m := range manifests {
+		var size int64
+		for _, f := range m.Files {
+			size += f.Size
+		}
+		fmt.Printf("  %s  %s  %d files, %d KB\n",
+			m.SessionID, m.CreatedAt.Local().Format(time.DateTime), len(m.Files), (size+1023)/1024)
+	}
+	fmt.Println("\nRestore one with 'claudio sessions restore <session-id>'.")
+	return nil
+}
+
+func runSessionsRestore(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	if restoreFrom != "" {
+		return restoreSnapshot(cwd, restoreFrom)
+	}
+	sessionID := args[0]
+	if session.SessionExists(cwd, sessionID) && !restoreForce {
+		return fmt.Errorf("session %s already exists locally; use --force to overwrite it", sessionID)
+	}
+	if info, err := session.GetSessionInfo(cwd, sessionID); err == nil && info.IsLocked {
+		return fmt.Errorf("session %s is locked by PID %d; stop it before restoring", sessionID, info.LockInfo.PID)
+	}
+	store, err := openSessionStore()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	m, err := storage.Restore(ctx, store, sessionID, session.GetSessionDir(cwd, sessionID))
+	if errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("no checkpoint found for session %s", sessionID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to restore session: %w", err)
+	}
+	fmt.Printf("Restored session %s (%d files, checkpointed %s)\n",
+		sessionID, len(m.Files), m.CreatedAt.Local().Format(time.DateTime))
+	fmt.Printf("Inspect it with 'claudio logs --session %s' or attach with 'claudio sessions attach %s'.\n", sessionID, sessionID)
+	return nil
+}
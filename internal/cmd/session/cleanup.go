@@ -6,12 +6,13 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/Iron-Ham/claudio/internal/cleanup"
 	"github.com/Iron-Ham/claudio/internal/config"
-	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/instance"
 	"github.com/Iron-Ham/claudio/internal/session"
 	"github.com/Iron-Ham/claudio/internal/tmux"
 	"github.com/Iron-Ham/claudio/internal/worktree"
@@ -40,7 +41,7 @@ var cleanupCmd = &cobra.Command{
 	Short: "Clean up stale worktrees, branches, tmux sessions, and empty sessions",
 	Long: `Cleanup removes orphaned resources that can accumulate over time:
 
-- Worktrees: In .claudio/worktrees/ with no active session
+- Worktrees: In .claudio/worktrees/ whose instance no session file references
 - Branches: <prefix>/* branches not associated with active work
   (prefix is configured via branch.prefix, default: "claudio")
 - Tmux sessions: Orphaned claudio-* tmux sessions
@@ -157,9 +158,7 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 }
 
 func discoverStaleResources(baseDir string) (*CleanupResult, error) {
-	result := &CleanupResult{
-		ActiveInstanceIDs: make(map[string]bool),
-	}
+	result := &CleanupResult{}
 
 	// Get configuration for worktree directory and branch prefix
 	cfg := config.Get()
@@ -169,15 +168,13 @@ func discoverStaleResources(baseDir string) (*CleanupResult, error) {
 		branchPrefix = "claudio"
 	}
 
-	// Load active session to know which instances are active
-	orch, err := orchestrator.New(baseDir)
-	if err == nil {
-		if sess, err := orch.LoadSession(); err == nil {
-			for _, inst := range sess.Instances {
-				result.ActiveInstanceIDs[inst.ID] = true
-			}
-		}
+	// Anything an instance of some session file still points at is kept,
+	// so an unreadable session file stops the scan rather than risk live work
+	refs, err := cleanup.LoadReferences(baseDir)
+	if err != nil {
+		return nil, err
 	}
+	result.ActiveInstanceIDs = refs.Instances
 
 	// Find stale worktrees
 	result.StaleWorktrees = FindStaleWorktrees(worktreesDir, result.ActiveInstanceIDs)
@@ -319,8 +316,8 @@ func FindOrphanedTmuxSessions(activeIDs map[string]bool) []string {
 			continue
 		}
 
-		// Extract instance ID
-		instanceID := strings.TrimPrefix(sess, "claudio-")
+		// Extract instance ID (claudio-<instance> or claudio-<session>-<instance>)
+		_, instanceID := instance.ExtractSessionAndInstanceID(sess)
 
 		// Check if it belongs to an active instance
 		if activeIDs[instanceID] {
@@ -496,6 +493,83 @@ func performCleanup(baseDir string, result *CleanupResult, cleanAll bool) error
 	return nil
 }
 
+// runStartupGC removes what crashed or removed sessions left behind, limited
+// to what can go without losing work: orphaned worktrees without uncommitted
+// changes, and the tmux sessions of their instances. Branches keep their
+// commits and are left for 'claudio cleanup', as are tmux sessions that
+// cannot be tied to a worktree of this repository. It does nothing while
+// another session in the repository is running, since that session may be
+// creating instances it has not saved yet.
+func runStartupGC(baseDir string) {
+	sessions, err := session.ListSessions(baseDir)
+	if err != nil {
+		return
+	}
+	for _, s := range sessions {
+		if s.IsLocked {
+			return
+		}
+	}
+
+	refs, err := cleanup.LoadReferences(baseDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: skipping startup cleanup: %v\n", err)
+		return
+	}
+
+	removedIDs := removeCleanOrphanedWorktrees(baseDir, config.Get().Paths.ResolveWorktreeDir(baseDir), refs.Instances)
+	if len(removedIDs) == 0 {
+		return
+	}
+
+	var killed int
+	if infos, err := instance.ListClaudioTmuxSessionsWithSocket(); err == nil {
+		for _, info := range infos {
+			if _, instanceID := instance.ExtractSessionAndInstanceID(info.SessionName); slices.Contains(removedIDs, instanceID) {
+				if info.KillCommand().Run() == nil {
+					killed++
+				}
+			}
+		}
+	}
+
+	fmt.Printf("Removed %d worktree(s) and %d tmux session(s) left behind by crashed sessions. Run 'claudio cleanup --dry-run' to review orphaned branches.\n\n",
+		len(removedIDs), killed)
+}
+
+// removeCleanOrphanedWorktrees removes the worktrees in worktreesDir whose
+// instance is not in activeIDs and that have no uncommitted changes, and
+// returns the instance IDs of the worktrees it removed. Their branches are
+// kept.
+func removeCleanOrphanedWorktrees(baseDir, worktreesDir string, activeIDs map[string]bool) []string {
+	entries, err := os.ReadDir(worktreesDir)
+	if err != nil {
+		return nil
+	}
+
+	var wt *worktree.Manager
+	var removed []string
+	for _, entry := range entries {
+		if !entry.IsDir() || activeIDs[entry.Name()] {
+			continue
+		}
+		wtPath := filepath.Join(worktreesDir, entry.Name())
+		status, err := exec.Command("git", "-C", wtPath, "status", "--porcelain").Output()
+		if err != nil || len(strings.TrimSpace(string(status))) > 0 {
+			continue
+		}
+		if wt == nil {
+			if wt, err = worktree.New(baseDir); err != nil {
+				return removed
+			}
+		}
+		if err := wt.Remove(wtPath); err == nil {
+			removed = append(removed, entry.Name())
+		}
+	}
+	return removed
+}
+
 // killAllClaudioTmuxSessions kills all tmux sessions with claudio-* prefix
 func killAllClaudioTmuxSessions() int {
 	cmd := tmux.Command("list-sessions", "-F", "#{session_name}")
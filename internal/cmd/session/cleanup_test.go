@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/Iron-Ham/claudio/internal/cleanup"
+	"github.com/Iron-Ham/claudio/internal/testutil"
 )
 
 // captureOutput captures stdout during function execution
@@ -283,3 +287,37 @@ func TestShowJobStatus_ResultsWithErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestRemoveCleanOrphanedWorktrees(t *testing.T) {
+	testutil.SkipIfNoGit(t)
+	repo := testutil.SetupTestRepo(t)
+	worktreesDir := filepath.Join(repo, ".claudio", "worktrees")
+	for _, id := range []string{"live0001", "orphan01", "dirty001"} {
+		cmd := exec.Command("git", "worktree", "add", "-b", "claudio/"+id+"-task", filepath.Join(worktreesDir, id))
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git worktree add %s: %v\n%s", id, err, out)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(worktreesDir, "dirty001", "wip.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	removed := removeCleanOrphanedWorktrees(repo, worktreesDir, map[string]bool{"live0001": true})
+
+	if !slices.Equal(removed, []string{"orphan01"}) {
+		t.Errorf("removed = %v, want [orphan01]", removed)
+	}
+	for id, wantExists := range map[string]bool{"live0001": true, "orphan01": false, "dirty001": true} {
+		_, err := os.Stat(filepath.Join(worktreesDir, id))
+		if exists := err == nil; exists != wantExists {
+			t.Errorf("worktree %s exists = %v, want %v", id, exists, wantExists)
+		}
+	}
+	// The orphan's branch keeps its commits
+	cmd := exec.Command("git", "rev-parse", "--verify", "claudio/orphan01-task")
+	cmd.Dir = repo
+	if err := cmd.Run(); err != nil {
+		t.Errorf("branch of removed worktree was deleted: %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	orchsession "github.com/Iron-Ham/claudio/internal/orchestrator/session"
+	"github.com/Iron-Ham/claudio/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var sessionsCloneCmd = &cobra.Command{
+	Use:   "clone <session-id>",
+	Short: "Start a new session from an existing session's tasks",
+	Long: `Create a new session with the same tasks, instance groups and context file as
+an existing one, so a recurring workflow can be re-run without setting it up
+again. Each task gets a new pending instance; worktrees, branches, status,
+metrics and ultraplan state are not copied.
+
+The new session is saved but not started. Attach to it with
+'claudio sessions attach <new-session-id>'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSessionsClone,
+}
+
+var cloneName string
+
+func init() {
+	sessionsCmd.AddCommand(sessionsCloneCmd)
+	sessionsCloneCmd.Flags().StringVar(&cloneName, "name", "", "Name for the new session (default the source session's name)")
+}
+
+func runSessionsClone(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	sourceID := args[0]
+	if !session.SessionExists(cwd, sourceID) {
+		return fmt.Errorf("session not found: %s", sourceID)
+	}
+
+	mgr := orchsession.NewManager(orchsession.Config{BaseDir: cwd})
+	clone, err := mgr.CloneSession(sourceID, orchsession.CloneOptions{Name: cloneName})
+	if errors.Is(err, orchsession.ErrSessionExists) {
+		return fmt.Errorf("%w; run the command again for a new session ID", err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to clone session: %w", err)
+	}
+
+	fmt.Printf("Cloned session %s to %s (%d instances)\n", sourceID, clone.ID, len(clone.Instances))
+	fmt.Printf("Attach with 'claudio sessions attach %s'.\n", clone.ID)
+	return nil
+}
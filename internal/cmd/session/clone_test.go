@@ -0,0 +1,54 @@
+package session
+
+import (
+	"testing"
+
+	orchsession "github.com/Iron-Ham/claudio/internal/orchestrator/session"
+	"github.com/Iron-Ham/claudio/internal/session"
+)
+
+func TestRunSessionsClone(t *testing.T) {
+	cwd := t.TempDir()
+	t.Chdir(cwd)
+
+	src := orchsession.NewManager(orchsession.Config{BaseDir: cwd, SessionID: "weekly"})
+	sess, err := src.CreateSession("Weekly bumps", cwd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = src.ReleaseLock() }()
+	sess.Instances = append(sess.Instances, orchsession.NewInstanceData("Bump deps"))
+	if err := src.SaveSession(sess); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runSessionsClone(sessionsCloneCmd, []string{"missing"}); err == nil {
+		t.Error("expected an error cloning a session that does not exist")
+	}
+
+	cloneName = "Weekly bumps again"
+	t.Cleanup(func() { cloneName = "" })
+	if err := runSessionsClone(sessionsCloneCmd, []string{"weekly"}); err != nil {
+		t.Fatalf("runSessionsClone() error = %v", err)
+	}
+
+	infos, err := session.ListSessions(cwd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("found %d sessions after cloning, want 2", len(infos))
+	}
+	for _, info := range infos {
+		if info.ID == "weekly" {
+			continue
+		}
+		clone, err := orchsession.NewManager(orchsession.Config{BaseDir: cwd, SessionID: info.ID}).LoadSession()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if clone.Name != "Weekly bumps again" || len(clone.Instances) != 1 || clone.Instances[0].Task != "Bump deps" {
+			t.Errorf("clone = %q with %d instances, want the --name and the source's task", clone.Name, len(clone.Instances))
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	instmetrics "github.com/Iron-Ham/claudio/internal/instance/metrics"
+	"github.com/Iron-Ham/claudio/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var sessionsHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show cost and usage of recent sessions",
+	Long: `Show sessions recorded in the session history database, newest first, with
+their instance count, tokens, and cost. With --tasks, list each task of those
+sessions instead, most expensive first within each session.
+
+Sessions are recorded while session.database.enabled is set. The history
+keeps stopped and removed sessions.`,
+	Args: cobra.NoArgs,
+	RunE: runSessionsHistory,
+}
+
+var (
+	historyLimit int
+	historyTasks bool
+)
+
+func init() {
+	sessionsCmd.AddCommand(sessionsHistoryCmd)
+
+	sessionsHistoryCmd.Flags().IntVarP(&historyLimit, "limit", "n", 10, "Number of recent sessions to show (0 = all)")
+	sessionsHistoryCmd.Flags().BoolVar(&historyTasks, "tasks", false, "Show the cost of each task")
+}
+
+func runSessionsHistory(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	db, err := store.New(config.Get().Session.Database, cwd)
+	if err != nil {
+		return fmt.Errorf("failed to open session history: %w", err)
+	}
+	if db == nil {
+		return fmt.Errorf("session history is not enabled; set session.database.enabled to true")
+	}
+	defer func() { _ = db.Close() }()
+
+	if historyTasks {
+		return printTaskHistory(db)
+	}
+	return printSessionHistory(db)
+}
+
+func printSessionHistory(db *store.Store) error {
+	sessions, err := db.RecentSessions(historyLimit)
+	if err != nil {
+		return fmt.Errorf("failed to read session history: %w", err)
+	}
+
+	fmt.Println(strings.Repeat("─", 70))
+	fmt.Println("Session History")
+	fmt.Println(strings.Repeat("─", 70))
+	if len(sessions) == 0 {
+		fmt.Println("\nNo sessions recorded yet.")
+		return nil
+	}
+
+	var total float64
+	fmt.Println()
+	for _, s := range sessions {
+		state := ""
+		if s.Deleted {
+			state = "  (stopped)"
+		}
+		fmt.Printf("  %s  %s  %-24s %3d instance(s)  %8s tokens  %8s%s\n",
+			s.ID, s.Created.Local().Format(time.DateTime), truncateHistory(s.Name, 24), s.Instances,
+			instmetrics.FormatTokens(s.InputTokens+s.OutputTokens), instmetrics.FormatCost(s.Cost), state)
+		total += s.Cost
+	}
+	fmt.Printf("\nTotal cost of %d session(s): %s\n", len(sessions), instmetrics.FormatCost(total))
+	return nil
+}
+
+func printTaskHistory(db *store.Store) error {
+	tasks, err := db.TaskCosts(historyLimit)
+	if err != nil {
+		return fmt.Errorf("failed to read task history: %w", err)
+	}
+
+	fmt.Println(strings.Repeat("─", 70))
+	fmt.Println("Task Cost History")
+	fmt.Println(strings.Repeat("─", 70))
+	if len(tasks) == 0 {
+		fmt.Println("\nNo tasks recorded yet.")
+		return nil
+	}
+
+	session := ""
+	for _, t := range tasks {
+		if t.SessionID != session {
+			session = t.SessionID
+			fmt.Printf("\n%s  %s\n", t.SessionID, t.SessionName)
+		}
+		duration := "-"
+		if t.Duration > 0 {
+			duration = t.Duration.Round(time.Second).String()
+		}
+		fmt.Printf("  %8s  %8s tokens  %10s  %-10s %s\n",
+			instmetrics.FormatCost(t.Cost), instmetrics.FormatTokens(t.InputTokens+t.OutputTokens), duration, t.Status, truncateHistory(t.Task, 40))
+	}
+	return nil
+}
+
+// truncateHistory shortens s to one line of at most n runes.
+func truncateHistory(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s
+}
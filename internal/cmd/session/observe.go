@@ -0,0 +1,31 @@
+package session
+
+import (
+	"fmt"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/session"
+)
+
+// ObserveSession shows a session read-only in the TUI while another process
+// runs it. The session is neither locked nor saved, and nothing is logged to
+// its directory, whose log belongs to the process running it.
+func ObserveSession(cwd, sessionID string, cfg *config.Config) error {
+	if !session.SessionExists(cwd, sessionID) {
+		return fmt.Errorf("session %s not found", sessionID)
+	}
+
+	orch, err := orchestrator.NewWithSession(cwd, sessionID, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create orchestrator: %w", err)
+	}
+
+	sess, err := orch.ObserveSession()
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+
+	return launchTUI(cwd, orch, sess, logging.NopLogger())
+}
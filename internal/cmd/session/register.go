@@ -10,4 +10,5 @@ func Register(parent *cobra.Command) {
 	RegisterStopCmd(parent)
 	RegisterSessionsCmd(parent)
 	RegisterCleanupCmd(parent)
+	RegisterReportCmd(parent)
 }
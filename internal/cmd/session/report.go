@@ -0,0 +1,156 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/report"
+	"github.com/Iron-Ham/claudio/internal/session"
+	"github.com/Iron-Ham/claudio/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report [session-id]",
+	Short: "Print a session's report",
+	Long: `Print a report of a session as Markdown: its objective and plan, the outcome,
+commits, and cost of each task, its pull requests, a timeline, and why failed
+tasks failed.
+
+A session that still exists is reported from its current state. A stopped
+session is reported from the copy written to .claudio/reports when it
+completed (see session.report). Without a session ID, the only session is
+reported, or with no sessions, the most recent saved report.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runReport,
+}
+
+var (
+	reportHTML   bool
+	reportOutput string
+)
+
+func init() {
+	reportCmd.Flags().BoolVar(&reportHTML, "html", false, "Print the report as an HTML page")
+	reportCmd.Flags().StringVarP(&reportOutput, "output", "o", "", "Write the report to a file instead of printing it")
+}
+
+// RegisterReportCmd registers the report command with the given parent command.
+func RegisterReportCmd(parent *cobra.Command) {
+	parent.AddCommand(reportCmd)
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	sessionID := ""
+	if len(args) > 0 {
+		sessionID = args[0]
+	} else if sessionID, err = defaultReportSession(cwd); err != nil {
+		return err
+	}
+
+	var content string
+	if session.SessionExists(cwd, sessionID) {
+		content, err = renderSessionReport(cwd, sessionID)
+	} else {
+		content, err = savedReport(cwd, sessionID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if reportOutput != "" {
+		if err := os.WriteFile(reportOutput, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		fmt.Printf("Report of session %s written to %s\n", sessionID, reportOutput)
+		return nil
+	}
+	fmt.Print(content)
+	return nil
+}
+
+// defaultReportSession returns the session to report on without an ID:
+// the only session, or with none, the one most recently saved a report.
+func defaultReportSession(cwd string) (string, error) {
+	sessions, err := session.ListSessions(cwd)
+	if err != nil {
+		return "", fmt.Errorf("failed to list sessions: %w", err)
+	}
+	switch len(sessions) {
+	case 1:
+		return sessions[0].ID, nil
+	case 0:
+	default:
+		return "", fmt.Errorf("%d sessions exist; pass a session ID (see 'claudio sessions list')", len(sessions))
+	}
+
+	entries, err := os.ReadDir(report.Dir(cwd))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("failed to list reports: %w", err)
+	}
+	var latest fs.FileInfo
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) != ".md" {
+			continue
+		}
+		info, err := entry.Info()
+		if err == nil && (latest == nil || info.ModTime().After(latest.ModTime())) {
+			latest = info
+		}
+	}
+	if latest == nil {
+		return "", fmt.Errorf("no sessions or saved reports found")
+	}
+	return strings.TrimSuffix(latest.Name(), ".md"), nil
+}
+
+// renderSessionReport builds the report of an existing session from its
+// session file.
+func renderSessionReport(cwd, sessionID string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(session.GetSessionDir(cwd, sessionID), session.SessionFileName))
+	if err != nil {
+		return "", fmt.Errorf("failed to read session: %w", err)
+	}
+	var opts []report.Option
+	if wt, err := worktree.New(cwd); err == nil {
+		opts = append(opts, report.WithCommitCounter(func(branch string) (int, error) {
+			return wt.CountCommitsBetween(cwd, wt.FindMainBranch(), branch)
+		}))
+	}
+	r, err := report.Build(data, opts...)
+	if err != nil {
+		return "", err
+	}
+	if reportHTML {
+		return report.HTML(r)
+	}
+	return report.Markdown(r), nil
+}
+
+// savedReport returns the report written when a stopped session completed.
+func savedReport(cwd, sessionID string) (string, error) {
+	ext := ".md"
+	if reportHTML {
+		ext = ".html"
+	}
+	data, err := os.ReadFile(report.Path(report.Dir(cwd), sessionID, ext))
+	if errors.Is(err, fs.ErrNotExist) {
+		if reportHTML {
+			return "", fmt.Errorf("no HTML report saved for session %s (set session.report.html to save one)", sessionID)
+		}
+		return "", fmt.Errorf("no session or saved report found: %s", sessionID)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read report: %w", err)
+	}
+	return string(data), nil
+}
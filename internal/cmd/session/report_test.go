@@ -0,0 +1,68 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/report"
+	"github.com/Iron-Ham/claudio/internal/session"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDefaultReportSession(t *testing.T) {
+	cwd := t.TempDir()
+	if _, err := defaultReportSession(cwd); err == nil {
+		t.Error("expected an error without sessions or reports")
+	}
+
+	// With no sessions, the newest saved report
+	dir := report.Dir(cwd)
+	writeTestFile(t, report.Path(dir, "old", ".md"), "old")
+	writeTestFile(t, report.Path(dir, "new", ".md"), "new")
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(report.Path(dir, "old", ".md"), past, past); err != nil {
+		t.Fatal(err)
+	}
+	if id, err := defaultReportSession(cwd); err != nil || id != "new" {
+		t.Errorf("defaultReportSession() = %q, %v; want the newest report", id, err)
+	}
+
+	// The only session wins over saved reports
+	writeTestFile(t, filepath.Join(session.GetSessionDir(cwd, "live"), session.SessionFileName), `{"id": "live"}`)
+	if id, err := defaultReportSession(cwd); err != nil || id != "live" {
+		t.Errorf("defaultReportSession() = %q, %v; want the live session", id, err)
+	}
+
+	writeTestFile(t, filepath.Join(session.GetSessionDir(cwd, "other"), session.SessionFileName), `{"id": "other"}`)
+	if _, err := defaultReportSession(cwd); err == nil {
+		t.Error("expected an error with several sessions")
+	}
+}
+
+func TestSavedReport(t *testing.T) {
+	cwd := t.TempDir()
+	writeTestFile(t, report.Path(report.Dir(cwd), "s1", ".md"), "# Session Report: s1\n")
+
+	got, err := savedReport(cwd, "s1")
+	if err != nil || !strings.HasPrefix(got, "# Session Report") {
+		t.Errorf("savedReport() = %q, %v", got, err)
+	}
+
+	reportHTML = true
+	defer func() { reportHTML = false }()
+	if _, err := savedReport(cwd, "s1"); err == nil || !strings.Contains(err.Error(), "session.report.html") {
+		t.Errorf("savedReport() error = %v, want a hint to enable HTML reports", err)
+	}
+}
@@ -13,9 +13,10 @@ import (
 )
 
 var sessionsCmd = &cobra.Command{
-	Use:   "sessions",
-	Short: "Manage Claudio sessions",
-	Long:  `Commands for listing, attaching, and cleaning up Claudio sessions.`,
+	Use:     "sessions",
+	Aliases: []string{"session"},
+	Short:   "Manage Claudio sessions",
+	Long:    `Commands for listing, attaching, cloning, cleaning up, checkpointing, snapshotting, archiving, and upgrading Claudio sessions.`,
 }
 
 var sessionsListCmd = &cobra.Command{
@@ -38,7 +39,12 @@ This command will:
 1. Load the session state
 2. Acquire a lock to prevent concurrent access
 3. Attempt to reconnect to any tmux sessions that are still running
-4. Launch the TUI`,
+4. Launch the TUI
+
+With --observe, the session is shown read-only while another process runs
+it, even when it is locked: instance output is followed, but nothing is sent
+to instances, started, stopped, or saved. Commands that change the session
+are refused in the TUI.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runSessionsAttach,
 }
@@ -67,6 +73,8 @@ This command will:
 	RunE: runSessionsClean,
 }
 
+var attachObserve bool
+
 var (
 	cleanAll       bool
 	cleanSessionID string
@@ -77,9 +85,15 @@ func init() {
 	sessionsCmd.AddCommand(sessionsAttachCmd)
 	sessionsCmd.AddCommand(sessionsRecoverCmd)
 	sessionsCmd.AddCommand(sessionsCleanCmd)
+	sessionsCmd.AddCommand(sessionsCheckpointCmd)
+	sessionsCmd.AddCommand(sessionsCheckpointsCmd)
+	sessionsCmd.AddCommand(sessionsRestoreCmd)
 
+	sessionsAttachCmd.Flags().BoolVar(&attachObserve, "observe", false, "Observe the session read-only while another process runs it")
 	sessionsCleanCmd.Flags().BoolVar(&cleanAll, "all", false, "Remove all session data")
 	sessionsCleanCmd.Flags().StringVar(&cleanSessionID, "session", "", "Clean specific session by ID")
+	sessionsRestoreCmd.Flags().BoolVar(&restoreForce, "force", false, "Overwrite a session that exists locally")
+	sessionsRestoreCmd.Flags().StringVar(&restoreFrom, "from", "", "Restore from a snapshot tarball instead of session storage")
 }
 
 // RegisterSessionsCmd registers the sessions command with the given parent command.
@@ -221,8 +235,13 @@ func runSessionsAttach(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("session not found: %s", sessionID)
 	}
 
+	if attachObserve {
+		fmt.Printf("Observing session %s (read-only)...\n", targetSession.ID)
+		return ObserveSession(cwd, targetSession.ID, config.Get())
+	}
+
 	if targetSession.IsLocked {
-		return fmt.Errorf("session %s is locked by PID %d. Use 'claudio sessions list' to see status",
+		return fmt.Errorf("session %s is locked by PID %d. Use 'claudio sessions list' to see status, or --observe to watch it read-only",
 			targetSession.ID, targetSession.LockInfo.PID)
 	}
 
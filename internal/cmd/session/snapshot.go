@@ -0,0 +1,94 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	orchsession "github.com/Iron-Ham/claudio/internal/orchestrator/session"
+	"github.com/Iron-Ham/claudio/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var sessionsSnapshotCmd = &cobra.Command{
+	Use:   "snapshot <session-id>",
+	Short: "Write a session's state to a tarball",
+	Long: `Capture a session's full state into a single gzipped tarball: session state
+(including task retry and consolidation state), task queue state, and the
+mailbox. Copy it to another machine and unpack it into a fresh checkout of the
+repository with 'claudio sessions restore --from <snapshot>' to continue a long
+ultraplan run there.
+
+Worktrees and logs are not included. Push the task branches first so the
+worktrees can be recreated on the other machine.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSessionsSnapshot,
+}
+
+var snapshotOutput string
+
+func init() {
+	sessionsCmd.AddCommand(sessionsSnapshotCmd)
+	sessionsSnapshotCmd.Flags().StringVarP(&snapshotOutput, "output", "o", "", "Snapshot file (default claudio-<session-id>.tar.gz)")
+}
+
+func runSessionsSnapshot(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	sessionID := args[0]
+	if !session.SessionExists(cwd, sessionID) {
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+	output := snapshotOutput
+	if output == "" {
+		output = fmt.Sprintf("claudio-%s.tar.gz", sessionID)
+	}
+
+	// Write beside the target and rename, so an interrupted snapshot never
+	// leaves a truncated tarball behind
+	tmp := output + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	mgr := orchsession.NewManager(orchsession.Config{BaseDir: cwd, SessionID: sessionID})
+	manifest, err := mgr.Snapshot(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, output)
+	}
+	if err != nil {
+		_ = os.Remove(tmp) // best-effort cleanup
+		return fmt.Errorf("failed to snapshot session: %w", err)
+	}
+
+	fmt.Printf("Snapshot of session %s written to %s (%d files)\n", sessionID, output, len(manifest.Files))
+	fmt.Println("Restore it in another checkout with 'claudio sessions restore --from <snapshot>'.")
+	return nil
+}
+
+// restoreSnapshot unpacks the snapshot at path into the repository at cwd.
+func restoreSnapshot(cwd, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	m, err := orchsession.RestoreSnapshot(f, cwd, orchsession.RestoreOptions{Force: restoreForce})
+	if errors.Is(err, orchsession.ErrSessionExists) {
+		return fmt.Errorf("%w locally; use --force to overwrite it", err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+	fmt.Printf("Restored session %s (%d files, snapshot taken %s)\n",
+		m.SessionID, len(m.Files), m.CreatedAt.Local().Format(time.DateTime))
+	fmt.Printf("Attach with 'claudio sessions attach %s'.\n", m.SessionID)
+	return nil
+}
@@ -63,7 +63,11 @@ func runStart(cmd *cobra.Command, args []string) error {
 		sessionName = args[0]
 	}
 
-	// Check for stale resources if configured
+	// Remove what crashed sessions left behind, then warn about the rest
+	if viper.GetBool("cleanup.auto_gc") {
+		runStartupGC(cwd)
+	}
+	runStartupArchive(cwd)
 	if viper.GetBool("cleanup.warn_on_stale") {
 		checkStaleResourcesWarning(cwd)
 	}
@@ -542,7 +546,7 @@ func resumeUltraplanSession(orch *orchestrator.Orchestrator, sess *orchestrator.
 
 	case orchestrator.PhaseExecuting:
 		fmt.Println("Resuming execution...")
-		if err := coordinator.StartExecution(); err != nil {
+		if err := coordinator.ResumeExecution(); err != nil {
 			return fmt.Errorf("failed to resume execution: %w", err)
 		}
 
@@ -826,15 +830,28 @@ func CreateLogger(sessionDir string, cfg *config.Config) *logging.Logger {
 		Compress:   false, // Not exposed in config yet
 	}
 
+	sinks := make([]logging.SinkConfig, 0, len(cfg.Logging.Sinks))
+	for _, sink := range cfg.Logging.Sinks {
+		sinks = append(sinks, logging.SinkConfig(sink))
+	}
+
 	// Create the logger with rotation support
-	logger, err := logging.NewLoggerWithRotation(sessionDir, cfg.Logging.Level, rotationConfig)
+	logger, err := logging.NewLoggerWithRotation(sessionDir, cfg.Logging.Level, rotationConfig, sinks...)
 	if err != nil {
 		// Log creation failure shouldn't prevent the application from starting
 		fmt.Fprintf(os.Stderr, "Warning: failed to create logger: %v\n", err)
 		return logging.NopLogger()
 	}
 
-	return logger
+	limits := make([]logging.RateLimit, 0, len(cfg.Logging.RateLimits))
+	for _, limit := range cfg.Logging.RateLimits {
+		limits = append(limits, logging.RateLimit{
+			Msg:      limit.Message,
+			Interval: limit.Interval(),
+			Sample:   limit.Sample,
+		})
+	}
+	return logger.WithRateLimits(limits...)
 }
 
 // promptMultiSessionAction prompts the user to choose what to do when sessions exist
@@ -0,0 +1,89 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/session"
+	"github.com/Iron-Ham/claudio/internal/session/migrate"
+	"github.com/spf13/cobra"
+)
+
+var sessionsUpgradeCmd = &cobra.Command{
+	Use:   "upgrade <session-id>",
+	Short: "Upgrade a session's state files to this version of Claudio",
+	Long: `Migrate a session started under an older Claudio release so it can continue
+under this one: session state (including task retry state) and task queue
+state are migrated to the formats this version writes.
+
+The original files are first copied to .claudio/sessions/<session-id>/backups/
+upgrade-<time>/; copy them back to undo the upgrade. The session must not be
+running. The command also checks that the session's instances were prompted
+with a completion-file protocol this version reads; if not, their unfinished
+tasks must be restarted.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSessionsUpgrade,
+}
+
+var upgradeDryRun bool
+
+func init() {
+	sessionsCmd.AddCommand(sessionsUpgradeCmd)
+	sessionsUpgradeCmd.Flags().BoolVar(&upgradeDryRun, "dry-run", false, "Show what would be migrated without changing anything")
+}
+
+func runSessionsUpgrade(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	sessionID := args[0]
+	if !session.SessionExists(cwd, sessionID) {
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+	sessionDir := session.GetSessionDir(cwd, sessionID)
+	if lock, locked := session.IsLocked(sessionDir); locked {
+		return fmt.Errorf("session %s is running (PID %d); stop it before upgrading", sessionID, lock.PID)
+	}
+
+	result, err := migrate.UpgradeSession(sessionDir, upgradeDryRun)
+	if err != nil {
+		return fmt.Errorf("failed to upgrade session: %w", err)
+	}
+
+	fmt.Println(strings.Repeat("─", 70))
+	fmt.Printf("Session Upgrade: %s\n", sessionID)
+	fmt.Println(strings.Repeat("─", 70))
+	fmt.Println()
+	for _, f := range result.Files {
+		if !f.Changed() {
+			fmt.Printf("  %-40s  %s v%d, current\n", f.Path, f.Kind, f.To)
+			continue
+		}
+		fmt.Printf("  %-40s  %s v%d → v%d\n", f.Path, f.Kind, f.From, f.To)
+		for _, summary := range f.Applied {
+			fmt.Printf("      - %s\n", summary)
+		}
+	}
+	fmt.Println()
+
+	changed := len(result.Changed())
+	switch {
+	case changed == 0:
+		fmt.Println("All state files are current; nothing to migrate.")
+	case upgradeDryRun:
+		fmt.Printf("%d file(s) would be migrated. Run without --dry-run to upgrade.\n", changed)
+	default:
+		rel, err := filepath.Rel(cwd, result.BackupDir)
+		if err != nil {
+			rel = result.BackupDir
+		}
+		fmt.Printf("Migrated %d file(s). Originals are in %s\n", changed, rel)
+	}
+	if result.ProtocolErr != nil {
+		fmt.Printf("\nWarning: %v\n", result.ProtocolErr)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"time"
 
@@ -25,8 +26,13 @@ type Config struct {
 	Tripleshot   TripleshotConfig   `mapstructure:"tripleshot"`
 	Adversarial  AdversarialConfig  `mapstructure:"adversarial"`
 	Logging      LoggingConfig      `mapstructure:"logging"`
+	EventServer  EventServerConfig  `mapstructure:"event_server"`
+	Webhooks     WebhooksConfig     `mapstructure:"webhooks"`
+	API          APIConfig          `mapstructure:"api"`
+	Tracing      TracingConfig      `mapstructure:"tracing"`
 	Paths        PathsConfig        `mapstructure:"paths"`
 	Experimental ExperimentalConfig `mapstructure:"experimental"`
+	Experiments  []ExperimentConfig `mapstructure:"experiments"`
 }
 
 // CompletionConfig controls what happens when an instance completes
@@ -47,8 +53,55 @@ type TUIConfig struct {
 	// SidebarWidth is the width of the sidebar panel in columns (default: 36, min: 20, max: 60)
 	SidebarWidth int `mapstructure:"sidebar_width"`
 	// Theme is the color theme for the TUI (default: "default")
-	// Options: "default", "monokai", "dracula", "nord"
+	// Options include "dark", "light", "high-contrast", "solarized", the other
+	// built-in themes, and custom themes
 	Theme string `mapstructure:"theme"`
+	// Colors overrides single colors of the theme, by color name
+	// ("primary", "status_working", ...), with hex values such as "#FF8800"
+	Colors map[string]string `mapstructure:"colors"`
+	// ConfirmDestructiveInput holds back Ctrl+C, Ctrl+D, and Ctrl+\ in input mode
+	// until they are pressed twice, so a key meant for the TUI doesn't interrupt
+	// or end the backend (default: true)
+	ConfirmDestructiveInput bool `mapstructure:"confirm_destructive_input"`
+	// RequireInputModifier makes Alt+i the only key that enters input mode,
+	// so a stray [i] or [Enter] can't send keystrokes to an instance (default: false)
+	RequireInputModifier bool `mapstructure:"require_input_modifier"`
+	// Mouse enables the mouse: the wheel scrolls, clicking the sidebar selects
+	// an instance, and clicking or dragging over output selects lines to copy.
+	// It takes over the terminal's own text selection (default: false)
+	Mouse bool `mapstructure:"mouse"`
+	// Keys overrides key bindings: for each view (normal, select, dashboard,
+	// split, ultraplan, ...), actions mapped to the keys that replace their
+	// defaults. Keys separated by spaces form a chord, such as "g g"
+	Keys map[string]map[string][]string `mapstructure:"keys"`
+	// Alerts notifies the user when an instance starts waiting for input
+	Alerts TUIAlertsConfig `mapstructure:"alerts"`
+}
+
+// TUIAlertsConfig controls how the TUI alerts the user to instances
+// waiting for input, such as a permission prompt or a question.
+type TUIAlertsConfig struct {
+	// Bell rings the terminal bell when an instance starts waiting (default: true)
+	Bell bool `mapstructure:"bell"`
+	// Desktop shows an OS notification (osascript on macOS, notify-send on
+	// Linux) for instances still waiting after EscalateAfterSeconds (default: false)
+	Desktop bool `mapstructure:"desktop"`
+	// EscalateAfterSeconds is how long an instance waits before the desktop
+	// notification; 0 notifies at once (default: 30)
+	EscalateAfterSeconds int `mapstructure:"escalate_after_seconds"`
+	// MinIntervalSeconds is the least time between desktop notifications;
+	// instances due in between are combined into the next one (default: 60)
+	MinIntervalSeconds int `mapstructure:"min_interval_seconds"`
+}
+
+// EscalateAfter returns how long an instance waits before the desktop notification
+func (a *TUIAlertsConfig) EscalateAfter() time.Duration {
+	return time.Duration(a.EscalateAfterSeconds) * time.Second
+}
+
+// MinInterval returns the least time between desktop notifications
+func (a *TUIAlertsConfig) MinInterval() time.Duration {
+	return time.Duration(a.MinIntervalSeconds) * time.Second
 }
 
 // SessionConfig controls session behavior
@@ -57,10 +110,122 @@ type SessionConfig struct {
 	// When true (default), instances start immediately after being added.
 	// When false, instances are created in pending state and must be started manually with :s.
 	AutoStartOnAdd bool `mapstructure:"auto_start_on_add"`
+	// Storage checkpoints session state to durable storage so a session on an
+	// ephemeral machine (e.g., a CI runner) can be resumed or inspected elsewhere.
+	Storage SessionStorageConfig `mapstructure:"storage"`
+	// Database records every session and its instance metrics in a SQLite
+	// database, so cost and usage can be queried across sessions.
+	Database SessionDatabaseConfig `mapstructure:"database"`
+	// Report writes a Markdown report of each session to .claudio/reports
+	// when it completes.
+	Report SessionReportConfig `mapstructure:"report"`
+	// Archive compresses the directories of completed sessions into
+	// .claudio/archive and prunes old archives.
+	Archive SessionArchiveConfig `mapstructure:"archive"`
+}
+
+// SessionArchiveConfig controls session archival. Sessions that were stopped
+// cleanly and have not changed for AfterDays are compressed into
+// .claudio/archive/<session-id>.tar.zst when Claudio starts.
+type SessionArchiveConfig struct {
+	// AfterDays archives a completed session once it has been idle this many
+	// days, 0 = never (default: 0)
+	AfterDays int `mapstructure:"after_days"`
+	// KeepLast keeps at most this many archives, 0 = no limit (default: 0)
+	KeepLast int `mapstructure:"keep_last"`
+	// MaxTotalMB deletes the oldest archives until the rest fit in this many
+	// megabytes, 0 = no limit (default: 0)
+	MaxTotalMB int `mapstructure:"max_total_mb"`
+}
+
+// SessionReportConfig controls the report written when a session completes:
+// when its ultraplan finishes or when it is stopped. `claudio report`
+// prints it.
+type SessionReportConfig struct {
+	// Enabled writes the report (default: true)
+	Enabled bool `mapstructure:"enabled"`
+	// HTML also writes an HTML copy of the report (default: false)
+	HTML bool `mapstructure:"html"`
+}
+
+// SessionDatabaseConfig controls the session history database. Sessions
+// still run from session.json; the database keeps a copy of each session
+// and its instance metrics that outlives the session directory.
+type SessionDatabaseConfig struct {
+	// Enabled records sessions in the database (default: false)
+	Enabled bool `mapstructure:"enabled"`
+	// Path is the database file (default: .claudio/history.db in the repository)
+	Path string `mapstructure:"path"`
+}
+
+// SessionStorageConfig controls where session checkpoints are written.
+// Sessions always run from the local .claudio directory; checkpoints copy
+// the session directory (session state, queue state, logs) to the backend.
+type SessionStorageConfig struct {
+	// Backend selects the checkpoint store.
+	// Options: "" (no checkpoints), "local", "s3"
+	Backend string `mapstructure:"backend"`
+	// CheckpointIntervalSeconds is how often changed session files are
+	// checkpointed while a session runs (default: 30)
+	CheckpointIntervalSeconds int `mapstructure:"checkpoint_interval_seconds"`
+	// Path is the checkpoint directory for the "local" backend, such as a
+	// mounted volume or a CI cache directory.
+	Path string `mapstructure:"path"`
+	// Prefix is prepended to every checkpoint key (optional).
+	Prefix string `mapstructure:"prefix"`
+	// Bucket is the bucket for the "s3" backend.
+	Bucket string `mapstructure:"bucket"`
+	// Region is the bucket's region (default: $AWS_REGION, then "us-east-1").
+	Region string `mapstructure:"region"`
+	// Endpoint is the URL of an S3-compatible service such as MinIO or R2
+	// (default: AWS S3 for Region).
+	Endpoint string `mapstructure:"endpoint"`
+	// PathStyle addresses the bucket in the URL path instead of the host
+	// name. Most S3-compatible services need it.
+	PathStyle bool `mapstructure:"path_style"`
+}
+
+// CheckpointInterval returns the checkpoint interval as a time.Duration.
+func (c *SessionStorageConfig) CheckpointInterval() time.Duration {
+	return time.Duration(c.CheckpointIntervalSeconds) * time.Second
+}
+
+// ValidSessionStorageBackends returns the valid session.storage.backend values.
+func ValidSessionStorageBackends() []string {
+	return []string{"", "local", "s3"}
+}
+
+// ValidInstanceBackends returns the valid instance.backend values.
+func ValidInstanceBackends() []string {
+	return []string{"tmux", "pty"}
+}
+
+// ValidInstanceOutputFormats returns the valid instance.output_format values.
+func ValidInstanceOutputFormats() []string {
+	return []string{"text", "stream-json"}
+}
+
+// defaultInstanceBackend returns the default instance.backend: pty on
+// Windows, where tmux is not available, and tmux elsewhere.
+func defaultInstanceBackend() string {
+	if runtime.GOOS == "windows" {
+		return "pty"
+	}
+	return "tmux"
 }
 
 // InstanceConfig controls instance behavior
 type InstanceConfig struct {
+	// Backend is what each instance runs in: "tmux" sessions (default), or
+	// "pty" to run the backend directly in a pseudo-terminal without tmux
+	// (default and only choice on Windows)
+	Backend string `mapstructure:"backend"`
+	// OutputFormat is how Claude instances report their progress: "text"
+	// (default) runs them interactively and reads their state from the
+	// rendered screen; "stream-json" runs each one non-interactively with
+	// --output-format stream-json and reads its state from the structured
+	// events, which avoids false matches on rendered text
+	OutputFormat string `mapstructure:"output_format"`
 	// OutputBufferSize is the size of the output ring buffer in bytes
 	OutputBufferSize int `mapstructure:"output_buffer_size"`
 	// CaptureInterval is how often to capture output from tmux (in milliseconds)
@@ -77,15 +242,195 @@ type InstanceConfig struct {
 	CompletionTimeoutMinutes int `mapstructure:"completion_timeout_minutes"`
 	// StaleDetection enables detection of stuck instances via output pattern analysis
 	StaleDetection bool `mapstructure:"stale_detection"`
+	// RecordTranscripts records each instance's screen to an asciicast
+	// transcript in the session directory, for replay with :replay
+	RecordTranscripts bool `mapstructure:"record_transcripts"`
+	// Escalation controls the recovery steps tried before an instance that
+	// trips the activity or stale timeout is marked stuck
+	Escalation EscalationConfig `mapstructure:"escalation"`
+	// TimeoutPolicies override the timeouts and escalation for the instances
+	// of matching ultraplan tasks, e.g. longer timeouts for high-complexity tasks
+	TimeoutPolicies []TimeoutPolicyConfig `mapstructure:"timeout_policies"`
+	// PermissionPolicy answers permission prompts matching its allow rules
+	// without waiting for the user
+	PermissionPolicy PermissionPolicyConfig `mapstructure:"permission_policy"`
+	// ResourceLimits limits the CPU and memory instances and the builds
+	// they run may use
+	ResourceLimits ResourceLimitsConfig `mapstructure:"resource_limits"`
+}
+
+// ResourceLimitsConfig limits the machine resources instances use. Each
+// instance's processes are reniced and given a GOMAXPROCS hint; on Linux
+// with cgroup v2, CPUPercent and MemoryMB are totals divided evenly among
+// the running instances.
+type ResourceLimitsConfig struct {
+	// Enabled applies the limits to every instance (default: false)
+	Enabled bool `mapstructure:"enabled"`
+	// Nice is the niceness of instance processes, 0-19 (default: 10)
+	Nice int `mapstructure:"nice"`
+	// GoMaxProcs is the GOMAXPROCS set for instances, so Go builds they run
+	// share the CPUs (0 = CPUs divided by the running instances)
+	GoMaxProcs int `mapstructure:"gomaxprocs"`
+	// CPUPercent is the CPU time all instances may use together, in percent
+	// of one CPU, e.g. 400 for four CPUs (0 = unlimited; Linux only)
+	CPUPercent int `mapstructure:"cpu_percent"`
+	// MemoryMB is the memory all instances may use together, in MiB
+	// (0 = unlimited; Linux only)
+	MemoryMB int `mapstructure:"memory_mb"`
+	// CgroupParent is the cgroup v2 directory to create instance cgroups in
+	// (empty = the cgroup Claudio runs in)
+	CgroupParent string `mapstructure:"cgroup_parent"`
+}
+
+// PermissionPolicyConfig controls automatic answers to permission prompts.
+// When an instance asks for permission, deny rules are checked first, then
+// allow rules; a prompt an allow rule matches is approved by sending
+// Response, and any other prompt waits for the user.
+type PermissionPolicyConfig struct {
+	// Enabled evaluates permission prompts against Rules (default: false)
+	Enabled bool `mapstructure:"enabled"`
+	// Response is the input sent to approve a prompt (default: "y")
+	Response string `mapstructure:"response"`
+	// Rules are the allow and deny rules
+	Rules []PermissionRuleConfig `mapstructure:"rules"`
+}
+
+// PermissionRuleConfig matches permission prompts. Every criterion set must
+// match; any entry of a list matching is enough.
+type PermissionRuleConfig struct {
+	// Name identifies the rule in logs and the audit log
+	Name string `mapstructure:"name"`
+	// Action is what to do with matching prompts. Options: "allow", "deny"
+	Action string `mapstructure:"action"`
+	// Tools are tool names, such as "Bash", "Edit", "Read"
+	Tools []string `mapstructure:"tools"`
+	// Commands are globs matched against the whole shell command (* matches anything)
+	Commands []string `mapstructure:"commands"`
+	// Paths are globs matched against the file path, relative to the worktree
+	// (* stays within a directory, ** spans directories)
+	Paths []string `mapstructure:"paths"`
+	// Prompt is a regular expression matched against the prompt text
+	Prompt string `mapstructure:"prompt"`
+}
+
+// TimeoutPolicyConfig is a per-task timeout policy. A task uses the policy
+// it names in its timeout_policy field, or else the first policy listing its
+// estimated complexity. Zero thresholds keep the global values.
+type TimeoutPolicyConfig struct {
+	// Name identifies the policy; plan tasks can select it by name
+	Name string `mapstructure:"name"`
+	// Complexity lists the task complexities the policy applies to.
+	// Options: "low", "medium", "high"
+	Complexity []string `mapstructure:"complexity"`
+	// ActivityTimeoutMinutes replaces instance.activity_timeout_minutes (0 = keep global)
+	ActivityTimeoutMinutes int `mapstructure:"activity_timeout_minutes"`
+	// CompletionTimeoutMinutes replaces instance.completion_timeout_minutes (0 = keep global)
+	CompletionTimeoutMinutes int `mapstructure:"completion_timeout_minutes"`
+	// StaleThreshold is the number of unchanged output captures before the
+	// instance counts as stale (0 = keep global)
+	StaleThreshold int `mapstructure:"stale_threshold"`
+	// Actions are the escalation steps taken on an activity or stale
+	// timeout, in order. Options: "warn", "nudge", "restart", "fail".
+	// The instance is marked stuck after the last action. Empty uses
+	// instance.escalation.
+	Actions []string `mapstructure:"actions"`
+	// NudgeMessage is the message sent by the nudge action (empty = instance.escalation.nudge_message)
+	NudgeMessage string `mapstructure:"nudge_message"`
+}
+
+// EscalationConfig controls the escalation ladder for stalled instances.
+// Each step is tried in order, waiting StepWaitSeconds for new output after
+// each one; the instance is marked stuck only when every step fails.
+type EscalationConfig struct {
+	// Enabled runs the ladder on activity and stale timeouts (default: true).
+	// When false, stalled instances are marked stuck immediately.
+	Enabled bool `mapstructure:"enabled"`
+	// Steps lists the ladder steps in order. Options: "nudge", "interview",
+	// "interrupt", "restart", "mark_stuck". mark_stuck always ends the ladder
+	// and is added when omitted.
+	Steps []string `mapstructure:"steps"`
+	// StepWaitSeconds is how long to wait for new output after each step (default: 120)
+	StepWaitSeconds int `mapstructure:"step_wait_seconds"`
+	// MaxNudges is how many times the nudge step sends its message, waiting
+	// StepWaitSeconds after each, before moving on (default: 1)
+	MaxNudges int `mapstructure:"max_nudges"`
+	// NudgeMessage is the message sent by the nudge step (empty = built-in message)
+	NudgeMessage string `mapstructure:"nudge_message"`
+	// InterviewPrompt is the prompt sent by the interview step (empty = built-in prompt)
+	InterviewPrompt string `mapstructure:"interview_prompt"`
+}
+
+// StepWait returns the per-step wait as a time.Duration
+func (c *EscalationConfig) StepWait() time.Duration {
+	return time.Duration(c.StepWaitSeconds) * time.Second
 }
 
 // AIConfig controls which AI backend Claudio uses.
 type AIConfig struct {
 	// Backend selects the AI backend to use for instances and AI workflows.
-	// Options: "claude"
+	// Options: "claude", or the name of one of CLIBackends
 	Backend string `mapstructure:"backend"`
 	// Claude-specific settings
 	Claude ClaudeBackendConfig `mapstructure:"claude"`
+	// CLIBackends defines additional agent CLIs (e.g., Aider, OpenHands) that
+	// instances and plan tasks can select by name.
+	CLIBackends []CLIBackendConfig `mapstructure:"cli_backends"`
+}
+
+// CLIBackendNames returns the names of the configured CLI backends.
+func (c *AIConfig) CLIBackendNames() []string {
+	names := make([]string, 0, len(c.CLIBackends))
+	for _, b := range c.CLIBackends {
+		names = append(names, b.Name)
+	}
+	return names
+}
+
+// BackendNames returns every backend name ai.backend may select: the
+// built-in backends followed by the configured CLI backends.
+func (c *AIConfig) BackendNames() []string {
+	return append(ValidAIBackends(), c.CLIBackendNames()...)
+}
+
+// CLIBackendConfig describes an agent CLI that Claudio drives the same way it
+// drives Claude Code: started in a tmux session with the task prompt, its
+// output captured and matched against state patterns.
+type CLIBackendConfig struct {
+	// Name identifies the backend in ai.backend and in plan task "backend" fields.
+	Name string `mapstructure:"name"`
+	// DisplayName is shown in the UI (default: Name).
+	DisplayName string `mapstructure:"display_name"`
+	// Command is the shell command template that starts the agent. The
+	// placeholder {prompt} expands to the quoted prompt text and
+	// {prompt_file} to the quoted path of the file holding it. Including
+	// {session_id} passes the agent a session ID Claudio generates.
+	Command string `mapstructure:"command"`
+	// ResumeCommand is the shell command template that resumes a session,
+	// with {session_id} as the placeholder. Empty means resume is unsupported.
+	// Resuming needs the session ID, so Command must include {session_id} too.
+	ResumeCommand string `mapstructure:"resume_command"`
+	// PromptFile is the name of the prompt file written to the worktree
+	// (default: ".claudio-prompt").
+	PromptFile string `mapstructure:"prompt_file"`
+	// Completion selects how Claudio learns that the agent finished.
+	// Options: "sentinel" (the agent writes the task completion file, as
+	// instructed by the prompt), "exit" (the agent is done when the command exits)
+	Completion string `mapstructure:"completion"`
+	// Metrics selects the format of token and cost reporting in the output.
+	// Options: "none", "claude"
+	Metrics string `mapstructure:"metrics"`
+	// ReadyPatterns match output shown when the agent is waiting for input.
+	// The remaining pattern lists match the other detected states. Empty
+	// lists fall back to the Claude Code patterns.
+	ReadyPatterns      []string `mapstructure:"ready_patterns"`
+	WorkingPatterns    []string `mapstructure:"working_patterns"`
+	CompletionPatterns []string `mapstructure:"completion_patterns"`
+	ErrorPatterns      []string `mapstructure:"error_patterns"`
+	PermissionPatterns []string `mapstructure:"permission_patterns"`
+	QuestionPatterns   []string `mapstructure:"question_patterns"`
+	// LocalConfigFiles are untracked files copied into each worktree
+	// (e.g., ".aider.conf.yml").
+	LocalConfigFiles []string `mapstructure:"local_config_files"`
 }
 
 // ClaudeBackendConfig controls Claude-specific settings.
@@ -119,6 +464,11 @@ type ClaudeBackendConfig struct {
 	// When true, Claude Code creates and manages its own git worktree instead of Claudio
 	// managing worktrees externally.
 	NativeWorktree bool `mapstructure:"native_worktree"`
+	// Hooks installs Claude Code hooks (PostToolUse, Stop, Notification,
+	// UserPromptSubmit) in each worktree's .claude/settings.local.json that
+	// report to a local Claudio listener. An instance's hook events then
+	// decide its state instead of its terminal output (default: false).
+	Hooks bool `mapstructure:"hooks"`
 }
 
 // ResolvedPermissionMode returns the effective permission mode by resolving the
@@ -163,6 +513,15 @@ type PRConfig struct {
 	Reviewers ReviewerConfig `mapstructure:"reviewers"`
 	// Labels to add to all PRs by default
 	Labels []string `mapstructure:"labels"`
+	// MissingCLI controls what happens when the gh CLI is not installed:
+	// "degrade" pushes branches and records the PRs as pending so
+	// `claudio pr create --pending` can create them later, "fail" stops
+	// before anything is pushed (default: "degrade")
+	MissingCLI string `mapstructure:"missing_cli"`
+	// Provider selects where pull requests are created: "github" (gh),
+	// "gitlab" (glab merge requests), "bitbucket" (Bitbucket Cloud REST API),
+	// or "auto" to pick from the origin remote's host (default: "auto")
+	Provider string `mapstructure:"provider"`
 }
 
 // ReviewerConfig controls automatic reviewer assignment
@@ -177,8 +536,18 @@ type ReviewerConfig struct {
 type CleanupConfig struct {
 	// WarnOnStale shows a warning on start if stale resources exist (default: true)
 	WarnOnStale bool `mapstructure:"warn_on_stale"`
+	// AutoGC removes orphaned tmux sessions and clean orphaned worktrees on
+	// start when no other session is running (default: true)
+	AutoGC bool `mapstructure:"auto_gc"`
 	// KeepRemoteBranches prevents deletion of branches that exist on remote (default: true)
 	KeepRemoteBranches bool `mapstructure:"keep_remote_branches"`
+	// ReapMerged deletes task and group branches and removes task worktrees once
+	// the ultra-plan PRs that carry them have merged (default: true). Remote
+	// branches are only deleted when KeepRemoteBranches is false.
+	ReapMerged bool `mapstructure:"reap_merged"`
+	// MergedRetentionHours keeps reapable branches and worktrees this many hours
+	// after the last PR merges, 0 = reap as soon as the merge is seen (default: 0)
+	MergedRetentionHours int `mapstructure:"merged_retention_hours"`
 }
 
 // ResourceConfig controls resource monitoring and cost tracking
@@ -189,6 +558,11 @@ type ResourceConfig struct {
 	CostLimit float64 `mapstructure:"cost_limit"`
 	// TokenLimitPerInstance limits tokens per instance, 0 = no limit
 	TokenLimitPerInstance int64 `mapstructure:"token_limit_per_instance"`
+	// InstanceCostLimit limits the cost of each instance (USD), 0 = no limit
+	InstanceCostLimit float64 `mapstructure:"instance_cost_limit"`
+	// BudgetAction is what happens to instances over a hard limit: "pause"
+	// suspends them so they can be resumed, "stop" ends them (default: "pause")
+	BudgetAction string `mapstructure:"budget_action"`
 	// ShowMetricsInSidebar shows token/cost metrics in TUI sidebar
 	ShowMetricsInSidebar bool `mapstructure:"show_metrics_in_sidebar"`
 }
@@ -212,18 +586,256 @@ type UltraplanConfig struct {
 	// ConsolidationMode controls how completed work is consolidated: "stacked" creates
 	// a chain of PRs, "single" merges all work into one PR (default: "stacked")
 	ConsolidationMode string `mapstructure:"consolidation_mode"`
+	// MergeQueue consolidates task branches with rerere enabled, resolves
+	// conflicts where both sides only added lines, and retries conflicting
+	// branches after the others, pausing only for overlapping changes
+	// (default: false)
+	MergeQueue bool `mapstructure:"merge_queue"`
+	// ConflictResolver hands a cherry-pick conflict during group consolidation
+	// to an instance started in the consolidation worktree, and carries on
+	// once it reports the conflicts resolved (default: false)
+	ConflictResolver bool `mapstructure:"conflict_resolver"`
 	// CreateDraftPRs creates PRs as drafts during consolidation (default: true)
 	CreateDraftPRs bool `mapstructure:"create_draft_prs"`
 	// PRLabels are labels to add to PRs created during consolidation (default: ["ultraplan"])
 	PRLabels []string `mapstructure:"pr_labels"`
 	// BranchPrefix overrides branch.prefix for ultraplan branches (default: "" uses branch.prefix)
 	BranchPrefix string `mapstructure:"branch_prefix"`
+	// MaxBehindCommits is how many commits the consolidated branch may trail origin's
+	// main branch before PRs are flagged as stale (default: 20, 0 = disable the check)
+	MaxBehindCommits int `mapstructure:"max_behind_commits"`
+	// AutoRebase rebases stale consolidated branches onto origin's main branch and
+	// re-runs verification before opening PRs (default: false)
+	AutoRebase bool `mapstructure:"auto_rebase"`
+	// DriftAction is what happens when origin's main branch gains commits while
+	// a plan runs: "off" ignores them, "warn" reports the remaining tasks whose
+	// files they touched, and "replan" also tells affected tasks that start
+	// afterwards what changed (default: "warn")
+	DriftAction string `mapstructure:"drift_action"`
+	// ConflictPrediction is what happens to parallel tasks predicted to
+	// conflict, by shared files, shared Go packages, or imports between
+	// their packages: "off" skips the analysis, "warn" shows them in the plan
+	// view, and "serialize" also makes tasks that share files run one after
+	// the other (default: "warn")
+	ConflictPrediction string `mapstructure:"conflict_prediction"`
 
 	// Task verification settings
 	// MaxTaskRetries is the max retry attempts for tasks that produce no commits (default: 3)
 	MaxTaskRetries int `mapstructure:"max_task_retries"`
+	// Retry controls how tasks that produce no commits are retried
+	Retry RetryConfig `mapstructure:"retry"`
+	// Models selects the model each phase and task runs on
+	Models ModelsConfig `mapstructure:"models"`
+	// Verify runs project commands in the consolidated worktree after each
+	// group is consolidated
+	Verify GroupVerifyConfig `mapstructure:"verify"`
 	// RequireVerifiedCommits requires tasks to produce commits to be marked successful (default: true)
 	RequireVerifiedCommits bool `mapstructure:"require_verified_commits"`
+	// FreshVerification re-runs verification commands even when a result for
+	// the same tree and command is cached (default: false)
+	FreshVerification bool `mapstructure:"fresh_verification"`
+	// GroupApproval pauses after each execution group is consolidated until
+	// a human approves starting the next one, from the TUI or the control
+	// API (default: false)
+	GroupApproval bool `mapstructure:"group_approval"`
+	// SynthesisReviewers is how many reviewers review in parallel during
+	// synthesis, each with its own focus (correctness, tests, architecture),
+	// their issues deduplicated and merged with severity voting, 1-3
+	// (default: 1, a single synthesis instance)
+	SynthesisReviewers int `mapstructure:"synthesis_reviewers"`
+	// SelfReview ends every task prompt with a self-review step: review the
+	// diff, run the tests, and report a confidence score in the completion
+	// file. Synthesis reviews low-confidence tasks first (default: false)
+	SelfReview bool `mapstructure:"self_review"`
+	// ProgressSummaryMinutes is how often each running task is asked to
+	// write a summary of its progress. The latest is given to the task's
+	// next attempt, so a retry or restart continues instead of starting
+	// over, 0 = never ask (default: 0)
+	ProgressSummaryMinutes int `mapstructure:"progress_summary_minutes"`
+	// EnforceFileClaims installs a pre-commit hook in each pipeline task's
+	// worktree that rejects commits touching files claimed by another task
+	// (default: false, claims are advisory)
+	EnforceFileClaims bool `mapstructure:"enforce_file_claims"`
+	// PriorityAgingSeconds is how long a ready pipeline task waits before its
+	// priority is raised by one level, so low-priority tasks aren't starved,
+	// 0 = no aging (default: 60)
+	PriorityAgingSeconds int `mapstructure:"priority_aging_seconds"`
+
+	// Preemption pauses low-priority running tasks for urgent waiting ones
+	Preemption PreemptionConfig `mapstructure:"preemption"`
+
+	// Scaling makes pipeline teams weigh session spend when deciding how
+	// many instances to run
+	Scaling ScalingConfig `mapstructure:"scaling"`
+
+	// WorkStealing lets a pipeline team that has finished its tasks run ready
+	// tasks from other teams in the same repository (default: false)
+	WorkStealing bool `mapstructure:"work_stealing"`
+
+	// Repos maps the repository names plan tasks give in their "repo" field
+	// to local checkouts. Pipeline teams for those tasks create their
+	// worktrees in the named repository; tasks without a repo, or naming
+	// one not listed here, run in the session's repository (default: none)
+	Repos map[string]string `mapstructure:"repos"`
+
+	// MessageGuard is what happens to an inter-instance message that looks
+	// like instructions for its recipient: "strip" removes the suspicious
+	// lines and delivers the rest, "hold" keeps the message out of prompts
+	// until it is released with :release (default: "strip")
+	MessageGuard string `mapstructure:"message_guard"`
+
+	// Placement schedules pipeline task instances onto worker nodes for self-hosted backends
+	Placement PlacementConfig `mapstructure:"placement"`
+
+	// Templates are objective templates selectable with --template or the TUI
+	// picker. A template named like a built-in replaces it.
+	Templates []ObjectiveTemplateConfig `mapstructure:"templates"`
+}
+
+// RetryConfig controls how ultraplan tasks that produce no commits are
+// retried.
+type RetryConfig struct {
+	// BackoffSeconds is the wait before a task's first retry, doubling for
+	// each retry after that, 0 = retry immediately (default: 15)
+	BackoffSeconds int `mapstructure:"backoff_seconds"`
+	// MaxBackoffSeconds caps the wait between retries, 0 = no cap (default: 300)
+	MaxBackoffSeconds int `mapstructure:"max_backoff_seconds"`
+	// AugmentPrompt tells each retry why the previous attempt failed and
+	// shows the uncommitted changes it left behind (default: true)
+	AugmentPrompt bool `mapstructure:"augment_prompt"`
+	// FinalAttemptModel runs a task's last retry on this model, e.g. "opus"
+	// when tasks usually run on "sonnet" (default: "" keeps the usual model)
+	FinalAttemptModel string `mapstructure:"final_attempt_model"`
+}
+
+// PreemptionConfig controls whether the adaptive lead pauses a running
+// pipeline task so a much more urgent ready task can have its instance.
+type PreemptionConfig struct {
+	// Enabled turns preemption on (default: false)
+	Enabled bool `mapstructure:"enabled"`
+	// WaitSeconds is how long a ready task waits for an instance before it
+	// may preempt a running one (default: 120)
+	WaitSeconds int `mapstructure:"wait_seconds"`
+	// MinPriorityGap is how many priority levels the waiting task must be
+	// ahead of the running task it preempts, at least 1 (default: 2)
+	MinPriorityGap int `mapstructure:"min_priority_gap"`
+}
+
+// ScalingConfig controls the cost-aware scaling of pipeline teams. Spend is
+// the session's, measured from instance metrics.
+type ScalingConfig struct {
+	// BudgetCeiling is the spend (USD) a team's projected cost must stay
+	// within; scale-ups that would exceed it are vetoed. A team with a
+	// budget of its own uses that instead. 0 = no ceiling (default: 0)
+	BudgetCeiling float64 `mapstructure:"budget_ceiling"`
+	// BurnRateLimit is the spend velocity (USD per hour) above which teams
+	// scale down. 0 = no limit (default: 0)
+	BurnRateLimit float64 `mapstructure:"burn_rate_limit"`
+}
+
+// GroupVerifyConfig lists the commands Claudio runs itself to verify each
+// consolidated group, instead of trusting the consolidator's report.
+type GroupVerifyConfig struct {
+	// Commands run in order in the consolidated worktree (default: none)
+	Commands []VerifyCommandConfig `mapstructure:"commands"`
+	// AutoDetect runs build, lint, and test commands derived from the
+	// worktree's project files (go.mod, Cargo.toml, package.json,
+	// pyproject.toml, Makefile) when Commands is empty (default: true)
+	AutoDetect bool `mapstructure:"auto_detect"`
+	// OnFailure is what happens when a command fails: "fail" fails the
+	// group, "pause" waits for approval before the next group starts
+	// (default: "fail")
+	OnFailure string `mapstructure:"on_failure"`
+	// TimeoutSeconds stops a command that runs longer, 0 = no limit
+	// (default: 600)
+	TimeoutSeconds int `mapstructure:"timeout_seconds"`
+}
+
+// VerifyCommandConfig is a project command run to verify a consolidated group.
+type VerifyCommandConfig struct {
+	// Name labels the command in results (e.g. "test"; default: the command)
+	Name string `mapstructure:"name"`
+	// Command is run with sh -c in the worktree (e.g. "go test ./...")
+	Command string `mapstructure:"command"`
+}
+
+// ModelsConfig selects the model each ultraplan phase runs on. Empty values
+// keep the backend's configured model.
+type ModelsConfig struct {
+	// Planning is the model for planning and multi-pass plan selection
+	Planning string `mapstructure:"planning"`
+	// Execution is the model for task instances
+	Execution string `mapstructure:"execution"`
+	// Synthesis is the model for the synthesis review
+	Synthesis string `mapstructure:"synthesis"`
+	// Revision is the model for revision instances
+	Revision string `mapstructure:"revision"`
+	// Consolidation is the model for consolidation instances
+	Consolidation string `mapstructure:"consolidation"`
+	// ByComplexity overrides Execution for tasks by their estimated
+	// complexity: "low", "medium", or "high" (e.g. high: opus)
+	ByComplexity map[string]string `mapstructure:"by_complexity"`
+}
+
+// ObjectiveTemplateConfig describes a reusable ultraplan objective.
+type ObjectiveTemplateConfig struct {
+	// Name selects the template (e.g. "upgrade" for --template upgrade)
+	Name string `mapstructure:"name"`
+	// Description is the one-line summary shown in template listings
+	Description string `mapstructure:"description"`
+	// Prefix is placed before the user's objective (e.g. "Upgrade: ")
+	Prefix string `mapstructure:"prefix"`
+	// Constraints are added to the objective as constraints the plan must respect
+	Constraints []string `mapstructure:"constraints"`
+	// Verification lists checks that must pass before the work is complete
+	Verification []string `mapstructure:"verification"`
+	// ConsolidationMode overrides ultraplan.consolidation_mode: "stacked" or "single"
+	ConsolidationMode string `mapstructure:"consolidation_mode"`
+	// Goal is objective text with {{variable}} placeholders, placed before
+	// any objective the user gives (e.g. "Add test coverage for {{package}}")
+	Goal string `mapstructure:"goal"`
+	// Hints are planning hints added to the objective
+	Hints []string `mapstructure:"hints"`
+	// Variables are the typed values the template's placeholders are
+	// filled in with
+	Variables []TemplateVariableConfig `mapstructure:"variables"`
+}
+
+// TemplateVariableConfig describes a typed objective template variable.
+type TemplateVariableConfig struct {
+	// Name is used in placeholders as {{name}} and given as --var name=value
+	Name string `mapstructure:"name"`
+	// Type is string, int, bool, path, or choice (default: string)
+	Type string `mapstructure:"type"`
+	// Description is shown in template listings and missing-value errors
+	Description string `mapstructure:"description"`
+	// Default is used when no value is given; a variable without one is required
+	Default string `mapstructure:"default"`
+	// Options are the allowed values of a choice variable
+	Options []string `mapstructure:"options"`
+}
+
+// PlacementConfig controls which worker node each pipeline task instance runs
+// against. Placement is disabled while Nodes is empty.
+type PlacementConfig struct {
+	// Policy selects among nodes with free capacity: "spread" balances load
+	// across nodes, "pack" fills one node before the next (default: "spread")
+	Policy string `mapstructure:"policy"`
+	// Nodes is the worker node inventory
+	Nodes []NodeConfig `mapstructure:"nodes"`
+}
+
+// NodeConfig describes a worker node that serves a self-hosted backend.
+type NodeConfig struct {
+	// ID names the node in the TUI and placement events
+	ID string `mapstructure:"id"`
+	// Capacity is the maximum number of concurrent instances on the node
+	Capacity int `mapstructure:"capacity"`
+	// Backends lists the backends the node serves (empty = any backend)
+	Backends []string `mapstructure:"backends"`
+	// Env holds KEY=VALUE entries exported for instances placed on the node,
+	// typically the node's endpoint. Example: ["ANTHROPIC_BASE_URL=http://gpu-1:8000"]
+	Env []string `mapstructure:"env"`
 }
 
 // NotificationConfig controls notification behavior for ultraplan
@@ -283,6 +895,171 @@ type LoggingConfig struct {
 	MaxSizeMB int `mapstructure:"max_size_mb"`
 	// MaxBackups is the number of backup log files to keep (default: 3)
 	MaxBackups int `mapstructure:"max_backups"`
+	// Sinks are where log records are sent in addition to the session's
+	// debug.log; none keeps logs in the file only
+	Sinks []LogSinkConfig `mapstructure:"sinks"`
+	// RateLimits cap how often noisy DEBUG and INFO messages are logged
+	// (default: "instance status check" once per 30s and "output captured"
+	// once per 10s, per instance)
+	RateLimits []LogRateLimitConfig `mapstructure:"rate_limits"`
+}
+
+// LogRateLimitConfig caps how often one log message is written. Each
+// instance's occurrences are counted separately.
+type LogRateLimitConfig struct {
+	// Message is the exact log message limited
+	Message string `mapstructure:"message"`
+	// IntervalSeconds is the least time between two logged occurrences
+	IntervalSeconds int `mapstructure:"interval_seconds"`
+	// Sample logs one in every Sample occurrences
+	Sample int `mapstructure:"sample"`
+}
+
+// Interval returns the least time between logged occurrences
+func (r LogRateLimitConfig) Interval() time.Duration {
+	return time.Duration(r.IntervalSeconds) * time.Second
+}
+
+// LogSinkConfig is a destination log records are shipped to.
+type LogSinkConfig struct {
+	// Type is the destination: "stderr" (JSON lines), "syslog", or "http"
+	Type string `mapstructure:"type"`
+	// Address is the syslog server, as "udp://host:514", "tcp://host:514",
+	// or "unix:///dev/log" (default: the local syslog daemon)
+	Address string `mapstructure:"address"`
+	// Tag is the syslog tag (default: "claudio")
+	Tag string `mapstructure:"tag"`
+	// URL is the endpoint records are posted to, for the http sink
+	URL string `mapstructure:"url"`
+	// Format is the http payload: "json" for newline-delimited JSON, or
+	// "loki" for the Loki push API (default: "json")
+	Format string `mapstructure:"format"`
+	// Headers are sent with each http request, e.g. for authorization
+	Headers map[string]string `mapstructure:"headers"`
+	// Labels are the Loki stream labels (default: job=claudio)
+	Labels map[string]string `mapstructure:"labels"`
+	// BufferSize is how many records are kept while the sink is
+	// unreachable; beyond it the oldest are dropped (default: 1000)
+	BufferSize int `mapstructure:"buffer_size"`
+}
+
+// ValidLogSinkTypes returns the valid log sink types
+func ValidLogSinkTypes() []string {
+	return []string{"stderr", "syslog", "http"}
+}
+
+// ValidLogSinkFormats returns the valid http log sink payload formats
+func ValidLogSinkFormats() []string {
+	return []string{"json", "loki"}
+}
+
+// EventServerConfig controls the server that streams a session's events to
+// external dashboards as Server-Sent Events.
+type EventServerConfig struct {
+	// Enabled starts the event server with each session (default: false)
+	Enabled bool `mapstructure:"enabled"`
+	// Address is the host:port to listen on (default: "127.0.0.1:7878").
+	// Use port 0 to pick a free port; the address is logged at startup.
+	Address string `mapstructure:"address"`
+	// ReplaySize is how many recent events are kept for clients that
+	// reconnect or ask for a replay (default: 256)
+	ReplaySize int `mapstructure:"replay_size"`
+}
+
+// WebhooksConfig controls chat messages posted to Slack or Discord incoming
+// webhooks when key session events happen.
+type WebhooksConfig struct {
+	// Endpoints are the webhooks to post to; none disables webhook messages
+	Endpoints []WebhookEndpointConfig `mapstructure:"endpoints"`
+	// TimeoutSeconds bounds each webhook request (default: 10)
+	TimeoutSeconds int `mapstructure:"timeout_seconds"`
+	// Events enables or disables each kind of message (default: all enabled)
+	Events WebhookEventsConfig `mapstructure:"events"`
+	// Templates replace each kind's message with a Go text/template; empty
+	// keeps the built-in message
+	Templates WebhookTemplatesConfig `mapstructure:"templates"`
+}
+
+// Timeout returns the request timeout as a time.Duration
+func (w *WebhooksConfig) Timeout() time.Duration {
+	return time.Duration(w.TimeoutSeconds) * time.Second
+}
+
+// WebhookEndpointConfig is one webhook messages are posted to.
+type WebhookEndpointConfig struct {
+	// URL is the incoming webhook URL
+	URL string `mapstructure:"url"`
+	// Format is the payload: "slack" or "discord" (default: "discord" for
+	// discord.com URLs, "slack" otherwise)
+	Format string `mapstructure:"format"`
+}
+
+// WebhookEventsConfig enables each kind of webhook message.
+type WebhookEventsConfig struct {
+	// PhaseChanged posts when an ultraplan moves to a new phase
+	PhaseChanged bool `mapstructure:"phase_changed"`
+	// TaskFailed posts when a planned task fails
+	TaskFailed bool `mapstructure:"task_failed"`
+	// PROpened posts when a pull request is opened
+	PROpened bool `mapstructure:"pr_opened"`
+	// BudgetWarning posts when the session reaches resources.cost_warning_threshold
+	BudgetWarning bool `mapstructure:"budget_warning"`
+	// WaitingInput posts when an instance is waiting for input
+	WaitingInput bool `mapstructure:"waiting_input"`
+}
+
+// WebhookTemplatesConfig holds the message template of each kind of
+// webhook message.
+type WebhookTemplatesConfig struct {
+	PhaseChanged  string `mapstructure:"phase_changed"`
+	TaskFailed    string `mapstructure:"task_failed"`
+	PROpened      string `mapstructure:"pr_opened"`
+	BudgetWarning string `mapstructure:"budget_warning"`
+	WaitingInput  string `mapstructure:"waiting_input"`
+}
+
+// ValidWebhookFormats returns the valid webhook payload formats
+func ValidWebhookFormats() []string {
+	return []string{"slack", "discord"}
+}
+
+// APIConfig controls the gRPC control API, through which external tools
+// inspect and steer a running session.
+type APIConfig struct {
+	// Enabled starts the API server with each session (default: false)
+	Enabled bool `mapstructure:"enabled"`
+	// Address is the host:port to listen on (default: "127.0.0.1:7880").
+	// Use port 0 to pick a free port; the address is logged at startup.
+	Address string `mapstructure:"address"`
+	// Token, when set, must be sent by clients as "authorization: Bearer
+	// <token>" metadata. Set it through CLAUDIO_API_TOKEN rather than the
+	// config file (default: "")
+	Token string `mapstructure:"token"`
+}
+
+// TracingConfig controls OpenTelemetry tracing of ultra-plan runs: a span
+// per session, phase, and task, so long runs show where their time went.
+type TracingConfig struct {
+	// Enabled exports spans with each session (default: false)
+	Enabled bool `mapstructure:"enabled"`
+	// Exporter selects where spans go: "otlp" sends them to an OTLP
+	// collector over gRPC, "file" appends them as JSON to traces.jsonl in
+	// the session directory (default: "otlp")
+	Exporter string `mapstructure:"exporter"`
+	// Endpoint is the collector's host:port for "otlp". Empty uses
+	// OTEL_EXPORTER_OTLP_ENDPOINT, then localhost:4317 (default: "")
+	Endpoint string `mapstructure:"endpoint"`
+	// Insecure connects to the collector without TLS (default: true)
+	Insecure bool `mapstructure:"insecure"`
+	// SampleRatio is the fraction of sessions traced, from 0 to 1 (default: 1)
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+	// ServiceName is the service.name resource attribute (default: "claudio")
+	ServiceName string `mapstructure:"service_name"`
+}
+
+// ValidTracingExporters returns the valid tracing.exporter values.
+func ValidTracingExporters() []string {
+	return []string{"otlp", "file"}
 }
 
 // PathsConfig controls where Claudio stores data
@@ -298,6 +1075,40 @@ type PathsConfig struct {
 	// When enabled, only specified directories are checked out, reducing
 	// disk usage and improving performance for large monorepos.
 	SparseCheckout SparseCheckoutConfig `mapstructure:"sparse_checkout"`
+
+	// Bootstrap configures preparation steps run in each new worktree before
+	// its instance starts, so instances do not rebuild dependency caches.
+	Bootstrap BootstrapConfig `mapstructure:"bootstrap"`
+}
+
+// BootstrapConfig controls worktree bootstrapping: linking shared cache
+// directories from the main repository and running setup commands in a fresh
+// worktree before the instance starts. Timing is recorded per instance so the
+// startup cost is visible in `claudio stats`.
+type BootstrapConfig struct {
+	// Enabled controls whether new worktrees are bootstrapped (default: false).
+	Enabled bool `mapstructure:"enabled"`
+
+	// SharedDirs lists repository-relative directories symlinked from the main
+	// repository into each worktree instead of being rebuilt.
+	// Examples: ["node_modules", ".build", "Pods"]
+	SharedDirs []string `mapstructure:"shared_dirs"`
+
+	// Commands are shell commands run sequentially in the worktree after
+	// SharedDirs are linked. Examples: ["npm ci --prefer-offline", "go mod download"]
+	Commands []string `mapstructure:"commands"`
+
+	// Env holds extra KEY=VALUE environment entries for Commands.
+	// Example: ["GOFLAGS=-mod=mod"]
+	Env []string `mapstructure:"env"`
+
+	// TimeoutSeconds bounds the total time spent running Commands (0 = no limit).
+	TimeoutSeconds int `mapstructure:"timeout_seconds"`
+}
+
+// Timeout returns the command timeout as a time.Duration (0 means no limit)
+func (b *BootstrapConfig) Timeout() time.Duration {
+	return time.Duration(b.TimeoutSeconds) * time.Second
 }
 
 // SparseCheckoutConfig controls partial worktree cloning using git sparse-checkout.
@@ -360,6 +1171,38 @@ type ExperimentalConfig struct {
 	// instead of tmux sessions for pipeline instances. This replaces screen scraping with typed
 	// NDJSON event parsing for more reliable completion detection. (default: false)
 	SubprocessMode bool `mapstructure:"subprocess_mode"`
+
+	// GitBackend selects how branches are created, cherry-picked, and pushed
+	// during consolidation: "exec" runs the git binary (default), "go-git" uses
+	// a pure Go implementation so no git binary is needed. The go-git backend
+	// reports a conflict whenever both sides changed the same file.
+	GitBackend string `mapstructure:"git_backend"`
+}
+
+// ExperimentConfig defines a prompt A/B experiment. A fraction of subjects
+// (tasks or whole sessions) is enrolled and split across variants by weight;
+// everyone else runs the unmodified "control" prompt.
+type ExperimentConfig struct {
+	// Name identifies the experiment in recorded outcomes and reports
+	Name string `mapstructure:"name"`
+	// Phase is the prompt phase the experiment applies to (currently only "task")
+	Phase string `mapstructure:"phase"`
+	// Unit controls what is randomized: "task" (default) or "session"
+	Unit string `mapstructure:"unit"`
+	// Fraction of subjects enrolled in a non-control variant, in (0, 1] (default: 1)
+	Fraction float64 `mapstructure:"fraction"`
+	// Variants are the alternative prompt templates under test
+	Variants []ExperimentVariantConfig `mapstructure:"variants"`
+}
+
+// ExperimentVariantConfig is one arm of a prompt experiment.
+type ExperimentVariantConfig struct {
+	// Name identifies the variant; "control" is reserved
+	Name string `mapstructure:"name"`
+	// Weight is the relative share of enrolled subjects (default: 1)
+	Weight float64 `mapstructure:"weight"`
+	// Template is a Go text/template rendered with .Prompt, .TaskID and .Title
+	Template string `mapstructure:"template"`
 }
 
 // ResolveWorktreeDir returns the resolved worktree directory path.
@@ -401,16 +1244,31 @@ func Default() *Config {
 			DefaultAction: "prompt",
 		},
 		TUI: TUIConfig{
-			AutoFocusOnInput:   true,
-			MaxOutputLines:     1000,
-			VerboseCommandHelp: true,
-			SidebarWidth:       36,
-			Theme:              "default",
+			AutoFocusOnInput:        true,
+			MaxOutputLines:          1000,
+			VerboseCommandHelp:      true,
+			SidebarWidth:            36,
+			Theme:                   "default",
+			ConfirmDestructiveInput: true,
+			Alerts: TUIAlertsConfig{
+				Bell:                 true,
+				Desktop:              false,
+				EscalateAfterSeconds: 30,
+				MinIntervalSeconds:   60,
+			},
 		},
 		Session: SessionConfig{
 			AutoStartOnAdd: true, // Auto-start instances added via :a by default
+			Storage: SessionStorageConfig{
+				CheckpointIntervalSeconds: 30,
+			},
+			Report: SessionReportConfig{
+				Enabled: true,
+			},
 		},
 		Instance: InstanceConfig{
+			Backend:                  defaultInstanceBackend(),
+			OutputFormat:             "text",
 			OutputBufferSize:         100000, // 100KB
 			CaptureIntervalMs:        100,
 			TmuxWidth:                200,
@@ -419,6 +1277,23 @@ func Default() *Config {
 			ActivityTimeoutMinutes:   30,    // 30 minutes of no activity
 			CompletionTimeoutMinutes: 0,     // Disabled by default (no max runtime limit)
 			StaleDetection:           true,
+			RecordTranscripts:        false,
+			Escalation: EscalationConfig{
+				Enabled:         true,
+				Steps:           ValidEscalationSteps(),
+				StepWaitSeconds: 120,
+				MaxNudges:       1,
+			},
+			TimeoutPolicies: []TimeoutPolicyConfig{},
+			PermissionPolicy: PermissionPolicyConfig{
+				Enabled:  false,
+				Response: "y",
+				Rules:    []PermissionRuleConfig{},
+			},
+			ResourceLimits: ResourceLimitsConfig{
+				Enabled: false,
+				Nice:    10,
+			},
 		},
 		AI: AIConfig{
 			Backend: "claude",
@@ -440,16 +1315,23 @@ func Default() *Config {
 				Default: []string{},
 				ByPath:  map[string][]string{},
 			},
-			Labels: []string{},
+			Labels:     []string{},
+			MissingCLI: "degrade",
+			Provider:   "auto",
 		},
 		Cleanup: CleanupConfig{
-			WarnOnStale:        true,
-			KeepRemoteBranches: true,
+			WarnOnStale:          true,
+			AutoGC:               true,
+			KeepRemoteBranches:   true,
+			ReapMerged:           true,
+			MergedRetentionHours: 0,
 		},
 		Resources: ResourceConfig{
 			CostWarningThreshold:  5.00, // Warn at $5
 			CostLimit:             0,    // No limit by default
 			TokenLimitPerInstance: 0,    // No limit by default
+			InstanceCostLimit:     0,    // No limit by default
+			BudgetAction:          "pause",
 			ShowMetricsInSidebar:  true, // Show metrics by default
 		},
 		Ultraplan: UltraplanConfig{
@@ -460,12 +1342,51 @@ func Default() *Config {
 				UseSound:  false,
 				SoundPath: "",
 			},
-			ConsolidationMode:      "stacked",
-			CreateDraftPRs:         true,
-			PRLabels:               []string{"ultraplan"},
-			BranchPrefix:           "", // Empty means use branch.prefix
-			MaxTaskRetries:         3,
+			ConsolidationMode:  "stacked",
+			MergeQueue:         false,
+			ConflictResolver:   false,
+			CreateDraftPRs:     true,
+			PRLabels:           []string{"ultraplan"},
+			BranchPrefix:       "", // Empty means use branch.prefix
+			MaxBehindCommits:   20,
+			AutoRebase:         false,
+			DriftAction:        "warn",
+			ConflictPrediction: "warn",
+			MaxTaskRetries:     3,
+			Retry: RetryConfig{
+				BackoffSeconds:    15,
+				MaxBackoffSeconds: 300,
+				AugmentPrompt:     true,
+			},
+			Models: ModelsConfig{
+				ByComplexity: map[string]string{},
+			},
+			Verify: GroupVerifyConfig{
+				Commands:       []VerifyCommandConfig{},
+				AutoDetect:     true,
+				OnFailure:      "fail",
+				TimeoutSeconds: 600,
+			},
 			RequireVerifiedCommits: true,
+			SynthesisReviewers:     1,
+			PriorityAgingSeconds:   60,
+			Preemption: PreemptionConfig{
+				Enabled:        false,
+				WaitSeconds:    120,
+				MinPriorityGap: 2,
+			},
+			Scaling: ScalingConfig{
+				BudgetCeiling: 0,
+				BurnRateLimit: 0,
+			},
+			WorkStealing: false,
+			Repos:        map[string]string{},
+			MessageGuard: "strip",
+			Placement: PlacementConfig{
+				Policy: "spread",
+				Nodes:  []NodeConfig{},
+			},
+			Templates: []ObjectiveTemplateConfig{},
 		},
 		Plan: PlanConfig{
 			OutputFormat: "issues",
@@ -488,6 +1409,36 @@ func Default() *Config {
 			Level:      "info",
 			MaxSizeMB:  10,
 			MaxBackups: 3,
+			RateLimits: []LogRateLimitConfig{
+				{Message: "instance status check", IntervalSeconds: 30},
+				{Message: "output captured", IntervalSeconds: 10},
+			},
+		},
+		EventServer: EventServerConfig{
+			Enabled:    false,
+			Address:    "127.0.0.1:7878",
+			ReplaySize: 256,
+		},
+		Webhooks: WebhooksConfig{
+			TimeoutSeconds: 10,
+			Events: WebhookEventsConfig{
+				PhaseChanged:  true,
+				TaskFailed:    true,
+				PROpened:      true,
+				BudgetWarning: true,
+				WaitingInput:  true,
+			},
+		},
+		API: APIConfig{
+			Enabled: false,
+			Address: "127.0.0.1:7880",
+		},
+		Tracing: TracingConfig{
+			Enabled:     false,
+			Exporter:    "otlp",
+			Insecure:    true,
+			SampleRatio: 1,
+			ServiceName: "claudio",
 		},
 		Paths: PathsConfig{
 			WorktreeDir: "", // Empty means use default: .claudio/worktrees
@@ -497,9 +1448,17 @@ func Default() *Config {
 				AlwaysInclude: []string{},
 				ConeMode:      true, // Cone mode is faster and recommended
 			},
+			Bootstrap: BootstrapConfig{
+				Enabled:        false,
+				SharedDirs:     []string{},
+				Commands:       []string{},
+				Env:            []string{},
+				TimeoutSeconds: 300,
+			},
 		},
 		Experimental: ExperimentalConfig{
 			SubprocessMode: false, // Disabled by default until stable
+			GitBackend:     "exec",
 		},
 	}
 }
@@ -532,11 +1491,35 @@ func SetDefaults() {
 	viper.SetDefault("tui.verbose_command_help", defaults.TUI.VerboseCommandHelp)
 	viper.SetDefault("tui.sidebar_width", defaults.TUI.SidebarWidth)
 	viper.SetDefault("tui.theme", defaults.TUI.Theme)
+	viper.SetDefault("tui.confirm_destructive_input", defaults.TUI.ConfirmDestructiveInput)
+	viper.SetDefault("tui.require_input_modifier", defaults.TUI.RequireInputModifier)
+	viper.SetDefault("tui.mouse", defaults.TUI.Mouse)
+	viper.SetDefault("tui.alerts.bell", defaults.TUI.Alerts.Bell)
+	viper.SetDefault("tui.alerts.desktop", defaults.TUI.Alerts.Desktop)
+	viper.SetDefault("tui.alerts.escalate_after_seconds", defaults.TUI.Alerts.EscalateAfterSeconds)
+	viper.SetDefault("tui.alerts.min_interval_seconds", defaults.TUI.Alerts.MinIntervalSeconds)
 
 	// Session defaults
 	viper.SetDefault("session.auto_start_on_add", defaults.Session.AutoStartOnAdd)
+	viper.SetDefault("session.storage.backend", defaults.Session.Storage.Backend)
+	viper.SetDefault("session.storage.checkpoint_interval_seconds", defaults.Session.Storage.CheckpointIntervalSeconds)
+	viper.SetDefault("session.storage.path", defaults.Session.Storage.Path)
+	viper.SetDefault("session.storage.prefix", defaults.Session.Storage.Prefix)
+	viper.SetDefault("session.storage.bucket", defaults.Session.Storage.Bucket)
+	viper.SetDefault("session.storage.region", defaults.Session.Storage.Region)
+	viper.SetDefault("session.storage.endpoint", defaults.Session.Storage.Endpoint)
+	viper.SetDefault("session.storage.path_style", defaults.Session.Storage.PathStyle)
+	viper.SetDefault("session.database.enabled", defaults.Session.Database.Enabled)
+	viper.SetDefault("session.database.path", defaults.Session.Database.Path)
+	viper.SetDefault("session.report.enabled", defaults.Session.Report.Enabled)
+	viper.SetDefault("session.report.html", defaults.Session.Report.HTML)
+	viper.SetDefault("session.archive.after_days", defaults.Session.Archive.AfterDays)
+	viper.SetDefault("session.archive.keep_last", defaults.Session.Archive.KeepLast)
+	viper.SetDefault("session.archive.max_total_mb", defaults.Session.Archive.MaxTotalMB)
 
 	// Instance defaults
+	viper.SetDefault("instance.backend", defaults.Instance.Backend)
+	viper.SetDefault("instance.output_format", defaults.Instance.OutputFormat)
 	viper.SetDefault("instance.output_buffer_size", defaults.Instance.OutputBufferSize)
 	viper.SetDefault("instance.capture_interval_ms", defaults.Instance.CaptureIntervalMs)
 	viper.SetDefault("instance.tmux_width", defaults.Instance.TmuxWidth)
@@ -545,6 +1528,23 @@ func SetDefaults() {
 	viper.SetDefault("instance.activity_timeout_minutes", defaults.Instance.ActivityTimeoutMinutes)
 	viper.SetDefault("instance.completion_timeout_minutes", defaults.Instance.CompletionTimeoutMinutes)
 	viper.SetDefault("instance.stale_detection", defaults.Instance.StaleDetection)
+	viper.SetDefault("instance.record_transcripts", defaults.Instance.RecordTranscripts)
+	viper.SetDefault("instance.escalation.enabled", defaults.Instance.Escalation.Enabled)
+	viper.SetDefault("instance.escalation.steps", defaults.Instance.Escalation.Steps)
+	viper.SetDefault("instance.escalation.step_wait_seconds", defaults.Instance.Escalation.StepWaitSeconds)
+	viper.SetDefault("instance.escalation.max_nudges", defaults.Instance.Escalation.MaxNudges)
+	viper.SetDefault("instance.escalation.nudge_message", defaults.Instance.Escalation.NudgeMessage)
+	viper.SetDefault("instance.escalation.interview_prompt", defaults.Instance.Escalation.InterviewPrompt)
+	viper.SetDefault("instance.timeout_policies", defaults.Instance.TimeoutPolicies)
+	viper.SetDefault("instance.permission_policy.enabled", defaults.Instance.PermissionPolicy.Enabled)
+	viper.SetDefault("instance.permission_policy.response", defaults.Instance.PermissionPolicy.Response)
+	viper.SetDefault("instance.permission_policy.rules", defaults.Instance.PermissionPolicy.Rules)
+	viper.SetDefault("instance.resource_limits.enabled", defaults.Instance.ResourceLimits.Enabled)
+	viper.SetDefault("instance.resource_limits.nice", defaults.Instance.ResourceLimits.Nice)
+	viper.SetDefault("instance.resource_limits.gomaxprocs", defaults.Instance.ResourceLimits.GoMaxProcs)
+	viper.SetDefault("instance.resource_limits.cpu_percent", defaults.Instance.ResourceLimits.CPUPercent)
+	viper.SetDefault("instance.resource_limits.memory_mb", defaults.Instance.ResourceLimits.MemoryMB)
+	viper.SetDefault("instance.resource_limits.cgroup_parent", defaults.Instance.ResourceLimits.CgroupParent)
 
 	// AI backend defaults
 	viper.SetDefault("ai.backend", defaults.AI.Backend)
@@ -557,6 +1557,7 @@ func SetDefaults() {
 	viper.SetDefault("ai.claude.model", defaults.AI.Claude.Model)
 	viper.SetDefault("ai.claude.append_system_prompt", defaults.AI.Claude.AppendSystemPrompt)
 	viper.SetDefault("ai.claude.native_worktree", defaults.AI.Claude.NativeWorktree)
+	viper.SetDefault("ai.claude.hooks", defaults.AI.Claude.Hooks)
 
 	// Branch defaults
 	viper.SetDefault("branch.prefix", defaults.Branch.Prefix)
@@ -570,15 +1571,22 @@ func SetDefaults() {
 	viper.SetDefault("pr.reviewers.default", defaults.PR.Reviewers.Default)
 	viper.SetDefault("pr.reviewers.by_path", defaults.PR.Reviewers.ByPath)
 	viper.SetDefault("pr.labels", defaults.PR.Labels)
+	viper.SetDefault("pr.missing_cli", defaults.PR.MissingCLI)
+	viper.SetDefault("pr.provider", defaults.PR.Provider)
 
 	// Cleanup defaults
 	viper.SetDefault("cleanup.warn_on_stale", defaults.Cleanup.WarnOnStale)
+	viper.SetDefault("cleanup.auto_gc", defaults.Cleanup.AutoGC)
 	viper.SetDefault("cleanup.keep_remote_branches", defaults.Cleanup.KeepRemoteBranches)
+	viper.SetDefault("cleanup.reap_merged", defaults.Cleanup.ReapMerged)
+	viper.SetDefault("cleanup.merged_retention_hours", defaults.Cleanup.MergedRetentionHours)
 
 	// Resource defaults
 	viper.SetDefault("resources.cost_warning_threshold", defaults.Resources.CostWarningThreshold)
 	viper.SetDefault("resources.cost_limit", defaults.Resources.CostLimit)
 	viper.SetDefault("resources.token_limit_per_instance", defaults.Resources.TokenLimitPerInstance)
+	viper.SetDefault("resources.instance_cost_limit", defaults.Resources.InstanceCostLimit)
+	viper.SetDefault("resources.budget_action", defaults.Resources.BudgetAction)
 	viper.SetDefault("resources.show_metrics_in_sidebar", defaults.Resources.ShowMetricsInSidebar)
 
 	// Ultraplan defaults
@@ -589,11 +1597,49 @@ func SetDefaults() {
 	viper.SetDefault("ultraplan.notifications.use_sound", defaults.Ultraplan.Notifications.UseSound)
 	viper.SetDefault("ultraplan.notifications.sound_path", defaults.Ultraplan.Notifications.SoundPath)
 	viper.SetDefault("ultraplan.consolidation_mode", defaults.Ultraplan.ConsolidationMode)
+	viper.SetDefault("ultraplan.merge_queue", defaults.Ultraplan.MergeQueue)
+	viper.SetDefault("ultraplan.conflict_resolver", defaults.Ultraplan.ConflictResolver)
 	viper.SetDefault("ultraplan.create_draft_prs", defaults.Ultraplan.CreateDraftPRs)
 	viper.SetDefault("ultraplan.pr_labels", defaults.Ultraplan.PRLabels)
 	viper.SetDefault("ultraplan.branch_prefix", defaults.Ultraplan.BranchPrefix)
+	viper.SetDefault("ultraplan.max_behind_commits", defaults.Ultraplan.MaxBehindCommits)
+	viper.SetDefault("ultraplan.auto_rebase", defaults.Ultraplan.AutoRebase)
+	viper.SetDefault("ultraplan.drift_action", defaults.Ultraplan.DriftAction)
+	viper.SetDefault("ultraplan.conflict_prediction", defaults.Ultraplan.ConflictPrediction)
 	viper.SetDefault("ultraplan.max_task_retries", defaults.Ultraplan.MaxTaskRetries)
+	viper.SetDefault("ultraplan.retry.backoff_seconds", defaults.Ultraplan.Retry.BackoffSeconds)
+	viper.SetDefault("ultraplan.retry.max_backoff_seconds", defaults.Ultraplan.Retry.MaxBackoffSeconds)
+	viper.SetDefault("ultraplan.retry.augment_prompt", defaults.Ultraplan.Retry.AugmentPrompt)
+	viper.SetDefault("ultraplan.retry.final_attempt_model", defaults.Ultraplan.Retry.FinalAttemptModel)
+	viper.SetDefault("ultraplan.models.planning", defaults.Ultraplan.Models.Planning)
+	viper.SetDefault("ultraplan.models.execution", defaults.Ultraplan.Models.Execution)
+	viper.SetDefault("ultraplan.models.synthesis", defaults.Ultraplan.Models.Synthesis)
+	viper.SetDefault("ultraplan.models.revision", defaults.Ultraplan.Models.Revision)
+	viper.SetDefault("ultraplan.models.consolidation", defaults.Ultraplan.Models.Consolidation)
+	viper.SetDefault("ultraplan.models.by_complexity", defaults.Ultraplan.Models.ByComplexity)
+	viper.SetDefault("ultraplan.verify.commands", defaults.Ultraplan.Verify.Commands)
+	viper.SetDefault("ultraplan.verify.auto_detect", defaults.Ultraplan.Verify.AutoDetect)
+	viper.SetDefault("ultraplan.verify.on_failure", defaults.Ultraplan.Verify.OnFailure)
+	viper.SetDefault("ultraplan.verify.timeout_seconds", defaults.Ultraplan.Verify.TimeoutSeconds)
 	viper.SetDefault("ultraplan.require_verified_commits", defaults.Ultraplan.RequireVerifiedCommits)
+	viper.SetDefault("ultraplan.fresh_verification", defaults.Ultraplan.FreshVerification)
+	viper.SetDefault("ultraplan.group_approval", defaults.Ultraplan.GroupApproval)
+	viper.SetDefault("ultraplan.synthesis_reviewers", defaults.Ultraplan.SynthesisReviewers)
+	viper.SetDefault("ultraplan.self_review", defaults.Ultraplan.SelfReview)
+	viper.SetDefault("ultraplan.progress_summary_minutes", defaults.Ultraplan.ProgressSummaryMinutes)
+	viper.SetDefault("ultraplan.enforce_file_claims", defaults.Ultraplan.EnforceFileClaims)
+	viper.SetDefault("ultraplan.priority_aging_seconds", defaults.Ultraplan.PriorityAgingSeconds)
+	viper.SetDefault("ultraplan.preemption.enabled", defaults.Ultraplan.Preemption.Enabled)
+	viper.SetDefault("ultraplan.preemption.wait_seconds", defaults.Ultraplan.Preemption.WaitSeconds)
+	viper.SetDefault("ultraplan.preemption.min_priority_gap", defaults.Ultraplan.Preemption.MinPriorityGap)
+	viper.SetDefault("ultraplan.scaling.budget_ceiling", defaults.Ultraplan.Scaling.BudgetCeiling)
+	viper.SetDefault("ultraplan.scaling.burn_rate_limit", defaults.Ultraplan.Scaling.BurnRateLimit)
+	viper.SetDefault("ultraplan.work_stealing", defaults.Ultraplan.WorkStealing)
+	viper.SetDefault("ultraplan.repos", defaults.Ultraplan.Repos)
+	viper.SetDefault("ultraplan.message_guard", defaults.Ultraplan.MessageGuard)
+	viper.SetDefault("ultraplan.placement.policy", defaults.Ultraplan.Placement.Policy)
+	viper.SetDefault("ultraplan.placement.nodes", defaults.Ultraplan.Placement.Nodes)
+	viper.SetDefault("ultraplan.templates", defaults.Ultraplan.Templates)
 
 	// Plan defaults
 	viper.SetDefault("plan.output_format", defaults.Plan.OutputFormat)
@@ -616,6 +1662,34 @@ func SetDefaults() {
 	viper.SetDefault("logging.level", defaults.Logging.Level)
 	viper.SetDefault("logging.max_size_mb", defaults.Logging.MaxSizeMB)
 	viper.SetDefault("logging.max_backups", defaults.Logging.MaxBackups)
+	viper.SetDefault("logging.rate_limits", defaults.Logging.RateLimits)
+
+	// Event server defaults
+	viper.SetDefault("event_server.enabled", defaults.EventServer.Enabled)
+	viper.SetDefault("event_server.address", defaults.EventServer.Address)
+	viper.SetDefault("event_server.replay_size", defaults.EventServer.ReplaySize)
+	viper.SetDefault("webhooks.timeout_seconds", defaults.Webhooks.TimeoutSeconds)
+	viper.SetDefault("webhooks.events.phase_changed", defaults.Webhooks.Events.PhaseChanged)
+	viper.SetDefault("webhooks.events.task_failed", defaults.Webhooks.Events.TaskFailed)
+	viper.SetDefault("webhooks.events.pr_opened", defaults.Webhooks.Events.PROpened)
+	viper.SetDefault("webhooks.events.budget_warning", defaults.Webhooks.Events.BudgetWarning)
+	viper.SetDefault("webhooks.events.waiting_input", defaults.Webhooks.Events.WaitingInput)
+	viper.SetDefault("webhooks.templates.phase_changed", defaults.Webhooks.Templates.PhaseChanged)
+	viper.SetDefault("webhooks.templates.task_failed", defaults.Webhooks.Templates.TaskFailed)
+	viper.SetDefault("webhooks.templates.pr_opened", defaults.Webhooks.Templates.PROpened)
+	viper.SetDefault("webhooks.templates.budget_warning", defaults.Webhooks.Templates.BudgetWarning)
+	viper.SetDefault("webhooks.templates.waiting_input", defaults.Webhooks.Templates.WaitingInput)
+	viper.SetDefault("api.enabled", defaults.API.Enabled)
+	viper.SetDefault("api.address", defaults.API.Address)
+	viper.SetDefault("api.token", defaults.API.Token)
+
+	// Tracing defaults
+	viper.SetDefault("tracing.enabled", defaults.Tracing.Enabled)
+	viper.SetDefault("tracing.exporter", defaults.Tracing.Exporter)
+	viper.SetDefault("tracing.endpoint", defaults.Tracing.Endpoint)
+	viper.SetDefault("tracing.insecure", defaults.Tracing.Insecure)
+	viper.SetDefault("tracing.sample_ratio", defaults.Tracing.SampleRatio)
+	viper.SetDefault("tracing.service_name", defaults.Tracing.ServiceName)
 
 	// Paths defaults
 	viper.SetDefault("paths.worktree_dir", defaults.Paths.WorktreeDir)
@@ -623,9 +1697,15 @@ func SetDefaults() {
 	viper.SetDefault("paths.sparse_checkout.directories", defaults.Paths.SparseCheckout.Directories)
 	viper.SetDefault("paths.sparse_checkout.always_include", defaults.Paths.SparseCheckout.AlwaysInclude)
 	viper.SetDefault("paths.sparse_checkout.cone_mode", defaults.Paths.SparseCheckout.ConeMode)
+	viper.SetDefault("paths.bootstrap.enabled", defaults.Paths.Bootstrap.Enabled)
+	viper.SetDefault("paths.bootstrap.shared_dirs", defaults.Paths.Bootstrap.SharedDirs)
+	viper.SetDefault("paths.bootstrap.commands", defaults.Paths.Bootstrap.Commands)
+	viper.SetDefault("paths.bootstrap.env", defaults.Paths.Bootstrap.Env)
+	viper.SetDefault("paths.bootstrap.timeout_seconds", defaults.Paths.Bootstrap.TimeoutSeconds)
 
 	// Experimental defaults
 	viper.SetDefault("experimental.subprocess_mode", defaults.Experimental.SubprocessMode)
+	viper.SetDefault("experimental.git_backend", defaults.Experimental.GitBackend)
 }
 
 // Load reads the configuration from viper into a Config struct and validates it
@@ -682,6 +1762,41 @@ func ValidAIBackends() []string {
 	return []string{"claude"}
 }
 
+// ValidCLIBackendCompletions returns the valid ai.cli_backends completion conventions.
+func ValidCLIBackendCompletions() []string {
+	return []string{"sentinel", "exit"}
+}
+
+// ValidCLIBackendMetrics returns the valid ai.cli_backends metrics formats.
+func ValidCLIBackendMetrics() []string {
+	return []string{"none", "claude"}
+}
+
+// ValidBudgetActions returns the list of valid resources.budget_action values
+func ValidBudgetActions() []string {
+	return []string{"pause", "stop"}
+}
+
+// ValidPRProviders returns the list of valid pr.provider values
+func ValidPRProviders() []string {
+	return []string{"auto", "github", "gitlab", "bitbucket"}
+}
+
+// ValidMessageGuards returns the list of valid ultraplan.message_guard values
+func ValidMessageGuards() []string {
+	return []string{"strip", "hold"}
+}
+
+// ValidTemplateVariableTypes returns the valid objective template variable types
+func ValidTemplateVariableTypes() []string {
+	return []string{"string", "int", "bool", "path", "choice"}
+}
+
+// ValidGitBackends returns the list of valid experimental.git_backend values
+func ValidGitBackends() []string {
+	return []string{"exec", "go-git"}
+}
+
 // IsValidCompletionAction checks if the given action is valid
 func IsValidCompletionAction(action string) bool {
 	for _, valid := range ValidCompletionActions() {
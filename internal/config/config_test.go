@@ -78,6 +78,9 @@ func TestDefault(t *testing.T) {
 	if !cfg.Cleanup.WarnOnStale {
 		t.Error("Cleanup.WarnOnStale should be true by default")
 	}
+	if !cfg.Cleanup.AutoGC {
+		t.Error("Cleanup.AutoGC should be true by default")
+	}
 	if !cfg.Cleanup.KeepRemoteBranches {
 		t.Error("Cleanup.KeepRemoteBranches should be true by default")
 	}
@@ -2,12 +2,18 @@ package config
 
 import (
 	"fmt"
+	"maps"
+	"net"
+	"net/url"
 	"os"
 	"regexp"
+	"runtime"
 	"slices"
 	"strings"
+	"text/template"
 
 	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/Iron-Ham/claudio/internal/tui/theme"
 )
 
 // ValidationError represents a single validation failure
@@ -69,11 +75,21 @@ func (c *Config) Validate() []ValidationError {
 	// Validate Instance config
 	errors = append(errors, c.validateInstance()...)
 
+	// Validate session storage config
+	errors = append(errors, c.validateSessionStorage()...)
+
+	// Validate event server config
+	errors = append(errors, c.validateEventServer()...)
+	errors = append(errors, c.validateWebhooks()...)
+	errors = append(errors, c.validateAPI()...)
+	errors = append(errors, c.validateTracing()...)
+
 	// Validate AI backend config
 	errors = append(errors, c.validateAI()...)
 
 	// Validate Branch config
 	errors = append(errors, c.validateBranch()...)
+	errors = append(errors, c.validatePR()...)
 
 	// Validate Resources config
 	errors = append(errors, c.validateResources()...)
@@ -93,6 +109,18 @@ func (c *Config) Validate() []ValidationError {
 	// Validate Paths config
 	errors = append(errors, c.validatePaths()...)
 
+	// Validate Experiments config
+	errors = append(errors, c.validateExperiments()...)
+
+	// Validate Experimental config
+	errors = append(errors, c.validateExperimental()...)
+
+	// Validate node placement config
+	errors = append(errors, c.validatePlacement()...)
+
+	// Validate objective templates
+	errors = append(errors, c.validateObjectiveTemplates()...)
+
 	return errors
 }
 
@@ -115,6 +143,21 @@ func (c *Config) validateCompletion() []ValidationError {
 func (c *Config) validateTUI() []ValidationError {
 	var errors []ValidationError
 
+	if c.TUI.Alerts.EscalateAfterSeconds < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "tui.alerts.escalate_after_seconds",
+			Value:   c.TUI.Alerts.EscalateAfterSeconds,
+			Message: "must be non-negative (0 notifies at once)",
+		})
+	}
+	if c.TUI.Alerts.MinIntervalSeconds < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "tui.alerts.min_interval_seconds",
+			Value:   c.TUI.Alerts.MinIntervalSeconds,
+			Message: "must be non-negative",
+		})
+	}
+
 	if c.TUI.MaxOutputLines < 0 {
 		errors = append(errors, ValidationError{
 			Field:   "tui.max_output_lines",
@@ -164,6 +207,14 @@ func (c *Config) validateTUI() []ValidationError {
 		})
 	}
 
+	if err := theme.ValidateColors(c.TUI.Colors); err != nil {
+		errors = append(errors, ValidationError{
+			Field:   "tui.colors",
+			Value:   c.TUI.Colors,
+			Message: err.Error(),
+		})
+	}
+
 	return errors
 }
 
@@ -171,6 +222,30 @@ func (c *Config) validateTUI() []ValidationError {
 func (c *Config) validateInstance() []ValidationError {
 	var errors []ValidationError
 
+	// Empty means the platform default
+	if c.Instance.Backend != "" && !slices.Contains(ValidInstanceBackends(), c.Instance.Backend) {
+		errors = append(errors, ValidationError{
+			Field:   "instance.backend",
+			Value:   c.Instance.Backend,
+			Message: "must be one of: tmux, pty",
+		})
+	} else if c.Instance.Backend == "tmux" && runtime.GOOS == "windows" {
+		errors = append(errors, ValidationError{
+			Field:   "instance.backend",
+			Value:   c.Instance.Backend,
+			Message: "tmux is not available on Windows; use pty",
+		})
+	}
+
+	// Empty means text
+	if c.Instance.OutputFormat != "" && !slices.Contains(ValidInstanceOutputFormats(), c.Instance.OutputFormat) {
+		errors = append(errors, ValidationError{
+			Field:   "instance.output_format",
+			Value:   c.Instance.OutputFormat,
+			Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidInstanceOutputFormats(), ", ")),
+		})
+	}
+
 	// Buffer size validation
 	const minBufferSize = 1024        // 1KB minimum
 	const maxBufferSize = 100_000_000 // 100MB maximum
@@ -260,6 +335,425 @@ func (c *Config) validateInstance() []ValidationError {
 		})
 	}
 
+	errors = append(errors, c.validateEscalation()...)
+	errors = append(errors, c.validateTimeoutPolicies()...)
+	errors = append(errors, c.validatePermissionPolicy()...)
+	errors = append(errors, c.validateResourceLimits()...)
+
+	return errors
+}
+
+// validateResourceLimits validates the instance resource limits
+func (c *Config) validateResourceLimits() []ValidationError {
+	var errors []ValidationError
+	rl := c.Instance.ResourceLimits
+
+	if rl.Nice < 0 || rl.Nice > 19 {
+		errors = append(errors, ValidationError{
+			Field:   "instance.resource_limits.nice",
+			Value:   rl.Nice,
+			Message: "must be between 0 and 19",
+		})
+	}
+	if rl.GoMaxProcs < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "instance.resource_limits.gomaxprocs",
+			Value:   rl.GoMaxProcs,
+			Message: "must be non-negative (0 divides the CPUs among instances)",
+		})
+	}
+	if rl.CPUPercent < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "instance.resource_limits.cpu_percent",
+			Value:   rl.CPUPercent,
+			Message: "must be non-negative (0 = unlimited)",
+		})
+	}
+	if rl.MemoryMB < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "instance.resource_limits.memory_mb",
+			Value:   rl.MemoryMB,
+			Message: "must be non-negative (0 = unlimited)",
+		})
+	}
+
+	return errors
+}
+
+// ValidEscalationSteps returns the escalation ladder steps in their default order
+func ValidEscalationSteps() []string {
+	return []string{"nudge", "interview", "interrupt", "restart", "mark_stuck"}
+}
+
+// validateEscalation validates the escalation ladder for stalled instances
+func (c *Config) validateEscalation() []ValidationError {
+	var errors []ValidationError
+	esc := c.Instance.Escalation
+
+	seen := make(map[string]bool)
+	for i, step := range esc.Steps {
+		field := fmt.Sprintf("instance.escalation.steps[%d]", i)
+		switch {
+		case !slices.Contains(ValidEscalationSteps(), step):
+			errors = append(errors, ValidationError{
+				Field:   field,
+				Value:   step,
+				Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidEscalationSteps(), ", ")),
+			})
+		case seen[step]:
+			errors = append(errors, ValidationError{
+				Field:   field,
+				Value:   step,
+				Message: "must not be repeated",
+			})
+		case step == "mark_stuck" && i != len(esc.Steps)-1:
+			errors = append(errors, ValidationError{
+				Field:   field,
+				Value:   step,
+				Message: "must be the last step",
+			})
+		}
+		seen[step] = true
+	}
+
+	if esc.Enabled && esc.StepWaitSeconds <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "instance.escalation.step_wait_seconds",
+			Value:   esc.StepWaitSeconds,
+			Message: "must be positive when escalation is enabled",
+		})
+	}
+
+	if esc.MaxNudges < 1 {
+		errors = append(errors, ValidationError{
+			Field:   "instance.escalation.max_nudges",
+			Value:   esc.MaxNudges,
+			Message: "must be at least 1",
+		})
+	}
+
+	return errors
+}
+
+// ValidTimeoutPolicyActions returns the valid instance.timeout_policies actions
+func ValidTimeoutPolicyActions() []string {
+	return []string{"warn", "nudge", "restart", "fail"}
+}
+
+// ValidTaskComplexities returns the task complexities a timeout policy can match
+func ValidTaskComplexities() []string {
+	return []string{"low", "medium", "high"}
+}
+
+// validateTimeoutPolicies validates the per-task timeout policies
+func (c *Config) validateTimeoutPolicies() []ValidationError {
+	var errors []ValidationError
+
+	seen := make(map[string]bool)
+	for i, p := range c.Instance.TimeoutPolicies {
+		prefix := fmt.Sprintf("instance.timeout_policies[%d]", i)
+
+		switch {
+		case p.Name == "":
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".name",
+				Value:   p.Name,
+				Message: "is required",
+			})
+		case seen[p.Name]:
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".name",
+				Value:   p.Name,
+				Message: "must be unique",
+			})
+		}
+		seen[p.Name] = true
+
+		for j, complexity := range p.Complexity {
+			if !slices.Contains(ValidTaskComplexities(), complexity) {
+				errors = append(errors, ValidationError{
+					Field:   fmt.Sprintf("%s.complexity[%d]", prefix, j),
+					Value:   complexity,
+					Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidTaskComplexities(), ", ")),
+				})
+			}
+		}
+
+		thresholds := []struct {
+			field string
+			value int
+		}{
+			{"activity_timeout_minutes", p.ActivityTimeoutMinutes},
+			{"completion_timeout_minutes", p.CompletionTimeoutMinutes},
+			{"stale_threshold", p.StaleThreshold},
+		}
+		for _, th := range thresholds {
+			if th.value < 0 {
+				errors = append(errors, ValidationError{
+					Field:   prefix + "." + th.field,
+					Value:   th.value,
+					Message: "must be non-negative (0 keeps the global value)",
+				})
+			}
+		}
+
+		for j, action := range p.Actions {
+			field := fmt.Sprintf("%s.actions[%d]", prefix, j)
+			switch {
+			case !slices.Contains(ValidTimeoutPolicyActions(), action):
+				errors = append(errors, ValidationError{
+					Field:   field,
+					Value:   action,
+					Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidTimeoutPolicyActions(), ", ")),
+				})
+			case action == "fail" && j != len(p.Actions)-1:
+				errors = append(errors, ValidationError{
+					Field:   field,
+					Value:   action,
+					Message: "must be the last action",
+				})
+			}
+		}
+	}
+
+	return errors
+}
+
+// ValidPermissionRuleActions returns the valid instance.permission_policy rule actions
+func ValidPermissionRuleActions() []string {
+	return []string{"allow", "deny"}
+}
+
+// validatePermissionPolicy validates the permission prompt rules
+func (c *Config) validatePermissionPolicy() []ValidationError {
+	var errors []ValidationError
+	pp := c.Instance.PermissionPolicy
+
+	if pp.Enabled && strings.TrimSpace(pp.Response) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "instance.permission_policy.response",
+			Value:   pp.Response,
+			Message: "must not be empty when the permission policy is enabled",
+		})
+	}
+
+	for i, r := range pp.Rules {
+		prefix := fmt.Sprintf("instance.permission_policy.rules[%d]", i)
+
+		if !slices.Contains(ValidPermissionRuleActions(), r.Action) {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".action",
+				Value:   r.Action,
+				Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidPermissionRuleActions(), ", ")),
+			})
+		}
+		if len(r.Tools) == 0 && len(r.Commands) == 0 && len(r.Paths) == 0 && r.Prompt == "" {
+			errors = append(errors, ValidationError{
+				Field:   prefix,
+				Value:   r.Name,
+				Message: "must set at least one of tools, commands, paths, or prompt",
+			})
+		}
+		if r.Prompt != "" {
+			if _, err := regexp.Compile(r.Prompt); err != nil {
+				errors = append(errors, ValidationError{
+					Field:   prefix + ".prompt",
+					Value:   r.Prompt,
+					Message: fmt.Sprintf("invalid regular expression: %v", err),
+				})
+			}
+		}
+	}
+
+	return errors
+}
+
+// validateSessionStorage validates the session checkpoint storage configuration.
+func (c *Config) validateSessionStorage() []ValidationError {
+	var errors []ValidationError
+	st := c.Session.Storage
+
+	if !slices.Contains(ValidSessionStorageBackends(), st.Backend) {
+		errors = append(errors, ValidationError{
+			Field:   "session.storage.backend",
+			Value:   st.Backend,
+			Message: `must be one of: "" (no checkpoints), local, s3`,
+		})
+	}
+
+	if st.CheckpointIntervalSeconds < 1 {
+		errors = append(errors, ValidationError{
+			Field:   "session.storage.checkpoint_interval_seconds",
+			Value:   st.CheckpointIntervalSeconds,
+			Message: "must be at least 1",
+		})
+	}
+
+	if st.Backend == "local" && st.Path == "" {
+		errors = append(errors, ValidationError{
+			Field:   "session.storage.path",
+			Value:   st.Path,
+			Message: "is required for the local backend",
+		})
+	}
+
+	if st.Backend == "s3" && st.Bucket == "" {
+		errors = append(errors, ValidationError{
+			Field:   "session.storage.bucket",
+			Value:   st.Bucket,
+			Message: "is required for the s3 backend",
+		})
+	}
+
+	ar := c.Session.Archive
+	for _, f := range []struct {
+		field string
+		value int
+	}{
+		{"session.archive.after_days", ar.AfterDays},
+		{"session.archive.keep_last", ar.KeepLast},
+		{"session.archive.max_total_mb", ar.MaxTotalMB},
+	} {
+		if f.value < 0 {
+			errors = append(errors, ValidationError{
+				Field:   f.field,
+				Value:   f.value,
+				Message: "must be non-negative (0 disables it)",
+			})
+		}
+	}
+
+	if st.Endpoint != "" {
+		if u, err := url.Parse(st.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errors = append(errors, ValidationError{
+				Field:   "session.storage.endpoint",
+				Value:   st.Endpoint,
+				Message: "must be an http or https URL",
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateEventServer validates the event server configuration.
+func (c *Config) validateEventServer() []ValidationError {
+	var errors []ValidationError
+
+	if _, _, err := net.SplitHostPort(c.EventServer.Address); err != nil {
+		errors = append(errors, ValidationError{
+			Field:   "event_server.address",
+			Value:   c.EventServer.Address,
+			Message: "must be host:port (e.g., 127.0.0.1:7878)",
+		})
+	}
+
+	if c.EventServer.ReplaySize < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "event_server.replay_size",
+			Value:   c.EventServer.ReplaySize,
+			Message: "must be non-negative (0 disables replay)",
+		})
+	}
+
+	return errors
+}
+
+// validateWebhooks validates the webhook endpoints and message templates.
+func (c *Config) validateWebhooks() []ValidationError {
+	var errors []ValidationError
+
+	for i, endpoint := range c.Webhooks.Endpoints {
+		prefix := fmt.Sprintf("webhooks.endpoints[%d]", i)
+		if u, err := url.Parse(endpoint.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".url",
+				Value:   endpoint.URL,
+				Message: "must be an http or https URL",
+			})
+		}
+		if endpoint.Format != "" && !slices.Contains(ValidWebhookFormats(), endpoint.Format) {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".format",
+				Value:   endpoint.Format,
+				Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidWebhookFormats(), ", ")),
+			})
+		}
+	}
+
+	if c.Webhooks.TimeoutSeconds < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "webhooks.timeout_seconds",
+			Value:   c.Webhooks.TimeoutSeconds,
+			Message: "must be non-negative (0 uses the default)",
+		})
+	}
+
+	tmpl := c.Webhooks.Templates
+	for _, t := range []struct{ field, text string }{
+		{"phase_changed", tmpl.PhaseChanged},
+		{"task_failed", tmpl.TaskFailed},
+		{"pr_opened", tmpl.PROpened},
+		{"budget_warning", tmpl.BudgetWarning},
+		{"waiting_input", tmpl.WaitingInput},
+	} {
+		if _, err := template.New(t.field).Parse(t.text); err != nil {
+			errors = append(errors, ValidationError{
+				Field:   "webhooks.templates." + t.field,
+				Value:   t.text,
+				Message: fmt.Sprintf("is not a valid template: %v", err),
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateAPI validates the control API configuration.
+func (c *Config) validateAPI() []ValidationError {
+	var errors []ValidationError
+
+	if _, _, err := net.SplitHostPort(c.API.Address); err != nil {
+		errors = append(errors, ValidationError{
+			Field:   "api.address",
+			Value:   c.API.Address,
+			Message: "must be host:port (e.g., 127.0.0.1:7880)",
+		})
+	}
+
+	return errors
+}
+
+// validateTracing validates the tracing configuration.
+func (c *Config) validateTracing() []ValidationError {
+	var errors []ValidationError
+
+	if !slices.Contains(ValidTracingExporters(), c.Tracing.Exporter) {
+		errors = append(errors, ValidationError{
+			Field:   "tracing.exporter",
+			Value:   c.Tracing.Exporter,
+			Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidTracingExporters(), ", ")),
+		})
+	}
+
+	if c.Tracing.Endpoint != "" {
+		if _, _, err := net.SplitHostPort(c.Tracing.Endpoint); err != nil {
+			errors = append(errors, ValidationError{
+				Field:   "tracing.endpoint",
+				Value:   c.Tracing.Endpoint,
+				Message: "must be host:port (e.g., localhost:4317)",
+			})
+		}
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errors = append(errors, ValidationError{
+			Field:   "tracing.sample_ratio",
+			Value:   c.Tracing.SampleRatio,
+			Message: "must be between 0 and 1",
+		})
+	}
+
 	return errors
 }
 
@@ -267,11 +761,11 @@ func (c *Config) validateInstance() []ValidationError {
 func (c *Config) validateAI() []ValidationError {
 	var errors []ValidationError
 
-	if c.AI.Backend != "" && !slices.Contains(ValidAIBackends(), c.AI.Backend) {
+	if c.AI.Backend != "" && !slices.Contains(c.AI.BackendNames(), c.AI.Backend) {
 		errors = append(errors, ValidationError{
 			Field:   "ai.backend",
 			Value:   c.AI.Backend,
-			Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidAIBackends(), ", ")),
+			Message: fmt.Sprintf("must be one of: %s", strings.Join(c.AI.BackendNames(), ", ")),
 		})
 	}
 
@@ -299,9 +793,104 @@ func (c *Config) validateAI() []ValidationError {
 		})
 	}
 
+	seen := make(map[string]bool, len(c.AI.CLIBackends))
+	for i, b := range c.AI.CLIBackends {
+		field := fmt.Sprintf("ai.cli_backends[%d]", i)
+		switch {
+		case !cliBackendNameRegex.MatchString(b.Name):
+			errors = append(errors, ValidationError{
+				Field:   field + ".name",
+				Value:   b.Name,
+				Message: "must start with a lowercase letter and contain only lowercase letters, digits, hyphens, or underscores",
+			})
+		case slices.Contains(reservedBackendNames, b.Name):
+			errors = append(errors, ValidationError{
+				Field:   field + ".name",
+				Value:   b.Name,
+				Message: "is reserved for a built-in backend",
+			})
+		case seen[b.Name]:
+			errors = append(errors, ValidationError{
+				Field:   field + ".name",
+				Value:   b.Name,
+				Message: "is defined more than once",
+			})
+		}
+		seen[b.Name] = true
+
+		if strings.TrimSpace(b.Command) == "" {
+			errors = append(errors, ValidationError{
+				Field:   field + ".command",
+				Value:   b.Command,
+				Message: "must be non-empty",
+			})
+		} else if !strings.Contains(b.Command, "{prompt}") && !strings.Contains(b.Command, "{prompt_file}") {
+			errors = append(errors, ValidationError{
+				Field:   field + ".command",
+				Value:   b.Command,
+				Message: "must contain {prompt} or {prompt_file}",
+			})
+		}
+		if b.ResumeCommand != "" && (!strings.Contains(b.ResumeCommand, "{session_id}") || !strings.Contains(b.Command, "{session_id}")) {
+			errors = append(errors, ValidationError{
+				Field:   field + ".resume_command",
+				Value:   b.ResumeCommand,
+				Message: "requires {session_id} in both command and resume_command",
+			})
+		}
+		if strings.ContainsAny(b.PromptFile, `/\`) {
+			errors = append(errors, ValidationError{
+				Field:   field + ".prompt_file",
+				Value:   b.PromptFile,
+				Message: "must be a file name, not a path",
+			})
+		}
+		if b.Completion != "" && !slices.Contains(ValidCLIBackendCompletions(), b.Completion) {
+			errors = append(errors, ValidationError{
+				Field:   field + ".completion",
+				Value:   b.Completion,
+				Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidCLIBackendCompletions(), ", ")),
+			})
+		}
+		if b.Metrics != "" && !slices.Contains(ValidCLIBackendMetrics(), b.Metrics) {
+			errors = append(errors, ValidationError{
+				Field:   field + ".metrics",
+				Value:   b.Metrics,
+				Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidCLIBackendMetrics(), ", ")),
+			})
+		}
+		patterns := map[string][]string{
+			"ready_patterns":      b.ReadyPatterns,
+			"working_patterns":    b.WorkingPatterns,
+			"completion_patterns": b.CompletionPatterns,
+			"error_patterns":      b.ErrorPatterns,
+			"permission_patterns": b.PermissionPatterns,
+			"question_patterns":   b.QuestionPatterns,
+		}
+		for _, key := range slices.Sorted(maps.Keys(patterns)) {
+			for _, p := range patterns[key] {
+				if _, err := regexp.Compile(p); err != nil {
+					errors = append(errors, ValidationError{
+						Field:   field + "." + key,
+						Value:   p,
+						Message: fmt.Sprintf("invalid regular expression: %v", err),
+					})
+				}
+			}
+		}
+	}
+
 	return errors
 }
 
+// cliBackendNameRegex matches valid ai.cli_backends names. Names are
+// lowercase because plan task backends are matched case-insensitively.
+var cliBackendNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// reservedBackendNames are backend names ai.cli_backends may not redefine:
+// the built-in backends, the per-task tool runner, and the removed codex backend.
+var reservedBackendNames = []string{"claude", "tool", "codex"}
+
 // validateBranch validates the BranchConfig
 func (c *Config) validateBranch() []ValidationError {
 	var errors []ValidationError
@@ -333,6 +922,29 @@ func (c *Config) validateBranch() []ValidationError {
 	return errors
 }
 
+// validatePR validates the PRConfig
+func (c *Config) validatePR() []ValidationError {
+	var errors []ValidationError
+
+	validMissingCLI := []string{"degrade", "fail"}
+	if c.PR.MissingCLI != "" && !slices.Contains(validMissingCLI, c.PR.MissingCLI) {
+		errors = append(errors, ValidationError{
+			Field:   "pr.missing_cli",
+			Value:   c.PR.MissingCLI,
+			Message: "must be 'degrade' or 'fail'",
+		})
+	}
+	if c.PR.Provider != "" && !slices.Contains(ValidPRProviders(), c.PR.Provider) {
+		errors = append(errors, ValidationError{
+			Field:   "pr.provider",
+			Value:   c.PR.Provider,
+			Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidPRProviders(), ", ")),
+		})
+	}
+
+	return errors
+}
+
 // validateResources validates the ResourceConfig
 func (c *Config) validateResources() []ValidationError {
 	var errors []ValidationError
@@ -370,6 +982,20 @@ func (c *Config) validateResources() []ValidationError {
 			Message: "must be non-negative (0 disables limit)",
 		})
 	}
+	if c.Resources.InstanceCostLimit < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "resources.instance_cost_limit",
+			Value:   c.Resources.InstanceCostLimit,
+			Message: "must be non-negative (0 disables limit)",
+		})
+	}
+	if c.Resources.BudgetAction != "" && !slices.Contains(ValidBudgetActions(), c.Resources.BudgetAction) {
+		errors = append(errors, ValidationError{
+			Field:   "resources.budget_action",
+			Value:   c.Resources.BudgetAction,
+			Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidBudgetActions(), ", ")),
+		})
+	}
 
 	return errors
 }
@@ -417,6 +1043,35 @@ func (c *Config) validateUltraplan() []ValidationError {
 		})
 	}
 
+	// Validate freshness threshold (0 disables the check)
+	if c.Ultraplan.MaxBehindCommits < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ultraplan.max_behind_commits",
+			Value:   c.Ultraplan.MaxBehindCommits,
+			Message: "cannot be negative",
+		})
+	}
+
+	// Validate drift action
+	validDriftActions := []string{"off", "warn", "replan"}
+	if c.Ultraplan.DriftAction != "" && !slices.Contains(validDriftActions, c.Ultraplan.DriftAction) {
+		errors = append(errors, ValidationError{
+			Field:   "ultraplan.drift_action",
+			Value:   c.Ultraplan.DriftAction,
+			Message: "must be 'off', 'warn', or 'replan'",
+		})
+	}
+
+	// Validate conflict prediction
+	validConflictPredictions := []string{"off", "warn", "serialize"}
+	if c.Ultraplan.ConflictPrediction != "" && !slices.Contains(validConflictPredictions, c.Ultraplan.ConflictPrediction) {
+		errors = append(errors, ValidationError{
+			Field:   "ultraplan.conflict_prediction",
+			Value:   c.Ultraplan.ConflictPrediction,
+			Message: "must be 'off', 'warn', or 'serialize'",
+		})
+	}
+
 	// Validate max task retries
 	if c.Ultraplan.MaxTaskRetries < 0 {
 		errors = append(errors, ValidationError{
@@ -426,6 +1081,133 @@ func (c *Config) validateUltraplan() []ValidationError {
 		})
 	}
 
+	// Validate synthesis reviewers
+	if c.Ultraplan.SynthesisReviewers < 0 || c.Ultraplan.SynthesisReviewers > 3 {
+		errors = append(errors, ValidationError{
+			Field:   "ultraplan.synthesis_reviewers",
+			Value:   c.Ultraplan.SynthesisReviewers,
+			Message: "must be between 1 and 3",
+		})
+	}
+
+	// Validate retry backoff
+	if c.Ultraplan.Retry.BackoffSeconds < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ultraplan.retry.backoff_seconds",
+			Value:   c.Ultraplan.Retry.BackoffSeconds,
+			Message: "cannot be negative",
+		})
+	}
+	if c.Ultraplan.Retry.MaxBackoffSeconds < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ultraplan.retry.max_backoff_seconds",
+			Value:   c.Ultraplan.Retry.MaxBackoffSeconds,
+			Message: "cannot be negative",
+		})
+	}
+
+	if c.Ultraplan.ProgressSummaryMinutes < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ultraplan.progress_summary_minutes",
+			Value:   c.Ultraplan.ProgressSummaryMinutes,
+			Message: "cannot be negative",
+		})
+	}
+
+	// Validate priority scheduling
+	if c.Ultraplan.PriorityAgingSeconds < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ultraplan.priority_aging_seconds",
+			Value:   c.Ultraplan.PriorityAgingSeconds,
+			Message: "cannot be negative",
+		})
+	}
+	if c.Ultraplan.Preemption.WaitSeconds < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ultraplan.preemption.wait_seconds",
+			Value:   c.Ultraplan.Preemption.WaitSeconds,
+			Message: "cannot be negative",
+		})
+	}
+	if c.Ultraplan.Preemption.MinPriorityGap < 1 {
+		errors = append(errors, ValidationError{
+			Field:   "ultraplan.preemption.min_priority_gap",
+			Value:   c.Ultraplan.Preemption.MinPriorityGap,
+			Message: "must be at least 1",
+		})
+	}
+
+	// Validate cost-aware scaling
+	if c.Ultraplan.Scaling.BudgetCeiling < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ultraplan.scaling.budget_ceiling",
+			Value:   c.Ultraplan.Scaling.BudgetCeiling,
+			Message: "cannot be negative",
+		})
+	}
+	if c.Ultraplan.Scaling.BurnRateLimit < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ultraplan.scaling.burn_rate_limit",
+			Value:   c.Ultraplan.Scaling.BurnRateLimit,
+			Message: "cannot be negative",
+		})
+	}
+
+	// Validate per-complexity models
+	for _, complexity := range slices.Sorted(maps.Keys(c.Ultraplan.Models.ByComplexity)) {
+		if !slices.Contains([]string{"low", "medium", "high"}, complexity) {
+			errors = append(errors, ValidationError{
+				Field:   "ultraplan.models.by_complexity",
+				Value:   complexity,
+				Message: "keys must be one of: low, medium, high",
+			})
+		}
+	}
+
+	// Validate multi-repo checkouts
+	for _, name := range slices.Sorted(maps.Keys(c.Ultraplan.Repos)) {
+		if c.Ultraplan.Repos[name] == "" {
+			errors = append(errors, ValidationError{
+				Field:   "ultraplan.repos",
+				Value:   name,
+				Message: "repository path cannot be empty",
+			})
+		}
+	}
+
+	if c.Ultraplan.MessageGuard != "" && !slices.Contains(ValidMessageGuards(), c.Ultraplan.MessageGuard) {
+		errors = append(errors, ValidationError{
+			Field:   "ultraplan.message_guard",
+			Value:   c.Ultraplan.MessageGuard,
+			Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidMessageGuards(), ", ")),
+		})
+	}
+
+	// Validate group verification commands
+	if c.Ultraplan.Verify.OnFailure != "" && !slices.Contains([]string{"fail", "pause"}, c.Ultraplan.Verify.OnFailure) {
+		errors = append(errors, ValidationError{
+			Field:   "ultraplan.verify.on_failure",
+			Value:   c.Ultraplan.Verify.OnFailure,
+			Message: "must be 'fail' or 'pause'",
+		})
+	}
+	if c.Ultraplan.Verify.TimeoutSeconds < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ultraplan.verify.timeout_seconds",
+			Value:   c.Ultraplan.Verify.TimeoutSeconds,
+			Message: "cannot be negative",
+		})
+	}
+	for i, cmd := range c.Ultraplan.Verify.Commands {
+		if strings.TrimSpace(cmd.Command) == "" {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("ultraplan.verify.commands[%d].command", i),
+				Value:   cmd.Command,
+				Message: "is required",
+			})
+		}
+	}
+
 	return errors
 }
 
@@ -484,11 +1266,11 @@ func (c *Config) validateAdversarial() []ValidationError {
 	}
 
 	// ReviewerBackend must be a valid backend if specified
-	if c.Adversarial.ReviewerBackend != "" && !slices.Contains(ValidAIBackends(), c.Adversarial.ReviewerBackend) {
+	if c.Adversarial.ReviewerBackend != "" && !slices.Contains(c.AI.BackendNames(), c.Adversarial.ReviewerBackend) {
 		errors = append(errors, ValidationError{
 			Field:   "adversarial.reviewer_backend",
 			Value:   c.Adversarial.ReviewerBackend,
-			Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidAIBackends(), ", ")),
+			Message: fmt.Sprintf("must be one of: %s", strings.Join(c.AI.BackendNames(), ", ")),
 		})
 	}
 
@@ -536,6 +1318,99 @@ func (c *Config) validateLogging() []ValidationError {
 		})
 	}
 
+	for i, sink := range c.Logging.Sinks {
+		errors = append(errors, validateLogSink(fmt.Sprintf("logging.sinks[%d]", i), sink)...)
+	}
+
+	for i, limit := range c.Logging.RateLimits {
+		prefix := fmt.Sprintf("logging.rate_limits[%d]", i)
+		if limit.Message == "" {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".message",
+				Value:   limit.Message,
+				Message: "must not be empty",
+			})
+		}
+		if limit.IntervalSeconds < 0 || limit.Sample < 0 {
+			errors = append(errors, ValidationError{
+				Field:   prefix,
+				Value:   limit,
+				Message: "interval_seconds and sample must be non-negative",
+			})
+		} else if limit.IntervalSeconds == 0 && limit.Sample <= 1 {
+			errors = append(errors, ValidationError{
+				Field:   prefix,
+				Value:   limit,
+				Message: "must set interval_seconds or a sample above 1",
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateLogSink validates one log sink, whose fields are named under prefix.
+func validateLogSink(prefix string, sink LogSinkConfig) []ValidationError {
+	var errors []ValidationError
+
+	switch sink.Type {
+	case "syslog":
+		if sink.Address != "" {
+			if u, err := url.Parse(sink.Address); err != nil || !slices.Contains([]string{"udp", "tcp", "unix"}, u.Scheme) {
+				errors = append(errors, ValidationError{
+					Field:   prefix + ".address",
+					Value:   sink.Address,
+					Message: "must be a udp://, tcp://, or unix:// address",
+				})
+			}
+		}
+	case "http":
+		if u, err := url.Parse(sink.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".url",
+				Value:   sink.URL,
+				Message: "must be an http or https URL",
+			})
+		}
+		if sink.Format != "" && !slices.Contains(ValidLogSinkFormats(), sink.Format) {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".format",
+				Value:   sink.Format,
+				Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidLogSinkFormats(), ", ")),
+			})
+		}
+	case "stderr":
+	default:
+		errors = append(errors, ValidationError{
+			Field:   prefix + ".type",
+			Value:   sink.Type,
+			Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidLogSinkTypes(), ", ")),
+		})
+	}
+
+	if sink.BufferSize < 0 {
+		errors = append(errors, ValidationError{
+			Field:   prefix + ".buffer_size",
+			Value:   sink.BufferSize,
+			Message: "must be non-negative (0 uses the default)",
+		})
+	}
+
+	return errors
+}
+
+// validateExperimental validates the ExperimentalConfig
+func (c *Config) validateExperimental() []ValidationError {
+	var errors []ValidationError
+
+	if c.Experimental.GitBackend != "" && !slices.Contains(ValidGitBackends(), c.Experimental.GitBackend) {
+		errors = append(errors, ValidationError{
+			Field:   "experimental.git_backend",
+			Value:   c.Experimental.GitBackend,
+			Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidGitBackends(), ", ")),
+		})
+	}
+
 	return errors
 }
 
@@ -570,6 +1445,49 @@ func (c *Config) validatePaths() []ValidationError {
 	// Validate sparse checkout configuration
 	errors = append(errors, c.validateSparseCheckout()...)
 
+	// Validate worktree bootstrap configuration
+	errors = append(errors, c.validateBootstrap()...)
+
+	return errors
+}
+
+// validateBootstrap validates the BootstrapConfig
+func (c *Config) validateBootstrap() []ValidationError {
+	var errors []ValidationError
+
+	bs := c.Paths.Bootstrap
+
+	// Shared dirs follow the same path rules as sparse checkout directories
+	errors = append(errors, validateDirectoryList(bs.SharedDirs, "paths.bootstrap.shared_dirs", false)...)
+
+	for i, cmd := range bs.Commands {
+		if strings.TrimSpace(cmd) == "" {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("paths.bootstrap.commands[%d]", i),
+				Value:   cmd,
+				Message: "command cannot be empty",
+			})
+		}
+	}
+
+	for i, kv := range bs.Env {
+		if key, _, ok := strings.Cut(kv, "="); !ok || key == "" {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("paths.bootstrap.env[%d]", i),
+				Value:   kv,
+				Message: "must be in KEY=VALUE form",
+			})
+		}
+	}
+
+	if bs.TimeoutSeconds < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "paths.bootstrap.timeout_seconds",
+			Value:   bs.TimeoutSeconds,
+			Message: "must be non-negative (0 = no limit)",
+		})
+	}
+
 	return errors
 }
 
@@ -716,3 +1634,237 @@ func checkDuplicateDirectories(dirs, alwaysInclude []string) []ValidationError {
 
 	return errors
 }
+
+// ValidExperimentUnits returns the list of valid experiment randomization units
+func ValidExperimentUnits() []string {
+	return []string{"task", "session"}
+}
+
+// validateExperiments validates the prompt experiment definitions
+func (c *Config) validateExperiments() []ValidationError {
+	var errors []ValidationError
+
+	seen := make(map[string]bool)
+	for i, exp := range c.Experiments {
+		prefix := fmt.Sprintf("experiments[%d]", i)
+
+		if exp.Name == "" {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".name",
+				Value:   exp.Name,
+				Message: "is required",
+			})
+		} else if seen[exp.Name] {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".name",
+				Value:   exp.Name,
+				Message: "must be unique",
+			})
+		}
+		seen[exp.Name] = true
+
+		if exp.Phase != "" && exp.Phase != "task" {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".phase",
+				Value:   exp.Phase,
+				Message: "must be 'task'",
+			})
+		}
+		if exp.Unit != "" && !slices.Contains(ValidExperimentUnits(), exp.Unit) {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".unit",
+				Value:   exp.Unit,
+				Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidExperimentUnits(), ", ")),
+			})
+		}
+		if exp.Fraction < 0 || exp.Fraction > 1 {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".fraction",
+				Value:   exp.Fraction,
+				Message: "must be between 0 and 1",
+			})
+		}
+		if len(exp.Variants) == 0 {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".variants",
+				Value:   len(exp.Variants),
+				Message: "at least one variant is required",
+			})
+		}
+		for j, v := range exp.Variants {
+			vprefix := fmt.Sprintf("%s.variants[%d]", prefix, j)
+			if v.Name == "" || v.Name == "control" {
+				errors = append(errors, ValidationError{
+					Field:   vprefix + ".name",
+					Value:   v.Name,
+					Message: "is required and must not be 'control'",
+				})
+			}
+			if v.Weight < 0 {
+				errors = append(errors, ValidationError{
+					Field:   vprefix + ".weight",
+					Value:   v.Weight,
+					Message: "cannot be negative",
+				})
+			}
+		}
+	}
+
+	return errors
+}
+
+// ValidPlacementPolicies returns the list of valid node placement policies
+func ValidPlacementPolicies() []string {
+	return []string{"spread", "pack"}
+}
+
+// envKeyPattern matches a portable environment variable name
+var envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validatePlacement validates the worker node inventory used for placement
+func (c *Config) validatePlacement() []ValidationError {
+	var errors []ValidationError
+	placement := c.Ultraplan.Placement
+
+	if placement.Policy != "" && !slices.Contains(ValidPlacementPolicies(), placement.Policy) {
+		errors = append(errors, ValidationError{
+			Field:   "ultraplan.placement.policy",
+			Value:   placement.Policy,
+			Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidPlacementPolicies(), ", ")),
+		})
+	}
+
+	seen := make(map[string]bool)
+	for i, node := range placement.Nodes {
+		prefix := fmt.Sprintf("ultraplan.placement.nodes[%d]", i)
+
+		if node.ID == "" {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".id",
+				Value:   node.ID,
+				Message: "is required",
+			})
+		} else if seen[node.ID] {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".id",
+				Value:   node.ID,
+				Message: "must be unique",
+			})
+		}
+		seen[node.ID] = true
+
+		if node.Capacity <= 0 {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".capacity",
+				Value:   node.Capacity,
+				Message: "must be positive",
+			})
+		}
+		for j, entry := range node.Env {
+			key, _, ok := strings.Cut(entry, "=")
+			if !ok || !envKeyPattern.MatchString(key) {
+				errors = append(errors, ValidationError{
+					Field:   fmt.Sprintf("%s.env[%d]", prefix, j),
+					Value:   entry,
+					Message: "must be KEY=VALUE with a valid variable name",
+				})
+			}
+		}
+	}
+
+	return errors
+}
+
+// templateNamePattern matches a template name usable as a CLI argument
+var templateNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// validateObjectiveTemplates validates user-defined ultraplan objective templates
+func (c *Config) validateObjectiveTemplates() []ValidationError {
+	var errors []ValidationError
+
+	seen := make(map[string]bool)
+	for i, tmpl := range c.Ultraplan.Templates {
+		prefix := fmt.Sprintf("ultraplan.templates[%d]", i)
+
+		switch {
+		case tmpl.Name == "":
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".name",
+				Value:   tmpl.Name,
+				Message: "is required",
+			})
+		case !templateNamePattern.MatchString(tmpl.Name):
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".name",
+				Value:   tmpl.Name,
+				Message: "must contain only lowercase letters, digits, and hyphens",
+			})
+		case seen[tmpl.Name]:
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".name",
+				Value:   tmpl.Name,
+				Message: "must be unique",
+			})
+		}
+		seen[tmpl.Name] = true
+
+		if tmpl.ConsolidationMode != "" && tmpl.ConsolidationMode != "stacked" && tmpl.ConsolidationMode != "single" {
+			errors = append(errors, ValidationError{
+				Field:   prefix + ".consolidation_mode",
+				Value:   tmpl.ConsolidationMode,
+				Message: "must be 'stacked' or 'single'",
+			})
+		}
+
+		errors = append(errors, validateTemplateVariables(prefix, tmpl.Variables)...)
+	}
+
+	return errors
+}
+
+// templateVariablePattern matches a variable name usable in a {{name}} placeholder
+var templateVariablePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// validateTemplateVariables validates the variables of an objective template.
+// Whether defaults suit their types is checked when the template is loaded.
+func validateTemplateVariables(prefix string, variables []TemplateVariableConfig) []ValidationError {
+	var errors []ValidationError
+
+	seen := make(map[string]bool)
+	for i, v := range variables {
+		field := fmt.Sprintf("%s.variables[%d]", prefix, i)
+
+		switch {
+		case !templateVariablePattern.MatchString(v.Name):
+			errors = append(errors, ValidationError{
+				Field:   field + ".name",
+				Value:   v.Name,
+				Message: "must start with a lowercase letter and contain only lowercase letters, digits, and underscores",
+			})
+		case seen[v.Name]:
+			errors = append(errors, ValidationError{
+				Field:   field + ".name",
+				Value:   v.Name,
+				Message: "must be unique",
+			})
+		}
+		seen[v.Name] = true
+
+		if v.Type != "" && !slices.Contains(ValidTemplateVariableTypes(), v.Type) {
+			errors = append(errors, ValidationError{
+				Field:   field + ".type",
+				Value:   v.Type,
+				Message: fmt.Sprintf("must be one of: %s", strings.Join(ValidTemplateVariableTypes(), ", ")),
+			})
+		}
+		if v.Type == "choice" && len(v.Options) == 0 {
+			errors = append(errors, ValidationError{
+				Field:   field + ".options",
+				Value:   v.Options,
+				Message: "is required for a choice variable",
+			})
+		}
+	}
+
+	return errors
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -207,7 +208,7 @@ func TestConfig_Validate_TUI(t *testing.T) {
 	})
 
 	t.Run("valid themes", func(t *testing.T) {
-		for _, theme := range []string{"default", "monokai", "dracula", "nord", ""} {
+		for _, theme := range []string{"default", "monokai", "dracula", "nord", "light", "high-contrast", ""} {
 			cfg := Default()
 			cfg.TUI.Theme = theme
 			errs := cfg.Validate()
@@ -253,6 +254,30 @@ func TestConfig_Validate_TUI(t *testing.T) {
 			t.Error("expected error for uppercase theme name")
 		}
 	})
+
+	t.Run("colors", func(t *testing.T) {
+		tests := []struct {
+			colors  map[string]string
+			wantErr bool
+		}{
+			{map[string]string{"primary": "#FF8800", "status_working": "#0F0"}, false},
+			{map[string]string{"primary": "orange"}, true},
+			{map[string]string{"sky": "#0000FF"}, true},
+		}
+		for _, tt := range tests {
+			cfg := Default()
+			cfg.TUI.Colors = tt.colors
+			found := false
+			for _, err := range cfg.Validate() {
+				if err.Field == "tui.colors" {
+					found = true
+				}
+			}
+			if found != tt.wantErr {
+				t.Errorf("colors %v: got error = %v, want %v", tt.colors, found, tt.wantErr)
+			}
+		}
+	})
 }
 
 func TestConfig_Validate_Instance(t *testing.T) {
@@ -392,6 +417,212 @@ func TestConfig_Validate_Instance(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("escalation steps", func(t *testing.T) {
+		cfg := Default()
+		cfg.Instance.Escalation.Steps = []string{"nudge", "mark_stuck", "nudge", "reboot"}
+		errs := cfg.Validate()
+
+		want := map[string]string{
+			"instance.escalation.steps[1]": "must be the last step",
+			"instance.escalation.steps[2]": "must not be repeated",
+			"instance.escalation.steps[3]": "must be one of",
+		}
+		for _, err := range errs {
+			if msg, ok := want[err.Field]; ok && strings.Contains(err.Message, msg) {
+				delete(want, err.Field)
+			}
+		}
+		for field, msg := range want {
+			t.Errorf("expected %q error for %s", msg, field)
+		}
+	})
+
+	t.Run("escalation step wait", func(t *testing.T) {
+		stepWaitError := func(cfg *Config) bool {
+			for _, err := range cfg.Validate() {
+				if err.Field == "instance.escalation.step_wait_seconds" {
+					return true
+				}
+			}
+			return false
+		}
+
+		cfg := Default()
+		cfg.Instance.Escalation.StepWaitSeconds = 0
+		if !stepWaitError(cfg) {
+			t.Error("expected error for zero step wait with escalation enabled")
+		}
+
+		cfg.Instance.Escalation.Enabled = false
+		if stepWaitError(cfg) {
+			t.Error("step wait should not be validated when escalation is disabled")
+		}
+	})
+
+	t.Run("escalation max nudges", func(t *testing.T) {
+		cfg := Default()
+		cfg.Instance.Escalation.MaxNudges = 0
+		found := false
+		for _, err := range cfg.Validate() {
+			if err.Field == "instance.escalation.max_nudges" {
+				found = true
+			}
+		}
+		if !found {
+			t.Error("expected error for zero max nudges")
+		}
+	})
+
+	t.Run("timeout policies", func(t *testing.T) {
+		cfg := Default()
+		cfg.Instance.TimeoutPolicies = []TimeoutPolicyConfig{
+			{Name: "long", Complexity: []string{"high"}, ActivityTimeoutMinutes: 90, Actions: []string{"warn", "nudge", "fail"}},
+			{Name: "long", Complexity: []string{"huge"}, StaleThreshold: -1, Actions: []string{"fail", "reboot"}},
+			{Name: ""},
+		}
+		errs := cfg.Validate()
+
+		want := map[string]string{
+			"instance.timeout_policies[1].name":            "must be unique",
+			"instance.timeout_policies[1].complexity[0]":   "must be one of",
+			"instance.timeout_policies[1].stale_threshold": "must be non-negative",
+			"instance.timeout_policies[1].actions[0]":      "must be the last action",
+			"instance.timeout_policies[1].actions[1]":      "must be one of",
+			"instance.timeout_policies[2].name":            "is required",
+		}
+		for _, err := range errs {
+			if !strings.HasPrefix(err.Field, "instance.timeout_policies") {
+				continue
+			}
+			msg, ok := want[err.Field]
+			if !ok || !strings.Contains(err.Message, msg) {
+				t.Errorf("unexpected error: %v", err)
+				continue
+			}
+			delete(want, err.Field)
+		}
+		for field, msg := range want {
+			t.Errorf("expected %q error for %s", msg, field)
+		}
+	})
+
+	t.Run("permission policy", func(t *testing.T) {
+		cfg := Default()
+		cfg.Instance.PermissionPolicy = PermissionPolicyConfig{
+			Enabled:  true,
+			Response: " ",
+			Rules: []PermissionRuleConfig{
+				{Name: "tests", Action: "allow", Tools: []string{"Bash"}, Commands: []string{"go test *"}},
+				{Name: "maybe", Action: "ask", Tools: []string{"Bash"}},
+				{Name: "empty", Action: "deny"},
+				{Name: "broken", Action: "deny", Prompt: "("},
+			},
+		}
+		errs := cfg.Validate()
+
+		want := map[string]string{
+			"instance.permission_policy.response":        "must not be empty",
+			"instance.permission_policy.rules[1].action": "must be one of",
+			"instance.permission_policy.rules[2]":        "must set at least one",
+			"instance.permission_policy.rules[3].prompt": "invalid regular expression",
+		}
+		for _, err := range errs {
+			if !strings.HasPrefix(err.Field, "instance.permission_policy") {
+				continue
+			}
+			msg, ok := want[err.Field]
+			if !ok || !strings.Contains(err.Message, msg) {
+				t.Errorf("unexpected error: %v", err)
+				continue
+			}
+			delete(want, err.Field)
+		}
+		for field, msg := range want {
+			t.Errorf("expected %q error for %s", msg, field)
+		}
+	})
+
+	t.Run("resource limits", func(t *testing.T) {
+		cfg := Default()
+		cfg.Instance.ResourceLimits = ResourceLimitsConfig{
+			Enabled:    true,
+			Nice:       20,
+			GoMaxProcs: -1,
+			CPUPercent: -100,
+			MemoryMB:   -1,
+		}
+		errs := cfg.Validate()
+
+		want := map[string]string{
+			"instance.resource_limits.nice":        "between 0 and 19",
+			"instance.resource_limits.gomaxprocs":  "must be non-negative",
+			"instance.resource_limits.cpu_percent": "must be non-negative",
+			"instance.resource_limits.memory_mb":   "must be non-negative",
+		}
+		for _, err := range errs {
+			if !strings.HasPrefix(err.Field, "instance.resource_limits") {
+				continue
+			}
+			msg, ok := want[err.Field]
+			if !ok || !strings.Contains(err.Message, msg) {
+				t.Errorf("unexpected error: %v", err)
+				continue
+			}
+			delete(want, err.Field)
+		}
+		for field, msg := range want {
+			t.Errorf("expected %q error for %s", msg, field)
+		}
+	})
+
+	t.Run("backend", func(t *testing.T) {
+		for _, backend := range []string{"", "tmux", "pty"} {
+			cfg := Default()
+			cfg.Instance.Backend = backend
+			for _, err := range cfg.Validate() {
+				if err.Field == "instance.backend" {
+					t.Errorf("backend %q: unexpected error: %v", backend, err)
+				}
+			}
+		}
+
+		cfg := Default()
+		cfg.Instance.Backend = "screen"
+		hasError := false
+		for _, err := range cfg.Validate() {
+			if err.Field == "instance.backend" {
+				hasError = true
+			}
+		}
+		if !hasError {
+			t.Error("expected validation error for instance.backend")
+		}
+	})
+
+	t.Run("output format", func(t *testing.T) {
+		for _, format := range []string{"", "text", "stream-json"} {
+			cfg := Default()
+			cfg.Instance.OutputFormat = format
+			for _, err := range cfg.Validate() {
+				if err.Field == "instance.output_format" {
+					t.Errorf("output format %q: unexpected error: %v", format, err)
+				}
+			}
+		}
+
+		cfg := Default()
+		cfg.Instance.OutputFormat = "json"
+		hasError := false
+		for _, err := range cfg.Validate() {
+			if err.Field == "instance.output_format" {
+				hasError = true
+			}
+		}
+		if !hasError {
+			t.Error("expected validation error for instance.output_format")
+		}
+	})
 }
 
 func TestConfig_Validate_AI(t *testing.T) {
@@ -485,6 +716,314 @@ func TestConfig_Validate_AI(t *testing.T) {
 	})
 }
 
+func TestConfig_Validate_SessionStorage(t *testing.T) {
+	tests := []struct {
+		name    string
+		storage SessionStorageConfig
+		field   string // Expected error field; empty means valid
+	}{
+		{"disabled", SessionStorageConfig{CheckpointIntervalSeconds: 30}, ""},
+		{"local", SessionStorageConfig{Backend: "local", Path: "/cache", CheckpointIntervalSeconds: 30}, ""},
+		{"s3", SessionStorageConfig{Backend: "s3", Bucket: "b", Endpoint: "http://minio:9000", CheckpointIntervalSeconds: 30}, ""},
+		{"unknown backend", SessionStorageConfig{Backend: "gcs", CheckpointIntervalSeconds: 30}, "session.storage.backend"},
+		{"zero interval", SessionStorageConfig{}, "session.storage.checkpoint_interval_seconds"},
+		{"local without path", SessionStorageConfig{Backend: "local", CheckpointIntervalSeconds: 30}, "session.storage.path"},
+		{"s3 without bucket", SessionStorageConfig{Backend: "s3", CheckpointIntervalSeconds: 30}, "session.storage.bucket"},
+		{"bad endpoint", SessionStorageConfig{Backend: "s3", Bucket: "b", Endpoint: "minio:9000", CheckpointIntervalSeconds: 30}, "session.storage.endpoint"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Session.Storage = tt.storage
+			var fields []string
+			for _, err := range cfg.Validate() {
+				if strings.HasPrefix(err.Field, "session.storage.") {
+					fields = append(fields, err.Field)
+				}
+			}
+			if tt.field == "" && len(fields) > 0 {
+				t.Errorf("unexpected errors for %v", fields)
+			}
+			if tt.field != "" && !slices.Contains(fields, tt.field) {
+				t.Errorf("errors = %v, want %s", fields, tt.field)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_SessionArchive(t *testing.T) {
+	tests := []struct {
+		name    string
+		archive SessionArchiveConfig
+		field   string // Expected error field; empty means valid
+	}{
+		{"disabled", SessionArchiveConfig{}, ""},
+		{"enabled", SessionArchiveConfig{AfterDays: 14, KeepLast: 20, MaxTotalMB: 512}, ""},
+		{"negative after days", SessionArchiveConfig{AfterDays: -1}, "session.archive.after_days"},
+		{"negative keep last", SessionArchiveConfig{KeepLast: -1}, "session.archive.keep_last"},
+		{"negative max total", SessionArchiveConfig{MaxTotalMB: -1}, "session.archive.max_total_mb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Session.Archive = tt.archive
+			var fields []string
+			for _, err := range cfg.Validate() {
+				if strings.HasPrefix(err.Field, "session.archive.") {
+					fields = append(fields, err.Field)
+				}
+			}
+			if tt.field == "" && len(fields) > 0 {
+				t.Errorf("unexpected errors for %v", fields)
+			}
+			if tt.field != "" && !slices.Contains(fields, tt.field) {
+				t.Errorf("errors = %v, want %s", fields, tt.field)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_Webhooks(t *testing.T) {
+	slack := WebhookEndpointConfig{URL: "https://hooks.slack.com/services/T/B/x"}
+	tests := []struct {
+		name   string
+		modify func(*WebhooksConfig)
+		field  string // Expected error field; empty means valid
+	}{
+		{"default", func(*WebhooksConfig) {}, ""},
+		{"endpoint", func(w *WebhooksConfig) { w.Endpoints = []WebhookEndpointConfig{slack} }, ""},
+		{"discord format", func(w *WebhooksConfig) {
+			w.Endpoints = []WebhookEndpointConfig{{URL: slack.URL, Format: "discord"}}
+		}, ""},
+		{"relative url", func(w *WebhooksConfig) {
+			w.Endpoints = []WebhookEndpointConfig{{URL: "hooks.slack.com/x"}}
+		}, "webhooks.endpoints[0].url"},
+		{"unknown format", func(w *WebhooksConfig) {
+			w.Endpoints = []WebhookEndpointConfig{slack, {URL: slack.URL, Format: "teams"}}
+		}, "webhooks.endpoints[1].format"},
+		{"negative timeout", func(w *WebhooksConfig) { w.TimeoutSeconds = -1 }, "webhooks.timeout_seconds"},
+		{"custom template", func(w *WebhooksConfig) { w.Templates.TaskFailed = "{{.Task}} failed" }, ""},
+		{"broken template", func(w *WebhooksConfig) { w.Templates.PROpened = "{{.PRURL" }, "webhooks.templates.pr_opened"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(&cfg.Webhooks)
+			var fields []string
+			for _, err := range cfg.Validate() {
+				if strings.HasPrefix(err.Field, "webhooks.") {
+					fields = append(fields, err.Field)
+				}
+			}
+			if tt.field == "" && len(fields) > 0 {
+				t.Errorf("unexpected errors for %v", fields)
+			}
+			if tt.field != "" && !slices.Contains(fields, tt.field) {
+				t.Errorf("errors = %v, want %s", fields, tt.field)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_LogSinks(t *testing.T) {
+	tests := []struct {
+		name  string
+		sink  LogSinkConfig
+		field string // Expected error field; empty means valid
+	}{
+		{"stderr", LogSinkConfig{Type: "stderr"}, ""},
+		{"local syslog", LogSinkConfig{Type: "syslog"}, ""},
+		{"remote syslog", LogSinkConfig{Type: "syslog", Address: "udp://logs.example.com:514"}, ""},
+		{"bare syslog address", LogSinkConfig{Type: "syslog", Address: "logs.example.com:514"}, "logging.sinks[0].address"},
+		{"loki", LogSinkConfig{Type: "http", URL: "http://localhost:3100/loki/api/v1/push", Format: "loki"}, ""},
+		{"missing url", LogSinkConfig{Type: "http"}, "logging.sinks[0].url"},
+		{"unknown format", LogSinkConfig{Type: "http", URL: "https://logs.example.com", Format: "xml"}, "logging.sinks[0].format"},
+		{"unknown type", LogSinkConfig{Type: "kafka"}, "logging.sinks[0].type"},
+		{"negative buffer", LogSinkConfig{Type: "stderr", BufferSize: -1}, "logging.sinks[0].buffer_size"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Logging.Sinks = []LogSinkConfig{tt.sink}
+			var fields []string
+			for _, err := range cfg.Validate() {
+				if strings.HasPrefix(err.Field, "logging.") {
+					fields = append(fields, err.Field)
+				}
+			}
+			if tt.field == "" && len(fields) > 0 {
+				t.Errorf("unexpected errors for %v", fields)
+			}
+			if tt.field != "" && !slices.Contains(fields, tt.field) {
+				t.Errorf("errors = %v, want %s", fields, tt.field)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_LogRateLimits(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit LogRateLimitConfig
+		field string // Expected error field; empty means valid
+	}{
+		{"interval", LogRateLimitConfig{Message: "tick", IntervalSeconds: 30}, ""},
+		{"sample", LogRateLimitConfig{Message: "tick", Sample: 10}, ""},
+		{"no message", LogRateLimitConfig{IntervalSeconds: 30}, "logging.rate_limits[0].message"},
+		{"limits nothing", LogRateLimitConfig{Message: "tick", Sample: 1}, "logging.rate_limits[0]"},
+		{"negative interval", LogRateLimitConfig{Message: "tick", IntervalSeconds: -1}, "logging.rate_limits[0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Logging.RateLimits = []LogRateLimitConfig{tt.limit}
+			var fields []string
+			for _, err := range cfg.Validate() {
+				if strings.HasPrefix(err.Field, "logging.") {
+					fields = append(fields, err.Field)
+				}
+			}
+			if tt.field == "" && len(fields) > 0 {
+				t.Errorf("unexpected errors for %v", fields)
+			}
+			if tt.field != "" && !slices.Contains(fields, tt.field) {
+				t.Errorf("errors = %v, want %s", fields, tt.field)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_EventServer(t *testing.T) {
+	tests := []struct {
+		name   string
+		server EventServerConfig
+		field  string // Expected error field; empty means valid
+	}{
+		{"default", Default().EventServer, ""},
+		{"any interface", EventServerConfig{Enabled: true, Address: ":7878"}, ""},
+		{"missing port", EventServerConfig{Address: "localhost"}, "event_server.address"},
+		{"negative replay", EventServerConfig{Address: "127.0.0.1:7878", ReplaySize: -1}, "event_server.replay_size"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.EventServer = tt.server
+			var fields []string
+			for _, err := range cfg.Validate() {
+				if strings.HasPrefix(err.Field, "event_server.") {
+					fields = append(fields, err.Field)
+				}
+			}
+			if tt.field == "" && len(fields) > 0 {
+				t.Errorf("unexpected errors for %v", fields)
+			}
+			if tt.field != "" && !slices.Contains(fields, tt.field) {
+				t.Errorf("errors = %v, want %s", fields, tt.field)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_Tracing(t *testing.T) {
+	tests := []struct {
+		name    string
+		tracing TracingConfig
+		field   string // Expected error field; empty means valid
+	}{
+		{"default", Default().Tracing, ""},
+		{"file exporter", TracingConfig{Enabled: true, Exporter: "file", SampleRatio: 0.5}, ""},
+		{"collector", TracingConfig{Exporter: "otlp", Endpoint: "otel.internal:4317", SampleRatio: 1}, ""},
+		{"unknown exporter", TracingConfig{Exporter: "jaeger", SampleRatio: 1}, "tracing.exporter"},
+		{"missing port", TracingConfig{Exporter: "otlp", Endpoint: "localhost", SampleRatio: 1}, "tracing.endpoint"},
+		{"ratio above one", TracingConfig{Exporter: "otlp", SampleRatio: 1.5}, "tracing.sample_ratio"},
+		{"negative ratio", TracingConfig{Exporter: "otlp", SampleRatio: -0.1}, "tracing.sample_ratio"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Tracing = tt.tracing
+			var fields []string
+			for _, err := range cfg.Validate() {
+				if strings.HasPrefix(err.Field, "tracing.") {
+					fields = append(fields, err.Field)
+				}
+			}
+			if tt.field == "" && len(fields) > 0 {
+				t.Errorf("unexpected errors for %v", fields)
+			}
+			if tt.field != "" && !slices.Contains(fields, tt.field) {
+				t.Errorf("errors = %v, want %s", fields, tt.field)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_CLIBackends(t *testing.T) {
+	valid := CLIBackendConfig{
+		Name:    "aider",
+		Command: "aider --message-file {prompt_file}",
+	}
+
+	t.Run("valid backend can be selected", func(t *testing.T) {
+		cfg := Default()
+		cfg.AI.CLIBackends = []CLIBackendConfig{valid}
+		cfg.AI.Backend = "aider"
+		cfg.Adversarial.ReviewerBackend = "aider"
+		for _, err := range cfg.Validate() {
+			if strings.HasPrefix(err.Field, "ai.") || err.Field == "adversarial.reviewer_backend" {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}
+	})
+
+	tests := []struct {
+		name   string
+		modify func(*CLIBackendConfig)
+		field  string
+	}{
+		{"uppercase name", func(b *CLIBackendConfig) { b.Name = "Aider" }, "ai.cli_backends[0].name"},
+		{"reserved name", func(b *CLIBackendConfig) { b.Name = "tool" }, "ai.cli_backends[0].name"},
+		{"empty command", func(b *CLIBackendConfig) { b.Command = "" }, "ai.cli_backends[0].command"},
+		{"command without prompt", func(b *CLIBackendConfig) { b.Command = "aider" }, "ai.cli_backends[0].command"},
+		{"resume without session id", func(b *CLIBackendConfig) { b.ResumeCommand = "aider --resume {session_id}" }, "ai.cli_backends[0].resume_command"},
+		{"prompt file path", func(b *CLIBackendConfig) { b.PromptFile = "dir/prompt" }, "ai.cli_backends[0].prompt_file"},
+		{"invalid completion", func(b *CLIBackendConfig) { b.Completion = "poll" }, "ai.cli_backends[0].completion"},
+		{"invalid metrics", func(b *CLIBackendConfig) { b.Metrics = "json" }, "ai.cli_backends[0].metrics"},
+		{"invalid pattern", func(b *CLIBackendConfig) { b.ReadyPatterns = []string{"("} }, "ai.cli_backends[0].ready_patterns"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			b := valid
+			tt.modify(&b)
+			cfg.AI.CLIBackends = []CLIBackendConfig{b}
+			found := false
+			for _, err := range cfg.Validate() {
+				if err.Field == tt.field {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected validation error for %s", tt.field)
+			}
+		})
+	}
+
+	t.Run("duplicate name", func(t *testing.T) {
+		cfg := Default()
+		cfg.AI.CLIBackends = []CLIBackendConfig{valid, valid}
+		found := false
+		for _, err := range cfg.Validate() {
+			if err.Field == "ai.cli_backends[1].name" {
+				found = true
+			}
+		}
+		if !found {
+			t.Error("expected validation error for duplicate name")
+		}
+	})
+}
+
 func TestConfig_Validate_Branch(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -541,6 +1080,38 @@ func TestConfig_Validate_Branch(t *testing.T) {
 	})
 }
 
+func TestConfig_Validate_PR(t *testing.T) {
+	for _, mode := range []string{"degrade", "fail", ""} {
+		cfg := Default()
+		cfg.PR.MissingCLI = mode
+		for _, err := range cfg.Validate() {
+			if err.Field == "pr.missing_cli" {
+				t.Errorf("missing_cli %q should be valid: %v", mode, err)
+			}
+		}
+	}
+
+	cfg := Default()
+	cfg.PR.MissingCLI = "ignore"
+	found := false
+	for _, err := range cfg.Validate() {
+		if err.Field == "pr.missing_cli" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("expected error for invalid missing_cli")
+	}
+
+	cfg = Default()
+	cfg.PR.Provider = "gitea"
+	errs := cfg.Validate()
+	if len(errs) != 1 || errs[0].Field != "pr.provider" {
+		t.Errorf("errors = %v, want one for pr.provider", errs)
+	}
+}
+
 func TestConfig_Validate_Resources(t *testing.T) {
 	t.Run("negative cost warning threshold", func(t *testing.T) {
 		cfg := Default()
@@ -576,6 +1147,21 @@ func TestConfig_Validate_Resources(t *testing.T) {
 		}
 	})
 
+	t.Run("negative instance cost limit and invalid budget action", func(t *testing.T) {
+		cfg := Default()
+		cfg.Resources.InstanceCostLimit = -1
+		cfg.Resources.BudgetAction = "kill"
+		errs := cfg.Validate()
+
+		fields := make(map[string]bool)
+		for _, err := range errs {
+			fields[err.Field] = true
+		}
+		if !fields["resources.instance_cost_limit"] || !fields["resources.budget_action"] {
+			t.Errorf("expected errors for instance_cost_limit and budget_action, got %v", errs)
+		}
+	})
+
 	t.Run("warning threshold greater than limit", func(t *testing.T) {
 		cfg := Default()
 		cfg.Resources.CostWarningThreshold = 20.0
@@ -739,6 +1325,56 @@ func TestConfig_Validate_Ultraplan(t *testing.T) {
 		}
 	})
 
+	t.Run("drift actions", func(t *testing.T) {
+		for _, action := range []string{"off", "warn", "replan", ""} {
+			cfg := Default()
+			cfg.Ultraplan.DriftAction = action
+			for _, err := range cfg.Validate() {
+				if err.Field == "ultraplan.drift_action" {
+					t.Errorf("drift action %q should be valid: %v", action, err)
+				}
+			}
+		}
+
+		cfg := Default()
+		cfg.Ultraplan.DriftAction = "rebase"
+		found := false
+		for _, err := range cfg.Validate() {
+			if err.Field == "ultraplan.drift_action" {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Error("expected error for invalid drift action")
+		}
+	})
+
+	t.Run("conflict predictions", func(t *testing.T) {
+		for _, mode := range []string{"off", "warn", "serialize", ""} {
+			cfg := Default()
+			cfg.Ultraplan.ConflictPrediction = mode
+			for _, err := range cfg.Validate() {
+				if err.Field == "ultraplan.conflict_prediction" {
+					t.Errorf("conflict prediction %q should be valid: %v", mode, err)
+				}
+			}
+		}
+
+		cfg := Default()
+		cfg.Ultraplan.ConflictPrediction = "block"
+		found := false
+		for _, err := range cfg.Validate() {
+			if err.Field == "ultraplan.conflict_prediction" {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Error("expected error for invalid conflict prediction")
+		}
+	})
+
 	t.Run("negative max task retries", func(t *testing.T) {
 		cfg := Default()
 		cfg.Ultraplan.MaxTaskRetries = -1
@@ -767,6 +1403,130 @@ func TestConfig_Validate_Ultraplan(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("synthesis reviewers", func(t *testing.T) {
+		for n, wantErr := range map[int]bool{-1: true, 1: false, 3: false, 4: true} {
+			cfg := Default()
+			cfg.Ultraplan.SynthesisReviewers = n
+			errs := cfg.Validate()
+
+			found := false
+			for _, err := range errs {
+				if err.Field == "ultraplan.synthesis_reviewers" {
+					found = true
+				}
+			}
+			if found != wantErr {
+				t.Errorf("SynthesisReviewers = %d: got error %v, want %v", n, found, wantErr)
+			}
+		}
+	})
+
+	t.Run("negative retry backoff", func(t *testing.T) {
+		cfg := Default()
+		cfg.Ultraplan.Retry.BackoffSeconds = -1
+		cfg.Ultraplan.Retry.MaxBackoffSeconds = -1
+		errs := cfg.Validate()
+
+		fields := map[string]bool{}
+		for _, err := range errs {
+			fields[err.Field] = true
+		}
+		for _, field := range []string{"ultraplan.retry.backoff_seconds", "ultraplan.retry.max_backoff_seconds"} {
+			if !fields[field] {
+				t.Errorf("expected error for negative %s", field)
+			}
+		}
+	})
+
+	t.Run("invalid priority scheduling", func(t *testing.T) {
+		cfg := Default()
+		cfg.Ultraplan.PriorityAgingSeconds = -1
+		cfg.Ultraplan.Preemption.WaitSeconds = -1
+		cfg.Ultraplan.Preemption.MinPriorityGap = 0
+		errs := cfg.Validate()
+
+		fields := map[string]bool{}
+		for _, err := range errs {
+			fields[err.Field] = true
+		}
+		for _, field := range []string{"ultraplan.priority_aging_seconds", "ultraplan.preemption.wait_seconds", "ultraplan.preemption.min_priority_gap"} {
+			if !fields[field] {
+				t.Errorf("expected error for invalid %s", field)
+			}
+		}
+	})
+
+	t.Run("negative scaling limits", func(t *testing.T) {
+		cfg := Default()
+		cfg.Ultraplan.Scaling.BudgetCeiling = -1
+		cfg.Ultraplan.Scaling.BurnRateLimit = -0.5
+		errs := cfg.Validate()
+
+		fields := map[string]bool{}
+		for _, err := range errs {
+			fields[err.Field] = true
+		}
+		for _, field := range []string{"ultraplan.scaling.budget_ceiling", "ultraplan.scaling.burn_rate_limit"} {
+			if !fields[field] {
+				t.Errorf("expected error for negative %s", field)
+			}
+		}
+	})
+
+	t.Run("unknown model complexity", func(t *testing.T) {
+		cfg := Default()
+		cfg.Ultraplan.Models.ByComplexity = map[string]string{"high": "opus", "huge": "opus"}
+		errs := cfg.Validate()
+
+		var found []any
+		for _, err := range errs {
+			if err.Field == "ultraplan.models.by_complexity" {
+				found = append(found, err.Value)
+			}
+		}
+		if len(found) != 1 || found[0] != "huge" {
+			t.Errorf("expected one error for \"huge\", got %v", found)
+		}
+	})
+
+	t.Run("invalid group verification", func(t *testing.T) {
+		cfg := Default()
+		cfg.Ultraplan.Verify.OnFailure = "retry"
+		cfg.Ultraplan.Verify.TimeoutSeconds = -1
+		cfg.Ultraplan.Verify.Commands = []VerifyCommandConfig{{Name: "build", Command: "go build ./..."}, {Name: "test"}}
+		errs := cfg.Validate()
+
+		fields := map[string]bool{}
+		for _, err := range errs {
+			fields[err.Field] = true
+		}
+		for _, field := range []string{"ultraplan.verify.on_failure", "ultraplan.verify.timeout_seconds", "ultraplan.verify.commands[1].command"} {
+			if !fields[field] {
+				t.Errorf("expected error for %s", field)
+			}
+		}
+		if fields["ultraplan.verify.commands[0].command"] {
+			t.Error("unexpected error for a command that is set")
+		}
+	})
+
+	t.Run("negative max behind commits", func(t *testing.T) {
+		cfg := Default()
+		cfg.Ultraplan.MaxBehindCommits = -1
+		errs := cfg.Validate()
+
+		found := false
+		for _, err := range errs {
+			if err.Field == "ultraplan.max_behind_commits" {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Error("expected error for negative max behind commits")
+		}
+	})
 }
 
 func TestConfig_Validate_Adversarial(t *testing.T) {
@@ -1606,3 +2366,231 @@ func TestConfig_Validate_SparseCheckout(t *testing.T) {
 		}
 	})
 }
+
+func TestConfig_Validate_Experiments(t *testing.T) {
+	hasError := func(errs []ValidationError, field string) bool {
+		for _, err := range errs {
+			if err.Field == field {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("valid experiment", func(t *testing.T) {
+		cfg := Default()
+		cfg.Experiments = []ExperimentConfig{{
+			Name:     "terse",
+			Unit:     "session",
+			Fraction: 0.5,
+			Variants: []ExperimentVariantConfig{{Name: "short", Weight: 1, Template: "{{.Prompt}}"}},
+		}}
+		for _, err := range cfg.Validate() {
+			if strings.HasPrefix(err.Field, "experiments") {
+				t.Errorf("valid experiment should not error: %v", err)
+			}
+		}
+	})
+
+	t.Run("invalid fields", func(t *testing.T) {
+		cfg := Default()
+		cfg.Experiments = []ExperimentConfig{
+			{
+				Name:     "dup",
+				Phase:    "planning",
+				Unit:     "instance",
+				Fraction: 1.5,
+				Variants: []ExperimentVariantConfig{{Name: "control", Weight: -1}},
+			},
+			{Name: "dup"},
+		}
+		errs := cfg.Validate()
+
+		for _, field := range []string{
+			"experiments[0].phase",
+			"experiments[0].unit",
+			"experiments[0].fraction",
+			"experiments[0].variants[0].name",
+			"experiments[0].variants[0].weight",
+			"experiments[1].name",
+			"experiments[1].variants",
+		} {
+			if !hasError(errs, field) {
+				t.Errorf("expected validation error for %s", field)
+			}
+		}
+	})
+}
+
+func TestConfig_Validate_Experimental(t *testing.T) {
+	for _, backend := range []string{"", "exec", "go-git"} {
+		cfg := Default()
+		cfg.Experimental.GitBackend = backend
+		if errs := cfg.Validate(); len(errs) > 0 {
+			t.Errorf("git_backend %q: unexpected errors %v", backend, errs)
+		}
+	}
+
+	cfg := Default()
+	cfg.Experimental.GitBackend = "libgit2"
+	errs := cfg.Validate()
+	if len(errs) != 1 || errs[0].Field != "experimental.git_backend" {
+		t.Errorf("errors = %v, want one for experimental.git_backend", errs)
+	}
+}
+
+func TestConfig_Validate_Bootstrap(t *testing.T) {
+	t.Run("valid bootstrap config", func(t *testing.T) {
+		cfg := Default()
+		cfg.Paths.Bootstrap = BootstrapConfig{
+			Enabled:        true,
+			SharedDirs:     []string{"node_modules", ".build/"},
+			Commands:       []string{"npm ci --prefer-offline"},
+			Env:            []string{"GOFLAGS=-mod=mod", "EMPTY="},
+			TimeoutSeconds: 120,
+		}
+		for _, err := range cfg.Validate() {
+			if strings.HasPrefix(err.Field, "paths.bootstrap") {
+				t.Errorf("valid bootstrap config should not error: %v", err)
+			}
+		}
+	})
+
+	t.Run("invalid fields", func(t *testing.T) {
+		cfg := Default()
+		cfg.Paths.Bootstrap = BootstrapConfig{
+			SharedDirs:     []string{"../outside"},
+			Commands:       []string{"  "},
+			Env:            []string{"NOEQUALS", "=value"},
+			TimeoutSeconds: -1,
+		}
+		errs := cfg.Validate()
+
+		for _, field := range []string{
+			"paths.bootstrap.shared_dirs[0]",
+			"paths.bootstrap.commands[0]",
+			"paths.bootstrap.env[0]",
+			"paths.bootstrap.env[1]",
+			"paths.bootstrap.timeout_seconds",
+		} {
+			found := false
+			for _, err := range errs {
+				if err.Field == field {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("expected validation error for %s", field)
+			}
+		}
+	})
+}
+
+func TestConfig_Validate_Placement(t *testing.T) {
+	t.Run("valid placement config", func(t *testing.T) {
+		cfg := Default()
+		cfg.Ultraplan.Placement = PlacementConfig{
+			Policy: "pack",
+			Nodes: []NodeConfig{
+				{ID: "gpu-1", Capacity: 2, Backends: []string{"claude"}, Env: []string{"ANTHROPIC_BASE_URL=http://gpu-1:8000"}},
+				{ID: "gpu-2", Capacity: 1},
+			},
+		}
+		for _, err := range cfg.Validate() {
+			if strings.HasPrefix(err.Field, "ultraplan.placement") {
+				t.Errorf("valid placement config should not error: %v", err)
+			}
+		}
+	})
+
+	t.Run("invalid fields", func(t *testing.T) {
+		cfg := Default()
+		cfg.Ultraplan.Placement = PlacementConfig{
+			Policy: "random",
+			Nodes: []NodeConfig{
+				{ID: "gpu-1", Capacity: 0, Env: []string{"1BAD=x"}},
+				{ID: "gpu-1", Capacity: 1},
+				{Capacity: 1},
+			},
+		}
+		errs := cfg.Validate()
+
+		for _, field := range []string{
+			"ultraplan.placement.policy",
+			"ultraplan.placement.nodes[0].capacity",
+			"ultraplan.placement.nodes[0].env[0]",
+			"ultraplan.placement.nodes[1].id",
+			"ultraplan.placement.nodes[2].id",
+		} {
+			found := false
+			for _, err := range errs {
+				if err.Field == field {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("expected validation error for %s", field)
+			}
+		}
+	})
+}
+
+func TestConfig_Validate_ObjectiveTemplates(t *testing.T) {
+	t.Run("valid templates", func(t *testing.T) {
+		cfg := Default()
+		cfg.Ultraplan.Templates = []ObjectiveTemplateConfig{
+			{Name: "upgrade", Prefix: "Upgrade: ", ConsolidationMode: "single"},
+			{Name: "api-v2", Constraints: []string{"Keep v1 working"}},
+			{Name: "cover", Goal: "Cover {{pkg}}", Variables: []TemplateVariableConfig{
+				{Name: "pkg", Type: "path"},
+				{Name: "level", Type: "choice", Options: []string{"unit", "integration"}, Default: "unit"},
+			}},
+		}
+		for _, err := range cfg.Validate() {
+			if strings.HasPrefix(err.Field, "ultraplan.templates") {
+				t.Errorf("valid templates should not error: %v", err)
+			}
+		}
+	})
+
+	t.Run("invalid fields", func(t *testing.T) {
+		cfg := Default()
+		cfg.Ultraplan.Templates = []ObjectiveTemplateConfig{
+			{Name: "upgrade", ConsolidationMode: "octopus"},
+			{Name: "upgrade"},
+			{Name: "Big Rename"},
+			{},
+			{Name: "cover", Variables: []TemplateVariableConfig{
+				{Name: "pkg", Type: "path"},
+				{Name: "pkg"},
+				{Name: "Target", Type: "float"},
+				{Name: "level", Type: "choice"},
+			}},
+		}
+		errs := cfg.Validate()
+
+		for _, field := range []string{
+			"ultraplan.templates[0].consolidation_mode",
+			"ultraplan.templates[1].name",
+			"ultraplan.templates[2].name",
+			"ultraplan.templates[3].name",
+			"ultraplan.templates[4].variables[1].name",
+			"ultraplan.templates[4].variables[2].name",
+			"ultraplan.templates[4].variables[2].type",
+			"ultraplan.templates[4].variables[3].options",
+		} {
+			found := false
+			for _, err := range errs {
+				if err.Field == field {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("expected validation error for %s", field)
+			}
+		}
+	})
+}
@@ -3,6 +3,9 @@ package coordination
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/Iron-Ham/claudio/internal/adaptive"
@@ -12,6 +15,7 @@ import (
 	"github.com/Iron-Ham/claudio/internal/filelock"
 	"github.com/Iron-Ham/claudio/internal/mailbox"
 	"github.com/Iron-Ham/claudio/internal/scaling"
+	"github.com/Iron-Ham/claudio/internal/session/migrate"
 	"github.com/Iron-Ham/claudio/internal/taskqueue"
 	"github.com/Iron-Ham/claudio/internal/ultraplan"
 )
@@ -43,6 +47,8 @@ type Hub struct {
 	scalingMonitor *scaling.Monitor
 	propagator     *contextprop.Propagator
 	fileLockReg    *filelock.Registry
+	claimServer    *filelock.Server // nil unless claims are enforced
+	sessionDir     string
 }
 
 // NewHub creates a Hub that wires all Orchestration 2.0 components together.
@@ -73,6 +79,9 @@ func NewHub(cfg Config, opts ...Option) (*Hub, error) {
 	if hc.rebalanceInterval > 0 {
 		adaptiveOpts = append(adaptiveOpts, adaptive.WithRebalanceInterval(hc.rebalanceInterval))
 	}
+	if hc.preemptionEnabled {
+		adaptiveOpts = append(adaptiveOpts, adaptive.WithPreemption(hc.preemptionWait, hc.preemptionMinGap))
+	}
 
 	// Default TaskLookup: no approvals required.
 	lookup := cfg.TaskLookup
@@ -80,7 +89,7 @@ func NewHub(cfg Config, opts ...Option) (*Hub, error) {
 		lookup = func(string) (bool, bool) { return false, true }
 	}
 
-	// Scaling policy defaults — apply per-hub min/max overrides.
+	// Scaling policy defaults — apply per-hub min/max and cost overrides.
 	policy := hc.scalingPolicy
 	if policy == nil {
 		var policyOpts []scaling.Option
@@ -90,11 +99,38 @@ func NewHub(cfg Config, opts ...Option) (*Hub, error) {
 		if hc.maxInstances > 0 {
 			policyOpts = append(policyOpts, scaling.WithMaxInstances(hc.maxInstances))
 		}
+		if hc.budgetCeiling > 0 {
+			policyOpts = append(policyOpts, scaling.WithBudgetCeiling(hc.budgetCeiling))
+		}
+		if hc.burnRateLimit > 0 {
+			policyOpts = append(policyOpts, scaling.WithBurnRateLimit(hc.burnRateLimit))
+		}
 		policy = scaling.NewPolicy(policyOpts...)
 	}
 
-	mb := mailbox.NewMailbox(cfg.SessionDir, mailbox.WithBus(cfg.Bus))
+	guard := mailbox.DefaultGuardPolicy()
+	if hc.messageGuard != nil {
+		guard = *hc.messageGuard
+	}
+
+	rateLimit := mailbox.DefaultRateLimit()
+	if hc.messageRateLimit != nil {
+		rateLimit = *hc.messageRateLimit
+	}
+
+	mb := mailbox.NewMailbox(cfg.SessionDir,
+		mailbox.WithBus(cfg.Bus),
+		mailbox.WithGuard(guard),
+		mailbox.WithRateLimit(rateLimit))
 	queue := taskqueue.NewFromPlan(cfg.Plan)
+	if hc.resumeQueue {
+		if saved := loadSavedQueue(cfg.SessionDir, cfg.Plan); saved != nil {
+			queue = saved
+		}
+	}
+	if hc.priorityAging != nil {
+		queue.SetPriorityAging(*hc.priorityAging)
+	}
 	eq := taskqueue.NewEventQueue(queue, cfg.Bus)
 	gate := approval.NewGate(eq, cfg.Bus, lookup)
 	lead := adaptive.NewLead(eq, cfg.Bus, adaptiveOpts...)
@@ -102,6 +138,11 @@ func NewHub(cfg Config, opts ...Option) (*Hub, error) {
 	prop := contextprop.NewPropagator(mb, cfg.Bus)
 	reg := filelock.NewRegistry(mb, cfg.Bus)
 
+	var claimServer *filelock.Server
+	if hc.enforceFileClaims {
+		claimServer = filelock.NewServer(reg, filelock.SocketPath(cfg.SessionDir))
+	}
+
 	return &Hub{
 		mb:             mb,
 		queue:          queue,
@@ -111,9 +152,39 @@ func NewHub(cfg Config, opts ...Option) (*Hub, error) {
 		scalingMonitor: monitor,
 		propagator:     prop,
 		fileLockReg:    reg,
+		claimServer:    claimServer,
+		sessionDir:     cfg.SessionDir,
 	}, nil
 }
 
+// loadSavedQueue returns the queue saved in dir, or nil if there is none or
+// its tasks are not the plan's.
+func loadSavedQueue(dir string, plan *ultraplan.PlanSpec) *taskqueue.TaskQueue {
+	if _, err := os.Stat(filepath.Join(dir, migrate.QueueFileName)); err != nil {
+		return nil
+	}
+	queue, err := taskqueue.LoadState(dir)
+	if err != nil {
+		return nil
+	}
+	saved := queue.AllTasks()
+	if len(saved) != len(plan.Tasks) {
+		return nil
+	}
+	for _, t := range plan.Tasks {
+		if queue.GetTask(t.ID) == nil {
+			return nil
+		}
+	}
+	return queue
+}
+
+// SaveQueueState writes the task queue, checkpoints included, to the
+// session directory, where WithQueueResume finds it after a restart.
+func (h *Hub) SaveQueueState() error {
+	return h.gate.SaveState(h.sessionDir)
+}
+
 // Gate returns the approval gate for task operations.
 func (h *Hub) Gate() *approval.Gate { return h.gate }
 
@@ -135,6 +206,15 @@ func (h *Hub) Propagator() *contextprop.Propagator { return h.propagator }
 // FileLockRegistry returns the file lock registry for conflict prevention.
 func (h *Hub) FileLockRegistry() *filelock.Registry { return h.fileLockReg }
 
+// ClaimSocket returns the socket path pre-commit hooks query for file
+// claims, or "" when claims are advisory (see WithClaimEnforcement).
+func (h *Hub) ClaimSocket() string {
+	if h.claimServer == nil {
+		return ""
+	}
+	return h.claimServer.Path()
+}
+
 // Mailbox returns the underlying mailbox for inter-instance messaging.
 func (h *Hub) Mailbox() *mailbox.Mailbox { return h.mb }
 
@@ -148,6 +228,12 @@ func (h *Hub) Start(ctx context.Context) error {
 		return errors.New("coordination: hub already started")
 	}
 
+	if h.claimServer != nil {
+		if err := h.claimServer.Start(); err != nil {
+			return fmt.Errorf("coordination: start claim server: %w", err)
+		}
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	h.cancel = cancel
 	h.started = true
@@ -182,6 +268,16 @@ func (h *Hub) Stop() error {
 	// Stop adaptive lead.
 	h.lead.Stop()
 
+	// Deliver throttled messages rather than leaving them in memory.
+	_ = h.mb.FlushDigests()
+
+	// Keep the queue for a later resume.
+	_ = h.SaveQueueState()
+
+	if h.claimServer != nil {
+		_ = h.claimServer.Close()
+	}
+
 	h.started = false
 	return nil
 }
@@ -3,6 +3,7 @@ package coordination
 import (
 	"time"
 
+	"github.com/Iron-Ham/claudio/internal/mailbox"
 	"github.com/Iron-Ham/claudio/internal/scaling"
 )
 
@@ -15,6 +16,16 @@ type hubConfig struct {
 	initialInstances    int
 	minInstances        int
 	maxInstances        int
+	budgetCeiling       float64
+	burnRateLimit       float64
+	messageGuard        *mailbox.GuardPolicy
+	messageRateLimit    *mailbox.RateLimit
+	enforceFileClaims   bool
+	priorityAging       *time.Duration
+	preemptionEnabled   bool
+	preemptionWait      time.Duration
+	preemptionMinGap    int
+	resumeQueue         bool
 }
 
 // Option configures a Hub.
@@ -57,3 +68,64 @@ func WithMinInstances(n int) Option {
 func WithMaxInstances(n int) Option {
 	return func(c *hubConfig) { c.maxInstances = n }
 }
+
+// WithBudgetCeiling sets the session budget (USD) the scaling policy keeps
+// projected cost within; scale-ups that would exceed it are vetoed. A value
+// of 0 disables the check.
+func WithBudgetCeiling(usd float64) Option {
+	return func(c *hubConfig) { c.budgetCeiling = usd }
+}
+
+// WithBurnRateLimit sets the spend velocity (USD per hour) above which the
+// scaling policy prefers scaling down. A value of 0 disables the check.
+func WithBurnRateLimit(usdPerHour float64) Option {
+	return func(c *hubConfig) { c.burnRateLimit = usdPerHour }
+}
+
+// WithMessageGuard sets the prompt-injection policy applied to messages sent
+// through the hub's mailbox. If unset, mailbox.DefaultGuardPolicy is used.
+func WithMessageGuard(p mailbox.GuardPolicy) Option {
+	return func(c *hubConfig) { c.messageGuard = &p }
+}
+
+// WithMessageRateLimit sets the per-sender bandwidth limit applied to messages
+// sent through the hub's mailbox. If unset, mailbox.DefaultRateLimit is used;
+// pass a zero RateLimit to deliver every message as sent.
+func WithMessageRateLimit(l mailbox.RateLimit) Option {
+	return func(c *hubConfig) { c.messageRateLimit = &l }
+}
+
+// WithClaimEnforcement makes file claims binding rather than advisory. The
+// hub serves its file lock registry on a unix socket while started (see
+// Hub.ClaimSocket), so pre-commit hooks installed with filelock.InstallHook
+// can reject commits touching files claimed by another instance.
+func WithClaimEnforcement() Option {
+	return func(c *hubConfig) { c.enforceFileClaims = true }
+}
+
+// WithPriorityAging sets how long a ready task waits before the task queue
+// raises its priority by one level. If unset, taskqueue.DefaultPriorityAging
+// is used; a value of 0 disables aging.
+func WithPriorityAging(d time.Duration) Option {
+	return func(c *hubConfig) { c.priorityAging = &d }
+}
+
+// WithPreemption lets the adaptive lead pause a running task for a ready
+// task that has waited at least wait and whose priority is better by at
+// least minGap levels.
+func WithPreemption(wait time.Duration, minGap int) Option {
+	return func(c *hubConfig) {
+		c.preemptionEnabled = true
+		c.preemptionWait = wait
+		c.preemptionMinGap = minGap
+	}
+}
+
+// WithQueueResume restores the task queue from the state the hub saved in
+// its session directory (see Hub.SaveQueueState) instead of building it from
+// the plan, so a restarted session keeps finished work and the checkpoints
+// of claimed tasks. The plan is used when no saved state exists or the saved
+// tasks do not match it.
+func WithQueueResume() Option {
+	return func(c *hubConfig) { c.resumeQueue = true }
+}
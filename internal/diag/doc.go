@@ -0,0 +1,26 @@
+// Package diag captures performance diagnostics from a running Claudio
+// process for bug reports.
+//
+// Instrumented code records latency samples into [Default]: the event bus
+// times each handler ("event.<type>"), instance capture loops time tmux
+// polling ("capture.status", "capture.full", "capture.visible"), and the TUI
+// times its update and render passes ("tui.update", "tui.view").
+// [CaptureProfile] bundles those stats with CPU and heap profiles and a
+// goroutine dump into a .tar.gz.
+//
+// # Profiling Another Process
+//
+// A session's process runs [Serve] for its session directory. The
+// `claudio debug profile` command calls [RequestProfile], which leaves a
+// [Request] in the session directory and signals the process (SIGUSR1); the
+// process writes the bundle to the session's profiles directory and answers
+// with a [Response]. Requests are not supported on Windows.
+//
+// # Usage
+//
+//	defer diag.Default.Since("capture.full", time.Now())
+//
+//	diag.Serve(ctx, sessionDir, sessionInfo, logger)
+//
+//	path, err := diag.RequestProfile(ctx, sessionDir, pid, 10*time.Second)
+package diag
@@ -0,0 +1,214 @@
+package diag
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"time"
+)
+
+// DefaultCPUDuration is how long the CPU profile runs when no duration is
+// given.
+const DefaultCPUDuration = 10 * time.Second
+
+// ProfileOptions configures a profile bundle.
+type ProfileOptions struct {
+	// CPUDuration is how long the CPU profile runs. Zero uses
+	// DefaultCPUDuration.
+	CPUDuration time.Duration
+
+	// Timings supplies the timing stats in the bundle. Nil uses Default.
+	Timings *Timings
+
+	// Info is written to the bundle's runtime.json under "session", e.g.
+	// the session ID and instance count.
+	Info map[string]any
+}
+
+// RuntimeInfo describes the process a bundle was captured from.
+type RuntimeInfo struct {
+	CapturedAt   time.Time      `json:"captured_at"`
+	CPUDuration  string         `json:"cpu_duration"`
+	GoVersion    string         `json:"go_version"`
+	OS           string         `json:"os"`
+	Arch         string         `json:"arch"`
+	NumCPU       int            `json:"num_cpu"`
+	NumGoroutine int            `json:"num_goroutine"`
+	HeapAlloc    uint64         `json:"heap_alloc_bytes"`
+	HeapObjects  uint64         `json:"heap_objects"`
+	TotalAlloc   uint64         `json:"total_alloc_bytes"`
+	Sys          uint64         `json:"sys_bytes"`
+	NumGC        uint32         `json:"num_gc"`
+	PauseTotal   time.Duration  `json:"gc_pause_total_ns"`
+	Session      map[string]any `json:"session,omitempty"`
+}
+
+// CaptureProfile profiles the current process and writes a gzipped tar
+// bundle into dir, returning its path. The bundle holds:
+//
+//   - cpu.pprof: CPU profile over opts.CPUDuration
+//   - heap.pprof: heap profile taken after the CPU profile
+//   - goroutines.txt: stack dump of every goroutine
+//   - timings.json: stats from opts.Timings
+//   - runtime.json: RuntimeInfo
+//
+// Cancelling ctx ends the CPU profile early; the rest of the bundle is still
+// written.
+func CaptureProfile(ctx context.Context, dir string, opts ProfileOptions) (string, error) {
+	if opts.CPUDuration <= 0 {
+		opts.CPUDuration = DefaultCPUDuration
+	}
+	if opts.Timings == nil {
+		opts.Timings = Default
+	}
+
+	var cpu bytes.Buffer
+	if err := pprof.StartCPUProfile(&cpu); err != nil {
+		return "", fmt.Errorf("diag: start CPU profile: %w", err)
+	}
+	start := time.Now()
+	select {
+	case <-ctx.Done():
+	case <-time.After(opts.CPUDuration):
+	}
+	pprof.StopCPUProfile()
+	elapsed := time.Since(start)
+
+	var heap, goroutines bytes.Buffer
+	runtime.GC() // Up-to-date heap statistics
+	if err := pprof.Lookup("heap").WriteTo(&heap, 0); err != nil {
+		return "", fmt.Errorf("diag: write heap profile: %w", err)
+	}
+	if err := pprof.Lookup("goroutine").WriteTo(&goroutines, 2); err != nil {
+		return "", fmt.Errorf("diag: write goroutine dump: %w", err)
+	}
+
+	timings, err := json.MarshalIndent(opts.Timings.Stats(""), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("diag: marshal timings: %w", err)
+	}
+	info, err := json.MarshalIndent(runtimeInfo(elapsed, opts.Info), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("diag: marshal runtime info: %w", err)
+	}
+
+	files := []bundleFile{
+		{"cpu.pprof", cpu.Bytes()},
+		{"heap.pprof", heap.Bytes()},
+		{"goroutines.txt", goroutines.Bytes()},
+		{"timings.json", timings},
+		{"runtime.json", info},
+	}
+	name := fmt.Sprintf("claudio-profile-%s.tar.gz", time.Now().Format("20060102-150405"))
+	path := filepath.Join(dir, name)
+	if err := writeBundle(path, files); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func runtimeInfo(cpuDuration time.Duration, session map[string]any) RuntimeInfo {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return RuntimeInfo{
+		CapturedAt:   time.Now(),
+		CPUDuration:  cpuDuration.Round(time.Millisecond).String(),
+		GoVersion:    runtime.Version(),
+		OS:           runtime.GOOS,
+		Arch:         runtime.GOARCH,
+		NumCPU:       runtime.NumCPU(),
+		NumGoroutine: runtime.NumGoroutine(),
+		HeapAlloc:    m.HeapAlloc,
+		HeapObjects:  m.HeapObjects,
+		TotalAlloc:   m.TotalAlloc,
+		Sys:          m.Sys,
+		NumGC:        m.NumGC,
+		PauseTotal:   time.Duration(m.PauseTotalNs),
+		Session:      session,
+	}
+}
+
+// bundleFile is one file in a profile bundle.
+type bundleFile struct {
+	name string
+	data []byte
+}
+
+// writeBundle writes files as a gzipped tar at path, via a temporary file so
+// a reader polling for the bundle never sees a partial one.
+func writeBundle(path string, files []bundleFile) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("diag: create directory: %w", err)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+	for _, f := range files {
+		hdr := &tar.Header{Name: f.name, Mode: 0o644, Size: int64(len(f.data)), ModTime: now}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("diag: write bundle: %w", err)
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return fmt.Errorf("diag: write bundle: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("diag: write bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("diag: write bundle: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("diag: write bundle: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("diag: write bundle: %w", err)
+	}
+	return nil
+}
+
+// ReadBundleTimings returns the timing stats stored in a profile bundle.
+func ReadBundleTimings(path string) ([]TimingStats, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("diag: open bundle: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("diag: read bundle: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("diag: bundle has no timings.json")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("diag: read bundle: %w", err)
+		}
+		if hdr.Name != "timings.json" {
+			continue
+		}
+		var stats []TimingStats
+		if err := json.NewDecoder(tr).Decode(&stats); err != nil {
+			return nil, fmt.Errorf("diag: parse timings: %w", err)
+		}
+		return stats, nil
+	}
+}
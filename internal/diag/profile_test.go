@@ -0,0 +1,109 @@
+package diag
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readBundle returns the files in a profile bundle by name.
+func readBundle(t *testing.T, path string) map[string][]byte {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[hdr.Name] = data
+	}
+}
+
+func TestCaptureProfile(t *testing.T) {
+	tm := NewTimings()
+	tm.Record("event.instance.started", time.Millisecond)
+	dir := filepath.Join(t.TempDir(), "profiles")
+
+	path, err := CaptureProfile(context.Background(), dir, ProfileOptions{
+		CPUDuration: 50 * time.Millisecond,
+		Timings:     tm,
+		Info:        map[string]any{"session_id": "sess-1"},
+	})
+	if err != nil {
+		t.Fatalf("CaptureProfile() error = %v", err)
+	}
+	if filepath.Dir(path) != dir || !strings.HasSuffix(path, ".tar.gz") {
+		t.Errorf("bundle path = %s, want a .tar.gz in %s", path, dir)
+	}
+
+	files := readBundle(t, path)
+	var names []string
+	for name, data := range files {
+		names = append(names, name)
+		if len(data) == 0 {
+			t.Errorf("%s is empty", name)
+		}
+	}
+	slices.Sort(names)
+	if want := []string{"cpu.pprof", "goroutines.txt", "heap.pprof", "runtime.json", "timings.json"}; !slices.Equal(names, want) {
+		t.Errorf("bundle files = %v, want %v", names, want)
+	}
+	if !strings.Contains(string(files["goroutines.txt"]), "TestCaptureProfile") {
+		t.Error("goroutine dump is missing the test goroutine")
+	}
+
+	var info RuntimeInfo
+	if err := json.Unmarshal(files["runtime.json"], &info); err != nil {
+		t.Fatal(err)
+	}
+	if info.GoVersion != runtime.Version() || info.NumGoroutine == 0 || info.Session["session_id"] != "sess-1" {
+		t.Errorf("runtime.json = %+v", info)
+	}
+
+	stats, err := ReadBundleTimings(path)
+	if err != nil {
+		t.Fatalf("ReadBundleTimings() error = %v", err)
+	}
+	if len(stats) != 1 || stats[0].Name != "event.instance.started" {
+		t.Errorf("ReadBundleTimings() = %+v", stats)
+	}
+}
+
+func TestCaptureProfile_CancelEndsCPUProfile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	if _, err := CaptureProfile(ctx, t.TempDir(), ProfileOptions{CPUDuration: time.Minute}); err != nil {
+		t.Fatalf("CaptureProfile() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("CaptureProfile() took %v after cancel", elapsed)
+	}
+}
@@ -0,0 +1,173 @@
+package diag
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/logging"
+)
+
+const (
+	// RequestFileName is the profile request a CLI leaves in the session
+	// directory before signalling the session's process.
+	RequestFileName = "profile-request.json"
+
+	// ResponseFileName is where the session's process reports the bundle it
+	// wrote for a request.
+	ResponseFileName = "profile-response.json"
+
+	// ProfilesDir is the directory within the session directory that holds
+	// profile bundles.
+	ProfilesDir = "profiles"
+)
+
+// responsePollInterval is how often RequestProfile checks for a response.
+const responsePollInterval = 200 * time.Millisecond
+
+// Request asks a running session to capture a profile bundle.
+type Request struct {
+	ID          string        `json:"id"`
+	CPUDuration time.Duration `json:"cpu_duration_ns"`
+	RequestedAt time.Time     `json:"requested_at"`
+}
+
+// Response reports the outcome of a Request.
+type Response struct {
+	ID    string `json:"id"`
+	Path  string `json:"path,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Serve captures a profile bundle whenever another process requests one with
+// RequestProfile, until ctx is cancelled. Bundles are written to the
+// ProfilesDir of sessionDir; info, if not nil, supplies session details for
+// each bundle's runtime.json. Only one profile is captured at a time.
+func Serve(ctx context.Context, sessionDir string, info func() map[string]any, logger *logging.Logger) {
+	sigs := make(chan os.Signal, 1)
+	notifyProfileRequests(sigs)
+	go func() {
+		defer signal.Stop(sigs)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigs:
+				serveRequest(ctx, sessionDir, info, logger)
+			}
+		}
+	}()
+}
+
+// serveRequest handles the pending request in sessionDir, if any.
+func serveRequest(ctx context.Context, sessionDir string, info func() map[string]any, logger *logging.Logger) {
+	var req Request
+	if err := readJSON(filepath.Join(sessionDir, RequestFileName), &req); err != nil {
+		// The signal is process-wide; a missing file means it was for
+		// another session served by this process
+		if !errors.Is(err, fs.ErrNotExist) && logger != nil {
+			logger.Warn("ignoring profile request", "error", err)
+		}
+		return
+	}
+	// Consume the request so a signal meant for another session doesn't
+	// serve it again
+	_ = os.Remove(filepath.Join(sessionDir, RequestFileName))
+
+	opts := ProfileOptions{CPUDuration: req.CPUDuration}
+	if info != nil {
+		opts.Info = info()
+	}
+	if logger != nil {
+		logger.Info("capturing profile", "request_id", req.ID, "cpu_duration", opts.CPUDuration)
+	}
+
+	resp := Response{ID: req.ID}
+	path, err := CaptureProfile(ctx, filepath.Join(sessionDir, ProfilesDir), opts)
+	if err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Path = path
+	}
+	if err := writeJSON(filepath.Join(sessionDir, ResponseFileName), resp); err != nil && logger != nil {
+		logger.Warn("failed to write profile response", "error", err)
+	}
+}
+
+// RequestProfile asks the session process pid, which must be running Serve
+// for sessionDir, to capture a profile bundle, and waits for its path. The
+// wait is bounded by ctx, which should allow for cpuDuration.
+func RequestProfile(ctx context.Context, sessionDir string, pid int, cpuDuration time.Duration) (string, error) {
+	if cpuDuration <= 0 {
+		cpuDuration = DefaultCPUDuration
+	}
+	req := Request{ID: newRequestID(), CPUDuration: cpuDuration, RequestedAt: time.Now()}
+	if err := writeJSON(filepath.Join(sessionDir, RequestFileName), req); err != nil {
+		return "", err
+	}
+	if err := signalProfileRequest(pid); err != nil {
+		return "", fmt.Errorf("diag: signal session process %d: %w", pid, err)
+	}
+
+	ticker := time.NewTicker(responsePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("diag: no profile from session process %d: %w", pid, ctx.Err())
+		case <-ticker.C:
+		}
+
+		var resp Response
+		if err := readJSON(filepath.Join(sessionDir, ResponseFileName), &resp); err != nil || resp.ID != req.ID {
+			continue // No response yet, or one for an earlier request
+		}
+		if resp.Error != "" {
+			return "", fmt.Errorf("diag: session process %d: %s", pid, resp.Error)
+		}
+		return resp.Path, nil
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("diag: parse %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// writeJSON writes v to path via a temporary file so readers never see a
+// partial file.
+func writeJSON(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("diag: marshal %s: %w", filepath.Base(path), err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("diag: write %s: %w", filepath.Base(path), err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("diag: write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+//go:build unix
+
+package diag
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRequestProfile_ServedByRunningProcess(t *testing.T) {
+	sessionDir := t.TempDir()
+	otherDir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	Serve(ctx, sessionDir, func() map[string]any { return map[string]any{"session_id": "sess-1"} }, nil)
+	// A second session in the same process ignores the signal
+	Serve(ctx, otherDir, nil, nil)
+
+	reqCtx, reqCancel := context.WithTimeout(ctx, 30*time.Second)
+	defer reqCancel()
+	path, err := RequestProfile(reqCtx, sessionDir, os.Getpid(), 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("RequestProfile() error = %v", err)
+	}
+	if filepath.Dir(path) != filepath.Join(sessionDir, ProfilesDir) {
+		t.Errorf("bundle path = %s, want it in the session's profiles directory", path)
+	}
+	if _, err := os.Stat(filepath.Join(sessionDir, RequestFileName)); !os.IsNotExist(err) {
+		t.Error("request file was not consumed")
+	}
+	if entries, _ := os.ReadDir(otherDir); len(entries) != 0 {
+		t.Errorf("other session wrote %d files, want none", len(entries))
+	}
+}
+
+func TestRequestProfile_TimesOutWithoutResponse(t *testing.T) {
+	sessionDir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	// Keep SIGUSR1 from terminating the test process while nobody serves it
+	Serve(ctx, t.TempDir(), nil, nil)
+	defer cancel()
+
+	reqCtx, reqCancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer reqCancel()
+	if _, err := RequestProfile(reqCtx, sessionDir, os.Getpid(), time.Millisecond); err == nil {
+		t.Error("RequestProfile() without a server succeeded, want timeout")
+	}
+}
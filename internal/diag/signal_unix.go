@@ -0,0 +1,19 @@
+//go:build unix
+
+package diag
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyProfileRequests relays profile request signals (SIGUSR1) to ch.
+func notifyProfileRequests(ch chan<- os.Signal) {
+	signal.Notify(ch, syscall.SIGUSR1)
+}
+
+// signalProfileRequest asks the process pid to serve a profile request.
+func signalProfileRequest(pid int) error {
+	return syscall.Kill(pid, syscall.SIGUSR1)
+}
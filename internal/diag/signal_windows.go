@@ -0,0 +1,16 @@
+//go:build windows
+
+package diag
+
+import (
+	"errors"
+	"os"
+)
+
+// notifyProfileRequests is a no-op on Windows, which has no SIGUSR1.
+func notifyProfileRequests(ch chan<- os.Signal) {}
+
+// signalProfileRequest fails on Windows, which has no SIGUSR1.
+func signalProfileRequest(pid int) error {
+	return errors.New("profiling a running session is not supported on Windows")
+}
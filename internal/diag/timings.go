@@ -0,0 +1,123 @@
+package diag
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// timingSamples is how many recent samples each timing keeps for
+// percentiles. Count, total, and max cover every sample.
+const timingSamples = 512
+
+// TimingStats summarizes the samples recorded under one name.
+type TimingStats struct {
+	Name  string        `json:"name"`
+	Count int64         `json:"count"`
+	Total time.Duration `json:"total_ns"`
+	Mean  time.Duration `json:"mean_ns"`
+	P50   time.Duration `json:"p50_ns"` // Percentiles are over the most recent samples
+	P95   time.Duration `json:"p95_ns"`
+	P99   time.Duration `json:"p99_ns"`
+	Max   time.Duration `json:"max_ns"`
+}
+
+// series accumulates samples for one name.
+type series struct {
+	count  int64
+	total  time.Duration
+	max    time.Duration
+	recent [timingSamples]time.Duration
+	next   int // Index in recent the next sample is written to
+}
+
+func (s *series) add(d time.Duration) {
+	s.count++
+	s.total += d
+	s.max = max(s.max, d)
+	s.recent[s.next] = d
+	s.next = (s.next + 1) % timingSamples
+}
+
+func (s *series) stats(name string) TimingStats {
+	n := int(min(s.count, timingSamples))
+	sorted := slices.Clone(s.recent[:n])
+	slices.Sort(sorted)
+	at := func(p float64) time.Duration {
+		return sorted[int(p*float64(n-1))]
+	}
+	return TimingStats{
+		Name:  name,
+		Count: s.count,
+		Total: s.total,
+		Mean:  s.total / time.Duration(s.count),
+		P50:   at(0.50),
+		P95:   at(0.95),
+		P99:   at(0.99),
+		Max:   s.max,
+	}
+}
+
+// Timings records durations by name, such as how long each event bus handler
+// or tmux capture takes. The zero value is not usable; use NewTimings.
+type Timings struct {
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+// NewTimings creates an empty Timings.
+func NewTimings() *Timings {
+	return &Timings{series: make(map[string]*series)}
+}
+
+// Record adds a sample under name.
+func (t *Timings) Record(name string, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.series[name]
+	if !ok {
+		s = &series{}
+		t.series[name] = s
+	}
+	s.add(d)
+}
+
+// Since records the time elapsed since start under name. It is meant for
+// defer: defer timings.Since("capture.full", time.Now()).
+func (t *Timings) Since(name string, start time.Time) {
+	t.Record(name, time.Since(start))
+}
+
+// Stats returns a summary of every name with a prefix of prefix, sorted by
+// name. An empty prefix returns all names.
+func (t *Timings) Stats(prefix string) []TimingStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var out []TimingStats
+	for name, s := range t.series {
+		if strings.HasPrefix(name, prefix) {
+			out = append(out, s.stats(name))
+		}
+	}
+	slices.SortFunc(out, func(a, b TimingStats) int { return strings.Compare(a.Name, b.Name) })
+	return out
+}
+
+// Reset discards every sample.
+func (t *Timings) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.series = make(map[string]*series)
+}
+
+// Default is the process-wide Timings that instrumented code records into
+// and profile bundles report.
+var Default = NewTimings()
+
+// Record adds a sample under name to Default.
+func Record(name string, d time.Duration) {
+	Default.Record(name, d)
+}
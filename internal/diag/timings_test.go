@@ -0,0 +1,55 @@
+package diag
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimings_Stats(t *testing.T) {
+	tm := NewTimings()
+	for i := 1; i <= 100; i++ {
+		tm.Record("capture.full", time.Duration(i)*time.Millisecond)
+	}
+	tm.Record("event.instance.started", 3*time.Microsecond)
+
+	stats := tm.Stats("capture.")
+	if len(stats) != 1 {
+		t.Fatalf("Stats(capture.) = %d entries, want 1", len(stats))
+	}
+	s := stats[0]
+	if s.Name != "capture.full" || s.Count != 100 {
+		t.Errorf("stats = %+v, want 100 capture.full samples", s)
+	}
+	if s.P50 != 50*time.Millisecond || s.P95 != 95*time.Millisecond || s.Max != 100*time.Millisecond {
+		t.Errorf("p50/p95/max = %v/%v/%v, want 50ms/95ms/100ms", s.P50, s.P95, s.Max)
+	}
+	if s.Mean != 50500*time.Microsecond || s.Total != 5050*time.Millisecond {
+		t.Errorf("mean/total = %v/%v, want 50.5ms/5.05s", s.Mean, s.Total)
+	}
+
+	all := tm.Stats("")
+	if len(all) != 2 || all[0].Name != "capture.full" || all[1].Name != "event.instance.started" {
+		t.Errorf("Stats(\"\") = %+v, want both names sorted", all)
+	}
+
+	tm.Reset()
+	if len(tm.Stats("")) != 0 {
+		t.Error("Stats() after Reset() is not empty")
+	}
+}
+
+func TestTimings_PercentilesUseRecentSamples(t *testing.T) {
+	tm := NewTimings()
+	tm.Record("tui.view", time.Hour)
+	for range timingSamples {
+		tm.Record("tui.view", time.Millisecond)
+	}
+
+	s := tm.Stats("tui.view")[0]
+	if s.P99 != time.Millisecond {
+		t.Errorf("P99 = %v, want the old outlier rotated out", s.P99)
+	}
+	if s.Max != time.Hour || s.Count != timingSamples+1 {
+		t.Errorf("max/count = %v/%d, want every sample counted", s.Max, s.Count)
+	}
+}
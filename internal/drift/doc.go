@@ -0,0 +1,26 @@
+// Package drift detects new commits on the base branch of a running plan.
+//
+// A plan is decomposed against the repository as it was when planning
+// finished. When someone merges to main while the plan runs, the plan's
+// assumptions drift, and the damage usually shows up only at consolidation
+// as conflicts. A [Detector] watches each [Target]'s base ref (e.g.
+// origin/main) and, once it moves past the commit the plan started from,
+// produces a [Report] of the new commits, the files they changed, and the
+// remaining tasks whose files overlap them.
+//
+// # Usage
+//
+//	d := drift.New(worktreeManager)
+//
+//	go d.Watch(ctx, 2*time.Minute, currentTargets, func(rep drift.Report) {
+//		for _, o := range rep.Affected {
+//			log.Printf("task %s: %s changed upstream", o.TaskID, strings.Join(o.Files, ", "))
+//		}
+//	})
+//
+// # Matching
+//
+// Task files are matched with [Matches]: an entry matches the same file,
+// any file below it when it names a directory, or files matching it as a
+// glob. Tasks that list no files are never affected.
+package drift
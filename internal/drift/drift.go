@@ -0,0 +1,251 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Git reads the history of the watched base ref. *worktree.Manager
+// satisfies it.
+type Git interface {
+	FetchBranch(branch string) error
+	ResolveCommit(ref string) (string, error)
+	CommitSummariesBetween(from, to string) ([]string, error)
+	ChangedFilesBetween(from, to string) ([]string, error)
+}
+
+// Task is a plan task that has not finished yet.
+type Task struct {
+	ID string
+
+	// Files are the paths the task expects to touch. Entries may name a
+	// file, a directory, or a glob such as "internal/api/*.go".
+	Files []string
+}
+
+// Target is a running plan whose base ref is watched.
+type Target struct {
+	// ID identifies the target in reports, e.g. an ultra-plan session ID.
+	ID string
+
+	// Branch is fetched from origin before each check, e.g. "main". Empty
+	// skips the fetch.
+	Branch string
+
+	// Ref is the ref compared against Since, e.g. "origin/main".
+	Ref string
+
+	// Since is the commit Ref pointed at when the plan started.
+	Since string
+
+	// Tasks are the plan's remaining tasks, checked for overlap with the
+	// files changed on Ref.
+	Tasks []Task
+}
+
+// Overlap lists the changed files that fall within one task's files.
+type Overlap struct {
+	TaskID string   `json:"task_id"`
+	Files  []string `json:"files"`
+}
+
+// Report describes the commits that landed on a target's base ref since the
+// plan started.
+type Report struct {
+	TargetID   string    `json:"target_id"`
+	Ref        string    `json:"ref"`
+	From       string    `json:"from"`               // Commit the plan started from
+	To         string    `json:"to"`                 // Commit Ref points at now
+	Commits    []string  `json:"commits"`            // "<short sha> <subject>", oldest first
+	Files      []string  `json:"files"`              // Files changed between From and To
+	Affected   []Overlap `json:"affected,omitempty"` // Remaining tasks whose files changed
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// AffectedFiles returns the changed files that overlap the given task, or
+// nil when the task is not affected.
+func (r *Report) AffectedFiles(taskID string) []string {
+	for _, o := range r.Affected {
+		if o.TaskID == taskID {
+			return o.Files
+		}
+	}
+	return nil
+}
+
+// AffectedTaskIDs returns the IDs of the affected tasks.
+func (r *Report) AffectedTaskIDs() []string {
+	ids := make([]string, len(r.Affected))
+	for i, o := range r.Affected {
+		ids[i] = o.TaskID
+	}
+	return ids
+}
+
+// Option configures a Detector.
+type Option func(*Detector)
+
+// WithClock sets the clock used to stamp reports.
+func WithClock(now func() time.Time) Option {
+	return func(d *Detector) { d.now = now }
+}
+
+// Detector watches the base refs of running plans and reports new commits
+// on them, along with the remaining tasks whose files those commits touched.
+type Detector struct {
+	git Git
+	now func() time.Time
+
+	mu       sync.Mutex
+	reported map[string]string // Target ID -> Ref commit of the last report
+}
+
+// New creates a Detector.
+func New(git Git, opts ...Option) *Detector {
+	d := &Detector{
+		git:      git,
+		now:      time.Now,
+		reported: make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Check fetches t's branch and compares its ref against t.Since. It returns
+// a report and true when the ref has moved since the last report for t, and
+// false when it has not moved at all or not since the last report. Each
+// report covers every commit since t.Since, so the latest one supersedes
+// earlier ones. A failed fetch (e.g. offline) is not an error: the ref is
+// compared as last fetched.
+func (d *Detector) Check(t Target) (Report, bool, error) {
+	if t.Ref == "" || t.Since == "" {
+		return Report{}, false, nil
+	}
+	if t.Branch != "" {
+		_ = d.git.FetchBranch(t.Branch)
+	}
+
+	head, err := d.git.ResolveCommit(t.Ref)
+	if err != nil {
+		return Report{}, false, fmt.Errorf("resolve %s: %w", t.Ref, err)
+	}
+	if head == t.Since || d.lastReported(t.ID) == head {
+		return Report{}, false, nil
+	}
+
+	commits, err := d.git.CommitSummariesBetween(t.Since, head)
+	if err != nil {
+		return Report{}, false, fmt.Errorf("list commits on %s: %w", t.Ref, err)
+	}
+	if len(commits) == 0 {
+		// The ref moved backwards or was rewritten to an ancestor; nothing
+		// new landed under the plan.
+		return Report{}, false, nil
+	}
+	files, err := d.git.ChangedFilesBetween(t.Since, head)
+	if err != nil {
+		return Report{}, false, fmt.Errorf("list changed files on %s: %w", t.Ref, err)
+	}
+
+	d.setReported(t.ID, head)
+	return Report{
+		TargetID:   t.ID,
+		Ref:        t.Ref,
+		From:       t.Since,
+		To:         head,
+		Commits:    commits,
+		Files:      files,
+		Affected:   overlaps(t.Tasks, files),
+		DetectedAt: d.now(),
+	}, true, nil
+}
+
+// Sweep checks each target once and returns the new reports. Targets that
+// cannot be checked are skipped and checked again on the next sweep.
+func (d *Detector) Sweep(ctx context.Context, targets []Target) []Report {
+	var reports []Report
+	for _, t := range targets {
+		if ctx.Err() != nil {
+			break
+		}
+		if rep, ok, err := d.Check(t); err == nil && ok {
+			reports = append(reports, rep)
+		}
+	}
+	return reports
+}
+
+// Watch sweeps targets immediately and then every interval until ctx is
+// cancelled, passing each new report to onDrift. targets is called before
+// every sweep so plans that start or finish are picked up.
+func (d *Detector) Watch(ctx context.Context, interval time.Duration, targets func() []Target, onDrift func(Report)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, rep := range d.Sweep(ctx, targets()) {
+			onDrift(rep)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Detector) lastReported(id string) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.reported[id]
+}
+
+func (d *Detector) setReported(id, head string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.reported[id] = head
+}
+
+// overlaps returns, for each task with at least one changed file within its
+// files, the changed files it overlaps, in task order.
+func overlaps(tasks []Task, changed []string) []Overlap {
+	var out []Overlap
+	for _, t := range tasks {
+		var hits []string
+		for _, f := range changed {
+			if slices.ContainsFunc(t.Files, func(p string) bool { return Matches(p, f) }) {
+				hits = append(hits, f)
+			}
+		}
+		if len(hits) > 0 {
+			out = append(out, Overlap{TaskID: t.ID, Files: hits})
+		}
+	}
+	return out
+}
+
+// Matches reports whether the changed file falls within a task file entry:
+// the same file, a file below the entry's directory, or a match of the
+// entry as a glob. Both are repository-relative slash paths.
+func Matches(entry, file string) bool {
+	entry = strings.TrimSpace(entry)
+	if entry == "" {
+		return false
+	}
+	entry = strings.TrimPrefix(path.Clean(entry), "./")
+	file = strings.TrimPrefix(path.Clean(file), "./")
+	if entry == "." || entry == "/" {
+		return false
+	}
+	if entry == file || strings.HasPrefix(file, entry+"/") {
+		return true
+	}
+	ok, _ := path.Match(entry, file)
+	return ok
+}
@@ -0,0 +1,135 @@
+package drift
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+type fakeGit struct {
+	head    string
+	commits []string
+	files   []string
+	fetched []string
+	err     error
+}
+
+func (g *fakeGit) FetchBranch(branch string) error {
+	g.fetched = append(g.fetched, branch)
+	return errors.New("offline")
+}
+
+func (g *fakeGit) ResolveCommit(string) (string, error) { return g.head, g.err }
+
+func (g *fakeGit) CommitSummariesBetween(from, to string) ([]string, error) {
+	return g.commits, nil
+}
+
+func (g *fakeGit) ChangedFilesBetween(from, to string) ([]string, error) {
+	return g.files, nil
+}
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		entry, file string
+		want        bool
+	}{
+		{"internal/api/server.go", "internal/api/server.go", true},
+		{"./internal/api/server.go", "internal/api/server.go", true},
+		{"internal/api", "internal/api/server.go", true},
+		{"internal/api/", "internal/api/v2/routes.go", true},
+		{"internal/api/*.go", "internal/api/server.go", true},
+		{"internal/api/*.go", "internal/api/v2/routes.go", false},
+		{"internal/api", "internal/apiclient/client.go", false},
+		{"internal/api/server.go", "internal/api/server_test.go", false},
+		{"", "README.md", false},
+		{".", "README.md", false},
+	}
+	for _, tt := range tests {
+		if got := Matches(tt.entry, tt.file); got != tt.want {
+			t.Errorf("Matches(%q, %q) = %v, want %v", tt.entry, tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestCheck_ReportsOverlap(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	git := &fakeGit{
+		head:    "def456",
+		commits: []string{"def456 Rename Client to APIClient"},
+		files:   []string{"internal/api/client.go", "docs/api.md"},
+	}
+	d := New(git, WithClock(func() time.Time { return now }))
+
+	target := Target{
+		ID:     "plan-1",
+		Branch: "main",
+		Ref:    "origin/main",
+		Since:  "abc123",
+		Tasks: []Task{
+			{ID: "task-1", Files: []string{"internal/api/"}},
+			{ID: "task-2", Files: []string{"internal/ui/view.go"}},
+			{ID: "task-3"},
+		},
+	}
+	rep, ok, err := d.Check(target)
+	if err != nil || !ok {
+		t.Fatalf("Check() = ok %v, err %v; want a report", ok, err)
+	}
+	if !slices.Equal(git.fetched, []string{"main"}) {
+		t.Errorf("fetched %v, want [main]", git.fetched)
+	}
+	if rep.From != "abc123" || rep.To != "def456" || !rep.DetectedAt.Equal(now) {
+		t.Errorf("report = %+v", rep)
+	}
+	if got := rep.AffectedTaskIDs(); !slices.Equal(got, []string{"task-1"}) {
+		t.Errorf("affected = %v, want [task-1]", got)
+	}
+	if got := rep.AffectedFiles("task-1"); !slices.Equal(got, []string{"internal/api/client.go"}) {
+		t.Errorf("task-1 files = %v", got)
+	}
+	if rep.AffectedFiles("task-2") != nil {
+		t.Error("task-2 should not be affected")
+	}
+
+	// The same head is reported once
+	if _, ok, _ := d.Check(target); ok {
+		t.Error("second Check() at the same head should not report")
+	}
+
+	// A newer head is reported again
+	git.head = "0a0b0c"
+	if _, ok, _ := d.Check(target); !ok {
+		t.Error("Check() after the ref moved again should report")
+	}
+}
+
+func TestCheck_NoDrift(t *testing.T) {
+	git := &fakeGit{head: "abc123"}
+	d := New(git)
+	if _, ok, err := d.Check(Target{ID: "p", Ref: "origin/main", Since: "abc123"}); ok || err != nil {
+		t.Errorf("Check() = ok %v, err %v; want no report", ok, err)
+	}
+
+	// No recorded base: nothing to compare against
+	if _, ok, err := d.Check(Target{ID: "p", Ref: "origin/main"}); ok || err != nil {
+		t.Errorf("Check() without Since = ok %v, err %v; want no report", ok, err)
+	}
+
+	// The ref moved to an ancestor: no new commits
+	git.head = "999999"
+	if _, ok, err := d.Check(Target{ID: "p", Ref: "origin/main", Since: "abc123"}); ok || err != nil {
+		t.Errorf("Check() with no new commits = ok %v, err %v; want no report", ok, err)
+	}
+}
+
+func TestSweep_SkipsErrors(t *testing.T) {
+	git := &fakeGit{err: errors.New("unknown ref")}
+	d := New(git)
+	reports := d.Sweep(context.Background(), []Target{{ID: "p", Ref: "origin/main", Since: "abc123"}})
+	if len(reports) != 0 {
+		t.Errorf("Sweep() = %v, want no reports", reports)
+	}
+}
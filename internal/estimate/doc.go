@@ -0,0 +1,27 @@
+// Package estimate predicts the tokens, cost, and duration of planned
+// ultraplan tasks from the usage of past ones.
+//
+// Samples come from the completed tasks of ultraplans saved in the session
+// history database (session.database): each task's est_complexity and
+// expected file count from the plan, joined with its instance's metrics.
+// An [Estimator] averages the samples of the same complexity and a similar
+// number of files, falls back to every sample of that complexity, and then
+// to built-in per-complexity defaults scaled to the overall average. With no
+// history, estimates are the defaults.
+//
+// # Main Types
+//
+//   - [Estimator]: Per-task estimates from past samples
+//   - [Estimate]: Tokens, cost, and duration of a task or a set of tasks
+//   - [Sample]: The recorded usage of one past task
+//
+// # Usage
+//
+//	samples, err := estimate.Load(db, 20) // Last 20 sessions
+//	e := estimate.New(samples)
+//	est := e.EstimateTask(task) // *orchestrator.PlannedTask
+//	fmt.Println(est)            // "~$1.20, ~20m"
+//
+//	// A group's duration when at most 3 of its tasks run at once
+//	d := estimate.GroupDuration(durations, 3)
+package estimate
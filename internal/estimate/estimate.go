@@ -0,0 +1,220 @@
+package estimate
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	instmetrics "github.com/Iron-Ham/claudio/internal/instance/metrics"
+)
+
+// MinSamples is the fewest past tasks an average is taken from. Smaller
+// buckets fall back to a coarser one.
+const MinSamples = 3
+
+// Complexities of planned tasks, as written in a plan's est_complexity.
+const (
+	ComplexityLow    = "low"
+	ComplexityMedium = "medium"
+	ComplexityHigh   = "high"
+)
+
+// Estimate is the expected usage of a task, or the sum over several tasks.
+type Estimate struct {
+	Tokens   int64         `json:"tokens"` // Input plus output
+	Cost     float64       `json:"cost"`   // USD
+	Duration time.Duration `json:"duration_ns"`
+
+	// Samples is how many past tasks a task's estimate is averaged from;
+	// zero means the built-in defaults were used.
+	Samples int `json:"samples,omitempty"`
+}
+
+// Add returns the sum of e and o, for totals over several tasks. Samples
+// is not summed.
+func (e Estimate) Add(o Estimate) Estimate {
+	return Estimate{Tokens: e.Tokens + o.Tokens, Cost: e.Cost + o.Cost, Duration: e.Duration + o.Duration}
+}
+
+// String formats the estimate for display, e.g. "~$1.20, ~20m".
+func (e Estimate) String() string {
+	return fmt.Sprintf("~%s, ~%s", instmetrics.FormatCost(e.Cost), FormatDuration(e.Duration))
+}
+
+// FormatDuration formats an estimated duration to the minute, e.g. "1h 5m".
+func FormatDuration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes())
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+}
+
+// defaults are the estimates used when there is not enough history.
+// Medium is also used for tasks without a known complexity.
+var defaults = map[string]Estimate{
+	ComplexityLow:    {Tokens: 130_000, Cost: 0.40, Duration: 8 * time.Minute},
+	ComplexityMedium: {Tokens: 400_000, Cost: 1.20, Duration: 20 * time.Minute},
+	ComplexityHigh:   {Tokens: 1_000_000, Cost: 3.00, Duration: 45 * time.Minute},
+}
+
+// Default returns the built-in estimate for a task of complexity.
+func Default(complexity string) Estimate {
+	if est, ok := defaults[complexity]; ok {
+		return est
+	}
+	return defaults[ComplexityMedium]
+}
+
+// PlannedTask is the part of a plan's task an estimate depends on.
+// *orchestrator.PlannedTask satisfies it.
+type PlannedTask interface {
+	GetEstComplexity() string
+	GetFiles() []string
+}
+
+// bucket groups samples by complexity and number of expected files.
+type bucket struct {
+	complexity string
+	files      int // fileBucket of the file count
+}
+
+// totals accumulates samples for an average.
+type totals struct {
+	tokens   int64
+	cost     float64
+	duration time.Duration
+	n        int
+}
+
+func (t *totals) add(s Sample) {
+	t.tokens += s.Metrics.InputTokens + s.Metrics.OutputTokens
+	t.cost += s.Metrics.Cost
+	t.duration += s.Duration
+	t.n++
+}
+
+func (t *totals) mean() Estimate {
+	n := int64(t.n)
+	return Estimate{
+		Tokens:   t.tokens / n,
+		Cost:     t.cost / float64(n),
+		Duration: (t.duration / time.Duration(n)).Round(time.Second),
+		Samples:  t.n,
+	}
+}
+
+// Estimator estimates planned tasks from the usage of past ones. It is
+// read-only once built and safe for concurrent use.
+type Estimator struct {
+	byBucket     map[bucket]*totals
+	byComplexity map[string]*totals
+	all          totals
+}
+
+// New builds an Estimator from samples of past tasks. With no samples its
+// estimates are the built-in defaults.
+func New(samples []Sample) *Estimator {
+	e := &Estimator{
+		byBucket:     make(map[bucket]*totals),
+		byComplexity: make(map[string]*totals),
+	}
+	for _, s := range samples {
+		complexity := knownComplexity(s.Complexity)
+		b := bucket{complexity: complexity, files: fileBucket(s.Files)}
+		if e.byBucket[b] == nil {
+			e.byBucket[b] = &totals{}
+		}
+		e.byBucket[b].add(s)
+		if e.byComplexity[complexity] == nil {
+			e.byComplexity[complexity] = &totals{}
+		}
+		e.byComplexity[complexity].add(s)
+		e.all.add(s)
+	}
+	return e
+}
+
+// Samples returns how many past tasks the estimator was built from.
+func (e *Estimator) Samples() int {
+	if e == nil {
+		return 0
+	}
+	return e.all.n
+}
+
+// EstimateTask estimates the tokens, cost, and duration of task. It
+// averages past tasks of the same complexity and a similar number of files,
+// falling back to all past tasks of that complexity, and then to the
+// defaults scaled to the average of every past task. A nil Estimator
+// returns the defaults.
+func (e *Estimator) EstimateTask(task PlannedTask) Estimate {
+	complexity := knownComplexity(task.GetEstComplexity())
+	if e == nil {
+		return Default(complexity)
+	}
+	if t := e.byBucket[bucket{complexity: complexity, files: fileBucket(len(task.GetFiles()))}]; t != nil && t.n >= MinSamples {
+		return t.mean()
+	}
+	if t := e.byComplexity[complexity]; t != nil && t.n >= MinSamples {
+		return t.mean()
+	}
+	if e.all.n < MinSamples {
+		return Default(complexity)
+	}
+
+	// Scale the defaults so that their medium matches the overall average,
+	// keeping the relative sizes of the complexities
+	avg, medium, est := e.all.mean(), defaults[ComplexityMedium], Default(complexity)
+	return Estimate{
+		Tokens:   int64(float64(est.Tokens) * float64(avg.Tokens) / float64(medium.Tokens)),
+		Cost:     est.Cost * avg.Cost / medium.Cost,
+		Duration: time.Duration(float64(est.Duration) * float64(avg.Duration) / float64(medium.Duration)).Round(time.Second),
+		Samples:  avg.Samples,
+	}
+}
+
+// GroupDuration estimates how long a group of tasks takes with at most
+// maxParallel running at once (0 = unlimited): each task, longest first,
+// starts on whichever slot frees up first.
+func GroupDuration(durations []time.Duration, maxParallel int) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	slots := len(durations)
+	if maxParallel > 0 && maxParallel < slots {
+		slots = maxParallel
+	}
+	sorted := slices.Clone(durations)
+	slices.SortFunc(sorted, func(a, b time.Duration) int { return cmp.Compare(b, a) })
+
+	busy := make([]time.Duration, slots)
+	for _, d := range sorted {
+		next := slices.Index(busy, slices.Min(busy))
+		busy[next] += d
+	}
+	return slices.Max(busy)
+}
+
+// knownComplexity returns complexity, or medium for an unknown one.
+func knownComplexity(complexity string) string {
+	if _, ok := defaults[complexity]; ok {
+		return complexity
+	}
+	return ComplexityMedium
+}
+
+// fileBucket groups file counts: none, 1-2, 3-5, and 6 or more.
+func fileBucket(files int) int {
+	switch {
+	case files == 0:
+		return 0
+	case files <= 2:
+		return 1
+	case files <= 5:
+		return 2
+	default:
+		return 3
+	}
+}
@@ -0,0 +1,128 @@
+package estimate
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	instmetrics "github.com/Iron-Ham/claudio/internal/instance/metrics"
+)
+
+type task struct {
+	complexity string
+	files      []string
+}
+
+func (t task) GetEstComplexity() string { return t.complexity }
+func (t task) GetFiles() []string       { return t.files }
+
+// samples returns n samples of complexity with files files, each costing
+// cost and taking d.
+func samples(n int, complexity string, files int, cost float64, d time.Duration) []Sample {
+	out := make([]Sample, n)
+	for i := range out {
+		out[i] = Sample{
+			Complexity: complexity,
+			Files:      files,
+			Metrics:    instmetrics.ParsedMetrics{InputTokens: 1000, OutputTokens: 500, Cost: cost},
+			Duration:   d,
+		}
+	}
+	return out
+}
+
+func TestEstimator_EstimateTask(t *testing.T) {
+	var history []Sample
+	history = append(history, samples(3, ComplexityHigh, 4, 6, time.Hour)...)
+	history = append(history, samples(2, ComplexityHigh, 1, 2, 30*time.Minute)...)
+	history = append(history, samples(1, ComplexityLow, 1, 0.1, time.Minute)...)
+	e := New(history)
+
+	tests := []struct {
+		name string
+		task task
+		want Estimate
+	}{
+		{
+			name: "matching bucket",
+			task: task{complexity: ComplexityHigh, files: []string{"a", "b", "c"}},
+			want: Estimate{Tokens: 1500, Cost: 6, Duration: time.Hour, Samples: 3},
+		},
+		{
+			name: "falls back to the complexity",
+			task: task{complexity: ComplexityHigh},
+			want: Estimate{Tokens: 1500, Cost: 4.4, Duration: 48 * time.Minute, Samples: 5},
+		},
+		{
+			// Too few low tasks: the defaults, scaled to the average of all six
+			name: "falls back to scaled defaults",
+			task: task{complexity: ComplexityLow, files: []string{"a"}},
+			want: Estimate{
+				Tokens:   Default(ComplexityLow).Tokens * 1500 / Default(ComplexityMedium).Tokens,
+				Cost:     Default(ComplexityLow).Cost * (22.1 / 6) / Default(ComplexityMedium).Cost,
+				Duration: time.Duration(float64(Default(ComplexityLow).Duration) * float64(241*time.Minute/6) / float64(Default(ComplexityMedium).Duration)).Round(time.Second),
+				Samples:  6,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := e.EstimateTask(tt.task)
+			if got.Tokens != tt.want.Tokens || math.Abs(got.Cost-tt.want.Cost) > 1e-9 ||
+				got.Duration != tt.want.Duration || got.Samples != tt.want.Samples {
+				t.Errorf("EstimateTask() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimator_Defaults(t *testing.T) {
+	few := New(samples(MinSamples-1, ComplexityMedium, 0, 50, 5*time.Hour))
+	for _, e := range []*Estimator{nil, New(nil), few} {
+		if got := e.EstimateTask(task{complexity: ComplexityHigh}); got != Default(ComplexityHigh) {
+			t.Errorf("EstimateTask(high) = %+v, want the default", got)
+		}
+		if got := e.EstimateTask(task{complexity: "huge"}); got != Default(ComplexityMedium) {
+			t.Errorf("EstimateTask(unknown) = %+v, want the medium default", got)
+		}
+	}
+	if few.Samples() != MinSamples-1 {
+		t.Errorf("Samples() = %d, want %d", few.Samples(), MinSamples-1)
+	}
+}
+
+func TestEstimate_String(t *testing.T) {
+	tests := []struct {
+		est  Estimate
+		want string
+	}{
+		{Estimate{Cost: 1.2, Duration: 20 * time.Minute}, "~$1.20, ~20m"},
+		{Estimate{Cost: 12.345, Duration: 65*time.Minute + 40*time.Second}, "~$12.35, ~1h 6m"},
+	}
+	for _, tt := range tests {
+		if got := tt.est.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestGroupDuration(t *testing.T) {
+	d := []time.Duration{10 * time.Minute, 30 * time.Minute, 20 * time.Minute}
+	tests := []struct {
+		maxParallel int
+		want        time.Duration
+	}{
+		{maxParallel: 0, want: 30 * time.Minute},
+		{maxParallel: 3, want: 30 * time.Minute},
+		{maxParallel: 2, want: 30 * time.Minute}, // 30 | 20+10
+		{maxParallel: 1, want: 60 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := GroupDuration(d, tt.maxParallel); got != tt.want {
+			t.Errorf("GroupDuration(max %d) = %v, want %v", tt.maxParallel, got, tt.want)
+		}
+	}
+	if got := GroupDuration(nil, 2); got != 0 {
+		t.Errorf("GroupDuration(nil) = %v, want 0", got)
+	}
+}
@@ -0,0 +1,93 @@
+package estimate
+
+import (
+	"encoding/json"
+	"time"
+
+	instmetrics "github.com/Iron-Ham/claudio/internal/instance/metrics"
+	orchsession "github.com/Iron-Ham/claudio/internal/orchestrator/session"
+	"github.com/Iron-Ham/claudio/internal/store"
+)
+
+// completedStatus is the status of an instance that finished its task.
+const completedStatus = "completed"
+
+// Sample is the recorded usage of one completed task of a past ultraplan.
+type Sample struct {
+	Complexity string
+	Files      int // Files the plan expected the task to modify
+	Metrics    instmetrics.ParsedMetrics
+	Duration   time.Duration
+}
+
+// ultraPlanData is the part of a saved ultraplan session samples are taken
+// from. It mirrors orchestrator.UltraPlanSession, which this package cannot
+// import.
+type ultraPlanData struct {
+	Plan *struct {
+		Tasks []struct {
+			ID            string   `json:"id"`
+			Files         []string `json:"files"`
+			EstComplexity string   `json:"est_complexity"`
+		} `json:"tasks"`
+	} `json:"plan"`
+	TaskToInstance map[string]string `json:"task_to_instance"`
+}
+
+// SamplesFromSession returns a sample for each task of sess's ultraplan
+// whose instance completed with recorded metrics. Sessions without an
+// ultraplan have none.
+func SamplesFromSession(sess *orchsession.SessionData) []Sample {
+	if sess == nil || sess.UltraPlan == nil {
+		return nil
+	}
+	// UltraPlan is decoded generically; round-trip it into ultraPlanData
+	raw, err := json.Marshal(sess.UltraPlan)
+	if err != nil {
+		return nil
+	}
+	var up ultraPlanData
+	if err := json.Unmarshal(raw, &up); err != nil || up.Plan == nil {
+		return nil
+	}
+
+	var samples []Sample
+	for _, task := range up.Plan.Tasks {
+		inst := sess.GetInstance(up.TaskToInstance[task.ID])
+		if inst == nil || inst.Status != completedStatus || inst.Metrics == nil {
+			continue
+		}
+		m := inst.Metrics
+		if m.StartTime == nil || m.EndTime == nil || !m.EndTime.After(*m.StartTime) {
+			continue
+		}
+		samples = append(samples, Sample{
+			Complexity: task.EstComplexity,
+			Files:      len(task.Files),
+			Metrics: instmetrics.ParsedMetrics{
+				InputTokens:      m.InputTokens,
+				OutputTokens:     m.OutputTokens,
+				CacheReadTokens:  m.CacheRead,
+				CacheWriteTokens: m.CacheWrite,
+				Cost:             m.Cost,
+				APICalls:         m.APICalls,
+			},
+			Duration: m.EndTime.Sub(*m.StartTime),
+		})
+	}
+	return samples
+}
+
+// Load returns the samples of the last sessions sessions recorded in the
+// history database (session.database). sessions <= 0 covers every session.
+func Load(db *store.Store, sessions int) ([]Sample, error) {
+	recent, err := db.RecentSessionData(sessions)
+	if err != nil {
+		return nil, err
+	}
+	var samples []Sample
+	for _, sess := range recent {
+		samples = append(samples, SamplesFromSession(sess)...)
+	}
+	return samples, nil
+}
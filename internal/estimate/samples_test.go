@@ -0,0 +1,80 @@
+package estimate
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	orchsession "github.com/Iron-Ham/claudio/internal/orchestrator/session"
+	"github.com/Iron-Ham/claudio/internal/store"
+)
+
+// ultraplanSession returns a saved session of an ultraplan with three tasks:
+// t1 completed, t2 failed, and t3 never started.
+func ultraplanSession(t *testing.T, id string) *orchsession.SessionData {
+	t.Helper()
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(25 * time.Minute)
+	metrics := &orchsession.MetricsData{InputTokens: 2000, OutputTokens: 1000, Cost: 1.5, APICalls: 4, StartTime: &start, EndTime: &end}
+
+	// Decode the ultraplan generically, as loading a session file does
+	var ultraPlan any
+	err := json.Unmarshal([]byte(`{
+		"plan": {"tasks": [
+			{"id": "t1", "est_complexity": "high", "files": ["a.go", "b.go"]},
+			{"id": "t2", "est_complexity": "low"},
+			{"id": "t3", "est_complexity": "low"}
+		]},
+		"task_to_instance": {"t1": "i1", "t2": "i2"}
+	}`), &ultraPlan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &orchsession.SessionData{
+		ID:      id,
+		Created: start,
+		Instances: []*orchsession.InstanceData{
+			{ID: "i1", Status: "completed", Metrics: metrics},
+			{ID: "i2", Status: "error", Metrics: metrics},
+		},
+		UltraPlan: ultraPlan,
+	}
+}
+
+func TestSamplesFromSession(t *testing.T) {
+	got := SamplesFromSession(ultraplanSession(t, "s1"))
+	if len(got) != 1 {
+		t.Fatalf("SamplesFromSession() = %+v, want only the completed task", got)
+	}
+	s := got[0]
+	if s.Complexity != ComplexityHigh || s.Files != 2 || s.Duration != 25*time.Minute ||
+		s.Metrics.InputTokens != 2000 || s.Metrics.Cost != 1.5 || s.Metrics.APICalls != 4 {
+		t.Errorf("sample = %+v", s)
+	}
+
+	if got := SamplesFromSession(&orchsession.SessionData{ID: "plain"}); got != nil {
+		t.Errorf("SamplesFromSession(no ultraplan) = %+v, want none", got)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	db, err := store.Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("store.Open() error = %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	for _, id := range []string{"s1", "s2"} {
+		if err := db.SaveSession(ultraplanSession(t, id)); err != nil {
+			t.Fatalf("SaveSession() error = %v", err)
+		}
+	}
+
+	got, err := Load(db, 0)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Complexity != ComplexityHigh {
+		t.Errorf("Load() = %+v, want one sample per session", got)
+	}
+}
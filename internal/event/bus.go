@@ -5,6 +5,7 @@ import (
 	"runtime/debug"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // Handler is a function that handles an event.
@@ -17,12 +18,16 @@ type subscription struct {
 	handler   Handler
 }
 
+// HandlerObserver is told how long each handler took to handle an event.
+type HandlerObserver func(eventType string, d time.Duration)
+
 // Bus is a simple synchronous pub-sub event bus.
 // It allows components to communicate without direct dependencies.
 type Bus struct {
 	mu            sync.RWMutex
 	subscriptions map[string][]subscription // eventType -> subscriptions
 	nextID        atomic.Uint64
+	observer      atomic.Pointer[HandlerObserver]
 }
 
 // NewBus creates a new event bus.
@@ -49,6 +54,17 @@ func (b *Bus) Subscribe(eventType string, handler Handler) string {
 	return id
 }
 
+// SetHandlerObserver times every handler call from now on and reports it to
+// observer, e.g. to find handlers that slow down publishers. A nil observer
+// stops timing.
+func (b *Bus) SetHandlerObserver(observer HandlerObserver) {
+	if observer == nil {
+		b.observer.Store(nil)
+		return
+	}
+	b.observer.Store(&observer)
+}
+
 // SubscribeAll registers a handler for all event types.
 // The handler will be called for every published event.
 // Returns a subscription ID that can be used to unsubscribe.
@@ -109,6 +125,10 @@ func (b *Bus) Publish(event Event) {
 // Panics are logged with stack traces to aid debugging while ensuring
 // one misbehaving handler cannot block event delivery to other handlers.
 func (b *Bus) safeCall(handler Handler, event Event) {
+	if observe := b.observer.Load(); observe != nil {
+		start := time.Now()
+		defer func() { (*observe)(event.EventType(), time.Since(start)) }()
+	}
 	defer func() {
 		if r := recover(); r != nil {
 			log.Printf("ERROR: event handler panicked for event %s: %v\n%s",
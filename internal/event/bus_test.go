@@ -3,6 +3,7 @@ package event
 import (
 	"sync"
 	"testing"
+	"time"
 )
 
 func TestBus_Subscribe(t *testing.T) {
@@ -195,6 +196,34 @@ func TestBus_HandlerPanicRecovery(t *testing.T) {
 	}
 }
 
+func TestBus_HandlerObserver(t *testing.T) {
+	bus := NewBus()
+	bus.Subscribe("test.event", func(e Event) { time.Sleep(time.Millisecond) })
+	bus.Subscribe("test.event", func(e Event) { panic("handler panic") })
+	bus.SubscribeAll(func(e Event) {})
+
+	var observed []string
+	var slowest time.Duration
+	bus.SetHandlerObserver(func(eventType string, d time.Duration) {
+		observed = append(observed, eventType)
+		slowest = max(slowest, d)
+	})
+	bus.Publish(newBaseEvent("test.event"))
+
+	if len(observed) != 3 {
+		t.Errorf("observer saw %d handler calls, want 3 including the panicking one", len(observed))
+	}
+	if slowest < time.Millisecond {
+		t.Errorf("slowest handler = %v, want at least 1ms", slowest)
+	}
+
+	bus.SetHandlerObserver(nil)
+	bus.Publish(newBaseEvent("test.event"))
+	if len(observed) != 3 {
+		t.Error("observer called after being cleared")
+	}
+}
+
 func TestBus_ConcurrentPublish(t *testing.T) {
 	bus := NewBus()
 
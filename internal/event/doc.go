@@ -33,6 +33,46 @@
 //   - [PhaseChangeEvent]: Emitted when the ultra-plan phase changes
 //   - [MetricsUpdateEvent]: Emitted when instance metrics are updated
 //
+// # Schema
+//
+// Event types are declared in schema.yaml, the single source of truth for
+// their Go fields, constructors, and JSON names. types_gen.go is generated
+// from it; after editing the schema run:
+//
+//	go generate ./internal/event
+//
+// Field types the schema refers to, such as [TimeoutType] and [Phase], and
+// any methods on events live in types.go.
+//
+// # Wire Format
+//
+// Events marshal to JSON as an [Envelope] carrying the event type, schema
+// version, timestamp, and fields; [Decode] turns an envelope back into the
+// event value a [Bus] would deliver. [SchemaVersion] is the version this
+// build writes and [MinSchemaVersion] the oldest it reads.
+//
+// Streams of events, such as a recording on disk or a connection to another
+// process, start with a [Header]. [NewEncoder] negotiates the version with
+// the reader's header and [NewDecoder] rejects streams this build cannot
+// read:
+//
+//	enc, err := event.NewEncoder(w, peerHeader) // event.LocalHeader() for files
+//	err = enc.Encode(e)                         // ErrNotInVersion: skip e
+//
+//	dec, err := event.NewDecoder(r)
+//	e, err := dec.Decode() // io.EOF at the end; ErrUnknownEventType: skip
+//
+// # Journal
+//
+// A [Journal] appends every event published on a bus to a JSON lines file
+// (events.jsonl in the session directory), so a session's history survives a
+// crash. After a restart, [Journal.Replay] publishes the journaled events
+// again so subscribers can rebuild their state:
+//
+//	j, err := event.OpenJournal(filepath.Join(sessionDir, event.JournalFileName))
+//	j.Attach(bus)
+//	n, err := j.Replay(bus, since) // Zero since replays everything
+//
 // # Thread Safety
 //
 // The [Bus] type is safe for concurrent use. Multiple goroutines can publish
@@ -65,9 +105,10 @@
 // # Event Type Naming Convention
 //
 // Event types follow the pattern "category.action":
-//   - instance.started, instance.stopped, instance.timeout, instance.bell
+//   - instance.started, instance.stopped, instance.timeout, instance.bell,
+//     instance.waiting_input
 //   - pr.completed, pr.opened
 //   - task.completed
 //   - phase.changed
-//   - metrics.updated
+//   - metrics.updated, budget.warning, budget.exceeded
 package event
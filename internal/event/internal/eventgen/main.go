@@ -0,0 +1,168 @@
+// Command eventgen generates the event types, their JSON encoding, and the
+// event registry from the declarative event schema. It is run by go generate
+// in the event package:
+//
+//	go run ./internal/eventgen -schema schema.yaml -out types_gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+	"text/template"
+)
+
+func main() {
+	schemaPath := flag.String("schema", "schema.yaml", "event schema to read")
+	outPath := flag.String("out", "types_gen.go", "Go file to write")
+	flag.Parse()
+
+	if err := run(*schemaPath, *outPath); err != nil {
+		fmt.Fprintln(os.Stderr, "eventgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaPath, outPath string) error {
+	data, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return err
+	}
+	src, err := generate(data)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outPath, src, 0o644)
+}
+
+// generate returns the gofmt-ed Go source for a schema.
+func generate(schemaData []byte) ([]byte, error) {
+	s, err := parseSchema(schemaData)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, s); err != nil {
+		return nil, fmt.Errorf("render: %w", err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated source: %w\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+// param is one group of constructor parameters sharing a type.
+type param struct {
+	Names []string
+	Type  string
+}
+
+// params groups the constructor parameters of ev the way gofmt-ed code
+// writes them: "taskID, instanceID string, success bool".
+func params(ev EventSpec) string {
+	var groups []param
+	for _, name := range ev.Args {
+		f := ev.field(name)
+		if n := len(groups); n > 0 && groups[n-1].Type == f.Type {
+			groups[n-1].Names = append(groups[n-1].Names, f.Param)
+			continue
+		}
+		groups = append(groups, param{Names: []string{f.Param}, Type: f.Type})
+	}
+	parts := make([]string, len(groups))
+	for i, g := range groups {
+		parts[i] = strings.Join(g.Names, ", ") + " " + g.Type
+	}
+	return strings.Join(parts, ", ")
+}
+
+// comment renders text as // comment lines.
+func comment(text string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight("// "+l, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// article returns "a" or "an" for name.
+func article(name string) string {
+	if strings.ContainsRune("AEIOU", rune(name[0])) {
+		return "an"
+	}
+	return "a"
+}
+
+var fileTemplate = template.Must(template.New("types_gen.go").Funcs(template.FuncMap{
+	"params":  params,
+	"comment": comment,
+	"article": article,
+}).Parse(`// Code generated by eventgen from schema.yaml; DO NOT EDIT.
+
+package event
+
+const (
+	// SchemaVersion is the event wire format version this build writes.
+	SchemaVersion = {{.Version}}
+
+	// MinSchemaVersion is the oldest event wire format version this build
+	// reads.
+	MinSchemaVersion = {{.MinVersion}}
+)
+{{range .Sections}}
+// -----------------------------------------------------------------------------
+// {{.Title}}
+// -----------------------------------------------------------------------------
+{{range .Events}}
+{{comment .Doc}}
+type {{.Name}} struct {
+	baseEvent
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`json:\"{{.JSON}}\"`" + `{{if .Doc}} // {{.Doc}}{{end}}
+{{- end}}
+}
+
+// New{{.Name}} creates {{article .Name}} {{.Name}}.
+func New{{.Name}}({{params .}}) {{.Name}} {
+	return {{.Name}}{
+		baseEvent: newBaseEvent("{{.Type}}"),
+{{- range .Fields}}
+		{{.Name}}: {{.Param}},
+{{- end}}
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e {{.Name}}) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *{{.Name}}) UnmarshalJSON(data []byte) error {
+	type payload {{.Name}}
+	return unmarshalEvent(data, "{{.Type}}", &e.baseEvent, (*payload)(e))
+}
+
+func (e {{.Name}}) payload() any {
+	type payload {{.Name}}
+	return payload(e)
+}
+{{end}}{{end}}
+// registry holds every event type in the schema.
+var registry = map[string]schemaEntry{
+{{- range .Sections}}{{range .Events}}
+	"{{.Type}}": {since: {{.Since}}, decode: decode[{{.Name}}]},
+{{- end}}{{end}}
+}
+
+// Compile-time checks that every event can be encoded.
+var (
+{{- range .Sections}}{{range .Events}}
+	_ wireEvent = {{.Name}}{}
+{{- end}}{{end}}
+)
+`))
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGenerate_UpToDate(t *testing.T) {
+	schema, err := os.ReadFile("../../schema.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := generate(schema)
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	got, err := os.ReadFile("../../types_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("types_gen.go is out of date with schema.yaml; run go generate ./internal/event")
+	}
+}
+
+func TestParseSchema_Invalid(t *testing.T) {
+	const valid = `
+version: 1
+min_version: 1
+sections:
+  - title: Test Events
+    events:
+      - name: FooEvent
+        type: test.foo
+        doc: FooEvent is a test.
+        fields:
+          - {name: ID, type: string, json: id}
+`
+	if _, err := parseSchema([]byte(valid)); err != nil {
+		t.Fatalf("parseSchema(valid) error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		replace [2]string
+	}{
+		{"version below min", [2]string{"version: 1", "version: 0"}},
+		{"bad event type", [2]string{"type: test.foo", "type: Foo"}},
+		{"since after version", [2]string{"type: test.foo", "type: test.foo\n        since: 2"}},
+		{"bad json name", [2]string{"json: id", "json: ID"}},
+		{"missing doc", [2]string{"doc: FooEvent is a test.", "doc: ''"}},
+		{"args missing a field", [2]string{"fields:", "args: [Other]\n        fields:"}},
+		{"duplicate field", [2]string{"json: id}", "json: id}\n          - {name: ID, type: string, json: id2}"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := strings.Replace(valid, tt.replace[0], tt.replace[1], 1)
+			if _, err := parseSchema([]byte(schema)); err == nil {
+				t.Error("parseSchema() should fail")
+			}
+		})
+	}
+
+	dup := valid + `
+      - name: BarEvent
+        type: test.foo
+        doc: BarEvent reuses FooEvent's type.
+`
+	if _, err := parseSchema([]byte(dup)); err == nil {
+		t.Error("parseSchema() should reject a duplicate event type")
+	}
+}
+
+func TestParamName(t *testing.T) {
+	tests := map[string]string{
+		"InstanceID": "instanceID",
+		"APICalls":   "apiCalls",
+		"ID":         "id",
+		"Success":    "success",
+		"PRURL":      "prurl",
+	}
+	for field, want := range tests {
+		if got := paramName(field); got != want {
+			t.Errorf("paramName(%q) = %q, want %q", field, got, want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Schema is the parsed schema.yaml.
+type Schema struct {
+	Version    int       `yaml:"version"`
+	MinVersion int       `yaml:"min_version"`
+	Sections   []Section `yaml:"sections"`
+}
+
+// Section groups related events under a banner in the generated file.
+type Section struct {
+	Title  string      `yaml:"title"`
+	Events []EventSpec `yaml:"events"`
+}
+
+// EventSpec declares one event.
+type EventSpec struct {
+	Name   string      `yaml:"name"`  // Go type name, e.g. "InstanceStartedEvent"
+	Type   string      `yaml:"type"`  // Event type on the bus, e.g. "instance.started"
+	Since  int         `yaml:"since"` // Schema version that introduced the event (default 1)
+	Doc    string      `yaml:"doc"`   // Type doc comment, one comment line per line
+	Args   []string    `yaml:"args"`  // Constructor parameter order by field name (default field order)
+	Fields []FieldSpec `yaml:"fields"`
+}
+
+// FieldSpec declares one event field.
+type FieldSpec struct {
+	Name  string `yaml:"name"`  // Go field name
+	Type  string `yaml:"type"`  // Go type
+	JSON  string `yaml:"json"`  // Wire name
+	Param string `yaml:"param"` // Constructor parameter name (default derived from Name)
+	Doc   string `yaml:"doc"`   // Trailing field comment
+}
+
+var (
+	eventTypePattern = regexp.MustCompile(`^[a-z]+\.[a-z_]+$`)
+	jsonNamePattern  = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+)
+
+// parseSchema decodes and validates a schema, filling in defaults.
+func parseSchema(data []byte) (*Schema, error) {
+	var s Schema
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parse schema: %w", err)
+	}
+	if s.MinVersion < 1 || s.Version < s.MinVersion {
+		return nil, fmt.Errorf("schema: need 1 <= min_version <= version, got %d and %d", s.MinVersion, s.Version)
+	}
+
+	names := make(map[string]bool)
+	types := make(map[string]bool)
+	for si := range s.Sections {
+		sec := &s.Sections[si]
+		if sec.Title == "" {
+			return nil, fmt.Errorf("schema: section %d has no title", si+1)
+		}
+		for ei := range sec.Events {
+			ev := &sec.Events[ei]
+			if err := ev.validate(s.Version); err != nil {
+				return nil, fmt.Errorf("schema: %w", err)
+			}
+			if names[ev.Name] {
+				return nil, fmt.Errorf("schema: event %s declared twice", ev.Name)
+			}
+			if types[ev.Type] {
+				return nil, fmt.Errorf("schema: event type %q declared twice", ev.Type)
+			}
+			names[ev.Name] = true
+			types[ev.Type] = true
+		}
+	}
+	return &s, nil
+}
+
+func (ev *EventSpec) validate(version int) error {
+	if !token.IsIdentifier(ev.Name) || !token.IsExported(ev.Name) || !strings.HasSuffix(ev.Name, "Event") {
+		return fmt.Errorf("event name %q must be an exported identifier ending in Event", ev.Name)
+	}
+	if !eventTypePattern.MatchString(ev.Type) {
+		return fmt.Errorf("%s: type %q must look like \"category.action\"", ev.Name, ev.Type)
+	}
+	if ev.Since == 0 {
+		ev.Since = 1
+	}
+	if ev.Since < 1 || ev.Since > version {
+		return fmt.Errorf("%s: since %d is outside schema versions 1 to %d", ev.Name, ev.Since, version)
+	}
+	if strings.TrimSpace(ev.Doc) == "" {
+		return fmt.Errorf("%s: doc is required", ev.Name)
+	}
+
+	fieldNames := make(map[string]bool)
+	jsonNames := make(map[string]bool)
+	params := make(map[string]bool)
+	for i := range ev.Fields {
+		f := &ev.Fields[i]
+		if !token.IsIdentifier(f.Name) || !token.IsExported(f.Name) {
+			return fmt.Errorf("%s: field name %q must be an exported identifier", ev.Name, f.Name)
+		}
+		if f.Type == "" {
+			return fmt.Errorf("%s.%s: type is required", ev.Name, f.Name)
+		}
+		if !jsonNamePattern.MatchString(f.JSON) {
+			return fmt.Errorf("%s.%s: json name %q must be snake_case", ev.Name, f.Name, f.JSON)
+		}
+		if f.Param == "" {
+			f.Param = paramName(f.Name)
+		}
+		if !token.IsIdentifier(f.Param) || token.IsExported(f.Param) {
+			return fmt.Errorf("%s.%s: param %q must be an unexported identifier", ev.Name, f.Name, f.Param)
+		}
+		if fieldNames[f.Name] || jsonNames[f.JSON] || params[f.Param] {
+			return fmt.Errorf("%s.%s: field, json name, or param declared twice", ev.Name, f.Name)
+		}
+		fieldNames[f.Name] = true
+		jsonNames[f.JSON] = true
+		params[f.Param] = true
+	}
+
+	if ev.Args == nil {
+		for _, f := range ev.Fields {
+			ev.Args = append(ev.Args, f.Name)
+		}
+	}
+	sorted := slices.Sorted(slices.Values(ev.Args))
+	fields := make([]string, 0, len(ev.Fields))
+	for _, f := range ev.Fields {
+		fields = append(fields, f.Name)
+	}
+	slices.Sort(fields)
+	if !slices.Equal(sorted, fields) {
+		return fmt.Errorf("%s: args must list every field exactly once", ev.Name)
+	}
+	return nil
+}
+
+// field returns the field with the given name.
+func (ev *EventSpec) field(name string) FieldSpec {
+	i := slices.IndexFunc(ev.Fields, func(f FieldSpec) bool { return f.Name == name })
+	return ev.Fields[i]
+}
+
+// paramName derives a constructor parameter name from a field name,
+// keeping initialisms intact: InstanceID -> instanceID, APICalls -> apiCalls.
+func paramName(field string) string {
+	r := []rune(field)
+	upper := 0
+	for upper < len(r) && unicode.IsUpper(r[upper]) {
+		upper++
+	}
+	switch {
+	case upper == len(r):
+		return strings.ToLower(field)
+	case upper > 1:
+		upper-- // The last capital starts the next word
+	}
+	return strings.ToLower(string(r[:upper])) + string(r[upper:])
+}
@@ -0,0 +1,234 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// JournalFileName is the name of a session's event journal within its
+// session directory.
+const JournalFileName = "events.jsonl"
+
+// Journal appends events to a JSON lines file written by an Encoder, so a
+// session's history survives a crash. Events outside the schema are not
+// journaled.
+type Journal struct {
+	mu    sync.Mutex
+	path  string
+	f     *os.File
+	enc   *Encoder
+	bus   *Bus
+	subID string
+	last  time.Time // Timestamp of the newest journaled event
+	err   error     // First write error
+
+	// While replays are running, events at or before replayThrough are
+	// replayed copies of journaled events and are not journaled again.
+	replays       int
+	replayThrough time.Time
+}
+
+// OpenJournal opens the journal at path for appending, creating it with a
+// Header if it does not exist. An existing journal keeps the schema version
+// it was started at, so it stays readable as one stream.
+func OpenJournal(path string) (*Journal, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open event journal: %w", err)
+	}
+	j := &Journal{path: path, f: f}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("open event journal: %w", err)
+	}
+	if info.Size() == 0 {
+		j.enc, err = NewEncoder(f, LocalHeader())
+	} else {
+		j.enc, err = j.resume(info.Size())
+	}
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("open event journal %s: %w", path, err)
+	}
+	return j, nil
+}
+
+// resume reads an existing journal of the given size to find its version
+// and newest event, and returns an Encoder that appends to it. A last line
+// cut short by a crash is terminated, so the next event starts a new line.
+func (j *Journal) resume(size int64) (*Encoder, error) {
+	last := make([]byte, 1)
+	if _, err := j.f.ReadAt(last, size-1); err != nil {
+		return nil, err
+	}
+	if last[0] != '\n' {
+		if _, err := j.f.Write([]byte{'\n'}); err != nil {
+			return nil, err
+		}
+	}
+
+	dec, err := NewDecoder(io.NewSectionReader(j.f, 0, size))
+	if err != nil {
+		return nil, err
+	}
+	for {
+		e, err := dec.Decode()
+		switch {
+		case errors.Is(err, io.EOF):
+			return &Encoder{w: j.f, version: dec.Version()}, nil
+		case err != nil && dec.scanner.Err() != nil:
+			return nil, err
+		case err == nil && e.Timestamp().After(j.last):
+			j.last = e.Timestamp()
+		}
+	}
+}
+
+// Path returns the journal's file path.
+func (j *Journal) Path() string {
+	return j.path
+}
+
+// Attach journals every event published on bus until Close.
+func (j *Journal) Attach(bus *Bus) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.subID != "" {
+		return
+	}
+	j.bus = bus
+	j.subID = bus.SubscribeAll(j.record)
+}
+
+// Record appends e to the journal. Events outside the schema, or newer than
+// the journal's version, are skipped without error.
+func (j *Journal) Record(e Event) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.f == nil {
+		return os.ErrClosed
+	}
+	if j.replays > 0 && !e.Timestamp().After(j.replayThrough) {
+		return nil
+	}
+	err := j.enc.Encode(e)
+	switch {
+	case errors.Is(err, ErrUnknownEventType) || errors.Is(err, ErrNotInVersion):
+		return nil
+	case err != nil:
+		if j.err == nil {
+			j.err = err
+		}
+		return fmt.Errorf("journal %s: %w", e.EventType(), err)
+	}
+	if e.Timestamp().After(j.last) {
+		j.last = e.Timestamp()
+	}
+	return nil
+}
+
+// record is the bus handler. Failures are kept for Err rather than
+// returned, since publishers must not be blocked by a broken journal.
+func (j *Journal) record(e Event) {
+	_ = j.Record(e)
+}
+
+// Err returns the first error writing to the journal, if any.
+func (j *Journal) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.err
+}
+
+// Replay publishes the journaled events timestamped at or after since on
+// bus, oldest first, and returns how many it published. A zero since
+// replays everything. The events are the values originally published, so
+// subscribers such as the TUI and coordinator rebuild their state exactly
+// as they built it live. When the journal is attached to bus, the replayed
+// events are not journaled a second time.
+func (j *Journal) Replay(bus *Bus, since time.Time) (int, error) {
+	j.mu.Lock()
+	if j.f == nil {
+		j.mu.Unlock()
+		return 0, os.ErrClosed
+	}
+	j.replays++
+	j.replayThrough = j.last
+	j.mu.Unlock()
+	defer func() {
+		j.mu.Lock()
+		j.replays--
+		j.mu.Unlock()
+	}()
+
+	events, err := ReadJournal(j.path, since)
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range events {
+		bus.Publish(e)
+	}
+	return len(events), nil
+}
+
+// Close stops journaling and closes the file. It is safe to call more than
+// once.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	bus, subID := j.bus, j.subID
+	j.bus, j.subID = nil, ""
+	j.mu.Unlock()
+	if subID != "" {
+		bus.Unsubscribe(subID)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.f == nil {
+		return nil
+	}
+	err := j.f.Close()
+	j.f = nil
+	if err != nil {
+		return fmt.Errorf("close event journal: %w", err)
+	}
+	return nil
+}
+
+// ReadJournal returns the events in the journal at path timestamped at or
+// after since, oldest first. Lines that cannot be decoded, such as events
+// from a newer build or a line cut short by a crash, are skipped.
+func ReadJournal(path string, since time.Time) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("read event journal: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	dec, err := NewDecoder(f)
+	if err != nil {
+		return nil, fmt.Errorf("read event journal %s: %w", path, err)
+	}
+	var events []Event
+	for {
+		e, err := dec.Decode()
+		switch {
+		case errors.Is(err, io.EOF):
+			return events, nil
+		case err != nil && dec.scanner.Err() != nil:
+			return events, fmt.Errorf("read event journal %s: %w", path, err)
+		case err != nil:
+			continue
+		}
+		if !e.Timestamp().Before(since) {
+			events = append(events, e)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package event
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// unknownEvent is an event outside the schema.
+type unknownEvent struct{ baseEvent }
+
+// collect subscribes to every event on bus and returns a function reporting
+// the events received so far.
+func collect(bus *Bus) func() []Event {
+	var mu sync.Mutex
+	var got []Event
+	bus.SubscribeAll(func(e Event) {
+		mu.Lock()
+		got = append(got, e)
+		mu.Unlock()
+	})
+	return func() []Event {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]Event(nil), got...)
+	}
+}
+
+func TestJournal_RecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), JournalFileName)
+	j, err := OpenJournal(path)
+	if err != nil {
+		t.Fatalf("OpenJournal() error = %v", err)
+	}
+	bus := NewBus()
+	j.Attach(bus)
+
+	published := []Event{
+		NewInstanceStartedEvent("inst-1", "/wt", "feature", "do things"),
+		NewTaskCompletedEvent("task-1", "inst-1", true, "", ""),
+		NewMetricsUpdateEvent("inst-1", 10, 20, 3, 4, 0.25, 7),
+	}
+	for _, e := range published {
+		bus.Publish(e)
+	}
+	bus.Publish(unknownEvent{baseEvent: newBaseEvent("test.unknown")})
+	if err := j.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	bus.Publish(NewBellEvent("inst-1")) // After Close: not journaled
+
+	events, err := ReadJournal(path, time.Time{})
+	if err != nil {
+		t.Fatalf("ReadJournal() error = %v", err)
+	}
+	if len(events) != len(published) {
+		t.Fatalf("ReadJournal() = %d events, want %d", len(events), len(published))
+	}
+	for i, want := range published {
+		if reflect.TypeOf(events[i]) != reflect.TypeOf(want) || events[i].EventType() != want.EventType() {
+			t.Errorf("events[%d] = %T, want %T", i, events[i], want)
+		}
+	}
+	if got := events[1].(TaskCompletedEvent); got.TaskID != "task-1" || !got.Success {
+		t.Errorf("decoded task.completed = %+v", got)
+	}
+
+	// A restarted session reopens the journal and replays it onto a new bus.
+	j, err = OpenJournal(path)
+	if err != nil {
+		t.Fatalf("reopen error = %v", err)
+	}
+	defer func() { _ = j.Close() }()
+	restarted := NewBus()
+	j.Attach(restarted)
+	received := collect(restarted)
+
+	n, err := j.Replay(restarted, time.Time{})
+	if err != nil || n != len(published) {
+		t.Fatalf("Replay() = %d, %v; want %d", n, err, len(published))
+	}
+	if got := received(); len(got) != len(published) {
+		t.Errorf("subscribers received %d replayed events, want %d", len(got), len(published))
+	}
+
+	restarted.Publish(NewInstanceStoppedEvent("inst-1", true, "completed"))
+	events, err = ReadJournal(path, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != len(published)+1 {
+		t.Errorf("journal has %d events after replay and one live event, want %d (replays must not be journaled again)",
+			len(events), len(published)+1)
+	}
+}
+
+func TestJournal_ReplaySince(t *testing.T) {
+	path := filepath.Join(t.TempDir(), JournalFileName)
+	j, err := OpenJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = j.Close() }()
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, id := range []string{"old", "cutoff", "new"} {
+		e := NewInstanceStartedEvent(id, "", "", "")
+		e.timestamp = base.Add(time.Duration(i) * time.Minute)
+		if err := j.Record(e); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	bus := NewBus()
+	received := collect(bus)
+	n, err := j.Replay(bus, base.Add(time.Minute))
+	if err != nil || n != 2 {
+		t.Fatalf("Replay() = %d, %v; want 2", n, err)
+	}
+	got := received()
+	if got[0].(InstanceStartedEvent).InstanceID != "cutoff" || got[1].(InstanceStartedEvent).InstanceID != "new" {
+		t.Errorf("replayed %v, want cutoff then new", got)
+	}
+}
+
+func TestJournal_TruncatedLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), JournalFileName)
+	j, err := OpenJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Record(NewBellEvent("inst-1")); err != nil {
+		t.Fatal(err)
+	}
+	_ = j.Close()
+
+	// Simulate a crash in the middle of writing an event.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"type":"instance.bell","vers`); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	j, err = OpenJournal(path)
+	if err != nil {
+		t.Fatalf("OpenJournal() after crash error = %v", err)
+	}
+	if err := j.Record(NewBellEvent("inst-2")); err != nil {
+		t.Fatal(err)
+	}
+	_ = j.Close()
+
+	events, err := ReadJournal(path, time.Time{})
+	if err != nil {
+		t.Fatalf("ReadJournal() error = %v", err)
+	}
+	if len(events) != 2 || events[1].(BellEvent).InstanceID != "inst-2" {
+		t.Errorf("ReadJournal() = %v, want both complete events", events)
+	}
+}
+
+func TestJournal_Closed(t *testing.T) {
+	j, err := OpenJournal(filepath.Join(t.TempDir(), JournalFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+	if err := j.Record(NewBellEvent("inst-1")); err == nil {
+		t.Error("Record() after Close should fail")
+	}
+	if _, err := j.Replay(NewBus(), time.Time{}); err == nil {
+		t.Error("Replay() after Close should fail")
+	}
+}
@@ -0,0 +1,2103 @@
+// Code generated by eventgen from schema.yaml; DO NOT EDIT.
+
+package event
+
+const (
+	// SchemaVersion is the event wire format version this build writes.
+	SchemaVersion = 1
+
+	// MinSchemaVersion is the oldest event wire format version this build
+	// reads.
+	MinSchemaVersion = 1
+)
+
+// -----------------------------------------------------------------------------
+// Instance Lifecycle Events
+// -----------------------------------------------------------------------------
+
+// InstanceStartedEvent is emitted when a backend instance begins execution.
+type InstanceStartedEvent struct {
+	baseEvent
+	InstanceID   string `json:"instance_id"`   // Unique identifier for the instance
+	WorktreePath string `json:"worktree_path"` // Path to the git worktree
+	Branch       string `json:"branch"`        // Git branch name
+	Task         string `json:"task"`          // Task description or prompt
+}
+
+// NewInstanceStartedEvent creates an InstanceStartedEvent.
+func NewInstanceStartedEvent(instanceID, worktreePath, branch, task string) InstanceStartedEvent {
+	return InstanceStartedEvent{
+		baseEvent:    newBaseEvent("instance.started"),
+		InstanceID:   instanceID,
+		WorktreePath: worktreePath,
+		Branch:       branch,
+		Task:         task,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e InstanceStartedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *InstanceStartedEvent) UnmarshalJSON(data []byte) error {
+	type payload InstanceStartedEvent
+	return unmarshalEvent(data, "instance.started", &e.baseEvent, (*payload)(e))
+}
+
+func (e InstanceStartedEvent) payload() any {
+	type payload InstanceStartedEvent
+	return payload(e)
+}
+
+// InstanceStoppedEvent is emitted when a backend instance stops execution.
+type InstanceStoppedEvent struct {
+	baseEvent
+	InstanceID string `json:"instance_id"` // Unique identifier for the instance
+	Success    bool   `json:"success"`     // Whether the instance completed successfully
+	Reason     string `json:"reason"`      // Reason for stopping (e.g., "completed", "error", "cancelled")
+}
+
+// NewInstanceStoppedEvent creates an InstanceStoppedEvent.
+func NewInstanceStoppedEvent(instanceID string, success bool, reason string) InstanceStoppedEvent {
+	return InstanceStoppedEvent{
+		baseEvent:  newBaseEvent("instance.stopped"),
+		InstanceID: instanceID,
+		Success:    success,
+		Reason:     reason,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e InstanceStoppedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *InstanceStoppedEvent) UnmarshalJSON(data []byte) error {
+	type payload InstanceStoppedEvent
+	return unmarshalEvent(data, "instance.stopped", &e.baseEvent, (*payload)(e))
+}
+
+func (e InstanceStoppedEvent) payload() any {
+	type payload InstanceStoppedEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// PR Events
+// -----------------------------------------------------------------------------
+
+// PRCompleteEvent is emitted when a pull request operation completes.
+type PRCompleteEvent struct {
+	baseEvent
+	InstanceID string `json:"instance_id"` // Instance that created/updated the PR
+	Success    bool   `json:"success"`     // Whether the PR operation succeeded
+	PRURL      string `json:"pr_url"`      // URL of the pull request (if created)
+	Error      string `json:"error"`       // Error message (if failed)
+}
+
+// NewPRCompleteEvent creates a PRCompleteEvent.
+func NewPRCompleteEvent(instanceID string, success bool, prURL, errMsg string) PRCompleteEvent {
+	return PRCompleteEvent{
+		baseEvent:  newBaseEvent("pr.completed"),
+		InstanceID: instanceID,
+		Success:    success,
+		PRURL:      prURL,
+		Error:      errMsg,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e PRCompleteEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *PRCompleteEvent) UnmarshalJSON(data []byte) error {
+	type payload PRCompleteEvent
+	return unmarshalEvent(data, "pr.completed", &e.baseEvent, (*payload)(e))
+}
+
+func (e PRCompleteEvent) payload() any {
+	type payload PRCompleteEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Timeout Events
+// -----------------------------------------------------------------------------
+
+// TimeoutEvent is emitted when an instance times out.
+type TimeoutEvent struct {
+	baseEvent
+	InstanceID  string      `json:"instance_id"`  // Instance that timed out
+	TimeoutType TimeoutType `json:"timeout_type"` // Type of timeout
+	Duration    string      `json:"duration"`     // How long since last activity or start
+}
+
+// NewTimeoutEvent creates a TimeoutEvent.
+func NewTimeoutEvent(instanceID string, timeoutType TimeoutType, duration string) TimeoutEvent {
+	return TimeoutEvent{
+		baseEvent:   newBaseEvent("instance.timeout"),
+		InstanceID:  instanceID,
+		TimeoutType: timeoutType,
+		Duration:    duration,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e TimeoutEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *TimeoutEvent) UnmarshalJSON(data []byte) error {
+	type payload TimeoutEvent
+	return unmarshalEvent(data, "instance.timeout", &e.baseEvent, (*payload)(e))
+}
+
+func (e TimeoutEvent) payload() any {
+	type payload TimeoutEvent
+	return payload(e)
+}
+
+// InstanceNudgedEvent is emitted each time the escalation ladder sends a
+// stalled instance its nudge message.
+type InstanceNudgedEvent struct {
+	baseEvent
+	InstanceID  string `json:"instance_id"`  // Instance that was nudged
+	Attempt     int    `json:"attempt"`      // Nudge number in the current escalation, starting at 1
+	MaxAttempts int    `json:"max_attempts"` // Nudges sent before escalating further
+	Message     string `json:"message"`      // Text sent to the instance
+}
+
+// NewInstanceNudgedEvent creates an InstanceNudgedEvent.
+func NewInstanceNudgedEvent(instanceID string, attempt, maxAttempts int, message string) InstanceNudgedEvent {
+	return InstanceNudgedEvent{
+		baseEvent:   newBaseEvent("instance.nudged"),
+		InstanceID:  instanceID,
+		Attempt:     attempt,
+		MaxAttempts: maxAttempts,
+		Message:     message,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e InstanceNudgedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *InstanceNudgedEvent) UnmarshalJSON(data []byte) error {
+	type payload InstanceNudgedEvent
+	return unmarshalEvent(data, "instance.nudged", &e.baseEvent, (*payload)(e))
+}
+
+func (e InstanceNudgedEvent) payload() any {
+	type payload InstanceNudgedEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Task Events (Ultra-Plan)
+// -----------------------------------------------------------------------------
+
+// TaskCompletedEvent is emitted when an ultra-plan task completes.
+type TaskCompletedEvent struct {
+	baseEvent
+	TaskID     string `json:"task_id"`     // Task identifier from the plan
+	InstanceID string `json:"instance_id"` // Instance that executed the task (empty if not yet started)
+	Success    bool   `json:"success"`     // Whether the task completed successfully
+	Reason     string `json:"reason"`      // Additional context (error message if failed)
+	Title      string `json:"title"`       // Task title from the plan
+}
+
+// NewTaskCompletedEvent creates a TaskCompletedEvent.
+func NewTaskCompletedEvent(taskID, instanceID string, success bool, reason, title string) TaskCompletedEvent {
+	return TaskCompletedEvent{
+		baseEvent:  newBaseEvent("task.completed"),
+		TaskID:     taskID,
+		InstanceID: instanceID,
+		Success:    success,
+		Reason:     reason,
+		Title:      title,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e TaskCompletedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *TaskCompletedEvent) UnmarshalJSON(data []byte) error {
+	type payload TaskCompletedEvent
+	return unmarshalEvent(data, "task.completed", &e.baseEvent, (*payload)(e))
+}
+
+func (e TaskCompletedEvent) payload() any {
+	type payload TaskCompletedEvent
+	return payload(e)
+}
+
+// TaskAddedEvent is emitted when a task is added to the plan of a running
+// ultra-plan session.
+type TaskAddedEvent struct {
+	baseEvent
+	TaskID    string   `json:"task_id"`    // Identifier of the new task
+	Title     string   `json:"title"`      // Task title
+	DependsOn []string `json:"depends_on"` // Tasks the new task waits for
+}
+
+// NewTaskAddedEvent creates a TaskAddedEvent.
+func NewTaskAddedEvent(taskID, title string, dependsOn []string) TaskAddedEvent {
+	return TaskAddedEvent{
+		baseEvent: newBaseEvent("task.added"),
+		TaskID:    taskID,
+		Title:     title,
+		DependsOn: dependsOn,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e TaskAddedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *TaskAddedEvent) UnmarshalJSON(data []byte) error {
+	type payload TaskAddedEvent
+	return unmarshalEvent(data, "task.added", &e.baseEvent, (*payload)(e))
+}
+
+func (e TaskAddedEvent) payload() any {
+	type payload TaskAddedEvent
+	return payload(e)
+}
+
+// PlanRevisedEvent is emitted when a revised plan is merged into a running
+// ultra-plan session.
+type PlanRevisedEvent struct {
+	baseEvent
+	Added     []string `json:"added"`     // Tasks only in the revised plan
+	Removed   []string `json:"removed"`   // Tasks dropped from the plan
+	Modified  []string `json:"modified"`  // Tasks whose definition changed
+	Cancelled []string `json:"cancelled"` // Running tasks whose instance was stopped
+}
+
+// NewPlanRevisedEvent creates a PlanRevisedEvent.
+func NewPlanRevisedEvent(added, removed, modified, cancelled []string) PlanRevisedEvent {
+	return PlanRevisedEvent{
+		baseEvent: newBaseEvent("plan.revised"),
+		Added:     added,
+		Removed:   removed,
+		Modified:  modified,
+		Cancelled: cancelled,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e PlanRevisedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *PlanRevisedEvent) UnmarshalJSON(data []byte) error {
+	type payload PlanRevisedEvent
+	return unmarshalEvent(data, "plan.revised", &e.baseEvent, (*payload)(e))
+}
+
+func (e PlanRevisedEvent) payload() any {
+	type payload PlanRevisedEvent
+	return payload(e)
+}
+
+// TaskProposedEvent is emitted when an instance proposes a follow-up task
+// and the proposal is queued for approval.
+type TaskProposedEvent struct {
+	baseEvent
+	ProposalID string `json:"proposal_id"` // Identifier of the proposal, also the task ID once approved
+	From       string `json:"from"`        // Task whose instance proposed it
+	Title      string `json:"title"`       // Proposed task title
+}
+
+// NewTaskProposedEvent creates a TaskProposedEvent.
+func NewTaskProposedEvent(proposalID, from, title string) TaskProposedEvent {
+	return TaskProposedEvent{
+		baseEvent:  newBaseEvent("task.proposed"),
+		ProposalID: proposalID,
+		From:       from,
+		Title:      title,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e TaskProposedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *TaskProposedEvent) UnmarshalJSON(data []byte) error {
+	type payload TaskProposedEvent
+	return unmarshalEvent(data, "task.proposed", &e.baseEvent, (*payload)(e))
+}
+
+func (e TaskProposedEvent) payload() any {
+	type payload TaskProposedEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Phase Events (Ultra-Plan)
+// -----------------------------------------------------------------------------
+
+// PhaseChangeEvent is emitted when the ultra-plan phase changes.
+type PhaseChangeEvent struct {
+	baseEvent
+	PreviousPhase Phase  `json:"previous_phase"` // Previous phase (empty if first transition)
+	CurrentPhase  Phase  `json:"current_phase"`  // New current phase
+	SessionID     string `json:"session_id"`     // Ultra-plan session ID
+}
+
+// NewPhaseChangeEvent creates a PhaseChangeEvent.
+func NewPhaseChangeEvent(sessionID string, previousPhase, currentPhase Phase) PhaseChangeEvent {
+	return PhaseChangeEvent{
+		baseEvent:     newBaseEvent("phase.changed"),
+		PreviousPhase: previousPhase,
+		CurrentPhase:  currentPhase,
+		SessionID:     sessionID,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e PhaseChangeEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *PhaseChangeEvent) UnmarshalJSON(data []byte) error {
+	type payload PhaseChangeEvent
+	return unmarshalEvent(data, "phase.changed", &e.baseEvent, (*payload)(e))
+}
+
+func (e PhaseChangeEvent) payload() any {
+	type payload PhaseChangeEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Metrics Events
+// -----------------------------------------------------------------------------
+
+// MetricsUpdateEvent is emitted when instance metrics are updated.
+type MetricsUpdateEvent struct {
+	baseEvent
+	InstanceID   string  `json:"instance_id"`   // Instance the metrics belong to
+	InputTokens  int64   `json:"input_tokens"`  // Total input tokens used
+	OutputTokens int64   `json:"output_tokens"` // Total output tokens used
+	CacheRead    int64   `json:"cache_read"`    // Tokens read from cache
+	CacheWrite   int64   `json:"cache_write"`   // Tokens written to cache
+	Cost         float64 `json:"cost"`          // Estimated cost in USD
+	APICalls     int     `json:"api_calls"`     // Number of API calls made
+}
+
+// NewMetricsUpdateEvent creates a MetricsUpdateEvent.
+func NewMetricsUpdateEvent(instanceID string, inputTokens, outputTokens, cacheRead, cacheWrite int64, cost float64, apiCalls int) MetricsUpdateEvent {
+	return MetricsUpdateEvent{
+		baseEvent:    newBaseEvent("metrics.updated"),
+		InstanceID:   instanceID,
+		InputTokens:  inputTokens,
+		OutputTokens: outputTokens,
+		CacheRead:    cacheRead,
+		CacheWrite:   cacheWrite,
+		Cost:         cost,
+		APICalls:     apiCalls,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e MetricsUpdateEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *MetricsUpdateEvent) UnmarshalJSON(data []byte) error {
+	type payload MetricsUpdateEvent
+	return unmarshalEvent(data, "metrics.updated", &e.baseEvent, (*payload)(e))
+}
+
+func (e MetricsUpdateEvent) payload() any {
+	type payload MetricsUpdateEvent
+	return payload(e)
+}
+
+// BudgetExceededEvent is emitted when an instance or the session exceeds a
+// hard budget limit and the budget enforcer pauses or stops instances.
+type BudgetExceededEvent struct {
+	baseEvent
+	Limit      string   `json:"limit"`       // Limit exceeded: session_cost, instance_cost, or instance_tokens
+	InstanceID string   `json:"instance_id"` // Instance over its limit (empty for the session limit)
+	Threshold  float64  `json:"threshold"`   // Configured limit (USD or tokens)
+	Spent      float64  `json:"spent"`       // Spend when the limit was exceeded (USD or tokens)
+	Action     string   `json:"action"`      // What was done to the instances: pause or stop
+	Instances  []string `json:"instances"`   // Instances paused or stopped
+}
+
+// NewBudgetExceededEvent creates a BudgetExceededEvent.
+func NewBudgetExceededEvent(limit, instanceID string, threshold, spent float64, action string, instances []string) BudgetExceededEvent {
+	return BudgetExceededEvent{
+		baseEvent:  newBaseEvent("budget.exceeded"),
+		Limit:      limit,
+		InstanceID: instanceID,
+		Threshold:  threshold,
+		Spent:      spent,
+		Action:     action,
+		Instances:  instances,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e BudgetExceededEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *BudgetExceededEvent) UnmarshalJSON(data []byte) error {
+	type payload BudgetExceededEvent
+	return unmarshalEvent(data, "budget.exceeded", &e.baseEvent, (*payload)(e))
+}
+
+func (e BudgetExceededEvent) payload() any {
+	type payload BudgetExceededEvent
+	return payload(e)
+}
+
+// BudgetWarningEvent is emitted once per session when its cost first
+// reaches resources.cost_warning_threshold.
+type BudgetWarningEvent struct {
+	baseEvent
+	Threshold float64 `json:"threshold"` // Configured warning threshold (USD)
+	Spent     float64 `json:"spent"`     // Session cost when the threshold was reached (USD)
+}
+
+// NewBudgetWarningEvent creates a BudgetWarningEvent.
+func NewBudgetWarningEvent(threshold, spent float64) BudgetWarningEvent {
+	return BudgetWarningEvent{
+		baseEvent: newBaseEvent("budget.warning"),
+		Threshold: threshold,
+		Spent:     spent,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e BudgetWarningEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *BudgetWarningEvent) UnmarshalJSON(data []byte) error {
+	type payload BudgetWarningEvent
+	return unmarshalEvent(data, "budget.warning", &e.baseEvent, (*payload)(e))
+}
+
+func (e BudgetWarningEvent) payload() any {
+	type payload BudgetWarningEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Bell Events (Terminal Notification)
+// -----------------------------------------------------------------------------
+
+// BellEvent is emitted when a terminal bell is detected in an instance.
+// Used to forward audio notifications from tmux sessions to the parent terminal.
+type BellEvent struct {
+	baseEvent
+	InstanceID string `json:"instance_id"` // Instance that triggered the bell
+}
+
+// NewBellEvent creates a BellEvent.
+func NewBellEvent(instanceID string) BellEvent {
+	return BellEvent{
+		baseEvent:  newBaseEvent("instance.bell"),
+		InstanceID: instanceID,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e BellEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *BellEvent) UnmarshalJSON(data []byte) error {
+	type payload BellEvent
+	return unmarshalEvent(data, "instance.bell", &e.baseEvent, (*payload)(e))
+}
+
+func (e BellEvent) payload() any {
+	type payload BellEvent
+	return payload(e)
+}
+
+// InstanceWaitingInputEvent is emitted when an instance stops to wait for
+// user input: a question, a permission prompt, or an idle prompt.
+type InstanceWaitingInputEvent struct {
+	baseEvent
+	InstanceID string `json:"instance_id"` // Instance waiting for input
+	Task       string `json:"task"`        // Task description or prompt of the instance
+}
+
+// NewInstanceWaitingInputEvent creates an InstanceWaitingInputEvent.
+func NewInstanceWaitingInputEvent(instanceID, task string) InstanceWaitingInputEvent {
+	return InstanceWaitingInputEvent{
+		baseEvent:  newBaseEvent("instance.waiting_input"),
+		InstanceID: instanceID,
+		Task:       task,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e InstanceWaitingInputEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *InstanceWaitingInputEvent) UnmarshalJSON(data []byte) error {
+	type payload InstanceWaitingInputEvent
+	return unmarshalEvent(data, "instance.waiting_input", &e.baseEvent, (*payload)(e))
+}
+
+func (e InstanceWaitingInputEvent) payload() any {
+	type payload InstanceWaitingInputEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// PR Opened Events (Inline PR Detection)
+// -----------------------------------------------------------------------------
+
+// PROpenedEvent is emitted when a PR URL is detected in instance output.
+// This indicates an inline PR was created during task execution (via gh pr create).
+// It is also emitted for each PR an ultra-plan's consolidation opens.
+type PROpenedEvent struct {
+	baseEvent
+	InstanceID string `json:"instance_id"` // Instance that opened the PR
+	PRURL      string `json:"pr_url"`      // URL of the pull request (empty when detected in instance output)
+}
+
+// NewPROpenedEvent creates a PROpenedEvent.
+func NewPROpenedEvent(instanceID, prURL string) PROpenedEvent {
+	return PROpenedEvent{
+		baseEvent:  newBaseEvent("pr.opened"),
+		InstanceID: instanceID,
+		PRURL:      prURL,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e PROpenedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *PROpenedEvent) UnmarshalJSON(data []byte) error {
+	type payload PROpenedEvent
+	return unmarshalEvent(data, "pr.opened", &e.baseEvent, (*payload)(e))
+}
+
+func (e PROpenedEvent) payload() any {
+	type payload PROpenedEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// PR Events
+// -----------------------------------------------------------------------------
+
+// BranchesReapedEvent is emitted when the branches and worktrees of merged
+// work are cleaned up.
+type BranchesReapedEvent struct {
+	baseEvent
+	TargetID  string   `json:"target_id"` // Work that was reaped, e.g. an ultra-plan session ID
+	PRURLs    []string `json:"pr_urls"`   // Merged PRs that carried the work
+	Branches  int      `json:"branches"`  // Local and remote branches deleted
+	Worktrees int      `json:"worktrees"` // Worktrees removed
+	Errors    int      `json:"errors"`    // Operations that failed
+}
+
+// NewBranchesReapedEvent creates a BranchesReapedEvent.
+func NewBranchesReapedEvent(targetID string, prURLs []string, branches, worktrees, errors int) BranchesReapedEvent {
+	return BranchesReapedEvent{
+		baseEvent: newBaseEvent("pr.branches_reaped"),
+		TargetID:  targetID,
+		PRURLs:    prURLs,
+		Branches:  branches,
+		Worktrees: worktrees,
+		Errors:    errors,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e BranchesReapedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *BranchesReapedEvent) UnmarshalJSON(data []byte) error {
+	type payload BranchesReapedEvent
+	return unmarshalEvent(data, "pr.branches_reaped", &e.baseEvent, (*payload)(e))
+}
+
+func (e BranchesReapedEvent) payload() any {
+	type payload BranchesReapedEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Base Drift Events (Ultra-Plan)
+// -----------------------------------------------------------------------------
+
+// BaseDriftEvent is emitted when the base branch of a running plan gains
+// commits after the plan started.
+type BaseDriftEvent struct {
+	baseEvent
+	PlanID        string   `json:"plan_id"`        // Ultra-plan session whose base moved
+	Ref           string   `json:"ref"`            // Base ref that moved, e.g. "origin/main"
+	Commits       int      `json:"commits"`        // Commits on Ref since the plan started
+	Files         int      `json:"files"`          // Files those commits changed
+	AffectedTasks []string `json:"affected_tasks"` // Remaining tasks whose files were changed
+	Replanned     bool     `json:"replanned"`      // Affected tasks will be told what changed when they start
+}
+
+// NewBaseDriftEvent creates a BaseDriftEvent.
+func NewBaseDriftEvent(planID, ref string, commits, files int, affectedTasks []string, replanned bool) BaseDriftEvent {
+	return BaseDriftEvent{
+		baseEvent:     newBaseEvent("plan.base_drift"),
+		PlanID:        planID,
+		Ref:           ref,
+		Commits:       commits,
+		Files:         files,
+		AffectedTasks: affectedTasks,
+		Replanned:     replanned,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e BaseDriftEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *BaseDriftEvent) UnmarshalJSON(data []byte) error {
+	type payload BaseDriftEvent
+	return unmarshalEvent(data, "plan.base_drift", &e.baseEvent, (*payload)(e))
+}
+
+func (e BaseDriftEvent) payload() any {
+	type payload BaseDriftEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Group Phase Events (Group-Aware Lifecycle)
+// -----------------------------------------------------------------------------
+
+// GroupPhaseChangeEvent is emitted when a group's phase changes.
+// This enables TUI reactivity to group state transitions.
+type GroupPhaseChangeEvent struct {
+	baseEvent
+	GroupID       string     `json:"group_id"`       // Unique identifier for the group
+	GroupName     string     `json:"group_name"`     // Human-readable group name
+	PreviousPhase GroupPhase `json:"previous_phase"` // Previous phase
+	CurrentPhase  GroupPhase `json:"current_phase"`  // New current phase
+}
+
+// NewGroupPhaseChangeEvent creates a GroupPhaseChangeEvent.
+func NewGroupPhaseChangeEvent(groupID, groupName string, previousPhase, currentPhase GroupPhase) GroupPhaseChangeEvent {
+	return GroupPhaseChangeEvent{
+		baseEvent:     newBaseEvent("group.phase_changed"),
+		GroupID:       groupID,
+		GroupName:     groupName,
+		PreviousPhase: previousPhase,
+		CurrentPhase:  currentPhase,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e GroupPhaseChangeEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *GroupPhaseChangeEvent) UnmarshalJSON(data []byte) error {
+	type payload GroupPhaseChangeEvent
+	return unmarshalEvent(data, "group.phase_changed", &e.baseEvent, (*payload)(e))
+}
+
+func (e GroupPhaseChangeEvent) payload() any {
+	type payload GroupPhaseChangeEvent
+	return payload(e)
+}
+
+// GroupCompletionEvent is emitted when a group completes (all instances finished).
+type GroupCompletionEvent struct {
+	baseEvent
+	GroupID      string `json:"group_id"`      // Unique identifier for the group
+	GroupName    string `json:"group_name"`    // Human-readable group name
+	Success      bool   `json:"success"`       // True if all instances completed successfully
+	FailedCount  int    `json:"failed_count"`  // Number of instances that failed
+	SuccessCount int    `json:"success_count"` // Number of instances that succeeded
+}
+
+// NewGroupCompletionEvent creates a GroupCompletionEvent.
+func NewGroupCompletionEvent(groupID, groupName string, success bool, failedCount, successCount int) GroupCompletionEvent {
+	return GroupCompletionEvent{
+		baseEvent:    newBaseEvent("group.completed"),
+		GroupID:      groupID,
+		GroupName:    groupName,
+		Success:      success,
+		FailedCount:  failedCount,
+		SuccessCount: successCount,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e GroupCompletionEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *GroupCompletionEvent) UnmarshalJSON(data []byte) error {
+	type payload GroupCompletionEvent
+	return unmarshalEvent(data, "group.completed", &e.baseEvent, (*payload)(e))
+}
+
+func (e GroupCompletionEvent) payload() any {
+	type payload GroupCompletionEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Mailbox Events (Inter-Instance Communication)
+// -----------------------------------------------------------------------------
+
+// MailboxMessageEvent is emitted when an inter-instance mailbox message is sent.
+type MailboxMessageEvent struct {
+	baseEvent
+	From        string `json:"from"`         // Sender instance ID or "coordinator"
+	To          string `json:"to"`           // Recipient instance ID or "broadcast"
+	MessageType string `json:"message_type"` // Message type (discovery, claim, warning, etc.)
+	Body        string `json:"body"`         // Message content
+}
+
+// NewMailboxMessageEvent creates a MailboxMessageEvent.
+func NewMailboxMessageEvent(from, to, messageType, body string) MailboxMessageEvent {
+	return MailboxMessageEvent{
+		baseEvent:   newBaseEvent("mailbox.message"),
+		From:        from,
+		To:          to,
+		MessageType: messageType,
+		Body:        body,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e MailboxMessageEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *MailboxMessageEvent) UnmarshalJSON(data []byte) error {
+	type payload MailboxMessageEvent
+	return unmarshalEvent(data, "mailbox.message", &e.baseEvent, (*payload)(e))
+}
+
+func (e MailboxMessageEvent) payload() any {
+	type payload MailboxMessageEvent
+	return payload(e)
+}
+
+// MailboxMessageFlaggedEvent is emitted when the mailbox guard flags a message
+// body as instruction-like content.
+type MailboxMessageFlaggedEvent struct {
+	baseEvent
+	MessageID   string   `json:"message_id"`   // ID of the flagged message
+	From        string   `json:"from"`         // Sender instance ID or "coordinator"
+	To          string   `json:"to"`           // Recipient instance ID or "broadcast"
+	MessageType string   `json:"message_type"` // Message type (discovery, claim, warning, etc.)
+	Flags       []string `json:"flags"`        // Guard patterns the body matched
+	Held        bool     `json:"held"`         // True if the message awaits review before injection
+	MailboxDir  string   `json:"mailbox_dir"`  // Session directory of the mailbox holding the message
+}
+
+// NewMailboxMessageFlaggedEvent creates a MailboxMessageFlaggedEvent.
+func NewMailboxMessageFlaggedEvent(messageID, from, to, messageType string, flags []string, held bool, mailboxDir string) MailboxMessageFlaggedEvent {
+	return MailboxMessageFlaggedEvent{
+		baseEvent:   newBaseEvent("mailbox.message_flagged"),
+		MessageID:   messageID,
+		From:        from,
+		To:          to,
+		MessageType: messageType,
+		Flags:       flags,
+		Held:        held,
+		MailboxDir:  mailboxDir,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e MailboxMessageFlaggedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *MailboxMessageFlaggedEvent) UnmarshalJSON(data []byte) error {
+	type payload MailboxMessageFlaggedEvent
+	return unmarshalEvent(data, "mailbox.message_flagged", &e.baseEvent, (*payload)(e))
+}
+
+func (e MailboxMessageFlaggedEvent) payload() any {
+	type payload MailboxMessageFlaggedEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Task Queue Events (Dynamic Task Claiming)
+// -----------------------------------------------------------------------------
+
+// TaskClaimedEvent is emitted when an instance claims a task from the queue.
+type TaskClaimedEvent struct {
+	baseEvent
+	TaskID     string `json:"task_id"`     // Task that was claimed
+	InstanceID string `json:"instance_id"` // Instance that claimed it
+}
+
+// NewTaskClaimedEvent creates a TaskClaimedEvent.
+func NewTaskClaimedEvent(taskID, instanceID string) TaskClaimedEvent {
+	return TaskClaimedEvent{
+		baseEvent:  newBaseEvent("queue.task_claimed"),
+		TaskID:     taskID,
+		InstanceID: instanceID,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e TaskClaimedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *TaskClaimedEvent) UnmarshalJSON(data []byte) error {
+	type payload TaskClaimedEvent
+	return unmarshalEvent(data, "queue.task_claimed", &e.baseEvent, (*payload)(e))
+}
+
+func (e TaskClaimedEvent) payload() any {
+	type payload TaskClaimedEvent
+	return payload(e)
+}
+
+// TaskReleasedEvent is emitted when a task is returned to the queue.
+type TaskReleasedEvent struct {
+	baseEvent
+	TaskID string `json:"task_id"` // Task that was released
+	Reason string `json:"reason"`  // Why it was released (e.g., "stale_claim", "instance_died")
+}
+
+// NewTaskReleasedEvent creates a TaskReleasedEvent.
+func NewTaskReleasedEvent(taskID, reason string) TaskReleasedEvent {
+	return TaskReleasedEvent{
+		baseEvent: newBaseEvent("queue.task_released"),
+		TaskID:    taskID,
+		Reason:    reason,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e TaskReleasedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *TaskReleasedEvent) UnmarshalJSON(data []byte) error {
+	type payload TaskReleasedEvent
+	return unmarshalEvent(data, "queue.task_released", &e.baseEvent, (*payload)(e))
+}
+
+func (e TaskReleasedEvent) payload() any {
+	type payload TaskReleasedEvent
+	return payload(e)
+}
+
+// TaskRemovedEvent is emitted when a task is removed from the queue, for
+// example because a plan revision dropped or redefined it.
+type TaskRemovedEvent struct {
+	baseEvent
+	TaskID string `json:"task_id"` // Task that was removed
+}
+
+// NewTaskRemovedEvent creates a TaskRemovedEvent.
+func NewTaskRemovedEvent(taskID string) TaskRemovedEvent {
+	return TaskRemovedEvent{
+		baseEvent: newBaseEvent("queue.task_removed"),
+		TaskID:    taskID,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e TaskRemovedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *TaskRemovedEvent) UnmarshalJSON(data []byte) error {
+	type payload TaskRemovedEvent
+	return unmarshalEvent(data, "queue.task_removed", &e.baseEvent, (*payload)(e))
+}
+
+func (e TaskRemovedEvent) payload() any {
+	type payload TaskRemovedEvent
+	return payload(e)
+}
+
+// QueueDepthChangedEvent is emitted when the queue depth changes.
+// Used by the TUI to display queue progress.
+type QueueDepthChangedEvent struct {
+	baseEvent
+	Pending   int `json:"pending"`   // Number of pending tasks
+	Claimed   int `json:"claimed"`   // Number of claimed tasks
+	Running   int `json:"running"`   // Number of running tasks
+	Completed int `json:"completed"` // Number of completed tasks
+	Failed    int `json:"failed"`    // Number of permanently failed tasks
+	Total     int `json:"total"`     // Total number of tasks
+}
+
+// NewQueueDepthChangedEvent creates a QueueDepthChangedEvent.
+func NewQueueDepthChangedEvent(pending, claimed, running, completed, failed, total int) QueueDepthChangedEvent {
+	return QueueDepthChangedEvent{
+		baseEvent: newBaseEvent("queue.depth_changed"),
+		Pending:   pending,
+		Claimed:   claimed,
+		Running:   running,
+		Completed: completed,
+		Failed:    failed,
+		Total:     total,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e QueueDepthChangedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *QueueDepthChangedEvent) UnmarshalJSON(data []byte) error {
+	type payload QueueDepthChangedEvent
+	return unmarshalEvent(data, "queue.depth_changed", &e.baseEvent, (*payload)(e))
+}
+
+func (e QueueDepthChangedEvent) payload() any {
+	type payload QueueDepthChangedEvent
+	return payload(e)
+}
+
+// TaskAwaitingApprovalEvent is emitted when a task enters the awaiting_approval state.
+// This occurs when a task with RequiresApproval=true is claimed and the gate
+// intercepts the transition to running.
+type TaskAwaitingApprovalEvent struct {
+	baseEvent
+	TaskID     string `json:"task_id"`     // Task that is awaiting approval
+	InstanceID string `json:"instance_id"` // Instance that claimed the task
+}
+
+// NewTaskAwaitingApprovalEvent creates a TaskAwaitingApprovalEvent.
+func NewTaskAwaitingApprovalEvent(taskID, instanceID string) TaskAwaitingApprovalEvent {
+	return TaskAwaitingApprovalEvent{
+		baseEvent:  newBaseEvent("queue.task_awaiting_approval"),
+		TaskID:     taskID,
+		InstanceID: instanceID,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e TaskAwaitingApprovalEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *TaskAwaitingApprovalEvent) UnmarshalJSON(data []byte) error {
+	type payload TaskAwaitingApprovalEvent
+	return unmarshalEvent(data, "queue.task_awaiting_approval", &e.baseEvent, (*payload)(e))
+}
+
+func (e TaskAwaitingApprovalEvent) payload() any {
+	type payload TaskAwaitingApprovalEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Scaling Events
+// -----------------------------------------------------------------------------
+
+// ScalingDecisionEvent is emitted when the scaling monitor makes a scaling decision.
+type ScalingDecisionEvent struct {
+	baseEvent
+	Action           string `json:"action"`            // "scale_up", "scale_down", or "none"
+	Delta            int    `json:"delta"`             // Number of instances to add (positive) or remove (negative)
+	Reason           string `json:"reason"`            // Human-readable explanation of the decision
+	CurrentInstances int    `json:"current_instances"` // Number of instances before the scaling action
+}
+
+// NewScalingDecisionEvent creates a ScalingDecisionEvent.
+func NewScalingDecisionEvent(action string, delta int, reason string, currentInstances int) ScalingDecisionEvent {
+	return ScalingDecisionEvent{
+		baseEvent:        newBaseEvent("scaling.decision"),
+		Action:           action,
+		Delta:            delta,
+		Reason:           reason,
+		CurrentInstances: currentInstances,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e ScalingDecisionEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *ScalingDecisionEvent) UnmarshalJSON(data []byte) error {
+	type payload ScalingDecisionEvent
+	return unmarshalEvent(data, "scaling.decision", &e.baseEvent, (*payload)(e))
+}
+
+func (e ScalingDecisionEvent) payload() any {
+	type payload ScalingDecisionEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Debate Events (Peer Debate Protocol)
+// -----------------------------------------------------------------------------
+
+// DebateStartedEvent is emitted when a structured debate begins between two instances.
+type DebateStartedEvent struct {
+	baseEvent
+	DebateID  string `json:"debate_id"`  // Unique identifier for the debate session
+	InstanceA string `json:"instance_a"` // First participant
+	InstanceB string `json:"instance_b"` // Second participant
+	Topic     string `json:"topic"`      // Subject of the debate
+}
+
+// NewDebateStartedEvent creates a DebateStartedEvent.
+func NewDebateStartedEvent(debateID, instanceA, instanceB, topic string) DebateStartedEvent {
+	return DebateStartedEvent{
+		baseEvent: newBaseEvent("debate.started"),
+		DebateID:  debateID,
+		InstanceA: instanceA,
+		InstanceB: instanceB,
+		Topic:     topic,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e DebateStartedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *DebateStartedEvent) UnmarshalJSON(data []byte) error {
+	type payload DebateStartedEvent
+	return unmarshalEvent(data, "debate.started", &e.baseEvent, (*payload)(e))
+}
+
+func (e DebateStartedEvent) payload() any {
+	type payload DebateStartedEvent
+	return payload(e)
+}
+
+// DebateResolvedEvent is emitted when a debate reaches consensus.
+type DebateResolvedEvent struct {
+	baseEvent
+	DebateID   string `json:"debate_id"`  // Unique identifier for the debate session
+	Resolution string `json:"resolution"` // The consensus resolution
+	Rounds     int    `json:"rounds"`     // Number of challenge-defense rounds
+}
+
+// NewDebateResolvedEvent creates a DebateResolvedEvent.
+func NewDebateResolvedEvent(debateID, resolution string, rounds int) DebateResolvedEvent {
+	return DebateResolvedEvent{
+		baseEvent:  newBaseEvent("debate.resolved"),
+		DebateID:   debateID,
+		Resolution: resolution,
+		Rounds:     rounds,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e DebateResolvedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *DebateResolvedEvent) UnmarshalJSON(data []byte) error {
+	type payload DebateResolvedEvent
+	return unmarshalEvent(data, "debate.resolved", &e.baseEvent, (*payload)(e))
+}
+
+func (e DebateResolvedEvent) payload() any {
+	type payload DebateResolvedEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Context Propagation Events
+// -----------------------------------------------------------------------------
+
+// ContextPropagatedEvent is emitted when context is shared across instances.
+type ContextPropagatedEvent struct {
+	baseEvent
+	From          string `json:"from"`           // Instance that shared the context
+	InstanceCount int    `json:"instance_count"` // Number of instances that received the context
+	MessageType   string `json:"message_type"`   // Type of message propagated (discovery, warning, etc.)
+}
+
+// NewContextPropagatedEvent creates a ContextPropagatedEvent.
+func NewContextPropagatedEvent(from string, instanceCount int, messageType string) ContextPropagatedEvent {
+	return ContextPropagatedEvent{
+		baseEvent:     newBaseEvent("context.propagated"),
+		From:          from,
+		InstanceCount: instanceCount,
+		MessageType:   messageType,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e ContextPropagatedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *ContextPropagatedEvent) UnmarshalJSON(data []byte) error {
+	type payload ContextPropagatedEvent
+	return unmarshalEvent(data, "context.propagated", &e.baseEvent, (*payload)(e))
+}
+
+func (e ContextPropagatedEvent) payload() any {
+	type payload ContextPropagatedEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// File Lock Events (File Conflict Prevention)
+// -----------------------------------------------------------------------------
+
+// FileClaimEvent is emitted when an instance claims ownership of a file.
+type FileClaimEvent struct {
+	baseEvent
+	InstanceID string `json:"instance_id"` // Instance claiming the file
+	FilePath   string `json:"file_path"`   // Path to the claimed file
+}
+
+// NewFileClaimEvent creates a FileClaimEvent.
+func NewFileClaimEvent(instanceID, filePath string) FileClaimEvent {
+	return FileClaimEvent{
+		baseEvent:  newBaseEvent("filelock.claimed"),
+		InstanceID: instanceID,
+		FilePath:   filePath,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e FileClaimEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *FileClaimEvent) UnmarshalJSON(data []byte) error {
+	type payload FileClaimEvent
+	return unmarshalEvent(data, "filelock.claimed", &e.baseEvent, (*payload)(e))
+}
+
+func (e FileClaimEvent) payload() any {
+	type payload FileClaimEvent
+	return payload(e)
+}
+
+// FileReleaseEvent is emitted when an instance releases ownership of a file.
+type FileReleaseEvent struct {
+	baseEvent
+	InstanceID string `json:"instance_id"` // Instance releasing the file
+	FilePath   string `json:"file_path"`   // Path to the released file
+}
+
+// NewFileReleaseEvent creates a FileReleaseEvent.
+func NewFileReleaseEvent(instanceID, filePath string) FileReleaseEvent {
+	return FileReleaseEvent{
+		baseEvent:  newBaseEvent("filelock.released"),
+		InstanceID: instanceID,
+		FilePath:   filePath,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e FileReleaseEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *FileReleaseEvent) UnmarshalJSON(data []byte) error {
+	type payload FileReleaseEvent
+	return unmarshalEvent(data, "filelock.released", &e.baseEvent, (*payload)(e))
+}
+
+func (e FileReleaseEvent) payload() any {
+	type payload FileReleaseEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Adaptive Lead Events (Dynamic Coordination)
+// -----------------------------------------------------------------------------
+
+// ScalingSignalEvent is emitted when the adaptive lead detects a scaling need.
+type ScalingSignalEvent struct {
+	baseEvent
+	Pending        int    `json:"pending"`        // Number of pending tasks
+	Running        int    `json:"running"`        // Number of running tasks
+	Recommendation string `json:"recommendation"` // Human-readable recommendation
+}
+
+// NewScalingSignalEvent creates a ScalingSignalEvent.
+func NewScalingSignalEvent(pending, running int, recommendation string) ScalingSignalEvent {
+	return ScalingSignalEvent{
+		baseEvent:      newBaseEvent("adaptive.scaling_signal"),
+		Pending:        pending,
+		Running:        running,
+		Recommendation: recommendation,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e ScalingSignalEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *ScalingSignalEvent) UnmarshalJSON(data []byte) error {
+	type payload ScalingSignalEvent
+	return unmarshalEvent(data, "adaptive.scaling_signal", &e.baseEvent, (*payload)(e))
+}
+
+func (e ScalingSignalEvent) payload() any {
+	type payload ScalingSignalEvent
+	return payload(e)
+}
+
+// TaskReassignedEvent is emitted when the adaptive lead reassigns a task.
+type TaskReassignedEvent struct {
+	baseEvent
+	TaskID       string `json:"task_id"`       // Task that was reassigned
+	FromInstance string `json:"from_instance"` // Instance the task was taken from
+	ToInstance   string `json:"to_instance"`   // Instance the task was given to
+	Reason       string `json:"reason"`        // Why the reassignment happened
+}
+
+// NewTaskReassignedEvent creates a TaskReassignedEvent.
+func NewTaskReassignedEvent(taskID, fromInstance, toInstance, reason string) TaskReassignedEvent {
+	return TaskReassignedEvent{
+		baseEvent:    newBaseEvent("adaptive.task_reassigned"),
+		TaskID:       taskID,
+		FromInstance: fromInstance,
+		ToInstance:   toInstance,
+		Reason:       reason,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e TaskReassignedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *TaskReassignedEvent) UnmarshalJSON(data []byte) error {
+	type payload TaskReassignedEvent
+	return unmarshalEvent(data, "adaptive.task_reassigned", &e.baseEvent, (*payload)(e))
+}
+
+func (e TaskReassignedEvent) payload() any {
+	type payload TaskReassignedEvent
+	return payload(e)
+}
+
+// TaskPreemptedEvent is emitted when the adaptive lead pauses a running
+// task so a more urgent ready task can have its instance.
+type TaskPreemptedEvent struct {
+	baseEvent
+	TaskID           string `json:"task_id"`            // Task that was paused and returned to the queue
+	InstanceID       string `json:"instance_id"`        // Instance that was running the task
+	PreemptingTaskID string `json:"preempting_task_id"` // Waiting task the instance is freed for
+	Reason           string `json:"reason"`             // Why the task was preempted
+}
+
+// NewTaskPreemptedEvent creates a TaskPreemptedEvent.
+func NewTaskPreemptedEvent(taskID, instanceID, preemptingTaskID, reason string) TaskPreemptedEvent {
+	return TaskPreemptedEvent{
+		baseEvent:        newBaseEvent("adaptive.task_preempted"),
+		TaskID:           taskID,
+		InstanceID:       instanceID,
+		PreemptingTaskID: preemptingTaskID,
+		Reason:           reason,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e TaskPreemptedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *TaskPreemptedEvent) UnmarshalJSON(data []byte) error {
+	type payload TaskPreemptedEvent
+	return unmarshalEvent(data, "adaptive.task_preempted", &e.baseEvent, (*payload)(e))
+}
+
+func (e TaskPreemptedEvent) payload() any {
+	type payload TaskPreemptedEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Team Lifecycle Events (Multi-Team Orchestration)
+// -----------------------------------------------------------------------------
+
+// TeamCreatedEvent is emitted when a new team is added to the manager.
+type TeamCreatedEvent struct {
+	baseEvent
+	TeamID   string `json:"team_id"`   // Unique identifier for the team
+	TeamName string `json:"team_name"` // Human-readable team name
+	TeamRole string `json:"team_role"` // Team's role (execution, planning, review, consolidation)
+}
+
+// NewTeamCreatedEvent creates a TeamCreatedEvent.
+func NewTeamCreatedEvent(teamID, teamName, teamRole string) TeamCreatedEvent {
+	return TeamCreatedEvent{
+		baseEvent: newBaseEvent("team.created"),
+		TeamID:    teamID,
+		TeamName:  teamName,
+		TeamRole:  teamRole,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e TeamCreatedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *TeamCreatedEvent) UnmarshalJSON(data []byte) error {
+	type payload TeamCreatedEvent
+	return unmarshalEvent(data, "team.created", &e.baseEvent, (*payload)(e))
+}
+
+func (e TeamCreatedEvent) payload() any {
+	type payload TeamCreatedEvent
+	return payload(e)
+}
+
+// TeamPhaseChangedEvent is emitted when a team transitions between phases.
+type TeamPhaseChangedEvent struct {
+	baseEvent
+	TeamID        string `json:"team_id"`        // Unique identifier for the team
+	TeamName      string `json:"team_name"`      // Human-readable team name
+	PreviousPhase string `json:"previous_phase"` // Previous phase (e.g., "forming", "blocked")
+	CurrentPhase  string `json:"current_phase"`  // New phase (e.g., "working", "done")
+}
+
+// NewTeamPhaseChangedEvent creates a TeamPhaseChangedEvent.
+func NewTeamPhaseChangedEvent(teamID, teamName, previousPhase, currentPhase string) TeamPhaseChangedEvent {
+	return TeamPhaseChangedEvent{
+		baseEvent:     newBaseEvent("team.phase_changed"),
+		TeamID:        teamID,
+		TeamName:      teamName,
+		PreviousPhase: previousPhase,
+		CurrentPhase:  currentPhase,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e TeamPhaseChangedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *TeamPhaseChangedEvent) UnmarshalJSON(data []byte) error {
+	type payload TeamPhaseChangedEvent
+	return unmarshalEvent(data, "team.phase_changed", &e.baseEvent, (*payload)(e))
+}
+
+func (e TeamPhaseChangedEvent) payload() any {
+	type payload TeamPhaseChangedEvent
+	return payload(e)
+}
+
+// TeamCompletedEvent is emitted when a team finishes all its work.
+type TeamCompletedEvent struct {
+	baseEvent
+	TeamID      string `json:"team_id"`      // Unique identifier for the team
+	TeamName    string `json:"team_name"`    // Human-readable team name
+	Success     bool   `json:"success"`      // True if the team completed without failures
+	TasksDone   int    `json:"tasks_done"`   // Number of tasks completed successfully
+	TasksFailed int    `json:"tasks_failed"` // Number of tasks that failed
+}
+
+// NewTeamCompletedEvent creates a TeamCompletedEvent.
+func NewTeamCompletedEvent(teamID, teamName string, success bool, tasksDone, tasksFailed int) TeamCompletedEvent {
+	return TeamCompletedEvent{
+		baseEvent:   newBaseEvent("team.completed"),
+		TeamID:      teamID,
+		TeamName:    teamName,
+		Success:     success,
+		TasksDone:   tasksDone,
+		TasksFailed: tasksFailed,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e TeamCompletedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *TeamCompletedEvent) UnmarshalJSON(data []byte) error {
+	type payload TeamCompletedEvent
+	return unmarshalEvent(data, "team.completed", &e.baseEvent, (*payload)(e))
+}
+
+func (e TeamCompletedEvent) payload() any {
+	type payload TeamCompletedEvent
+	return payload(e)
+}
+
+// TeamBudgetExhaustedEvent is emitted when a team exhausts its token budget.
+type TeamBudgetExhaustedEvent struct {
+	baseEvent
+	TeamID          string  `json:"team_id"`           // Unique identifier for the team
+	MaxInputTokens  int64   `json:"max_input_tokens"`  // Configured input token limit
+	MaxOutputTokens int64   `json:"max_output_tokens"` // Configured output token limit
+	MaxTotalCost    float64 `json:"max_total_cost"`    // Configured cost limit (USD)
+	UsedInput       int64   `json:"used_input"`        // Actual input tokens consumed
+	UsedOutput      int64   `json:"used_output"`       // Actual output tokens consumed
+	UsedCost        float64 `json:"used_cost"`         // Actual cost consumed (USD)
+}
+
+// NewTeamBudgetExhaustedEvent creates a TeamBudgetExhaustedEvent.
+func NewTeamBudgetExhaustedEvent(teamID string, maxInputTokens, maxOutputTokens, usedInput, usedOutput int64, maxTotalCost, usedCost float64) TeamBudgetExhaustedEvent {
+	return TeamBudgetExhaustedEvent{
+		baseEvent:       newBaseEvent("team.budget_exhausted"),
+		TeamID:          teamID,
+		MaxInputTokens:  maxInputTokens,
+		MaxOutputTokens: maxOutputTokens,
+		MaxTotalCost:    maxTotalCost,
+		UsedInput:       usedInput,
+		UsedOutput:      usedOutput,
+		UsedCost:        usedCost,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e TeamBudgetExhaustedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *TeamBudgetExhaustedEvent) UnmarshalJSON(data []byte) error {
+	type payload TeamBudgetExhaustedEvent
+	return unmarshalEvent(data, "team.budget_exhausted", &e.baseEvent, (*payload)(e))
+}
+
+func (e TeamBudgetExhaustedEvent) payload() any {
+	type payload TeamBudgetExhaustedEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Team Dynamic Management Events
+// -----------------------------------------------------------------------------
+
+// TeamDynamicAddedEvent is emitted when a team is dynamically added to a
+// running manager (after Start).
+type TeamDynamicAddedEvent struct {
+	baseEvent
+	TeamID   string `json:"team_id"`   // Unique identifier for the team
+	TeamName string `json:"team_name"` // Human-readable team name
+	Phase    string `json:"phase"`     // Team's initial phase (working or blocked)
+}
+
+// NewTeamDynamicAddedEvent creates a TeamDynamicAddedEvent.
+func NewTeamDynamicAddedEvent(teamID, teamName, phase string) TeamDynamicAddedEvent {
+	return TeamDynamicAddedEvent{
+		baseEvent: newBaseEvent("team.dynamic_added"),
+		TeamID:    teamID,
+		TeamName:  teamName,
+		Phase:     phase,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e TeamDynamicAddedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *TeamDynamicAddedEvent) UnmarshalJSON(data []byte) error {
+	type payload TeamDynamicAddedEvent
+	return unmarshalEvent(data, "team.dynamic_added", &e.baseEvent, (*payload)(e))
+}
+
+func (e TeamDynamicAddedEvent) payload() any {
+	type payload TeamDynamicAddedEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Pipeline Lifecycle Events
+// -----------------------------------------------------------------------------
+
+// PipelinePhaseChangedEvent is emitted when the pipeline transitions between phases.
+type PipelinePhaseChangedEvent struct {
+	baseEvent
+	PipelineID    string `json:"pipeline_id"`    // Unique identifier for the pipeline
+	PreviousPhase string `json:"previous_phase"` // Previous phase (e.g., "planning", "execution")
+	CurrentPhase  string `json:"current_phase"`  // New phase (e.g., "execution", "review")
+}
+
+// NewPipelinePhaseChangedEvent creates a PipelinePhaseChangedEvent.
+func NewPipelinePhaseChangedEvent(pipelineID, previousPhase, currentPhase string) PipelinePhaseChangedEvent {
+	return PipelinePhaseChangedEvent{
+		baseEvent:     newBaseEvent("pipeline.phase_changed"),
+		PipelineID:    pipelineID,
+		PreviousPhase: previousPhase,
+		CurrentPhase:  currentPhase,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e PipelinePhaseChangedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *PipelinePhaseChangedEvent) UnmarshalJSON(data []byte) error {
+	type payload PipelinePhaseChangedEvent
+	return unmarshalEvent(data, "pipeline.phase_changed", &e.baseEvent, (*payload)(e))
+}
+
+func (e PipelinePhaseChangedEvent) payload() any {
+	type payload PipelinePhaseChangedEvent
+	return payload(e)
+}
+
+// PipelineCompletedEvent is emitted when a pipeline finishes.
+type PipelineCompletedEvent struct {
+	baseEvent
+	PipelineID string `json:"pipeline_id"` // Unique identifier for the pipeline
+	Success    bool   `json:"success"`     // True if the pipeline completed without failures
+	PhasesRun  int    `json:"phases_run"`  // Number of phases that were executed
+}
+
+// NewPipelineCompletedEvent creates a PipelineCompletedEvent.
+func NewPipelineCompletedEvent(pipelineID string, success bool, phasesRun int) PipelineCompletedEvent {
+	return PipelineCompletedEvent{
+		baseEvent:  newBaseEvent("pipeline.completed"),
+		PipelineID: pipelineID,
+		Success:    success,
+		PhasesRun:  phasesRun,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e PipelineCompletedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *PipelineCompletedEvent) UnmarshalJSON(data []byte) error {
+	type payload PipelineCompletedEvent
+	return unmarshalEvent(data, "pipeline.completed", &e.baseEvent, (*payload)(e))
+}
+
+func (e PipelineCompletedEvent) payload() any {
+	type payload PipelineCompletedEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Bridge Events (Pipeline → Instance Execution)
+// -----------------------------------------------------------------------------
+
+// BridgeTaskStartedEvent is emitted when a bridge starts executing a task
+// by spawning a Claude Code instance.
+type BridgeTaskStartedEvent struct {
+	baseEvent
+	TeamID     string `json:"team_id"`     // Team the task belongs to
+	TaskID     string `json:"task_id"`     // Task being executed
+	InstanceID string `json:"instance_id"` // Instance created for the task
+}
+
+// NewBridgeTaskStartedEvent creates a BridgeTaskStartedEvent.
+func NewBridgeTaskStartedEvent(teamID, taskID, instanceID string) BridgeTaskStartedEvent {
+	return BridgeTaskStartedEvent{
+		baseEvent:  newBaseEvent("bridge.task_started"),
+		TeamID:     teamID,
+		TaskID:     taskID,
+		InstanceID: instanceID,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e BridgeTaskStartedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *BridgeTaskStartedEvent) UnmarshalJSON(data []byte) error {
+	type payload BridgeTaskStartedEvent
+	return unmarshalEvent(data, "bridge.task_started", &e.baseEvent, (*payload)(e))
+}
+
+func (e BridgeTaskStartedEvent) payload() any {
+	type payload BridgeTaskStartedEvent
+	return payload(e)
+}
+
+// BridgePlacementFailedEvent is emitted when a bridge cannot place a task's
+// instance on a worker node.
+type BridgePlacementFailedEvent struct {
+	baseEvent
+	TeamID  string `json:"team_id"` // Team the task belongs to
+	TaskID  string `json:"task_id"` // Task that could not be placed
+	Backend string `json:"backend"` // Backend the task requested ("" = session default)
+	Reason  string `json:"reason"`  // Why placement failed
+	Waiting bool   `json:"waiting"` // True if the task waits for capacity; false if it failed
+}
+
+// NewBridgePlacementFailedEvent creates a BridgePlacementFailedEvent.
+func NewBridgePlacementFailedEvent(teamID, taskID, backend, reason string, waiting bool) BridgePlacementFailedEvent {
+	return BridgePlacementFailedEvent{
+		baseEvent: newBaseEvent("bridge.placement_failed"),
+		TeamID:    teamID,
+		TaskID:    taskID,
+		Backend:   backend,
+		Reason:    reason,
+		Waiting:   waiting,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e BridgePlacementFailedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *BridgePlacementFailedEvent) UnmarshalJSON(data []byte) error {
+	type payload BridgePlacementFailedEvent
+	return unmarshalEvent(data, "bridge.placement_failed", &e.baseEvent, (*payload)(e))
+}
+
+func (e BridgePlacementFailedEvent) payload() any {
+	type payload BridgePlacementFailedEvent
+	return payload(e)
+}
+
+// BridgeTaskCompletedEvent is emitted when a bridge-managed task finishes
+// (either successfully or with failure).
+type BridgeTaskCompletedEvent struct {
+	baseEvent
+	TeamID      string `json:"team_id"`      // Team the task belongs to
+	TaskID      string `json:"task_id"`      // Task that completed
+	InstanceID  string `json:"instance_id"`  // Instance that executed the task
+	Success     bool   `json:"success"`      // Whether the task completed successfully
+	CommitCount int    `json:"commit_count"` // Number of commits produced (0 if failed)
+	Error       string `json:"error"`        // Error description (empty on success)
+}
+
+// NewBridgeTaskCompletedEvent creates a BridgeTaskCompletedEvent.
+func NewBridgeTaskCompletedEvent(teamID, taskID, instanceID string, success bool, commitCount int, errMsg string) BridgeTaskCompletedEvent {
+	return BridgeTaskCompletedEvent{
+		baseEvent:   newBaseEvent("bridge.task_completed"),
+		TeamID:      teamID,
+		TaskID:      taskID,
+		InstanceID:  instanceID,
+		Success:     success,
+		CommitCount: commitCount,
+		Error:       errMsg,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e BridgeTaskCompletedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *BridgeTaskCompletedEvent) UnmarshalJSON(data []byte) error {
+	type payload BridgeTaskCompletedEvent
+	return unmarshalEvent(data, "bridge.task_completed", &e.baseEvent, (*payload)(e))
+}
+
+func (e BridgeTaskCompletedEvent) payload() any {
+	type payload BridgeTaskCompletedEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Inter-Team Communication Events
+// -----------------------------------------------------------------------------
+
+// InterTeamMessageEvent is emitted when a message is routed between teams.
+type InterTeamMessageEvent struct {
+	baseEvent
+	FromTeam    string `json:"from_team"`    // Source team ID
+	ToTeam      string `json:"to_team"`      // Destination team ID or "broadcast"
+	MessageType string `json:"message_type"` // Message category (discovery, dependency, warning, request)
+	Content     string `json:"content"`      // Message content
+	Priority    string `json:"priority"`     // Message priority (info, important, urgent)
+}
+
+// NewInterTeamMessageEvent creates an InterTeamMessageEvent.
+func NewInterTeamMessageEvent(fromTeam, toTeam, messageType, content, priority string) InterTeamMessageEvent {
+	return InterTeamMessageEvent{
+		baseEvent:   newBaseEvent("team.message"),
+		FromTeam:    fromTeam,
+		ToTeam:      toTeam,
+		MessageType: messageType,
+		Content:     content,
+		Priority:    priority,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e InterTeamMessageEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *InterTeamMessageEvent) UnmarshalJSON(data []byte) error {
+	type payload InterTeamMessageEvent
+	return unmarshalEvent(data, "team.message", &e.baseEvent, (*payload)(e))
+}
+
+func (e InterTeamMessageEvent) payload() any {
+	type payload InterTeamMessageEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// TripleShot Team Events
+// -----------------------------------------------------------------------------
+
+// TripleShotAttemptCompletedEvent is emitted when a tripleshot attempt finishes
+// (either successfully or with failure) in the team-based coordinator.
+type TripleShotAttemptCompletedEvent struct {
+	baseEvent
+	AttemptIndex int    `json:"attempt_index"` // 0, 1, or 2
+	TeamID       string `json:"team_id"`       // Team that ran this attempt
+	Success      bool   `json:"success"`       // Whether the attempt completed successfully
+}
+
+// NewTripleShotAttemptCompletedEvent creates a TripleShotAttemptCompletedEvent.
+func NewTripleShotAttemptCompletedEvent(attemptIndex int, teamID string, success bool) TripleShotAttemptCompletedEvent {
+	return TripleShotAttemptCompletedEvent{
+		baseEvent:    newBaseEvent("tripleshot.attempt_completed"),
+		AttemptIndex: attemptIndex,
+		TeamID:       teamID,
+		Success:      success,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e TripleShotAttemptCompletedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *TripleShotAttemptCompletedEvent) UnmarshalJSON(data []byte) error {
+	type payload TripleShotAttemptCompletedEvent
+	return unmarshalEvent(data, "tripleshot.attempt_completed", &e.baseEvent, (*payload)(e))
+}
+
+func (e TripleShotAttemptCompletedEvent) payload() any {
+	type payload TripleShotAttemptCompletedEvent
+	return payload(e)
+}
+
+// TripleShotJudgeCompletedEvent is emitted when the tripleshot judge finishes
+// its evaluation in the team-based coordinator.
+type TripleShotJudgeCompletedEvent struct {
+	baseEvent
+	TeamID  string `json:"team_id"` // Team that ran the judge
+	Success bool   `json:"success"` // Whether the evaluation completed successfully
+}
+
+// NewTripleShotJudgeCompletedEvent creates a TripleShotJudgeCompletedEvent.
+func NewTripleShotJudgeCompletedEvent(teamID string, success bool) TripleShotJudgeCompletedEvent {
+	return TripleShotJudgeCompletedEvent{
+		baseEvent: newBaseEvent("tripleshot.judge_completed"),
+		TeamID:    teamID,
+		Success:   success,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e TripleShotJudgeCompletedEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *TripleShotJudgeCompletedEvent) UnmarshalJSON(data []byte) error {
+	type payload TripleShotJudgeCompletedEvent
+	return unmarshalEvent(data, "tripleshot.judge_completed", &e.baseEvent, (*payload)(e))
+}
+
+func (e TripleShotJudgeCompletedEvent) payload() any {
+	type payload TripleShotJudgeCompletedEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Team Scaling Events
+// -----------------------------------------------------------------------------
+
+// TeamScaledEvent is emitted when a team's instance count changes due to
+// a scaling decision from the scaling monitor.
+type TeamScaledEvent struct {
+	baseEvent
+	TeamID        string `json:"team_id"`        // Team whose concurrency changed
+	PrevInstances int    `json:"prev_instances"` // Instance count before scaling
+	NewInstances  int    `json:"new_instances"`  // Instance count after scaling
+	Reason        string `json:"reason"`         // Human-readable reason for the scaling decision
+}
+
+// NewTeamScaledEvent creates a TeamScaledEvent.
+func NewTeamScaledEvent(teamID string, prevInstances, newInstances int, reason string) TeamScaledEvent {
+	return TeamScaledEvent{
+		baseEvent:     newBaseEvent("team.scaled"),
+		TeamID:        teamID,
+		PrevInstances: prevInstances,
+		NewInstances:  newInstances,
+		Reason:        reason,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e TeamScaledEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *TeamScaledEvent) UnmarshalJSON(data []byte) error {
+	type payload TeamScaledEvent
+	return unmarshalEvent(data, "team.scaled", &e.baseEvent, (*payload)(e))
+}
+
+func (e TeamScaledEvent) payload() any {
+	type payload TeamScaledEvent
+	return payload(e)
+}
+
+// -----------------------------------------------------------------------------
+// Audit Events
+// -----------------------------------------------------------------------------
+
+// OperatorActionEvent is emitted when a human intervenes in a session, or a
+// policy approves something on their behalf. The session's audit log records
+// each one.
+type OperatorActionEvent struct {
+	baseEvent
+	Action     string `json:"action"`      // Kind of intervention (see audit.Action)
+	InstanceID string `json:"instance_id"` // Affected instance, if any
+	TaskID     string `json:"task_id"`     // Affected plan task, if any
+	Detail     string `json:"detail"`      // What was done, e.g. the command or text sent
+}
+
+// NewOperatorActionEvent creates an OperatorActionEvent.
+func NewOperatorActionEvent(action, instanceID, taskID, detail string) OperatorActionEvent {
+	return OperatorActionEvent{
+		baseEvent:  newBaseEvent("operator.action"),
+		Action:     action,
+		InstanceID: instanceID,
+		TaskID:     taskID,
+		Detail:     detail,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e OperatorActionEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *OperatorActionEvent) UnmarshalJSON(data []byte) error {
+	type payload OperatorActionEvent
+	return unmarshalEvent(data, "operator.action", &e.baseEvent, (*payload)(e))
+}
+
+func (e OperatorActionEvent) payload() any {
+	type payload OperatorActionEvent
+	return payload(e)
+}
+
+// OrchestratorDecisionEvent is emitted when the orchestrator makes a
+// consequential decision that no other event records, such as starting or
+// retrying a planned task or resolving a merge conflict. The session's audit
+// log records each one.
+type OrchestratorDecisionEvent struct {
+	baseEvent
+	Decision   string            `json:"decision"`    // Kind of decision (see audit.Action)
+	InstanceID string            `json:"instance_id"` // Affected instance, if any
+	TaskID     string            `json:"task_id"`     // Affected plan task, if any
+	Reason     string            `json:"reason"`      // Why the decision was made
+	Inputs     map[string]string `json:"inputs"`      // What the decision was based on, e.g. attempt counts
+}
+
+// NewOrchestratorDecisionEvent creates an OrchestratorDecisionEvent.
+func NewOrchestratorDecisionEvent(decision, instanceID, taskID, reason string, inputs map[string]string) OrchestratorDecisionEvent {
+	return OrchestratorDecisionEvent{
+		baseEvent:  newBaseEvent("orchestrator.decision"),
+		Decision:   decision,
+		InstanceID: instanceID,
+		TaskID:     taskID,
+		Reason:     reason,
+		Inputs:     inputs,
+	}
+}
+
+// MarshalJSON encodes e as an [Envelope] at [SchemaVersion].
+func (e OrchestratorDecisionEvent) MarshalJSON() ([]byte, error) {
+	return marshalEvent(e, SchemaVersion)
+}
+
+// UnmarshalJSON decodes e from an [Envelope].
+func (e *OrchestratorDecisionEvent) UnmarshalJSON(data []byte) error {
+	type payload OrchestratorDecisionEvent
+	return unmarshalEvent(data, "orchestrator.decision", &e.baseEvent, (*payload)(e))
+}
+
+func (e OrchestratorDecisionEvent) payload() any {
+	type payload OrchestratorDecisionEvent
+	return payload(e)
+}
+
+// registry holds every event type in the schema.
+var registry = map[string]schemaEntry{
+	"instance.started":             {since: 1, decode: decode[InstanceStartedEvent]},
+	"instance.stopped":             {since: 1, decode: decode[InstanceStoppedEvent]},
+	"pr.completed":                 {since: 1, decode: decode[PRCompleteEvent]},
+	"instance.timeout":             {since: 1, decode: decode[TimeoutEvent]},
+	"instance.nudged":              {since: 1, decode: decode[InstanceNudgedEvent]},
+	"task.completed":               {since: 1, decode: decode[TaskCompletedEvent]},
+	"task.added":                   {since: 1, decode: decode[TaskAddedEvent]},
+	"plan.revised":                 {since: 1, decode: decode[PlanRevisedEvent]},
+	"task.proposed":                {since: 1, decode: decode[TaskProposedEvent]},
+	"phase.changed":                {since: 1, decode: decode[PhaseChangeEvent]},
+	"metrics.updated":              {since: 1, decode: decode[MetricsUpdateEvent]},
+	"budget.exceeded":              {since: 1, decode: decode[BudgetExceededEvent]},
+	"budget.warning":               {since: 1, decode: decode[BudgetWarningEvent]},
+	"instance.bell":                {since: 1, decode: decode[BellEvent]},
+	"instance.waiting_input":       {since: 1, decode: decode[InstanceWaitingInputEvent]},
+	"pr.opened":                    {since: 1, decode: decode[PROpenedEvent]},
+	"pr.branches_reaped":           {since: 1, decode: decode[BranchesReapedEvent]},
+	"plan.base_drift":              {since: 1, decode: decode[BaseDriftEvent]},
+	"group.phase_changed":          {since: 1, decode: decode[GroupPhaseChangeEvent]},
+	"group.completed":              {since: 1, decode: decode[GroupCompletionEvent]},
+	"mailbox.message":              {since: 1, decode: decode[MailboxMessageEvent]},
+	"mailbox.message_flagged":      {since: 1, decode: decode[MailboxMessageFlaggedEvent]},
+	"queue.task_claimed":           {since: 1, decode: decode[TaskClaimedEvent]},
+	"queue.task_released":          {since: 1, decode: decode[TaskReleasedEvent]},
+	"queue.task_removed":           {since: 1, decode: decode[TaskRemovedEvent]},
+	"queue.depth_changed":          {since: 1, decode: decode[QueueDepthChangedEvent]},
+	"queue.task_awaiting_approval": {since: 1, decode: decode[TaskAwaitingApprovalEvent]},
+	"scaling.decision":             {since: 1, decode: decode[ScalingDecisionEvent]},
+	"debate.started":               {since: 1, decode: decode[DebateStartedEvent]},
+	"debate.resolved":              {since: 1, decode: decode[DebateResolvedEvent]},
+	"context.propagated":           {since: 1, decode: decode[ContextPropagatedEvent]},
+	"filelock.claimed":             {since: 1, decode: decode[FileClaimEvent]},
+	"filelock.released":            {since: 1, decode: decode[FileReleaseEvent]},
+	"adaptive.scaling_signal":      {since: 1, decode: decode[ScalingSignalEvent]},
+	"adaptive.task_reassigned":     {since: 1, decode: decode[TaskReassignedEvent]},
+	"adaptive.task_preempted":      {since: 1, decode: decode[TaskPreemptedEvent]},
+	"team.created":                 {since: 1, decode: decode[TeamCreatedEvent]},
+	"team.phase_changed":           {since: 1, decode: decode[TeamPhaseChangedEvent]},
+	"team.completed":               {since: 1, decode: decode[TeamCompletedEvent]},
+	"team.budget_exhausted":        {since: 1, decode: decode[TeamBudgetExhaustedEvent]},
+	"team.dynamic_added":           {since: 1, decode: decode[TeamDynamicAddedEvent]},
+	"pipeline.phase_changed":       {since: 1, decode: decode[PipelinePhaseChangedEvent]},
+	"pipeline.completed":           {since: 1, decode: decode[PipelineCompletedEvent]},
+	"bridge.task_started":          {since: 1, decode: decode[BridgeTaskStartedEvent]},
+	"bridge.placement_failed":      {since: 1, decode: decode[BridgePlacementFailedEvent]},
+	"bridge.task_completed":        {since: 1, decode: decode[BridgeTaskCompletedEvent]},
+	"team.message":                 {since: 1, decode: decode[InterTeamMessageEvent]},
+	"tripleshot.attempt_completed": {since: 1, decode: decode[TripleShotAttemptCompletedEvent]},
+	"tripleshot.judge_completed":   {since: 1, decode: decode[TripleShotJudgeCompletedEvent]},
+	"team.scaled":                  {since: 1, decode: decode[TeamScaledEvent]},
+	"operator.action":              {since: 1, decode: decode[OperatorActionEvent]},
+	"orchestrator.decision":        {since: 1, decode: decode[OrchestratorDecisionEvent]},
+}
+
+// Compile-time checks that every event can be encoded.
+var (
+	_ wireEvent = InstanceStartedEvent{}
+	_ wireEvent = InstanceStoppedEvent{}
+	_ wireEvent = PRCompleteEvent{}
+	_ wireEvent = TimeoutEvent{}
+	_ wireEvent = InstanceNudgedEvent{}
+	_ wireEvent = TaskCompletedEvent{}
+	_ wireEvent = TaskAddedEvent{}
+	_ wireEvent = PlanRevisedEvent{}
+	_ wireEvent = TaskProposedEvent{}
+	_ wireEvent = PhaseChangeEvent{}
+	_ wireEvent = MetricsUpdateEvent{}
+	_ wireEvent = BudgetExceededEvent{}
+	_ wireEvent = BudgetWarningEvent{}
+	_ wireEvent = BellEvent{}
+	_ wireEvent = InstanceWaitingInputEvent{}
+	_ wireEvent = PROpenedEvent{}
+	_ wireEvent = BranchesReapedEvent{}
+	_ wireEvent = BaseDriftEvent{}
+	_ wireEvent = GroupPhaseChangeEvent{}
+	_ wireEvent = GroupCompletionEvent{}
+	_ wireEvent = MailboxMessageEvent{}
+	_ wireEvent = MailboxMessageFlaggedEvent{}
+	_ wireEvent = TaskClaimedEvent{}
+	_ wireEvent = TaskReleasedEvent{}
+	_ wireEvent = TaskRemovedEvent{}
+	_ wireEvent = QueueDepthChangedEvent{}
+	_ wireEvent = TaskAwaitingApprovalEvent{}
+	_ wireEvent = ScalingDecisionEvent{}
+	_ wireEvent = DebateStartedEvent{}
+	_ wireEvent = DebateResolvedEvent{}
+	_ wireEvent = ContextPropagatedEvent{}
+	_ wireEvent = FileClaimEvent{}
+	_ wireEvent = FileReleaseEvent{}
+	_ wireEvent = ScalingSignalEvent{}
+	_ wireEvent = TaskReassignedEvent{}
+	_ wireEvent = TaskPreemptedEvent{}
+	_ wireEvent = TeamCreatedEvent{}
+	_ wireEvent = TeamPhaseChangedEvent{}
+	_ wireEvent = TeamCompletedEvent{}
+	_ wireEvent = TeamBudgetExhaustedEvent{}
+	_ wireEvent = TeamDynamicAddedEvent{}
+	_ wireEvent = PipelinePhaseChangedEvent{}
+	_ wireEvent = PipelineCompletedEvent{}
+	_ wireEvent = BridgeTaskStartedEvent{}
+	_ wireEvent = BridgePlacementFailedEvent{}
+	_ wireEvent = BridgeTaskCompletedEvent{}
+	_ wireEvent = InterTeamMessageEvent{}
+	_ wireEvent = TripleShotAttemptCompletedEvent{}
+	_ wireEvent = TripleShotJudgeCompletedEvent{}
+	_ wireEvent = TeamScaledEvent{}
+	_ wireEvent = OperatorActionEvent{}
+	_ wireEvent = OrchestratorDecisionEvent{}
+)
@@ -0,0 +1,283 @@
+package event
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+)
+
+// SchemaName identifies Claudio's event schema in stream headers.
+const SchemaName = "claudio.event"
+
+var (
+	// ErrUnsupportedVersion is returned for data written at a schema version
+	// this build cannot read or write.
+	ErrUnsupportedVersion = errors.New("unsupported event schema version")
+
+	// ErrUnknownEventType is returned for events that are not in the schema,
+	// such as events added by a newer build at the same schema version.
+	ErrUnknownEventType = errors.New("unknown event type")
+
+	// ErrNotInVersion is returned when encoding an event at a schema version
+	// older than the one that introduced it. Readers at that version would
+	// not know it, so writers skip it.
+	ErrNotInVersion = errors.New("event not in schema version")
+)
+
+// Envelope is the wire format of an event. Data holds the event's fields
+// under the JSON names declared in schema.yaml.
+type Envelope struct {
+	Type      string          `json:"type"`
+	Version   int             `json:"version"`
+	Timestamp time.Time       `json:"timestamp"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// wireEvent is implemented by every generated event type.
+type wireEvent interface {
+	Event
+	json.Marshaler
+
+	// payload returns the event's fields as a value whose JSON encoding is
+	// the envelope's Data.
+	payload() any
+}
+
+// schemaEntry is an event type's registry entry.
+type schemaEntry struct {
+	since  int                         // Schema version that introduced the event
+	decode func([]byte) (Event, error) // Decodes an envelope of the event type
+}
+
+// Types returns every event type in the schema, sorted.
+func Types() []string {
+	types := make([]string, 0, len(registry))
+	for t := range registry {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+	return types
+}
+
+// Marshal encodes e as an Envelope at the given schema version, for writing
+// to a peer that negotiated an older version than SchemaVersion.
+func Marshal(e Event, version int) ([]byte, error) {
+	we, ok := e.(wireEvent)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnknownEventType, e)
+	}
+	return marshalEvent(we, version)
+}
+
+// Decode decodes an Envelope into the event type it names. The result is
+// the event value, e.g. InstanceStartedEvent, just as published on a Bus.
+func Decode(data []byte) (Event, error) {
+	var env Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("decode event envelope: %w", err)
+	}
+	entry, ok := registry[env.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEventType, env.Type)
+	}
+	return entry.decode(data)
+}
+
+func marshalEvent(e wireEvent, version int) ([]byte, error) {
+	if err := checkVersion(version); err != nil {
+		return nil, err
+	}
+	entry, ok := registry[e.EventType()]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEventType, e.EventType())
+	}
+	if entry.since > version {
+		return nil, fmt.Errorf("%w: %s was added in version %d, encoding at %d", ErrNotInVersion, e.EventType(), entry.since, version)
+	}
+	data, err := json.Marshal(e.payload())
+	if err != nil {
+		return nil, fmt.Errorf("encode %s: %w", e.EventType(), err)
+	}
+	return json.Marshal(Envelope{
+		Type:      e.EventType(),
+		Version:   version,
+		Timestamp: e.Timestamp(),
+		Data:      data,
+	})
+}
+
+func unmarshalEvent(data []byte, eventType string, base *baseEvent, payload any) error {
+	var env Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return fmt.Errorf("decode event envelope: %w", err)
+	}
+	if env.Type != eventType {
+		return fmt.Errorf("decode %s: envelope holds %q", eventType, env.Type)
+	}
+	if err := checkVersion(env.Version); err != nil {
+		return err
+	}
+	if len(env.Data) > 0 {
+		if err := json.Unmarshal(env.Data, payload); err != nil {
+			return fmt.Errorf("decode %s: %w", eventType, err)
+		}
+	}
+	*base = baseEvent{eventType: env.Type, timestamp: env.Timestamp}
+	return nil
+}
+
+// decode is a schemaEntry decoder for event type T.
+func decode[T any, P interface {
+	*T
+	json.Unmarshaler
+}](data []byte) (Event, error) {
+	var e T
+	if err := P(&e).UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return any(e).(Event), nil
+}
+
+func checkVersion(version int) error {
+	if version < MinSchemaVersion || version > SchemaVersion {
+		return fmt.Errorf("%w: %d (supported: %d to %d)", ErrUnsupportedVersion, version, MinSchemaVersion, SchemaVersion)
+	}
+	return nil
+}
+
+// Header opens an event stream. A writer sends it before the first event;
+// peers exchanging events over a connection send it to each other first and
+// Negotiate the version to use.
+type Header struct {
+	Schema     string `json:"schema"`      // Always SchemaName
+	Version    int    `json:"version"`     // Newest schema version the sender supports
+	MinVersion int    `json:"min_version"` // Oldest schema version the sender supports
+}
+
+// LocalHeader returns the Header describing the versions this build
+// supports.
+func LocalHeader() Header {
+	return Header{Schema: SchemaName, Version: SchemaVersion, MinVersion: MinSchemaVersion}
+}
+
+// Negotiate returns the newest schema version supported both by this build
+// and by the peer that sent h. It returns an error wrapping
+// ErrUnsupportedVersion when the two have no version in common.
+func Negotiate(h Header) (int, error) {
+	if h.Schema != SchemaName {
+		return 0, fmt.Errorf("not an event stream: schema %q", h.Schema)
+	}
+	peerMin := h.MinVersion
+	if peerMin == 0 {
+		peerMin = h.Version
+	}
+	version := min(h.Version, SchemaVersion)
+	if version < max(peerMin, MinSchemaVersion) {
+		return 0, fmt.Errorf("%w: peer supports %d to %d, this build %d to %d",
+			ErrUnsupportedVersion, peerMin, h.Version, MinSchemaVersion, SchemaVersion)
+	}
+	return version, nil
+}
+
+// Encoder writes events as JSON lines: a Header, then one Envelope per
+// event.
+type Encoder struct {
+	w       io.Writer
+	version int
+}
+
+// NewEncoder negotiates a version with peer, the Header received from the
+// reader (LocalHeader for a file this build will read back), and writes the
+// stream's Header to w.
+func NewEncoder(w io.Writer, peer Header) (*Encoder, error) {
+	version, err := Negotiate(peer)
+	if err != nil {
+		return nil, err
+	}
+	enc := &Encoder{w: w, version: version}
+	if err := enc.writeLine(Header{Schema: SchemaName, Version: version, MinVersion: version}); err != nil {
+		return nil, err
+	}
+	return enc, nil
+}
+
+// Version returns the schema version the stream is written at.
+func (enc *Encoder) Version() int {
+	return enc.version
+}
+
+// Encode writes e. It returns an error wrapping ErrNotInVersion, and writes
+// nothing, for events newer than the stream's version.
+func (enc *Encoder) Encode(e Event) error {
+	data, err := Marshal(e, enc.version)
+	if err != nil {
+		return err
+	}
+	return enc.writeLine(json.RawMessage(data))
+}
+
+func (enc *Encoder) writeLine(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = enc.w.Write(append(data, '\n'))
+	return err
+}
+
+// Decoder reads the events written by an Encoder.
+type Decoder struct {
+	scanner *bufio.Scanner
+	version int
+}
+
+// NewDecoder reads the stream's Header from r and checks that this build
+// can read its version.
+func NewDecoder(r io.Reader) (*Decoder, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("read event stream header: %w", err)
+		}
+		return nil, fmt.Errorf("read event stream header: %w", io.ErrUnexpectedEOF)
+	}
+	var h Header
+	if err := json.Unmarshal(scanner.Bytes(), &h); err != nil {
+		return nil, fmt.Errorf("decode event stream header: %w", err)
+	}
+	version, err := Negotiate(h)
+	if err != nil {
+		return nil, err
+	}
+	if version != h.Version {
+		return nil, fmt.Errorf("%w: stream written at %d", ErrUnsupportedVersion, h.Version)
+	}
+	return &Decoder{scanner: scanner, version: version}, nil
+}
+
+// Version returns the schema version the stream was written at.
+func (dec *Decoder) Version() int {
+	return dec.version
+}
+
+// Decode returns the next event, or io.EOF at the end of the stream. An
+// error wrapping ErrUnknownEventType leaves the stream readable, so callers
+// may skip events they don't know.
+func (dec *Decoder) Decode() (Event, error) {
+	for dec.scanner.Scan() {
+		line := dec.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		return Decode(line)
+	}
+	if err := dec.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read event stream: %w", err)
+	}
+	return nil, io.EOF
+}
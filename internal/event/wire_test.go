@@ -0,0 +1,207 @@
+package event
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarshal_RoundTrip(t *testing.T) {
+	events := []Event{
+		NewInstanceStartedEvent("inst-1", "/wt", "feature", "do things"),
+		NewTimeoutEvent("inst-1", TimeoutStale, "5m"),
+		NewPhaseChangeEvent("plan-1", PhasePlanning, PhaseExecuting),
+		NewMetricsUpdateEvent("inst-1", 10, 20, 3, 4, 0.25, 7),
+		NewBaseDriftEvent("plan-1", "origin/main", 2, 3, []string{"task-1"}, true),
+		NewTeamBudgetExhaustedEvent("team-1", 100, 200, 150, 250, 1.5, 2.5),
+	}
+	for _, want := range events {
+		t.Run(want.EventType(), func(t *testing.T) {
+			data, err := json.Marshal(want)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			got, err := Decode(data)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if reflect.TypeOf(got) != reflect.TypeOf(want) {
+				t.Fatalf("Decode() type = %T, want %T", got, want)
+			}
+			again, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("Marshal() of decoded event error = %v", err)
+			}
+			if !bytes.Equal(again, data) {
+				t.Errorf("round trip =\n%s\nwant\n%s", again, data)
+			}
+		})
+	}
+}
+
+func TestMarshal_WireFormat(t *testing.T) {
+	e := NewTimeoutEvent("inst-1", TimeoutCompletion, "1h")
+	e.timestamp = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"instance.timeout","version":1,"timestamp":"2026-01-02T03:04:05Z",` +
+		`"data":{"instance_id":"inst-1","timeout_type":"completion","duration":"1h"}}`
+	if string(data) != want {
+		t.Errorf("Marshal() =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestDecode_EveryType(t *testing.T) {
+	types := Types()
+	if len(types) != len(registry) {
+		t.Fatalf("Types() = %d types, registry has %d", len(types), len(registry))
+	}
+	for _, typ := range types {
+		e, err := Decode([]byte(`{"type":"` + typ + `","version":1,"data":{}}`))
+		if err != nil {
+			t.Errorf("Decode(%s) error = %v", typ, err)
+			continue
+		}
+		if e.EventType() != typ {
+			t.Errorf("Decode(%s).EventType() = %q", typ, e.EventType())
+		}
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{"unknown type", `{"type":"nope.nothing","version":1,"data":{}}`, ErrUnknownEventType},
+		{"newer version", `{"type":"instance.bell","version":99,"data":{}}`, ErrUnsupportedVersion},
+		{"missing version", `{"type":"instance.bell","data":{}}`, ErrUnsupportedVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode([]byte(tt.data)); !errors.Is(err, tt.want) {
+				t.Errorf("Decode() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	var bell BellEvent
+	if err := json.Unmarshal([]byte(`{"type":"pr.opened","version":1,"data":{}}`), &bell); err == nil {
+		t.Error("Unmarshal() of another event type should fail")
+	}
+}
+
+func TestMarshal_NotInVersion(t *testing.T) {
+	entry := registry["instance.bell"]
+	entry.since = SchemaVersion + 1
+	registry["instance.bell"] = entry
+	defer func() {
+		entry.since = 1
+		registry["instance.bell"] = entry
+	}()
+
+	if _, err := Marshal(NewBellEvent("inst-1"), SchemaVersion); !errors.Is(err, ErrNotInVersion) {
+		t.Errorf("Marshal() error = %v, want ErrNotInVersion", err)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name    string
+		peer    Header
+		want    int
+		wantErr bool
+	}{
+		{"same build", LocalHeader(), SchemaVersion, false},
+		{"newer peer that still reads ours", Header{Schema: SchemaName, Version: SchemaVersion + 1, MinVersion: SchemaVersion}, SchemaVersion, false},
+		{"newer peer that dropped ours", Header{Schema: SchemaName, Version: SchemaVersion + 2, MinVersion: SchemaVersion + 1}, 0, true},
+		{"fixed version stream", Header{Schema: SchemaName, Version: SchemaVersion}, SchemaVersion, false},
+		{"other schema", Header{Schema: "other", Version: SchemaVersion}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Negotiate(tt.peer)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Negotiate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Negotiate() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncoderDecoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := NewEncoder(&buf, LocalHeader())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sent := []Event{
+		NewInstanceStartedEvent("inst-1", "/wt", "feature", "task"),
+		NewOperatorActionEvent("input", "inst-1", "", "ls"),
+	}
+	for _, e := range sent {
+		if err := enc.Encode(e); err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+	}
+	// An event from a newer build at the same version is skippable
+	buf.WriteString(`{"type":"future.event","version":1,"data":{}}` + "\n")
+
+	dec, err := NewDecoder(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dec.Version() != SchemaVersion {
+		t.Errorf("Version() = %d, want %d", dec.Version(), SchemaVersion)
+	}
+	for _, want := range sent {
+		got, err := dec.Decode()
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if got.EventType() != want.EventType() {
+			t.Errorf("Decode() = %s, want %s", got.EventType(), want.EventType())
+		}
+	}
+	if _, err := dec.Decode(); !errors.Is(err, ErrUnknownEventType) {
+		t.Errorf("Decode() of unknown event error = %v, want ErrUnknownEventType", err)
+	}
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Errorf("Decode() at end error = %v, want io.EOF", err)
+	}
+}
+
+func TestNewDecoder_UnsupportedVersion(t *testing.T) {
+	stream := `{"schema":"claudio.event","version":99,"min_version":99}` + "\n"
+	if _, err := NewDecoder(strings.NewReader(stream)); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("NewDecoder() error = %v, want ErrUnsupportedVersion", err)
+	}
+	if _, err := NewDecoder(strings.NewReader("")); err == nil {
+		t.Error("NewDecoder() of empty stream should fail")
+	}
+}
+
+func TestTimeoutType_Text(t *testing.T) {
+	for _, tt := range []TimeoutType{TimeoutActivity, TimeoutCompletion, TimeoutStale} {
+		text, _ := tt.MarshalText()
+		var got TimeoutType
+		if err := got.UnmarshalText(text); err != nil || got != tt {
+			t.Errorf("UnmarshalText(%q) = %v, %v; want %v", text, got, err, tt)
+		}
+	}
+	var got TimeoutType
+	if err := got.UnmarshalText([]byte("bogus")); err == nil {
+		t.Error("UnmarshalText(bogus) should fail")
+	}
+}
@@ -0,0 +1,33 @@
+// Package eventserver streams event bus events to external dashboards over
+// HTTP as Server-Sent Events.
+//
+// When event_server.enabled is set, the orchestrator starts a [Server] for
+// each session. Every published event is encoded as an [event.Envelope] and
+// sent with a per-server sequence number as its SSE id. The most recent
+// events are kept in a replay buffer, so a dashboard that reconnects with
+// Last-Event-ID (as browsers' EventSource does automatically) receives the
+// events it missed.
+//
+//	GET /events?types=instance.*,pr.opened&version=1&replay=50
+//	GET /types
+//
+// # Main Types
+//
+//   - [Server]: Subscribes to a Bus and serves the event stream
+//   - [Filter]: Event type selection parsed from the types parameter
+//
+// # Usage
+//
+//	srv := eventserver.New(bus, eventserver.WithReplaySize(256), eventserver.WithLogger(logger))
+//	if err := srv.Start("127.0.0.1:7878"); err != nil {
+//		return err
+//	}
+//	defer srv.Stop(ctx)
+//
+// From a shell:
+//
+//	curl -N 'http://127.0.0.1:7878/events?types=instance.*'
+//
+// The server is read-only and has no authentication; it listens on the
+// loopback interface by default.
+package eventserver
@@ -0,0 +1,48 @@
+package eventserver
+
+import "strings"
+
+// Filter selects event types. The zero Filter matches every type.
+type Filter struct {
+	types    map[string]bool
+	prefixes []string // From "category.*" patterns, kept with the dot
+}
+
+// ParseFilter parses a comma-separated list of event types, where
+// "instance.*" matches every type in the instance category and "*" matches
+// everything. An empty list matches everything.
+func ParseFilter(spec string) Filter {
+	var f Filter
+	for _, t := range strings.Split(spec, ",") {
+		t = strings.TrimSpace(t)
+		switch {
+		case t == "":
+		case t == "*":
+			return Filter{}
+		case strings.HasSuffix(t, ".*"):
+			f.prefixes = append(f.prefixes, strings.TrimSuffix(t, "*"))
+		default:
+			if f.types == nil {
+				f.types = make(map[string]bool)
+			}
+			f.types[t] = true
+		}
+	}
+	return f
+}
+
+// Match reports whether the filter accepts eventType.
+func (f Filter) Match(eventType string) bool {
+	if f.types == nil && f.prefixes == nil {
+		return true
+	}
+	if f.types[eventType] {
+		return true
+	}
+	for _, p := range f.prefixes {
+		if strings.HasPrefix(eventType, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,430 @@
+package eventserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/logging"
+)
+
+const (
+	// DefaultReplaySize is how many recent events a Server keeps by default.
+	DefaultReplaySize = 256
+
+	// clientBuffer is how many events may queue for one client. A client
+	// that falls further behind is disconnected; it reconnects with
+	// Last-Event-ID and catches up from the replay buffer.
+	clientBuffer = 256
+
+	// keepAliveInterval is how often an idle stream sends a comment so
+	// proxies do not close it.
+	keepAliveInterval = 15 * time.Second
+
+	// retryMillis is the reconnect delay suggested to EventSource clients.
+	retryMillis = 2000
+)
+
+// record is a published event with its stream sequence number.
+type record struct {
+	seq uint64
+	ev  event.Event
+}
+
+// client is one connected stream.
+type client struct {
+	filter Filter
+	events chan record
+	done   chan struct{} // Closed when the server drops the client
+}
+
+// Server streams the events published on a Bus to HTTP clients as
+// Server-Sent Events. Each event carries a sequence number as its SSE id,
+// so a client that reconnects with Last-Event-ID receives the events it
+// missed, as long as they are still in the replay buffer.
+type Server struct {
+	bus        *event.Bus
+	logger     *logging.Logger
+	replaySize int
+
+	mu      sync.Mutex
+	subID   string
+	seq     uint64
+	history []record // The last replaySize events, oldest first
+	clients map[*client]struct{}
+	http    *http.Server
+	addr    net.Addr
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithReplaySize sets how many recent events are kept for replay. Zero
+// disables replay.
+func WithReplaySize(n int) Option {
+	return func(s *Server) {
+		s.replaySize = max(n, 0)
+	}
+}
+
+// WithLogger sets the logger for connection and server errors.
+func WithLogger(logger *logging.Logger) Option {
+	return func(s *Server) {
+		s.logger = logger
+	}
+}
+
+// New creates a Server for bus. It records nothing until Start.
+func New(bus *event.Bus, opts ...Option) *Server {
+	s := &Server{
+		bus:        bus,
+		replaySize: DefaultReplaySize,
+		clients:    make(map[*client]struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Start subscribes to the bus and serves the stream on addr (host:port).
+// It returns once the listener is open; the address actually bound, useful
+// with port 0, is available from Addr.
+func (s *Server) Start(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("event server: listen on %s: %w", addr, err)
+	}
+
+	s.mu.Lock()
+	if s.http != nil {
+		s.mu.Unlock()
+		_ = ln.Close()
+		return errors.New("event server: already started")
+	}
+	s.http = &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	s.addr = ln.Addr()
+	s.subID = s.bus.SubscribeAll(s.publish)
+	srv := s.http
+	s.mu.Unlock()
+
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) && s.logger != nil {
+			s.logger.Warn("event server stopped", "error", err)
+		}
+	}()
+	return nil
+}
+
+// Addr returns the address the server listens on, or nil before Start.
+func (s *Server) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addr
+}
+
+// Stop unsubscribes from the bus, disconnects every client, and closes the
+// listener.
+func (s *Server) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	subID, srv := s.subID, s.http
+	s.subID = ""
+	s.mu.Unlock()
+	if subID != "" {
+		s.bus.Unsubscribe(subID)
+	}
+
+	s.mu.Lock()
+	for c := range s.clients {
+		s.dropLocked(c)
+	}
+	s.mu.Unlock()
+
+	if srv == nil {
+		return nil
+	}
+	return srv.Shutdown(ctx)
+}
+
+// Handler returns the HTTP handler serving:
+//
+//	GET /events  the event stream (see ServeEvents)
+//	GET /types   the event types in the schema, as a JSON array
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /events", s.ServeEvents)
+	mux.HandleFunc("GET /types", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(event.Types())
+	})
+	return mux
+}
+
+// publish records e and queues it for every client whose filter accepts
+// it. It runs inline in the publisher's goroutine, so it never blocks: a
+// client whose queue is full is dropped.
+func (s *Server) publish(e event.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	rec := record{seq: s.seq, ev: e}
+	if s.replaySize > 0 {
+		if len(s.history) == s.replaySize {
+			s.history = slices.Delete(s.history, 0, 1)
+		}
+		s.history = append(s.history, rec)
+	}
+
+	for c := range s.clients {
+		if !c.filter.Match(e.EventType()) {
+			continue
+		}
+		select {
+		case c.events <- rec:
+		default:
+			s.dropLocked(c)
+		}
+	}
+}
+
+// dropLocked disconnects c. Caller must hold s.mu.
+func (s *Server) dropLocked(c *client) {
+	if _, ok := s.clients[c]; ok {
+		delete(s.clients, c)
+		close(c.done)
+	}
+}
+
+// ServeEvents streams events as Server-Sent Events. Every event is sent
+// with its type as the SSE event name, its sequence number as the id, and
+// its event.Envelope as the data. Query parameters:
+//
+//	types    Comma-separated event types to send; "instance.*" matches a
+//	         category. Default: all.
+//	version  Schema version to encode events at. Events newer than it are
+//	         skipped. Default: event.SchemaVersion.
+//	replay   Number of recent events to send first. Ignored when the
+//	         request carries Last-Event-ID.
+//
+// A reconnecting client's Last-Event-ID header (or last_event_id parameter)
+// replays every retained event after that id. If some were already evicted
+// from the replay buffer, a "gap" event reports the first id still
+// available before the replay.
+//
+// The stream opens with a "hello" event carrying the event.Header of the
+// negotiated schema version.
+func (s *Server) ServeEvents(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := ParseFilter(q.Get("types"))
+
+	version := event.SchemaVersion
+	if v := q.Get("version"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err == nil {
+			n, err = event.Negotiate(event.Header{Schema: event.SchemaName, Version: n, MinVersion: n})
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("unsupported version %q", v), http.StatusBadRequest)
+			return
+		}
+		version = n
+	}
+
+	lastID, resume, err := lastEventID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	replay := 0
+	if v := q.Get("replay"); v != "" && !resume {
+		if replay, err = strconv.Atoi(v); err != nil || replay < 0 {
+			http.Error(w, fmt.Sprintf("invalid replay %q", v), http.StatusBadRequest)
+			return
+		}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	// Register and snapshot the replay under one lock, so no event falls
+	// between the two.
+	c := &client{filter: filter, events: make(chan record, clientBuffer), done: make(chan struct{})}
+	s.mu.Lock()
+	backlog, gapFrom := s.backlogLocked(filter, resume, lastID, replay)
+	s.clients[c] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.dropLocked(c)
+		s.mu.Unlock()
+	}()
+
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	sw := &sseWriter{w: w, version: version}
+	fmt.Fprintf(w, "retry: %d\n\n", retryMillis)
+	sw.writeJSON("hello", "", event.Header{Schema: event.SchemaName, Version: version, MinVersion: version})
+	if gapFrom > 0 {
+		sw.writeJSON("gap", "", map[string]uint64{"last_event_id": lastID, "first_available_id": gapFrom})
+	}
+	for _, rec := range backlog {
+		sw.writeEvent(rec)
+	}
+	if sw.err != nil {
+		return
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-c.done:
+			return
+		case rec := <-c.events:
+			sw.writeEvent(rec)
+		case <-keepAlive.C:
+			_, sw.err = fmt.Fprint(w, ": keep-alive\n\n")
+		}
+		if sw.err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// backlogLocked returns the retained events to send a new client before
+// live ones: those after lastID when resuming, else the last replay
+// events. gapFrom is the first retained id when a resuming client missed
+// evicted events, or 0. Caller must hold s.mu.
+func (s *Server) backlogLocked(filter Filter, resume bool, lastID uint64, replay int) (backlog []record, gapFrom uint64) {
+	var candidates []record
+	switch {
+	case resume:
+		i, _ := slices.BinarySearchFunc(s.history, lastID+1, func(r record, seq uint64) int {
+			return cmpUint64(r.seq, seq)
+		})
+		candidates = s.history[i:]
+		oldest := s.seq + 1 // Nothing retained: only ids after s.seq remain
+		if len(s.history) > 0 {
+			oldest = s.history[0].seq
+		}
+		if lastID < s.seq && lastID+1 < oldest {
+			gapFrom = oldest
+		}
+	case replay > 0:
+		candidates = s.history
+	}
+
+	for _, rec := range candidates {
+		if filter.Match(rec.ev.EventType()) {
+			backlog = append(backlog, rec)
+		}
+	}
+	if !resume && len(backlog) > replay {
+		backlog = backlog[len(backlog)-replay:]
+	}
+	return backlog, gapFrom
+}
+
+func cmpUint64(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// lastEventID returns the id a reconnecting client last received.
+func lastEventID(r *http.Request) (uint64, bool, error) {
+	v := r.Header.Get("Last-Event-ID")
+	if v == "" {
+		v = r.URL.Query().Get("last_event_id")
+	}
+	if v == "" {
+		return 0, false, nil
+	}
+	id, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid last event id %q", v)
+	}
+	return id, true, nil
+}
+
+// sseWriter writes SSE messages, remembering the first write error.
+type sseWriter struct {
+	w       http.ResponseWriter
+	version int
+	err     error
+}
+
+// writeEvent writes a published event as an Envelope. Events the client's
+// schema version does not know are skipped, but their id is still sent so
+// a reconnect resumes after them.
+func (sw *sseWriter) writeEvent(rec record) {
+	if sw.err != nil {
+		return
+	}
+	id := strconv.FormatUint(rec.seq, 10)
+	data, err := event.Marshal(rec.ev, sw.version)
+	if errors.Is(err, event.ErrNotInVersion) || errors.Is(err, event.ErrUnknownEventType) {
+		_, sw.err = fmt.Fprintf(sw.w, "id: %s\n\n", id)
+		return
+	}
+	if err != nil {
+		sw.err = err
+		return
+	}
+	sw.write(rec.ev.EventType(), id, data)
+}
+
+func (sw *sseWriter) writeJSON(name, id string, v any) {
+	if sw.err != nil {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		sw.err = err
+		return
+	}
+	sw.write(name, id, data)
+}
+
+// write writes one message. data is single-line JSON, so it needs no
+// splitting across data fields.
+func (sw *sseWriter) write(name, id string, data []byte) {
+	if sw.err != nil {
+		return
+	}
+	var b strings.Builder
+	b.WriteString("event: " + name + "\n")
+	if id != "" {
+		b.WriteString("id: " + id + "\n")
+	}
+	b.WriteString("data: ")
+	b.Write(data)
+	b.WriteString("\n\n")
+	_, sw.err = fmt.Fprint(sw.w, b.String())
+}
@@ -0,0 +1,336 @@
+package eventserver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+)
+
+// message is one parsed SSE message.
+type message struct {
+	event string
+	id    string
+	data  string
+}
+
+// stream is an open event stream.
+type stream struct {
+	resp     *http.Response
+	messages chan message
+}
+
+func startServer(t *testing.T, opts ...Option) (*Server, *event.Bus) {
+	t.Helper()
+	bus := event.NewBus()
+	s := New(bus, opts...)
+	if err := s.Start("127.0.0.1:0"); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = s.Stop(ctx)
+	})
+	return s, bus
+}
+
+func openStream(t *testing.T, s *Server, query string, header http.Header) *stream {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, "http://"+s.Addr().String()+"/events"+query, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /events error = %v", err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("GET /events status = %d: %s", resp.StatusCode, body)
+	}
+
+	st := &stream{resp: resp, messages: make(chan message, 64)}
+	go func() {
+		defer close(st.messages)
+		var m message
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if m != (message{}) {
+					st.messages <- m
+				}
+				m = message{}
+			case strings.HasPrefix(line, "event: "):
+				m.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "id: "):
+				m.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "data: "):
+				m.data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+	return st
+}
+
+// next returns the next message that carries an event name, skipping
+// retry hints and id-only messages.
+func (st *stream) next(t *testing.T) message {
+	t.Helper()
+	for {
+		select {
+		case m, ok := <-st.messages:
+			if !ok {
+				t.Fatal("stream closed")
+			}
+			if m.event != "" {
+				return m
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for an event")
+		}
+	}
+}
+
+func (st *stream) expectHello(t *testing.T) {
+	t.Helper()
+	m := st.next(t)
+	if m.event != "hello" {
+		t.Fatalf("first event = %q, want hello", m.event)
+	}
+	var h event.Header
+	if err := json.Unmarshal([]byte(m.data), &h); err != nil {
+		t.Fatalf("hello data: %v", err)
+	}
+	if h.Schema != event.SchemaName || h.Version != event.SchemaVersion {
+		t.Errorf("hello header = %+v", h)
+	}
+}
+
+// waitForClients waits until n clients are registered, so events published
+// next reach them live rather than racing the subscription.
+func waitForClients(t *testing.T, s *Server, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		got := len(s.clients)
+		s.mu.Unlock()
+		if got == n {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d clients", n)
+}
+
+func decodeInstanceID(t *testing.T, m message) string {
+	t.Helper()
+	var env event.Envelope
+	if err := json.Unmarshal([]byte(m.data), &env); err != nil {
+		t.Fatalf("envelope: %v", err)
+	}
+	if env.Type != m.event {
+		t.Errorf("envelope type = %q, SSE event = %q", env.Type, m.event)
+	}
+	var data struct {
+		InstanceID string `json:"instance_id"`
+	}
+	if err := json.Unmarshal(env.Data, &data); err != nil {
+		t.Fatalf("envelope data: %v", err)
+	}
+	return data.InstanceID
+}
+
+func TestServer_StreamsLiveEvents(t *testing.T) {
+	s, bus := startServer(t)
+	st := openStream(t, s, "", nil)
+	st.expectHello(t)
+	waitForClients(t, s, 1)
+
+	bus.Publish(event.NewInstanceStartedEvent("inst-1", "/wt", "branch", "task"))
+
+	m := st.next(t)
+	if m.event != "instance.started" || m.id != "1" {
+		t.Errorf("event = %q id %q, want instance.started id 1", m.event, m.id)
+	}
+	if got := decodeInstanceID(t, m); got != "inst-1" {
+		t.Errorf("instance_id = %q, want inst-1", got)
+	}
+	if ct := st.resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+}
+
+func TestServer_FiltersByType(t *testing.T) {
+	s, bus := startServer(t)
+	st := openStream(t, s, "?types=task.completed,instance.*", nil)
+	st.expectHello(t)
+	waitForClients(t, s, 1)
+
+	bus.Publish(event.NewPRCompleteEvent("inst-1", true, "https://example.com/pr/1", ""))
+	bus.Publish(event.NewInstanceStoppedEvent("inst-2", true, ""))
+	bus.Publish(event.NewTaskCompletedEvent("task-1", "inst-3", true, "", ""))
+
+	if m := st.next(t); m.event != "instance.stopped" || m.id != "2" {
+		t.Errorf("first event = %q id %q, want instance.stopped id 2", m.event, m.id)
+	}
+	if m := st.next(t); m.event != "task.completed" || m.id != "3" {
+		t.Errorf("second event = %q id %q, want task.completed id 3", m.event, m.id)
+	}
+}
+
+func TestServer_Replay(t *testing.T) {
+	s, bus := startServer(t, WithReplaySize(3))
+	for _, id := range []string{"a", "b", "c", "d"} {
+		bus.Publish(event.NewInstanceStoppedEvent(id, true, ""))
+	}
+
+	st := openStream(t, s, "?replay=2", nil)
+	st.expectHello(t)
+	for _, want := range []string{"c", "d"} {
+		if got := decodeInstanceID(t, st.next(t)); got != want {
+			t.Errorf("replayed %q, want %q", got, want)
+		}
+	}
+}
+
+func TestServer_ResumeFromLastEventID(t *testing.T) {
+	s, bus := startServer(t, WithReplaySize(10))
+	for _, id := range []string{"a", "b", "c"} {
+		bus.Publish(event.NewInstanceStoppedEvent(id, true, ""))
+	}
+
+	st := openStream(t, s, "", http.Header{"Last-Event-Id": {"1"}})
+	st.expectHello(t)
+	for _, want := range []string{"2", "3"} {
+		if m := st.next(t); m.id != want {
+			t.Errorf("resumed id = %q, want %q", m.id, want)
+		}
+	}
+
+	waitForClients(t, s, 1)
+	bus.Publish(event.NewInstanceStoppedEvent("d", true, ""))
+	if m := st.next(t); m.id != "4" {
+		t.Errorf("live id = %q, want 4", m.id)
+	}
+}
+
+func TestServer_ResumeReportsGap(t *testing.T) {
+	s, bus := startServer(t, WithReplaySize(2))
+	for _, id := range []string{"a", "b", "c", "d"} {
+		bus.Publish(event.NewInstanceStoppedEvent(id, true, ""))
+	}
+
+	st := openStream(t, s, "?last_event_id=1", nil)
+	st.expectHello(t)
+	m := st.next(t)
+	if m.event != "gap" {
+		t.Fatalf("event = %q, want gap", m.event)
+	}
+	var gap map[string]uint64
+	if err := json.Unmarshal([]byte(m.data), &gap); err != nil {
+		t.Fatal(err)
+	}
+	if gap["first_available_id"] != 3 {
+		t.Errorf("first_available_id = %d, want 3", gap["first_available_id"])
+	}
+	if m := st.next(t); m.id != "3" {
+		t.Errorf("first replayed id = %q, want 3", m.id)
+	}
+}
+
+func TestServer_RejectsBadRequests(t *testing.T) {
+	s, _ := startServer(t)
+	for _, query := range []string{"?version=99", "?replay=-1", "?last_event_id=abc"} {
+		resp, err := http.Get("http://" + s.Addr().String() + "/events" + query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("GET /events%s status = %d, want 400", query, resp.StatusCode)
+		}
+	}
+}
+
+func TestServer_Types(t *testing.T) {
+	s, _ := startServer(t)
+	resp, err := http.Get("http://" + s.Addr().String() + "/types")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	var types []string
+	if err := json.NewDecoder(resp.Body).Decode(&types); err != nil {
+		t.Fatal(err)
+	}
+	if len(types) != len(event.Types()) {
+		t.Errorf("got %d types, want %d", len(types), len(event.Types()))
+	}
+}
+
+func TestServer_StopDisconnectsClients(t *testing.T) {
+	bus := event.NewBus()
+	s := New(bus)
+	if err := s.Start("127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+	st := openStream(t, s, "", nil)
+	st.expectHello(t)
+	waitForClients(t, s, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := s.Stop(ctx); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	select {
+	case _, ok := <-st.messages:
+		for ok {
+			_, ok = <-st.messages
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream still open after Stop")
+	}
+
+	// Publishing after Stop must not reach the server.
+	bus.Publish(event.NewInstanceStoppedEvent("a", true, ""))
+	if s.seq != 0 {
+		t.Errorf("seq = %d after Stop, want 0", s.seq)
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		spec      string
+		eventType string
+		want      bool
+	}{
+		{"", "instance.started", true},
+		{"*", "pr.opened", true},
+		{"instance.started", "instance.started", true},
+		{"instance.started", "instance.stopped", false},
+		{"instance.*", "instance.stopped", true},
+		{"instance.*", "instances.x", false},
+		{" pr.opened , queue.*", "queue.task_ready", true},
+		{"pr.opened,queue.*", "phase.changed", false},
+	}
+	for _, tt := range tests {
+		if got := ParseFilter(tt.spec).Match(tt.eventType); got != tt.want {
+			t.Errorf("ParseFilter(%q).Match(%q) = %v, want %v", tt.spec, tt.eventType, got, tt.want)
+		}
+	}
+}
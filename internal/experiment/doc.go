@@ -0,0 +1,28 @@
+// Package experiment runs prompt A/B experiments and reports their outcomes.
+//
+// An [Experiment] enrolls a fraction of subjects (individual tasks or whole
+// sessions) and assigns each enrolled subject to one of several prompt
+// template variants, weighted and deterministic: the same subject always lands
+// in the same variant, so retries of a task keep their variant. Subjects that
+// are not enrolled run the unmodified "control" prompt.
+//
+// A [Tracker] applies the assigned variants to prompts as they are built and
+// appends per-attempt outcomes (success, verification failure, cost) to a
+// [stats.Store] tagged by experiment and variant. [Report] aggregates those
+// records into per-variant summaries for comparison.
+//
+// # Usage
+//
+//	tracker := experiment.NewTracker(store, sessionID, experiments)
+//
+//	prompt = tracker.Transform(experiment.PhaseTask, taskID, title, prompt)
+//	// ... run the task ...
+//	_ = tracker.RecordAttempt(taskID, experiment.Outcome{Success: true, Cost: 0.31})
+//
+//	summaries, err := experiment.Report(store, "terse-task-prompt")
+//
+// # Thread Safety
+//
+// [Tracker] is safe for concurrent use. [Experiment] values are immutable after
+// construction.
+package experiment
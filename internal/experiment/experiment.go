@@ -0,0 +1,163 @@
+package experiment
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"text/template"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+)
+
+// ControlVariant is the name of the implicit variant that leaves prompts unchanged.
+const ControlVariant = "control"
+
+// PhaseTask is the prompt phase for task execution prompts.
+const PhaseTask = "task"
+
+// Unit controls what an experiment randomizes over.
+type Unit string
+
+const (
+	// UnitTask assigns each task independently.
+	UnitTask Unit = "task"
+
+	// UnitSession assigns every task in a session to the same variant.
+	UnitSession Unit = "session"
+)
+
+// Variant is one arm of an experiment.
+type Variant struct {
+	Name   string
+	Weight float64
+	tmpl   *template.Template // nil for control
+}
+
+// TemplateData is the data a variant template is rendered with.
+type TemplateData struct {
+	Prompt string // The prompt that would have been used without the experiment
+	TaskID string
+	Title  string
+}
+
+// Apply renders the variant's template around the base prompt. The control
+// variant (or any variant without a template) returns the prompt unchanged.
+func (v Variant) Apply(data TemplateData) (string, error) {
+	if v.tmpl == nil {
+		return data.Prompt, nil
+	}
+	var buf bytes.Buffer
+	if err := v.tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("experiment: render variant %q: %w", v.Name, err)
+	}
+	return buf.String(), nil
+}
+
+// Experiment assigns subjects to prompt variants.
+type Experiment struct {
+	Name     string
+	Phase    string
+	Unit     Unit
+	Fraction float64
+	Variants []Variant
+}
+
+// New builds an experiment from its configuration, parsing variant templates.
+// Missing Phase, Unit, Fraction and Weight values take their defaults ("task",
+// "task", 1 and 1 respectively).
+func New(cfg config.ExperimentConfig) (Experiment, error) {
+	if cfg.Name == "" {
+		return Experiment{}, errors.New("experiment: name is required")
+	}
+	if len(cfg.Variants) == 0 {
+		return Experiment{}, fmt.Errorf("experiment %q: at least one variant is required", cfg.Name)
+	}
+
+	exp := Experiment{
+		Name:     cfg.Name,
+		Phase:    cfg.Phase,
+		Unit:     Unit(cfg.Unit),
+		Fraction: cfg.Fraction,
+	}
+	if exp.Phase == "" {
+		exp.Phase = PhaseTask
+	}
+	if exp.Unit == "" {
+		exp.Unit = UnitTask
+	}
+	if exp.Fraction == 0 {
+		exp.Fraction = 1
+	}
+
+	for _, vc := range cfg.Variants {
+		if vc.Name == "" || vc.Name == ControlVariant {
+			return Experiment{}, fmt.Errorf("experiment %q: invalid variant name %q", cfg.Name, vc.Name)
+		}
+		v := Variant{Name: vc.Name, Weight: vc.Weight}
+		if v.Weight == 0 {
+			v.Weight = 1
+		}
+		if vc.Template != "" {
+			tmpl, err := template.New(vc.Name).Option("missingkey=error").Parse(vc.Template)
+			if err != nil {
+				return Experiment{}, fmt.Errorf("experiment %q: parse variant %q: %w", cfg.Name, vc.Name, err)
+			}
+			v.tmpl = tmpl
+		}
+		exp.Variants = append(exp.Variants, v)
+	}
+	return exp, nil
+}
+
+// FromConfig builds all configured experiments, skipping (and reporting)
+// any that fail to parse so one bad template does not disable the rest.
+func FromConfig(cfgs []config.ExperimentConfig) ([]Experiment, error) {
+	var exps []Experiment
+	var errs []error
+	for _, c := range cfgs {
+		exp, err := New(c)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		exps = append(exps, exp)
+	}
+	return exps, errors.Join(errs...)
+}
+
+// Assign returns the variant for a subject. Assignment is a pure function of
+// the experiment name and subject, so it is stable across retries and process
+// restarts. Subjects outside the enrolled fraction get the control variant.
+func (e Experiment) Assign(subject string) Variant {
+	control := Variant{Name: ControlVariant}
+
+	if bucket(e.Name, "enroll", subject) >= e.Fraction {
+		return control
+	}
+
+	var total float64
+	for _, v := range e.Variants {
+		total += v.Weight
+	}
+	if total <= 0 {
+		return control
+	}
+
+	point := bucket(e.Name, "variant", subject) * total
+	for _, v := range e.Variants {
+		if point < v.Weight {
+			return v
+		}
+		point -= v.Weight
+	}
+	return e.Variants[len(e.Variants)-1]
+}
+
+// bucket hashes the inputs to a uniform value in [0, 1). Separate salts keep
+// the enrollment and variant draws independent.
+func bucket(name, salt, subject string) float64 {
+	sum := sha256.Sum256([]byte(name + "\x00" + salt + "\x00" + subject))
+	return float64(binary.BigEndian.Uint64(sum[:8])>>11) / float64(1<<53)
+}
@@ -0,0 +1,201 @@
+package experiment
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/stats"
+)
+
+func TestNew_Defaults(t *testing.T) {
+	exp, err := New(config.ExperimentConfig{
+		Name:     "e1",
+		Variants: []config.ExperimentVariantConfig{{Name: "terse", Template: "{{.Prompt}}!"}},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if exp.Phase != PhaseTask || exp.Unit != UnitTask || exp.Fraction != 1 {
+		t.Errorf("defaults = phase %q unit %q fraction %v", exp.Phase, exp.Unit, exp.Fraction)
+	}
+	if exp.Variants[0].Weight != 1 {
+		t.Errorf("default weight = %v, want 1", exp.Variants[0].Weight)
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.ExperimentConfig
+		wantErr string
+	}{
+		{"missing name", config.ExperimentConfig{Variants: []config.ExperimentVariantConfig{{Name: "a"}}}, "name is required"},
+		{"no variants", config.ExperimentConfig{Name: "e"}, "at least one variant"},
+		{"control name", config.ExperimentConfig{Name: "e", Variants: []config.ExperimentVariantConfig{{Name: "control"}}}, "invalid variant name"},
+		{"bad template", config.ExperimentConfig{Name: "e", Variants: []config.ExperimentVariantConfig{{Name: "a", Template: "{{.Prompt"}}}, "parse variant"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFromConfig_SkipsInvalid(t *testing.T) {
+	exps, err := FromConfig([]config.ExperimentConfig{
+		{Name: "good", Variants: []config.ExperimentVariantConfig{{Name: "a"}}},
+		{Name: "bad"},
+	})
+	if err == nil {
+		t.Error("expected error for invalid experiment")
+	}
+	if len(exps) != 1 || exps[0].Name != "good" {
+		t.Errorf("FromConfig = %+v, want only 'good'", exps)
+	}
+}
+
+func TestAssign_DeterministicAndWeighted(t *testing.T) {
+	exp, err := New(config.ExperimentConfig{
+		Name:     "weights",
+		Fraction: 0.5,
+		Variants: []config.ExperimentVariantConfig{
+			{Name: "a", Weight: 3},
+			{Name: "b", Weight: 1},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counts := make(map[string]int)
+	const n = 4000
+	for i := range n {
+		subject := fmt.Sprintf("task-%d", i)
+		v := exp.Assign(subject)
+		if again := exp.Assign(subject); again.Name != v.Name {
+			t.Fatalf("Assign(%q) not deterministic: %q then %q", subject, v.Name, again.Name)
+		}
+		counts[v.Name]++
+	}
+
+	// Expect ~50% control, ~37.5% a, ~12.5% b. Allow generous tolerance.
+	check := func(name string, want float64) {
+		got := float64(counts[name]) / n
+		if got < want-0.05 || got > want+0.05 {
+			t.Errorf("share of %q = %.3f, want ~%.3f", name, got, want)
+		}
+	}
+	check(ControlVariant, 0.5)
+	check("a", 0.375)
+	check("b", 0.125)
+}
+
+func TestVariant_Apply(t *testing.T) {
+	exp, err := New(config.ExperimentConfig{
+		Name: "e",
+		Variants: []config.ExperimentVariantConfig{
+			{Name: "wrap", Template: "[{{.TaskID}}: {{.Title}}]\n{{.Prompt}}"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := exp.Variants[0].Apply(TemplateData{Prompt: "do it", TaskID: "t1", Title: "Title"})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if got != "[t1: Title]\ndo it" {
+		t.Errorf("Apply = %q", got)
+	}
+
+	control := Variant{Name: ControlVariant}
+	if got, _ := control.Apply(TemplateData{Prompt: "same"}); got != "same" {
+		t.Errorf("control Apply = %q, want unchanged", got)
+	}
+}
+
+func TestTracker_TransformRecordAndReport(t *testing.T) {
+	store := stats.NewStore(t.TempDir())
+	exp, err := New(config.ExperimentConfig{
+		Name:     "e",
+		Unit:     "session",
+		Variants: []config.ExperimentVariantConfig{{Name: "loud", Template: "{{.Prompt}} NOW"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := NewTracker(store, "sess-1", []Experiment{exp})
+
+	// Fraction 1 with a single variant: every session is enrolled in "loud".
+	if got := tr.Transform(PhaseTask, "t1", "T1", "work"); got != "work NOW" {
+		t.Errorf("Transform = %q, want %q", got, "work NOW")
+	}
+	if got := tr.Transform("synthesis", "t2", "T2", "work"); got != "work" {
+		t.Errorf("Transform for other phase = %q, want unchanged", got)
+	}
+	tr.Transform(PhaseTask, "t2", "T2", "work")
+
+	// t1 fails verification once, then succeeds; t2 succeeds first time.
+	for _, rec := range []struct {
+		task string
+		o    Outcome
+	}{
+		{"t1", Outcome{VerificationFailed: true, Cost: 1}},
+		{"t1", Outcome{Success: true, Cost: 2}},
+		{"t2", Outcome{Success: true, Cost: 3}},
+		{"untracked", Outcome{Success: true, Cost: 100}},
+	} {
+		if err := tr.RecordAttempt(rec.task, rec.o); err != nil {
+			t.Fatalf("RecordAttempt: %v", err)
+		}
+	}
+
+	summaries, err := Report(store, "e")
+	if err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+	if len(summaries) != 1 {
+		t.Fatalf("summaries = %+v, want 1 variant", summaries)
+	}
+	s := summaries[0]
+	if s.Variant != "loud" || s.Tasks != 2 || s.Attempts != 3 || s.Successes != 2 || s.VerificationFails != 1 {
+		t.Errorf("summary = %+v", s)
+	}
+	if s.RetriesPerTask != 0.5 || s.SuccessRate != 1 || s.CostPerSuccess != 3 {
+		t.Errorf("derived = retries %v success %v cost %v", s.RetriesPerTask, s.SuccessRate, s.CostPerSuccess)
+	}
+
+	names, err := Names(store)
+	if err != nil || len(names) != 1 || names[0] != "e" {
+		t.Errorf("Names = %v, %v", names, err)
+	}
+}
+
+func TestReport_ControlFirst(t *testing.T) {
+	store := stats.NewStore(t.TempDir())
+	for _, v := range []string{"zeta", ControlVariant, "alpha"} {
+		if err := store.Append(stats.Record{
+			Kind:    RecordKind,
+			Subject: "t-" + v,
+			Tags:    map[string]string{"experiment": "e", "variant": v},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	summaries, err := Report(store, "e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order []string
+	for _, s := range summaries {
+		order = append(order, s.Variant)
+	}
+	if strings.Join(order, ",") != "control,alpha,zeta" {
+		t.Errorf("order = %v", order)
+	}
+}
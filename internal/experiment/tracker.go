@@ -0,0 +1,200 @@
+package experiment
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/Iron-Ham/claudio/internal/stats"
+)
+
+// RecordKind is the stats record kind for experiment attempt outcomes.
+const RecordKind = "experiment.attempt"
+
+// Outcome describes a single execution attempt of a task.
+type Outcome struct {
+	Success            bool    // The attempt completed and passed verification
+	VerificationFailed bool    // The attempt finished but failed verification
+	Cost               float64 // Estimated cost of the attempt in USD
+}
+
+// assignment records which variant a task received for one experiment.
+type assignment struct {
+	experiment string
+	variant    string
+}
+
+// Tracker applies experiment variants to prompts and records outcomes.
+type Tracker struct {
+	store       *stats.Store
+	sessionID   string
+	experiments []Experiment
+
+	mu          sync.Mutex
+	assignments map[string][]assignment // taskID → assignments
+}
+
+// NewTracker creates a Tracker that records outcomes to store.
+func NewTracker(store *stats.Store, sessionID string, experiments []Experiment) *Tracker {
+	return &Tracker{
+		store:       store,
+		sessionID:   sessionID,
+		experiments: experiments,
+		assignments: make(map[string][]assignment),
+	}
+}
+
+// Transform applies every experiment for the given phase to prompt, in
+// configuration order, and remembers the assignments so later outcomes for
+// the task are tagged correctly. A variant whose template fails to render
+// falls back to the untransformed prompt and is recorded as control, so a
+// broken template never blocks execution.
+func (t *Tracker) Transform(phase, taskID, title, prompt string) string {
+	var assigned []assignment
+	for _, exp := range t.experiments {
+		if exp.Phase != phase {
+			continue
+		}
+		subject := taskID
+		if exp.Unit == UnitSession {
+			subject = t.sessionID
+		}
+		v := exp.Assign(subject)
+		out, err := v.Apply(TemplateData{Prompt: prompt, TaskID: taskID, Title: title})
+		if err != nil {
+			v = Variant{Name: ControlVariant}
+		} else {
+			prompt = out
+		}
+		assigned = append(assigned, assignment{experiment: exp.Name, variant: v.Name})
+	}
+
+	if len(assigned) > 0 {
+		t.mu.Lock()
+		t.assignments[taskID] = assigned
+		t.mu.Unlock()
+	}
+	return prompt
+}
+
+// RecordAttempt appends the outcome of one attempt of a task to the stats
+// store, once per experiment the task participates in. Tasks that were never
+// transformed are ignored.
+func (t *Tracker) RecordAttempt(taskID string, o Outcome) error {
+	t.mu.Lock()
+	assigned := t.assignments[taskID]
+	t.mu.Unlock()
+
+	values := map[string]float64{
+		"success":             boolValue(o.Success),
+		"verification_failed": boolValue(o.VerificationFailed),
+		"cost":                o.Cost,
+	}
+
+	var errs []error
+	for _, a := range assigned {
+		errs = append(errs, t.store.Append(stats.Record{
+			Kind:    RecordKind,
+			Session: t.sessionID,
+			Subject: taskID,
+			Tags:    map[string]string{"experiment": a.experiment, "variant": a.variant},
+			Values:  values,
+		}))
+	}
+	return errors.Join(errs...)
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// VariantSummary aggregates outcomes for one variant of an experiment.
+type VariantSummary struct {
+	Variant           string
+	Tasks             int     // Distinct tasks (per session) that ran this variant
+	Attempts          int     // Total attempts, including retries
+	Successes         int     // Attempts that succeeded
+	VerificationFails int     // Attempts that failed verification
+	TotalCost         float64 // Sum of attempt costs
+	RetriesPerTask    float64 // (Attempts - Tasks) / Tasks
+	SuccessRate       float64 // Successes / Tasks
+	VerifyFailRate    float64 // VerificationFails / Attempts
+	CostPerSuccess    float64 // TotalCost / Successes (0 when no successes)
+}
+
+// Report aggregates recorded outcomes for an experiment into per-variant
+// summaries, sorted with control first and the rest by name.
+func Report(store *stats.Store, name string) ([]VariantSummary, error) {
+	records, err := store.Query(RecordKind, func(r stats.Record) bool {
+		return r.Tags["experiment"] == name
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byVariant := make(map[string]*VariantSummary)
+	tasks := make(map[string]map[string]bool)
+	for _, r := range records {
+		v := r.Tags["variant"]
+		s, ok := byVariant[v]
+		if !ok {
+			s = &VariantSummary{Variant: v}
+			byVariant[v] = s
+			tasks[v] = make(map[string]bool)
+		}
+		tasks[v][r.Session+"/"+r.Subject] = true
+		s.Attempts++
+		if r.Values["success"] > 0 {
+			s.Successes++
+		}
+		if r.Values["verification_failed"] > 0 {
+			s.VerificationFails++
+		}
+		s.TotalCost += r.Values["cost"]
+	}
+
+	out := make([]VariantSummary, 0, len(byVariant))
+	for v, s := range byVariant {
+		s.Tasks = len(tasks[v])
+		if s.Tasks > 0 {
+			s.RetriesPerTask = float64(s.Attempts-s.Tasks) / float64(s.Tasks)
+			s.SuccessRate = float64(s.Successes) / float64(s.Tasks)
+		}
+		if s.Attempts > 0 {
+			s.VerifyFailRate = float64(s.VerificationFails) / float64(s.Attempts)
+		}
+		if s.Successes > 0 {
+			s.CostPerSuccess = s.TotalCost / float64(s.Successes)
+		}
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if (out[i].Variant == ControlVariant) != (out[j].Variant == ControlVariant) {
+			return out[i].Variant == ControlVariant
+		}
+		return out[i].Variant < out[j].Variant
+	})
+	return out, nil
+}
+
+// Names returns the distinct experiment names that have recorded outcomes.
+func Names(store *stats.Store) ([]string, error) {
+	records, err := store.Query(RecordKind, nil)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for _, r := range records {
+		n := r.Tags["experiment"]
+		if n != "" && !seen[n] {
+			seen[n] = true
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -34,6 +34,16 @@
 //	// Release all on shutdown
 //	err = reg.ReleaseAll("instance-1")
 //
+// # Enforcement
+//
+// Claims are advisory unless enforced. A [Server] answers claim checks for
+// a Registry over a unix socket (see [SocketPath]), and [InstallHook]
+// installs a pre-commit hook into a worktree that pipes the staged paths to
+// `claudio claim-check`, which queries the server with [Check]. Commits
+// staging a file claimed by another owner are rejected unless
+// CLAUDIO_ALLOW_CLAIMED=1 ([OverrideEnv]) is set. The coordination Hub runs
+// the server when created with coordination.WithClaimEnforcement.
+//
 // # Thread Safety
 //
 // All [Registry] methods are safe for concurrent use via an internal sync.RWMutex.
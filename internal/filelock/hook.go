@@ -0,0 +1,139 @@
+package filelock
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// hookDirName is the per-worktree hooks directory, created inside the
+	// worktree's private git directory so it never shows up in the tree.
+	hookDirName = "claudio-hooks"
+
+	// previousHooksFile records the hooks directory that was in effect
+	// before InstallHook, so reinstalling keeps chaining to it.
+	previousHooksFile = "previous-hooks-path"
+
+	// OverrideEnv, set to "1", lets a commit through the installed hook
+	// even when it touches files claimed by another instance.
+	OverrideEnv = "CLAUDIO_ALLOW_CLAIMED"
+)
+
+// HookConfig describes the pre-commit hook InstallHook writes.
+type HookConfig struct {
+	// Socket is the claim server's socket path (see SocketPath).
+	Socket string
+
+	// Owner is the claim owner the worktree commits as: the ID the
+	// worktree's files were claimed under.
+	Owner string
+
+	// Executable is the claudio binary the hook runs. Defaults to the
+	// running executable.
+	Executable string
+}
+
+// InstallHook installs a pre-commit hook into the git worktree at
+// worktreeDir that rejects commits staging files claimed by another owner.
+// The hook lives in the worktree's own git directory and is enabled with a
+// worktree-scoped core.hooksPath, so other worktrees and the main checkout
+// are unaffected. The hook runs the pre-commit hook that was in effect
+// before, if any, after its own check. Installing again replaces the hook.
+func InstallHook(worktreeDir string, cfg HookConfig) error {
+	if cfg.Socket == "" || cfg.Owner == "" {
+		return fmt.Errorf("filelock: hook needs a socket and an owner")
+	}
+	if cfg.Executable == "" {
+		exe, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("filelock: find claudio executable: %w", err)
+		}
+		cfg.Executable = exe
+	}
+
+	gitDir, err := gitOutput(worktreeDir, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return err
+	}
+	hookDir := filepath.Join(gitDir, hookDirName)
+
+	previous, err := previousHooksPath(worktreeDir, hookDir)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(hookDir, 0o755); err != nil {
+		return fmt.Errorf("filelock: create hook directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(hookDir, previousHooksFile), []byte(previous+"\n"), 0o644); err != nil {
+		return fmt.Errorf("filelock: record previous hooks path: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(hookDir, "pre-commit"), []byte(hookScript(cfg, previous)), 0o755); err != nil {
+		return fmt.Errorf("filelock: write pre-commit hook: %w", err)
+	}
+
+	if _, err := gitOutput(worktreeDir, "config", "extensions.worktreeConfig", "true"); err != nil {
+		return err
+	}
+	if _, err := gitOutput(worktreeDir, "config", "--worktree", "core.hooksPath", hookDir); err != nil {
+		return err
+	}
+	return nil
+}
+
+// previousHooksPath returns the hooks directory in effect for the worktree
+// before InstallHook took over, as an absolute path.
+func previousHooksPath(worktreeDir, hookDir string) (string, error) {
+	current, err := gitOutput(worktreeDir, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(current) {
+		current = filepath.Join(worktreeDir, current)
+	}
+	if filepath.Clean(current) != filepath.Clean(hookDir) {
+		return current, nil
+	}
+	// Already installed: keep chaining to what the first install recorded.
+	data, err := os.ReadFile(filepath.Join(hookDir, previousHooksFile))
+	if err != nil {
+		return "", fmt.Errorf("filelock: read previous hooks path: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// hookScript renders the pre-commit hook.
+func hookScript(cfg HookConfig, previous string) string {
+	var sb strings.Builder
+	sb.WriteString("#!/bin/sh\n")
+	sb.WriteString("# Installed by claudio: rejects commits that touch files claimed by\n")
+	fmt.Fprintf(&sb, "# another instance. Set %s=1 to commit anyway.\n", OverrideEnv)
+	fmt.Fprintf(&sb, "if [ \"${%s:-}\" != \"1\" ]; then\n", OverrideEnv)
+	fmt.Fprintf(&sb, "\tgit diff --cached --name-only --no-renames -z | %s claim-check --socket %s --owner %s || exit 1\n",
+		shellQuote(cfg.Executable), shellQuote(cfg.Socket), shellQuote(cfg.Owner))
+	sb.WriteString("fi\n")
+	if previous != "" {
+		prev := shellQuote(filepath.Join(previous, "pre-commit"))
+		fmt.Fprintf(&sb, "if [ -x %s ]; then\n\texec %s \"$@\"\nfi\n", prev, prev)
+	}
+	return sb.String()
+}
+
+// gitOutput runs git in dir and returns its trimmed output.
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("filelock: git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// shellQuote wraps s in single quotes for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
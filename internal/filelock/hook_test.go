@@ -0,0 +1,119 @@
+package filelock
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// hookRepo creates a repository with a linked worktree and returns the paths
+// of the main checkout and the worktree.
+func hookRepo(t *testing.T) (string, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	wt := filepath.Join(root, "wt")
+	if err := os.Mkdir(repo, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, nil, "init", "-q")
+	runGit(t, repo, nil, "commit", "-q", "--allow-empty", "-m", "init")
+	runGit(t, repo, nil, "worktree", "add", "-q", "-b", "task", wt)
+	return repo, wt
+}
+
+func runGit(t *testing.T, dir string, env []string, args ...string) (string, error) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+	cmd.Env = append(cmd.Env, env...)
+	out, err := cmd.CombinedOutput()
+	if err != nil && args[0] != "commit" {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return string(out), err
+}
+
+// commitFile stages and commits name in dir, returning the commit error.
+func commitFile(t *testing.T, dir, name string, env ...string) error {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, nil, "add", name)
+	_, err := runGit(t, dir, env, "commit", "-q", "-m", "add "+name)
+	return err
+}
+
+func TestInstallHook(t *testing.T) {
+	repo, wt := hookRepo(t)
+
+	// Stand-in for `claudio claim-check`: rejects staged files named blocked*.
+	exe := filepath.Join(t.TempDir(), "claudio")
+	script := "#!/bin/sh\n[ \"$1 $2 $4 $5\" = \"claim-check --socket --owner task-1\" ] || exit 2\n" +
+		"tr '\\0' '\\n' | grep -q '^blocked' && exit 1\nexit 0\n"
+	if err := os.WriteFile(exe, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	// An existing shared pre-commit hook must keep running after ours.
+	marker := filepath.Join(t.TempDir(), "previous-ran")
+	commonHooks := filepath.Join(repo, ".git", "hooks")
+	if err := os.MkdirAll(commonHooks, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(commonHooks, "pre-commit"), []byte("#!/bin/sh\ntouch '"+marker+"'\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := HookConfig{Socket: "/tmp/claims.sock", Owner: "task-1", Executable: exe}
+	if err := InstallHook(wt, cfg); err != nil {
+		t.Fatalf("InstallHook() error = %v", err)
+	}
+	// Reinstalling must not chain the hook to itself.
+	if err := InstallHook(wt, cfg); err != nil {
+		t.Fatalf("second InstallHook() error = %v", err)
+	}
+
+	if err := commitFile(t, wt, "blocked.go"); err == nil {
+		t.Error("commit of a claimed file succeeded, want it rejected")
+	}
+	if err := commitFile(t, wt, "blocked.go", OverrideEnv+"=1"); err != nil {
+		t.Errorf("commit with %s=1 failed: %v", OverrideEnv, err)
+	}
+
+	_ = os.Remove(marker)
+	if err := commitFile(t, wt, "free.go"); err != nil {
+		t.Errorf("commit of an unclaimed file failed: %v", err)
+	}
+	if _, err := os.Stat(marker); err != nil {
+		t.Errorf("previous pre-commit hook did not run: %v", err)
+	}
+
+	// The main checkout keeps the shared hooks.
+	out, _ := runGit(t, repo, nil, "rev-parse", "--git-path", "hooks")
+	if strings.Contains(out, hookDirName) {
+		t.Errorf("main checkout hooks path = %q, want it unchanged", out)
+	}
+	if err := commitFile(t, repo, "blocked-main.go"); err != nil {
+		t.Errorf("commit in main checkout failed: %v", err)
+	}
+}
+
+func TestInstallHook_Validation(t *testing.T) {
+	if err := InstallHook(t.TempDir(), HookConfig{Owner: "task-1"}); err == nil {
+		t.Error("InstallHook() without a socket should error")
+	}
+	if err := InstallHook(t.TempDir(), HookConfig{Socket: "/tmp/s.sock"}); err == nil {
+		t.Error("InstallHook() without an owner should error")
+	}
+	if err := InstallHook(t.TempDir(), HookConfig{Socket: "/tmp/s.sock", Owner: "task-1"}); err == nil {
+		t.Error("InstallHook() outside a git worktree should error")
+	}
+}
@@ -2,6 +2,7 @@ package filelock
 
 import (
 	"fmt"
+	"path/filepath"
 	"sort"
 	"sync"
 	"time"
@@ -219,6 +220,31 @@ func (r *Registry) GetInstanceFiles(instanceID string) []string {
 	return files
 }
 
+// Conflicts returns the claims other instances hold on any of filePaths, in
+// path order. Paths are compared after filepath.Clean, so "./pkg/foo.go"
+// matches a claim on "pkg/foo.go".
+func (r *Registry) Conflicts(instanceID string, filePaths []string) []FileClaim {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var conflicts []FileClaim
+	seen := make(map[string]bool)
+	for _, fp := range filePaths {
+		fp = filepath.Clean(fp)
+		if seen[fp] {
+			continue
+		}
+		seen[fp] = true
+		if claim, ok := r.claims[fp]; ok && claim.InstanceID != instanceID {
+			conflicts = append(conflicts, claim)
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].FilePath < conflicts[j].FilePath
+	})
+	return conflicts
+}
+
 // WatchClaims registers a handler that is called whenever a claim is established.
 // Handlers are called outside the registry's lock; they may safely call read
 // methods like Owner, IsAvailable, and GetInstanceFiles without deadlocking.
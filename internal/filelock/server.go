@@ -0,0 +1,181 @@
+package filelock
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// socketFile is the name of the claim server's socket in a session directory.
+	socketFile = "filelock.sock"
+
+	// maxSocketPath is the longest unix socket path that is safe on every
+	// supported platform (sun_path is 104 bytes on macOS, 108 on Linux).
+	maxSocketPath = 100
+
+	// checkTimeout bounds a single claim check, so a wedged server cannot
+	// hang a commit.
+	checkTimeout = 5 * time.Second
+)
+
+// checkRequest asks which of Paths are claimed by someone other than Owner.
+type checkRequest struct {
+	Owner string   `json:"owner"`
+	Paths []string `json:"paths"`
+}
+
+// checkResponse lists the conflicting claims.
+type checkResponse struct {
+	Conflicts []Conflict `json:"conflicts"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// Conflict is a path claimed by another instance.
+type Conflict struct {
+	Path  string `json:"path"`
+	Owner string `json:"owner"`
+}
+
+// Server answers claim checks for a Registry over a unix socket. The
+// pre-commit hook installed by InstallHook queries it (through
+// `claudio claim-check`) to reject commits that touch files claimed by
+// another instance.
+type Server struct {
+	reg  *Registry
+	path string
+
+	mu sync.Mutex
+	ln net.Listener
+	wg sync.WaitGroup
+}
+
+// NewServer creates a Server for reg listening on the unix socket at path.
+// Use SocketPath to pick a path for a session.
+func NewServer(reg *Registry, path string) *Server {
+	return &Server{reg: reg, path: path}
+}
+
+// SocketPath returns the claim server socket path for a session directory.
+// Paths too long for a unix socket are replaced with one in the system
+// temporary directory derived from the session directory.
+func SocketPath(sessionDir string) string {
+	path := filepath.Join(sessionDir, socketFile)
+	if len(path) <= maxSocketPath {
+		return path
+	}
+	sum := sha256.Sum256([]byte(sessionDir))
+	return filepath.Join(os.TempDir(), "claudio-"+hex.EncodeToString(sum[:6])+".sock")
+}
+
+// Path returns the socket path the server listens on.
+func (s *Server) Path() string { return s.path }
+
+// Start opens the socket and serves checks in the background. A stale
+// socket file left by a crashed session is removed first.
+func (s *Server) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ln != nil {
+		return errors.New("filelock: server already started")
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("filelock: create socket directory: %w", err)
+	}
+	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("filelock: remove stale socket: %w", err)
+	}
+	ln, err := net.Listen("unix", s.path)
+	if err != nil {
+		return fmt.Errorf("filelock: listen on %s: %w", s.path, err)
+	}
+	s.ln = ln
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.acceptLoop(ln)
+	}()
+	return nil
+}
+
+// Close stops the server and removes its socket. It is idempotent, and the
+// server can be started again afterwards.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	ln := s.ln
+	s.ln = nil
+	s.mu.Unlock()
+	if ln == nil {
+		return nil
+	}
+
+	err := ln.Close()
+	s.wg.Wait()
+	if rmErr := os.Remove(s.path); rmErr != nil && !os.IsNotExist(rmErr) && err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+func (s *Server) acceptLoop(ln net.Listener) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return // Listener closed
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.serve(conn)
+		}()
+	}
+}
+
+// serve answers one request on conn.
+func (s *Server) serve(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(checkTimeout))
+
+	var req checkRequest
+	var resp checkResponse
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		resp.Error = fmt.Sprintf("decode request: %v", err)
+	} else {
+		for _, claim := range s.reg.Conflicts(req.Owner, req.Paths) {
+			resp.Conflicts = append(resp.Conflicts, Conflict{Path: claim.FilePath, Owner: claim.InstanceID})
+		}
+	}
+	_ = json.NewEncoder(conn).Encode(resp)
+}
+
+// Check asks the claim server at socketPath which of paths are claimed by
+// an instance other than owner.
+func Check(socketPath, owner string, paths []string) ([]Conflict, error) {
+	conn, err := net.DialTimeout("unix", socketPath, checkTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("filelock: connect to claim server: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(checkTimeout))
+
+	if err := json.NewEncoder(conn).Encode(checkRequest{Owner: owner, Paths: paths}); err != nil {
+		return nil, fmt.Errorf("filelock: send claim check: %w", err)
+	}
+	var resp checkResponse
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("filelock: read claim check: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("filelock: claim server: %s", resp.Error)
+	}
+	return resp.Conflicts, nil
+}
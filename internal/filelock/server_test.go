@@ -0,0 +1,100 @@
+package filelock
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConflicts(t *testing.T) {
+	reg, _ := newTestRegistry(t)
+	if err := reg.ClaimMultiple("inst-1", []string{"pkg/b.go", "pkg/a.go"}); err != nil {
+		t.Fatalf("ClaimMultiple() error = %v", err)
+	}
+	if err := reg.Claim("inst-2", "pkg/c.go"); err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+
+	conflicts := reg.Conflicts("inst-2", []string{"pkg/b.go", "./pkg/a.go", "pkg/c.go", "pkg/d.go", "pkg/a.go"})
+	var got []string
+	for _, c := range conflicts {
+		got = append(got, c.FilePath+"@"+c.InstanceID)
+	}
+	if strings.Join(got, ",") != "pkg/a.go@inst-1,pkg/b.go@inst-1" {
+		t.Errorf("Conflicts() = %v, want pkg/a.go and pkg/b.go owned by inst-1", got)
+	}
+	if conflicts := reg.Conflicts("inst-1", []string{"pkg/a.go"}); len(conflicts) != 0 {
+		t.Errorf("Conflicts() for the owner = %v, want none", conflicts)
+	}
+}
+
+func TestServer_Check(t *testing.T) {
+	reg, _ := newTestRegistry(t)
+	if err := reg.Claim("inst-1", "main.go"); err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+
+	srv := NewServer(reg, SocketPath(t.TempDir()))
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer func() { _ = srv.Close() }()
+	if err := srv.Start(); err == nil {
+		t.Error("second Start() should error")
+	}
+
+	conflicts, err := Check(srv.Path(), "inst-2", []string{"main.go", "util.go"})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0] != (Conflict{Path: "main.go", Owner: "inst-1"}) {
+		t.Errorf("Check() = %+v, want main.go owned by inst-1", conflicts)
+	}
+	if conflicts, err := Check(srv.Path(), "inst-1", []string{"main.go"}); err != nil || len(conflicts) != 0 {
+		t.Errorf("Check() for the owner = %+v, %v; want no conflicts", conflicts, err)
+	}
+
+	if err := srv.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := os.Stat(srv.Path()); !os.IsNotExist(err) {
+		t.Errorf("socket still exists after Close: %v", err)
+	}
+	if _, err := Check(srv.Path(), "inst-2", []string{"main.go"}); err == nil {
+		t.Error("Check() against a closed server should error")
+	}
+	if err := srv.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+}
+
+func TestServer_StartRemovesStaleSocket(t *testing.T) {
+	reg, _ := newTestRegistry(t)
+	path := SocketPath(t.TempDir())
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := NewServer(reg, path)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start() over a stale socket error = %v", err)
+	}
+	_ = srv.Close()
+}
+
+func TestSocketPath(t *testing.T) {
+	short := filepath.Join(os.TempDir(), "s")
+	if got := SocketPath(short); got != filepath.Join(short, socketFile) {
+		t.Errorf("SocketPath(short) = %q, want it inside the session directory", got)
+	}
+
+	long := filepath.Join(os.TempDir(), strings.Repeat("d", maxSocketPath))
+	got := SocketPath(long)
+	if len(got) > maxSocketPath || strings.HasPrefix(got, long) {
+		t.Errorf("SocketPath(long) = %q, want a short path outside the session directory", got)
+	}
+	if SocketPath(long) != got || SocketPath(long+"x") == got {
+		t.Error("SocketPath(long) should be stable per session directory and differ between them")
+	}
+}
@@ -0,0 +1,227 @@
+package flake
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheFileName is the verification cache file within the cache directory.
+const CacheFileName = "verify-cache.json"
+
+// maxCacheEntries bounds the cache file; the oldest entries are evicted first.
+const maxCacheEntries = 500
+
+// maxCachedOutput bounds the output kept per entry. Longer output keeps its
+// tail, where build and test failures are reported.
+const maxCachedOutput = 32 * 1024
+
+// CacheEntry is a classified verification result for one command run against
+// one git tree.
+type CacheEntry struct {
+	Tree          string        `json:"tree"`
+	Command       string        `json:"command"`
+	Outcome       Outcome       `json:"outcome"`
+	Output        string        `json:"output,omitempty"`
+	FailureOutput string        `json:"failure_output,omitempty"`
+	Duration      time.Duration `json:"duration"`
+	At            time.Time     `json:"at"`
+
+	FlakyTests       []string `json:"flaky_tests,omitempty"`
+	QuarantinedTests []string `json:"quarantined_tests,omitempty"`
+}
+
+// Cache persists verification results keyed by (tree hash, command), so a
+// command re-run against an identical tree, such as a retry that changed
+// nothing, returns the earlier result instead of running again. The file is
+// re-read on every lookup, so results are shared between sessions and
+// processes using the same directory.
+type Cache struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewCache creates a Cache that keeps its file in dir. The directory is
+// created lazily on first write.
+func NewCache(dir string) *Cache {
+	return &Cache{path: filepath.Join(dir, CacheFileName)}
+}
+
+// Path returns the location of the cache file.
+func (c *Cache) Path() string {
+	return c.path
+}
+
+// Lookup returns the cached entry for command run against tree.
+func (c *Cache) Lookup(tree, command string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := c.load()
+	if err != nil {
+		return CacheEntry{}, false
+	}
+	for _, e := range entries {
+		if e.Tree == tree && e.Command == command {
+			return e, true
+		}
+	}
+	return CacheEntry{}, false
+}
+
+// Store saves e, replacing any entry for the same tree and command. A zero
+// At is replaced with the current time.
+func (c *Cache) Store(e CacheEntry) error {
+	if e.Tree == "" || e.Command == "" {
+		return errors.New("flake: cache entry needs a tree and a command")
+	}
+	if e.At.IsZero() {
+		e.At = time.Now()
+	}
+	e.Output = tail(e.Output, maxCachedOutput)
+	e.FailureOutput = tail(e.FailureOutput, maxCachedOutput)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// A corrupt file is replaced rather than blocking the cache forever
+	entries, _ := c.load()
+	kept := entries[:0]
+	for _, old := range entries {
+		if old.Tree != e.Tree || old.Command != e.Command {
+			kept = append(kept, old)
+		}
+	}
+	kept = append(kept, e)
+	if len(kept) > maxCacheEntries {
+		sort.SliceStable(kept, func(i, j int) bool { return kept[i].At.Before(kept[j].At) })
+		kept = kept[len(kept)-maxCacheEntries:]
+	}
+	return c.save(kept)
+}
+
+// Clear removes the cache file. A missing file is not an error.
+func (c *Cache) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("flake: remove cache: %w", err)
+	}
+	return nil
+}
+
+// load reads the cache file. A missing file yields no entries and no error.
+func (c *Cache) load() ([]CacheEntry, error) {
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("flake: read cache: %w", err)
+	}
+	var entries []CacheEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("flake: parse cache: %w", err)
+	}
+	return entries, nil
+}
+
+// save writes entries via a temporary file so readers never see a torn file.
+func (c *Cache) save(entries []CacheEntry) error {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("flake: marshal cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("flake: create directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), CacheFileName+".*")
+	if err != nil {
+		return fmt.Errorf("flake: write cache: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("flake: write cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("flake: write cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("flake: write cache: %w", err)
+	}
+	return nil
+}
+
+// tail returns the last max bytes of s.
+func tail(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[len(s)-max:]
+}
+
+// TreeHashFunc returns the git tree hash of the working tree in dir.
+type TreeHashFunc func(ctx context.Context, dir string) (string, error)
+
+// TreeHash returns the git tree hash of the working tree in dir, including
+// uncommitted changes and untracked files that are not ignored. It stages
+// into a copy of the index, so the repository's own index is never touched.
+func TreeHash(ctx context.Context, dir string) (string, error) {
+	indexPath, err := gitOutput(ctx, dir, nil, "rev-parse", "--git-path", "index")
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(indexPath) {
+		indexPath = filepath.Join(dir, indexPath)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "claudio-tree-*")
+	if err != nil {
+		return "", fmt.Errorf("flake: create temp index: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	// Starting from the real index keeps git's stat cache, so unchanged
+	// files are not re-hashed
+	tmpIndex := filepath.Join(tmpDir, "index")
+	if data, err := os.ReadFile(indexPath); err == nil {
+		if err := os.WriteFile(tmpIndex, data, 0o644); err != nil {
+			return "", fmt.Errorf("flake: copy index: %w", err)
+		}
+	}
+
+	env := []string{"GIT_INDEX_FILE=" + tmpIndex}
+	if _, err := gitOutput(ctx, dir, env, "add", "--all"); err != nil {
+		return "", err
+	}
+	return gitOutput(ctx, dir, env, "write-tree")
+}
+
+// gitOutput runs git in dir with extra environment and returns its trimmed
+// standard output.
+func gitOutput(ctx context.Context, dir string, env []string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("flake: git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
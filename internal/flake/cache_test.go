@@ -0,0 +1,218 @@
+package flake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/stats"
+)
+
+func TestCache_StoreAndLookup(t *testing.T) {
+	c := NewCache(t.TempDir())
+
+	if _, ok := c.Lookup("tree-1", "go test ./..."); ok {
+		t.Fatal("Lookup() on an empty cache found an entry")
+	}
+
+	if err := c.Store(CacheEntry{Tree: "tree-1", Command: "go test ./...", Outcome: OutcomeFail, Output: "FAIL"}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if err := c.Store(CacheEntry{Tree: "tree-1", Command: "go test ./...", Outcome: OutcomePass, Output: "ok"}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	e, ok := c.Lookup("tree-1", "go test ./...")
+	if !ok || e.Outcome != OutcomePass || e.Output != "ok" || e.At.IsZero() {
+		t.Errorf("Lookup() = (%+v, %v), want the replacing pass entry", e, ok)
+	}
+	if _, ok := c.Lookup("tree-2", "go test ./..."); ok {
+		t.Error("Lookup() matched a different tree")
+	}
+	if _, ok := c.Lookup("tree-1", "go vet ./..."); ok {
+		t.Error("Lookup() matched a different command")
+	}
+
+	// Another Cache on the same directory shares results
+	if _, ok := NewCache(filepath.Dir(c.Path())).Lookup("tree-1", "go test ./..."); !ok {
+		t.Error("second Cache did not see the stored entry")
+	}
+
+	if err := c.Store(CacheEntry{Command: "go test ./..."}); err == nil {
+		t.Error("Store() without a tree succeeded, want error")
+	}
+
+	if err := c.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if _, ok := c.Lookup("tree-1", "go test ./..."); ok {
+		t.Error("Lookup() found an entry after Clear()")
+	}
+	if err := c.Clear(); err != nil {
+		t.Errorf("Clear() of a missing file error = %v", err)
+	}
+}
+
+func TestCache_EvictsOldestAndTruncatesOutput(t *testing.T) {
+	c := NewCache(t.TempDir())
+	start := time.Unix(1000, 0)
+	for i := range maxCacheEntries + 1 {
+		e := CacheEntry{Tree: fmt.Sprintf("tree-%d", i), Command: "make", Outcome: OutcomePass, At: start.Add(time.Duration(i) * time.Second)}
+		if err := c.Store(e); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+	}
+	if _, ok := c.Lookup("tree-0", "make"); ok {
+		t.Error("oldest entry was not evicted")
+	}
+	if _, ok := c.Lookup(fmt.Sprintf("tree-%d", maxCacheEntries), "make"); !ok {
+		t.Error("newest entry is missing")
+	}
+
+	long := strings.Repeat("x", maxCachedOutput) + "tail"
+	if err := c.Store(CacheEntry{Tree: "big", Command: "make", Outcome: OutcomeFail, Output: long}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	e, _ := c.Lookup("big", "make")
+	if len(e.Output) != maxCachedOutput || !strings.HasSuffix(e.Output, "tail") {
+		t.Errorf("cached output length = %d, want the last %d bytes", len(e.Output), maxCachedOutput)
+	}
+}
+
+func TestCache_CorruptFileIsReplaced(t *testing.T) {
+	c := NewCache(t.TempDir())
+	if err := os.WriteFile(c.Path(), []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Lookup("tree-1", "make"); ok {
+		t.Fatal("Lookup() in a corrupt file found an entry")
+	}
+	if err := c.Store(CacheEntry{Tree: "tree-1", Command: "make", Outcome: OutcomePass}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if _, ok := c.Lookup("tree-1", "make"); !ok {
+		t.Error("entry stored over a corrupt file is missing")
+	}
+}
+
+func TestDetector_CachedResults(t *testing.T) {
+	store := stats.NewStore(t.TempDir())
+	cache := NewCache(t.TempDir())
+	tree := "tree-1"
+	treeHash := func(context.Context, string) (string, error) { return tree, nil }
+	var calls int
+	d := NewDetector(store, "sess-1", "/repo",
+		WithRunFunc(scriptedRun(&calls, false, false, true)),
+		WithCache(cache),
+		WithTreeHashFunc(treeHash))
+
+	first := d.Run(context.Background(), "/repo", "go test ./...")
+	if first.Outcome != OutcomeFail || first.Cached {
+		t.Fatalf("first Run() = (%q, cached=%v), want a fresh fail", first.Outcome, first.Cached)
+	}
+
+	// Same tree: the failure is returned without running again
+	again := d.Recheck(context.Background(), "/repo", "go test ./...", "FAIL")
+	if !again.Cached || again.Outcome != OutcomeFail || again.Err == nil {
+		t.Errorf("repeat = (%q, cached=%v, err=%v), want cached fail", again.Outcome, again.Cached, again.Err)
+	}
+	if calls != 2 {
+		t.Errorf("runs = %d, want 2", calls)
+	}
+	if records, _ := store.Query(RecordKind, nil); len(records) != 1 {
+		t.Errorf("recorded %d records, want 1 (cached results are not recorded)", len(records))
+	}
+
+	// A changed tree runs the command
+	tree = "tree-2"
+	if r := d.Recheck(context.Background(), "/repo", "go test ./...", "FAIL"); r.Cached || r.Outcome != OutcomeFlakyPass {
+		t.Errorf("changed tree = (%q, cached=%v), want a fresh flaky pass", r.Outcome, r.Cached)
+	}
+}
+
+func TestDetector_TreeHashErrorSkipsCache(t *testing.T) {
+	cache := NewCache(t.TempDir())
+	var calls int
+	d := NewDetector(nil, "sess-1", "/repo",
+		WithRunFunc(scriptedRun(&calls, true, true)),
+		WithCache(cache),
+		WithTreeHashFunc(func(context.Context, string) (string, error) { return "", errors.New("not a git repository") }))
+
+	d.Run(context.Background(), "/repo", "make")
+	if r := d.Run(context.Background(), "/repo", "make"); r.Cached || calls != 2 {
+		t.Errorf("Run() without a tree hash: cached=%v runs=%d, want uncached and 2 runs", r.Cached, calls)
+	}
+}
+
+func TestTreeHash(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "-q")
+	write("main.go", "package main\n")
+	write(".gitignore", "out/\n")
+	git("add", "-A")
+	git("commit", "-q", "-m", "init")
+
+	ctx := context.Background()
+	committed, err := TreeHash(ctx, dir)
+	if err != nil {
+		t.Fatalf("TreeHash() error = %v", err)
+	}
+	if want := git("rev-parse", "HEAD^{tree}"); committed != want {
+		t.Errorf("TreeHash() of a clean tree = %s, want HEAD tree %s", committed, want)
+	}
+
+	// Ignored files don't change the hash
+	if err := os.MkdirAll(filepath.Join(dir, "out"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write("out/bin", "binary")
+	if h, _ := TreeHash(ctx, dir); h != committed {
+		t.Error("ignored file changed the tree hash")
+	}
+
+	// Untracked and modified files do, without being staged
+	write("new.go", "package main\n")
+	untracked, err := TreeHash(ctx, dir)
+	if err != nil {
+		t.Fatalf("TreeHash() error = %v", err)
+	}
+	if untracked == committed {
+		t.Error("untracked file did not change the tree hash")
+	}
+	if status := git("status", "--porcelain"); !strings.Contains(status, "?? new.go") {
+		t.Errorf("TreeHash() touched the index: status = %q", status)
+	}
+}
+
+func TestTreeHash_NotARepository(t *testing.T) {
+	if _, err := TreeHash(context.Background(), t.TempDir()); err == nil {
+		t.Error("TreeHash() outside a repository succeeded, want error")
+	}
+}
@@ -0,0 +1,44 @@
+// Package flake detects flaky verification commands.
+//
+// A verification command (build, lint, test) that fails is re-run once on its
+// own. If the re-run passes, the result is classified as [OutcomeFlakyPass]
+// rather than a plain pass, so callers can accept it without burning a full
+// retry while still surfacing that the command is unreliable. Every
+// classified run is appended to a [stats.Store] tagged with the repository
+// and outcome, and [Report] ranks commands by how often they flake.
+//
+// A failed go test command is re-run with -run narrowed to the tests that
+// failed ([GoTestFailures]), and tests that pass are reported in
+// [Result.FlakyTests] and recorded per test. When a re-run fails only in
+// tests that already flaked in the session, the command is
+// [OutcomeQuarantined] and counts as passed. [ReportTests] ranks tests.
+//
+// With [WithCache], classified results are also stored in a [Cache] keyed by
+// the git tree hash of the directory ([TreeHash]) and the command. Running
+// the same command against an identical tree returns the cached result with
+// [Result.Cached] set, without running it or recording it again.
+//
+// # Usage
+//
+//	detector := flake.NewDetector(store, sessionID, repoRoot)
+//
+//	// Run a command, re-running it once if it fails
+//	result := detector.Run(ctx, worktreePath, "go test ./...")
+//
+//	// Re-check a command that already failed elsewhere
+//	result = detector.Recheck(ctx, worktreePath, "go test ./...")
+//	if result.Outcome == flake.OutcomeFlakyPass {
+//		// accept the step, but it is flaky
+//	}
+//
+//	summaries, err := flake.Report(store, repoRoot)
+//
+//	// Reuse results for commands already run against an identical tree
+//	detector = flake.NewDetector(store, sessionID, repoRoot,
+//		flake.WithCache(flake.NewCache(claudioDir)))
+//
+// # Thread Safety
+//
+// [Detector] is safe for concurrent use; runs are independent and recording
+// is delegated to the store.
+package flake
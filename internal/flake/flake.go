@@ -0,0 +1,375 @@
+package flake
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/stats"
+)
+
+// RecordKind is the stats record kind for classified verification runs.
+const RecordKind = "verify.command"
+
+// runWaitDelay bounds how long a cancelled command may keep its output pipes
+// open before Wait gives up on it.
+const runWaitDelay = 2 * time.Second
+
+// Outcome classifies a verification command after flake detection.
+type Outcome string
+
+const (
+	// OutcomePass means the command passed on its first run.
+	OutcomePass Outcome = "pass"
+
+	// OutcomeFail means the command failed and failed again when re-run.
+	OutcomeFail Outcome = "fail"
+
+	// OutcomeFlakyPass means the command failed and then passed when re-run
+	// in isolation. Callers may accept the result but should report it apart
+	// from a clean pass.
+	OutcomeFlakyPass Outcome = "flaky-pass"
+
+	// OutcomeQuarantined means the command failed again when re-run, but
+	// only in Go tests that already passed on a re-run earlier in the
+	// session. Known-flaky tests don't fail verification; callers should
+	// report them like a flaky pass.
+	OutcomeQuarantined Outcome = "quarantined"
+)
+
+// Passed reports whether the outcome counts as a passing verification.
+func (o Outcome) Passed() bool {
+	return o == OutcomePass || o == OutcomeFlakyPass || o == OutcomeQuarantined
+}
+
+// Result is the classified outcome of one verification command.
+type Result struct {
+	Command       string
+	Outcome       Outcome
+	Output        string        // Combined output of the last run
+	FailureOutput string        // Output of the first failing run, kept for flaky passes
+	Duration      time.Duration // Total time across runs
+	Err           error         // Error of the last run; nil when the outcome passed
+
+	// FlakyTests are the Go tests that failed and then passed on the re-run,
+	// and QuarantinedTests those that failed again but are known flaky. Both
+	// hold package-qualified names (see Test.String).
+	FlakyTests       []string
+	QuarantinedTests []string
+
+	// Cached means the result was read from the verification cache for an
+	// identical tree instead of running the command; Duration is that of the
+	// original run.
+	Cached bool
+}
+
+// RunFunc runs a shell command in dir and returns its combined output. A
+// non-nil error means the command failed.
+type RunFunc func(ctx context.Context, dir, command string) ([]byte, error)
+
+// ShellRun runs command via sh -c in dir with the current environment.
+func ShellRun(ctx context.Context, dir, command string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+	cmd.WaitDelay = runWaitDelay
+	return cmd.CombinedOutput()
+}
+
+// Detector runs verification commands, re-runs failures once, and records
+// the classified outcomes.
+type Detector struct {
+	store     *stats.Store
+	sessionID string
+	repo      string
+	run       RunFunc
+	cache     *Cache
+	treeHash  TreeHashFunc
+
+	mu         sync.Mutex
+	flakyTests map[string]bool // Tests that passed on a re-run by this detector
+}
+
+// Option configures a Detector.
+type Option func(*Detector)
+
+// WithRunFunc replaces how commands are executed. Intended for tests.
+func WithRunFunc(run RunFunc) Option {
+	return func(d *Detector) {
+		d.run = run
+	}
+}
+
+// WithCache short-circuits commands already classified against an identical
+// git tree, returning the cached result. A nil cache disables caching.
+func WithCache(cache *Cache) Option {
+	return func(d *Detector) {
+		d.cache = cache
+	}
+}
+
+// WithTreeHashFunc replaces how the tree hash of a directory is computed for
+// the cache. Intended for tests.
+func WithTreeHashFunc(treeHash TreeHashFunc) Option {
+	return func(d *Detector) {
+		d.treeHash = treeHash
+	}
+}
+
+// NewDetector creates a Detector that records outcomes for repo to store. A
+// nil store disables recording.
+func NewDetector(store *stats.Store, sessionID, repo string, opts ...Option) *Detector {
+	d := &Detector{
+		store:      store,
+		sessionID:  sessionID,
+		repo:       repo,
+		run:        ShellRun,
+		treeHash:   TreeHash,
+		flakyTests: make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Run runs command in dir. If it fails, it is re-run once and the result is
+// OutcomeFlakyPass when the re-run passes. Runs cut short by ctx are returned
+// as failures and not recorded, since they say nothing about the command.
+// With a cache, a command already classified against the same tree returns
+// the cached result without running.
+func (d *Detector) Run(ctx context.Context, dir, command string) Result {
+	tree := d.tree(ctx, dir)
+	if r, ok := d.lookup(tree, command); ok {
+		return r
+	}
+
+	start := time.Now()
+	output, err := d.run(ctx, dir, command)
+	if err == nil {
+		r := Result{Command: command, Outcome: OutcomePass, Output: string(output), Duration: time.Since(start)}
+		return d.record(tree, r, 1)
+	}
+	if ctx.Err() != nil {
+		return Result{Command: command, Outcome: OutcomeFail, Output: string(output), Duration: time.Since(start), Err: ctx.Err()}
+	}
+
+	r := d.rerun(ctx, dir, command, string(output))
+	r.Duration = time.Since(start)
+	return d.record(tree, r, 2)
+}
+
+// Recheck re-runs a command that already failed elsewhere, such as in a
+// verification run reported by an instance, and classifies it: a passing
+// re-run is OutcomeFlakyPass, a failing one OutcomeFail. The command is run
+// alone, so failures caused by contention with other steps do not repeat.
+// With a cache, a command already rechecked against the same tree returns
+// the cached result without running.
+func (d *Detector) Recheck(ctx context.Context, dir, command, failureOutput string) Result {
+	tree := d.tree(ctx, dir)
+	if r, ok := d.lookup(tree, command); ok {
+		return r
+	}
+
+	start := time.Now()
+	r := d.rerun(ctx, dir, command, failureOutput)
+	r.Duration = time.Since(start)
+	if ctx.Err() != nil {
+		return r
+	}
+	return d.record(tree, r, 2)
+}
+
+// tree returns the cache key for dir, or "" when caching is disabled or the
+// tree hash cannot be computed (e.g. dir is not a git worktree).
+func (d *Detector) tree(ctx context.Context, dir string) string {
+	if d.cache == nil {
+		return ""
+	}
+	tree, err := d.treeHash(ctx, dir)
+	if err != nil {
+		return ""
+	}
+	return tree
+}
+
+// lookup returns the cached result of command for tree. Cached results are
+// not recorded again, so repeats don't skew flake rates.
+func (d *Detector) lookup(tree, command string) (Result, bool) {
+	if tree == "" {
+		return Result{}, false
+	}
+	e, ok := d.cache.Lookup(tree, command)
+	if !ok {
+		return Result{}, false
+	}
+	r := Result{
+		Command:          command,
+		Outcome:          e.Outcome,
+		Output:           e.Output,
+		FailureOutput:    e.FailureOutput,
+		Duration:         e.Duration,
+		FlakyTests:       e.FlakyTests,
+		QuarantinedTests: e.QuarantinedTests,
+		Cached:           true,
+	}
+	if !e.Outcome.Passed() {
+		r.Err = errors.New("cached failure")
+	}
+	return r, true
+}
+
+// rerun runs a command that has failed once and classifies the second run.
+// When the failure is accounted for by failed Go tests, only those tests
+// are re-run. Tests that pass on the re-run are flaky; if the re-run fails
+// only in tests already known to be flaky, the command is quarantined.
+func (d *Detector) rerun(ctx context.Context, dir, command, failureOutput string) Result {
+	failed, complete := GoTestFailures(failureOutput)
+	rerunCommand := command
+	if complete {
+		if narrowed, ok := rerunTestsCommand(command, failed); ok {
+			rerunCommand = narrowed
+		}
+	}
+
+	output, err := d.run(ctx, dir, rerunCommand)
+	r := Result{Command: command, Output: string(output), FailureOutput: failureOutput}
+	if err == nil {
+		r.Outcome = OutcomeFlakyPass
+		r.FlakyTests = testNames(failed)
+		return r
+	}
+	if ctx.Err() != nil {
+		r.Outcome = OutcomeFail
+		r.Err = ctx.Err()
+		return r
+	}
+
+	r.Outcome = OutcomeFail
+	r.Err = err
+	again, againComplete := GoTestFailures(r.Output)
+	failedAgain := testNames(again)
+	r.FlakyTests = slices.DeleteFunc(testNames(failed), func(t string) bool {
+		return slices.Contains(failedAgain, t)
+	})
+	if againComplete && d.knownFlaky(failedAgain) {
+		r.Outcome = OutcomeQuarantined
+		r.Err = nil
+		r.QuarantinedTests = failedAgain
+	}
+	return r
+}
+
+// record appends r and its flaky and quarantined tests to the stats store
+// and caches it for tree. Recording and caching are best-effort: a store or
+// cache error never changes the verification result.
+func (d *Detector) record(tree string, r Result, runs int) Result {
+	d.recordTests(r)
+	if tree != "" {
+		_ = d.cache.Store(CacheEntry{
+			Tree:             tree,
+			Command:          r.Command,
+			Outcome:          r.Outcome,
+			Output:           r.Output,
+			FailureOutput:    r.FailureOutput,
+			Duration:         r.Duration,
+			FlakyTests:       r.FlakyTests,
+			QuarantinedTests: r.QuarantinedTests,
+		})
+	}
+	if d.store == nil {
+		return r
+	}
+	_ = d.store.Append(stats.Record{
+		Kind:    RecordKind,
+		Session: d.sessionID,
+		Subject: r.Command,
+		Tags:    map[string]string{"repo": d.repo, "outcome": string(r.Outcome)},
+		Values: map[string]float64{
+			"runs":    float64(runs),
+			"seconds": r.Duration.Seconds(),
+			"flaky":   boolValue(r.Outcome == OutcomeFlakyPass),
+			"failed":  boolValue(r.Outcome == OutcomeFail),
+		},
+	})
+	return r
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// CommandSummary aggregates recorded outcomes for one command in one repository.
+type CommandSummary struct {
+	Repo        string
+	Command     string
+	Runs        int     // Classified runs of the command
+	Passes      int     // Runs that passed the first time
+	FlakyPasses int     // Runs that failed and then passed on re-run, or failed again only in known-flaky tests
+	Failures    int     // Runs that failed twice
+	FlakeRate   float64 // FlakyPasses / Runs
+	LastFlaky   time.Time
+}
+
+// Report aggregates recorded outcomes per repository and command, sorted by
+// flake rate (highest first), then by flaky-pass count, repository, and
+// command. An empty repo includes every repository.
+func Report(store *stats.Store, repo string) ([]CommandSummary, error) {
+	records, err := store.Query(RecordKind, func(r stats.Record) bool {
+		return repo == "" || r.Tags["repo"] == repo
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct{ repo, command string }
+	byCommand := make(map[key]*CommandSummary)
+	for _, r := range records {
+		k := key{r.Tags["repo"], r.Subject}
+		s, ok := byCommand[k]
+		if !ok {
+			s = &CommandSummary{Repo: k.repo, Command: k.command}
+			byCommand[k] = s
+		}
+		s.Runs++
+		switch Outcome(r.Tags["outcome"]) {
+		case OutcomePass:
+			s.Passes++
+		case OutcomeFlakyPass, OutcomeQuarantined:
+			s.FlakyPasses++
+			if r.Time.After(s.LastFlaky) {
+				s.LastFlaky = r.Time
+			}
+		case OutcomeFail:
+			s.Failures++
+		}
+	}
+
+	out := make([]CommandSummary, 0, len(byCommand))
+	for _, s := range byCommand {
+		s.FlakeRate = float64(s.FlakyPasses) / float64(s.Runs)
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.FlakeRate != b.FlakeRate {
+			return a.FlakeRate > b.FlakeRate
+		}
+		if a.FlakyPasses != b.FlakyPasses {
+			return a.FlakyPasses > b.FlakyPasses
+		}
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		return a.Command < b.Command
+	})
+	return out, nil
+}
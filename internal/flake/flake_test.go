@@ -0,0 +1,173 @@
+package flake
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/stats"
+)
+
+// scriptedRun returns a RunFunc that fails or passes according to results,
+// one entry per call, and counts the calls.
+func scriptedRun(calls *int, results ...bool) RunFunc {
+	return func(ctx context.Context, dir, command string) ([]byte, error) {
+		pass := results[*calls]
+		*calls++
+		if pass {
+			return []byte("ok"), nil
+		}
+		return []byte("FAIL"), errors.New("exit status 1")
+	}
+}
+
+func TestDetector_Run(t *testing.T) {
+	tests := []struct {
+		name      string
+		results   []bool
+		want      Outcome
+		wantCalls int
+	}{
+		{"pass", []bool{true}, OutcomePass, 1},
+		{"flaky pass", []bool{false, true}, OutcomeFlakyPass, 2},
+		{"fail", []bool{false, false}, OutcomeFail, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := stats.NewStore(t.TempDir())
+			var calls int
+			d := NewDetector(store, "sess-1", "/repo", WithRunFunc(scriptedRun(&calls, tt.results...)))
+
+			r := d.Run(context.Background(), "/repo", "go test ./...")
+			if r.Outcome != tt.want {
+				t.Errorf("Outcome = %q, want %q", r.Outcome, tt.want)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("runs = %d, want %d", calls, tt.wantCalls)
+			}
+			if r.Outcome.Passed() != (r.Err == nil) {
+				t.Errorf("Passed() = %v with Err = %v", r.Outcome.Passed(), r.Err)
+			}
+			if tt.want == OutcomeFlakyPass && r.FailureOutput != "FAIL" {
+				t.Errorf("FailureOutput = %q, want first run output", r.FailureOutput)
+			}
+
+			records, err := store.Query(RecordKind, nil)
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			if len(records) != 1 {
+				t.Fatalf("recorded %d records, want 1", len(records))
+			}
+			rec := records[0]
+			if rec.Subject != "go test ./..." || rec.Tags["repo"] != "/repo" || rec.Tags["outcome"] != string(tt.want) {
+				t.Errorf("record = %+v", rec)
+			}
+		})
+	}
+}
+
+func TestDetector_Recheck(t *testing.T) {
+	store := stats.NewStore(t.TempDir())
+	var calls int
+	d := NewDetector(store, "sess-1", "/repo", WithRunFunc(scriptedRun(&calls, true)))
+
+	r := d.Recheck(context.Background(), "/repo", "npm test", "1 failing")
+	if r.Outcome != OutcomeFlakyPass {
+		t.Errorf("Outcome = %q, want %q", r.Outcome, OutcomeFlakyPass)
+	}
+	if calls != 1 {
+		t.Errorf("runs = %d, want 1", calls)
+	}
+	if r.FailureOutput != "1 failing" {
+		t.Errorf("FailureOutput = %q, want reported output", r.FailureOutput)
+	}
+}
+
+func TestDetector_Run_CancelledNotRecorded(t *testing.T) {
+	store := stats.NewStore(t.TempDir())
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int
+	run := func(ctx context.Context, dir, command string) ([]byte, error) {
+		calls++
+		cancel()
+		return nil, errors.New("signal: killed")
+	}
+	d := NewDetector(store, "sess-1", "/repo", WithRunFunc(run))
+
+	r := d.Run(ctx, "/repo", "make test")
+	if r.Outcome != OutcomeFail || !errors.Is(r.Err, context.Canceled) {
+		t.Errorf("Run() = (%q, %v), want fail with context.Canceled", r.Outcome, r.Err)
+	}
+	if calls != 1 {
+		t.Errorf("runs = %d, want 1 (no re-run after cancellation)", calls)
+	}
+	if records, _ := store.Query(RecordKind, nil); len(records) != 0 {
+		t.Errorf("recorded %d records for a cancelled run, want 0", len(records))
+	}
+}
+
+func TestShellRun(t *testing.T) {
+	if out, err := ShellRun(context.Background(), t.TempDir(), "echo hi"); err != nil || string(out) != "hi\n" {
+		t.Errorf("ShellRun(echo) = (%q, %v)", out, err)
+	}
+	if _, err := ShellRun(context.Background(), t.TempDir(), "exit 3"); err == nil {
+		t.Error("ShellRun(exit 3) error = nil, want failure")
+	}
+}
+
+func TestReport(t *testing.T) {
+	store := stats.NewStore(t.TempDir())
+	add := func(repo, command string, outcome Outcome) {
+		t.Helper()
+		if err := store.Append(stats.Record{
+			Kind:    RecordKind,
+			Subject: command,
+			Tags:    map[string]string{"repo": repo, "outcome": string(outcome)},
+		}); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+	add("/a", "go test ./...", OutcomePass)
+	add("/a", "go test ./...", OutcomeFlakyPass)
+	add("/a", "go vet ./...", OutcomePass)
+	add("/a", "npm test", OutcomeFlakyPass)
+	add("/a", "npm test", OutcomeFail)
+	add("/b", "go test ./...", OutcomeFlakyPass)
+
+	all, err := Report(store, "")
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if len(all) != 4 {
+		t.Fatalf("Report() returned %d summaries, want 4", len(all))
+	}
+	if all[0].Repo != "/b" || all[0].FlakeRate != 1 {
+		t.Errorf("flakiest = %+v, want /b go test at 100%%", all[0])
+	}
+	last := all[len(all)-1]
+	if last.Command != "go vet ./..." || last.FlakyPasses != 0 {
+		t.Errorf("least flaky = %+v, want go vet", last)
+	}
+
+	repoA, err := Report(store, "/a")
+	if err != nil {
+		t.Fatalf("Report(/a) error = %v", err)
+	}
+	if len(repoA) != 3 {
+		t.Fatalf("Report(/a) returned %d summaries, want 3", len(repoA))
+	}
+	for _, s := range repoA {
+		if s.Repo != "/a" {
+			t.Errorf("Report(/a) included repo %q", s.Repo)
+		}
+	}
+	// npm test and go test both flake half the time; ties sort by command.
+	if repoA[0].Command != "go test ./..." || repoA[1].Command != "npm test" {
+		t.Errorf("Report(/a) order = %q, %q", repoA[0].Command, repoA[1].Command)
+	}
+	if repoA[1].Failures != 1 || repoA[1].Runs != 2 {
+		t.Errorf("npm test summary = %+v, want 2 runs with 1 failure", repoA[1])
+	}
+}
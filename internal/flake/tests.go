@@ -0,0 +1,216 @@
+package flake
+
+import (
+	"bufio"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/stats"
+)
+
+// TestRecordKind is the stats record kind for individual tests classified
+// by a re-run as flaky-pass or quarantined.
+const TestRecordKind = "verify.test"
+
+var (
+	// goTestFailPattern matches a failed top-level test in go test output.
+	// Subtests are indented and run again with their parent.
+	goTestFailPattern = regexp.MustCompile(`^--- FAIL: (\S+)`)
+
+	// goPackageFailPattern matches the line go test prints for a failed
+	// package, e.g. "FAIL\texample.com/x\t0.01s" or "FAIL\texample.com/x [build failed]".
+	goPackageFailPattern = regexp.MustCompile(`^FAIL\s+(\S+)`)
+
+	// goTestCommandPattern matches a plain go test invocation that failed
+	// tests can be re-run with by appending -run.
+	goTestCommandPattern = regexp.MustCompile(`^go\s+test(\s|$)`)
+)
+
+// Test identifies a top-level Go test.
+type Test struct {
+	Package string
+	Name    string
+}
+
+// String returns the test's package-qualified name, e.g. "example.com/x.TestLogin".
+func (t Test) String() string {
+	return t.Package + "." + t.Name
+}
+
+// GoTestFailures returns the top-level tests that failed in go test output.
+// complete reports whether every failed package is accounted for by its
+// failed tests: a package that failed to build, panicked outside a test, or
+// timed out has none, and re-running only the failed tests would miss it.
+func GoTestFailures(output string) (tests []Test, complete bool) {
+	complete = true
+	var pending []string
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := goTestFailPattern.FindStringSubmatch(line); m != nil {
+			pending = append(pending, m[1])
+			continue
+		}
+		if m := goPackageFailPattern.FindStringSubmatch(line); m != nil {
+			if len(pending) == 0 {
+				complete = false
+			}
+			for _, name := range pending {
+				tests = append(tests, Test{Package: m[1], Name: name})
+			}
+			pending = nil
+		}
+	}
+	if len(pending) > 0 || len(tests) == 0 {
+		complete = false
+	}
+	return tests, complete
+}
+
+// rerunTestsCommand returns command narrowed to the failed tests with -run,
+// so a re-run repeats only the test binaries' failures. ok is false when
+// command is not a plain go test invocation: pipes, quoting, and
+// redirections make appending a flag unsafe.
+func rerunTestsCommand(command string, tests []Test) (string, bool) {
+	if !goTestCommandPattern.MatchString(command) || strings.ContainsAny(command, "|&;<>()$`\\\"'\n") ||
+		strings.Contains(command, "-args") || len(tests) == 0 {
+		return "", false
+	}
+	var names []string
+	for _, t := range tests {
+		names = append(names, t.Name)
+	}
+	slices.Sort(names)
+	names = slices.Compact(names)
+	return command + " -run '^(" + strings.Join(names, "|") + ")$'", true
+}
+
+// testNames returns the package-qualified names of tests.
+func testNames(tests []Test) []string {
+	names := make([]string, 0, len(tests))
+	for _, t := range tests {
+		names = append(names, t.String())
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// knownFlaky reports whether every test passed on a re-run earlier in the
+// session, in this detector or in another recording to the same store.
+func (d *Detector) knownFlaky(tests []string) bool {
+	d.mu.Lock()
+	unknown := slices.DeleteFunc(slices.Clone(tests), func(t string) bool { return d.flakyTests[t] })
+	d.mu.Unlock()
+	if len(unknown) == 0 {
+		return true
+	}
+	if d.store == nil || d.sessionID == "" {
+		return false
+	}
+
+	records, err := d.store.Query(TestRecordKind, func(r stats.Record) bool {
+		return r.Session == d.sessionID && r.Tags["repo"] == d.repo && Outcome(r.Tags["outcome"]) == OutcomeFlakyPass
+	})
+	if err != nil {
+		return false
+	}
+	for _, r := range records {
+		unknown = slices.DeleteFunc(unknown, func(t string) bool { return t == r.Subject })
+	}
+	return len(unknown) == 0
+}
+
+// recordTests appends a record per flaky and quarantined test of r and
+// remembers the flaky ones for the rest of the session.
+func (d *Detector) recordTests(r Result) {
+	d.mu.Lock()
+	for _, t := range r.FlakyTests {
+		d.flakyTests[t] = true
+	}
+	d.mu.Unlock()
+
+	if d.store == nil {
+		return
+	}
+	record := func(test string, outcome Outcome) {
+		_ = d.store.Append(stats.Record{
+			Kind:    TestRecordKind,
+			Session: d.sessionID,
+			Subject: test,
+			Tags:    map[string]string{"repo": d.repo, "outcome": string(outcome), "command": r.Command},
+		})
+	}
+	for _, t := range r.FlakyTests {
+		record(t, OutcomeFlakyPass)
+	}
+	for _, t := range r.QuarantinedTests {
+		record(t, OutcomeQuarantined)
+	}
+}
+
+// TestSummary aggregates the recorded re-run outcomes of one Go test in one
+// repository.
+type TestSummary struct {
+	Repo        string
+	Test        string // Package-qualified name
+	FlakyPasses int    // Re-runs the test passed after failing
+	Quarantined int    // Re-runs it failed again after it was known flaky
+	Sessions    int    // Sessions it flaked in
+	LastFlaky   time.Time
+}
+
+// ReportTests aggregates recorded test outcomes per repository and test,
+// sorted by flaky-pass count (highest first), then by repository and test.
+// An empty repo includes every repository.
+func ReportTests(store *stats.Store, repo string) ([]TestSummary, error) {
+	records, err := store.Query(TestRecordKind, func(r stats.Record) bool {
+		return repo == "" || r.Tags["repo"] == repo
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct{ repo, test string }
+	byTest := make(map[key]*TestSummary)
+	sessions := make(map[key]map[string]bool)
+	for _, r := range records {
+		k := key{r.Tags["repo"], r.Subject}
+		s, ok := byTest[k]
+		if !ok {
+			s = &TestSummary{Repo: k.repo, Test: k.test}
+			byTest[k] = s
+			sessions[k] = make(map[string]bool)
+		}
+		switch Outcome(r.Tags["outcome"]) {
+		case OutcomeFlakyPass:
+			s.FlakyPasses++
+			sessions[k][r.Session] = true
+			if r.Time.After(s.LastFlaky) {
+				s.LastFlaky = r.Time
+			}
+		case OutcomeQuarantined:
+			s.Quarantined++
+		}
+	}
+
+	out := make([]TestSummary, 0, len(byTest))
+	for k, s := range byTest {
+		s.Sessions = len(sessions[k])
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.FlakyPasses != b.FlakyPasses {
+			return a.FlakyPasses > b.FlakyPasses
+		}
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		return a.Test < b.Test
+	})
+	return out, nil
+}
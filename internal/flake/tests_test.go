@@ -0,0 +1,118 @@
+package flake
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/stats"
+)
+
+const goTestFailure = `--- FAIL: TestLogin (0.01s)
+    login_test.go:12: timed out waiting for server
+--- FAIL: TestRefresh (0.00s)
+    --- FAIL: TestRefresh/expired (0.00s)
+FAIL
+FAIL	example.com/app/auth	0.020s
+ok  	example.com/app/store	0.004s
+FAIL
+`
+
+func TestGoTestFailures(t *testing.T) {
+	tests := []struct {
+		name         string
+		output       string
+		want         []string
+		wantComplete bool
+	}{
+		{name: "failed tests", output: goTestFailure, want: []string{"example.com/app/auth.TestLogin", "example.com/app/auth.TestRefresh"}, wantComplete: true},
+		{name: "build failure", output: "FAIL\texample.com/app/auth [build failed]\n"},
+		{
+			name:   "panic outside a test",
+			output: goTestFailure + "panic: init failed\nFAIL\texample.com/app/api\t0.003s\n",
+			want:   []string{"example.com/app/auth.TestLogin", "example.com/app/auth.TestRefresh"},
+		},
+		{name: "not go test", output: "1 failing\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, complete := GoTestFailures(tt.output)
+			if names := testNames(got); !slices.Equal(names, tt.want) {
+				t.Errorf("GoTestFailures() = %v, want %v", names, tt.want)
+			}
+			if complete != tt.wantComplete {
+				t.Errorf("complete = %v, want %v", complete, tt.wantComplete)
+			}
+		})
+	}
+}
+
+func TestRerunTestsCommand(t *testing.T) {
+	failed := []Test{{"example.com/a", "TestB"}, {"example.com/b", "TestA"}, {"example.com/c", "TestB"}}
+	got, ok := rerunTestsCommand("go test -race ./...", failed)
+	if !ok || got != "go test -race ./... -run '^(TestA|TestB)$'" {
+		t.Errorf("rerunTestsCommand() = %q, %v", got, ok)
+	}
+	for _, command := range []string{"make test", "go test ./... | tee out.txt", "go test -tags 'a b' ./...", "go test ./... -args -v"} {
+		if _, ok := rerunTestsCommand(command, failed); ok {
+			t.Errorf("rerunTestsCommand(%q) narrowed a command it should re-run whole", command)
+		}
+	}
+}
+
+func TestDetector_FlakyTests(t *testing.T) {
+	store := stats.NewStore(t.TempDir())
+	var commands []string
+	// The first run fails TestLogin and TestRefresh; re-runs fail only TestLogin
+	run := func(_ context.Context, _, command string) ([]byte, error) {
+		commands = append(commands, command)
+		if len(commands) == 1 {
+			return []byte(goTestFailure), errors.New("exit status 1")
+		}
+		return []byte("--- FAIL: TestLogin (0.01s)\nFAIL\nFAIL\texample.com/app/auth\t0.020s\n"), errors.New("exit status 1")
+	}
+	d := NewDetector(store, "sess-1", "/repo", WithRunFunc(run))
+
+	r := d.Run(context.Background(), "/repo", "go test ./...")
+	if r.Outcome != OutcomeFail {
+		t.Fatalf("Outcome = %q, want fail while TestLogin is not known flaky", r.Outcome)
+	}
+	if commands[1] != "go test ./... -run '^(TestLogin|TestRefresh)$'" {
+		t.Errorf("re-ran %q, want only the failed tests", commands[1])
+	}
+	if !slices.Equal(r.FlakyTests, []string{"example.com/app/auth.TestRefresh"}) {
+		t.Errorf("FlakyTests = %v, want TestRefresh", r.FlakyTests)
+	}
+
+	// TestLogin passes on a re-run once, in another detector of the session
+	flaky := NewDetector(store, "sess-1", "/repo", WithRunFunc(func(context.Context, string, string) ([]byte, error) { return nil, nil }))
+	flaky.Recheck(context.Background(), "/repo", "go test ./...", goTestFailure)
+
+	commands = nil
+	r = d.Run(context.Background(), "/repo", "go test ./...")
+	if r.Outcome != OutcomeQuarantined || !r.Outcome.Passed() || r.Err != nil {
+		t.Fatalf("Run() = (%q, %v), want quarantined", r.Outcome, r.Err)
+	}
+	if !slices.Equal(r.QuarantinedTests, []string{"example.com/app/auth.TestLogin"}) {
+		t.Errorf("QuarantinedTests = %v, want TestLogin", r.QuarantinedTests)
+	}
+
+	// Another session knows nothing about TestLogin
+	commands = nil
+	other := NewDetector(store, "sess-2", "/repo", WithRunFunc(run))
+	if r := other.Run(context.Background(), "/repo", "go test ./..."); r.Outcome != OutcomeFail {
+		t.Errorf("Outcome in another session = %q, want fail", r.Outcome)
+	}
+
+	summaries, err := ReportTests(store, "/repo")
+	if err != nil {
+		t.Fatalf("ReportTests() error = %v", err)
+	}
+	if len(summaries) != 2 || summaries[0].Test != "example.com/app/auth.TestRefresh" || summaries[0].FlakyPasses != 4 || summaries[0].Sessions != 2 {
+		t.Fatalf("ReportTests() = %+v, want TestRefresh first with 4 flaky passes in 2 sessions", summaries)
+	}
+	if login := summaries[1]; login.FlakyPasses != 1 || login.Quarantined != 1 {
+		t.Errorf("TestLogin summary = %+v, want 1 flaky pass and 1 quarantine", login)
+	}
+}
@@ -0,0 +1,43 @@
+// Package headless runs an ultraplan to completion without a terminal UI,
+// for long runs on a remote server or in CI.
+//
+// A [Runner] takes over the steps the TUI leaves to a human. It polls for
+// the planning instance's plan file, installs and approves the plan, starts
+// execution, approves synthesis, and lets consolidation open the pull
+// requests. Task execution and consolidation themselves are monitored by
+// the orchestrator as usual; the runner follows them through coordinator
+// callbacks. A run stops without a human in three cases: the ultraplan
+// completes or fails, an execution group partly fails (unless
+// Config.ContinueOnPartialFailure is set), or consolidation pauses on a
+// merge conflict. The session stays on disk in every case, so it can be
+// attached to with 'claudio sessions attach'.
+//
+// Progress is served over a local HTTP API, and a [Report] is written as
+// JSON when the run ends:
+//
+//	GET /status   current phase, progress and per-task state
+//	GET /report   the final report, 404 until the run ends
+//
+// Nothing in this package imports Bubble Tea.
+//
+// # Main Types
+//
+//   - [Runner]: Drives a Coordinator and serves its progress
+//   - [Status]: Point-in-time progress of a run
+//   - [Report]: Final result, with an [Outcome] and phase timeline
+//
+// # Usage
+//
+//	runner := headless.New(orch, coord, headless.Config{
+//		SessionID:  sessionID,
+//		ReportPath: filepath.Join(sessionDir, "headless-report.json"),
+//		Output:     os.Stdout,
+//	})
+//	if err := runner.Serve("127.0.0.1:7879"); err != nil {
+//		return err
+//	}
+//	defer runner.Close(ctx)
+//	report, err := runner.Run(ctx)
+//
+// The status API is read-only and unauthenticated; bind it to loopback.
+package headless
@@ -0,0 +1,226 @@
+package headless
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+)
+
+// Outcome is how a headless run ended.
+type Outcome string
+
+const (
+	// OutcomeComplete means the ultraplan finished, including consolidation.
+	OutcomeComplete Outcome = "complete"
+	// OutcomePlanned means a dry run produced a plan without executing it.
+	OutcomePlanned Outcome = "planned"
+	// OutcomeFailed means the ultraplan failed.
+	OutcomeFailed Outcome = "failed"
+	// OutcomeNeedsAttention means the run stopped at a decision it does not
+	// make on its own; the session can be attached to and continued.
+	OutcomeNeedsAttention Outcome = "needs_attention"
+	// OutcomeCancelled means the run was interrupted.
+	OutcomeCancelled Outcome = "cancelled"
+)
+
+// Task states reported in TaskStatus.State.
+const (
+	TaskPending   = "pending"
+	TaskRunning   = "running"
+	TaskCompleted = "completed"
+	TaskFailed    = "failed"
+)
+
+// TaskStatus is the progress of one planned task.
+type TaskStatus struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Group      int    `json:"group"` // 1-based execution group, 0 if unknown
+	State      string `json:"state"`
+	InstanceID string `json:"instance_id,omitempty"`
+	Reason     string `json:"reason,omitempty"` // Why a failed task failed
+}
+
+// Status is a point-in-time view of a headless run, served by GET /status.
+type Status struct {
+	SessionID    string                      `json:"session_id,omitempty"`
+	UltraPlanID  string                      `json:"ultraplan_id,omitempty"`
+	Objective    string                      `json:"objective"`
+	Phase        orchestrator.UltraPlanPhase `json:"phase"`
+	Completed    int                         `json:"completed"`
+	Total        int                         `json:"total"`
+	CurrentGroup int                         `json:"current_group"` // 1-based, 0 before execution
+	Groups       int                         `json:"groups"`
+	Tasks        []TaskStatus                `json:"tasks,omitempty"`
+	PRURLs       []string                    `json:"pr_urls,omitempty"`
+	Error        string                      `json:"error,omitempty"`
+	Done         bool                        `json:"done"`
+	StartedAt    time.Time                   `json:"started_at"`
+	UpdatedAt    time.Time                   `json:"updated_at"`
+}
+
+// TimelineEntry records when the ultraplan entered a phase.
+type TimelineEntry struct {
+	Time  time.Time                   `json:"time"`
+	Phase orchestrator.UltraPlanPhase `json:"phase"`
+}
+
+// Report is the final result of a headless run, written to
+// Config.ReportPath and served by GET /report.
+type Report struct {
+	Status
+	Outcome    Outcome         `json:"outcome"`
+	Reason     string          `json:"reason,omitempty"`  // Why the run stopped, unless it completed
+	Summary    string          `json:"summary,omitempty"` // The coordinator's completion summary
+	FinishedAt time.Time       `json:"finished_at"`
+	Duration   string          `json:"duration"`
+	Timeline   []TimelineEntry `json:"timeline,omitempty"`
+
+	// DryRun holds the task prompts, execution groups, and estimates of a
+	// dry run; nil for any other outcome.
+	DryRun *orchestrator.DryRunReport `json:"dry_run,omitempty"`
+}
+
+// Status returns the current status of the run.
+func (r *Runner) Status() Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.status
+	s.Tasks = slices.Clone(s.Tasks)
+	s.PRURLs = slices.Clone(s.PRURLs)
+	return s
+}
+
+// Report returns the final report, or nil while the run is in progress.
+func (r *Runner) Report() *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.report
+}
+
+// refreshStatus rebuilds the cached status from session. It runs on the
+// Run goroutine so HTTP handlers never read the session directly.
+func (r *Runner) refreshStatus(session *orchestrator.UltraPlanSession) {
+	completed, total, phase := r.coord.GetProgress()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := &r.status
+	s.UltraPlanID = session.ID
+	s.Objective = session.Objective
+	s.Phase = phase
+	s.Completed = completed
+	s.Total = total
+	s.Error = session.Error
+	s.UpdatedAt = time.Now()
+	if session.Consolidation != nil {
+		s.PRURLs = slices.Clone(session.Consolidation.PRUrls)
+	}
+	s.Tasks = s.Tasks[:0:0]
+	s.Groups, s.CurrentGroup = 0, 0
+	plan := session.Plan
+	if plan == nil {
+		return
+	}
+	s.Groups = len(plan.ExecutionOrder)
+	if phase == orchestrator.PhaseExecuting {
+		s.CurrentGroup = session.CurrentGroup + 1
+	}
+
+	groupOf := make(map[string]int, len(plan.Tasks))
+	for i, group := range plan.ExecutionOrder {
+		for _, id := range group {
+			groupOf[id] = i + 1
+		}
+	}
+	for _, task := range plan.Tasks {
+		ts := TaskStatus{
+			ID:         task.ID,
+			Title:      task.Title,
+			Group:      groupOf[task.ID],
+			State:      TaskPending,
+			InstanceID: session.TaskToInstance[task.ID],
+		}
+		switch {
+		case slices.Contains(session.CompletedTasks, task.ID):
+			ts.State = TaskCompleted
+		case slices.Contains(session.FailedTasks, task.ID):
+			ts.State = TaskFailed
+			ts.Reason = r.failures[task.ID]
+		case ts.InstanceID != "":
+			ts.State = TaskRunning
+		}
+		s.Tasks = append(s.Tasks, ts)
+	}
+}
+
+// finish records the final report for outcome and returns it.
+func (r *Runner) finish(outcome Outcome, reason string) *Report {
+	if session := r.coord.Session(); session != nil {
+		r.refreshStatus(session)
+	}
+
+	r.mu.Lock()
+	now := time.Now()
+	r.status.Done = true
+	r.status.UpdatedAt = now
+	report := &Report{
+		Status:     r.status,
+		Outcome:    outcome,
+		Reason:     reason,
+		Summary:    r.summary,
+		FinishedAt: now,
+		Duration:   now.Sub(r.status.StartedAt).Round(time.Second).String(),
+		Timeline:   slices.Clone(r.timeline),
+		DryRun:     r.dryRun,
+	}
+	report.Tasks = slices.Clone(report.Tasks)
+	r.report = report
+	r.mu.Unlock()
+
+	if reason != "" {
+		r.printf("Finished (%s): %s", outcome, reason)
+	} else {
+		r.printf("Finished (%s)", outcome)
+	}
+	return report
+}
+
+// writeReport writes report to Config.ReportPath and joins any failure with
+// runErr.
+func (r *Runner) writeReport(report *Report, runErr error) error {
+	if r.cfg.ReportPath == "" {
+		return runErr
+	}
+	if err := WriteReport(r.cfg.ReportPath, report); err != nil {
+		return errors.Join(runErr, err)
+	}
+	return runErr
+}
+
+// WriteReport writes report to path as indented JSON, replacing any existing
+// file atomically.
+func WriteReport(path string, report *Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal report: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp) // best-effort cleanup
+		return fmt.Errorf("write report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,404 @@
+package headless
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/audit"
+	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+)
+
+// DefaultPollInterval is how often a Runner checks for plan files and
+// decisions when Config.PollInterval is zero.
+const DefaultPollInterval = 2 * time.Second
+
+// Coordinator is the part of *orchestrator.Coordinator a Runner drives.
+type Coordinator interface {
+	Session() *orchestrator.UltraPlanSession
+	Manager() *orchestrator.UltraPlanManager
+	SetCallbacks(cb *orchestrator.CoordinatorCallbacks)
+	RunPlanning() error
+	RunPlanManager() error
+	SetPlan(plan *orchestrator.PlanSpec) error
+	StartExecution() error
+	TriggerConsolidation() error
+	ResumeWithPartialWork() error
+	ApproveGroup() error
+	GetProgress() (completed, total int, phase orchestrator.UltraPlanPhase)
+	DryRun() (*orchestrator.DryRunReport, error)
+}
+
+// Orchestrator is the part of *orchestrator.Orchestrator a Runner uses.
+type Orchestrator interface {
+	GetInstance(id string) *orchestrator.Instance
+	StopInstance(inst *orchestrator.Instance) error
+	RecordOperatorAction(action audit.Action, instanceID, taskID, detail string)
+}
+
+// Config configures a Runner.
+type Config struct {
+	// SessionID is the Claudio session the ultraplan runs in, shown in the
+	// status and report so the session can be attached to later.
+	SessionID string
+
+	// ReportPath is where Run writes the final report as JSON. Empty skips
+	// writing it.
+	ReportPath string
+
+	// PollInterval is how often plan files and pending decisions are
+	// checked. Zero uses DefaultPollInterval.
+	PollInterval time.Duration
+
+	// ContinueOnPartialFailure resumes with the tasks that succeeded when an
+	// execution group partly fails. Otherwise the run stops and the session
+	// is left for a human to decide.
+	ContinueOnPartialFailure bool
+
+	// Output receives one line per phase change and task event. Nil
+	// discards them.
+	Output io.Writer
+
+	// Logger records decisions the runner makes. Nil disables logging.
+	Logger *logging.Logger
+}
+
+// Runner drives an ultraplan from planning through consolidation without a
+// terminal UI. It makes the decisions the TUI leaves to a human: plans are
+// approved as soon as they are written, synthesis is approved when it
+// finishes, and a partly failed group either continues or stops the run.
+type Runner struct {
+	orch   Orchestrator
+	coord  Coordinator
+	cfg    Config
+	logger *logging.Logger
+
+	wake chan struct{} // Signalled by coordinator callbacks
+
+	mu       sync.Mutex
+	status   Status
+	timeline []TimelineEntry
+	failures map[string]string // Task ID -> failure reason
+	summary  string            // From the coordinator's completion callback
+	dryRun   *orchestrator.DryRunReport
+	report   *Report
+
+	httpMu sync.Mutex
+	http   *http.Server
+	addr   net.Addr
+}
+
+// New creates a Runner for the ultraplan coord coordinates in orch.
+func New(orch Orchestrator, coord Coordinator, cfg Config) *Runner {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	if cfg.Output == nil {
+		cfg.Output = io.Discard
+	}
+	logger := cfg.Logger
+	if logger == nil {
+		logger = logging.NopLogger()
+	}
+	return &Runner{
+		orch:     orch,
+		coord:    coord,
+		cfg:      cfg,
+		logger:   logger.WithPhase("headless"),
+		wake:     make(chan struct{}, 1),
+		failures: make(map[string]string),
+		status:   Status{SessionID: cfg.SessionID, StartedAt: time.Now()},
+	}
+}
+
+// Run starts the ultraplan (planning, or execution when the session already
+// has a plan) and drives it until it completes, fails, needs a human, or ctx
+// is cancelled. It returns the final report, which it also writes to
+// Config.ReportPath. The error is non-nil only when the run could not start,
+// was cancelled, or the report could not be written; check Report.Outcome
+// for how the ultraplan itself ended.
+func (r *Runner) Run(ctx context.Context) (*Report, error) {
+	r.coord.SetCallbacks(r.callbacks())
+
+	if report, err := r.start(); report != nil || err != nil {
+		if report == nil {
+			report = r.finish(OutcomeFailed, err.Error())
+		}
+		return report, r.writeReport(report, err)
+	}
+
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if report := r.step(); report != nil {
+			return report, r.writeReport(report, nil)
+		}
+		select {
+		case <-ctx.Done():
+			// Leave the ultraplan as it is, like quitting the TUI, so the
+			// session can be attached to and resumed
+			report := r.finish(OutcomeCancelled, "run interrupted; the session is preserved")
+			return report, r.writeReport(report, ctx.Err())
+		case <-ticker.C:
+		case <-r.wake:
+		}
+	}
+}
+
+// start kicks off the ultraplan from the phase its session is in. A resumed
+// session past planning is left running and only monitored.
+func (r *Runner) start() (*Report, error) {
+	session := r.coord.Session()
+	if session == nil {
+		return nil, fmt.Errorf("coordinator has no ultraplan session")
+	}
+	r.refreshStatus(session)
+
+	switch {
+	case session.Plan != nil && session.Phase == orchestrator.PhaseRefresh:
+		return r.startExecution(session)
+	case session.Phase == orchestrator.PhasePlanning && session.CoordinatorID == "" && len(session.PlanCoordinatorIDs) == 0:
+		r.printf("Planning: %s", session.Objective)
+		if err := r.coord.RunPlanning(); err != nil {
+			return nil, fmt.Errorf("failed to start planning: %w", err)
+		}
+	}
+	return nil, nil
+}
+
+// step advances the ultraplan by one decision, if one is due, and returns
+// the final report once the run is over.
+func (r *Runner) step() *Report {
+	session := r.coord.Session()
+	r.refreshStatus(session)
+
+	switch session.Phase {
+	case orchestrator.PhaseComplete:
+		return r.finish(OutcomeComplete, "")
+
+	case orchestrator.PhaseFailed:
+		return r.finish(OutcomeFailed, session.Error)
+
+	case orchestrator.PhasePlanning:
+		if session.Plan != nil {
+			return nil
+		}
+		if session.Config.MultiPass {
+			r.collectCandidatePlans(session)
+			return nil
+		}
+		if plan := r.readPlan(session, session.CoordinatorID); plan != nil {
+			return r.approvePlan(session, plan, session.CoordinatorID)
+		}
+
+	case orchestrator.PhasePlanSelection:
+		if session.Plan == nil && session.PlanManagerID != "" {
+			if plan := r.readPlan(session, session.PlanManagerID); plan != nil {
+				return r.approvePlan(session, plan, session.PlanManagerID)
+			}
+		}
+
+	case orchestrator.PhaseRefresh:
+		if session.Plan != nil {
+			return r.mustStartExecution(session)
+		}
+
+	case orchestrator.PhaseExecuting:
+		if d := session.GroupDecision; d != nil && d.AwaitingDecision {
+			return r.decidePartialFailure(d)
+		}
+		if g := session.GroupApproval; g != nil {
+			r.printf("Group %d consolidated (%d files changed); starting group %d", g.GroupIndex+1, len(g.FilesChanged), g.GroupIndex+2)
+			r.orch.RecordOperatorAction(audit.ActionAutoApprove, "", "",
+				fmt.Sprintf("headless run approved starting group %d", g.GroupIndex+2))
+			if err := r.coord.ApproveGroup(); err != nil {
+				return r.finish(OutcomeFailed, fmt.Sprintf("failed to approve group %d: %v", g.GroupIndex+2, err))
+			}
+		}
+
+	case orchestrator.PhaseSynthesis:
+		if session.SynthesisAwaitingApproval {
+			r.printf("Synthesis finished; proceeding to consolidation")
+			r.orch.RecordOperatorAction(audit.ActionAutoApprove, "", "", "headless run approved synthesis")
+			if err := r.coord.TriggerConsolidation(); err != nil {
+				return r.finish(OutcomeFailed, fmt.Sprintf("failed to start consolidation: %v", err))
+			}
+		}
+
+	case orchestrator.PhaseConsolidating:
+		if c := session.Consolidation; c != nil && c.Phase == orchestrator.ConsolidationPaused {
+			return r.finish(OutcomeNeedsAttention,
+				fmt.Sprintf("consolidation paused on a conflict in %s; resolve it and resume the session", c.ConflictWorktree))
+		}
+	}
+	return nil
+}
+
+// readPlan returns the plan written by the planning instance id, or nil
+// while it is missing or still being written.
+func (r *Runner) readPlan(session *orchestrator.UltraPlanSession, id string) *orchestrator.PlanSpec {
+	if id == "" {
+		return nil
+	}
+	inst := r.orch.GetInstance(id)
+	if inst == nil {
+		return nil
+	}
+	plan, err := orchestrator.ParsePlanFromFile(orchestrator.PlanFilePath(inst.WorktreePath), session.Objective)
+	if err != nil {
+		return nil // Not written yet, or partially written
+	}
+	return plan
+}
+
+// collectCandidatePlans stores the plans of multi-pass coordinators as they
+// are written and starts the plan manager once every coordinator is done.
+func (r *Runner) collectCandidatePlans(session *orchestrator.UltraPlanSession) {
+	n := len(session.PlanCoordinatorIDs)
+	if n == 0 || session.PlanManagerID != "" {
+		return
+	}
+	mgr := r.coord.Manager()
+	for i, id := range session.PlanCoordinatorIDs {
+		if session.ProcessedCoordinators[i] {
+			continue
+		}
+		if plan := r.readPlan(session, id); plan != nil {
+			mgr.StoreCandidatePlan(i, plan)
+			r.printf("Candidate plan %d/%d: %d tasks", i+1, n, len(plan.Tasks))
+		} else if inst := r.orch.GetInstance(id); inst != nil && inst.Status == orchestrator.StatusError {
+			mgr.StoreCandidatePlan(i, nil)
+			r.printf("Candidate plan %d/%d: coordinator failed", i+1, n)
+		}
+	}
+	if mgr.CountCoordinatorsCompleted() < n {
+		return
+	}
+	if mgr.CountCandidatePlans() == 0 {
+		session.Error = "all multi-pass coordinators failed to produce valid plans"
+		mgr.SetPhase(orchestrator.PhaseFailed)
+		return
+	}
+	r.printf("Evaluating %d candidate plans", mgr.CountCandidatePlans())
+	if err := r.coord.RunPlanManager(); err != nil {
+		session.Error = fmt.Sprintf("failed to start plan manager: %v", err)
+		mgr.SetPhase(orchestrator.PhaseFailed)
+	}
+}
+
+// approvePlan installs plan, stops the instance that wrote it, and starts
+// execution.
+func (r *Runner) approvePlan(session *orchestrator.UltraPlanSession, plan *orchestrator.PlanSpec, planner string) *Report {
+	if err := r.coord.SetPlan(plan); err != nil {
+		return r.finish(OutcomeFailed, fmt.Sprintf("plan is invalid: %v", err))
+	}
+	if inst := r.orch.GetInstance(planner); inst != nil {
+		_ = r.orch.StopInstance(inst)
+	}
+	return r.mustStartExecution(session)
+}
+
+func (r *Runner) mustStartExecution(session *orchestrator.UltraPlanSession) *Report {
+	report, err := r.startExecution(session)
+	if err != nil {
+		return r.finish(OutcomeFailed, err.Error())
+	}
+	return report
+}
+
+// startExecution starts executing the session's plan, or ends a dry run.
+func (r *Runner) startExecution(session *orchestrator.UltraPlanSession) (*Report, error) {
+	plan := session.Plan
+	r.printf("Plan ready: %d tasks in %d groups", len(plan.Tasks), len(plan.ExecutionOrder))
+	if session.Config.DryRun {
+		dryRun, err := r.coord.DryRun()
+		if err != nil {
+			return nil, fmt.Errorf("dry run failed: %w", err)
+		}
+		r.mu.Lock()
+		r.dryRun = dryRun
+		r.mu.Unlock()
+		return r.finish(OutcomePlanned, "dry run: plan not executed"), nil
+	}
+	r.orch.RecordOperatorAction(audit.ActionAutoApprove, "", "",
+		fmt.Sprintf("headless run started plan without review (%d tasks)", len(plan.Tasks)))
+	if err := r.coord.StartExecution(); err != nil {
+		return nil, fmt.Errorf("failed to start execution: %w", err)
+	}
+	return nil, nil
+}
+
+// decidePartialFailure handles a group in which some tasks failed.
+func (r *Runner) decidePartialFailure(d *orchestrator.GroupDecisionState) *Report {
+	if !r.cfg.ContinueOnPartialFailure {
+		return r.finish(OutcomeNeedsAttention,
+			fmt.Sprintf("group %d partly failed (%d of %d tasks); attach to the session to retry or continue",
+				d.GroupIndex+1, len(d.FailedTasks), len(d.FailedTasks)+len(d.SucceededTasks)))
+	}
+	r.printf("Group %d partly failed; continuing with %d succeeded task(s)", d.GroupIndex+1, len(d.SucceededTasks))
+	r.orch.RecordOperatorAction(audit.ActionAutoApprove, "", "",
+		fmt.Sprintf("headless run continued group %d without %d failed task(s)", d.GroupIndex+1, len(d.FailedTasks)))
+	if err := r.coord.ResumeWithPartialWork(); err != nil {
+		return r.finish(OutcomeFailed, fmt.Sprintf("failed to continue after partial failure: %v", err))
+	}
+	return nil
+}
+
+// callbacks returns the coordinator callbacks that feed the status, the
+// timeline and Output, and wake the run loop.
+func (r *Runner) callbacks() *orchestrator.CoordinatorCallbacks {
+	return &orchestrator.CoordinatorCallbacks{
+		OnPhaseChange: func(phase orchestrator.UltraPlanPhase) {
+			r.mu.Lock()
+			r.timeline = append(r.timeline, TimelineEntry{Time: time.Now(), Phase: phase})
+			r.mu.Unlock()
+			r.printf("Phase: %s", phase)
+			r.signal()
+		},
+		OnTaskStart: func(taskID, instanceID string) {
+			r.printf("Task %s started (instance %s)", taskID, instanceID)
+		},
+		OnTaskComplete: func(taskID string) {
+			r.printf("Task %s completed", taskID)
+			r.signal()
+		},
+		OnTaskFailed: func(taskID, reason string) {
+			r.mu.Lock()
+			r.failures[taskID] = reason
+			r.mu.Unlock()
+			r.printf("Task %s failed: %s", taskID, reason)
+			r.signal()
+		},
+		OnGroupComplete: func(groupIndex int) {
+			r.printf("Group %d complete", groupIndex+1)
+			r.signal()
+		},
+		OnComplete: func(success bool, summary string) {
+			r.mu.Lock()
+			r.summary = summary
+			r.mu.Unlock()
+			r.signal()
+		},
+	}
+}
+
+// signal wakes the run loop without blocking.
+func (r *Runner) signal() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// printf writes a timestamped progress line to Output.
+func (r *Runner) printf(format string, args ...any) {
+	r.logger.Info(fmt.Sprintf(format, args...))
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, _ = fmt.Fprintf(r.cfg.Output, "[%s] %s\n", time.Now().Format(time.TimeOnly), fmt.Sprintf(format, args...))
+}
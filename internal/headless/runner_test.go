@@ -0,0 +1,421 @@
+package headless
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/audit"
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+)
+
+// fakeOrchestrator serves planning instances whose worktrees are temp dirs.
+type fakeOrchestrator struct {
+	mu        sync.Mutex
+	instances map[string]*orchestrator.Instance
+	stopped   []string
+	actions   []string
+}
+
+func (o *fakeOrchestrator) GetInstance(id string) *orchestrator.Instance {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.instances[id]
+}
+
+func (o *fakeOrchestrator) StopInstance(inst *orchestrator.Instance) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.stopped = append(o.stopped, inst.ID)
+	return nil
+}
+
+func (o *fakeOrchestrator) RecordOperatorAction(_ audit.Action, _, _, detail string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.actions = append(o.actions, detail)
+}
+
+// fakeCoordinator moves a real UltraPlanManager through the phases the way
+// the coordinator does, finishing each task as soon as execution starts.
+type fakeCoordinator struct {
+	mgr     *orchestrator.UltraPlanManager
+	cb      *orchestrator.CoordinatorCallbacks
+	failing string // Task ID that fails, leaving its group awaiting a decision
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func newFakeCoordinator(cfg orchestrator.UltraPlanConfig) *fakeCoordinator {
+	session := orchestrator.NewUltraPlanSession("add a feature", cfg)
+	return &fakeCoordinator{mgr: orchestrator.NewUltraPlanManager(nil, nil, session, nil)}
+}
+
+func (c *fakeCoordinator) record(call string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, call)
+}
+
+func (c *fakeCoordinator) Session() *orchestrator.UltraPlanSession            { return c.mgr.Session() }
+func (c *fakeCoordinator) Manager() *orchestrator.UltraPlanManager            { return c.mgr }
+func (c *fakeCoordinator) SetCallbacks(cb *orchestrator.CoordinatorCallbacks) { c.cb = cb }
+func (c *fakeCoordinator) RunPlanManager() error                              { return nil }
+
+func (c *fakeCoordinator) DryRun() (*orchestrator.DryRunReport, error) {
+	c.record("DryRun")
+	return &orchestrator.DryRunReport{Objective: c.Session().Objective}, nil
+}
+
+func (c *fakeCoordinator) setPhase(phase orchestrator.UltraPlanPhase) {
+	c.mgr.SetPhase(phase)
+	c.cb.OnPhaseChange(phase)
+}
+
+func (c *fakeCoordinator) RunPlanning() error {
+	c.record("RunPlanning")
+	c.Session().CoordinatorID = "planner"
+	return nil
+}
+
+func (c *fakeCoordinator) SetPlan(plan *orchestrator.PlanSpec) error {
+	c.record("SetPlan")
+	if err := orchestrator.ValidatePlan(plan); err != nil {
+		return err
+	}
+	c.mgr.SetPlan(plan)
+	c.setPhase(orchestrator.PhaseRefresh)
+	return nil
+}
+
+func (c *fakeCoordinator) StartExecution() error {
+	c.record("StartExecution")
+	session := c.Session()
+	c.setPhase(orchestrator.PhaseExecuting)
+	var succeeded, failed []string
+	for _, task := range session.Plan.Tasks {
+		c.mgr.AssignTaskToInstance(task.ID, "inst-"+task.ID)
+		if task.ID == c.failing {
+			c.mgr.MarkTaskFailed(task.ID, "tests failed")
+			c.cb.OnTaskFailed(task.ID, "tests failed")
+			failed = append(failed, task.ID)
+			continue
+		}
+		c.mgr.MarkTaskComplete(task.ID)
+		c.cb.OnTaskComplete(task.ID)
+		succeeded = append(succeeded, task.ID)
+	}
+	if session.Config.GroupApproval {
+		session.GroupApproval = &orchestrator.GroupApprovalState{FilesChanged: []string{"a.go"}}
+		return nil
+	}
+	if len(failed) > 0 {
+		session.GroupDecision = &orchestrator.GroupDecisionState{
+			SucceededTasks: succeeded, FailedTasks: failed, AwaitingDecision: true,
+		}
+		c.cb.OnGroupComplete(0)
+		return nil
+	}
+	return c.synthesize()
+}
+
+func (c *fakeCoordinator) synthesize() error {
+	c.Session().SynthesisAwaitingApproval = true
+	c.setPhase(orchestrator.PhaseSynthesis)
+	return nil
+}
+
+func (c *fakeCoordinator) ResumeWithPartialWork() error {
+	c.record("ResumeWithPartialWork")
+	c.Session().GroupDecision = nil
+	return c.synthesize()
+}
+
+func (c *fakeCoordinator) ApproveGroup() error {
+	c.record("ApproveGroup")
+	c.Session().GroupApproval = nil
+	return c.synthesize()
+}
+
+func (c *fakeCoordinator) TriggerConsolidation() error {
+	c.record("TriggerConsolidation")
+	session := c.Session()
+	session.SynthesisAwaitingApproval = false
+	session.Consolidation = &orchestrator.ConsolidatorState{
+		Phase:  orchestrator.ConsolidationComplete,
+		PRUrls: []string{"https://github.com/o/r/pull/1"},
+	}
+	c.setPhase(orchestrator.PhaseComplete)
+	c.cb.OnComplete(true, "1 PR opened")
+	return nil
+}
+
+func (c *fakeCoordinator) GetProgress() (int, int, orchestrator.UltraPlanPhase) {
+	s := c.Session()
+	if s.Plan == nil {
+		return 0, 0, s.Phase
+	}
+	return len(s.CompletedTasks), len(s.Plan.Tasks), s.Phase
+}
+
+// writePlanFile writes a two-task plan where the planning instance puts it.
+func writePlanFile(t *testing.T, worktree string) {
+	t.Helper()
+	plan := `{"summary":"two steps","tasks":[
+		{"id":"t1","title":"First","description":"do one","depends_on":[]},
+		{"id":"t2","title":"Second","description":"do two","depends_on":["t1"]}]}`
+	if err := os.WriteFile(orchestrator.PlanFilePath(worktree), []byte(plan), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func runWithTimeout(t *testing.T, r *Runner) (*Report, error) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return r.Run(ctx)
+}
+
+func TestRun_PlansExecutesAndConsolidates(t *testing.T) {
+	worktree := t.TempDir()
+	orch := &fakeOrchestrator{instances: map[string]*orchestrator.Instance{
+		"planner": {ID: "planner", WorktreePath: worktree},
+	}}
+	coord := newFakeCoordinator(orchestrator.UltraPlanConfig{})
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	var out strings.Builder
+	r := New(orch, coord, Config{
+		SessionID:    "sess-1",
+		ReportPath:   reportPath,
+		PollInterval: 10 * time.Millisecond,
+		Output:       &out,
+	})
+	writePlanFile(t, worktree)
+
+	report, err := runWithTimeout(t, r)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if report.Outcome != OutcomeComplete {
+		t.Fatalf("Outcome = %q (%s), want complete", report.Outcome, report.Reason)
+	}
+	want := "RunPlanning,SetPlan,StartExecution,TriggerConsolidation"
+	if got := strings.Join(coord.calls, ","); got != want {
+		t.Errorf("coordinator calls = %s, want %s", got, want)
+	}
+	if len(orch.stopped) != 1 || orch.stopped[0] != "planner" {
+		t.Errorf("stopped instances = %v, want [planner]", orch.stopped)
+	}
+	if len(orch.actions) != 2 {
+		t.Errorf("audited actions = %v, want plan and synthesis approvals", orch.actions)
+	}
+	if report.SessionID != "sess-1" || report.Completed != 2 || report.Total != 2 || !report.Done {
+		t.Errorf("report status = %+v", report.Status)
+	}
+	if report.Summary != "1 PR opened" || len(report.PRURLs) != 1 {
+		t.Errorf("report summary = %q, PRs = %v", report.Summary, report.PRURLs)
+	}
+	if len(report.Tasks) != 2 || report.Tasks[1].State != TaskCompleted || report.Tasks[1].Group != 2 {
+		t.Errorf("report tasks = %+v", report.Tasks)
+	}
+	if n := len(report.Timeline); n == 0 || report.Timeline[n-1].Phase != orchestrator.PhaseComplete {
+		t.Errorf("timeline = %+v, want it to end complete", report.Timeline)
+	}
+	if !strings.Contains(out.String(), "Finished (complete)") {
+		t.Errorf("output = %q", out.String())
+	}
+
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+	var written Report
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatalf("report is not JSON: %v", err)
+	}
+	if written.Outcome != OutcomeComplete {
+		t.Errorf("written outcome = %q", written.Outcome)
+	}
+}
+
+func TestRun_PartialFailure(t *testing.T) {
+	tests := []struct {
+		name         string
+		continueOn   bool
+		wantOutcome  Outcome
+		wantResumed  bool
+		wantTaskFail string
+	}{
+		{"stops for a decision", false, OutcomeNeedsAttention, false, "tests failed"},
+		{"continues", true, OutcomeComplete, true, "tests failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coord := newFakeCoordinator(orchestrator.UltraPlanConfig{})
+			coord.failing = "t2"
+			r := New(&fakeOrchestrator{}, coord, Config{PollInterval: 10 * time.Millisecond, ContinueOnPartialFailure: tt.continueOn})
+			coord.SetCallbacks(r.callbacks())
+			plan, err := orchestrator.ParsePlanFromFile(func() string {
+				dir := t.TempDir()
+				writePlanFile(t, dir)
+				return orchestrator.PlanFilePath(dir)
+			}(), "add a feature")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := coord.SetPlan(plan); err != nil {
+				t.Fatal(err)
+			}
+
+			report, err := runWithTimeout(t, r)
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if report.Outcome != tt.wantOutcome {
+				t.Errorf("Outcome = %q (%s), want %q", report.Outcome, report.Reason, tt.wantOutcome)
+			}
+			if got := strings.Contains(strings.Join(coord.calls, ","), "ResumeWithPartialWork"); got != tt.wantResumed {
+				t.Errorf("resumed = %v, want %v", got, tt.wantResumed)
+			}
+			if report.Tasks[1].State != TaskFailed || report.Tasks[1].Reason != tt.wantTaskFail {
+				t.Errorf("failed task = %+v", report.Tasks[1])
+			}
+		})
+	}
+}
+
+func TestRun_ApprovesGroups(t *testing.T) {
+	coord := newFakeCoordinator(orchestrator.UltraPlanConfig{GroupApproval: true})
+	orch := &fakeOrchestrator{}
+	r := New(orch, coord, Config{PollInterval: 10 * time.Millisecond})
+	coord.SetCallbacks(r.callbacks())
+	plan, err := orchestrator.ParsePlanFromFile(func() string {
+		dir := t.TempDir()
+		writePlanFile(t, dir)
+		return orchestrator.PlanFilePath(dir)
+	}(), "add a feature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := coord.SetPlan(plan); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := runWithTimeout(t, r)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if report.Outcome != OutcomeComplete {
+		t.Errorf("Outcome = %q (%s), want %q", report.Outcome, report.Reason, OutcomeComplete)
+	}
+	if !strings.Contains(strings.Join(coord.calls, ","), "ApproveGroup") {
+		t.Errorf("calls = %v, want the group approved", coord.calls)
+	}
+	if !slices.Contains(orch.actions, "headless run approved starting group 2") {
+		t.Errorf("actions = %v, want the approval recorded", orch.actions)
+	}
+}
+
+func TestRun_DryRunStopsAtPlan(t *testing.T) {
+	worktree := t.TempDir()
+	orch := &fakeOrchestrator{instances: map[string]*orchestrator.Instance{
+		"planner": {ID: "planner", WorktreePath: worktree},
+	}}
+	coord := newFakeCoordinator(orchestrator.UltraPlanConfig{DryRun: true})
+	writePlanFile(t, worktree)
+
+	report, err := runWithTimeout(t, New(orch, coord, Config{PollInterval: 10 * time.Millisecond}))
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if report.Outcome != OutcomePlanned || report.Total != 2 {
+		t.Errorf("report = %q with %d tasks, want planned with 2", report.Outcome, report.Total)
+	}
+	if strings.Contains(strings.Join(coord.calls, ","), "StartExecution") {
+		t.Error("a dry run should not start execution")
+	}
+	if report.DryRun == nil {
+		t.Error("report.DryRun = nil, want the dry-run report")
+	}
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	// No planner instance, so planning never finishes
+	coord := newFakeCoordinator(orchestrator.UltraPlanConfig{})
+	r := New(&fakeOrchestrator{}, coord, Config{PollInterval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	report, err := r.Run(ctx)
+	if err == nil {
+		t.Error("Run() error = nil, want the context error")
+	}
+	if report.Outcome != OutcomeCancelled {
+		t.Errorf("Outcome = %q, want cancelled", report.Outcome)
+	}
+	if phase := coord.Session().Phase; phase != orchestrator.PhasePlanning {
+		t.Errorf("phase after cancel = %s, want the session left in planning", phase)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	coord := newFakeCoordinator(orchestrator.UltraPlanConfig{})
+	r := New(&fakeOrchestrator{}, coord, Config{SessionID: "sess-1"})
+	r.refreshStatus(coord.Session())
+	srv := httptest.NewServer(r.Handler())
+	defer srv.Close()
+
+	get := func(path string) (int, map[string]any) {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		var body map[string]any
+		_ = json.NewDecoder(resp.Body).Decode(&body)
+		return resp.StatusCode, body
+	}
+
+	code, status := get("/status")
+	if code != http.StatusOK || status["session_id"] != "sess-1" || status["phase"] != string(orchestrator.PhasePlanning) {
+		t.Errorf("GET /status = %d %v", code, status)
+	}
+	if code, _ := get("/report"); code != http.StatusNotFound {
+		t.Errorf("GET /report before the run ends = %d, want 404", code)
+	}
+
+	r.finish(OutcomeFailed, "boom")
+	code, report := get("/report")
+	if code != http.StatusOK || report["outcome"] != string(OutcomeFailed) || report["reason"] != "boom" {
+		t.Errorf("GET /report = %d %v", code, report)
+	}
+}
+
+func TestServe(t *testing.T) {
+	r := New(&fakeOrchestrator{}, newFakeCoordinator(orchestrator.UltraPlanConfig{}), Config{})
+	if err := r.Serve("127.0.0.1:0"); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+	defer func() { _ = r.Close(context.Background()) }()
+	if err := r.Serve("127.0.0.1:0"); err == nil {
+		t.Error("second Serve() should fail")
+	}
+
+	resp, err := http.Get("http://" + r.Addr().String() + "/status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /status = %d", resp.StatusCode)
+	}
+}
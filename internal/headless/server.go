@@ -0,0 +1,84 @@
+package headless
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Handler returns the HTTP handler serving:
+//
+//	GET /status  the current Status, as JSON
+//	GET /report  the final Report, or 404 while the run is in progress
+func (r *Runner) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, r.Status())
+	})
+	mux.HandleFunc("GET /report", func(w http.ResponseWriter, _ *http.Request) {
+		report := r.Report()
+		if report == nil {
+			http.Error(w, "run in progress", http.StatusNotFound)
+			return
+		}
+		writeJSON(w, report)
+	})
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+// Serve serves Handler on addr (host:port). It returns once the listener is
+// open; the address actually bound, useful with port 0, is available from
+// Addr. The API is unauthenticated, so bind it to a loopback address.
+func (r *Runner) Serve(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("headless: listen on %s: %w", addr, err)
+	}
+
+	r.httpMu.Lock()
+	if r.http != nil {
+		r.httpMu.Unlock()
+		_ = ln.Close()
+		return errors.New("headless: already serving")
+	}
+	r.http = &http.Server{Handler: r.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	r.addr = ln.Addr()
+	srv := r.http
+	r.httpMu.Unlock()
+
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			r.logger.Warn("status server stopped", "error", err)
+		}
+	}()
+	return nil
+}
+
+// Addr returns the address the status API listens on, or nil before Serve.
+func (r *Runner) Addr() net.Addr {
+	r.httpMu.Lock()
+	defer r.httpMu.Unlock()
+	return r.addr
+}
+
+// Close stops the status API.
+func (r *Runner) Close(ctx context.Context) error {
+	r.httpMu.Lock()
+	srv := r.http
+	r.httpMu.Unlock()
+	if srv == nil {
+		return nil
+	}
+	return srv.Shutdown(ctx)
+}
@@ -0,0 +1,33 @@
+// Package hookserver receives Claude Code hook events from running
+// instances over HTTP.
+//
+// When ai.claude.hooks is set, the orchestrator starts a [Server] on the
+// loopback interface for each session and writes hooks for PostToolUse,
+// Stop, Notification, and UserPromptSubmit into every worktree's
+// .claude/settings.local.json with [Install]. Each hook pipes the payload
+// Claude Code gives it on stdin to the instance's URL with curl:
+//
+//	POST /hooks/{token}/{instance}
+//
+// The server decodes the payload with [detect.ParseHookEvent] and passes it
+// to its handler, which feeds it to the state monitor. The token is random
+// per server, so a session restarted on a new port rewrites the settings
+// and hooks from an earlier run are ignored.
+//
+// # Main Types
+//
+//   - [Server]: HTTP listener dispatching hook events to a [HandlerFunc]
+//   - [Install]: Adds (or removes) Claudio's hooks in a settings map
+//
+// # Usage
+//
+//	srv := hookserver.New(func(id string, ev detect.HookEvent) {
+//		monitor.ProcessHookEvent(id, ev)
+//	}, hookserver.WithLogger(logger))
+//	if err := srv.Start("127.0.0.1:0"); err != nil {
+//		return err
+//	}
+//	defer srv.Stop(ctx)
+//
+//	hookserver.Install(settings, srv.URL(instanceID))
+package hookserver
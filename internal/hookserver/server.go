@@ -0,0 +1,157 @@
+package hookserver
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/instance/detect"
+	"github.com/Iron-Ham/claudio/internal/logging"
+)
+
+// maxPayloadBytes bounds a hook payload. PostToolUse payloads carry the
+// tool's input and response, which for a large file read can be sizeable.
+const maxPayloadBytes = 4 << 20
+
+// HandlerFunc receives the hook events an instance reports.
+type HandlerFunc func(instanceID string, ev detect.HookEvent)
+
+// Server receives Claude Code hook events over HTTP and passes them to a
+// HandlerFunc. Each instance posts to its own URL (see URL), which carries
+// a token generated per server, so other local processes cannot report
+// events for an instance without reading its settings.
+type Server struct {
+	handler HandlerFunc
+	logger  *logging.Logger
+	token   string
+
+	mu   sync.Mutex
+	http *http.Server
+	addr net.Addr
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithLogger sets the logger for rejected payloads and server errors.
+func WithLogger(logger *logging.Logger) Option {
+	return func(s *Server) {
+		s.logger = logger
+	}
+}
+
+// New creates a Server that passes events to handler. It receives nothing
+// until Start.
+func New(handler HandlerFunc, opts ...Option) *Server {
+	s := &Server{handler: handler, token: newToken()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// newToken returns a random hex token for hook URLs.
+func newToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b) // crypto/rand.Read never returns an error
+	return hex.EncodeToString(b)
+}
+
+// Start listens on addr (host:port). It returns once the listener is open;
+// the address actually bound, useful with port 0, is available from Addr.
+func (s *Server) Start(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("hook server: listen on %s: %w", addr, err)
+	}
+
+	s.mu.Lock()
+	if s.http != nil {
+		s.mu.Unlock()
+		_ = ln.Close()
+		return errors.New("hook server: already started")
+	}
+	s.http = &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	s.addr = ln.Addr()
+	srv := s.http
+	s.mu.Unlock()
+
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) && s.logger != nil {
+			s.logger.Warn("hook server stopped", "error", err)
+		}
+	}()
+	return nil
+}
+
+// Addr returns the address the server listens on, or nil before Start.
+func (s *Server) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addr
+}
+
+// URL returns the URL an instance's hooks post to, or "" before Start.
+func (s *Server) URL(instanceID string) string {
+	addr := s.Addr()
+	if addr == nil {
+		return ""
+	}
+	return fmt.Sprintf("http://%s/hooks/%s/%s", addr, s.token, url.PathEscape(instanceID))
+}
+
+// Stop closes the listener, waiting for requests in flight until ctx is
+// done.
+func (s *Server) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	srv := s.http
+	s.mu.Unlock()
+	if srv == nil {
+		return nil
+	}
+	return srv.Shutdown(ctx)
+}
+
+// Handler returns the HTTP handler serving:
+//
+//	POST /hooks/{token}/{instance}  one hook payload, as Claude Code passes it on stdin
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /hooks/{token}/{instance}", s.serveHook)
+	return mux
+}
+
+// serveHook decodes a hook payload and hands it to the handler. Hooks
+// ignore the response, so errors are only logged.
+func (s *Server) serveHook(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("token") != s.token {
+		http.NotFound(w, r)
+		return
+	}
+	instanceID := r.PathValue("instance")
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadBytes))
+	if err != nil {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	ev, err := detect.ParseHookEvent(data)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Debug("invalid hook payload", "instance_id", instanceID, "error", err)
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.handler(instanceID, ev)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,106 @@
+package hookserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/instance/detect"
+)
+
+// received records the events a test server was given.
+type received struct {
+	mu     sync.Mutex
+	ids    []string
+	events []detect.HookEvent
+}
+
+func (r *received) handle(id string, ev detect.HookEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ids = append(r.ids, id)
+	r.events = append(r.events, ev)
+}
+
+func startServer(t *testing.T) (*Server, *received) {
+	t.Helper()
+	rec := &received{}
+	s := New(rec.handle)
+	if err := s.Start("127.0.0.1:0"); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = s.Stop(ctx)
+	})
+	return s, rec
+}
+
+func post(t *testing.T, url, body string) int {
+	t.Helper()
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST %s error = %v", url, err)
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestServer_DeliversHookEvents(t *testing.T) {
+	s, rec := startServer(t)
+
+	status := post(t, s.URL("inst-1"), `{"hook_event_name":"PostToolUse","tool_name":"Bash"}`)
+	if status != http.StatusNoContent {
+		t.Fatalf("POST status = %d, want %d", status, http.StatusNoContent)
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if len(rec.events) != 1 || rec.ids[0] != "inst-1" || rec.events[0].ToolName != "Bash" {
+		t.Errorf("handler got ids %v events %+v, want one Bash event for inst-1", rec.ids, rec.events)
+	}
+}
+
+func TestServer_RejectsBadRequests(t *testing.T) {
+	s, rec := startServer(t)
+	base := "http://" + s.Addr().String()
+
+	if status := post(t, base+"/hooks/wrong-token/inst-1", `{"hook_event_name":"Stop"}`); status != http.StatusNotFound {
+		t.Errorf("POST with a wrong token status = %d, want %d", status, http.StatusNotFound)
+	}
+	if status := post(t, s.URL("inst-1"), `{"tool_name":"Bash"}`); status != http.StatusBadRequest {
+		t.Errorf("POST without hook_event_name status = %d, want %d", status, http.StatusBadRequest)
+	}
+	if status := post(t, s.URL("inst-1"), strings.Repeat("x", maxPayloadBytes+1)); status != http.StatusRequestEntityTooLarge {
+		t.Errorf("POST of an oversized payload status = %d, want %d", status, http.StatusRequestEntityTooLarge)
+	}
+
+	resp, err := http.Get(s.URL("inst-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d, want %d", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if len(rec.events) != 0 {
+		t.Errorf("handler got %d events, want none", len(rec.events))
+	}
+}
+
+func TestServer_URL(t *testing.T) {
+	s := New(func(string, detect.HookEvent) {})
+	if got := s.URL("inst-1"); got != "" {
+		t.Errorf("URL() before Start = %q, want empty", got)
+	}
+	if other := New(func(string, detect.HookEvent) {}); other.token == s.token {
+		t.Error("two servers share a token")
+	}
+}
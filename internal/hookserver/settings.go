@@ -0,0 +1,105 @@
+package hookserver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/instance/detect"
+)
+
+// hookMarker is the header every hook command Claudio installs sends. It
+// tells Claudio's hooks apart from the user's when settings are rewritten.
+const hookMarker = "X-Claudio-Hook: 1"
+
+// hookTimeoutSeconds bounds each hook command, so an unreachable listener
+// never holds up Claude Code.
+const hookTimeoutSeconds = 5
+
+// Command returns the hook command that posts the payload on its stdin to
+// url. It always exits 0, so a listener that has gone away does not show
+// up as a failing hook.
+func Command(url string) string {
+	return fmt.Sprintf("curl -s -m %d -X POST -H 'Content-Type: application/json' -H '%s' --data-binary @- '%s' >/dev/null 2>&1 || true",
+		hookTimeoutSeconds-1, hookMarker, url)
+}
+
+// Install adds hooks posting every event in detect.HookEventNames to url to
+// the "hooks" key of a Claude Code settings map, as read from
+// settings.local.json. Hooks Claudio installed earlier are replaced and the
+// user's own hooks are kept. An empty url only removes Claudio's hooks.
+func Install(settings map[string]any, url string) {
+	hooks, _ := settings["hooks"].(map[string]any)
+	if hooks == nil {
+		if url == "" {
+			return
+		}
+		hooks = make(map[string]any)
+	}
+
+	for _, name := range detect.HookEventNames {
+		entries := withoutClaudioHooks(hooks[name])
+		if url != "" {
+			entries = append(entries, hookEntry(name, url))
+		}
+		if len(entries) == 0 {
+			delete(hooks, name)
+		} else {
+			hooks[name] = entries
+		}
+	}
+
+	if len(hooks) == 0 {
+		delete(settings, "hooks")
+	} else {
+		settings["hooks"] = hooks
+	}
+}
+
+// hookEntry returns the settings entry running Command(url) for an event.
+// PostToolUse hooks need a matcher; "*" matches every tool.
+func hookEntry(event, url string) map[string]any {
+	entry := map[string]any{
+		"hooks": []any{map[string]any{
+			"type":    "command",
+			"command": Command(url),
+			"timeout": hookTimeoutSeconds,
+		}},
+	}
+	if event == detect.HookPostToolUse {
+		entry["matcher"] = "*"
+	}
+	return entry
+}
+
+// withoutClaudioHooks returns an event's settings entries with the hook
+// commands Claudio installed removed, dropping entries left without hooks.
+// Entries it does not recognize are kept as they are.
+func withoutClaudioHooks(v any) []any {
+	entries, _ := v.([]any)
+	var kept []any
+	for _, e := range entries {
+		entry, ok := e.(map[string]any)
+		if !ok {
+			kept = append(kept, e)
+			continue
+		}
+		commands, _ := entry["hooks"].([]any)
+		var own []any
+		for _, c := range commands {
+			if cmd, ok := c.(map[string]any); ok {
+				if s, _ := cmd["command"].(string); strings.Contains(s, hookMarker) {
+					continue
+				}
+			}
+			own = append(own, c)
+		}
+		if len(commands) > 0 {
+			if len(own) == 0 {
+				continue
+			}
+			entry["hooks"] = own
+		}
+		kept = append(kept, entry)
+	}
+	return kept
+}
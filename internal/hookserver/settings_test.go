@@ -0,0 +1,99 @@
+package hookserver
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/instance/detect"
+)
+
+// userSettings is a settings.local.json with a hook of the user's own.
+const userSettings = `{
+  "teammateMode": "in-process",
+  "hooks": {
+    "PostToolUse": [
+      {"matcher": "Write", "hooks": [{"type": "command", "command": "gofmt -w ."}]}
+    ]
+  }
+}`
+
+func parseSettings(t *testing.T, data string) map[string]any {
+	t.Helper()
+	settings := make(map[string]any)
+	if err := json.Unmarshal([]byte(data), &settings); err != nil {
+		t.Fatal(err)
+	}
+	return settings
+}
+
+// roundTrip writes and re-reads settings, as happens between sessions.
+func roundTrip(t *testing.T, settings map[string]any) map[string]any {
+	t.Helper()
+	data, err := json.Marshal(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parseSettings(t, string(data))
+}
+
+// commands returns the hook commands for an event.
+func commands(settings map[string]any, event string) []string {
+	hooks, _ := settings["hooks"].(map[string]any)
+	entries, _ := hooks[event].([]any)
+	var out []string
+	for _, e := range entries {
+		for _, c := range e.(map[string]any)["hooks"].([]any) {
+			out = append(out, c.(map[string]any)["command"].(string))
+		}
+	}
+	return out
+}
+
+func TestInstall(t *testing.T) {
+	settings := parseSettings(t, userSettings)
+
+	Install(settings, "http://127.0.0.1:1/hooks/a/inst-1")
+	for _, event := range detect.HookEventNames {
+		cmds := commands(settings, event)
+		if n := len(cmds); n == 0 || !strings.Contains(cmds[n-1], "http://127.0.0.1:1/hooks/a/inst-1") {
+			t.Errorf("%s commands = %v, want Claudio's hook last", event, cmds)
+		}
+	}
+
+	// Reinstalling with a new URL replaces Claudio's hooks only
+	settings = roundTrip(t, settings)
+	Install(settings, "http://127.0.0.1:2/hooks/b/inst-1")
+	settings = roundTrip(t, settings)
+	cmds := commands(settings, detect.HookPostToolUse)
+	if len(cmds) != 2 || cmds[0] != "gofmt -w ." || !strings.Contains(cmds[1], "127.0.0.1:2") {
+		t.Errorf("PostToolUse commands after reinstall = %v, want the user's hook and the new one", cmds)
+	}
+	if settings["teammateMode"] != "in-process" {
+		t.Errorf("Install() changed unrelated settings: %v", settings)
+	}
+
+	// An empty URL removes Claudio's hooks and keeps the user's
+	Install(settings, "")
+	if cmds := commands(settings, detect.HookPostToolUse); len(cmds) != 1 || cmds[0] != "gofmt -w ." {
+		t.Errorf("PostToolUse commands after removal = %v, want only the user's hook", cmds)
+	}
+	if cmds := commands(settings, detect.HookStop); len(cmds) != 0 {
+		t.Errorf("Stop commands after removal = %v, want none", cmds)
+	}
+
+	empty := map[string]any{}
+	Install(empty, "")
+	if _, ok := empty["hooks"]; ok {
+		t.Error("Install() with an empty URL added a hooks key")
+	}
+}
+
+func TestCommand(t *testing.T) {
+	cmd := Command("http://127.0.0.1:1/hooks/a/inst-1")
+	for _, want := range []string{"--data-binary @-", "'http://127.0.0.1:1/hooks/a/inst-1'", hookMarker, "|| true"} {
+		if !strings.Contains(cmd, want) {
+			t.Errorf("Command() = %q, missing %q", cmd, want)
+		}
+	}
+}
@@ -4,11 +4,16 @@
 // # Main Types
 //
 //   - [RingBuffer]: Bounded circular buffer for memory-efficient output storage
+//   - [Recorder]: Writes screen frames to an asciicast v2 transcript for replay
+//   - [Transcript]: A recorded transcript read back with [ReadTranscript]
+//   - [TranscriptTail]: Follows a transcript another process is recording
 //
 // # Thread Safety
 //
-// All types in this package are safe for concurrent use. The [RingBuffer]
-// uses internal synchronization to protect against concurrent reads and writes.
+// [RingBuffer] and [Recorder] are safe for concurrent use; both use internal
+// synchronization to protect against concurrent reads and writes. A
+// [Transcript] is read-only once loaded. A [TranscriptTail] must be used
+// from one goroutine.
 //
 // # Basic Usage
 //
@@ -16,4 +21,24 @@
 //	buf.Write([]byte("some output"))
 //	data := buf.Read()
 //	buf.Clear()
+//
+// # Transcripts
+//
+// A [Recorder] is fed every capture of an instance's pane and writes a
+// frame whenever the screen changed, at most once per
+// [DefaultFrameInterval]. Each frame clears the screen and redraws it, so
+// transcripts play back in asciinema as well as in the TUI's replay mode:
+//
+//	rec, err := capture.OpenRecorder(path, capture.TranscriptHeader{Width: 200, Height: 30})
+//	rec.WriteFrame(screen)
+//	rec.Close()
+//
+//	t, err := capture.ReadTranscript(path)
+//	frame := t.Frames[t.FrameAt(90 * time.Second)]
+//
+// Observers of a session follow an instance's recording with a
+// [TranscriptTail] when they cannot capture its pane:
+//
+//	tail := capture.NewTranscriptTail(path)
+//	screen, changed, err := tail.Screen()
 package capture
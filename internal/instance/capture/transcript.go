@@ -0,0 +1,362 @@
+package capture
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// asciicastVersion is the asciicast format version transcripts are written in.
+	asciicastVersion = 2
+
+	// clearScreen homes the cursor and clears the screen. Every recorded
+	// frame starts with it, so a player redraws the whole screen per frame.
+	clearScreen = "\x1b[H\x1b[2J"
+
+	// DefaultFrameInterval is the minimum time between recorded frames.
+	// Changes in between are coalesced into the next frame, which keeps a
+	// constantly redrawing spinner from filling the transcript.
+	DefaultFrameInterval = time.Second
+
+	// maxFrameLine bounds a single transcript line when reading.
+	maxFrameLine = 4 << 20
+)
+
+// TranscriptHeader is the first line of an asciicast v2 transcript.
+type TranscriptHeader struct {
+	Version   int    `json:"version"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Timestamp int64  `json:"timestamp"`       // Unix time the recording started
+	Title     string `json:"title,omitempty"` // Usually the instance ID and task
+}
+
+// Recorder writes an instance's screen to an asciicast v2 transcript, one
+// frame per change, so the instance's session can be replayed afterwards
+// (in the TUI or with any asciicast player such as asciinema).
+//
+// Recorder is safe for concurrent use.
+type Recorder struct {
+	mu       sync.Mutex
+	file     *os.File
+	height   int
+	start    time.Time
+	interval time.Duration
+	last     string    // Last recorded screen
+	lastAt   time.Time // When the last frame was recorded
+	now      func() time.Time
+}
+
+// OpenRecorder opens the transcript at path for recording, creating it and
+// its directory if needed. An existing transcript is appended to, keeping
+// its header, so an instance restarted within a session keeps one timeline.
+// Frames are written at most once per DefaultFrameInterval.
+func OpenRecorder(path string, header TranscriptHeader) (*Recorder, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("capture: create transcript directory: %w", err)
+	}
+
+	if existing, err := readHeader(path); err == nil {
+		header = existing
+	} else {
+		if header.Timestamp == 0 {
+			header.Timestamp = time.Now().Unix()
+		}
+		header.Version = asciicastVersion
+		data, err := json.Marshal(header)
+		if err != nil {
+			return nil, fmt.Errorf("capture: encode transcript header: %w", err)
+		}
+		if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+			return nil, fmt.Errorf("capture: write transcript header: %w", err)
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("capture: open transcript: %w", err)
+	}
+	return &Recorder{
+		file:     f,
+		height:   header.Height,
+		start:    time.Unix(header.Timestamp, 0),
+		interval: DefaultFrameInterval,
+		now:      time.Now,
+	}, nil
+}
+
+// readHeader reads the header of an existing transcript.
+func readHeader(path string) (TranscriptHeader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return TranscriptHeader{}, err
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 4096), maxFrameLine)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return TranscriptHeader{}, err
+		}
+		return TranscriptHeader{}, errors.New("capture: empty transcript")
+	}
+	var header TranscriptHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return TranscriptHeader{}, fmt.Errorf("capture: parse transcript header: %w", err)
+	}
+	if header.Version != asciicastVersion {
+		return TranscriptHeader{}, fmt.Errorf("capture: unsupported asciicast version %d", header.Version)
+	}
+	return header, nil
+}
+
+// WriteFrame records screen if it differs from the last recorded frame and
+// the frame interval has passed. It is cheap to call on every capture: an
+// unchanged screen is skipped, and a change inside the interval is picked
+// up by a later call. Captures with scrollback are cut to the header's
+// height so every frame is one screen.
+func (r *Recorder) WriteFrame(screen []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return errors.New("capture: recorder is closed")
+	}
+	text := lastLines(string(screen), r.height)
+	if text == r.last {
+		return nil
+	}
+	now := r.now()
+	if !r.lastAt.IsZero() && now.Sub(r.lastAt) < r.interval {
+		return nil
+	}
+
+	data := clearScreen + strings.ReplaceAll(text, "\n", "\r\n")
+	event, err := json.Marshal([]any{now.Sub(r.start).Seconds(), "o", data})
+	if err != nil {
+		return fmt.Errorf("capture: encode transcript frame: %w", err)
+	}
+	if _, err := r.file.Write(append(event, '\n')); err != nil {
+		return fmt.Errorf("capture: write transcript frame: %w", err)
+	}
+	r.last = text
+	r.lastAt = now
+	return nil
+}
+
+// Close closes the transcript. Further frames are rejected.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// lastLines returns the last n lines of s, ignoring trailing blank lines.
+// n <= 0 keeps every line.
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if n > 0 && len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Frame is one recorded screen of a transcript.
+type Frame struct {
+	At     time.Duration // Offset from the start of the recording
+	Screen string        // Screen contents, lines separated by "\n"
+}
+
+// Transcript is a recorded instance session read back for replay.
+type Transcript struct {
+	Header TranscriptHeader
+	Frames []Frame
+}
+
+// Start returns when the recording started.
+func (t *Transcript) Start() time.Time {
+	return time.Unix(t.Header.Timestamp, 0)
+}
+
+// Duration returns the offset of the last frame.
+func (t *Transcript) Duration() time.Duration {
+	if len(t.Frames) == 0 {
+		return 0
+	}
+	return t.Frames[len(t.Frames)-1].At
+}
+
+// FrameAt returns the index of the frame on screen at offset at: the last
+// frame recorded at or before it, or 0 if at precedes every frame.
+func (t *Transcript) FrameAt(at time.Duration) int {
+	idx := 0
+	for i, f := range t.Frames {
+		if f.At > at {
+			break
+		}
+		idx = i
+	}
+	return idx
+}
+
+// ReadTranscript reads an asciicast v2 transcript. Output events that
+// start by clearing the screen, as Recorder writes them, become one frame
+// each; other output is appended to the previous frame's screen. Input and
+// other event types are ignored.
+func ReadTranscript(path string) (*Transcript, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("capture: open transcript: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxFrameLine)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("capture: read transcript: %w", err)
+		}
+		return nil, errors.New("capture: empty transcript")
+	}
+
+	t := &Transcript{}
+	if err := json.Unmarshal(scanner.Bytes(), &t.Header); err != nil {
+		return nil, fmt.Errorf("capture: parse transcript header: %w", err)
+	}
+	if t.Header.Version != asciicastVersion {
+		return nil, fmt.Errorf("capture: unsupported asciicast version %d", t.Header.Version)
+	}
+
+	for line := 2; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		secs, code, data, ok := decodeEvent(scanner.Bytes())
+		if !ok {
+			return nil, fmt.Errorf("capture: transcript line %d is not an event", line)
+		}
+		if code != "o" {
+			continue
+		}
+
+		at := time.Duration(secs * float64(time.Second))
+		if screen, ok := strings.CutPrefix(data, clearScreen); ok || len(t.Frames) == 0 {
+			t.Frames = append(t.Frames, Frame{At: at, Screen: screen})
+			continue
+		}
+		prev := t.Frames[len(t.Frames)-1]
+		t.Frames = append(t.Frames, Frame{At: at, Screen: prev.Screen + data})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("capture: read transcript: %w", err)
+	}
+	return t, nil
+}
+
+// decodeEvent decodes an asciicast event line into its offset in seconds,
+// event code and data, with CRLF line endings turned into LF.
+func decodeEvent(line []byte) (secs float64, code, data string, ok bool) {
+	var event []any
+	if err := json.Unmarshal(line, &event); err != nil || len(event) != 3 {
+		return 0, "", "", false
+	}
+	secs, okTime := event[0].(float64)
+	code, okCode := event[1].(string)
+	data, okData := event[2].(string)
+	if !okTime || !okCode || !okData {
+		return 0, "", "", false
+	}
+	return secs, code, strings.ReplaceAll(data, "\r\n", "\n"), true
+}
+
+// TranscriptTail follows a transcript another process is recording and
+// keeps its latest screen, reading only what was appended since the last
+// call. It lets a process that cannot capture an instance's pane show the
+// instance's screen a frame interval behind.
+//
+// TranscriptTail is not safe for concurrent use.
+type TranscriptTail struct {
+	path    string
+	offset  int64  // Bytes of the file consumed so far
+	partial []byte // An incomplete last line, completed by a later write
+	header  bool   // Whether the header line has been consumed
+	screen  string
+}
+
+// NewTranscriptTail returns a tail of the transcript at path. The file
+// need not exist yet.
+func NewTranscriptTail(path string) *TranscriptTail {
+	return &TranscriptTail{path: path}
+}
+
+// Screen reads what was appended to the transcript and returns the latest
+// screen and whether it changed since the previous call. A missing
+// transcript is reported as an error wrapping fs.ErrNotExist.
+func (t *TranscriptTail) Screen() (string, bool, error) {
+	f, err := os.Open(t.path)
+	if err != nil {
+		return t.screen, false, fmt.Errorf("capture: open transcript: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if st, err := f.Stat(); err == nil && st.Size() < t.offset {
+		// Truncated or replaced: start over
+		*t = TranscriptTail{path: t.path, screen: t.screen}
+	}
+	if _, err := f.Seek(t.offset, io.SeekStart); err != nil {
+		return t.screen, false, fmt.Errorf("capture: read transcript: %w", err)
+	}
+	data, err := io.ReadAll(io.LimitReader(f, maxFrameLine))
+	if err != nil {
+		return t.screen, false, fmt.Errorf("capture: read transcript: %w", err)
+	}
+	t.offset += int64(len(data))
+
+	prev := t.screen
+	buf := append(t.partial, data...)
+	for {
+		i := bytes.IndexByte(buf, '\n')
+		if i < 0 {
+			break
+		}
+		line := buf[:i]
+		buf = buf[i+1:]
+		if !t.header {
+			t.header = true
+			continue
+		}
+		_, code, data, ok := decodeEvent(line)
+		if !ok || code != "o" {
+			continue
+		}
+		if screen, ok := strings.CutPrefix(data, clearScreen); ok {
+			t.screen = screen
+		} else {
+			t.screen += data
+		}
+	}
+	if len(buf) > maxFrameLine {
+		buf = nil // Not an event line Recorder wrote
+	}
+	t.partial = slices.Clone(buf)
+	return t.screen, t.screen != prev, nil
+}
@@ -0,0 +1,195 @@
+package capture
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestRecorder opens a recorder whose clock is advanced by the test.
+func newTestRecorder(t *testing.T, path string, height int) (*Recorder, *time.Time) {
+	t.Helper()
+	start := time.Unix(1_700_000_000, 0)
+	rec, err := OpenRecorder(path, TranscriptHeader{Width: 80, Height: height, Timestamp: start.Unix(), Title: "inst-1"})
+	if err != nil {
+		t.Fatalf("OpenRecorder() error = %v", err)
+	}
+	now := start
+	rec.now = func() time.Time { return now }
+	return rec, &now
+}
+
+func TestRecorder_WritesAndReadsFrames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transcripts", "inst-1.cast")
+	rec, now := newTestRecorder(t, path, 2)
+
+	*now = now.Add(500 * time.Millisecond)
+	if err := rec.WriteFrame([]byte("one\n")); err != nil {
+		t.Fatalf("WriteFrame() error = %v", err)
+	}
+	*now = now.Add(2 * time.Second)
+	_ = rec.WriteFrame([]byte("one\n"))               // unchanged: skipped
+	_ = rec.WriteFrame([]byte("one\ntwo\nthree\n\n")) // cut to the last two lines
+	*now = now.Add(100 * time.Millisecond)
+	_ = rec.WriteFrame([]byte("four\n")) // inside the frame interval: skipped
+	*now = now.Add(time.Second)
+	_ = rec.WriteFrame([]byte("four\n"))
+	if err := rec.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := rec.WriteFrame([]byte("late")); err == nil {
+		t.Error("WriteFrame() after Close() succeeded")
+	}
+
+	tr, err := ReadTranscript(path)
+	if err != nil {
+		t.Fatalf("ReadTranscript() error = %v", err)
+	}
+	if tr.Header.Version != 2 || tr.Header.Width != 80 || tr.Header.Title != "inst-1" {
+		t.Errorf("Header = %+v, want asciicast v2 with the given size and title", tr.Header)
+	}
+	want := []Frame{
+		{At: 500 * time.Millisecond, Screen: "one"},
+		{At: 2500 * time.Millisecond, Screen: "two\nthree"},
+		{At: 3600 * time.Millisecond, Screen: "four"},
+	}
+	if len(tr.Frames) != len(want) {
+		t.Fatalf("Frames = %+v, want %+v", tr.Frames, want)
+	}
+	for i, f := range tr.Frames {
+		if f.Screen != want[i].Screen || (f.At-want[i].At).Abs() > time.Millisecond {
+			t.Errorf("Frames[%d] = %+v, want %+v", i, f, want[i])
+		}
+	}
+	if got := tr.Duration(); (got - 3600*time.Millisecond).Abs() > time.Millisecond {
+		t.Errorf("Duration() = %v, want 3.6s", got)
+	}
+}
+
+func TestOpenRecorder_AppendsToExistingTranscript(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inst-1.cast")
+	rec, now := newTestRecorder(t, path, 10)
+	*now = now.Add(time.Second)
+	_ = rec.WriteFrame([]byte("before restart"))
+	_ = rec.Close()
+
+	// A reopened transcript keeps its header, so offsets continue
+	rec, err := OpenRecorder(path, TranscriptHeader{Width: 10, Height: 10, Timestamp: now.Unix() + 100})
+	if err != nil {
+		t.Fatalf("OpenRecorder() error = %v", err)
+	}
+	rec.now = func() time.Time { return now.Add(5 * time.Second) }
+	_ = rec.WriteFrame([]byte("after restart"))
+	_ = rec.Close()
+
+	tr, err := ReadTranscript(path)
+	if err != nil {
+		t.Fatalf("ReadTranscript() error = %v", err)
+	}
+	if tr.Header.Width != 80 {
+		t.Errorf("Header.Width = %d, want the original 80", tr.Header.Width)
+	}
+	if len(tr.Frames) != 2 || tr.Frames[1].Screen != "after restart" || tr.Frames[1].At != 6*time.Second {
+		t.Errorf("Frames = %+v, want the appended frame at 6s", tr.Frames)
+	}
+}
+
+func TestReadTranscript_StreamOutputAndErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "stream.cast")
+	content := `{"version":2,"width":80,"height":24,"timestamp":1}
+[0.5,"o","hello "]
+[1.0,"i","ignored input"]
+[1.5,"o","world\r\n"]
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tr, err := ReadTranscript(path)
+	if err != nil {
+		t.Fatalf("ReadTranscript() error = %v", err)
+	}
+	if len(tr.Frames) != 2 || tr.Frames[1].Screen != "hello world\n" {
+		t.Errorf("Frames = %+v, want stream output accumulated", tr.Frames)
+	}
+
+	for name, content := range map[string]string{
+		"empty":       "",
+		"bad version": `{"version":1}`,
+		"bad event":   "{\"version\":2}\n[1,\"o\"]\n",
+	} {
+		path := filepath.Join(dir, strings.ReplaceAll(name, " ", "-")+".cast")
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ReadTranscript(path); err == nil {
+			t.Errorf("ReadTranscript(%s) succeeded, want error", name)
+		}
+	}
+}
+
+func TestTranscript_FrameAt(t *testing.T) {
+	tr := &Transcript{Frames: []Frame{{At: time.Second}, {At: 3 * time.Second}, {At: 5 * time.Second}}}
+	tests := []struct {
+		at   time.Duration
+		want int
+	}{
+		{0, 0},
+		{time.Second, 0},
+		{4 * time.Second, 1},
+		{time.Minute, 2},
+	}
+	for _, tt := range tests {
+		if got := tr.FrameAt(tt.at); got != tt.want {
+			t.Errorf("FrameAt(%v) = %d, want %d", tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestTranscriptTail_FollowsRecording(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inst-1.cast")
+	tail := NewTranscriptTail(path)
+	if _, _, err := tail.Screen(); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Screen() of a missing transcript error = %v, want fs.ErrNotExist", err)
+	}
+
+	rec, now := newTestRecorder(t, path, 5)
+	defer func() { _ = rec.Close() }()
+	if screen, changed, err := tail.Screen(); err != nil || changed || screen != "" {
+		t.Errorf("Screen() of an empty recording = %q, %v, %v", screen, changed, err)
+	}
+
+	*now = now.Add(time.Second)
+	_ = rec.WriteFrame([]byte("one\n"))
+	if screen, changed, err := tail.Screen(); err != nil || !changed || screen != "one" {
+		t.Errorf("Screen() = %q, %v, %v, want the first frame", screen, changed, err)
+	}
+	if _, changed, _ := tail.Screen(); changed {
+		t.Error("Screen() reported a change without a new frame")
+	}
+
+	*now = now.Add(time.Second)
+	_ = rec.WriteFrame([]byte("one\ntwo\n"))
+	if screen, changed, err := tail.Screen(); err != nil || !changed || screen != "one\ntwo" {
+		t.Errorf("Screen() = %q, %v, %v, want the appended frame", screen, changed, err)
+	}
+
+	// A line still being written is kept until it is complete
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	_, _ = f.WriteString(`[3.0, "o", "\u001b[H\u001b[2Jth`)
+	if screen, _, _ := tail.Screen(); screen != "one\ntwo" {
+		t.Errorf("Screen() with a partial line = %q, want the previous frame", screen)
+	}
+	_, _ = f.WriteString(`ree"]` + "\n")
+	if screen, changed, _ := tail.Screen(); !changed || screen != "three" {
+		t.Errorf("Screen() = %q, %v, want the completed frame", screen, changed)
+	}
+}
@@ -8,10 +8,23 @@
 // The default pattern set targets Claude Code output. Other backends can supply
 // their own PatternSet via NewDetectorWithPatterns.
 //
+// For Claude Code started with --output-format stream-json, [JSONDetector]
+// reads the structured events (tool_use, message_stop, permission requests,
+// results) instead of rendered text. It cannot mistake a question in the
+// conversation for a prompt, or miss an AskUserQuestion menu whose footer
+// the text patterns do not recognize.
+//
+// With ai.claude.hooks, Claude Code reports PostToolUse, Stop, Notification,
+// and UserPromptSubmit hook events to Claudio directly. [HookState] folds
+// them into a state and records tool usage; once an instance has sent its
+// first event, its hook state replaces detection from output.
+//
 // # Main Types
 //
 //   - [WaitingState]: Enum representing detected instance states
 //   - [Detector]: Pattern matcher that analyzes output to determine state
+//   - [JSONDetector]: Event reader for stream-json output
+//   - [HookState]: Fold of Claude Code hook events ([HookEvent])
 //   - [TimeoutType]: Types of timeout conditions (Activity, Completion, Stale)
 //
 // # Waiting States
@@ -56,4 +69,8 @@
 //   - Activity timeout: No new output for configured duration
 //   - Completion timeout: Instance hasn't completed within time limit
 //   - Stale detection: Repeated identical output indicating a loop
+//
+// TimeoutPolicy overrides these thresholds for the instances of individual
+// tasks, selected by name or by the task's estimated complexity, and lists
+// the escalation actions (warn, nudge, restart, fail) taken when they trip.
 package detect
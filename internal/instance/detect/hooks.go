@@ -0,0 +1,168 @@
+package detect
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"regexp"
+	"strings"
+)
+
+// Claude Code hook events Claudio installs when ai.claude.hooks is set.
+const (
+	HookPostToolUse      = "PostToolUse"
+	HookStop             = "Stop"
+	HookNotification     = "Notification"
+	HookUserPromptSubmit = "UserPromptSubmit"
+)
+
+// HookEventNames lists the hook events Claudio installs, in the order they
+// are written to a worktree's settings.
+var HookEventNames = []string{HookPostToolUse, HookStop, HookNotification, HookUserPromptSubmit}
+
+// HookEvent is the JSON payload Claude Code passes to a hook command on
+// stdin. Only the fields the detector reads are decoded.
+type HookEvent struct {
+	HookEventName string `json:"hook_event_name"`
+	SessionID     string `json:"session_id"`
+	Cwd           string `json:"cwd"`
+
+	// Set on PostToolUse
+	ToolName     string          `json:"tool_name"`
+	ToolInput    json.RawMessage `json:"tool_input"`
+	ToolResponse json.RawMessage `json:"tool_response"`
+
+	// Set on Notification. NotificationType is absent from older Claude
+	// Code versions, which only send the message.
+	Message          string `json:"message"`
+	NotificationType string `json:"notification_type"`
+}
+
+// ParseHookEvent decodes a hook payload. Payloads without a
+// hook_event_name are rejected.
+func ParseHookEvent(data []byte) (HookEvent, error) {
+	var ev HookEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return HookEvent{}, err
+	}
+	if ev.HookEventName == "" {
+		return HookEvent{}, errors.New("hook event has no hook_event_name")
+	}
+	return ev, nil
+}
+
+// HookActivity summarizes the hook events an instance has reported.
+type HookActivity struct {
+	Events   int            // Events applied
+	ToolUses map[string]int // Completed tool calls by tool name
+	LastTool string         // Tool of the most recent PostToolUse
+}
+
+// HookState folds an instance's Claude Code hook events into a
+// WaitingState. Unlike the output detectors it reads events Claude Code
+// reports itself, so it is authoritative once the first event arrives:
+//
+//   - UserPromptSubmit and PostToolUse: StateWorking
+//   - Notification asking for permission: StateWaitingPermission
+//   - Notification for an elicitation dialog: StateWaitingQuestion
+//   - Notification that Claude is idle: StateWaitingInput
+//   - Stop: StateWaitingInput, or StatePROpened when a tool result in the
+//     turn contained a GitHub PR URL
+//
+// Other notifications and unknown events leave the state unchanged.
+// HookState is not safe for concurrent use.
+type HookState struct {
+	prOpenedPatterns []*regexp.Regexp
+
+	state    WaitingState
+	activity HookActivity
+	prSeen   bool // A PR URL appeared in a tool result this turn
+}
+
+// NewHookState creates an empty hook state.
+func NewHookState() *HookState {
+	return &HookState{
+		prOpenedPatterns: compilePatterns(PROpenedPatterns),
+		activity:         HookActivity{ToolUses: make(map[string]int)},
+	}
+}
+
+// Apply updates the state with ev and returns the state after it.
+func (h *HookState) Apply(ev HookEvent) WaitingState {
+	h.activity.Events++
+	switch ev.HookEventName {
+	case HookUserPromptSubmit:
+		h.state = StateWorking
+		h.prSeen = false
+	case HookPostToolUse:
+		h.state = StateWorking
+		if ev.ToolName != "" {
+			h.activity.ToolUses[ev.ToolName]++
+			h.activity.LastTool = ev.ToolName
+		}
+		if !h.prSeen && h.matchesPR(ev.ToolResponse) {
+			h.prSeen = true
+		}
+	case HookNotification:
+		if state, ok := notificationState(ev); ok {
+			h.state = state
+		}
+	case HookStop:
+		if h.prSeen {
+			h.state = StatePROpened
+		} else {
+			h.state = StateWaitingInput
+		}
+	}
+	return h.state
+}
+
+// notificationState maps a Notification to the state it announces.
+func notificationState(ev HookEvent) (WaitingState, bool) {
+	switch ev.NotificationType {
+	case "permission_prompt":
+		return StateWaitingPermission, true
+	case "elicitation_dialog":
+		return StateWaitingQuestion, true
+	case "idle_prompt":
+		return StateWaitingInput, true
+	case "":
+		msg := strings.ToLower(ev.Message)
+		switch {
+		case strings.Contains(msg, "permission"):
+			return StateWaitingPermission, true
+		case strings.Contains(msg, "waiting for your input"):
+			return StateWaitingInput, true
+		}
+	}
+	return StateWorking, false
+}
+
+// State returns the state after the events applied so far.
+func (h *HookState) State() WaitingState {
+	return h.state
+}
+
+// Seen reports whether any event has been applied.
+func (h *HookState) Seen() bool {
+	return h.activity.Events > 0
+}
+
+// Activity returns a copy of the tool usage recorded so far.
+func (h *HookState) Activity() HookActivity {
+	a := h.activity
+	a.ToolUses = maps.Clone(h.activity.ToolUses)
+	return a
+}
+
+func (h *HookState) matchesPR(data []byte) bool {
+	if len(data) == 0 {
+		return false
+	}
+	for _, p := range h.prOpenedPatterns {
+		if p.Match(data) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package detect
+
+import "testing"
+
+// Hook payloads in the shape Claude Code passes to hook commands on stdin.
+const (
+	hookPrompt         = `{"hook_event_name":"UserPromptSubmit","session_id":"s1","prompt":"continue"}`
+	hookBash           = `{"hook_event_name":"PostToolUse","tool_name":"Bash","tool_input":{"command":"go test"},"tool_response":{"stdout":"ok"}}`
+	hookEdit           = `{"hook_event_name":"PostToolUse","tool_name":"Edit","tool_input":{"file_path":"a.go"},"tool_response":{}}`
+	hookPRBash         = `{"hook_event_name":"PostToolUse","tool_name":"Bash","tool_response":{"stdout":"https://github.com/owner/repo/pull/42"}}`
+	hookStop           = `{"hook_event_name":"Stop","stop_hook_active":false}`
+	hookPermission     = `{"hook_event_name":"Notification","notification_type":"permission_prompt","message":"Claude needs your permission to use Bash"}`
+	hookIdle           = `{"hook_event_name":"Notification","notification_type":"idle_prompt","message":"Claude is waiting for your input"}`
+	hookElicitation    = `{"hook_event_name":"Notification","notification_type":"elicitation_dialog","message":"Choose an option"}`
+	hookAuth           = `{"hook_event_name":"Notification","notification_type":"auth_success","message":"Signed in"}`
+	hookOldPermission  = `{"hook_event_name":"Notification","message":"Claude needs your permission to use Write"}`
+	hookUnknownFuture  = `{"hook_event_name":"PreCompact","trigger":"auto"}`
+	hookMissingEventID = `{"tool_name":"Bash"}`
+)
+
+func applyHooks(t *testing.T, payloads ...string) *HookState {
+	t.Helper()
+	h := NewHookState()
+	for _, p := range payloads {
+		ev, err := ParseHookEvent([]byte(p))
+		if err != nil {
+			t.Fatalf("ParseHookEvent(%s) error = %v", p, err)
+		}
+		h.Apply(ev)
+	}
+	return h
+}
+
+func TestHookState_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		payloads []string
+		want     WaitingState
+	}{
+		{"tool use", []string{hookPrompt, hookBash}, StateWorking},
+		{"turn ended", []string{hookPrompt, hookBash, hookStop}, StateWaitingInput},
+		{"permission prompt", []string{hookBash, hookPermission}, StateWaitingPermission},
+		{"permission granted", []string{hookBash, hookPermission, hookEdit}, StateWorking},
+		{"permission from message only", []string{hookBash, hookOldPermission}, StateWaitingPermission},
+		{"idle", []string{hookStop, hookIdle}, StateWaitingInput},
+		{"elicitation", []string{hookBash, hookElicitation}, StateWaitingQuestion},
+		{"other notification", []string{hookBash, hookAuth}, StateWorking},
+		{"unknown event", []string{hookBash, hookUnknownFuture}, StateWorking},
+		{"PR opened", []string{hookPrompt, hookPRBash, hookEdit, hookStop}, StatePROpened},
+		{"PR from an earlier turn", []string{hookPRBash, hookStop, hookPrompt, hookBash, hookStop}, StateWaitingInput},
+		{"input after stop", []string{hookStop, hookPrompt}, StateWorking},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := applyHooks(t, tt.payloads...).State(); got != tt.want {
+				t.Errorf("State() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHookState_Activity(t *testing.T) {
+	h := NewHookState()
+	if h.Seen() {
+		t.Error("Seen() before any event = true, want false")
+	}
+
+	h = applyHooks(t, hookPrompt, hookBash, hookEdit, hookBash, hookStop)
+	if !h.Seen() {
+		t.Error("Seen() = false, want true")
+	}
+	a := h.Activity()
+	if a.Events != 5 || a.ToolUses["Bash"] != 2 || a.ToolUses["Edit"] != 1 || a.LastTool != "Bash" {
+		t.Errorf("Activity() = %+v, want 5 events, Bash x2, Edit x1, last Bash", a)
+	}
+
+	a.ToolUses["Bash"] = 99
+	if h.Activity().ToolUses["Bash"] != 2 {
+		t.Error("Activity() returned the live tool map, want a copy")
+	}
+}
+
+func TestParseHookEvent(t *testing.T) {
+	ev, err := ParseHookEvent([]byte(hookBash))
+	if err != nil {
+		t.Fatalf("ParseHookEvent() error = %v", err)
+	}
+	if ev.HookEventName != HookPostToolUse || ev.ToolName != "Bash" {
+		t.Errorf("ParseHookEvent() = %+v", ev)
+	}
+
+	for _, bad := range []string{hookMissingEventID, `not json`, ``} {
+		if _, err := ParseHookEvent([]byte(bad)); err == nil {
+			t.Errorf("ParseHookEvent(%q) error = nil, want an error", bad)
+		}
+	}
+}
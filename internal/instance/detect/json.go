@@ -0,0 +1,265 @@
+package detect
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sync"
+)
+
+// jsonDetectWindow is how much of the end of the output Detect parses. It is
+// larger than the text detector's window because a single event line (a
+// tool result, for example) can be several kilobytes.
+const jsonDetectWindow = 64 * 1024
+
+// askUserQuestionTool is the tool Claude Code calls to ask the user a
+// multiple-choice question. Its tool_use waits for an answer rather than
+// running.
+const askUserQuestionTool = "AskUserQuestion"
+
+// JSONDetector implements StateDetector for instances started with
+// --output-format stream-json. Instead of matching rendered terminal text,
+// it reads the newline-delimited JSON events Claude Code emits and derives
+// the state from the most recent ones:
+//
+//   - system, user (tool results), and streaming deltas: StateWorking
+//   - tool_use: StateWorking, or StateWaitingQuestion for AskUserQuestion
+//   - permission_request, or a can_use_tool control request: StateWaitingPermission
+//   - message_stop ending a turn, or a successful result: StateWaitingInput
+//   - a result with is_error set: StateError
+//
+// A turn that ends after a GitHub PR URL appeared in the events is reported
+// as StatePROpened, as with the text detector. Lines that are not JSON
+// events, such as shell noise or lines cut at the start of the window, are
+// ignored.
+//
+// Detect is stateless and safe for concurrent use. Observe feeds events one
+// line at a time for callers reading the stream directly.
+type JSONDetector struct {
+	prOpenedPatterns []*regexp.Regexp
+
+	mu      sync.Mutex
+	current streamState
+}
+
+// NewJSONDetector creates a detector for stream-json output.
+func NewJSONDetector() *JSONDetector {
+	return &JSONDetector{prOpenedPatterns: compilePatterns(PROpenedPatterns)}
+}
+
+// streamEvent is the subset of a stream-json event the detector reads.
+type streamEvent struct {
+	Type    string `json:"type"`
+	Subtype string `json:"subtype"`
+	IsError bool   `json:"is_error"`
+
+	// Message is set on assistant and user events.
+	Message *struct {
+		// Content is a list of blocks, or a plain string for user input.
+		Content    json.RawMessage `json:"content"`
+		StopReason string          `json:"stop_reason"`
+	} `json:"message"`
+
+	// Event is set on stream_event events (--include-partial-messages).
+	Event *struct {
+		Type  string `json:"type"`
+		Delta struct {
+			StopReason string `json:"stop_reason"`
+		} `json:"delta"`
+		ContentBlock contentBlock `json:"content_block"`
+	} `json:"event"`
+
+	// Request is set on control_request events.
+	Request *struct {
+		Subtype string `json:"subtype"`
+	} `json:"request"`
+}
+
+// contentBlock is one block of a message's content.
+type contentBlock struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// streamState folds events into a WaitingState.
+type streamState struct {
+	state      WaitingState
+	seen       bool   // At least one event was parsed
+	stopReason string // Stop reason of the message being streamed
+	lastTool   string // Name of the most recent tool_use
+	prSeen     bool   // A PR URL appeared in an event
+}
+
+// parseStreamEvent decodes one line of stream-json output.
+func parseStreamEvent(line []byte) (streamEvent, bool) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 || line[0] != '{' {
+		return streamEvent{}, false
+	}
+	var ev streamEvent
+	if err := json.Unmarshal(line, &ev); err != nil || ev.Type == "" {
+		return streamEvent{}, false
+	}
+	return ev, true
+}
+
+// apply updates s with ev.
+func (s *streamState) apply(ev streamEvent) {
+	s.seen = true
+	switch ev.Type {
+	case "assistant":
+		s.state = StateWorking
+		if ev.Message == nil {
+			return
+		}
+		var blocks []contentBlock
+		_ = json.Unmarshal(ev.Message.Content, &blocks) // Non-list content has no tool use
+		for _, b := range blocks {
+			if b.Type == "tool_use" {
+				s.lastTool = b.Name
+			}
+		}
+		switch {
+		case ev.Message.StopReason == "tool_use" || hasToolUse(blocks):
+			s.state = s.toolState()
+		case ev.Message.StopReason != "":
+			s.state = StateWaitingInput
+		}
+	case "stream_event":
+		s.state = StateWorking
+		if ev.Event == nil {
+			return
+		}
+		switch ev.Event.Type {
+		case "message_start":
+			s.stopReason = ""
+		case "content_block_start":
+			if ev.Event.ContentBlock.Type == "tool_use" {
+				s.lastTool = ev.Event.ContentBlock.Name
+			}
+		case "message_delta":
+			if ev.Event.Delta.StopReason != "" {
+				s.stopReason = ev.Event.Delta.StopReason
+			}
+		case "message_stop":
+			if s.stopReason == "tool_use" {
+				s.state = s.toolState()
+			} else {
+				s.state = StateWaitingInput
+			}
+		}
+	case "permission_request":
+		s.state = StateWaitingPermission
+	case "control_request":
+		if ev.Request != nil && ev.Request.Subtype == "can_use_tool" {
+			s.state = StateWaitingPermission
+		}
+	case "result":
+		if ev.IsError {
+			s.state = StateError
+		} else {
+			s.state = StateWaitingInput
+		}
+	case "system", "user", "control_response", "control_cancel_request":
+		s.state = StateWorking
+	default:
+		// Unknown event types leave the state unchanged, so events added by
+		// newer Claude Code versions do not cause false transitions.
+	}
+}
+
+// toolState is the state while the most recent tool_use is outstanding.
+func (s *streamState) toolState() WaitingState {
+	if s.lastTool == askUserQuestionTool {
+		return StateWaitingQuestion
+	}
+	return StateWorking
+}
+
+// result returns the detected state, applying the PR-opened override.
+func (s *streamState) result() WaitingState {
+	if s.prSeen && s.state == StateWaitingInput {
+		return StatePROpened
+	}
+	return s.state
+}
+
+func hasToolUse(blocks []contentBlock) bool {
+	for _, b := range blocks {
+		if b.Type == "tool_use" {
+			return true
+		}
+	}
+	return false
+}
+
+// observe applies one line to s.
+func (d *JSONDetector) observe(s *streamState, line []byte) {
+	ev, ok := parseStreamEvent(line)
+	if !ok {
+		return
+	}
+	s.apply(ev)
+	if !s.prSeen && d.matchesAny(line) {
+		s.prSeen = true
+	}
+}
+
+// detect folds the events at the end of output.
+func (d *JSONDetector) detect(output []byte) streamState {
+	var s streamState
+	if len(output) > jsonDetectWindow {
+		output = output[len(output)-jsonDetectWindow:]
+		// Drop the line cut by the window.
+		if i := bytes.IndexByte(output, '\n'); i >= 0 {
+			output = output[i+1:]
+		}
+	}
+	for line := range bytes.SplitSeq(output, []byte("\n")) {
+		d.observe(&s, line)
+	}
+	return s
+}
+
+// Detect returns the state implied by the stream-json events at the end of
+// output. Output without any events returns StateWorking.
+func (d *JSONDetector) Detect(output []byte) WaitingState {
+	s := d.detect(output)
+	return s.result()
+}
+
+// HasWorkingIndicators returns true if the latest events show the instance
+// working.
+func (d *JSONDetector) HasWorkingIndicators(output []byte) bool {
+	s := d.detect(output)
+	return s.seen && s.state == StateWorking
+}
+
+// Observe feeds one line of stream-json output to the detector and returns
+// the state after it. Use it when reading the event stream directly rather
+// than from captured output.
+func (d *JSONDetector) Observe(line []byte) WaitingState {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.observe(&d.current, line)
+	return d.current.result()
+}
+
+// State returns the state after the lines passed to Observe so far.
+func (d *JSONDetector) State() WaitingState {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.current.result()
+}
+
+func (d *JSONDetector) matchesAny(line []byte) bool {
+	for _, p := range d.prOpenedPatterns {
+		if p.Match(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// Ensure JSONDetector implements StateDetector.
+var _ StateDetector = (*JSONDetector)(nil)
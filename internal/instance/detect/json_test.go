@@ -0,0 +1,135 @@
+package detect
+
+import (
+	"strings"
+	"testing"
+)
+
+// Event lines in the shape Claude Code emits with --output-format stream-json.
+const (
+	evInit          = `{"type":"system","subtype":"init","session_id":"s1","tools":["Bash"]}`
+	evText          = `{"type":"assistant","message":{"content":[{"type":"text","text":"Let me look."}],"stop_reason":null}}`
+	evQuestionText  = `{"type":"assistant","message":{"content":[{"type":"text","text":"Which database should I use?"}],"stop_reason":null}}`
+	evBash          = `{"type":"assistant","message":{"content":[{"type":"tool_use","id":"t1","name":"Bash","input":{"command":"go test"}}],"stop_reason":null}}`
+	evAsk           = `{"type":"assistant","message":{"content":[{"type":"tool_use","id":"t2","name":"AskUserQuestion","input":{"questions":[]}}],"stop_reason":null}}`
+	evToolResult    = `{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"t1","content":"ok"}]}}`
+	evUserString    = `{"type":"user","message":{"content":"continue"}}`
+	evEndTurn       = `{"type":"assistant","message":{"content":[{"type":"text","text":"Done."}],"stop_reason":"end_turn"}}`
+	evPermission    = `{"type":"permission_request","tool_name":"Bash","input":{"command":"rm -rf build"}}`
+	evCanUseTool    = `{"type":"control_request","request_id":"r1","request":{"subtype":"can_use_tool","tool_name":"Edit"}}`
+	evControlReply  = `{"type":"control_response","response":{"request_id":"r1","subtype":"success"}}`
+	evResult        = `{"type":"result","subtype":"success","is_error":false,"result":"All done"}`
+	evResultError   = `{"type":"result","subtype":"error_during_execution","is_error":true}`
+	evPRText        = `{"type":"assistant","message":{"content":[{"type":"text","text":"Opened https://github.com/owner/repo/pull/42"}],"stop_reason":null}}`
+	evMessageStart  = `{"type":"stream_event","event":{"type":"message_start","message":{}}}`
+	evBlockTool     = `{"type":"stream_event","event":{"type":"content_block_start","index":0,"content_block":{"type":"tool_use","name":"AskUserQuestion"}}}`
+	evBlockDelta    = `{"type":"stream_event","event":{"type":"content_block_delta","delta":{"type":"text_delta","text":"hi"}}}`
+	evDeltaToolUse  = `{"type":"stream_event","event":{"type":"message_delta","delta":{"stop_reason":"tool_use"}}}`
+	evDeltaEndTurn  = `{"type":"stream_event","event":{"type":"message_delta","delta":{"stop_reason":"end_turn"}}}`
+	evMessageStop   = `{"type":"stream_event","event":{"type":"message_stop"}}`
+	evUnknownFuture = `{"type":"telemetry","data":{}}`
+)
+
+func stream(lines ...string) []byte {
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+func TestJSONDetector_Detect(t *testing.T) {
+	tests := []struct {
+		name   string
+		output []byte
+		want   WaitingState
+	}{
+		{"empty", nil, StateWorking},
+		{"not json", []byte("$ claude -p ...\nsome text?\n"), StateWorking},
+		{"init", stream(evInit), StateWorking},
+		{"running tool", stream(evInit, evBash), StateWorking},
+		{"tool result", stream(evInit, evBash, evToolResult), StateWorking},
+		{"string user content", stream(evInit, evUserString), StateWorking},
+		{"ask user question", stream(evInit, evText, evAsk), StateWaitingQuestion},
+		{"question answered", stream(evInit, evAsk, evToolResult), StateWorking},
+		{"permission request", stream(evInit, evBash, evPermission), StateWaitingPermission},
+		{"can_use_tool request", stream(evInit, evCanUseTool), StateWaitingPermission},
+		{"permission answered", stream(evInit, evCanUseTool, evControlReply), StateWorking},
+		{"end turn", stream(evInit, evText, evEndTurn), StateWaitingInput},
+		{"result", stream(evInit, evText, evResult), StateWaitingInput},
+		{"error result", stream(evInit, evResultError), StateError},
+		{"pr opened", stream(evInit, evPRText, evResult), StatePROpened},
+		{"pr opened while working", stream(evInit, evPRText, evBash), StateWorking},
+		{"unknown event keeps state", stream(evInit, evAsk, evUnknownFuture), StateWaitingQuestion},
+		{"garbage between events", stream(evInit, evAsk, "}{ not json", "⠋ spinner"), StateWaitingQuestion},
+		{"partial message tool stop", stream(evMessageStart, evBlockTool, evDeltaToolUse, evMessageStop), StateWaitingQuestion},
+		{"partial message end turn", stream(evMessageStart, evBlockDelta, evDeltaEndTurn, evMessageStop), StateWaitingInput},
+		{"partial message streaming", stream(evMessageStart, evBlockDelta), StateWorking},
+	}
+
+	d := NewJSONDetector()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := d.Detect(tt.output); got != tt.want {
+				t.Errorf("Detect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// A question the text detector would report as waiting is only text to the
+// JSON detector; the turn is still running until an event says otherwise.
+func TestJSONDetector_NoTextFalsePositives(t *testing.T) {
+	output := stream(evInit, evQuestionText, evBash)
+
+	if got := NewJSONDetector().Detect(output); got != StateWorking {
+		t.Errorf("JSONDetector.Detect() = %v, want working", got)
+	}
+}
+
+func TestJSONDetector_Window(t *testing.T) {
+	// A huge tool result pushes the start of the stream out of the window;
+	// the line cut by the window must be ignored, not misparsed.
+	big := `{"type":"user","message":{"content":[{"type":"tool_result","content":"` + strings.Repeat("x", jsonDetectWindow) + `"}]}}`
+	output := stream(evInit, evAsk, big, evEndTurn)
+
+	if got := NewJSONDetector().Detect(output); got != StateWaitingInput {
+		t.Errorf("Detect() = %v, want waiting_input", got)
+	}
+}
+
+func TestJSONDetector_HasWorkingIndicators(t *testing.T) {
+	d := NewJSONDetector()
+
+	if d.HasWorkingIndicators(nil) {
+		t.Error("HasWorkingIndicators(nil) = true, want false")
+	}
+	if !d.HasWorkingIndicators(stream(evInit, evBash)) {
+		t.Error("HasWorkingIndicators(running tool) = false, want true")
+	}
+	if d.HasWorkingIndicators(stream(evInit, evResult)) {
+		t.Error("HasWorkingIndicators(result) = true, want false")
+	}
+}
+
+func TestJSONDetector_Observe(t *testing.T) {
+	d := NewJSONDetector()
+
+	steps := []struct {
+		line string
+		want WaitingState
+	}{
+		{evInit, StateWorking},
+		{evAsk, StateWaitingQuestion},
+		{"not an event", StateWaitingQuestion},
+		{evToolResult, StateWorking},
+		{evPermission, StateWaitingPermission},
+		{evToolResult, StateWorking},
+		{evPRText, StateWorking},
+		{evResult, StatePROpened},
+	}
+	for i, s := range steps {
+		if got := d.Observe([]byte(s.line)); got != s.want {
+			t.Errorf("step %d: Observe() = %v, want %v", i, got, s.want)
+		}
+	}
+	if got := d.State(); got != StatePROpened {
+		t.Errorf("State() = %v, want pr_opened", got)
+	}
+}
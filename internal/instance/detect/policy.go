@@ -0,0 +1,103 @@
+package detect
+
+import (
+	"slices"
+	"time"
+)
+
+// TimeoutAction is an escalation step a TimeoutPolicy takes when an instance
+// stalls.
+type TimeoutAction string
+
+const (
+	// TimeoutActionWarn logs a warning and keeps waiting for output.
+	TimeoutActionWarn TimeoutAction = "warn"
+	// TimeoutActionNudge sends the policy's nudge message to the instance.
+	TimeoutActionNudge TimeoutAction = "nudge"
+	// TimeoutActionRestart restarts the instance, resuming its conversation
+	// when the backend supports it.
+	TimeoutActionRestart TimeoutAction = "restart"
+	// TimeoutActionFail marks the instance stuck (or timed out). It always
+	// ends the escalation.
+	TimeoutActionFail TimeoutAction = "fail"
+)
+
+// ValidTimeoutActions returns the action names accepted in configuration.
+func ValidTimeoutActions() []string {
+	return []string{
+		string(TimeoutActionWarn),
+		string(TimeoutActionNudge),
+		string(TimeoutActionRestart),
+		string(TimeoutActionFail),
+	}
+}
+
+// TimeoutPolicy overrides the global timeout thresholds and escalation for
+// the instances of matching tasks. Tasks select a policy by name, or by
+// their estimated complexity (see TimeoutPolicies.ForTask).
+type TimeoutPolicy struct {
+	// Name identifies the policy; tasks can name it explicitly.
+	Name string
+
+	// Complexity lists the task complexities ("low", "medium", "high") the
+	// policy applies to when a task doesn't name a policy.
+	Complexity []string
+
+	// ActivityTimeout, CompletionTimeout, and StaleThreshold replace the
+	// global thresholds when non-zero.
+	ActivityTimeout   time.Duration
+	CompletionTimeout time.Duration
+	StaleThreshold    int
+
+	// Actions are the escalation steps taken, in order, on an activity or
+	// stale timeout. Empty uses the global escalation ladder.
+	Actions []TimeoutAction
+
+	// NudgeMessage is sent by TimeoutActionNudge (empty = the global one).
+	NudgeMessage string
+}
+
+// Apply returns base with the policy's non-zero thresholds substituted.
+func (p TimeoutPolicy) Apply(base TimeoutConfig) TimeoutConfig {
+	if p.ActivityTimeout > 0 {
+		base.ActivityTimeout = p.ActivityTimeout
+	}
+	if p.CompletionTimeout > 0 {
+		base.CompletionTimeout = p.CompletionTimeout
+	}
+	if p.StaleThreshold > 0 {
+		base.StaleThreshold = p.StaleThreshold
+	}
+	return base
+}
+
+// TimeoutPolicies is an ordered list of policies.
+type TimeoutPolicies []TimeoutPolicy
+
+// Get returns the policy with the given name.
+func (ps TimeoutPolicies) Get(name string) (TimeoutPolicy, bool) {
+	for _, p := range ps {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return TimeoutPolicy{}, false
+}
+
+// ForTask returns the policy for a task: the one it names, or else the
+// first policy listing its complexity. ok is false when no policy applies
+// and the global timeouts should be used.
+func (ps TimeoutPolicies) ForTask(name, complexity string) (TimeoutPolicy, bool) {
+	if name != "" {
+		return ps.Get(name)
+	}
+	if complexity == "" {
+		return TimeoutPolicy{}, false
+	}
+	for _, p := range ps {
+		if slices.Contains(p.Complexity, complexity) {
+			return p, true
+		}
+	}
+	return TimeoutPolicy{}, false
+}
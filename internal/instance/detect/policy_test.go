@@ -0,0 +1,55 @@
+package detect
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeoutPolicy_Apply(t *testing.T) {
+	base := TimeoutConfig{
+		ActivityTimeout:   30 * time.Minute,
+		CompletionTimeout: 0,
+		StaleThreshold:    3000,
+	}
+
+	got := TimeoutPolicy{ActivityTimeout: time.Hour, CompletionTimeout: 2 * time.Hour}.Apply(base)
+	want := TimeoutConfig{ActivityTimeout: time.Hour, CompletionTimeout: 2 * time.Hour, StaleThreshold: 3000}
+	if got != want {
+		t.Errorf("Apply() = %+v, want %+v", got, want)
+	}
+
+	if got := (TimeoutPolicy{Name: "empty"}).Apply(base); got != base {
+		t.Errorf("Apply() of a policy without thresholds = %+v, want base %+v", got, base)
+	}
+}
+
+func TestTimeoutPolicies_ForTask(t *testing.T) {
+	policies := TimeoutPolicies{
+		{Name: "long", Complexity: []string{"high"}},
+		{Name: "short", Complexity: []string{"low", "high"}},
+		{Name: "manual"},
+	}
+
+	tests := []struct {
+		name       string
+		policy     string
+		complexity string
+		want       string
+		wantOK     bool
+	}{
+		{"named policy wins over complexity", "manual", "high", "manual", true},
+		{"first complexity match", "", "high", "long", true},
+		{"later complexity match", "", "low", "short", true},
+		{"no match", "", "medium", "", false},
+		{"no complexity", "", "", "", false},
+		{"unknown name", "missing", "high", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := policies.ForTask(tt.policy, tt.complexity)
+			if ok != tt.wantOK || got.Name != tt.want {
+				t.Errorf("ForTask(%q, %q) = %q, %v; want %q, %v", tt.policy, tt.complexity, got.Name, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
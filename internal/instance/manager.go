@@ -11,11 +11,13 @@ import (
 	"time"
 
 	"github.com/Iron-Ham/claudio/internal/ai"
+	"github.com/Iron-Ham/claudio/internal/diag"
 	"github.com/Iron-Ham/claudio/internal/instance/capture"
 	"github.com/Iron-Ham/claudio/internal/instance/detect"
 	"github.com/Iron-Ham/claudio/internal/instance/input"
 	"github.com/Iron-Ham/claudio/internal/instance/lifecycle"
 	"github.com/Iron-Ham/claudio/internal/instance/metrics"
+	"github.com/Iron-Ham/claudio/internal/instance/process"
 	"github.com/Iron-Ham/claudio/internal/instance/state"
 	"github.com/Iron-Ham/claudio/internal/logging"
 	"github.com/Iron-Ham/claudio/internal/tmux"
@@ -85,6 +87,24 @@ type ManagerConfig struct {
 	ActivityTimeoutMinutes   int  // 0 = disabled
 	CompletionTimeoutMinutes int  // 0 = disabled
 	StaleDetection           bool // Enable repeated output detection
+
+	// ProcessBackend is what the backend runs in: ProcessBackendTmux ("" or
+	// "tmux") or ProcessBackendPTY. Windows always uses ProcessBackendPTY.
+	ProcessBackend string
+
+	// TranscriptPath is the asciicast file the instance's screen is recorded
+	// to for replay ("" = not recorded)
+	TranscriptPath string
+
+	// ReadOnly observes an instance another process runs (see Observe):
+	// output is captured, but input, resizes, recovery, and recording are
+	// skipped, and Stop leaves the instance running. TranscriptPath is then
+	// the recording to follow when the pane cannot be captured.
+	ReadOnly bool
+
+	// JoinWrappedLines captures lines the tmux pane wrapped as one line, so
+	// each stream-json event reaches the state detector whole
+	JoinWrappedLines bool
 }
 
 // DefaultManagerConfig returns the default manager configuration
@@ -134,12 +154,13 @@ type ManagerOptions struct {
 	WorkDir          string
 	Task             string
 	Config           ManagerConfig
-	Callbacks        ManagerCallbacks   // Callbacks for state changes, metrics, timeouts, bells
-	StateMonitor     *state.Monitor     // Optional - if nil, an internal monitor is created
-	LifecycleManager *lifecycle.Manager // Optional - if set, delegates Start/Stop/Reconnect
-	ClaudeSessionID  string             // Optional - backend session UUID for resume capability (legacy field name)
-	Backend          ai.Backend         // Optional - AI backend (defaults to Claude)
-	StartOverrides   ai.StartOptions    // Optional - per-instance overrides merged into BuildStartCommand
+	Callbacks        ManagerCallbacks    // Callbacks for state changes, metrics, timeouts, bells
+	StateMonitor     *state.Monitor      // Optional - if nil, an internal monitor is created
+	LifecycleManager *lifecycle.Manager  // Optional - if set, delegates Start/Stop/Reconnect
+	ClaudeSessionID  string              // Optional - backend session UUID for resume capability (legacy field name)
+	Backend          ai.Backend          // Optional - AI backend (defaults to Claude)
+	StartOverrides   ai.StartOptions     // Optional - per-instance overrides merged into BuildStartCommand
+	Resources        *process.Controller // Optional - limits the CPU and memory of the instance's processes
 }
 
 // Manager handles a single AI backend instance running in a tmux session.
@@ -217,9 +238,16 @@ type Manager struct {
 	stateMonitor *state.Monitor
 
 	// startOverrides holds per-instance CLI flag overrides merged into BuildStartCommand.
-	// These are set at construction time via ManagerOptions.StartOverrides and take
-	// precedence over the backend's config-level defaults.
+	// These are set at construction time via ManagerOptions.StartOverrides, or
+	// later via SetStartOverrides, and take precedence over the backend's
+	// config-level defaults.
 	startOverrides ai.StartOptions
+
+	// resources limits the CPU and memory of the pane's processes (nil = unlimited)
+	resources *process.Controller
+
+	// pty runs the backend when usesPTY
+	pty *process.PTYProcess
 }
 
 // NewManagerWithDeps creates a new instance manager with explicit dependencies.
@@ -278,34 +306,33 @@ func NewManagerWithDeps(opts ManagerOptions) *Manager {
 	// Each instance gets its own tmux socket for crash isolation
 	socketName := tmux.InstanceSocketName(opts.ID)
 
-	return &Manager{
-		id:              opts.ID,
-		sessionID:       opts.SessionID,
-		workdir:         opts.WorkDir,
-		task:            opts.Task,
-		sessionName:     sessionName,
-		socketName:      socketName,
-		claudeSessionID: opts.ClaudeSessionID,
-		outputBuf:       capture.NewRingBuffer(cfg.OutputBufferSize),
-		doneChan:        make(chan struct{}),
-		config:          cfg,
-		configured:      true, // Mark as properly constructed
-		stateCallback:   opts.Callbacks.OnStateChange,
-		metricsParser:   metricsParser,
-		metricsCallback: opts.Callbacks.OnMetrics,
-		timeoutCallback: opts.Callbacks.OnTimeout,
-		bellCallback:    opts.Callbacks.OnBell,
-		inputHandler: input.NewHandler(
-			input.WithPersistentSender(sessionName, socketName),
-			input.WithBatching(sessionName, input.DefaultBatchConfig()),
-		),
+	m := &Manager{
+		id:                  opts.ID,
+		sessionID:           opts.SessionID,
+		workdir:             opts.WorkDir,
+		task:                opts.Task,
+		sessionName:         sessionName,
+		socketName:          socketName,
+		claudeSessionID:     opts.ClaudeSessionID,
+		outputBuf:           capture.NewRingBuffer(cfg.OutputBufferSize),
+		doneChan:            make(chan struct{}),
+		config:              cfg,
+		configured:          true, // Mark as properly constructed
+		stateCallback:       opts.Callbacks.OnStateChange,
+		metricsParser:       metricsParser,
+		metricsCallback:     opts.Callbacks.OnMetrics,
+		timeoutCallback:     opts.Callbacks.OnTimeout,
+		bellCallback:        opts.Callbacks.OnBell,
 		maxRecoveryAttempts: defaultMaxRecoveryAttempts,
 		recoveryCallback:    opts.Callbacks.OnRecovery,
 		stateMonitor:        monitor,
 		lifecycleManager:    opts.LifecycleManager,
 		backend:             backend,
 		startOverrides:      opts.StartOverrides,
+		resources:           opts.Resources,
 	}
+	m.inputHandler = m.newInputHandler()
+	return m
 }
 
 // SetStateCallback sets a callback that will be invoked when the detected state changes.
@@ -443,6 +470,7 @@ func (m *Manager) createTmuxSession() error {
 	createCmd.Dir = m.workdir
 	// Append TERM to the existing env (which already includes TMUX_TMPDIR from CommandWithSocket)
 	createCmd.Env = append(createCmd.Env, "TERM=xterm-256color")
+	createCmd.Env = append(createCmd.Env, m.resources.Env(m.id)...)
 	if err := createCmd.Run(); err != nil {
 		if m.logger != nil {
 			m.logger.Error("failed to create tmux session",
@@ -468,6 +496,13 @@ func (m *Manager) createTmuxSession() error {
 		}
 	}
 
+	// Limit the pane before the backend starts so everything it runs inherits the limits
+	if m.resources != nil {
+		if err := m.resources.Attach(m.id, tmux.GetPanePID(m.socketName, m.sessionName)); err != nil && m.logger != nil {
+			m.logger.Warn("failed to apply resource limits", "error", err.Error())
+		}
+	}
+
 	return nil
 }
 
@@ -479,6 +514,9 @@ func (m *Manager) Start() error {
 	if !m.configured {
 		return ErrManagerNotConfigured
 	}
+	if m.config.ReadOnly {
+		return ErrReadOnly
+	}
 
 	// Delegate to lifecycle manager if available
 	if m.lifecycleManager != nil {
@@ -496,7 +534,7 @@ func (m *Manager) Start() error {
 		m.backend = ai.DefaultBackend()
 	}
 
-	if err := m.createTmuxSession(); err != nil {
+	if err := m.createSession(); err != nil {
 		return err
 	}
 
@@ -504,7 +542,7 @@ func (m *Manager) Start() error {
 	// (prompts with <, >, |, etc. would otherwise be interpreted by the shell)
 	promptFile := filepath.Join(m.workdir, m.backend.PromptFileName())
 	if err := os.WriteFile(promptFile, []byte(m.task), 0600); err != nil {
-		_ = m.tmuxCmd("kill-session", "-t", m.sessionName).Run()
+		m.killSession()
 		return fmt.Errorf("failed to write prompt file: %w", err)
 	}
 
@@ -517,20 +555,15 @@ func (m *Manager) Start() error {
 	opts.Mode = ai.StartModeInteractive
 	backendCmd, err := m.backend.BuildStartCommand(opts)
 	if err != nil {
-		_ = m.tmuxCmd("kill-session", "-t", m.sessionName).Run()
+		m.killSession()
 		_ = os.Remove(promptFile)
 		return fmt.Errorf("failed to build backend command: %w", err)
 	}
+	backendCmd = ai.CommandWithEnv(opts.Env, backendCmd)
 
-	sendCmd := m.tmuxCmd(
-		"send-keys",
-		"-t", m.sessionName,
-		backendCmd,
-		"Enter",
-	)
-	if err := sendCmd.Run(); err != nil {
+	if err := m.runInSession(backendCmd); err != nil {
 		// Clean up the session if we failed to start the backend
-		_ = m.tmuxCmd("kill-session", "-t", m.sessionName).Run()
+		m.killSession()
 		_ = os.Remove(promptFile)
 		if m.logger != nil {
 			m.logger.Error("failed to start backend in tmux session",
@@ -578,6 +611,9 @@ func (m *Manager) StartWithResume() error {
 	if !m.configured {
 		return ErrManagerNotConfigured
 	}
+	if m.config.ReadOnly {
+		return ErrReadOnly
+	}
 
 	// Delegate to lifecycle manager if available
 	if m.lifecycleManager != nil {
@@ -601,25 +637,19 @@ func (m *Manager) StartWithResume() error {
 		return fmt.Errorf("backend %s does not support resume", m.backend.Name())
 	}
 
-	if err := m.createTmuxSession(); err != nil {
+	if err := m.createSession(); err != nil {
 		return err
 	}
 
 	// Build the backend command with resume to continue the previous session
 	backendCmd, err := m.backend.BuildResumeCommand(m.claudeSessionID)
 	if err != nil {
-		_ = m.tmuxCmd("kill-session", "-t", m.sessionName).Run()
+		m.killSession()
 		return fmt.Errorf("failed to build backend resume command: %w", err)
 	}
 
-	sendCmd := m.tmuxCmd(
-		"send-keys",
-		"-t", m.sessionName,
-		backendCmd,
-		"Enter",
-	)
-	if err := sendCmd.Run(); err != nil {
-		_ = m.tmuxCmd("kill-session", "-t", m.sessionName).Run()
+	if err := m.runInSession(backendCmd); err != nil {
+		m.killSession()
 		if m.logger != nil {
 			m.logger.Error("failed to start backend with resume in tmux session",
 				"session_name", m.sessionName,
@@ -674,6 +704,11 @@ func (m *Manager) captureLoop() {
 	var lastVisibleOutput string
 	var lastFullOutput string
 
+	transcript := m.openTranscript()
+	if transcript != nil {
+		defer func() { _ = transcript.Close() }()
+	}
+
 	for {
 		select {
 		case <-m.doneChan:
@@ -718,7 +753,9 @@ func (m *Manager) captureLoop() {
 
 			// Batched tmux query: get history_size, bell_flag, and session existence
 			// in a single subprocess call to reduce overhead (was 4 calls, now 2).
+			statusStart := time.Now()
 			status := m.getSessionStatus(sessionName)
+			diag.Record("capture.status", time.Since(statusStart))
 
 			// Check if session ended
 			if !status.sessionExists {
@@ -766,10 +803,13 @@ func (m *Manager) captureLoop() {
 
 			var output []byte
 			var err error
+			captureStart := time.Now()
 			if doFullCapture {
 				output, err = m.captureFullPane(sessionName)
+				diag.Record("capture.full", time.Since(captureStart))
 			} else {
 				output, err = m.captureVisiblePane(sessionName)
+				diag.Record("capture.visible", time.Since(captureStart))
 			}
 			if err != nil {
 				m.mu.Lock()
@@ -800,8 +840,13 @@ func (m *Manager) captureLoop() {
 							"time_since_success", timeSinceSuccess,
 							"consecutive_errors", consecutiveErrors)
 					}
-					// Attempt to kill the tmux session to clean up resources
-					_ = m.tmuxCmd("kill-session", "-t", sessionName).Run()
+					// Attempt to kill the tmux session to clean up resources,
+					// unless it belongs to another process
+					m.mu.Lock()
+					if !m.config.ReadOnly {
+						m.killSession()
+					}
+					m.mu.Unlock()
 					if m.attemptSessionRecovery(instanceID) {
 						lastVisibleOutput = ""
 						lastFullOutput = ""
@@ -839,6 +884,14 @@ func (m *Manager) captureLoop() {
 			m.consecutiveCaptureErrors = 0
 			m.mu.Unlock()
 
+			if transcript != nil {
+				if err := transcript.WriteFrame(output); err != nil {
+					m.logTranscriptError(err)
+					_ = transcript.Close()
+					transcript = nil
+				}
+			}
+
 			// Check if content changed — using separate tracking for visible vs full captures.
 			// Visible captures only trigger forceFullCapture; full captures write to outputBuf.
 			currentOutput := string(output)
@@ -906,6 +959,43 @@ func (m *Manager) captureLoop() {
 	}
 }
 
+// openTranscript opens the transcript recorder when recording is configured.
+// Recording is best effort: failures are logged and the instance runs
+// unrecorded.
+func (m *Manager) openTranscript() *capture.Recorder {
+	m.mu.RLock()
+	path := m.config.TranscriptPath
+	header := capture.TranscriptHeader{
+		Width:  m.config.TmuxWidth,
+		Height: m.config.TmuxHeight,
+		Title:  m.id,
+	}
+	readOnly := m.config.ReadOnly
+	m.mu.RUnlock()
+	if path == "" || readOnly {
+		return nil
+	}
+
+	rec, err := capture.OpenRecorder(path, header)
+	if err != nil {
+		m.logTranscriptError(err)
+		return nil
+	}
+	return rec
+}
+
+// logTranscriptError logs that recording the transcript failed.
+func (m *Manager) logTranscriptError(err error) {
+	m.mu.RLock()
+	logger := m.logger
+	m.mu.RUnlock()
+	if logger != nil {
+		logger.Warn("transcript recording stopped",
+			"instance_id", m.id,
+			"error", err.Error())
+	}
+}
+
 // sessionStatus holds the result of a batched tmux query for session state.
 // Using a single tmux command to query multiple values reduces subprocess overhead.
 type sessionStatus struct {
@@ -938,6 +1028,10 @@ func parseSessionStatusOutput(output string) (historySize int, bellActive bool,
 // If the command fails with a known "session not found" error, sessionExists is false.
 // For unknown errors or transient failures, sessionExists is true to allow retry.
 func (m *Manager) getSessionStatus(sessionName string) sessionStatus {
+	if m.usesPTY() {
+		return m.ptyStatus()
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), tmuxCommandTimeout)
 	defer cancel()
 
@@ -1032,6 +1126,10 @@ func (m *Manager) getSessionStatus(sessionName string) sessionStatus {
 // This is used for heartbeat checks on paused instances - it avoids the overhead
 // of querying history_size and bell_flag when we only care about session existence.
 func (m *Manager) checkSessionExists(sessionName string) bool {
+	if m.usesPTY() {
+		return m.ptyStatus().sessionExists
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), tmuxCommandTimeout)
 	defer cancel()
 
@@ -1126,6 +1224,11 @@ func (m *Manager) attemptSessionRecovery(instanceID string) bool {
 	// Snapshot and check preconditions atomically under a single lock acquisition
 	// to prevent TOCTOU races on the recovery counter.
 	m.mu.Lock()
+	if m.config.ReadOnly {
+		// The process running the instance recovers it
+		m.mu.Unlock()
+		return false
+	}
 	attempts := m.recoveryAttempts
 	maxAttempts := m.maxRecoveryAttempts
 	sessionID := m.claudeSessionID
@@ -1186,14 +1289,14 @@ func (m *Manager) attemptSessionRecovery(instanceID string) bool {
 	}
 
 	// Kill the old tmux server (cleanup dead socket)
-	if err := tmux.KillServer(m.socketName); err != nil && logger != nil {
+	if err := m.killServer(); err != nil && logger != nil {
 		logger.Debug("failed to kill old tmux server during recovery (may already be dead)",
 			"instance_id", instanceID, "error", err.Error())
 	}
 
 	// Create a fresh tmux session
 	m.mu.Lock()
-	err := m.createTmuxSession()
+	err := m.createSession()
 	m.mu.Unlock()
 	if err != nil {
 		if logger != nil {
@@ -1213,26 +1316,23 @@ func (m *Manager) attemptSessionRecovery(instanceID string) bool {
 				"error", err.Error())
 		}
 		// Clean up the freshly created server since recovery failed
-		if killErr := tmux.KillServer(m.socketName); killErr != nil && logger != nil {
+		if killErr := m.killServer(); killErr != nil && logger != nil {
 			logger.Debug("failed to clean up tmux server after recovery failure",
 				"instance_id", instanceID, "error", killErr.Error())
 		}
 		return false
 	}
 
-	sendCmd := m.tmuxCmd(
-		"send-keys",
-		"-t", m.sessionName,
-		resumeCmd,
-		"Enter",
-	)
-	if err := sendCmd.Run(); err != nil {
+	m.mu.Lock()
+	err = m.runInSession(resumeCmd)
+	m.mu.Unlock()
+	if err != nil {
 		if logger != nil {
 			logger.Error("tmux recovery failed: could not send resume command",
 				"instance_id", instanceID,
 				"error", err.Error())
 		}
-		if killErr := tmux.KillServer(m.socketName); killErr != nil && logger != nil {
+		if killErr := m.killServer(); killErr != nil && logger != nil {
 			logger.Debug("failed to clean up tmux server after recovery failure",
 				"instance_id", instanceID, "error", killErr.Error())
 		}
@@ -1270,16 +1370,31 @@ func (m *Manager) attemptSessionRecovery(instanceID string) bool {
 // captureVisiblePane captures only the visible pane content (no scrollback history).
 // This is much faster than capturing the full scrollback buffer.
 func (m *Manager) captureVisiblePane(sessionName string) ([]byte, error) {
+	if m.usesPTY() {
+		return m.capturePTY(false)
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), tmuxCommandTimeout)
 	defer cancel()
-	return m.tmuxCmdCtx(ctx, "capture-pane", "-t", sessionName, "-p", "-e").Output()
+	return m.tmuxCmdCtx(ctx, m.captureArgs(sessionName)...).Output()
 }
 
 // captureFullPane captures the full pane content including scrollback history.
 func (m *Manager) captureFullPane(sessionName string) ([]byte, error) {
+	if m.usesPTY() {
+		return m.capturePTY(true)
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), tmuxCommandTimeout)
 	defer cancel()
-	return m.tmuxCmdCtx(ctx, "capture-pane", "-t", sessionName, "-p", "-e", "-S", "-", "-E", "-").Output()
+	return m.tmuxCmdCtx(ctx, append(m.captureArgs(sessionName), "-S", "-", "-E", "-")...).Output()
+}
+
+// captureArgs returns the tmux capture-pane arguments for the visible pane.
+func (m *Manager) captureArgs(sessionName string) []string {
+	args := []string{"capture-pane", "-t", sessionName, "-p", "-e"}
+	if m.config.JoinWrappedLines {
+		args = append(args, "-J")
+	}
+	return args
 }
 
 // parseAndNotifyMetrics parses metrics from output and notifies if changed
@@ -1326,6 +1441,11 @@ func (m *Manager) parseAndNotifyMetrics(output []byte) {
 
 // Stop terminates the tmux session and ensures all backend processes are killed.
 func (m *Manager) Stop() error {
+	if m.config.ReadOnly {
+		m.stopObserving()
+		return nil
+	}
+
 	// Delegate to lifecycle manager if available
 	if m.lifecycleManager != nil {
 		// Close input handler before delegating (Manager-specific cleanup)
@@ -1359,8 +1479,19 @@ func (m *Manager) Stop() error {
 		_ = m.inputHandler.Close()
 	}
 
-	// Graceful shutdown: Ctrl+C → poll → kill session → kill server → force-kill survivors
-	tmux.GracefulShutdown(m.socketName, m.sessionName, tmux.DefaultGracefulStopTimeout)
+	if m.usesPTY() {
+		// Ctrl+C → poll → hang up → force-kill survivors
+		if m.pty != nil {
+			_ = m.pty.Stop()
+		}
+	} else {
+		// Graceful shutdown: Ctrl+C → poll → kill session → kill server → force-kill survivors
+		tmux.GracefulShutdown(m.socketName, m.sessionName, tmux.DefaultGracefulStopTimeout)
+	}
+
+	if err := m.resources.Detach(m.id); err != nil && m.logger != nil {
+		m.logger.Debug("failed to release resource limits", "error", err.Error())
+	}
 
 	// Unregister from state monitor
 	m.stateMonitor.Stop(m.id)
@@ -1404,7 +1535,7 @@ func (m *Manager) Resume() error {
 // SendInput sends input to the tmux session
 func (m *Manager) SendInput(data []byte) {
 	m.mu.RLock()
-	running := m.running
+	running := m.running && !m.config.ReadOnly
 	sessionName := m.sessionName
 	handler := m.inputHandler
 	m.mu.RUnlock()
@@ -1420,7 +1551,7 @@ func (m *Manager) SendInput(data []byte) {
 // SendKey sends a special key to the tmux session
 func (m *Manager) SendKey(key string) {
 	m.mu.RLock()
-	running := m.running
+	running := m.running && !m.config.ReadOnly
 	sessionName := m.sessionName
 	handler := m.inputHandler
 	m.mu.RUnlock()
@@ -1436,7 +1567,7 @@ func (m *Manager) SendKey(key string) {
 // SendLiteral sends literal text to the tmux session (no interpretation)
 func (m *Manager) SendLiteral(text string) {
 	m.mu.RLock()
-	running := m.running
+	running := m.running && !m.config.ReadOnly
 	sessionName := m.sessionName
 	handler := m.inputHandler
 	m.mu.RUnlock()
@@ -1453,7 +1584,7 @@ func (m *Manager) SendLiteral(text string) {
 // This preserves the paste context for applications that support bracketed paste mode
 func (m *Manager) SendPaste(text string) {
 	m.mu.RLock()
-	running := m.running
+	running := m.running && !m.config.ReadOnly
 	sessionName := m.sessionName
 	handler := m.inputHandler
 	m.mu.RUnlock()
@@ -1525,6 +1656,14 @@ func (m *Manager) SetClaudeSessionID(sessionID string) {
 	m.claudeSessionID = sessionID
 }
 
+// SetStartOverrides replaces the CLI flag overrides applied the next time
+// the instance starts.
+func (m *Manager) SetStartOverrides(overrides ai.StartOptions) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.startOverrides = overrides
+}
+
 // tmuxCmd creates a tmux command using this instance's isolated socket.
 func (m *Manager) tmuxCmd(args ...string) *exec.Cmd {
 	return tmux.CommandWithSocket(m.socketName, args...)
@@ -1543,6 +1682,12 @@ func (m *Manager) PID() int {
 	if !m.running {
 		return 0
 	}
+	if m.usesPTY() {
+		if m.pty == nil {
+			return 0
+		}
+		return m.pty.PID()
+	}
 
 	// Get the PID from tmux
 	ctx, cancel := context.WithTimeout(context.Background(), tmuxCommandTimeout)
@@ -1559,13 +1704,26 @@ func (m *Manager) PID() int {
 }
 
 // AttachCommand returns the command to attach to this instance's tmux session
-// This allows users to attach directly if needed
+// This allows users to attach directly if needed. It is empty for the PTY
+// backend, which cannot be attached to. A read-only manager's command
+// attaches read-only.
 func (m *Manager) AttachCommand() string {
+	if m.usesPTY() {
+		return ""
+	}
+	if m.config.ReadOnly {
+		return fmt.Sprintf("tmux -L %s attach -r -t %s", m.socketName, m.sessionName)
+	}
 	return fmt.Sprintf("tmux -L %s attach -t %s", m.socketName, m.sessionName)
 }
 
-// TmuxSessionExists checks if the tmux session for this instance exists
+// TmuxSessionExists checks if the tmux session for this instance exists.
+// For the PTY backend, it reports whether the PTY's shell is running.
 func (m *Manager) TmuxSessionExists() bool {
+	if m.usesPTY() {
+		p := m.currentPTY()
+		return p != nil && p.Running()
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), tmuxCommandTimeout)
 	defer cancel()
 	cmd := m.tmuxCmdCtx(ctx, "has-session", "-t", m.sessionName)
@@ -1580,6 +1738,9 @@ func (m *Manager) Reconnect() error {
 	if !m.configured {
 		return ErrManagerNotConfigured
 	}
+	if m.config.ReadOnly {
+		return ErrReadOnly
+	}
 
 	// Delegate to lifecycle manager if available
 	if m.lifecycleManager != nil {
@@ -1593,6 +1754,11 @@ func (m *Manager) Reconnect() error {
 		return fmt.Errorf("instance already running")
 	}
 
+	// A PTY ends with the process that started it, so there is nothing to reconnect to
+	if m.usesPTY() {
+		return fmt.Errorf("pty backend sessions cannot be reconnected")
+	}
+
 	// Check if the tmux session exists
 	if !m.TmuxSessionExists() {
 		return fmt.Errorf("tmux session %s does not exist", m.sessionName)
@@ -1829,17 +1995,28 @@ func (m *Manager) OnStopped() {
 
 // Resize changes the tmux pane dimensions
 // This is useful when the display area changes (e.g., sidebar added/removed)
+// The next capture re-reads the full scrollback, which tmux has reflowed to
+// the new width, so the viewer doesn't keep showing lines wrapped at the old one.
 func (m *Manager) Resize(width, height int) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if !m.running {
+	// An observed pane keeps the size its owner gave it
+	if !m.running || m.config.ReadOnly || (width == m.config.TmuxWidth && height == m.config.TmuxHeight) {
 		return nil
 	}
 
-	// Update stored config
-	m.config.TmuxWidth = width
-	m.config.TmuxHeight = height
+	if m.usesPTY() {
+		if m.pty != nil {
+			if err := m.pty.Resize(width, height); err != nil {
+				return err
+			}
+		}
+		m.config.TmuxWidth = width
+		m.config.TmuxHeight = height
+		m.forceFullCapture = true
+		return nil
+	}
 
 	// Resize the tmux window
 	// Note: We resize the window (not pane) since each session has one window
@@ -1855,6 +2032,12 @@ func (m *Manager) Resize(width, height int) error {
 		return fmt.Errorf("failed to resize tmux session: %w", err)
 	}
 
+	// Update stored config only once tmux has the new size, so a failed
+	// resize is retried rather than skipped as unchanged
+	m.config.TmuxWidth = width
+	m.config.TmuxHeight = height
+	m.forceFullCapture = true
+
 	return nil
 }
 
@@ -2,11 +2,13 @@ package instance
 
 import (
 	"errors"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/Iron-Ham/claudio/internal/ai"
+	"github.com/Iron-Ham/claudio/internal/instance/capture"
 	"github.com/Iron-Ham/claudio/internal/instance/detect"
 	"github.com/Iron-Ham/claudio/internal/instance/lifecycle"
 	"github.com/Iron-Ham/claudio/internal/instance/state"
@@ -236,6 +238,20 @@ func TestManager_Running_NotStarted(t *testing.T) {
 	}
 }
 
+func TestManager_Resize_UnchangedIsNoop(t *testing.T) {
+	mgr := newTestManagerWithConfig("test", "/tmp", "task", ManagerConfig{TmuxWidth: 120, TmuxHeight: 40})
+	mgr.running = true
+	mgr.sessionName = "claudio-test-no-such-session"
+
+	// Same size: no tmux command runs, so the missing session is no error
+	if err := mgr.Resize(120, 40); err != nil {
+		t.Errorf("Resize() with unchanged size = %v, want nil", err)
+	}
+	if mgr.forceFullCapture {
+		t.Error("Resize() with unchanged size should not force a full capture")
+	}
+}
+
 func TestManager_Paused_NotStarted(t *testing.T) {
 	mgr := newTestManager("test", "/tmp", "task")
 
@@ -1341,3 +1357,32 @@ type noResumeBackend struct {
 }
 
 func (n *noResumeBackend) SupportsResume() bool { return false }
+
+func TestManager_OpenTranscript(t *testing.T) {
+	mgr := newTestManager("inst-1", t.TempDir(), "task")
+	if rec := mgr.openTranscript(); rec != nil {
+		t.Error("openTranscript() returned a recorder without a transcript path")
+	}
+
+	path := filepath.Join(t.TempDir(), "transcripts", "inst-1.cast")
+	mgr = newTestManagerWithConfig("inst-1", t.TempDir(), "task", ManagerConfig{TranscriptPath: path})
+	rec := mgr.openTranscript()
+	if rec == nil {
+		t.Fatal("openTranscript() = nil with a transcript path")
+	}
+	if err := rec.WriteFrame([]byte("hello")); err != nil {
+		t.Fatalf("WriteFrame() error = %v", err)
+	}
+	_ = rec.Close()
+
+	tr, err := capture.ReadTranscript(path)
+	if err != nil {
+		t.Fatalf("ReadTranscript() error = %v", err)
+	}
+	if tr.Header.Title != "inst-1" || tr.Header.Height != mgr.config.TmuxHeight {
+		t.Errorf("Header = %+v, want the instance ID and pane size", tr.Header)
+	}
+	if len(tr.Frames) != 1 || tr.Frames[0].Screen != "hello" {
+		t.Errorf("Frames = %+v, want one frame", tr.Frames)
+	}
+}
@@ -0,0 +1,104 @@
+package instance
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/instance/capture"
+)
+
+// ErrReadOnly is returned when a read-only manager is asked to start or
+// reconnect to the instance it observes.
+var ErrReadOnly = errors.New("instance is observed read-only")
+
+// Observe starts following an instance that another process runs. The
+// manager must be configured ReadOnly. The instance's tmux pane is captured
+// as Reconnect would, without taking it over; when there is no pane to
+// capture (the PTY backend, or a tmux server this user cannot reach), the
+// instance's recorded transcript is followed instead.
+func (m *Manager) Observe() error {
+	if !m.config.ReadOnly {
+		return fmt.Errorf("observe: manager is not read-only")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.running {
+		return nil
+	}
+
+	useTranscript := m.usesPTY() || !m.TmuxSessionExists()
+	if useTranscript {
+		if m.config.TranscriptPath == "" {
+			return fmt.Errorf("observe: no tmux session or transcript for %s", m.id)
+		}
+		if _, err := os.Stat(m.config.TranscriptPath); err != nil {
+			return fmt.Errorf("observe: no tmux session or transcript for %s: %w", m.id, err)
+		}
+	}
+
+	m.running = true
+	m.paused = false
+	m.doneChan = make(chan struct{})
+	m.lastSuccessfulCapture = time.Now()
+	m.consecutiveCaptureErrors = 0
+	m.captureTick = time.NewTicker(time.Duration(m.config.CaptureIntervalMs) * time.Millisecond)
+
+	if useTranscript {
+		go m.transcriptLoop(capture.NewTranscriptTail(m.config.TranscriptPath))
+	} else {
+		m.stateMonitor.Start(m.id)
+		go m.captureLoop()
+	}
+
+	if m.logger != nil {
+		m.logger.Info("observing instance",
+			"session_name", m.sessionName,
+			"from_transcript", useTranscript)
+	}
+	return nil
+}
+
+// transcriptLoop copies the latest screen of the instance's transcript into
+// the output buffer until the manager is stopped.
+func (m *Manager) transcriptLoop(tail *capture.TranscriptTail) {
+	for {
+		select {
+		case <-m.doneChan:
+			return
+		case <-m.captureTick.C:
+			screen, changed, err := tail.Screen()
+			if err != nil || !changed {
+				continue
+			}
+			m.outputBuf.ReplaceWith([]byte(screen))
+		}
+	}
+}
+
+// stopObserving stops capturing an observed instance, leaving the instance
+// itself running.
+func (m *Manager) stopObserving() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.running {
+		return
+	}
+	select {
+	case <-m.doneChan:
+	default:
+		close(m.doneChan)
+	}
+	if m.captureTick != nil {
+		m.captureTick.Stop()
+	}
+	if m.inputHandler != nil {
+		_ = m.inputHandler.Close()
+	}
+	m.stateMonitor.Stop(m.id)
+	m.running = false
+}
@@ -0,0 +1,78 @@
+package instance
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/instance/capture"
+)
+
+func TestManager_ObserveFollowsTranscript(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transcripts", "inst-1.cast")
+	mgr := newTestManagerWithConfig("inst-1", t.TempDir(), "task", ManagerConfig{
+		CaptureIntervalMs: 10,
+		TmuxWidth:         80,
+		TmuxHeight:        10,
+		ProcessBackend:    ProcessBackendPTY,
+		TranscriptPath:    path,
+		ReadOnly:          true,
+	})
+
+	if err := mgr.Observe(); err == nil {
+		t.Fatal("Observe() without a transcript should fail")
+	}
+
+	rec, err := capture.OpenRecorder(path, capture.TranscriptHeader{Width: 80, Height: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = rec.Close() }()
+	if err := rec.WriteFrame([]byte("working on it\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mgr.Observe(); err != nil {
+		t.Fatalf("Observe() error = %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(string(mgr.GetOutput()), "working on it") {
+		if time.Now().After(deadline) {
+			t.Fatalf("transcript never followed, output %q", mgr.GetOutput())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for name, err := range map[string]error{
+		"Start":           mgr.Start(),
+		"StartWithResume": mgr.StartWithResume(),
+		"Reconnect":       mgr.Reconnect(),
+	} {
+		if !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%s() error = %v, want ErrReadOnly", name, err)
+		}
+	}
+
+	if err := mgr.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if mgr.Running() {
+		t.Error("Running() = true after Stop")
+	}
+}
+
+func TestManager_ObserveRequiresReadOnly(t *testing.T) {
+	mgr := newTestManagerWithConfig("inst-1", t.TempDir(), "task", ManagerConfig{ProcessBackend: ProcessBackendPTY})
+	if err := mgr.Observe(); err == nil {
+		t.Error("Observe() on a manager that is not read-only should fail")
+	}
+}
+
+func TestManager_ReadOnlyAttachCommand(t *testing.T) {
+	mgr := newTestManagerWithConfig("inst-1", t.TempDir(), "task", ManagerConfig{ReadOnly: true})
+	if got := mgr.AttachCommand(); !strings.Contains(got, "attach -r -t") {
+		t.Errorf("AttachCommand() = %q, want a read-only attach", got)
+	}
+}
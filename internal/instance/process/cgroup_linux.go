@@ -0,0 +1,157 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// cgroupRoot is where the cgroup v2 hierarchy is mounted.
+const cgroupRoot = "/sys/fs/cgroup"
+
+// cpuPeriod is the cpu.max period, in microseconds.
+const cpuPeriod = 100000
+
+// cgroupTree creates instance cgroups under a cgroup v2 parent.
+type cgroupTree struct {
+	parent      string
+	controllers []string
+}
+
+// openCgroupTree prepares parent, or the cgroup this process runs in when
+// parent is "", to hold instance cgroups limited by controllers.
+func openCgroupTree(parent string, controllers []string) (*cgroupTree, error) {
+	if parent == "" {
+		own, err := ownCgroup("/proc/self/cgroup")
+		if err != nil {
+			return nil, err
+		}
+		parent = filepath.Join(cgroupRoot, own)
+	}
+
+	available, err := os.ReadFile(filepath.Join(parent, "cgroup.controllers"))
+	if err != nil {
+		return nil, fmt.Errorf("no cgroup v2 at %s: %w", parent, err)
+	}
+	for _, ctl := range controllers {
+		if !slices.Contains(strings.Fields(string(available)), ctl) {
+			return nil, fmt.Errorf("cgroup %s does not delegate the %s controller", parent, ctl)
+		}
+	}
+
+	t := &cgroupTree{parent: parent, controllers: controllers}
+	if err := t.enableControllers(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ownCgroup returns the cgroup v2 path listed in a /proc/<pid>/cgroup file.
+func ownCgroup(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if p, ok := strings.CutPrefix(line, "0::"); ok {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("no cgroup v2 entry in %s", path)
+}
+
+// enableControllers makes the controllers available to the parent's child
+// cgroups. A cgroup with processes of its own cannot, so those processes,
+// Claudio's included, are first moved to a "claudio" child.
+func (t *cgroupTree) enableControllers() error {
+	control := filepath.Join(t.parent, "cgroup.subtree_control")
+	want := "+" + strings.Join(t.controllers, " +")
+	err := writeCgroupFile(control, want)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, syscall.EBUSY) {
+		return fmt.Errorf("failed to enable %s in %s: %w", want, t.parent, err)
+	}
+
+	if err := t.moveProcesses(filepath.Join(t.parent, "claudio")); err != nil {
+		return err
+	}
+	if err := writeCgroupFile(control, want); err != nil {
+		return fmt.Errorf("failed to enable %s in %s: %w", want, t.parent, err)
+	}
+	return nil
+}
+
+// moveProcesses moves every process in the parent to the child cgroup dir.
+func (t *cgroupTree) moveProcesses(dir string) error {
+	if err := os.Mkdir(dir, 0o755); err != nil && !errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("failed to create cgroup %s: %w", dir, err)
+	}
+	procs, err := os.ReadFile(filepath.Join(t.parent, "cgroup.procs"))
+	if err != nil {
+		return fmt.Errorf("failed to list processes of %s: %w", t.parent, err)
+	}
+	for _, pid := range strings.Fields(string(procs)) {
+		// A process that exited meanwhile cannot be moved
+		if err := writeCgroupFile(filepath.Join(dir, "cgroup.procs"), pid); err != nil && !errors.Is(err, syscall.ESRCH) {
+			return fmt.Errorf("failed to move pid %s to %s: %w", pid, dir, err)
+		}
+	}
+	return nil
+}
+
+// add moves pid to instance id's cgroup, creating it if needed, and
+// returns the cgroup's directory.
+func (t *cgroupTree) add(id string, pid int) (string, error) {
+	dir := filepath.Join(t.parent, "claudio-"+id)
+	if err := os.Mkdir(dir, 0o755); err != nil && !errors.Is(err, fs.ErrExist) {
+		return "", fmt.Errorf("failed to create cgroup %s: %w", dir, err)
+	}
+	if err := writeCgroupFile(filepath.Join(dir, "cgroup.procs"), strconv.Itoa(pid)); err != nil {
+		return "", fmt.Errorf("failed to move pid %d to %s: %w", pid, dir, err)
+	}
+	return dir, nil
+}
+
+// setLimits writes share to the cgroup dir.
+func (t *cgroupTree) setLimits(dir string, share Share) error {
+	if slices.Contains(t.controllers, "cpu") {
+		cpuMax := "max " + strconv.Itoa(cpuPeriod)
+		if share.CPUPercent > 0 {
+			cpuMax = fmt.Sprintf("%d %d", share.CPUPercent*cpuPeriod/100, cpuPeriod)
+		}
+		if err := writeCgroupFile(filepath.Join(dir, "cpu.max"), cpuMax); err != nil {
+			return fmt.Errorf("failed to limit CPU of %s: %w", dir, err)
+		}
+	}
+	if slices.Contains(t.controllers, "memory") {
+		memoryMax := "max"
+		if share.MemoryMB > 0 {
+			memoryMax = strconv.FormatInt(int64(share.MemoryMB)<<20, 10)
+		}
+		if err := writeCgroupFile(filepath.Join(dir, "memory.max"), memoryMax); err != nil {
+			return fmt.Errorf("failed to limit memory of %s: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// remove deletes the cgroup dir, which fails while processes remain in it.
+func (t *cgroupTree) remove(dir string) error {
+	if err := os.Remove(dir); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove cgroup %s: %w", dir, err)
+	}
+	return nil
+}
+
+// writeCgroupFile writes value to a cgroup interface file.
+func writeCgroupFile(path, value string) error {
+	return os.WriteFile(path, []byte(value), 0o644)
+}
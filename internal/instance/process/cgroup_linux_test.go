@@ -0,0 +1,90 @@
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readCgroupFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile(%s) error = %v", path, err)
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func TestOwnCgroup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cgroup")
+	content := "12:cpuset:/legacy\n0::/user.slice/user-1000.slice/session-2.scope\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ownCgroup(path)
+	if err != nil {
+		t.Fatalf("ownCgroup() error = %v", err)
+	}
+	if got != "/user.slice/user-1000.slice/session-2.scope" {
+		t.Errorf("ownCgroup() = %q", got)
+	}
+
+	if err := os.WriteFile(path, []byte("12:cpuset:/legacy\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ownCgroup(path); err == nil {
+		t.Error("ownCgroup() without a v2 entry: want error")
+	}
+}
+
+func TestController_CgroupRebalancing(t *testing.T) {
+	parent := t.TempDir()
+	if err := os.WriteFile(filepath.Join(parent, "cgroup.controllers"), []byte("cpu memory pids\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c := NewController(Limits{CPUPercent: 400, MemoryMB: 1024, CgroupParent: parent})
+
+	if err := c.Attach("inst-1", 101); err != nil {
+		t.Fatalf("Attach(inst-1) error = %v", err)
+	}
+	if got := readCgroupFile(t, filepath.Join(parent, "cgroup.subtree_control")); got != "+cpu +memory" {
+		t.Errorf("subtree_control = %q, want +cpu +memory", got)
+	}
+	first := filepath.Join(parent, "claudio-inst-1")
+	if got := readCgroupFile(t, filepath.Join(first, "cgroup.procs")); got != "101" {
+		t.Errorf("inst-1 cgroup.procs = %q, want 101", got)
+	}
+	if got := readCgroupFile(t, filepath.Join(first, "cpu.max")); got != "400000 100000" {
+		t.Errorf("inst-1 cpu.max alone = %q, want the whole total", got)
+	}
+
+	if err := c.Attach("inst-2", 202); err != nil {
+		t.Fatalf("Attach(inst-2) error = %v", err)
+	}
+	for _, dir := range []string{first, filepath.Join(parent, "claudio-inst-2")} {
+		if got := readCgroupFile(t, filepath.Join(dir, "cpu.max")); got != "200000 100000" {
+			t.Errorf("%s cpu.max = %q, want half the total", filepath.Base(dir), got)
+		}
+		if got := readCgroupFile(t, filepath.Join(dir, "memory.max")); got != "536870912" {
+			t.Errorf("%s memory.max = %q, want 512 MiB", filepath.Base(dir), got)
+		}
+	}
+
+	// The temporary directory keeps its files, so only the rebalancing is checked
+	_ = c.Detach("inst-2")
+	if got := readCgroupFile(t, filepath.Join(first, "cpu.max")); got != "400000 100000" {
+		t.Errorf("inst-1 cpu.max after Detach(inst-2) = %q, want the whole total", got)
+	}
+}
+
+func TestController_CgroupUnavailable(t *testing.T) {
+	c := NewController(Limits{MemoryMB: 1024, CgroupParent: t.TempDir()})
+	if err := c.Attach("inst-1", 101); err == nil {
+		t.Error("Attach() without cgroup.controllers: want error")
+	}
+	// Only the first attach reports it
+	if err := c.Attach("inst-2", 202); err != nil {
+		t.Errorf("second Attach() error = %v, want nil", err)
+	}
+}
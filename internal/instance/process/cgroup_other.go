@@ -0,0 +1,18 @@
+//go:build !linux
+
+package process
+
+import "errors"
+
+// cgroupTree is unavailable outside Linux.
+type cgroupTree struct{}
+
+func openCgroupTree(string, []string) (*cgroupTree, error) {
+	return nil, errors.New("cgroup limits require Linux")
+}
+
+func (*cgroupTree) add(string, int) (string, error) { return "", nil }
+
+func (*cgroupTree) setLimits(string, Share) error { return nil }
+
+func (*cgroupTree) remove(string) error { return nil }
@@ -0,0 +1,240 @@
+//go:build windows
+
+package process
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+	"unicode/utf16"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// conPTY is a shell in a Windows pseudo console (ConPTY). The shell and
+// everything it starts run in a job object, so they can be killed together.
+type conPTY struct {
+	in      *os.File // typed into the console
+	out     *os.File // the console's output
+	process windows.Handle
+	job     windows.Handle
+	procID  int
+
+	// mu guards console, which is closed once the shell exits
+	mu      sync.Mutex
+	console windows.Handle
+
+	closeOnce sync.Once
+}
+
+// defaultShell returns $SHELL, or Git Bash, which Claude Code also needs on
+// Windows. cmd.exe and PowerShell are not used because instance commands
+// are written for a POSIX shell.
+func defaultShell() (string, error) {
+	if shell := os.Getenv("SHELL"); shell != "" {
+		return shell, nil
+	}
+	candidates := []string{os.Getenv("CLAUDE_CODE_GIT_BASH_PATH")}
+	if git, err := exec.LookPath("git"); err == nil {
+		// git.exe is in Git\cmd or Git\bin; bash.exe is in Git\bin
+		candidates = append(candidates, filepath.Join(filepath.Dir(filepath.Dir(git)), "bin", "bash.exe"))
+	}
+	if dir := os.Getenv("ProgramFiles"); dir != "" {
+		candidates = append(candidates, filepath.Join(dir, "Git", "bin", "bash.exe"))
+	}
+	for _, path := range candidates {
+		if path == "" {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+	}
+	return "", errors.New("no shell found: install Git for Windows or set SHELL to bash.exe")
+}
+
+func startTerminal(shell string, cfg PTYConfig, width, height int) (terminal, error) {
+	var inRead, inWrite, outRead, outWrite windows.Handle
+	if err := windows.CreatePipe(&inRead, &inWrite, nil, 0); err != nil {
+		return nil, err
+	}
+	if err := windows.CreatePipe(&outRead, &outWrite, nil, 0); err != nil {
+		_ = windows.CloseHandle(inRead)
+		_ = windows.CloseHandle(inWrite)
+		return nil, err
+	}
+	// The console keeps its own references to its ends of the pipes
+	defer func() {
+		_ = windows.CloseHandle(inRead)
+		_ = windows.CloseHandle(outWrite)
+	}()
+
+	t := &conPTY{
+		in:  os.NewFile(uintptr(inWrite), "conpty-in"),
+		out: os.NewFile(uintptr(outRead), "conpty-out"),
+	}
+	if err := windows.CreatePseudoConsole(coord(width, height), inRead, outWrite, 0, &t.console); err != nil {
+		t.close()
+		return nil, err
+	}
+	if err := t.spawn(shell, cfg); err != nil {
+		t.close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// spawn starts the shell attached to the console, suspended until it is in
+// the job object so nothing it starts can escape the job.
+func (t *conPTY) spawn(shell string, cfg PTYConfig) error {
+	attrs, err := windows.NewProcThreadAttributeList(1)
+	if err != nil {
+		return err
+	}
+	defer attrs.Delete()
+	// The attribute's value is the console handle itself
+	console := *(*unsafe.Pointer)(unsafe.Pointer(&t.console))
+	if err := attrs.Update(windows.PROC_THREAD_ATTRIBUTE_PSEUDOCONSOLE, console, unsafe.Sizeof(t.console)); err != nil {
+		return err
+	}
+
+	si := new(windows.StartupInfoEx)
+	si.Cb = uint32(unsafe.Sizeof(*si))
+	// Without std handles of its own the shell would inherit claudio's
+	// instead of using the console
+	si.Flags = windows.STARTF_USESTDHANDLES
+	si.ProcThreadAttributeList = attrs.List()
+
+	cmdLine, err := windows.UTF16PtrFromString(windows.ComposeCommandLine([]string{shell}))
+	if err != nil {
+		return err
+	}
+	var dir *uint16
+	if cfg.Dir != "" {
+		if dir, err = windows.UTF16PtrFromString(cfg.Dir); err != nil {
+			return err
+		}
+	}
+	var env *uint16
+	if cfg.Env != nil {
+		env = envBlock(cfg.Env)
+	}
+
+	var pi windows.ProcessInformation
+	flags := uint32(windows.EXTENDED_STARTUPINFO_PRESENT | windows.CREATE_UNICODE_ENVIRONMENT | windows.CREATE_SUSPENDED)
+	if err := windows.CreateProcess(nil, cmdLine, nil, nil, false, flags, env, dir, &si.StartupInfo, &pi); err != nil {
+		return err
+	}
+	defer func() { _ = windows.CloseHandle(pi.Thread) }()
+	t.process, t.procID = pi.Process, int(pi.ProcessId)
+
+	if t.job, err = newKillOnCloseJob(); err == nil {
+		err = windows.AssignProcessToJobObject(t.job, pi.Process)
+	}
+	if err != nil {
+		_ = windows.TerminateProcess(pi.Process, 1)
+		return err
+	}
+	if _, err := windows.ResumeThread(pi.Thread); err != nil {
+		_ = windows.TerminateProcess(pi.Process, 1)
+		return err
+	}
+	return nil
+}
+
+// newKillOnCloseJob creates a job object whose processes are killed when
+// its last handle is closed, including if claudio exits.
+func newKillOnCloseJob() (windows.Handle, error) {
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	var info windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION
+	info.BasicLimitInformation.LimitFlags = windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE
+	if _, err := windows.SetInformationJobObject(job, windows.JobObjectExtendedLimitInformation,
+		uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info))); err != nil {
+		_ = windows.CloseHandle(job)
+		return 0, err
+	}
+	return job, nil
+}
+
+// envBlock returns env as a Unicode environment block: each KEY=value
+// NUL-terminated, followed by another NUL.
+func envBlock(env []string) *uint16 {
+	var block []uint16
+	for _, kv := range env {
+		block = append(block, utf16.Encode([]rune(kv))...)
+		block = append(block, 0)
+	}
+	block = append(block, 0)
+	if len(env) == 0 {
+		block = append(block, 0)
+	}
+	return &block[0]
+}
+
+func coord(width, height int) windows.Coord {
+	return windows.Coord{X: int16(width), Y: int16(height)}
+}
+
+func (t *conPTY) Read(b []byte) (int, error)  { return t.out.Read(b) }
+func (t *conPTY) Write(b []byte) (int, error) { return t.in.Write(b) }
+
+func (t *conPTY) pid() int { return t.procID }
+
+func (t *conPTY) resize(width, height int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.console == 0 {
+		return nil
+	}
+	return windows.ResizePseudoConsole(t.console, coord(width, height))
+}
+
+func (t *conPTY) wait() error {
+	_, err := windows.WaitForSingleObject(t.process, windows.INFINITE)
+	return err
+}
+
+// closeAfterExit closes the console first, which flushes its last output
+// and ends the output pipe, so the reader finishes on its own.
+func (t *conPTY) closeAfterExit(read <-chan struct{}) {
+	t.closeConsole()
+	select {
+	case <-read:
+	case <-time.After(drainTimeout):
+	}
+	t.close()
+}
+
+// killer kills the job, which holds the shell and everything it started.
+func (t *conPTY) killer() func() {
+	return func() { _ = windows.TerminateJobObject(t.job, 1) }
+}
+
+func (t *conPTY) closeConsole() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.console != 0 {
+		windows.ClosePseudoConsole(t.console)
+		t.console = 0
+	}
+}
+
+func (t *conPTY) close() {
+	t.closeOnce.Do(func() {
+		t.closeConsole()
+		_ = t.in.Close()
+		_ = t.out.Close()
+		for _, h := range []windows.Handle{t.job, t.process} {
+			if h != 0 {
+				_ = windows.CloseHandle(h)
+			}
+		}
+	})
+}
@@ -0,0 +1,30 @@
+//go:build windows
+
+package process
+
+import (
+	"slices"
+	"testing"
+	"unicode/utf16"
+	"unsafe"
+)
+
+func TestEnvBlock(t *testing.T) {
+	tests := []struct {
+		name string
+		env  []string
+		want string
+	}{
+		{"empty", []string{}, "\x00\x00"},
+		{"variables", []string{"A=1", "PATH=C:\\bin"}, "A=1\x00PATH=C:\\bin\x00\x00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := utf16.Encode([]rune(tt.want))
+			got := unsafe.Slice(envBlock(tt.env), len(want))
+			if !slices.Equal(got, want) {
+				t.Errorf("envBlock(%q) = %q, want %q", tt.env, string(utf16.Decode(got)), tt.want)
+			}
+		})
+	}
+}
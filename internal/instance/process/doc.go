@@ -0,0 +1,62 @@
+// Package process runs backend instances without tmux and limits the CPU
+// and memory that instances, and the builds they run, may take from the
+// machine.
+//
+// # Main Types
+//
+//   - [Process], [OutputProvider], [Resizable]: A program in a terminal
+//   - [PTYProcess]: Runs a shell in a pseudo-terminal instead of a tmux pane
+//   - [Screen]: The terminal emulator that captures a [PTYProcess]'s output
+//   - [Limits]: The configured niceness, GOMAXPROCS hint, and totals
+//   - [Controller]: Applies the limits to each instance's pane process
+//
+// # PTY Backend
+//
+// A [PTYProcess] starts the user's shell in a pseudo-terminal, like a tmux
+// pane, and reads its output continuously into a [Screen]. Capturing the
+// screen or the history is then a copy instead of a tmux command. Input is
+// written to the terminal; [PTYProcess.SendKeys] takes the key names tmux
+// send-keys does, translated by [KeyBytes]. Unlike a tmux session, the
+// process ends with claudio and cannot be attached to from another terminal.
+//
+// On Windows the terminal is a ConPTY pseudo console, the default shell is
+// Git Bash, and the shell runs in a job object so stopping it ends
+// everything it started.
+//
+// # Limits
+//
+// Each instance's pane process is reniced to [Limits.Nice]; everything the
+// instance starts inherits it. [Controller.Env] returns a GOMAXPROCS hint
+// for the next instance, so Go builds it runs share the CPUs with the other
+// instances instead of each using all of them.
+//
+// On Linux with cgroup v2, [Limits.CPUPercent] and [Limits.MemoryMB] are
+// totals for all instances. Each attached instance gets its own cgroup
+// under [Limits.CgroupParent], and the totals are divided evenly among the
+// attached instances, rebalanced whenever one is attached or detached. On
+// other systems, or when no cgroup can be created, only niceness and the
+// GOMAXPROCS hint apply.
+//
+// # Thread Safety
+//
+// [PTYProcess] and [Controller] are safe for concurrent use. A nil
+// *Controller applies no limits. [Screen] is not safe for concurrent use.
+//
+// # Basic Usage
+//
+//	ctrl := process.NewController(process.Limits{Nice: 10, CPUPercent: 400})
+//	env := ctrl.Env("inst-1") // GOMAXPROCS for the new pane's environment
+//	if err := ctrl.Attach("inst-1", panePID); err != nil {
+//		// limits only partly applied
+//	}
+//	defer ctrl.Detach("inst-1")
+//
+//	p := process.NewPTYProcess(process.PTYConfig{Dir: workdir, Width: 200, Height: 30})
+//	if err := p.Start(); err != nil {
+//		return err
+//	}
+//	defer p.Stop()
+//	_ = p.SendKeys("", "claude", true)
+//	_ = p.SendKeys("", "Enter", false)
+//	screen := p.Screen()
+package process
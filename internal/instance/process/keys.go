@@ -0,0 +1,118 @@
+package process
+
+import "strconv"
+
+// namedKeys maps tmux key names to the bytes an xterm-compatible terminal
+// sends for them.
+var namedKeys = map[string]string{
+	"Enter":    "\r",
+	"Tab":      "\t",
+	"BTab":     "\x1b[Z",
+	"BSpace":   "\x7f",
+	"Escape":   "\x1b",
+	"Space":    " ",
+	"Up":       "\x1b[A",
+	"Down":     "\x1b[B",
+	"Right":    "\x1b[C",
+	"Left":     "\x1b[D",
+	"Home":     "\x1b[H",
+	"End":      "\x1b[F",
+	"IC":       "\x1b[2~",
+	"DC":       "\x1b[3~",
+	"PageUp":   "\x1b[5~",
+	"PPage":    "\x1b[5~",
+	"PageDown": "\x1b[6~",
+	"NPage":    "\x1b[6~",
+	"F1":       "\x1bOP",
+	"F2":       "\x1bOQ",
+	"F3":       "\x1bOR",
+	"F4":       "\x1bOS",
+	"F5":       "\x1b[15~",
+	"F6":       "\x1b[17~",
+	"F7":       "\x1b[18~",
+	"F8":       "\x1b[19~",
+	"F9":       "\x1b[20~",
+	"F10":      "\x1b[21~",
+	"F11":      "\x1b[23~",
+	"F12":      "\x1b[24~",
+}
+
+// cursorKeys are the final bytes of the keys that take modifiers as
+// "ESC [ 1 ; m X".
+var cursorKeys = map[string]byte{
+	"Up":    'A',
+	"Down":  'B',
+	"Right": 'C',
+	"Left":  'D',
+	"Home":  'H',
+	"End":   'F',
+}
+
+// KeyBytes returns the bytes a terminal sends for a tmux key name, such as
+// "Enter", "C-c", "M-Left", or "S-Up". Names tmux doesn't know are sent as
+// typed, as tmux send-keys does.
+func KeyBytes(key string) string {
+	if b, ok := namedKeys[key]; ok {
+		return b
+	}
+
+	mods, name := 0, key
+	for len(name) > 2 && name[1] == '-' {
+		switch name[0] {
+		case 'S':
+			mods |= 1
+		case 'M':
+			mods |= 2
+		case 'C':
+			mods |= 4
+		default:
+			return key
+		}
+		name = name[2:]
+	}
+	if mods == 0 {
+		return key
+	}
+
+	if final, ok := cursorKeys[name]; ok {
+		return "\x1b[1;" + strconv.Itoa(mods+1) + string(final)
+	}
+
+	b := name
+	if named, ok := namedKeys[name]; ok {
+		b = named
+	} else if len(name) != 1 {
+		return key
+	}
+	if mods&4 != 0 {
+		ctrl, ok := controlByte(b)
+		if !ok {
+			return key
+		}
+		b = ctrl
+	}
+	if mods&2 != 0 {
+		b = "\x1b" + b
+	}
+	return b
+}
+
+// controlByte returns the control character for Ctrl and a key, such as
+// "\x03" for c.
+func controlByte(key string) (string, bool) {
+	if len(key) != 1 {
+		return "", false
+	}
+	c := key[0]
+	switch {
+	case c >= 'a' && c <= 'z':
+		return string(c - 'a' + 1), true
+	case c >= '@' && c <= '_':
+		return string(c - '@'), true
+	case c == ' ' || c == '2':
+		return "\x00", true
+	case c == '?':
+		return "\x7f", true
+	}
+	return "", false
+}
@@ -0,0 +1,36 @@
+package process
+
+import "testing"
+
+func TestKeyBytes(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"Enter", "\r"},
+		{"BSpace", "\x7f"},
+		{"Escape", "\x1b"},
+		{"Up", "\x1b[A"},
+		{"PageDown", "\x1b[6~"},
+		{"DC", "\x1b[3~"},
+		{"F5", "\x1b[15~"},
+		{"C-c", "\x03"},
+		{"C-a", "\x01"},
+		{"C-Space", "\x00"},
+		{"M-b", "\x1bb"},
+		{"M-BSpace", "\x1b\x7f"},
+		{"M-Left", "\x1b[1;3D"},
+		{"S-Up", "\x1b[1;2A"},
+		{"C-S-Right", "\x1b[1;6C"},
+		{"x", "x"},
+		{"hello", "hello"},
+		{"Q-x", "Q-x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := KeyBytes(tt.key); got != tt.want {
+				t.Errorf("KeyBytes(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
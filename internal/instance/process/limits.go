@@ -0,0 +1,201 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// Limits are the resource limits applied to instances.
+type Limits struct {
+	// Nice is the niceness given to each instance's processes (0 = unchanged).
+	// Windows maps it to a priority class.
+	Nice int
+	// GoMaxProcs is the GOMAXPROCS hint given to each instance. 0 divides
+	// the CPUs evenly among the running instances.
+	GoMaxProcs int
+	// CPUPercent is the CPU time all instances may use together, in percent
+	// of one CPU (400 = four CPUs). 0 = unlimited. Linux cgroup v2 only.
+	CPUPercent int
+	// MemoryMB is the memory all instances may use together, in MiB.
+	// 0 = unlimited. Linux cgroup v2 only.
+	MemoryMB int
+	// CgroupParent is the cgroup v2 directory instance cgroups are created
+	// in ("" = the cgroup Claudio runs in)
+	CgroupParent string
+}
+
+// Share is one instance's part of the CPU and memory totals. Zero fields
+// are unlimited.
+type Share struct {
+	CPUPercent int
+	MemoryMB   int
+}
+
+// ShareOf divides the CPU and memory totals evenly among n instances.
+func (l Limits) ShareOf(n int) Share {
+	n = max(n, 1)
+	var s Share
+	if l.CPUPercent > 0 {
+		s.CPUPercent = max(1, l.CPUPercent/n)
+	}
+	if l.MemoryMB > 0 {
+		s.MemoryMB = max(1, l.MemoryMB/n)
+	}
+	return s
+}
+
+// controllers returns the cgroup controllers the limits need.
+func (l Limits) controllers() []string {
+	var ctls []string
+	if l.CPUPercent > 0 {
+		ctls = append(ctls, "cpu")
+	}
+	if l.MemoryMB > 0 {
+		ctls = append(ctls, "memory")
+	}
+	return ctls
+}
+
+// Controller applies Limits to instances as they start and rebalances the
+// cgroup limits among them as they come and go.
+type Controller struct {
+	limits Limits
+	numCPU int
+
+	mu sync.Mutex
+	// attached maps each attached instance to its cgroup ("" = none)
+	attached map[string]string
+	// tree is where instance cgroups are created, opened by the first
+	// Attach that needs it (nil = not opened, or treeErr)
+	tree    *cgroupTree
+	treeErr error
+}
+
+// NewController creates a Controller applying limits.
+func NewController(limits Limits) *Controller {
+	return &Controller{
+		limits:   limits,
+		numCPU:   runtime.NumCPU(),
+		attached: make(map[string]string),
+	}
+}
+
+// Env returns environment variables for instance id's pane: its GOMAXPROCS
+// hint, counting id among the running instances.
+func (c *Controller) Env(id string) []string {
+	if c == nil {
+		return nil
+	}
+	procs := c.limits.GoMaxProcs
+	if procs <= 0 {
+		c.mu.Lock()
+		n := len(c.attached)
+		if _, ok := c.attached[id]; !ok {
+			n++
+		}
+		c.mu.Unlock()
+		procs = max(1, c.numCPU/n)
+	}
+	return []string{fmt.Sprintf("GOMAXPROCS=%d", procs)}
+}
+
+// Attach applies the limits to instance id, whose pane runs as pid:
+// pid is reniced and moved to the instance's cgroup, and the CPU and memory
+// totals are divided anew. Attaching an instance again, after its pane was
+// recreated, moves the new pane. An error means the limits were only partly
+// applied; the instance runs either way.
+func (c *Controller) Attach(id string, pid int) error {
+	if c == nil || pid <= 0 {
+		return nil
+	}
+
+	var errs []error
+	if c.limits.Nice != 0 {
+		if err := setNice(pid, c.limits.Nice); err != nil {
+			errs = append(errs, fmt.Errorf("failed to renice pid %d: %w", pid, err))
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dir := c.attached[id]
+	if ctls := c.limits.controllers(); len(ctls) > 0 {
+		if err := c.openTree(ctls); err != nil {
+			errs = append(errs, err)
+		}
+		if c.tree != nil {
+			d, err := c.tree.add(id, pid)
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				dir = d
+			}
+		}
+	}
+	c.attached[id] = dir
+	if dir != "" {
+		errs = append(errs, c.rebalance())
+	}
+	return errors.Join(errs...)
+}
+
+// Detach removes instance id's cgroup once its processes have exited and
+// gives its share to the instances still attached.
+func (c *Controller) Detach(id string) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dir, ok := c.attached[id]
+	if !ok {
+		return nil
+	}
+	delete(c.attached, id)
+	if dir == "" {
+		return nil
+	}
+
+	var errs []error
+	if err := c.tree.remove(dir); err != nil {
+		errs = append(errs, err)
+	}
+	errs = append(errs, c.rebalance())
+	return errors.Join(errs...)
+}
+
+// openTree opens the cgroup tree instance cgroups are created in. Only the
+// first attempt reports why no tree could be opened. Caller must hold c.mu.
+func (c *Controller) openTree(controllers []string) error {
+	if c.tree != nil || c.treeErr != nil {
+		return nil
+	}
+	c.tree, c.treeErr = openCgroupTree(c.limits.CgroupParent, controllers)
+	if c.treeErr != nil {
+		return fmt.Errorf("cgroup limits disabled: %w", c.treeErr)
+	}
+	return nil
+}
+
+// rebalance sets every instance cgroup to an even share of the totals.
+// Caller must hold c.mu.
+func (c *Controller) rebalance() error {
+	var dirs []string
+	for _, dir := range c.attached {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	share := c.limits.ShareOf(len(dirs))
+	var errs []error
+	for _, dir := range dirs {
+		if err := c.tree.setLimits(dir, share); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
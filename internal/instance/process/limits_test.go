@@ -0,0 +1,79 @@
+package process
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLimits_ShareOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits Limits
+		n      int
+		want   Share
+	}{
+		{"unlimited", Limits{}, 3, Share{}},
+		{"even split", Limits{CPUPercent: 400, MemoryMB: 8192}, 4, Share{CPUPercent: 100, MemoryMB: 2048}},
+		{"no instances counts as one", Limits{CPUPercent: 400}, 0, Share{CPUPercent: 400}},
+		{"never below one", Limits{CPUPercent: 2, MemoryMB: 2}, 5, Share{CPUPercent: 1, MemoryMB: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.limits.ShareOf(tt.n); got != tt.want {
+				t.Errorf("ShareOf(%d) = %+v, want %+v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestController_Env(t *testing.T) {
+	c := NewController(Limits{})
+	c.numCPU = 8
+
+	if got := c.Env("inst-1"); !slices.Equal(got, []string{"GOMAXPROCS=8"}) {
+		t.Errorf("Env() with no instances = %v, want GOMAXPROCS=8", got)
+	}
+
+	c.attached["inst-1"] = ""
+	c.attached["inst-2"] = ""
+	if got := c.Env("inst-3"); !slices.Equal(got, []string{"GOMAXPROCS=2"}) {
+		t.Errorf("Env() for a third instance = %v, want GOMAXPROCS=2", got)
+	}
+	if got := c.Env("inst-1"); !slices.Equal(got, []string{"GOMAXPROCS=4"}) {
+		t.Errorf("Env() for an attached instance = %v, want GOMAXPROCS=4", got)
+	}
+
+	fixed := NewController(Limits{GoMaxProcs: 3})
+	if got := fixed.Env("inst-1"); !slices.Equal(got, []string{"GOMAXPROCS=3"}) {
+		t.Errorf("Env() with GoMaxProcs = %v, want GOMAXPROCS=3", got)
+	}
+
+	var none *Controller
+	if got := none.Env("inst-1"); got != nil {
+		t.Errorf("nil Controller Env() = %v, want nil", got)
+	}
+}
+
+func TestController_NilAndWithoutCgroupLimits(t *testing.T) {
+	var none *Controller
+	if err := none.Attach("inst-1", 1234); err != nil {
+		t.Errorf("nil Controller Attach() error = %v", err)
+	}
+	if err := none.Detach("inst-1"); err != nil {
+		t.Errorf("nil Controller Detach() error = %v", err)
+	}
+
+	c := NewController(Limits{})
+	if err := c.Attach("inst-1", 1234); err != nil {
+		t.Fatalf("Attach() without limits error = %v", err)
+	}
+	if dir, ok := c.attached["inst-1"]; !ok || dir != "" {
+		t.Errorf("attached[inst-1] = %q, %v; want no cgroup", dir, ok)
+	}
+	if err := c.Detach("inst-1"); err != nil {
+		t.Errorf("Detach() error = %v", err)
+	}
+	if len(c.attached) != 0 {
+		t.Errorf("attached after Detach() = %v, want empty", c.attached)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix && !windows
+
+package process
+
+import "errors"
+
+// setNice is unsupported without Unix process priorities.
+func setNice(int, int) error {
+	return errors.New("niceness requires a Unix system")
+}
@@ -0,0 +1,11 @@
+//go:build unix
+
+package process
+
+import "syscall"
+
+// setNice sets the niceness of pid. Processes it starts afterwards inherit
+// it.
+func setNice(pid, nice int) error {
+	return syscall.Setpriority(syscall.PRIO_PROCESS, pid, nice)
+}
@@ -0,0 +1,24 @@
+//go:build windows
+
+package process
+
+import "golang.org/x/sys/windows"
+
+// setNice gives pid the priority class closest to the niceness. Windows has
+// no niceness; processes it starts afterwards inherit the below-normal and
+// idle classes.
+func setNice(pid, nice int) error {
+	class := uint32(windows.NORMAL_PRIORITY_CLASS)
+	switch {
+	case nice >= 15:
+		class = windows.IDLE_PRIORITY_CLASS
+	case nice > 0:
+		class = windows.BELOW_NORMAL_PRIORITY_CLASS
+	}
+	h, err := windows.OpenProcess(windows.PROCESS_SET_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = windows.CloseHandle(h) }()
+	return windows.SetPriorityClass(h, class)
+}
@@ -0,0 +1,26 @@
+package process
+
+// Process is a backend program running in a terminal.
+type Process interface {
+	// Start starts the process.
+	Start() error
+	// Stop stops the process and everything it started.
+	Stop() error
+	// Running reports whether the process is still running.
+	Running() bool
+	// PID returns the process ID, or 0 before Start.
+	PID() int
+}
+
+// OutputProvider captures what a process shows in its terminal.
+type OutputProvider interface {
+	// Screen returns the visible screen.
+	Screen() []byte
+	// Output returns the history followed by the visible screen.
+	Output() []byte
+}
+
+// Resizable is a process whose terminal can be resized.
+type Resizable interface {
+	Resize(width, height int) error
+}
@@ -0,0 +1,272 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/tmux"
+)
+
+// drainTimeout bounds how long a stopped process's last output is read.
+// Descendants that keep the terminal open would otherwise hold it forever.
+const drainTimeout = time.Second
+
+// PTYConfig configures a [PTYProcess].
+type PTYConfig struct {
+	// Shell is the program started in the terminal. Empty uses $SHELL, or
+	// when it is unset /bin/sh, or Git Bash on Windows.
+	Shell string
+	// Dir is the working directory.
+	Dir string
+	// Env is the environment. Nil inherits the current one.
+	Env []string
+	// Width and Height are the terminal size in cells.
+	Width, Height int
+	// HistoryLimit is the number of lines kept above the screen.
+	HistoryLimit int
+}
+
+// PTYProcess runs a shell in a pseudo-terminal, as a tmux pane does, without
+// a tmux server. The terminal is a Unix pty, or a ConPTY pseudo console on
+// Windows. Its output is read continuously into a [Screen], so it can
+// be captured at any time.
+//
+// PTYProcess implements [Process], [OutputProvider], and [Resizable], and
+// its SendKeys method accepts the key names tmux send-keys does.
+type PTYProcess struct {
+	cfg PTYConfig
+
+	mu     sync.Mutex
+	term   terminal
+	screen *Screen
+	done   chan struct{}
+
+	// writeMu keeps each write to the terminal whole
+	writeMu sync.Mutex
+}
+
+// terminal is a shell running in a platform pseudo-terminal. Reads return
+// the terminal's output and writes are typed into it.
+type terminal interface {
+	io.ReadWriter
+	// pid returns the shell's process ID.
+	pid() int
+	// resize changes the terminal size.
+	resize(width, height int) error
+	// wait blocks until the shell exits.
+	wait() error
+	// closeAfterExit closes the terminal once the shell has exited, after
+	// the reader has taken the last output or drainTimeout has passed.
+	closeAfterExit(read <-chan struct{})
+	// killer notes the shell and everything it started, and returns a
+	// func that kills them. It is taken before the shell is interrupted
+	// because orphaned descendants can't be found later.
+	killer() func()
+}
+
+var (
+	_ Process        = (*PTYProcess)(nil)
+	_ OutputProvider = (*PTYProcess)(nil)
+	_ Resizable      = (*PTYProcess)(nil)
+)
+
+// NewPTYProcess returns a process that runs in a terminal of the configured
+// size once started.
+func NewPTYProcess(cfg PTYConfig) *PTYProcess {
+	cfg.Width, cfg.Height = max(cfg.Width, 1), max(cfg.Height, 1)
+	return &PTYProcess{
+		cfg:    cfg,
+		screen: NewScreen(cfg.Width, cfg.Height, cfg.HistoryLimit),
+	}
+}
+
+// Start starts the shell in a new terminal.
+func (p *PTYProcess) Start() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.term != nil {
+		return errors.New("process already started")
+	}
+
+	shell := p.cfg.Shell
+	if shell == "" {
+		var err error
+		if shell, err = defaultShell(); err != nil {
+			return err
+		}
+	}
+
+	width, height := p.screen.Size()
+	term, err := startTerminal(shell, p.cfg, width, height)
+	if err != nil {
+		return fmt.Errorf("failed to start %s in a pty: %w", shell, err)
+	}
+
+	p.term = term
+	p.done = make(chan struct{})
+	read := make(chan struct{})
+	go p.readLoop(term, read)
+	go p.wait(term, read, p.done)
+	return nil
+}
+
+// readLoop feeds the terminal's output to the screen until it is closed.
+func (p *PTYProcess) readLoop(term terminal, read chan<- struct{}) {
+	defer close(read)
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := term.Read(buf)
+		if n > 0 {
+			p.mu.Lock()
+			_, _ = p.screen.Write(buf[:n])
+			p.mu.Unlock()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// wait reaps the shell, lets the reader take its last output, and closes
+// the terminal.
+func (p *PTYProcess) wait(term terminal, read <-chan struct{}, done chan<- struct{}) {
+	_ = term.wait()
+	term.closeAfterExit(read)
+	close(done)
+}
+
+// Stop sends Ctrl+C and waits briefly for the shell to exit, then kills the
+// shell and everything it started.
+func (p *PTYProcess) Stop() error {
+	p.mu.Lock()
+	term, done := p.term, p.done
+	p.mu.Unlock()
+
+	if term == nil {
+		return nil
+	}
+	select {
+	case <-done:
+		return nil
+	default:
+	}
+
+	pid := term.pid()
+	kill := term.killer()
+
+	_, _ = p.write([]byte(KeyBytes("C-c")))
+	tmux.WaitForProcessExit(pid, tmux.DefaultGracefulStopTimeout)
+	kill()
+
+	select {
+	case <-done:
+	case <-time.After(2 * drainTimeout):
+		return fmt.Errorf("process %d did not exit", pid)
+	}
+	return nil
+}
+
+// Running reports whether the shell is still running.
+func (p *PTYProcess) Running() bool {
+	p.mu.Lock()
+	done := p.done
+	p.mu.Unlock()
+
+	if done == nil {
+		return false
+	}
+	select {
+	case <-done:
+		return false
+	default:
+		return true
+	}
+}
+
+// PID returns the shell's process ID, or 0 before Start.
+func (p *PTYProcess) PID() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.term == nil {
+		return 0
+	}
+	return p.term.pid()
+}
+
+// Screen returns the visible screen with colors, like tmux capture-pane -p -e.
+func (p *PTYProcess) Screen() []byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return []byte(p.screen.Visible())
+}
+
+// Output returns the history followed by the visible screen.
+func (p *PTYProcess) Output() []byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return []byte(p.screen.Contents())
+}
+
+// HistorySize returns the number of lines scrolled off the screen.
+func (p *PTYProcess) HistorySize() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.screen.HistorySize()
+}
+
+// TakeBell reports whether the terminal bell rang since the last call.
+func (p *PTYProcess) TakeBell() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.screen.TakeBell()
+}
+
+// Resize changes the terminal size and tells the programs in it.
+func (p *PTYProcess) Resize(width, height int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.screen.Resize(width, height)
+	if p.term == nil {
+		return nil
+	}
+	width, height = p.screen.Size()
+	if err := p.term.resize(width, height); err != nil {
+		return fmt.Errorf("failed to resize pty: %w", err)
+	}
+	return nil
+}
+
+// Write sends input to the terminal as if typed.
+func (p *PTYProcess) Write(b []byte) (int, error) {
+	return p.write(b)
+}
+
+// SendKeys sends keys the way tmux send-keys does: literally, or as a key
+// name such as "Enter" or "C-c". The session name is ignored; it lets a
+// PTYProcess stand in for tmux as an input sender.
+func (p *PTYProcess) SendKeys(_ string, keys string, literal bool) error {
+	if !literal {
+		keys = KeyBytes(keys)
+	}
+	_, err := p.write([]byte(keys))
+	return err
+}
+
+func (p *PTYProcess) write(b []byte) (int, error) {
+	p.mu.Lock()
+	term := p.term
+	p.mu.Unlock()
+
+	if term == nil {
+		return 0, errors.New("process not started")
+	}
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	return term.Write(b)
+}
@@ -0,0 +1,92 @@
+//go:build unix
+
+package process
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestPTYProcess(t *testing.T) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("/bin/sh not available")
+	}
+	p := NewPTYProcess(PTYConfig{
+		Shell:  "/bin/sh",
+		Dir:    t.TempDir(),
+		Env:    []string{"TERM=xterm-256color", "PS1=$ "},
+		Width:  40,
+		Height: 5,
+	})
+	if p.Running() || p.PID() != 0 {
+		t.Fatal("process running before Start")
+	}
+	if err := p.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = p.Stop() })
+
+	if !p.Running() || p.PID() <= 0 {
+		t.Fatalf("Running() = %v, PID() = %d after Start", p.Running(), p.PID())
+	}
+	if err := p.Start(); err == nil {
+		t.Error("second Start() error = nil")
+	}
+
+	if err := p.SendKeys("", "echo pty-$((40+2))", true); err != nil {
+		t.Fatalf("SendKeys() error = %v", err)
+	}
+	if err := p.SendKeys("", "Enter", false); err != nil {
+		t.Fatalf("SendKeys(Enter) error = %v", err)
+	}
+	waitFor(t, "command output", func() bool {
+		return strings.Contains(string(p.Output()), "pty-42")
+	})
+
+	if err := p.Resize(30, 4); err != nil {
+		t.Errorf("Resize() error = %v", err)
+	}
+	_, _ = p.Write([]byte("stty size\r"))
+	waitFor(t, "new size", func() bool {
+		return strings.Contains(string(p.Screen()), "4 30")
+	})
+
+	if err := p.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if p.Running() {
+		t.Error("Running() = true after Stop")
+	}
+}
+
+func TestPTYProcess_ExitsOnItsOwn(t *testing.T) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("/bin/sh not available")
+	}
+	p := NewPTYProcess(PTYConfig{Shell: "/bin/sh", Width: 40, Height: 5})
+	if err := p.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	_, _ = p.Write([]byte("echo bye; exit\r"))
+	waitFor(t, "exit", func() bool { return !p.Running() })
+
+	if !strings.Contains(string(p.Output()), "bye") {
+		t.Errorf("Output() = %q, want the last output", p.Output())
+	}
+	if err := p.Stop(); err != nil {
+		t.Errorf("Stop() after exit error = %v", err)
+	}
+}
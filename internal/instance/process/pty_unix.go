@@ -0,0 +1,69 @@
+//go:build unix
+
+package process
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"github.com/creack/pty"
+
+	"github.com/Iron-Ham/claudio/internal/tmux"
+)
+
+// unixPTY is a shell in a Unix pseudo-terminal.
+type unixPTY struct {
+	cmd *exec.Cmd
+	tty *os.File
+}
+
+// defaultShell returns $SHELL, or /bin/sh when it is unset.
+func defaultShell() (string, error) {
+	if shell := os.Getenv("SHELL"); shell != "" {
+		return shell, nil
+	}
+	return "/bin/sh", nil
+}
+
+func startTerminal(shell string, cfg PTYConfig, width, height int) (terminal, error) {
+	cmd := exec.Command(shell)
+	cmd.Dir = cfg.Dir
+	cmd.Env = cfg.Env
+	tty, err := pty.StartWithSize(cmd, &pty.Winsize{Cols: uint16(width), Rows: uint16(height)})
+	if err != nil {
+		return nil, err
+	}
+	return &unixPTY{cmd: cmd, tty: tty}, nil
+}
+
+func (t *unixPTY) Read(b []byte) (int, error)  { return t.tty.Read(b) }
+func (t *unixPTY) Write(b []byte) (int, error) { return t.tty.Write(b) }
+
+func (t *unixPTY) pid() int { return t.cmd.Process.Pid }
+
+func (t *unixPTY) resize(width, height int) error {
+	return pty.Setsize(t.tty, &pty.Winsize{Cols: uint16(width), Rows: uint16(height)})
+}
+
+func (t *unixPTY) wait() error { return t.cmd.Wait() }
+
+func (t *unixPTY) closeAfterExit(read <-chan struct{}) {
+	select {
+	case <-read:
+	case <-time.After(drainTimeout):
+	}
+	_ = t.tty.Close()
+}
+
+func (t *unixPTY) killer() func() {
+	pid := t.cmd.Process.Pid
+	pids := append([]int{pid}, tmux.GetDescendantPIDs(pid)...)
+	return func() {
+		// Closing the terminal sends SIGHUP to its foreground jobs
+		_ = t.tty.Close()
+		_ = t.cmd.Process.Signal(syscall.SIGHUP)
+		tmux.EnsureProcessesKilled(pids)
+	}
+}
@@ -0,0 +1,661 @@
+package process
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// DefaultHistoryLimit is the number of lines a [Screen] keeps above the
+// visible area when no limit is configured, matching tmux's default.
+const DefaultHistoryLimit = 2000
+
+// maxPendingLen bounds an unterminated sequence held for the next write.
+// Longer ones are dropped.
+const maxPendingLen = 64 * 1024
+
+// maxPenLen bounds the accumulated SGR state of a cell. Programs that never
+// reset attributes would otherwise grow it without limit.
+const maxPenLen = 256
+
+// cell is one character cell of the screen. An empty text is a blank cell.
+type cell struct {
+	text string
+	// pen holds the SGR sequences in effect when the cell was written
+	pen string
+	// cont marks the second cell of a wide character
+	cont bool
+}
+
+// cursor is a cursor position with the pen in effect, as saved by DECSC.
+type cursor struct {
+	row, col int
+	pen      string
+}
+
+// Screen is a minimal terminal emulator. It interprets the output of a
+// program running in a terminal and keeps the visible screen and the lines
+// scrolled off its top, so the output can be captured the way tmux's
+// capture-pane does.
+//
+// Screen supports cursor movement, erasing, scroll regions, insert and
+// delete, the alternate screen, and colors and attributes, which are kept
+// as the SGR sequences that set them. Other sequences, such as window titles
+// and mode changes, are ignored.
+//
+// Screen is not safe for concurrent use.
+type Screen struct {
+	width, height int
+	historyLimit  int
+
+	rows    [][]cell
+	history []string
+
+	cur      cursor
+	saved    cursor
+	wrapNext bool
+	// top and bottom are the scroll region rows, inclusive
+	top, bottom int
+
+	// main holds the main screen's rows while the alternate screen is shown
+	main      [][]cell
+	mainSaved cursor
+
+	bell bool
+
+	parser  *ansi.Parser
+	pending []byte
+}
+
+// NewScreen returns a blank screen of the given size. A historyLimit of
+// zero or less uses [DefaultHistoryLimit].
+func NewScreen(width, height, historyLimit int) *Screen {
+	width, height = max(width, 1), max(height, 1)
+	if historyLimit <= 0 {
+		historyLimit = DefaultHistoryLimit
+	}
+	s := &Screen{
+		width:        width,
+		height:       height,
+		historyLimit: historyLimit,
+		parser:       ansi.NewParser(),
+	}
+	s.reset()
+	return s
+}
+
+// reset clears the screen and cursor state, keeping the history.
+func (s *Screen) reset() {
+	s.rows = make([][]cell, s.height)
+	for i := range s.rows {
+		s.rows[i] = make([]cell, s.width)
+	}
+	s.cur = cursor{}
+	s.saved = cursor{}
+	s.wrapNext = false
+	s.top, s.bottom = 0, s.height-1
+	s.main = nil
+}
+
+// Size returns the width and height of the screen.
+func (s *Screen) Size() (width, height int) {
+	return s.width, s.height
+}
+
+// Write interprets p as terminal output. Escape sequences and UTF-8
+// characters split across writes are completed by later writes. It never
+// returns an error.
+func (s *Screen) Write(p []byte) (int, error) {
+	data := p
+	if len(s.pending) > 0 {
+		data = append(s.pending, p...)
+		s.pending = nil
+	}
+	data = s.holdIncompleteRune(data)
+
+	for len(data) > 0 {
+		seq, width, n, state := ansi.DecodeSequence(data, ansi.NormalState, s.parser)
+		if state != ansi.NormalState {
+			// The sequence continues in the next write
+			if len(data) <= maxPendingLen {
+				s.pending = append(s.pending, data...)
+			}
+			break
+		}
+		if n == 0 {
+			n = 1
+		}
+		s.handle(seq, width)
+		data = data[n:]
+	}
+	return len(p), nil
+}
+
+// holdIncompleteRune moves a UTF-8 character cut off at the end of data
+// into pending and returns the rest.
+func (s *Screen) holdIncompleteRune(data []byte) []byte {
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if !utf8.RuneStart(data[i]) {
+			continue
+		}
+		if data[i] >= utf8.RuneSelf && !utf8.FullRune(data[i:]) {
+			s.pending = append(s.pending, data[i:]...)
+			return data[:i]
+		}
+		break
+	}
+	return data
+}
+
+// handle applies one decoded sequence or grapheme.
+func (s *Screen) handle(seq []byte, width int) {
+	switch {
+	case width > 0:
+		s.print(string(seq), width)
+	case ansi.HasCsiPrefix(seq):
+		s.csi(seq)
+	case ansi.HasOscPrefix(seq), ansi.HasDcsPrefix(seq), ansi.HasApcPrefix(seq),
+		ansi.HasSosPrefix(seq), ansi.HasPmPrefix(seq):
+		// Titles, clipboard, and other strings don't change the screen
+	case ansi.HasEscPrefix(seq) && len(seq) > 1:
+		s.esc()
+	case len(seq) == 1:
+		s.control(seq[0])
+	}
+}
+
+// print writes a grapheme at the cursor and advances it.
+func (s *Screen) print(text string, width int) {
+	if s.wrapNext {
+		s.cur.col = 0
+		s.lineFeed()
+	}
+	if width > s.width {
+		width = s.width
+	}
+	if s.cur.col+width > s.width {
+		s.cur.col = 0
+		s.lineFeed()
+	}
+	row := s.rows[s.cur.row]
+	s.clearWide(row, s.cur.col, s.cur.col+width)
+	row[s.cur.col] = cell{text: text, pen: s.cur.pen}
+	for i := 1; i < width; i++ {
+		row[s.cur.col+i] = cell{pen: s.cur.pen, cont: true}
+	}
+	s.cur.col += width
+	if s.cur.col >= s.width {
+		s.cur.col = s.width - 1
+		s.wrapNext = true
+	}
+}
+
+// clearWide blanks the halves of wide characters that [from, to) would
+// split.
+func (s *Screen) clearWide(row []cell, from, to int) {
+	if from > 0 && from < len(row) && row[from].cont {
+		row[from-1] = cell{}
+	}
+	for i := to; i < len(row) && row[i].cont; i++ {
+		row[i] = cell{}
+	}
+}
+
+// control applies a C0 control character.
+func (s *Screen) control(c byte) {
+	switch c {
+	case ansi.BEL:
+		s.bell = true
+	case ansi.BS:
+		if s.cur.col > 0 {
+			s.cur.col--
+		}
+		s.wrapNext = false
+	case ansi.HT:
+		s.cur.col = min(s.width-1, (s.cur.col/8+1)*8)
+	case ansi.LF, ansi.VT, ansi.FF:
+		s.lineFeed()
+	case ansi.CR:
+		s.cur.col = 0
+		s.wrapNext = false
+	}
+}
+
+// esc applies an ESC sequence.
+func (s *Screen) esc() {
+	cmd := ansi.Cmd(s.parser.Command())
+	if cmd.Intermediate() != 0 {
+		// Character set designations
+		return
+	}
+	switch cmd.Final() {
+	case '7':
+		s.saved = s.cur
+	case '8':
+		s.restoreCursor()
+	case 'D':
+		s.lineFeed()
+	case 'E':
+		s.cur.col = 0
+		s.lineFeed()
+	case 'M':
+		s.wrapNext = false
+		if s.cur.row == s.top {
+			s.scrollDown(1)
+		} else if s.cur.row > 0 {
+			s.cur.row--
+		}
+	case 'c':
+		s.reset()
+	}
+}
+
+// param returns CSI parameter i, treating missing and zero values as def.
+func (s *Screen) param(i, def int) int {
+	v, _ := s.parser.Param(i, def)
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+// csi applies a CSI sequence.
+func (s *Screen) csi(seq []byte) {
+	cmd := ansi.Cmd(s.parser.Command())
+	if cmd.Intermediate() != 0 {
+		return
+	}
+	if cmd.Prefix() == '?' {
+		switch cmd.Final() {
+		case 'h', 'l':
+			s.privateMode(cmd.Final() == 'h')
+		}
+		return
+	}
+	if cmd.Prefix() != 0 {
+		return
+	}
+
+	n := s.param(0, 1)
+	switch cmd.Final() {
+	case 'A':
+		s.moveTo(s.cur.row-n, s.cur.col)
+	case 'B', 'e':
+		s.moveTo(s.cur.row+n, s.cur.col)
+	case 'C', 'a':
+		s.moveTo(s.cur.row, s.cur.col+n)
+	case 'D':
+		s.moveTo(s.cur.row, s.cur.col-n)
+	case 'E':
+		s.moveTo(s.cur.row+n, 0)
+	case 'F':
+		s.moveTo(s.cur.row-n, 0)
+	case 'G', '`':
+		s.moveTo(s.cur.row, n-1)
+	case 'd':
+		s.moveTo(n-1, s.cur.col)
+	case 'H', 'f':
+		s.moveTo(n-1, s.param(1, 1)-1)
+	case 'J':
+		mode, _ := s.parser.Param(0, 0)
+		s.eraseDisplay(mode)
+	case 'K':
+		mode, _ := s.parser.Param(0, 0)
+		s.eraseLine(mode)
+	case 'L':
+		s.insertLines(n)
+	case 'M':
+		s.deleteLines(n)
+	case '@':
+		s.insertChars(n)
+	case 'P':
+		s.deleteChars(n)
+	case 'X':
+		row := s.rows[s.cur.row]
+		end := min(s.width, s.cur.col+n)
+		s.clearWide(row, s.cur.col, end)
+		clear(row[s.cur.col:end])
+	case 'S':
+		s.scrollUp(n)
+	case 'T':
+		s.scrollDown(n)
+	case 'r':
+		top, bottom := s.param(0, 1)-1, s.param(1, s.height)-1
+		if top < bottom && bottom < s.height {
+			s.top, s.bottom = top, bottom
+			s.moveTo(0, 0)
+		}
+	case 's':
+		s.saved = s.cur
+	case 'u':
+		s.restoreCursor()
+	case 'm':
+		s.sgr(seq)
+	}
+}
+
+// privateMode applies DECSET/DECRST. Only the alternate screen changes
+// what is captured.
+func (s *Screen) privateMode(set bool) {
+	for _, p := range s.parser.Params() {
+		switch p.Param(0) {
+		case 47, 1047, 1049:
+			if set {
+				s.enterAltScreen()
+			} else {
+				s.exitAltScreen()
+			}
+		}
+	}
+}
+
+// sgr records the attribute change in the pen. A reset clears it.
+func (s *Screen) sgr(seq []byte) {
+	params := s.parser.Params()
+	if len(params) == 0 || (len(params) == 1 && params[0].Param(0) == 0) {
+		s.cur.pen = ""
+		return
+	}
+	if len(s.cur.pen)+len(seq) > maxPenLen {
+		s.cur.pen = ""
+	}
+	s.cur.pen += string(seq)
+}
+
+// moveTo places the cursor, clamped to the screen.
+func (s *Screen) moveTo(row, col int) {
+	s.cur.row = min(max(row, 0), s.height-1)
+	s.cur.col = min(max(col, 0), s.width-1)
+	s.wrapNext = false
+}
+
+func (s *Screen) restoreCursor() {
+	s.cur = s.saved
+	s.moveTo(s.cur.row, s.cur.col)
+}
+
+// lineFeed moves the cursor down a row, scrolling at the bottom of the
+// scroll region.
+func (s *Screen) lineFeed() {
+	s.wrapNext = false
+	switch {
+	case s.cur.row == s.bottom:
+		s.scrollUp(1)
+	case s.cur.row < s.height-1:
+		s.cur.row++
+	}
+}
+
+// scrollUp scrolls the scroll region up n rows. Rows leaving the top of the
+// main screen go to the history.
+func (s *Screen) scrollUp(n int) {
+	s.shiftUp(s.top, n, s.top == 0 && s.main == nil)
+}
+
+// scrollDown scrolls the scroll region down n rows.
+func (s *Screen) scrollDown(n int) {
+	s.shiftDown(s.top, n)
+}
+
+// shiftUp moves rows top through the bottom of the scroll region up n rows,
+// blanking the rows uncovered at the bottom.
+func (s *Screen) shiftUp(top, n int, toHistory bool) {
+	region := s.rows[top : s.bottom+1]
+	n = min(n, len(region))
+	if toHistory {
+		for _, row := range region[:n] {
+			s.pushHistory(renderRow(row))
+		}
+	}
+	copy(region, region[n:])
+	for i := len(region) - n; i < len(region); i++ {
+		region[i] = make([]cell, s.width)
+	}
+}
+
+// shiftDown moves rows top through the bottom of the scroll region down n
+// rows, blanking the rows uncovered at the top.
+func (s *Screen) shiftDown(top, n int) {
+	region := s.rows[top : s.bottom+1]
+	n = min(n, len(region))
+	copy(region[n:], region)
+	for i := range n {
+		region[i] = make([]cell, s.width)
+	}
+}
+
+func (s *Screen) pushHistory(line string) {
+	s.history = append(s.history, line)
+	if extra := len(s.history) - s.historyLimit; extra > 0 {
+		s.history = s.history[extra:]
+	}
+}
+
+// insertLines inserts n blank rows at the cursor within the scroll region.
+func (s *Screen) insertLines(n int) {
+	if s.cur.row < s.top || s.cur.row > s.bottom {
+		return
+	}
+	s.shiftDown(s.cur.row, n)
+	s.cur.col = 0
+	s.wrapNext = false
+}
+
+// deleteLines deletes n rows at the cursor within the scroll region.
+func (s *Screen) deleteLines(n int) {
+	if s.cur.row < s.top || s.cur.row > s.bottom {
+		return
+	}
+	s.shiftUp(s.cur.row, n, false)
+	s.cur.col = 0
+	s.wrapNext = false
+}
+
+// insertChars shifts the rest of the row right by n blank cells.
+func (s *Screen) insertChars(n int) {
+	row := s.rows[s.cur.row]
+	n = min(n, s.width-s.cur.col)
+	s.clearWide(row, s.cur.col, s.cur.col)
+	copy(row[s.cur.col+n:], row[s.cur.col:])
+	clear(row[s.cur.col : s.cur.col+n])
+	s.wrapNext = false
+}
+
+// deleteChars shifts the rest of the row left by n cells.
+func (s *Screen) deleteChars(n int) {
+	row := s.rows[s.cur.row]
+	n = min(n, s.width-s.cur.col)
+	s.clearWide(row, s.cur.col, s.cur.col+n)
+	copy(row[s.cur.col:], row[s.cur.col+n:])
+	clear(row[s.width-n:])
+	s.wrapNext = false
+}
+
+// eraseDisplay implements ED: 0 erases below the cursor, 1 above it, 2 the
+// whole screen, and 3 the history.
+func (s *Screen) eraseDisplay(mode int) {
+	switch mode {
+	case 0:
+		s.eraseLine(0)
+		for _, row := range s.rows[s.cur.row+1:] {
+			clear(row)
+		}
+	case 1:
+		s.eraseLine(1)
+		for _, row := range s.rows[:s.cur.row] {
+			clear(row)
+		}
+	case 2:
+		for _, row := range s.rows {
+			clear(row)
+		}
+	case 3:
+		s.history = nil
+	}
+}
+
+// eraseLine implements EL: 0 erases to the end of the row, 1 to its start,
+// and 2 the whole row.
+func (s *Screen) eraseLine(mode int) {
+	row := s.rows[s.cur.row]
+	switch mode {
+	case 0:
+		s.clearWide(row, s.cur.col, s.width)
+		clear(row[s.cur.col:])
+	case 1:
+		s.clearWide(row, 0, s.cur.col+1)
+		clear(row[:s.cur.col+1])
+	case 2:
+		clear(row)
+	}
+	s.wrapNext = false
+}
+
+func (s *Screen) enterAltScreen() {
+	if s.main != nil {
+		return
+	}
+	s.main, s.mainSaved = s.rows, s.cur
+	s.rows = make([][]cell, s.height)
+	for i := range s.rows {
+		s.rows[i] = make([]cell, s.width)
+	}
+}
+
+func (s *Screen) exitAltScreen() {
+	if s.main == nil {
+		return
+	}
+	s.rows, s.cur = s.main, s.mainSaved
+	s.main = nil
+	s.moveTo(s.cur.row, s.cur.col)
+}
+
+// Resize changes the screen size. When it gets shorter, rows above the
+// cursor move to the history first, as in tmux, so the cursor row stays
+// visible.
+func (s *Screen) Resize(width, height int) {
+	width, height = max(width, 1), max(height, 1)
+	if width == s.width && height == s.height {
+		return
+	}
+	s.rows = resizeRows(s.rows, width)
+	if s.main != nil {
+		s.main = resizeRows(s.main, width)
+	}
+	s.width = width
+
+	if height < s.height {
+		drop := min(s.height-height, s.cur.row)
+		if s.main == nil {
+			for _, row := range s.rows[:drop] {
+				s.pushHistory(renderRow(row))
+			}
+		}
+		s.rows = s.rows[drop : drop+height]
+		s.cur.row -= drop
+	}
+	for len(s.rows) < height {
+		s.rows = append(s.rows, make([]cell, width))
+	}
+	if s.main != nil {
+		s.main = resizeHeight(s.main, height, width)
+	}
+	s.height = height
+	s.top, s.bottom = 0, height-1
+	s.moveTo(s.cur.row, s.cur.col)
+}
+
+func resizeRows(rows [][]cell, width int) [][]cell {
+	for i, row := range rows {
+		if len(row) >= width {
+			if width < len(row) && row[width].cont {
+				// Don't keep half of a wide character
+				row[width-1] = cell{}
+			}
+			rows[i] = row[:width]
+			continue
+		}
+		grown := make([]cell, width)
+		copy(grown, row)
+		rows[i] = grown
+	}
+	return rows
+}
+
+func resizeHeight(rows [][]cell, height, width int) [][]cell {
+	if len(rows) > height {
+		return rows[len(rows)-height:]
+	}
+	for len(rows) < height {
+		rows = append(rows, make([]cell, width))
+	}
+	return rows
+}
+
+// TakeBell reports whether a bell was rung since the last call.
+func (s *Screen) TakeBell() bool {
+	bell := s.bell
+	s.bell = false
+	return bell
+}
+
+// HistorySize returns the number of lines scrolled off the top.
+func (s *Screen) HistorySize() int {
+	return len(s.history)
+}
+
+// Visible returns the visible screen, one line per row with colors and
+// attributes as SGR sequences, like tmux capture-pane -p -e.
+func (s *Screen) Visible() string {
+	var b strings.Builder
+	for _, row := range s.rows {
+		b.WriteString(renderRow(row))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Contents returns the history followed by the visible screen.
+func (s *Screen) Contents() string {
+	var b strings.Builder
+	for _, line := range s.history {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	b.WriteString(s.Visible())
+	return b.String()
+}
+
+// renderRow renders a row without trailing blank cells, resetting the
+// attributes at its end.
+func renderRow(row []cell) string {
+	end := len(row)
+	for end > 0 && row[end-1].text == "" && !row[end-1].cont {
+		end--
+	}
+	var b strings.Builder
+	pen := ""
+	for _, c := range row[:end] {
+		if c.cont {
+			continue
+		}
+		if c.pen != pen {
+			if pen != "" {
+				b.WriteString(ansi.ResetStyle)
+			}
+			b.WriteString(c.pen)
+			pen = c.pen
+		}
+		if c.text == "" {
+			b.WriteByte(' ')
+		} else {
+			b.WriteString(c.text)
+		}
+	}
+	if pen != "" {
+		b.WriteString(ansi.ResetStyle)
+	}
+	return b.String()
+}
@@ -0,0 +1,144 @@
+package process
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func screenLines(s *Screen) []string {
+	return strings.Split(strings.TrimSuffix(s.Visible(), "\n"), "\n")
+}
+
+func TestScreen_Write(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"plain lines", "ab\r\ncd", []string{"ab", "cd", ""}},
+		{"carriage return overwrites", "abc\rX", []string{"Xbc", "", ""}},
+		{"cursor position", "\x1b[2;3Hx", []string{"", "  x", ""}},
+		{"cursor up and forward", "a\r\n\x1b[A\x1b[2Cb", []string{"a b", "", ""}},
+		{"erase to end of line", "abcdef\x1b[3D\x1b[K", []string{"abc", "", ""}},
+		{"erase screen", "abc\r\ndef\x1b[2J", []string{"", "", ""}},
+		{"autowrap", "abcdefghij", []string{"abcdefgh", "ij", ""}},
+		{"backspace and tab", "ab\bX\tY", []string{"aX     Y", "", ""}},
+		{"delete and insert characters", "abcdef\x1b[1G\x1b[2P\x1b[@", []string{" cdef", "", ""}},
+		{"insert line", "a\r\nb\x1b[1;1H\x1b[L", []string{"", "a", "b"}},
+		{"wide characters", "日本", []string{"日本", "", ""}},
+		{"window title ignored", "\x1b]0;title\x07ok", []string{"ok", "", ""}},
+		{"colors kept", "\x1b[31mred\x1b[0m", []string{"\x1b[31mred\x1b[m", "", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScreen(8, 3, 0)
+			_, _ = s.Write([]byte(tt.input))
+			if got := screenLines(s); !slices.Equal(got, tt.want) {
+				t.Errorf("screen = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScreen_SplitWrites(t *testing.T) {
+	s := NewScreen(10, 2, 0)
+	input := []byte("\x1b[2;3H日x")
+	// Split inside the escape sequence and inside the UTF-8 character
+	for _, chunk := range [][]byte{input[:3], input[3:7], input[7:]} {
+		_, _ = s.Write(chunk)
+	}
+	if got := screenLines(s); !slices.Equal(got, []string{"", "  日x"}) {
+		t.Errorf("screen = %q", got)
+	}
+}
+
+func TestScreen_History(t *testing.T) {
+	s := NewScreen(10, 2, 2)
+	_, _ = s.Write([]byte("one\r\ntwo\r\nthree\r\nfour\r\nfive"))
+
+	if got := s.HistorySize(); got != 2 {
+		t.Errorf("HistorySize() = %d, want the limit of 2", got)
+	}
+	want := "two\nthree\nfour\nfive\n"
+	if got := s.Contents(); got != want {
+		t.Errorf("Contents() = %q, want %q", got, want)
+	}
+	if got := s.Visible(); got != "four\nfive\n" {
+		t.Errorf("Visible() = %q", got)
+	}
+
+	_, _ = s.Write([]byte("\x1b[3J"))
+	if got := s.HistorySize(); got != 0 {
+		t.Errorf("HistorySize() after ED 3 = %d, want 0", got)
+	}
+}
+
+func TestScreen_ScrollRegion(t *testing.T) {
+	s := NewScreen(6, 4, 0)
+	// Status line at the bottom stays while the region above it scrolls
+	_, _ = s.Write([]byte("\x1b[4;1Hstatus\x1b[1;3r\x1b[3;1Ha\r\nb\r\nc\r\nd"))
+
+	if got := screenLines(s); !slices.Equal(got, []string{"b", "c", "d", "status"}) {
+		t.Errorf("screen = %q", got)
+	}
+	// The two blank rows scrolled off before "a"
+	if got := s.Contents(); got != "\n\na\nb\nc\nd\nstatus\n" {
+		t.Errorf("Contents() = %q, want the scrolled-off rows in the history", got)
+	}
+}
+
+func TestScreen_AltScreen(t *testing.T) {
+	s := NewScreen(6, 2, 0)
+	_, _ = s.Write([]byte("main"))
+	_, _ = s.Write([]byte("\x1b[?1049h\x1b[Hfull\r\nscreen\r\napp"))
+	if got := screenLines(s); !slices.Equal(got, []string{"screen", "app"}) {
+		t.Errorf("alternate screen = %q", got)
+	}
+	if got := s.HistorySize(); got != 0 {
+		t.Errorf("HistorySize() = %d, alternate screen must not add history", got)
+	}
+
+	_, _ = s.Write([]byte("\x1b[?1049l!"))
+	if got := screenLines(s); !slices.Equal(got, []string{"main!", ""}) {
+		t.Errorf("main screen after exit = %q", got)
+	}
+}
+
+func TestScreen_Bell(t *testing.T) {
+	s := NewScreen(6, 2, 0)
+	_, _ = s.Write([]byte("done\a"))
+	if !s.TakeBell() {
+		t.Error("TakeBell() = false after BEL")
+	}
+	if s.TakeBell() {
+		t.Error("TakeBell() = true on the second call")
+	}
+	// A BEL terminating an OSC is not a bell
+	_, _ = s.Write([]byte("\x1b]0;title\a"))
+	if s.TakeBell() {
+		t.Error("TakeBell() = true after an OSC")
+	}
+}
+
+func TestScreen_Resize(t *testing.T) {
+	s := NewScreen(6, 3, 0)
+	_, _ = s.Write([]byte("a\r\nb\r\nc"))
+
+	s.Resize(4, 2)
+	if got := screenLines(s); !slices.Equal(got, []string{"b", "c"}) {
+		t.Errorf("screen after shrinking = %q", got)
+	}
+	if got := s.HistorySize(); got != 1 {
+		t.Errorf("HistorySize() = %d, want the row above the cursor", got)
+	}
+
+	s.Resize(8, 3)
+	if w, h := s.Size(); w != 8 || h != 3 {
+		t.Errorf("Size() = %d, %d; want 8, 3", w, h)
+	}
+	_, _ = s.Write([]byte("\r\nlonger"))
+	if got := screenLines(s); !slices.Equal(got, []string{"b", "c", "longer"}) {
+		t.Errorf("screen after growing = %q", got)
+	}
+}
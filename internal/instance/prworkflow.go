@@ -10,6 +10,7 @@ import (
 	"github.com/Iron-Ham/claudio/internal/ai"
 	"github.com/Iron-Ham/claudio/internal/instance/capture"
 	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/Iron-Ham/claudio/internal/pr"
 	"github.com/Iron-Ham/claudio/internal/tmux"
 )
 
@@ -21,6 +22,7 @@ type PRWorkflowConfig struct {
 	TmuxWidth  int
 	TmuxHeight int
 	Backend    ai.Backend
+	Provider   pr.Provider // Creates the pull request (nil = GitHub via gh)
 }
 
 // PRWorkflowCallback is called when the PR workflow completes
@@ -262,6 +264,14 @@ func (p *PRWorkflow) Start() error {
 	return nil
 }
 
+// provider returns the configured PR provider, defaulting to GitHub.
+func (p *PRWorkflow) provider() pr.Provider {
+	if p.config.Provider == nil {
+		return &pr.GitHub{}
+	}
+	return p.config.Provider
+}
+
 // buildAICommand builds the AI backend command for PR creation.
 func (p *PRWorkflow) buildAICommand() (string, error) {
 	if p.backend == nil {
@@ -274,12 +284,12 @@ Please do the following:
 1. Check if there are any uncommitted changes with git status
 2. If there are uncommitted changes, create a commit with an appropriate message following conventional commits
 3. Push the branch to remote
-4. Create a pull request using gh pr create
+4. Create a pull request %s
 
 Use the branch name: %s
-If creating a draft PR, add the --draft flag.
+If creating a draft PR, mark it as a draft.
 
-Be concise and just execute the commands. Exit when done.`, p.task, p.branch)
+Be concise and just execute the commands. Exit when done.`, p.task, p.provider().Instructions(), p.branch)
 
 	if p.config.Draft {
 		prompt += "\n\nCreate the PR as a draft."
@@ -316,16 +326,18 @@ Be concise and just execute the commands. Exit when done.`, p.task, p.branch)
 // buildShellCommand builds direct shell commands for PR creation without AI
 func (p *PRWorkflow) buildShellCommand() string {
 	// Build a shell script that handles commit, push, and PR creation
-	var draftFlag string
-	if p.config.Draft {
-		draftFlag = "--draft "
-	}
+	createPR := p.provider().Command(pr.PROptions{
+		Title:  "feat: " + truncateForCommit(p.task),
+		Body:   "## Task\n" + p.task,
+		Branch: p.branch,
+		Draft:  p.config.Draft,
+	})
 
 	// The script:
 	// 1. Stages all changes
 	// 2. Commits with a simple message based on task
 	// 3. Pushes to remote
-	// 4. Creates PR via gh
+	// 4. Creates the PR with the provider's command
 	script := fmt.Sprintf(`
 # PR workflow for: %s
 set -e
@@ -343,12 +355,12 @@ git push -u origin %s
 
 # Create PR
 echo "Creating pull request..."
-gh pr create --title "feat: %s" --body "## Task\n%s" %s--head %s
+%s
 
 echo ""
 echo "PR workflow completed!"
 exit 0
-`, p.task, truncateForCommit(p.task), p.branch, truncateForCommit(p.task), p.task, draftFlag, p.branch)
+`, p.task, truncateForCommit(p.task), p.branch, createPR)
 
 	return script
 }
@@ -444,10 +456,12 @@ func (p *PRWorkflow) monitorLoop() {
 // checkSuccess analyzes output to determine if the PR workflow succeeded
 func (p *PRWorkflow) checkSuccess(output string) bool {
 	// Look for success indicators in the output
-	// gh pr create outputs the PR URL on success
+	// The PR creation command outputs the PR URL on success
 	return containsAny(output, []string{
 		"github.com",
 		"pull/",
+		"merge_requests/",
+		"pull-requests/",
 		"Pull request created",
 		"PR workflow completed",
 	})
@@ -467,24 +481,19 @@ func containsAny(s string, substrings []string) bool {
 	return false
 }
 
-// extractPRURL extracts a GitHub PR URL from the output text.
+// extractPRURL extracts a GitHub pull request, GitLab merge request, or
+// Bitbucket pull request URL from the output text.
 // Returns empty string if no PR URL is found.
 func extractPRURL(output string) string {
-	// Look for github.com URLs with /pull/ in them
-	// gh pr create outputs the PR URL on a line by itself
-	lines := strings.Split(output, "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.Contains(line, "github.com") && strings.Contains(line, "/pull/") {
-			// Extract the URL portion (handles lines like "https://github.com/org/repo/pull/123")
-			if idx := strings.Index(line, "https://github.com"); idx != -1 {
-				url := line[idx:]
-				// Trim any trailing whitespace or characters after the URL
-				if spaceIdx := strings.IndexAny(url, " \t\n\r"); spaceIdx != -1 {
-					url = url[:spaceIdx]
-				}
-				return url
-			}
+	// The creating command outputs the PR URL, on a line by itself for the
+	// CLIs or inside the JSON response for the Bitbucket API
+	for _, part := range strings.Split(output, "https://")[1:] {
+		url := "https://" + part
+		if end := strings.IndexAny(url, " \t\r\n\"'<>(){}[],"); end != -1 {
+			url = url[:end]
+		}
+		if strings.Contains(url, "/pull/") || strings.Contains(url, "/merge_requests/") || strings.Contains(url, "/pull-requests/") {
+			return url
 		}
 	}
 	return ""
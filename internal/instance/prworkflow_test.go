@@ -98,3 +98,39 @@ func TestPRWorkflow_Stop_Idempotent(t *testing.T) {
 		}
 	}
 }
+
+func TestExtractPRURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{
+			name:   "gh",
+			output: "Creating pull request for feature into main\n\nhttps://github.com/org/repo/pull/123\n",
+			want:   "https://github.com/org/repo/pull/123",
+		},
+		{
+			name:   "glab",
+			output: "Creating merge request for feature into main in org/repo\n\n!7 feat: task (feature)\n https://gitlab.com/org/repo/-/merge_requests/7\n",
+			want:   "https://gitlab.com/org/repo/-/merge_requests/7",
+		},
+		{
+			name:   "bitbucket api",
+			output: `{"links":{"self":{"href":"https://api.bitbucket.org/2.0/repositories/ws/repo/pullrequests/3"},"html":{"href":"https://bitbucket.org/ws/repo/pull-requests/3"}}}`,
+			want:   "https://bitbucket.org/ws/repo/pull-requests/3",
+		},
+		{
+			name:   "no pr url",
+			output: "remote: https://github.com/org/repo\n",
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractPRURL(tt.output); got != tt.want {
+				t.Errorf("extractPRURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
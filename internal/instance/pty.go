@@ -0,0 +1,167 @@
+package instance
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/Iron-Ham/claudio/internal/instance/input"
+	"github.com/Iron-Ham/claudio/internal/instance/process"
+	"github.com/Iron-Ham/claudio/internal/tmux"
+)
+
+// Process backends select what an instance's backend runs in.
+const (
+	// ProcessBackendTmux runs each instance in its own tmux session (default
+	// except on Windows).
+	ProcessBackendTmux = "tmux"
+	// ProcessBackendPTY runs each instance directly in a pseudo-terminal,
+	// so tmux is not needed. The instance cannot be attached to and does
+	// not outlive claudio.
+	ProcessBackendPTY = "pty"
+)
+
+// usesPTY reports whether the instance runs in a PTY instead of tmux. On
+// Windows, where tmux is not available, it always does.
+func (m *Manager) usesPTY() bool {
+	return m.config.ProcessBackend == ProcessBackendPTY || runtime.GOOS == "windows"
+}
+
+// newInputHandler returns an input handler that sends to tmux, or to the
+// PTY for the PTY backend.
+func (m *Manager) newInputHandler() *input.Handler {
+	sender := input.WithPersistentSender(m.sessionName, m.socketName)
+	if m.usesPTY() {
+		sender = input.WithTmuxSender(ptySender{m: m})
+	}
+	return input.NewHandler(
+		sender,
+		input.WithBatching(m.sessionName, input.DefaultBatchConfig()),
+	)
+}
+
+// ptySender sends input to the manager's current PTY. The PTY is looked up
+// on each send because recovery replaces it.
+type ptySender struct {
+	m *Manager
+}
+
+// SendKeys implements input.TmuxSender.
+func (s ptySender) SendKeys(sessionName string, keys string, literal bool) error {
+	p := s.m.currentPTY()
+	if p == nil {
+		return errors.New("pty not started")
+	}
+	return p.SendKeys(sessionName, keys, literal)
+}
+
+// currentPTY returns the running PTY, or nil for the tmux backend.
+func (m *Manager) currentPTY() *process.PTYProcess {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.pty
+}
+
+// createSession creates a fresh tmux session or PTY for the backend to run
+// in. Caller must hold m.mu.
+func (m *Manager) createSession() error {
+	if !m.usesPTY() {
+		return m.createTmuxSession()
+	}
+
+	if m.pty != nil {
+		_ = m.pty.Stop()
+	}
+	env := append(os.Environ(), "TERM=xterm-256color")
+	env = append(env, m.resources.Env(m.id)...)
+	p := process.NewPTYProcess(process.PTYConfig{
+		Dir:          m.workdir,
+		Env:          env,
+		Width:        m.config.TmuxWidth,
+		Height:       m.config.TmuxHeight,
+		HistoryLimit: m.config.TmuxHistoryLimit,
+	})
+	if err := p.Start(); err != nil {
+		if m.logger != nil {
+			m.logger.Error("failed to start pty",
+				"instance_id", m.id,
+				"workdir", m.workdir,
+				"error", err.Error())
+		}
+		return err
+	}
+	m.pty = p
+
+	if m.resources != nil {
+		if err := m.resources.Attach(m.id, p.PID()); err != nil && m.logger != nil {
+			m.logger.Warn("failed to apply resource limits", "error", err.Error())
+		}
+	}
+	return nil
+}
+
+// runInSession types cmd into the session's shell and presses Enter.
+// Caller must hold m.mu.
+func (m *Manager) runInSession(cmd string) error {
+	if !m.usesPTY() {
+		return m.tmuxCmd("send-keys", "-t", m.sessionName, cmd, "Enter").Run()
+	}
+	if m.pty == nil {
+		return errors.New("pty not started")
+	}
+	if err := m.pty.SendKeys(m.sessionName, cmd, true); err != nil {
+		return fmt.Errorf("failed to write to pty: %w", err)
+	}
+	return m.pty.SendKeys(m.sessionName, "Enter", false)
+}
+
+// killSession kills the tmux session, or stops the PTY. Caller must hold
+// m.mu.
+func (m *Manager) killSession() {
+	if !m.usesPTY() {
+		_ = m.tmuxCmd("kill-session", "-t", m.sessionName).Run()
+		return
+	}
+	if m.pty != nil {
+		_ = m.pty.Stop()
+	}
+}
+
+// killServer kills the instance's tmux server, or stops the PTY.
+func (m *Manager) killServer() error {
+	if !m.usesPTY() {
+		return tmux.KillServer(m.socketName)
+	}
+	if p := m.currentPTY(); p != nil {
+		return p.Stop()
+	}
+	return nil
+}
+
+// ptyStatus reports the PTY's history size, bell, and whether its shell is
+// still running, as getSessionStatus does for tmux.
+func (m *Manager) ptyStatus() sessionStatus {
+	p := m.currentPTY()
+	if p == nil || !p.Running() {
+		return sessionStatus{historySize: -1, sessionExists: false}
+	}
+	return sessionStatus{
+		historySize:   p.HistorySize(),
+		bellActive:    p.TakeBell(),
+		sessionExists: true,
+	}
+}
+
+// capturePTY returns the PTY's visible screen, or with full its history
+// too.
+func (m *Manager) capturePTY(full bool) ([]byte, error) {
+	p := m.currentPTY()
+	if p == nil {
+		return nil, errors.New("pty not started")
+	}
+	if full {
+		return p.Output(), nil
+	}
+	return p.Screen(), nil
+}
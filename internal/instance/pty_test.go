@@ -0,0 +1,73 @@
+//go:build unix
+
+package instance
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/ai"
+)
+
+func newPTYTestManager(t *testing.T, task string) *Manager {
+	t.Helper()
+	// The PTY starts the user's shell; keep their startup files out of the test
+	t.Setenv("SHELL", "/bin/sh")
+	return NewManagerWithDeps(ManagerOptions{
+		ID:      "pty-test",
+		WorkDir: t.TempDir(),
+		Task:    task,
+		Backend: ai.NewToolRunnerBackend(),
+		Config: ManagerConfig{
+			CaptureIntervalMs: 20,
+			TmuxWidth:         80,
+			TmuxHeight:        10,
+			ProcessBackend:    ProcessBackendPTY,
+		},
+	})
+}
+
+func TestManager_PTYBackend(t *testing.T) {
+	mgr := newPTYTestManager(t, "echo pty-$((20+22)); sleep 30")
+	if mgr.TmuxSessionExists() {
+		t.Fatal("TmuxSessionExists() = true before Start")
+	}
+	if err := mgr.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = mgr.Stop() })
+
+	if !mgr.TmuxSessionExists() || mgr.PID() <= 0 {
+		t.Fatalf("TmuxSessionExists() = %v, PID() = %d after Start", mgr.TmuxSessionExists(), mgr.PID())
+	}
+	if got := mgr.AttachCommand(); got != "" {
+		t.Errorf("AttachCommand() = %q, want empty for the pty backend", got)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(string(mgr.GetOutput()), "pty-42") {
+		if time.Now().After(deadline) {
+			t.Fatalf("output never captured, got %q", mgr.GetOutput())
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if err := mgr.Resize(60, 8); err != nil {
+		t.Errorf("Resize() error = %v", err)
+	}
+
+	if err := mgr.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if mgr.Running() || mgr.TmuxSessionExists() {
+		t.Error("instance still running after Stop")
+	}
+}
+
+func TestManager_PTYBackend_CannotReconnect(t *testing.T) {
+	mgr := newPTYTestManager(t, "true")
+	if err := mgr.Reconnect(); err == nil {
+		t.Error("Reconnect() error = nil, want an error for the pty backend")
+	}
+}
@@ -28,6 +28,19 @@ type MonitorConfig struct {
 	StaleThreshold int
 }
 
+// TimeoutConfig returns the configured thresholds as a detect.TimeoutConfig.
+// StaleThreshold is zero when stale detection is disabled.
+func (c MonitorConfig) TimeoutConfig() detect.TimeoutConfig {
+	tc := detect.TimeoutConfig{
+		ActivityTimeout:   time.Duration(c.ActivityTimeoutMinutes) * time.Minute,
+		CompletionTimeout: time.Duration(c.CompletionTimeoutMinutes) * time.Minute,
+	}
+	if c.StaleDetection {
+		tc.StaleThreshold = c.StaleThreshold
+	}
+	return tc
+}
+
 // DefaultMonitorConfig returns sensible default monitoring configuration.
 func DefaultMonitorConfig() MonitorConfig {
 	return MonitorConfig{
@@ -70,6 +83,10 @@ type instanceState struct {
 	timedOut            bool
 	timeoutType         TimeoutType
 	lastBellState       bool
+
+	// hooks folds the instance's Claude Code hook events (nil until the
+	// first arrives). Once set, it decides the state instead of output.
+	hooks *detect.HookState
 }
 
 // Monitor tracks state changes, timeouts, and bell events for instances.
@@ -83,6 +100,11 @@ type Monitor struct {
 	detector  detect.StateDetector
 	instances map[string]*instanceState
 
+	// overrides replaces the configured thresholds for individual instances
+	// (see SetTimeoutOverride). Overrides outlive Stop so they survive a
+	// restart of the instance.
+	overrides map[string]detect.TimeoutConfig
+
 	// Callbacks
 	stateCallback   StateChangeCallback
 	timeoutCallback TimeoutCallback
@@ -116,6 +138,7 @@ func NewMonitorWithDetector(cfg MonitorConfig, detector detect.StateDetector) *M
 		config:    cfg,
 		detector:  detector,
 		instances: make(map[string]*instanceState),
+		overrides: make(map[string]detect.TimeoutConfig),
 	}
 }
 
@@ -296,6 +319,42 @@ func (m *Monitor) ClearTimeout(instanceID string) {
 	}
 }
 
+// SetTimeoutOverride replaces the configured timeout thresholds for one
+// instance. A zero duration or threshold in cfg disables that check for the
+// instance. The override is kept until ClearTimeoutOverride, including
+// across Stop and Start.
+func (m *Monitor) SetTimeoutOverride(instanceID string, cfg detect.TimeoutConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.overrides[instanceID] = cfg
+
+	if m.logger != nil {
+		m.logger.Debug("set timeout override for instance",
+			"instance_id", instanceID,
+			"activity_timeout", cfg.ActivityTimeout,
+			"completion_timeout", cfg.CompletionTimeout,
+			"stale_threshold", cfg.StaleThreshold)
+	}
+}
+
+// ClearTimeoutOverride restores the configured timeout thresholds for an
+// instance.
+func (m *Monitor) ClearTimeoutOverride(instanceID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.overrides, instanceID)
+}
+
+// timeoutConfigLocked returns the timeout thresholds in effect for an
+// instance: its override if one is set, otherwise the monitor's config.
+// The caller holds m.mu.
+func (m *Monitor) timeoutConfigLocked(instanceID string) detect.TimeoutConfig {
+	if cfg, ok := m.overrides[instanceID]; ok {
+		return cfg
+	}
+	return m.config.TimeoutConfig()
+}
+
 // ProcessOutput processes new output for an instance, detecting state changes.
 // This should be called periodically with the instance's terminal output.
 // Returns the detected state.
@@ -315,20 +374,28 @@ func (m *Monitor) ProcessOutput(instanceID string, output []byte, outputHash str
 		return currentState
 	}
 
-	// Detect new state
-	newState := m.detector.Detect(output)
+	// Detect new state. Hook events, once an instance reports them, are
+	// authoritative and output only tracks activity.
+	var newState detect.WaitingState
+	var hasWorkingIndicators bool
+	if inst.hooks != nil {
+		newState = inst.hooks.State()
+		hasWorkingIndicators = newState == detect.StateWorking
+	} else {
+		newState = m.detector.Detect(output)
+		hasWorkingIndicators = m.detector.HasWorkingIndicators(output)
+	}
 	oldState := inst.currentState
 	stateChanged := newState != oldState
 
-	// Track output changes and working indicators
+	// Track output changes
 	outputChanged := outputHash != inst.lastOutputHash
-	hasWorkingIndicators := m.detector.HasWorkingIndicators(output)
 
 	if outputChanged {
 		inst.lastActivityTime = time.Now()
 		inst.lastOutputHash = outputHash
 		inst.repeatedOutputCount = 0
-	} else if m.config.StaleDetection {
+	} else if m.timeoutConfigLocked(instanceID).StaleThreshold > 0 {
 		// Only increment stale counter if:
 		// 1. No working indicators are present (spinners, "Reading...", etc.)
 		// 2. The instance is not in a waiting state (at the input prompt, asking a question, etc.)
@@ -368,6 +435,79 @@ func (m *Monitor) ProcessOutput(instanceID string, output []byte, outputHash str
 	return newState
 }
 
+// ProcessHookEvent applies a Claude Code hook event reported by an instance
+// and returns the resulting state. From the first event on, the instance's
+// state comes from its hook events rather than its output (see
+// detect.HookState). Each event counts as activity for timeouts. Events for
+// instances that are not monitored are ignored.
+func (m *Monitor) ProcessHookEvent(instanceID string, ev detect.HookEvent) detect.WaitingState {
+	m.mu.Lock()
+
+	inst, exists := m.instances[instanceID]
+	if !exists {
+		m.mu.Unlock()
+		return detect.StateWorking
+	}
+
+	if inst.hooks == nil {
+		inst.hooks = detect.NewHookState()
+	}
+	newState := inst.hooks.Apply(ev)
+	inst.lastActivityTime = time.Now()
+	inst.repeatedOutputCount = 0
+
+	// A timed-out instance keeps its state until the timeout is cleared
+	if inst.timedOut {
+		currentState := inst.currentState
+		m.mu.Unlock()
+		return currentState
+	}
+
+	oldState := inst.currentState
+	stateChanged := newState != oldState
+	if stateChanged {
+		inst.currentState = newState
+	}
+	callback := m.stateCallback
+	logger := m.logger
+	m.mu.Unlock()
+
+	if logger != nil {
+		logger.Debug("hook event received",
+			"instance_id", instanceID,
+			"event", ev.HookEventName,
+			"tool", ev.ToolName)
+	}
+	if stateChanged {
+		if logger != nil {
+			logger.Info("instance state changed",
+				"instance_id", instanceID,
+				"old_state", oldState.String(),
+				"new_state", newState.String(),
+				"source", "hook")
+		}
+		if callback != nil {
+			callback(instanceID, oldState, newState)
+		}
+	}
+
+	return newState
+}
+
+// HookActivity returns the tool usage an instance has reported through
+// hook events. It returns false if the instance is not monitored or has
+// not reported any.
+func (m *Monitor) HookActivity(instanceID string) (detect.HookActivity, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	inst, exists := m.instances[instanceID]
+	if !exists || inst.hooks == nil {
+		return detect.HookActivity{}, false
+	}
+	return inst.hooks.Activity(), true
+}
+
 // CheckTimeouts checks for timeout conditions on an instance.
 // Returns the detected timeout type, or nil if no timeout occurred.
 // The callback is invoked if a timeout is detected.
@@ -381,12 +521,12 @@ func (m *Monitor) CheckTimeouts(instanceID string) *TimeoutType {
 	}
 
 	now := time.Now()
+	cfg := m.timeoutConfigLocked(instanceID)
 	var triggeredTimeout *TimeoutType
 
 	// Check completion timeout (total runtime) - highest priority
-	if m.config.CompletionTimeoutMinutes > 0 && inst.startTime != nil {
-		completionTimeout := time.Duration(m.config.CompletionTimeoutMinutes) * time.Minute
-		if now.Sub(*inst.startTime) > completionTimeout {
+	if cfg.CompletionTimeout > 0 && inst.startTime != nil {
+		if now.Sub(*inst.startTime) > cfg.CompletionTimeout {
 			t := TimeoutCompletion
 			triggeredTimeout = &t
 			inst.timedOut = true
@@ -395,9 +535,8 @@ func (m *Monitor) CheckTimeouts(instanceID string) *TimeoutType {
 	}
 
 	// Check activity timeout (no output changes)
-	if triggeredTimeout == nil && m.config.ActivityTimeoutMinutes > 0 {
-		activityTimeout := time.Duration(m.config.ActivityTimeoutMinutes) * time.Minute
-		if now.Sub(inst.lastActivityTime) > activityTimeout {
+	if triggeredTimeout == nil && cfg.ActivityTimeout > 0 {
+		if now.Sub(inst.lastActivityTime) > cfg.ActivityTimeout {
 			t := TimeoutActivity
 			triggeredTimeout = &t
 			inst.timedOut = true
@@ -411,9 +550,9 @@ func (m *Monitor) CheckTimeouts(instanceID string) *TimeoutType {
 	// so this check is already filtered. The waiting-state guard here is belt-and-suspenders:
 	// even if the counter somehow accumulated, don't trigger stale for an instance
 	// that the detector has identified as waiting for user input/question/permission.
-	if triggeredTimeout == nil && m.config.StaleDetection &&
+	if triggeredTimeout == nil && cfg.StaleThreshold > 0 &&
 		!inst.currentState.IsWaiting() &&
-		inst.repeatedOutputCount > m.config.StaleThreshold {
+		inst.repeatedOutputCount > cfg.StaleThreshold {
 		t := TimeoutStale
 		triggeredTimeout = &t
 		inst.timedOut = true
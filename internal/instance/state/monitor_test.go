@@ -1018,3 +1018,133 @@ func TestMonitor_ResetStaleCounter_NonMonitored(t *testing.T) {
 	// Should not panic for non-monitored instance
 	m.ResetStaleCounter("nonexistent")
 }
+
+func TestMonitorConfig_TimeoutConfig(t *testing.T) {
+	cfg := MonitorConfig{
+		ActivityTimeoutMinutes:   30,
+		CompletionTimeoutMinutes: 120,
+		StaleDetection:           true,
+		StaleThreshold:           3000,
+	}
+	want := detect.TimeoutConfig{
+		ActivityTimeout:   30 * time.Minute,
+		CompletionTimeout: 2 * time.Hour,
+		StaleThreshold:    3000,
+	}
+	if got := cfg.TimeoutConfig(); got != want {
+		t.Errorf("TimeoutConfig() = %+v, want %+v", got, want)
+	}
+
+	cfg.StaleDetection = false
+	if got := cfg.TimeoutConfig().StaleThreshold; got != 0 {
+		t.Errorf("StaleThreshold with stale detection off = %d, want 0", got)
+	}
+}
+
+func TestMonitor_TimeoutOverride(t *testing.T) {
+	m := NewMonitor(MonitorConfig{ActivityTimeoutMinutes: 1})
+
+	pastTime := time.Now().Add(-2 * time.Minute)
+	m.StartWithTime("inst-1", pastTime)
+	m.StartWithTime("inst-2", pastTime)
+	m.mu.Lock()
+	m.instances["inst-1"].lastActivityTime = pastTime
+	m.instances["inst-2"].lastActivityTime = pastTime
+	m.mu.Unlock()
+
+	m.SetTimeoutOverride("inst-1", detect.TimeoutConfig{ActivityTimeout: time.Hour})
+	if result := m.CheckTimeouts("inst-1"); result != nil {
+		t.Errorf("CheckTimeouts(inst-1) = %v, want nil with a longer override", *result)
+	}
+	if result := m.CheckTimeouts("inst-2"); result == nil || *result != TimeoutActivity {
+		t.Errorf("CheckTimeouts(inst-2) = %v, want TimeoutActivity", result)
+	}
+
+	// The override survives a restart of the instance.
+	m.Stop("inst-1")
+	m.StartWithTime("inst-1", pastTime)
+	m.mu.Lock()
+	m.instances["inst-1"].lastActivityTime = pastTime
+	m.mu.Unlock()
+	if result := m.CheckTimeouts("inst-1"); result != nil {
+		t.Errorf("CheckTimeouts(inst-1) after restart = %v, want nil", *result)
+	}
+
+	m.ClearTimeoutOverride("inst-1")
+	if result := m.CheckTimeouts("inst-1"); result == nil || *result != TimeoutActivity {
+		t.Errorf("CheckTimeouts(inst-1) after clearing override = %v, want TimeoutActivity", result)
+	}
+}
+
+func TestMonitor_TimeoutOverride_StaleThreshold(t *testing.T) {
+	m := NewMonitor(MonitorConfig{StaleDetection: false})
+	m.Start("inst-1")
+	m.SetTimeoutOverride("inst-1", detect.TimeoutConfig{StaleThreshold: 3})
+
+	for i := 0; i < 5; i++ {
+		m.ProcessOutput("inst-1", []byte("same output"), "samehash")
+	}
+
+	if result := m.CheckTimeouts("inst-1"); result == nil || *result != TimeoutStale {
+		t.Errorf("CheckTimeouts() = %v, want TimeoutStale from the override", result)
+	}
+}
+
+func TestMonitor_ProcessHookEvent(t *testing.T) {
+	m := NewMonitorWithDefaults()
+
+	var changes []detect.WaitingState
+	m.OnStateChange(func(_ string, _, new detect.WaitingState) {
+		changes = append(changes, new)
+	})
+
+	if state := m.ProcessHookEvent("inst-1", detect.HookEvent{HookEventName: detect.HookStop}); state != detect.StateWorking {
+		t.Errorf("ProcessHookEvent() for an unmonitored instance = %v, want StateWorking", state)
+	}
+	if _, ok := m.HookActivity("inst-1"); ok {
+		t.Error("HookActivity() for an unmonitored instance ok = true, want false")
+	}
+
+	m.Start("inst-1")
+	m.ProcessHookEvent("inst-1", detect.HookEvent{HookEventName: detect.HookPostToolUse, ToolName: "Bash"})
+	if state := m.ProcessHookEvent("inst-1", detect.HookEvent{HookEventName: detect.HookStop}); state != detect.StateWaitingInput {
+		t.Errorf("ProcessHookEvent(Stop) = %v, want StateWaitingInput", state)
+	}
+
+	// Hook state wins over output that would read as a question
+	if state := m.ProcessOutput("inst-1", []byte("What file would you like me to edit?"), "hash1"); state != detect.StateWaitingInput {
+		t.Errorf("ProcessOutput() after hook events = %v, want the hook state StateWaitingInput", state)
+	}
+
+	if len(changes) != 1 || changes[0] != detect.StateWaitingInput {
+		t.Errorf("state changes = %v, want [waiting_input]", changes)
+	}
+
+	activity, ok := m.HookActivity("inst-1")
+	if !ok || activity.ToolUses["Bash"] != 1 || activity.Events != 2 {
+		t.Errorf("HookActivity() = %+v, %v; want one Bash use in 2 events", activity, ok)
+	}
+}
+
+func TestMonitor_ProcessHookEvent_ResetsActivity(t *testing.T) {
+	m := NewMonitor(MonitorConfig{StaleDetection: true, StaleThreshold: 2})
+	m.Start("inst-1")
+	m.ProcessHookEvent("inst-1", detect.HookEvent{HookEventName: detect.HookPostToolUse, ToolName: "Read"})
+
+	// Unchanged output while working counts toward stale detection...
+	m.ProcessOutput("inst-1", []byte("same"), "hash1")
+	m.ProcessOutput("inst-1", []byte("same"), "hash1")
+
+	// ...until the instance reports another tool call
+	before := m.GetLastActivityTime("inst-1")
+	time.Sleep(time.Millisecond)
+	m.ProcessHookEvent("inst-1", detect.HookEvent{HookEventName: detect.HookPostToolUse, ToolName: "Read"})
+	m.ProcessOutput("inst-1", []byte("same"), "hash1")
+
+	if timeout := m.CheckTimeouts("inst-1"); timeout != nil {
+		t.Errorf("CheckTimeouts() = %v, want none after a hook event", *timeout)
+	}
+	if !m.GetLastActivityTime("inst-1").After(before) {
+		t.Error("ProcessHookEvent() did not update the last activity time")
+	}
+}
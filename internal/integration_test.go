@@ -115,7 +115,7 @@ func TestEventBusWildcardSubscription(t *testing.T) {
 	bus.Publish(event.NewInstanceStartedEvent("inst-1", "/tmp", "branch", "task"))
 	bus.Publish(event.NewMetricsUpdateEvent("inst-1", 1000, 500, 100, 50, 0.05, 1))
 	bus.Publish(event.NewBellEvent("inst-1"))
-	bus.Publish(event.NewTaskCompletedEvent("task-1", "inst-1", true, ""))
+	bus.Publish(event.NewTaskCompletedEvent("task-1", "inst-1", true, "", ""))
 	bus.Publish(event.NewPhaseChangeEvent("session-1", event.PhasePlanning, event.PhaseExecuting))
 
 	mu.Lock()
@@ -497,10 +497,11 @@ func TestEventTypesIntegration(t *testing.T) {
 		event.NewInstanceStoppedEvent("id", true, "reason"),
 		event.NewPRCompleteEvent("id", true, "url", ""),
 		event.NewPROpenedEvent("id", "url"),
+		event.NewBranchesReapedEvent("plan", []string{"url"}, 2, 1, 0),
 		event.NewTimeoutEvent("id", event.TimeoutActivity, "5m"),
 		event.NewTimeoutEvent("id", event.TimeoutCompletion, "30m"),
 		event.NewTimeoutEvent("id", event.TimeoutStale, "5m"),
-		event.NewTaskCompletedEvent("task", "inst", true, ""),
+		event.NewTaskCompletedEvent("task", "inst", true, "", ""),
 		event.NewPhaseChangeEvent("session", event.PhasePlanning, event.PhaseExecuting),
 		event.NewMetricsUpdateEvent("id", 100, 50, 10, 5, 0.01, 1),
 		event.NewBellEvent("id"),
@@ -12,6 +12,8 @@
 //   - Context propagation (session ID, instance ID, phase)
 //   - Log rotation with configurable size limits
 //   - Optional gzip compression for rotated logs
+//   - Shipping to stderr, syslog, or HTTP (e.g. Loki) sinks
+//   - Per-message rate limiting and sampling
 //
 // # Thread Safety
 //
@@ -77,6 +79,37 @@
 // most recent backup. When compression is enabled, rotated files become
 // debug.log.1.gz, etc.
 //
+// # Rate Limits
+//
+// [Logger.WithRateLimits] keeps noisy messages, like a per-second status
+// check, from dominating the log. Each [RateLimit] names a message and logs
+// it at most once per Interval, or one in every Sample occurrences, counted
+// separately for each instance_id:
+//
+//	logger = logger.WithRateLimits(logging.RateLimit{
+//	    Msg:      "instance status check",
+//	    Interval: 30 * time.Second,
+//	})
+//
+// Only DEBUG and INFO records are held back. The next logged occurrence
+// carries the number held back in a "suppressed" field.
+//
+// # Log Sinks
+//
+// Records can be shipped to other destinations as well as the log file by
+// passing [SinkConfig]s to [NewLoggerWithRotation]: JSON lines on stderr,
+// syslog, or an HTTP endpoint taking newline-delimited JSON or Loki pushes:
+//
+//	logger, err := logging.NewLoggerWithRotation(dir, "INFO", config,
+//	    logging.SinkConfig{Type: logging.SinkHTTP, URL: lokiURL, Format: logging.SinkFormatLoki},
+//	)
+//
+// Each sink is fed by a [SinkWriter], which buffers records and sends them
+// in the background so logging never waits on the network. A failed send
+// is retried with exponential backoff while the records stay buffered, and
+// the oldest are dropped once the buffer is full. [Logger.Close] sends what
+// is left before closing the file.
+//
 // # Testing
 //
 // For testing, use [NopLogger] to discard all log output:
@@ -0,0 +1,182 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// LogFileName is the name of the log file in a session directory.
+const LogFileName = "debug.log"
+
+// Entry is one parsed line of a session log.
+type Entry struct {
+	Time       time.Time      `json:"time"`
+	Level      string         `json:"level"`
+	Msg        string         `json:"msg"`
+	SessionID  string         `json:"session_id,omitempty"`
+	InstanceID string         `json:"instance_id,omitempty"`
+	Phase      string         `json:"phase,omitempty"`
+	Extra      map[string]any `json:"-"` // Captures additional fields
+}
+
+// ParseEntry parses a JSON log line as written by [Logger].
+func ParseEntry(line []byte) (Entry, error) {
+	var e Entry
+	err := json.Unmarshal(line, &e)
+	return e, err
+}
+
+// ReadEntries reads the complete lines written to the log at path from
+// offset on and returns their entries, with the offset to continue from.
+// Lines that are not JSON become entries with only Msg set. A negative
+// offset starts about -offset bytes before the end, at the next full line.
+// When the file is shorter than offset it was rotated, and is read from the
+// start.
+func ReadEntries(path string, offset int64) ([]Entry, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, offset, err
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, offset, err
+	}
+	skipPartial := false
+	switch {
+	case offset < 0:
+		skipPartial = info.Size() > -offset
+		offset = max(info.Size()+offset, 0)
+	case info.Size() < offset:
+		offset = 0
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, err
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, offset, err
+	}
+
+	// A line still being written is left for the next read
+	end := bytes.LastIndexByte(data, '\n') + 1
+	next := offset + int64(end)
+	data = data[:end]
+	if skipPartial {
+		data = data[bytes.IndexByte(data, '\n')+1:]
+	}
+
+	var entries []Entry
+	for line := range bytes.SplitSeq(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		e, err := ParseEntry(line)
+		if err != nil {
+			e = Entry{Msg: string(line)}
+		}
+		entries = append(entries, e)
+	}
+	return entries, next, nil
+}
+
+// UnmarshalJSON implements custom unmarshaling to capture extra fields
+func (e *Entry) UnmarshalJSON(data []byte) error {
+	// First, unmarshal known fields using a type alias to avoid recursion
+	type Alias Entry
+	aux := &struct {
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+
+	// Then unmarshal all fields to capture extras
+	var all map[string]any
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+
+	// Remove known fields, keep the rest as extra
+	for _, known := range []string{"time", "level", "msg", "session_id", "instance_id", "phase"} {
+		delete(all, known)
+	}
+
+	if len(all) > 0 {
+		e.Extra = all
+	}
+
+	return nil
+}
+
+// LevelPriority returns the priority of a log level for filtering, from 0
+// for DEBUG to 3 for ERROR, or -1 for an unknown level.
+func LevelPriority(level string) int {
+	switch strings.ToUpper(level) {
+	case LevelDebug:
+		return 0
+	case LevelInfo:
+		return 1
+	case LevelWarn:
+		return 2
+	case LevelError:
+		return 3
+	default:
+		return -1
+	}
+}
+
+// LogFilter selects log entries. Zero fields match every entry.
+type LogFilter struct {
+	// MinLevel is the lowest level shown (DEBUG, INFO, WARN, or ERROR)
+	MinLevel string
+	// InstanceID matches entries logged for one instance
+	InstanceID string
+	// Phase matches entries logged in one phase
+	Phase string
+	// Since drops entries logged before it
+	Since time.Time
+	// Pattern is matched against the message and the extra fields
+	Pattern *regexp.Regexp
+}
+
+// IsZero reports whether the filter matches every entry.
+func (f LogFilter) IsZero() bool {
+	return f.MinLevel == "" && f.InstanceID == "" && f.Phase == "" && f.Since.IsZero() && f.Pattern == nil
+}
+
+// Match reports whether e passes every criterion of the filter.
+func (f LogFilter) Match(e *Entry) bool {
+	if f.MinLevel != "" && LevelPriority(e.Level) < LevelPriority(f.MinLevel) {
+		return false
+	}
+	if f.InstanceID != "" && e.InstanceID != f.InstanceID {
+		return false
+	}
+	if f.Phase != "" && e.Phase != f.Phase {
+		return false
+	}
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	if f.Pattern != nil {
+		searchText := e.Msg
+		for _, v := range e.Extra {
+			searchText += " " + fmt.Sprintf("%v", v)
+		}
+		if !f.Pattern.MatchString(searchText) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestParseEntry(t *testing.T) {
+	line := `{"time":"2026-01-02T03:04:05Z","level":"WARN","msg":"slow","instance_id":"a1","phase":"execution","ms":120}`
+	e, err := ParseEntry([]byte(line))
+	if err != nil {
+		t.Fatalf("ParseEntry() error = %v", err)
+	}
+	if e.Level != LevelWarn || e.Msg != "slow" || e.InstanceID != "a1" || e.Phase != "execution" {
+		t.Errorf("ParseEntry() = %+v", e)
+	}
+	if len(e.Extra) != 1 || e.Extra["ms"] != float64(120) {
+		t.Errorf("Extra = %v, want only ms", e.Extra)
+	}
+}
+
+func TestLogFilter_Match(t *testing.T) {
+	now := time.Now()
+	entry := Entry{
+		Time:       now,
+		Level:      LevelWarn,
+		Msg:        "merge failed",
+		InstanceID: "a1",
+		Phase:      "consolidation",
+		Extra:      map[string]any{"branch": "feature/x"},
+	}
+
+	tests := []struct {
+		name   string
+		filter LogFilter
+		want   bool
+	}{
+		{"zero filter", LogFilter{}, true},
+		{"level at minimum", LogFilter{MinLevel: LevelWarn}, true},
+		{"level below minimum", LogFilter{MinLevel: LevelError}, false},
+		{"same instance", LogFilter{InstanceID: "a1"}, true},
+		{"other instance", LogFilter{InstanceID: "b2"}, false},
+		{"same phase", LogFilter{Phase: "consolidation"}, true},
+		{"other phase", LogFilter{Phase: "planning"}, false},
+		{"since before", LogFilter{Since: now.Add(-time.Minute)}, true},
+		{"since after", LogFilter{Since: now.Add(time.Minute)}, false},
+		{"pattern in message", LogFilter{Pattern: regexp.MustCompile("merge")}, true},
+		{"pattern in extra field", LogFilter{Pattern: regexp.MustCompile("feature/")}, true},
+		{"pattern missing", LogFilter{Pattern: regexp.MustCompile("timeout")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(&entry); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !(LogFilter{}).IsZero() || (LogFilter{Phase: "planning"}).IsZero() {
+		t.Error("IsZero() is wrong")
+	}
+}
+
+func TestReadEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), LogFileName)
+	write := func(s string, flag int) {
+		t.Helper()
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|flag, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.WriteString(s); err != nil {
+			t.Fatal(err)
+		}
+		_ = f.Close()
+	}
+	msgs := func(entries []Entry) []string {
+		var out []string
+		for _, e := range entries {
+			out = append(out, e.Msg)
+		}
+		return out
+	}
+
+	write(`{"level":"INFO","msg":"one"}`+"\nplain text\n"+`{"level":"INFO","msg":"part`, os.O_TRUNC)
+	entries, offset, err := ReadEntries(path, 0)
+	if err != nil {
+		t.Fatalf("ReadEntries() error = %v", err)
+	}
+	if got := msgs(entries); len(got) != 2 || got[0] != "one" || got[1] != "plain text" {
+		t.Errorf("first read = %q, want the two complete lines", got)
+	}
+
+	write(`ial"}`+"\n", os.O_APPEND)
+	entries, offset, _ = ReadEntries(path, offset)
+	if got := msgs(entries); len(got) != 1 || got[0] != "partial" {
+		t.Errorf("second read = %q, want the finished line", got)
+	}
+
+	// Rotated: the new file is shorter than the offset
+	write(`{"level":"INFO","msg":"fresh"}`+"\n", os.O_TRUNC)
+	entries, _, _ = ReadEntries(path, offset)
+	if got := msgs(entries); len(got) != 1 || got[0] != "fresh" {
+		t.Errorf("read after rotation = %q, want the new file", got)
+	}
+
+	// A negative offset starts at the first full line near the end
+	write("aaaa\nbbbb\ncccc\n", os.O_TRUNC)
+	entries, offset, _ = ReadEntries(path, -7)
+	if got := msgs(entries); len(got) != 1 || got[0] != "cccc" || offset != 15 {
+		t.Errorf("tail read = %q at %d, want the last line at 15", got, offset)
+	}
+
+	if _, _, err := ReadEntries(filepath.Join(t.TempDir(), "missing.log"), 0); !os.IsNotExist(err) {
+		t.Errorf("ReadEntries(missing) error = %v, want not exist", err)
+	}
+}
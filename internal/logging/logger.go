@@ -5,6 +5,7 @@ package logging
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -28,6 +29,8 @@ type Logger struct {
 	logger   *slog.Logger
 	file     *os.File        // For backward compatibility when not using rotation
 	rotation *RotatingWriter // Used when rotation is enabled
+	sinks    []*SinkWriter   // External destinations records are also sent to
+	limiter  *rateLimiter    // Holds back rate limited messages; nil for none
 	mu       sync.Mutex      // Protects file operations
 	attrs    []slog.Attr     // Persistent attributes (session, instance, phase)
 }
@@ -56,7 +59,7 @@ func NewLogger(sessionDir string, level string) (*Logger, error) {
 			return nil, fmt.Errorf("failed to create session directory: %w", err)
 		}
 
-		logPath := filepath.Join(sessionDir, "debug.log")
+		logPath := filepath.Join(sessionDir, LogFileName)
 		var err error
 		file, err = os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
 		if err != nil {
@@ -97,7 +100,10 @@ func NewLogger(sessionDir string, level string) (*Logger, error) {
 // Rotation is thread-safe and will not block logging during rotation.
 // Old log files are named: debug.log.1, debug.log.2, etc., where .1 is
 // the most recent backup.
-func NewLoggerWithRotation(sessionDir string, level string, rotationConfig RotationConfig) (*Logger, error) {
+//
+// Each of sinks also receives every record, buffered and sent in the
+// background (see [SinkWriter]).
+func NewLoggerWithRotation(sessionDir string, level string, rotationConfig RotationConfig, sinks ...SinkConfig) (*Logger, error) {
 	var writer io.Writer
 	var rotation *RotatingWriter
 
@@ -107,7 +113,7 @@ func NewLoggerWithRotation(sessionDir string, level string, rotationConfig Rotat
 			return nil, fmt.Errorf("failed to create session directory: %w", err)
 		}
 
-		logPath := filepath.Join(sessionDir, "debug.log")
+		logPath := filepath.Join(sessionDir, LogFileName)
 		var err error
 		rotation, err = NewRotatingWriter(logPath, rotationConfig)
 		if err != nil {
@@ -118,17 +124,37 @@ func NewLoggerWithRotation(sessionDir string, level string, rotationConfig Rotat
 		writer = os.Stderr
 	}
 
+	var sinkWriters []*SinkWriter
+	writers := []io.Writer{writer}
+	for _, cfg := range sinks {
+		w, err := NewSinkWriter(cfg)
+		if err != nil {
+			for _, sw := range sinkWriters {
+				_ = sw.Close()
+			}
+			if rotation != nil {
+				_ = rotation.Close()
+			}
+			return nil, fmt.Errorf("failed to create %s log sink: %w", cfg.Type, err)
+		}
+		sinkWriters = append(sinkWriters, w)
+		writers = append(writers, w)
+	}
+
 	slogLevel := parseLevel(level)
 
 	opts := &slog.HandlerOptions{
 		Level: slogLevel,
 	}
 
-	handler := slog.NewJSONHandler(writer, opts)
+	// The file comes first: sink writers never fail, so they can't keep a
+	// record from the file.
+	handler := slog.NewJSONHandler(io.MultiWriter(writers...), opts)
 
 	return &Logger{
 		logger:   slog.New(handler),
 		rotation: rotation,
+		sinks:    sinkWriters,
 		attrs:    make([]slog.Attr, 0),
 	}, nil
 }
@@ -192,12 +218,7 @@ func (l *Logger) With(args ...any) *Logger {
 		newAttrs = append(newAttrs, slog.Any(key, args[i+1]))
 	}
 
-	return &Logger{
-		logger:   l.logger,
-		file:     l.file,
-		rotation: l.rotation,
-		attrs:    newAttrs,
-	}
+	return l.withAttrs(newAttrs)
 }
 
 // withAttr creates a new Logger with an additional attribute.
@@ -206,11 +227,19 @@ func (l *Logger) withAttr(attr slog.Attr) *Logger {
 	copy(newAttrs, l.attrs)
 	newAttrs[len(l.attrs)] = attr
 
+	return l.withAttrs(newAttrs)
+}
+
+// withAttrs creates a new Logger sharing l's output and rate limits, with
+// attrs as its persistent attributes.
+func (l *Logger) withAttrs(attrs []slog.Attr) *Logger {
 	return &Logger{
 		logger:   l.logger,
 		file:     l.file,
 		rotation: l.rotation,
-		attrs:    newAttrs,
+		sinks:    l.sinks,
+		limiter:  l.limiter,
+		attrs:    attrs,
 	}
 }
 
@@ -241,30 +270,73 @@ func (l *Logger) Error(msg string, args ...any) {
 // log is the internal logging method that combines persistent attributes
 // with per-call arguments.
 func (l *Logger) log(level slog.Level, msg string, args ...any) {
+	suppressed := 0
+	if l.limiter != nil && level < slog.LevelWarn {
+		// Occurrences below the level don't count against the limit
+		if !l.logger.Enabled(context.Background(), level) {
+			return
+		}
+		var ok bool
+		if ok, suppressed = l.limiter.allow(msg, l.instanceID(args)); !ok {
+			return
+		}
+	}
+
 	// Combine persistent attrs with per-call args
-	allArgs := make([]any, 0, len(l.attrs)*2+len(args))
+	allArgs := make([]any, 0, len(l.attrs)*2+len(args)+2)
 	for _, attr := range l.attrs {
 		allArgs = append(allArgs, attr.Key, attr.Value.Any())
 	}
 	allArgs = append(allArgs, args...)
+	if suppressed > 0 {
+		allArgs = append(allArgs, "suppressed", suppressed)
+	}
 
 	l.logger.Log(context.Background(), level, msg, allArgs...)
 }
 
-// Close flushes and closes the log file.
-// If the logger was created without a session directory (writing to stderr),
-// this method is a no-op.
+// instanceID returns the instance a record is about: the instance_id in
+// args, or else the Logger's own.
+func (l *Logger) instanceID(args []any) string {
+	for i := 0; i < len(args)-1; i += 2 {
+		if key, ok := args[i].(string); ok && key == "instance_id" {
+			if id, ok := args[i+1].(string); ok {
+				return id
+			}
+		}
+	}
+	for _, attr := range l.attrs {
+		if attr.Key == "instance_id" {
+			return attr.Value.String()
+		}
+	}
+	return ""
+}
+
+// Close sends the records buffered for sinks, then flushes and closes the
+// log file. If the logger was created without a session directory (writing
+// to stderr) or sinks, this method is a no-op.
 func (l *Logger) Close() error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	// Sink failures don't keep the file from closing
+	var sinkErr error
+	for _, w := range l.sinks {
+		sinkErr = errors.Join(sinkErr, w.Close())
+	}
+	l.sinks = nil
+	if sinkErr != nil {
+		sinkErr = fmt.Errorf("failed to flush log sinks: %w", sinkErr)
+	}
+
 	// Handle rotation-based logger
 	if l.rotation != nil {
 		if err := l.rotation.Close(); err != nil {
 			return err
 		}
 		l.rotation = nil
-		return nil
+		return sinkErr
 	}
 
 	// Handle legacy file-based logger
@@ -277,7 +349,7 @@ func (l *Logger) Close() error {
 		}
 		l.file = nil
 	}
-	return nil
+	return sinkErr
 }
 
 // NopLogger returns a Logger that discards all log output.
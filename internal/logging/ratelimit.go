@@ -0,0 +1,108 @@
+package logging
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimit caps how often a DEBUG or INFO message is logged. Warnings and
+// errors are never held back. Occurrences are counted separately for each
+// instance, so one busy instance doesn't hide another's messages.
+type RateLimit struct {
+	// Msg is the exact message limited.
+	Msg string
+	// Interval is the least time between two logged occurrences. Zero
+	// doesn't limit by time.
+	Interval time.Duration
+	// Sample logs only one in every Sample occurrences. Zero or one logs
+	// every occurrence the interval lets through.
+	Sample int
+}
+
+// rateKey identifies the occurrences of a limited message counted together.
+type rateKey struct {
+	msg      string
+	instance string
+}
+
+// rateState is what a rateLimiter tracks for one key.
+type rateState struct {
+	last       time.Time // When an occurrence was last logged
+	seen       int       // Occurrences the interval let through
+	suppressed int       // Occurrences held back since the last logged one
+}
+
+// rateLimiter decides which occurrences of rate limited messages are
+// logged. It is shared by a Logger and its children, and is safe for
+// concurrent use.
+type rateLimiter struct {
+	limits map[string]RateLimit
+	now    func() time.Time
+
+	mu    sync.Mutex
+	state map[rateKey]*rateState
+}
+
+// newRateLimiter returns a limiter for limits, or nil if none limits
+// anything.
+func newRateLimiter(limits []RateLimit) *rateLimiter {
+	byMsg := make(map[string]RateLimit, len(limits))
+	for _, limit := range limits {
+		if limit.Msg != "" && (limit.Interval > 0 || limit.Sample > 1) {
+			byMsg[limit.Msg] = limit
+		}
+	}
+	if len(byMsg) == 0 {
+		return nil
+	}
+	return &rateLimiter{
+		limits: byMsg,
+		now:    time.Now,
+		state:  make(map[rateKey]*rateState),
+	}
+}
+
+// allow reports whether an occurrence of msg for instance is logged and,
+// if so, how many occurrences were held back since the last one logged.
+func (r *rateLimiter) allow(msg, instance string) (bool, int) {
+	limit, ok := r.limits[msg]
+	if !ok {
+		return true, 0
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := rateKey{msg: msg, instance: instance}
+	s := r.state[key]
+	if s == nil {
+		s = &rateState{}
+		r.state[key] = s
+	}
+
+	now := r.now()
+	if limit.Interval > 0 && !s.last.IsZero() && now.Sub(s.last) < limit.Interval {
+		s.suppressed++
+		return false, 0
+	}
+	s.seen++
+	if limit.Sample > 1 && (s.seen-1)%limit.Sample != 0 {
+		s.suppressed++
+		return false, 0
+	}
+
+	suppressed := s.suppressed
+	s.last = now
+	s.suppressed = 0
+	return true, suppressed
+}
+
+// WithRateLimits returns a new Logger that holds back occurrences of the
+// limited messages beyond their rate. A logged occurrence that follows
+// held-back ones carries their count in a "suppressed" field. It replaces
+// any limits the Logger had; children created from the returned Logger
+// share its counts.
+func (l *Logger) WithRateLimits(limits ...RateLimit) *Logger {
+	child := l.withAttrs(l.attrs)
+	child.limiter = newRateLimiter(limits)
+	return child
+}
@@ -0,0 +1,98 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readLogEntries returns the entries logged to dir's log file.
+func readLogEntries(t *testing.T, dir string) []Entry {
+	t.Helper()
+	entries, _, err := ReadEntries(filepath.Join(dir, LogFileName), 0)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	return entries
+}
+
+func TestLogger_WithRateLimits(t *testing.T) {
+	dir := t.TempDir()
+	base, err := NewLogger(dir, "DEBUG")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = base.Close() })
+
+	logger := base.WithRateLimits(
+		RateLimit{Msg: "instance status check", Interval: 30 * time.Second},
+		RateLimit{Msg: "output captured", Sample: 3},
+	)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	logger.limiter.now = func() time.Time { return now }
+
+	for range 3 {
+		logger.Debug("instance status check", "instance_id", "a1")
+	}
+	logger.Debug("instance status check", "instance_id", "b2")
+	logger.WithInstance("b2").Debug("instance status check")
+	now = now.Add(31 * time.Second)
+	logger.Debug("instance status check", "instance_id", "a1")
+
+	for range 4 {
+		logger.Info("output captured")
+	}
+	logger.Warn("instance status check", "instance_id", "a1")
+	logger.Debug("unlimited")
+
+	var got []string
+	for _, e := range readLogEntries(t, dir) {
+		fields := []string{e.Msg}
+		if e.InstanceID != "" {
+			fields = append(fields, e.InstanceID)
+		}
+		if n, ok := e.Extra["suppressed"]; ok {
+			fields = append(fields, fmt.Sprintf("suppressed=%v", n))
+		}
+		got = append(got, strings.Join(fields, " "))
+	}
+	want := []string{
+		"instance status check a1",
+		"instance status check b2",
+		"instance status check a1 suppressed=2",
+		"output captured",
+		"output captured suppressed=2",
+		"instance status check a1",
+		"unlimited",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("logged:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestLogger_WithRateLimits_DisabledLevelDoesNotCount(t *testing.T) {
+	dir := t.TempDir()
+	base, err := NewLogger(dir, "INFO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = base.Close() })
+
+	logger := base.WithRateLimits(RateLimit{Msg: "tick", Sample: 2})
+	logger.Debug("tick")
+	logger.Info("tick")
+
+	entries := readLogEntries(t, dir)
+	if len(entries) != 1 || entries[0].Extra["suppressed"] != nil {
+		t.Errorf("entries = %+v, want the info record with nothing suppressed", entries)
+	}
+}
+
+func TestNewRateLimiter_IgnoresNoOpLimits(t *testing.T) {
+	if r := newRateLimiter([]RateLimit{{Msg: "x"}, {Msg: "y", Sample: 1}, {Interval: time.Second}}); r != nil {
+		t.Errorf("newRateLimiter() = %+v, want nil for limits that limit nothing", r)
+	}
+}
@@ -0,0 +1,304 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Sink types supported by NewLoggerWithRotation.
+const (
+	SinkStderr = "stderr"
+	SinkSyslog = "syslog"
+	SinkHTTP   = "http"
+)
+
+const (
+	// DefaultSinkBufferSize is how many records a sink keeps while it is
+	// unreachable, unless its config sets BufferSize.
+	DefaultSinkBufferSize = 1000
+
+	// sinkFlushInterval is how often buffered records are sent.
+	sinkFlushInterval = time.Second
+
+	// sinkBatchSize is the most records sent at once. A full batch is sent
+	// without waiting for the flush interval.
+	sinkBatchSize = 200
+
+	// sinkMinBackoff and sinkMaxBackoff bound the wait before retrying a
+	// sink that failed; it doubles with each consecutive failure.
+	sinkMinBackoff = time.Second
+	sinkMaxBackoff = time.Minute
+
+	// sinkCloseTimeout bounds how long Close waits for buffered records to
+	// be sent.
+	sinkCloseTimeout = 5 * time.Second
+)
+
+// SinkConfig configures a destination that log records are shipped to in
+// addition to the session's log file.
+type SinkConfig struct {
+	// Type is SinkStderr, SinkSyslog, or SinkHTTP.
+	Type string
+	// Address is the syslog server as "udp://host:514", "tcp://host:514",
+	// or "unix:///dev/log". Empty uses the local syslog daemon.
+	Address string
+	// Tag is the syslog tag. Empty uses "claudio".
+	Tag string
+	// URL is the endpoint the http sink posts to.
+	URL string
+	// Format is the http payload: "json" (newline-delimited JSON, the
+	// default) or "loki" (the Loki push API).
+	Format string
+	// Headers are added to each http request.
+	Headers map[string]string
+	// Labels are the Loki stream labels. Empty uses job=claudio.
+	Labels map[string]string
+	// BufferSize is how many records are kept while the sink is
+	// unreachable. Zero uses DefaultSinkBufferSize.
+	BufferSize int
+}
+
+// sink delivers batches of JSON log records, one record per element
+// without its trailing newline.
+type sink interface {
+	send(records [][]byte) error
+	close() error
+}
+
+// newSink creates the sink described by cfg.
+func newSink(cfg SinkConfig) (sink, error) {
+	switch cfg.Type {
+	case SinkStderr:
+		return writerSink{w: os.Stderr}, nil
+	case SinkSyslog:
+		return newSyslogSink(cfg)
+	case SinkHTTP:
+		return newHTTPSink(cfg)
+	default:
+		return nil, fmt.Errorf("unknown log sink type %q", cfg.Type)
+	}
+}
+
+// writerSink writes records as JSON lines to w.
+type writerSink struct {
+	w io.Writer
+}
+
+func (s writerSink) send(records [][]byte) error {
+	var buf bytes.Buffer
+	for _, r := range records {
+		buf.Write(r)
+		buf.WriteByte('\n')
+	}
+	_, err := s.w.Write(buf.Bytes())
+	return err
+}
+
+func (writerSink) close() error { return nil }
+
+// parseSyslogAddress splits a syslog address into the network and address
+// syslog.Dial takes. An empty address means the local syslog daemon.
+func parseSyslogAddress(address string) (network, raddr string, err error) {
+	if address == "" {
+		return "", "", nil
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid syslog address %q: %w", address, err)
+	}
+	switch u.Scheme {
+	case "udp", "tcp":
+		return u.Scheme, u.Host, nil
+	case "unix":
+		return "unixgram", u.Path, nil
+	default:
+		return "", "", fmt.Errorf("syslog address %q must start with udp://, tcp://, or unix://", address)
+	}
+}
+
+// syslogTag returns the tag syslog messages are sent with.
+func syslogTag(cfg SinkConfig) string {
+	if cfg.Tag != "" {
+		return cfg.Tag
+	}
+	return "claudio"
+}
+
+// recordLevel returns the level of a JSON log record, or "" if it has none.
+func recordLevel(record []byte) string {
+	var r struct {
+		Level string `json:"level"`
+	}
+	_ = json.Unmarshal(record, &r)
+	return strings.ToUpper(r.Level)
+}
+
+// SinkWriter buffers the log records written to it and sends them to a
+// sink in the background, so a slow or unreachable destination never
+// blocks logging. Records that fail to send stay buffered and are retried
+// with exponential backoff; when the buffer is full, the oldest records are
+// dropped. It is safe for concurrent use.
+type SinkWriter struct {
+	sink       sink
+	bufferSize int
+
+	mu      sync.Mutex
+	pending [][]byte
+	dropped int
+	closed  bool
+
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewSinkWriter creates the sink described by cfg and starts sending the
+// records written to it. Call Close to send what is buffered and stop.
+func NewSinkWriter(cfg SinkConfig) (*SinkWriter, error) {
+	s, err := newSink(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return newSinkWriter(s, cfg.BufferSize), nil
+}
+
+// newSinkWriter starts a SinkWriter for s.
+func newSinkWriter(s sink, bufferSize int) *SinkWriter {
+	if bufferSize <= 0 {
+		bufferSize = DefaultSinkBufferSize
+	}
+	w := &SinkWriter{
+		sink:       s,
+		bufferSize: bufferSize,
+		wake:       make(chan struct{}, 1),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Write buffers p, one JSON record as written by slog, for sending. It never
+// blocks on the sink and never fails.
+func (w *SinkWriter) Write(p []byte) (int, error) {
+	record := bytes.Clone(bytes.TrimRight(p, "\n"))
+	if len(record) == 0 {
+		return len(p), nil
+	}
+
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return len(p), nil
+	}
+	w.pending = append(w.pending, record)
+	w.trimLocked()
+	full := len(w.pending) >= sinkBatchSize
+	w.mu.Unlock()
+
+	if full {
+		select {
+		case w.wake <- struct{}{}:
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+// Dropped returns how many records were dropped because the buffer was full.
+func (w *SinkWriter) Dropped() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.dropped
+}
+
+// Close sends the buffered records, waiting at most sinkCloseTimeout, and
+// closes the sink. Records written after Close are discarded.
+func (w *SinkWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.mu.Unlock()
+
+	close(w.stop)
+	var err error
+	select {
+	case <-w.done:
+	case <-time.After(sinkCloseTimeout):
+		err = errors.New("timed out sending buffered log records")
+	}
+	return errors.Join(err, w.sink.close())
+}
+
+// run sends buffered records every sinkFlushInterval, or sooner once a full
+// batch is waiting, backing off after a failure. It makes one last attempt
+// when stopped.
+func (w *SinkWriter) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(sinkFlushInterval)
+	defer ticker.Stop()
+
+	var backoff time.Duration
+	var retryAt time.Time
+	for {
+		select {
+		case <-w.stop:
+			_ = w.flush()
+			return
+		case <-ticker.C:
+		case <-w.wake:
+		}
+		if time.Now().Before(retryAt) {
+			continue
+		}
+		if err := w.flush(); err != nil {
+			backoff = min(max(backoff*2, sinkMinBackoff), sinkMaxBackoff)
+			retryAt = time.Now().Add(backoff)
+			continue
+		}
+		backoff = 0
+	}
+}
+
+// flush sends the buffered records in batches until none are left or a
+// batch fails. A failed batch is put back at the front of the buffer.
+func (w *SinkWriter) flush() error {
+	for {
+		w.mu.Lock()
+		n := min(len(w.pending), sinkBatchSize)
+		batch := w.pending[:n:n]
+		w.pending = w.pending[n:]
+		w.mu.Unlock()
+		if n == 0 {
+			return nil
+		}
+
+		if err := w.sink.send(batch); err != nil {
+			w.mu.Lock()
+			w.pending = append(batch, w.pending...)
+			w.trimLocked()
+			w.mu.Unlock()
+			return err
+		}
+	}
+}
+
+// trimLocked drops the oldest records beyond the buffer size. Caller must
+// hold w.mu.
+func (w *SinkWriter) trimLocked() {
+	if over := len(w.pending) - w.bufferSize; over > 0 {
+		w.pending = w.pending[over:]
+		w.dropped += over
+	}
+}
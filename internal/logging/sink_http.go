@@ -0,0 +1,133 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// HTTP sink payload formats.
+const (
+	SinkFormatJSON = "json"
+	SinkFormatLoki = "loki"
+)
+
+// httpSinkTimeout bounds each request of the http sink.
+const httpSinkTimeout = 10 * time.Second
+
+// httpSink posts batches of records to an HTTP endpoint, either as
+// newline-delimited JSON or as a Loki push request.
+type httpSink struct {
+	url     string
+	format  string
+	headers map[string]string
+	labels  map[string]string
+	client  *http.Client
+}
+
+// newHTTPSink creates the http sink described by cfg.
+func newHTTPSink(cfg SinkConfig) (sink, error) {
+	if u, err := url.Parse(cfg.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("log sink url %q is not an http or https URL", cfg.URL)
+	}
+	format := cfg.Format
+	if format == "" {
+		format = SinkFormatJSON
+	}
+	if format != SinkFormatJSON && format != SinkFormatLoki {
+		return nil, fmt.Errorf("unknown log sink format %q", cfg.Format)
+	}
+	labels := cfg.Labels
+	if len(labels) == 0 {
+		labels = map[string]string{"job": "claudio"}
+	}
+	return &httpSink{
+		url:     cfg.URL,
+		format:  format,
+		headers: cfg.Headers,
+		labels:  labels,
+		client:  &http.Client{Timeout: httpSinkTimeout},
+	}, nil
+}
+
+func (s *httpSink) send(records [][]byte) error {
+	var body []byte
+	var contentType string
+	switch s.format {
+	case SinkFormatLoki:
+		var err error
+		if body, err = lokiPush(records, s.labels); err != nil {
+			return err
+		}
+		contentType = "application/json"
+	default:
+		body = append(bytes.Join(records, []byte("\n")), '\n')
+		contentType = "application/x-ndjson"
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		// The URL may carry credentials; keep it out of the error.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("post logs to %s: %w", redactURL(s.url), err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("post logs to %s: %s", redactURL(s.url), resp.Status)
+	}
+	return nil
+}
+
+func (s *httpSink) close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// lokiPush returns a Loki push API request holding records in a single
+// stream with labels. Each record is timestamped with its "time" field.
+func lokiPush(records [][]byte, labels map[string]string) ([]byte, error) {
+	values := make([][2]string, 0, len(records))
+	for _, r := range records {
+		ts := time.Now()
+		if e, err := ParseEntry(r); err == nil && !e.Time.IsZero() {
+			ts = e.Time
+		}
+		values = append(values, [2]string{strconv.FormatInt(ts.UnixNano(), 10), string(r)})
+	}
+	type stream struct {
+		Stream map[string]string `json:"stream"`
+		Values [][2]string       `json:"values"`
+	}
+	return json.Marshal(map[string][]stream{
+		"streams": {{Stream: labels, Values: values}},
+	})
+}
+
+// redactURL returns the scheme and host of raw, leaving out any path,
+// query, or user info that may hold a token.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "log sink"
+	}
+	return u.Scheme + "://" + u.Host
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package logging
+
+import "errors"
+
+// newSyslogSink reports that syslog is not available on this platform.
+func newSyslogSink(SinkConfig) (sink, error) {
+	return nil, errors.New("the syslog log sink is only supported on Unix")
+}
@@ -0,0 +1,61 @@
+//go:build unix
+
+package logging
+
+import (
+	"log/syslog"
+)
+
+// syslogSink sends each record to syslog at the severity of its level.
+// The connection is made on the first send and remade after a failure.
+type syslogSink struct {
+	network string
+	raddr   string
+	tag     string
+	w       *syslog.Writer
+}
+
+// newSyslogSink creates the syslog sink described by cfg.
+func newSyslogSink(cfg SinkConfig) (sink, error) {
+	network, raddr, err := parseSyslogAddress(cfg.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogSink{network: network, raddr: raddr, tag: syslogTag(cfg)}, nil
+}
+
+func (s *syslogSink) send(records [][]byte) error {
+	if s.w == nil {
+		w, err := syslog.Dial(s.network, s.raddr, syslog.LOG_INFO|syslog.LOG_USER, s.tag)
+		if err != nil {
+			return err
+		}
+		s.w = w
+	}
+	for _, r := range records {
+		var err error
+		switch recordLevel(r) {
+		case LevelError:
+			err = s.w.Err(string(r))
+		case LevelWarn:
+			err = s.w.Warning(string(r))
+		case LevelDebug:
+			err = s.w.Debug(string(r))
+		default:
+			err = s.w.Info(string(r))
+		}
+		if err != nil {
+			_ = s.w.Close()
+			s.w = nil
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *syslogSink) close() error {
+	if s.w == nil {
+		return nil
+	}
+	return s.w.Close()
+}
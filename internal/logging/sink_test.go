@@ -0,0 +1,226 @@
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeSink records what it is sent, failing while failures is positive.
+type fakeSink struct {
+	mu       sync.Mutex
+	failures int
+	sent     []string
+	closed   bool
+}
+
+func (f *fakeSink) send(records [][]byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("unreachable")
+	}
+	for _, r := range records {
+		f.sent = append(f.sent, string(r))
+	}
+	return nil
+}
+
+func (f *fakeSink) close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	return nil
+}
+
+func TestSinkWriter_KeepsRecordsUntilSent(t *testing.T) {
+	fake := &fakeSink{failures: 1}
+	// Not started, so the test drives flush itself
+	w := &SinkWriter{sink: fake, bufferSize: 10}
+	for _, r := range []string{`{"msg":"a"}`, `{"msg":"b"}`} {
+		_, _ = w.Write([]byte(r + "\n"))
+	}
+
+	if err := w.flush(); err == nil {
+		t.Fatal("flush() error = nil, want the sink's failure")
+	}
+	_, _ = w.Write([]byte(`{"msg":"c"}` + "\n"))
+	if err := w.flush(); err != nil {
+		t.Fatalf("flush() after recovery error = %v", err)
+	}
+	if got := strings.Join(fake.sent, ","); got != `{"msg":"a"},{"msg":"b"},{"msg":"c"}` {
+		t.Errorf("sent = %s, want every record in order", got)
+	}
+}
+
+func TestSinkWriter_DropsOldestWhenFull(t *testing.T) {
+	fake := &fakeSink{}
+	w := &SinkWriter{sink: fake, bufferSize: 2}
+	for _, r := range []string{"1", "2", "3"} {
+		_, _ = w.Write([]byte(r + "\n"))
+	}
+	if err := w.flush(); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+	if got := strings.Join(fake.sent, ","); got != "2,3" || w.Dropped() != 1 {
+		t.Errorf("sent = %s with %d dropped, want 2,3 with 1 dropped", got, w.Dropped())
+	}
+}
+
+func TestSinkWriter_CloseSendsBuffered(t *testing.T) {
+	fake := &fakeSink{}
+	w := newSinkWriter(fake, 0)
+	_, _ = w.Write([]byte(`{"msg":"last"}` + "\n"))
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if len(fake.sent) != 1 || !fake.closed {
+		t.Errorf("sent = %v, closed = %v; want the record sent and the sink closed", fake.sent, fake.closed)
+	}
+
+	// Written after Close: discarded
+	_, _ = w.Write([]byte(`{"msg":"late"}` + "\n"))
+	if err := w.Close(); err != nil || len(fake.sent) != 1 {
+		t.Errorf("second Close() error = %v, sent = %v", err, fake.sent)
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	records := [][]byte{
+		[]byte(`{"time":"2026-01-02T03:04:05Z","level":"INFO","msg":"one"}`),
+		[]byte(`{"time":"2026-01-02T03:04:06Z","level":"WARN","msg":"two"}`),
+	}
+
+	var body []byte
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	t.Run("json", func(t *testing.T) {
+		s, err := newHTTPSink(SinkConfig{URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer x"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.send(records); err != nil {
+			t.Fatalf("send() error = %v", err)
+		}
+		if want := string(records[0]) + "\n" + string(records[1]) + "\n"; string(body) != want {
+			t.Errorf("body = %q, want %q", body, want)
+		}
+		if header.Get("Authorization") != "Bearer x" || header.Get("Content-Type") != "application/x-ndjson" {
+			t.Errorf("headers = %v", header)
+		}
+	})
+
+	t.Run("loki", func(t *testing.T) {
+		s, err := newHTTPSink(SinkConfig{URL: srv.URL, Format: SinkFormatLoki})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.send(records); err != nil {
+			t.Fatalf("send() error = %v", err)
+		}
+		var push struct {
+			Streams []struct {
+				Stream map[string]string `json:"stream"`
+				Values [][2]string       `json:"values"`
+			} `json:"streams"`
+		}
+		if err := json.Unmarshal(body, &push); err != nil {
+			t.Fatalf("body is not a push request: %v", err)
+		}
+		if len(push.Streams) != 1 || push.Streams[0].Stream["job"] != "claudio" || len(push.Streams[0].Values) != 2 {
+			t.Fatalf("push = %+v", push)
+		}
+		if v := push.Streams[0].Values[0]; v[0] != "1767323045000000000" || v[1] != string(records[0]) {
+			t.Errorf("first value = %v", v)
+		}
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		s, err := newHTTPSink(SinkConfig{URL: srv.URL + "/fail?token=secret"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = s.send(records)
+		if err == nil || !strings.Contains(err.Error(), "503") {
+			t.Fatalf("send() error = %v, want the status", err)
+		}
+		if strings.Contains(err.Error(), "secret") {
+			t.Errorf("error %q leaks the URL's token", err)
+		}
+	})
+
+	if _, err := newHTTPSink(SinkConfig{URL: "localhost:3100"}); err == nil {
+		t.Error("newHTTPSink(relative URL) error = nil")
+	}
+}
+
+func TestParseSyslogAddress(t *testing.T) {
+	tests := []struct {
+		address, network, raddr string
+		wantErr                 bool
+	}{
+		{"", "", "", false},
+		{"udp://logs.example.com:514", "udp", "logs.example.com:514", false},
+		{"tcp://10.0.0.1:601", "tcp", "10.0.0.1:601", false},
+		{"unix:///dev/log", "unixgram", "/dev/log", false},
+		{"logs.example.com:514", "", "", true},
+	}
+	for _, tt := range tests {
+		network, raddr, err := parseSyslogAddress(tt.address)
+		if (err != nil) != tt.wantErr || network != tt.network || raddr != tt.raddr {
+			t.Errorf("parseSyslogAddress(%q) = %q, %q, %v", tt.address, network, raddr, err)
+		}
+	}
+}
+
+func TestNewLoggerWithRotation_Sinks(t *testing.T) {
+	var mu sync.Mutex
+	var received string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received += string(body)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	logger, err := NewLoggerWithRotation(dir, "INFO", DefaultRotationConfig(), SinkConfig{Type: SinkHTTP, URL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewLoggerWithRotation() error = %v", err)
+	}
+	logger.WithInstance("a1").Info("shipped", "n", 1)
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !strings.Contains(received, `"msg":"shipped"`) || !strings.Contains(received, `"instance_id":"a1"`) {
+		t.Errorf("sink received %q", received)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, LogFileName))
+	if err != nil || !strings.Contains(string(data), `"msg":"shipped"`) {
+		t.Errorf("log file = %q, %v; want the record there too", data, err)
+	}
+
+	if _, err := NewLoggerWithRotation(dir, "INFO", DefaultRotationConfig(), SinkConfig{Type: "kafka"}); err == nil {
+		t.Error("NewLoggerWithRotation(unknown sink) error = nil")
+	}
+}
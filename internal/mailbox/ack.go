@@ -0,0 +1,236 @@
+package mailbox
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ackedFile is the append-only log of which instances have consumed which
+// messages. It lives beside the recipient directories.
+const ackedFile = "acked.jsonl"
+
+// ackRecord is one line of the acknowledgement log.
+type ackRecord struct {
+	ID         string    `json:"id"`
+	InstanceID string    `json:"instance_id"`
+	AckedAt    time.Time `json:"acked_at"`
+}
+
+// CompactResult reports what Compact removed.
+type CompactResult struct {
+	// Expired is the number of messages dropped because their TTL passed.
+	Expired int
+
+	// Acked is the number of messages dropped because every recipient
+	// acknowledged them.
+	Acked int
+}
+
+// Ack records that instanceID has consumed messageID. Acknowledging a
+// message twice, or an unknown ID, is not an error.
+func (s *Store) Ack(instanceID, messageID string) error {
+	if instanceID == "" {
+		return fmt.Errorf("mailbox: instanceID is required")
+	}
+	if messageID == "" {
+		return fmt.Errorf("mailbox: messageID is required")
+	}
+
+	dir := filepath.Join(s.sessionDir, mailboxDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("mailbox: create directory: %w", err)
+	}
+
+	data, err := json.Marshal(ackRecord{ID: messageID, InstanceID: instanceID, AckedAt: time.Now()})
+	if err != nil {
+		return fmt.Errorf("mailbox: marshal ack: %w", err)
+	}
+	data = append(data, '\n')
+
+	return s.atomicAppend(filepath.Join(dir, ackedFile), data)
+}
+
+// Acks returns, for each acknowledged message ID, the set of instances that
+// acknowledged it. Returns an empty map (not error) if nothing has been
+// acknowledged.
+func (s *Store) Acks() (map[string]map[string]bool, error) {
+	records, err := s.readAcks()
+	if err != nil {
+		return nil, err
+	}
+	return ackSets(records), nil
+}
+
+// Compact rewrites every index.jsonl without the messages that no longer
+// need to be kept: those expired as of now, and those acknowledged by
+// every recipient. A targeted message is fully acknowledged once its
+// recipient has acknowledged it. A broadcast is fully acknowledged once
+// every known instance other than its sender has, where the known
+// instances are those with a mailbox directory or an acknowledgement on
+// record. Acknowledgements of dropped messages are removed from the log.
+//
+// Compact holds the store lock throughout, so concurrent Sends in this
+// process wait for it; appends from other processes during a compaction
+// may be lost.
+func (s *Store) Compact(now time.Time) (CompactResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result CompactResult
+	root := filepath.Join(s.sessionDir, mailboxDir)
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return result, nil
+		}
+		return result, fmt.Errorf("mailbox: list mailboxes: %w", err)
+	}
+
+	records, err := s.readAcks()
+	if err != nil {
+		return result, err
+	}
+	acks := ackSets(records)
+
+	instances := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != BroadcastRecipient {
+			instances[entry.Name()] = true
+		}
+	}
+	for _, rec := range records {
+		instances[rec.InstanceID] = true
+	}
+
+	dropped := make(map[string]bool)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := s.dirForRecipient(entry.Name())
+		messages, err := s.readIndex(dir)
+		if err != nil {
+			return result, err
+		}
+
+		kept := make([]Message, 0, len(messages))
+		for _, msg := range messages {
+			switch {
+			case msg.Expired(now):
+				result.Expired++
+			case fullyAcked(msg, acks[msg.ID], instances):
+				result.Acked++
+			default:
+				kept = append(kept, msg)
+				continue
+			}
+			dropped[msg.ID] = true
+		}
+		if len(kept) == len(messages) {
+			continue
+		}
+		if err := rewriteJSONL(filepath.Join(dir, indexFile), kept); err != nil {
+			return result, err
+		}
+	}
+
+	if len(dropped) > 0 && len(records) > 0 {
+		var keptAcks []ackRecord
+		for _, rec := range records {
+			if !dropped[rec.ID] {
+				keptAcks = append(keptAcks, rec)
+			}
+		}
+		if len(keptAcks) != len(records) {
+			if err := rewriteJSONL(filepath.Join(root, ackedFile), keptAcks); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// fullyAcked reports whether every recipient of msg is in ackedBy.
+func fullyAcked(msg Message, ackedBy, instances map[string]bool) bool {
+	if len(ackedBy) == 0 {
+		return false
+	}
+	if !msg.IsBroadcast() {
+		return ackedBy[msg.To]
+	}
+	for id := range instances {
+		if id != msg.From && !ackedBy[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// ackSets groups acknowledgement records by message ID.
+func ackSets(records []ackRecord) map[string]map[string]bool {
+	acks := make(map[string]map[string]bool)
+	for _, rec := range records {
+		if acks[rec.ID] == nil {
+			acks[rec.ID] = make(map[string]bool)
+		}
+		acks[rec.ID][rec.InstanceID] = true
+	}
+	return acks
+}
+
+// readAcks reads the acknowledgement log, skipping malformed lines.
+func (s *Store) readAcks() ([]ackRecord, error) {
+	f, err := os.Open(filepath.Join(s.sessionDir, mailboxDir, ackedFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("mailbox: open ack log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var records []ackRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec ackRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil || rec.ID == "" || rec.InstanceID == "" {
+			continue
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("mailbox: scan ack log: %w", err)
+	}
+
+	return records, nil
+}
+
+// rewriteJSONL replaces path with one JSON line per value, via a temporary
+// file and rename so readers never see a partial file.
+func rewriteJSONL[T any](path string, values []T) error {
+	var buf bytes.Buffer
+	for _, v := range values {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("mailbox: marshal for compaction: %w", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("mailbox: write compacted file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("mailbox: replace compacted file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package mailbox
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStore_SendSetsExpiryFromTTL(t *testing.T) {
+	store := NewStore(t.TempDir())
+	sent := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if err := store.Send(Message{From: "inst-1", To: "inst-2", Type: MessageStatus, Timestamp: sent, TTL: time.Hour}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if err := store.Send(Message{From: "inst-1", To: "inst-2", Type: MessageStatus, Timestamp: sent}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	messages, err := store.ReadForInstance("inst-2")
+	if err != nil {
+		t.Fatalf("ReadForInstance() error = %v", err)
+	}
+	if want := sent.Add(time.Hour); !messages[0].ExpiresAt.Equal(want) {
+		t.Errorf("ExpiresAt = %v, want %v", messages[0].ExpiresAt, want)
+	}
+	if !messages[1].ExpiresAt.IsZero() {
+		t.Errorf("ExpiresAt without TTL = %v, want zero", messages[1].ExpiresAt)
+	}
+	if messages[0].Expired(sent.Add(59*time.Minute)) || !messages[0].Expired(sent.Add(time.Hour)) {
+		t.Error("Expired() should flip exactly at ExpiresAt")
+	}
+}
+
+func TestMailbox_ReceiveSkipsExpired(t *testing.T) {
+	mb := NewMailbox(t.TempDir())
+	now := time.Now()
+	mb.now = func() time.Time { return now }
+
+	for _, ttl := range []time.Duration{0, time.Minute} {
+		if err := mb.Send(Message{From: "inst-1", To: "inst-2", Type: MessageStatus, Timestamp: now, TTL: ttl}); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	if got, _ := mb.Receive("inst-2"); len(got) != 2 {
+		t.Fatalf("Receive() before expiry = %d messages, want 2", len(got))
+	}
+
+	now = now.Add(time.Minute)
+	got, err := mb.Receive("inst-2")
+	if err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+	if len(got) != 1 || !got[0].ExpiresAt.IsZero() {
+		t.Errorf("Receive() after expiry = %+v, want only the message without a TTL", got)
+	}
+}
+
+func TestMailbox_AckAndUnacked(t *testing.T) {
+	mb := NewMailbox(t.TempDir())
+	for _, to := range []string{"inst-2", BroadcastRecipient} {
+		if err := mb.Send(Message{ID: "to-" + to, From: "inst-1", To: to, Type: MessageDiscovery}); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	if err := mb.Ack("inst-2", "to-inst-2"); err != nil {
+		t.Fatalf("Ack() error = %v", err)
+	}
+	if err := mb.Ack("inst-2", "to-inst-2"); err != nil {
+		t.Fatalf("repeated Ack() error = %v", err)
+	}
+
+	unacked, err := mb.Unacked("inst-2")
+	if err != nil {
+		t.Fatalf("Unacked() error = %v", err)
+	}
+	if len(unacked) != 1 || unacked[0].ID != "to-broadcast" {
+		t.Errorf("Unacked(inst-2) = %+v, want only the broadcast", unacked)
+	}
+	if unacked, _ := mb.Unacked("inst-3"); len(unacked) != 1 {
+		t.Errorf("Unacked(inst-3) = %d messages, want 1", len(unacked))
+	}
+
+	acks, err := mb.store.Acks()
+	if err != nil {
+		t.Fatalf("Acks() error = %v", err)
+	}
+	if !acks["to-inst-2"]["inst-2"] || len(acks["to-inst-2"]) != 1 {
+		t.Errorf("Acks()[to-inst-2] = %v, want {inst-2}", acks["to-inst-2"])
+	}
+}
+
+func TestStore_AckValidation(t *testing.T) {
+	store := NewStore(t.TempDir())
+	if err := store.Ack("", "msg-1"); err == nil {
+		t.Error("Ack() with empty instanceID should error")
+	}
+	if err := store.Ack("inst-1", ""); err == nil {
+		t.Error("Ack() with empty messageID should error")
+	}
+}
+
+func TestMailbox_Compact(t *testing.T) {
+	dir := t.TempDir()
+	mb := NewMailbox(dir)
+	now := time.Now()
+	mb.now = func() time.Time { return now }
+
+	send := func(id, from, to string, ttl time.Duration) {
+		t.Helper()
+		if err := mb.Send(Message{ID: id, From: from, To: to, Type: MessageStatus, Timestamp: now, TTL: ttl}); err != nil {
+			t.Fatalf("Send(%s) error = %v", id, err)
+		}
+	}
+	send("expired", "inst-1", "inst-2", time.Minute)
+	send("acked", "inst-1", "inst-2", 0)
+	send("unacked", "inst-1", "inst-2", 0)
+	send("to-inst-3", "inst-1", "inst-3", 0)
+	send("bc-all", "inst-1", BroadcastRecipient, 0)
+	send("bc-partial", "inst-1", BroadcastRecipient, 0)
+
+	ack := func(instanceID, messageID string) {
+		t.Helper()
+		if err := mb.Ack(instanceID, messageID); err != nil {
+			t.Fatalf("Ack() error = %v", err)
+		}
+	}
+	ack("inst-3", "acked") // Not its recipient
+	ack("inst-2", "acked")
+	ack("inst-2", "bc-all")
+	ack("inst-3", "bc-all")
+	ack("inst-2", "bc-partial")
+	ack("inst-2", "unacked-elsewhere")
+
+	now = now.Add(time.Minute)
+	result, err := mb.Compact()
+	if err != nil {
+		t.Fatalf("Compact() error = %v", err)
+	}
+	if result.Expired != 1 || result.Acked != 2 {
+		t.Errorf("Compact() = %+v, want 1 expired and 2 acked", result)
+	}
+
+	all, err := mb.store.ReadEveryMailbox()
+	if err != nil {
+		t.Fatalf("ReadEveryMailbox() error = %v", err)
+	}
+	var ids []string
+	for _, msg := range all {
+		ids = append(ids, msg.ID)
+	}
+	slices.Sort(ids)
+	if got := strings.Join(ids, ","); got != "bc-partial,to-inst-3,unacked" {
+		t.Errorf("kept messages = %s, want bc-partial,to-inst-3,unacked", got)
+	}
+
+	acks, err := mb.store.Acks()
+	if err != nil {
+		t.Fatalf("Acks() error = %v", err)
+	}
+	if len(acks) != 2 || !acks["bc-partial"]["inst-2"] || !acks["unacked-elsewhere"]["inst-2"] {
+		t.Errorf("Acks() after compaction = %v, want only bc-partial and unacked-elsewhere", acks)
+	}
+	if _, err := os.Stat(filepath.Join(dir, mailboxDir, "inst-2", indexFile+".tmp")); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+
+	// A second compaction has nothing left to drop.
+	if result, err := mb.Compact(); err != nil || result != (CompactResult{}) {
+		t.Errorf("second Compact() = %+v, %v; want nothing dropped", result, err)
+	}
+}
+
+func TestMailbox_Compact_Empty(t *testing.T) {
+	mb := NewMailbox(t.TempDir())
+	if result, err := mb.Compact(); err != nil || result != (CompactResult{}) {
+		t.Errorf("Compact() on empty session = %+v, %v", result, err)
+	}
+}
+
+func TestMailbox_Watch_AfterCompact(t *testing.T) {
+	mb := NewMailbox(t.TempDir())
+	mb.SetPollInterval(20 * time.Millisecond)
+
+	for _, id := range []string{"old-1", "old-2"} {
+		if err := mb.Send(Message{ID: id, From: "inst-1", To: "inst-2", Type: MessageStatus}); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	var mu sync.Mutex
+	var received []string
+	cancel := mb.Watch("inst-2", func(msg Message) {
+		mu.Lock()
+		received = append(received, msg.ID)
+		mu.Unlock()
+	})
+	defer cancel()
+
+	// Compacting away both old messages leaves the index shorter than the
+	// watcher's snapshot; the new message must still be delivered.
+	_ = mb.Ack("inst-2", "old-1")
+	_ = mb.Ack("inst-2", "old-2")
+	if _, err := mb.Compact(); err != nil {
+		t.Fatalf("Compact() error = %v", err)
+	}
+	if err := mb.Send(Message{ID: "new", From: "inst-1", To: "inst-2", Type: MessageStatus}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 1 || received[0] != "new" {
+		t.Errorf("watched messages = %v, want [new]", received)
+	}
+}
@@ -15,6 +15,8 @@
 //	.claudio/mailbox/{sessionID}/
 //	    broadcast/index.jsonl    -- messages to all instances
 //	    {instanceID}/index.jsonl -- messages to a specific instance
+//	    released.jsonl           -- IDs of held messages approved for injection
+//	    acked.jsonl              -- which instances consumed which messages
 //
 // # Main Types
 //
@@ -32,6 +34,8 @@
 //   - [MessageQuestion]: Request help from other instances
 //   - [MessageAnswer]: Respond to a question
 //   - [MessageStatus]: Provide a progress update
+//   - [MessageDigest]: Summary of a sender's throttled messages
+//   - [MessageTaskProposal]: Follow-up task proposed to the coordinator (see [TaskProposal])
 //
 // # Basic Usage
 //
@@ -55,6 +59,45 @@
 //	})
 //	defer cancel()
 //
+// # Prompt-Injection Guard
+//
+// Messages are written by other instances, so their bodies are treated as
+// untrusted when they are injected into a prompt. [FormatForPrompt] wraps
+// each body in a <message-data> block under an explicit "data, not
+// instructions" notice and escapes framing tags inside bodies. [WithGuard]
+// additionally screens bodies on Send with [Inspect]: flagged messages record
+// the matched pattern names in Message.Flags, publish a
+// MailboxMessageFlaggedEvent, and are either stripped of instruction-like
+// lines or held out of prompts until [Mailbox.Release], per [GuardPolicy].
+//
+// # Bandwidth Limits
+//
+// [WithRateLimit] caps message bodies and limits how many messages each
+// sender delivers per window, per [RateLimit]. Messages over the rate are
+// not dropped: they are collected per sender and recipient and delivered as
+// one [MessageDigest] once the window has passed. Due digests are delivered
+// on the next Send or Receive; [Mailbox.FlushDigests] delivers all of them.
+//
+// # Export and Forwarding
+//
+// [Mailbox.Export] returns a session's messages across every mailbox,
+// filtered by [FilterOptions] and without held messages; [WriteExport] and
+// [ReadExport] persist them as an [Export]. [Mailbox.Forward] delivers
+// exported messages into another session as broadcasts with new IDs, each
+// recording its origin in Message.Forwarded as a [Provenance]. Forwarding is
+// idempotent per source message. [FindSessionDirs] locates the session
+// directories below a root, since pipeline runs keep one per team.
+//
+// # Expiry, Acknowledgement, and Compaction
+//
+// A message sent with a positive TTL records ExpiresAt and is no longer
+// returned by [Mailbox.Receive] once it passes. [Mailbox.Ack] records in
+// acked.jsonl that an instance has consumed a message, and
+// [Mailbox.Unacked] returns only the messages an instance has not
+// acknowledged. [Mailbox.Compact] rewrites each index.jsonl without
+// expired messages and messages every recipient has acknowledged, so a
+// long session's logs stop growing without bound.
+//
 // # Thread Safety
 //
 // The [Store] and [Mailbox] types are safe for concurrent use within a single
@@ -6,3 +6,9 @@ import "github.com/Iron-Ham/claudio/internal/event"
 func NewMailboxMessageEvent(msg Message) event.MailboxMessageEvent {
 	return event.NewMailboxMessageEvent(msg.From, msg.To, string(msg.Type), msg.Body)
 }
+
+// NewMailboxMessageFlaggedEvent creates an event.MailboxMessageFlaggedEvent
+// for a message the guard flagged in the mailbox rooted at sessionDir.
+func NewMailboxMessageFlaggedEvent(sessionDir string, msg Message) event.MailboxMessageFlaggedEvent {
+	return event.NewMailboxMessageFlaggedEvent(msg.ID, msg.From, msg.To, string(msg.Type), msg.Flags, msg.Held, sessionDir)
+}
@@ -0,0 +1,105 @@
+package mailbox
+
+import (
+	"regexp"
+	"strings"
+)
+
+// GuardPolicy controls how a Mailbox treats message bodies that look like
+// instructions aimed at the receiving instance rather than data for it.
+//
+// Every message that reaches another instance's prompt was written by a
+// different instance (or copied from a file in its worktree), so a confused
+// or compromised sender could otherwise steer its peers. Flagged messages
+// always carry their matched pattern names in Message.Flags and produce a
+// MailboxMessageFlaggedEvent; the policy decides what else happens to them.
+type GuardPolicy struct {
+	// StripInstructions replaces each instruction-like line of a flagged
+	// body with a short marker before the message is stored.
+	StripInstructions bool
+
+	// HoldSuspicious stores flagged messages unmodified but marks them held.
+	// Held messages are left out of prompts until Release is called, so a
+	// human can review the original text first. Takes precedence over
+	// StripInstructions.
+	HoldSuspicious bool
+}
+
+// DefaultGuardPolicy returns the policy used by coordination hubs: flagged
+// lines are stripped and the message is still delivered.
+func DefaultGuardPolicy() GuardPolicy {
+	return GuardPolicy{StripInstructions: true}
+}
+
+// guardPattern is a named, line-oriented heuristic for instruction-like text.
+type guardPattern struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// guardPatterns are matched against each line of a message body. They are
+// deliberately narrow: false positives cost a stripped line in a discovery,
+// while misses are still contained by the data-block framing in FormatForPrompt.
+var guardPatterns = []guardPattern{
+	{"override", regexp.MustCompile(`(?i)\b(ignore|disregard|forget|override)\b.{0,40}\b(previous|prior|above|earlier|all|your)\b.{0,20}\b(instructions?|prompts?|rules|guidelines|directions)\b`)},
+	{"role", regexp.MustCompile(`(?im)^\s*(system|assistant|developer)\s*:`)},
+	{"persona", regexp.MustCompile(`(?i)\b(you are now|from now on,? you|act as if you)\b`)},
+	{"directive", regexp.MustCompile(`(?i)\b(new|updated|real|actual)\s+instructions?\s*:`)},
+	{"markup", regexp.MustCompile(`(?i)</?\s*(system|instructions?|mailbox-messages|message-data)\b`)},
+	{"shell", regexp.MustCompile(`(?i)(\b(curl|wget)\b[^|\n]*\|\s*(ba|z)?sh\b|\brm\s+-rf\s+(/|~)(\s|$))`)},
+	{"secrecy", regexp.MustCompile(`(?i)\b(do not|don't|never)\s+(tell|inform|mention|report)\b.{0,30}\b(user|human|coordinator|operator)\b`)},
+}
+
+// Inspect returns the names of the guard patterns that match body, in
+// pattern order and without duplicates. A nil result means the body looks
+// like plain data.
+func Inspect(body string) []string {
+	var flags []string
+	for _, p := range guardPatterns {
+		if p.re.MatchString(body) {
+			flags = append(flags, p.name)
+		}
+	}
+	return flags
+}
+
+// StripInstructions replaces each line of body that matches a guard pattern
+// with a marker naming the pattern, leaving the remaining lines intact so
+// the receiving instance still sees the legitimate part of the message.
+func StripInstructions(body string) string {
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		for _, p := range guardPatterns {
+			if p.re.MatchString(line) {
+				lines[i] = "[line removed by message guard: " + p.name + "]"
+				break
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// apply runs the policy over msg and reports whether it was flagged.
+func (p GuardPolicy) apply(msg *Message) bool {
+	flags := Inspect(msg.Body)
+	if len(flags) == 0 {
+		return false
+	}
+	msg.Flags = flags
+	switch {
+	case p.HoldSuspicious:
+		msg.Held = true
+	case p.StripInstructions:
+		msg.Body = StripInstructions(msg.Body)
+	}
+	return true
+}
+
+// dataDelimiter matches the tags FormatForPrompt uses to frame messages, so
+// a body cannot close its own data block and continue as prompt text.
+var dataDelimiter = regexp.MustCompile(`(?i)<(/?)\s*(mailbox-messages|message-data)\s*>`)
+
+// escapeDelimiters neutralizes framing tags inside a message body.
+func escapeDelimiters(s string) string {
+	return dataDelimiter.ReplaceAllString(s, "[${1}${2}]")
+}
@@ -0,0 +1,190 @@
+package mailbox
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// digestEntryMaxBytes caps how much of each throttled body a digest keeps.
+const digestEntryMaxBytes = 200
+
+// RateLimit bounds how much one instance can put into other instances'
+// mailboxes, so a chatty sender doesn't flood everyone's prompts.
+//
+// Messages over the per-sender rate are not dropped: they are held back and
+// delivered as a single MessageDigest per sender and recipient once the
+// window that collected them has passed.
+type RateLimit struct {
+	// MaxMessages is how many rate-limited messages each sender may deliver
+	// per Window. Zero disables rate limiting.
+	MaxMessages int
+
+	// Window is the period MaxMessages applies to, and how long a digest
+	// collects throttled messages before it is delivered.
+	Window time.Duration
+
+	// MaxBodyBytes caps every message body; longer bodies are cut and marked
+	// with the number of bytes removed. Zero disables the cap.
+	MaxBodyBytes int
+
+	// Types lists the message types subject to MaxMessages. Empty means
+	// every type. The size cap applies to all types.
+	Types []MessageType
+}
+
+// DefaultRateLimit returns the limit used by coordination hubs: at most six
+// discovery or status messages per sender per minute, and bodies of at most
+// 2 KiB. Claims, warnings, and questions are never throttled.
+func DefaultRateLimit() RateLimit {
+	return RateLimit{
+		MaxMessages:  6,
+		Window:       time.Minute,
+		MaxBodyBytes: 2048,
+		Types:        []MessageType{MessageDiscovery, MessageStatus},
+	}
+}
+
+// limits reports whether t is subject to MaxMessages.
+func (l RateLimit) limits(t MessageType) bool {
+	return l.MaxMessages > 0 && (len(l.Types) == 0 || slices.Contains(l.Types, t))
+}
+
+// capBody truncates body to max bytes on a rune boundary and notes how much
+// was removed. A max of zero or less leaves body unchanged.
+func capBody(body string, max int) string {
+	if max <= 0 || len(body) <= max {
+		return body
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return body[:cut] + fmt.Sprintf("\n[truncated: %d more bytes]", len(body)-cut)
+}
+
+// shorten truncates a single line to max bytes on a rune boundary, marking
+// the cut with an ellipsis.
+func shorten(line string, max int) string {
+	if len(line) <= max {
+		return line
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(line[cut]) {
+		cut--
+	}
+	return line[:cut] + "..."
+}
+
+// digestKey identifies the digest a throttled message is collected into.
+type digestKey struct {
+	from, to string
+}
+
+// pendingDigest holds throttled messages until their window has passed.
+type pendingDigest struct {
+	since    time.Time
+	messages []Message
+}
+
+// limiter tracks per-sender send times and collects throttled messages.
+type limiter struct {
+	limit RateLimit
+
+	mu      sync.Mutex
+	sent    map[string][]time.Time // Delivery times of limited messages in the current window, by sender
+	pending map[digestKey]*pendingDigest
+}
+
+func newLimiter(limit RateLimit) *limiter {
+	if limit.Window <= 0 {
+		limit.Window = time.Minute
+	}
+	return &limiter{
+		limit:   limit,
+		sent:    make(map[string][]time.Time),
+		pending: make(map[digestKey]*pendingDigest),
+	}
+}
+
+// admit reports whether msg may be delivered now. A message over its
+// sender's rate is added to the pending digest for its sender and recipient.
+func (l *limiter) admit(msg Message, now time.Time) bool {
+	if !l.limit.limits(msg.Type) {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	cutoff := now.Add(-l.limit.Window)
+	recent := l.sent[msg.From][:0]
+	for _, t := range l.sent[msg.From] {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) < l.limit.MaxMessages {
+		l.sent[msg.From] = append(recent, now)
+		return true
+	}
+	l.sent[msg.From] = recent
+
+	key := digestKey{from: msg.From, to: msg.To}
+	d, ok := l.pending[key]
+	if !ok {
+		d = &pendingDigest{since: now}
+		l.pending[key] = d
+	}
+	d.messages = append(d.messages, msg)
+	return false
+}
+
+// due removes and returns the digests whose window has passed, or every
+// pending digest when all is true, ordered by when they started collecting.
+func (l *limiter) due(now time.Time, all bool) []Message {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	type ready struct {
+		since time.Time
+		msg   Message
+	}
+	var out []ready
+	for key, d := range l.pending {
+		if !all && now.Sub(d.since) < l.limit.Window {
+			continue
+		}
+		delete(l.pending, key)
+		out = append(out, ready{d.since, l.digest(key, d.messages, now)})
+	}
+	slices.SortFunc(out, func(a, b ready) int { return a.since.Compare(b.since) })
+
+	messages := make([]Message, len(out))
+	for i, r := range out {
+		messages[i] = r.msg
+	}
+	return messages
+}
+
+// digest builds the message that replaces a sender's throttled messages.
+func (l *limiter) digest(key digestKey, throttled []Message, now time.Time) Message {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d messages from %s were throttled (limit: %d per %s) and are summarized here:",
+		len(throttled), key.from, l.limit.MaxMessages, l.limit.Window)
+	for _, msg := range throttled {
+		line := strings.Join(strings.Fields(msg.Body), " ")
+		fmt.Fprintf(&b, "\n- [%s] %s", msg.Type, shorten(line, digestEntryMaxBytes))
+	}
+	return Message{
+		From:      key.from,
+		To:        key.to,
+		Type:      MessageDigest,
+		Body:      capBody(b.String(), l.limit.MaxBodyBytes),
+		Timestamp: now,
+		Metadata:  map[string]any{"throttled": len(throttled)},
+	}
+}
@@ -19,7 +19,10 @@ const (
 type Mailbox struct {
 	store        *Store
 	bus          *event.Bus
+	guard        *GuardPolicy
+	limiter      *limiter
 	pollInterval time.Duration
+	now          func() time.Time
 }
 
 // NewMailbox creates a Mailbox backed by a file store in the given session directory.
@@ -27,6 +30,7 @@ func NewMailbox(sessionDir string, opts ...Option) *Mailbox {
 	m := &Mailbox{
 		store:        NewStore(sessionDir),
 		pollInterval: defaultPollInterval,
+		now:          time.Now,
 	}
 	for _, opt := range opts {
 		opt(m)
@@ -43,22 +47,187 @@ func (m *Mailbox) SetPollInterval(d time.Duration) {
 }
 
 // Send delivers a message to the store. It populates the ID and Timestamp
-// fields if they are empty.
+// fields if they are empty. When a rate limit is configured (see
+// WithRateLimit), oversized bodies are truncated and messages over the
+// sender's rate are collected into a later digest instead of being stored
+// now. When a guard is configured (see WithGuard), the body is screened
+// before it is stored and flagged messages are stripped or held per policy.
 func (m *Mailbox) Send(msg Message) error {
+	if m.limiter == nil {
+		return m.deliver(msg)
+	}
+	if err := msg.validate(); err != nil {
+		return err
+	}
+
+	now := m.now()
+	if err := m.deliverDigests(now, false); err != nil {
+		return err
+	}
+	if msg.Timestamp.IsZero() {
+		msg.Timestamp = now
+	}
+	msg.Body = capBody(msg.Body, m.limiter.limit.MaxBodyBytes)
+	if !m.limiter.admit(msg, now) {
+		return nil
+	}
+	return m.deliver(msg)
+}
+
+// FlushDigests delivers every pending digest of throttled messages, even if
+// its window has not passed yet. Call it before shutting down so throttled
+// messages are not lost.
+func (m *Mailbox) FlushDigests() error {
+	if m.limiter == nil {
+		return nil
+	}
+	return m.deliverDigests(m.now(), true)
+}
+
+// deliverDigests delivers the digests that are due, or all of them.
+func (m *Mailbox) deliverDigests(now time.Time, all bool) error {
+	for _, digest := range m.limiter.due(now, all) {
+		if err := m.deliver(digest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliver screens msg with the guard, stores it, and publishes its events.
+func (m *Mailbox) deliver(msg Message) error {
+	flagged := false
+	if m.guard != nil {
+		if msg.ID == "" {
+			// Assign the ID here so the flagged event can reference it.
+			msg.ID = generateID()
+		}
+		flagged = m.guard.apply(&msg)
+	}
 	if err := m.store.Send(msg); err != nil {
 		return err
 	}
 	if m.bus != nil {
 		m.bus.Publish(NewMailboxMessageEvent(msg))
+		if flagged {
+			m.bus.Publish(NewMailboxMessageFlaggedEvent(m.store.sessionDir, msg))
+		}
 	}
 	return nil
 }
 
 // Receive returns all messages for the given instance, including both
 // broadcast messages and messages addressed directly to the instance.
-// Messages are sorted chronologically by timestamp.
+// Messages are sorted chronologically by timestamp. Held messages that have
+// since been released are returned with Held cleared, and expired messages
+// are left out. Digests whose window has passed are delivered first, so
+// readers see throttled messages without waiting for the sender's next Send.
 func (m *Mailbox) Receive(instanceID string) ([]Message, error) {
-	return m.store.ReadAll(instanceID)
+	now := m.now()
+	if m.limiter != nil {
+		if err := m.deliverDigests(now, false); err != nil {
+			return nil, err
+		}
+	}
+	messages, err := m.store.ReadAll(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	return m.applyReleases(withoutExpired(messages, now))
+}
+
+// Unacked returns the messages Receive would return for the given instance
+// that it has not acknowledged yet.
+func (m *Mailbox) Unacked(instanceID string) ([]Message, error) {
+	messages, err := m.Receive(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	acks, err := m.store.Acks()
+	if err != nil {
+		return nil, err
+	}
+	unacked := messages[:0]
+	for _, msg := range messages {
+		if !acks[msg.ID][instanceID] {
+			unacked = append(unacked, msg)
+		}
+	}
+	return unacked, nil
+}
+
+// Ack records that instanceID has consumed messageID. Once every recipient
+// has acknowledged a message, Compact drops it.
+func (m *Mailbox) Ack(instanceID, messageID string) error {
+	return m.store.Ack(instanceID, messageID)
+}
+
+// Compact rewrites the session's mailboxes without expired and fully
+// acknowledged messages. See Store.Compact.
+func (m *Mailbox) Compact() (CompactResult, error) {
+	return m.store.Compact(m.now())
+}
+
+// withoutExpired filters out the messages expired as of now, in place.
+func withoutExpired(messages []Message, now time.Time) []Message {
+	live := messages[:0]
+	for _, msg := range messages {
+		if !msg.Expired(now) {
+			live = append(live, msg)
+		}
+	}
+	return live
+}
+
+// Held returns every message, across all mailboxes, that is still awaiting
+// review. Messages are sorted chronologically by timestamp.
+func (m *Mailbox) Held() ([]Message, error) {
+	messages, err := m.store.ReadEveryMailbox()
+	if err != nil {
+		return nil, err
+	}
+	messages, err = m.applyReleases(withoutExpired(messages, m.now()))
+	if err != nil {
+		return nil, err
+	}
+	var held []Message
+	for _, msg := range messages {
+		if msg.Held {
+			held = append(held, msg)
+		}
+	}
+	return held, nil
+}
+
+// Release approves a held message so that it is included in prompts from
+// now on. Releasing an unknown or already-released ID is not an error.
+func (m *Mailbox) Release(messageID string) error {
+	return m.store.Release(messageID)
+}
+
+// applyReleases clears Held on messages whose IDs have been released.
+func (m *Mailbox) applyReleases(messages []Message) ([]Message, error) {
+	anyHeld := false
+	for _, msg := range messages {
+		if msg.Held {
+			anyHeld = true
+			break
+		}
+	}
+	if !anyHeld {
+		return messages, nil
+	}
+
+	released, err := m.store.ReleasedIDs()
+	if err != nil {
+		return nil, err
+	}
+	for i := range messages {
+		if messages[i].Held && released[messages[i].ID] {
+			messages[i].Held = false
+		}
+	}
+	return messages, nil
 }
 
 // maxWatchErrors is the number of consecutive Receive errors before the
@@ -70,20 +239,22 @@ const maxWatchErrors = 5
 // It returns a cancel function that stops the watcher. The watcher runs in a
 // separate goroutine. Messages are delivered in chronological order.
 //
-// The watcher tracks the count of previously seen messages and only delivers
-// messages that appear after the initial snapshot.
+// The watcher tracks the IDs of messages it has seen and only delivers
+// messages that appear after the initial snapshot, so expiry and Compact
+// removing older messages do not cause new ones to be skipped.
 func (m *Mailbox) Watch(instanceID string, handler func(Message)) (cancel func()) {
 	var stopped atomic.Bool
 	var wg sync.WaitGroup
 
 	// Take the initial snapshot synchronously so that any Send() after
-	// Watch() returns is guaranteed to be seen by the poller.
-	seen, err := m.countMessages(instanceID)
-	if err != nil {
-		// If the initial snapshot fails, start from 0 so we don't miss
-		// messages. This may re-deliver existing messages but is safer
-		// than silently skipping them.
-		seen = 0
+	// Watch() returns is guaranteed to be seen by the poller. If it fails,
+	// start from nothing so we don't miss messages. This may re-deliver
+	// existing messages but is safer than silently skipping them.
+	seen := make(map[string]bool)
+	if messages, err := m.Receive(instanceID); err == nil {
+		for _, msg := range messages {
+			seen[msg.ID] = true
+		}
 	}
 
 	wg.Go(func() {
@@ -106,11 +277,11 @@ func (m *Mailbox) Watch(instanceID string, handler func(Message)) (cancel func()
 			}
 			consecutiveErrors = 0
 
-			if len(messages) > seen {
-				for _, msg := range messages[seen:] {
+			for _, msg := range messages {
+				if !seen[msg.ID] {
+					seen[msg.ID] = true
 					handler(msg)
 				}
-				seen = len(messages)
 			}
 		}
 	})
@@ -120,12 +291,3 @@ func (m *Mailbox) Watch(instanceID string, handler func(Message)) (cancel func()
 		wg.Wait()
 	}
 }
-
-// countMessages returns the current message count for an instance (broadcast + targeted).
-func (m *Mailbox) countMessages(instanceID string) (int, error) {
-	messages, err := m.Receive(instanceID)
-	if err != nil {
-		return 0, err
-	}
-	return len(messages), nil
-}
@@ -12,3 +12,20 @@ func WithBus(bus *event.Bus) Option {
 		m.bus = bus
 	}
 }
+
+// WithRateLimit enforces per-sender bandwidth limits on Send. Messages over
+// the rate are collected into a digest per sender and recipient instead of
+// being dropped. Without it, every message is delivered as sent.
+func WithRateLimit(limit RateLimit) Option {
+	return func(m *Mailbox) {
+		m.limiter = newLimiter(limit)
+	}
+}
+
+// WithGuard enables prompt-injection screening on Send using the given
+// policy. Without it, message bodies are stored exactly as sent.
+func WithGuard(policy GuardPolicy) Option {
+	return func(m *Mailbox) {
+		m.guard = &policy
+	}
+}
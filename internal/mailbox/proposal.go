@@ -0,0 +1,85 @@
+package mailbox
+
+import (
+	"errors"
+	"strings"
+)
+
+// TaskProposal is a follow-up task an instance proposes while working, such
+// as dead code it found outside its own task's scope. It travels to the
+// coordinator as the body of a MessageTaskProposal message, where a human
+// approves or rejects it.
+type TaskProposal struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Files       []string `json:"files,omitempty"`
+	DependsOn   []string `json:"depends_on,omitempty"`
+}
+
+// Header lines of a task proposal body. The description follows a blank
+// line, so a capped body only loses the end of the description.
+const (
+	proposalTitle     = "Title: "
+	proposalFiles     = "Files: "
+	proposalDependsOn = "Depends on: "
+)
+
+// NewTaskProposalMessage returns the message proposing p to the coordinator
+// on behalf of from.
+func NewTaskProposalMessage(from string, p TaskProposal) (Message, error) {
+	title := strings.Join(strings.Fields(p.Title), " ")
+	if title == "" {
+		return Message{}, errors.New("mailbox: task proposal requires a title")
+	}
+	var b strings.Builder
+	b.WriteString(proposalTitle + title + "\n")
+	if len(p.Files) > 0 {
+		b.WriteString(proposalFiles + strings.Join(p.Files, ", ") + "\n")
+	}
+	if len(p.DependsOn) > 0 {
+		b.WriteString(proposalDependsOn + strings.Join(p.DependsOn, ", ") + "\n")
+	}
+	if desc := strings.TrimSpace(p.Description); desc != "" {
+		b.WriteString("\n" + desc)
+	}
+	return Message{
+		From: from,
+		To:   CoordinatorRecipient,
+		Type: MessageTaskProposal,
+		Body: strings.TrimRight(b.String(), "\n"),
+	}, nil
+}
+
+// ParseTaskProposal parses the body of a MessageTaskProposal message. It
+// fails when the title line is missing, for instance because the guard
+// stripped it.
+func ParseTaskProposal(body string) (TaskProposal, error) {
+	var p TaskProposal
+	header, desc, _ := strings.Cut(body, "\n\n")
+	for line := range strings.SplitSeq(header, "\n") {
+		switch {
+		case strings.HasPrefix(line, proposalTitle):
+			p.Title = strings.TrimSpace(strings.TrimPrefix(line, proposalTitle))
+		case strings.HasPrefix(line, proposalFiles):
+			p.Files = splitList(strings.TrimPrefix(line, proposalFiles))
+		case strings.HasPrefix(line, proposalDependsOn):
+			p.DependsOn = splitList(strings.TrimPrefix(line, proposalDependsOn))
+		}
+	}
+	if p.Title == "" {
+		return TaskProposal{}, errors.New("mailbox: task proposal has no title")
+	}
+	p.Description = strings.TrimSpace(desc)
+	return p, nil
+}
+
+// splitList splits a comma-separated header value, dropping empty entries.
+func splitList(s string) []string {
+	var out []string
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
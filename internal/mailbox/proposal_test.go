@@ -0,0 +1,49 @@
+package mailbox
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTaskProposal_RoundTrip(t *testing.T) {
+	p := TaskProposal{
+		Title:       "Remove dead code\nin pkg/foo",
+		Description: "pkg/foo/legacy.go has no callers.\n\nDelete it and its tests.",
+		Files:       []string{"pkg/foo/legacy.go", "pkg/foo/legacy_test.go"},
+		DependsOn:   []string{"task-2"},
+	}
+	msg, err := NewTaskProposalMessage("task-1", p)
+	if err != nil {
+		t.Fatalf("NewTaskProposalMessage() error = %v", err)
+	}
+	if msg.To != CoordinatorRecipient || msg.Type != MessageTaskProposal || msg.From != "task-1" {
+		t.Errorf("message = %+v, want a task proposal from task-1 to the coordinator", msg)
+	}
+
+	got, err := ParseTaskProposal(msg.Body)
+	if err != nil {
+		t.Fatalf("ParseTaskProposal() error = %v", err)
+	}
+	if got.Title != "Remove dead code in pkg/foo" {
+		t.Errorf("Title = %q", got.Title)
+	}
+	if got.Description != p.Description || !slices.Equal(got.Files, p.Files) || !slices.Equal(got.DependsOn, p.DependsOn) {
+		t.Errorf("ParseTaskProposal() = %+v, want %+v", got, p)
+	}
+
+	// A capped body keeps the header
+	capped, err := ParseTaskProposal(capBody(msg.Body, 140))
+	if err != nil || capped.Title != got.Title || !strings.Contains(capped.Description, "[truncated") {
+		t.Errorf("capped proposal = %+v, %v", capped, err)
+	}
+}
+
+func TestTaskProposal_RequiresTitle(t *testing.T) {
+	if _, err := NewTaskProposalMessage("task-1", TaskProposal{Title: " \n"}); err == nil {
+		t.Error("NewTaskProposalMessage() without a title should fail")
+	}
+	if _, err := ParseTaskProposal("Files: a.go\n\nno title line"); err == nil {
+		t.Error("ParseTaskProposal() without a title should fail")
+	}
+}
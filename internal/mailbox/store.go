@@ -17,6 +17,10 @@ const (
 
 	// indexFile is the append-only JSONL file within each mailbox directory.
 	indexFile = "index.jsonl"
+
+	// releasedFile is the append-only log of held message IDs approved for
+	// injection. It lives beside the recipient directories.
+	releasedFile = "released.jsonl"
 )
 
 // Store provides file-based mailbox storage with atomic writes.
@@ -34,16 +38,11 @@ func NewStore(sessionDir string) *Store {
 
 // Send persists a message to the appropriate mailbox directory.
 // If msg.ID is empty, a unique ID is generated. If msg.Timestamp is zero, the
-// current time is used. Writes are serialized via a mutex and use O_APPEND.
+// current time is used. A positive msg.TTL sets ExpiresAt, unless it is
+// already set. Writes are serialized via a mutex and use O_APPEND.
 func (s *Store) Send(msg Message) error {
-	if msg.From == "" {
-		return fmt.Errorf("mailbox: message From field is required")
-	}
-	if msg.To == "" {
-		return fmt.Errorf("mailbox: message To field is required")
-	}
-	if msg.Type == "" {
-		return fmt.Errorf("mailbox: message Type field is required")
+	if err := msg.validate(); err != nil {
+		return err
 	}
 
 	if msg.ID == "" {
@@ -52,6 +51,9 @@ func (s *Store) Send(msg Message) error {
 	if msg.Timestamp.IsZero() {
 		msg.Timestamp = time.Now()
 	}
+	if msg.TTL > 0 && msg.ExpiresAt.IsZero() {
+		msg.ExpiresAt = msg.Timestamp.Add(msg.TTL)
+	}
 
 	dir := s.dirForRecipient(msg.To)
 	if err := os.MkdirAll(dir, 0o755); err != nil {
@@ -67,6 +69,20 @@ func (s *Store) Send(msg Message) error {
 	return s.atomicAppend(filepath.Join(dir, indexFile), data)
 }
 
+// validate checks the fields every stored message needs.
+func (m Message) validate() error {
+	if m.From == "" {
+		return fmt.Errorf("mailbox: message From field is required")
+	}
+	if m.To == "" {
+		return fmt.Errorf("mailbox: message To field is required")
+	}
+	if m.Type == "" {
+		return fmt.Errorf("mailbox: message Type field is required")
+	}
+	return nil
+}
+
 // ReadBroadcast returns all messages from the broadcast mailbox.
 func (s *Store) ReadBroadcast() ([]Message, error) {
 	return s.readIndex(s.dirForRecipient(BroadcastRecipient))
@@ -101,6 +117,88 @@ func (s *Store) ReadAll(instanceID string) ([]Message, error) {
 	return all, nil
 }
 
+// ReadEveryMailbox returns the messages in every recipient mailbox of the
+// session, sorted chronologically by timestamp.
+func (s *Store) ReadEveryMailbox() ([]Message, error) {
+	entries, err := os.ReadDir(filepath.Join(s.sessionDir, mailboxDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("mailbox: list mailboxes: %w", err)
+	}
+
+	var all []Message
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		messages, err := s.readIndex(s.dirForRecipient(entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, messages...)
+	}
+
+	sortMessages(all)
+	return all, nil
+}
+
+// releaseRecord is one line of the released-messages log.
+type releaseRecord struct {
+	ID         string    `json:"id"`
+	ReleasedAt time.Time `json:"released_at"`
+}
+
+// Release records that a held message has been approved for injection.
+func (s *Store) Release(messageID string) error {
+	if messageID == "" {
+		return fmt.Errorf("mailbox: messageID is required")
+	}
+
+	dir := filepath.Join(s.sessionDir, mailboxDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("mailbox: create directory: %w", err)
+	}
+
+	data, err := json.Marshal(releaseRecord{ID: messageID, ReleasedAt: time.Now()})
+	if err != nil {
+		return fmt.Errorf("mailbox: marshal release: %w", err)
+	}
+	data = append(data, '\n')
+
+	return s.atomicAppend(filepath.Join(dir, releasedFile), data)
+}
+
+// ReleasedIDs returns the set of message IDs that have been released.
+// Returns an empty set (not error) if nothing has been released.
+func (s *Store) ReleasedIDs() (map[string]bool, error) {
+	released := make(map[string]bool)
+
+	f, err := os.Open(filepath.Join(s.sessionDir, mailboxDir, releasedFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return released, nil
+		}
+		return nil, fmt.Errorf("mailbox: open released log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec releaseRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil || rec.ID == "" {
+			continue
+		}
+		released[rec.ID] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("mailbox: scan released log: %w", err)
+	}
+
+	return released, nil
+}
+
 // dirForRecipient returns the mailbox directory for a given recipient.
 func (s *Store) dirForRecipient(recipient string) string {
 	return filepath.Join(s.sessionDir, mailboxDir, recipient)
@@ -0,0 +1,186 @@
+package mailbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ExportVersion is the format version written by WriteExport.
+const ExportVersion = 1
+
+// skippedExportDirs are never searched for session directories: they hold
+// repositories and dependencies, not mailboxes.
+var skippedExportDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+	"worktrees":    true,
+}
+
+// Provenance records where a forwarded message originally came from.
+type Provenance struct {
+	// Source names the session the message was exported from.
+	Source string `json:"source"`
+
+	// MessageID is the message's ID in the source session.
+	MessageID string `json:"message_id"`
+
+	// To is the message's original recipient.
+	To string `json:"to"`
+
+	// Timestamp is when the message was originally sent.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Export is a portable snapshot of messages taken from one session.
+type Export struct {
+	Version    int       `json:"version"`
+	Source     string    `json:"source"`
+	ExportedAt time.Time `json:"exported_at"`
+	Messages   []Message `json:"messages"`
+}
+
+// Export returns the session's messages across every mailbox that match
+// opts, sorted chronologically. Messages still held for review are left out,
+// so an export never carries content a human has not approved.
+func (m *Mailbox) Export(opts FilterOptions) ([]Message, error) {
+	messages, err := m.store.ReadEveryMailbox()
+	if err != nil {
+		return nil, err
+	}
+	messages, err = m.applyReleases(messages)
+	if err != nil {
+		return nil, err
+	}
+	var visible []Message
+	for _, msg := range messages {
+		if !msg.Held {
+			visible = append(visible, msg)
+		}
+	}
+	return FilterMessages(visible, opts), nil
+}
+
+// Forward delivers messages exported from the source session into this one
+// as broadcasts, since their original recipients do not exist here. Each
+// forwarded message gets a new ID and timestamp and records its origin in
+// Message.Forwarded; a message that was already forwarded keeps its
+// original provenance. Messages this session has already received from the
+// same origin are skipped, so forwarding the same export twice is harmless.
+//
+// Forwarded messages pass through the guard but not the rate limit. Forward
+// returns how many messages were delivered.
+func (m *Mailbox) Forward(source string, messages []Message) (int, error) {
+	existing, err := m.store.ReadEveryMailbox()
+	if err != nil {
+		return 0, err
+	}
+	seen := make(map[string]bool)
+	for _, msg := range existing {
+		if msg.Forwarded != nil {
+			seen[provenanceKey(*msg.Forwarded)] = true
+		}
+	}
+
+	forwarded := 0
+	for _, msg := range messages {
+		if msg.Held {
+			continue
+		}
+		origin := Provenance{Source: source, MessageID: msg.ID, To: msg.To, Timestamp: msg.Timestamp}
+		if msg.Forwarded != nil {
+			origin = *msg.Forwarded
+		}
+		if seen[provenanceKey(origin)] {
+			continue
+		}
+		out := Message{
+			From:      msg.From,
+			To:        BroadcastRecipient,
+			Type:      msg.Type,
+			Body:      msg.Body,
+			Timestamp: m.now(),
+			Metadata:  maps.Clone(msg.Metadata),
+			Forwarded: &origin,
+		}
+		if err := m.deliver(out); err != nil {
+			return forwarded, err
+		}
+		seen[provenanceKey(origin)] = true
+		forwarded++
+	}
+	return forwarded, nil
+}
+
+// provenanceKey identifies the original message a provenance points to.
+func provenanceKey(p Provenance) string {
+	return p.Source + "\x00" + p.MessageID
+}
+
+// WriteExport writes e as indented JSON, filling in the version and export
+// time when they are unset.
+func WriteExport(w io.Writer, e Export) error {
+	if e.Version == 0 {
+		e.Version = ExportVersion
+	}
+	if e.ExportedAt.IsZero() {
+		e.ExportedAt = time.Now()
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(e); err != nil {
+		return fmt.Errorf("mailbox: write export: %w", err)
+	}
+	return nil
+}
+
+// ReadExport reads an export written by WriteExport.
+func ReadExport(r io.Reader) (Export, error) {
+	var e Export
+	if err := json.NewDecoder(r).Decode(&e); err != nil {
+		return Export{}, fmt.Errorf("mailbox: read export: %w", err)
+	}
+	if e.Version < 1 || e.Version > ExportVersion {
+		return Export{}, fmt.Errorf("mailbox: unsupported export version %d", e.Version)
+	}
+	for i, msg := range e.Messages {
+		if err := msg.validate(); err != nil {
+			return Export{}, fmt.Errorf("mailbox: export message %d: %w", i, err)
+		}
+	}
+	return e, nil
+}
+
+// FindSessionDirs returns the session directories at or below root that
+// hold a mailbox, in lexical order. Pipeline runs keep one mailbox per
+// execution team, so a single run may have several.
+func FindSessionDirs(root string) ([]string, error) {
+	if _, err := os.Stat(root); err != nil {
+		return nil, fmt.Errorf("mailbox: %w", err)
+	}
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			return nil // Continue on errors (permission denied, etc.)
+		case !d.IsDir():
+			return nil
+		case d.Name() == mailboxDir && path != root:
+			dirs = append(dirs, filepath.Dir(path))
+			return fs.SkipDir
+		case path != root && skippedExportDirs[d.Name()]:
+			return fs.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("mailbox: find sessions: %w", err)
+	}
+	return dirs, nil
+}
@@ -35,11 +35,21 @@ const (
 
 	// MessageConsensus agrees with a resolution.
 	MessageConsensus MessageType = "consensus"
+
+	// MessageDigest summarizes messages a sender sent over its rate limit.
+	MessageDigest MessageType = "digest"
+
+	// MessageTaskProposal proposes a follow-up task to the coordinator.
+	MessageTaskProposal MessageType = "task_proposal"
 )
 
 // BroadcastRecipient is the special "to" value for messages intended for all instances.
 const BroadcastRecipient = "broadcast"
 
+// CoordinatorRecipient is the "to" value for messages intended for the
+// coordinator rather than an instance.
+const CoordinatorRecipient = "coordinator"
+
 // Message represents a single inter-instance communication.
 type Message struct {
 	ID        string         `json:"id"`
@@ -49,6 +59,26 @@ type Message struct {
 	Body      string         `json:"body"`
 	Timestamp time.Time      `json:"timestamp"`
 	Metadata  map[string]any `json:"metadata,omitempty"`
+
+	// Flags lists the guard patterns the body matched when it was sent.
+	// Empty for messages that passed the guard or were sent without one.
+	Flags []string `json:"flags,omitempty"`
+
+	// Held is true while a flagged message awaits human review. Held
+	// messages are excluded from FormatForPrompt until released.
+	Held bool `json:"held,omitempty"`
+
+	// Forwarded records the original message when this one was forwarded
+	// from another session (see Mailbox.Forward). Nil for local messages.
+	Forwarded *Provenance `json:"forwarded,omitempty"`
+
+	// ExpiresAt is when the message stops being delivered. Zero means it
+	// never expires. Send sets it from TTL when it is zero.
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+
+	// TTL is how long after Timestamp the message stays deliverable. It is
+	// only read by Send, which records it as ExpiresAt.
+	TTL time.Duration `json:"-"`
 }
 
 // IsBroadcast returns true if the message is addressed to all instances.
@@ -56,18 +86,25 @@ func (m Message) IsBroadcast() bool {
 	return m.To == BroadcastRecipient
 }
 
+// Expired returns true if the message has an expiry at or before now.
+func (m Message) Expired(now time.Time) bool {
+	return !m.ExpiresAt.IsZero() && !now.Before(m.ExpiresAt)
+}
+
 // Valid message types for validation.
 var validMessageTypes = map[MessageType]bool{
-	MessageDiscovery: true,
-	MessageClaim:     true,
-	MessageRelease:   true,
-	MessageWarning:   true,
-	MessageQuestion:  true,
-	MessageAnswer:    true,
-	MessageStatus:    true,
-	MessageChallenge: true,
-	MessageDefense:   true,
-	MessageConsensus: true,
+	MessageDiscovery:    true,
+	MessageClaim:        true,
+	MessageRelease:      true,
+	MessageWarning:      true,
+	MessageQuestion:     true,
+	MessageAnswer:       true,
+	MessageStatus:       true,
+	MessageChallenge:    true,
+	MessageDefense:      true,
+	MessageConsensus:    true,
+	MessageDigest:       true,
+	MessageTaskProposal: true,
 }
 
 // ValidateMessageType returns true if the given type is a known message type.
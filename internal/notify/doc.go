@@ -0,0 +1,29 @@
+// Package notify posts chat messages to Slack and Discord webhooks when key
+// events are published on the event bus.
+//
+// When webhooks.endpoints is set, the orchestrator starts a [Notifier] for
+// each session. It turns five kinds of event into messages:
+//
+//   - phase_changed: the ultra-plan moved to a new phase (phase.changed)
+//   - task_failed: a planned task failed (task.completed without success)
+//   - pr_opened: a pull request was opened (pr.opened, pr.completed)
+//   - budget_warning: the session reached its cost warning (budget.warning)
+//   - waiting_input: an instance is waiting for input (instance.waiting_input)
+//
+// Each kind can be turned off, and its message replaced with a text/template
+// over [Data]. Messages are queued and posted from a background goroutine,
+// so a slow webhook never holds up the publisher; when the queue is full,
+// messages are dropped and logged.
+//
+// # Usage
+//
+//	n := notify.New(bus,
+//		notify.WithEndpoints(notify.Endpoint{URL: url}),
+//		notify.WithSession("auth"),
+//		notify.WithTemplate(notify.KindTaskFailed, "Task {{.Task}} failed"),
+//	)
+//	if err := n.Start(); err != nil {
+//		return err // a template failed to parse
+//	}
+//	defer n.Stop(ctx)
+package notify
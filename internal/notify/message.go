@@ -0,0 +1,128 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+)
+
+// Kind is a kind of notification. Each has its own enable flag and
+// message template.
+type Kind string
+
+// Kinds of notification.
+const (
+	KindPhaseChanged  Kind = "phase_changed"
+	KindTaskFailed    Kind = "task_failed"
+	KindPROpened      Kind = "pr_opened"
+	KindBudgetWarning Kind = "budget_warning"
+	KindWaitingInput  Kind = "waiting_input"
+)
+
+// Kinds returns every kind of notification.
+func Kinds() []Kind {
+	return []Kind{KindPhaseChanged, KindTaskFailed, KindPROpened, KindBudgetWarning, KindWaitingInput}
+}
+
+// DefaultTemplates are the messages sent for each kind unless replaced
+// with WithTemplate.
+var DefaultTemplates = map[Kind]string{
+	KindPhaseChanged:  `[{{.Session}}] Ultraplan phase: {{if .PreviousPhase}}{{.PreviousPhase}} → {{end}}{{.Phase}}`,
+	KindTaskFailed:    `[{{.Session}}] Task failed: {{.Task}}{{if .Reason}} ({{.Reason}}){{end}}`,
+	KindPROpened:      `[{{.Session}}] Pull request opened{{if .PRURL}}: {{.PRURL}}{{else}} by instance {{.InstanceID}}{{end}}`,
+	KindBudgetWarning: `[{{.Session}}] Budget warning: ${{printf "%.2f" .Spent}} spent, warning threshold ${{printf "%.2f" .Threshold}}`,
+	KindWaitingInput:  `[{{.Session}}] Instance {{.InstanceID}} is waiting for input: {{.Task}}`,
+}
+
+// maxTaskLength bounds the task text in a message; instance tasks are
+// whole prompts.
+const maxTaskLength = 120
+
+// Data is what a message template is executed with. Fields that do not
+// apply to a kind are empty.
+type Data struct {
+	Kind          Kind
+	Session       string  // Session name, or its ID when unnamed
+	Phase         string  // phase_changed: the new phase
+	PreviousPhase string  // phase_changed: the phase left, empty for the first
+	TaskID        string  // task_failed: the planned task's ID
+	Task          string  // task_failed: task title; waiting_input: first line of the instance's task
+	InstanceID    string  // Instance the event is about, when known
+	Reason        string  // task_failed: why the task failed
+	PRURL         string  // pr_opened: URL of the pull request, when known
+	Threshold     float64 // budget_warning: warning threshold (USD)
+	Spent         float64 // budget_warning: session cost (USD)
+}
+
+// classify returns the kind of notification e is, and false for events
+// that are not notified.
+func classify(e event.Event) (Kind, bool) {
+	switch e := e.(type) {
+	case event.PhaseChangeEvent:
+		return KindPhaseChanged, true
+	case event.TaskCompletedEvent:
+		return KindTaskFailed, !e.Success
+	case event.PROpenedEvent:
+		return KindPROpened, true
+	case event.PRCompleteEvent:
+		return KindPROpened, e.Success
+	case event.BudgetWarningEvent:
+		return KindBudgetWarning, true
+	case event.InstanceWaitingInputEvent:
+		return KindWaitingInput, true
+	}
+	return "", false
+}
+
+// newData returns the template data for e, an event of the given kind.
+func newData(kind Kind, session string, e event.Event) Data {
+	d := Data{Kind: kind, Session: session}
+	switch e := e.(type) {
+	case event.PhaseChangeEvent:
+		d.Phase, d.PreviousPhase = string(e.CurrentPhase), string(e.PreviousPhase)
+	case event.TaskCompletedEvent:
+		d.TaskID, d.InstanceID, d.Reason = e.TaskID, e.InstanceID, e.Reason
+		d.Task = e.Title
+		if d.Task == "" {
+			d.Task = e.TaskID
+		}
+	case event.PROpenedEvent:
+		d.InstanceID, d.PRURL = e.InstanceID, e.PRURL
+	case event.PRCompleteEvent:
+		d.InstanceID, d.PRURL = e.InstanceID, e.PRURL
+	case event.BudgetWarningEvent:
+		d.Threshold, d.Spent = e.Threshold, e.Spent
+	case event.InstanceWaitingInputEvent:
+		d.InstanceID, d.Task = e.InstanceID, summarize(e.Task)
+	}
+	return d
+}
+
+// summarize returns the first line of task, truncated to maxTaskLength.
+func summarize(task string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(task), "\n")
+	if r := []rune(line); len(r) > maxTaskLength {
+		return string(r[:maxTaskLength-1]) + "…"
+	}
+	return line
+}
+
+// parseTemplates parses the message template of each kind, using
+// DefaultTemplates for kinds without an override.
+func parseTemplates(overrides map[Kind]string) (map[Kind]*template.Template, error) {
+	templates := make(map[Kind]*template.Template, len(DefaultTemplates))
+	for _, kind := range Kinds() {
+		text := overrides[kind]
+		if text == "" {
+			text = DefaultTemplates[kind]
+		}
+		tmpl, err := template.New(string(kind)).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("notify: %s template: %w", kind, err)
+		}
+		templates[kind] = tmpl
+	}
+	return templates, nil
+}
@@ -0,0 +1,290 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/logging"
+)
+
+const (
+	// DefaultTimeout bounds each webhook request by default.
+	DefaultTimeout = 10 * time.Second
+
+	// queueSize is how many events may wait to be posted. Further events
+	// are dropped until the queue drains.
+	queueSize = 64
+
+	// discordMaxLength is the longest message Discord accepts.
+	discordMaxLength = 2000
+)
+
+// Format is the payload a webhook expects.
+type Format string
+
+// Webhook payload formats.
+const (
+	// FormatSlack posts {"text": message}, which Slack incoming webhooks
+	// and compatible services (Mattermost, Rocket.Chat) accept.
+	FormatSlack Format = "slack"
+	// FormatDiscord posts {"content": message}.
+	FormatDiscord Format = "discord"
+)
+
+// Endpoint is a webhook notified of events.
+type Endpoint struct {
+	URL string
+	// Format is the payload to post. Empty picks Discord for discord.com
+	// URLs and Slack otherwise.
+	Format Format
+}
+
+// format returns the payload format of the endpoint.
+func (e Endpoint) format() Format {
+	if e.Format != "" {
+		return e.Format
+	}
+	if u, err := url.Parse(e.URL); err == nil {
+		host := strings.ToLower(u.Hostname())
+		if host == "discord.com" || host == "discordapp.com" || strings.HasSuffix(host, ".discord.com") {
+			return FormatDiscord
+		}
+	}
+	return FormatSlack
+}
+
+// Notifier posts a message to its endpoints for each notified event
+// published on a Bus.
+type Notifier struct {
+	bus       *event.Bus
+	logger    *logging.Logger
+	client    *http.Client
+	endpoints []Endpoint
+	session   string
+	disabled  map[Kind]bool
+	overrides map[Kind]string
+	templates map[Kind]*template.Template
+
+	mu    sync.Mutex
+	subID string
+	queue chan event.Event
+	done  chan struct{} // Closed when the sender has drained the queue
+}
+
+// Option configures a Notifier.
+type Option func(*Notifier)
+
+// WithEndpoints sets the webhooks to notify.
+func WithEndpoints(endpoints ...Endpoint) Option {
+	return func(n *Notifier) {
+		n.endpoints = append(n.endpoints, endpoints...)
+	}
+}
+
+// WithSession sets the session name shown in messages.
+func WithSession(name string) Option {
+	return func(n *Notifier) {
+		n.session = name
+	}
+}
+
+// WithKind enables or disables notifications of kind. Every kind is
+// enabled by default.
+func WithKind(kind Kind, enabled bool) Option {
+	return func(n *Notifier) {
+		n.disabled[kind] = !enabled
+	}
+}
+
+// WithTemplate replaces the message of kind with a text/template executed
+// with Data. An empty text keeps the default.
+func WithTemplate(kind Kind, text string) Option {
+	return func(n *Notifier) {
+		n.overrides[kind] = text
+	}
+}
+
+// WithTimeout bounds each webhook request.
+func WithTimeout(d time.Duration) Option {
+	return func(n *Notifier) {
+		if d > 0 {
+			n.client = &http.Client{Timeout: d}
+		}
+	}
+}
+
+// WithLogger sets the logger for delivery failures.
+func WithLogger(logger *logging.Logger) Option {
+	return func(n *Notifier) {
+		n.logger = logger
+	}
+}
+
+// New creates a Notifier for bus. It posts nothing until Start.
+func New(bus *event.Bus, opts ...Option) *Notifier {
+	n := &Notifier{
+		bus:       bus,
+		client:    &http.Client{Timeout: DefaultTimeout},
+		disabled:  make(map[Kind]bool),
+		overrides: make(map[Kind]string),
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+// Start parses the message templates, subscribes to the bus, and starts
+// posting. It fails if a template does not parse or no endpoint is set.
+func (n *Notifier) Start() error {
+	if len(n.endpoints) == 0 {
+		return errors.New("notify: no webhook endpoints")
+	}
+	templates, err := parseTemplates(n.overrides)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.queue != nil {
+		return errors.New("notify: already started")
+	}
+	n.templates = templates
+	n.queue = make(chan event.Event, queueSize)
+	n.done = make(chan struct{})
+	go n.send(n.queue, n.done)
+	n.subID = n.bus.SubscribeAll(n.publish)
+	return nil
+}
+
+// Stop unsubscribes from the bus and waits until the messages already
+// queued are posted, or ctx is done.
+func (n *Notifier) Stop(ctx context.Context) error {
+	n.mu.Lock()
+	subID, queue, done := n.subID, n.queue, n.done
+	if queue == nil {
+		n.mu.Unlock()
+		return nil
+	}
+	// publish sends under mu, so closing here cannot race a send.
+	n.subID, n.queue = "", nil
+	close(queue)
+	n.mu.Unlock()
+	n.bus.Unsubscribe(subID)
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// publish queues e if it is a notified kind. It runs inline in the
+// publisher's goroutine, so it never blocks: when the queue is full, e is
+// dropped.
+func (n *Notifier) publish(e event.Event) {
+	kind, ok := classify(e)
+	if !ok || n.disabled[kind] {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.queue == nil {
+		return
+	}
+	select {
+	case n.queue <- e:
+	default:
+		if n.logger != nil {
+			n.logger.Warn("webhook queue full, notification dropped", "event_type", e.EventType())
+		}
+	}
+}
+
+// send posts the message of each queued event to every endpoint, and
+// closes done once queue is closed and drained.
+func (n *Notifier) send(queue <-chan event.Event, done chan<- struct{}) {
+	defer close(done)
+	for e := range queue {
+		kind, _ := classify(e)
+		message, err := n.render(kind, e)
+		if err != nil {
+			if n.logger != nil {
+				n.logger.Warn("failed to render webhook message", "kind", string(kind), "error", err)
+			}
+			continue
+		}
+		for _, endpoint := range n.endpoints {
+			if err := n.post(endpoint, message); err != nil && n.logger != nil {
+				n.logger.Warn("webhook notification failed", "kind", string(kind), "error", err)
+			}
+		}
+	}
+}
+
+// render returns the message for e, an event of the given kind.
+func (n *Notifier) render(kind Kind, e event.Event) (string, error) {
+	var buf bytes.Buffer
+	if err := n.templates[kind].Execute(&buf, newData(kind, n.session, e)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// post sends message to endpoint in its payload format.
+func (n *Notifier) post(endpoint Endpoint, message string) error {
+	var payload any
+	switch endpoint.format() {
+	case FormatDiscord:
+		if r := []rune(message); len(r) > discordMaxLength {
+			message = string(r[:discordMaxLength-1]) + "…"
+		}
+		payload = map[string]string{"content": message}
+	default:
+		payload = map[string]string{"text": message}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := n.client.Post(endpoint.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		// The URL carries the webhook's secret; keep it out of the logs.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("post to %s: %w", redact(endpoint.URL), err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("post to %s: %s", redact(endpoint.URL), resp.Status)
+	}
+	return nil
+}
+
+// redact returns the scheme and host of a webhook URL, leaving out the
+// path that holds its token.
+func redact(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "webhook"
+	}
+	return u.Scheme + "://" + u.Host
+}
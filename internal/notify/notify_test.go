@@ -0,0 +1,164 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+)
+
+// webhook records the JSON payloads posted to it.
+type webhook struct {
+	*httptest.Server
+	mu       sync.Mutex
+	payloads []map[string]string
+}
+
+func newWebhook(t *testing.T, status int) *webhook {
+	t.Helper()
+	w := &webhook{}
+	w.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		w.mu.Lock()
+		w.payloads = append(w.payloads, payload)
+		w.mu.Unlock()
+		rw.WriteHeader(status)
+	}))
+	t.Cleanup(w.Close)
+	return w
+}
+
+func (w *webhook) received() []map[string]string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]map[string]string(nil), w.payloads...)
+}
+
+// publishAndStop publishes events on a started notifier's bus and stops
+// it, so every queued message has been posted on return.
+func publishAndStop(t *testing.T, bus *event.Bus, n *Notifier, events ...event.Event) {
+	t.Helper()
+	if err := n.Start(); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range events {
+		bus.Publish(e)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := n.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNotifier_Slack(t *testing.T) {
+	hook := newWebhook(t, http.StatusOK)
+	bus := event.NewBus()
+	n := New(bus, WithEndpoints(Endpoint{URL: hook.URL}), WithSession("auth"))
+
+	publishAndStop(t, bus, n,
+		event.NewPhaseChangeEvent("up-1", "planning", "executing"),
+		event.NewTaskCompletedEvent("t1", "i1", true, "", "Add login"), // successes are not notified
+		event.NewTaskCompletedEvent("t2", "i2", false, "no commits", "Add logout"),
+		event.NewPROpenedEvent("cons", "https://github.com/o/r/pull/1"),
+		event.NewBudgetWarningEvent(5, 5.25),
+		event.NewInstanceWaitingInputEvent("i3", "Fix the tests\nThey fail on CI"),
+		event.NewMetricsUpdateEvent("i1", 1, 1, 0, 0, 0.1, 1),
+	)
+
+	var got []string
+	for _, p := range hook.received() {
+		got = append(got, p["text"])
+	}
+	want := []string{
+		"[auth] Ultraplan phase: planning → executing",
+		"[auth] Task failed: Add logout (no commits)",
+		"[auth] Pull request opened: https://github.com/o/r/pull/1",
+		"[auth] Budget warning: $5.25 spent, warning threshold $5.00",
+		"[auth] Instance i3 is waiting for input: Fix the tests",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("messages =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestNotifier_DiscordKindsAndTemplates(t *testing.T) {
+	hook := newWebhook(t, http.StatusNoContent)
+	bus := event.NewBus()
+	n := New(bus,
+		WithEndpoints(Endpoint{URL: hook.URL, Format: FormatDiscord}),
+		WithKind(KindPhaseChanged, false),
+		WithTemplate(KindWaitingInput, "{{.InstanceID}} needs you"),
+	)
+
+	publishAndStop(t, bus, n,
+		event.NewPhaseChangeEvent("up-1", "", "planning"),
+		event.NewInstanceWaitingInputEvent("i3", "task"),
+	)
+
+	got := hook.received()
+	if len(got) != 1 || got[0]["content"] != "i3 needs you" {
+		t.Errorf("payloads = %v, want only the waiting_input template as Discord content", got)
+	}
+}
+
+func TestNotifier_Start(t *testing.T) {
+	bus := event.NewBus()
+	if err := New(bus).Start(); err == nil {
+		t.Error("expected an error without endpoints")
+	}
+	n := New(bus, WithEndpoints(Endpoint{URL: "http://127.0.0.1:1"}), WithTemplate(KindTaskFailed, "{{.Task"))
+	if err := n.Start(); err == nil || !strings.Contains(err.Error(), "task_failed") {
+		t.Errorf("Start() = %v, want a task_failed template error", err)
+	}
+	if err := n.Stop(context.Background()); err != nil {
+		t.Errorf("Stop() of an unstarted notifier = %v", err)
+	}
+}
+
+func TestNotifier_FailedPostDoesNotStopDelivery(t *testing.T) {
+	failing := newWebhook(t, http.StatusInternalServerError)
+	hook := newWebhook(t, http.StatusOK)
+	bus := event.NewBus()
+	n := New(bus, WithEndpoints(Endpoint{URL: failing.URL}, Endpoint{URL: hook.URL}))
+
+	publishAndStop(t, bus, n,
+		event.NewBudgetWarningEvent(5, 6),
+		event.NewPRCompleteEvent("i1", true, "", ""),
+	)
+	if got := len(hook.received()); got != 2 {
+		t.Errorf("working endpoint got %d messages, want 2", got)
+	}
+}
+
+func TestEndpointFormat(t *testing.T) {
+	tests := []struct {
+		endpoint Endpoint
+		want     Format
+	}{
+		{Endpoint{URL: "https://hooks.slack.com/services/T/B/x"}, FormatSlack},
+		{Endpoint{URL: "https://discord.com/api/webhooks/1/x"}, FormatDiscord},
+		{Endpoint{URL: "https://canary.discord.com/api/webhooks/1/x"}, FormatDiscord},
+		{Endpoint{URL: "https://discord.com/api/webhooks/1/x", Format: FormatSlack}, FormatSlack},
+	}
+	for _, tt := range tests {
+		if got := tt.endpoint.format(); got != tt.want {
+			t.Errorf("format(%s) = %s, want %s", tt.endpoint.URL, got, tt.want)
+		}
+	}
+}
+
+func TestRedact(t *testing.T) {
+	if got := redact("https://hooks.slack.com/services/T/B/secret"); got != "https://hooks.slack.com" {
+		t.Errorf("redact() = %q, want the scheme and host only", got)
+	}
+}
@@ -0,0 +1,288 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Iron-Ham/claudio/internal/api"
+	pb "github.com/Iron-Ham/claudio/internal/api/claudiov1"
+	"github.com/Iron-Ham/claudio/internal/audit"
+)
+
+// apiServerStopTimeout bounds how long stopping the API server waits for
+// calls in flight before cutting off open streams.
+const apiServerStopTimeout = 2 * time.Second
+
+// startAPIServer serves the gRPC control API when api.enabled is set. It
+// only runs for sessions with their own directory, and is a no-op if already
+// running. A listen failure is logged and the session continues without the
+// API. Caller must hold o.mu.
+func (o *Orchestrator) startAPIServer() {
+	cfg := o.config.API
+	if o.sessionDir == "" || !cfg.Enabled || o.apiServer != nil {
+		return
+	}
+
+	srv := api.New(apiController{o},
+		api.WithToken(cfg.Token),
+		api.WithLogger(o.logger),
+	)
+	if err := srv.Start(cfg.Address); err != nil {
+		if o.logger != nil {
+			o.logger.Warn("api server disabled", "error", err)
+		}
+		return
+	}
+	o.apiServer = srv
+	if o.logger != nil {
+		o.logger.Info("api server listening", "address", srv.Addr().String(), "authenticated", cfg.Token != "")
+	}
+}
+
+// stopAPIServerLocked stops the API server if it is running. Caller must
+// hold o.mu.
+func (o *Orchestrator) stopAPIServerLocked() {
+	if o.apiServer == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), apiServerStopTimeout)
+	defer cancel()
+	if err := o.apiServer.Stop(ctx); err != nil && o.logger != nil {
+		o.logger.Warn("failed to stop api server", "error", err)
+	}
+	o.apiServer = nil
+}
+
+// TaskApprover holds tasks that wait for a human to approve them before they
+// run. *approval.Gate implements it.
+type TaskApprover interface {
+	PendingApprovals() []string
+	Approve(taskID string) error
+}
+
+// approverSet is the registry behind AddTaskApprover. The zero value is
+// ready to use.
+type approverSet struct {
+	mu      sync.Mutex
+	entries []*approverEntry // Pointers, so removal finds its own entry
+}
+
+type approverEntry struct {
+	approver TaskApprover
+}
+
+// AddTaskApprover makes a's pending tasks visible to the control API, which
+// can then approve them. Call the returned function when a stops gating.
+func (o *Orchestrator) AddTaskApprover(a TaskApprover) (remove func()) {
+	entry := &approverEntry{approver: a}
+	o.approvers.mu.Lock()
+	o.approvers.entries = append(o.approvers.entries, entry)
+	o.approvers.mu.Unlock()
+	return func() {
+		o.approvers.mu.Lock()
+		defer o.approvers.mu.Unlock()
+		o.approvers.entries = slices.DeleteFunc(o.approvers.entries, func(e *approverEntry) bool { return e == entry })
+	}
+}
+
+// PendingApprovals returns the IDs of tasks waiting for approval in every
+// registered TaskApprover.
+func (o *Orchestrator) PendingApprovals() []string {
+	o.approvers.mu.Lock()
+	defer o.approvers.mu.Unlock()
+	var ids []string
+	for _, e := range o.approvers.entries {
+		ids = append(ids, e.approver.PendingApprovals()...)
+	}
+	return ids
+}
+
+// ApproveTask approves a task waiting in a registered TaskApprover.
+func (o *Orchestrator) ApproveTask(taskID string) error {
+	o.approvers.mu.Lock()
+	var owner TaskApprover
+	for _, e := range o.approvers.entries {
+		if slices.Contains(e.approver.PendingApprovals(), taskID) {
+			owner = e.approver
+			break
+		}
+	}
+	o.approvers.mu.Unlock()
+	if owner == nil {
+		return fmt.Errorf("task %s is not awaiting approval: %w", taskID, api.ErrNotFound)
+	}
+	return owner.Approve(taskID)
+}
+
+// UnpauseInstance resumes an instance paused with PauseInstance, or one
+// parked with ParkInstance along with where it left off.
+func (o *Orchestrator) UnpauseInstance(id string) error {
+	if inst := o.GetInstance(id); inst != nil && inst.ParkedAt != nil {
+		return o.UnparkInstance(id)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	mgr, ok := o.instances[id]
+	if !ok {
+		return fmt.Errorf("instance %s not found", id)
+	}
+	if err := mgr.Resume(); err != nil {
+		return err
+	}
+	for _, inst := range o.session.Instances {
+		if inst.ID == id && inst.Status == StatusPaused {
+			inst.Status = StatusWorking
+			break
+		}
+	}
+	o.publishSnapshot()
+	return nil
+}
+
+// apiController exposes the orchestrator to the control API.
+type apiController struct {
+	o *Orchestrator
+}
+
+// BaseDir implements api.Controller.
+func (c apiController) BaseDir() string { return c.o.BaseDir() }
+
+// SessionState implements api.Controller from the latest snapshot, so it
+// never takes orchestrator locks.
+func (c apiController) SessionState() *pb.SessionState {
+	sess := c.o.Snapshot().Session
+	if sess == nil {
+		return nil
+	}
+	state := &pb.SessionState{
+		Id:               sess.ID,
+		Name:             sess.Name,
+		BaseRepo:         sess.BaseRepo,
+		Created:          timestamppb.New(sess.Created),
+		PendingApprovals: c.o.PendingApprovals(),
+	}
+	for _, inst := range sess.Instances {
+		p := &pb.Instance{
+			Id:           inst.ID,
+			Name:         inst.DisplayName,
+			Task:         inst.Task,
+			Status:       string(inst.Status),
+			Branch:       inst.Branch,
+			WorktreePath: inst.WorktreePath,
+			Backend:      inst.Backend,
+			Created:      timestamppb.New(inst.Created),
+		}
+		if m := inst.Metrics; m != nil {
+			p.InputTokens, p.OutputTokens, p.CostUsd = m.InputTokens, m.OutputTokens, m.Cost
+		}
+		state.Instances = append(state.Instances, p)
+	}
+	if up := sess.UltraPlan; up != nil {
+		p := &pb.UltraPlan{
+			Id:             up.ID,
+			Objective:      up.Objective,
+			Phase:          string(up.Phase),
+			CompletedTasks: int32(len(up.CompletedTasks)),
+			FailedTasks:    slices.Clone(up.FailedTasks),
+			Error:          up.Error,
+		}
+		if up.Plan != nil {
+			p.TotalTasks = int32(len(up.Plan.Tasks))
+		}
+		if up.Phase == PhaseExecuting {
+			p.CurrentGroup = int32(up.CurrentGroup + 1)
+		}
+		if up.Consolidation != nil {
+			p.PrUrls = slices.Clone(up.Consolidation.PRUrls)
+		}
+		state.Ultraplan = p
+	}
+	return state
+}
+
+// ApproveTask implements api.Controller.
+func (c apiController) ApproveTask(taskID string) error {
+	if err := c.o.ApproveTask(taskID); err != nil {
+		return err
+	}
+	c.o.RecordOperatorAction(audit.ActionApprove, "", taskID, "approved task over the control API")
+	return nil
+}
+
+// manager returns the manager of instance id. The error wraps
+// api.ErrNotFound for an unknown instance and api.ErrFailedPrecondition for
+// one without a manager.
+func (c apiController) manager(id string) (instanceManager, error) {
+	if mgr := c.o.GetInstanceManager(id); mgr != nil {
+		return mgr, nil
+	}
+	if c.o.Snapshot().Instance(id) != nil {
+		return nil, fmt.Errorf("instance %s is not running: %w", id, api.ErrFailedPrecondition)
+	}
+	return nil, fmt.Errorf("instance %s: %w", id, api.ErrNotFound)
+}
+
+// instanceManager is the part of *instance.Manager the control API uses.
+type instanceManager interface {
+	Running() bool
+	SendLiteral(text string)
+	SendKey(key string)
+	GetOutput() []byte
+}
+
+// PauseInstance implements api.Controller.
+func (c apiController) PauseInstance(id string) error {
+	if _, err := c.manager(id); err != nil {
+		return err
+	}
+	return c.o.PauseInstance(id)
+}
+
+// ResumeInstance implements api.Controller.
+func (c apiController) ResumeInstance(id string) error {
+	if _, err := c.manager(id); err != nil {
+		return err
+	}
+	return c.o.UnpauseInstance(id)
+}
+
+// SendInput implements api.Controller.
+func (c apiController) SendInput(id, text string, submit bool) error {
+	mgr, err := c.manager(id)
+	if err != nil {
+		return err
+	}
+	if !mgr.Running() {
+		return fmt.Errorf("instance %s is not running: %w", id, api.ErrFailedPrecondition)
+	}
+	if text != "" {
+		mgr.SendLiteral(text)
+	}
+	detail := text + " (not submitted)"
+	if submit {
+		mgr.SendKey("Enter")
+		detail = text
+	}
+	c.o.RecordOperatorAction(audit.ActionInput, id, "", detail)
+	return nil
+}
+
+// InstanceOutput implements api.Controller. An instance that has not started
+// yet has no output.
+func (c apiController) InstanceOutput(id string) ([]byte, error) {
+	mgr, err := c.manager(id)
+	if err != nil {
+		if c.o.Snapshot().Instance(id) != nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return mgr.GetOutput(), nil
+}
@@ -0,0 +1,113 @@
+package orchestrator
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/api"
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/event"
+)
+
+func TestOrchestrator_APIServer(t *testing.T) {
+	cfg := config.Default()
+	o := &Orchestrator{sessionDir: t.TempDir(), config: cfg, eventBus: event.NewBus()}
+
+	o.startAPIServer()
+	if o.apiServer != nil {
+		t.Fatal("api server should not start when api.enabled is false")
+	}
+
+	cfg.API.Enabled = true
+	cfg.API.Address = "127.0.0.1:0"
+	o.startAPIServer()
+	if o.apiServer == nil {
+		t.Fatal("api server should start when enabled")
+	}
+	o.stopAPIServerLocked()
+	if o.apiServer != nil {
+		t.Error("api server should be cleared after stop")
+	}
+	o.stopAPIServerLocked() // no-op when stopped
+}
+
+// stubApprover holds a fixed set of tasks awaiting approval.
+type stubApprover struct {
+	pending []string
+}
+
+func (s *stubApprover) PendingApprovals() []string { return slices.Clone(s.pending) }
+
+func (s *stubApprover) Approve(taskID string) error {
+	s.pending = slices.DeleteFunc(s.pending, func(id string) bool { return id == taskID })
+	return nil
+}
+
+func TestOrchestrator_TaskApprovers(t *testing.T) {
+	o := &Orchestrator{}
+	first := &stubApprover{pending: []string{"t1"}}
+	second := &stubApprover{pending: []string{"t2", "t3"}}
+	o.AddTaskApprover(first)
+	removeSecond := o.AddTaskApprover(second)
+
+	if got := o.PendingApprovals(); !slices.Equal(got, []string{"t1", "t2", "t3"}) {
+		t.Errorf("PendingApprovals() = %v", got)
+	}
+	if err := o.ApproveTask("t2"); err != nil {
+		t.Fatalf("ApproveTask(t2) error = %v", err)
+	}
+	if !slices.Equal(second.pending, []string{"t3"}) {
+		t.Errorf("second approver pending = %v, want [t3]", second.pending)
+	}
+	if err := o.ApproveTask("t2"); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("approving twice error = %v, want api.ErrNotFound", err)
+	}
+
+	removeSecond()
+	if got := o.PendingApprovals(); !slices.Equal(got, []string{"t1"}) {
+		t.Errorf("PendingApprovals() after remove = %v, want [t1]", got)
+	}
+}
+
+func TestAPIController_SessionState(t *testing.T) {
+	o := &Orchestrator{}
+	ctrl := apiController{o}
+	if ctrl.SessionState() != nil {
+		t.Error("SessionState() before a session should be nil")
+	}
+
+	sess := NewSession("remote", "/repo")
+	sess.Instances = []*Instance{{ID: "i1", Task: "fix", Status: StatusWorking, Metrics: &Metrics{InputTokens: 10, Cost: 0.5}}}
+	sess.UltraPlan = &UltraPlanSession{
+		ID:             "up",
+		Phase:          PhaseExecuting,
+		Plan:           &PlanSpec{Tasks: []PlannedTask{{ID: "a"}, {ID: "b"}}},
+		CompletedTasks: []string{"a"},
+		CurrentGroup:   1,
+	}
+	o.session = sess
+	o.publishSnapshot()
+
+	state := ctrl.SessionState()
+	if state.GetName() != "remote" || len(state.GetInstances()) != 1 {
+		t.Fatalf("SessionState() = %v", state)
+	}
+	if inst := state.GetInstances()[0]; inst.GetStatus() != string(StatusWorking) || inst.GetInputTokens() != 10 || inst.GetCostUsd() != 0.5 {
+		t.Errorf("instance = %v", inst)
+	}
+	up := state.GetUltraplan()
+	if up.GetCompletedTasks() != 1 || up.GetTotalTasks() != 2 || up.GetCurrentGroup() != 2 {
+		t.Errorf("ultraplan = %v", up)
+	}
+
+	if err := ctrl.SendInput("i1", "hi", true); !errors.Is(err, api.ErrFailedPrecondition) {
+		t.Errorf("SendInput() to an instance without a manager error = %v, want ErrFailedPrecondition", err)
+	}
+	if _, err := ctrl.InstanceOutput("missing"); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("InstanceOutput(missing) error = %v, want ErrNotFound", err)
+	}
+	if out, err := ctrl.InstanceOutput("i1"); err != nil || out != nil {
+		t.Errorf("InstanceOutput() of an unstarted instance = %q, %v", out, err)
+	}
+}
@@ -0,0 +1,45 @@
+package orchestrator
+
+import (
+	"github.com/Iron-Ham/claudio/internal/audit"
+	"github.com/Iron-Ham/claudio/internal/event"
+)
+
+// startAudit records operator actions to the session's audit log. It only
+// runs for sessions with their own directory, and is a no-op if already
+// running. Caller must hold o.mu.
+func (o *Orchestrator) startAudit() {
+	if o.sessionDir == "" || o.auditRec != nil {
+		return
+	}
+	o.auditRec = audit.NewRecorder(audit.NewLog(o.sessionDir), o.eventBus, o.logger)
+}
+
+// stopAuditLocked stops recording operator actions. Caller must hold o.mu.
+func (o *Orchestrator) stopAuditLocked() {
+	if o.auditRec != nil {
+		o.auditRec.Stop()
+		o.auditRec = nil
+	}
+}
+
+// RecordOperatorAction publishes an operator action for the session's audit
+// log. Call it wherever a human intervenes in the session, or a policy
+// approves something on their behalf.
+func (o *Orchestrator) RecordOperatorAction(action audit.Action, instanceID, taskID, detail string) {
+	if o == nil || o.eventBus == nil {
+		return
+	}
+	o.eventBus.Publish(event.NewOperatorActionEvent(string(action), instanceID, taskID, detail))
+}
+
+// RecordDecision publishes a decision the orchestrator made on its own for
+// the session's audit log, with why it was made and what it was based on.
+// Decisions already published as events of their own (nudges, scaling,
+// task failures) are recorded from those.
+func (o *Orchestrator) RecordDecision(action audit.Action, instanceID, taskID, reason string, inputs map[string]string) {
+	if o == nil || o.eventBus == nil {
+		return
+	}
+	o.eventBus.Publish(event.NewOrchestratorDecisionEvent(string(action), instanceID, taskID, reason, inputs))
+}
@@ -0,0 +1,29 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/audit"
+	"github.com/Iron-Ham/claudio/internal/event"
+)
+
+func TestOrchestrator_RecordOperatorAction(t *testing.T) {
+	o := &Orchestrator{sessionDir: t.TempDir(), eventBus: event.NewBus()}
+	o.startAudit()
+	o.startAudit() // no-op while running
+
+	o.RecordOperatorAction(audit.ActionOverride, "inst-1", "", ":kill")
+	o.stopAuditLocked()
+	o.RecordOperatorAction(audit.ActionOverride, "inst-1", "", ":start")
+
+	entries, err := audit.NewLog(o.sessionDir).Entries()
+	if err != nil {
+		t.Fatalf("Entries() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Detail != ":kill" || entries[0].InstanceID != "inst-1" {
+		t.Errorf("entries = %+v, want only the action recorded while running", entries)
+	}
+
+	var nilOrch *Orchestrator
+	nilOrch.RecordOperatorAction(audit.ActionInput, "inst-1", "", "ignored") // must not panic
+}
@@ -0,0 +1,92 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Iron-Ham/claudio/internal/stats"
+	"github.com/Iron-Ham/claudio/internal/worktree"
+)
+
+// BootstrapRecordKind is the stats store record kind for worktree bootstrap timings.
+const BootstrapRecordKind = "worktree.bootstrap"
+
+// bootstrapWorktree runs the configured worktree bootstrap for an instance that
+// has not been bootstrapped yet, records the timing on the instance and in the
+// stats store, and never fails the start: a failed bootstrap only means the
+// instance rebuilds its caches itself.
+func (o *Orchestrator) bootstrapWorktree(inst *Instance) {
+	if o.config == nil || !o.config.Paths.Bootstrap.Enabled || inst.Bootstrap != nil || inst.WorktreePath == "" {
+		return
+	}
+	cfg := o.config.Paths.Bootstrap
+	opts := worktree.BootstrapOptions{
+		SharedDirs: cfg.SharedDirs,
+		Commands:   cfg.Commands,
+		Env:        cfg.Env,
+		Timeout:    cfg.Timeout(),
+	}
+	if opts.IsZero() {
+		return
+	}
+
+	result, err := o.wt.Bootstrap(context.Background(), inst.WorktreePath, opts)
+	timing := &BootstrapTiming{
+		LinkedDirs:  result.Linked,
+		CommandsRun: result.CommandsRun,
+		LinkMs:      result.LinkDuration.Milliseconds(),
+		CommandMs:   result.CommandDuration.Milliseconds(),
+	}
+	if err != nil {
+		timing.Error = err.Error()
+		if o.logger != nil {
+			o.logger.Warn("worktree bootstrap failed - instance will start without warm caches",
+				"instance_id", inst.ID,
+				"worktree_path", inst.WorktreePath,
+				"duration", result.Duration(),
+				"error", err,
+			)
+		}
+		fmt.Fprintf(os.Stderr, "Warning: worktree bootstrap failed for %s: %v\n", inst.ID, err)
+	} else if o.logger != nil {
+		o.logger.Info("worktree bootstrap complete",
+			"instance_id", inst.ID,
+			"linked_dirs", result.Linked,
+			"commands_run", result.CommandsRun,
+			"duration", result.Duration(),
+		)
+	}
+	inst.Bootstrap = timing
+
+	o.recordBootstrap(inst.ID, timing)
+}
+
+// recordBootstrap appends a bootstrap timing to the stats store so startup
+// cost can be compared across sessions and bootstrap configurations.
+func (o *Orchestrator) recordBootstrap(instanceID string, timing *BootstrapTiming) {
+	if o.claudioDir == "" {
+		return
+	}
+	success := 1.0
+	if timing.Error != "" {
+		success = 0
+	}
+	err := stats.NewStore(o.claudioDir).Append(stats.Record{
+		Kind:    BootstrapRecordKind,
+		Session: o.sessionID,
+		Subject: instanceID,
+		Values: map[string]float64{
+			"link_seconds":    float64(timing.LinkMs) / 1000,
+			"command_seconds": float64(timing.CommandMs) / 1000,
+			"linked_dirs":     float64(len(timing.LinkedDirs)),
+			"success":         success,
+		},
+	})
+	if err != nil && o.logger != nil {
+		o.logger.Warn("failed to record worktree bootstrap timing",
+			"instance_id", instanceID,
+			"error", err,
+		)
+	}
+}
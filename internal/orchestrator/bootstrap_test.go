@@ -0,0 +1,98 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/stats"
+	"github.com/Iron-Ham/claudio/internal/worktree"
+)
+
+// newBootstrapTestOrchestrator builds a minimal Orchestrator whose worktree
+// manager points at a fake repository root (FindGitRoot only needs a .git entry).
+func newBootstrapTestOrchestrator(t *testing.T, bootstrap config.BootstrapConfig) *Orchestrator {
+	t.Helper()
+	repoDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(repoDir, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	wt, err := worktree.New(repoDir)
+	if err != nil {
+		t.Fatalf("worktree.New: %v", err)
+	}
+	cfg := config.Default()
+	cfg.Paths.Bootstrap = bootstrap
+	return &Orchestrator{
+		config:     cfg,
+		wt:         wt,
+		claudioDir: filepath.Join(repoDir, ".claudio"),
+		sessionID:  "sess-1",
+	}
+}
+
+func TestBootstrapWorktree_RecordsTiming(t *testing.T) {
+	o := newBootstrapTestOrchestrator(t, config.BootstrapConfig{
+		Enabled:  true,
+		Commands: []string{"touch bootstrapped"},
+	})
+	inst := &Instance{ID: "inst-1", WorktreePath: t.TempDir()}
+
+	o.bootstrapWorktree(inst)
+
+	if inst.Bootstrap == nil {
+		t.Fatal("expected Bootstrap timing to be recorded on the instance")
+	}
+	if inst.Bootstrap.CommandsRun != 1 || inst.Bootstrap.Error != "" {
+		t.Errorf("Bootstrap = %+v, want 1 command and no error", inst.Bootstrap)
+	}
+	if _, err := os.Stat(filepath.Join(inst.WorktreePath, "bootstrapped")); err != nil {
+		t.Errorf("bootstrap command did not run in the worktree: %v", err)
+	}
+
+	records, err := stats.NewStore(o.claudioDir).Query(BootstrapRecordKind, nil)
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(records) != 1 || records[0].Subject != "inst-1" || records[0].Session != "sess-1" {
+		t.Fatalf("records = %+v, want one record for inst-1 in sess-1", records)
+	}
+	if records[0].Values["success"] != 1 {
+		t.Errorf("success = %v, want 1", records[0].Values["success"])
+	}
+
+	// A restart of the same instance must not bootstrap again.
+	first := inst.Bootstrap
+	o.bootstrapWorktree(inst)
+	if inst.Bootstrap != first {
+		t.Error("bootstrap should run only once per instance")
+	}
+}
+
+func TestBootstrapWorktree_FailureIsRecorded(t *testing.T) {
+	o := newBootstrapTestOrchestrator(t, config.BootstrapConfig{
+		Enabled:  true,
+		Commands: []string{"exit 1"},
+	})
+	inst := &Instance{ID: "inst-1", WorktreePath: t.TempDir()}
+
+	o.bootstrapWorktree(inst)
+
+	if inst.Bootstrap == nil || inst.Bootstrap.Error == "" {
+		t.Fatalf("Bootstrap = %+v, want recorded error", inst.Bootstrap)
+	}
+}
+
+func TestBootstrapWorktree_Disabled(t *testing.T) {
+	o := newBootstrapTestOrchestrator(t, config.BootstrapConfig{
+		Commands: []string{"touch bootstrapped"},
+	})
+	inst := &Instance{ID: "inst-1", WorktreePath: t.TempDir()}
+
+	o.bootstrapWorktree(inst)
+
+	if inst.Bootstrap != nil {
+		t.Errorf("Bootstrap = %+v, want nil when disabled", inst.Bootstrap)
+	}
+}
@@ -1,6 +1,7 @@
 package orchestrator
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/Iron-Ham/claudio/internal/config"
@@ -141,6 +142,44 @@ func TestGenerateBranchName(t *testing.T) {
 	}
 }
 
+func TestTaskBranchName(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		taskID  string
+		attempt int
+		want    string
+	}{
+		{"first attempt", "claudio", "task-1", 0, "claudio/plan-abc123-task-1"},
+		{"retry attempt", "claudio", "task-1", 2, "claudio/plan-abc123-task-1-retry2"},
+		{"custom prefix", "Iron-Ham", "setup_db", 0, "Iron-Ham/plan-abc123-setup_db"},
+		{"empty prefix uses fallback", "", "t1", 0, "claudio/plan-abc123-t1"},
+		{"unsafe id gets hash suffix", "claudio", "task 1", 0, "claudio/plan-abc123-task-1-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Orchestrator{config: &config.Config{Branch: config.BranchConfig{Prefix: tt.prefix}}}
+			got := o.TaskBranchName("abc123", tt.taskID, tt.attempt)
+			if strings.HasSuffix(tt.want, "-") {
+				if !strings.HasPrefix(got, tt.want) || len(got) != len(tt.want)+6 {
+					t.Errorf("TaskBranchName(%q) = %q, want %q plus a 6-char hash", tt.taskID, got, tt.want)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("TaskBranchName(%q, %d) = %q, want %q", tt.taskID, tt.attempt, got, tt.want)
+			}
+		})
+	}
+
+	// IDs that sanitize to the same text must still get distinct branches.
+	o := &Orchestrator{config: &config.Config{}}
+	if o.TaskBranchName("h", "task 1", 0) == o.TaskBranchName("h", "task/1", 0) {
+		t.Error("distinct task IDs produced the same branch name")
+	}
+}
+
 func TestBranchPrefix(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -3,10 +3,16 @@ package bridgewire
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"os/exec"
+	"strings"
+	"sync"
 
 	"github.com/Iron-Ham/claudio/internal/ai"
 	"github.com/Iron-Ham/claudio/internal/bridge"
+	"github.com/Iron-Ham/claudio/internal/mailbox"
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/verify"
 )
 
@@ -20,6 +26,11 @@ type instanceFactory struct {
 	orch           *orchestrator.Orchestrator
 	session        *orchestrator.Session
 	startOverrides ai.StartOptions
+	repoDir        string // Checkout instances are created in ("" = session repository)
+
+	mu      sync.Mutex
+	nodeEnv map[string]map[string]string // instanceID → placed node's environment
+	models  map[string]string            // instanceID → model selected for the task attempt
 }
 
 // NewInstanceFactory creates a bridge.InstanceFactory backed by the given Orchestrator.
@@ -35,9 +46,19 @@ func NewInstanceFactoryWithOverrides(orch *orchestrator.Orchestrator, session *o
 	return &instanceFactory{orch: orch, session: session, startOverrides: overrides}
 }
 
+// NewInstanceFactoryForRepo creates a bridge.InstanceFactory whose instances
+// have their worktrees in the checkout at repoDir rather than the session's
+// repository, for pipeline teams of another repository (ultraplan.repos).
+func NewInstanceFactoryForRepo(orch *orchestrator.Orchestrator, session *orchestrator.Session, repoDir string) bridge.InstanceFactory {
+	return &instanceFactory{orch: orch, session: session, repoDir: repoDir}
+}
+
 // Coverage: CreateInstance and StartInstance wrap *orchestrator.Orchestrator which
 // requires full session/worktree infrastructure; tested via integration tests.
 func (f *instanceFactory) CreateInstance(taskPrompt string) (bridge.Instance, error) {
+	if f.repoDir != "" {
+		return f.createInRepo(taskPrompt, "")
+	}
 	inst, err := f.orch.AddInstance(f.session, taskPrompt)
 	if err != nil {
 		return nil, fmt.Errorf("create instance: %w", err)
@@ -45,17 +66,162 @@ func (f *instanceFactory) CreateInstance(taskPrompt string) (bridge.Instance, er
 	return &orchInstance{inst: inst}, nil
 }
 
+// CreateInstanceWithBackend implements bridge.BackendInstanceFactory for plan
+// tasks that select their own backend (e.g., "tool").
+func (f *instanceFactory) CreateInstanceWithBackend(taskPrompt, backend string) (bridge.Instance, error) {
+	if f.repoDir != "" {
+		return f.createInRepo(taskPrompt, backend)
+	}
+	inst, err := f.orch.AddInstanceWithBackend(f.session, taskPrompt, "", backend)
+	if err != nil {
+		return nil, fmt.Errorf("create %s instance: %w", backend, err)
+	}
+	return &orchInstance{inst: inst}, nil
+}
+
+// CreateTaskInstance implements bridge.ReplayableInstanceFactory: the
+// instance's branch is derived from the plan hash, task ID, and attempt, and
+// an existing branch from an earlier run of the same plan is reattached.
+func (f *instanceFactory) CreateTaskInstance(spec bridge.TaskInstanceSpec) (bridge.Instance, bool, error) {
+	branch := f.orch.TaskBranchName(spec.PlanHash, spec.TaskID, spec.Attempt)
+	var (
+		inst   *orchestrator.Instance
+		reused bool
+		err    error
+	)
+	if f.repoDir != "" {
+		inst, reused, err = f.orch.AddOrReattachInstanceInRepo(f.session, spec.Prompt, f.repoDir, branch, spec.Backend)
+	} else {
+		inst, reused, err = f.orch.AddOrReattachInstance(f.session, spec.Prompt, branch, spec.Backend)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("create instance for task %s: %w", spec.TaskID, err)
+	}
+	f.setModel(inst.ID, spec.Model)
+	return &orchInstance{inst: inst}, reused, nil
+}
+
+// createInRepo creates an instance with a generated branch in the factory's
+// repository.
+func (f *instanceFactory) createInRepo(taskPrompt, backend string) (bridge.Instance, error) {
+	inst, _, err := f.orch.AddOrReattachInstanceInRepo(f.session, taskPrompt, f.repoDir, "", backend)
+	if err != nil {
+		return nil, fmt.Errorf("create instance in %s: %w", f.repoDir, err)
+	}
+	return &orchInstance{inst: inst}, nil
+}
+
+// CreatePlacedInstance implements bridge.PlacedInstanceFactory: the instance
+// is created as usual, recorded as running on spec.Node, and started with
+// the node's environment. The environment is kept in memory only so node
+// credentials are never written to session state.
+func (f *instanceFactory) CreatePlacedInstance(spec bridge.TaskInstanceSpec) (bridge.Instance, bool, error) {
+	var (
+		inst   bridge.Instance
+		reused bool
+		err    error
+	)
+	switch {
+	case spec.PlanHash != "":
+		inst, reused, err = f.CreateTaskInstance(spec)
+	case spec.Backend != "":
+		inst, err = f.CreateInstanceWithBackend(spec.Prompt, spec.Backend)
+	default:
+		inst, err = f.CreateInstance(spec.Prompt)
+	}
+	if err != nil {
+		return inst, reused, err
+	}
+	f.setModel(inst.ID(), spec.Model)
+	if spec.Node == nil {
+		return inst, reused, nil
+	}
+
+	f.orch.SetInstanceNode(inst.ID(), spec.Node.ID)
+	f.mu.Lock()
+	if f.nodeEnv == nil {
+		f.nodeEnv = make(map[string]map[string]string)
+	}
+	f.nodeEnv[inst.ID()] = spec.Node.Env
+	f.mu.Unlock()
+	return inst, reused, nil
+}
+
 func (f *instanceFactory) StartInstance(inst bridge.Instance) error {
 	orchInst := f.orch.GetInstance(inst.ID())
 	if orchInst == nil {
 		return fmt.Errorf("start instance: %q not found", inst.ID())
 	}
-	if err := f.orch.StartInstanceWithOverrides(orchInst, f.startOverrides); err != nil {
+	if err := f.orch.StartInstanceWithOverrides(orchInst, f.overridesFor(inst.ID())); err != nil {
 		return fmt.Errorf("start instance %q: %w", inst.ID(), err)
 	}
 	return nil
 }
 
+// PauseInstance implements bridge.InstancePauser: the backend is stopped so
+// it stops working and spending, and the instance is marked paused. Its
+// worktree and branch are kept for the task's next attempt.
+func (f *instanceFactory) PauseInstance(inst bridge.Instance) error {
+	orchInst := f.orch.GetInstance(inst.ID())
+	if orchInst == nil {
+		return fmt.Errorf("pause instance: %q not found", inst.ID())
+	}
+	if err := f.orch.StopInstance(orchInst); err != nil {
+		return fmt.Errorf("pause instance %q: %w", inst.ID(), err)
+	}
+	return f.orch.PauseInstance(inst.ID())
+}
+
+// FindInstance implements bridge.InstanceFinder. An instance is alive while
+// its tmux session exists, which outlives a restart of the session.
+func (f *instanceFactory) FindInstance(id string) (bridge.Instance, bool) {
+	inst := f.orch.GetInstance(id)
+	if inst == nil {
+		return nil, false
+	}
+	mgr := f.orch.GetInstanceManager(id)
+	return &orchInstance{inst: inst}, mgr != nil && mgr.TmuxSessionExists()
+}
+
+// setModel records the model selected for an instance's task attempt.
+func (f *instanceFactory) setModel(instanceID, model string) {
+	if model == "" {
+		return
+	}
+	f.mu.Lock()
+	if f.models == nil {
+		f.models = make(map[string]string)
+	}
+	f.models[instanceID] = model
+	f.mu.Unlock()
+}
+
+// overridesFor returns the start overrides for an instance, adding the
+// model selected for its task and the environment of the node it was placed
+// on.
+func (f *instanceFactory) overridesFor(instanceID string) ai.StartOptions {
+	f.mu.Lock()
+	env := f.nodeEnv[instanceID]
+	model := f.models[instanceID]
+	f.mu.Unlock()
+
+	overrides := f.startOverrides
+	if model != "" {
+		overrides.Model = model
+	}
+	if len(env) == 0 {
+		return overrides
+	}
+
+	merged := maps.Clone(overrides.Env)
+	if merged == nil {
+		merged = make(map[string]string, len(env))
+	}
+	maps.Copy(merged, env)
+	overrides.Env = merged
+	return overrides
+}
+
 // orchInstance adapts an orchestrator.Instance to bridge.Instance.
 type orchInstance struct {
 	inst *orchestrator.Instance
@@ -81,6 +247,26 @@ func (c *completionChecker) CheckCompletion(worktreePath string) (bool, error) {
 	return c.verifier.CheckCompletionFile(worktreePath)
 }
 
+// ReadTaskProposals returns the follow-up tasks proposed in the worktree's
+// completion file. It implements bridge.TaskProposalReader.
+func (c *completionChecker) ReadTaskProposals(worktreePath string) ([]mailbox.TaskProposal, error) {
+	completion, err := orchestrator.ParseTaskCompletionFile(worktreePath)
+	if err != nil {
+		return nil, err
+	}
+	return completion.ProposedTasks, nil
+}
+
+// HeadCommit returns the SHA of the worktree's HEAD commit. It implements
+// bridge.HeadReader.
+func (c *completionChecker) HeadCommit(worktreePath string) (string, error) {
+	out, err := exec.Command("git", "-C", worktreePath, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("read HEAD of %s: %w", worktreePath, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 func (c *completionChecker) VerifyWork(taskID, instanceID, worktreePath, baseBranch string) (bool, int, error) {
 	result := c.verifier.VerifyTaskWork(taskID, instanceID, worktreePath, baseBranch, &verify.TaskVerifyOptions{})
 	if result.Error != "" {
@@ -89,6 +275,59 @@ func (c *completionChecker) VerifyWork(taskID, instanceID, worktreePath, baseBra
 	return result.Success, result.CommitCount, nil
 }
 
+// VerifyToolWork implements bridge.ToolWorkVerifier: the tool runner's
+// completion report decides the outcome and failures are not retried.
+func (c *completionChecker) VerifyToolWork(taskID, instanceID, worktreePath, baseBranch string) (bool, int, error) {
+	result := c.verifier.VerifyTaskWork(taskID, instanceID, worktreePath, baseBranch, &verify.TaskVerifyOptions{Deterministic: true})
+	if result.Error != "" {
+		return result.Success, result.CommitCount, errors.New(result.Error)
+	}
+	return result.Success, result.CommitCount, nil
+}
+
+// criteriaVerifier adds each task's declared completion criteria to the
+// options the wrapped Verifier checks its work with.
+type criteriaVerifier struct {
+	orchestrator.Verifier
+	criteria map[string]*types.CompletionCriteria
+}
+
+func (v *criteriaVerifier) VerifyTaskWork(taskID, instanceID, worktreePath, baseBranch string, opts *verify.TaskVerifyOptions) verify.TaskCompletionResult {
+	if c := v.criteria[taskID]; c != nil {
+		var withCriteria verify.TaskVerifyOptions
+		if opts != nil {
+			withCriteria = *opts
+		}
+		withCriteria.Criteria = c
+		opts = &withCriteria
+	}
+	return v.Verifier.VerifyTaskWork(taskID, instanceID, worktreePath, baseBranch, opts)
+}
+
+// planCriteria returns the completion criteria of every task in plan that
+// declares any, by task ID.
+func planCriteria(plan *orchestrator.PlanSpec) map[string]*types.CompletionCriteria {
+	criteria := make(map[string]*types.CompletionCriteria)
+	for _, t := range plan.Tasks {
+		if !t.Criteria.IsEmpty() {
+			criteria[t.ID] = t.Criteria
+		}
+	}
+	return criteria
+}
+
+// planContextPacks returns the context pack of every task in plan that
+// declares one, by task ID.
+func planContextPacks(plan *orchestrator.PlanSpec) map[string]*types.ContextPack {
+	packs := make(map[string]*types.ContextPack)
+	for _, t := range plan.Tasks {
+		if !t.ContextPack.IsEmpty() {
+			packs[t.ID] = t.ContextPack
+		}
+	}
+	return packs
+}
+
 // --- SessionRecorder adapter ---
 
 // SessionRecorderDeps defines the coordinator operations needed by the session recorder.
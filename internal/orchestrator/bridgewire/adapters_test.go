@@ -1,11 +1,15 @@
 package bridgewire
 
 import (
+	"os"
+	"os/exec"
+	"strings"
 	"testing"
 
 	"github.com/Iron-Ham/claudio/internal/ai"
 	"github.com/Iron-Ham/claudio/internal/bridge"
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/verify"
 )
 
@@ -40,6 +44,63 @@ func TestNewCompletionChecker_CheckCompletion(t *testing.T) {
 	}
 }
 
+func TestNewCompletionChecker_ReadTaskProposals(t *testing.T) {
+	reader, ok := NewCompletionChecker(&mockVerifier{}).(bridge.TaskProposalReader)
+	if !ok {
+		t.Fatal("completion checker does not implement bridge.TaskProposalReader")
+	}
+
+	wt := t.TempDir()
+	report := `{"task_id": "t1", "status": "complete", "proposed_tasks": [{"title": "Remove dead code", "files": ["old.go"]}]}`
+	if err := os.WriteFile(orchestrator.TaskCompletionFilePath(wt), []byte(report), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	proposals, err := reader.ReadTaskProposals(wt)
+	if err != nil {
+		t.Fatalf("ReadTaskProposals: %v", err)
+	}
+	if len(proposals) != 1 || proposals[0].Title != "Remove dead code" || proposals[0].Files[0] != "old.go" {
+		t.Errorf("proposals = %+v", proposals)
+	}
+
+	if _, err := reader.ReadTaskProposals(t.TempDir()); err == nil {
+		t.Error("expected error without a completion file")
+	}
+}
+
+func TestNewCompletionChecker_HeadCommit(t *testing.T) {
+	reader, ok := NewCompletionChecker(&mockVerifier{}).(bridge.HeadReader)
+	if !ok {
+		t.Fatal("completion checker does not implement bridge.HeadReader")
+	}
+
+	wt := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", wt}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	want, err := exec.Command("git", "-C", wt, "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sha, err := reader.HeadCommit(wt)
+	if err != nil {
+		t.Fatalf("HeadCommit: %v", err)
+	}
+	if sha != strings.TrimSpace(string(want)) {
+		t.Errorf("HeadCommit = %q, want %q", sha, want)
+	}
+
+	if _, err := reader.HeadCommit(t.TempDir()); err == nil {
+		t.Error("expected error outside a repository")
+	}
+}
+
 func TestNewCompletionChecker_VerifyWorkSuccess(t *testing.T) {
 	v := &mockVerifier{
 		verifyResult: verify.TaskCompletionResult{
@@ -83,6 +144,46 @@ func TestNewCompletionChecker_VerifyWorkFailure(t *testing.T) {
 	}
 }
 
+// optsRecordingVerifier records the options VerifyTaskWork is called with.
+type optsRecordingVerifier struct {
+	mockVerifier
+	opts *verify.TaskVerifyOptions
+}
+
+func (v *optsRecordingVerifier) VerifyTaskWork(taskID, instanceID, worktreePath, baseBranch string, opts *verify.TaskVerifyOptions) verify.TaskCompletionResult {
+	v.opts = opts
+	return v.verifyResult
+}
+
+func TestCriteriaVerifier(t *testing.T) {
+	plan := &orchestrator.PlanSpec{Tasks: []orchestrator.PlannedTask{
+		{ID: "t1", Criteria: &types.CompletionCriteria{Files: []string{"login.go"}}},
+		{ID: "t2", Criteria: &types.CompletionCriteria{}},
+		{ID: "t3"},
+	}}
+	criteria := planCriteria(plan)
+	if len(criteria) != 1 || criteria["t1"] == nil {
+		t.Fatalf("planCriteria() = %v, want only t1", criteria)
+	}
+
+	inner := &optsRecordingVerifier{}
+	checker := NewCompletionChecker(&criteriaVerifier{Verifier: inner, criteria: criteria})
+
+	if _, _, err := checker.(*completionChecker).VerifyToolWork("t1", "inst-1", "/tmp/wt", "main"); err != nil {
+		t.Fatal(err)
+	}
+	if inner.opts == nil || !inner.opts.Deterministic || inner.opts.Criteria != criteria["t1"] {
+		t.Errorf("opts = %+v, want the tool options plus t1's criteria", inner.opts)
+	}
+
+	if _, _, err := checker.VerifyWork("t3", "inst-1", "/tmp/wt", "main"); err != nil {
+		t.Fatal(err)
+	}
+	if inner.opts == nil || inner.opts.Criteria != nil {
+		t.Errorf("opts = %+v, want no criteria for t3", inner.opts)
+	}
+}
+
 func TestNewSessionRecorder(t *testing.T) {
 	var assignedTask, assignedInst string
 	var sentinelTask, sentinelInst string
@@ -149,6 +250,67 @@ func TestNewInstanceFactory(t *testing.T) {
 	}
 }
 
+func TestInstanceFactory_ImplementsReplayableInstanceFactory(t *testing.T) {
+	f := NewInstanceFactory(&orchestrator.Orchestrator{}, &orchestrator.Session{})
+	if _, ok := f.(bridge.ReplayableInstanceFactory); !ok {
+		t.Error("instance factory should implement bridge.ReplayableInstanceFactory")
+	}
+}
+
+func TestInstanceFactory_ImplementsPlacedInstanceFactory(t *testing.T) {
+	f := NewInstanceFactory(&orchestrator.Orchestrator{}, &orchestrator.Session{})
+	if _, ok := f.(bridge.PlacedInstanceFactory); !ok {
+		t.Error("instance factory should implement bridge.PlacedInstanceFactory")
+	}
+}
+
+func TestInstanceFactory_ImplementsInstancePauser(t *testing.T) {
+	f := NewInstanceFactory(&orchestrator.Orchestrator{}, &orchestrator.Session{})
+	if _, ok := f.(bridge.InstancePauser); !ok {
+		t.Error("instance factory should implement bridge.InstancePauser")
+	}
+}
+
+func TestInstanceFactory_OverridesForPlacedNode(t *testing.T) {
+	f := &instanceFactory{
+		startOverrides: ai.StartOptions{
+			Model: "opus",
+			Env:   map[string]string{"SHARED": "1"},
+		},
+		nodeEnv: map[string]map[string]string{
+			"inst-1": {"ANTHROPIC_BASE_URL": "http://gpu-1:8000"},
+		},
+	}
+
+	got := f.overridesFor("inst-1")
+	if got.Model != "opus" {
+		t.Errorf("Model = %q, want role override preserved", got.Model)
+	}
+	if got.Env["ANTHROPIC_BASE_URL"] != "http://gpu-1:8000" || got.Env["SHARED"] != "1" {
+		t.Errorf("Env = %v, want node and role env merged", got.Env)
+	}
+	if _, leaked := f.startOverrides.Env["ANTHROPIC_BASE_URL"]; leaked {
+		t.Error("node env leaked into the shared start overrides")
+	}
+
+	if unplaced := f.overridesFor("inst-2"); unplaced.Env["ANTHROPIC_BASE_URL"] != "" {
+		t.Errorf("unplaced instance Env = %v, want no node env", unplaced.Env)
+	}
+}
+
+func TestInstanceFactory_OverridesForTaskModel(t *testing.T) {
+	f := &instanceFactory{startOverrides: ai.StartOptions{Model: "sonnet"}}
+	f.setModel("inst-1", "opus")
+	f.setModel("inst-2", "")
+
+	if got := f.overridesFor("inst-1").Model; got != "opus" {
+		t.Errorf("Model = %q, want the task's model", got)
+	}
+	if got := f.overridesFor("inst-2").Model; got != "sonnet" {
+		t.Errorf("Model = %q, want role override without a task model", got)
+	}
+}
+
 func TestOrchInstance_Methods(t *testing.T) {
 	inst := &orchInstance{inst: &orchestrator.Instance{
 		ID:           "test-id",
@@ -3,6 +3,7 @@ package bridgewire
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 type PipelineExecutor struct {
 	factory              bridge.InstanceFactory
 	factoryWithOverrides func(ai.StartOptions) bridge.InstanceFactory
+	factoryForRepo       func(repo string) bridge.InstanceFactory
 	roleOverrides        map[team.Role]ai.StartOptions
 	checker              bridge.CompletionChecker
 	bus                  *event.Bus
@@ -30,6 +32,7 @@ type PipelineExecutor struct {
 
 	pipe         *pipeline.Pipeline
 	bridgeOpts   []bridge.Option
+	workStealing bool
 	ctx          context.Context
 	cancel       context.CancelFunc
 	mu           sync.Mutex
@@ -56,6 +59,16 @@ type PipelineExecutorConfig struct {
 	// When a team's role has an entry here and FactoryWithOverrides is set,
 	// a dedicated factory with those overrides is created for that team.
 	RoleOverrides map[team.Role]ai.StartOptions
+
+	// FactoryForRepo creates a factory whose instances run in the named
+	// repository. It is consulted for teams with a non-empty Spec.Repo (from
+	// multi-repo plans) and takes precedence over role overrides. When nil,
+	// every team uses the primary repository.
+	FactoryForRepo func(repo string) bridge.InstanceFactory
+
+	// WorkStealing lets a team whose tasks are done run ready tasks from
+	// other execution teams in the same repository (ultraplan.work_stealing).
+	WorkStealing bool
 }
 
 // NewPipelineExecutor creates a PipelineExecutor that will attach bridges
@@ -83,6 +96,7 @@ func NewPipelineExecutor(cfg PipelineExecutorConfig) (*PipelineExecutor, error)
 	return &PipelineExecutor{
 		factory:              cfg.Factory,
 		factoryWithOverrides: cfg.FactoryWithOverrides,
+		factoryForRepo:       cfg.FactoryForRepo,
 		roleOverrides:        cfg.RoleOverrides,
 		checker:              cfg.Checker,
 		bus:                  cfg.Bus,
@@ -90,6 +104,7 @@ func NewPipelineExecutor(cfg PipelineExecutorConfig) (*PipelineExecutor, error)
 		recorder:             cfg.Recorder,
 		logger:               cfg.Logger,
 		bridgeOpts:           cfg.BridgeOpts,
+		workStealing:         cfg.WorkStealing,
 	}, nil
 }
 
@@ -99,7 +114,8 @@ func NewPipelineExecutor(cfg PipelineExecutorConfig) (*PipelineExecutor, error)
 //
 // roleOverrides maps team roles to per-invocation CLI flag overrides. When a
 // team's role has an entry, a dedicated factory with those overrides is created
-// for that team's bridge. Pass nil for no role-specific overrides.
+// for that team's bridge. Pass nil for no role-specific overrides. bridgeOpts
+// are forwarded to every bridge the executor creates.
 func NewPipelineExecutorFromOrch(
 	orch *orchestrator.Orchestrator,
 	session *orchestrator.Session,
@@ -109,6 +125,7 @@ func NewPipelineExecutorFromOrch(
 	recorder bridge.SessionRecorder,
 	logger *logging.Logger,
 	roleOverrides map[team.Role]ai.StartOptions,
+	bridgeOpts ...bridge.Option,
 ) (*PipelineExecutor, error) {
 	return NewPipelineExecutor(PipelineExecutorConfig{
 		Factory: NewInstanceFactory(orch, session),
@@ -121,9 +138,28 @@ func NewPipelineExecutorFromOrch(
 		Pipeline:      pipe,
 		Recorder:      recorder,
 		Logger:        logger,
+		BridgeOpts:    bridgeOpts,
 	})
 }
 
+// repoFactories returns a FactoryForRepo creating instances in the checkout
+// repos maps a repository name to. Relative checkout paths are resolved
+// against the session's repository. A name missing from repos is logged and
+// its team runs in the session's repository.
+func repoFactories(orch *orchestrator.Orchestrator, session *orchestrator.Session, repos map[string]string, logger *logging.Logger) func(string) bridge.InstanceFactory {
+	return func(repo string) bridge.InstanceFactory {
+		dir, ok := repos[repo]
+		if !ok {
+			logger.Warn("bridgewire: repository not in ultraplan.repos, using the session repository", "repo", repo)
+			return NewInstanceFactory(orch, session)
+		}
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(orch.BaseDir(), dir)
+		}
+		return NewInstanceFactoryForRepo(orch, session, dir)
+	}
+}
+
 // Start subscribes to pipeline phase change events and begins attaching
 // bridges when the execution phase starts.
 func (pe *PipelineExecutor) Start(ctx context.Context) error {
@@ -251,7 +287,13 @@ func (pe *PipelineExecutor) attachBridges() {
 	}
 
 	statuses := mgr.AllStatuses()
-	opts := append([]bridge.Option{bridge.WithLogger(pe.logger)}, pe.bridgeOpts...)
+	opts := append([]bridge.Option{
+		bridge.WithLogger(pe.logger),
+		bridge.WithContractPublisher(mgr),
+	}, pe.bridgeOpts...)
+	if pe.workStealing {
+		opts = append(opts, bridge.WithWorkStealing(mgr))
+	}
 
 	for _, status := range statuses {
 		if status.Role != team.RoleExecution {
@@ -264,10 +306,13 @@ func (pe *PipelineExecutor) attachBridges() {
 			continue
 		}
 
-		// Use a per-team factory with role-specific overrides when available,
-		// otherwise fall back to the shared default factory.
+		// Use a per-repo factory for multi-repo teams, then a per-team factory
+		// with role-specific overrides when available, otherwise fall back to
+		// the shared default factory.
 		f := pe.factory
-		if pe.factoryWithOverrides != nil {
+		if repo := t.Spec().Repo; repo != "" && pe.factoryForRepo != nil {
+			f = pe.factoryForRepo(repo)
+		} else if pe.factoryWithOverrides != nil {
 			if overrides, ok := pe.roleOverrides[status.Role]; ok {
 				f = pe.factoryWithOverrides(overrides)
 			}
@@ -13,6 +13,8 @@ import (
 	"github.com/Iron-Ham/claudio/internal/bridge"
 	"github.com/Iron-Ham/claudio/internal/coordination"
 	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	"github.com/Iron-Ham/claudio/internal/pipeline"
 	"github.com/Iron-Ham/claudio/internal/team"
 	"github.com/Iron-Ham/claudio/internal/ultraplan"
@@ -1750,3 +1752,32 @@ func TestPipelineExecutorConfig_RoleOverrides_Stored(t *testing.T) {
 			pe.roleOverrides[team.RolePlanning].PermissionMode, "plan")
 	}
 }
+
+func TestRepoFactories(t *testing.T) {
+	orch := &orchestrator.Orchestrator{}
+	sess := &orchestrator.Session{}
+	forRepo := repoFactories(orch, sess, map[string]string{
+		"backend":  "/src/backend",
+		"frontend": "web",
+	}, logging.NopLogger())
+
+	tests := []struct {
+		repo    string
+		wantDir string
+	}{
+		{repo: "backend", wantDir: "/src/backend"},
+		{repo: "frontend", wantDir: "web"}, // relative to the (empty) base dir
+		{repo: "unknown", wantDir: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.repo, func(t *testing.T) {
+			f, ok := forRepo(tt.repo).(*instanceFactory)
+			if !ok {
+				t.Fatalf("factory for %q is not an *instanceFactory", tt.repo)
+			}
+			if f.repoDir != tt.wantDir {
+				t.Errorf("repoDir = %q, want %q", f.repoDir, tt.wantDir)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package bridgewire
+
+import (
+	"sync"
+
+	"github.com/Iron-Ham/claudio/internal/bridge"
+	"github.com/Iron-Ham/claudio/internal/experiment"
+	"github.com/Iron-Ham/claudio/internal/logging"
+)
+
+// experimentRecorder decorates a bridge.SessionRecorder to record prompt
+// experiment outcomes for every task attempt. A failure after the sentinel
+// file was detected is counted as a verification failure; earlier failures
+// (instance creation, completion-check errors) are counted as plain failures.
+type experimentRecorder struct {
+	inner   bridge.SessionRecorder
+	tracker *experiment.Tracker
+	cost    func(instanceID string) float64
+	logger  *logging.Logger
+
+	mu        sync.Mutex
+	instances map[string]string // taskID → current instanceID
+	finishing map[string]bool   // taskID → sentinel detected for current attempt
+}
+
+// newExperimentRecorder wraps inner so attempt outcomes are also recorded on
+// tracker. cost returns the accumulated cost of an instance; nil means cost
+// is not tracked.
+func newExperimentRecorder(inner bridge.SessionRecorder, tracker *experiment.Tracker, cost func(string) float64, logger *logging.Logger) *experimentRecorder {
+	if logger == nil {
+		logger = logging.NopLogger()
+	}
+	return &experimentRecorder{
+		inner:     inner,
+		tracker:   tracker,
+		cost:      cost,
+		logger:    logger,
+		instances: make(map[string]string),
+		finishing: make(map[string]bool),
+	}
+}
+
+func (r *experimentRecorder) AssignTask(taskID, instanceID string) {
+	r.mu.Lock()
+	r.instances[taskID] = instanceID
+	delete(r.finishing, taskID)
+	r.mu.Unlock()
+	r.inner.AssignTask(taskID, instanceID)
+}
+
+func (r *experimentRecorder) RecordSentinelDetected(taskID, instanceID string) {
+	r.mu.Lock()
+	r.finishing[taskID] = true
+	r.mu.Unlock()
+	r.inner.RecordSentinelDetected(taskID, instanceID)
+}
+
+func (r *experimentRecorder) RecordCompletion(taskID string, commitCount int) {
+	r.inner.RecordCompletion(taskID, commitCount)
+	r.record(taskID, experiment.Outcome{Success: true})
+}
+
+func (r *experimentRecorder) RecordFailure(taskID, reason string) {
+	r.inner.RecordFailure(taskID, reason)
+	r.mu.Lock()
+	verifying := r.finishing[taskID]
+	r.mu.Unlock()
+	r.record(taskID, experiment.Outcome{VerificationFailed: verifying})
+}
+
+// record fills in the attempt cost and appends the outcome. Errors are logged
+// rather than surfaced: experiment bookkeeping must never affect execution.
+func (r *experimentRecorder) record(taskID string, o experiment.Outcome) {
+	r.mu.Lock()
+	instanceID := r.instances[taskID]
+	delete(r.instances, taskID)
+	delete(r.finishing, taskID)
+	r.mu.Unlock()
+
+	if r.cost != nil && instanceID != "" {
+		o.Cost = r.cost(instanceID)
+	}
+	if err := r.tracker.RecordAttempt(taskID, o); err != nil {
+		r.logger.Warn("bridgewire: failed to record experiment outcome",
+			"task", taskID, "error", err)
+	}
+}
@@ -0,0 +1,61 @@
+package bridgewire
+
+import (
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/experiment"
+	"github.com/Iron-Ham/claudio/internal/stats"
+)
+
+func TestExperimentRecorder_RecordsAttempts(t *testing.T) {
+	exp, err := experiment.New(config.ExperimentConfig{
+		Name:     "always",
+		Variants: []config.ExperimentVariantConfig{{Name: "v1", Template: "{{.Prompt}}"}},
+	})
+	if err != nil {
+		t.Fatalf("experiment.New: %v", err)
+	}
+
+	store := stats.NewStore(t.TempDir())
+	tracker := experiment.NewTracker(store, "sess", []experiment.Experiment{exp})
+	tracker.Transform(experiment.PhaseTask, "t1", "Task 1", "prompt")
+
+	var calls []string
+	inner := NewSessionRecorder(SessionRecorderDeps{
+		OnComplete: func(taskID string, _ int) { calls = append(calls, "complete:"+taskID) },
+		OnFailure:  func(taskID, _ string) { calls = append(calls, "failure:"+taskID) },
+	})
+	costs := map[string]float64{"inst-1": 0.5, "inst-2": 0.25}
+	rec := newExperimentRecorder(inner, tracker, func(id string) float64 { return costs[id] }, nil)
+
+	// First attempt fails verification; the retry succeeds.
+	rec.AssignTask("t1", "inst-1")
+	rec.RecordSentinelDetected("t1", "inst-1")
+	rec.RecordFailure("t1", "no commits")
+	rec.AssignTask("t1", "inst-2")
+	rec.RecordCompletion("t1", 1)
+
+	if len(calls) != 2 || calls[0] != "failure:t1" || calls[1] != "complete:t1" {
+		t.Errorf("inner recorder calls = %v, want [failure:t1 complete:t1]", calls)
+	}
+
+	summaries, err := experiment.Report(store, "always")
+	if err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+	if len(summaries) != 1 {
+		t.Fatalf("len(summaries) = %d, want 1", len(summaries))
+	}
+	s := summaries[0]
+	if s.Variant != "v1" {
+		t.Errorf("Variant = %q, want %q", s.Variant, "v1")
+	}
+	if s.Attempts != 2 || s.Successes != 1 || s.VerificationFails != 1 {
+		t.Errorf("attempts/successes/verifyFails = %d/%d/%d, want 2/1/1",
+			s.Attempts, s.Successes, s.VerificationFails)
+	}
+	if s.TotalCost != 0.75 {
+		t.Errorf("TotalCost = %v, want 0.75", s.TotalCost)
+	}
+}
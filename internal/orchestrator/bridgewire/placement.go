@@ -0,0 +1,44 @@
+package bridgewire
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/bridge"
+	"github.com/Iron-Ham/claudio/internal/config"
+)
+
+// PlacerFromConfig builds the node placer for a pipeline from the
+// ultraplan.placement config. It returns a nil Placer when no nodes are
+// configured, which leaves instances unplaced.
+func PlacerFromConfig(cfg config.PlacementConfig) (*bridge.Placer, error) {
+	if len(cfg.Nodes) == 0 {
+		return nil, nil
+	}
+
+	nodes := make([]bridge.Node, 0, len(cfg.Nodes))
+	for _, nc := range cfg.Nodes {
+		node := bridge.Node{
+			ID:       nc.ID,
+			Capacity: nc.Capacity,
+			Backends: nc.Backends,
+		}
+		for _, kv := range nc.Env {
+			key, value, ok := strings.Cut(kv, "=")
+			if !ok || key == "" {
+				return nil, fmt.Errorf("bridgewire: node %q: env entry %q must be KEY=VALUE", nc.ID, kv)
+			}
+			if node.Env == nil {
+				node.Env = make(map[string]string, len(nc.Env))
+			}
+			node.Env[key] = value
+		}
+		nodes = append(nodes, node)
+	}
+
+	placer, err := bridge.NewPlacer(nodes, bridge.PlacementPolicy(cfg.Policy))
+	if err != nil {
+		return nil, fmt.Errorf("bridgewire: %w", err)
+	}
+	return placer, nil
+}
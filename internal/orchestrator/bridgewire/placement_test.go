@@ -0,0 +1,52 @@
+package bridgewire
+
+import (
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+)
+
+func TestPlacerFromConfig(t *testing.T) {
+	t.Run("no nodes", func(t *testing.T) {
+		placer, err := PlacerFromConfig(config.PlacementConfig{Policy: "spread"})
+		if err != nil || placer != nil {
+			t.Errorf("PlacerFromConfig() = (%v, %v), want (nil, nil)", placer, err)
+		}
+	})
+
+	t.Run("parses env", func(t *testing.T) {
+		placer, err := PlacerFromConfig(config.PlacementConfig{
+			Policy: "pack",
+			Nodes: []config.NodeConfig{{
+				ID:       "gpu-1",
+				Capacity: 2,
+				Env:      []string{"ANTHROPIC_BASE_URL=http://gpu-1:8000/v1?a=b", "EMPTY="},
+			}},
+		})
+		if err != nil {
+			t.Fatalf("PlacerFromConfig() error = %v", err)
+		}
+		node, err := placer.Place("t1", "")
+		if err != nil {
+			t.Fatalf("Place() error = %v", err)
+		}
+		if got := node.Env["ANTHROPIC_BASE_URL"]; got != "http://gpu-1:8000/v1?a=b" {
+			t.Errorf("ANTHROPIC_BASE_URL = %q", got)
+		}
+		if v, ok := node.Env["EMPTY"]; !ok || v != "" {
+			t.Errorf("EMPTY = (%q, %v), want (\"\", true)", v, ok)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, cfg := range []config.PlacementConfig{
+			{Nodes: []config.NodeConfig{{ID: "gpu-1", Capacity: 1, Env: []string{"NOEQUALS"}}}},
+			{Nodes: []config.NodeConfig{{ID: "gpu-1"}}},
+			{Policy: "random", Nodes: []config.NodeConfig{{ID: "gpu-1", Capacity: 1}}},
+		} {
+			if _, err := PlacerFromConfig(cfg); err == nil {
+				t.Errorf("PlacerFromConfig(%+v) expected error", cfg)
+			}
+		}
+	})
+}
@@ -4,14 +4,22 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"path/filepath"
+	"slices"
+	"time"
 
 	"github.com/Iron-Ham/claudio/internal/ai"
 	"github.com/Iron-Ham/claudio/internal/bridge"
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/coordination"
 	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/experiment"
 	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/Iron-Ham/claudio/internal/mailbox"
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/prompt"
 	"github.com/Iron-Ham/claudio/internal/pipeline"
+	"github.com/Iron-Ham/claudio/internal/stats"
 	"github.com/Iron-Ham/claudio/internal/team"
 	"github.com/Iron-Ham/claudio/internal/ultraplan"
 )
@@ -33,6 +41,69 @@ type PipelineRunnerConfig struct {
 	// When set, execution instances for a given role will use these overrides
 	// for permission mode, model, tool restrictions, etc.
 	RoleOverrides map[team.Role]ai.StartOptions
+
+	// Experiments are prompt A/B experiments applied to task prompts. When
+	// non-empty, variant templates are applied by the bridges and attempt
+	// outcomes are recorded to the stats store under BaseDir/.claudio.
+	Experiments []experiment.Experiment
+
+	// Placer assigns task instances to worker nodes. It is shared by every
+	// bridge in the pipeline so node capacity holds across teams. Nil leaves
+	// instances unplaced.
+	Placer *bridge.Placer
+
+	// EnforceFileClaims installs a pre-commit hook in every task worktree
+	// that rejects commits touching files claimed by another task, from
+	// UltraplanConfig.EnforceFileClaims.
+	EnforceFileClaims bool
+
+	// PriorityAging is how long a ready task waits before its priority is
+	// raised by one level (ultraplan.priority_aging_seconds). Zero disables
+	// aging.
+	PriorityAging time.Duration
+
+	// Preemption lets the adaptive lead pause a low-priority running task
+	// for a more urgent waiting one (ultraplan.preemption). Ignored unless
+	// Enabled.
+	Preemption config.PreemptionConfig
+
+	// Scaling is the budget ceiling and burn rate limit the teams' scaling
+	// policies keep spend within (ultraplan.scaling). A team's own budget
+	// replaces the ceiling.
+	Scaling config.ScalingConfig
+
+	// WorkStealing lets a team whose tasks are done run ready tasks from
+	// other teams (ultraplan.work_stealing).
+	WorkStealing bool
+
+	// Repos maps the repository names of multi-repo plan tasks to their
+	// checkouts (ultraplan.repos). Relative paths are resolved against the
+	// session's repository. Teams for other repositories run in the primary
+	// repository.
+	Repos map[string]string
+
+	// MessageGuard is "hold" to keep instruction-like messages between
+	// instances out of prompts until they are released, or "strip" (or
+	// empty) to remove the suspicious lines (ultraplan.message_guard).
+	MessageGuard string
+
+	// RetrySection returns the prompt section telling a retried task how its
+	// previous attempt failed (ultraplan.retry.augment_prompt). Nil adds none.
+	RetrySection func(taskID string) string
+
+	// ProgressSection returns the prompt section giving a restarted or
+	// retried task the progress an earlier attempt recorded
+	// (ultraplan.progress_summary_minutes). Nil adds none.
+	ProgressSection func(taskID string) string
+
+	// TaskModel returns the model a task's next attempt runs on
+	// (ultraplan.models, ultraplan.retry.final_attempt_model), or "" for the
+	// backend's configured model. Nil keeps the configured model.
+	TaskModel func(taskID string) string
+
+	// Resume restores each team's task queue from the state saved in its
+	// directory under BaseDir, for a session resumed while executing.
+	Resume bool
 }
 
 // PipelineRunner implements orchestrator.ExecutionRunner using the
@@ -72,11 +143,32 @@ func NewPipelineRunner(cfg PipelineRunnerConfig) (*PipelineRunner, error) {
 	}
 
 	// Create the pipeline
+	var pipeOpts []pipeline.PipelineOption
+	if cfg.EnforceFileClaims {
+		pipeOpts = append(pipeOpts, pipeline.WithHubOptions(coordination.WithClaimEnforcement()))
+	}
+	pipeOpts = append(pipeOpts, pipeline.WithHubOptions(coordination.WithPriorityAging(cfg.PriorityAging)))
+	if cfg.MessageGuard == "hold" {
+		pipeOpts = append(pipeOpts, pipeline.WithHubOptions(coordination.WithMessageGuard(mailbox.GuardPolicy{HoldSuspicious: true})))
+	}
+	if cfg.Preemption.Enabled {
+		pipeOpts = append(pipeOpts, pipeline.WithHubOptions(coordination.WithPreemption(
+			time.Duration(cfg.Preemption.WaitSeconds)*time.Second, cfg.Preemption.MinPriorityGap)))
+	}
+	if cfg.Scaling.BudgetCeiling > 0 {
+		pipeOpts = append(pipeOpts, pipeline.WithHubOptions(coordination.WithBudgetCeiling(cfg.Scaling.BudgetCeiling)))
+	}
+	if cfg.Scaling.BurnRateLimit > 0 {
+		pipeOpts = append(pipeOpts, pipeline.WithHubOptions(coordination.WithBurnRateLimit(cfg.Scaling.BurnRateLimit)))
+	}
+	if cfg.Resume {
+		pipeOpts = append(pipeOpts, pipeline.WithHubOptions(coordination.WithQueueResume()))
+	}
 	pipe, err := pipeline.NewPipeline(pipeline.PipelineConfig{
 		Bus:     cfg.Bus,
 		BaseDir: baseDir,
 		Plan:    uplan,
-	})
+	}, pipeOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("bridgewire: create pipeline: %w", err)
 	}
@@ -114,15 +206,119 @@ func NewPipelineRunner(cfg PipelineRunnerConfig) (*PipelineRunner, error) {
 		return nil, fmt.Errorf("bridgewire: write orchestration system prompt: %w", sysErr)
 	}
 	roleOverrides = injectSystemPrompt(roleOverrides, sysPromptPath)
+	roleOverrides = injectPlanEnv(roleOverrides, cfg.Plan.Env)
+
+	// Name task branches from the plan's content so replaying the same plan
+	// reattaches to earlier work instead of duplicating it.
+	recorder := cfg.Recorder
+	bridgeOpts := []bridge.Option{bridge.WithPlanHash(cfg.Plan.ContentHash())}
+	var transforms []bridge.PromptTransform
+	if envSection := prompt.PlanEnvSection(cfg.Plan.Env); envSection != "" {
+		transforms = append(transforms, func(_, _, p string) string {
+			return p + "\n\n" + envSection
+		})
+	}
+	// Check each task's declared completion criteria when verifying its work,
+	// and tell the task what will be checked.
+	verifier := cfg.Verifier
+	if criteria := planCriteria(cfg.Plan); len(criteria) > 0 {
+		if verifier != nil {
+			verifier = &criteriaVerifier{Verifier: verifier, criteria: criteria}
+		}
+		transforms = append(transforms, func(taskID, _, p string) string {
+			if section := prompt.CriteriaSection(criteria[taskID]); section != "" {
+				return p + "\n\n" + section
+			}
+			return p
+		})
+	}
+	// Point tasks at the context pack the bridge copies into their worktree.
+	if packs := planContextPacks(cfg.Plan); len(packs) > 0 {
+		transforms = append(transforms, func(taskID, _, p string) string {
+			if section := prompt.ContextPackSection(packs[taskID]); section != "" {
+				return p + "\n\n" + section
+			}
+			return p
+		})
+	}
+	// Tell tasks that start after the base branch moved which of their files
+	// changed under them (ultraplan.drift_action: replan).
+	if cfg.Orch != nil {
+		transforms = append(transforms, func(taskID, _, p string) string {
+			if section := cfg.Orch.BaseDriftSection(taskID); section != "" {
+				return p + "\n\n" + section
+			}
+			return p
+		})
+	}
+	// Tell retried tasks why their previous attempt failed and what it left
+	// uncommitted (ultraplan.retry.augment_prompt).
+	if cfg.RetrySection != nil {
+		transforms = append(transforms, func(taskID, _, p string) string {
+			if section := cfg.RetrySection(taskID); section != "" {
+				return p + "\n\n" + section
+			}
+			return p
+		})
+	}
+	// Give restarted and retried tasks the progress an earlier attempt
+	// recorded (ultraplan.progress_summary_minutes).
+	if cfg.ProgressSection != nil {
+		transforms = append(transforms, func(taskID, _, p string) string {
+			if section := cfg.ProgressSection(taskID); section != "" {
+				return p + "\n\n" + section
+			}
+			return p
+		})
+	}
+	// Ask tasks to review their own work and score their confidence before
+	// writing the completion file (ultraplan.self_review).
+	if cfg.Orch != nil {
+		if section := cfg.Orch.SelfReviewSection(); section != "" {
+			transforms = append(transforms, func(_, _, p string) string {
+				return p + "\n\n" + section
+			})
+		}
+	}
+	if len(cfg.Experiments) > 0 {
+		if recorder == nil {
+			recorder = NewSessionRecorder(SessionRecorderDeps{})
+		}
+		store := stats.NewStore(filepath.Join(baseDir, ".claudio"))
+		tracker := experiment.NewTracker(store, cfg.Session.ID, cfg.Experiments)
+		recorder = newExperimentRecorder(recorder, tracker, func(instanceID string) float64 {
+			if m := cfg.Orch.GetInstanceMetrics(instanceID); m != nil {
+				return m.Cost
+			}
+			return 0
+		}, logger)
+		transforms = append(transforms, func(taskID, title, p string) string {
+			return tracker.Transform(experiment.PhaseTask, taskID, title, p)
+		})
+	}
+	if len(transforms) > 0 {
+		bridgeOpts = append(bridgeOpts, bridge.WithPromptTransform(chainPromptTransforms(transforms)))
+	}
+
+	if cfg.Placer != nil {
+		bridgeOpts = append(bridgeOpts, bridge.WithPlacer(cfg.Placer))
+	}
+	if cfg.TaskModel != nil {
+		bridgeOpts = append(bridgeOpts, bridge.WithTaskModel(cfg.TaskModel))
+	}
 
 	exec, err := NewPipelineExecutorFromOrch(
-		cfg.Orch, cfg.Session, cfg.Verifier,
-		cfg.Bus, pipe, cfg.Recorder, logger,
-		roleOverrides,
+		cfg.Orch, cfg.Session, verifier,
+		cfg.Bus, pipe, recorder, logger,
+		roleOverrides, bridgeOpts...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("bridgewire: create executor: %w", err)
 	}
+	exec.workStealing = cfg.WorkStealing
+	if len(cfg.Repos) > 0 {
+		exec.factoryForRepo = repoFactories(cfg.Orch, cfg.Session, cfg.Repos, logger)
+	}
 
 	return &PipelineRunner{
 		pipe: pipe,
@@ -149,32 +345,30 @@ func (r *PipelineRunner) Stop() {
 	_ = r.pipe.Stop()
 }
 
+// AddTask adds a task to the running execution phase. It implements
+// orchestrator.TaskAdder.
+func (r *PipelineRunner) AddTask(task orchestrator.PlannedTask) error {
+	if _, err := r.pipe.AddTask(convertTask(task)); err != nil {
+		return fmt.Errorf("bridgewire: add task: %w", err)
+	}
+	return nil
+}
+
+// RemoveTask drops an unfinished task from the running execution phase. It
+// implements orchestrator.TaskReviser along with AddTask.
+func (r *PipelineRunner) RemoveTask(taskID string) error {
+	if err := r.pipe.RemoveTask(taskID); err != nil {
+		return fmt.Errorf("bridgewire: remove task: %w", err)
+	}
+	return nil
+}
+
 // convertPlan converts an orchestrator.PlanSpec to an ultraplan.PlanSpec.
 // The two types have identical shapes (by design) so this is a field-by-field copy.
 func convertPlan(src *orchestrator.PlanSpec) *ultraplan.PlanSpec {
 	tasks := make([]ultraplan.PlannedTask, len(src.Tasks))
 	for i, t := range src.Tasks {
-		var files []string
-		if len(t.Files) > 0 {
-			files = make([]string, len(t.Files))
-			copy(files, t.Files)
-		}
-		var deps []string
-		if len(t.DependsOn) > 0 {
-			deps = make([]string, len(t.DependsOn))
-			copy(deps, t.DependsOn)
-		}
-		tasks[i] = ultraplan.PlannedTask{
-			ID:            t.ID,
-			Title:         t.Title,
-			Description:   t.Description,
-			Files:         files,
-			DependsOn:     deps,
-			Priority:      t.Priority,
-			EstComplexity: ultraplan.TaskComplexity(t.EstComplexity),
-			IssueURL:      t.IssueURL,
-			NoCode:        t.NoCode,
-		}
+		tasks[i] = convertTask(t)
 	}
 
 	depGraph := make(map[string][]string, len(src.DependencyGraph))
@@ -210,10 +404,73 @@ func convertPlan(src *orchestrator.PlanSpec) *ultraplan.PlanSpec {
 		ExecutionOrder:  execOrder,
 		Insights:        insights,
 		Constraints:     constraints,
+		Env:             maps.Clone(src.Env),
 		CreatedAt:       src.CreatedAt,
 	}
 }
 
+// convertTask converts an orchestrator.PlannedTask to an ultraplan.PlannedTask.
+func convertTask(t orchestrator.PlannedTask) ultraplan.PlannedTask {
+	var files []string
+	if len(t.Files) > 0 {
+		files = make([]string, len(t.Files))
+		copy(files, t.Files)
+	}
+	var deps []string
+	if len(t.DependsOn) > 0 {
+		deps = make([]string, len(t.DependsOn))
+		copy(deps, t.DependsOn)
+	}
+	return ultraplan.PlannedTask{
+		ID:            t.ID,
+		Title:         t.Title,
+		Description:   t.Description,
+		Files:         files,
+		DependsOn:     deps,
+		Priority:      t.Priority,
+		EstComplexity: ultraplan.TaskComplexity(t.EstComplexity),
+		TimeoutPolicy: t.TimeoutPolicy,
+		Model:         t.Model,
+		IssueURL:      t.IssueURL,
+		NoCode:        t.NoCode,
+		Repo:          t.Repo,
+		Contracts:     slices.Clone(t.Contracts),
+		Backend:       t.Backend,
+		Command:       t.Command,
+		Criteria:      t.Criteria.Clone(),
+		ContextPack:   t.ContextPack.Clone(),
+	}
+}
+
+// chainPromptTransforms applies each transform in order to the prompt the
+// previous one returned.
+func chainPromptTransforms(transforms []bridge.PromptTransform) bridge.PromptTransform {
+	return func(taskID, title, p string) string {
+		for _, fn := range transforms {
+			p = fn(taskID, title, p)
+		}
+		return p
+	}
+}
+
+// injectPlanEnv adds the plan's environment variables to the execution role's
+// overrides. Variables the caller already set for the role take precedence.
+//
+// Returns a new map to avoid mutating the caller's data (defensive copy).
+func injectPlanEnv(overrides map[team.Role]ai.StartOptions, env map[string]string) map[team.Role]ai.StartOptions {
+	if len(env) == 0 {
+		return overrides
+	}
+	result := make(map[team.Role]ai.StartOptions, len(overrides)+1)
+	maps.Copy(result, overrides)
+	execOverrides := result[team.RoleExecution]
+	merged := maps.Clone(env)
+	maps.Copy(merged, execOverrides.Env)
+	execOverrides.Env = merged
+	result[team.RoleExecution] = execOverrides
+	return result
+}
+
 // injectSystemPrompt ensures the execution role's overrides include the given
 // system prompt file path. If the role already has an AppendSystemPromptFile
 // set (explicitly by the caller), it is not overwritten.
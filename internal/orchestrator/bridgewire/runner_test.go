@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Iron-Ham/claudio/internal/ai"
+	"github.com/Iron-Ham/claudio/internal/bridge"
 	"github.com/Iron-Ham/claudio/internal/event"
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/prompt"
@@ -42,6 +43,8 @@ func TestConvertPlan(t *testing.T) {
 					Priority:      2,
 					EstComplexity: orchestrator.ComplexityHigh,
 					NoCode:        true,
+					Repo:          "frontend",
+					Contracts:     []string{"api/schema.json"},
 				},
 			},
 			DependencyGraph: map[string][]string{
@@ -50,11 +53,16 @@ func TestConvertPlan(t *testing.T) {
 			ExecutionOrder: [][]string{{"t1"}, {"t2"}},
 			Insights:       []string{"insight 1"},
 			Constraints:    []string{"constraint 1"},
+			Env:            map[string]string{"API_VERSION": "v3"},
 			CreatedAt:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
 		}
 
 		got := convertPlan(src)
 
+		if got.Env["API_VERSION"] != "v3" {
+			t.Errorf("Env = %v, want API_VERSION=v3", got.Env)
+		}
+
 		if got.ID != "plan-1" {
 			t.Errorf("ID = %q, want %q", got.ID, "plan-1")
 		}
@@ -98,6 +106,12 @@ func TestConvertPlan(t *testing.T) {
 		if len(task2.DependsOn) != 1 || task2.DependsOn[0] != "t1" {
 			t.Errorf("Tasks[1].DependsOn = %v, want [t1]", task2.DependsOn)
 		}
+		if task2.Repo != "frontend" {
+			t.Errorf("Tasks[1].Repo = %q, want %q", task2.Repo, "frontend")
+		}
+		if len(task2.Contracts) != 1 || task2.Contracts[0] != "api/schema.json" {
+			t.Errorf("Tasks[1].Contracts = %v, want [api/schema.json]", task2.Contracts)
+		}
 
 		// Dependency graph
 		if deps, ok := got.DependencyGraph["t2"]; !ok || len(deps) != 1 || deps[0] != "t1" {
@@ -157,6 +171,18 @@ func TestConvertPlan(t *testing.T) {
 			t.Error("convertPlan did not deep copy Files")
 		}
 	})
+
+	t.Run("preserves content hash", func(t *testing.T) {
+		src := &orchestrator.PlanSpec{
+			Tasks: []orchestrator.PlannedTask{
+				{ID: "t1", Title: "one", Description: "do one", Files: []string{"a.go"}},
+				{ID: "t2", Title: "fmt", DependsOn: []string{"t1"}, Backend: "tool", Command: "gofmt -w .", Repo: "api"},
+			},
+		}
+		if got, want := convertPlan(src).ContentHash(), src.ContentHash(); got != want {
+			t.Errorf("ultraplan ContentHash() = %q, want orchestrator hash %q", got, want)
+		}
+	})
 }
 
 func TestNewPipelineRunner_Validation(t *testing.T) {
@@ -288,6 +314,11 @@ func TestPipelineRunner_ImplementsExecutionRunner(t *testing.T) {
 	var _ orchestrator.ExecutionRunner = (*PipelineRunner)(nil)
 }
 
+func TestPipelineRunner_ImplementsTaskReviser(t *testing.T) {
+	// Compile-time check that PipelineRunner can revise a running plan.
+	var _ orchestrator.TaskReviser = (*PipelineRunner)(nil)
+}
+
 func TestPipelineRunner_StartCtxCancel(t *testing.T) {
 	bus := event.NewBus()
 	plan := &orchestrator.PlanSpec{
@@ -433,3 +464,43 @@ func TestNewPipelineRunner_WritesSystemPromptFile(t *testing.T) {
 			execOverrides.AppendSystemPromptFile, sysPromptPath)
 	}
 }
+
+func TestInjectPlanEnv(t *testing.T) {
+	original := map[team.Role]ai.StartOptions{
+		team.RoleExecution: {PermissionMode: "auto-accept", Env: map[string]string{"API_VERSION": "v4"}},
+	}
+	env := map[string]string{"API_VERSION": "v3", "FEATURE_FLAG": "on"}
+
+	result := injectPlanEnv(original, env)
+
+	execOpts := result[team.RoleExecution]
+	if execOpts.Env["FEATURE_FLAG"] != "on" {
+		t.Errorf("Env = %v, want the plan's FEATURE_FLAG", execOpts.Env)
+	}
+	if execOpts.Env["API_VERSION"] != "v4" {
+		t.Errorf("API_VERSION = %q, want the role's own value to win", execOpts.Env["API_VERSION"])
+	}
+	if execOpts.PermissionMode != "auto-accept" {
+		t.Errorf("PermissionMode = %q, want it preserved", execOpts.PermissionMode)
+	}
+	if len(original[team.RoleExecution].Env) != 1 {
+		t.Error("injectPlanEnv mutated the caller's overrides")
+	}
+	if len(env) != 2 || env["API_VERSION"] != "v3" {
+		t.Error("injectPlanEnv mutated the plan's env")
+	}
+
+	if got := injectPlanEnv(nil, nil); got != nil {
+		t.Errorf("injectPlanEnv(nil, nil) = %v, want nil", got)
+	}
+}
+
+func TestChainPromptTransforms(t *testing.T) {
+	chained := chainPromptTransforms([]bridge.PromptTransform{
+		func(_, _, p string) string { return p + " first" },
+		func(taskID, _, p string) string { return p + " then " + taskID },
+	})
+	if got := chained("t1", "title", "prompt"); got != "prompt first then t1" {
+		t.Errorf("chained transform = %q", got)
+	}
+}
@@ -0,0 +1,78 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/budget"
+)
+
+// startBudgetEnforcer enforces the session's hard budget limits from
+// metrics.updated events. It is a no-op if already running. Caller must hold
+// o.mu.
+func (o *Orchestrator) startBudgetEnforcer() {
+	if o.budgetEnforcer != nil || o.eventBus == nil {
+		return
+	}
+	o.budgetEnforcer = budget.NewEnforcer(budget.EnforcerConfigFromConfig(o.config), budgetController{o}, o.eventBus, o.logger)
+	o.budgetEnforcer.Start()
+}
+
+// stopBudgetEnforcerLocked stops enforcing budget limits. Caller must hold
+// o.mu.
+func (o *Orchestrator) stopBudgetEnforcerLocked() {
+	if o.budgetEnforcer == nil {
+		return
+	}
+	o.budgetEnforcer.Stop()
+	o.budgetEnforcer = nil
+}
+
+// handleBudgetExceeded notifies the user that a hard budget limit was hit.
+func (o *Orchestrator) handleBudgetExceeded(e event.Event) {
+	exceeded, ok := e.(event.BudgetExceededEvent)
+	if !ok {
+		return
+	}
+	var inst *Instance
+	if exceeded.InstanceID != "" {
+		inst = o.GetInstance(exceeded.InstanceID)
+	}
+	o.executeNotification("notifications.on_budget_limit", inst)
+}
+
+// budgetController lets the budget enforcer pause and stop session
+// instances. Instances that are not working or waiting for input are
+// reported as budget.ErrNotRunning.
+type budgetController struct {
+	o *Orchestrator
+}
+
+// PauseInstance implements budget.InstanceController.
+func (c budgetController) PauseInstance(id string) error {
+	if _, err := c.runningInstance(id); err != nil {
+		return err
+	}
+	return c.o.PauseInstance(id)
+}
+
+// StopInstance implements budget.InstanceController.
+func (c budgetController) StopInstance(id string) error {
+	inst, err := c.runningInstance(id)
+	if err != nil {
+		return err
+	}
+	return c.o.StopInstance(inst)
+}
+
+// runningInstance returns the instance with the given ID if it is running.
+func (c budgetController) runningInstance(id string) (*Instance, error) {
+	inst := c.o.GetInstance(id)
+	if inst == nil {
+		return nil, fmt.Errorf("instance %s not found", id)
+	}
+	if inst.Status != StatusWorking && inst.Status != StatusWaitingInput {
+		return nil, budget.ErrNotRunning
+	}
+	return inst, nil
+}
@@ -0,0 +1,279 @@
+package budget
+
+import (
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/logging"
+)
+
+// Limits reported in event.BudgetExceededEvent.
+const (
+	LimitSessionCost    = "session_cost"
+	LimitInstanceCost   = "instance_cost"
+	LimitInstanceTokens = "instance_tokens"
+)
+
+// Action is what the Enforcer does to instances over a hard limit.
+type Action string
+
+const (
+	// ActionPause suspends instances so they can be resumed.
+	ActionPause Action = "pause"
+	// ActionStop ends instances.
+	ActionStop Action = "stop"
+)
+
+// ErrNotRunning is returned by an InstanceController for an instance that
+// has nothing to pause or stop, such as one that already completed. The
+// Enforcer leaves such instances out of the BudgetExceededEvent.
+var ErrNotRunning = errors.New("instance is not running")
+
+// InstanceController pauses and stops instances that exceed hard limits.
+type InstanceController interface {
+	InstancePauser
+	// StopInstance stops the instance with the given ID.
+	StopInstance(id string) error
+}
+
+// EnforcerConfig holds the hard limits enforced by an Enforcer. A zero limit
+// is disabled.
+type EnforcerConfig struct {
+	SessionCostLimit   float64
+	InstanceCostLimit  float64
+	InstanceTokenLimit int64
+	Action             Action
+}
+
+// EnforcerConfigFromConfig returns the hard limits in the application config.
+func EnforcerConfigFromConfig(appCfg *config.Config) EnforcerConfig {
+	cfg := EnforcerConfig{Action: ActionPause}
+	if appCfg != nil {
+		cfg.SessionCostLimit = appCfg.Resources.CostLimit
+		cfg.InstanceCostLimit = appCfg.Resources.InstanceCostLimit
+		cfg.InstanceTokenLimit = appCfg.Resources.TokenLimitPerInstance
+		if appCfg.Resources.BudgetAction == string(ActionStop) {
+			cfg.Action = ActionStop
+		}
+	}
+	return cfg
+}
+
+// spend is an instance's cumulative consumption.
+type spend struct {
+	cost   float64
+	tokens int64
+}
+
+// exceeds reports whether s has consumed more than other.
+func (s spend) exceeds(other spend) bool {
+	return s.cost > other.cost || s.tokens > other.tokens
+}
+
+// breach is one exceeded limit and the instances acted on for it.
+type breach struct {
+	limit      string
+	instanceID string
+	threshold  float64
+	spent      float64
+	targets    []string
+}
+
+// Enforcer tracks per-instance and per-session spend from metrics.updated
+// events and pauses or stops instances when a hard limit is exceeded,
+// publishing an event.BudgetExceededEvent for each limit.
+//
+// An instance is acted on once per limit. If it keeps spending afterwards,
+// for example because it was resumed, it is acted on again.
+type Enforcer struct {
+	mu         sync.Mutex
+	config     EnforcerConfig
+	controller InstanceController
+	bus        *event.Bus
+	logger     *logging.Logger
+	subID      string
+
+	spend    map[string]spend // Latest spend per instance
+	enforced map[string]spend // Spend per instance when last paused or stopped
+}
+
+// NewEnforcer creates an Enforcer that acts on instances through controller.
+// Call Start to begin watching bus.
+func NewEnforcer(cfg EnforcerConfig, controller InstanceController, bus *event.Bus, logger *logging.Logger) *Enforcer {
+	if logger == nil {
+		logger = logging.NopLogger()
+	}
+	if cfg.Action == "" {
+		cfg.Action = ActionPause
+	}
+	return &Enforcer{
+		config:     cfg,
+		controller: controller,
+		bus:        bus,
+		logger:     logger,
+		spend:      make(map[string]spend),
+		enforced:   make(map[string]spend),
+	}
+}
+
+// Start subscribes to metrics.updated events. It is a no-op if already
+// started.
+func (e *Enforcer) Start() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.subID != "" {
+		return
+	}
+	e.subID = e.bus.Subscribe("metrics.updated", func(ev event.Event) {
+		if m, ok := ev.(event.MetricsUpdateEvent); ok {
+			e.Observe(m)
+		}
+	})
+}
+
+// Stop unsubscribes from the bus. It is idempotent.
+func (e *Enforcer) Stop() {
+	e.mu.Lock()
+	subID := e.subID
+	e.subID = ""
+	e.mu.Unlock()
+	if subID != "" {
+		e.bus.Unsubscribe(subID)
+	}
+}
+
+// UpdateConfig replaces the hard limits. They apply from the next metrics
+// update.
+func (e *Enforcer) UpdateConfig(cfg EnforcerConfig) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if cfg.Action == "" {
+		cfg.Action = ActionPause
+	}
+	e.config = cfg
+}
+
+// SessionCost returns the total cost of all instances seen (USD).
+func (e *Enforcer) SessionCost() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.sessionCostLocked()
+}
+
+// InstanceSpend returns the latest cost (USD) and total tokens of an
+// instance.
+func (e *Enforcer) InstanceSpend(id string) (cost float64, tokens int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	s := e.spend[id]
+	return s.cost, s.tokens
+}
+
+// Observe records an instance's cumulative metrics and enforces any hard
+// limit they exceed.
+func (e *Enforcer) Observe(m event.MetricsUpdateEvent) {
+	e.mu.Lock()
+	cur := spend{cost: m.Cost, tokens: m.InputTokens + m.OutputTokens}
+	e.spend[m.InstanceID] = cur
+	cfg := e.config
+
+	var breaches []breach
+	switch {
+	case !e.needsEnforcementLocked(m.InstanceID):
+	case cfg.InstanceCostLimit > 0 && cur.cost >= cfg.InstanceCostLimit:
+		breaches = append(breaches, breach{
+			limit: LimitInstanceCost, instanceID: m.InstanceID,
+			threshold: cfg.InstanceCostLimit, spent: cur.cost,
+			targets: []string{m.InstanceID},
+		})
+	case cfg.InstanceTokenLimit > 0 && cur.tokens >= cfg.InstanceTokenLimit:
+		breaches = append(breaches, breach{
+			limit: LimitInstanceTokens, instanceID: m.InstanceID,
+			threshold: float64(cfg.InstanceTokenLimit), spent: float64(cur.tokens),
+			targets: []string{m.InstanceID},
+		})
+	}
+	for _, b := range breaches {
+		for _, id := range b.targets {
+			e.enforced[id] = e.spend[id]
+		}
+	}
+
+	if total := e.sessionCostLocked(); cfg.SessionCostLimit > 0 && total >= cfg.SessionCostLimit {
+		var targets []string
+		for id := range e.spend {
+			if e.needsEnforcementLocked(id) {
+				targets = append(targets, id)
+				e.enforced[id] = e.spend[id]
+			}
+		}
+		if len(targets) > 0 {
+			slices.Sort(targets)
+			breaches = append(breaches, breach{
+				limit: LimitSessionCost, threshold: cfg.SessionCostLimit, spent: total, targets: targets,
+			})
+		}
+	}
+	e.mu.Unlock()
+
+	for _, b := range breaches {
+		e.enforce(cfg.Action, b)
+	}
+}
+
+// needsEnforcementLocked reports whether an instance has spent more since it
+// was last paused or stopped, or was never acted on.
+func (e *Enforcer) needsEnforcementLocked(id string) bool {
+	enforced, ok := e.enforced[id]
+	return !ok || e.spend[id].exceeds(enforced)
+}
+
+// sessionCostLocked sums the latest cost of every instance.
+func (e *Enforcer) sessionCostLocked() float64 {
+	var total float64
+	for _, s := range e.spend {
+		total += s.cost
+	}
+	return total
+}
+
+// enforce applies action to the instances of b and publishes the event. The
+// controller is called without e.mu held, since pausing or stopping an
+// instance may publish events of its own.
+func (e *Enforcer) enforce(action Action, b breach) {
+	e.logger.Warn("budget limit exceeded",
+		"limit", b.limit,
+		"instance_id", b.instanceID,
+		"threshold", b.threshold,
+		"spent", b.spent,
+		"action", string(action),
+	)
+
+	acted := make([]string, 0, len(b.targets))
+	for _, id := range b.targets {
+		var err error
+		if e.controller != nil {
+			if action == ActionStop {
+				err = e.controller.StopInstance(id)
+			} else {
+				err = e.controller.PauseInstance(id)
+			}
+		}
+		switch {
+		case errors.Is(err, ErrNotRunning):
+		case err != nil:
+			e.logger.Error("failed to enforce budget limit",
+				"instance_id", id,
+				"action", string(action),
+				"error", err,
+			)
+		default:
+			acted = append(acted, id)
+		}
+	}
+
+	e.bus.Publish(event.NewBudgetExceededEvent(b.limit, b.instanceID, b.threshold, b.spent, string(action), acted))
+}
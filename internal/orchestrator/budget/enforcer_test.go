@@ -0,0 +1,177 @@
+package budget
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/event"
+)
+
+// mockController implements InstanceController for testing.
+type mockController struct {
+	mu         sync.Mutex
+	paused     []string
+	stopped    []string
+	notRunning map[string]bool
+	failing    map[string]bool
+}
+
+func (m *mockController) act(id string, into *[]string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch {
+	case m.notRunning[id]:
+		return ErrNotRunning
+	case m.failing[id]:
+		return errors.New("tmux gone")
+	}
+	*into = append(*into, id)
+	return nil
+}
+
+func (m *mockController) PauseInstance(id string) error { return m.act(id, &m.paused) }
+func (m *mockController) StopInstance(id string) error  { return m.act(id, &m.stopped) }
+
+// exceededEvents subscribes to budget.exceeded events on bus.
+func exceededEvents(bus *event.Bus) func() []event.BudgetExceededEvent {
+	var mu sync.Mutex
+	var got []event.BudgetExceededEvent
+	bus.Subscribe("budget.exceeded", func(e event.Event) {
+		mu.Lock()
+		got = append(got, e.(event.BudgetExceededEvent))
+		mu.Unlock()
+	})
+	return func() []event.BudgetExceededEvent {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]event.BudgetExceededEvent(nil), got...)
+	}
+}
+
+func metrics(id string, tokens int64, cost float64) event.MetricsUpdateEvent {
+	return event.NewMetricsUpdateEvent(id, tokens, 0, 0, 0, cost, 1)
+}
+
+func TestEnforcer_InstanceLimits(t *testing.T) {
+	bus := event.NewBus()
+	ctrl := &mockController{}
+	enf := NewEnforcer(EnforcerConfig{InstanceCostLimit: 2, InstanceTokenLimit: 1000}, ctrl, bus, nil)
+	enf.Start()
+	defer enf.Stop()
+	events := exceededEvents(bus)
+
+	bus.Publish(metrics("inst-1", 100, 1.0))
+	bus.Publish(metrics("inst-2", 1500, 0.5))
+	bus.Publish(metrics("inst-1", 200, 2.5))
+	bus.Publish(metrics("inst-1", 200, 2.5)) // Unchanged: already paused
+
+	if want := []string{"inst-2", "inst-1"}; !reflect.DeepEqual(ctrl.paused, want) {
+		t.Errorf("paused = %v, want %v", ctrl.paused, want)
+	}
+	got := events()
+	if len(got) != 2 {
+		t.Fatalf("got %d budget.exceeded events, want 2", len(got))
+	}
+	if got[0].Limit != LimitInstanceTokens || got[0].InstanceID != "inst-2" || got[0].Spent != 1500 {
+		t.Errorf("first event = %+v, want instance_tokens for inst-2", got[0])
+	}
+	if got[1].Limit != LimitInstanceCost || got[1].Threshold != 2 || got[1].Action != "pause" {
+		t.Errorf("second event = %+v, want instance_cost pause", got[1])
+	}
+
+	// A resumed instance that keeps spending is paused again.
+	bus.Publish(metrics("inst-1", 300, 2.7))
+	if len(ctrl.paused) != 3 {
+		t.Errorf("paused = %v, want inst-1 paused again after more spend", ctrl.paused)
+	}
+}
+
+func TestEnforcer_SessionLimit(t *testing.T) {
+	bus := event.NewBus()
+	ctrl := &mockController{notRunning: map[string]bool{"done": true}}
+	enf := NewEnforcer(EnforcerConfig{SessionCostLimit: 5, Action: ActionStop}, ctrl, bus, nil)
+	enf.Start()
+	defer enf.Stop()
+	events := exceededEvents(bus)
+
+	bus.Publish(metrics("done", 0, 2))
+	bus.Publish(metrics("b", 0, 1))
+	bus.Publish(metrics("a", 0, 2.5))
+
+	if got := enf.SessionCost(); got != 5.5 {
+		t.Errorf("SessionCost() = %v, want 5.5", got)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(ctrl.stopped, want) {
+		t.Errorf("stopped = %v, want %v (not-running instances are skipped)", ctrl.stopped, want)
+	}
+	got := events()
+	if len(got) != 1 || got[0].Limit != LimitSessionCost || got[0].Action != "stop" ||
+		!reflect.DeepEqual(got[0].Instances, []string{"a", "b"}) {
+		t.Errorf("events = %+v, want one session_cost stop of a and b", got)
+	}
+
+	// New spend while over the session limit is stopped too.
+	bus.Publish(metrics("c", 0, 0.1))
+	if len(ctrl.stopped) != 3 || ctrl.stopped[2] != "c" {
+		t.Errorf("stopped = %v, want c stopped", ctrl.stopped)
+	}
+}
+
+func TestEnforcer_ControllerErrors(t *testing.T) {
+	bus := event.NewBus()
+	ctrl := &mockController{failing: map[string]bool{"inst-1": true}}
+	enf := NewEnforcer(EnforcerConfig{InstanceCostLimit: 1}, ctrl, bus, nil)
+	enf.Start()
+	defer enf.Stop()
+	events := exceededEvents(bus)
+
+	bus.Publish(metrics("inst-1", 0, 3))
+	got := events()
+	if len(got) != 1 || len(got[0].Instances) != 0 {
+		t.Errorf("events = %+v, want one event listing no instances acted on", got)
+	}
+}
+
+func TestEnforcer_NoLimits(t *testing.T) {
+	bus := event.NewBus()
+	ctrl := &mockController{}
+	enf := NewEnforcer(EnforcerConfig{}, ctrl, bus, nil)
+	enf.Start()
+	enf.Start() // no-op while started
+	events := exceededEvents(bus)
+
+	bus.Publish(metrics("inst-1", 1_000_000, 100))
+	if len(ctrl.paused) != 0 || len(events()) != 0 {
+		t.Error("no limits configured should never enforce")
+	}
+	if cost, tokens := enf.InstanceSpend("inst-1"); cost != 100 || tokens != 1_000_000 {
+		t.Errorf("InstanceSpend() = %v, %d", cost, tokens)
+	}
+
+	enf.Stop()
+	enf.Stop() // idempotent
+	enf.UpdateConfig(EnforcerConfig{InstanceCostLimit: 1})
+	bus.Publish(metrics("inst-1", 0, 200))
+	if len(ctrl.paused) != 0 {
+		t.Error("a stopped enforcer should not see events")
+	}
+}
+
+func TestEnforcerConfigFromConfig(t *testing.T) {
+	cfg := EnforcerConfigFromConfig(&config.Config{Resources: config.ResourceConfig{
+		CostLimit:             20,
+		InstanceCostLimit:     4,
+		TokenLimitPerInstance: 5000,
+		BudgetAction:          "stop",
+	}})
+	want := EnforcerConfig{SessionCostLimit: 20, InstanceCostLimit: 4, InstanceTokenLimit: 5000, Action: ActionStop}
+	if cfg != want {
+		t.Errorf("EnforcerConfigFromConfig() = %+v, want %+v", cfg, want)
+	}
+	if got := EnforcerConfigFromConfig(nil); got.Action != ActionPause {
+		t.Errorf("nil config action = %q, want pause", got.Action)
+	}
+}
@@ -1,4 +1,8 @@
 // Package budget provides budget monitoring and enforcement for orchestrator sessions.
+//
+// [Manager] aggregates session metrics and checks the cost warning threshold.
+// [Enforcer] watches metrics.updated events and pauses or stops instances
+// that exceed a hard limit, publishing a budget.exceeded event.
 package budget
 
 import (
@@ -186,16 +190,7 @@ func (m *Manager) CheckLimits() bool {
 	}
 
 	// Check cost warning threshold
-	if m.config.CostWarningThreshold > 0 && sessionMetrics.TotalCost >= m.config.CostWarningThreshold {
-		m.logger.Warn("budget warning threshold reached",
-			"total_cost", sessionMetrics.TotalCost,
-			"warning_threshold", m.config.CostWarningThreshold,
-		)
-
-		if m.callbacks.OnBudgetWarning != nil {
-			m.callbacks.OnBudgetWarning()
-		}
-	}
+	m.checkWarning(sessionMetrics)
 
 	// Check per-instance token limit
 	if m.config.TokenLimitPerInstance > 0 {
@@ -227,3 +222,30 @@ func (m *Manager) CheckLimits() bool {
 
 	return limitExceeded
 }
+
+// CheckWarning checks the cost warning threshold without enforcing any hard
+// limit, for callers that leave enforcement to an Enforcer. Returns true if
+// the threshold was reached.
+func (m *Manager) CheckWarning() bool {
+	if m.provider == nil {
+		return false
+	}
+	return m.checkWarning(m.GetSessionMetrics())
+}
+
+// checkWarning calls OnBudgetWarning if the session cost has reached the
+// warning threshold.
+func (m *Manager) checkWarning(sessionMetrics *SessionMetrics) bool {
+	if m.config.CostWarningThreshold <= 0 || sessionMetrics.TotalCost < m.config.CostWarningThreshold {
+		return false
+	}
+	m.logger.Warn("budget warning threshold reached",
+		"total_cost", sessionMetrics.TotalCost,
+		"warning_threshold", m.config.CostWarningThreshold,
+	)
+
+	if m.callbacks.OnBudgetWarning != nil {
+		m.callbacks.OnBudgetWarning()
+	}
+	return true
+}
@@ -204,6 +204,38 @@ func TestCheckLimits_CostWarning(t *testing.T) {
 	}
 }
 
+func TestCheckWarning(t *testing.T) {
+	provider := &mockProvider{
+		metrics: []InstanceMetrics{
+			{ID: "inst-1", Status: "working", Cost: 12.0},
+		},
+	}
+	pauser := &mockPauser{}
+
+	var warningCalled bool
+	callbacks := Callbacks{
+		OnBudgetWarning: func() {
+			warningCalled = true
+		},
+	}
+
+	cfg := Config{
+		CostLimit:            10.0,
+		CostWarningThreshold: 5.0,
+	}
+	mgr := NewManager(cfg, provider, pauser, callbacks, nil)
+
+	if !mgr.CheckWarning() {
+		t.Error("CheckWarning should return true when the warning threshold is reached")
+	}
+	if !warningCalled {
+		t.Error("OnBudgetWarning callback should be called")
+	}
+	if len(pauser.paused) != 0 {
+		t.Errorf("CheckWarning should not enforce hard limits, paused %v", pauser.paused)
+	}
+}
+
 func TestCheckLimits_TokenLimit(t *testing.T) {
 	provider := &mockProvider{
 		metrics: []InstanceMetrics{
@@ -0,0 +1,55 @@
+package orchestrator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/budget"
+)
+
+func TestOrchestrator_BudgetEnforcer(t *testing.T) {
+	o := &Orchestrator{config: config.Default(), eventBus: event.NewBus()}
+
+	o.startBudgetEnforcer()
+	if o.budgetEnforcer == nil {
+		t.Fatal("budget enforcer should start")
+	}
+	enf := o.budgetEnforcer
+	o.startBudgetEnforcer() // no-op while running
+	if o.budgetEnforcer != enf {
+		t.Error("starting twice should keep the running enforcer")
+	}
+
+	o.eventBus.Publish(event.NewMetricsUpdateEvent("inst-1", 10, 20, 0, 0, 0.5, 1))
+	if cost, tokens := enf.InstanceSpend("inst-1"); cost != 0.5 || tokens != 30 {
+		t.Errorf("InstanceSpend() = %v, %d; want 0.5, 30", cost, tokens)
+	}
+
+	o.stopBudgetEnforcerLocked()
+	if o.budgetEnforcer != nil {
+		t.Error("budget enforcer should be cleared after stop")
+	}
+	o.stopBudgetEnforcerLocked() // no-op when stopped
+}
+
+func TestBudgetController_SkipsInstancesNotRunning(t *testing.T) {
+	o := &Orchestrator{session: &Session{Instances: []*Instance{
+		{ID: "done", Status: StatusCompleted},
+		{ID: "paused", Status: StatusPaused},
+	}}}
+	ctrl := budgetController{o}
+
+	for _, id := range []string{"done", "paused"} {
+		if err := ctrl.PauseInstance(id); !errors.Is(err, budget.ErrNotRunning) {
+			t.Errorf("PauseInstance(%q) error = %v, want ErrNotRunning", id, err)
+		}
+		if err := ctrl.StopInstance(id); !errors.Is(err, budget.ErrNotRunning) {
+			t.Errorf("StopInstance(%q) error = %v, want ErrNotRunning", id, err)
+		}
+	}
+	if err := ctrl.PauseInstance("missing"); err == nil || errors.Is(err, budget.ErrNotRunning) {
+		t.Errorf("PauseInstance(missing) error = %v, want not found", err)
+	}
+}
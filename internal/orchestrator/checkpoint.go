@@ -0,0 +1,49 @@
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/Iron-Ham/claudio/internal/storage"
+)
+
+// startCheckpoints periodically copies the session directory to the store
+// configured by session.storage, so the session outlives an ephemeral
+// machine. It only runs for sessions with their own directory, and is a
+// no-op when session.storage is unset or checkpointing is already running.
+// Caller must hold o.mu.
+func (o *Orchestrator) startCheckpoints() {
+	if o.sessionDir == "" || o.sessionID == "" || o.stopCheckpoint != nil {
+		return
+	}
+	store, err := storage.New(o.config.Session.Storage)
+	if err != nil {
+		if o.logger != nil {
+			o.logger.Warn("session checkpoints disabled", "error", err)
+		}
+		return
+	}
+	if store == nil {
+		return
+	}
+
+	cp := storage.NewCheckpointer(store, o.sessionID, o.sessionDir, storage.WithLogger(o.logger))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	o.stopCheckpoint = func() {
+		cancel()
+		<-done
+	}
+	go func() {
+		defer close(done)
+		cp.Run(ctx, o.config.Session.Storage.CheckpointInterval())
+	}()
+}
+
+// stopCheckpointsLocked stops checkpointing if it is running, after a final
+// checkpoint of the session directory. Caller must hold o.mu.
+func (o *Orchestrator) stopCheckpointsLocked() {
+	if o.stopCheckpoint != nil {
+		o.stopCheckpoint()
+		o.stopCheckpoint = nil
+	}
+}
@@ -0,0 +1,48 @@
+package orchestrator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/storage"
+)
+
+func TestOrchestrator_Checkpoints(t *testing.T) {
+	storeDir := t.TempDir()
+	cfg := config.Default()
+	cfg.Session.Storage.Backend = "local"
+	cfg.Session.Storage.Path = storeDir
+
+	o := &Orchestrator{sessionID: "s1", sessionDir: t.TempDir(), config: cfg}
+	if err := os.WriteFile(filepath.Join(o.sessionDir, "session.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	o.startCheckpoints()
+	if o.stopCheckpoint == nil {
+		t.Fatal("checkpoints should start when session.storage is set")
+	}
+	o.startCheckpoints() // no-op while running
+	o.stopCheckpointsLocked()
+
+	store, err := storage.NewLocal(storeDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := storage.ReadManifest(context.Background(), store, "s1")
+	if err != nil {
+		t.Fatalf("stopping should leave a final checkpoint: %v", err)
+	}
+	if len(m.Files) != 1 || m.Files[0].Path != "session.json" {
+		t.Errorf("checkpoint files = %+v, want session.json", m.Files)
+	}
+
+	disabled := &Orchestrator{sessionID: "s2", sessionDir: t.TempDir(), config: config.Default()}
+	disabled.startCheckpoints()
+	if disabled.stopCheckpoint != nil {
+		t.Error("checkpoints should not start without session.storage")
+	}
+}
@@ -43,6 +43,17 @@ type ConsolidatorState struct {
 	Error            string             `json:"error,omitempty"`
 	StartedAt        *time.Time         `json:"started_at,omitempty"`
 	CompletedAt      *time.Time         `json:"completed_at,omitempty"`
+
+	// Freshness of the consolidated work, checked before PRs are opened
+	TargetBranch string `json:"target_branch,omitempty"` // Ref compared against, e.g. "origin/main"
+	BehindBy     int    `json:"behind_by,omitempty"`     // Commits the consolidated work trails TargetBranch
+	Stale        bool   `json:"stale,omitempty"`         // BehindBy exceeds the configured threshold
+	Rebasing     bool   `json:"rebasing,omitempty"`      // Stale branches are rebased and re-verified before PRs
+
+	// PR creation skipped because gh was missing; the branches are pushed and
+	// the PRs recorded on the session as pending
+	PRsSkipped    bool   `json:"prs_skipped,omitempty"`
+	PRsSkipReason string `json:"prs_skip_reason,omitempty"`
 }
 
 // HasConflict returns true if consolidation is paused due to a conflict.
@@ -50,6 +61,16 @@ func (s *ConsolidatorState) HasConflict() bool {
 	return s.Phase == ConsolidationPaused && len(s.ConflictFiles) > 0
 }
 
+// RecordFreshness stores how far the consolidated work trails target and
+// decides whether it is stale under cfg. A zero MaxBehindCommits disables
+// the threshold, so the count is recorded but never marked stale.
+func (s *ConsolidatorState) RecordFreshness(target string, behindBy int, cfg UltraPlanConfig) {
+	s.TargetBranch = target
+	s.BehindBy = behindBy
+	s.Stale = cfg.MaxBehindCommits > 0 && behindBy > cfg.MaxBehindCommits
+	s.Rebasing = s.Stale && cfg.AutoRebase
+}
+
 // GroupConsolidationResult holds the result of consolidating one group
 type GroupConsolidationResult struct {
 	GroupIndex   int      `json:"group_index"`
@@ -0,0 +1,256 @@
+// Package mergequeue consolidates task branches the way a merge queue
+// would, pausing only for conflicts a person has to decide.
+//
+// Merge cherry-picks each branch's commits onto the worktree's HEAD with
+// git rerere enabled, so a conflict resolved once (by a person or by the
+// queue) is resolved again the same way. When a cherry-pick still
+// conflicts, conflicts where both sides only inserted lines at the same
+// place, such as two entries added to the end of a list, are resolved by
+// keeping both. A branch with a conflict that truly overlaps is set aside
+// and retried after the others, since it may apply cleanly in another
+// order. Only when no remaining branch applies does Merge stop, with a
+// *ConflictError.
+//
+// The queue runs the git CLI and needs diff3 conflict markers, which it
+// sets per command without changing the repository's configuration.
+//
+// # Usage
+//
+//	result, err := mergequeue.Merge(worktreePath, []mergequeue.Branch{
+//		{Name: "claudio/login", TaskID: "t1"},
+//		{Name: "claudio/logout", TaskID: "t2"},
+//	})
+//	var conflict *mergequeue.ConflictError
+//	if errors.As(err, &conflict) {
+//		// pause for conflict.Files of conflict.Branch
+//	}
+package mergequeue
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// gitConfig is passed to every git command the queue runs: rerere with
+// resolutions staged as they are replayed, and conflicts written with the
+// base section that trivial resolution needs.
+var gitConfig = []string{
+	"-c", "rerere.enabled=true",
+	"-c", "rerere.autoUpdate=true",
+	"-c", "merge.conflictStyle=diff3",
+}
+
+// rererePattern matches the paths rerere reports resolving from a recorded
+// resolution.
+var rererePattern = regexp.MustCompile(`(?m)^(?:Resolved|Staged) '(.+)' using previous resolution`)
+
+// Branch is a branch to consolidate.
+type Branch struct {
+	Name   string
+	TaskID string // Task the branch implements, for reporting
+}
+
+// Result describes a completed merge.
+type Result struct {
+	// Applied lists the branches in the order they were applied.
+	Applied []Branch
+	// Reordered reports whether a branch was set aside and applied after
+	// branches that came later in the input.
+	Reordered bool
+	// AutoResolved lists the files whose conflicts the queue resolved by
+	// keeping both sides.
+	AutoResolved []string
+	// RerereResolved lists the files resolved from a recorded resolution.
+	RerereResolved []string
+}
+
+// ConflictError reports a branch that conflicts in every order tried. The
+// worktree is left at the last branch that applied.
+type ConflictError struct {
+	Branch Branch
+	Commit string
+	Files  []string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("branch %s conflicts at commit %s in %s",
+		e.Branch.Name, shortCommit(e.Commit), strings.Join(e.Files, ", "))
+}
+
+// Merge applies branches to the worktree at dir in order, setting aside
+// branches that conflict and retrying them once the others are applied,
+// until every branch is applied or none of the remaining ones can be.
+func Merge(dir string, branches []Branch) (*Result, error) {
+	result := &Result{}
+	queue := branches
+	var firstConflict *ConflictError
+	for len(queue) > 0 {
+		var deferred []Branch
+		firstConflict = nil
+		for _, b := range queue {
+			err := applyBranch(dir, b, result)
+			var conflict *ConflictError
+			switch {
+			case errors.As(err, &conflict):
+				if firstConflict == nil {
+					firstConflict = conflict
+				}
+				deferred = append(deferred, b)
+			case err != nil:
+				return result, err
+			default:
+				if len(deferred) > 0 {
+					result.Reordered = true
+				}
+				result.Applied = append(result.Applied, b)
+			}
+		}
+		if len(deferred) == len(queue) {
+			return result, firstConflict
+		}
+		queue = deferred
+	}
+	return result, nil
+}
+
+// applyBranch cherry-picks the commits of b missing from HEAD. On a
+// conflict it cannot resolve, the worktree is reset to where it was before
+// b and a *ConflictError is returned.
+func applyBranch(dir string, b Branch, result *Result) error {
+	start, err := git(dir, "rev-parse", "HEAD")
+	if err != nil {
+		return err
+	}
+	// Commits on the branch but not HEAD, skipping ones already applied
+	// under another hash
+	out, err := git(dir, "rev-list", "--reverse", "--no-merges", "--right-only", "--cherry-pick", "HEAD..."+b.Name)
+	if err != nil {
+		return fmt.Errorf("list commits of %s: %w", b.Name, err)
+	}
+
+	var autoResolved, rerereResolved []string
+	for _, commit := range strings.Fields(out) {
+		out, err := git(dir, "cherry-pick", commit)
+		if err == nil {
+			continue
+		}
+		rerere := rerereFiles(out)
+		unresolved, resolved, rerr := resolveCommit(dir)
+		if rerr != nil || len(unresolved) > 0 {
+			abort(dir, start)
+			if rerr != nil {
+				return fmt.Errorf("cherry-pick %s from %s: %w", shortCommit(commit), b.Name, rerr)
+			}
+			return &ConflictError{Branch: b, Commit: commit, Files: unresolved}
+		}
+		if !cherryPickInProgress(dir) {
+			abort(dir, start)
+			return fmt.Errorf("cherry-pick %s from %s: %w", shortCommit(commit), b.Name, err)
+		}
+		// A commit whose changes HEAD already has leaves nothing to commit
+		next := "--continue"
+		if _, err := git(dir, "diff", "--cached", "--quiet"); err == nil {
+			next = "--skip"
+		}
+		if _, err := git(dir, "cherry-pick", next); err != nil {
+			abort(dir, start)
+			return fmt.Errorf("cherry-pick %s from %s: %w", shortCommit(commit), b.Name, err)
+		}
+		autoResolved = append(autoResolved, resolved...)
+		rerereResolved = append(rerereResolved, rerere...)
+	}
+
+	result.AutoResolved = appendNew(result.AutoResolved, autoResolved...)
+	result.RerereResolved = appendNew(result.RerereResolved, rerereResolved...)
+	return nil
+}
+
+// resolveCommit resolves the conflicted files of an in-progress
+// cherry-pick that can be resolved by keeping both sides, staging them. It
+// returns the files left unresolved and the files it resolved.
+func resolveCommit(dir string) (unresolved, resolved []string, err error) {
+	out, err := git(dir, "diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, file := range strings.Split(out, "\n") {
+		if file == "" {
+			continue
+		}
+		ok, err := resolveFile(filepath.Join(dir, file))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, nil, err
+		}
+		if !ok {
+			unresolved = append(unresolved, file)
+			continue
+		}
+		resolved = append(resolved, file)
+	}
+	if len(unresolved) > 0 || len(resolved) == 0 {
+		return unresolved, resolved, nil
+	}
+	if _, err := git(dir, append([]string{"add", "--"}, resolved...)...); err != nil {
+		return nil, nil, err
+	}
+	return nil, resolved, nil
+}
+
+// cherryPickInProgress reports whether a cherry-pick is stopped in dir.
+func cherryPickInProgress(dir string) bool {
+	_, err := git(dir, "rev-parse", "-q", "--verify", "CHERRY_PICK_HEAD")
+	return err == nil
+}
+
+// abort ends an in-progress cherry-pick and resets the worktree to rev.
+func abort(dir, rev string) {
+	_, _ = git(dir, "cherry-pick", "--abort")
+	_, _ = git(dir, "reset", "--hard", rev)
+}
+
+// git runs git in dir with the queue's configuration and returns its
+// trimmed combined output. The editor is disabled so continuing a
+// cherry-pick keeps the original message.
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append(slices.Clone(gitConfig), args...)...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_EDITOR=true")
+	out, err := cmd.CombinedOutput()
+	output := strings.TrimSpace(string(out))
+	if err != nil {
+		return output, fmt.Errorf("git %s: %w: %s", args[0], err, output)
+	}
+	return output, nil
+}
+
+// rerereFiles returns the files git's output reports rerere resolving.
+func rerereFiles(output string) []string {
+	var files []string
+	for _, m := range rererePattern.FindAllStringSubmatch(output, -1) {
+		files = append(files, m[1])
+	}
+	return files
+}
+
+// appendNew appends the files not already in list.
+func appendNew(list []string, files ...string) []string {
+	for _, f := range files {
+		if !slices.Contains(list, f) {
+			list = append(list, f)
+		}
+	}
+	return list
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 8 {
+		return commit[:8]
+	}
+	return commit
+}
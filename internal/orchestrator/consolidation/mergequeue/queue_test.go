@@ -0,0 +1,174 @@
+package mergequeue
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testRepo is a repository whose main branch has f.txt.
+type testRepo struct {
+	t   *testing.T
+	dir string
+}
+
+func newTestRepo(t *testing.T, content string) *testRepo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	r := &testRepo{t: t, dir: t.TempDir()}
+	r.git("init", "-q", "-b", "main")
+	r.git("config", "user.email", "test@example.com")
+	r.git("config", "user.name", "Test")
+	r.commit("init", map[string]string{"f.txt": content})
+	return r
+}
+
+func (r *testRepo) git(args ...string) string {
+	r.t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.dir
+	cmd.Env = append(os.Environ(), "GIT_EDITOR=true")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		r.t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func (r *testRepo) commit(msg string, files map[string]string) {
+	r.t.Helper()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(r.dir, name), []byte(content), 0644); err != nil {
+			r.t.Fatal(err)
+		}
+	}
+	r.git("add", "-A")
+	r.git("commit", "-q", "-m", msg)
+}
+
+// branch creates name from main with one commit per file set.
+func (r *testRepo) branch(name string, commits ...map[string]string) {
+	r.t.Helper()
+	r.git("checkout", "-q", "-b", name, "main")
+	for i, files := range commits {
+		r.commit(name+" "+string(rune('1'+i)), files)
+	}
+	r.git("checkout", "-q", "main")
+}
+
+func (r *testRepo) read(name string) string {
+	r.t.Helper()
+	data, err := os.ReadFile(filepath.Join(r.dir, name))
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	return string(data)
+}
+
+func branchNames(branches []Branch) []string {
+	var names []string
+	for _, b := range branches {
+		names = append(names, b.Name)
+	}
+	return names
+}
+
+func TestMerge_KeepsBothInsertions(t *testing.T) {
+	r := newTestRepo(t, "one\ntwo\n")
+	r.branch("a", map[string]string{"f.txt": "one\ntwo\nthree-a\n"})
+	r.branch("b", map[string]string{"f.txt": "one\ntwo\nthree-b\n"})
+
+	result, err := Merge(r.dir, []Branch{{Name: "a"}, {Name: "b"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.read("f.txt"); got != "one\ntwo\nthree-a\nthree-b\n" {
+		t.Errorf("f.txt = %q, want both insertions", got)
+	}
+	if !slices.Equal(result.AutoResolved, []string{"f.txt"}) || result.Reordered {
+		t.Errorf("result = %+v, want f.txt auto-resolved in order", result)
+	}
+}
+
+func TestMerge_DefersOverlappingBranch(t *testing.T) {
+	r := newTestRepo(t, "x = 1\n")
+	r.branch("a", map[string]string{"f.txt": "x = 2\n"})
+	r.branch("b", map[string]string{"f.txt": "x = 3\n"})
+	r.branch("c", map[string]string{"g.txt": "new\n"})
+
+	result, err := Merge(r.dir, []Branch{{Name: "a"}, {Name: "b", TaskID: "t2"}, {Name: "c"}})
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("err = %v, want a ConflictError", err)
+	}
+	if conflict.Branch.TaskID != "t2" || !slices.Equal(conflict.Files, []string{"f.txt"}) {
+		t.Errorf("conflict = %+v, want f.txt of b", conflict)
+	}
+	if got := branchNames(result.Applied); !slices.Equal(got, []string{"a", "c"}) || !result.Reordered {
+		t.Errorf("applied %v (reordered %v), want a and c past the conflict", got, result.Reordered)
+	}
+	if status := r.git("status", "--porcelain"); status != "" {
+		t.Errorf("worktree left dirty:\n%s", status)
+	}
+	if got := r.read("f.txt"); got != "x = 2\n" {
+		t.Errorf("f.txt = %q, want a's change", got)
+	}
+}
+
+func TestMerge_ReusesRecordedResolution(t *testing.T) {
+	r := newTestRepo(t, "x = 1\n")
+	r.branch("a", map[string]string{"f.txt": "x = 2\n"})
+	r.branch("b", map[string]string{"f.txt": "x = 3\n"})
+	r.git("merge", "-q", "--ff-only", "a")
+	start := r.git("rev-parse", "HEAD")
+
+	// Resolve the conflict by hand once, with rerere recording it
+	cmd := exec.Command("git", "-c", "rerere.enabled=true", "cherry-pick", "b")
+	cmd.Dir = r.dir
+	if err := cmd.Run(); err == nil {
+		t.Fatal("expected the cherry-pick to conflict")
+	}
+	if err := os.WriteFile(filepath.Join(r.dir, "f.txt"), []byte("x = 5\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	r.git("add", "f.txt")
+	r.git("-c", "rerere.enabled=true", "cherry-pick", "--continue")
+	r.git("reset", "-q", "--hard", start)
+
+	result, err := Merge(r.dir, []Branch{{Name: "b"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.read("f.txt"); got != "x = 5\n" {
+		t.Errorf("f.txt = %q, want the recorded resolution", got)
+	}
+	if !slices.Equal(result.RerereResolved, []string{"f.txt"}) {
+		t.Errorf("RerereResolved = %v, want f.txt", result.RerereResolved)
+	}
+}
+
+func TestMerge_SkipsChangesAlreadyApplied(t *testing.T) {
+	r := newTestRepo(t, "a\n")
+	r.branch("wide", map[string]string{"f.txt": "b\n", "h.txt": "h\n"})
+	r.branch("narrow", map[string]string{"f.txt": "b\n"}, map[string]string{"f.txt": "c\n"})
+
+	result, err := Merge(r.dir, []Branch{{Name: "wide"}, {Name: "narrow"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := branchNames(result.Applied); !slices.Equal(got, []string{"wide", "narrow"}) {
+		t.Errorf("applied %v, want both", got)
+	}
+	if got := r.read("f.txt"); got != "c\n" {
+		t.Errorf("f.txt = %q, want narrow's second commit", got)
+	}
+	if count := r.git("rev-list", "--count", "main"); count != "3" {
+		t.Errorf("main has %s commits, want 3 (the empty pick skipped)", count)
+	}
+}
@@ -0,0 +1,109 @@
+package mergequeue
+
+import (
+	"bytes"
+	"os"
+)
+
+// Conflict markers as written with merge.conflictStyle=diff3.
+var (
+	markerOurs   = []byte("<<<<<<<")
+	markerBase   = []byte("|||||||")
+	markerSplit  = []byte("=======")
+	markerTheirs = []byte(">>>>>>>")
+)
+
+// resolveFile resolves every conflict in the file at path whose two sides
+// can be combined without choosing between them, and rewrites the file. It
+// reports false, leaving the file untouched, when any conflict overlaps.
+func resolveFile(path string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	resolved, ok := resolveConflicts(data)
+	if !ok {
+		return false, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(path, resolved, info.Mode().Perm())
+}
+
+// resolveConflicts resolves the diff3 conflict blocks in data. A block is
+// trivial when both sides only added lines at the same place (its base is
+// empty), in which case the resolution keeps ours followed by theirs, or
+// when one side left the base unchanged or both made the same change. It
+// reports false if data has a block that is not trivial, or no blocks.
+func resolveConflicts(data []byte) ([]byte, bool) {
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	var out []byte
+	blocks := 0
+	for i := 0; i < len(lines); i++ {
+		if !bytes.HasPrefix(lines[i], markerOurs) {
+			out = append(out, lines[i]...)
+			continue
+		}
+		ours, base, theirs, end, ok := parseBlock(lines, i+1)
+		if !ok {
+			return nil, false
+		}
+		resolution, ok := resolveBlock(ours, base, theirs)
+		if !ok {
+			return nil, false
+		}
+		out = append(out, resolution...)
+		blocks++
+		i = end
+	}
+	return out, blocks > 0
+}
+
+// parseBlock reads the sections of the conflict block whose first line
+// after the opening marker is start, returning the index of its closing
+// marker. It fails on a block without a base section, as written without
+// diff3, since which side added what cannot be told.
+func parseBlock(lines [][]byte, start int) (ours, base, theirs []byte, end int, ok bool) {
+	section := &ours
+	sawBase, sawSplit := false, false
+	for i := start; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case bytes.HasPrefix(line, markerBase) && !sawBase && !sawSplit:
+			sawBase = true
+			section = &base
+		case bytes.HasPrefix(line, markerSplit) && sawBase && !sawSplit:
+			sawSplit = true
+			section = &theirs
+		case bytes.HasPrefix(line, markerTheirs) && sawSplit:
+			return ours, base, theirs, i, true
+		case bytes.HasPrefix(line, markerOurs):
+			return nil, nil, nil, 0, false
+		default:
+			*section = append(*section, line...)
+		}
+	}
+	return nil, nil, nil, 0, false
+}
+
+// resolveBlock resolves one conflict block, or reports false when both
+// sides changed the same base lines differently.
+func resolveBlock(ours, base, theirs []byte) ([]byte, bool) {
+	switch {
+	case bytes.Equal(ours, theirs), bytes.Equal(theirs, base):
+		return ours, true
+	case bytes.Equal(ours, base):
+		return theirs, true
+	case len(base) == 0:
+		// Both sides inserted lines at the same place; keep both
+		resolution := append([]byte{}, ours...)
+		if len(resolution) > 0 && !bytes.HasSuffix(resolution, []byte("\n")) {
+			resolution = append(resolution, '\n')
+		}
+		return append(resolution, theirs...), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,68 @@
+package mergequeue
+
+import "testing"
+
+func TestResolveConflicts(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+		ok   bool
+	}{
+		{
+			name: "both sides inserted",
+			in:   "a\n<<<<<<< HEAD\nours\n||||||| base\n=======\ntheirs\n>>>>>>> abc\nz\n",
+			want: "a\nours\ntheirs\nz\n",
+			ok:   true,
+		},
+		{
+			name: "same change on both sides",
+			in:   "<<<<<<< HEAD\nsame\n||||||| base\nold\n=======\nsame\n>>>>>>> abc\n",
+			want: "same\n",
+			ok:   true,
+		},
+		{
+			name: "only theirs changed",
+			in:   "<<<<<<< HEAD\nold\n||||||| base\nold\n=======\nnew\n>>>>>>> abc\n",
+			want: "new\n",
+			ok:   true,
+		},
+		{
+			name: "overlapping change",
+			in:   "<<<<<<< HEAD\nx = 2\n||||||| base\nx = 1\n=======\nx = 3\n>>>>>>> abc\n",
+			ok:   false,
+		},
+		{
+			name: "one trivial and one overlapping block",
+			in: "<<<<<<< HEAD\nours\n||||||| base\n=======\ntheirs\n>>>>>>> abc\n" +
+				"<<<<<<< HEAD\nx = 2\n||||||| base\nx = 1\n=======\nx = 3\n>>>>>>> abc\n",
+			ok: false,
+		},
+		{
+			name: "without a base section",
+			in:   "<<<<<<< HEAD\nours\n=======\ntheirs\n>>>>>>> abc\n",
+			ok:   false,
+		},
+		{
+			name: "unterminated block",
+			in:   "<<<<<<< HEAD\nours\n||||||| base\n=======\ntheirs\n",
+			ok:   false,
+		},
+		{
+			name: "no conflicts",
+			in:   "plain\n",
+			ok:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := resolveConflicts([]byte(tt.in))
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if ok && string(got) != tt.want {
+				t.Errorf("resolved = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package orchestrator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/ai"
+	"github.com/Iron-Ham/claudio/internal/worktree"
+)
+
+// ConflictResolverPromptTemplate is the prompt of an instance started to
+// resolve the conflicts a paused consolidation is waiting on. It takes the
+// task ID and the list of conflicted files.
+const ConflictResolverPromptTemplate = `You are resolving merge conflicts left by a cherry-pick during consolidation.
+
+The commits of task %s conflict with the work already consolidated in this worktree. The cherry-pick is in progress; "ours" (HEAD) is the consolidated work and "theirs" is the task's commit.
+
+Conflicted files:
+%s
+
+For each file:
+1. Read both sides of every conflict hunk and understand what each change was for
+2. Edit the file so it keeps the intent of both sides, removing every conflict marker
+3. Run "git add <file>" once it is resolved
+
+Build and run the tests relevant to the files you touched. Do NOT commit, and do NOT run "git cherry-pick --continue", "--abort", or "--skip": the operator resumes consolidation once every file is resolved.`
+
+// pausedConflictWorktree returns the worktree a paused consolidation is
+// waiting on, or an error if consolidation is not paused on a conflict.
+func (c *Coordinator) pausedConflictWorktree() (string, error) {
+	session := c.Session()
+	if session == nil || session.Consolidation == nil {
+		return "", fmt.Errorf("no consolidation in progress")
+	}
+	if session.Consolidation.Phase != ConsolidationPaused || session.Consolidation.ConflictWorktree == "" {
+		return "", fmt.Errorf("consolidation is not paused on a conflict")
+	}
+	if c.orch == nil || c.orch.wt == nil {
+		return "", fmt.Errorf("orchestrator not initialized")
+	}
+	return session.Consolidation.ConflictWorktree, nil
+}
+
+// ConsolidationConflicts returns the files still conflicted in the worktree
+// a paused consolidation is waiting on, with their conflict hunks.
+func (c *Coordinator) ConsolidationConflicts() ([]worktree.ConflictedFile, error) {
+	path, err := c.pausedConflictWorktree()
+	if err != nil {
+		return nil, err
+	}
+	return c.orch.wt.Conflicts(path)
+}
+
+// ResolveConsolidationConflict resolves file in the paused consolidation's
+// worktree by keeping side's version of it.
+func (c *Coordinator) ResolveConsolidationConflict(file string, side worktree.ConflictSide) error {
+	path, err := c.pausedConflictWorktree()
+	if err != nil {
+		return err
+	}
+	return c.orch.wt.ResolveConflict(path, file, side)
+}
+
+// MarkConsolidationConflictResolved marks file resolved as it was edited in
+// the paused consolidation's worktree.
+func (c *Coordinator) MarkConsolidationConflictResolved(file string) error {
+	path, err := c.pausedConflictWorktree()
+	if err != nil {
+		return err
+	}
+	return c.orch.wt.MarkConflictResolved(path, file)
+}
+
+// StartConflictResolver starts an instance in the paused consolidation's
+// worktree to resolve its remaining conflicts. The instance stages what it
+// resolves; consolidation still waits for ResumeConsolidation.
+func (c *Coordinator) StartConflictResolver() (*Instance, error) {
+	path, err := c.pausedConflictWorktree()
+	if err != nil {
+		return nil, err
+	}
+	files, err := c.orch.wt.GetConflictingFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no conflicted files left to resolve")
+	}
+
+	session := c.Session()
+	taskID := session.Consolidation.ConflictTaskID
+	prompt := fmt.Sprintf(ConflictResolverPromptTemplate, taskID, "- "+strings.Join(files, "\n- "))
+	inst, err := c.orch.AddInstanceToWorktree(c.baseSession, prompt, path, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create conflict resolver instance: %w", err)
+	}
+
+	// Show it with the consolidation instance in the sidebar
+	sessionType := SessionTypeUltraPlan
+	if session.Config.MultiPass {
+		sessionType = SessionTypePlanMulti
+	}
+	if ultraGroup := c.baseSession.GetGroupBySessionType(sessionType); ultraGroup != nil {
+		ultraGroup.AddInstance(inst.ID)
+	}
+
+	if err := c.orch.StartInstanceWithOverrides(inst, ai.StartOptions{Model: c.phaseModel(PhaseConsolidating)}); err != nil {
+		return nil, fmt.Errorf("failed to start conflict resolver instance: %w", err)
+	}
+
+	c.logger.Info("started conflict resolver",
+		"instance_id", inst.ID,
+		"conflict_task_id", taskID,
+		"conflict_files", files,
+	)
+	return inst, nil
+}
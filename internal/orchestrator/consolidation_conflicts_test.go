@@ -0,0 +1,42 @@
+package orchestrator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/worktree"
+)
+
+func TestConsolidationConflicts_RequirePausedConflict(t *testing.T) {
+	tests := []struct {
+		name          string
+		consolidation *ConsolidatorState
+		wantErr       string
+	}{
+		{"no consolidation", nil, "no consolidation in progress"},
+		{"merging", &ConsolidatorState{Phase: ConsolidationMergingTasks, ConflictWorktree: "/tmp/wt"}, "not paused on a conflict"},
+		{"paused without worktree", &ConsolidatorState{Phase: ConsolidationPaused}, "not paused on a conflict"},
+		{"no orchestrator", &ConsolidatorState{Phase: ConsolidationPaused, ConflictWorktree: "/tmp/wt"}, "orchestrator not initialized"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := NewUltraPlanSession("Test objective", DefaultUltraPlanConfig())
+			session.Consolidation = tt.consolidation
+			coord := &Coordinator{manager: &UltraPlanManager{session: session}}
+
+			_, err := coord.ConsolidationConflicts()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ConsolidationConflicts() error = %v, want %q", err, tt.wantErr)
+			}
+			if err := coord.ResolveConsolidationConflict("a.go", worktree.ConflictOurs); err == nil {
+				t.Error("ResolveConsolidationConflict() error = nil")
+			}
+			if err := coord.MarkConsolidationConflictResolved("a.go"); err == nil {
+				t.Error("MarkConsolidationConflictResolved() error = nil")
+			}
+			if _, err := coord.StartConflictResolver(); err == nil {
+				t.Error("StartConflictResolver() error = nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+// Package contextpack assembles a task's context pack: the file excerpts,
+// interface definitions, and docs chosen for it during planning, copied
+// into one Markdown file in the task's worktree before it starts.
+//
+// The task prompt points at the file, so an instance starts from the code it
+// needs instead of spending tool calls and tokens finding it.
+package contextpack
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+)
+
+const (
+	// maxFileSize skips files too large to be hand-written source or docs.
+	maxFileSize = 1 << 20
+
+	// maxExcerptLines bounds a single excerpt.
+	maxExcerptLines = 400
+
+	// maxDefinitionLines bounds a single interface definition.
+	maxDefinitionLines = 200
+
+	// maxSignatureLines bounds how far a definition's header may wrap
+	// before its body opens.
+	maxSignatureLines = 20
+)
+
+// languages maps file extensions to Markdown code fence languages.
+var languages = map[string]string{
+	".go":    "go",
+	".py":    "python",
+	".rs":    "rust",
+	".swift": "swift",
+	".kt":    "kotlin",
+	".java":  "java",
+	".js":    "javascript",
+	".jsx":   "jsx",
+	".ts":    "typescript",
+	".tsx":   "tsx",
+	".rb":    "ruby",
+	".sh":    "sh",
+	".yaml":  "yaml",
+	".yml":   "yaml",
+	".json":  "json",
+	".proto": "protobuf",
+	".sql":   "sql",
+}
+
+// Write builds pack from the files in worktreePath and writes it to
+// types.ContextPackFileName at the worktree root. It returns how many
+// entries could not be resolved; those are listed in the file as missing.
+func Write(worktreePath string, pack *types.ContextPack) (missing int, err error) {
+	content, missing := Build(worktreePath, pack)
+	if err := os.WriteFile(filepath.Join(worktreePath, types.ContextPackFileName), []byte(content), 0644); err != nil {
+		return missing, fmt.Errorf("write context pack: %w", err)
+	}
+	return missing, nil
+}
+
+// Build renders pack as Markdown, reading each entry from the repository at
+// repoPath. Entries whose file or symbol is not found are listed as missing
+// rather than failing the pack; missing is their count.
+func Build(repoPath string, pack *types.ContextPack) (content string, missing int) {
+	var sb strings.Builder
+	sb.WriteString("# Context Pack\n\n")
+	sb.WriteString("Code and docs selected for this task during planning, copied from the repository as it was when the task started. ")
+	sb.WriteString("This file is not part of the repository: do not edit or commit it.\n")
+	if pack.IsEmpty() {
+		return sb.String(), 0
+	}
+
+	if len(pack.Excerpts) > 0 {
+		sb.WriteString("\n## Excerpts\n")
+		for _, e := range pack.Excerpts {
+			if !writeExcerpt(&sb, repoPath, e) {
+				missing++
+			}
+		}
+	}
+	if len(pack.Interfaces) > 0 {
+		sb.WriteString("\n## Interfaces\n")
+		for _, iface := range pack.Interfaces {
+			if !writeInterface(&sb, repoPath, iface) {
+				missing++
+			}
+		}
+	}
+	if len(pack.Docs) > 0 {
+		sb.WriteString("\n## Docs\n")
+		for _, doc := range pack.Docs {
+			if !writeDoc(&sb, repoPath, doc) {
+				missing++
+			}
+		}
+	}
+	return sb.String(), missing
+}
+
+// writeExcerpt writes the lines of e, reporting whether its file was found.
+func writeExcerpt(sb *strings.Builder, repoPath string, e types.Excerpt) bool {
+	lines, err := readLines(filepath.Join(repoPath, e.Path))
+	if err != nil {
+		fmt.Fprintf(sb, "\n### %s\n\n_Missing: %v_\n", e.Path, err)
+		return false
+	}
+
+	start, end := max(e.StartLine, 1), e.EndLine
+	if end == 0 || end > len(lines) {
+		end = len(lines)
+	}
+	if start > end {
+		fmt.Fprintf(sb, "\n### %s\n\n_Missing: lines %d-%d are past the end of the file (%d lines)_\n",
+			e.Path, e.StartLine, e.EndLine, len(lines))
+		return false
+	}
+	truncated := end-start+1 > maxExcerptLines
+	if truncated {
+		end = start + maxExcerptLines - 1
+	}
+
+	fmt.Fprintf(sb, "\n### %s (lines %d-%d)\n\n", e.Path, start, end)
+	if e.Reason != "" {
+		sb.WriteString(e.Reason + "\n\n")
+	}
+	writeCode(sb, e.Path, lines[start-1:end])
+	if truncated {
+		fmt.Fprintf(sb, "\n_Truncated to %d lines._\n", maxExcerptLines)
+	}
+	return true
+}
+
+// writeInterface writes the definition of an interface entry ("path:Name"),
+// reporting whether it was found.
+func writeInterface(sb *strings.Builder, repoPath, iface string) bool {
+	pattern, name := types.SplitSymbol(iface)
+	matches, _ := filepath.Glob(filepath.Join(repoPath, pattern))
+	slices.Sort(matches)
+	for _, match := range matches {
+		lines, err := readLines(match)
+		if err != nil {
+			continue
+		}
+		start, end, ok := findDefinition(lines, name)
+		if !ok {
+			continue
+		}
+		start = leadingComments(lines, start)
+		rel, _ := filepath.Rel(repoPath, match)
+		rel = filepath.ToSlash(rel)
+		fmt.Fprintf(sb, "\n### %s (%s, lines %d-%d)\n\n", name, rel, start+1, end)
+		writeCode(sb, rel, lines[start:end])
+		return true
+	}
+	fmt.Fprintf(sb, "\n### %s\n\n_Missing: no definition found in %s_\n", name, pattern)
+	return false
+}
+
+// writeDoc writes a doc file whole, reporting whether it was found.
+func writeDoc(sb *strings.Builder, repoPath, doc string) bool {
+	data, err := readFile(filepath.Join(repoPath, doc))
+	if err != nil {
+		fmt.Fprintf(sb, "\n### %s\n\n_Missing: %v_\n", doc, err)
+		return false
+	}
+	fmt.Fprintf(sb, "\n### %s\n\n", doc)
+	if ext := filepath.Ext(doc); ext == ".md" || ext == ".markdown" || ext == ".txt" {
+		sb.WriteString(strings.TrimRight(string(data), "\n") + "\n")
+	} else {
+		writeCode(sb, doc, strings.Split(strings.TrimRight(string(data), "\n"), "\n"))
+	}
+	return true
+}
+
+// writeCode writes lines as a fenced code block, lengthening the fence when
+// the code itself contains one.
+func writeCode(sb *strings.Builder, path string, lines []string) {
+	code := strings.Join(lines, "\n")
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	sb.WriteString(fence + languages[strings.ToLower(filepath.Ext(path))] + "\n")
+	sb.WriteString(code + "\n")
+	sb.WriteString(fence + "\n")
+}
+
+// findDefinition returns the line range [start, end) of name's definition.
+// A body in braces ends at its closing brace; a header ending in ":" (as in
+// Python) takes the lines indented under it; anything else is one
+// statement, continued while a line ends with "(" or ",".
+func findDefinition(lines []string, name string) (start, end int, ok bool) {
+	definition := types.SymbolDefinition(name)
+	start = slices.IndexFunc(lines, definition.MatchString)
+	if start < 0 {
+		return 0, 0, false
+	}
+	limit := min(len(lines), start+maxDefinitionLines)
+
+	if strings.HasSuffix(strings.TrimSpace(lines[start]), ":") {
+		indent := indentation(lines[start])
+		end = start + 1
+		for i := start + 1; i < limit; i++ {
+			if strings.TrimSpace(lines[i]) == "" {
+				continue
+			}
+			if indentation(lines[i]) <= indent {
+				break
+			}
+			end = i + 1
+		}
+		return start, end, true
+	}
+
+	depth, opened := 0, false
+	for i := start; i < limit; i++ {
+		depth += strings.Count(lines[i], "{") - strings.Count(lines[i], "}")
+		opened = opened || strings.Contains(lines[i], "{")
+		switch {
+		case opened && depth <= 0:
+			return start, i + 1, true
+		case !opened && i-start >= maxSignatureLines:
+			return start, start + 1, true
+		case !opened:
+			trimmed := strings.TrimSpace(lines[i])
+			if !strings.HasSuffix(trimmed, "(") && !strings.HasSuffix(trimmed, ",") &&
+				!strings.HasSuffix(trimmed, "->") && i+1 < len(lines) &&
+				!strings.HasPrefix(strings.TrimSpace(lines[i+1]), "{") &&
+				!strings.HasPrefix(strings.TrimSpace(lines[i+1]), ")") {
+				return start, i + 1, true
+			}
+		}
+	}
+	return start, limit, true
+}
+
+// leadingComments returns the first line of the doc comments, attributes,
+// and decorators directly above line def.
+func leadingComments(lines []string, def int) int {
+	for def > 0 {
+		trimmed := strings.TrimSpace(lines[def-1])
+		if trimmed == "" || !strings.HasPrefix(trimmed, "//") && !strings.HasPrefix(trimmed, "#") &&
+			!strings.HasPrefix(trimmed, "/*") && !strings.HasPrefix(trimmed, "*") && !strings.HasPrefix(trimmed, "@") {
+			return def
+		}
+		def--
+	}
+	return def
+}
+
+// indentation returns the width of line's leading whitespace.
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// readFile reads a regular file no larger than maxFileSize.
+func readFile(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	switch {
+	case err != nil:
+		return nil, errors.New("not found")
+	case !info.Mode().IsRegular():
+		return nil, errors.New("not a regular file")
+	case info.Size() > maxFileSize:
+		return nil, fmt.Errorf("file is larger than %d bytes", maxFileSize)
+	}
+	return os.ReadFile(path)
+}
+
+// readLines reads a file's lines.
+func readLines(path string) ([]string, error) {
+	data, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxFileSize)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
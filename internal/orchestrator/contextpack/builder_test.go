@@ -0,0 +1,158 @@
+package contextpack
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+)
+
+const storeGo = `package auth
+
+import "context"
+
+// Store persists sessions.
+type Store interface {
+	Get(ctx context.Context, id string) (*Session, error)
+	Put(ctx context.Context, s *Session) error
+}
+
+// Lookup finds a session by id.
+func Lookup(
+	ctx context.Context,
+	id string,
+) (*Session, error) {
+	return nil, nil
+}
+
+type ID string
+`
+
+const sessionPy = `import time
+
+
+@dataclass
+class Session:
+    id: str
+
+    def expired(self):
+        return time.time() > self.expires
+
+
+def helper():
+    pass
+`
+
+func writeRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestBuild(t *testing.T) {
+	repo := writeRepo(t, map[string]string{
+		"internal/auth/store.go": storeGo,
+		"auth/session.py":        sessionPy,
+		"docs/auth.md":           "# Auth\n\nSessions expire after an hour.\n",
+	})
+	pack := &types.ContextPack{
+		Excerpts: []types.Excerpt{
+			{Path: "internal/auth/store.go", StartLine: 5, EndLine: 9, Reason: "The interface to implement"},
+			{Path: "internal/auth/missing.go"},
+		},
+		Interfaces: []string{
+			"internal/auth/*.go:Lookup",
+			"internal/auth/store.go:ID",
+			"auth/session.py:Session",
+			"internal/auth/store.go:Nope",
+		},
+		Docs: []string{"docs/auth.md"},
+	}
+
+	content, missing := Build(repo, pack)
+	if missing != 2 {
+		t.Errorf("missing = %d, want 2", missing)
+	}
+	for _, want := range []string{
+		"do not edit or commit it",
+		"### internal/auth/store.go (lines 5-9)\n\nThe interface to implement\n\n```go\n// Store persists sessions.\ntype Store interface {",
+		"Put(ctx context.Context, s *Session) error\n}\n```",
+		"### internal/auth/missing.go\n\n_Missing: not found_",
+		"### Lookup (internal/auth/store.go, lines 11-17)\n\n```go\n// Lookup finds a session by id.\nfunc Lookup(\n",
+		"\treturn nil, nil\n}\n```",
+		"### ID (internal/auth/store.go, lines 19-19)\n\n```go\ntype ID string\n```",
+		"### Session (auth/session.py, lines 4-9)\n\n```python\n@dataclass\nclass Session:\n",
+		"        return time.time() > self.expires\n```",
+		"### Nope\n\n_Missing: no definition found in internal/auth/store.go_",
+		"## Docs\n\n### docs/auth.md\n\n# Auth\n\nSessions expire after an hour.\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("content missing %q\n\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "def helper") {
+		t.Error("Python definition should stop at the next top-level line")
+	}
+}
+
+func TestBuild_ExcerptRanges(t *testing.T) {
+	repo := writeRepo(t, map[string]string{"a.txt": "one\ntwo\nthree\n"})
+	tests := []struct {
+		name    string
+		excerpt types.Excerpt
+		want    string
+		missing int
+	}{
+		{"whole file", types.Excerpt{Path: "a.txt"}, "(lines 1-3)\n\n```\none\ntwo\nthree\n```", 0},
+		{"end clamped", types.Excerpt{Path: "a.txt", StartLine: 2, EndLine: 99}, "(lines 2-3)\n\n```\ntwo\nthree\n```", 0},
+		{"past end", types.Excerpt{Path: "a.txt", StartLine: 5}, "past the end of the file (3 lines)", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, missing := Build(repo, &types.ContextPack{Excerpts: []types.Excerpt{tt.excerpt}})
+			if missing != tt.missing {
+				t.Errorf("missing = %d, want %d", missing, tt.missing)
+			}
+			if !strings.Contains(content, tt.want) {
+				t.Errorf("content missing %q\n\n%s", tt.want, content)
+			}
+		})
+	}
+}
+
+func TestBuild_FenceInCode(t *testing.T) {
+	repo := writeRepo(t, map[string]string{"README.go": "// ```\n// example\n// ```\n"})
+	content, _ := Build(repo, &types.ContextPack{Excerpts: []types.Excerpt{{Path: "README.go"}}})
+	if !strings.Contains(content, "````go\n// ```\n") || !strings.HasSuffix(content, "// ```\n````\n") {
+		t.Errorf("fence should be longer than any fence in the code:\n%s", content)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	repo := writeRepo(t, map[string]string{"a.go": "package a\n"})
+	missing, err := Write(repo, &types.ContextPack{Excerpts: []types.Excerpt{{Path: "a.go"}}})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if missing != 0 {
+		t.Errorf("missing = %d, want 0", missing)
+	}
+	data, err := os.ReadFile(filepath.Join(repo, types.ContextPackFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "```go\npackage a\n```") {
+		t.Errorf("written pack = %q", data)
+	}
+}
@@ -3,9 +3,12 @@ package orchestrator
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/Iron-Ham/claudio/internal/ai"
+	"github.com/Iron-Ham/claudio/internal/estimate"
 	"github.com/Iron-Ham/claudio/internal/event"
 	"github.com/Iron-Ham/claudio/internal/logging"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/group"
@@ -35,6 +38,19 @@ type ExecutionRunner interface {
 	Stop()
 }
 
+// TaskAdder is implemented by execution backends that accept tasks while
+// they run (see Coordinator.AddTask).
+type TaskAdder interface {
+	AddTask(task PlannedTask) error
+}
+
+// TaskReviser is implemented by execution backends that can also drop tasks
+// while they run (see Coordinator.RevisePlan).
+type TaskReviser interface {
+	TaskAdder
+	RemoveTask(taskID string) error
+}
+
 // PipelineRunnerFactory creates a PipelineRunner on demand. It is called
 // lazily from StartExecution() when UsePipeline is enabled. The factory
 // receives the Coordinator's own dependencies so the caller doesn't need
@@ -48,6 +64,22 @@ type PipelineRunnerDeps struct {
 	Verifier    Verifier
 	Plan        *PlanSpec
 	MaxParallel int
+
+	// RetrySection returns the prompt section describing a task's failed
+	// previous attempt, or "" when there is none.
+	RetrySection func(taskID string) string
+
+	// ProgressSection returns the prompt section giving a task the progress
+	// an earlier attempt recorded, or "" when there is none.
+	ProgressSection func(taskID string) string
+
+	// TaskModel returns the model a task's next attempt runs on, or "" for
+	// the backend's configured model.
+	TaskModel func(taskID string) string
+
+	// Resume restores each team's task queue from the state saved by the
+	// interrupted run, set by Coordinator.ResumeExecution.
+	Resume bool
 }
 
 // Coordinator orchestrates the execution of an ultra-plan
@@ -67,6 +99,9 @@ type Coordinator struct {
 	synthesisOrchestrator     *phase.SynthesisOrchestrator
 	consolidationOrchestrator *phase.ConsolidationOrchestrator
 
+	// Serializes AddTask, which edits a copy of the plan
+	addTaskMu sync.Mutex
+
 	// Running state
 	ctx        context.Context
 	cancelFunc context.CancelFunc
@@ -82,6 +117,26 @@ type Coordinator struct {
 	pipelineFactory PipelineRunnerFactory // creates runner lazily on first StartExecution
 	pipelineSubIDs  []string              // event subscription IDs for cleanup
 	usePipeline     bool                  // opt-in flag
+	resumeExecution bool                  // restore team queues saved by an interrupted run
+
+	// OpenTelemetry spans of the run (tracing.enabled)
+	trace coordinatorTrace
+
+	// Task estimates from past sessions, loaded on first use (guarded by mu)
+	estimator *estimate.Estimator
+
+	// Event bus publishing state (guarded by mu, see coordinator_events.go)
+	publishedPhase UltraPlanPhase
+	taskInstances  map[string]string // taskID -> instanceID
+	taskResults    map[string]bool   // taskID -> success of the published outcome
+
+	// Unregisters the pending group approval from the control API (guarded
+	// by mu, see group_approval.go)
+	groupApproverRemove func()
+
+	// Progress summary watch (guarded by mu, see progress.go)
+	progressWatching bool
+	progressAsked    map[string]time.Time // instanceID -> when last asked
 }
 
 // NewCoordinator creates a new coordinator for an ultra-plan session.
@@ -198,6 +253,11 @@ func NewCoordinator(orch *Orchestrator, baseSession *Session, ultraSession *Ultr
 	// depends on the coordinator being fully constructed.
 	// This is handled by the getter methods which call initializeOrchestrators().
 
+	// A session restored while waiting for group approval still waits
+	if ultraSession.GroupApproval != nil {
+		c.registerGroupApprover()
+	}
+
 	return c
 }
 
@@ -312,7 +372,7 @@ func (c *Coordinator) RunPlanning() error {
 		session.CoordinatorID = id
 	}
 
-	return po.ExecuteWithPrompt(c.ctx, prompt, c.baseSession, getGroup, setCoordinatorID)
+	return po.ExecuteWithPrompt(c.traceContext(), prompt, c.baseSession, getGroup, setCoordinatorID)
 }
 
 // RunMultiPassPlanning executes the multi-pass planning phase
@@ -379,7 +439,7 @@ func (c *Coordinator) RunMultiPassPlanning() error {
 		planCoordinatorIDs = append(planCoordinatorIDs, inst.ID)
 
 		// Start the instance
-		if err := c.orch.StartInstance(inst); err != nil {
+		if err := c.orch.StartInstanceWithOverrides(inst, ai.StartOptions{Model: c.phaseModel(PhasePlanning)}); err != nil {
 			c.logger.Error("planning failed",
 				"error", err.Error(),
 				"strategy", strategy,
@@ -480,7 +540,7 @@ func (c *Coordinator) RunPlanManager() error {
 	_ = c.orch.SaveSession()
 
 	// Start the instance
-	if err := c.orch.StartInstance(inst); err != nil {
+	if err := c.orch.StartInstanceWithOverrides(inst, ai.StartOptions{Model: c.phaseModel(PhasePlanSelection)}); err != nil {
 		// Update PlanningOrchestrator state on error
 		if po := c.PlanningOrchestrator(); po != nil {
 			po.SetError(err.Error())
@@ -546,8 +606,11 @@ func (c *Coordinator) SetPlan(plan *PlanSpec) error {
 		return fmt.Errorf("invalid plan: %w", err)
 	}
 
+	conflicts := c.predictPlanConflicts(plan)
+
 	c.mu.Lock()
 	c.manager.session.Plan = plan
+	c.manager.session.PlanConflicts = conflicts
 	c.mu.Unlock()
 
 	// Persist the plan
@@ -561,6 +624,18 @@ func (c *Coordinator) SetPlan(plan *PlanSpec) error {
 	return nil
 }
 
+// ResumeExecution restarts the execution phase of a session interrupted
+// while executing. With pipeline execution, each team's task queue is
+// restored from the state saved before the interruption, so finished tasks
+// are not run again and tasks whose instance is still running are monitored
+// instead of restarted.
+func (c *Coordinator) ResumeExecution() error {
+	c.mu.Lock()
+	c.resumeExecution = true
+	c.mu.Unlock()
+	return c.StartExecution()
+}
+
 // StartExecution begins the execution phase
 // This spawns child instances for each task group
 func (c *Coordinator) StartExecution() error {
@@ -577,6 +652,7 @@ func (c *Coordinator) StartExecution() error {
 	usePipeline := c.usePipeline || session.Config.UsePipeline
 	factory := c.pipelineFactory
 	runner := c.pipelineRunner
+	resume := c.resumeExecution
 	c.mu.Unlock()
 
 	// Lazy runner creation: if UsePipeline is enabled via config but no
@@ -584,11 +660,15 @@ func (c *Coordinator) StartExecution() error {
 	if usePipeline && runner == nil && factory != nil {
 		var err error
 		runner, err = factory(PipelineRunnerDeps{
-			Orch:        c.orch,
-			Session:     c.baseSession,
-			Verifier:    c.verifier,
-			Plan:        session.Plan,
-			MaxParallel: session.Config.MaxParallel,
+			Orch:            c.orch,
+			Session:         c.baseSession,
+			Verifier:        c.verifier,
+			Plan:            session.Plan,
+			MaxParallel:     session.Config.MaxParallel,
+			RetrySection:    c.RetrySection,
+			ProgressSection: c.ProgressSection,
+			TaskModel:       c.TaskModel,
+			Resume:          resume,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to start plan execution: %w", err)
@@ -600,7 +680,19 @@ func (c *Coordinator) StartExecution() error {
 	}
 
 	if usePipeline && runner != nil {
-		return c.startPipelineExecution()
+		if err := c.startPipelineExecution(); err != nil {
+			return err
+		}
+		c.startProgressWatch()
+		return nil
+	}
+
+	// Tool tasks are dispatched by the pipeline bridges only; the legacy path
+	// would hand their description to the default AI backend.
+	for _, task := range session.Plan.Tasks {
+		if ai.IsDeterministic(task.Backend) {
+			return fmt.Errorf("task %s selects the %q backend, which requires pipeline execution", task.ID, task.Backend)
+		}
 	}
 
 	// Get ExecutionOrchestrator - always delegate to it
@@ -622,10 +714,11 @@ func (c *Coordinator) StartExecution() error {
 	c.wg.Add(1)
 	go func() {
 		defer c.wg.Done()
-		if execErr := eo.ExecuteWithContext(c.ctx, execCtx); execErr != nil {
+		if execErr := eo.ExecuteWithContext(c.traceContext(), execCtx); execErr != nil {
 			c.logger.Error("execution phase failed", "error", execErr)
 		}
 	}()
+	c.startProgressWatch()
 
 	return nil
 }
@@ -652,13 +745,29 @@ func (c *Coordinator) startPipelineExecution() error {
 		}
 	})
 
+	// Record task self-reviews as tasks complete (ultraplan.self_review)
+	reviewSubID := bus.Subscribe("bridge.task_completed", func(e event.Event) {
+		if tce, ok := e.(event.BridgeTaskCompletedEvent); ok {
+			go c.onBridgeTaskCompleted(tce)
+		}
+	})
+
+	// Queue follow-up tasks the bridges forward from completion reports
+	proposalSubID := bus.Subscribe("mailbox.message", func(e event.Event) {
+		if me, ok := e.(event.MailboxMessageEvent); ok {
+			go c.onMailboxMessage(me)
+		}
+	})
+
 	c.mu.Lock()
-	c.pipelineSubIDs = []string{subID}
+	c.pipelineSubIDs = []string{subID, reviewSubID, proposalSubID}
 	c.mu.Unlock()
 
-	if err := runner.Start(c.ctx); err != nil {
-		// Cleanup subscription on failure
+	if err := runner.Start(c.traceContext()); err != nil {
+		// Cleanup subscriptions on failure
 		bus.Unsubscribe(subID)
+		bus.Unsubscribe(reviewSubID)
+		bus.Unsubscribe(proposalSubID)
 		c.mu.Lock()
 		c.pipelineSubIDs = nil
 		c.mu.Unlock()
@@ -749,7 +858,7 @@ func (c *Coordinator) RunSynthesis() error {
 	c.wg.Add(1)
 	go func() {
 		defer c.wg.Done()
-		if err := so.Execute(c.ctx); err != nil {
+		if err := so.Execute(c.traceContext()); err != nil {
 			c.logger.Error("synthesis phase failed", "error", err)
 			// Update session state on failure
 			session := c.Session()
@@ -765,6 +874,100 @@ func (c *Coordinator) RunSynthesis() error {
 	return nil
 }
 
+// AddTask adds a follow-up task to the running plan, so work discovered
+// during execution runs in this session instead of a new one. dependsOn is
+// merged into the task's own DependsOn. The task is checked against the plan
+// like a plan edit, and may not depend on a failed task. The pipeline runner
+// queues it on a team; the legacy path adds it to the first group after its
+// dependencies' groups, or to the current group when they are done. The updated plan is then saved and a task.added
+// event published.
+func (c *Coordinator) AddTask(task PlannedTask, dependsOn []string) error {
+	c.addTaskMu.Lock()
+	defer c.addTaskMu.Unlock()
+
+	session := c.Session()
+	if session == nil || session.Plan == nil {
+		return fmt.Errorf("no plan available")
+	}
+	if session.Phase != PhaseExecuting {
+		return fmt.Errorf("can only add tasks during execution (current: %s)", session.Phase)
+	}
+
+	deps := slices.Clone(task.DependsOn)
+	for _, id := range dependsOn {
+		if !slices.Contains(deps, id) {
+			deps = append(deps, id)
+		}
+	}
+	task.DependsOn = deps
+
+	c.mu.RLock()
+	plan, err := ClonePlan(session.Plan)
+	currentGroup := session.CurrentGroup
+	failed := slices.Clone(session.FailedTasks)
+	runner := c.pipelineRunner
+	usePipeline := c.usePipeline && runner != nil
+	c.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range task.DependsOn {
+		if slices.Contains(failed, id) {
+			return ErrInvalidDependency{TaskID: task.ID, DependencyID: id, Reason: "dependency task failed"}
+		}
+	}
+	if !usePipeline && currentGroup >= len(plan.ExecutionOrder) {
+		return fmt.Errorf("all execution groups are complete")
+	}
+	order := plan.ExecutionOrder
+	if err := AddTask(plan, "", task); err != nil {
+		return fmt.Errorf("invalid task: %w", err)
+	}
+
+	if usePipeline {
+		adder, ok := runner.(TaskAdder)
+		if !ok {
+			return fmt.Errorf("execution backend does not support adding tasks")
+		}
+		if err := adder.AddTask(task); err != nil {
+			return err
+		}
+	} else {
+		// Groups before the current one are consolidated, and tasks added
+		// earlier may sit outside their recalculated group, so keep the
+		// running order and place the task after its dependencies, no
+		// earlier than the current group.
+		g := currentGroup
+		for i, group := range order {
+			for _, id := range task.DependsOn {
+				if slices.Contains(group, id) {
+					g = max(g, i+1)
+				}
+			}
+		}
+		if g == len(order) {
+			order = append(order, nil)
+		}
+		order[g] = append(order[g], task.ID)
+		plan.ExecutionOrder = order
+	}
+
+	c.mu.Lock()
+	session.Plan = plan
+	c.mu.Unlock()
+
+	if err := c.orch.SaveSession(); err != nil {
+		c.logger.Warn("failed to save session after adding task", "task_id", task.ID, "error", err)
+	}
+
+	c.logger.Info("task added", "task_id", task.ID, "depends_on", task.DependsOn)
+	if bus := c.eventBus(); bus != nil {
+		bus.Publish(event.NewTaskAddedEvent(task.ID, task.Title, task.DependsOn))
+	}
+	return nil
+}
+
 // TriggerConsolidation manually signals that synthesis is done and consolidation should proceed.
 // This is called from the TUI when the user indicates they're done with synthesis review.
 func (c *Coordinator) TriggerConsolidation() error {
@@ -788,10 +991,12 @@ func (c *Coordinator) TriggerConsolidation() error {
 		so.SetAwaitingApproval(false)
 	}
 
-	// Stop the synthesis instance if it's still running
-	if session.SynthesisID != "" {
-		inst := c.orch.GetInstance(session.SynthesisID)
-		if inst != nil {
+	// Stop the synthesis instances if they're still running
+	for _, id := range append([]string{session.SynthesisID}, session.SynthesisReviewerIDs...) {
+		if id == "" {
+			continue
+		}
+		if inst := c.orch.GetInstance(id); inst != nil {
 			_ = c.orch.StopInstance(inst)
 		}
 	}
@@ -823,6 +1028,14 @@ func (c *Coordinator) StartConsolidation() error {
 		co.Reset()
 	}
 
+	// Check that PRs can be created before any branch is pushed
+	if err := c.checkPRCapability(); err != nil {
+		return err
+	}
+
+	// Check how stale the consolidated work is before PRs are opened
+	c.checkConsolidationFreshness()
+
 	// Build the consolidation prompt
 	prompt := BuildConsolidationPrompt(c)
 
@@ -845,7 +1058,7 @@ func (c *Coordinator) StartConsolidation() error {
 	session.ConsolidationID = inst.ID
 
 	// Start the instance
-	if err := c.orch.StartInstance(inst); err != nil {
+	if err := c.orch.StartInstanceWithOverrides(inst, ai.StartOptions{Model: c.phaseModel(PhaseConsolidating)}); err != nil {
 		return fmt.Errorf("failed to start consolidation instance: %w", err)
 	}
 
@@ -859,6 +1072,75 @@ func (c *Coordinator) StartConsolidation() error {
 	return nil
 }
 
+// checkPRCapability checks that the PR provider (gh by default) can create
+// the consolidation PRs. If it cannot, pr.missing_cli "fail" stops
+// consolidation before anything is pushed; otherwise PR creation is marked
+// skipped, so the consolidation instance pushes its branches and reports the
+// PRs as pending instead.
+func (c *Coordinator) checkPRCapability() error {
+	err := checkPRCLI(c.orch.PRProvider())
+	if err == nil {
+		return nil
+	}
+	if c.orch.config.PR.MissingCLI == "fail" {
+		return fmt.Errorf("cannot create pull requests: %w (set pr.missing_cli to \"degrade\" to push branches and create the PRs later)", err)
+	}
+
+	c.logger.Warn("PR provider unavailable, consolidation will push branches without creating PRs",
+		"provider", c.orch.PRProvider().Name(), "error", err)
+	session := c.Session()
+	c.mu.Lock()
+	session.Consolidation.PRsSkipped = true
+	session.Consolidation.PRsSkipReason = err.Error()
+	c.mu.Unlock()
+	return nil
+}
+
+// checkConsolidationFreshness records how many commits the consolidated work
+// is behind origin's main branch. The most recent pre-consolidated group
+// branch is checked when one exists; otherwise the local main branch, which
+// the consolidation instance branches from. Failures (e.g. no origin remote)
+// are logged and leave the state without freshness information.
+func (c *Coordinator) checkConsolidationFreshness() {
+	session := c.Session()
+	if c.orch == nil || c.orch.wt == nil {
+		return
+	}
+
+	mainBranch := c.orch.wt.FindMainBranch()
+	branch := mainBranch
+	for _, b := range slices.Backward(session.GroupConsolidatedBranches) {
+		if b != "" {
+			branch = b
+			break
+		}
+	}
+
+	behindBy, err := c.orch.wt.GetBranchBehindCount(branch)
+	if err != nil {
+		c.logger.Warn("could not check consolidated branch freshness",
+			"branch", branch,
+			"error", err,
+		)
+		return
+	}
+
+	c.mu.Lock()
+	state := session.Consolidation
+	state.RecordFreshness("origin/"+mainBranch, behindBy, session.Config)
+	c.mu.Unlock()
+
+	if state.Stale {
+		c.logger.Warn("consolidated branch is behind target",
+			"branch", branch,
+			"target", state.TargetBranch,
+			"behind_by", behindBy,
+			"threshold", session.Config.MaxBehindCommits,
+			"auto_rebase", state.Rebasing,
+		)
+	}
+}
+
 // GetConsolidation returns the current consolidation state
 func (c *Coordinator) GetConsolidation() *ConsolidatorState {
 	session := c.Session()
@@ -973,13 +1255,20 @@ func (c *Coordinator) Cancel() {
 	session := c.Session()
 	session.Phase = PhaseFailed
 	session.Error = "cancelled by user"
+	session.GroupApproval = nil
 	c.pipelineSubIDs = nil
+	removeApprover := c.groupApproverRemove
+	c.groupApproverRemove = nil
 	c.mu.Unlock()
+	if removeApprover != nil {
+		removeApprover()
+	}
 
 	// Persist the cancellation state
 	if err := c.orch.SaveSession(); err != nil {
 		c.logger.Error("failed to persist cancellation state", "error", err)
 	}
+	c.traceComplete(false, session.Error)
 }
 
 // Wait waits for the ultra-plan to complete
@@ -1099,11 +1388,12 @@ func (c *Coordinator) ResumeWithPartialWork() error {
 	if err := eo.ResumeWithPartialWork(); err != nil {
 		return err
 	}
+	session := c.Session()
+	c.awaitGroupApproval(session.CurrentGroup)
 
 	// Advance to the next group AFTER consolidation succeeds
 	// This is critical - without this, checkAndAdvanceGroup() would detect
 	// the partial failure again and re-prompt the user
-	session := c.Session()
 	c.mu.Lock()
 	session.CurrentGroup++
 	c.mu.Unlock()
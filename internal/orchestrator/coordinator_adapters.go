@@ -1,6 +1,12 @@
 package orchestrator
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/audit"
+)
 
 // coordinatorRetryTracker adapts the Coordinator's RetryManager to the verify.RetryTracker interface.
 type coordinatorRetryTracker struct {
@@ -22,10 +28,20 @@ func (rt *coordinatorRetryTracker) IncrementRetry(taskID string) int {
 	if maxRetries == 0 {
 		maxRetries = 3
 	}
-	state := rt.c.retryManager.GetOrCreateState(taskID, maxRetries)
+	rt.c.retryManager.GetOrCreateState(taskID, maxRetries)
 	rt.c.retryManager.RecordAttempt(taskID, false) // false = failure
 	rt.c.retryManager.SetLastError(taskID, "task produced no commits")
-	return state.RetryCount + 1
+	retryCount := rt.c.retryManager.GetState(taskID).RetryCount
+	if delay := rt.c.retryStrategy().Delay(retryCount); delay > 0 {
+		rt.c.retryManager.ScheduleRetry(taskID, time.Now().Add(delay))
+		rt.c.logger.Info("task retry scheduled", "task_id", taskID, "retry", retryCount, "delay", delay)
+	}
+	return retryCount + 1
+}
+
+func (rt *coordinatorRetryTracker) RecordFailure(taskID, reason, diff string) {
+	rt.c.retryManager.SetLastError(taskID, reason)
+	rt.c.retryManager.SetLastDiff(taskID, truncateRetryDiff(diff))
 }
 
 func (rt *coordinatorRetryTracker) RecordCommitCount(taskID string, count int) {
@@ -61,6 +77,10 @@ func (e *coordinatorEventEmitter) EmitWarning(taskID, message string) {
 }
 
 func (e *coordinatorEventEmitter) EmitRetry(taskID string, attempt, maxRetries int, reason string) {
+	e.c.orch.RecordDecision(audit.ActionTaskRetried, e.c.taskInstance(taskID), taskID, reason, map[string]string{
+		"attempt":     strconv.Itoa(attempt),
+		"max_retries": strconv.Itoa(maxRetries),
+	})
 	e.c.manager.emitEvent(CoordinatorEvent{
 		Type:    EventTaskStarted, // Reuse for retry notification
 		TaskID:  taskID,
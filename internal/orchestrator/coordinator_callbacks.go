@@ -37,6 +37,8 @@ func (c *Coordinator) notifyPhaseChange(phase UltraPlanPhase) {
 	}
 
 	c.manager.SetPhase(phase)
+	c.tracePhase(phase)
+	c.publishPhaseChange(phase)
 
 	// Log the phase transition
 	c.logger.Info("phase changed",
@@ -60,6 +62,7 @@ func (c *Coordinator) notifyPhaseChange(phase UltraPlanPhase) {
 // notifyTaskStart notifies callbacks of task start
 func (c *Coordinator) notifyTaskStart(taskID, instanceID string) {
 	c.manager.AssignTaskToInstance(taskID, instanceID)
+	c.recordTaskInstance(taskID, instanceID)
 
 	// Get task title for logging
 	session := c.Session()
@@ -70,6 +73,9 @@ func (c *Coordinator) notifyTaskStart(taskID, instanceID string) {
 		}
 	}
 
+	c.traceTaskStart(taskID, instanceID, taskTitle)
+	c.recordTaskStart(taskID, instanceID, taskTitle)
+
 	// Log task started
 	c.logger.Info("task started",
 		"task_id", taskID,
@@ -89,6 +95,8 @@ func (c *Coordinator) notifyTaskStart(taskID, instanceID string) {
 // notifyTaskComplete notifies callbacks of task completion
 func (c *Coordinator) notifyTaskComplete(taskID string) {
 	c.manager.MarkTaskComplete(taskID)
+	c.trace.tasks.End(taskID)
+	c.publishTaskResult(taskID, true, "")
 
 	// Log task completed
 	// Note: duration tracking requires instance start time, which could be added in the future
@@ -111,6 +119,8 @@ func (c *Coordinator) notifyTaskComplete(taskID string) {
 // notifyTaskFailed notifies callbacks of task failure
 func (c *Coordinator) notifyTaskFailed(taskID, reason string) {
 	c.manager.MarkTaskFailed(taskID, reason)
+	c.trace.tasks.Fail(taskID, reason)
+	c.publishTaskResult(taskID, false, reason)
 
 	// Log task failed
 	c.logger.Info("task failed",
@@ -186,6 +196,13 @@ func (c *Coordinator) notifyComplete(success bool, summary string) {
 		"success", success,
 		"summary", summary,
 	)
+	c.traceComplete(success, summary)
+	if success {
+		c.publishConsolidationPRs()
+	}
+	if c.orch != nil {
+		c.orch.writeReport(c.baseSession)
+	}
 
 	c.mu.RLock()
 	cb := c.callbacks
@@ -2,10 +2,16 @@ package orchestrator
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
+	"github.com/Iron-Ham/claudio/internal/ai"
+	"github.com/Iron-Ham/claudio/internal/audit"
 	"github.com/Iron-Ham/claudio/internal/instance"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/consolidation/mergequeue"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/group/consolidate"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/verify"
 	"github.com/Iron-Ham/claudio/internal/worktree"
 )
 
@@ -27,7 +33,7 @@ func (a *coordinatorConsolidateAdapter) Session() consolidate.SessionInterface {
 }
 
 func (a *coordinatorConsolidateAdapter) Orchestrator() consolidate.OrchestratorInterface {
-	return &orchestratorConsolidateAdapter{o: a.c.orch}
+	return &orchestratorConsolidateAdapter{o: a.c.orch, model: a.c.phaseModel(PhaseConsolidating)}
 }
 
 func (a *coordinatorConsolidateAdapter) BaseSession() consolidate.BaseSessionInterface {
@@ -45,6 +51,32 @@ func (a *coordinatorConsolidateAdapter) Context() consolidate.ContextInterface {
 	return &contextConsolidateAdapter{ctx: a.c.ctx}
 }
 
+func (a *coordinatorConsolidateAdapter) RecordConflictResolution(groupIndex int, res *mergequeue.Result) {
+	inputs := map[string]string{
+		"group":     strconv.Itoa(groupIndex + 1),
+		"reordered": strconv.FormatBool(res.Reordered),
+	}
+	if len(res.AutoResolved) > 0 {
+		inputs["auto_resolved"] = strings.Join(res.AutoResolved, ",")
+	}
+	if len(res.RerereResolved) > 0 {
+		inputs["reused_resolutions"] = strings.Join(res.RerereResolved, ",")
+	}
+	a.c.orch.RecordDecision(audit.ActionConflictResolved, "", "", "merge queue resolved conflicts", inputs)
+}
+
+func (a *coordinatorConsolidateAdapter) RecordResolverOutcome(groupIndex int, taskID, instanceID string, completion *types.ConflictResolutionCompletionFile) {
+	files := make([]string, len(completion.FilesResolved))
+	for i, f := range completion.FilesResolved {
+		files[i] = f.File
+	}
+	inputs := map[string]string{
+		"group": strconv.Itoa(groupIndex + 1),
+		"files": strings.Join(files, ","),
+	}
+	a.c.orch.RecordDecision(audit.ActionConflictResolved, instanceID, taskID, "conflict resolver instance resolved cherry-pick conflicts", inputs)
+}
+
 // sessionConsolidateAdapter adapts UltraPlanSession to consolidate.SessionInterface.
 type sessionConsolidateAdapter struct {
 	s *UltraPlanSession
@@ -130,8 +162,12 @@ type configConsolidateAdapter struct {
 	c *UltraPlanConfig
 }
 
-func (a *configConsolidateAdapter) GetBranchPrefix() string { return a.c.BranchPrefix }
-func (a *configConsolidateAdapter) IsMultiPass() bool       { return a.c.MultiPass }
+func (a *configConsolidateAdapter) GetBranchPrefix() string       { return a.c.BranchPrefix }
+func (a *configConsolidateAdapter) IsMultiPass() bool             { return a.c.MultiPass }
+func (a *configConsolidateAdapter) IsFreshVerification() bool     { return a.c.FreshVerification }
+func (a *configConsolidateAdapter) IsMergeQueue() bool            { return a.c.MergeQueue }
+func (a *configConsolidateAdapter) IsConflictResolver() bool      { return a.c.ConflictResolver }
+func (a *configConsolidateAdapter) GetVerify() verify.GroupConfig { return a.c.Verify }
 
 // taskConsolidateAdapter adapts PlannedTask to consolidate.TaskInterface.
 type taskConsolidateAdapter struct {
@@ -143,7 +179,8 @@ func (a *taskConsolidateAdapter) GetTitle() string { return a.t.Title }
 
 // orchestratorConsolidateAdapter adapts Orchestrator to consolidate.OrchestratorInterface.
 type orchestratorConsolidateAdapter struct {
-	o *Orchestrator
+	o     *Orchestrator
+	model string // Model for consolidator instances ("" = backend default)
 }
 
 func (a *orchestratorConsolidateAdapter) Worktree() consolidate.WorktreeInterface {
@@ -175,6 +212,18 @@ func (a *orchestratorConsolidateAdapter) AddInstanceFromBranch(baseSession conso
 	return &instanceConsolidateAdapter{i: inst}, nil
 }
 
+func (a *orchestratorConsolidateAdapter) AddInstanceToWorktree(baseSession consolidate.BaseSessionInterface, prompt, worktreePath string) (consolidate.InstanceInterface, error) {
+	bsa, ok := baseSession.(*baseSessionConsolidateAdapter)
+	if !ok {
+		return nil, fmt.Errorf("baseSession is not a baseSessionConsolidateAdapter (got %T)", baseSession)
+	}
+	inst, err := a.o.AddInstanceToWorktree(bsa.s, prompt, worktreePath, "")
+	if err != nil {
+		return nil, err
+	}
+	return &instanceConsolidateAdapter{i: inst}, nil
+}
+
 func (a *orchestratorConsolidateAdapter) GetInstance(id string) consolidate.InstanceInterface {
 	inst := a.o.GetInstance(id)
 	if inst == nil {
@@ -188,7 +237,7 @@ func (a *orchestratorConsolidateAdapter) StartInstance(inst consolidate.Instance
 	if !ok {
 		return fmt.Errorf("instance is not an instanceConsolidateAdapter (got %T)", inst)
 	}
-	return a.o.StartInstance(ica.i)
+	return a.o.StartInstanceWithOverrides(ica.i, ai.StartOptions{Model: a.model})
 }
 
 func (a *orchestratorConsolidateAdapter) StopInstance(inst consolidate.InstanceInterface) error {
@@ -247,10 +296,22 @@ func (a *worktreeConsolidateAdapter) CherryPickBranch(worktreePath, sourceBranch
 	return a.wt.CherryPickBranch(worktreePath, sourceBranch)
 }
 
+func (a *worktreeConsolidateAdapter) CherryPickBranchAfter(worktreePath, sourceBranch, afterCommit string) error {
+	return a.wt.CherryPickBranchAfter(worktreePath, sourceBranch, afterCommit)
+}
+
 func (a *worktreeConsolidateAdapter) AbortCherryPick(worktreePath string) error {
 	return a.wt.AbortCherryPick(worktreePath)
 }
 
+func (a *worktreeConsolidateAdapter) ContinueCherryPick(worktreePath string) error {
+	return a.wt.ContinueCherryPick(worktreePath)
+}
+
+func (a *worktreeConsolidateAdapter) Conflicts(worktreePath string) ([]worktree.ConflictedFile, error) {
+	return a.wt.Conflicts(worktreePath)
+}
+
 func (a *worktreeConsolidateAdapter) CountCommitsBetween(worktreePath, baseBranch, head string) (int, error) {
 	return a.wt.CountCommitsBetween(worktreePath, baseBranch, head)
 }
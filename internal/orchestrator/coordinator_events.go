@@ -0,0 +1,113 @@
+package orchestrator
+
+import (
+	"strconv"
+
+	"github.com/Iron-Ham/claudio/internal/audit"
+	"github.com/Iron-Ham/claudio/internal/event"
+)
+
+// publishPhaseChange publishes a phase.changed event for the ultra-plan's
+// move to phase. Phases reach the coordinator both from its own transitions
+// and from the phase orchestrators, so a phase already published is
+// skipped.
+func (c *Coordinator) publishPhaseChange(phase UltraPlanPhase) {
+	bus := c.eventBus()
+	if bus == nil {
+		return
+	}
+	c.mu.Lock()
+	previous := c.publishedPhase
+	if previous == phase {
+		c.mu.Unlock()
+		return
+	}
+	c.publishedPhase = phase
+	c.mu.Unlock()
+
+	sessionID := ""
+	if session := c.Session(); session != nil {
+		sessionID = session.ID
+	}
+	bus.Publish(event.NewPhaseChangeEvent(sessionID, event.Phase(previous), event.Phase(phase)))
+}
+
+// recordTaskInstance remembers which instance runs taskID, for the
+// task.completed event published when it finishes.
+func (c *Coordinator) recordTaskInstance(taskID, instanceID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.taskInstances == nil {
+		c.taskInstances = make(map[string]string)
+	}
+	c.taskInstances[taskID] = instanceID
+}
+
+// taskInstance returns the instance recorded as running taskID, if any.
+func (c *Coordinator) taskInstance(taskID string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.taskInstances[taskID]
+}
+
+// recordTaskStart records the decision to start taskID on instanceID in the
+// audit log, with the attempt it is when the task was retried.
+func (c *Coordinator) recordTaskStart(taskID, instanceID, title string) {
+	inputs := map[string]string{"title": title}
+	if c.retryManager != nil {
+		if state := c.retryManager.GetState(taskID); state != nil && state.RetryCount > 0 {
+			inputs["attempt"] = strconv.Itoa(state.RetryCount + 1)
+		}
+	}
+	c.orch.RecordDecision(audit.ActionTaskStarted, instanceID, taskID, "dependencies complete", inputs)
+}
+
+// publishTaskResult publishes a task.completed event for a planned task
+// that completed or failed. A task's outcome can be reported on more than
+// one path, so an outcome already published for it is skipped.
+func (c *Coordinator) publishTaskResult(taskID string, success bool, reason string) {
+	bus := c.eventBus()
+	if bus == nil {
+		return
+	}
+	c.mu.Lock()
+	if published, ok := c.taskResults[taskID]; ok && published == success {
+		c.mu.Unlock()
+		return
+	}
+	if c.taskResults == nil {
+		c.taskResults = make(map[string]bool)
+	}
+	c.taskResults[taskID] = success
+	instanceID := c.taskInstances[taskID]
+	c.mu.Unlock()
+
+	title := ""
+	if session := c.Session(); session != nil {
+		if task := session.GetTask(taskID); task != nil {
+			title = task.Title
+		}
+	}
+	bus.Publish(event.NewTaskCompletedEvent(taskID, instanceID, success, reason, title))
+}
+
+// publishConsolidationPRs publishes a pr.opened event for each pull
+// request the consolidation opened.
+func (c *Coordinator) publishConsolidationPRs() {
+	bus := c.eventBus()
+	session := c.Session()
+	if bus == nil || session == nil || session.Consolidation == nil {
+		return
+	}
+	for _, url := range session.Consolidation.PRUrls {
+		bus.Publish(event.NewPROpenedEvent(session.ConsolidationID, url))
+	}
+}
+
+// eventBus returns the orchestrator's event bus, or nil without one.
+func (c *Coordinator) eventBus() *event.Bus {
+	if c.orch == nil {
+		return nil
+	}
+	return c.orch.EventBus()
+}
@@ -0,0 +1,54 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/logging"
+)
+
+func newEventsTestCoordinator(t *testing.T) (*Coordinator, *[]event.Event) {
+	t.Helper()
+	session := NewUltraPlanSession("Test", DefaultUltraPlanConfig())
+	session.Plan = &PlanSpec{Tasks: []PlannedTask{{ID: "t1", Title: "Add login"}}}
+	bus := event.NewBus()
+	var published []event.Event
+	bus.SubscribeAll(func(e event.Event) { published = append(published, e) })
+	return &Coordinator{
+		manager: NewUltraPlanManager(nil, nil, session, logging.NopLogger()),
+		orch:    &Orchestrator{eventBus: bus},
+	}, &published
+}
+
+func TestCoordinator_PublishPhaseChange(t *testing.T) {
+	c, published := newEventsTestCoordinator(t)
+
+	c.publishPhaseChange(PhasePlanning)
+	c.publishPhaseChange(PhasePlanning) // reported again by a phase orchestrator
+	c.publishPhaseChange(PhaseExecuting)
+
+	if len(*published) != 2 {
+		t.Fatalf("published %d events, want one per transition", len(*published))
+	}
+	last := (*published)[1].(event.PhaseChangeEvent)
+	if last.PreviousPhase != "planning" || last.CurrentPhase != "executing" || last.SessionID != c.Session().ID {
+		t.Errorf("event = %+v", last)
+	}
+}
+
+func TestCoordinator_PublishTaskResult(t *testing.T) {
+	c, published := newEventsTestCoordinator(t)
+
+	c.recordTaskInstance("t1", "inst-1")
+	c.publishTaskResult("t1", false, "no commits")
+	c.publishTaskResult("t1", false, "no commits") // reported on a second path
+	c.publishTaskResult("t1", true, "")            // succeeded on retry
+
+	if len(*published) != 2 {
+		t.Fatalf("published %d events, want one per outcome", len(*published))
+	}
+	failed := (*published)[0].(event.TaskCompletedEvent)
+	if failed.Success || failed.InstanceID != "inst-1" || failed.Title != "Add login" || failed.Reason != "no commits" {
+		t.Errorf("failure event = %+v", failed)
+	}
+}
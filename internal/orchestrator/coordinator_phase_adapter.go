@@ -2,9 +2,12 @@ package orchestrator
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/Iron-Ham/claudio/internal/ai"
 	"github.com/Iron-Ham/claudio/internal/logging"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/phase"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/verify"
 )
 
@@ -110,13 +113,61 @@ func (a *coordinatorOrchestratorAdapter) AddInstance(session any, task string) (
 	return a.c.orch.AddInstance(a.c.baseSession, task)
 }
 
-// StartInstance starts a backend process for the given instance.
+// AddTaskInstance creates the instance for the next attempt at a plan task
+// on its deterministic branch (see Orchestrator.TaskBranchName), branched
+// from baseBranch or HEAD. When an earlier run of the same plan left that
+// branch behind, the instance reattaches to it instead of creating another.
+func (a *coordinatorOrchestratorAdapter) AddTaskInstance(taskID, task, baseBranch string) (any, error) {
+	if a.c == nil || a.c.orch == nil {
+		return nil, ErrNilCoordinator
+	}
+	session := a.c.Session()
+	if session == nil || session.Plan == nil {
+		return nil, fmt.Errorf("no plan for task %s", taskID)
+	}
+	branch := a.c.orch.TaskBranchName(session.Plan.ContentHash(), taskID, a.c.TaskAttempt(taskID))
+	inst, reused, err := a.c.orch.AddOrReattachInstanceFromBranch(a.c.baseSession, task, baseBranch, branch, "")
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		a.c.logger.Info("reattached task to its existing branch", "task_id", taskID, "branch", branch)
+	}
+	return inst, nil
+}
+
+// StartInstance starts a backend process for the given instance, on the
+// model configured for the session's current phase.
 func (a *coordinatorOrchestratorAdapter) StartInstance(inst any) error {
 	if a.c == nil || a.c.orch == nil {
 		return ErrNilCoordinator
 	}
 	if instance, ok := inst.(*Instance); ok {
-		return a.c.orch.StartInstance(instance)
+		return a.c.orch.StartInstanceWithOverrides(instance, ai.StartOptions{Model: a.c.currentPhaseModel()})
+	}
+	return ErrInstanceTypeAssertion
+}
+
+// StartInstanceWithEnv starts a backend process for the given instance with
+// env exported, e.g. the plan's environment for a task instance.
+func (a *coordinatorOrchestratorAdapter) StartInstanceWithEnv(inst any, env map[string]string) error {
+	if a.c == nil || a.c.orch == nil {
+		return ErrNilCoordinator
+	}
+	if instance, ok := inst.(*Instance); ok {
+		return a.c.orch.StartInstanceWithOverrides(instance, ai.StartOptions{Env: env})
+	}
+	return ErrInstanceTypeAssertion
+}
+
+// StartInstanceWithModel starts a backend process for the given instance on
+// model, with env exported.
+func (a *coordinatorOrchestratorAdapter) StartInstanceWithModel(inst any, env map[string]string, model string) error {
+	if a.c == nil || a.c.orch == nil {
+		return ErrNilCoordinator
+	}
+	if instance, ok := inst.(*Instance); ok {
+		return a.c.orch.StartInstanceWithOverrides(instance, ai.StartOptions{Env: env, Model: model})
 	}
 	return ErrInstanceTypeAssertion
 }
@@ -286,6 +337,75 @@ func (a *coordinatorSessionAdapter) GetCompletedTasks() []string {
 	return a.session.CompletedTasks
 }
 
+// GetPlanEnv returns the variables the plan exports to every task.
+func (a *coordinatorSessionAdapter) GetPlanEnv() map[string]string {
+	if a.session == nil || a.session.Plan == nil {
+		return nil
+	}
+	return a.session.Plan.Env
+}
+
+// GetBaseDriftSection returns the prompt section describing base branch
+// changes to the task's files, or "" when there are none.
+func (a *coordinatorSessionAdapter) GetBaseDriftSection(taskID string) string {
+	if a.c == nil || a.c.orch == nil {
+		return ""
+	}
+	return a.c.orch.BaseDriftSection(taskID)
+}
+
+// GetSelfReviewSection returns the prompt section asking tasks to review
+// their own work, or "" when ultraplan.self_review is disabled.
+func (a *coordinatorSessionAdapter) GetSelfReviewSection() string {
+	if a.c == nil || a.c.orch == nil {
+		return ""
+	}
+	return a.c.orch.SelfReviewSection()
+}
+
+// GetRetrySection returns the prompt section describing the task's failed
+// previous attempt, or "" when there is none.
+func (a *coordinatorSessionAdapter) GetRetrySection(taskID string) string {
+	if a.c == nil {
+		return ""
+	}
+	return a.c.RetrySection(taskID)
+}
+
+// GetProgressSection returns the prompt section giving the task the progress
+// an earlier attempt recorded, or "" when there is none.
+func (a *coordinatorSessionAdapter) GetProgressSection(taskID string) string {
+	if a.c == nil {
+		return ""
+	}
+	return a.c.ProgressSection(taskID)
+}
+
+// GetTaskModel returns the model the task's next attempt runs on, or ""
+// for the backend's configured model.
+func (a *coordinatorSessionAdapter) GetTaskModel(taskID string) string {
+	if a.c == nil {
+		return ""
+	}
+	return a.c.TaskModel(taskID)
+}
+
+// GetRetryWait returns how long the task must still wait before retrying.
+func (a *coordinatorSessionAdapter) GetRetryWait(taskID string) time.Duration {
+	if a.c == nil {
+		return 0
+	}
+	return a.c.RetryWait(taskID)
+}
+
+// GetTaskSelfReviews returns the self-reviews tasks reported on completion.
+func (a *coordinatorSessionAdapter) GetTaskSelfReviews() map[string]types.SelfReview {
+	if a.session == nil {
+		return nil
+	}
+	return a.session.TaskSelfReviews
+}
+
 // GetTaskToInstance returns the mapping of task IDs to instance IDs.
 func (a *coordinatorSessionAdapter) GetTaskToInstance() map[string]string {
 	if a.session == nil {
@@ -318,6 +438,30 @@ func (a *coordinatorSessionAdapter) SetSynthesisID(id string) {
 	a.session.SynthesisID = id
 }
 
+// GetSynthesisReviewers returns how many parallel synthesis reviewers to run.
+func (a *coordinatorSessionAdapter) GetSynthesisReviewers() int {
+	if a.session == nil {
+		return 0
+	}
+	return a.session.Config.SynthesisReviewers
+}
+
+// GetSynthesisReviewerIDs returns the instance IDs of the parallel synthesis reviewers.
+func (a *coordinatorSessionAdapter) GetSynthesisReviewerIDs() []string {
+	if a.session == nil {
+		return nil
+	}
+	return a.session.SynthesisReviewerIDs
+}
+
+// SetSynthesisReviewerIDs sets the instance IDs of the parallel synthesis reviewers.
+func (a *coordinatorSessionAdapter) SetSynthesisReviewerIDs(ids []string) {
+	if a.session == nil {
+		return
+	}
+	a.session.SynthesisReviewerIDs = ids
+}
+
 // GetRevisionRound returns the current revision round (0 for first synthesis).
 func (a *coordinatorSessionAdapter) GetRevisionRound() int {
 	if a.session == nil || a.session.Revision == nil {
@@ -363,6 +507,7 @@ func (a *coordinatorSessionAdapter) SetSynthesisCompletion(completion *phase.Syn
 			Files:       issue.Files,
 			Severity:    issue.Severity,
 			Suggestion:  issue.Suggestion,
+			Reviewers:   issue.Reviewers,
 		})
 	}
 	a.session.SynthesisCompletion = orchCompletion
@@ -430,6 +575,8 @@ func (a *coordinatorCallbacksAdapter) OnPhaseChange(p phase.UltraPlanPhase) {
 	if a.c == nil {
 		return
 	}
+	a.c.tracePhase(UltraPlanPhase(p))
+	a.c.publishPhaseChange(UltraPlanPhase(p))
 	a.c.mu.RLock()
 	cb := a.c.callbacks
 	a.c.mu.RUnlock()
@@ -456,6 +603,7 @@ func (a *coordinatorCallbacksAdapter) OnTaskComplete(taskID string) {
 	if a.c == nil {
 		return
 	}
+	a.c.publishTaskResult(taskID, true, "")
 	a.c.mu.RLock()
 	cb := a.c.callbacks
 	a.c.mu.RUnlock()
@@ -469,6 +617,7 @@ func (a *coordinatorCallbacksAdapter) OnTaskFailed(taskID, reason string) {
 	if a.c == nil {
 		return
 	}
+	a.c.publishTaskResult(taskID, false, reason)
 	a.c.mu.RLock()
 	cb := a.c.callbacks
 	a.c.mu.RUnlock()
@@ -1025,8 +1174,8 @@ func (a *executionCoordinatorAdapter) VerifyTaskWork(taskID string, inst any) ph
 
 	// Build verification options from task metadata
 	var opts *verify.TaskVerifyOptions
-	if task := session.GetTask(taskID); task != nil && task.NoCode {
-		opts = &verify.TaskVerifyOptions{NoCode: true}
+	if task := session.GetTask(taskID); task != nil && (task.NoCode || ai.IsDeterministic(task.Backend) || !task.Criteria.IsEmpty()) {
+		opts = &verify.TaskVerifyOptions{NoCode: task.NoCode, Deterministic: ai.IsDeterministic(task.Backend), Criteria: task.Criteria}
 	}
 
 	// Delegate to the verifier for the core verification logic
@@ -1040,6 +1189,7 @@ func (a *executionCoordinatorAdapter) VerifyTaskWork(taskID string, inst any) ph
 		}
 		session.TaskCommitCounts[taskID] = verifyResult.CommitCount
 		a.c.mu.Unlock()
+		a.c.recordSelfReview(taskID, instance.WorktreePath)
 	}
 
 	// Sync retry state back to session for persistence after verification
@@ -1181,6 +1331,14 @@ func (a *executionCoordinatorAdapter) StartGroupConsolidation(groupIndex int) er
 	return StartGroupConsolidatorSession(a.c, groupIndex)
 }
 
+// AwaitGroupApproval pauses before the next group when ultraplan.group_approval is enabled.
+func (a *executionCoordinatorAdapter) AwaitGroupApproval(groupIndex int) {
+	if a.c == nil {
+		return
+	}
+	a.c.awaitGroupApproval(groupIndex)
+}
+
 // HandlePartialGroupFailure handles a group with mixed success/failure.
 // This sets up the GroupDecision state and pauses execution for user decision.
 func (a *executionCoordinatorAdapter) HandlePartialGroupFailure(groupIndex int) {
@@ -1401,6 +1559,8 @@ func (a *executionCoordinatorAdapter) ResetStateForRetrigger(targetGroup int, ta
 	for t := range tasksToReset {
 		delete(session.TaskToInstance, t)
 		delete(session.TaskCommitCounts, t)
+		delete(session.TaskSelfReviews, t)
+		delete(session.TaskProgress, t)
 	}
 
 	// Reset retry state for affected tasks
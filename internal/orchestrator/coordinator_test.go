@@ -1258,6 +1258,42 @@ func TestConsolidatorState_HasConflict(t *testing.T) {
 	}
 }
 
+// TestConsolidatorState_RecordFreshness tests the freshness threshold decision
+func TestConsolidatorState_RecordFreshness(t *testing.T) {
+	tests := []struct {
+		name         string
+		behindBy     int
+		maxBehind    int
+		autoRebase   bool
+		wantStale    bool
+		wantRebasing bool
+	}{
+		{"up to date", 0, 20, true, false, false},
+		{"at threshold", 20, 20, true, false, false},
+		{"over threshold", 21, 20, false, true, false},
+		{"over threshold with auto rebase", 21, 20, true, true, true},
+		{"check disabled", 500, 0, true, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var state ConsolidatorState
+			cfg := UltraPlanConfig{MaxBehindCommits: tt.maxBehind, AutoRebase: tt.autoRebase}
+			state.RecordFreshness("origin/main", tt.behindBy, cfg)
+
+			if state.TargetBranch != "origin/main" || state.BehindBy != tt.behindBy {
+				t.Errorf("recorded (%q, %d), want (origin/main, %d)", state.TargetBranch, state.BehindBy, tt.behindBy)
+			}
+			if state.Stale != tt.wantStale {
+				t.Errorf("Stale = %v, want %v", state.Stale, tt.wantStale)
+			}
+			if state.Rebasing != tt.wantRebasing {
+				t.Errorf("Rebasing = %v, want %v", state.Rebasing, tt.wantRebasing)
+			}
+		})
+	}
+}
+
 // =============================================================================
 // Phase Orchestrator Delegation Tests
 // =============================================================================
@@ -2917,3 +2953,106 @@ func assertGroupContains(t *testing.T, group *InstanceGroup, instanceID string)
 	}
 	t.Errorf("%s not found in group %q AllInstanceIDs()", instanceID, group.Name)
 }
+
+// addingRunner is an ExecutionRunner that accepts tasks while it runs.
+type addingRunner struct {
+	mockExecutionRunner
+	added []PlannedTask
+}
+
+func (r *addingRunner) AddTask(task PlannedTask) error {
+	r.added = append(r.added, task)
+	return nil
+}
+
+func newAddTaskTestCoordinator(t *testing.T) (*Coordinator, *[]event.TaskAddedEvent) {
+	t.Helper()
+	session := NewUltraPlanSession("Test", DefaultUltraPlanConfig())
+	session.Plan = &PlanSpec{Tasks: []PlannedTask{
+		{ID: "t1", Title: "Add login"},
+		{ID: "t2", Title: "Add logout", DependsOn: []string{"t1"}},
+	}}
+	if err := recalculatePlan(session.Plan); err != nil {
+		t.Fatal(err)
+	}
+	bus := event.NewBus()
+	var added []event.TaskAddedEvent
+	bus.Subscribe("task.added", func(e event.Event) { added = append(added, e.(event.TaskAddedEvent)) })
+	return &Coordinator{
+		manager: NewUltraPlanManager(nil, nil, session, logging.NopLogger()),
+		orch:    &Orchestrator{eventBus: bus},
+		logger:  logging.NopLogger(),
+	}, &added
+}
+
+func TestCoordinator_AddTask(t *testing.T) {
+	c, added := newAddTaskTestCoordinator(t)
+	session := c.Session()
+
+	if err := c.AddTask(PlannedTask{ID: "t3", Title: "Fix flaky test"}, nil); err == nil {
+		t.Fatal("expected error outside execution")
+	}
+
+	session.Phase = PhaseExecuting
+	session.CompletedTasks = []string{"t1"}
+	session.CurrentGroup = 1
+
+	// Group 0 is done, so a task without dependencies joins group 1
+	if err := c.AddTask(PlannedTask{ID: "t3", Title: "Fix flaky test"}, nil); err != nil {
+		t.Fatalf("AddTask(t3): %v", err)
+	}
+	if err := c.AddTask(PlannedTask{ID: "t4", Title: "Document logout", DependsOn: []string{"t1"}}, []string{"t2", "t1"}); err != nil {
+		t.Fatalf("AddTask(t4): %v", err)
+	}
+	want := [][]string{{"t1"}, {"t2", "t3"}, {"t4"}}
+	if got := session.Plan.ExecutionOrder; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ExecutionOrder = %v, want %v", got, want)
+	}
+	if task := session.GetTask("t4"); task == nil || fmt.Sprint(task.DependsOn) != "[t1 t2]" {
+		t.Errorf("t4 = %+v, want it to depend on t1 and t2", task)
+	}
+	if len(session.GetReadyTasks()) != 2 {
+		t.Errorf("ready tasks = %v, want t2 and t3", session.GetReadyTasks())
+	}
+
+	session.FailedTasks = []string{"t2"}
+	for _, tc := range []struct {
+		name string
+		task PlannedTask
+		deps []string
+	}{
+		{"duplicate", PlannedTask{ID: "t3"}, nil},
+		{"unknown dependency", PlannedTask{ID: "t5"}, []string{"nope"}},
+		{"failed dependency", PlannedTask{ID: "t5"}, []string{"t2"}},
+	} {
+		if err := c.AddTask(tc.task, tc.deps); err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+
+	if len(*added) != 2 || (*added)[1].TaskID != "t4" || len((*added)[1].DependsOn) != 2 {
+		t.Errorf("task.added events = %+v, want t3 and t4", *added)
+	}
+}
+
+func TestCoordinator_AddTask_Pipeline(t *testing.T) {
+	c, added := newAddTaskTestCoordinator(t)
+	c.Session().Phase = PhaseExecuting
+
+	c.SetPipelineRunner(&mockExecutionRunner{})
+	if err := c.AddTask(PlannedTask{ID: "t3", Title: "Fix flaky test"}, []string{"t1"}); err == nil {
+		t.Error("expected error from a runner that cannot add tasks")
+	}
+
+	runner := &addingRunner{}
+	c.SetPipelineRunner(runner)
+	if err := c.AddTask(PlannedTask{ID: "t3", Title: "Fix flaky test"}, []string{"t1"}); err != nil {
+		t.Fatalf("AddTask: %v", err)
+	}
+	if len(runner.added) != 1 || runner.added[0].DependsOn[0] != "t1" {
+		t.Errorf("runner got %+v, want t3 depending on t1", runner.added)
+	}
+	if c.Session().GetTask("t3") == nil || len(*added) != 1 {
+		t.Errorf("plan or events missing t3: %d events", len(*added))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/Iron-Ham/claudio/internal/instance"
 	"github.com/Iron-Ham/claudio/internal/logging"
 )
 
@@ -27,3 +28,14 @@ func NewCoordinatorForTesting(ultraSession *UltraPlanSession) *Coordinator {
 		mu:           sync.RWMutex{},
 	}
 }
+
+// NewObserverForTesting creates a minimal Orchestrator observing sess, as
+// ObserveSession would, without reading a session file or observing any
+// instance. It should only be used in tests of what observers may do.
+func NewObserverForTesting(sess *Session) *Orchestrator {
+	return &Orchestrator{
+		session:   sess,
+		observing: true,
+		instances: make(map[string]*instance.Manager),
+	}
+}
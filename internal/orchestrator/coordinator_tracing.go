@@ -0,0 +1,127 @@
+package orchestrator
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Iron-Ham/claudio/internal/tracing"
+)
+
+// coordinatorTrace holds the spans of an ultra-plan run: one for the whole
+// run, one for the current phase, and one per running task. The phase span
+// is carried by the context handed to phase orchestrators and the pipeline,
+// so their spans nest under it. Spans are no-ops unless tracing is enabled.
+type coordinatorTrace struct {
+	mu        sync.Mutex
+	run       trace.Span
+	runCtx    context.Context
+	phase     UltraPlanPhase
+	phaseSpan trace.Span
+	phaseCtx  context.Context
+	ended     bool
+	tasks     tracing.Spans
+}
+
+// tracePhase ends the current phase span and starts one for phase, starting
+// the run span first if this is the first phase. Entering the current phase
+// again keeps its span; complete and failed end the phase without starting
+// another.
+func (c *Coordinator) tracePhase(phase UltraPlanPhase) {
+	t := &c.trace
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.ended || (t.phaseSpan != nil && t.phase == phase) {
+		return
+	}
+
+	if t.run == nil {
+		var attrs []attribute.KeyValue
+		if session := c.Session(); session != nil {
+			attrs = append(attrs,
+				tracing.SessionID.String(session.ID),
+				attribute.Bool("claudio.multi_pass", session.Config.MultiPass),
+			)
+		}
+		t.runCtx, t.run = tracing.Tracer().Start(c.baseContext(), "ultraplan", trace.WithAttributes(attrs...))
+	}
+
+	t.endPhaseLocked()
+	t.phase = phase
+	if phase == PhaseComplete || phase == PhaseFailed {
+		return
+	}
+	t.phaseCtx, t.phaseSpan = tracing.Tracer().Start(t.runCtx, "ultraplan."+string(phase),
+		trace.WithAttributes(tracing.Phase.String(string(phase))))
+}
+
+// endPhaseLocked ends the current phase span, if any. Caller must hold t.mu.
+func (t *coordinatorTrace) endPhaseLocked() {
+	if t.phaseSpan != nil {
+		t.phaseSpan.End()
+		t.phaseSpan, t.phaseCtx = nil, nil
+	}
+}
+
+// traceContext returns the coordinator's context carrying the current phase
+// span, for work that runs within the phase.
+func (c *Coordinator) traceContext() context.Context {
+	c.trace.mu.Lock()
+	defer c.trace.mu.Unlock()
+	if c.trace.phaseCtx != nil {
+		return c.trace.phaseCtx
+	}
+	return c.baseContext()
+}
+
+// baseContext returns the coordinator's context, or a background context
+// for coordinators built without one.
+func (c *Coordinator) baseContext() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// traceTaskStart starts a span for a task as it starts on an instance.
+func (c *Coordinator) traceTaskStart(taskID, instanceID, title string) {
+	attrs := []attribute.KeyValue{
+		tracing.TaskID.String(taskID),
+		tracing.TaskTitle.String(title),
+		tracing.InstanceID.String(instanceID),
+	}
+	if c.groupTracker != nil {
+		attrs = append(attrs, tracing.Group.Int(c.getTaskGroupIndex(taskID)))
+	}
+	c.trace.tasks.Start(c.traceContext(), taskID, "ultraplan.task", attrs...)
+}
+
+// traceComplete ends the run with every span still open. A failed run's
+// open task spans are marked failed with its summary.
+func (c *Coordinator) traceComplete(success bool, summary string) {
+	t := &c.trace
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.ended {
+		return
+	}
+	t.ended = true
+
+	if success {
+		t.tasks.FailAll("ultra-plan finished while the task was running")
+	} else {
+		t.tasks.FailAll(summary)
+	}
+	t.endPhaseLocked()
+	if t.run == nil {
+		return
+	}
+	t.run.SetAttributes(attribute.Bool("claudio.success", success))
+	if !success {
+		t.run.SetStatus(codes.Error, summary)
+	}
+	t.run.End()
+}
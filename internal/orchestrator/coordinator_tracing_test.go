@@ -0,0 +1,75 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/tracing"
+)
+
+func TestCoordinator_Trace(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	p, err := tracing.Start(context.Background(), config.TracingConfig{Enabled: true, SampleRatio: 1}, "", tracing.WithExporter(exp))
+	if err != nil {
+		t.Fatalf("tracing.Start() error = %v", err)
+	}
+	defer func() { _ = p.Shutdown(context.Background()) }()
+
+	c := newTestCoordinatorForPhaseAdapter(t)
+	c.tracePhase(PhasePlanning)
+	c.tracePhase(PhasePlanning) // Re-entering a phase keeps its span
+	c.tracePhase(PhaseExecuting)
+	c.traceTaskStart("task-1", "inst-1", "First task")
+	c.traceTaskStart("task-2", "inst-2", "Second task")
+	c.trace.tasks.End("task-1")
+	c.trace.tasks.Fail("task-2", "no commits")
+	c.tracePhase(PhaseFailed)
+	c.traceComplete(false, "1 task(s) failed")
+	c.traceComplete(true, "ignored") // Only the first completion ends the run
+	c.tracePhase(PhaseSynthesis)     // Phases after completion are not traced
+
+	spans := make(map[string][]tracetest.SpanStub)
+	for _, s := range exp.GetSpans() {
+		spans[s.Name] = append(spans[s.Name], s)
+	}
+	if len(spans["ultraplan"]) != 1 || len(spans["ultraplan.planning"]) != 1 ||
+		len(spans["ultraplan.executing"]) != 1 || len(spans["ultraplan.task"]) != 2 || len(spans) != 4 {
+		t.Fatalf("spans = %v", exp.GetSpans().Snapshots())
+	}
+
+	run := spans["ultraplan"][0]
+	if run.Status.Code != codes.Error || run.Status.Description != "1 task(s) failed" {
+		t.Errorf("run status = %+v, want the failure summary", run.Status)
+	}
+	executing := spans["ultraplan.executing"][0]
+	if executing.Parent.SpanID() != run.SpanContext.SpanID() {
+		t.Error("phase span should be a child of the run span")
+	}
+	for _, task := range spans["ultraplan.task"] {
+		if task.Parent.SpanID() != executing.SpanContext.SpanID() {
+			t.Errorf("task span %v should be a child of the executing phase", task.Attributes)
+		}
+	}
+	if got := spans["ultraplan.task"][1].Status; got.Code != codes.Error || got.Description != "no commits" {
+		t.Errorf("failed task status = %+v", got)
+	}
+}
+
+func TestCoordinator_TraceContext(t *testing.T) {
+	c := newTestCoordinatorForPhaseAdapter(t)
+	if c.traceContext() != c.ctx {
+		t.Error("before any phase, traceContext should be the coordinator's context")
+	}
+
+	// The phase context must keep the coordinator's cancellation
+	c.tracePhase(PhaseExecuting)
+	ctx := c.traceContext()
+	c.cancelFunc()
+	if ctx.Err() == nil {
+		t.Error("phase context should be cancelled with the coordinator")
+	}
+}
@@ -0,0 +1,57 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/diag"
+)
+
+// startDiagnostics records event handler latency and serves profile requests
+// from `claudio debug profile`. It only runs for sessions with their own
+// directory, and is a no-op if already running. Caller must hold o.mu.
+func (o *Orchestrator) startDiagnostics() {
+	if o.sessionDir == "" || o.stopDiag != nil {
+		return
+	}
+
+	o.eventBus.SetHandlerObserver(func(eventType string, d time.Duration) {
+		diag.Record("event."+eventType, d)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	o.stopDiag = cancel
+	diag.Serve(ctx, o.sessionDir, o.diagnosticInfo, o.logger)
+}
+
+// diagnosticInfo describes the session for profile bundles. It reads the
+// latest snapshot so a profile can be taken while o.mu is contended.
+func (o *Orchestrator) diagnosticInfo() map[string]any {
+	snap := o.Snapshot()
+	info := map[string]any{
+		"snapshot_version":    snap.Version,
+		"capture_interval_ms": o.config.Instance.CaptureIntervalMs,
+	}
+	if snap.Session == nil {
+		return info
+	}
+
+	byStatus := make(map[InstanceStatus]int)
+	for _, inst := range snap.Session.Instances {
+		byStatus[inst.Status]++
+	}
+	info["session_id"] = snap.Session.ID
+	info["instances"] = len(snap.Session.Instances)
+	info["instances_by_status"] = byStatus
+	info["groups"] = len(snap.Session.Groups)
+	info["ultraplan"] = snap.Session.UltraPlan != nil
+	return info
+}
+
+// stopDiagnosticsLocked stops serving profile requests if it is running.
+func (o *Orchestrator) stopDiagnosticsLocked() {
+	if o.stopDiag != nil {
+		o.stopDiag()
+		o.stopDiag = nil
+		o.eventBus.SetHandlerObserver(nil)
+	}
+}
@@ -0,0 +1,173 @@
+package orchestrator
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/drift"
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/prompt"
+	"github.com/Iron-Ham/claudio/internal/worktree"
+)
+
+// driftPollInterval is how often the base branch of a running plan is
+// fetched and checked for new commits.
+const driftPollInterval = 2 * time.Minute
+
+// Compile-time check that the worktree manager can back the drift detector.
+var _ drift.Git = (*worktree.Manager)(nil)
+
+// startDriftWatch starts watching the base branch of the session's
+// ultra-plan for commits that land while it runs. It is a no-op when
+// ultraplan.drift_action is "off" or the watch is already running.
+// Caller must hold o.mu.
+func (o *Orchestrator) startDriftWatch() {
+	if o.config.Ultraplan.DriftAction == "off" || o.stopDrift != nil || o.wt == nil {
+		return
+	}
+
+	d := drift.New(o.wt)
+	ctx, cancel := context.WithCancel(context.Background())
+	o.stopDrift = cancel
+	go d.Watch(ctx, driftPollInterval, o.driftTargets, o.recordBaseDrift)
+}
+
+// watchesDrift reports whether a plan in this phase has tasks whose work
+// can still be affected by changes on its base branch.
+func watchesDrift(p UltraPlanPhase) bool {
+	switch p {
+	case PhaseExecuting, PhaseSynthesis, PhaseRevision:
+		return true
+	}
+	return false
+}
+
+// driftTargets returns the running ultra-plan, with the tasks it has not yet
+// completed, for the drift detector. The first time a plan is seen running,
+// the base ref's current commit is recorded as its baseline and no target is
+// returned, so drift is measured from that sweep on.
+func (o *Orchestrator) driftTargets() []drift.Target {
+	o.mu.RLock()
+	if o.session == nil || o.session.UltraPlan == nil || !watchesDrift(o.session.UltraPlan.Phase) {
+		o.mu.RUnlock()
+		return nil
+	}
+	up := o.session.UltraPlan
+	state := up.BaseDrift
+	if state == nil {
+		o.mu.RUnlock()
+		o.recordDriftBaseline(up.ID)
+		return nil
+	}
+
+	target := drift.Target{
+		ID:     up.ID,
+		Branch: state.Branch,
+		Ref:    state.Ref,
+		Since:  state.BaseCommit,
+	}
+	if up.Plan != nil {
+		for _, t := range up.Plan.Tasks {
+			if !slices.Contains(up.CompletedTasks, t.ID) {
+				target.Tasks = append(target.Tasks, drift.Task{ID: t.ID, Files: t.Files})
+			}
+		}
+	}
+	o.mu.RUnlock()
+	return []drift.Target{target}
+}
+
+// recordDriftBaseline records the commit origin's main branch points at as
+// the baseline of the ultra-plan with the given ID. Repositories without an
+// origin remote watch the local main branch instead.
+func (o *Orchestrator) recordDriftBaseline(planID string) {
+	mainBranch := o.wt.FindMainBranch()
+	state := &BaseDriftState{Ref: "origin/" + mainBranch, Branch: mainBranch}
+	fetchErr := o.wt.FetchBranch(mainBranch)
+	commit, err := o.wt.ResolveCommit(state.Ref)
+	if err != nil {
+		state = &BaseDriftState{Ref: mainBranch}
+		commit, err = o.wt.ResolveCommit(mainBranch)
+	}
+	if err != nil {
+		if o.logger != nil {
+			o.logger.Warn("cannot watch base branch for drift", "branch", mainBranch, "error", err)
+		}
+		return
+	}
+	state.BaseCommit = commit
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.session == nil || o.session.UltraPlan == nil || o.session.UltraPlan.ID != planID || o.session.UltraPlan.BaseDrift != nil {
+		return
+	}
+	o.session.UltraPlan.BaseDrift = state
+	if err := o.saveSession(); err != nil && o.logger != nil {
+		o.logger.Warn("failed to save drift baseline", "error", err)
+	}
+	if o.logger != nil {
+		o.logger.Info("watching base branch for drift",
+			"plan_id", planID,
+			"ref", state.Ref,
+			"base_commit", commit,
+			"fetched", fetchErr == nil,
+		)
+	}
+}
+
+// recordBaseDrift stores a drift report on the ultra-plan it concerns,
+// persists it, and announces it on the event bus.
+func (o *Orchestrator) recordBaseDrift(rep drift.Report) {
+	o.mu.Lock()
+	if o.session == nil || o.session.UltraPlan == nil || o.session.UltraPlan.ID != rep.TargetID || o.session.UltraPlan.BaseDrift == nil {
+		o.mu.Unlock()
+		return
+	}
+	o.session.UltraPlan.BaseDrift.Latest = &rep
+	if err := o.saveSession(); err != nil && o.logger != nil {
+		o.logger.Warn("failed to save base drift", "error", err)
+	}
+	replan := o.config.Ultraplan.DriftAction == "replan"
+	o.mu.Unlock()
+
+	affected := rep.AffectedTaskIDs()
+	if o.logger != nil {
+		o.logger.Warn("base branch moved under running plan",
+			"plan_id", rep.TargetID,
+			"ref", rep.Ref,
+			"commits", len(rep.Commits),
+			"files", len(rep.Files),
+			"affected_tasks", affected,
+			"replan", replan,
+		)
+	}
+	o.eventBus.Publish(event.NewBaseDriftEvent(rep.TargetID, rep.Ref, len(rep.Commits), len(rep.Files), affected, replan && len(affected) > 0))
+}
+
+// BaseDriftSection returns the prompt section that tells a task about
+// commits on the plan's base branch that changed its files, or "" when there
+// are none or ultraplan.drift_action is not "replan". Task prompts append it
+// so tasks that start after the base moved plan their work against it.
+func (o *Orchestrator) BaseDriftSection(taskID string) string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	if o.config.Ultraplan.DriftAction != "replan" || o.session == nil || o.session.UltraPlan == nil {
+		return ""
+	}
+	state := o.session.UltraPlan.BaseDrift
+	if state == nil || state.Latest == nil {
+		return ""
+	}
+	return prompt.BaseDriftSection(state.Ref, state.Latest.Commits, state.Latest.AffectedFiles(taskID))
+}
+
+// stopDriftWatchLocked stops the base branch drift watch if it is running.
+func (o *Orchestrator) stopDriftWatchLocked() {
+	if o.stopDrift != nil {
+		o.stopDrift()
+		o.stopDrift = nil
+	}
+}
@@ -0,0 +1,115 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/drift"
+	"github.com/Iron-Ham/claudio/internal/event"
+)
+
+func newDriftTestOrchestrator(t *testing.T) *Orchestrator {
+	t.Helper()
+	sess := NewSession("drift", "/repo")
+	sess.UltraPlan = &UltraPlanSession{
+		ID:    "plan-1",
+		Phase: PhaseExecuting,
+		Plan: &PlanSpec{Tasks: []PlannedTask{
+			{ID: "task-1", Files: []string{"internal/api/"}},
+			{ID: "task-2", Files: []string{"internal/ui/view.go"}},
+		}},
+		CompletedTasks: []string{"task-2"},
+		BaseDrift:      &BaseDriftState{Ref: "origin/main", Branch: "main", BaseCommit: "abc123"},
+	}
+	return &Orchestrator{
+		claudioDir: t.TempDir(),
+		config:     config.Default(),
+		session:    sess,
+		eventBus:   event.NewBus(),
+	}
+}
+
+func TestOrchestrator_DriftTargets(t *testing.T) {
+	o := newDriftTestOrchestrator(t)
+
+	targets := o.driftTargets()
+	if len(targets) != 1 {
+		t.Fatalf("driftTargets() = %d targets, want 1", len(targets))
+	}
+	target := targets[0]
+	if target.ID != "plan-1" || target.Ref != "origin/main" || target.Branch != "main" || target.Since != "abc123" {
+		t.Errorf("target = %+v", target)
+	}
+	if len(target.Tasks) != 1 || target.Tasks[0].ID != "task-1" {
+		t.Errorf("Tasks = %+v, want only the remaining task-1", target.Tasks)
+	}
+
+	o.session.UltraPlan.Phase = PhaseConsolidating
+	if targets := o.driftTargets(); len(targets) != 0 {
+		t.Errorf("driftTargets() while consolidating = %v, want none", targets)
+	}
+}
+
+func TestOrchestrator_RecordBaseDrift(t *testing.T) {
+	o := newDriftTestOrchestrator(t)
+	o.config.Ultraplan.DriftAction = "replan"
+	drifted := make(chan event.Event, 1)
+	o.eventBus.Subscribe("plan.base_drift", func(e event.Event) { drifted <- e })
+
+	o.recordBaseDrift(drift.Report{
+		TargetID: "plan-1",
+		Ref:      "origin/main",
+		From:     "abc123",
+		To:       "def456",
+		Commits:  []string{"def456 Rename Client"},
+		Files:    []string{"internal/api/client.go"},
+		Affected: []drift.Overlap{{TaskID: "task-1", Files: []string{"internal/api/client.go"}}},
+	})
+
+	data, err := os.ReadFile(o.sessionFilePath())
+	if err != nil {
+		t.Fatalf("session file not written: %v", err)
+	}
+	var saved Session
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	latest := saved.UltraPlan.BaseDrift.Latest
+	if latest == nil || latest.To != "def456" {
+		t.Fatalf("saved drift = %+v, want the report", saved.UltraPlan.BaseDrift)
+	}
+
+	select {
+	case e := <-drifted:
+		de := e.(event.BaseDriftEvent)
+		if de.PlanID != "plan-1" || de.Commits != 1 || !slices.Equal(de.AffectedTasks, []string{"task-1"}) || !de.Replanned {
+			t.Errorf("event = %+v", de)
+		}
+	default:
+		t.Error("expected plan.base_drift event")
+	}
+
+	if section := o.BaseDriftSection("task-1"); !strings.Contains(section, "internal/api/client.go") {
+		t.Errorf("BaseDriftSection(task-1) = %q, want the changed file", section)
+	}
+	if section := o.BaseDriftSection("task-2"); section != "" {
+		t.Errorf("BaseDriftSection(task-2) = %q, want empty", section)
+	}
+
+	o.config.Ultraplan.DriftAction = "warn"
+	if section := o.BaseDriftSection("task-1"); section != "" {
+		t.Errorf("BaseDriftSection() with drift_action warn = %q, want empty", section)
+	}
+}
+
+func TestOrchestrator_RecordBaseDrift_OtherPlan(t *testing.T) {
+	o := newDriftTestOrchestrator(t)
+	o.recordBaseDrift(drift.Report{TargetID: "plan-2", To: "def456"})
+	if o.session.UltraPlan.BaseDrift.Latest != nil {
+		t.Error("report for another plan should be ignored")
+	}
+}
@@ -0,0 +1,135 @@
+package orchestrator
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/ai"
+	"github.com/Iron-Ham/claudio/internal/estimate"
+)
+
+// estimateHistorySessions is how many recent sessions of the history
+// database task estimates are drawn from.
+const estimateHistorySessions = 20
+
+// DryRunTask is one planned task as a dry run would execute it.
+type DryRunTask struct {
+	ID         string            `json:"id"`
+	Title      string            `json:"title"`
+	Group      int               `json:"group"` // 1-based execution group
+	Complexity TaskComplexity    `json:"complexity,omitempty"`
+	Backend    string            `json:"backend,omitempty"`
+	Command    string            `json:"command,omitempty"` // Run instead of a prompt by tool tasks
+	Prompt     string            `json:"prompt,omitempty"`
+	Estimate   estimate.Estimate `json:"estimate"`
+}
+
+// DryRunGroup is one execution group of a dry run.
+type DryRunGroup struct {
+	Index    int               `json:"index"` // 1-based
+	TaskIDs  []string          `json:"task_ids"`
+	Estimate estimate.Estimate `json:"estimate"` // Duration accounts for max_parallel
+}
+
+// DryRunReport describes what executing a plan would do: every task's
+// prompt, the execution groups, and the estimated cost and duration.
+type DryRunReport struct {
+	Objective   string            `json:"objective"`
+	Summary     string            `json:"summary,omitempty"`
+	MaxParallel int               `json:"max_parallel"` // 0 = unlimited
+	Tasks       []DryRunTask      `json:"tasks"`
+	Groups      []DryRunGroup     `json:"groups"`
+	Estimate    estimate.Estimate `json:"estimate"`
+
+	// HistorySamples is how many completed tasks from the history database
+	// the estimates were drawn from; zero means the defaults were used.
+	HistorySamples int `json:"history_samples"`
+}
+
+// DryRun builds the prompt of every task in the session's plan, groups the
+// tasks as execution would, and estimates the cost and duration of running
+// them, without starting any instance. Estimates come from TaskEstimator.
+func (c *Coordinator) DryRun() (*DryRunReport, error) {
+	session := c.Session()
+	if session == nil || session.Plan == nil {
+		return nil, fmt.Errorf("no plan available")
+	}
+	plan := session.Plan
+
+	eo := c.ExecutionOrchestrator()
+	if eo == nil {
+		return nil, fmt.Errorf("execution orchestrator not initialized")
+	}
+	execCtx, err := c.BuildExecutionContext()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build execution context: %w", err)
+	}
+
+	estimator := c.TaskEstimator()
+	report := &DryRunReport{
+		Objective:      plan.Objective,
+		Summary:        plan.Summary,
+		MaxParallel:    session.Config.MaxParallel,
+		HistorySamples: estimator.Samples(),
+	}
+	for i, ids := range plan.ExecutionOrder {
+		group := DryRunGroup{Index: i + 1, TaskIDs: slices.Clone(ids)}
+		durations := make([]time.Duration, 0, len(ids))
+		for _, id := range ids {
+			task := session.GetTask(id)
+			if task == nil {
+				return nil, fmt.Errorf("task %s in execution group %d not found in plan", id, i+1)
+			}
+			dt := DryRunTask{
+				ID:         task.ID,
+				Title:      task.Title,
+				Group:      i + 1,
+				Complexity: task.EstComplexity,
+				Backend:    task.Backend,
+				Estimate:   estimator.EstimateTask(task),
+			}
+			if ai.IsDeterministic(task.Backend) {
+				dt.Command = task.Command
+			} else if dt.Prompt, err = eo.PreviewTaskPrompt(execCtx, id); err != nil {
+				return nil, err
+			}
+			report.Tasks = append(report.Tasks, dt)
+			group.Estimate = group.Estimate.Add(dt.Estimate)
+			durations = append(durations, dt.Estimate.Duration)
+		}
+		group.Estimate.Duration = estimate.GroupDuration(durations, report.MaxParallel)
+		report.Groups = append(report.Groups, group)
+		report.Estimate = report.Estimate.Add(group.Estimate)
+	}
+	return report, nil
+}
+
+// TaskEstimator returns the estimator of planned tasks, built on first use
+// from the completed ultraplan tasks of recent sessions in the history
+// database (session.database). Without history it estimates from each
+// task's complexity alone.
+func (c *Coordinator) TaskEstimator() *estimate.Estimator {
+	c.mu.RLock()
+	estimator := c.estimator
+	c.mu.RUnlock()
+	if estimator != nil {
+		return estimator
+	}
+
+	// Query the history without holding mu; a concurrent first call may
+	// load it twice, and the first result is kept
+	var samples []estimate.Sample
+	if c.orch != nil {
+		var err error
+		if samples, err = c.orch.taskSamples(estimateHistorySessions); err != nil {
+			c.logger.Warn("failed to read task history, using default estimates", "error", err)
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.estimator == nil {
+		c.estimator = estimate.New(samples)
+	}
+	return c.estimator
+}
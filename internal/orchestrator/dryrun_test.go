@@ -0,0 +1,61 @@
+package orchestrator
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/estimate"
+)
+
+func TestCoordinator_DryRun(t *testing.T) {
+	c := newTestCoordinatorForPhaseAdapter(t)
+	session := c.Session()
+	session.Config.MaxParallel = 1
+	session.Plan.Tasks[0].EstComplexity = ComplexityHigh
+	session.Plan.Tasks = append(session.Plan.Tasks, PlannedTask{
+		ID: "task-3", Title: "Task 3", Backend: "tool", Command: "make lint", DependsOn: []string{"task-1"},
+		EstComplexity: ComplexityLow,
+	})
+	session.Plan.ExecutionOrder[1] = append(session.Plan.ExecutionOrder[1], "task-3")
+
+	report, err := c.DryRun()
+	if err != nil {
+		t.Fatalf("DryRun() error = %v", err)
+	}
+	if len(report.Tasks) != 3 || len(report.Groups) != 2 || report.HistorySamples != 0 {
+		t.Fatalf("report = %+v", report)
+	}
+	if !strings.Contains(report.Tasks[0].Prompt, "First task") {
+		t.Errorf("task-1 prompt = %q, want the task description", report.Tasks[0].Prompt)
+	}
+	if tool := report.Tasks[2]; tool.Prompt != "" || tool.Command != "make lint" || tool.Group != 2 {
+		t.Errorf("tool task = %+v, want its command and no prompt", tool)
+	}
+
+	high, medium, low := estimate.Default(estimate.ComplexityHigh), estimate.Default(estimate.ComplexityMedium), estimate.Default(estimate.ComplexityLow)
+	if report.Tasks[1].Estimate != medium {
+		t.Errorf("task without complexity estimate = %+v, want medium", report.Tasks[1].Estimate)
+	}
+	// With max_parallel 1 the second group runs its tasks one after another
+	if got, want := report.Groups[1].Estimate.Duration, medium.Duration+low.Duration; got != want {
+		t.Errorf("group 2 duration = %v, want %v", got, want)
+	}
+	if got, want := report.Estimate.Duration, high.Duration+medium.Duration+low.Duration; got != want {
+		t.Errorf("total duration = %v, want %v", got, want)
+	}
+	if got, want := report.Estimate.Cost, high.Cost+medium.Cost+low.Cost; math.Abs(got-want) > 1e-9 {
+		t.Errorf("total cost = %v, want %v", got, want)
+	}
+	if len(c.GetRunningTasks()) != 0 {
+		t.Error("a dry run should not start tasks")
+	}
+}
+
+func TestCoordinator_DryRunWithoutPlan(t *testing.T) {
+	c := newTestCoordinatorForPhaseAdapter(t)
+	c.Session().Plan = nil
+	if _, err := c.DryRun(); err == nil {
+		t.Error("DryRun() error = nil, want an error without a plan")
+	}
+}
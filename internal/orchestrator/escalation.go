@@ -0,0 +1,342 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/instance"
+	"github.com/Iron-Ham/claudio/internal/instance/detect"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/escalation"
+)
+
+// initEscalation creates the escalation ladder that stalled instances walk
+// before being marked stuck, and loads the per-task timeout policies. The
+// ladder is left nil when escalation is disabled and no policy has actions,
+// in which case timeouts mark instances stuck immediately.
+func (o *Orchestrator) initEscalation() {
+	o.timeoutPolicies = timeoutPolicies(o.config.Instance.TimeoutPolicies)
+	o.instancePolicies = make(map[string]detect.TimeoutPolicy)
+
+	esc := o.config.Instance.Escalation
+	if !esc.Enabled && !slices.ContainsFunc(o.timeoutPolicies, func(p detect.TimeoutPolicy) bool {
+		return len(p.Actions) > 0
+	}) {
+		return
+	}
+
+	steps := make([]escalation.Step, len(esc.Steps))
+	for i, s := range esc.Steps {
+		steps[i] = escalation.Step(s)
+	}
+	o.ladderTarget = &escalationTarget{o: o, triggers: make(map[string]instance.TimeoutType)}
+	o.ladder = escalation.NewLadder(escalation.Config{
+		Steps:           steps,
+		StepWait:        esc.StepWait(),
+		MaxNudges:       esc.MaxNudges,
+		NudgeMessage:    esc.NudgeMessage,
+		InterviewPrompt: esc.InterviewPrompt,
+	}, o.ladderTarget, o.logger)
+}
+
+// escalateTimeout hands an activity or stale timeout to the escalation
+// ladder, running the actions of the instance's timeout policy when it has
+// any. It reports whether the ladder owns the timeout, in which case the
+// instance is marked stuck only if every step fails.
+func (o *Orchestrator) escalateTimeout(id string, timeoutType instance.TimeoutType) bool {
+	if o.ladder == nil {
+		return false
+	}
+	if timeoutType != instance.TimeoutActivity && timeoutType != instance.TimeoutStale {
+		return false
+	}
+
+	var override escalation.Override
+	policy, ok := o.instanceTimeoutPolicy(id)
+	if ok && len(policy.Actions) > 0 {
+		override = policyOverride(policy)
+	} else if !o.config.Instance.Escalation.Enabled {
+		return false
+	}
+
+	o.ladderTarget.setTrigger(id, timeoutType)
+	if o.ladder.EscalateWith(id, override) && o.logger != nil {
+		o.logger.Info("escalating stalled instance",
+			"instance_id", id,
+			"timeout_type", timeoutTypeString(timeoutType),
+			"timeout_policy", policy.Name,
+		)
+	}
+	return true
+}
+
+// timeoutPolicies converts the configured timeout policies.
+func timeoutPolicies(cfgs []config.TimeoutPolicyConfig) detect.TimeoutPolicies {
+	policies := make(detect.TimeoutPolicies, 0, len(cfgs))
+	for _, c := range cfgs {
+		p := detect.TimeoutPolicy{
+			Name:              c.Name,
+			Complexity:        c.Complexity,
+			ActivityTimeout:   time.Duration(c.ActivityTimeoutMinutes) * time.Minute,
+			CompletionTimeout: time.Duration(c.CompletionTimeoutMinutes) * time.Minute,
+			StaleThreshold:    c.StaleThreshold,
+			NudgeMessage:      c.NudgeMessage,
+		}
+		for _, a := range c.Actions {
+			p.Actions = append(p.Actions, detect.TimeoutAction(a))
+		}
+		policies = append(policies, p)
+	}
+	return policies
+}
+
+// policyOverride maps a timeout policy's actions onto ladder steps.
+func policyOverride(p detect.TimeoutPolicy) escalation.Override {
+	override := escalation.Override{NudgeMessage: p.NudgeMessage}
+	for _, a := range p.Actions {
+		switch a {
+		case detect.TimeoutActionWarn:
+			override.Steps = append(override.Steps, escalation.StepWarn)
+		case detect.TimeoutActionNudge:
+			override.Steps = append(override.Steps, escalation.StepNudge)
+		case detect.TimeoutActionRestart:
+			override.Steps = append(override.Steps, escalation.StepRestart)
+		case detect.TimeoutActionFail:
+			override.Steps = append(override.Steps, escalation.StepMarkStuck)
+		}
+	}
+	return override
+}
+
+// applyTaskTimeoutPolicy selects the timeout policy for the task an instance
+// was assigned and overrides the instance's timeout thresholds with it.
+// Instances of tasks without a policy keep the global timeouts.
+func (o *Orchestrator) applyTaskTimeoutPolicy(instanceID string, task *PlannedTask) {
+	if o == nil || task == nil || len(o.timeoutPolicies) == 0 {
+		return
+	}
+
+	policy, ok := o.timeoutPolicies.ForTask(task.TimeoutPolicy, string(task.EstComplexity))
+	if !ok {
+		if task.TimeoutPolicy != "" && o.logger != nil {
+			o.logger.Warn("task names an unknown timeout policy",
+				"task_id", task.ID,
+				"timeout_policy", task.TimeoutPolicy,
+			)
+		}
+		o.clearInstanceTimeoutPolicy(instanceID)
+		return
+	}
+
+	o.policyMu.Lock()
+	o.instancePolicies[instanceID] = policy
+	o.policyMu.Unlock()
+	o.stateMonitor.SetTimeoutOverride(instanceID, policy.Apply(o.stateMonitor.Config().TimeoutConfig()))
+
+	if o.logger != nil {
+		o.logger.Info("applied timeout policy",
+			"instance_id", instanceID,
+			"task_id", task.ID,
+			"timeout_policy", policy.Name,
+		)
+	}
+}
+
+// instanceTimeoutPolicy returns the timeout policy applied to an instance.
+func (o *Orchestrator) instanceTimeoutPolicy(id string) (detect.TimeoutPolicy, bool) {
+	o.policyMu.Lock()
+	defer o.policyMu.Unlock()
+	p, ok := o.instancePolicies[id]
+	return p, ok
+}
+
+// clearInstanceTimeoutPolicy restores the global timeouts for an instance.
+func (o *Orchestrator) clearInstanceTimeoutPolicy(id string) {
+	o.policyMu.Lock()
+	_, ok := o.instancePolicies[id]
+	delete(o.instancePolicies, id)
+	o.policyMu.Unlock()
+	if ok {
+		o.stateMonitor.ClearTimeoutOverride(id)
+	}
+}
+
+// cancelEscalation stops any escalation running for an instance, e.g.
+// because the operator stopped, removed, or restarted it.
+func (o *Orchestrator) cancelEscalation(id string) {
+	if o.ladder != nil {
+		o.ladder.Cancel(id)
+	}
+}
+
+// escalationTarget adapts the Orchestrator to escalation.Target.
+type escalationTarget struct {
+	o *Orchestrator
+
+	mu       sync.Mutex
+	triggers map[string]instance.TimeoutType // Timeout that started each instance's ladder
+}
+
+// setTrigger remembers which timeout started the ladder, so the instance is
+// marked stuck with the right timeout type if the ladder runs out.
+func (t *escalationTarget) setTrigger(id string, timeoutType instance.TimeoutType) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.triggers[id] = timeoutType
+}
+
+// trigger returns and forgets the timeout that started the ladder.
+func (t *escalationTarget) trigger(id string) instance.TimeoutType {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	timeoutType, ok := t.triggers[id]
+	if !ok {
+		timeoutType = instance.TimeoutActivity
+	}
+	delete(t.triggers, id)
+	return timeoutType
+}
+
+// manager returns the running instance manager for id.
+func (t *escalationTarget) manager(id string) (*instance.Manager, error) {
+	t.o.mu.RLock()
+	mgr, ok := t.o.instances[id]
+	t.o.mu.RUnlock()
+	if !ok || !mgr.Running() {
+		return nil, fmt.Errorf("instance %s is not running", id)
+	}
+	return mgr, nil
+}
+
+// Perform implements escalation.Target. Activity tracking is re-armed before
+// each step, since the monitor stops tracking output once it times out.
+func (t *escalationTarget) Perform(id string, step escalation.Step, message string) error {
+	if step == escalation.StepRestart {
+		return t.restart(id)
+	}
+
+	mgr, err := t.manager(id)
+	if err != nil {
+		return err
+	}
+	mgr.ClearTimeout()
+
+	switch step {
+	case escalation.StepWarn:
+		// Nothing to send; the ladder logs the stall and waits again
+	case escalation.StepNudge, escalation.StepInterview:
+		// Newlines would submit a partial prompt; send it as one line plus Enter
+		mgr.SendInput([]byte(strings.ReplaceAll(message, "\n", " ") + "\r"))
+	case escalation.StepInterrupt:
+		mgr.SendInput([]byte("\x1b"))
+	default:
+		return fmt.Errorf("unsupported escalation step %q", step)
+	}
+	return nil
+}
+
+// restart stops the backend and starts it again, resuming the conversation
+// when the backend supports it.
+func (t *escalationTarget) restart(id string) error {
+	mgr, err := t.manager(id)
+	if err != nil {
+		return err
+	}
+	inst := t.o.GetInstance(id)
+	if inst == nil {
+		return fmt.Errorf("instance %s not found", id)
+	}
+
+	if err := mgr.Stop(); err != nil {
+		return fmt.Errorf("stop instance: %w", err)
+	}
+	if inst.ClaudeSessionID != "" && t.o.backend != nil && t.o.backend.SupportsResume() {
+		err := t.o.ResumeInstance(inst)
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, instance.ErrManagerNotConfigured) {
+			return err
+		}
+		if t.o.logger != nil {
+			t.o.logger.Warn("escalation resume failed, restarting fresh",
+				"instance_id", id,
+				"error", err,
+			)
+		}
+	}
+	return t.o.ReconnectInstance(inst)
+}
+
+// Nudged implements escalation.Target by publishing an InstanceNudgedEvent.
+func (t *escalationTarget) Nudged(id string, attempt, maxAttempts int, message string) {
+	if t.o.eventBus != nil {
+		t.o.eventBus.Publish(event.NewInstanceNudgedEvent(id, attempt, maxAttempts, message))
+	}
+}
+
+// MarkStuck implements escalation.Target with the behavior timeouts had
+// before escalation: the instance is marked stuck and the timeout published.
+func (t *escalationTarget) MarkStuck(id string) {
+	inst := t.o.GetInstance(id)
+	if inst == nil {
+		return
+	}
+	t.o.markInstanceTimedOut(inst, t.trigger(id))
+}
+
+// LastActivity implements escalation.Target.
+func (t *escalationTarget) LastActivity(id string) time.Time {
+	t.o.mu.RLock()
+	mgr, ok := t.o.instances[id]
+	t.o.mu.RUnlock()
+	if !ok {
+		return time.Time{}
+	}
+	return mgr.LastActivityTime()
+}
+
+// Eligible implements escalation.Target: only running instances that are
+// working or waiting on input are escalated.
+func (t *escalationTarget) Eligible(id string) bool {
+	inst := t.o.GetInstance(id)
+	if inst == nil {
+		return false
+	}
+	t.o.mu.RLock()
+	status := inst.Status
+	t.o.mu.RUnlock()
+	if status != StatusWorking && status != StatusWaitingInput {
+		return false
+	}
+	_, err := t.manager(id)
+	return err == nil
+}
+
+// Record implements escalation.Target by storing the ladder state on the
+// instance, where the TUI and session file pick it up.
+func (t *escalationTarget) Record(id string, state *escalation.State) {
+	o := t.o
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	inst := o.findInstanceLocked(id)
+	if inst == nil {
+		return
+	}
+	inst.Escalation = state
+	if err := o.saveSession(); err != nil && o.logger != nil {
+		o.logger.Warn("failed to save session after escalation step",
+			"instance_id", id,
+			"error", err,
+		)
+	}
+	if state.Outcome == escalation.OutcomeRecovered || state.Outcome == escalation.OutcomeAborted {
+		t.trigger(id)
+	}
+}
@@ -0,0 +1,418 @@
+// Package escalation recovers instances that stop producing output.
+//
+// When an instance trips the activity or stale timeout, a Ladder walks it
+// through progressively stronger steps (nudge, diagnostic interview, soft
+// interrupt, restart with resume, or the actions of a timeout policy) and only marks it stuck once every step
+// has failed to bring back output. Each attempt is recorded so operators can
+// see what was already tried.
+package escalation
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/logging"
+)
+
+// Step is one rung of the escalation ladder.
+type Step string
+
+const (
+	// StepWarn only logs and records the stall, giving the instance another
+	// wait before the next step. Timeout policies use it to delay action.
+	StepWarn Step = "warn"
+	// StepNudge sends a short message asking the instance to continue.
+	StepNudge Step = "nudge"
+	// StepInterview asks the instance to report what it is doing and what
+	// it is blocked on, leaving a diagnosis in its output.
+	StepInterview Step = "interview"
+	// StepInterrupt sends Escape to cancel the current tool call or generation.
+	StepInterrupt Step = "interrupt"
+	// StepRestart stops the backend and restarts it, resuming the
+	// conversation when the backend supports it.
+	StepRestart Step = "restart"
+	// StepMarkStuck marks the instance stuck. It always ends the ladder.
+	StepMarkStuck Step = "mark_stuck"
+)
+
+// ValidSteps returns the step names accepted in configuration.
+func ValidSteps() []string {
+	return []string{
+		string(StepNudge),
+		string(StepInterview),
+		string(StepInterrupt),
+		string(StepRestart),
+		string(StepMarkStuck),
+	}
+}
+
+// DefaultSteps returns the full ladder in escalation order.
+func DefaultSteps() []Step {
+	return []Step{StepNudge, StepInterview, StepInterrupt, StepRestart, StepMarkStuck}
+}
+
+const (
+	// DefaultNudgeMessage is sent by StepNudge when no message is configured.
+	DefaultNudgeMessage = "You haven't produced any output for a while. " +
+		"If you're blocked, say what you're waiting on; otherwise continue with the task."
+
+	// DefaultInterviewPrompt is sent by StepInterview when no prompt is configured.
+	DefaultInterviewPrompt = "You appear to be stalled. Briefly report: " +
+		"(1) what you are doing right now, (2) what you are waiting on or blocked by, " +
+		"and (3) your next step. Then continue working on the task."
+
+	// DefaultStepWait is how long the ladder waits for output after each step.
+	DefaultStepWait = 2 * time.Minute
+
+	// settleDelay is how long after a step the ladder waits before taking its
+	// activity baseline, so the echo of a sent prompt doesn't count as the
+	// instance recovering.
+	settleDelay = 5 * time.Second
+)
+
+// Config configures a Ladder.
+type Config struct {
+	Steps           []Step        // Steps in order; StepMarkStuck is appended if missing
+	StepWait        time.Duration // Time to wait for output after each step
+	MaxNudges       int           // Times StepNudge is sent before moving on (default 1)
+	NudgeMessage    string        // Message for StepNudge (empty = DefaultNudgeMessage)
+	InterviewPrompt string        // Prompt for StepInterview (empty = DefaultInterviewPrompt)
+}
+
+// expandSteps returns the steps a run performs, with StepNudge repeated
+// MaxNudges times.
+func (c Config) expandSteps() []Step {
+	var steps []Step
+	for _, step := range c.Steps {
+		if step != StepNudge {
+			steps = append(steps, step)
+			continue
+		}
+		for range max(c.MaxNudges, 1) {
+			steps = append(steps, StepNudge)
+		}
+	}
+	return steps
+}
+
+// Message returns the text StepNudge or StepInterview sends, or "" for
+// steps that don't send text.
+func (c Config) Message(step Step) string {
+	switch step {
+	case StepNudge:
+		if c.NudgeMessage != "" {
+			return c.NudgeMessage
+		}
+		return DefaultNudgeMessage
+	case StepInterview:
+		if c.InterviewPrompt != "" {
+			return c.InterviewPrompt
+		}
+		return DefaultInterviewPrompt
+	default:
+		return ""
+	}
+}
+
+// Outcome is how a ladder run ended.
+type Outcome string
+
+const (
+	// OutcomeRecovered means the instance produced output after a step.
+	OutcomeRecovered Outcome = "recovered"
+	// OutcomeStuck means every step failed and the instance was marked stuck.
+	OutcomeStuck Outcome = "stuck"
+	// OutcomeAborted means the run was cancelled or the instance stopped
+	// being eligible, e.g. because the operator stopped it.
+	OutcomeAborted Outcome = "aborted"
+)
+
+// Attempt records one step the ladder performed.
+type Attempt struct {
+	Step  Step      `json:"step"`
+	At    time.Time `json:"at"`
+	Error string    `json:"error,omitempty"`
+}
+
+// State is the ladder state of one instance. It is persisted on the instance
+// so the TUI can show what claudio already tried.
+type State struct {
+	Active   bool      `json:"active"`
+	Attempts []Attempt `json:"attempts,omitempty"`
+	Outcome  Outcome   `json:"outcome,omitempty"`
+}
+
+// Clone returns a deep copy of the state.
+func (s *State) Clone() *State {
+	if s == nil {
+		return nil
+	}
+	cp := *s
+	cp.Attempts = slices.Clone(s.Attempts)
+	return &cp
+}
+
+// Steps returns the names of the attempted steps in order.
+func (s *State) Steps() []string {
+	if s == nil {
+		return nil
+	}
+	names := make([]string, len(s.Attempts))
+	for i, a := range s.Attempts {
+		names[i] = string(a.Step)
+	}
+	return names
+}
+
+// Override replaces parts of a Ladder's Config for one run, e.g. with the
+// actions of the instance's timeout policy.
+type Override struct {
+	Steps        []Step // Steps in order (empty = the ladder's steps); StepMarkStuck is appended if missing
+	NudgeMessage string // Message for StepNudge (empty = the ladder's message)
+}
+
+// apply returns cfg with the override's non-empty fields substituted.
+func (o Override) apply(cfg Config) Config {
+	if len(o.Steps) > 0 {
+		cfg.Steps = normalizeSteps(o.Steps)
+	}
+	if o.NudgeMessage != "" {
+		cfg.NudgeMessage = o.NudgeMessage
+	}
+	return cfg
+}
+
+// Target is the instance-facing side of a Ladder, implemented by the
+// orchestrator.
+type Target interface {
+	// Perform carries out a step other than StepMarkStuck on the instance.
+	// It must re-arm activity tracking so LastActivity reflects new output.
+	Perform(instanceID string, step Step, message string) error
+	// Nudged reports a successfully sent nudge: the attempt-th of at most
+	// maxAttempts in this run.
+	Nudged(instanceID string, attempt, maxAttempts int, message string)
+	// MarkStuck marks the instance stuck, ending the ladder.
+	MarkStuck(instanceID string)
+	// LastActivity returns when the instance's output last changed.
+	LastActivity(instanceID string) time.Time
+	// Eligible reports whether the instance is still running and may be escalated.
+	Eligible(instanceID string) bool
+	// Record stores the ladder state on the instance.
+	Record(instanceID string, state *State)
+}
+
+// Ladder runs escalation ladders for stalled instances, at most one per
+// instance at a time. It is safe for concurrent use.
+type Ladder struct {
+	cfg    Config
+	target Target
+	logger *logging.Logger
+
+	// sleep waits for d or until ctx is done, reporting whether the full
+	// duration elapsed. Replaced in tests.
+	sleep func(ctx context.Context, d time.Duration) bool
+
+	mu      sync.Mutex
+	running map[string]context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewLadder creates a Ladder. Steps default to DefaultSteps, StepWait to
+// DefaultStepWait, and MaxNudges to 1; StepMarkStuck is appended when the steps don't end with it.
+func NewLadder(cfg Config, target Target, logger *logging.Logger) *Ladder {
+	if logger == nil {
+		logger = logging.NopLogger()
+	}
+	if len(cfg.Steps) == 0 {
+		cfg.Steps = DefaultSteps()
+	}
+	cfg.Steps = normalizeSteps(cfg.Steps)
+	if cfg.StepWait <= 0 {
+		cfg.StepWait = DefaultStepWait
+	}
+	if cfg.MaxNudges <= 0 {
+		cfg.MaxNudges = 1
+	}
+
+	return &Ladder{
+		cfg:     cfg,
+		target:  target,
+		logger:  logger.WithPhase("escalation"),
+		sleep:   sleepContext,
+		running: make(map[string]context.CancelFunc),
+	}
+}
+
+// normalizeSteps moves StepMarkStuck to the end of steps, adding it if missing.
+func normalizeSteps(steps []Step) []Step {
+	steps = slices.DeleteFunc(slices.Clone(steps), func(s Step) bool { return s == StepMarkStuck })
+	return append(steps, StepMarkStuck)
+}
+
+// Escalate starts the ladder for an instance in the background. It returns
+// false if a ladder is already running for the instance.
+func (l *Ladder) Escalate(instanceID string) bool {
+	return l.EscalateWith(instanceID, Override{})
+}
+
+// EscalateWith is Escalate with parts of the ladder's Config replaced for
+// this run.
+func (l *Ladder) EscalateWith(instanceID string, override Override) bool {
+	cfg := override.apply(l.cfg)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.running[instanceID]; ok {
+		return false
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	l.running[instanceID] = cancel
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		defer l.finish(instanceID)
+		l.run(ctx, instanceID, cfg)
+	}()
+	return true
+}
+
+// Escalating reports whether a ladder is running for the instance.
+func (l *Ladder) Escalating(instanceID string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, ok := l.running[instanceID]
+	return ok
+}
+
+// Cancel stops the ladder running for an instance, if any. The run records
+// OutcomeAborted before it exits.
+func (l *Ladder) Cancel(instanceID string) {
+	l.mu.Lock()
+	cancel, ok := l.running[instanceID]
+	l.mu.Unlock()
+	if ok {
+		cancel()
+	}
+}
+
+// Stop cancels every running ladder and waits for them to exit.
+func (l *Ladder) Stop() {
+	l.mu.Lock()
+	for _, cancel := range l.running {
+		cancel()
+	}
+	l.mu.Unlock()
+	l.wg.Wait()
+}
+
+// finish removes the instance's run once it exits.
+func (l *Ladder) finish(instanceID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if cancel, ok := l.running[instanceID]; ok {
+		cancel()
+		delete(l.running, instanceID)
+	}
+}
+
+// run walks the ladder for one instance until it recovers, is aborted, or
+// reaches StepMarkStuck.
+func (l *Ladder) run(ctx context.Context, instanceID string, cfg Config) {
+	state := &State{Active: true}
+	end := func(outcome Outcome) {
+		state.Active = false
+		state.Outcome = outcome
+		l.target.Record(instanceID, state.Clone())
+		l.logger.Info("escalation finished",
+			"instance_id", instanceID,
+			"outcome", string(outcome),
+			"steps", state.Steps(),
+		)
+	}
+
+	nudges := 0
+	for _, step := range cfg.expandSteps() {
+		if ctx.Err() != nil || !l.target.Eligible(instanceID) {
+			end(OutcomeAborted)
+			return
+		}
+
+		attempt := Attempt{Step: step, At: time.Now()}
+		if step == StepMarkStuck {
+			state.Attempts = append(state.Attempts, attempt)
+			end(OutcomeStuck)
+			l.target.MarkStuck(instanceID)
+			return
+		}
+
+		err := l.target.Perform(instanceID, step, cfg.Message(step))
+		if err != nil {
+			attempt.Error = err.Error()
+			l.logger.Warn("escalation step failed",
+				"instance_id", instanceID,
+				"step", string(step),
+				"error", err,
+			)
+		} else {
+			l.logger.Warn("escalation step performed",
+				"instance_id", instanceID,
+				"step", string(step),
+				"wait", cfg.StepWait.String(),
+			)
+			if step == StepNudge {
+				nudges++
+				l.target.Nudged(instanceID, nudges, cfg.MaxNudges, cfg.Message(step))
+			}
+		}
+		state.Attempts = append(state.Attempts, attempt)
+		l.target.Record(instanceID, state.Clone())
+
+		// A failed step can't have helped; move straight on to the next one.
+		if err != nil {
+			continue
+		}
+
+		recovered, ok := l.awaitActivity(ctx, instanceID, cfg.StepWait)
+		if !ok {
+			end(OutcomeAborted)
+			return
+		}
+		if recovered {
+			end(OutcomeRecovered)
+			return
+		}
+	}
+}
+
+// awaitActivity waits stepWait for the instance to produce output after a
+// step. The activity baseline is taken after settleDelay so the echo of a
+// sent prompt is ignored. ok is false if the wait was cancelled.
+func (l *Ladder) awaitActivity(ctx context.Context, instanceID string, stepWait time.Duration) (recovered, ok bool) {
+	settle := min(settleDelay, stepWait/2)
+	if !l.sleep(ctx, settle) {
+		return false, false
+	}
+	baseline := l.target.LastActivity(instanceID)
+	if !l.sleep(ctx, stepWait-settle) {
+		return false, false
+	}
+	return l.target.LastActivity(instanceID).After(baseline), true
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,329 @@
+package escalation
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTarget records ladder calls. Output "resumes" after the step named in
+// recoverAfter, and steps in failSteps return an error.
+type fakeTarget struct {
+	mu           sync.Mutex
+	performed    []Step
+	messages     []string
+	nudges       [][2]int // attempt, maxAttempts
+	stuck        bool
+	recoverAfter Step
+	failSteps    []Step
+	activity     time.Time
+	ineligible   bool
+	states       []*State
+	done         chan *State
+}
+
+func newFakeTarget() *fakeTarget {
+	return &fakeTarget{activity: time.Unix(1000, 0), done: make(chan *State, 1)}
+}
+
+func (f *fakeTarget) Perform(_ string, step Step, message string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.performed = append(f.performed, step)
+	f.messages = append(f.messages, message)
+	if slices.Contains(f.failSteps, step) {
+		return errors.New("send failed")
+	}
+	return nil
+}
+
+func (f *fakeTarget) Nudged(_ string, attempt, maxAttempts int, _ string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nudges = append(f.nudges, [2]int{attempt, maxAttempts})
+}
+
+func (f *fakeTarget) MarkStuck(string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stuck = true
+}
+
+// LastActivity advances on every call once the recovery step was performed,
+// so the activity after the baseline looks new.
+func (f *fakeTarget) LastActivity(string) time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.recoverAfter != "" && slices.Contains(f.performed, f.recoverAfter) {
+		f.activity = f.activity.Add(time.Second)
+	}
+	return f.activity
+}
+
+func (f *fakeTarget) Eligible(string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return !f.ineligible
+}
+
+func (f *fakeTarget) Record(_ string, state *State) {
+	f.mu.Lock()
+	f.states = append(f.states, state)
+	f.mu.Unlock()
+	if !state.Active {
+		f.done <- state
+	}
+}
+
+// newTestLadder returns a ladder whose waits return immediately and are counted.
+func newTestLadder(cfg Config, target Target) (*Ladder, *int) {
+	l := NewLadder(cfg, target, nil)
+	var mu sync.Mutex
+	sleeps := 0
+	l.sleep = func(ctx context.Context, _ time.Duration) bool {
+		mu.Lock()
+		sleeps++
+		mu.Unlock()
+		return ctx.Err() == nil
+	}
+	return l, &sleeps
+}
+
+func waitDone(t *testing.T, f *fakeTarget) *State {
+	t.Helper()
+	select {
+	case state := <-f.done:
+		return state
+	case <-time.After(5 * time.Second):
+		t.Fatal("ladder did not finish")
+		return nil
+	}
+}
+
+func TestNewLadder_Defaults(t *testing.T) {
+	l := NewLadder(Config{}, newFakeTarget(), nil)
+	if !slices.Equal(l.cfg.Steps, DefaultSteps()) {
+		t.Errorf("Steps = %v, want %v", l.cfg.Steps, DefaultSteps())
+	}
+	if l.cfg.StepWait != DefaultStepWait {
+		t.Errorf("StepWait = %v, want %v", l.cfg.StepWait, DefaultStepWait)
+	}
+
+	l = NewLadder(Config{Steps: []Step{StepMarkStuck, StepInterrupt}}, newFakeTarget(), nil)
+	if want := []Step{StepInterrupt, StepMarkStuck}; !slices.Equal(l.cfg.Steps, want) {
+		t.Errorf("Steps = %v, want %v", l.cfg.Steps, want)
+	}
+}
+
+func TestConfig_Message(t *testing.T) {
+	var cfg Config
+	if got := cfg.Message(StepNudge); got != DefaultNudgeMessage {
+		t.Errorf("Message(nudge) = %q, want default", got)
+	}
+	if got := cfg.Message(StepInterview); got != DefaultInterviewPrompt {
+		t.Errorf("Message(interview) = %q, want default", got)
+	}
+	if got := cfg.Message(StepInterrupt); got != "" {
+		t.Errorf("Message(interrupt) = %q, want empty", got)
+	}
+
+	cfg.NudgeMessage = "keep going"
+	if got := cfg.Message(StepNudge); got != "keep going" {
+		t.Errorf("Message(nudge) = %q, want configured message", got)
+	}
+}
+
+func TestLadder_ExhaustsStepsAndMarksStuck(t *testing.T) {
+	target := newFakeTarget()
+	l, _ := newTestLadder(Config{}, target)
+
+	if !l.Escalate("inst-1") {
+		t.Fatal("Escalate() = false, want true")
+	}
+	state := waitDone(t, target)
+	l.Stop()
+
+	if state.Outcome != OutcomeStuck {
+		t.Errorf("Outcome = %q, want %q", state.Outcome, OutcomeStuck)
+	}
+	if want := []string{"nudge", "interview", "interrupt", "restart", "mark_stuck"}; !slices.Equal(state.Steps(), want) {
+		t.Errorf("attempted steps = %v, want %v", state.Steps(), want)
+	}
+	if !target.stuck {
+		t.Error("MarkStuck was not called")
+	}
+	if target.messages[0] != DefaultNudgeMessage || target.messages[1] != DefaultInterviewPrompt {
+		t.Errorf("messages = %q, want default nudge and interview prompts", target.messages[:2])
+	}
+	if l.Escalating("inst-1") {
+		t.Error("Escalating() = true after the ladder finished")
+	}
+}
+
+func TestLadder_RepeatsNudges(t *testing.T) {
+	target := newFakeTarget()
+	target.failSteps = []Step{StepInterrupt}
+	l, sleeps := newTestLadder(Config{Steps: []Step{StepNudge, StepInterrupt}, MaxNudges: 3}, target)
+
+	l.Escalate("inst-1")
+	state := waitDone(t, target)
+	l.Stop()
+
+	if want := []string{"nudge", "nudge", "nudge", "interrupt", "mark_stuck"}; !slices.Equal(state.Steps(), want) {
+		t.Errorf("attempted steps = %v, want %v", state.Steps(), want)
+	}
+	if want := [][2]int{{1, 3}, {2, 3}, {3, 3}}; !slices.Equal(target.nudges, want) {
+		t.Errorf("nudges = %v, want %v", target.nudges, want)
+	}
+	// Each nudge waits for output (settle + remainder); the failed interrupt doesn't
+	if *sleeps != 6 {
+		t.Errorf("sleeps = %d, want 6", *sleeps)
+	}
+}
+
+func TestLadder_StopsNudgingOnRecovery(t *testing.T) {
+	target := newFakeTarget()
+	target.recoverAfter = StepNudge
+	l, _ := newTestLadder(Config{MaxNudges: 3}, target)
+
+	l.Escalate("inst-1")
+	state := waitDone(t, target)
+	l.Stop()
+
+	if state.Outcome != OutcomeRecovered {
+		t.Errorf("Outcome = %q, want %q", state.Outcome, OutcomeRecovered)
+	}
+	if len(target.nudges) != 1 {
+		t.Errorf("nudges = %v, want one before recovery", target.nudges)
+	}
+}
+
+func TestLadder_EscalateWithOverride(t *testing.T) {
+	target := newFakeTarget()
+	l, _ := newTestLadder(Config{NudgeMessage: "global nudge"}, target)
+
+	l.EscalateWith("inst-1", Override{
+		Steps:        []Step{StepWarn, StepMarkStuck, StepNudge},
+		NudgeMessage: "policy nudge",
+	})
+	state := waitDone(t, target)
+	l.Stop()
+
+	if want := []string{"warn", "nudge", "mark_stuck"}; !slices.Equal(state.Steps(), want) {
+		t.Errorf("attempted steps = %v, want %v", state.Steps(), want)
+	}
+	if want := []string{"", "policy nudge"}; !slices.Equal(target.messages, want) {
+		t.Errorf("messages = %q, want %q", target.messages, want)
+	}
+	if !target.stuck {
+		t.Error("MarkStuck was not called")
+	}
+
+	// The override applies to that run only
+	target = newFakeTarget()
+	l, _ = newTestLadder(Config{Steps: []Step{StepNudge}, NudgeMessage: "global nudge"}, target)
+	l.EscalateWith("inst-1", Override{})
+	state = waitDone(t, target)
+	l.Stop()
+	if want := []string{"nudge", "mark_stuck"}; !slices.Equal(state.Steps(), want) {
+		t.Errorf("attempted steps without override = %v, want %v", state.Steps(), want)
+	}
+	if target.messages[0] != "global nudge" {
+		t.Errorf("message = %q, want the ladder's nudge message", target.messages[0])
+	}
+}
+
+func TestLadder_RecoversAfterStep(t *testing.T) {
+	target := newFakeTarget()
+	target.recoverAfter = StepInterview
+	l, _ := newTestLadder(Config{}, target)
+
+	l.Escalate("inst-1")
+	state := waitDone(t, target)
+	l.Stop()
+
+	if state.Outcome != OutcomeRecovered {
+		t.Errorf("Outcome = %q, want %q", state.Outcome, OutcomeRecovered)
+	}
+	if want := []Step{StepNudge, StepInterview}; !slices.Equal(target.performed, want) {
+		t.Errorf("performed = %v, want %v", target.performed, want)
+	}
+	if target.stuck {
+		t.Error("recovered instance was marked stuck")
+	}
+	// Every step is recorded while the ladder is still active
+	if len(target.states) != 3 || !target.states[0].Active || !target.states[1].Active {
+		t.Errorf("recorded states = %+v, want two active states and a final one", target.states)
+	}
+}
+
+func TestLadder_FailedStepSkipsWait(t *testing.T) {
+	target := newFakeTarget()
+	target.failSteps = []Step{StepNudge}
+	l, sleeps := newTestLadder(Config{Steps: []Step{StepNudge, StepInterrupt}}, target)
+
+	l.Escalate("inst-1")
+	state := waitDone(t, target)
+	l.Stop()
+
+	if state.Attempts[0].Error != "send failed" {
+		t.Errorf("nudge attempt error = %q, want send failed", state.Attempts[0].Error)
+	}
+	// Only the interrupt step waits (settle + remainder)
+	if *sleeps != 2 {
+		t.Errorf("sleeps = %d, want 2", *sleeps)
+	}
+	if state.Outcome != OutcomeStuck {
+		t.Errorf("Outcome = %q, want %q", state.Outcome, OutcomeStuck)
+	}
+}
+
+func TestLadder_IneligibleInstanceAborts(t *testing.T) {
+	target := newFakeTarget()
+	target.ineligible = true
+	l, _ := newTestLadder(Config{}, target)
+
+	l.Escalate("inst-1")
+	state := waitDone(t, target)
+	l.Stop()
+
+	if state.Outcome != OutcomeAborted {
+		t.Errorf("Outcome = %q, want %q", state.Outcome, OutcomeAborted)
+	}
+	if len(target.performed) != 0 || target.stuck {
+		t.Errorf("ineligible instance was escalated: performed=%v stuck=%v", target.performed, target.stuck)
+	}
+}
+
+func TestLadder_CancelAndSingleRunPerInstance(t *testing.T) {
+	target := newFakeTarget()
+	l := NewLadder(Config{}, target, nil)
+	waiting := make(chan struct{}, 1)
+	l.sleep = func(ctx context.Context, _ time.Duration) bool {
+		waiting <- struct{}{}
+		<-ctx.Done()
+		return false
+	}
+
+	if !l.Escalate("inst-1") {
+		t.Fatal("Escalate() = false, want true")
+	}
+	<-waiting
+	if l.Escalate("inst-1") {
+		t.Error("second Escalate() for a running instance = true, want false")
+	}
+
+	l.Cancel("inst-1")
+	state := waitDone(t, target)
+	l.Stop()
+
+	if state.Outcome != OutcomeAborted {
+		t.Errorf("Outcome = %q, want %q", state.Outcome, OutcomeAborted)
+	}
+	if target.stuck {
+		t.Error("cancelled ladder marked the instance stuck")
+	}
+}
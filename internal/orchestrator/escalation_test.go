@@ -0,0 +1,169 @@
+package orchestrator
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/instance"
+	"github.com/Iron-Ham/claudio/internal/instance/detect"
+	instancestate "github.com/Iron-Ham/claudio/internal/instance/state"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/escalation"
+)
+
+func newEscalationTestOrchestrator(t *testing.T, enabled bool) *Orchestrator {
+	t.Helper()
+	cfg := config.Default()
+	cfg.Instance.Escalation.Enabled = enabled
+	o := &Orchestrator{
+		claudioDir: t.TempDir(),
+		config:     cfg,
+		session:    newSnapshotTestSession(),
+		instances:  make(map[string]*instance.Manager),
+		eventBus:   event.NewBus(),
+	}
+	o.initEscalation()
+	return o
+}
+
+func TestHandleInstanceTimeout_EscalationDisabledMarksStuck(t *testing.T) {
+	o := newEscalationTestOrchestrator(t, false)
+	if o.ladder != nil {
+		t.Fatal("ladder created with escalation disabled")
+	}
+
+	o.handleInstanceTimeout("inst-1", instance.TimeoutStale)
+
+	if got := o.GetInstance("inst-1").Status; got != StatusStuck {
+		t.Errorf("Status = %s, want %s", got, StatusStuck)
+	}
+}
+
+func TestHandleInstanceTimeout_CompletionTimeoutSkipsLadder(t *testing.T) {
+	o := newEscalationTestOrchestrator(t, true)
+	defer o.ladder.Stop()
+
+	o.handleInstanceTimeout("inst-1", instance.TimeoutCompletion)
+
+	if got := o.GetInstance("inst-1").Status; got != StatusTimeout {
+		t.Errorf("Status = %s, want %s", got, StatusTimeout)
+	}
+	if o.ladder.Escalating("inst-1") {
+		t.Error("completion timeout started the escalation ladder")
+	}
+}
+
+func TestHandleInstanceTimeout_StallEscalates(t *testing.T) {
+	o := newEscalationTestOrchestrator(t, true)
+	defer o.ladder.Stop()
+
+	// inst-1 has no running manager, so the ladder aborts instead of
+	// marking the instance stuck
+	o.handleInstanceTimeout("inst-1", instance.TimeoutActivity)
+
+	deadline := time.Now().Add(5 * time.Second)
+	var esc *escalation.State
+	for time.Now().Before(deadline) {
+		if inst := o.Snapshot().Instance("inst-1"); inst != nil {
+			if esc = inst.Escalation; esc != nil && !esc.Active {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if esc == nil || esc.Active {
+		t.Fatalf("escalation state = %+v, want a finished ladder", esc)
+	}
+	if esc.Outcome != escalation.OutcomeAborted {
+		t.Errorf("Outcome = %q, want %q", esc.Outcome, escalation.OutcomeAborted)
+	}
+	if got := o.GetInstance("inst-1").Status; got != StatusWorking {
+		t.Errorf("Status = %s, want %s (not marked stuck)", got, StatusWorking)
+	}
+}
+
+func TestTimeoutPolicy_AppliedOnAssignAndEscalates(t *testing.T) {
+	cfg := config.Default()
+	cfg.Instance.Escalation.Enabled = false
+	cfg.Instance.TimeoutPolicies = []config.TimeoutPolicyConfig{
+		{Name: "long", Complexity: []string{"high"}, CompletionTimeoutMinutes: 90, Actions: []string{"warn", "nudge", "fail"}},
+	}
+	o := &Orchestrator{
+		claudioDir:   t.TempDir(),
+		config:       cfg,
+		session:      newSnapshotTestSession(),
+		instances:    make(map[string]*instance.Manager),
+		eventBus:     event.NewBus(),
+		stateMonitor: instancestate.NewMonitor(instancestate.MonitorConfig{CompletionTimeoutMinutes: 30}),
+	}
+	o.initEscalation()
+	if o.ladder == nil {
+		t.Fatal("ladder not created for a policy with actions")
+	}
+	defer o.ladder.Stop()
+
+	// Both instances have run for an hour: past the global 30m completion
+	// timeout but within the policy's 90m
+	started := time.Now().Add(-time.Hour)
+	o.stateMonitor.StartWithTime("inst-1", started)
+	o.stateMonitor.StartWithTime("inst-2", started)
+
+	o.applyTaskTimeoutPolicy("inst-1", &PlannedTask{ID: "task-1", EstComplexity: ComplexityHigh})
+	if got := o.stateMonitor.CheckTimeouts("inst-1"); got != nil {
+		t.Errorf("CheckTimeouts(inst-1) = %v, want nil within the policy's 90m", *got)
+	}
+	o.applyTaskTimeoutPolicy("inst-2", &PlannedTask{ID: "task-2", EstComplexity: ComplexityLow})
+	if got := o.stateMonitor.CheckTimeouts("inst-2"); got == nil || *got != instancestate.TimeoutCompletion {
+		t.Errorf("CheckTimeouts(inst-2) = %v, want TimeoutCompletion past the global 30m", got)
+	}
+
+	// Only the instance with policy actions walks the ladder
+	if !o.escalateTimeout("inst-1", instance.TimeoutActivity) {
+		t.Error("escalateTimeout() = false for an instance with policy actions")
+	}
+	if o.escalateTimeout("inst-2", instance.TimeoutActivity) {
+		t.Error("escalateTimeout() = true without a policy while escalation is disabled")
+	}
+
+	o.clearInstanceTimeoutPolicy("inst-1")
+	if got := o.stateMonitor.CheckTimeouts("inst-1"); got == nil || *got != instancestate.TimeoutCompletion {
+		t.Errorf("CheckTimeouts(inst-1) after clearing = %v, want TimeoutCompletion", got)
+	}
+}
+
+func TestPolicyOverride(t *testing.T) {
+	got := policyOverride(detect.TimeoutPolicy{
+		Actions:      []detect.TimeoutAction{detect.TimeoutActionWarn, detect.TimeoutActionNudge, detect.TimeoutActionRestart, detect.TimeoutActionFail},
+		NudgeMessage: "keep going",
+	})
+	want := []escalation.Step{escalation.StepWarn, escalation.StepNudge, escalation.StepRestart, escalation.StepMarkStuck}
+	if !slices.Equal(got.Steps, want) {
+		t.Errorf("Steps = %v, want %v", got.Steps, want)
+	}
+	if got.NudgeMessage != "keep going" {
+		t.Errorf("NudgeMessage = %q, want %q", got.NudgeMessage, "keep going")
+	}
+}
+
+func TestEscalationTarget_NudgedPublishesEvent(t *testing.T) {
+	o := newEscalationTestOrchestrator(t, true)
+	defer o.ladder.Stop()
+
+	var got []event.InstanceNudgedEvent
+	o.eventBus.Subscribe("instance.nudged", func(e event.Event) {
+		if nudged, ok := e.(event.InstanceNudgedEvent); ok {
+			got = append(got, nudged)
+		}
+	})
+
+	o.ladderTarget.Nudged("inst-1", 2, 3, "keep going")
+
+	if len(got) != 1 {
+		t.Fatalf("published %d nudge events, want 1", len(got))
+	}
+	if got[0].InstanceID != "inst-1" || got[0].Attempt != 2 || got[0].MaxAttempts != 3 || got[0].Message != "keep going" {
+		t.Errorf("event = %+v, want inst-1 attempt 2 of 3 with the message", got[0])
+	}
+}
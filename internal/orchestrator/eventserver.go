@@ -0,0 +1,53 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/eventserver"
+)
+
+// eventServerStopTimeout bounds how long stopping the event server waits
+// for open streams to close.
+const eventServerStopTimeout = 2 * time.Second
+
+// startEventServer streams the session's events to external dashboards when
+// event_server.enabled is set. It only runs for sessions with their own
+// directory, and is a no-op if already running. A listen failure, such as
+// another session holding the port, is logged and the session continues
+// without the server. Caller must hold o.mu.
+func (o *Orchestrator) startEventServer() {
+	cfg := o.config.EventServer
+	if o.sessionDir == "" || !cfg.Enabled || o.eventServer != nil {
+		return
+	}
+
+	srv := eventserver.New(o.eventBus,
+		eventserver.WithReplaySize(cfg.ReplaySize),
+		eventserver.WithLogger(o.logger),
+	)
+	if err := srv.Start(cfg.Address); err != nil {
+		if o.logger != nil {
+			o.logger.Warn("event server disabled", "error", err)
+		}
+		return
+	}
+	o.eventServer = srv
+	if o.logger != nil {
+		o.logger.Info("event server listening", "address", srv.Addr().String())
+	}
+}
+
+// stopEventServerLocked stops the event server if it is running. Caller must
+// hold o.mu.
+func (o *Orchestrator) stopEventServerLocked() {
+	if o.eventServer == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), eventServerStopTimeout)
+	defer cancel()
+	if err := o.eventServer.Stop(ctx); err != nil && o.logger != nil {
+		o.logger.Warn("failed to stop event server", "error", err)
+	}
+	o.eventServer = nil
+}
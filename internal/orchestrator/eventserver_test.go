@@ -0,0 +1,48 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/event"
+)
+
+func TestOrchestrator_EventServer(t *testing.T) {
+	cfg := config.Default()
+	o := &Orchestrator{sessionDir: t.TempDir(), config: cfg, eventBus: event.NewBus()}
+
+	o.startEventServer()
+	if o.eventServer != nil {
+		t.Fatal("event server should not start when event_server.enabled is false")
+	}
+
+	cfg.EventServer.Enabled = true
+	cfg.EventServer.Address = "127.0.0.1:0"
+	o.startEventServer()
+	if o.eventServer == nil {
+		t.Fatal("event server should start when enabled")
+	}
+	srv := o.eventServer
+	o.startEventServer() // no-op while running
+	if o.eventServer != srv {
+		t.Error("starting twice should keep the running server")
+	}
+
+	o.stopEventServerLocked()
+	if o.eventServer != nil {
+		t.Error("event server should be cleared after stop")
+	}
+	o.stopEventServerLocked() // no-op when stopped
+}
+
+func TestOrchestrator_EventServerListenFailure(t *testing.T) {
+	cfg := config.Default()
+	cfg.EventServer.Enabled = true
+	cfg.EventServer.Address = "127.0.0.1:-1"
+	o := &Orchestrator{sessionDir: t.TempDir(), config: cfg, eventBus: event.NewBus()}
+
+	o.startEventServer()
+	if o.eventServer != nil {
+		t.Error("a listen failure should leave the event server stopped")
+	}
+}
@@ -4,7 +4,10 @@
 package consolidate
 
 import (
+	"github.com/Iron-Ham/claudio/internal/orchestrator/consolidation/mergequeue"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/verify"
+	"github.com/Iron-Ham/claudio/internal/worktree"
 )
 
 // CoordinatorInterface defines the coordinator methods needed by group consolidation.
@@ -30,6 +33,15 @@ type CoordinatorInterface interface {
 
 	// Context returns the context for cancellation
 	Context() ContextInterface
+
+	// RecordConflictResolution records in the audit log that the merge
+	// queue resolved conflicts while consolidating a group
+	RecordConflictResolution(groupIndex int, res *mergequeue.Result)
+
+	// RecordResolverOutcome records in the audit log that a conflict
+	// resolver instance resolved a task's conflicts while consolidating a
+	// group
+	RecordResolverOutcome(groupIndex int, taskID, instanceID string, completion *types.ConflictResolutionCompletionFile)
 }
 
 // SessionInterface defines session methods needed by group consolidation.
@@ -61,6 +73,15 @@ type PlanInterface interface {
 type ConfigInterface interface {
 	GetBranchPrefix() string
 	IsMultiPass() bool
+	// IsFreshVerification reports whether cached verification results are bypassed
+	IsFreshVerification() bool
+	// IsMergeQueue reports whether branches are consolidated with the merge queue
+	IsMergeQueue() bool
+	// IsConflictResolver reports whether cherry-pick conflicts are handed to
+	// a conflict resolver instance
+	IsConflictResolver() bool
+	// GetVerify returns the commands run in each group's consolidated worktree
+	GetVerify() verify.GroupConfig
 }
 
 // TaskInterface defines task methods needed by group consolidation.
@@ -77,6 +98,7 @@ type OrchestratorInterface interface {
 	// Instance operations
 	AddInstance(baseSession BaseSessionInterface, prompt string) (InstanceInterface, error)
 	AddInstanceFromBranch(baseSession BaseSessionInterface, prompt, branch string) (InstanceInterface, error)
+	AddInstanceToWorktree(baseSession BaseSessionInterface, prompt, worktreePath string) (InstanceInterface, error)
 	GetInstance(id string) InstanceInterface
 	StartInstance(inst InstanceInterface) error
 	StopInstance(inst InstanceInterface) error
@@ -95,7 +117,10 @@ type WorktreeInterface interface {
 	CreateWorktreeFromBranch(path, branch string) error
 	Remove(path string) error
 	CherryPickBranch(worktreePath, sourceBranch string) error
+	CherryPickBranchAfter(worktreePath, sourceBranch, afterCommit string) error
 	AbortCherryPick(worktreePath string) error
+	ContinueCherryPick(worktreePath string) error
+	Conflicts(worktreePath string) ([]worktree.ConflictedFile, error)
 	CountCommitsBetween(worktreePath, baseBranch, head string) (int, error)
 	Push(worktreePath string, force bool) error
 }
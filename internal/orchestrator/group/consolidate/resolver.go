@@ -0,0 +1,253 @@
+package consolidate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+	"github.com/Iron-Ham/claudio/internal/worktree"
+)
+
+// resolverPollInterval is how often a conflict resolver instance is checked
+// for its completion file.
+var resolverPollInterval = time.Second
+
+// maxResolverHunkLines caps the lines quoted from each side of a hunk in a
+// conflict resolver's prompt; the resolver reads the rest from the file.
+const maxResolverHunkLines = 40
+
+// cherryPickBranch cherry-picks the commits of a task branch into the
+// worktree. With the conflict resolver enabled, each conflict is handed to
+// a resolver instance and the branch's remaining commits are picked once it
+// is resolved. applied lists the tasks already in the worktree. When a
+// conflict is left unresolved, the cherry-pick is left in progress.
+func (c *Consolidator) cherryPickBranch(groupIndex int, worktreePath, branch, taskID string, applied []string) error {
+	wt := c.coord.Orchestrator().Worktree()
+	err := wt.CherryPickBranch(worktreePath, branch)
+	for err != nil {
+		var conflict *worktree.CherryPickConflictError
+		if !c.coord.Session().GetConfig().IsConflictResolver() || !errors.As(err, &conflict) {
+			return err
+		}
+		if rerr := c.resolveConflict(groupIndex, worktreePath, taskID, applied); rerr != nil {
+			return fmt.Errorf("%w; conflict resolver: %w", err, rerr)
+		}
+		err = wt.CherryPickBranchAfter(worktreePath, branch, conflict.Commit)
+	}
+	return nil
+}
+
+// resolveConflict starts a conflict resolver instance in the worktree of a
+// cherry-pick stopped by a conflict, waits for its completion file, and
+// continues the cherry-pick once every file is resolved.
+func (c *Consolidator) resolveConflict(groupIndex int, worktreePath, taskID string, applied []string) error {
+	session := c.coord.Session()
+	orch := c.coord.Orchestrator()
+	wt := orch.Worktree()
+
+	conflicts, err := wt.Conflicts(worktreePath)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) == 0 {
+		return fmt.Errorf("no conflicted files to resolve")
+	}
+	files := make([]string, len(conflicts))
+	for i, f := range conflicts {
+		files[i] = f.Path
+	}
+
+	// A completion file left by an earlier resolver would end this one at once
+	completionPath := types.ConflictResolutionCompletionFilePath(worktreePath)
+	_ = os.Remove(completionPath)
+
+	prompt := c.BuildResolverPrompt(groupIndex, taskID, applied, conflicts)
+	baseSession := c.coord.BaseSession()
+	inst, err := orch.AddInstanceToWorktree(baseSession, prompt, worktreePath)
+	if err != nil {
+		return fmt.Errorf("failed to create conflict resolver instance: %w", err)
+	}
+
+	// Add to ultraplan group for display
+	sessionType := SessionTypeUltraPlan
+	if session.GetConfig().IsMultiPass() {
+		sessionType = SessionTypePlanMulti
+	}
+	if ultraGroup := baseSession.GetGroupBySessionType(sessionType); ultraGroup != nil {
+		ultraGroup.AddInstance(inst.GetID())
+	}
+	_ = orch.SaveSession()
+
+	c.coord.Manager().EmitEvent(EventGroupComplete,
+		fmt.Sprintf("Group %d: task %s conflicts in %s, starting a conflict resolver", groupIndex+1, taskID, strings.Join(files, ", ")))
+
+	if err := orch.StartInstance(inst); err != nil {
+		return fmt.Errorf("failed to start conflict resolver instance: %w", err)
+	}
+
+	completion, err := c.monitorResolver(inst.GetID(), worktreePath)
+	_ = orch.StopInstance(inst)
+	if err != nil {
+		return err
+	}
+	// Removed so the cherry-pick can't commit it
+	_ = os.Remove(completionPath)
+	if completion.Status != "resolved" {
+		return fmt.Errorf("conflict resolver could not resolve the conflicts: %s", completion.Notes)
+	}
+
+	remaining, err := wt.Conflicts(worktreePath)
+	if err != nil {
+		return err
+	}
+	if len(remaining) > 0 {
+		left := make([]string, len(remaining))
+		for i, f := range remaining {
+			left[i] = f.Path
+		}
+		return fmt.Errorf("conflict resolver left %s unresolved", strings.Join(left, ", "))
+	}
+	if err := wt.ContinueCherryPick(worktreePath); err != nil {
+		return err
+	}
+
+	c.coord.RecordResolverOutcome(groupIndex, taskID, inst.GetID(), completion)
+	c.coord.Manager().EmitEvent(EventGroupComplete,
+		fmt.Sprintf("Group %d: conflict resolver resolved task %s's conflicts", groupIndex+1, taskID))
+	return nil
+}
+
+// monitorResolver waits for a conflict resolver instance to write its
+// completion file.
+func (c *Consolidator) monitorResolver(instanceID, worktreePath string) (*types.ConflictResolutionCompletionFile, error) {
+	orch := c.coord.Orchestrator()
+	ctx := c.coord.Context()
+
+	ticker := time.NewTicker(resolverPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("context cancelled")
+
+		case <-ticker.C:
+			// A file that fails to parse may still be being written
+			if completion, err := types.ParseConflictResolutionCompletionFile(worktreePath); err == nil {
+				return completion, nil
+			}
+
+			inst := orch.GetInstance(instanceID)
+			if inst == nil {
+				return nil, fmt.Errorf("conflict resolver instance not found")
+			}
+			switch inst.GetStatus() {
+			case StatusError:
+				return nil, fmt.Errorf("conflict resolver instance failed with error")
+			case StatusCompleted:
+				mgr := orch.GetInstanceManager(instanceID)
+				if mgr != nil && mgr.TmuxSessionExists() {
+					continue
+				}
+				return nil, fmt.Errorf("conflict resolver completed without writing %s", types.ConflictResolutionCompletionFileName)
+			}
+		}
+	}
+}
+
+// BuildResolverPrompt builds the prompt for a conflict resolver instance:
+// the conflicted hunks, with the summaries of the tasks on each side.
+func (c *Consolidator) BuildResolverPrompt(groupIndex int, taskID string, applied []string, conflicts []worktree.ConflictedFile) string {
+	session := c.coord.Session()
+	summaries := c.GatherTaskCompletionContext(groupIndex).TaskSummaries
+
+	writeTask := func(sb *strings.Builder, id string) {
+		title := ""
+		if task := session.GetTask(id); task != nil {
+			title = task.GetTitle()
+		}
+		sb.WriteString(fmt.Sprintf("### %s: %s\n", id, title))
+		if summary := summaries[id]; summary != "" {
+			sb.WriteString(fmt.Sprintf("- Summary: %s\n", summary))
+		}
+		sb.WriteString("\n")
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Group %d Conflict Resolution\n\n", groupIndex+1))
+	if plan := session.GetPlan(); plan != nil {
+		sb.WriteString(fmt.Sprintf("## Part of Ultra-Plan: %s\n\n", plan.GetSummary()))
+	}
+
+	sb.WriteString("## Objective\n\n")
+	sb.WriteString(fmt.Sprintf("Group %d is being consolidated in this worktree, and cherry-picking the commits of task %s stopped on merge conflicts. ", groupIndex+1, taskID))
+	sb.WriteString("Resolve them so the consolidated branch keeps the work of both sides. \"Ours\" (HEAD) is the work already consolidated; \"theirs\" is the task's commit.\n\n")
+
+	sb.WriteString("## Our Side: Already Consolidated\n\n")
+	if groupIndex > 0 {
+		sb.WriteString(fmt.Sprintf("The worktree starts from group %d's consolidated branch.\n\n", groupIndex))
+	}
+	if len(applied) == 0 {
+		sb.WriteString("No other task from this group has been applied yet.\n\n")
+	}
+	for _, id := range applied {
+		writeTask(&sb, id)
+	}
+
+	sb.WriteString("## Their Side: Task Being Cherry-Picked\n\n")
+	writeTask(&sb, taskID)
+
+	sb.WriteString("## Conflicts\n\n")
+	for _, f := range conflicts {
+		sb.WriteString(fmt.Sprintf("### `%s`\n\n", f.Path))
+		switch {
+		case f.Err != nil:
+			sb.WriteString(fmt.Sprintf("Could not be read: %v\n\n", f.Err))
+		case len(f.Hunks) == 0:
+			sb.WriteString("No conflict markers: the file was deleted on one side. Keep it or remove it.\n\n")
+		}
+		for _, h := range f.Hunks {
+			sb.WriteString(fmt.Sprintf("Line %d:\n```\n", h.Line))
+			sb.WriteString("<<<<<<< ours " + h.OursLabel + "\n")
+			writeHunkLines(&sb, h.Ours)
+			sb.WriteString("=======\n")
+			writeHunkLines(&sb, h.Theirs)
+			sb.WriteString(">>>>>>> theirs " + h.TheirsLabel + "\n```\n\n")
+		}
+	}
+
+	sb.WriteString("## Your Tasks\n\n")
+	sb.WriteString("1. **Resolve each file**: read both sides of every hunk with the task summaries above, and edit the file so it keeps the intent of both, removing every conflict marker.\n")
+	sb.WriteString("2. **Stage each resolved file** with `git add <file>`, or `git rm <file>` if it should be deleted.\n")
+	sb.WriteString("3. **Run verification**: build and run the tests relevant to the files you touched.\n")
+	sb.WriteString("4. **Write the completion file** to signal you are done.\n\n")
+	sb.WriteString("Do NOT commit, and do NOT run `git cherry-pick --continue`, `--abort`, or `--skip`: consolidation continues the cherry-pick once you are done.\n\n")
+
+	sb.WriteString("## Completion Protocol\n\n")
+	sb.WriteString(fmt.Sprintf("Write `%s` in the worktree root, without staging it:\n\n", types.ConflictResolutionCompletionFileName))
+	sb.WriteString("```json\n")
+	sb.WriteString("{\n")
+	sb.WriteString("  \"status\": \"resolved\",\n")
+	sb.WriteString("  \"files_resolved\": [{\"file\": \"path/to/file\", \"resolution\": \"How both sides were kept\"}],\n")
+	sb.WriteString("  \"notes\": \"Anything the next group should know\"\n")
+	sb.WriteString("}\n")
+	sb.WriteString("```\n\n")
+	sb.WriteString("Use `\"status\": \"failed\"` with the reason in `notes` if the conflicts need a person to decide.\n")
+
+	return sb.String()
+}
+
+// writeHunkLines writes one side of a hunk, up to maxResolverHunkLines.
+func writeHunkLines(sb *strings.Builder, lines []string) {
+	for i, l := range lines {
+		if i == maxResolverHunkLines {
+			sb.WriteString(fmt.Sprintf("… %d more lines\n", len(lines)-i))
+			break
+		}
+		sb.WriteString(l + "\n")
+	}
+}
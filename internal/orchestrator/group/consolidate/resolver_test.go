@@ -0,0 +1,108 @@
+package consolidate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+	"github.com/Iron-Ham/claudio/internal/worktree"
+)
+
+func TestConsolidator_ConflictResolver(t *testing.T) {
+	resolverPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { resolverPollInterval = time.Second })
+
+	conflicted := []worktree.ConflictedFile{{
+		Path:  "api/auth.go",
+		Hunks: []worktree.ConflictHunk{{Line: 12, OursLabel: "HEAD", Ours: []string{"return nil"}, Theirs: []string{"return token"}}},
+	}}
+
+	tests := []struct {
+		name      string
+		resolver  bool
+		status    string
+		remaining []worktree.ConflictedFile // Conflicts left once the resolver is done
+		wantErr   string
+	}{
+		{name: "disabled", wantErr: "cherry-pick conflict"},
+		{name: "resolved", resolver: true, status: "resolved"},
+		{name: "gave up", resolver: true, status: "failed", wantErr: "could not resolve the conflicts: needs a decision"},
+		{name: "left unresolved", resolver: true, status: "resolved", remaining: conflicted, wantErr: "left api/auth.go unresolved"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claudioDir := t.TempDir()
+			worktreePath := filepath.Join(claudioDir, "consolidation-group-0")
+			if err := os.MkdirAll(worktreePath, 0o755); err != nil {
+				t.Fatal(err)
+			}
+
+			wt := &mockWorktree{
+				mainBranch:         "main",
+				countCommitsResult: 2,
+				cherryPickErr:      &worktree.CherryPickConflictError{Commit: "abc123", SourceBranch: "Iron-Ham/task-1"},
+				conflicts:          [][]worktree.ConflictedFile{conflicted, tt.remaining},
+			}
+			orch := &mockOrchestrator{
+				worktree:   wt,
+				claudioDir: claudioDir,
+				onStart: func(inst InstanceInterface) {
+					data, _ := json.Marshal(types.ConflictResolutionCompletionFile{Status: tt.status, Notes: "needs a decision"})
+					if err := os.WriteFile(types.ConflictResolutionCompletionFilePath(inst.GetWorktreePath()), data, 0o644); err != nil {
+						t.Error(err)
+					}
+				},
+			}
+			coord := &mockCoordinator{
+				session: &mockSession{
+					id:               "abc12345",
+					plan:             &mockPlan{summary: "Add auth", executionOrder: [][]string{{"task-1"}}},
+					taskCommitCounts: map[string]int{"task-1": 2},
+					tasks:            map[string]*mockTask{"task-1": {id: "task-1", title: "Add tokens"}},
+					config:           &mockConfig{conflictResolver: tt.resolver},
+				},
+				orchestrator: orch,
+				baseSession: &mockBaseSession{instances: []InstanceInterface{
+					&mockInstance{id: "inst-1", task: "task-1", branch: "Iron-Ham/task-1"},
+				}},
+				manager: &mockManager{},
+			}
+
+			err := NewConsolidator(coord).ConsolidateWithVerification(0)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ConsolidateWithVerification() error = %v, want %q", err, tt.wantErr)
+				}
+				if wt.aborted != 1 || wt.continued != 0 {
+					t.Errorf("aborted %d and continued %d times, want the cherry-pick aborted", wt.aborted, wt.continued)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConsolidateWithVerification() error = %v", err)
+			}
+
+			if len(orch.prompts) != 1 {
+				t.Fatalf("started %d resolvers, want 1", len(orch.prompts))
+			}
+			for _, want := range []string{"task-1: Add tokens", "`api/auth.go`", "Line 12:", "return nil", "return token", types.ConflictResolutionCompletionFileName} {
+				if !strings.Contains(orch.prompts[0], want) {
+					t.Errorf("resolver prompt missing %q:\n%s", want, orch.prompts[0])
+				}
+			}
+			if wt.continued != 1 || len(wt.pickedAfter) != 1 || wt.pickedAfter[0] != "Iron-Ham/task-1@abc123" {
+				t.Errorf("continued %d times and picked %v, want the rest of the branch picked after abc123", wt.continued, wt.pickedAfter)
+			}
+			if _, err := os.Stat(types.ConflictResolutionCompletionFilePath(worktreePath)); !os.IsNotExist(err) {
+				t.Error("completion file left in the worktree, where the cherry-pick could commit it")
+			}
+			if len(coord.resolved) != 1 || coord.resolved[0] != "task-1" {
+				t.Errorf("recorded resolutions = %v, want task-1", coord.resolved)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package consolidate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/verify"
+)
+
+// verifyGroup runs the configured or detected verification commands in a
+// group's consolidated worktree and returns their results, or nil when there
+// are none. A failed verification is returned as an error unless the
+// session pauses on failure, leaving the decision to whoever approves the
+// next group.
+func (c *Consolidator) verifyGroup(groupIndex int, worktreePath string) (*types.VerificationResult, error) {
+	cfg := c.coord.Session().GetConfig().GetVerify()
+	steps, projectType := cfg.StepsFor(worktreePath)
+	if len(steps) == 0 {
+		return nil, nil
+	}
+
+	// Each command has its own timeout; the run as a whole ends with the coordinator
+	ctx, cancel := c.stopContext(0)
+	defer cancel()
+	c.coord.Manager().EmitEvent(EventGroupComplete,
+		fmt.Sprintf("Group %d: running %d verification commands", groupIndex+1, len(steps)))
+
+	timeout := time.Duration(cfg.TimeoutSeconds) * time.Second
+	result := verify.NewCommandRunner(c.flakeDetector(), timeout).Run(ctx, worktreePath, steps)
+	result.ProjectType = projectType
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("context cancelled")
+	}
+	if !result.OverallSuccess && !cfg.PauseOnFailure() {
+		return &result, fmt.Errorf("group %d verification failed: %s", groupIndex+1, result.Summary)
+	}
+	return &result, nil
+}
@@ -0,0 +1,125 @@
+package consolidate
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/flake"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/verify"
+)
+
+func TestConsolidator_VerifyGroup(t *testing.T) {
+	run := func(_ context.Context, _, command string) ([]byte, error) {
+		if command == "go test ./..." {
+			return []byte("--- FAIL: TestLogin\n"), errors.New("exit status 1")
+		}
+		return []byte("ok\n"), nil
+	}
+
+	tests := []struct {
+		name        string
+		steps       []verify.Step
+		onFailure   string
+		detect      bool // The worktree holds a go.mod and commands are auto-detected
+		wantErr     string
+		wantContext bool // A consolidation context with the results is stored
+		wantSuccess bool
+	}{
+		{name: "no commands"},
+		{name: "passing", steps: []verify.Step{{Name: "build", Command: "go build ./..."}}, wantContext: true, wantSuccess: true},
+		{name: "failing", steps: []verify.Step{{Name: "build", Command: "go build ./..."}, {Name: "test", Command: "go test ./..."}},
+			wantErr: "group 1 verification failed: 1 of 2 commands failed: test"},
+		{name: "detected", detect: true, onFailure: verify.OnFailurePause, wantContext: true},
+		{name: "failing with pause", steps: []verify.Step{{Name: "test", Command: "go test ./..."}}, onFailure: verify.OnFailurePause, wantContext: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &mockSession{
+				id:               "abc12345",
+				plan:             &mockPlan{executionOrder: [][]string{{"task-1"}}},
+				taskCommitCounts: map[string]int{"task-1": 1},
+				tasks:            map[string]*mockTask{"task-1": {id: "task-1", title: "Add login"}},
+				config:           &mockConfig{verify: verify.GroupConfig{Steps: tt.steps, AutoDetect: tt.detect, OnFailure: tt.onFailure}},
+			}
+			claudioDir := t.TempDir()
+			if tt.detect {
+				worktreePath := filepath.Join(claudioDir, "consolidation-group-0")
+				if err := os.MkdirAll(worktreePath, 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(worktreePath, "go.mod"), []byte("module example.com/x\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			wt := &mockWorktree{mainBranch: "main", countCommitsResult: 1}
+			coord := &mockCoordinator{
+				session:      session,
+				orchestrator: &mockOrchestrator{worktree: wt, claudioDir: claudioDir},
+				baseSession: &mockBaseSession{instances: []InstanceInterface{
+					&mockInstance{id: "inst-1", task: "task-1", branch: "Iron-Ham/task-1"},
+				}},
+				manager: &mockManager{},
+			}
+			c := NewConsolidator(coord)
+			c.flakes = flake.NewDetector(nil, "", "", flake.WithRunFunc(run))
+
+			err := c.ConsolidateWithVerification(0)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("ConsolidateWithVerification() error = %v, want %q", err, tt.wantErr)
+				}
+				if len(session.groupConsolidatedBranches) > 0 {
+					t.Errorf("consolidated branches = %v, want none stored for a failed group", session.groupConsolidatedBranches)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConsolidateWithVerification() error = %v", err)
+			}
+
+			var report bool
+			if len(session.groupConsolidationContexts) > 0 && session.groupConsolidationContexts[0] != nil {
+				report = true
+				v := session.groupConsolidationContexts[0].Verification
+				if !v.Native || v.OverallSuccess != tt.wantSuccess {
+					t.Errorf("stored verification = %+v, want native with success %v", v, tt.wantSuccess)
+				}
+				if tt.detect && (v.ProjectType != verify.ProjectGo || len(v.CommandsRun) != 3) {
+					t.Errorf("stored verification = %+v, want the go commands detected", v)
+				} else if !tt.detect && len(v.CommandsRun) != len(tt.steps) {
+					t.Errorf("ran %d commands, want %d", len(v.CommandsRun), len(tt.steps))
+				}
+			}
+			if report != tt.wantContext {
+				t.Errorf("consolidation context stored = %v, want %v", report, tt.wantContext)
+			}
+			events := strings.Join(coord.manager.emittedEvents, "\n")
+			if tt.wantContext && !strings.Contains(events, "verification: ") {
+				t.Errorf("events missing the verification summary:\n%s", events)
+			}
+		})
+	}
+}
+
+func TestConsolidator_BuildPrompt_VerifyCommands(t *testing.T) {
+	coord := &mockCoordinator{
+		session: &mockSession{
+			id:     "abc12345",
+			plan:   &mockPlan{summary: "Add auth", executionOrder: [][]string{{"task-1"}}},
+			tasks:  map[string]*mockTask{"task-1": {id: "task-1", title: "Add login"}},
+			config: &mockConfig{verify: verify.GroupConfig{Steps: []verify.Step{{Name: "test", Command: "go test ./..."}}}},
+		},
+		orchestrator: &mockOrchestrator{worktree: &mockWorktree{mainBranch: "main"}},
+		baseSession:  &mockBaseSession{},
+		manager:      &mockManager{},
+	}
+
+	prompt := NewConsolidator(coord).BuildPrompt(0)
+	if !strings.Contains(prompt, "- `go test ./...`") || !strings.Contains(prompt, "must pass before the next group starts") {
+		t.Errorf("prompt does not list the verification commands:\n%s", prompt)
+	}
+}
@@ -0,0 +1,189 @@
+package orchestrator
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+)
+
+// awaitGroupApproval pauses execution after group groupIndex was
+// consolidated when another group follows and either
+// ultraplan.group_approval is enabled or the group's verification commands
+// failed with ultraplan.verify.on_failure set to "pause". Until ApproveGroup
+// is called, no task of the next group starts. The pause is also offered to
+// the control API as a pending approval.
+func (c *Coordinator) awaitGroupApproval(groupIndex int) {
+	session := c.Session()
+	if session == nil || session.Plan == nil || groupIndex+1 >= len(session.Plan.ExecutionOrder) {
+		return
+	}
+	verifyFailed := c.groupVerificationFailed(session, groupIndex)
+	if !session.Config.GroupApproval && !verifyFailed {
+		return
+	}
+
+	gate := c.groupApprovalSummary(session, groupIndex)
+	c.mu.Lock()
+	session.GroupApproval = gate
+	c.mu.Unlock()
+
+	c.registerGroupApprover()
+	c.logger.Info("awaiting approval before next group",
+		"group_index", groupIndex,
+		"files_changed", len(gate.FilesChanged),
+		"verification_failed", verifyFailed,
+	)
+	msg := fmt.Sprintf("Group %d consolidated (%d files changed). Awaiting approval to start group %d.",
+		groupIndex+1, len(gate.FilesChanged), groupIndex+2)
+	if verifyFailed {
+		msg = fmt.Sprintf("Group %d verification failed (%s). Awaiting approval to start group %d.",
+			groupIndex+1, gate.Verification, groupIndex+2)
+	}
+	c.manager.emitEvent(CoordinatorEvent{Type: EventGroupComplete, Message: msg})
+	_ = c.orch.SaveSession()
+}
+
+// groupVerificationFailed reports whether the verification commands run
+// after group groupIndex was consolidated failed in a session that pauses on
+// failure.
+func (c *Coordinator) groupVerificationFailed(session *UltraPlanSession, groupIndex int) bool {
+	if !session.Config.Verify.PauseOnFailure() {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if groupIndex >= len(session.GroupConsolidationContexts) {
+		return false
+	}
+	report := session.GroupConsolidationContexts[groupIndex]
+	return report != nil && report.Verification.Native && !report.Verification.OverallSuccess
+}
+
+// groupApprovalSummary describes what group groupIndex changed, from its
+// consolidation report and consolidated branch.
+func (c *Coordinator) groupApprovalSummary(session *UltraPlanSession, groupIndex int) *GroupApprovalState {
+	c.mu.RLock()
+	gate := &GroupApprovalState{GroupIndex: groupIndex}
+	if groupIndex < len(session.GroupConsolidatedBranches) {
+		gate.Branch = session.GroupConsolidatedBranches[groupIndex]
+	}
+	var report *types.GroupConsolidationCompletionFile
+	if groupIndex < len(session.GroupConsolidationContexts) {
+		report = session.GroupConsolidationContexts[groupIndex]
+	}
+	c.mu.RUnlock()
+
+	if report != nil {
+		if gate.Branch == "" {
+			gate.Branch = report.BranchName
+		}
+		gate.TasksConsolidated = slices.Clone(report.TasksConsolidated)
+		gate.VerificationPassed = report.Verification.OverallSuccess
+		gate.Verification = report.Verification.Summary
+		gate.Notes = report.Notes
+		gate.IssuesForNextGroup = slices.Clone(report.IssuesForNextGroup)
+	}
+
+	if gate.Branch != "" && c.orch != nil && c.orch.wt != nil {
+		base := GetBaseBranchForGroup(c, groupIndex)
+		if base == "" {
+			base = c.orch.wt.FindMainBranch()
+		}
+		files, err := c.orch.wt.ChangedFilesBetween(base, gate.Branch)
+		if err != nil {
+			c.logger.Warn("cannot list files changed by group", "group_index", groupIndex, "error", err)
+		} else {
+			gate.FilesChanged = files
+		}
+	}
+	return gate
+}
+
+// GroupAwaitingApproval returns the pause before the next execution group,
+// or nil when execution is not waiting for approval.
+func (c *Coordinator) GroupAwaitingApproval() *GroupApprovalState {
+	session := c.Session()
+	if session == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return session.GroupApproval
+}
+
+// ApproveGroup lets execution continue with the next group after a pause
+// for ultraplan.group_approval.
+func (c *Coordinator) ApproveGroup() error {
+	session := c.Session()
+	if session == nil {
+		return fmt.Errorf("no ultraplan session")
+	}
+	c.mu.Lock()
+	gate := session.GroupApproval
+	session.GroupApproval = nil
+	remove := c.groupApproverRemove
+	c.groupApproverRemove = nil
+	c.mu.Unlock()
+	if gate == nil {
+		return fmt.Errorf("no group is awaiting approval")
+	}
+	// Unregister outside c.mu: the registry calls back into the coordinator
+	if remove != nil {
+		remove()
+	}
+
+	c.logger.Info("group approved", "next_group", gate.GroupIndex+1)
+	c.manager.emitEvent(CoordinatorEvent{
+		Type:    EventGroupComplete,
+		Message: fmt.Sprintf("Group %d approved. Starting group %d.", gate.GroupIndex+1, gate.GroupIndex+2),
+	})
+	return c.orch.SaveSession()
+}
+
+// registerGroupApprover offers the pending group approval to the control
+// API, once.
+func (c *Coordinator) registerGroupApprover() {
+	if c.orch == nil {
+		return
+	}
+	c.mu.Lock()
+	registered := c.groupApproverRemove != nil
+	c.mu.Unlock()
+	if registered {
+		return
+	}
+	remove := c.orch.AddTaskApprover(groupApprover{c})
+	c.mu.Lock()
+	if c.groupApproverRemove == nil {
+		c.groupApproverRemove = remove
+		remove = nil
+	}
+	c.mu.Unlock()
+	if remove != nil {
+		remove()
+	}
+}
+
+// groupApprover exposes a coordinator's pending group approval to the
+// control API, named by GroupApprovalState.ApprovalID.
+type groupApprover struct {
+	c *Coordinator
+}
+
+// PendingApprovals implements TaskApprover.
+func (g groupApprover) PendingApprovals() []string {
+	if gate := g.c.GroupAwaitingApproval(); gate != nil {
+		return []string{gate.ApprovalID()}
+	}
+	return nil
+}
+
+// Approve implements TaskApprover.
+func (g groupApprover) Approve(id string) error {
+	gate := g.c.GroupAwaitingApproval()
+	if gate == nil || gate.ApprovalID() != id {
+		return fmt.Errorf("%s is not awaiting approval", id)
+	}
+	return g.c.ApproveGroup()
+}
@@ -0,0 +1,127 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/verify"
+)
+
+func groupApprovalTestCoordinator(enabled bool) *Coordinator {
+	c := retryTestCoordinator(config.RetryConfig{})
+	session := c.Session()
+	session.Config.GroupApproval = enabled
+	session.Plan = &PlanSpec{
+		Tasks: []PlannedTask{
+			{ID: "task-1"},
+			{ID: "task-2", DependsOn: []string{"task-1"}},
+		},
+		ExecutionOrder: [][]string{{"task-1"}, {"task-2"}},
+	}
+	session.CompletedTasks = []string{"task-1"}
+	session.CurrentGroup = 1
+	session.GroupConsolidatedBranches = []string{"claudio/group-1"}
+	session.GroupConsolidationContexts = []*types.GroupConsolidationCompletionFile{{
+		GroupIndex:         0,
+		BranchName:         "claudio/group-1",
+		TasksConsolidated:  []string{"task-1"},
+		Notes:              "clean merge",
+		IssuesForNextGroup: []string{"rename helper"},
+		Verification:       types.VerificationResult{OverallSuccess: true, Summary: "tests pass"},
+	}}
+	return c
+}
+
+func TestCoordinator_AwaitGroupApproval(t *testing.T) {
+	t.Run("disabled does not pause", func(t *testing.T) {
+		c := groupApprovalTestCoordinator(false)
+		c.awaitGroupApproval(0)
+		if gate := c.GroupAwaitingApproval(); gate != nil {
+			t.Fatalf("GroupAwaitingApproval() = %+v, want nil", gate)
+		}
+	})
+
+	t.Run("last group does not pause", func(t *testing.T) {
+		c := groupApprovalTestCoordinator(true)
+		c.awaitGroupApproval(1)
+		if gate := c.GroupAwaitingApproval(); gate != nil {
+			t.Fatalf("GroupAwaitingApproval() = %+v, want nil", gate)
+		}
+	})
+
+	t.Run("pauses until approved", func(t *testing.T) {
+		c := groupApprovalTestCoordinator(true)
+		c.awaitGroupApproval(0)
+
+		gate := c.GroupAwaitingApproval()
+		if gate == nil {
+			t.Fatal("GroupAwaitingApproval() = nil, want a pending approval")
+		}
+		if gate.Branch != "claudio/group-1" || !gate.VerificationPassed || gate.Notes != "clean merge" {
+			t.Errorf("summary = %+v, want branch, verification and notes from the consolidation report", gate)
+		}
+		if len(gate.TasksConsolidated) != 1 || len(gate.IssuesForNextGroup) != 1 {
+			t.Errorf("summary = %+v, want consolidated tasks and issues", gate)
+		}
+		if ready := c.Session().GetReadyTasks(); len(ready) != 0 {
+			t.Errorf("GetReadyTasks() while awaiting approval = %v, want none", ready)
+		}
+
+		if err := c.ApproveGroup(); err != nil {
+			t.Fatalf("ApproveGroup() error = %v", err)
+		}
+		if gate := c.GroupAwaitingApproval(); gate != nil {
+			t.Errorf("GroupAwaitingApproval() after approval = %+v, want nil", gate)
+		}
+		if ready := c.Session().GetReadyTasks(); len(ready) != 1 || ready[0] != "task-2" {
+			t.Errorf("GetReadyTasks() after approval = %v, want [task-2]", ready)
+		}
+		if err := c.ApproveGroup(); err == nil {
+			t.Error("ApproveGroup() with nothing pending: want error")
+		}
+	})
+
+	t.Run("failed verification pauses", func(t *testing.T) {
+		for _, onFailure := range []string{verify.OnFailureFail, verify.OnFailurePause} {
+			c := groupApprovalTestCoordinator(false)
+			session := c.Session()
+			session.Config.Verify = verify.GroupConfig{OnFailure: onFailure}
+			session.GroupConsolidationContexts[0].Verification = types.VerificationResult{
+				Native:  true,
+				Summary: "1 of 2 commands failed: test",
+			}
+			c.awaitGroupApproval(0)
+
+			gate := c.GroupAwaitingApproval()
+			if (gate != nil) != (onFailure == verify.OnFailurePause) {
+				t.Fatalf("on_failure %q: GroupAwaitingApproval() = %+v", onFailure, gate)
+			}
+			if gate != nil && (gate.VerificationPassed || gate.Verification != "1 of 2 commands failed: test") {
+				t.Errorf("summary = %+v, want the failed verification", gate)
+			}
+		}
+	})
+}
+
+func TestCoordinator_GroupApprovalThroughAPI(t *testing.T) {
+	c := groupApprovalTestCoordinator(true)
+	c.awaitGroupApproval(0)
+
+	pending := c.orch.PendingApprovals()
+	if len(pending) != 1 || pending[0] != "group-2" {
+		t.Fatalf("PendingApprovals() = %v, want [group-2]", pending)
+	}
+	if err := c.orch.ApproveTask("group-3"); err == nil {
+		t.Error("ApproveTask(group-3): want error")
+	}
+	if err := c.orch.ApproveTask("group-2"); err != nil {
+		t.Fatalf("ApproveTask(group-2) error = %v", err)
+	}
+	if gate := c.GroupAwaitingApproval(); gate != nil {
+		t.Errorf("GroupAwaitingApproval() after API approval = %+v, want nil", gate)
+	}
+	if pending := c.orch.PendingApprovals(); len(pending) != 0 {
+		t.Errorf("PendingApprovals() after approval = %v, want none", pending)
+	}
+}
@@ -0,0 +1,65 @@
+package orchestrator
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/mailbox"
+)
+
+// heldMessages records the mailbox of every message the message guard held
+// for review (ultraplan.message_guard: hold), so it can be released by ID.
+type heldMessages struct {
+	mu   sync.Mutex
+	dirs map[string]string // message ID → session directory of its mailbox
+}
+
+// initMessageGuard starts recording held messages from the event bus.
+func (o *Orchestrator) initMessageGuard() {
+	if o.eventBus == nil {
+		return
+	}
+	o.eventBus.Subscribe("mailbox.message_flagged", func(e event.Event) {
+		fe, ok := e.(event.MailboxMessageFlaggedEvent)
+		if !ok || !fe.Held || fe.MailboxDir == "" {
+			return
+		}
+		o.held.mu.Lock()
+		defer o.held.mu.Unlock()
+		if o.held.dirs == nil {
+			o.held.dirs = make(map[string]string)
+		}
+		o.held.dirs[fe.MessageID] = fe.MailboxDir
+	})
+}
+
+// HeldMessages returns the IDs of the messages held for review that have
+// not been released, sorted.
+func (o *Orchestrator) HeldMessages() []string {
+	o.held.mu.Lock()
+	defer o.held.mu.Unlock()
+	ids := make([]string, 0, len(o.held.dirs))
+	for id := range o.held.dirs {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// ReleaseMessage approves a message the guard held for review, so that it is
+// included in its recipients' prompts from now on.
+func (o *Orchestrator) ReleaseMessage(id string) error {
+	o.held.mu.Lock()
+	defer o.held.mu.Unlock()
+	dir, ok := o.held.dirs[id]
+	if !ok {
+		return fmt.Errorf("no held message %q", id)
+	}
+	if err := mailbox.NewMailbox(dir).Release(id); err != nil {
+		return err
+	}
+	delete(o.held.dirs, id)
+	return nil
+}
@@ -0,0 +1,98 @@
+package orchestrator
+
+import (
+	"encoding/json"
+
+	"github.com/Iron-Ham/claudio/internal/estimate"
+	orchsession "github.com/Iron-Ham/claudio/internal/orchestrator/session"
+	"github.com/Iron-Ham/claudio/internal/store"
+)
+
+// startHistory opens the session history database configured by
+// session.database, attaches it to the session manager, and records the
+// current session. Every later save is recorded too. It is a no-op when
+// session.database is disabled or the database is already open.
+// Caller must hold o.mu.
+func (o *Orchestrator) startHistory() {
+	o.historyMu.Lock()
+	if o.history != nil {
+		o.historyMu.Unlock()
+		return
+	}
+	db, err := store.New(o.config.Session.Database, o.baseDir)
+	if err != nil {
+		o.historyMu.Unlock()
+		if o.logger != nil {
+			o.logger.Warn("session history disabled", "error", err)
+		}
+		return
+	}
+	if db == nil {
+		o.historyMu.Unlock()
+		return
+	}
+	o.history = db
+	o.sessionMgr.SetBackend(db)
+	o.historyMu.Unlock()
+
+	if o.session != nil {
+		if data, err := json.Marshal(o.session); err == nil {
+			o.recordHistory(data)
+		}
+	}
+}
+
+// recordHistory records a saved session, as written to session.json, in
+// the history database. Failures are logged: history never fails a save.
+func (o *Orchestrator) recordHistory(data []byte) {
+	o.historyMu.Lock()
+	defer o.historyMu.Unlock()
+	if o.history == nil {
+		return
+	}
+
+	var sess orchsession.SessionData
+	if err := json.Unmarshal(data, &sess); err != nil {
+		if o.logger != nil {
+			o.logger.Warn("failed to decode session for history", "error", err)
+		}
+		return
+	}
+	if err := o.history.SaveSession(&sess); err != nil && o.logger != nil {
+		o.logger.Warn("failed to record session history", "session_id", sess.ID, "error", err)
+	}
+}
+
+// stopHistoryLocked closes the history database if it is open. When
+// deleted is true the session is marked deleted first, because its session
+// file is gone; its history is kept. Caller must hold o.mu.
+func (o *Orchestrator) stopHistoryLocked(sessionID string, deleted bool) {
+	o.historyMu.Lock()
+	defer o.historyMu.Unlock()
+	if o.history == nil {
+		return
+	}
+
+	if deleted && sessionID != "" {
+		if err := o.history.DeleteSession(sessionID); err != nil && o.logger != nil {
+			o.logger.Warn("failed to mark session deleted in history", "session_id", sessionID, "error", err)
+		}
+	}
+	if err := o.history.Close(); err != nil && o.logger != nil {
+		o.logger.Warn("failed to close session history", "error", err)
+	}
+	o.history = nil
+	o.sessionMgr.SetBackend(nil)
+}
+
+// taskSamples returns the completed ultraplan tasks of the last sessions
+// sessions in the history database, or nil when session.database is
+// disabled.
+func (o *Orchestrator) taskSamples(sessions int) ([]estimate.Sample, error) {
+	o.historyMu.Lock()
+	defer o.historyMu.Unlock()
+	if o.history == nil {
+		return nil, nil
+	}
+	return estimate.Load(o.history, sessions)
+}
@@ -0,0 +1,77 @@
+package orchestrator
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	orchsession "github.com/Iron-Ham/claudio/internal/orchestrator/session"
+	"github.com/Iron-Ham/claudio/internal/store"
+)
+
+func TestOrchestrator_History(t *testing.T) {
+	baseDir := t.TempDir()
+	cfg := config.Default()
+	cfg.Session.Database.Enabled = true
+
+	o := &Orchestrator{
+		baseDir:    baseDir,
+		sessionID:  "s1",
+		sessionDir: t.TempDir(),
+		config:     cfg,
+		sessionMgr: orchsession.NewManager(orchsession.Config{BaseDir: baseDir}),
+		session: &Session{
+			ID:      "s1",
+			Name:    "history",
+			Created: time.Now(),
+			Instances: []*Instance{
+				{ID: "i1", Task: "write docs", Status: StatusWorking, Metrics: &Metrics{Cost: 0.25}},
+			},
+		},
+	}
+
+	o.startHistory()
+	if o.history == nil {
+		t.Fatal("history should open when session.database is enabled")
+	}
+	if o.sessionMgr.Backend() == nil {
+		t.Error("the session manager should persist to the history database")
+	}
+
+	o.session.Instances[0].Status = StatusCompleted
+	o.session.Instances[0].Metrics.Cost = 1.5
+	if err := o.saveSession(); err != nil {
+		t.Fatalf("saveSession() error = %v", err)
+	}
+	o.stopHistoryLocked("s1", true)
+	if o.history != nil || o.sessionMgr.Backend() != nil {
+		t.Error("stopping should close the history database")
+	}
+
+	db, err := store.Open(filepath.Join(baseDir, ".claudio", store.DefaultFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+	costs, err := db.TaskCosts(0)
+	if err != nil {
+		t.Fatalf("TaskCosts() error = %v", err)
+	}
+	if len(costs) != 1 || costs[0].Cost != 1.5 || costs[0].Status != string(StatusCompleted) {
+		t.Errorf("recorded tasks = %+v, want the last saved state", costs)
+	}
+	sums, err := db.RecentSessions(0)
+	if err != nil {
+		t.Fatalf("RecentSessions() error = %v", err)
+	}
+	if len(sums) != 1 || !sums[0].Deleted {
+		t.Errorf("recorded sessions = %+v, want s1 marked deleted", sums)
+	}
+
+	disabled := &Orchestrator{baseDir: t.TempDir(), config: config.Default()}
+	disabled.startHistory()
+	if disabled.history != nil {
+		t.Error("history should not open without session.database.enabled")
+	}
+}
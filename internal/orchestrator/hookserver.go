@@ -0,0 +1,109 @@
+package orchestrator
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/ai"
+	"github.com/Iron-Ham/claudio/internal/hookserver"
+	"github.com/Iron-Ham/claudio/internal/instance/detect"
+)
+
+// hookServerAddress is where the hook server listens. Hooks learn the port
+// from the worktree settings, so any free loopback port will do.
+const hookServerAddress = "127.0.0.1:0"
+
+// hookServerStopTimeout bounds how long stopping the hook server waits for
+// hook requests in flight.
+const hookServerStopTimeout = 2 * time.Second
+
+// startHookServer receives Claude Code hook events from instances when
+// ai.claude.hooks is set, and feeds them to the state monitor. Worktrees of
+// instances already in the session are pointed at the new listener; the
+// instances pick it up when they next start. A listen failure is logged and
+// state is detected from output as before. It is a no-op if already
+// running. Caller must hold o.mu.
+func (o *Orchestrator) startHookServer() {
+	if !o.config.AI.Claude.Hooks || o.hookServer != nil || o.stateMonitor == nil ||
+		o.backend == nil || o.backend.Name() != ai.BackendClaude {
+		return
+	}
+
+	monitor := o.stateMonitor
+	srv := hookserver.New(func(instanceID string, ev detect.HookEvent) {
+		monitor.ProcessHookEvent(instanceID, ev)
+		if ev.HookEventName == detect.HookPostToolUse {
+			o.recordHookActivity(instanceID)
+		}
+	}, hookserver.WithLogger(o.logger))
+	if err := srv.Start(hookServerAddress); err != nil {
+		if o.logger != nil {
+			o.logger.Warn("hook server disabled", "error", err)
+		}
+		return
+	}
+	o.hookServer = srv
+	if o.logger != nil {
+		o.logger.Info("hook server listening", "address", srv.Addr().String())
+	}
+
+	if o.session == nil {
+		return
+	}
+	for _, inst := range o.session.Instances {
+		if inst.Backend != "" || inst.WorktreePath == "" {
+			continue
+		}
+		if _, err := os.Stat(inst.WorktreePath); err != nil {
+			continue
+		}
+		o.writeWorktreeTeamSettings(inst.ID, inst.WorktreePath)
+	}
+}
+
+// recordHookActivity copies the tool usage an instance has reported through
+// hooks into its metrics, where the instance view shows it.
+func (o *Orchestrator) recordHookActivity(instanceID string) {
+	activity, ok := o.stateMonitor.HookActivity(instanceID)
+	if !ok {
+		return
+	}
+	inst := o.GetInstance(instanceID)
+	if inst == nil {
+		return
+	}
+
+	if inst.Metrics == nil {
+		inst.Metrics = &Metrics{}
+	}
+	toolUses := 0
+	for _, n := range activity.ToolUses {
+		toolUses += n
+	}
+	inst.Metrics.ToolUses = toolUses
+	inst.Metrics.LastTool = activity.LastTool
+}
+
+// hookURL returns the URL an instance's hooks post to, or "" when the hook
+// server is not running. Caller must hold o.mu.
+func (o *Orchestrator) hookURL(instanceID string) string {
+	if o.hookServer == nil {
+		return ""
+	}
+	return o.hookServer.URL(instanceID)
+}
+
+// stopHookServerLocked stops the hook server if it is running. Caller must
+// hold o.mu.
+func (o *Orchestrator) stopHookServerLocked() {
+	if o.hookServer == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), hookServerStopTimeout)
+	defer cancel()
+	if err := o.hookServer.Stop(ctx); err != nil && o.logger != nil {
+		o.logger.Warn("failed to stop hook server", "error", err)
+	}
+	o.hookServer = nil
+}
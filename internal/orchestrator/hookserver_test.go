@@ -0,0 +1,110 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/ai"
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/instance/detect"
+	instancestate "github.com/Iron-Ham/claudio/internal/instance/state"
+)
+
+func TestOrchestrator_HookServer(t *testing.T) {
+	cfg := config.Default()
+	wtPath := t.TempDir()
+	o := &Orchestrator{
+		config:       cfg,
+		backend:      &stubBackend{name: ai.BackendClaude},
+		stateMonitor: instancestate.NewMonitorWithDefaults(),
+		session: &Session{Instances: []*Instance{
+			{ID: "inst-1", WorktreePath: wtPath},
+			{ID: "inst-gone", WorktreePath: filepath.Join(wtPath, "missing")},
+		}},
+	}
+
+	o.startHookServer()
+	if o.hookServer != nil {
+		t.Fatal("hook server should not start when ai.claude.hooks is false")
+	}
+
+	cfg.AI.Claude.Hooks = true
+	o.startHookServer()
+	if o.hookServer == nil {
+		t.Fatal("hook server should start when enabled")
+	}
+	t.Cleanup(o.stopHookServerLocked)
+
+	// Existing worktrees are pointed at the new listener
+	data, err := os.ReadFile(filepath.Join(wtPath, ".claude", "settings.local.json"))
+	if err != nil {
+		t.Fatalf("settings not written: %v", err)
+	}
+	url := o.hookURL("inst-1")
+	if !strings.Contains(string(data), url) {
+		t.Errorf("settings = %s, want hooks posting to %s", data, url)
+	}
+	if _, err := os.Stat(filepath.Join(wtPath, "missing")); !os.IsNotExist(err) {
+		t.Error("settings were written for an instance whose worktree is gone")
+	}
+
+	// Events posted by the hooks drive the monitored state
+	o.stateMonitor.Start("inst-1")
+	resp, err := http.Post(url, "application/json", strings.NewReader(`{"hook_event_name":"Notification","notification_type":"permission_prompt"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if got := o.stateMonitor.GetState("inst-1"); got != detect.StateWaitingPermission {
+		t.Errorf("state after a permission notification = %v, want %v", got, detect.StateWaitingPermission)
+	}
+
+	// Tool calls reported by the hooks show up in the instance's metrics
+	for _, tool := range []string{"Bash", "Edit"} {
+		resp, err = http.Post(url, "application/json", strings.NewReader(`{"hook_event_name":"PostToolUse","tool_name":"`+tool+`"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+	if m := o.GetInstance("inst-1").Metrics; m == nil || m.ToolUses != 2 || m.LastTool != "Edit" {
+		t.Errorf("metrics after two tool calls = %+v, want 2 tool uses, last Edit", m)
+	}
+
+	// Without the server, rewriting the settings removes the hooks
+	o.stopHookServerLocked()
+	if o.hookServer != nil {
+		t.Error("hook server should be cleared after stop")
+	}
+	o.writeWorktreeTeamSettings("inst-1", wtPath)
+	data, err = os.ReadFile(filepath.Join(wtPath, ".claude", "settings.local.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var settings map[string]any
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := settings["hooks"]; ok {
+		t.Errorf("settings = %s, want Claudio's hooks removed", data)
+	}
+}
+
+func TestOrchestrator_HookServerNeedsClaude(t *testing.T) {
+	cfg := config.Default()
+	cfg.AI.Claude.Hooks = true
+	o := &Orchestrator{
+		config:       cfg,
+		backend:      &stubBackend{name: ai.BackendToolRunner},
+		stateMonitor: instancestate.NewMonitorWithDefaults(),
+	}
+
+	o.startHookServer()
+	if o.hookServer != nil {
+		t.Error("hook server should not start for a backend other than Claude")
+	}
+}
@@ -0,0 +1,60 @@
+package orchestrator
+
+import (
+	"errors"
+	"path/filepath"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+)
+
+// startJournal appends every event published during the session to the
+// session's event journal, so its history survives a crash. It only runs for
+// sessions with their own directory, and is a no-op if already running. A
+// journal that cannot be opened is logged and the session continues without
+// it. Caller must hold o.mu.
+func (o *Orchestrator) startJournal() {
+	if o.sessionDir == "" || o.journal != nil {
+		return
+	}
+	j, err := event.OpenJournal(filepath.Join(o.sessionDir, event.JournalFileName))
+	if err != nil {
+		if o.logger != nil {
+			o.logger.Warn("event journal disabled", "error", err)
+		}
+		return
+	}
+	j.Attach(o.eventBus)
+	o.journal = j
+}
+
+// stopJournalLocked stops journaling events. Caller must hold o.mu.
+func (o *Orchestrator) stopJournalLocked() {
+	if o.journal == nil {
+		return
+	}
+	if err := o.journal.Err(); err != nil && o.logger != nil {
+		o.logger.Warn("event journal is incomplete", "error", err)
+	}
+	if err := o.journal.Close(); err != nil && o.logger != nil {
+		o.logger.Warn("failed to close event journal", "error", err)
+	}
+	o.journal = nil
+}
+
+// ReplayEvents publishes the session's journaled events timestamped at or
+// after since (zero for all) on bus, so components such as the TUI and
+// coordinator can rebuild their state after a restart. Subscribers see the
+// same event values they saw live. bus is normally a private bus holding only
+// the subscribers that rebuild state: replaying onto the session's own bus
+// would repeat side effects, such as notifications, that already happened.
+// It returns the number of events replayed.
+func (o *Orchestrator) ReplayEvents(bus *event.Bus, since time.Time) (int, error) {
+	o.mu.RLock()
+	j := o.journal
+	o.mu.RUnlock()
+	if j == nil {
+		return 0, errors.New("no event journal for this session")
+	}
+	return j.Replay(bus, since)
+}
@@ -0,0 +1,57 @@
+package orchestrator
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/event"
+)
+
+func TestOrchestrator_Journal(t *testing.T) {
+	dir := t.TempDir()
+	o := &Orchestrator{sessionDir: dir, config: config.Default(), eventBus: event.NewBus()}
+
+	if _, err := o.ReplayEvents(o.eventBus, time.Time{}); err == nil {
+		t.Error("ReplayEvents() without a journal should fail")
+	}
+
+	o.startJournal()
+	if o.journal == nil {
+		t.Fatal("journal should start for a session with a directory")
+	}
+	o.eventBus.Publish(event.NewBellEvent("inst-1"))
+	o.stopJournalLocked()
+	if o.journal != nil {
+		t.Error("journal should be cleared after stop")
+	}
+	o.stopJournalLocked() // no-op when stopped
+
+	// After a restart, the journal is reopened and replayed onto a new bus.
+	o.eventBus = event.NewBus()
+	var replayed []event.Event
+	o.eventBus.SubscribeAll(func(e event.Event) { replayed = append(replayed, e) })
+	o.startJournal()
+	defer o.stopJournalLocked()
+
+	n, err := o.ReplayEvents(o.eventBus, time.Time{})
+	if err != nil || n != 1 {
+		t.Fatalf("ReplayEvents() = %d, %v; want 1", n, err)
+	}
+	if len(replayed) != 1 || replayed[0].EventType() != "instance.bell" {
+		t.Errorf("replayed = %v, want one instance.bell", replayed)
+	}
+	events, err := event.ReadJournal(filepath.Join(dir, event.JournalFileName), time.Time{})
+	if err != nil || len(events) != 1 {
+		t.Errorf("journal has %d events (err %v) after replay, want 1", len(events), err)
+	}
+}
+
+func TestOrchestrator_JournalWithoutSessionDir(t *testing.T) {
+	o := &Orchestrator{config: config.Default(), eventBus: event.NewBus()}
+	o.startJournal()
+	if o.journal != nil {
+		t.Error("journal should not start without a session directory")
+	}
+}
@@ -0,0 +1,79 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/notify"
+)
+
+// notifierStopTimeout bounds how long stopping the notifier waits for
+// queued webhook messages to be posted.
+const notifierStopTimeout = 5 * time.Second
+
+// startNotifier posts webhook messages for the session's key events when
+// webhooks.endpoints is set. It only runs for sessions with their own
+// directory, and is a no-op if already running. Caller must hold o.mu.
+func (o *Orchestrator) startNotifier() {
+	cfg := o.config.Webhooks
+	if o.sessionDir == "" || len(cfg.Endpoints) == 0 || o.notifier != nil {
+		return
+	}
+
+	opts := []notify.Option{
+		notify.WithSession(o.notifierSessionName()),
+		notify.WithTimeout(cfg.Timeout()),
+		notify.WithLogger(o.logger),
+		notify.WithKind(notify.KindPhaseChanged, cfg.Events.PhaseChanged),
+		notify.WithKind(notify.KindTaskFailed, cfg.Events.TaskFailed),
+		notify.WithKind(notify.KindPROpened, cfg.Events.PROpened),
+		notify.WithKind(notify.KindBudgetWarning, cfg.Events.BudgetWarning),
+		notify.WithKind(notify.KindWaitingInput, cfg.Events.WaitingInput),
+		notify.WithTemplate(notify.KindPhaseChanged, cfg.Templates.PhaseChanged),
+		notify.WithTemplate(notify.KindTaskFailed, cfg.Templates.TaskFailed),
+		notify.WithTemplate(notify.KindPROpened, cfg.Templates.PROpened),
+		notify.WithTemplate(notify.KindBudgetWarning, cfg.Templates.BudgetWarning),
+		notify.WithTemplate(notify.KindWaitingInput, cfg.Templates.WaitingInput),
+	}
+	for _, endpoint := range cfg.Endpoints {
+		opts = append(opts, notify.WithEndpoints(notify.Endpoint{
+			URL:    endpoint.URL,
+			Format: notify.Format(endpoint.Format),
+		}))
+	}
+
+	n := notify.New(o.eventBus, opts...)
+	if err := n.Start(); err != nil {
+		if o.logger != nil {
+			o.logger.Warn("webhook notifications disabled", "error", err)
+		}
+		return
+	}
+	o.notifier = n
+}
+
+// notifierSessionName returns the name messages identify the session by.
+// Caller must hold o.mu.
+func (o *Orchestrator) notifierSessionName() string {
+	if o.session == nil {
+		return ""
+	}
+	if o.session.Name != "" {
+		return o.session.Name
+	}
+	return o.session.ID
+}
+
+// stopNotifierLocked stops the notifier if it is running, after posting
+// the messages already queued. Caller must hold o.mu.
+func (o *Orchestrator) stopNotifierLocked() {
+	if o.notifier == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), notifierStopTimeout)
+	defer cancel()
+	if err := o.notifier.Stop(ctx); err != nil && o.logger != nil {
+		o.logger.Warn("webhook messages not sent before stop", "error", err)
+	}
+	o.notifier = nil
+}
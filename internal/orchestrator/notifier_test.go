@@ -0,0 +1,69 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/budget"
+)
+
+func TestOrchestrator_Notifier(t *testing.T) {
+	cfg := config.Default()
+	o := &Orchestrator{sessionDir: t.TempDir(), config: cfg, eventBus: event.NewBus()}
+
+	o.startNotifier()
+	if o.notifier != nil {
+		t.Fatal("notifier should not start without webhook endpoints")
+	}
+
+	cfg.Webhooks.Endpoints = []config.WebhookEndpointConfig{{URL: "http://127.0.0.1:1/hook"}}
+	o.startNotifier()
+	if o.notifier == nil {
+		t.Fatal("notifier should start with an endpoint")
+	}
+	n := o.notifier
+	o.startNotifier() // no-op while running
+	if o.notifier != n {
+		t.Error("starting twice should keep the running notifier")
+	}
+
+	o.stopNotifierLocked()
+	if o.notifier != nil {
+		t.Error("notifier should be cleared after stop")
+	}
+	o.stopNotifierLocked() // no-op when stopped
+}
+
+func TestOrchestrator_NotifierBadTemplate(t *testing.T) {
+	cfg := config.Default()
+	cfg.Webhooks.Endpoints = []config.WebhookEndpointConfig{{URL: "http://127.0.0.1:1/hook"}}
+	cfg.Webhooks.Templates.TaskFailed = "{{.Task"
+	o := &Orchestrator{sessionDir: t.TempDir(), config: cfg, eventBus: event.NewBus()}
+
+	o.startNotifier()
+	if o.notifier != nil {
+		t.Error("a template that does not parse should leave the notifier stopped")
+	}
+}
+
+func TestOrchestrator_PublishBudgetWarningOnce(t *testing.T) {
+	cfg := config.Default()
+	bus := event.NewBus()
+	o := &Orchestrator{config: cfg, eventBus: bus, session: &Session{}}
+	o.budgetMgr = budget.NewManagerFromConfig(cfg, o, o, budget.Callbacks{}, nil)
+
+	var warnings []event.BudgetWarningEvent
+	bus.Subscribe("budget.warning", func(e event.Event) {
+		warnings = append(warnings, e.(event.BudgetWarningEvent))
+	})
+	o.publishBudgetWarning()
+	o.publishBudgetWarning()
+
+	if len(warnings) != 1 {
+		t.Fatalf("published %d budget warnings, want 1", len(warnings))
+	}
+	if warnings[0].Threshold != cfg.Resources.CostWarningThreshold {
+		t.Errorf("threshold = %v, want %v", warnings[0].Threshold, cfg.Resources.CostWarningThreshold)
+	}
+}
@@ -0,0 +1,159 @@
+package orchestrator
+
+import (
+	"github.com/Iron-Ham/claudio/internal/instance"
+)
+
+// ObserveSession loads the session to observe it while another process runs
+// it. Unlike LoadSession, it takes no lock and starts none of the session's
+// services. Instance managers are read-only: they capture each instance's
+// pane, or follow its transcript, but never send input or stop it. The
+// session is never saved; call ApplyObservedSession with a fresh
+// ReadObservedSession to follow the owner's changes.
+func (o *Orchestrator) ObserveSession() (*Session, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	sess, err := o.readSessionFile()
+	if err != nil {
+		return nil, err
+	}
+
+	o.observing = true
+	o.session = sess
+	if o.sessionID == "" && sess.ID != "" {
+		o.sessionID = sess.ID
+	}
+	o.observeInstancesLocked()
+	o.publishSnapshot()
+
+	if o.logger != nil {
+		o.logger.Info("observing session",
+			"session_id", sess.ID,
+			"instance_count", len(sess.Instances),
+		)
+	}
+	return o.session, nil
+}
+
+// Observing reports whether the orchestrator observes a session another
+// process runs (see ObserveSession).
+func (o *Orchestrator) Observing() bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.observing
+}
+
+// ReadObservedSession reads the observed session's file as its owner last
+// saved it, without changing the orchestrator. It is safe to call off the
+// goroutine that applies the result.
+func (o *Orchestrator) ReadObservedSession() (*Session, error) {
+	return o.readSessionFile()
+}
+
+// ApplyObservedSession replaces the observed session's state with fresh,
+// keeping the *Session the TUI holds, and starts observing instances the
+// owner added or started since.
+func (o *Orchestrator) ApplyObservedSession(fresh *Session) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if !o.observing || o.session == nil || fresh == nil {
+		return
+	}
+	o.session.replaceWith(fresh)
+
+	// Stop following instances the owner removed
+	for id, mgr := range o.instances {
+		if o.session.GetInstance(id) == nil {
+			_ = mgr.Stop()
+			delete(o.instances, id)
+		}
+	}
+	o.observeInstancesLocked()
+	o.publishSnapshot()
+}
+
+// observeInstancesLocked starts a read-only manager for every instance not
+// yet observed, and retries active instances whose manager is not capturing
+// (the owner may have started them since). Caller must hold o.mu.
+func (o *Orchestrator) observeInstancesLocked() {
+	for _, inst := range o.session.Instances {
+		mgr, ok := o.instances[inst.ID]
+		if ok && (mgr.Running() || !observable(inst.Status)) {
+			continue
+		}
+		if !ok {
+			mgr = o.newObserverManager(inst)
+			o.instances[inst.ID] = mgr
+		}
+		if err := mgr.Observe(); err != nil && o.logger != nil {
+			o.logger.Debug("instance not observable yet", "instance_id", inst.ID, "error", err)
+		}
+	}
+}
+
+// observable reports whether an instance with status may have a pane or
+// transcript being written to.
+func observable(status InstanceStatus) bool {
+	switch status {
+	case StatusWorking, StatusWaitingInput, StatusFinishing, StatusPaused, StatusStuck:
+		return true
+	}
+	return false
+}
+
+// newObserverManager creates a read-only manager for inst. It has its own
+// state monitor and no callbacks: what it detects is shown, but must not
+// drive the orchestrator's handlers, which act on the owner's instances.
+func (o *Orchestrator) newObserverManager(inst *Instance) *instance.Manager {
+	cfg := o.instanceManagerConfig()
+	cfg.ReadOnly = true
+	cfg.TranscriptPath = o.TranscriptPath(inst.ID)
+
+	return instance.NewManagerWithDeps(instance.ManagerOptions{
+		ID:              inst.ID,
+		SessionID:       o.sessionID,
+		WorkDir:         inst.WorktreePath,
+		Task:            inst.Task,
+		Config:          cfg,
+		ClaudeSessionID: inst.ClaudeSessionID,
+		Backend:         o.instanceBackend(inst),
+	})
+}
+
+// stopObservingLocked stops every read-only manager. The observed
+// instances keep running. Caller must hold o.mu.
+func (o *Orchestrator) stopObservingLocked() {
+	for _, mgr := range o.instances {
+		_ = mgr.Stop()
+	}
+	if o.logger != nil {
+		o.logger.Info("stopped observing session")
+	}
+}
+
+// replaceWith copies src's state into s, so holders of s see what src
+// holds. Fields added to Session must be copied here too.
+func (s *Session) replaceWith(src *Session) {
+	s.FormatVersion = src.FormatVersion
+	s.ID = src.ID
+	s.Name = src.Name
+	s.BaseRepo = src.BaseRepo
+	s.Created = src.Created
+	s.Instances = src.Instances
+	s.SetGroups(src.GetGroups())
+	s.UltraPlan = src.UltraPlan
+	s.TripleShots = src.TripleShots
+	s.AdversarialSessions = src.AdversarialSessions
+	s.RalphSessions = src.RalphSessions
+	s.RecoveryState = src.RecoveryState
+	s.LastActiveAt = src.LastActiveAt
+	s.CleanShutdown = src.CleanShutdown
+	s.InterruptedAt = src.InterruptedAt
+	s.RecoveredAt = src.RecoveredAt
+	s.RecoveryAttempt = src.RecoveryAttempt
+	s.BranchCleanups = src.BranchCleanups
+	s.PendingPRs = src.PendingPRs
+	s.SentinelProtocol = src.SentinelProtocol
+}
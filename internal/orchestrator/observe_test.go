@@ -0,0 +1,126 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/instance"
+	"github.com/Iron-Ham/claudio/internal/instance/capture"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/display"
+	"github.com/Iron-Ham/claudio/internal/session"
+)
+
+// newObserverTestOrchestrator returns an orchestrator for a session whose
+// instances run on the PTY backend, so they are observed from transcripts.
+func newObserverTestOrchestrator(t *testing.T) *Orchestrator {
+	t.Helper()
+	claudioDir := filepath.Join(t.TempDir(), ".claudio")
+	cfg := config.Default()
+	cfg.Instance.Backend = instance.ProcessBackendPTY
+	cfg.Instance.CaptureIntervalMs = 10
+	return &Orchestrator{
+		config:     cfg,
+		claudioDir: claudioDir,
+		sessionID:  "sess-1",
+		sessionDir: filepath.Join(claudioDir, "sessions", "sess-1"),
+		displayMgr: display.NewManager(display.DefaultConfig()),
+		instances:  make(map[string]*instance.Manager),
+	}
+}
+
+func writeObservedSession(t *testing.T, o *Orchestrator, sess *Session) {
+	t.Helper()
+	sess.ID = "sess-1"
+	if err := os.MkdirAll(o.sessionDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	owner := &Orchestrator{session: sess, sessionDir: o.sessionDir, claudioDir: t.TempDir()}
+	if err := owner.saveSession(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestObserveSession_ReadOnly(t *testing.T) {
+	o := newObserverTestOrchestrator(t)
+	writeObservedSession(t, o, &Session{Instances: []*Instance{{ID: "inst-1", Task: "first", Status: StatusWorking}}})
+
+	rec, err := capture.OpenRecorder(o.TranscriptPath("inst-1"), capture.TranscriptHeader{Width: 80, Height: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = rec.Close() }()
+	_ = rec.WriteFrame([]byte("owner's screen\n"))
+
+	sess, err := o.ObserveSession()
+	if err != nil {
+		t.Fatalf("ObserveSession() error = %v", err)
+	}
+	defer func() { _ = o.Shutdown() }()
+	if !o.Observing() {
+		t.Error("Observing() = false after ObserveSession")
+	}
+	if _, locked := session.IsLocked(o.sessionDir); locked {
+		t.Error("ObserveSession() took the session lock")
+	}
+
+	mgr := o.GetInstanceManager("inst-1")
+	if mgr == nil {
+		t.Fatal("no manager for the observed instance")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(string(mgr.GetOutput()), "owner's screen") {
+		if time.Now().After(deadline) {
+			t.Fatalf("instance output never observed, got %q", mgr.GetOutput())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Changes made by the observer are never written back
+	before, err := os.ReadFile(o.sessionFilePath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sess.Name = "renamed by observer"
+	if err := o.SaveSession(); err != nil {
+		t.Fatalf("SaveSession() error = %v", err)
+	}
+	after, _ := os.ReadFile(o.sessionFilePath())
+	if string(before) != string(after) {
+		t.Error("observer wrote the session file")
+	}
+	if err := o.StopSession(sess, false); err == nil {
+		t.Error("StopSession() of an observed session should fail")
+	}
+
+	// The owner's changes are picked up in place
+	writeObservedSession(t, o, &Session{Name: "owner", Instances: []*Instance{
+		{ID: "inst-1", Task: "first", Status: StatusCompleted},
+		{ID: "inst-2", Task: "second", Status: StatusPending},
+	}})
+	fresh, err := o.ReadObservedSession()
+	if err != nil {
+		t.Fatalf("ReadObservedSession() error = %v", err)
+	}
+	o.ApplyObservedSession(fresh)
+	if sess.Name != "owner" || len(sess.Instances) != 2 || sess.Instances[0].Status != StatusCompleted {
+		t.Errorf("session after refresh = %q with %d instances, want the owner's state", sess.Name, len(sess.Instances))
+	}
+	if o.GetInstanceManager("inst-2") == nil {
+		t.Error("no manager for the instance the owner added")
+	}
+
+	if err := o.Shutdown(); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if mgr.Running() {
+		t.Error("observer still capturing after Shutdown")
+	}
+	after, _ = os.ReadFile(o.sessionFilePath())
+	if strings.Contains(string(after), "clean_shutdown") {
+		t.Error("observer's Shutdown marked the owner's session as stopped")
+	}
+}
@@ -1,32 +1,49 @@
 package orchestrator
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Iron-Ham/claudio/internal/ai"
+	"github.com/Iron-Ham/claudio/internal/api"
+	"github.com/Iron-Ham/claudio/internal/audit"
 	"github.com/Iron-Ham/claudio/internal/config"
 	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/eventserver"
+	"github.com/Iron-Ham/claudio/internal/hookserver"
 	"github.com/Iron-Ham/claudio/internal/instance"
 	"github.com/Iron-Ham/claudio/internal/instance/detect"
 	instmetrics "github.com/Iron-Ham/claudio/internal/instance/metrics"
+	"github.com/Iron-Ham/claudio/internal/instance/process"
 	instancestate "github.com/Iron-Ham/claudio/internal/instance/state"
 	"github.com/Iron-Ham/claudio/internal/logging"
 	"github.com/Iron-Ham/claudio/internal/namer"
+	"github.com/Iron-Ham/claudio/internal/notify"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/budget"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/display"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/escalation"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/lifecycle"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/permission"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/prworkflow"
 	orchsession "github.com/Iron-Ham/claudio/internal/orchestrator/session"
+	"github.com/Iron-Ham/claudio/internal/pr"
 	"github.com/Iron-Ham/claudio/internal/session"
+	"github.com/Iron-Ham/claudio/internal/session/migrate"
+	"github.com/Iron-Ham/claudio/internal/store"
+	"github.com/Iron-Ham/claudio/internal/tracing"
 	"github.com/Iron-Ham/claudio/internal/util"
 	"github.com/Iron-Ham/claudio/internal/worktree"
 	"github.com/spf13/viper"
@@ -51,16 +68,50 @@ type Orchestrator struct {
 	sessionDir  string // Session-specific directory (.claudio/sessions/{sessionID})
 	lock        *session.Lock
 	logger      *logging.Logger // Structured logger for debugging (nil = no logging)
+	observing   bool            // Session is run by another process and only observed (see ObserveSession)
 
 	// Composed managers (delegation targets for refactored operations)
-	sessionMgr    *orchsession.Manager   // Session lifecycle management
-	lifecycleMgr  *lifecycle.Manager     // Instance lifecycle management
-	prWorkflowMgr *prworkflow.Manager    // PR workflow management
-	displayMgr    *display.Manager       // Display dimension management
-	eventBus      *event.Bus             // Inter-component event communication
-	stateMonitor  *instancestate.Monitor // Centralized state monitoring for all instances
-	budgetMgr     *budget.Manager        // Budget monitoring and enforcement
-	namer         *namer.Namer           // Intelligent instance naming (optional)
+	sessionMgr     *orchsession.Manager   // Session lifecycle management
+	lifecycleMgr   *lifecycle.Manager     // Instance lifecycle management
+	prWorkflowMgr  *prworkflow.Manager    // PR workflow management
+	prProvider     pr.Provider            // Creates pull requests on the origin's forge
+	displayMgr     *display.Manager       // Display dimension management
+	eventBus       *event.Bus             // Inter-component event communication
+	stateMonitor   *instancestate.Monitor // Centralized state monitoring for all instances
+	budgetMgr      *budget.Manager        // Budget monitoring and cost warnings
+	budgetWarned   atomic.Bool            // budget.warning already published this session
+	budgetEnforcer *budget.Enforcer       // Enforces hard budget limits (nil = not running)
+	ladder         *escalation.Ladder     // Recovery steps for stalled instances (nil = disabled)
+	ladderTarget   *escalationTarget      // Orchestrator side of the ladder
+	namer          *namer.Namer           // Intelligent instance naming (optional)
+	stopReaper     context.CancelFunc     // Stops the merged-branch reaper (nil = not running)
+	stopDrift      context.CancelFunc     // Stops the base branch drift watch (nil = not running)
+	stopDiag       context.CancelFunc     // Stops serving profile requests (nil = not running)
+	auditRec       *audit.Recorder        // Records operator actions to the audit log (nil = not running)
+	stopCheckpoint func()                 // Stops checkpointing after a final checkpoint (nil = not running)
+	eventServer    *eventserver.Server    // Streams events to dashboards (nil = not running)
+	hookServer     *hookserver.Server     // Receives Claude Code hook events (nil = not running)
+	notifier       *notify.Notifier       // Posts webhook messages (nil = not running)
+	apiServer      *api.Server            // Serves the gRPC control API (nil = not running)
+	approvers      approverSet            // Tasks awaiting approval, for the control API
+	journal        *event.Journal         // Appends events to the session's journal (nil = not running)
+	tracer         *tracing.Provider      // Exports OpenTelemetry spans (nil = not running)
+	held           heldMessages           // Messages the message guard holds for review
+
+	// Session history database (nil = session.database disabled)
+	history   *store.Store
+	historyMu sync.Mutex
+
+	// Per-task timeout policies and the policy applied to each task instance
+	timeoutPolicies  detect.TimeoutPolicies
+	instancePolicies map[string]detect.TimeoutPolicy
+	policyMu         sync.Mutex
+
+	// Rules answering permission prompts (nil = every prompt waits for the user)
+	permissionPolicy *permission.Policy
+
+	// Limits the CPU and memory of instances (nil = unlimited)
+	resources *process.Controller
 
 	session   *Session
 	instances map[string]*instance.Manager
@@ -77,6 +128,13 @@ type Orchestrator struct {
 	// Callback for when a terminal bell is detected in an instance
 	bellCallback func(instanceID string)
 
+	// snapshots holds the latest immutable copy of session state for readers
+	// that must not take mu, such as TUI rendering
+	snapshots snapshotStore
+
+	// status writes .claudio/status.json whenever a snapshot is published
+	status statusWriter
+
 	mu sync.RWMutex
 }
 
@@ -104,6 +162,15 @@ func NewWithConfig(baseDir string, cfg *config.Config) (*Orchestrator, error) {
 		}
 	}
 
+	if err := wt.UseGitBackend(cfg.Experimental.GitBackend); err != nil {
+		return nil, fmt.Errorf("failed to configure git backend: %w", err)
+	}
+
+	prProvider, err := newPRProvider(cfg, wt)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create event bus for inter-component communication
 	eventBus := event.NewBus()
 
@@ -126,8 +193,10 @@ func NewWithConfig(baseDir string, cfg *config.Config) (*Orchestrator, error) {
 	)
 
 	// Create PR workflow manager (legacy mode without session ID)
+	prWorkflowCfg := prworkflow.NewConfigFromConfig(cfg)
+	prWorkflowCfg.Provider = prProvider
 	prWorkflowMgr := prworkflow.NewManager(
-		prworkflow.NewConfigFromConfig(cfg),
+		prWorkflowCfg,
 		"", // no session ID in legacy mode
 		eventBus,
 		backend,
@@ -144,7 +213,7 @@ func NewWithConfig(baseDir string, cfg *config.Config) (*Orchestrator, error) {
 		ActivityTimeoutMinutes:   cfg.Instance.ActivityTimeoutMinutes,
 		CompletionTimeoutMinutes: cfg.Instance.CompletionTimeoutMinutes,
 		StaleDetection:           cfg.Instance.StaleDetection,
-	}, backend.Detector())
+	}, ai.DetectorFor(backend, instanceOutputFormat(cfg, backend)))
 
 	orch := &Orchestrator{
 		baseDir:       baseDir,
@@ -153,6 +222,7 @@ func NewWithConfig(baseDir string, cfg *config.Config) (*Orchestrator, error) {
 		sessionMgr:    sessionMgr,
 		lifecycleMgr:  lifecycleMgr,
 		prWorkflowMgr: prWorkflowMgr,
+		prProvider:    prProvider,
 		displayMgr:    displayMgr,
 		eventBus:      eventBus,
 		stateMonitor:  stateMonitor,
@@ -164,6 +234,10 @@ func NewWithConfig(baseDir string, cfg *config.Config) (*Orchestrator, error) {
 
 	// Initialize budget manager with orchestrator as provider and pauser
 	orch.initBudgetManager()
+	orch.initMessageGuard()
+	orch.initEscalation()
+	orch.initPermissionPolicy()
+	orch.initResourceLimits()
 
 	// Wire state monitor callbacks to orchestrator handlers
 	orch.wireStateMonitorCallbacks()
@@ -195,6 +269,15 @@ func NewWithSession(baseDir, sessionID string, cfg *config.Config) (*Orchestrato
 		}
 	}
 
+	if err := wt.UseGitBackend(cfg.Experimental.GitBackend); err != nil {
+		return nil, fmt.Errorf("failed to configure git backend: %w", err)
+	}
+
+	prProvider, err := newPRProvider(cfg, wt)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create event bus for inter-component communication
 	eventBus := event.NewBus()
 
@@ -223,8 +306,10 @@ func NewWithSession(baseDir, sessionID string, cfg *config.Config) (*Orchestrato
 	)
 
 	// Create PR workflow manager with session-scoped naming
+	prWorkflowCfg := prworkflow.NewConfigFromConfig(cfg)
+	prWorkflowCfg.Provider = prProvider
 	prWorkflowMgr := prworkflow.NewManager(
-		prworkflow.NewConfigFromConfig(cfg),
+		prWorkflowCfg,
 		sessionID,
 		eventBus,
 		backend,
@@ -241,7 +326,7 @@ func NewWithSession(baseDir, sessionID string, cfg *config.Config) (*Orchestrato
 		ActivityTimeoutMinutes:   cfg.Instance.ActivityTimeoutMinutes,
 		CompletionTimeoutMinutes: cfg.Instance.CompletionTimeoutMinutes,
 		StaleDetection:           cfg.Instance.StaleDetection,
-	}, backend.Detector())
+	}, ai.DetectorFor(backend, instanceOutputFormat(cfg, backend)))
 
 	orch := &Orchestrator{
 		baseDir:       baseDir,
@@ -252,6 +337,7 @@ func NewWithSession(baseDir, sessionID string, cfg *config.Config) (*Orchestrato
 		sessionMgr:    sessionMgr,
 		lifecycleMgr:  lifecycleMgr,
 		prWorkflowMgr: prWorkflowMgr,
+		prProvider:    prProvider,
 		displayMgr:    displayMgr,
 		eventBus:      eventBus,
 		stateMonitor:  stateMonitor,
@@ -263,6 +349,10 @@ func NewWithSession(baseDir, sessionID string, cfg *config.Config) (*Orchestrato
 
 	// Initialize budget manager with orchestrator as provider and pauser
 	orch.initBudgetManager()
+	orch.initMessageGuard()
+	orch.initEscalation()
+	orch.initPermissionPolicy()
+	orch.initResourceLimits()
 
 	// Wire state monitor callbacks to orchestrator handlers
 	orch.wireStateMonitorCallbacks()
@@ -347,6 +437,20 @@ func (o *Orchestrator) StartSession(name string) (*Session, error) {
 		)
 	}
 
+	o.startJournal()
+	o.startBudgetEnforcer()
+	o.startReaper()
+	o.startDriftWatch()
+	o.startDiagnostics()
+	o.startAudit()
+	o.startEventServer()
+	o.startHookServer()
+	o.startNotifier()
+	o.startAPIServer()
+	o.startCheckpoints()
+	o.startHistory()
+	o.startTracing()
+
 	return o.session, nil
 }
 
@@ -355,24 +459,13 @@ func (o *Orchestrator) LoadSession() (*Session, error) {
 	o.mu.Lock()
 	defer o.mu.Unlock()
 
-	sessionFile := o.sessionFilePath()
-	data, err := os.ReadFile(sessionFile)
+	sess, err := o.readSessionFile()
 	if err != nil {
-		if o.logger != nil {
-			o.logger.Error("failed to read session file", "file_path", sessionFile, "error", err)
-		}
-		return nil, fmt.Errorf("failed to read session file: %w", err)
-	}
-
-	var sess Session
-	if err := json.Unmarshal(data, &sess); err != nil {
-		if o.logger != nil {
-			o.logger.Error("failed to parse session file", "file_path", sessionFile, "error", err)
-		}
-		return nil, fmt.Errorf("failed to parse session file: %w", err)
+		return nil, err
 	}
 
-	o.session = &sess
+	o.session = sess
+	o.publishSnapshot()
 
 	// Set sessionID from loaded session if not already set
 	if o.sessionID == "" && sess.ID != "" {
@@ -387,9 +480,55 @@ func (o *Orchestrator) LoadSession() (*Session, error) {
 		)
 	}
 
+	o.startJournal()
+	o.startBudgetEnforcer()
+	o.startReaper()
+	o.startDriftWatch()
+	o.startDiagnostics()
+	o.startAudit()
+	o.startEventServer()
+	o.startHookServer()
+	o.startNotifier()
+	o.startAPIServer()
+	o.startCheckpoints()
+	o.startHistory()
+	o.startTracing()
+
 	return o.session, nil
 }
 
+// readSessionFile reads and parses the session file without changing the
+// orchestrator's state.
+func (o *Orchestrator) readSessionFile() (*Session, error) {
+	sessionFile := o.sessionFilePath()
+	data, err := os.ReadFile(sessionFile)
+	if err != nil {
+		if o.logger != nil {
+			o.logger.Error("failed to read session file", "file_path", sessionFile, "error", err)
+		}
+		return nil, fmt.Errorf("failed to read session file: %w", err)
+	}
+
+	if err := migrate.Check(migrate.KindSession, data); err != nil {
+		if o.logger != nil {
+			o.logger.Error("session file cannot be loaded by this version", "file_path", sessionFile, "error", err)
+		}
+		return nil, err
+	}
+	if err := migrate.CheckProtocol(data); err != nil && o.logger != nil {
+		o.logger.Warn("session instances may not complete", "error", err)
+	}
+
+	var sess Session
+	if err := json.Unmarshal(data, &sess); err != nil {
+		if o.logger != nil {
+			o.logger.Error("failed to parse session file", "file_path", sessionFile, "error", err)
+		}
+		return nil, fmt.Errorf("failed to parse session file: %w", err)
+	}
+	return &sess, nil
+}
+
 // LoadSessionWithLock loads an existing session and acquires a lock on it.
 // Use this for multi-session mode to prevent concurrent access.
 func (o *Orchestrator) LoadSessionWithLock() (*Session, error) {
@@ -569,6 +708,194 @@ func (o *Orchestrator) AddInstance(session *Session, task string) (*Instance, er
 	return inst, nil
 }
 
+// AddInstanceWithBackend adds a new instance that runs the named backend instead
+// of the session default, with a worktree branched from baseBranch (or HEAD when
+// empty). This is used for plan tasks that select their own executor, such as
+// scripted codemods run by the "tool" backend. Unlike the adversarial reviewer
+// path, an unknown backend is an error rather than a fallback to the default,
+// since a tool task's script must never be handed to an LLM as a prompt.
+func (o *Orchestrator) AddInstanceWithBackend(session *Session, task, baseBranch, backendName string) (*Instance, error) {
+	backend, err := o.resolveBackend(backendName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve backend %q: %w", backendName, err)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	inst := NewInstance(task)
+	if o.backend == nil || backend.Name() != o.backend.Name() {
+		inst.Backend = string(backend.Name())
+	}
+	o.initializeInstanceSessionID(inst)
+
+	branchSlug := slugify(task)
+	inst.Branch = o.generateBranchName(inst.ID, branchSlug)
+
+	wtPath := filepath.Join(o.worktreeDir, inst.ID)
+	if baseBranch != "" {
+		err = o.wt.CreateFromBranch(wtPath, inst.Branch, baseBranch)
+	} else {
+		err = o.wt.Create(wtPath, inst.Branch)
+	}
+	if err != nil {
+		if o.logger != nil {
+			o.logger.Error("failed to create worktree",
+				"instance_id", inst.ID,
+				"base_branch", baseBranch,
+				"backend", backendName,
+				"error", err,
+			)
+		}
+		return nil, fmt.Errorf("failed to create worktree: %w", err)
+	}
+	inst.WorktreePath = wtPath
+
+	if err := o.registerInstance(session, inst); err != nil {
+		return nil, err
+	}
+
+	if o.logger != nil {
+		o.logger.Info("instance added",
+			"instance_id", inst.ID,
+			"task", util.TruncateString(task, 100),
+			"branch", inst.Branch,
+			"base_branch", baseBranch,
+			"backend", backend.Name(),
+		)
+	}
+
+	return inst, nil
+}
+
+// AddOrReattachInstance adds an instance on the given deterministic branch
+// (see TaskBranchName) running the named backend ("" = session default). When
+// the branch already exists, from an earlier run of the same plan, the new
+// instance reattaches to it: it uses the worktree that has the branch checked
+// out, or a new worktree on the existing branch, and reused is true. Otherwise
+// a fresh worktree and branch are created from HEAD. Replaying a plan after a
+// partial failure therefore never duplicates branches for the same task.
+func (o *Orchestrator) AddOrReattachInstance(session *Session, task, branch, backendName string) (inst *Instance, reused bool, err error) {
+	return o.addOrReattachInstance(session, task, "", "", branch, backendName)
+}
+
+// AddOrReattachInstanceFromBranch is AddOrReattachInstance for a task whose
+// fresh branch starts from baseBranch (or HEAD when empty), such as a task
+// in a later execution group building on the previous group's consolidated
+// branch.
+func (o *Orchestrator) AddOrReattachInstanceFromBranch(session *Session, task, baseBranch, branch, backendName string) (inst *Instance, reused bool, err error) {
+	return o.addOrReattachInstance(session, task, "", baseBranch, branch, backendName)
+}
+
+// AddOrReattachInstanceInRepo is AddOrReattachInstance for a plan task in
+// another repository (ultraplan.repos): the worktree is created from, or
+// reattached in, the checkout at repoDir. An empty branch gets a generated
+// name, as with AddInstance.
+func (o *Orchestrator) AddOrReattachInstanceInRepo(session *Session, task, repoDir, branch, backendName string) (inst *Instance, reused bool, err error) {
+	return o.addOrReattachInstance(session, task, repoDir, "", branch, backendName)
+}
+
+// addOrReattachInstance implements the AddOrReattachInstance variants;
+// repoDir is "" for the session's repository and baseBranch "" for HEAD.
+func (o *Orchestrator) addOrReattachInstance(session *Session, task, repoDir, baseBranch, branch, backendName string) (inst *Instance, reused bool, err error) {
+	backend, err := o.resolveBackend(backendName)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to resolve backend %q: %w", backendName, err)
+	}
+
+	wt := o.wt
+	if repoDir != "" {
+		if wt, err = worktree.New(repoDir); err != nil {
+			return nil, false, fmt.Errorf("failed to open repository %s: %w", repoDir, err)
+		}
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	inst = NewInstance(task)
+	if o.backend == nil || backend.Name() != o.backend.Name() {
+		inst.Backend = string(backend.Name())
+	}
+	o.initializeInstanceSessionID(inst)
+	if branch == "" {
+		branch = o.generateBranchName(inst.ID, slugify(task))
+	}
+	inst.Branch = branch
+	inst.RepoDir = repoDir
+
+	reused = wt.BranchExists(branch)
+	if reused {
+		wtPath, findErr := wt.FindWorktreeForBranch(branch)
+		if findErr != nil {
+			return nil, false, findErr
+		}
+		if wtPath == "" {
+			wtPath = filepath.Join(o.worktreeDir, inst.ID)
+			err = wt.CreateWorktreeFromBranch(wtPath, branch)
+		}
+		inst.WorktreePath = wtPath
+	} else {
+		inst.WorktreePath = filepath.Join(o.worktreeDir, inst.ID)
+		if baseBranch != "" {
+			err = wt.CreateFromBranch(inst.WorktreePath, branch, baseBranch)
+		} else {
+			err = wt.Create(inst.WorktreePath, branch)
+		}
+	}
+	if err != nil {
+		if o.logger != nil {
+			o.logger.Error("failed to create worktree",
+				"instance_id", inst.ID,
+				"branch", branch,
+				"repo_dir", repoDir,
+				"base_branch", baseBranch,
+				"reattach", reused,
+				"error", err,
+			)
+		}
+		return nil, false, fmt.Errorf("failed to create worktree: %w", err)
+	}
+
+	if err := o.registerInstance(session, inst); err != nil {
+		return nil, false, err
+	}
+
+	if o.logger != nil {
+		o.logger.Info("instance added",
+			"instance_id", inst.ID,
+			"task", util.TruncateString(task, 100),
+			"branch", inst.Branch,
+			"worktree_path", inst.WorktreePath,
+			"reattached", reused,
+			"backend", backend.Name(),
+		)
+	}
+
+	return inst, reused, nil
+}
+
+// worktreeFor returns the worktree manager for the repository an
+// instance's worktree belongs to. An instance in another repository whose
+// checkout can no longer be opened falls back to the session's repository.
+func (o *Orchestrator) worktreeFor(inst *Instance) *worktree.Manager {
+	if inst.RepoDir == "" {
+		return o.wt
+	}
+	wt, err := worktree.New(inst.RepoDir)
+	if err != nil {
+		if o.logger != nil {
+			o.logger.Warn("failed to open instance repository",
+				"instance_id", inst.ID,
+				"repo_dir", inst.RepoDir,
+				"error", err,
+			)
+		}
+		return o.wt
+	}
+	return wt
+}
+
 // AddInstanceWithDependencies adds a new AI backend instance with dependencies on other instances.
 // The instance will be created in pending state. If autoStart is true, the orchestrator
 // will automatically start the instance when all dependencies complete.
@@ -750,7 +1077,7 @@ func (o *Orchestrator) AddInstanceToWorktreeWithBackend(session *Session, task s
 	// Use a specific backend if requested, otherwise use the default
 	var mgr *instance.Manager
 	if backendName != "" {
-		mgr = o.newInstanceManagerWithBackend(inst.ID, inst.WorktreePath, task, inst.ClaudeSessionID, backendName)
+		mgr = o.newInstanceManagerWithBackend(inst.ID, inst.WorktreePath, task, inst.ClaudeSessionID, backendName, nil)
 	} else {
 		mgr = o.newInstanceManager(inst.ID, inst.WorktreePath, task, inst.ClaudeSessionID, ai.StartOptions{})
 	}
@@ -829,18 +1156,30 @@ func (o *Orchestrator) StartInstanceWithOverrides(inst *Instance, overrides ai.S
 	// Ensure session ID is set when backend supports explicit IDs.
 	o.ensureInstanceSessionID(inst)
 
+	// Warm dependency caches in a fresh worktree before the backend starts.
+	o.bootstrapWorktree(inst)
+
 	o.mu.Lock()
 	mgr, ok := o.instances[inst.ID]
 	o.mu.Unlock()
 
 	if !ok {
-		mgr = o.newInstanceManager(inst.ID, inst.WorktreePath, inst.Task, inst.ClaudeSessionID, overrides)
+		if inst.Backend != "" {
+			mgr = o.newInstanceManagerWithBackend(inst.ID, inst.WorktreePath, inst.Task, inst.ClaudeSessionID, inst.Backend, overrides.Env)
+		} else {
+			mgr = o.newInstanceManager(inst.ID, inst.WorktreePath, inst.Task, inst.ClaudeSessionID, overrides)
+		}
 		o.mu.Lock()
 		o.instances[inst.ID] = mgr
 		o.mu.Unlock()
 	} else {
 		// Update the manager's backend session ID if it changed
 		mgr.SetClaudeSessionID(inst.ClaudeSessionID)
+		// Managers registered when the instance was added carry no
+		// overrides; apply these ones
+		if !reflect.ValueOf(overrides).IsZero() {
+			mgr.SetStartOverrides(overrides)
+		}
 	}
 
 	if err := mgr.Start(); err != nil {
@@ -885,6 +1224,8 @@ func (o *Orchestrator) StartInstanceWithOverrides(inst *Instance, overrides ai.S
 
 // StopInstance stops a running AI backend instance
 func (o *Orchestrator) StopInstance(inst *Instance) error {
+	o.cancelEscalation(inst.ID)
+
 	o.mu.RLock()
 	mgr, ok := o.instances[inst.ID]
 	o.mu.RUnlock()
@@ -1006,6 +1347,9 @@ func (o *Orchestrator) PRWorkflowManager() *prworkflow.Manager {
 
 // RemoveInstance stops and removes a specific instance, including its worktree and branch
 func (o *Orchestrator) RemoveInstance(session *Session, instanceID string, force bool) error {
+	o.cancelEscalation(instanceID)
+	o.clearInstanceTimeoutPolicy(instanceID)
+
 	o.mu.Lock()
 	defer o.mu.Unlock()
 
@@ -1057,7 +1401,8 @@ func (o *Orchestrator) RemoveInstance(session *Session, instanceID string, force
 	}
 
 	// Remove worktree
-	if err := o.wt.Remove(inst.WorktreePath); err != nil {
+	wt := o.worktreeFor(inst)
+	if err := wt.Remove(inst.WorktreePath); err != nil {
 		// Log but don't fail - the directory might already be gone
 		if o.logger != nil {
 			o.logger.Warn("failed to remove worktree",
@@ -1069,7 +1414,7 @@ func (o *Orchestrator) RemoveInstance(session *Session, instanceID string, force
 	}
 
 	// Delete branch
-	if err := o.wt.DeleteBranch(inst.Branch); err != nil {
+	if err := wt.DeleteBranch(inst.Branch); err != nil {
 		// Log but don't fail - the branch might already be gone
 		if o.logger != nil {
 			o.logger.Warn("failed to delete branch",
@@ -1099,11 +1444,32 @@ func (o *Orchestrator) RemoveInstance(session *Session, instanceID string, force
 
 // StopSession stops all instances and optionally cleans up
 func (o *Orchestrator) StopSession(sess *Session, force bool) error {
+	if o.Observing() {
+		return fmt.Errorf("cannot stop a session that is only observed")
+	}
+
+	// Stop escalations first: they take o.mu to record their outcome
+	if o.ladder != nil {
+		o.ladder.Stop()
+	}
+
 	o.mu.Lock()
 	defer o.mu.Unlock()
 
 	instanceCount := len(sess.Instances)
 
+	o.stopReaperLocked()
+	o.stopDriftWatchLocked()
+	o.stopDiagnosticsLocked()
+	o.stopAuditLocked()
+	o.stopEventServerLocked()
+	o.stopHookServerLocked()
+	o.stopNotifierLocked()
+	o.stopAPIServerLocked()
+	o.stopJournalLocked()
+	o.stopBudgetEnforcerLocked()
+	o.stopCheckpointsLocked()
+
 	// Stop namer service
 	if o.namer != nil {
 		o.namer.Stop()
@@ -1129,7 +1495,7 @@ func (o *Orchestrator) StopSession(sess *Session, force bool) error {
 	// Clean up worktrees if forced
 	if force {
 		for _, inst := range sess.Instances {
-			if err := o.wt.Remove(inst.WorktreePath); err != nil {
+			if err := o.worktreeFor(inst).Remove(inst.WorktreePath); err != nil {
 				if o.logger != nil {
 					o.logger.Warn("failed to remove worktree during session stop",
 						"instance_id", inst.ID,
@@ -1153,6 +1519,9 @@ func (o *Orchestrator) StopSession(sess *Session, force bool) error {
 		o.lock = nil
 	}
 
+	// Report on the session before its file is removed
+	o.writeReport(sess)
+
 	// Remove session file
 	sessionFile := o.sessionFilePath()
 	if err := os.Remove(sessionFile); err != nil && !os.IsNotExist(err) {
@@ -1163,6 +1532,9 @@ func (o *Orchestrator) StopSession(sess *Session, force bool) error {
 			)
 		}
 	}
+	o.removeStatusFile(sess.ID)
+	o.stopHistoryLocked(sess.ID, true)
+	o.stopTracingLocked()
 
 	// Log session stopped
 	if o.logger != nil {
@@ -1184,9 +1556,31 @@ func (o *Orchestrator) StopSession(sess *Session, force bool) error {
 //
 // Shutdown is idempotent - safe to call multiple times.
 func (o *Orchestrator) Shutdown() error {
+	// Stop escalations first: they take o.mu to record their outcome
+	if o.ladder != nil {
+		o.ladder.Stop()
+	}
+
 	o.mu.Lock()
 	defer o.mu.Unlock()
 
+	// An observer leaves the session to the process running it
+	if o.observing {
+		o.stopObservingLocked()
+		return nil
+	}
+
+	o.stopReaperLocked()
+	o.stopDriftWatchLocked()
+	o.stopDiagnosticsLocked()
+	o.stopAuditLocked()
+	o.stopEventServerLocked()
+	o.stopHookServerLocked()
+	o.stopNotifierLocked()
+	o.stopAPIServerLocked()
+	o.stopJournalLocked()
+	o.stopBudgetEnforcerLocked()
+
 	// Stop namer service
 	if o.namer != nil {
 		o.namer.Stop()
@@ -1223,6 +1617,7 @@ func (o *Orchestrator) Shutdown() error {
 			o.logger.Error("failed to save clean shutdown state",
 				"error", err.Error())
 		}
+		o.removeStatusFile(o.session.ID)
 	}
 
 	if o.lock != nil {
@@ -1236,6 +1631,11 @@ func (o *Orchestrator) Shutdown() error {
 		o.lock = nil
 	}
 
+	// Checkpoint last, so the store holds the clean shutdown state
+	o.stopCheckpointsLocked()
+	o.stopHistoryLocked("", false)
+	o.stopTracingLocked()
+
 	if o.logger != nil {
 		o.logger.Info("orchestrator shutdown complete")
 	}
@@ -1294,7 +1694,64 @@ func (o *Orchestrator) instanceManagerConfig() instance.ManagerConfig {
 		ActivityTimeoutMinutes:   o.config.Instance.ActivityTimeoutMinutes,
 		CompletionTimeoutMinutes: o.config.Instance.CompletionTimeoutMinutes,
 		StaleDetection:           o.config.Instance.StaleDetection,
+		ProcessBackend:           o.config.Instance.Backend,
+		JoinWrappedLines:         instanceOutputFormat(o.config, o.backend) == ai.OutputFormatStreamJSON,
+	}
+}
+
+// instanceOutputFormat returns the output format instances of backend run
+// with (instance.output_format). Only Claude Code emits stream-json; other
+// backends always produce text.
+func instanceOutputFormat(cfg *config.Config, backend ai.Backend) ai.OutputFormat {
+	if cfg == nil || backend == nil || backend.Name() != ai.BackendClaude {
+		return ai.OutputFormatText
+	}
+	if format := ai.OutputFormat(cfg.Instance.OutputFormat); format == ai.OutputFormatStreamJSON {
+		return format
+	}
+	return ai.OutputFormatText
+}
+
+// withOutputFormat adds instance.output_format to the start options of an
+// instance on the session's backend. Options that already pick a format
+// are kept.
+func (o *Orchestrator) withOutputFormat(opts ai.StartOptions) ai.StartOptions {
+	if opts.OutputFormat != "" {
+		return opts
 	}
+	if format := instanceOutputFormat(o.config, o.backend); format == ai.OutputFormatStreamJSON {
+		opts.OutputOnly = true
+		opts.OutputFormat = format
+	}
+	return opts
+}
+
+// LogPath returns the session's debug log, or "" without a session
+// directory. The file exists only when logging is enabled.
+func (o *Orchestrator) LogPath() string {
+	if o.sessionDir == "" {
+		return ""
+	}
+	return filepath.Join(o.sessionDir, logging.LogFileName)
+}
+
+// TranscriptPath returns where an instance's transcript is recorded when
+// instance.record_transcripts is enabled, or "" without a session directory.
+// The file exists only if the instance was recorded.
+func (o *Orchestrator) TranscriptPath(instanceID string) string {
+	if o.sessionDir == "" {
+		return ""
+	}
+	return filepath.Join(o.sessionDir, "transcripts", instanceID+".cast")
+}
+
+// recordedTranscriptPath returns the transcript path for a new instance
+// manager, or "" when recording is disabled.
+func (o *Orchestrator) recordedTranscriptPath(instanceID string) string {
+	if !o.config.Instance.RecordTranscripts {
+		return ""
+	}
+	return o.TranscriptPath(instanceID)
 }
 
 // buildInstanceCallbacks creates the standard callback set that routes instance
@@ -1306,7 +1763,9 @@ func (o *Orchestrator) buildInstanceCallbacks() instance.ManagerCallbacks {
 			switch state {
 			case detect.StateCompleted:
 				o.handleInstanceExit(id)
-			case detect.StateWaitingInput, detect.StateWaitingQuestion, detect.StateWaitingPermission:
+			case detect.StateWaitingPermission:
+				o.handleInstancePermission(id)
+			case detect.StateWaitingInput, detect.StateWaitingQuestion:
 				o.handleInstanceWaitingInput(id)
 			case detect.StatePROpened:
 				o.handleInstancePROpened(id)
@@ -1342,6 +1801,7 @@ func (o *Orchestrator) buildInstanceCallbacks() instance.ManagerCallbacks {
 // ai.StartOptions for no overrides.
 func (o *Orchestrator) newInstanceManager(instanceID, workdir, task, claudeSessionID string, overrides ai.StartOptions) *instance.Manager {
 	cfg := o.instanceManagerConfig()
+	cfg.TranscriptPath = o.recordedTranscriptPath(instanceID)
 
 	mgr := instance.NewManagerWithDeps(instance.ManagerOptions{
 		ID:              instanceID,
@@ -1353,7 +1813,8 @@ func (o *Orchestrator) newInstanceManager(instanceID, workdir, task, claudeSessi
 		StateMonitor:    o.stateMonitor,
 		ClaudeSessionID: claudeSessionID,
 		Backend:         o.backend,
-		StartOverrides:  overrides,
+		StartOverrides:  o.withOutputFormat(overrides),
+		Resources:       o.resources,
 		// LifecycleManager not set - instances use internal Start/Stop/Reconnect
 	})
 
@@ -1365,9 +1826,11 @@ func (o *Orchestrator) newInstanceManager(instanceID, workdir, task, claudeSessi
 
 // newInstanceManagerWithBackend creates an instance manager with a specific backend.
 // This is used for mixed-backend workflows like adversarial sessions where the reviewer
-// may use a different backend than the implementer.
-func (o *Orchestrator) newInstanceManagerWithBackend(instanceID, workdir, task, claudeSessionID, backendName string) *instance.Manager {
+// may use a different backend than the implementer. Role overrides are Claude-specific
+// and do not apply; only env (e.g., a placed node's endpoint) is passed through.
+func (o *Orchestrator) newInstanceManagerWithBackend(instanceID, workdir, task, claudeSessionID, backendName string, env map[string]string) *instance.Manager {
 	cfg := o.instanceManagerConfig()
+	cfg.TranscriptPath = o.recordedTranscriptPath(instanceID)
 
 	// Resolve the requested backend
 	requestedBackend, err := o.resolveBackend(backendName)
@@ -1392,6 +1855,8 @@ func (o *Orchestrator) newInstanceManagerWithBackend(instanceID, workdir, task,
 		StateMonitor:    o.stateMonitor,
 		ClaudeSessionID: claudeSessionID,
 		Backend:         requestedBackend,
+		StartOverrides:  ai.StartOptions{Env: env},
+		Resources:       o.resources,
 		// LifecycleManager not set - instances use internal Start/Stop/Reconnect
 	})
 
@@ -1401,6 +1866,19 @@ func (o *Orchestrator) newInstanceManagerWithBackend(instanceID, workdir, task,
 	return mgr
 }
 
+// instanceBackend returns the backend an instance runs: its own when it
+// selected one, otherwise the session default.
+func (o *Orchestrator) instanceBackend(inst *Instance) ai.Backend {
+	if inst.Backend == "" {
+		return o.backend
+	}
+	backend, err := o.resolveBackend(inst.Backend)
+	if err != nil {
+		return o.backend
+	}
+	return backend
+}
+
 // resolveBackend creates a backend from the given name using the current configuration.
 // Returns the default backend if name is empty.
 func (o *Orchestrator) resolveBackend(name string) (ai.Backend, error) {
@@ -1408,28 +1886,34 @@ func (o *Orchestrator) resolveBackend(name string) (ai.Backend, error) {
 		return o.backend, nil
 	}
 
-	// Create a temporary config with the requested backend to use ai.NewFromConfig
-	tempCfg := *o.config
-	tempCfg.AI.Backend = name
-	return ai.NewFromConfig(&tempCfg)
+	return ai.NewForTask(o.config, name)
 }
 
 // registerInstance performs common registration steps after an instance is created.
 // This includes copying config files, registering with managers, and saving the session.
 // Must be called while holding o.mu lock.
 func (o *Orchestrator) registerInstance(session *Session, inst *Instance) error {
-	// Copy local backend configuration files (e.g., CLAUDE.local.md) to the worktree.
-	// Failures are logged but do not block instance creation since local config is optional.
-	o.copyLocalBackendFilesToWorktree(inst.ID, inst.WorktreePath)
+	// Backend-specific worktree files only apply to instances running the
+	// session's default backend; a tool runner would commit them.
+	if inst.Backend == "" {
+		// Copy local backend configuration files (e.g., CLAUDE.local.md) to the worktree.
+		// Failures are logged but do not block instance creation since local config is optional.
+		o.copyLocalBackendFilesToWorktree(inst.ID, inst.WorktreePath)
 
-	// Write team settings to prevent nested tmux sessions in Claude Code.
-	o.writeWorktreeTeamSettings(inst.ID, inst.WorktreePath)
+		// Write team settings to prevent nested tmux sessions in Claude Code.
+		o.writeWorktreeTeamSettings(inst.ID, inst.WorktreePath)
+	}
 
 	// Add to session
 	session.Instances = append(session.Instances, inst)
 
 	// Create instance manager with config (including backend session ID for resume capability)
-	mgr := o.newInstanceManager(inst.ID, inst.WorktreePath, inst.Task, inst.ClaudeSessionID, ai.StartOptions{})
+	var mgr *instance.Manager
+	if inst.Backend != "" {
+		mgr = o.newInstanceManagerWithBackend(inst.ID, inst.WorktreePath, inst.Task, inst.ClaudeSessionID, inst.Backend, nil)
+	} else {
+		mgr = o.newInstanceManager(inst.ID, inst.WorktreePath, inst.Task, inst.ClaudeSessionID, ai.StartOptions{})
+	}
 	o.instances[inst.ID] = mgr
 
 	// Update shared context
@@ -1493,7 +1977,9 @@ func (o *Orchestrator) wireStateMonitorCallbacks() {
 		switch newState {
 		case detect.StateCompleted:
 			o.handleInstanceExit(instanceID)
-		case detect.StateWaitingInput, detect.StateWaitingQuestion, detect.StateWaitingPermission:
+		case detect.StateWaitingPermission:
+			o.handleInstancePermission(instanceID)
+		case detect.StateWaitingInput, detect.StateWaitingQuestion:
 			o.handleInstanceWaitingInput(instanceID)
 		case detect.StatePROpened:
 			o.handleInstancePROpened(instanceID)
@@ -1518,17 +2004,34 @@ func (o *Orchestrator) wireStateMonitorCallbacks() {
 
 // initBudgetManager creates and configures the budget manager.
 // The orchestrator itself implements InstanceProvider and InstancePauser.
+// Hard limits are enforced per session by the budget enforcer, which
+// publishes budget.exceeded events.
 func (o *Orchestrator) initBudgetManager() {
 	callbacks := budget.Callbacks{
-		OnBudgetLimit: func() {
-			o.executeNotification("notifications.on_budget_limit", nil)
-		},
 		OnBudgetWarning: func() {
 			o.executeNotification("notifications.on_budget_warning", nil)
+			o.publishBudgetWarning()
 		},
 	}
 
 	o.budgetMgr = budget.NewManagerFromConfig(o.config, o, o, callbacks, o.logger)
+	if o.eventBus != nil {
+		o.eventBus.Subscribe("budget.exceeded", o.handleBudgetExceeded)
+	}
+}
+
+// publishBudgetWarning publishes a budget.warning event the first time the
+// session reaches the cost warning threshold. The budget manager reports
+// the threshold on every metrics update past it, which is too often for
+// subscribers such as webhooks.
+func (o *Orchestrator) publishBudgetWarning() {
+	if o.eventBus == nil || o.budgetMgr == nil || !o.budgetWarned.CompareAndSwap(false, true) {
+		return
+	}
+	o.eventBus.Publish(event.NewBudgetWarningEvent(
+		o.config.Resources.CostWarningThreshold,
+		o.budgetMgr.GetSessionMetrics().TotalCost,
+	))
 }
 
 // initNamer initializes the intelligent naming service.
@@ -1647,11 +2150,16 @@ func (o *Orchestrator) handleGroupRenamed(groupID, newName string) {
 
 // saveSession persists the session state to disk
 func (o *Orchestrator) saveSession() error {
-	if o.session == nil {
+	// An observed session's file belongs to the process running it
+	if o.session == nil || o.observing {
 		return nil
 	}
 
+	// Every persisted change is also a change snapshot readers should see
+	o.publishSnapshot()
+
 	sessionFile := o.sessionFilePath()
+	o.session.FormatVersion = migrate.SessionVersion
 	data, err := json.MarshalIndent(o.session, "", "  ")
 	if err != nil {
 		if o.logger != nil {
@@ -1672,6 +2180,8 @@ func (o *Orchestrator) saveSession() error {
 		o.logger.Debug("session saved", "file_path", sessionFile)
 	}
 
+	o.recordHistory(data)
+
 	return nil
 }
 
@@ -1767,6 +2277,37 @@ func (o *Orchestrator) generateBranchName(instanceID, slug string) string {
 	return fmt.Sprintf("%s/%s", prefix, slug)
 }
 
+// TaskBranchName returns the deterministic branch name for one attempt at a
+// plan task: <prefix>/plan-<hash>-<task>, with a -retry<N> suffix for retries.
+// Task IDs that are not already branch-safe get a short hash suffix so two
+// IDs that sanitize alike never share a branch.
+func (o *Orchestrator) TaskBranchName(planHash, taskID string, attempt int) string {
+	ref := branchSafe(taskID)
+	if ref != taskID {
+		sum := sha256.Sum256([]byte(taskID))
+		ref += "-" + hex.EncodeToString(sum[:3])
+	}
+	name := fmt.Sprintf("%s/plan-%s-%s", o.BranchPrefix(), planHash, ref)
+	if attempt > 0 {
+		name += fmt.Sprintf("-retry%d", attempt)
+	}
+	return name
+}
+
+// branchSafe replaces characters that are awkward in git branch names with
+// dashes, keeping letters, digits, '.', '_' and '-'.
+func branchSafe(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '.' || r == '_' || r == '-' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+	return strings.Trim(b.String(), ".-")
+}
+
 // BranchPrefix returns the configured branch prefix for use by other packages
 func (o *Orchestrator) BranchPrefix() string {
 	prefix := o.config.Branch.Prefix
@@ -1805,10 +2346,14 @@ func slugify(text string) string {
 // initializeInstanceSessionID sets or clears the backend session ID for a new instance.
 // For backends that don't support explicit session IDs, this clears any auto-generated ID.
 func (o *Orchestrator) initializeInstanceSessionID(inst *Instance) {
-	if inst == nil || o.backend == nil {
+	if inst == nil {
+		return
+	}
+	backend := o.instanceBackend(inst)
+	if backend == nil {
 		return
 	}
-	if !o.backend.SupportsExplicitSessionID() {
+	if !backend.SupportsExplicitSessionID() {
 		inst.ClaudeSessionID = ""
 		return
 	}
@@ -1819,10 +2364,11 @@ func (o *Orchestrator) initializeInstanceSessionID(inst *Instance) {
 
 // ensureInstanceSessionID ensures an instance has a session ID when the backend supports it.
 func (o *Orchestrator) ensureInstanceSessionID(inst *Instance) {
-	if inst == nil || o.backend == nil {
+	if inst == nil {
 		return
 	}
-	if !o.backend.SupportsExplicitSessionID() {
+	backend := o.instanceBackend(inst)
+	if backend == nil || !backend.SupportsExplicitSessionID() {
 		return
 	}
 	if inst.ClaudeSessionID == "" {
@@ -1857,6 +2403,9 @@ func (o *Orchestrator) copyLocalBackendFilesToWorktree(instID, wtPath string) {
 // nested tmux sessions. When Claudio runs Claude Code inside tmux, Claude Code detects
 // $TMUX and activates tmux-based Agent Teams, creating nested sessions Claudio can't manage.
 // Setting teammateMode to "in-process" forces inline execution instead.
+//
+// It also installs the hooks reporting to the hook server when ai.claude.hooks is set,
+// and removes hooks left by an earlier session when it is not.
 func (o *Orchestrator) writeWorktreeTeamSettings(instID, wtPath string) {
 	if o.backend == nil || o.backend.Name() != ai.BackendClaude {
 		return
@@ -1889,6 +2438,7 @@ func (o *Orchestrator) writeWorktreeTeamSettings(instID, wtPath string) {
 	}
 
 	settings["teammateMode"] = "in-process"
+	hookserver.Install(settings, o.hookURL(instID))
 
 	data, err := json.MarshalIndent(settings, "", "  ")
 	if err != nil {
@@ -2107,20 +2657,32 @@ func (o *Orchestrator) handleInstanceMetrics(id string, m *instmetrics.ParsedMet
 		}
 	}
 
-	// Check budget limits
+	// Publish the update; the budget enforcer checks hard limits from it
+	if o.eventBus != nil {
+		o.eventBus.Publish(event.NewMetricsUpdateEvent(id,
+			inst.Metrics.InputTokens,
+			inst.Metrics.OutputTokens,
+			inst.Metrics.CacheRead,
+			inst.Metrics.CacheWrite,
+			inst.Metrics.Cost,
+			inst.Metrics.APICalls,
+		))
+	}
+
+	// Check the cost warning threshold
 	o.checkBudgetLimits()
 
 	// Save session periodically (not on every metric update to avoid excessive I/O)
 	// The session will be saved when status changes occur
 }
 
-// checkBudgetLimits checks if any budget limits have been exceeded.
-// Delegates to the budget manager for limit checking and enforcement.
+// checkBudgetLimits checks if the cost warning threshold has been reached.
+// Hard limits are enforced by the budget enforcer.
 func (o *Orchestrator) checkBudgetLimits() {
 	if o.budgetMgr == nil || o.session == nil {
 		return
 	}
-	o.budgetMgr.CheckLimits()
+	o.budgetMgr.CheckWarning()
 }
 
 // SessionMetrics holds aggregated metrics for the entire session
@@ -2202,6 +2764,8 @@ func (o *Orchestrator) GetAllInstanceMetrics() []budget.InstanceMetrics {
 // PauseInstance implements budget.InstancePauser.
 // Pauses the instance and updates its status.
 func (o *Orchestrator) PauseInstance(id string) error {
+	o.cancelEscalation(id)
+
 	o.mu.Lock()
 	defer o.mu.Unlock()
 
@@ -2221,6 +2785,7 @@ func (o *Orchestrator) PauseInstance(id string) error {
 			break
 		}
 	}
+	o.publishSnapshot()
 
 	return nil
 }
@@ -2228,10 +2793,14 @@ func (o *Orchestrator) PauseInstance(id string) error {
 // handleInstanceWaitingInput handles when an instance is waiting for input
 func (o *Orchestrator) handleInstanceWaitingInput(id string) {
 	inst := o.GetInstance(id)
-	if inst != nil {
+	// A parked instance waits at its prompt until it is unparked
+	if inst != nil && inst.ParkedAt == nil {
 		inst.Status = StatusWaitingInput
 		_ = o.saveSession()
 		o.executeNotification("notifications.on_waiting_input", inst)
+		if o.eventBus != nil {
+			o.eventBus.Publish(event.NewInstanceWaitingInputEvent(inst.ID, inst.Task))
+		}
 	}
 }
 
@@ -2257,6 +2826,14 @@ func (o *Orchestrator) handleInstanceTimeout(id string, timeoutType instance.Tim
 		return
 	}
 
+	// A parked instance is idle on purpose
+	if inst.ParkedAt != nil {
+		if mgr := o.GetInstanceManager(id); mgr != nil {
+			mgr.ClearTimeout()
+		}
+		return
+	}
+
 	// Log timeout detection at WARN level
 	if o.logger != nil {
 		o.logger.Warn("instance timeout detected",
@@ -2265,6 +2842,19 @@ func (o *Orchestrator) handleInstanceTimeout(id string, timeoutType instance.Tim
 		)
 	}
 
+	// Stalled instances walk the escalation ladder before being marked stuck
+	if o.escalateTimeout(id, timeoutType) {
+		return
+	}
+
+	o.markInstanceTimedOut(inst, timeoutType)
+}
+
+// markInstanceTimedOut marks the instance stuck or timed out, publishes the
+// timeout event, and notifies the timeout callback.
+func (o *Orchestrator) markInstanceTimedOut(inst *Instance, timeoutType instance.TimeoutType) {
+	id := inst.ID
+
 	// Update status based on timeout type
 	switch timeoutType {
 	case instance.TimeoutActivity, instance.TimeoutStale:
@@ -2333,11 +2923,13 @@ func (o *Orchestrator) executeNotification(configKey string, inst *Instance) {
 		return
 	}
 
-	// Replace placeholders
-	cmd = strings.ReplaceAll(cmd, "{id}", inst.ID)
-	cmd = strings.ReplaceAll(cmd, "{task}", inst.Task)
-	cmd = strings.ReplaceAll(cmd, "{branch}", inst.Branch)
-	cmd = strings.ReplaceAll(cmd, "{status}", string(inst.Status))
+	// Replace placeholders (session-wide notifications have no instance)
+	if inst != nil {
+		cmd = strings.ReplaceAll(cmd, "{id}", inst.ID)
+		cmd = strings.ReplaceAll(cmd, "{task}", inst.Task)
+		cmd = strings.ReplaceAll(cmd, "{branch}", inst.Branch)
+		cmd = strings.ReplaceAll(cmd, "{status}", string(inst.Status))
+	}
 
 	// Execute asynchronously to not block
 	go func() {
@@ -2375,6 +2967,66 @@ func (o *Orchestrator) GetInstance(id string) *Instance {
 	return nil
 }
 
+// SetInstanceNode records the worker node an instance was placed on.
+// Returns false if the instance was not found.
+func (o *Orchestrator) SetInstanceNode(id, node string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.session == nil {
+		return false
+	}
+
+	for _, inst := range o.session.Instances {
+		if inst.ID == id {
+			inst.Node = node
+			o.publishSnapshot()
+			return true
+		}
+	}
+	return false
+}
+
+// SetInstanceTask replaces the task label shown for an instance.
+// Returns false if the instance was not found.
+func (o *Orchestrator) SetInstanceTask(id, task string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.session == nil {
+		return false
+	}
+
+	for _, inst := range o.session.Instances {
+		if inst.ID == id {
+			inst.Task = task
+			o.publishSnapshot()
+			return true
+		}
+	}
+	return false
+}
+
+// SetInstanceDependsOn replaces the instance IDs an instance waits for
+// before it starts. Returns false if the instance was not found.
+func (o *Orchestrator) SetInstanceDependsOn(id string, dependsOn []string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.session == nil {
+		return false
+	}
+
+	for _, inst := range o.session.Instances {
+		if inst.ID == id {
+			inst.DependsOn = dependsOn
+			o.publishSnapshot()
+			return true
+		}
+	}
+	return false
+}
+
 // SetInstanceStatus atomically sets the status of an instance by ID.
 // Returns false if the instance was not found.
 func (o *Orchestrator) SetInstanceStatus(id string, status InstanceStatus) bool {
@@ -2388,6 +3040,7 @@ func (o *Orchestrator) SetInstanceStatus(id string, status InstanceStatus) bool
 	for _, inst := range o.session.Instances {
 		if inst.ID == id {
 			inst.Status = status
+			o.publishSnapshot()
 			return true
 		}
 	}
@@ -2399,6 +3052,12 @@ func (o *Orchestrator) GetInstanceDiff(worktreePath string) (string, error) {
 	return o.wt.GetDiffAgainstMain(worktreePath)
 }
 
+// GetUncommittedFiles returns the files with uncommitted changes in an
+// instance's worktree
+func (o *Orchestrator) GetUncommittedFiles(worktreePath string) ([]string, error) {
+	return o.wt.GetUncommittedFiles(worktreePath)
+}
+
 // ListBranches returns all local git branches, sorted with main/master first
 func (o *Orchestrator) ListBranches() ([]worktree.BranchInfo, error) {
 	return o.wt.ListBranches()
@@ -3,12 +3,15 @@
 package orchestrator
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+	"github.com/Iron-Ham/claudio/internal/session/migrate"
 	"github.com/Iron-Ham/claudio/internal/testutil"
 )
 
@@ -175,6 +178,34 @@ func TestOrchestrator_LoadSession(t *testing.T) {
 	if loaded.Name != original.Name {
 		t.Errorf("loaded.Name = %q, want %q", loaded.Name, original.Name)
 	}
+	if loaded.FormatVersion != migrate.SessionVersion || loaded.SentinelProtocol != types.SentinelProtocolVersion {
+		t.Errorf("loaded versions = %d, %d; want the versions this build writes", loaded.FormatVersion, loaded.SentinelProtocol)
+	}
+}
+
+func TestOrchestrator_LoadSessionFromNewerVersion(t *testing.T) {
+	testutil.SkipIfNoGit(t)
+
+	repoDir := testutil.SetupTestRepo(t)
+	orch, err := New(repoDir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := orch.StartSession("test-session"); err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+	newer := fmt.Sprintf(`{"format_version": %d, "id": "future"}`, migrate.SessionVersion+1)
+	if err := os.WriteFile(orch.sessionFilePath(), []byte(newer), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	orch2, err := New(repoDir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := orch2.LoadSession(); !errors.Is(err, migrate.ErrNewerVersion) {
+		t.Errorf("LoadSession() error = %v, want ErrNewerVersion", err)
+	}
 }
 
 func TestOrchestrator_AddInstance(t *testing.T) {
@@ -223,6 +254,101 @@ func TestOrchestrator_AddInstance(t *testing.T) {
 	}
 }
 
+func TestOrchestrator_AddOrReattachInstance(t *testing.T) {
+	testutil.SkipIfNoGit(t)
+
+	repoDir := testutil.SetupTestRepo(t)
+	orch, err := New(repoDir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	session, err := orch.StartSession("test")
+	if err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+
+	branch := orch.TaskBranchName("abc123", "task-1", 0)
+
+	first, reused, err := orch.AddOrReattachInstance(session, "implement task 1", branch, "")
+	if err != nil {
+		t.Fatalf("AddOrReattachInstance() error = %v", err)
+	}
+	if reused {
+		t.Error("first AddOrReattachInstance() reported reuse of a new branch")
+	}
+	if first.Branch != branch {
+		t.Errorf("first.Branch = %q, want %q", first.Branch, branch)
+	}
+
+	// Replaying the task reattaches to the worktree that holds the branch.
+	second, reused, err := orch.AddOrReattachInstance(session, "implement task 1", branch, "")
+	if err != nil {
+		t.Fatalf("AddOrReattachInstance() replay error = %v", err)
+	}
+	if !reused {
+		t.Error("replayed AddOrReattachInstance() did not report reuse")
+	}
+	if second.ID == first.ID {
+		t.Error("replay should create a new instance")
+	}
+	want, _ := filepath.EvalSymlinks(first.WorktreePath)
+	got, _ := filepath.EvalSymlinks(second.WorktreePath)
+	if got != want {
+		t.Errorf("replay WorktreePath = %q, want %q", second.WorktreePath, first.WorktreePath)
+	}
+
+	// With the worktree gone, the existing branch is checked out afresh.
+	if err := orch.wt.Remove(first.WorktreePath); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	third, reused, err := orch.AddOrReattachInstance(session, "implement task 1", branch, "")
+	if err != nil {
+		t.Fatalf("AddOrReattachInstance() after removal error = %v", err)
+	}
+	if !reused {
+		t.Error("AddOrReattachInstance() on an existing branch did not report reuse")
+	}
+	if _, err := os.Stat(third.WorktreePath); err != nil {
+		t.Errorf("reattached worktree missing: %v", err)
+	}
+	if b, _ := orch.wt.GetBranch(third.WorktreePath); b != branch {
+		t.Errorf("reattached worktree branch = %q, want %q", b, branch)
+	}
+}
+
+func TestOrchestrator_AddOrReattachInstanceFromBranch(t *testing.T) {
+	testutil.SkipIfNoGit(t)
+
+	repoDir := testutil.SetupTestRepo(t)
+	testutil.CreateBranch(t, repoDir, "consolidated-group-0")
+	testutil.CommitFile(t, repoDir, "later.txt", "later\n", "commit after the base branch")
+
+	orch, err := New(repoDir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	session, err := orch.StartSession("test")
+	if err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+
+	branch := orch.TaskBranchName("abc123", "task-2", 0)
+	inst, reused, err := orch.AddOrReattachInstanceFromBranch(session, "implement task 2", "consolidated-group-0", branch, "")
+	if err != nil {
+		t.Fatalf("AddOrReattachInstanceFromBranch() error = %v", err)
+	}
+	if reused {
+		t.Error("AddOrReattachInstanceFromBranch() reported reuse of a new branch")
+	}
+	if inst.Branch != branch {
+		t.Errorf("inst.Branch = %q, want %q", inst.Branch, branch)
+	}
+	if _, err := os.Stat(filepath.Join(inst.WorktreePath, "later.txt")); !os.IsNotExist(err) {
+		t.Errorf("worktree has a file committed after its base branch (stat error = %v)", err)
+	}
+}
+
 func TestOrchestrator_AddMultipleInstances(t *testing.T) {
 	testutil.SkipIfNoGit(t)
 
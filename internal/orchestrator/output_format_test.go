@@ -0,0 +1,47 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/ai"
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/instance/detect"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/display"
+)
+
+func TestOrchestrator_WithOutputFormat(t *testing.T) {
+	cfg := config.Default()
+	cfg.Instance.OutputFormat = "stream-json"
+	o := &Orchestrator{
+		config:     cfg,
+		backend:    ai.NewClaudeBackend(config.ClaudeBackendConfig{}),
+		displayMgr: display.NewManager(display.Config{}),
+	}
+
+	opts := o.withOutputFormat(ai.StartOptions{Model: "opus"})
+	if !opts.OutputOnly || opts.OutputFormat != ai.OutputFormatStreamJSON || opts.Model != "opus" {
+		t.Errorf("withOutputFormat() = %+v, want print mode with stream-json", opts)
+	}
+	if !o.instanceManagerConfig().JoinWrappedLines {
+		t.Error("stream-json instances should capture wrapped lines joined")
+	}
+	if _, ok := ai.DetectorFor(o.backend, instanceOutputFormat(cfg, o.backend)).(*detect.JSONDetector); !ok {
+		t.Error("stream-json instances should use the JSON state detector")
+	}
+
+	kept := o.withOutputFormat(ai.StartOptions{OutputFormat: ai.OutputFormatJSON})
+	if kept.OutputFormat != ai.OutputFormatJSON {
+		t.Errorf("withOutputFormat() replaced an explicit format: %+v", kept)
+	}
+
+	o.backend = ai.NewToolRunnerBackend()
+	if opts := o.withOutputFormat(ai.StartOptions{}); opts.OutputOnly || opts.OutputFormat != "" {
+		t.Errorf("withOutputFormat() on a non-Claude backend = %+v, want unchanged", opts)
+	}
+
+	cfg.Instance.OutputFormat = "text"
+	o.backend = ai.NewClaudeBackend(config.ClaudeBackendConfig{})
+	if opts := o.withOutputFormat(ai.StartOptions{}); opts.OutputOnly || opts.OutputFormat != "" {
+		t.Errorf("withOutputFormat() for text = %+v, want unchanged", opts)
+	}
+}
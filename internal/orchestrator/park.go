@@ -0,0 +1,208 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/instance/capture"
+	"github.com/Iron-Ham/claudio/internal/instance/detect"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+)
+
+// resumeScreenLines is how many of the last non-empty screen lines go into
+// a resumed instance's context.
+const resumeScreenLines = 40
+
+// parkPrompt asks a working instance to stop at a safe point and record its
+// progress. It is sent as one line, since a newline would submit it early.
+var parkPrompt = fmt.Sprintf("Pause now: finish the step you are on, but start nothing new. "+
+	"Write a draft of your completion report to `%s` in the format of `%s`, with status \"in_progress\", "+
+	"a summary of what is done, and notes on what is left. Then wait; you will be told when to continue.",
+	types.TaskDraftFileName, types.TaskCompletionFileName)
+
+// resumePrompt tells a parked instance to continue. Like parkPrompt it is
+// one line; the context it refers to is written to the worktree.
+var resumePrompt = fmt.Sprintf("Continue your task. Read `%s` for where you left off before the pause, then carry on from there.",
+	types.ResumeContextFileName)
+
+// ParkInstance pauses a working instance until UnparkInstance: it is asked
+// to stop at a safe point and draft its completion report, and is marked
+// paused. A parked instance is not timed out and, as a plan task, does not
+// count against ultraplan.max_parallel, so another task can run meanwhile.
+func (o *Orchestrator) ParkInstance(id string) error {
+	o.cancelEscalation(id)
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	mgr, ok := o.instances[id]
+	if !ok || !mgr.Running() {
+		return fmt.Errorf("instance %s is not running", id)
+	}
+	inst := o.session.GetInstance(id)
+	if inst == nil {
+		return fmt.Errorf("instance %s not found", id)
+	}
+	if inst.Status != StatusWorking && inst.Status != StatusWaitingInput {
+		return fmt.Errorf("instance %s is %s, not working", id, inst.Status)
+	}
+
+	// A draft from an earlier pause would be taken for this one's
+	if inst.WorktreePath != "" {
+		_ = os.Remove(types.TaskDraftFilePath(inst.WorktreePath))
+	}
+	mgr.SendInput([]byte(parkPrompt + "\r"))
+	mgr.ClearTimeout()
+
+	now := time.Now()
+	inst.Status = StatusPaused
+	inst.ParkedAt = &now
+	if err := o.saveSession(); err != nil {
+		return err
+	}
+
+	if o.logger != nil {
+		o.logger.Info("instance parked", "instance_id", id)
+	}
+	return nil
+}
+
+// UnparkInstance resumes an instance parked with ParkInstance. Where it left
+// off, built from its draft completion report and the end of its screen, is
+// written to types.ResumeContextFileName in its worktree, and it is told to
+// read it and continue.
+func (o *Orchestrator) UnparkInstance(id string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	mgr, ok := o.instances[id]
+	if !ok || !mgr.Running() {
+		return fmt.Errorf("instance %s is not running", id)
+	}
+	inst := o.session.GetInstance(id)
+	if inst == nil {
+		return fmt.Errorf("instance %s not found", id)
+	}
+	if inst.ParkedAt == nil {
+		return fmt.Errorf("instance %s is not parked", id)
+	}
+
+	rc := ResumeContext{
+		Task:     inst.Task,
+		ParkedAt: *inst.ParkedAt,
+		Screen:   lastLines(detect.StripAnsi(string(mgr.GetOutput())), resumeScreenLines),
+	}
+	if rc.Screen == "" {
+		// The output buffer is empty when Claudio restarted since the pause
+		rc.Screen = o.transcriptTail(id)
+	}
+	if inst.WorktreePath != "" {
+		draft, err := types.ParseTaskDraftFile(inst.WorktreePath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) && o.logger != nil {
+			o.logger.Warn("unreadable task draft", "instance_id", id, "error", err)
+		}
+		rc.Draft = draft
+
+		path := filepath.Join(inst.WorktreePath, types.ResumeContextFileName)
+		if err := os.WriteFile(path, []byte(rc.Build()), 0644); err != nil {
+			return fmt.Errorf("failed to write resume context: %w", err)
+		}
+	}
+
+	_ = mgr.Resume()
+	mgr.SendInput([]byte(resumePrompt + "\r"))
+
+	inst.Status = StatusWorking
+	inst.ParkedAt = nil
+	if err := o.saveSession(); err != nil {
+		return err
+	}
+
+	if o.logger != nil {
+		o.logger.Info("instance unparked", "instance_id", id, "has_draft", rc.Draft != nil)
+	}
+	return nil
+}
+
+// transcriptTail returns the last lines of the instance's recorded
+// transcript, or "" when it was not recorded.
+func (o *Orchestrator) transcriptTail(id string) string {
+	path := o.TranscriptPath(id)
+	if path == "" {
+		return ""
+	}
+	t, err := capture.ReadTranscript(path)
+	if err != nil || len(t.Frames) == 0 {
+		return ""
+	}
+	return lastLines(detect.StripAnsi(t.Frames[len(t.Frames)-1].Screen), resumeScreenLines)
+}
+
+// lastLines returns the last n non-empty lines of s.
+func lastLines(s string, n int) string {
+	var lines []string
+	for line := range strings.SplitSeq(s, "\n") {
+		if line = strings.TrimRight(line, " \t\r"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ResumeContext is what an instance resumed after being parked is told
+// about where it left off.
+type ResumeContext struct {
+	Task     string
+	ParkedAt time.Time
+	Draft    *types.TaskCompletionFile // nil when the instance wrote none
+	Screen   string                    // The end of its screen, ANSI codes stripped
+}
+
+// Build renders the context as the Markdown document written for the
+// instance.
+func (c ResumeContext) Build() string {
+	var b strings.Builder
+	b.WriteString("# Where You Left Off\n\n")
+	fmt.Fprintf(&b, "You were paused at %s and have now been resumed.\n\n", c.ParkedAt.Format(time.DateTime))
+	b.WriteString("## Your Task\n\n")
+	b.WriteString(c.Task)
+	b.WriteString("\n\n")
+
+	if d := c.Draft; d != nil {
+		b.WriteString("## Your Draft Report\n\n")
+		if d.Summary != "" {
+			b.WriteString(d.Summary)
+			b.WriteString("\n\n")
+		}
+		if len(d.FilesModified) > 0 {
+			fmt.Fprintf(&b, "Files modified: %s\n\n", strings.Join(d.FilesModified, ", "))
+		}
+		if notes := d.Notes.String(); notes != "" {
+			fmt.Fprintf(&b, "Notes: %s\n\n", notes)
+		}
+		for _, issue := range d.Issues {
+			fmt.Fprintf(&b, "- Issue: %s\n", issue)
+		}
+		if len(d.Issues) > 0 {
+			b.WriteString("\n")
+		}
+	} else {
+		fmt.Fprintf(&b, "You did not write a draft report to `%s` before the pause; check `git status` and `git diff` for what you changed.\n\n", types.TaskDraftFileName)
+	}
+
+	if c.Screen != "" {
+		b.WriteString("## Your Last Output\n\n```\n")
+		b.WriteString(c.Screen)
+		b.WriteString("\n```\n\n")
+	}
+
+	b.WriteString("Continue the task from here. Don't redo finished work; complete it as your task instructs.\n")
+	return b.String()
+}
@@ -0,0 +1,109 @@
+//go:build unix
+
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/ai"
+	"github.com/Iron-Ham/claudio/internal/instance"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+)
+
+func TestParkInstance(t *testing.T) {
+	// The PTY starts the user's shell; keep their startup files out of the test
+	t.Setenv("SHELL", "/bin/sh")
+	worktree := t.TempDir()
+	mgr := instance.NewManagerWithDeps(instance.ManagerOptions{
+		ID:      "inst-1",
+		WorkDir: worktree,
+		Task:    "cat",
+		Backend: ai.NewToolRunnerBackend(),
+		Config: instance.ManagerConfig{
+			CaptureIntervalMs: 20,
+			TmuxWidth:         200,
+			TmuxHeight:        20,
+			ProcessBackend:    instance.ProcessBackendPTY,
+		},
+	})
+	if err := mgr.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = mgr.Stop() })
+
+	inst := &Instance{ID: "inst-1", Task: "Add the login form", WorktreePath: worktree, Status: StatusWorking}
+	o := &Orchestrator{
+		claudioDir: t.TempDir(),
+		session:    &Session{Instances: []*Instance{inst}},
+		instances:  map[string]*instance.Manager{"inst-1": mgr},
+	}
+
+	if err := o.UnparkInstance("inst-1"); err == nil {
+		t.Error("UnparkInstance() of an instance that is not parked should fail")
+	}
+	if err := o.ParkInstance("inst-1"); err != nil {
+		t.Fatalf("ParkInstance() error = %v", err)
+	}
+	if inst.Status != StatusPaused || inst.ParkedAt == nil {
+		t.Fatalf("after ParkInstance status = %s, parked at %v", inst.Status, inst.ParkedAt)
+	}
+	if err := o.ParkInstance("inst-1"); err == nil {
+		t.Error("ParkInstance() of a parked instance should fail")
+	}
+
+	// A parked instance waiting at its prompt stays parked
+	o.handleInstanceWaitingInput("inst-1")
+	if inst.Status != StatusPaused {
+		t.Errorf("status = %s after waiting for input, want paused", inst.Status)
+	}
+
+	// cat echoes the park prompt back onto the screen
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(string(mgr.GetOutput()), "Pause now") {
+		if time.Now().After(deadline) {
+			t.Fatalf("park prompt never sent, output %q", mgr.GetOutput())
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	draft := `{"task_id": "login", "status": "in_progress", "summary": "Form renders; validation is left", "files_modified": ["login.tsx"]}`
+	if err := os.WriteFile(types.TaskDraftFilePath(worktree), []byte(draft), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := o.UnparkInstance("inst-1"); err != nil {
+		t.Fatalf("UnparkInstance() error = %v", err)
+	}
+	if inst.Status != StatusWorking || inst.ParkedAt != nil {
+		t.Errorf("after UnparkInstance status = %s, parked at %v", inst.Status, inst.ParkedAt)
+	}
+	data, err := os.ReadFile(filepath.Join(worktree, types.ResumeContextFileName))
+	if err != nil {
+		t.Fatalf("resume context not written: %v", err)
+	}
+	for _, want := range []string{"Add the login form", "validation is left", "login.tsx", "Pause now"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("resume context has no %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestResumeContext_WithoutDraft(t *testing.T) {
+	doc := ResumeContext{Task: "Fix the bug", ParkedAt: time.Now()}.Build()
+	if !strings.Contains(doc, "Fix the bug") || !strings.Contains(doc, "did not write a draft") {
+		t.Errorf("Build() =\n%s", doc)
+	}
+	if strings.Contains(doc, "Your Last Output") {
+		t.Error("Build() has an output section without a screen")
+	}
+}
+
+func TestLastLines(t *testing.T) {
+	if got := lastLines("a\n\nb  \nc\n\n", 2); got != "b\nc" {
+		t.Errorf("lastLines() = %q, want %q", got, "b\nc")
+	}
+}
@@ -0,0 +1,144 @@
+package orchestrator
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/pr"
+	"github.com/Iron-Ham/claudio/internal/worktree"
+)
+
+// checkPRCLI is pr.Provider.CheckCLI, replaced in tests.
+var checkPRCLI = pr.Provider.CheckCLI
+
+// newPRProvider returns the pull request provider pr.provider selects for
+// the repository's origin remote.
+func newPRProvider(cfg *config.Config, wt *worktree.Manager) (pr.Provider, error) {
+	remote, _ := wt.RemoteURL() // Without an origin, auto-detection picks GitHub
+	provider, err := pr.NewProvider(cfg.PR.Provider, remote)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure PR provider: %w", err)
+	}
+	return provider, nil
+}
+
+// PRProvider returns the provider that creates pull requests for the
+// repository.
+func (o *Orchestrator) PRProvider() pr.Provider {
+	if o.prProvider == nil {
+		return &pr.GitHub{}
+	}
+	return o.prProvider
+}
+
+// RecordPendingPRs adds pull requests that could not be created to the
+// session and persists them. A pending PR for a branch that is already
+// recorded replaces the earlier one.
+func (o *Orchestrator) RecordPendingPRs(pending ...pr.Pending) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.session == nil {
+		return fmt.Errorf("no active session")
+	}
+	for _, p := range pending {
+		o.session.PendingPRs = slices.DeleteFunc(o.session.PendingPRs, func(q pr.Pending) bool {
+			return q.Branch == p.Branch
+		})
+		o.session.PendingPRs = append(o.session.PendingPRs, p)
+	}
+	return o.saveSession()
+}
+
+// PendingPRs returns the session's pull requests that still need creating.
+func (o *Orchestrator) PendingPRs() []pr.Pending {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	if o.session == nil {
+		return nil
+	}
+	return slices.Clone(o.session.PendingPRs)
+}
+
+// ResolvePendingPR removes the pending PR for branch once it has been created
+// at url, and persists the session. A PR skipped by ultra-plan consolidation
+// joins the plan's PR URLs, so merged-branch cleanup watches it.
+func (o *Orchestrator) ResolvePendingPR(branch, url string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.session == nil {
+		return fmt.Errorf("no active session")
+	}
+	i := slices.IndexFunc(o.session.PendingPRs, func(p pr.Pending) bool { return p.Branch == branch })
+	if i < 0 {
+		return fmt.Errorf("no pending PR for branch %s", branch)
+	}
+	p := o.session.PendingPRs[i]
+	o.session.PendingPRs = slices.Delete(o.session.PendingPRs, i, i+1)
+	if up := o.session.UltraPlan; up != nil && p.PlanID != "" && p.PlanID == up.ID {
+		up.PRUrls = append(up.PRUrls, url)
+	}
+	return o.saveSession()
+}
+
+// PRCompareURL returns the forge page that opens a pull request from head
+// into base, or "" when the origin remote is not recognized.
+func (o *Orchestrator) PRCompareURL(base, head string) string {
+	return o.PRProvider().CompareURL(base, head)
+}
+
+// recordSkippedConsolidationPRs records the PRs a consolidation that could
+// not create them reported as pending, logs how to create each one, and returns
+// how many were recorded.
+func recordSkippedConsolidationPRs(c *Coordinator) int {
+	session := c.Session()
+	inst := c.orch.GetInstance(session.ConsolidationID)
+	if inst == nil || inst.WorktreePath == "" {
+		c.logger.Warn("cannot read pending PRs: consolidation instance not found",
+			"instance_id", session.ConsolidationID)
+		return 0
+	}
+	completion, err := ParseConsolidationCompletionFile(inst.WorktreePath)
+	if err != nil {
+		c.logger.Warn("cannot read pending PRs from consolidation completion file", "error", err)
+		return 0
+	}
+
+	now := time.Now()
+	pending := make([]pr.Pending, 0, len(completion.PendingPRs))
+	for _, info := range completion.PendingPRs {
+		if info.Branch == "" {
+			continue
+		}
+		pending = append(pending, pr.Pending{
+			Branch:    info.Branch,
+			Base:      info.Base,
+			Title:     info.Title,
+			Body:      info.Body,
+			Draft:     session.Config.CreateDraftPRs,
+			Labels:    session.Config.PRLabels,
+			PlanID:    session.ID,
+			Reason:    session.Consolidation.PRsSkipReason,
+			SkippedAt: now,
+		})
+	}
+	if len(pending) == 0 {
+		return 0
+	}
+	if err := c.orch.RecordPendingPRs(pending...); err != nil {
+		c.logger.Warn("failed to record pending PRs", "error", err)
+	}
+	for _, p := range pending {
+		c.logger.Info("pull request pending",
+			"branch", p.Branch,
+			"base", p.Base,
+			"compare_url", c.orch.PRCompareURL(p.Base, p.Branch),
+			"command", c.orch.PRProvider().Command(p.Options()),
+		)
+	}
+	return len(pending)
+}
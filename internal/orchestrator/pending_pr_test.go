@@ -0,0 +1,91 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/Iron-Ham/claudio/internal/pr"
+)
+
+func TestOrchestrator_PendingPRs(t *testing.T) {
+	sess := NewSession("pending", "/repo")
+	sess.UltraPlan = &UltraPlanSession{ID: "plan-1"}
+	o := &Orchestrator{
+		claudioDir: t.TempDir(),
+		config:     config.Default(),
+		session:    sess,
+	}
+
+	if err := o.RecordPendingPRs(
+		pr.Pending{Branch: "claudio/group-1", Title: "old", PlanID: "plan-1"},
+		pr.Pending{Branch: "claudio/task", Title: "task", InstanceID: "inst-1"},
+	); err != nil {
+		t.Fatalf("RecordPendingPRs() error = %v", err)
+	}
+	if err := o.RecordPendingPRs(pr.Pending{Branch: "claudio/group-1", Title: "new", PlanID: "plan-1"}); err != nil {
+		t.Fatalf("RecordPendingPRs() error = %v", err)
+	}
+
+	pending := o.PendingPRs()
+	if len(pending) != 2 {
+		t.Fatalf("PendingPRs() = %+v, want 2 entries", pending)
+	}
+	if i := slices.IndexFunc(pending, func(p pr.Pending) bool { return p.Branch == "claudio/group-1" }); i < 0 || pending[i].Title != "new" {
+		t.Errorf("re-recorded branch should replace the earlier entry, got %+v", pending)
+	}
+
+	if err := o.ResolvePendingPR("claudio/group-1", "https://github.com/o/r/pull/1"); err != nil {
+		t.Fatalf("ResolvePendingPR() error = %v", err)
+	}
+	if got := o.PendingPRs(); len(got) != 1 || got[0].Branch != "claudio/task" {
+		t.Errorf("PendingPRs() after resolve = %+v", got)
+	}
+	if !slices.Equal(sess.UltraPlan.PRUrls, []string{"https://github.com/o/r/pull/1"}) {
+		t.Errorf("plan PR URLs = %v, want the created PR", sess.UltraPlan.PRUrls)
+	}
+	if err := o.ResolvePendingPR("claudio/group-1", "x"); err == nil {
+		t.Error("resolving a branch that is not pending should fail")
+	}
+}
+
+func TestCoordinator_CheckPRCapability(t *testing.T) {
+	orig := checkPRCLI
+	t.Cleanup(func() { checkPRCLI = orig })
+	checkPRCLI = func(pr.Provider) error { return fmt.Errorf("%w: install it", pr.ErrCLIMissing) }
+
+	newCoordinator := func(missingCLI string) *Coordinator {
+		cfg := config.Default()
+		cfg.PR.MissingCLI = missingCLI
+		return &Coordinator{
+			manager: &UltraPlanManager{session: &UltraPlanSession{ID: "plan-1", Consolidation: &ConsolidatorState{}}},
+			orch:    &Orchestrator{config: cfg},
+			logger:  logging.NopLogger(),
+		}
+	}
+
+	c := newCoordinator("degrade")
+	if err := c.checkPRCapability(); err != nil {
+		t.Fatalf("checkPRCapability() with degrade = %v, want nil", err)
+	}
+	if state := c.Session().Consolidation; !state.PRsSkipped || state.PRsSkipReason == "" {
+		t.Errorf("consolidation state = %+v, want PR creation marked skipped", state)
+	}
+
+	c = newCoordinator("fail")
+	if err := c.checkPRCapability(); !errors.Is(err, pr.ErrCLIMissing) {
+		t.Errorf("checkPRCapability() with fail = %v, want ErrCLIMissing", err)
+	}
+	if c.Session().Consolidation.PRsSkipped {
+		t.Error("fail mode should not mark PR creation skipped")
+	}
+
+	checkPRCLI = func(pr.Provider) error { return nil }
+	c = newCoordinator("degrade")
+	if err := c.checkPRCapability(); err != nil || c.Session().Consolidation.PRsSkipped {
+		t.Errorf("with gh available: err = %v, skipped = %v", err, c.Session().Consolidation.PRsSkipped)
+	}
+}
@@ -0,0 +1,99 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	"github.com/Iron-Ham/claudio/internal/audit"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/permission"
+)
+
+// initPermissionPolicy compiles instance.permission_policy. The policy stays
+// nil when it is disabled or, since validation rejects invalid rules when
+// the config loads, cannot be compiled; every prompt then waits for the user.
+func (o *Orchestrator) initPermissionPolicy() {
+	pp := o.config.Instance.PermissionPolicy
+	if !pp.Enabled {
+		return
+	}
+	rules := make([]permission.Rule, 0, len(pp.Rules))
+	for _, r := range pp.Rules {
+		rules = append(rules, permission.Rule{
+			Name:     r.Name,
+			Action:   permission.Action(r.Action),
+			Tools:    r.Tools,
+			Commands: r.Commands,
+			Paths:    r.Paths,
+			Prompt:   r.Prompt,
+		})
+	}
+	if policy, err := permission.NewPolicy(rules); err == nil {
+		o.permissionPolicy = policy
+	}
+}
+
+// handleInstancePermission handles an instance asking for permission. A
+// prompt the permission policy allows is approved; any other prompt waits
+// for the user like other input.
+func (o *Orchestrator) handleInstancePermission(id string) {
+	if !o.approvePermission(id) {
+		o.handleInstanceWaitingInput(id)
+	}
+}
+
+// approvePermission evaluates the permission prompt in the instance's
+// output against the permission policy, and sends the configured response
+// if an allow rule matches. It reports whether the prompt was approved.
+func (o *Orchestrator) approvePermission(id string) bool {
+	if o.permissionPolicy == nil {
+		return false
+	}
+	o.mu.RLock()
+	mgr, ok := o.instances[id]
+	o.mu.RUnlock()
+	inst := o.GetInstance(id)
+	if !ok || inst == nil || !mgr.Running() {
+		return false
+	}
+
+	req, found := permission.ParsePrompt(mgr.GetOutput())
+	if !found {
+		return false
+	}
+	req = req.Relative(inst.WorktreePath)
+	decision := o.permissionPolicy.Evaluate(req)
+	if o.logger != nil {
+		o.logger.Info("permission prompt evaluated",
+			"instance_id", id,
+			"tool", req.Tool,
+			"command", req.Command,
+			"path", req.Path,
+			"action", string(decision.Action),
+			"rule", decision.Rule,
+		)
+	}
+	if !decision.Approved() {
+		return false
+	}
+
+	mgr.SendInput([]byte(o.config.Instance.PermissionPolicy.Response))
+	o.RecordOperatorAction(audit.ActionAutoApprove, id, "",
+		fmt.Sprintf("approved %s by permission rule %q", describePermission(req), decision.Rule))
+	return true
+}
+
+// describePermission names what a permission prompt asks for, such as
+// "Bash: go test ./...".
+func describePermission(req permission.Request) string {
+	tool := req.Tool
+	if tool == "" {
+		tool = "permission prompt"
+	}
+	switch {
+	case req.Command != "":
+		return tool + ": " + req.Command
+	case req.Path != "":
+		return tool + ": " + req.Path
+	default:
+		return tool
+	}
+}
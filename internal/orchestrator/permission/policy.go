@@ -0,0 +1,218 @@
+// Package permission decides whether an instance's permission prompt can be
+// answered without the user.
+//
+// A Policy is an ordered list of rules. Each rule allows or denies the
+// prompts it matches, by tool name, shell command glob, file path glob, or a
+// regular expression on the prompt text. Deny rules win over allow rules, and
+// a prompt no rule matches is left to the user, so a policy only ever
+// approves what it was told to.
+package permission
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Action is what a rule does with the prompts it matches.
+type Action string
+
+const (
+	// ActionAllow answers the prompt with approval.
+	ActionAllow Action = "allow"
+	// ActionDeny leaves the prompt to the user even when an allow rule
+	// matches too.
+	ActionDeny Action = "deny"
+)
+
+// ValidActions returns the action names accepted in configuration.
+func ValidActions() []string {
+	return []string{string(ActionAllow), string(ActionDeny)}
+}
+
+// Rule matches permission prompts. A rule matches a prompt when every
+// criterion it sets matches; within a criterion, any one entry matching is
+// enough. A rule must set at least one criterion.
+type Rule struct {
+	// Name identifies the rule in logs and the audit trail.
+	Name string
+	// Action is ActionAllow or ActionDeny.
+	Action Action
+	// Tools are tool names such as "Bash", "Edit", or "WebFetch", compared
+	// case-insensitively.
+	Tools []string
+	// Commands are globs matched against the whole shell command, where *
+	// matches any text, so "go test *" matches every go test run.
+	Commands []string
+	// Paths are globs matched against the file path, relative to the
+	// instance's worktree when inside it. * stays within a directory and
+	// ** spans directories.
+	Paths []string
+	// Prompt is a regular expression matched against the prompt text.
+	Prompt string
+}
+
+// Request is a permission prompt, as parsed by ParsePrompt.
+type Request struct {
+	Tool    string // Tool asking for permission, e.g. "Bash"; empty if unknown
+	Command string // Shell command for Bash prompts
+	Path    string // File the tool reads or writes
+	Text    string // The prompt as shown, one line per row
+}
+
+// Relative returns r with an absolute Path inside dir made relative to dir,
+// so path rules can be written for any worktree.
+func (r Request) Relative(dir string) Request {
+	if dir == "" || !filepath.IsAbs(r.Path) {
+		return r
+	}
+	rel, err := filepath.Rel(dir, r.Path)
+	if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		r.Path = filepath.ToSlash(rel)
+	}
+	return r
+}
+
+// Decision is the outcome of evaluating a request.
+type Decision struct {
+	// Action is the action of the deciding rule, or empty when no rule
+	// matched.
+	Action Action
+	// Rule is the name of the deciding rule.
+	Rule string
+}
+
+// Approved reports whether the prompt should be answered with approval.
+func (d Decision) Approved() bool {
+	return d.Action == ActionAllow
+}
+
+// Policy evaluates permission prompts against a list of rules.
+type Policy struct {
+	rules []compiledRule
+}
+
+type compiledRule struct {
+	Rule
+	commands []*regexp.Regexp
+	paths    []*regexp.Regexp
+	prompt   *regexp.Regexp
+}
+
+// NewPolicy compiles rules into a Policy. It fails on a rule with an
+// unknown action, no criteria, or a pattern that does not compile.
+func NewPolicy(rules []Rule) (*Policy, error) {
+	p := &Policy{rules: make([]compiledRule, 0, len(rules))}
+	for i, r := range rules {
+		c, err := compileRule(r)
+		if err != nil {
+			return nil, fmt.Errorf("permission rule %d (%s): %w", i, r.Name, err)
+		}
+		p.rules = append(p.rules, c)
+	}
+	return p, nil
+}
+
+func compileRule(r Rule) (compiledRule, error) {
+	c := compiledRule{Rule: r}
+	if !slices.Contains(ValidActions(), string(r.Action)) {
+		return c, fmt.Errorf("unknown action %q", r.Action)
+	}
+	if len(r.Tools) == 0 && len(r.Commands) == 0 && len(r.Paths) == 0 && r.Prompt == "" {
+		return c, errors.New("rule matches nothing: set tools, commands, paths, or prompt")
+	}
+	for _, g := range r.Commands {
+		c.commands = append(c.commands, CommandGlob(g))
+	}
+	for _, g := range r.Paths {
+		c.paths = append(c.paths, PathGlob(g))
+	}
+	if r.Prompt != "" {
+		re, err := regexp.Compile(r.Prompt)
+		if err != nil {
+			return c, fmt.Errorf("invalid prompt pattern: %w", err)
+		}
+		c.prompt = re
+	}
+	return c, nil
+}
+
+// Evaluate decides req. The first matching deny rule decides, then the first
+// matching allow rule; with neither, the zero Decision leaves the prompt to
+// the user.
+func (p *Policy) Evaluate(req Request) Decision {
+	if p == nil {
+		return Decision{}
+	}
+	for _, action := range []Action{ActionDeny, ActionAllow} {
+		for _, r := range p.rules {
+			if r.Action == action && r.matches(req) {
+				return Decision{Action: r.Action, Rule: r.Name}
+			}
+		}
+	}
+	return Decision{}
+}
+
+func (r *compiledRule) matches(req Request) bool {
+	if len(r.Tools) > 0 && !slices.ContainsFunc(r.Tools, func(t string) bool {
+		return strings.EqualFold(t, req.Tool)
+	}) {
+		return false
+	}
+	if len(r.commands) > 0 && (req.Command == "" || !matchAny(r.commands, req.Command)) {
+		return false
+	}
+	if len(r.paths) > 0 && (req.Path == "" || !matchAny(r.paths, req.Path)) {
+		return false
+	}
+	return r.prompt == nil || r.prompt.MatchString(req.Text)
+}
+
+func matchAny(patterns []*regexp.Regexp, s string) bool {
+	return slices.ContainsFunc(patterns, func(re *regexp.Regexp) bool {
+		return re.MatchString(s)
+	})
+}
+
+// CommandGlob compiles a command glob, where * matches any text (including
+// spaces and slashes) and ? matches one character.
+func CommandGlob(glob string) *regexp.Regexp {
+	return globRegexp(glob, ".*", ".")
+}
+
+// PathGlob compiles a path glob, where ** matches any text, * matches within
+// one path segment, and ? matches one character other than a slash.
+func PathGlob(glob string) *regexp.Regexp {
+	glob = strings.ReplaceAll(glob, "**/", "\x00")
+	return globRegexp(glob, "[^/]*", "[^/]")
+}
+
+// globRegexp compiles glob, anchored at both ends, with star and question
+// as the expansions of * and ?. A NUL stands for a "**/" prefix of zero or
+// more directories, and a remaining ** matches anything.
+func globRegexp(glob, star, question string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString(`(?s)^`)
+	runes := []rune(glob)
+	for i := 0; i < len(runes); i++ {
+		switch ch := runes[i]; {
+		case ch == 0:
+			b.WriteString(`(?:.*/)?`)
+		case ch == '*' && i+1 < len(runes) && runes[i+1] == '*':
+			b.WriteString(`.*`)
+			i++
+		case ch == '*':
+			b.WriteString(star)
+		case ch == '?':
+			b.WriteString(question)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	b.WriteString(`$`)
+	return regexp.MustCompile(b.String())
+}
@@ -0,0 +1,73 @@
+package permission
+
+import "testing"
+
+func TestPolicy_Evaluate(t *testing.T) {
+	policy, err := NewPolicy([]Rule{
+		{Name: "tests", Action: ActionAllow, Tools: []string{"bash"}, Commands: []string{"go test *", "make test"}},
+		{Name: "source edits", Action: ActionAllow, Tools: []string{"Edit", "Write"}, Paths: []string{"internal/**/*.go"}},
+		{Name: "reads", Action: ActionAllow, Tools: []string{"Read"}},
+		{Name: "secrets", Action: ActionDeny, Paths: []string{"**/.env", "*.pem"}},
+		{Name: "pushes", Action: ActionDeny, Prompt: `git push`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		req  Request
+		want Decision
+	}{
+		{"allowed command", Request{Tool: "Bash", Command: "go test ./..."}, Decision{ActionAllow, "tests"}},
+		{"exact command", Request{Tool: "Bash", Command: "make test"}, Decision{ActionAllow, "tests"}},
+		{"other command", Request{Tool: "Bash", Command: "rm -rf /"}, Decision{}},
+		{"command needed", Request{Tool: "Bash"}, Decision{}},
+		{"nested source file", Request{Tool: "Edit", Path: "internal/tui/app.go"}, Decision{ActionAllow, "source edits"}},
+		{"star stays in a directory", Request{Tool: "Edit", Path: "internal/app.go/x"}, Decision{}},
+		{"file outside glob", Request{Tool: "Write", Path: "go.mod"}, Decision{}},
+		{"deny wins over allow", Request{Tool: "Read", Path: "config/.env"}, Decision{ActionDeny, "secrets"}},
+		{"root-level globstar", Request{Tool: "Read", Path: ".env"}, Decision{ActionDeny, "secrets"}},
+		{"prompt regex", Request{Tool: "Bash", Command: "go test ./... && git push", Text: "go test ./... && git push"}, Decision{ActionDeny, "pushes"}},
+		{"unknown tool", Request{Text: "Do you want to proceed?"}, Decision{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Evaluate(tt.req); got != tt.want {
+				t.Errorf("Evaluate(%+v) = %+v, want %+v", tt.req, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPolicy_InvalidRules(t *testing.T) {
+	for _, r := range []Rule{
+		{Name: "no action", Tools: []string{"Read"}},
+		{Name: "no criteria", Action: ActionAllow},
+		{Name: "bad prompt", Action: ActionDeny, Prompt: "("},
+	} {
+		if _, err := NewPolicy([]Rule{r}); err == nil {
+			t.Errorf("NewPolicy(%+v) succeeded, want an error", r)
+		}
+	}
+}
+
+func TestNilPolicy(t *testing.T) {
+	var p *Policy
+	if d := p.Evaluate(Request{Tool: "Read"}); d.Approved() {
+		t.Error("a nil policy approved a request")
+	}
+}
+
+func TestRequest_Relative(t *testing.T) {
+	tests := []struct{ path, want string }{
+		{"/work/tree/internal/a.go", "internal/a.go"},
+		{"/work/other/a.go", "/work/other/a.go"},
+		{"internal/a.go", "internal/a.go"},
+	}
+	for _, tt := range tests {
+		if got := (Request{Path: tt.path}).Relative("/work/tree").Path; got != tt.want {
+			t.Errorf("Relative(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package permission
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/instance/detect"
+)
+
+// promptWindow is how much of the end of the output ParsePrompt reads. A
+// permission dialog with an edit diff can be long, so it is wider than the
+// detector's window.
+const promptWindow = 8000
+
+// unboxedLines is how many lines above the question a prompt without a
+// dialog box is taken to span.
+const unboxedLines = 6
+
+// dialogTools maps the headers of Claude Code's permission dialogs to the
+// tools they ask about.
+var dialogTools = map[string]string{
+	"bash command": "Bash",
+	"edit file":    "Edit",
+	"create file":  "Write",
+	"write file":   "Write",
+	"read file":    "Read",
+	"fetch":        "WebFetch",
+	"web search":   "WebSearch",
+}
+
+// fileTools are the tools whose prompts name a file.
+var fileTools = map[string]bool{
+	"Read":         true,
+	"Edit":         true,
+	"MultiEdit":    true,
+	"Write":        true,
+	"NotebookEdit": true,
+}
+
+var (
+	questionPatterns = compileAll(detect.PermissionPatterns)
+
+	// toolCallPattern matches a tool call as Claude Code prints it, such as
+	// "Bash(go test ./...)" or "Read(internal/foo.go)".
+	toolCallPattern = regexp.MustCompile(`^(?:⏺\s*)?([A-Za-z][\w-]*)\((.+)\)$`)
+
+	// pathPattern matches a line that is only a file path.
+	pathPattern = regexp.MustCompile(`^[\w.~/-]*[\w-]\.\w+$|^[\w.~-]*/[\w./-]+$`)
+
+	// questionPathPattern takes the file from questions such as "Do you
+	// want to make this edit to foo.go?".
+	questionPathPattern = regexp.MustCompile(`(?i)(?:edit to|create|read|write to|overwrite)\s+(\S+)\?`)
+)
+
+func compileAll(patterns []string) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		compiled = append(compiled, regexp.MustCompile(p))
+	}
+	return compiled
+}
+
+// ParsePrompt finds the last permission prompt in an instance's output and
+// reads the tool, command, and file it asks about. It reports false when the
+// output ends without a prompt. Fields it cannot read are left empty, which
+// only tools, commands, or paths rules then fail to match.
+func ParsePrompt(output []byte) (Request, bool) {
+	text := string(output)
+	if len(text) > promptWindow {
+		text = text[len(text)-promptWindow:]
+	}
+	lines := strings.Split(detect.StripAnsi(text), "\n")
+
+	question := -1
+	for i := len(lines) - 1; i >= 0 && question < 0; i-- {
+		if matchAny(questionPatterns, lines[i]) {
+			question = i
+		}
+	}
+	if question < 0 {
+		return Request{}, false
+	}
+
+	var body []string
+	for _, line := range lines[dialogStart(lines, question):] {
+		if strings.HasPrefix(strings.TrimSpace(line), "╰") {
+			break
+		}
+		if line = cleanLine(line); line != "" {
+			body = append(body, line)
+		}
+	}
+
+	req := Request{Text: strings.Join(body, "\n")}
+	if len(body) > 0 {
+		req.Tool = dialogTools[strings.ToLower(body[0])]
+	}
+	for i, line := range body {
+		if m := toolCallPattern.FindStringSubmatch(line); m != nil && (req.Tool == "" || req.Tool == m[1]) {
+			req.Tool = m[1]
+			switch {
+			case m[1] == "Bash":
+				req.Command = m[2]
+			case fileTools[m[1]] && req.Path == "":
+				req.Path = m[2]
+			}
+			continue
+		}
+		switch {
+		case i == 1 && req.Tool == "Bash":
+			req.Command = line
+		case i > 0 && fileTools[req.Tool] && req.Path == "" && pathPattern.MatchString(line):
+			req.Path = line
+		}
+	}
+	if req.Path == "" && fileTools[req.Tool] {
+		if m := questionPathPattern.FindStringSubmatch(req.Text); m != nil {
+			req.Path = m[1]
+		}
+	}
+	return req, true
+}
+
+// dialogStart returns the first line of the prompt whose question is on
+// line question: the top of its dialog box, or a few lines above it.
+func dialogStart(lines []string, question int) int {
+	for i := question; i >= 0; i-- {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, "╭") {
+			return i
+		}
+		if !strings.HasPrefix(trimmed, "│") {
+			break
+		}
+	}
+	return max(question-unboxedLines, 0)
+}
+
+// cleanLine strips a dialog line of its box borders and padding.
+func cleanLine(line string) string {
+	return strings.TrimSpace(strings.Trim(strings.TrimSpace(line), "│╭╮╰╯─ "))
+}
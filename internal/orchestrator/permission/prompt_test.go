@@ -0,0 +1,89 @@
+package permission
+
+import "testing"
+
+func TestParsePrompt(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   Request
+	}{
+		{
+			name: "bash dialog",
+			output: "⏺ I'll run the tests.\n" +
+				"╭──────────────────────────────────────╮\n" +
+				"│ Bash command                         │\n" +
+				"│                                      │\n" +
+				"│   go test ./internal/...             │\n" +
+				"│   Run the unit tests                 │\n" +
+				"│                                      │\n" +
+				"│ Do you want to proceed?              │\n" +
+				"│ ❯ 1. Yes                             │\n" +
+				"│   2. No, and tell Claude what to do  │\n" +
+				"╰──────────────────────────────────────╯\n",
+			want: Request{Tool: "Bash", Command: "go test ./internal/..."},
+		},
+		{
+			name: "edit dialog",
+			output: "╭───────────────────────────────────────────╮\n" +
+				"│ Edit file                                 │\n" +
+				"│ ╭───────────────────────────────────────╮ │\n" +
+				"│ │ internal/tui/app.go                   │ │\n" +
+				"│ │                                       │ │\n" +
+				"│ │  12 - return nil                      │ │\n" +
+				"│ │  12 + return err                      │ │\n" +
+				"│ ╰───────────────────────────────────────╯ │\n" +
+				"│ Do you want to make this edit to app.go?  │\n" +
+				"│ ❯ 1. Yes                                  │\n" +
+				"╰───────────────────────────────────────────╯\n",
+			want: Request{Tool: "Edit", Path: "internal/tui/app.go"},
+		},
+		{
+			name: "path from the question",
+			output: "╭─────────────────────────────────────────╮\n" +
+				"│ Edit file                               │\n" +
+				"│ Do you want to make this edit to x.md?  │\n" +
+				"╰─────────────────────────────────────────╯\n",
+			want: Request{Tool: "Edit", Path: "x.md"},
+		},
+		{
+			name:   "tool call without a dialog",
+			output: "some output\n⏺ Read(docs/guide.md)\nAllow this action? (y/n)\n",
+			want:   Request{Tool: "Read", Path: "docs/guide.md"},
+		},
+		{
+			name:   "unknown prompt",
+			output: "Overwrite the cache? [Y/n]\n",
+			want:   Request{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParsePrompt([]byte(tt.output))
+			if !ok {
+				t.Fatal("ParsePrompt found no prompt")
+			}
+			if got.Text == "" {
+				t.Error("Text is empty")
+			}
+			got.Text = ""
+			if got != tt.want {
+				t.Errorf("ParsePrompt() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePrompt_NoPrompt(t *testing.T) {
+	if _, ok := ParsePrompt([]byte("⏺ Done. All tests pass.\n> ")); ok {
+		t.Error("ParsePrompt found a prompt in output without one")
+	}
+}
+
+func TestParsePrompt_StripsANSI(t *testing.T) {
+	output := "\x1b[1m│ Bash command\x1b[0m │\n│   \x1b[33mls\x1b[0m │\n│ Do you want to proceed? │\n"
+	got, ok := ParsePrompt([]byte(output))
+	if !ok || got.Command != "ls" {
+		t.Errorf("ParsePrompt() = %+v, %v; want the ls command", got, ok)
+	}
+}
@@ -0,0 +1,80 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/instance"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/permission"
+)
+
+func newPermissionTestOrchestrator(t *testing.T, pp config.PermissionPolicyConfig) *Orchestrator {
+	t.Helper()
+	cfg := config.Default()
+	cfg.Instance.PermissionPolicy = pp
+	o := &Orchestrator{
+		claudioDir: t.TempDir(),
+		config:     cfg,
+		session:    newSnapshotTestSession(),
+		instances:  make(map[string]*instance.Manager),
+		eventBus:   event.NewBus(),
+	}
+	o.initPermissionPolicy()
+	return o
+}
+
+func TestInitPermissionPolicy(t *testing.T) {
+	rules := []config.PermissionRuleConfig{{Name: "reads", Action: "allow", Tools: []string{"Read"}}}
+
+	if o := newPermissionTestOrchestrator(t, config.PermissionPolicyConfig{Rules: rules}); o.permissionPolicy != nil {
+		t.Error("policy compiled while disabled")
+	}
+	o := newPermissionTestOrchestrator(t, config.PermissionPolicyConfig{Enabled: true, Response: "y", Rules: rules})
+	if o.permissionPolicy == nil {
+		t.Fatal("policy not compiled while enabled")
+	}
+	if d := o.permissionPolicy.Evaluate(permission.Request{Tool: "Read"}); !d.Approved() || d.Rule != "reads" {
+		t.Errorf("Evaluate() = %+v, want approval by the reads rule", d)
+	}
+}
+
+func TestHandleInstancePermission_SurfacesWithoutRunningInstance(t *testing.T) {
+	o := newPermissionTestOrchestrator(t, config.PermissionPolicyConfig{
+		Enabled:  true,
+		Response: "y",
+		Rules:    []config.PermissionRuleConfig{{Name: "all", Action: "allow", Prompt: "."}},
+	})
+	var waiting []string
+	o.eventBus.Subscribe("instance.waiting_input", func(e event.Event) {
+		if w, ok := e.(event.InstanceWaitingInputEvent); ok {
+			waiting = append(waiting, w.InstanceID)
+		}
+	})
+
+	// inst-1 has no running manager, so nothing can be sent to it
+	o.handleInstancePermission("inst-1")
+
+	if got := o.GetInstance("inst-1").Status; got != StatusWaitingInput {
+		t.Errorf("Status = %s, want %s", got, StatusWaitingInput)
+	}
+	if len(waiting) != 1 {
+		t.Errorf("published %d waiting events, want 1", len(waiting))
+	}
+}
+
+func TestDescribePermission(t *testing.T) {
+	tests := []struct {
+		req  permission.Request
+		want string
+	}{
+		{permission.Request{Tool: "Bash", Command: "go test ./..."}, "Bash: go test ./..."},
+		{permission.Request{Tool: "Edit", Path: "main.go"}, "Edit: main.go"},
+		{permission.Request{}, "permission prompt"},
+	}
+	for _, tt := range tests {
+		if got := describePermission(tt.req); got != tt.want {
+			t.Errorf("describePermission(%+v) = %q, want %q", tt.req, got, tt.want)
+		}
+	}
+}
@@ -9,7 +9,11 @@ import (
 	"time"
 
 	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/contextpack"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/prompt"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+	"github.com/Iron-Ham/claudio/internal/tracing"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // TaskCompletionFileName is the sentinel file that tasks write to signal completion.
@@ -643,11 +647,15 @@ func (e *ExecutionOrchestrator) executionLoop() {
 			}
 
 			// Check if we can start more tasks (MaxParallel <= 0 means unlimited)
-			if maxParallel <= 0 || runningCount < maxParallel {
+			parked := 0
+			if maxParallel > 0 {
+				parked = e.parkedTaskCount()
+			}
+			if maxParallel <= 0 || runningCount-parked < maxParallel {
 				readyTasks := session.GetReadyTasks()
 				for _, taskID := range readyTasks {
 					e.mu.RLock()
-					currentRunning := e.state.RunningCount
+					currentRunning := e.state.RunningCount - parked
 					e.mu.RUnlock()
 
 					if maxParallel > 0 && currentRunning >= maxParallel {
@@ -659,6 +667,11 @@ func (e *ExecutionOrchestrator) executionLoop() {
 						continue
 					}
 
+					// Skip failed tasks still waiting out their retry backoff
+					if e.retryWait(taskID) > 0 {
+						continue
+					}
+
 					if err := e.startTask(taskID); err != nil {
 						e.notifyTaskFailed(taskID, err.Error())
 					}
@@ -671,8 +684,13 @@ func (e *ExecutionOrchestrator) executionLoop() {
 	}
 }
 
-// startTask starts a single task as a new instance.
-func (e *ExecutionOrchestrator) startTask(taskID string) error {
+// startTask starts a single task as a new instance. Its span covers creating
+// the worktree and launching the instance, not the task's run.
+func (e *ExecutionOrchestrator) startTask(taskID string) (err error) {
+	_, span := tracing.Tracer().Start(e.spanContext(), "execution.start_task",
+		trace.WithAttributes(tracing.TaskID.String(taskID)))
+	defer func() { tracing.End(span, err) }()
+
 	session := e.phaseCtx.Session
 	task := session.GetTask(taskID)
 	if task == nil {
@@ -692,10 +710,14 @@ func (e *ExecutionOrchestrator) startTask(taskID string) error {
 		baseBranch = e.execCtx.Coordinator.GetBaseBranchForGroup(currentGroup)
 	}
 
-	// Create a new instance for this task
+	// Create a new instance for this task, on the task's deterministic
+	// branch when the orchestrator names them
 	var inst any
-	var err error
-	if baseBranch != "" && e.execCtx != nil && e.execCtx.ExecutionOrchestrator != nil {
+	if adder, ok := e.phaseCtx.Orchestrator.(interface {
+		AddTaskInstance(taskID, task, baseBranch string) (any, error)
+	}); ok {
+		inst, err = adder.AddTaskInstance(taskID, prompt, baseBranch)
+	} else if baseBranch != "" && e.execCtx != nil && e.execCtx.ExecutionOrchestrator != nil {
 		// Use the consolidated branch from the previous group as the base
 		inst, err = e.execCtx.ExecutionOrchestrator.AddInstanceFromBranch(nil, prompt, baseBranch)
 	} else {
@@ -714,6 +736,9 @@ func (e *ExecutionOrchestrator) startTask(taskID string) error {
 	// Get instance ID
 	instanceID := e.getInstanceID(inst)
 
+	// Copy the task's context pack into its worktree before it starts
+	e.writeContextPack(taskID, task, inst)
+
 	// Record the task-to-instance mapping BEFORE adding to the group.
 	// addInstanceToSubgroup calls determineSubgroupType which checks
 	// TaskToInstance to route execution instances to the correct "Group N"
@@ -742,7 +767,7 @@ func (e *ExecutionOrchestrator) startTask(taskID string) error {
 	}
 
 	// Start the instance
-	if err := e.phaseCtx.Orchestrator.StartInstance(inst); err != nil {
+	if err := e.startTaskInstance(taskID, inst); err != nil {
 		e.mu.Lock()
 		delete(e.state.RunningTasks, taskID)
 		e.state.RunningCount--
@@ -770,10 +795,111 @@ func (e *ExecutionOrchestrator) startTask(taskID string) error {
 	return nil
 }
 
+// spanContext returns the execution context for starting spans. It carries
+// the coordinator's phase span, so spans nest under the execution phase.
+func (e *ExecutionOrchestrator) spanContext() context.Context {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.ctx == nil {
+		return context.Background()
+	}
+	return e.ctx
+}
+
+// writeContextPack writes the task's context pack, if it declares one, into
+// the instance's worktree. A failure is logged and the task starts anyway:
+// the pack only saves it from finding the code itself.
+func (e *ExecutionOrchestrator) writeContextPack(taskID string, task, inst any) {
+	withPack, ok := task.(interface{ GetContextPack() *types.ContextPack })
+	if !ok || withPack.GetContextPack().IsEmpty() {
+		return
+	}
+	worktreePath := e.getInstanceWorktreePath(inst)
+	if worktreePath == "" {
+		return
+	}
+	missing, err := contextpack.Write(worktreePath, withPack.GetContextPack())
+	if err != nil {
+		e.logger.Warn("failed to write context pack", "task_id", taskID, "error", err)
+		return
+	}
+	if missing > 0 {
+		e.logger.Warn("context pack has missing entries", "task_id", taskID, "missing", missing)
+	}
+}
+
+// startTaskInstance starts a task's instance with the plan's environment
+// exported, when the orchestrator supports it, on the model selected for the
+// task (ultraplan.models, or ultraplan.retry.final_attempt_model for its last
+// retry) when one is set.
+func (e *ExecutionOrchestrator) startTaskInstance(taskID string, inst any) error {
+	env := e.getPlanEnv()
+	if model := e.taskModel(taskID); model != "" {
+		if starter, ok := e.phaseCtx.Orchestrator.(interface {
+			StartInstanceWithModel(inst any, env map[string]string, model string) error
+		}); ok {
+			e.logger.Info("starting task on selected model", "task_id", taskID, "model", model)
+			return starter.StartInstanceWithModel(inst, env, model)
+		}
+	}
+	if starter, ok := e.phaseCtx.Orchestrator.(interface {
+		StartInstanceWithEnv(inst any, env map[string]string) error
+	}); ok && len(env) > 0 {
+		return starter.StartInstanceWithEnv(inst, env)
+	}
+	return e.phaseCtx.Orchestrator.StartInstance(inst)
+}
+
+// taskModel returns the model the task's next attempt runs on, or "" for
+// the backend's configured model.
+func (e *ExecutionOrchestrator) taskModel(taskID string) string {
+	if getter, ok := e.phaseCtx.Session.(interface{ GetTaskModel(taskID string) string }); ok {
+		return getter.GetTaskModel(taskID)
+	}
+	return ""
+}
+
+// retryWait returns how long a failed task must still wait before its next
+// attempt (ultraplan.retry.backoff_seconds), or 0 when it may start now.
+func (e *ExecutionOrchestrator) retryWait(taskID string) time.Duration {
+	if getter, ok := e.phaseCtx.Session.(interface {
+		GetRetryWait(taskID string) time.Duration
+	}); ok {
+		return getter.GetRetryWait(taskID)
+	}
+	return 0
+}
+
+// getPlanEnv returns the plan's environment variables from the session.
+// Returns nil if not available.
+func (e *ExecutionOrchestrator) getPlanEnv() map[string]string {
+	if getter, ok := e.phaseCtx.Session.(interface{ GetPlanEnv() map[string]string }); ok {
+		return getter.GetPlanEnv()
+	}
+	return nil
+}
+
 // buildTaskPrompt creates the prompt for a child task instance.
 // It delegates to prompt.TaskBuilder for the actual prompt generation,
 // after converting the task data to the prompt package's types.
 func (e *ExecutionOrchestrator) buildTaskPrompt(taskID string, task any) string {
+	return e.taskPrompt(e.execCtx, taskID, task)
+}
+
+// PreviewTaskPrompt returns the prompt taskID's instance would be started
+// with under execCtx, without starting anything. Context from the previous
+// group's consolidation is included only once that consolidation exists.
+func (e *ExecutionOrchestrator) PreviewTaskPrompt(execCtx *ExecutionContext, taskID string) (string, error) {
+	task := e.phaseCtx.Session.GetTask(taskID)
+	if task == nil {
+		return "", fmt.Errorf("task %s not found", taskID)
+	}
+	return e.taskPrompt(execCtx, taskID, task), nil
+}
+
+// taskPrompt builds the prompt for task using execCtx for the plan summary,
+// group index, and previous group context.
+func (e *ExecutionOrchestrator) taskPrompt(execCtx *ExecutionContext, taskID string, task any) string {
 	// Try to use the task as PlannedTaskData
 	taskData, ok := task.(PlannedTaskData)
 	if !ok {
@@ -787,14 +913,14 @@ func (e *ExecutionOrchestrator) buildTaskPrompt(taskID string, task any) string
 
 	// Get plan summary
 	planSummary := "Ultra-Plan Task"
-	if e.execCtx != nil && e.execCtx.ExecutionSession != nil {
-		planSummary = e.execCtx.ExecutionSession.GetPlanSummary()
+	if execCtx != nil && execCtx.ExecutionSession != nil {
+		planSummary = execCtx.ExecutionSession.GetPlanSummary()
 	}
 
 	// Determine group index for this task
 	groupIndex := 0
-	if e.execCtx != nil && e.execCtx.Coordinator != nil {
-		groupIndex = e.execCtx.Coordinator.GetTaskGroupIndex(taskID)
+	if execCtx != nil && execCtx.Coordinator != nil {
+		groupIndex = execCtx.Coordinator.GetTaskGroupIndex(taskID)
 	}
 
 	// Build prompt context
@@ -802,15 +928,16 @@ func (e *ExecutionOrchestrator) buildTaskPrompt(taskID string, task any) string
 		Phase: prompt.PhaseTask,
 		Plan: &prompt.PlanInfo{
 			Summary: planSummary,
+			Env:     e.getPlanEnv(),
 		},
 		Task:       convertPlannedTaskDataToTaskInfo(taskData),
 		GroupIndex: groupIndex,
 	}
 
 	// Add previous group context if this task is not in group 0
-	if groupIndex > 0 && e.execCtx != nil && e.execCtx.ExecutionSession != nil {
+	if groupIndex > 0 && execCtx != nil && execCtx.ExecutionSession != nil {
 		prevGroupIdx := groupIndex - 1
-		prevContext := e.execCtx.ExecutionSession.GetGroupConsolidationContext(prevGroupIdx)
+		prevContext := execCtx.ExecutionSession.GetGroupConsolidationContext(prevGroupIdx)
 		if prevContext != nil {
 			ctx.PreviousGroup = convertGroupConsolidationContextToGroupContext(prevContext, prevGroupIdx)
 		}
@@ -829,6 +956,34 @@ func (e *ExecutionOrchestrator) buildTaskPrompt(taskID string, task any) string
 		return fmt.Sprintf("# Task: %s\n\n%s", taskData.GetTitle(), taskData.GetDescription())
 	}
 
+	// Changes that landed on the base branch after planning
+	if getter, ok := e.phaseCtx.Session.(interface{ GetBaseDriftSection(taskID string) string }); ok {
+		if section := getter.GetBaseDriftSection(taskID); section != "" {
+			result += "\n\n" + section
+		}
+	}
+
+	// How the previous attempt failed, for retries (ultraplan.retry.augment_prompt)
+	if getter, ok := e.phaseCtx.Session.(interface{ GetRetrySection(taskID string) string }); ok {
+		if section := getter.GetRetrySection(taskID); section != "" {
+			result += "\n\n" + section
+		}
+	}
+
+	// Where an earlier attempt got to (ultraplan.progress_summary_minutes)
+	if getter, ok := e.phaseCtx.Session.(interface{ GetProgressSection(taskID string) string }); ok {
+		if section := getter.GetProgressSection(taskID); section != "" {
+			result += "\n\n" + section
+		}
+	}
+
+	// Self-review before completing (ultraplan.self_review)
+	if getter, ok := e.phaseCtx.Session.(interface{ GetSelfReviewSection() string }); ok {
+		if section := getter.GetSelfReviewSection(); section != "" {
+			result += "\n\n" + section
+		}
+	}
+
 	return result
 }
 
@@ -847,12 +1002,21 @@ func convertPlannedTaskDataToTaskInfo(task PlannedTaskData) *prompt.TaskInfo {
 		copy(filesCopy, files)
 	}
 
-	return &prompt.TaskInfo{
+	info := &prompt.TaskInfo{
 		ID:          task.GetID(),
 		Title:       task.GetTitle(),
 		Description: task.GetDescription(),
 		Files:       filesCopy,
 	}
+	if withCriteria, ok := task.(interface {
+		GetCriteria() *types.CompletionCriteria
+	}); ok {
+		info.Criteria = withCriteria.GetCriteria()
+	}
+	if withPack, ok := task.(interface{ GetContextPack() *types.ContextPack }); ok {
+		info.ContextPack = withPack.GetContextPack()
+	}
+	return info
 }
 
 // convertGroupConsolidationContextToGroupContext converts a GroupConsolidationContextData
@@ -901,19 +1065,7 @@ func (e *ExecutionOrchestrator) monitorTaskInstance(taskID, instanceID string) {
 			return
 
 		case <-ticker.C:
-			// Get the instance - try multiple methods
-			var inst any
-			if e.execCtx != nil && e.execCtx.ExecutionOrchestrator != nil {
-				inst = e.execCtx.ExecutionOrchestrator.GetInstanceByID(instanceID)
-			}
-			if inst == nil && e.phaseCtx.Orchestrator != nil {
-				// Try the base orchestrator interface
-				instIface := e.phaseCtx.Orchestrator.GetInstance(instanceID)
-				if instIface != nil {
-					inst = instIface
-				}
-			}
-
+			inst := e.lookupInstance(instanceID)
 			if inst == nil {
 				e.logger.Debug("instance status check",
 					"task_id", taskID,
@@ -991,6 +1143,43 @@ func (e *ExecutionOrchestrator) monitorTaskInstance(taskID, instanceID string) {
 	}
 }
 
+// lookupInstance returns the instance with the given ID, trying the
+// extended orchestrator interface before the base one, or nil.
+func (e *ExecutionOrchestrator) lookupInstance(instanceID string) any {
+	if e.execCtx != nil && e.execCtx.ExecutionOrchestrator != nil {
+		if inst := e.execCtx.ExecutionOrchestrator.GetInstanceByID(instanceID); inst != nil {
+			return inst
+		}
+	}
+	if e.phaseCtx.Orchestrator != nil {
+		// Try the base orchestrator interface
+		if inst := e.phaseCtx.Orchestrator.GetInstance(instanceID); inst != nil {
+			return inst
+		}
+	}
+	return nil
+}
+
+// parkedTaskCount returns how many running tasks have their instance
+// paused. Parked instances don't count against MaxParallel, so other tasks
+// can run while they wait.
+func (e *ExecutionOrchestrator) parkedTaskCount() int {
+	e.mu.RLock()
+	instanceIDs := make([]string, 0, len(e.state.RunningTasks))
+	for _, instanceID := range e.state.RunningTasks {
+		instanceIDs = append(instanceIDs, instanceID)
+	}
+	e.mu.RUnlock()
+
+	parked := 0
+	for _, instanceID := range instanceIDs {
+		if e.getInstanceStatus(e.lookupInstance(instanceID)) == StatusPaused {
+			parked++
+		}
+	}
+	return parked
+}
+
 // handleTaskCompletion processes a task completion notification.
 // This method implements duplicate detection, retry handling, and group advancement.
 //
@@ -1129,7 +1318,11 @@ func (e *ExecutionOrchestrator) checkAndAdvanceGroup() {
 	// Start the group consolidator backend session
 	// This blocks until the consolidator completes (writes completion file)
 	if e.execCtx.Coordinator != nil {
-		if err := e.execCtx.Coordinator.StartGroupConsolidation(currentGroup); err != nil {
+		_, span := tracing.Tracer().Start(e.spanContext(), "execution.consolidate_group",
+			trace.WithAttributes(tracing.Group.Int(currentGroup)))
+		err := e.execCtx.Coordinator.StartGroupConsolidation(currentGroup)
+		tracing.End(span, err)
+		if err != nil {
 			e.logger.Error("consolidation failed",
 				"group_index", currentGroup,
 				"error", err.Error(),
@@ -1146,6 +1339,11 @@ func (e *ExecutionOrchestrator) checkAndAdvanceGroup() {
 		}
 	}
 
+	// With ultraplan.group_approval, the next group waits for approval
+	if gate, ok := e.execCtx.Coordinator.(interface{ AwaitGroupApproval(groupIndex int) }); ok {
+		gate.AwaitGroupApproval(currentGroup)
+	}
+
 	// Advance to the next group - only after consolidation succeeds
 	nextGroup, _ := e.execCtx.GroupTracker.AdvanceGroup(currentGroup)
 
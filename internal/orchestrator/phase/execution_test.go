@@ -813,6 +813,121 @@ func TestExecutionOrchestrator_BuildTaskPrompt(t *testing.T) {
 	})
 }
 
+// envSession is a session whose plan exports environment variables.
+type envSession struct {
+	mockSession
+	env map[string]string
+}
+
+func (s *envSession) GetPlanEnv() map[string]string { return s.env }
+
+// envOrchestrator records the environment instances are started with.
+type envOrchestrator struct {
+	mockOrchestrator
+	startedWithEnv map[string]string
+}
+
+func (o *envOrchestrator) StartInstanceWithEnv(inst any, env map[string]string) error {
+	o.startedWithEnv = env
+	return nil
+}
+
+func TestExecutionOrchestrator_PlanEnv(t *testing.T) {
+	env := map[string]string{"FEATURE_FLAG": "new-checkout", "API_VERSION": "v3"}
+	orch := &envOrchestrator{}
+	exec, err := NewExecutionOrchestrator(&PhaseContext{
+		Manager:      &mockManager{},
+		Orchestrator: orch,
+		Session:      &envSession{env: env},
+	})
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+
+	prompt := exec.buildTaskPrompt("task-1", &mockPlannedTask{id: "task-1", title: "Flag the checkout"})
+	if !contains(prompt, "## Plan Environment") || !contains(prompt, "`FEATURE_FLAG` = `new-checkout`") {
+		t.Errorf("prompt does not describe the plan environment:\n%s", prompt)
+	}
+
+	if err := exec.startTaskInstance("task-1", &mockInstance{}); err != nil {
+		t.Fatalf("startTaskInstance() error = %v", err)
+	}
+	if orch.startedWithEnv["API_VERSION"] != "v3" {
+		t.Errorf("instance started with env %v, want the plan env", orch.startedWithEnv)
+	}
+
+	// Without plan env the plain StartInstance is used
+	orch.startedWithEnv = nil
+	exec.phaseCtx.Session = &mockSession{}
+	if err := exec.startTaskInstance("task-1", &mockInstance{}); err != nil {
+		t.Fatalf("startTaskInstance() error = %v", err)
+	}
+	if orch.startedWithEnv != nil {
+		t.Errorf("instance started with env %v, want none", orch.startedWithEnv)
+	}
+}
+
+// retrySession is a session whose tasks are being retried.
+type retrySession struct {
+	mockSession
+	section string
+	model   string
+	wait    time.Duration
+}
+
+func (s *retrySession) GetRetrySection(string) string     { return s.section }
+func (s *retrySession) GetTaskModel(string) string        { return s.model }
+func (s *retrySession) GetRetryWait(string) time.Duration { return s.wait }
+
+// modelOrchestrator records the model instances are started with.
+type modelOrchestrator struct {
+	mockOrchestrator
+	startedWithModel string
+}
+
+func (o *modelOrchestrator) StartInstanceWithModel(inst any, env map[string]string, model string) error {
+	o.startedWithModel = model
+	return nil
+}
+
+func TestExecutionOrchestrator_Retry(t *testing.T) {
+	orch := &modelOrchestrator{}
+	session := &retrySession{section: "## Previous Attempt\n\nThis is attempt 2 of 4.", model: "opus", wait: time.Minute}
+	exec, err := NewExecutionOrchestrator(&PhaseContext{
+		Manager:      &mockManager{},
+		Orchestrator: orch,
+		Session:      session,
+	})
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+
+	prompt := exec.buildTaskPrompt("task-1", &mockPlannedTask{id: "task-1", title: "Retry me"})
+	if !contains(prompt, "## Previous Attempt") {
+		t.Errorf("prompt does not describe the previous attempt:\n%s", prompt)
+	}
+	if got := exec.retryWait("task-1"); got != time.Minute {
+		t.Errorf("retryWait() = %v, want %v", got, time.Minute)
+	}
+
+	if err := exec.startTaskInstance("task-1", &mockInstance{}); err != nil {
+		t.Fatalf("startTaskInstance() error = %v", err)
+	}
+	if orch.startedWithModel != "opus" {
+		t.Errorf("instance started with model %q, want opus", orch.startedWithModel)
+	}
+
+	// Without a retry model the usual start is used
+	orch.startedWithModel = ""
+	session.model = ""
+	if err := exec.startTaskInstance("task-1", &mockInstance{}); err != nil {
+		t.Fatalf("startTaskInstance() error = %v", err)
+	}
+	if orch.startedWithModel != "" {
+		t.Errorf("instance started with model %q, want the usual one", orch.startedWithModel)
+	}
+}
+
 func TestExecutionOrchestrator_BuildTaskPromptWithContext(t *testing.T) {
 	execSession := newMockExecutionSession()
 	execSession.planSummary = "Major Refactoring Project"
@@ -3037,6 +3152,71 @@ func TestExecutionOrchestrator_StartTask(t *testing.T) {
 		}
 		coord.mu.Unlock()
 	})
+
+	t.Run("creates the task instance through AddTaskInstance when supported", func(t *testing.T) {
+		task := &mockPlannedTask{
+			id:    "task-1",
+			title: "Test Task",
+		}
+		session := &mockSession{
+			tasks: map[string]any{"task-1": task},
+		}
+		execSession := newMockExecutionSession()
+		execSession.currentGroup = 1
+
+		coord := newMockExecutionCoordinator()
+		coord.baseBranches[1] = "consolidated-group-0"
+
+		orch := &mockTaskInstanceOrchestrator{mockOrchestratorForStartTask: newMockOrchestratorForStartTask()}
+
+		exec, err := NewExecutionOrchestratorWithContext(&ExecutionContext{
+			PhaseContext: &PhaseContext{
+				Manager:      &mockManager{},
+				Orchestrator: orch,
+				Session:      session,
+			},
+			Coordinator:      coord,
+			ExecutionSession: execSession,
+		})
+		if err != nil {
+			t.Fatalf("failed to create orchestrator: %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		exec.mu.Lock()
+		exec.ctx = ctx
+		exec.mu.Unlock()
+
+		if err := exec.startTask("task-1"); err != nil {
+			t.Errorf("startTask() error = %v", err)
+		}
+		cancel()
+		exec.wg.Wait()
+
+		orch.mu.Lock()
+		defer orch.mu.Unlock()
+		if len(orch.taskInstances) != 1 || orch.taskInstances[0] != "task-1@consolidated-group-0" {
+			t.Errorf("AddTaskInstance calls = %v, want [task-1@consolidated-group-0]", orch.taskInstances)
+		}
+		if len(orch.addedInstances) != 0 {
+			t.Errorf("AddInstance called %d times, want 0", len(orch.addedInstances))
+		}
+	})
+}
+
+// mockTaskInstanceOrchestrator records AddTaskInstance calls as
+// "taskID@baseBranch".
+type mockTaskInstanceOrchestrator struct {
+	*mockOrchestratorForStartTask
+	taskInstances []string
+}
+
+func (m *mockTaskInstanceOrchestrator) AddTaskInstance(taskID, task, baseBranch string) (any, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.taskInstances = append(m.taskInstances, taskID+"@"+baseBranch)
+	return &mockInstance{id: "inst-" + taskID, worktreePath: "/tmp/worktree"}, nil
 }
 
 func TestExecutionOrchestrator_IsTaskRunning(t *testing.T) {
@@ -4099,3 +4279,30 @@ func init() {
 	// Ensure mockGroupTracker implements GroupTrackerInterface
 	var _ GroupTrackerInterface = (*mockGroupTracker)(nil)
 }
+
+func TestExecutionOrchestrator_ParkedTaskCount(t *testing.T) {
+	execOrch := newMockExecutionOrchestrator()
+	execOrch.instances["inst-1"] = &mockInstance{id: "inst-1", status: StatusRunning}
+	execOrch.instances["inst-2"] = &mockInstance{id: "inst-2", status: StatusPaused}
+	exec, err := NewExecutionOrchestratorWithContext(&ExecutionContext{
+		PhaseContext: &PhaseContext{
+			Manager:      &mockManager{},
+			Orchestrator: &mockOrchestrator{},
+			Session:      &mockSession{},
+		},
+		ExecutionOrchestrator: execOrch,
+	})
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+
+	exec.mu.Lock()
+	exec.state.RunningTasks["task-1"] = "inst-1"
+	exec.state.RunningTasks["task-2"] = "inst-2"
+	exec.state.RunningTasks["task-3"] = "inst-missing"
+	exec.mu.Unlock()
+
+	if got := exec.parkedTaskCount(); got != 1 {
+		t.Errorf("parkedTaskCount() = %d, want 1", got)
+	}
+}
@@ -174,6 +174,7 @@ const (
 	StatusError        InstanceStatus = "error"
 	StatusTimeout      InstanceStatus = "timeout"
 	StatusStuck        InstanceStatus = "stuck"
+	StatusPaused       InstanceStatus = "paused"
 )
 
 // InstanceManagerInterface provides access to instance output for parsing completion data.
@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/Iron-Ham/claudio/internal/tracing"
 )
 
 // PlanningState tracks the current state of planning execution.
@@ -234,7 +235,7 @@ func (p *PlanningOrchestrator) ExecuteWithPrompt(
 	baseSession any,
 	getGroup func() any,
 	setCoordinatorID func(id string),
-) error {
+) (err error) {
 	p.mu.Lock()
 	if p.cancelled {
 		p.mu.Unlock()
@@ -244,6 +245,9 @@ func (p *PlanningOrchestrator) ExecuteWithPrompt(
 	p.ctx, p.cancel = context.WithCancel(ctx)
 	p.mu.Unlock()
 
+	_, span := tracing.Tracer().Start(ctx, "planning.start_instance")
+	defer func() { tracing.End(span, err) }()
+
 	defer func() {
 		p.mu.Lock()
 		p.running = false
@@ -8,11 +8,14 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+	"github.com/Iron-Ham/claudio/internal/tracing"
 )
 
 // SynthesisCompletionFileName is the sentinel file that synthesis writes when complete.
@@ -200,8 +203,13 @@ type revisionTaskCompletion struct {
 // This includes the instance performing synthesis and any revision-related state.
 type SynthesisState struct {
 	// InstanceID is the ID of the backend instance performing synthesis review.
+	// With parallel reviewers, it is the first reviewer.
 	InstanceID string
 
+	// ReviewerIDs are the instance IDs of the parallel synthesis reviewers,
+	// in focus order. It is empty for a single synthesis instance.
+	ReviewerIDs []string
+
 	// AwaitingApproval is true when synthesis has completed but is waiting
 	// for user approval before proceeding to revision or consolidation.
 	AwaitingApproval bool
@@ -235,6 +243,7 @@ type RevisionIssue struct {
 	Files       []string // Files affected by the issue
 	Severity    string   // "critical", "major", "minor"
 	Suggestion  string   // Suggested fix
+	Reviewers   []string // Focuses of the parallel reviewers that reported it
 }
 
 // SynthesisCompletionFile represents the completion report from the synthesis phase.
@@ -347,6 +356,46 @@ func (s *SynthesisOrchestrator) Execute(ctx context.Context) error {
 	// Build the synthesis prompt
 	prompt := s.buildSynthesisPrompt()
 
+	// With ultraplan.synthesis_reviewers above 1, several reviewers with
+	// different focuses review in parallel and their issues are merged
+	focuses := s.synthesisReviewers()
+
+	// The span covers launching the synthesis instance, not its review
+	_, span := tracing.Tracer().Start(ctx, "synthesis.start_instance")
+	var err error
+	if len(focuses) > 0 {
+		err = s.startSynthesisReviewers(prompt, focuses)
+	} else {
+		err = s.startSynthesisInstance(prompt)
+	}
+	if err != nil {
+		tracing.End(span, err)
+		return err
+	}
+	tracing.End(span, nil)
+
+	// Monitor the synthesis instance for completion in a goroutine
+	// The monitoring loop will block until completion or cancellation
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if ids := s.GetReviewerIDs(); len(ids) > 0 {
+			s.monitorSynthesisReviewers(ids)
+			return
+		}
+		s.monitorSynthesisInstance(s.GetInstanceID())
+	}()
+
+	// Wait for the monitoring to complete
+	s.wg.Wait()
+
+	return nil
+}
+
+// startSynthesisInstance creates and starts the synthesis instance, and
+// adds it to the ultraplan group for sidebar display.
+func (s *SynthesisOrchestrator) startSynthesisInstance(prompt string) error {
+
 	// Create a synthesis instance
 	inst, err := s.phaseCtx.Orchestrator.AddInstance(s.phaseCtx.BaseSession, prompt)
 	if err != nil {
@@ -387,18 +436,6 @@ func (s *SynthesisOrchestrator) Execute(ctx context.Context) error {
 		)
 		return fmt.Errorf("failed to start synthesis instance: %w", err)
 	}
-
-	// Monitor the synthesis instance for completion in a goroutine
-	// The monitoring loop will block until completion or cancellation
-	s.wg.Add(1)
-	go func() {
-		defer s.wg.Done()
-		s.monitorSynthesisInstance(instanceID)
-	}()
-
-	// Wait for the monitoring to complete
-	s.wg.Wait()
-
 	return nil
 }
 
@@ -566,7 +603,9 @@ func (s *SynthesisOrchestrator) notifyPhaseChange(phase UltraPlanPhase) {
 
 // buildSynthesisPrompt constructs the prompt for the synthesis review instance.
 // It includes the objective, list of completed tasks with commit counts, and
-// a summary of results from each task instance.
+// a summary of results from each task instance. When tasks reported
+// self-reviews (ultraplan.self_review), low-confidence tasks are listed first
+// for review.
 func (s *SynthesisOrchestrator) buildSynthesisPrompt() string {
 	session := s.phaseCtx.Session
 
@@ -576,6 +615,7 @@ func (s *SynthesisOrchestrator) buildSynthesisPrompt() string {
 	completedTasks := session.GetCompletedTasks()
 	taskToInstance := session.GetTaskToInstance()
 	taskCommitCounts := session.GetTaskCommitCounts()
+	selfReviews := s.taskSelfReviews()
 
 	for _, taskID := range completedTasks {
 		task := session.GetTask(taskID)
@@ -592,12 +632,18 @@ func (s *SynthesisOrchestrator) buildSynthesisPrompt() string {
 			commitCount = count
 		}
 
+		confidence := ""
+		if review, ok := selfReviews[taskID]; ok {
+			confidence = fmt.Sprintf(", confidence %d/%d", review.Confidence, types.MaxConfidence)
+		}
+
 		if commitCount > 0 {
-			taskList.WriteString(fmt.Sprintf("- [%s] %s (%d commits)\n", taskID, taskInfo.Title, commitCount))
+			taskList.WriteString(fmt.Sprintf("- [%s] %s (%d commits%s)\n", taskID, taskInfo.Title, commitCount, confidence))
 		} else {
-			taskList.WriteString(fmt.Sprintf("- [%s] %s (NO COMMITS - verify this task)\n", taskID, taskInfo.Title))
+			taskList.WriteString(fmt.Sprintf("- [%s] %s (NO COMMITS - verify this task%s)\n", taskID, taskInfo.Title, confidence))
 		}
 	}
+	writeLowConfidenceTasks(&taskList, completedTasks, selfReviews, session)
 
 	// Get summaries from completed instances
 	for taskID, instanceID := range taskToInstance {
@@ -616,6 +662,9 @@ func (s *SynthesisOrchestrator) buildSynthesisPrompt() string {
 			if filesModified := inst.GetFilesModified(); len(filesModified) > 0 {
 				resultsSummary.WriteString(fmt.Sprintf("Files modified: %s\n", strings.Join(filesModified, ", ")))
 			}
+			if review, ok := selfReviews[taskID]; ok {
+				writeSelfReview(&resultsSummary, review)
+			}
 			resultsSummary.WriteString("\n")
 		}
 	}
@@ -635,6 +684,9 @@ func (s *SynthesisOrchestrator) buildSynthesisPrompt() string {
 		if count, ok := taskCommitCounts[taskID]; ok {
 			resultsSummary.WriteString(fmt.Sprintf("Commits: %d\n", count))
 		}
+		if review, ok := selfReviews[taskID]; ok {
+			writeSelfReview(&resultsSummary, review)
+		}
 		resultsSummary.WriteString("\n")
 	}
 
@@ -644,6 +696,61 @@ func (s *SynthesisOrchestrator) buildSynthesisPrompt() string {
 	return fmt.Sprintf(SynthesisPromptTemplate, session.GetObjective(), taskList.String(), resultsSummary.String(), revisionRound)
 }
 
+// taskSelfReviews returns the self-reviews tasks reported on completion, if
+// the session records them.
+func (s *SynthesisOrchestrator) taskSelfReviews() map[string]types.SelfReview {
+	getter, ok := s.phaseCtx.Session.(interface {
+		GetTaskSelfReviews() map[string]types.SelfReview
+	})
+	if !ok {
+		return nil
+	}
+	return getter.GetTaskSelfReviews()
+}
+
+// writeLowConfidenceTasks lists the completed tasks whose self-review
+// reported low confidence, least confident first, so synthesis reviews them
+// before the rest.
+func writeLowConfidenceTasks(b *strings.Builder, completedTasks []string, reviews map[string]types.SelfReview, session UltraPlanSessionInterface) {
+	var low []string
+	for _, taskID := range completedTasks {
+		if review, ok := reviews[taskID]; ok && review.IsLow() {
+			low = append(low, taskID)
+		}
+	}
+	if len(low) == 0 {
+		return
+	}
+	slices.SortStableFunc(low, func(a, b string) int {
+		return reviews[a].Confidence - reviews[b].Confidence
+	})
+
+	b.WriteString("\n**Review these first** - their authors reported low confidence in their own work:\n")
+	for _, taskID := range low {
+		title := taskID
+		if task := session.GetTask(taskID); task != nil {
+			title = extractTaskInfo(task).Title
+		}
+		review := reviews[taskID]
+		b.WriteString(fmt.Sprintf("- [%s] %s (confidence %d/%d)", taskID, title, review.Confidence, types.MaxConfidence))
+		if len(review.Concerns) > 0 {
+			b.WriteString(": " + strings.Join(review.Concerns, "; "))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// writeSelfReview adds a task's self-review to its results summary.
+func writeSelfReview(b *strings.Builder, review types.SelfReview) {
+	b.WriteString(fmt.Sprintf("Self-review confidence: %d/%d\n", review.Confidence, types.MaxConfidence))
+	if len(review.TestsRun) > 0 {
+		b.WriteString(fmt.Sprintf("Tests run: %s\n", strings.Join(review.TestsRun, ", ")))
+	}
+	if len(review.Concerns) > 0 {
+		b.WriteString(fmt.Sprintf("Concerns: %s\n", strings.Join(review.Concerns, "; ")))
+	}
+}
+
 // taskInfo holds extracted task information.
 type taskInfo struct {
 	ID          string
@@ -788,7 +895,7 @@ func (s *SynthesisOrchestrator) onSynthesisReady() {
 
 	s.mu.Lock()
 	if synthesisCompletion != nil {
-		s.setCompletionFile(synthesisCompletion)
+		s.state.CompletionFile = synthesisCompletion
 		s.phaseCtx.Session.SetSynthesisCompletion(synthesisCompletion)
 	}
 	if issues != nil {
@@ -870,6 +977,15 @@ func (s *SynthesisOrchestrator) onSynthesisComplete() {
 // parseRevisionIssues extracts revision issues from the synthesis completion file (preferred)
 // or falls back to parsing stdout output. Returns the full completion struct (if available) and issues.
 func (s *SynthesisOrchestrator) parseRevisionIssues() (*SynthesisCompletionFile, []RevisionIssue) {
+	// Parallel reviewers' reports are merged
+	if ids := s.GetReviewerIDs(); len(ids) > 0 {
+		completion := s.parseReviewerReports(ids)
+		if completion == nil {
+			return nil, nil
+		}
+		return completion, convertToRevisionIssues(completion.IssuesFound)
+	}
+
 	synthesisID := s.phaseCtx.Session.GetSynthesisID()
 	if synthesisID == "" {
 		return nil, nil
@@ -1239,10 +1355,16 @@ func (s *SynthesisOrchestrator) TriggerConsolidation() error {
 	s.SetAwaitingApproval(false)
 	s.phaseCtx.Session.SetSynthesisAwaitingApproval(false)
 
-	// Stop the synthesis instance if it's still running
-	synthesisID := s.phaseCtx.Session.GetSynthesisID()
-	if synthesisID != "" {
-		inst := s.phaseCtx.Orchestrator.GetInstance(synthesisID)
+	// Stop the synthesis instances if they're still running
+	ids := s.GetReviewerIDs()
+	if len(ids) == 0 {
+		ids = []string{s.phaseCtx.Session.GetSynthesisID()}
+	}
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		inst := s.phaseCtx.Orchestrator.GetInstance(id)
 		if inst != nil {
 			// Try to stop the instance if orchestrator supports it
 			if extOrch, ok := s.phaseCtx.Orchestrator.(SynthesisOrchestratorExtended); ok {
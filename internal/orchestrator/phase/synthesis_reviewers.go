@@ -0,0 +1,441 @@
+package phase
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ReviewFocus is what one of several parallel synthesis reviewers
+// concentrates on.
+type ReviewFocus struct {
+	Name         string // Short name, e.g. "tests"
+	Instructions string // What the reviewer looks for
+}
+
+// SynthesisReviewFocuses are the focuses given to parallel synthesis
+// reviewers, in order: with ultraplan.synthesis_reviewers set to N, the
+// first N are used.
+var SynthesisReviewFocuses = []ReviewFocus{
+	{
+		Name:         "correctness",
+		Instructions: "Bugs, logic errors, unhandled edge cases and error paths, and mistakes where one task's changes meet another's.",
+	},
+	{
+		Name:         "tests",
+		Instructions: "Missing or inadequate tests for the new behavior, untested error paths, and tests that fail, are flaky, or assert the wrong thing.",
+	},
+	{
+		Name:         "architecture",
+		Instructions: "Consistency with the existing design: layering, duplication across tasks, naming, public API shape, and maintainability.",
+	},
+}
+
+// minIssueSimilarity is how alike two reviewers' descriptions of an issue in
+// the same task must be, as the share of their words in common, for the
+// issues to be merged.
+const minIssueSimilarity = 0.5
+
+// ReviewerReport is the completion report of one parallel synthesis
+// reviewer.
+type ReviewerReport struct {
+	Focus      string
+	Completion *SynthesisCompletionFile
+}
+
+// reviewFocusSection returns the prompt section telling a reviewer its focus
+// and what the other reviewers cover.
+func reviewFocusSection(focus ReviewFocus, others []ReviewFocus) string {
+	var b strings.Builder
+	b.WriteString("## Review Focus: " + focus.Name + "\n\n")
+	b.WriteString(fmt.Sprintf("You are one of %d reviewers examining this work in parallel. ", len(others)+1))
+	b.WriteString("Concentrate on: " + focus.Instructions + "\n\n")
+	if len(others) > 0 {
+		names := make([]string, len(others))
+		for i, o := range others {
+			names[i] = o.Name
+		}
+		b.WriteString("Other reviewers cover " + strings.Join(names, " and ") + ". ")
+	}
+	b.WriteString("Report the issues within your focus in issues_found; your findings are merged with theirs and each issue's severity is decided by vote.")
+	return b.String()
+}
+
+// synthesisReviewers returns the focuses of the parallel synthesis reviewers
+// to run, or nil for a single synthesis instance.
+func (s *SynthesisOrchestrator) synthesisReviewers() []ReviewFocus {
+	getter, ok := s.phaseCtx.Session.(interface{ GetSynthesisReviewers() int })
+	if !ok {
+		return nil
+	}
+	n := min(getter.GetSynthesisReviewers(), len(SynthesisReviewFocuses))
+	if n < 2 {
+		return nil
+	}
+	return SynthesisReviewFocuses[:n]
+}
+
+// GetReviewerIDs returns the instance IDs of the parallel synthesis
+// reviewers, in focus order, or nil for a single synthesis instance.
+func (s *SynthesisOrchestrator) GetReviewerIDs() []string {
+	s.mu.RLock()
+	ids := slices.Clone(s.state.ReviewerIDs)
+	s.mu.RUnlock()
+	if len(ids) > 0 {
+		return ids
+	}
+	// After a restart the IDs are only on the session
+	if getter, ok := s.phaseCtx.Session.(interface{ GetSynthesisReviewerIDs() []string }); ok {
+		return getter.GetSynthesisReviewerIDs()
+	}
+	return nil
+}
+
+// setReviewerIDs records the parallel reviewers' instance IDs, on the
+// session too when it keeps them.
+func (s *SynthesisOrchestrator) setReviewerIDs(ids []string) {
+	s.mu.Lock()
+	s.state.ReviewerIDs = ids
+	s.mu.Unlock()
+	if setter, ok := s.phaseCtx.Session.(interface{ SetSynthesisReviewerIDs(ids []string) }); ok {
+		setter.SetSynthesisReviewerIDs(ids)
+	}
+}
+
+// startSynthesisReviewers starts one synthesis instance per focus, each
+// with the synthesis prompt plus its focus. The first reviewer is recorded
+// as the session's synthesis instance.
+func (s *SynthesisOrchestrator) startSynthesisReviewers(prompt string, focuses []ReviewFocus) error {
+	ids := make([]string, 0, len(focuses))
+	for i, focus := range focuses {
+		others := slices.Concat(focuses[:i], focuses[i+1:])
+		if err := s.startSynthesisInstance(prompt + "\n\n" + reviewFocusSection(focus, others)); err != nil {
+			s.setReviewerIDs(ids)
+			return fmt.Errorf("%s reviewer: %w", focus.Name, err)
+		}
+		ids = append(ids, s.GetInstanceID())
+	}
+	s.setInstanceID(ids[0])
+	s.phaseCtx.Session.SetSynthesisID(ids[0])
+	s.setReviewerIDs(ids)
+	s.logger.Info("started parallel synthesis reviewers", "reviewers", len(ids))
+	return nil
+}
+
+// monitorSynthesisReviewers waits for every reviewer to write its
+// completion file or stop. Once all are done, the merged review awaits
+// approval like a single synthesis; if none wrote a report, synthesis
+// completes as when its instance finishes, or fails when every reviewer
+// failed.
+func (s *SynthesisOrchestrator) monitorSynthesisReviewers(ids []string) {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	done := make(map[string]InstanceStatus, len(ids))
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+
+		case <-ticker.C:
+			for _, id := range ids {
+				if _, ok := done[id]; ok {
+					continue
+				}
+				inst := s.phaseCtx.Orchestrator.GetInstance(id)
+				switch {
+				case inst == nil:
+					done[id] = StatusCompleted
+				case s.checkForSynthesisCompletionFile(inst):
+					done[id] = StatusCompleted
+				default:
+					switch status := inst.GetStatus(); status {
+					case StatusCompleted, StatusError, StatusTimeout, StatusStuck:
+						done[id] = status
+					}
+				}
+			}
+			if len(done) < len(ids) {
+				continue
+			}
+
+			if completion, _ := s.parseRevisionIssues(); completion != nil {
+				s.onSynthesisReady()
+				return
+			}
+			for _, status := range done {
+				if status == StatusCompleted {
+					s.onSynthesisComplete()
+					return
+				}
+			}
+			s.mu.Lock()
+			s.phaseCtx.Session.SetPhase(PhaseFailed)
+			s.phaseCtx.Session.SetError("synthesis failed: every reviewer failed")
+			s.mu.Unlock()
+			_ = s.phaseCtx.Orchestrator.SaveSession()
+			s.notifyComplete(false, "synthesis failed: every reviewer failed")
+			return
+		}
+	}
+}
+
+// parseReviewerReports reads the completion file of each reviewer that
+// wrote one and merges them. It returns nil when none did.
+func (s *SynthesisOrchestrator) parseReviewerReports(ids []string) *SynthesisCompletionFile {
+	var reports []ReviewerReport
+	for i, id := range ids {
+		inst := s.phaseCtx.Orchestrator.GetInstance(id)
+		if inst == nil || inst.GetWorktreePath() == "" {
+			continue
+		}
+		completion, err := parseReviewerCompletionFile(inst.GetWorktreePath())
+		if err != nil {
+			continue
+		}
+		focus := id
+		if i < len(SynthesisReviewFocuses) {
+			focus = SynthesisReviewFocuses[i].Name
+		}
+		reports = append(reports, ReviewerReport{Focus: focus, Completion: completion})
+	}
+	if len(reports) == 0 {
+		return nil
+	}
+	if len(reports) < len(ids) {
+		s.logger.Warn("merging synthesis review without every reviewer",
+			"reports", len(reports), "reviewers", len(ids))
+	}
+	return MergeReviews(reports)
+}
+
+// reviewerCompletionFile is a reviewer's completion file, in the JSON
+// layout the synthesis prompt asks for.
+type reviewerCompletionFile struct {
+	Status        string `json:"status"`
+	RevisionRound int    `json:"revision_round"`
+	IssuesFound   []struct {
+		TaskID      string   `json:"task_id"`
+		Description string   `json:"description"`
+		Files       []string `json:"files"`
+		Severity    string   `json:"severity"`
+		Suggestion  string   `json:"suggestion"`
+	} `json:"issues_found"`
+	TasksAffected    []string `json:"tasks_affected"`
+	IntegrationNotes string   `json:"integration_notes"`
+	Recommendations  []string `json:"recommendations"`
+}
+
+// parseReviewerCompletionFile reads the completion file a reviewer wrote in
+// worktreePath.
+func parseReviewerCompletionFile(worktreePath string) (*SynthesisCompletionFile, error) {
+	data, err := os.ReadFile(filepath.Join(worktreePath, SynthesisCompletionFileName))
+	if err != nil {
+		return nil, err
+	}
+	var raw reviewerCompletionFile
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse reviewer completion JSON: %w", err)
+	}
+	completion := &SynthesisCompletionFile{
+		Status:           raw.Status,
+		RevisionRound:    raw.RevisionRound,
+		TasksAffected:    raw.TasksAffected,
+		IntegrationNotes: raw.IntegrationNotes,
+		Recommendations:  raw.Recommendations,
+	}
+	for _, issue := range raw.IssuesFound {
+		if issue.Description == "" {
+			continue
+		}
+		completion.IssuesFound = append(completion.IssuesFound, RevisionIssue{
+			TaskID:      issue.TaskID,
+			Description: issue.Description,
+			Files:       issue.Files,
+			Severity:    issue.Severity,
+			Suggestion:  issue.Suggestion,
+		})
+	}
+	return completion, nil
+}
+
+// MergeReviews combines the reports of parallel synthesis reviewers into
+// one. Issues two reviewers reported for the same task, with largely the
+// same description and (when both name files) a file in common, are merged
+// into one issue whose Reviewers lists who reported it. A merged issue's
+// severity is the one most of its reviewers gave, the more severe on a tie.
+// Issues are ordered most severe first. The merged status is
+// "needs_revision" when any issue is critical or major.
+func MergeReviews(reports []ReviewerReport) *SynthesisCompletionFile {
+	merged := &SynthesisCompletionFile{Status: "complete"}
+	var clusters []*issueCluster
+	var notes []string
+	for _, r := range reports {
+		if r.Completion == nil {
+			continue
+		}
+		c := r.Completion
+		merged.RevisionRound = max(merged.RevisionRound, c.RevisionRound)
+		merged.TasksAffected = appendUnique(merged.TasksAffected, c.TasksAffected...)
+		merged.Recommendations = appendUnique(merged.Recommendations, c.Recommendations...)
+		if c.IntegrationNotes != "" {
+			notes = append(notes, fmt.Sprintf("[%s] %s", r.Focus, c.IntegrationNotes))
+		}
+		for _, issue := range c.IssuesFound {
+			cluster := findCluster(clusters, issue)
+			if cluster == nil {
+				cluster = &issueCluster{issue: issue, votes: make(map[string]string)}
+				cluster.issue.Files = slices.Clone(issue.Files)
+				clusters = append(clusters, cluster)
+			} else {
+				cluster.issue.Files = appendUnique(cluster.issue.Files, issue.Files...)
+				if cluster.issue.Suggestion == "" {
+					cluster.issue.Suggestion = issue.Suggestion
+				}
+			}
+			cluster.vote(r.Focus, issue.Severity)
+		}
+	}
+	merged.IntegrationNotes = strings.Join(notes, "\n\n")
+
+	for _, c := range clusters {
+		issue := c.issue
+		issue.Severity = c.severity()
+		issue.Reviewers = c.voters
+		merged.IssuesFound = append(merged.IssuesFound, issue)
+		if issue.Severity == "critical" || issue.Severity == "major" {
+			merged.Status = "needs_revision"
+		}
+	}
+	slices.SortStableFunc(merged.IssuesFound, func(a, b RevisionIssue) int {
+		return severityRank(b.Severity) - severityRank(a.Severity)
+	})
+	return merged
+}
+
+// issueCluster is one issue as reported by one or more reviewers.
+type issueCluster struct {
+	issue  RevisionIssue
+	votes  map[string]string // reviewer focus → severity
+	voters []string          // reviewer focuses, in report order
+}
+
+// vote records a reviewer's severity for the issue. A reviewer reporting the
+// issue twice counts once, at the more severe of its severities.
+func (c *issueCluster) vote(focus, severity string) {
+	severity = normalizeSeverity(severity)
+	prev, ok := c.votes[focus]
+	if !ok {
+		c.voters = append(c.voters, focus)
+	}
+	if !ok || severityRank(severity) > severityRank(prev) {
+		c.votes[focus] = severity
+	}
+}
+
+// severity returns the severity most reviewers gave, the more severe on a
+// tie.
+func (c *issueCluster) severity() string {
+	counts := make(map[string]int, 3)
+	best := ""
+	for _, sev := range c.votes {
+		counts[sev]++
+	}
+	for sev, n := range counts {
+		if best == "" || n > counts[best] || (n == counts[best] && severityRank(sev) > severityRank(best)) {
+			best = sev
+		}
+	}
+	return best
+}
+
+// findCluster returns the cluster issue belongs to, or nil.
+func findCluster(clusters []*issueCluster, issue RevisionIssue) *issueCluster {
+	for _, c := range clusters {
+		if sameIssue(c.issue, issue) {
+			return c
+		}
+	}
+	return nil
+}
+
+// sameIssue reports whether two reviewers' issues describe the same problem.
+func sameIssue(a, b RevisionIssue) bool {
+	if a.TaskID != b.TaskID {
+		return false
+	}
+	if len(a.Files) > 0 && len(b.Files) > 0 &&
+		!slices.ContainsFunc(a.Files, func(f string) bool { return slices.Contains(b.Files, f) }) {
+		return false
+	}
+	return similarity(a.Description, b.Description) >= minIssueSimilarity
+}
+
+// similarity returns the share of distinct words two descriptions have in
+// common (Jaccard index), ignoring case, punctuation, and short words.
+func similarity(a, b string) float64 {
+	wa, wb := descriptionWords(a), descriptionWords(b)
+	if len(wa) == 0 || len(wb) == 0 {
+		return 0
+	}
+	common := 0
+	for w := range wa {
+		if wb[w] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(wa)+len(wb)-common)
+}
+
+// descriptionWords returns the distinct words of three or more letters in s.
+func descriptionWords(s string) map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(w) >= 3 {
+			words[w] = true
+		}
+	}
+	return words
+}
+
+// normalizeSeverity returns a known severity, treating an unspecified or
+// unknown one as "major", as revision does.
+func normalizeSeverity(severity string) string {
+	switch s := strings.ToLower(strings.TrimSpace(severity)); s {
+	case "critical", "major", "minor":
+		return s
+	default:
+		return "major"
+	}
+}
+
+// severityRank orders severities, most severe highest.
+func severityRank(severity string) int {
+	switch severity {
+	case "critical":
+		return 3
+	case "major", "":
+		return 2
+	case "minor":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// appendUnique appends the items not already in list.
+func appendUnique(list []string, items ...string) []string {
+	for _, item := range items {
+		if !slices.Contains(list, item) {
+			list = append(list, item)
+		}
+	}
+	return list
+}
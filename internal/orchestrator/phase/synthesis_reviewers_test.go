@@ -0,0 +1,274 @@
+package phase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMergeReviews(t *testing.T) {
+	t.Run("merges the same issue and votes on severity", func(t *testing.T) {
+		merged := MergeReviews([]ReviewerReport{
+			{Focus: "correctness", Completion: &SynthesisCompletionFile{
+				Status: "needs_revision",
+				IssuesFound: []RevisionIssue{
+					{TaskID: "task-1", Description: "Missing nil check on the config in Load", Files: []string{"config.go"}, Severity: "critical"},
+					{TaskID: "task-2", Description: "Typo in log message", Severity: "minor"},
+				},
+				TasksAffected:    []string{"task-1"},
+				IntegrationNotes: "Tasks fit together",
+			}},
+			{Focus: "tests", Completion: &SynthesisCompletionFile{
+				Status: "needs_revision",
+				IssuesFound: []RevisionIssue{
+					{TaskID: "task-1", Description: "Load is missing a nil check on config", Files: []string{"config.go", "config_test.go"}, Severity: "major", Suggestion: "Return an error"},
+				},
+				TasksAffected: []string{"task-1", "task-3"},
+			}},
+			{Focus: "architecture", Completion: &SynthesisCompletionFile{
+				Status: "needs_revision",
+				IssuesFound: []RevisionIssue{
+					{TaskID: "task-1", Description: "Missing nil check for config in Load", Files: []string{"config.go"}, Severity: "major"},
+				},
+				IntegrationNotes: "Naming is consistent",
+			}},
+		})
+
+		if len(merged.IssuesFound) != 2 {
+			t.Fatalf("IssuesFound = %+v, want 2 issues", merged.IssuesFound)
+		}
+		issue := merged.IssuesFound[0]
+		if issue.TaskID != "task-1" || issue.Severity != "major" {
+			t.Errorf("first issue = %s/%s, want task-1/major by two votes to one", issue.TaskID, issue.Severity)
+		}
+		if !slices.Equal(issue.Reviewers, []string{"correctness", "tests", "architecture"}) {
+			t.Errorf("Reviewers = %v, want all three focuses", issue.Reviewers)
+		}
+		if !slices.Equal(issue.Files, []string{"config.go", "config_test.go"}) {
+			t.Errorf("Files = %v, want the union of reported files", issue.Files)
+		}
+		if issue.Suggestion != "Return an error" {
+			t.Errorf("Suggestion = %q, want the first one reported", issue.Suggestion)
+		}
+		if merged.IssuesFound[1].Severity != "minor" {
+			t.Errorf("second issue severity = %q, want minor", merged.IssuesFound[1].Severity)
+		}
+		if merged.Status != "needs_revision" {
+			t.Errorf("Status = %q, want needs_revision", merged.Status)
+		}
+		if !slices.Equal(merged.TasksAffected, []string{"task-1", "task-3"}) {
+			t.Errorf("TasksAffected = %v, want [task-1 task-3]", merged.TasksAffected)
+		}
+		if want := "[correctness] Tasks fit together\n\n[architecture] Naming is consistent"; merged.IntegrationNotes != want {
+			t.Errorf("IntegrationNotes = %q, want %q", merged.IntegrationNotes, want)
+		}
+	})
+
+	t.Run("keeps distinct issues apart", func(t *testing.T) {
+		merged := MergeReviews([]ReviewerReport{
+			{Focus: "correctness", Completion: &SynthesisCompletionFile{IssuesFound: []RevisionIssue{
+				{TaskID: "task-1", Description: "Missing nil check on the config in Load", Severity: "minor"},
+			}}},
+			{Focus: "tests", Completion: &SynthesisCompletionFile{IssuesFound: []RevisionIssue{
+				// Same description, different task
+				{TaskID: "task-2", Description: "Missing nil check on the config in Load", Severity: "minor"},
+				{TaskID: "task-1", Description: "Missing nil check on the config in Load", Files: []string{"a.go"}, Severity: "minor"},
+			}}},
+			{Focus: "architecture", Completion: &SynthesisCompletionFile{IssuesFound: []RevisionIssue{
+				{TaskID: "task-1", Description: "Missing nil check on the config in Load", Files: []string{"b.go"}, Severity: "minor"},
+			}}},
+		})
+
+		// The correctness issue lists no files, so the tests task-1 issue
+		// merges into it and adds a.go; the architecture issue shares no
+		// file with that and stays apart
+		if len(merged.IssuesFound) != 3 {
+			t.Fatalf("IssuesFound = %+v, want 3 issues", merged.IssuesFound)
+		}
+		if merged.Status != "complete" {
+			t.Errorf("Status = %q, want complete with only minor issues", merged.Status)
+		}
+	})
+
+	t.Run("ties go to the more severe and unknown severities count as major", func(t *testing.T) {
+		merged := MergeReviews([]ReviewerReport{
+			{Focus: "correctness", Completion: &SynthesisCompletionFile{IssuesFound: []RevisionIssue{
+				{TaskID: "task-1", Description: "Race on the shared counter", Severity: "minor"},
+				{TaskID: "task-2", Description: "Unbounded retry loop", Severity: "bogus"},
+			}}},
+			{Focus: "tests", Completion: &SynthesisCompletionFile{IssuesFound: []RevisionIssue{
+				{TaskID: "task-1", Description: "Race on shared counter", Severity: "critical"},
+			}}},
+		})
+
+		if len(merged.IssuesFound) != 2 {
+			t.Fatalf("IssuesFound = %+v, want 2 issues", merged.IssuesFound)
+		}
+		if got := merged.IssuesFound[0]; got.TaskID != "task-1" || got.Severity != "critical" {
+			t.Errorf("first issue = %s/%s, want task-1/critical", got.TaskID, got.Severity)
+		}
+		if got := merged.IssuesFound[1]; got.Severity != "major" {
+			t.Errorf("second issue severity = %q, want major", got.Severity)
+		}
+	})
+
+	t.Run("one reviewer reporting an issue twice votes once", func(t *testing.T) {
+		merged := MergeReviews([]ReviewerReport{
+			{Focus: "correctness", Completion: &SynthesisCompletionFile{IssuesFound: []RevisionIssue{
+				{TaskID: "task-1", Description: "Handler ignores the close error", Severity: "minor"},
+				{TaskID: "task-1", Description: "Handler ignores close error", Severity: "minor"},
+			}}},
+			{Focus: "tests", Completion: &SynthesisCompletionFile{IssuesFound: []RevisionIssue{
+				{TaskID: "task-1", Description: "The handler ignores the close error", Severity: "major"},
+			}}},
+		})
+
+		if len(merged.IssuesFound) != 1 {
+			t.Fatalf("IssuesFound = %+v, want 1 issue", merged.IssuesFound)
+		}
+		if got := merged.IssuesFound[0].Severity; got != "major" {
+			t.Errorf("Severity = %q, want major on a one-to-one tie", got)
+		}
+	})
+}
+
+func TestReviewFocusSection(t *testing.T) {
+	section := reviewFocusSection(SynthesisReviewFocuses[1], []ReviewFocus{SynthesisReviewFocuses[0], SynthesisReviewFocuses[2]})
+	for _, want := range []string{"## Review Focus: tests", "one of 3 reviewers", "correctness and architecture"} {
+		if !strings.Contains(section, want) {
+			t.Errorf("section missing %q:\n%s", want, section)
+		}
+	}
+}
+
+// mockReviewerSession is a session configured for parallel synthesis
+// reviewers.
+type mockReviewerSession struct {
+	mockSession
+	reviewers   int
+	reviewerIDs []string
+}
+
+func (m *mockReviewerSession) GetSynthesisReviewers() int           { return m.reviewers }
+func (m *mockReviewerSession) GetSynthesisReviewerIDs() []string    { return m.reviewerIDs }
+func (m *mockReviewerSession) SetSynthesisReviewerIDs(ids []string) { m.reviewerIDs = ids }
+
+// mockReviewerOrchestrator hands out a new instance, in its own worktree,
+// for every synthesis reviewer.
+type mockReviewerOrchestrator struct {
+	mockOrchestratorForSynthesis
+	mu        sync.Mutex
+	worktrees []string
+	prompts   []string
+	started   map[string]*mockInstanceForSynthesis
+}
+
+func (m *mockReviewerOrchestrator) AddInstance(session any, task string) (any, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := len(m.prompts)
+	m.prompts = append(m.prompts, task)
+	inst := &mockInstanceForSynthesis{
+		id:           "reviewer-" + string(rune('a'+n)),
+		worktreePath: m.worktrees[n],
+		status:       StatusRunning,
+	}
+	if m.started == nil {
+		m.started = make(map[string]*mockInstanceForSynthesis)
+	}
+	m.started[inst.id] = inst
+	return inst, nil
+}
+
+func (m *mockReviewerOrchestrator) GetInstance(id string) InstanceInterface {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if inst, ok := m.started[id]; ok {
+		return inst
+	}
+	return nil
+}
+
+func TestSynthesisOrchestrator_ParallelReviewers(t *testing.T) {
+	reports := []string{
+		`{"status": "needs_revision", "issues_found": [{"task_id": "task-1", "description": "Missing nil check on config in Load", "files": ["config.go"], "severity": "critical"}], "tasks_affected": ["task-1"]}`,
+		`{"status": "needs_revision", "issues_found": [{"task_id": "task-1", "description": "Load is missing a nil check on config", "files": ["config.go"], "severity": "major"}], "tasks_affected": ["task-1"]}`,
+		`{"status": "complete", "issues_found": [{"task_id": "task-2", "description": "Duplicated helper", "severity": "minor"}]}`,
+	}
+	orch := &mockReviewerOrchestrator{}
+	for _, report := range reports {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, SynthesisCompletionFileName), []byte(report), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		orch.worktrees = append(orch.worktrees, dir)
+	}
+	session := &mockReviewerSession{reviewers: 3}
+
+	synth, err := NewSynthesisOrchestrator(&PhaseContext{
+		Manager:      &mockManager{},
+		Orchestrator: orch,
+		Session:      session,
+	})
+	if err != nil {
+		t.Fatalf("NewSynthesisOrchestrator() error = %v", err)
+	}
+	if err := synth.Execute(context.Background()); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !synth.IsAwaitingApproval() {
+		if time.Now().After(deadline) {
+			t.Fatal("merged review never awaited approval")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	synth.Cancel()
+
+	if ids := synth.GetReviewerIDs(); !slices.Equal(ids, []string{"reviewer-a", "reviewer-b", "reviewer-c"}) {
+		t.Errorf("GetReviewerIDs() = %v, want three reviewers", ids)
+	}
+	if synth.GetInstanceID() != "reviewer-a" {
+		t.Errorf("GetInstanceID() = %q, want the first reviewer", synth.GetInstanceID())
+	}
+	for i, focus := range SynthesisReviewFocuses {
+		if !strings.Contains(orch.prompts[i], "## Review Focus: "+focus.Name) {
+			t.Errorf("reviewer %d prompt lacks the %s focus", i, focus.Name)
+		}
+	}
+
+	issues := synth.GetIssuesFound()
+	if len(issues) != 2 {
+		t.Fatalf("GetIssuesFound() = %+v, want 2 merged issues", issues)
+	}
+	if issues[0].TaskID != "task-1" || issues[0].Severity != "critical" {
+		t.Errorf("first issue = %s/%s, want task-1/critical on a tie", issues[0].TaskID, issues[0].Severity)
+	}
+	if !slices.Equal(issues[0].Reviewers, []string{"correctness", "tests"}) {
+		t.Errorf("first issue Reviewers = %v, want [correctness tests]", issues[0].Reviewers)
+	}
+	if completion := synth.GetCompletionFile(); completion == nil || completion.Status != "needs_revision" {
+		t.Errorf("GetCompletionFile() = %+v, want needs_revision", completion)
+	}
+}
+
+func TestSynthesisOrchestrator_SingleReviewerByDefault(t *testing.T) {
+	session := &mockReviewerSession{reviewers: 1}
+	synth, err := NewSynthesisOrchestrator(&PhaseContext{
+		Manager:      &mockManager{},
+		Orchestrator: &mockOrchestratorForSynthesis{},
+		Session:      session,
+	})
+	if err != nil {
+		t.Fatalf("NewSynthesisOrchestrator() error = %v", err)
+	}
+	if focuses := synth.synthesisReviewers(); focuses != nil {
+		t.Errorf("synthesisReviewers() = %v, want nil for one reviewer", focuses)
+	}
+}
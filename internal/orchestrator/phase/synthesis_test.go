@@ -6,6 +6,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
 )
 
 func TestNewSynthesisOrchestrator(t *testing.T) {
@@ -2334,6 +2336,73 @@ func TestSynthesisOrchestrator_BuildSynthesisPrompt(t *testing.T) {
 	})
 }
 
+// selfReviewSession is a mockSession that also reports task self-reviews.
+type selfReviewSession struct {
+	*mockSession
+	reviews map[string]types.SelfReview
+}
+
+func (s *selfReviewSession) GetTaskSelfReviews() map[string]types.SelfReview { return s.reviews }
+
+func TestSynthesisOrchestrator_BuildSynthesisPrompt_SelfReviews(t *testing.T) {
+	session := &selfReviewSession{
+		mockSession: &mockSession{
+			objective:      "Test objective",
+			completedTasks: []string{"task-1", "task-2", "task-3"},
+			tasks: map[string]any{
+				"task-1": &mockTask{id: "task-1", title: "Confident Task"},
+				"task-2": &mockTask{id: "task-2", title: "Shaky Task"},
+				"task-3": &mockTask{id: "task-3", title: "Broken Task"},
+			},
+			taskToInstance: map[string]string{},
+			taskCommitCounts: map[string]int{
+				"task-1": 1,
+				"task-2": 1,
+				"task-3": 1,
+			},
+		},
+		reviews: map[string]types.SelfReview{
+			"task-1": {Confidence: 5, TestsRun: []string{"go test ./..."}},
+			"task-2": {Confidence: 2, Concerns: []string{"retry path untested"}},
+			"task-3": {Confidence: 1},
+		},
+	}
+
+	synth, err := NewSynthesisOrchestrator(&PhaseContext{
+		Manager:      &mockManager{},
+		Orchestrator: &mockOrchestrator{},
+		Session:      session,
+	})
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+
+	prompt := synth.buildSynthesisPrompt()
+
+	for _, want := range []string{
+		"- [task-1] Confident Task (1 commits, confidence 5/5)",
+		"**Review these first**",
+		"- [task-2] Shaky Task (confidence 2/5): retry path untested",
+		"Self-review confidence: 5/5",
+		"Tests run: go test ./...",
+		"Concerns: retry path untested",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+
+	_, review, _ := strings.Cut(prompt, "**Review these first**")
+	broken := strings.Index(review, "[task-3]")
+	shaky := strings.Index(review, "[task-2]")
+	if broken < 0 || shaky < 0 || broken > shaky {
+		t.Errorf("low-confidence tasks should be listed least confident first:\n%s", review)
+	}
+	if strings.Contains(review, "- [task-1] Confident Task (confidence") {
+		t.Error("confident tasks should not be listed for review first")
+	}
+}
+
 // Tests for checkForRevisionCompletionFile
 
 func TestSynthesisOrchestrator_CheckForRevisionCompletionFile(t *testing.T) {
@@ -0,0 +1,36 @@
+package orchestrator
+
+// phaseModel returns the model for instances started during phase
+// (ultraplan.models), or "" for the backend's configured model.
+func (c *Coordinator) phaseModel(phase UltraPlanPhase) string {
+	session := c.Session()
+	if session == nil {
+		return ""
+	}
+	return session.Config.Models.ForPhase(phase)
+}
+
+// currentPhaseModel returns the model for instances started now, by the
+// session's current phase.
+func (c *Coordinator) currentPhaseModel() string {
+	session := c.Session()
+	if session == nil {
+		return ""
+	}
+	return session.Config.Models.ForPhase(session.Phase)
+}
+
+// TaskModel returns the model a task's next attempt runs on: the
+// final-attempt model for its last retry, else the task's own model, the
+// model for its estimated complexity, or the execution model. It returns
+// "" for the backend's configured model.
+func (c *Coordinator) TaskModel(taskID string) string {
+	if model := c.RetryModel(taskID); model != "" {
+		return model
+	}
+	session := c.Session()
+	if session == nil {
+		return ""
+	}
+	return session.Config.Models.ForTask(session.GetTask(taskID))
+}
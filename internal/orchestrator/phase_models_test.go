@@ -0,0 +1,82 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+)
+
+func TestPhaseModels_ForPhase(t *testing.T) {
+	m := PhaseModels{
+		Planning:      "opus",
+		Execution:     "sonnet",
+		Synthesis:     "opus-synth",
+		Revision:      "sonnet-rev",
+		Consolidation: "haiku",
+	}
+	tests := map[UltraPlanPhase]string{
+		PhasePlanning:      "opus",
+		PhasePlanSelection: "opus",
+		PhaseExecuting:     "sonnet",
+		PhaseSynthesis:     "opus-synth",
+		PhaseRevision:      "sonnet-rev",
+		PhaseConsolidating: "haiku",
+		PhaseComplete:      "",
+	}
+	for phase, want := range tests {
+		if got := m.ForPhase(phase); got != want {
+			t.Errorf("ForPhase(%s) = %q, want %q", phase, got, want)
+		}
+	}
+}
+
+func TestPhaseModels_ForTask(t *testing.T) {
+	m := PhaseModels{
+		Execution:    "sonnet",
+		ByComplexity: map[string]string{"high": "opus"},
+	}
+	tests := []struct {
+		name string
+		task *PlannedTask
+		want string
+	}{
+		{"nil task", nil, "sonnet"},
+		{"low complexity", &PlannedTask{EstComplexity: ComplexityLow}, "sonnet"},
+		{"high complexity", &PlannedTask{EstComplexity: ComplexityHigh}, "opus"},
+		{"task model wins", &PlannedTask{EstComplexity: ComplexityHigh, Model: "haiku"}, "haiku"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.ForTask(tt.task); got != tt.want {
+				t.Errorf("ForTask() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoordinator_TaskModel(t *testing.T) {
+	c := retryTestCoordinator(config.RetryConfig{FinalAttemptModel: "opus"})
+	session := c.Session()
+	session.Config.Models = PhaseModels{
+		Execution:    "sonnet",
+		ByComplexity: map[string]string{"high": "opus"},
+	}
+	session.Plan = &PlanSpec{Tasks: []PlannedTask{
+		{ID: "task-1", EstComplexity: ComplexityLow},
+		{ID: "task-2", EstComplexity: ComplexityHigh},
+	}}
+
+	if got := c.TaskModel("task-1"); got != "sonnet" {
+		t.Errorf("TaskModel(task-1) = %q, want execution model", got)
+	}
+	if got := c.TaskModel("task-2"); got != "opus" {
+		t.Errorf("TaskModel(task-2) = %q, want high-complexity model", got)
+	}
+
+	tracker := &coordinatorRetryTracker{c: c}
+	tracker.IncrementRetry("task-1")
+	tracker.IncrementRetry("task-1")
+	if got := c.TaskModel("task-1"); got != "opus" {
+		t.Errorf("TaskModel(task-1) on its last retry = %q, want final-attempt model", got)
+	}
+}
@@ -0,0 +1,102 @@
+package orchestrator
+
+import (
+	"slices"
+
+	"github.com/Iron-Ham/claudio/internal/ultraplan/decomposition"
+)
+
+// conflictPrediction returns ultraplan.conflict_prediction: "off", "warn",
+// or "serialize".
+func (c *Coordinator) conflictPrediction() string {
+	if c.orch == nil || c.orch.config == nil || c.orch.config.Ultraplan.ConflictPrediction == "" {
+		return "warn"
+	}
+	return c.orch.config.Ultraplan.ConflictPrediction
+}
+
+// predictPlanConflicts finds the parallel tasks of plan likely to conflict
+// at consolidation. With ultraplan.conflict_prediction set to "serialize",
+// tasks that change the same files are made to depend on each other first,
+// updating the plan's dependencies and execution order, and the report
+// lists what was serialized. It returns nil when prediction is off.
+func (c *Coordinator) predictPlanConflicts(plan *PlanSpec) *decomposition.Report {
+	mode := c.conflictPrediction()
+	if mode == "off" || plan == nil {
+		return nil
+	}
+
+	var graph *decomposition.ImportGraph
+	if c.orch != nil && c.orch.baseDir != "" {
+		g, err := decomposition.LoadImportGraph(c.orch.baseDir)
+		if err != nil {
+			c.logger.Warn("cannot read import graph for conflict prediction", "error", err)
+		} else {
+			graph = g
+		}
+	}
+
+	tasks := decompositionTasks(plan)
+	report := decomposition.Predict(tasks, graph)
+	if mode == "serialize" {
+		if deps := decomposition.Serialize(tasks, report, decomposition.LevelFile); len(deps) > 0 {
+			serializePlan(plan, deps)
+			report = decomposition.Predict(decompositionTasks(plan), graph)
+			report.Serialized = deps
+			c.logger.Info("serialized tasks predicted to conflict", "dependencies_added", len(deps))
+		}
+	}
+	if n := len(report.Conflicts); n > 0 {
+		c.logger.Warn("parallel tasks may conflict",
+			"pairs", n, "shared_files", report.Count(decomposition.LevelFile))
+	}
+	return report
+}
+
+// decompositionTasks returns plan's tasks in execution order, each after
+// its dependencies.
+func decompositionTasks(plan *PlanSpec) []decomposition.Task {
+	byID := make(map[string]*PlannedTask, len(plan.Tasks))
+	for i := range plan.Tasks {
+		byID[plan.Tasks[i].ID] = &plan.Tasks[i]
+	}
+	var ids []string
+	for _, group := range plan.ExecutionOrder {
+		ids = append(ids, group...)
+	}
+	for _, t := range plan.Tasks {
+		if !slices.Contains(ids, t.ID) {
+			ids = append(ids, t.ID)
+		}
+	}
+
+	tasks := make([]decomposition.Task, 0, len(ids))
+	for _, id := range ids {
+		if t := byID[id]; t != nil {
+			tasks = append(tasks, decomposition.Task{
+				ID:        t.ID,
+				Repo:      t.Repo,
+				Files:     t.Files,
+				DependsOn: t.DependsOn,
+			})
+		}
+	}
+	return tasks
+}
+
+// serializePlan adds deps to plan's tasks and recomputes its dependency
+// graph and execution order.
+func serializePlan(plan *PlanSpec, deps []decomposition.Dependency) {
+	for _, d := range deps {
+		for i := range plan.Tasks {
+			if plan.Tasks[i].ID == d.Task {
+				plan.Tasks[i].DependsOn = append(plan.Tasks[i].DependsOn, d.DependsOn)
+			}
+		}
+	}
+	plan.DependencyGraph = make(map[string][]string, len(plan.Tasks))
+	for _, t := range plan.Tasks {
+		plan.DependencyGraph[t.ID] = t.DependsOn
+	}
+	plan.ExecutionOrder = calculateExecutionOrder(plan.Tasks, plan.DependencyGraph)
+}
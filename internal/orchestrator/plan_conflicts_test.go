@@ -0,0 +1,69 @@
+package orchestrator
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/Iron-Ham/claudio/internal/ultraplan/decomposition"
+)
+
+func conflictTestPlan() *PlanSpec {
+	plan := &PlanSpec{
+		Tasks: []PlannedTask{
+			{ID: "t1", Files: []string{"internal/api/handler.go"}},
+			{ID: "t2", Files: []string{"internal/api/handler.go", "internal/api/routes.go"}},
+			{ID: "t3", Files: []string{"docs/guide.md"}},
+		},
+	}
+	plan.DependencyGraph = map[string][]string{"t1": nil, "t2": nil, "t3": nil}
+	plan.ExecutionOrder = calculateExecutionOrder(plan.Tasks, plan.DependencyGraph)
+	return plan
+}
+
+func conflictTestCoordinator(mode string) *Coordinator {
+	cfg := config.Default()
+	cfg.Ultraplan.ConflictPrediction = mode
+	return &Coordinator{
+		orch:   &Orchestrator{config: cfg},
+		logger: logging.NopLogger(),
+	}
+}
+
+func TestPredictPlanConflicts_Warn(t *testing.T) {
+	plan := conflictTestPlan()
+	report := conflictTestCoordinator("warn").predictPlanConflicts(plan)
+
+	if got := report.Level("t1", "t2"); got != decomposition.LevelFile {
+		t.Errorf("Level(t1, t2) = %s, want file", got)
+	}
+	if len(report.Serialized) != 0 || len(plan.ExecutionOrder) != 1 {
+		t.Errorf("warn changed the plan: order %v, serialized %v", plan.ExecutionOrder, report.Serialized)
+	}
+}
+
+func TestPredictPlanConflicts_Serialize(t *testing.T) {
+	plan := conflictTestPlan()
+	report := conflictTestCoordinator("serialize").predictPlanConflicts(plan)
+
+	want := []decomposition.Dependency{{Task: "t2", DependsOn: "t1", Level: decomposition.LevelFile}}
+	if !slices.Equal(report.Serialized, want) {
+		t.Errorf("Serialized = %+v, want %+v", report.Serialized, want)
+	}
+	if len(report.Conflicts) != 0 {
+		t.Errorf("conflicts left after serializing: %+v", report.Conflicts)
+	}
+	if !slices.Equal(plan.Tasks[1].DependsOn, []string{"t1"}) || !slices.Equal(plan.DependencyGraph["t2"], []string{"t1"}) {
+		t.Errorf("t2 depends on %v (graph %v), want t1", plan.Tasks[1].DependsOn, plan.DependencyGraph["t2"])
+	}
+	if len(plan.ExecutionOrder) != 2 || !slices.Contains(plan.ExecutionOrder[1], "t2") {
+		t.Errorf("ExecutionOrder = %v, want t2 in a second group", plan.ExecutionOrder)
+	}
+}
+
+func TestPredictPlanConflicts_Off(t *testing.T) {
+	if report := conflictTestCoordinator("off").predictPlanConflicts(conflictTestPlan()); report != nil {
+		t.Errorf("report = %+v, want nil when off", report)
+	}
+}
@@ -0,0 +1,54 @@
+package orchestrator
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// planEnvNamePattern matches names a POSIX shell can export.
+var planEnvNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// reservedPlanEnv are variables a plan may not set: the shell and tmux rely on
+// them, and backends read their credentials and endpoints from the prefixed
+// ones.
+var (
+	reservedPlanEnv         = []string{"HOME", "PATH", "PWD", "SHELL", "TERM", "TMUX", "TMUX_PANE", "USER"}
+	reservedPlanEnvPrefixes = []string{"ANTHROPIC_", "CLAUDE_", "CLAUDIO_"}
+)
+
+// ValidatePlanEnvVar checks that a plan may set the environment variable name
+// to value.
+func ValidatePlanEnvVar(name, value string) error {
+	if !planEnvNamePattern.MatchString(name) {
+		return fmt.Errorf("env %q is not a valid variable name (letters, digits, and underscores, not starting with a digit)", name)
+	}
+	if slices.Contains(reservedPlanEnv, name) {
+		return fmt.Errorf("env %s is reserved and cannot be set by a plan", name)
+	}
+	for _, prefix := range reservedPlanEnvPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return fmt.Errorf("env %s is reserved (%s* variables cannot be set by a plan)", name, prefix)
+		}
+	}
+	if strings.ContainsRune(value, 0) {
+		return fmt.Errorf("env %s contains a NUL byte", name)
+	}
+	return nil
+}
+
+// ValidatePlanEnv checks every variable in a plan's Env, returning the first
+// problem by name.
+func ValidatePlanEnv(env map[string]string) error {
+	for _, name := range slices.Sorted(maps.Keys(env)) {
+		if err := ValidatePlanEnvVar(name, env[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetEnv returns the variables the plan exports to every task.
+func (p *PlanSpec) GetEnv() map[string]string { return p.Env }
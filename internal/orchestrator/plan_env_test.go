@@ -0,0 +1,79 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidatePlanEnvVar(t *testing.T) {
+	tests := []struct {
+		name, value string
+		wantErr     string
+	}{
+		{name: "FEATURE_FLAG", value: "new-checkout"},
+		{name: "_private", value: "has spaces and 'quotes'"},
+		{name: "API_VERSION", value: ""},
+		{name: "1BAD", wantErr: "not a valid variable name"},
+		{name: "BAD-NAME", wantErr: "not a valid variable name"},
+		{name: "", wantErr: "not a valid variable name"},
+		{name: "PATH", wantErr: "reserved"},
+		{name: "ANTHROPIC_API_KEY", wantErr: "reserved"},
+		{name: "CLAUDIO_SESSION", wantErr: "reserved"},
+		{name: "NUL", value: "a\x00b", wantErr: "NUL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePlanEnvVar(tt.name, tt.value)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidatePlanEnvVar() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidatePlanEnvVar() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePlan_Env(t *testing.T) {
+	plan := &PlanSpec{
+		Tasks: []PlannedTask{{ID: "t1", Title: "One", Description: "Do it"}},
+		Env:   map[string]string{"FEATURE_FLAG": "on"},
+	}
+	if err := ValidatePlan(plan); err != nil {
+		t.Fatalf("ValidatePlan() error = %v", err)
+	}
+
+	plan.Env["HOME"] = "/tmp"
+	if err := ValidatePlan(plan); err == nil || !strings.Contains(err.Error(), "HOME") {
+		t.Errorf("ValidatePlan() error = %v, want HOME rejected", err)
+	}
+
+	result := ValidatePlanForEditor(plan)
+	if result.IsValid || result.ErrorCount != 1 {
+		t.Fatalf("ValidatePlanForEditor() = %+v, want one error", result)
+	}
+	if msg := result.Messages[0]; msg.Field != "env" || !strings.Contains(msg.Message, "HOME") {
+		t.Errorf("message = %+v, want an env error for HOME", msg)
+	}
+}
+
+func TestParsePlanFromFile_Env(t *testing.T) {
+	path := filepath.Join(t.TempDir(), PlanFileName)
+	data := `{"summary": "s", "env": {"API_VERSION": "v3"}, "tasks": [{"id": "t1", "title": "One", "description": "d"}]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := ParsePlanFromFile(path, "objective")
+	if err != nil {
+		t.Fatalf("ParsePlanFromFile() error = %v", err)
+	}
+	if plan.Env["API_VERSION"] != "v3" {
+		t.Errorf("Env = %v, want API_VERSION=v3", plan.Env)
+	}
+}
@@ -0,0 +1,240 @@
+package orchestrator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PlanFormat is a file format plans are exported to or imported from.
+type PlanFormat string
+
+// Plan formats. JSON and YAML round-trip; DOT is a Graphviz rendering of the
+// dependency graph and can only be exported.
+const (
+	PlanFormatJSON PlanFormat = "json"
+	PlanFormatYAML PlanFormat = "yaml"
+	PlanFormatDOT  PlanFormat = "dot"
+)
+
+// exportedPlan is a PlanSpec without its dependency graph and execution
+// order, which are computed from the tasks' dependencies. Exports leave them
+// out so a hand-edited plan can't carry an execution order that no longer
+// matches its dependencies.
+type exportedPlan struct {
+	*PlanSpec
+	DependencyGraph map[string][]string `json:"dependency_graph,omitempty"`
+	ExecutionOrder  [][]string          `json:"execution_order,omitempty"`
+}
+
+// ParsePlanFormat returns the format named by a --format flag.
+func ParsePlanFormat(name string) (PlanFormat, error) {
+	switch f := PlanFormat(strings.ToLower(name)); f {
+	case PlanFormatJSON, PlanFormatYAML, PlanFormatDOT:
+		return f, nil
+	case "yml":
+		return PlanFormatYAML, nil
+	case "gv", "graphviz":
+		return PlanFormatDOT, nil
+	default:
+		return "", fmt.Errorf("unknown plan format %q (want json, yaml, or dot)", name)
+	}
+}
+
+// PlanFormatForPath returns the format a plan file's extension implies:
+// .yaml and .yml are YAML, .dot and .gv are DOT, and anything else is JSON.
+func PlanFormatForPath(path string) PlanFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return PlanFormatYAML
+	case ".dot", ".gv":
+		return PlanFormatDOT
+	default:
+		return PlanFormatJSON
+	}
+}
+
+// MarshalPlan encodes plan in format. JSON and YAML hold the plan without its
+// dependency graph and execution order, which are recomputed on import.
+func MarshalPlan(plan *PlanSpec, format PlanFormat) ([]byte, error) {
+	if plan == nil {
+		return nil, fmt.Errorf("plan is nil")
+	}
+	switch format {
+	case PlanFormatJSON:
+		data, err := json.MarshalIndent(exportedPlan{PlanSpec: plan}, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode plan: %w", err)
+		}
+		return append(data, '\n'), nil
+	case PlanFormatYAML:
+		return marshalPlanYAML(plan)
+	case PlanFormatDOT:
+		return []byte(PlanDOT(plan)), nil
+	default:
+		return nil, fmt.Errorf("unknown plan format %q", format)
+	}
+}
+
+// marshalPlanYAML encodes plan as YAML with PlanSpec's JSON field names and
+// order, going through JSON since PlanSpec only has JSON tags.
+func marshalPlanYAML(plan *PlanSpec) ([]byte, error) {
+	data, err := json.Marshal(exportedPlan{PlanSpec: plan})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plan: %w", err)
+	}
+	// JSON is YAML, so parsing it into a node keeps the field order
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to encode plan as YAML: %w", err)
+	}
+	clearNodeStyle(&doc)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to encode plan as YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode plan as YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalPlan decodes a plan exported in format, or written by hand in it,
+// into a PlanSpec. DOT can't be decoded.
+func UnmarshalPlan(data []byte, format PlanFormat) (*PlanSpec, error) {
+	switch format {
+	case PlanFormatJSON:
+	case PlanFormatYAML:
+		var err error
+		if data, err = yamlToJSON(data); err != nil {
+			return nil, err
+		}
+	case PlanFormatDOT:
+		return nil, fmt.Errorf("DOT plans can only be exported; import the plan as JSON or YAML")
+	default:
+		return nil, fmt.Errorf("unknown plan format %q", format)
+	}
+
+	var plan PlanSpec
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan %s: %w", strings.ToUpper(string(format)), err)
+	}
+	return &plan, nil
+}
+
+// ReadPlanFile imports a plan from a JSON or YAML file, chosen by its
+// extension, computing its dependency graph and execution order when the
+// file leaves them out, and validates it.
+func ReadPlanFile(path string) (*PlanSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan file: %w", err)
+	}
+	plan, err := UnmarshalPlan(data, PlanFormatForPath(path))
+	if err != nil {
+		return nil, err
+	}
+	EnsurePlanComputed(plan)
+	if err := ValidatePlan(plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// yamlToJSON converts a YAML document to JSON, so YAML plans decode through
+// PlanSpec's JSON field names.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var v any
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("failed to parse plan YAML: %w", err)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse plan YAML: %w", err)
+	}
+	return out, nil
+}
+
+// clearNodeStyle resets the quoting and flow styles a document parsed from
+// JSON has, so it encodes as block YAML with strings quoted only where needed.
+func clearNodeStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		clearNodeStyle(c)
+	}
+}
+
+// PlanDOT renders plan's dependency graph in Graphviz DOT: a box per task,
+// filled by estimated complexity and clustered by execution group, with an
+// edge from each task to the tasks that depend on it.
+func PlanDOT(plan *PlanSpec) string {
+	var b strings.Builder
+	b.WriteString("digraph plan {\n")
+	if plan.Summary != "" {
+		fmt.Fprintf(&b, "  label=%s;\n  labelloc=t;\n", dotQuote(plan.Summary))
+	}
+	b.WriteString("  rankdir=TB;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+
+	tasks := make(map[string]*PlannedTask, len(plan.Tasks))
+	for i := range plan.Tasks {
+		tasks[plan.Tasks[i].ID] = &plan.Tasks[i]
+	}
+	writeNode := func(indent string, t *PlannedTask) {
+		fmt.Fprintf(&b, "%s%s [label=%s, fillcolor=%s];\n", indent, dotQuote(t.ID),
+			dotQuote(t.ID+"\n"+t.Title), dotQuote(dotComplexityColor(t.EstComplexity)))
+	}
+
+	placed := make(map[string]bool, len(plan.Tasks))
+	for i, group := range plan.ExecutionOrder {
+		fmt.Fprintf(&b, "\n  subgraph cluster_group_%d {\n    label=%s;\n    style=dashed;\n", i+1, dotQuote(fmt.Sprintf("Group %d", i+1)))
+		for _, id := range group {
+			if t, ok := tasks[id]; ok && !placed[id] {
+				writeNode("    ", t)
+				placed[id] = true
+			}
+		}
+		b.WriteString("  }\n")
+	}
+	// Tasks outside the execution order, as in a plan not yet computed
+	for i := range plan.Tasks {
+		if !placed[plan.Tasks[i].ID] {
+			writeNode("  ", &plan.Tasks[i])
+		}
+	}
+
+	b.WriteString("\n")
+	for _, t := range plan.Tasks {
+		for _, dep := range t.DependsOn {
+			fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote(dep), dotQuote(t.ID))
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotComplexityColor returns the fill color of a task box.
+func dotComplexityColor(c TaskComplexity) string {
+	switch c {
+	case ComplexityLow:
+		return "#d4edda"
+	case ComplexityHigh:
+		return "#f8d7da"
+	default:
+		return "#fff3cd"
+	}
+}
+
+// dotQuote returns s as a quoted DOT ID.
+func dotQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
@@ -0,0 +1,118 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func formatTestPlan() *PlanSpec {
+	plan := &PlanSpec{
+		Objective: "Add search",
+		Summary:   `Index and query "docs"`,
+		Tasks: []PlannedTask{
+			{ID: "index", Title: "Build the index", Description: "Walk the docs.\nStore tokens.", Files: []string{"index.go"}, EstComplexity: ComplexityHigh},
+			{ID: "query", Title: "Query endpoint", DependsOn: []string{"index"}, Priority: 1, EstComplexity: ComplexityLow},
+			{ID: "true", Title: "A task named like a YAML bool", DependsOn: []string{"index"}},
+		},
+		Env: map[string]string{"SEARCH_PORT": "8080"},
+	}
+	EnsurePlanComputed(plan)
+	return plan
+}
+
+func TestMarshalPlan_RoundTrip(t *testing.T) {
+	for _, format := range []PlanFormat{PlanFormatJSON, PlanFormatYAML} {
+		t.Run(string(format), func(t *testing.T) {
+			plan := formatTestPlan()
+			data, err := MarshalPlan(plan, format)
+			if err != nil {
+				t.Fatalf("MarshalPlan() error = %v", err)
+			}
+			if strings.Contains(string(data), "execution_order") || strings.Contains(string(data), "dependency_graph") {
+				t.Errorf("export holds the computed fields:\n%s", data)
+			}
+
+			path := filepath.Join(t.TempDir(), "plan."+string(format))
+			if err := os.WriteFile(path, data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := ReadPlanFile(path)
+			if err != nil {
+				t.Fatalf("ReadPlanFile() error = %v\n%s", err, data)
+			}
+			if !reflect.DeepEqual(got.Tasks, plan.Tasks) || got.Summary != plan.Summary || got.Objective != plan.Objective || !reflect.DeepEqual(got.Env, plan.Env) {
+				t.Errorf("round trip = %+v, want %+v", got, plan)
+			}
+			if !reflect.DeepEqual(got.ExecutionOrder, plan.ExecutionOrder) {
+				t.Errorf("ExecutionOrder = %v, want %v recomputed", got.ExecutionOrder, plan.ExecutionOrder)
+			}
+		})
+	}
+}
+
+func TestMarshalPlan_YAMLIsReadable(t *testing.T) {
+	data, err := MarshalPlan(formatTestPlan(), PlanFormatYAML)
+	if err != nil {
+		t.Fatalf("MarshalPlan() error = %v", err)
+	}
+	for _, want := range []string{"objective: Add search\n", "  - id: index\n", "    description: |-\n", "    depends_on:\n      - index\n", `id: "true"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("YAML missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestPlanDOT(t *testing.T) {
+	dot := PlanDOT(formatTestPlan())
+	for _, want := range []string{
+		"digraph plan {",
+		`label="Index and query \"docs\""`,
+		`subgraph cluster_group_2 {`,
+		`"index" [label="index\nBuild the index", fillcolor="#f8d7da"];`,
+		`"index" -> "query";`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("PlanDOT() missing %q:\n%s", want, dot)
+		}
+	}
+	if _, err := UnmarshalPlan([]byte(dot), PlanFormatDOT); err == nil {
+		t.Error("UnmarshalPlan() imported a DOT plan")
+	}
+}
+
+func TestReadPlanFile_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.yml")
+	yaml := "summary: Broken\ntasks:\n  - id: a\n    title: A\n    depends_on: [missing]\n"
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadPlanFile(path); err == nil || !strings.Contains(err.Error(), "unknown task missing") {
+		t.Errorf("ReadPlanFile() error = %v, want the unknown dependency", err)
+	}
+
+	// The lenient parser reads the same file, aliases and all
+	if err := os.WriteFile(path, []byte("plan:\n  summary: Nested\n  tasks:\n    - id: a\n      title: A\n      complexity: low\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	plan, err := ParsePlanFromFile(path, "")
+	if err != nil || plan.Tasks[0].EstComplexity != ComplexityLow {
+		t.Errorf("ParsePlanFromFile() = %+v, %v; want the nested YAML plan", plan, err)
+	}
+}
+
+func TestParsePlanFormat(t *testing.T) {
+	for name, want := range map[string]PlanFormat{"YAML": PlanFormatYAML, "yml": PlanFormatYAML, "gv": PlanFormatDOT, "json": PlanFormatJSON} {
+		if got, err := ParsePlanFormat(name); err != nil || got != want {
+			t.Errorf("ParsePlanFormat(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParsePlanFormat("toml"); err == nil {
+		t.Error("ParsePlanFormat(\"toml\") did not fail")
+	}
+	if got := PlanFormatForPath("out/Plan.YML"); got != PlanFormatYAML {
+		t.Errorf("PlanFormatForPath() = %q, want yaml", got)
+	}
+}
@@ -3,6 +3,7 @@ package orchestrator
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"slices"
 	"strings"
@@ -929,6 +930,50 @@ func ValidatePlanForEditor(plan *PlanSpec) *ValidationResult {
 		}
 	}
 
+	// Check declared completion criteria (error - the verifier cannot evaluate them)
+	for _, task := range plan.Tasks {
+		if err := task.Criteria.Validate(); err != nil {
+			result.IsValid = false
+			result.Messages = append(result.Messages, ValidationMessage{
+				Severity:   SeverityError,
+				Message:    err.Error(),
+				TaskID:     task.ID,
+				Field:      "criteria",
+				Suggestion: "Use repo-relative paths and plain identifiers",
+			})
+			result.ErrorCount++
+		}
+	}
+
+	// Check context packs (error - the pack cannot be built)
+	for _, task := range plan.Tasks {
+		if err := task.ContextPack.Validate(); err != nil {
+			result.IsValid = false
+			result.Messages = append(result.Messages, ValidationMessage{
+				Severity:   SeverityError,
+				Message:    err.Error(),
+				TaskID:     task.ID,
+				Field:      "context_pack",
+				Suggestion: "Use repo-relative file paths, line ranges with start <= end, and path:Name interfaces",
+			})
+			result.ErrorCount++
+		}
+	}
+
+	// Check plan-level environment variables (error - they are exported into every task)
+	for _, name := range slices.Sorted(maps.Keys(plan.Env)) {
+		if err := ValidatePlanEnvVar(name, plan.Env[name]); err != nil {
+			result.IsValid = false
+			result.Messages = append(result.Messages, ValidationMessage{
+				Severity:   SeverityError,
+				Message:    err.Error(),
+				Field:      "env",
+				Suggestion: "Rename or remove the variable",
+			})
+			result.ErrorCount++
+		}
+	}
+
 	// Verify execution order coverage matches task count
 	if plan.ExecutionOrder != nil {
 		scheduledTasks := 0
@@ -0,0 +1,173 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/prompt"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+)
+
+// progressPollInterval is how often running tasks' progress files are read.
+const progressPollInterval = 30 * time.Second
+
+// progressInterval returns how often running tasks are asked for a progress
+// summary (ultraplan.progress_summary_minutes), or 0 when they are not.
+func (c *Coordinator) progressInterval() time.Duration {
+	if c.orch == nil || c.orch.config == nil {
+		return 0
+	}
+	return time.Duration(c.orch.config.Ultraplan.ProgressSummaryMinutes) * time.Minute
+}
+
+// startProgressWatch asks each running task for a progress summary every
+// progressInterval and records the summaries they write, until execution
+// ends or the coordinator is cancelled. It is a no-op when
+// ultraplan.progress_summary_minutes is 0 or the watch is already running.
+func (c *Coordinator) startProgressWatch() {
+	interval := c.progressInterval()
+	if interval <= 0 {
+		return
+	}
+	c.mu.Lock()
+	if c.progressWatching {
+		c.mu.Unlock()
+		return
+	}
+	c.progressWatching = true
+	c.mu.Unlock()
+
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			c.progressWatching = false
+			c.mu.Unlock()
+		}()
+		ticker := time.NewTicker(min(progressPollInterval, interval))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.ctx.Done():
+				return
+			case now := <-ticker.C:
+				if !c.watchProgress(now, interval) {
+					return
+				}
+			}
+		}
+	}()
+}
+
+// watchProgress records the progress summaries running tasks have written
+// since the last call and asks those whose interval has passed for a new
+// one. An instance is first asked one interval after it is first seen. It
+// reports whether the plan is still executing.
+func (c *Coordinator) watchProgress(now time.Time, interval time.Duration) bool {
+	session := c.Session()
+	if session == nil {
+		return false
+	}
+	c.mu.RLock()
+	executing := session.Phase == PhaseExecuting
+	c.mu.RUnlock()
+	if !executing {
+		return false
+	}
+
+	recorded := false
+	for taskID, instanceID := range c.manager.TaskInstances() {
+		inst := c.orch.GetInstance(instanceID)
+		if inst == nil {
+			continue
+		}
+		if c.recordProgress(taskID, inst.WorktreePath) {
+			recorded = true
+		}
+
+		c.mu.Lock()
+		if c.progressAsked == nil {
+			c.progressAsked = make(map[string]time.Time)
+		}
+		asked, seen := c.progressAsked[instanceID]
+		due := seen && now.Sub(asked) >= interval
+		if !seen || due {
+			c.progressAsked[instanceID] = now
+		}
+		c.mu.Unlock()
+
+		if due {
+			if err := c.orch.RequestProgressSummary(instanceID); err != nil {
+				c.logger.Debug("progress summary not requested", "task_id", taskID, "error", err)
+			}
+		}
+	}
+
+	if recorded {
+		if err := c.orch.SaveSession(); err != nil {
+			c.logger.Warn("failed to save task progress", "error", err)
+		}
+	}
+	return true
+}
+
+// recordProgress stores the progress summary in a task's worktree when it
+// is newer than the one already recorded, and reports whether it was.
+func (c *Coordinator) recordProgress(taskID, worktreePath string) bool {
+	if worktreePath == "" {
+		return false
+	}
+	summary, err := types.ParseProgressFile(worktreePath)
+	if err != nil {
+		// A file that does not parse is most likely still being written
+		if !errors.Is(err, os.ErrNotExist) {
+			c.logger.Debug("unreadable progress summary", "task_id", taskID, "error", err)
+		}
+		return false
+	}
+	if !c.manager.RecordTaskProgress(taskID, *summary) {
+		return false
+	}
+	c.logger.Info("task progress recorded",
+		"task_id", taskID,
+		"done", len(summary.Done),
+		"remaining", len(summary.Remaining),
+	)
+	return true
+}
+
+// ProgressSection returns the prompt section giving a task the latest
+// progress summary an earlier attempt recorded, or "" when there is none.
+func (c *Coordinator) ProgressSection(taskID string) string {
+	if c.manager == nil {
+		return ""
+	}
+	summary, ok := c.manager.TaskProgress(taskID)
+	if !ok {
+		return ""
+	}
+	return prompt.ProgressSection(summary)
+}
+
+// RequestProgressSummary asks a working instance to write a summary of its
+// progress to types.ProgressFileName. Instances that are not working, such
+// as those waiting for input or parked, are not asked.
+func (o *Orchestrator) RequestProgressSummary(id string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	mgr, ok := o.instances[id]
+	if !ok || !mgr.Running() {
+		return fmt.Errorf("instance %s is not running", id)
+	}
+	inst := o.session.GetInstance(id)
+	if inst == nil {
+		return fmt.Errorf("instance %s not found", id)
+	}
+	if inst.Status != StatusWorking {
+		return fmt.Errorf("instance %s is %s, not working", id, inst.Status)
+	}
+	mgr.SendInput([]byte(prompt.ProgressRequest() + "\r"))
+	return nil
+}
@@ -0,0 +1,58 @@
+package orchestrator
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+)
+
+func TestCoordinator_RecordProgress(t *testing.T) {
+	c := newTestCoordinatorForPhaseAdapter(t)
+
+	if section := c.ProgressSection("task-1"); section != "" {
+		t.Errorf("ProgressSection() before any progress = %q, want empty", section)
+	}
+
+	dir := t.TempDir()
+	writeProgress := func(t *testing.T, body string, at time.Time) {
+		t.Helper()
+		if err := os.WriteFile(types.ProgressFilePath(dir), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(types.ProgressFilePath(dir), at, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	first := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	writeProgress(t, `{"summary": "Tokenizer done", "remaining": ["parser"]}`, first)
+	if !c.recordProgress("task-1", dir) {
+		t.Fatal("recordProgress() = false, want the first summary recorded")
+	}
+	if c.recordProgress("task-1", dir) {
+		t.Error("recordProgress() of an unchanged file = true, want false")
+	}
+
+	writeProgress(t, `{"summary": "Parser half done"}`, first.Add(-time.Minute))
+	if c.recordProgress("task-1", dir) {
+		t.Error("recordProgress() of an older summary = true, want false")
+	}
+	if section := c.ProgressSection("task-1"); !strings.Contains(section, "Tokenizer done") {
+		t.Errorf("ProgressSection() = %q, want the first summary", section)
+	}
+
+	writeProgress(t, `{"summary": "Parser half done"}`, first.Add(time.Minute))
+	if !c.recordProgress("task-1", dir) {
+		t.Error("recordProgress() of a newer summary = false, want true")
+	}
+	if section := c.ProgressSection("task-1"); !strings.Contains(section, "Parser half done") {
+		t.Errorf("ProgressSection() = %q, want the newer summary", section)
+	}
+
+	if c.recordProgress("task-2", t.TempDir()) {
+		t.Error("recordProgress() without a progress file = true, want false")
+	}
+}
@@ -5,6 +5,8 @@ package prompt
 import (
 	"errors"
 	"fmt"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
 )
 
 // Builder defines the interface for building prompts from context.
@@ -102,6 +104,7 @@ type PlanInfo struct {
 	ExecutionOrder [][]string
 	Insights       []string
 	Constraints    []string
+	Env            map[string]string // Plan-wide variables exported to every task
 }
 
 // TaskInfo contains task-level information for prompt building.
@@ -114,7 +117,9 @@ type TaskInfo struct {
 	Priority      int
 	EstComplexity string
 	IssueURL      string
-	CommitCount   int // Number of commits made by this task (for synthesis)
+	CommitCount   int                       // Number of commits made by this task (for synthesis)
+	Criteria      *types.CompletionCriteria // Checks the verifier runs before accepting the task
+	ContextPack   *types.ContextPack        // Code and docs copied into the worktree before the task starts
 }
 
 // RevisionInfo contains revision phase context.
@@ -150,6 +155,12 @@ type ConsolidationInfo struct {
 	TaskWorktrees         []TaskWorktreeInfo
 	GroupBranches         []string
 	PreConsolidatedBranch string
+	TargetBranch          string
+	BehindBy              int
+	Stale                 bool
+	RebaseOnTarget        bool
+	SkipPRs               bool   // PR CLI is unavailable: push branches and report PRs as pending
+	PRInstructions        string // Completes "Create pull requests ..." for the PR provider
 }
 
 // TaskWorktreeInfo contains information about a task's worktree.
@@ -31,6 +31,8 @@ func (b *ConsolidationBuilder) Build(ctx *Context) (string, error) {
 	groupsInfo := b.buildGroupsInfo(ctx)
 	worktreeInfo := b.buildWorktreeInfo(ctx)
 	synthesisContext := b.buildSynthesisContext(ctx)
+	freshnessInfo := b.buildFreshnessInfo(ctx)
+	prStep, prInfo := b.buildPRInfo(ctx)
 
 	return fmt.Sprintf(consolidationPromptTemplate,
 		ctx.Objective,
@@ -41,6 +43,8 @@ func (b *ConsolidationBuilder) Build(ctx *Context) (string, error) {
 		groupsInfo,
 		worktreeInfo,
 		synthesisContext,
+		prStep,
+		freshnessInfo+prInfo,
 		ctx.Consolidation.Mode,
 	), nil
 }
@@ -146,6 +150,57 @@ func (b *ConsolidationBuilder) buildSynthesisContext(ctx *Context) string {
 	return sb.String()
 }
 
+// buildFreshnessInfo builds instructions for consolidated work that has
+// fallen too far behind the target branch. It is empty when the work is
+// fresh enough (or the freshness check did not run).
+func (b *ConsolidationBuilder) buildFreshnessInfo(ctx *Context) string {
+	info := ctx.Consolidation
+	if info == nil || !info.Stale {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n## Base Freshness\n")
+	sb.WriteString(fmt.Sprintf("The consolidated work is **%d commit(s) behind %s**.\n", info.BehindBy, info.TargetBranch))
+
+	if !info.RebaseOnTarget {
+		sb.WriteString("Automatic rebasing is disabled. Note in each PR description that the branch is behind and may need a rebase before merging.\n")
+		return sb.String()
+	}
+
+	sb.WriteString("Before creating any pull request:\n")
+	sb.WriteString("1. Run `git fetch origin`\n")
+	sb.WriteString(fmt.Sprintf("2. Rebase the consolidated branches onto %s (for stacked PRs, rebase each group branch onto the rebased branch before it, in order)\n", info.TargetBranch))
+	sb.WriteString("3. Resolve any conflicts the rebase produces\n")
+	sb.WriteString("4. Re-run verification (build, lint, tests) on the rebased branches and fix any failures\n")
+	sb.WriteString("5. Push rebased branches with `--force-with-lease`\n")
+	return sb.String()
+}
+
+// buildPRInfo returns the instruction step for pull requests and, when the PR
+// provider is unavailable, the section explaining how to report the PRs instead.
+func (b *ConsolidationBuilder) buildPRInfo(ctx *Context) (step, info string) {
+	if !ctx.Consolidation.SkipPRs {
+		if ctx.Consolidation.PRInstructions != "" {
+			return "**Create** pull requests " + ctx.Consolidation.PRInstructions, ""
+		}
+		return "**Create** pull requests", ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n## Pull Requests Unavailable\n")
+	sb.WriteString("The pull request CLI is not available, so do **not** try to create pull requests. ")
+	sb.WriteString("Push every consolidated branch, then list the PR each branch needs in the completion file ")
+	sb.WriteString("under `pending_prs` (leave `prs_created` empty). The user creates them later.\n\n")
+	sb.WriteString("```json\n")
+	sb.WriteString(`"pending_prs": [` + "\n")
+	sb.WriteString(fmt.Sprintf(`  {"branch": "%s/...", "base": "%s", "title": "PR title", "body": "PR description", "group_index": 0}`+"\n",
+		ctx.Consolidation.BranchPrefix, ctx.Consolidation.MainBranch))
+	sb.WriteString("]\n")
+	sb.WriteString("```\n")
+	return "**Push** the consolidated branches (see Pull Requests Unavailable below)", sb.String()
+}
+
 // findTask finds a task by ID.
 func (b *ConsolidationBuilder) findTask(tasks []TaskInfo, id string) *TaskInfo {
 	for i := range tasks {
@@ -183,8 +238,8 @@ const consolidationPromptTemplate = `You are consolidating work from multiple pa
 2. **Create** consolidated branches according to the consolidation mode
 3. **Resolve** any merge conflicts
 4. **Run** verification (build, lint, tests)
-5. **Create** pull requests
-
+5. %s
+%s
 ## Completion Protocol - FINAL MANDATORY STEP
 
 **IMPORTANT**: Writing the completion file is your FINAL MANDATORY ACTION.
@@ -87,6 +87,88 @@ func TestConsolidationBuilder_Build(t *testing.T) {
 				"pre-consolidated",
 			},
 		},
+		{
+			name: "stale work with auto rebase",
+			ctx: &Context{
+				Phase:     PhaseConsolidation,
+				SessionID: "test-session",
+				Objective: "Test",
+				Plan:      &PlanInfo{ExecutionOrder: [][]string{{"t1"}}},
+				Consolidation: &ConsolidationInfo{
+					Mode:           "stacked",
+					MainBranch:     "main",
+					TargetBranch:   "origin/main",
+					BehindBy:       42,
+					Stale:          true,
+					RebaseOnTarget: true,
+				},
+			},
+			contains: []string{
+				"## Base Freshness",
+				"42 commit(s) behind origin/main",
+				"Rebase the consolidated branches onto origin/main",
+				"Re-run verification",
+			},
+		},
+		{
+			name: "stale work without auto rebase",
+			ctx: &Context{
+				Phase:     PhaseConsolidation,
+				SessionID: "test-session",
+				Objective: "Test",
+				Plan:      &PlanInfo{ExecutionOrder: [][]string{{"t1"}}},
+				Consolidation: &ConsolidationInfo{
+					Mode:         "single",
+					MainBranch:   "main",
+					TargetBranch: "origin/main",
+					BehindBy:     42,
+					Stale:        true,
+				},
+			},
+			contains: []string{
+				"42 commit(s) behind origin/main",
+				"Automatic rebasing is disabled",
+			},
+		},
+		{
+			name: "provider instructions",
+			ctx: &Context{
+				Phase:     PhaseConsolidation,
+				SessionID: "test-session",
+				Objective: "Test",
+				Plan:      &PlanInfo{ExecutionOrder: [][]string{{"t1"}}},
+				Consolidation: &ConsolidationInfo{
+					Mode:           "single",
+					BranchPrefix:   "claudio",
+					MainBranch:     "main",
+					PRInstructions: "as GitLab merge requests with `glab mr create`",
+				},
+			},
+			contains: []string{
+				"**Create** pull requests as GitLab merge requests with `glab mr create`",
+			},
+		},
+		{
+			name: "gh unavailable",
+			ctx: &Context{
+				Phase:     PhaseConsolidation,
+				SessionID: "test-session",
+				Objective: "Test",
+				Plan:      &PlanInfo{ExecutionOrder: [][]string{{"t1"}}},
+				Consolidation: &ConsolidationInfo{
+					Mode:         "single",
+					BranchPrefix: "claudio",
+					MainBranch:   "main",
+					SkipPRs:      true,
+				},
+			},
+			contains: []string{
+				"5. **Push** the consolidated branches",
+				"## Pull Requests Unavailable",
+				`"pending_prs"`,
+				`"base": "main"`,
+			},
+		},
 		{
 			name:        "nil context",
 			ctx:         nil,
@@ -241,8 +241,13 @@ Write a JSON file with this exact structure at the root level (do NOT wrap in a
   - "depends_on": IDs of tasks that must complete first (array of strings, empty for independent tasks)
   - "priority": Lower = higher priority within dependency level (number)
   - "est_complexity": "low", "medium", or "high" (string)
+  - "criteria": Checks the verifier runs before accepting the task: "files" that must exist, "symbols" that must be defined ("Name" or "path:Name"), and "tests" name patterns that must pass (object, optional)
+  - "context_pack": Code the task needs, copied into a file in its worktree before it starts so it does not have to search: "excerpts" of files as {"path", "start_line", "end_line", "reason"}, "interfaces" to include whole as "path:Name", and "docs" paths (object, optional)
+  - "repo": Name of the repository the task runs in, for plans spanning several repositories; tasks in the same repository share a team (string, optional)
+  - "contracts": Repository-relative paths of artifacts this task produces that tasks in other repositories depend on, such as API schemas (array of strings, optional)
 - "insights": Key findings about the codebase (array of strings)
 - "constraints": Risks or constraints to consider (array of strings)
+- "env": Shared values every task needs, such as a feature flag name or target API version, as NAME → value; exported into each task's environment (object of strings, optional)
 
 Before the plan file, output your reasoning in this format:
 <plan_decision>
@@ -66,12 +66,14 @@ If you changed directories during the task (e.g., ` + "`cd project/`" + `), use
   "notes": "Any implementation notes for the consolidation phase",
   "issues": ["Any concerns or blocking issues found"],
   "suggestions": ["Suggestions for integration with other tasks"],
-  "dependencies": ["Any new runtime dependencies added"]
+  "dependencies": ["Any new runtime dependencies added"],
+  "proposed_tasks": [{"title": "Follow-up task", "description": "What to do and why", "files": ["files/it/touches"]}]
 }
 ` + "```" + `
 
 3. Use status "blocked" if you cannot complete (explain in issues), or "failed" if something broke
 4. This file signals that your work is done and provides context for consolidation
+5. Only add proposed_tasks for follow-up work you found outside your task's scope (e.g. dead code to remove); each one runs only if the user approves it
 
 **REMEMBER**: Your task is NOT complete until you write this file. Do it NOW after finishing your work.
 `
@@ -4,7 +4,12 @@ package prompt
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
 )
 
 // TaskCompletionFileName is the filename for task completion signaling.
@@ -47,6 +52,15 @@ func (b *TaskBuilder) Build(ctx *Context) (string, error) {
 		sb.WriteString("\n")
 	}
 
+	// Declared completion criteria
+	sb.WriteString(CriteriaSection(ctx.Task.Criteria))
+
+	// Code and docs copied into the worktree
+	sb.WriteString(ContextPackSection(ctx.Task.ContextPack))
+
+	// Plan-wide environment
+	sb.WriteString(PlanEnvSection(ctx.Plan.Env))
+
 	// Previous group context (for tasks not in group 0)
 	if ctx.PreviousGroup != nil && ctx.GroupIndex > 0 {
 		b.writePreviousGroupContext(&sb, ctx.PreviousGroup)
@@ -64,6 +78,235 @@ func (b *TaskBuilder) Build(ctx *Context) (string, error) {
 	return sb.String(), nil
 }
 
+// CriteriaSection formats a task's completion criteria as a prompt section
+// telling the task what the verifier will check. Returns "" when there are
+// none.
+func CriteriaSection(c *types.CompletionCriteria) string {
+	if c.IsEmpty() {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Completion Criteria\n\n")
+	sb.WriteString("Your work is verified automatically after you write the completion file. ")
+	sb.WriteString("The task is retried unless all of these hold:\n")
+	for _, f := range c.Files {
+		fmt.Fprintf(&sb, "- File `%s` exists\n", f)
+	}
+	for _, s := range c.Symbols {
+		if path, name := types.SplitSymbol(s); path != "" {
+			fmt.Fprintf(&sb, "- `%s` is defined in `%s`\n", name, path)
+		} else {
+			fmt.Fprintf(&sb, "- `%s` is defined\n", name)
+		}
+	}
+	for _, t := range c.Tests {
+		if c.TestCommand != "" {
+			fmt.Fprintf(&sb, "- Tests matching `%s` pass (`%s`)\n", t, c.TestCommand)
+		} else {
+			fmt.Fprintf(&sb, "- Tests matching `%s` pass\n", t)
+		}
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// ContextPackSection formats a task's context pack as a prompt section
+// pointing the task at the pack file in its worktree and listing what it
+// holds. Returns "" when the pack is empty.
+func ContextPackSection(p *types.ContextPack) string {
+	if p.IsEmpty() {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Context Pack\n\n")
+	fmt.Fprintf(&sb, "The code and docs this task needs were copied into `%s` at the root of your worktree. ", types.ContextPackFileName)
+	sb.WriteString("Read it before exploring, and search the repository only for what it does not cover. ")
+	sb.WriteString("Do not edit or commit it. It contains:\n")
+	for _, e := range p.Excerpts {
+		switch {
+		case e.StartLine > 0 || e.EndLine > 0:
+			fmt.Fprintf(&sb, "- `%s` lines %s", e.Path, lineRange(e.StartLine, e.EndLine))
+		default:
+			fmt.Fprintf(&sb, "- `%s`", e.Path)
+		}
+		if e.Reason != "" {
+			fmt.Fprintf(&sb, ": %s", e.Reason)
+		}
+		sb.WriteString("\n")
+	}
+	for _, iface := range p.Interfaces {
+		path, name := types.SplitSymbol(iface)
+		fmt.Fprintf(&sb, "- Definition of `%s` from `%s`\n", name, path)
+	}
+	for _, doc := range p.Docs {
+		fmt.Fprintf(&sb, "- `%s`\n", doc)
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// lineRange formats an excerpt's line range, where zero means the start or
+// end of the file.
+func lineRange(start, end int) string {
+	switch {
+	case end == 0:
+		return fmt.Sprintf("%d-end", start)
+	case start == 0:
+		return fmt.Sprintf("1-%d", end)
+	}
+	return fmt.Sprintf("%d-%d", start, end)
+}
+
+// PlanEnvSection formats a plan's environment variables as a prompt section
+// telling the task they are already set in its shell. Returns "" when env is
+// empty.
+func PlanEnvSection(env map[string]string) string {
+	if len(env) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Plan Environment\n\n")
+	sb.WriteString("These variables are set in your shell for every task in this plan. ")
+	sb.WriteString("Use them instead of hard-coding their values:\n")
+	for _, name := range slices.Sorted(maps.Keys(env)) {
+		fmt.Fprintf(&sb, "- `%s` = `%s`\n", name, env[name])
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// maxDriftCommits bounds the commits listed by BaseDriftSection.
+const maxDriftCommits = 20
+
+// BaseDriftSection formats the commits that landed on the plan's base ref
+// after the plan was made, and the task's files they changed, as a prompt
+// section. Returns "" when files is empty.
+func BaseDriftSection(ref string, commits, files []string) string {
+	if len(files) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Upstream Changes\n\n")
+	fmt.Fprintf(&sb, "`%s` moved after this plan was made. These commits landed on it:\n", ref)
+	for i, c := range commits {
+		if i == maxDriftCommits {
+			fmt.Fprintf(&sb, "- ... and %d more\n", len(commits)-maxDriftCommits)
+			break
+		}
+		fmt.Fprintf(&sb, "- %s\n", c)
+	}
+	sb.WriteString("\nThey changed files this task works with:\n")
+	for _, f := range files {
+		fmt.Fprintf(&sb, "- `%s`\n", f)
+	}
+	fmt.Fprintf(&sb, "\nYour worktree may not include these changes. Before editing these files, read their "+
+		"current version (`git fetch origin && git show %s:<path>`) and keep your changes compatible with it; "+
+		"the task description may predate it.\n\n", ref)
+	return sb.String()
+}
+
+// SelfReviewSection returns the prompt section asking a task to review its
+// own work before completing and to report a confidence score in the
+// completion file's self_review field.
+func SelfReviewSection() string {
+	var sb strings.Builder
+	sb.WriteString("## Self-Review Before Completing\n\n")
+	sb.WriteString("Before you write the completion file, review your own work:\n\n")
+	sb.WriteString("1. Read the full diff of your changes against the commit you started from, and check that:\n")
+	sb.WriteString("   - every part of the task is implemented, with nothing stubbed or left as a TODO\n")
+	sb.WriteString("   - there is no debugging output, commented-out code, or unrelated change\n")
+	sb.WriteString("   - the changes follow the surrounding code's conventions and handle errors\n")
+	sb.WriteString("   - new behavior is covered by tests\n")
+	sb.WriteString("2. Run the tests (and the build and linters, if the project has them) for the code you changed. ")
+	sb.WriteString("Fix and commit anything that fails.\n")
+	sb.WriteString("3. Add a `self_review` object to the completion file:\n")
+	sb.WriteString("```json\n")
+	sb.WriteString("\"self_review\": {\n")
+	sb.WriteString("  \"confidence\": 4,\n")
+	sb.WriteString("  \"tests_run\": [\"Each test command you ran and whether it passed\"],\n")
+	sb.WriteString("  \"concerns\": [\"Anything a reviewer should check\"]\n")
+	sb.WriteString("}\n")
+	sb.WriteString("```\n\n")
+	fmt.Fprintf(&sb, "`confidence` is a whole number from %d to %d: %d means you reviewed the diff and the tests pass, ",
+		types.MinConfidence, types.MaxConfidence, types.MaxConfidence)
+	fmt.Fprintf(&sb, "3 means it works but parts are untested, and %d means it is likely incomplete or broken. ", types.MinConfidence)
+	sb.WriteString("Score honestly: low scores are reviewed first, not held against you.\n")
+	return sb.String()
+}
+
+// RetrySection tells a retried task that its previous attempt failed, why,
+// and which uncommitted changes it left behind, so the retry can build on
+// them instead of repeating the same mistake. attempt and maxAttempts count
+// from 1 and include the first attempt.
+func RetrySection(attempt, maxAttempts int, reason, diff string) string {
+	var sb strings.Builder
+	sb.WriteString("## Previous Attempt\n\n")
+	fmt.Fprintf(&sb, "This is attempt %d of %d. The previous attempt failed", attempt, maxAttempts)
+	if reason != "" {
+		fmt.Fprintf(&sb, ": %s", reason)
+	}
+	sb.WriteString(".\n\n")
+	if strings.TrimSpace(diff) == "" {
+		sb.WriteString("It left no uncommitted changes behind.\n\n")
+	} else {
+		sb.WriteString("It left these uncommitted changes behind:\n\n")
+		sb.WriteString("````diff\n")
+		sb.WriteString(strings.TrimRight(diff, "\n"))
+		sb.WriteString("\n````\n\n")
+		sb.WriteString("Your worktree may not include them. Reuse what is correct instead of starting over.\n\n")
+	}
+	sb.WriteString("Work that is not committed is lost when the attempt ends: commit your changes " +
+		"before writing the completion file.\n")
+	return sb.String()
+}
+
+// ProgressRequest asks a running task to record how far it has got in
+// types.ProgressFileName. It is sent to the instance as one line, since a
+// newline would submit it early.
+func ProgressRequest() string {
+	return fmt.Sprintf("Checkpoint: without stopping your work, write a summary of your progress to `%s` "+
+		"as JSON with the fields \"summary\", \"done\" (steps finished), \"remaining\" (steps left), "+
+		"\"files_modified\", and \"notes\" (decisions and anything the next step needs to know). "+
+		"Replace the file if it exists and do not commit it. Then carry on with your task.",
+		types.ProgressFileName)
+}
+
+// ProgressSection gives a restarted or retried task the latest progress
+// summary an earlier attempt wrote, so it continues that work instead of
+// starting over.
+func ProgressSection(summary types.ProgressSummary) string {
+	var sb strings.Builder
+	sb.WriteString("## Progress So Far\n\n")
+	fmt.Fprintf(&sb, "An earlier attempt at this task recorded its progress at %s:\n\n", summary.RecordedAt.Format(time.DateTime))
+	if summary.Summary != "" {
+		sb.WriteString(summary.Summary)
+		sb.WriteString("\n\n")
+	}
+	writeList := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "%s:\n", title)
+		for _, item := range items {
+			fmt.Fprintf(&sb, "- %s\n", item)
+		}
+		sb.WriteString("\n")
+	}
+	writeList("Done", summary.Done)
+	writeList("Remaining", summary.Remaining)
+	writeList("Files modified", summary.FilesModified)
+	if summary.Notes != "" {
+		fmt.Fprintf(&sb, "Notes: %s\n\n", summary.Notes)
+	}
+	sb.WriteString("Check what of this is in your worktree with `git log` and `git status`, then continue from there. " +
+		"Don't redo work that is already committed.\n")
+	return sb.String()
+}
+
 // validate checks that the context has all required fields for task prompts.
 func (b *TaskBuilder) validate(ctx *Context) error {
 	if ctx == nil {
@@ -3,6 +3,9 @@ package prompt
 import (
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
 )
 
 func TestTaskBuilder_Build(t *testing.T) {
@@ -44,6 +47,76 @@ func TestTaskBuilder_Build(t *testing.T) {
 			notContains: []string{
 				"## Expected Files",
 				"## Context from Previous Group",
+				"## Plan Environment",
+				"## Completion Criteria",
+			},
+		},
+		{
+			name: "valid context with completion criteria",
+			ctx: &Context{
+				Phase: PhaseTask,
+				Plan:  &PlanInfo{Summary: "Plan"},
+				Task: &TaskInfo{
+					ID:          "task-1",
+					Title:       "Add login",
+					Description: "Implement login",
+					Criteria: &types.CompletionCriteria{
+						Files:       []string{"internal/auth/login.go"},
+						Symbols:     []string{"Login", "internal/auth/session.go:Session"},
+						Tests:       []string{"TestLogin"},
+						TestCommand: "go test ./internal/auth -run {pattern}",
+					},
+				},
+			},
+			contains: []string{
+				"## Completion Criteria",
+				"- File `internal/auth/login.go` exists",
+				"- `Login` is defined\n",
+				"- `Session` is defined in `internal/auth/session.go`",
+				"- Tests matching `TestLogin` pass (`go test ./internal/auth -run {pattern}`)",
+			},
+		},
+		{
+			name: "valid context with context pack",
+			ctx: &Context{
+				Phase: PhaseTask,
+				Plan:  &PlanInfo{Summary: "Plan"},
+				Task: &TaskInfo{
+					ID:          "task-1",
+					Title:       "Add login",
+					Description: "Implement login",
+					ContextPack: &types.ContextPack{
+						Excerpts: []types.Excerpt{
+							{Path: "internal/auth/store.go", StartLine: 10, EndLine: 40, Reason: "Session storage"},
+							{Path: "go.mod"},
+						},
+						Interfaces: []string{"internal/auth/*.go:Store"},
+						Docs:       []string{"docs/auth.md"},
+					},
+				},
+			},
+			contains: []string{
+				"## Context Pack",
+				"copied into `.claudio-context-pack.md`",
+				"- `internal/auth/store.go` lines 10-40: Session storage\n",
+				"- `go.mod`\n",
+				"- Definition of `Store` from `internal/auth/*.go`",
+				"- `docs/auth.md`",
+			},
+		},
+		{
+			name: "valid context with plan environment",
+			ctx: &Context{
+				Phase: PhaseTask,
+				Plan: &PlanInfo{
+					Summary: "Plan",
+					Env:     map[string]string{"FEATURE_FLAG": "new-checkout", "API_VERSION": "v3"},
+				},
+				Task: &TaskInfo{ID: "task-1", Title: "Flag checkout", Description: "Gate the checkout"},
+			},
+			contains: []string{
+				"## Plan Environment",
+				"- `API_VERSION` = `v3`\n- `FEATURE_FLAG` = `new-checkout`",
 			},
 		},
 		{
@@ -317,3 +390,97 @@ func TestTaskCompletionFileName(t *testing.T) {
 		t.Errorf("TaskCompletionFileName should end with .json, got %q", TaskCompletionFileName)
 	}
 }
+
+func TestBaseDriftSection(t *testing.T) {
+	if got := BaseDriftSection("origin/main", []string{"abc123 Fix"}, nil); got != "" {
+		t.Errorf("BaseDriftSection() with no files = %q, want empty", got)
+	}
+
+	commits := make([]string, maxDriftCommits+2)
+	for i := range commits {
+		commits[i] = "abc123 Commit"
+	}
+	got := BaseDriftSection("origin/main", commits, []string{"internal/api/client.go"})
+	for _, want := range []string{
+		"## Upstream Changes",
+		"`origin/main` moved",
+		"- `internal/api/client.go`",
+		"git show origin/main:<path>",
+		"... and 2 more",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("BaseDriftSection() missing %q:\n%s", want, got)
+		}
+	}
+	if n := strings.Count(got, "abc123 Commit"); n != maxDriftCommits {
+		t.Errorf("listed %d commits, want %d", n, maxDriftCommits)
+	}
+}
+
+func TestSelfReviewSection(t *testing.T) {
+	got := SelfReviewSection()
+	for _, want := range []string{
+		"## Self-Review Before Completing",
+		"Run the tests",
+		`"self_review": {`,
+		`"confidence": 4`,
+		"from 1 to 5",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("SelfReviewSection() missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRetrySection(t *testing.T) {
+	got := RetrySection(2, 4, "task produced no commits", "diff --git a/x.go b/x.go\n+func X() {}\n")
+	for _, want := range []string{
+		"## Previous Attempt",
+		"attempt 2 of 4",
+		"failed: task produced no commits.",
+		"````diff\ndiff --git a/x.go b/x.go\n+func X() {}\n````",
+		"commit your changes",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RetrySection() missing %q:\n%s", want, got)
+		}
+	}
+
+	got = RetrySection(2, 2, "", "")
+	if !strings.Contains(got, "previous attempt failed.") || !strings.Contains(got, "no uncommitted changes") {
+		t.Errorf("RetrySection() without reason or diff:\n%s", got)
+	}
+	if strings.Contains(got, "````") {
+		t.Errorf("RetrySection() without diff has a diff block:\n%s", got)
+	}
+}
+
+func TestProgressSection(t *testing.T) {
+	got := ProgressSection(types.ProgressSummary{
+		Summary:    "Parser rewritten",
+		Done:       []string{"tokenizer"},
+		Remaining:  []string{"error recovery"},
+		Notes:      "Keep the old AST types",
+		RecordedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+	for _, want := range []string{
+		"## Progress So Far",
+		"2026-01-02 03:04:05",
+		"Parser rewritten",
+		"Done:\n- tokenizer",
+		"Remaining:\n- error recovery",
+		"Notes: Keep the old AST types",
+		"continue from there",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ProgressSection() missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Files modified") {
+		t.Errorf("ProgressSection() lists files without any:\n%s", got)
+	}
+
+	if req := ProgressRequest(); !strings.Contains(req, types.ProgressFileName) || strings.Contains(req, "\n") {
+		t.Errorf("ProgressRequest() = %q, want one line naming the progress file", req)
+	}
+}
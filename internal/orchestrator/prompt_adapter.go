@@ -36,6 +36,7 @@ func planInfoFromPlanSpec(spec *PlanSpec) *prompt.PlanInfo {
 		ExecutionOrder: spec.ExecutionOrder,
 		Insights:       spec.Insights,
 		Constraints:    spec.Constraints,
+		Env:            spec.Env,
 	}
 }
 
@@ -52,6 +53,8 @@ func taskInfoFromPlannedTask(task PlannedTask) prompt.TaskInfo {
 		Priority:      task.Priority,
 		EstComplexity: string(task.EstComplexity),
 		IssueURL:      task.IssueURL,
+		Criteria:      task.Criteria,
+		ContextPack:   task.ContextPack,
 		// CommitCount is not available from PlannedTask - it's populated later
 		// during synthesis when we know how many commits a task made
 	}
@@ -199,7 +202,7 @@ func consolidationInfoFromSession(session *UltraPlanSession, mainBranch string)
 		preConsolidatedBranch = session.GroupConsolidatedBranches[len(session.GroupConsolidatedBranches)-1]
 	}
 
-	return &prompt.ConsolidationInfo{
+	info := &prompt.ConsolidationInfo{
 		Mode:                  mode,
 		BranchPrefix:          branchPrefix,
 		MainBranch:            mainBranch,
@@ -207,6 +210,17 @@ func consolidationInfoFromSession(session *UltraPlanSession, mainBranch string)
 		GroupBranches:         session.GroupConsolidatedBranches,
 		PreConsolidatedBranch: preConsolidatedBranch,
 	}
+
+	// Carry the freshness check result so the instance can rebase before PRs
+	if state := session.Consolidation; state != nil {
+		info.TargetBranch = state.TargetBranch
+		info.BehindBy = state.BehindBy
+		info.Stale = state.Stale
+		info.RebaseOnTarget = state.Rebasing
+		info.SkipPRs = state.PRsSkipped
+	}
+
+	return info
 }
 
 // taskWorktreeInfoFromOrchestrator converts orchestrator.TaskWorktreeInfo to prompt.TaskWorktreeInfo.
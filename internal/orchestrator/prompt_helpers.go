@@ -73,6 +73,9 @@ func BuildConsolidationPrompt(c *Coordinator) string {
 	if ctx.Consolidation != nil && ctx.Consolidation.BranchPrefix == "" {
 		ctx.Consolidation.BranchPrefix = branchPrefix
 	}
+	if ctx.Consolidation != nil {
+		ctx.Consolidation.PRInstructions = c.orch.PRProvider().Instructions()
+	}
 
 	// Add previous group context if available
 	ctx.PreviousGroupContext = buildPreviousGroupContextStrings(session.GroupConsolidationContexts)
@@ -153,9 +156,16 @@ func FinishConsolidation(c *Coordinator) {
 		completedAt := time.Now()
 		session.Consolidation.CompletedAt = &completedAt
 	}
+	skipped := session.Consolidation != nil && session.Consolidation.PRsSkipped
 	c.mu.Unlock()
 	_ = c.orch.SaveSession()
 
+	if skipped {
+		pending := recordSkippedConsolidationPRs(c)
+		c.notifyComplete(true, fmt.Sprintf("Completed: branches pushed, %d PR(s) pending because the PR CLI is unavailable; run `claudio pr create --pending`", pending))
+		return
+	}
+
 	prCount := len(session.PRUrls)
 	c.notifyComplete(true, fmt.Sprintf("Completed: %d PR(s) created", prCount))
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/Iron-Ham/claudio/internal/event"
 	"github.com/Iron-Ham/claudio/internal/instance"
 	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/Iron-Ham/claudio/internal/pr"
 )
 
 // InstanceInfo provides the minimal information about an instance needed
@@ -39,6 +40,8 @@ type Config struct {
 	TmuxWidth int
 	// TmuxHeight is the default tmux window height
 	TmuxHeight int
+	// Provider creates the pull request (nil = GitHub via gh)
+	Provider pr.Provider
 }
 
 // NewConfigFromConfig creates a PR workflow Config from the global config.
@@ -131,6 +134,7 @@ func (m *Manager) Start(inst InstanceInfo) error {
 		TmuxWidth:  m.displayWidth,
 		TmuxHeight: m.displayHeight,
 		Backend:    m.backend,
+		Provider:   m.config.Provider,
 	}
 
 	// Use config defaults if display dimensions not set
@@ -0,0 +1,126 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/reaper"
+	"github.com/Iron-Ham/claudio/internal/worktree"
+)
+
+// reapPollInterval is how often the merged-branch reaper checks PR states.
+const reapPollInterval = 2 * time.Minute
+
+// Compile-time check that the worktree manager can back the reaper.
+var _ reaper.Git = (*worktree.Manager)(nil)
+
+// startReaper starts watching the session's consolidation PRs so task and
+// group branches and task worktrees are removed once the PRs merge. It is a
+// no-op when cleanup.reap_merged is off or the reaper is already running.
+// Caller must hold o.mu.
+func (o *Orchestrator) startReaper() {
+	if !o.config.Cleanup.ReapMerged || o.stopReaper != nil {
+		return
+	}
+
+	r := reaper.New(reaper.NewGitHub(o.baseDir), o.wt, reaper.Policy{
+		Retain:          time.Duration(o.config.Cleanup.MergedRetentionHours) * time.Hour,
+		DeleteRemote:    !o.config.Cleanup.KeepRemoteBranches,
+		DeleteLocal:     true,
+		RemoveWorktrees: true,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	o.stopReaper = cancel
+	go r.Watch(ctx, reapPollInterval, o.reapTargets, o.recordBranchCleanup)
+}
+
+// reapTargets returns the ultra-plan work whose PRs the reaper should watch:
+// the consolidation PRs with the task and group branches they carry and the
+// worktrees of task instances that are no longer running.
+func (o *Orchestrator) reapTargets() []reaper.Target {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	if o.session == nil || o.session.UltraPlan == nil || len(o.session.UltraPlan.PRUrls) == 0 {
+		return nil
+	}
+	up := o.session.UltraPlan
+	for _, rec := range o.session.BranchCleanups {
+		if rec.TargetID == up.ID {
+			return nil
+		}
+	}
+
+	target := reaper.Target{
+		ID:     up.ID,
+		PRURLs: append([]string(nil), up.PRUrls...),
+	}
+	for _, branch := range up.GroupConsolidatedBranches {
+		if branch != "" {
+			target.Branches = append(target.Branches, branch)
+		}
+	}
+	if up.Consolidation != nil {
+		target.Branches = append(target.Branches, up.Consolidation.GroupBranches...)
+	}
+	for _, instID := range up.TaskToInstance {
+		inst := o.session.GetInstance(instID)
+		if inst == nil {
+			continue
+		}
+		if inst.Branch != "" {
+			target.Branches = append(target.Branches, inst.Branch)
+		}
+		if inst.WorktreePath != "" && !isRunningStatus(inst.Status) {
+			target.Worktrees = append(target.Worktrees, inst.WorktreePath)
+		}
+	}
+	return []reaper.Target{target}
+}
+
+// isRunningStatus reports whether an instance in this status may still be
+// using its worktree.
+func isRunningStatus(s InstanceStatus) bool {
+	switch s {
+	case StatusPending, StatusPreparing, StatusWorking, StatusFinishing, StatusWaitingInput, StatusCreatingPR:
+		return true
+	}
+	return false
+}
+
+// recordBranchCleanup appends a reaper record to the session history,
+// persists it, and announces it on the event bus.
+func (o *Orchestrator) recordBranchCleanup(rec reaper.Record) {
+	o.mu.Lock()
+	if o.session == nil {
+		o.mu.Unlock()
+		return
+	}
+	o.session.BranchCleanups = append(o.session.BranchCleanups, rec)
+	if err := o.saveSession(); err != nil && o.logger != nil {
+		o.logger.Warn("failed to save branch cleanup", "error", err)
+	}
+	o.mu.Unlock()
+
+	if o.logger != nil {
+		o.logger.Info("reaped merged branches",
+			"target_id", rec.TargetID,
+			"local_branches", len(rec.LocalBranches),
+			"remote_branches", len(rec.RemoteBranches),
+			"worktrees", len(rec.Worktrees),
+			"kept_worktrees", len(rec.KeptWorktrees),
+			"errors", len(rec.Errors),
+		)
+	}
+	o.eventBus.Publish(event.NewBranchesReapedEvent(rec.TargetID, rec.PRURLs,
+		len(rec.LocalBranches)+len(rec.RemoteBranches), len(rec.Worktrees), len(rec.Errors)))
+}
+
+// stopReaperLocked stops the merged-branch reaper if it is running.
+func (o *Orchestrator) stopReaperLocked() {
+	if o.stopReaper != nil {
+		o.stopReaper()
+		o.stopReaper = nil
+	}
+}
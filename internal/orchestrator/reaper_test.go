@@ -0,0 +1,121 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/reaper"
+)
+
+func newReaperTestOrchestrator(t *testing.T) *Orchestrator {
+	t.Helper()
+	sess := NewSession("reap", "/repo")
+	sess.Instances = []*Instance{
+		{ID: "inst-a", Branch: "claudio/task-a", WorktreePath: "/wt/a", Status: StatusCompleted},
+		{ID: "inst-b", Branch: "claudio/task-b", WorktreePath: "/wt/b", Status: StatusWorking},
+	}
+	sess.UltraPlan = &UltraPlanSession{
+		ID:                        "plan-1",
+		PRUrls:                    []string{"https://github.com/o/r/pull/1"},
+		TaskToInstance:            map[string]string{"task-a": "inst-a", "task-b": "inst-b"},
+		GroupConsolidatedBranches: []string{"claudio/group-1", ""},
+	}
+	return &Orchestrator{
+		claudioDir: t.TempDir(),
+		config:     config.Default(),
+		session:    sess,
+		eventBus:   event.NewBus(),
+	}
+}
+
+func TestOrchestrator_ReapTargets(t *testing.T) {
+	o := newReaperTestOrchestrator(t)
+
+	targets := o.reapTargets()
+	if len(targets) != 1 {
+		t.Fatalf("reapTargets() = %d targets, want 1", len(targets))
+	}
+	target := targets[0]
+	if target.ID != "plan-1" || !slices.Equal(target.PRURLs, o.session.UltraPlan.PRUrls) {
+		t.Errorf("target = %+v, want plan-1 with its PRs", target)
+	}
+	branches := slices.Sorted(slices.Values(target.Branches))
+	if want := []string{"claudio/group-1", "claudio/task-a", "claudio/task-b"}; !slices.Equal(branches, want) {
+		t.Errorf("Branches = %v, want %v", branches, want)
+	}
+	if !slices.Equal(target.Worktrees, []string{"/wt/a"}) {
+		t.Errorf("Worktrees = %v, want only the finished task's worktree", target.Worktrees)
+	}
+
+	o.session.UltraPlan.PRUrls = nil
+	if targets := o.reapTargets(); len(targets) != 0 {
+		t.Errorf("reapTargets() without PRs = %v, want none", targets)
+	}
+}
+
+func TestOrchestrator_RecordBranchCleanup(t *testing.T) {
+	o := newReaperTestOrchestrator(t)
+	reaped := make(chan event.Event, 1)
+	o.eventBus.Subscribe("pr.branches_reaped", func(e event.Event) { reaped <- e })
+
+	o.recordBranchCleanup(reaper.Record{
+		TargetID:      "plan-1",
+		PRURLs:        []string{"https://github.com/o/r/pull/1"},
+		ReapedAt:      time.Unix(1000, 0),
+		LocalBranches: []string{"claudio/group-1", "claudio/task-a"},
+		Worktrees:     []string{"/wt/a"},
+	})
+
+	data, err := os.ReadFile(o.sessionFilePath())
+	if err != nil {
+		t.Fatalf("session file not written: %v", err)
+	}
+	var saved Session
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.BranchCleanups) != 1 || saved.BranchCleanups[0].TargetID != "plan-1" {
+		t.Errorf("saved BranchCleanups = %+v, want the plan-1 record", saved.BranchCleanups)
+	}
+
+	select {
+	case e := <-reaped:
+		ev := e.(event.BranchesReapedEvent)
+		if ev.Branches != 2 || ev.Worktrees != 1 {
+			t.Errorf("event = %+v, want 2 branches and 1 worktree", ev)
+		}
+	default:
+		t.Error("no pr.branches_reaped event")
+	}
+
+	// A reaped plan is not watched again, even by a new reaper after reload
+	if targets := o.reapTargets(); len(targets) != 0 {
+		t.Errorf("reapTargets() after cleanup = %v, want none", targets)
+	}
+}
+
+func TestOrchestrator_StartReaperRespectsConfig(t *testing.T) {
+	o := newReaperTestOrchestrator(t)
+	o.config.Cleanup.ReapMerged = false
+	o.startReaper()
+	if o.stopReaper != nil {
+		t.Fatal("reaper started with cleanup.reap_merged off")
+	}
+
+	// Without PRs the reaper never reaches the forge
+	o.session.UltraPlan = nil
+	o.config.Cleanup.ReapMerged = true
+	o.startReaper()
+	if o.stopReaper == nil {
+		t.Fatal("reaper not started")
+	}
+	o.stopReaperLocked()
+	if o.stopReaper != nil {
+		t.Error("stopReaperLocked() left the reaper running")
+	}
+}
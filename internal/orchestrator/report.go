@@ -0,0 +1,46 @@
+package orchestrator
+
+import (
+	"encoding/json"
+
+	"github.com/Iron-Ham/claudio/internal/report"
+)
+
+// writeReport writes the report of sess to .claudio/reports when
+// session.report is enabled. It runs when an ultraplan completes and when
+// a session is stopped. Failures are logged: a report never fails the
+// session.
+func (o *Orchestrator) writeReport(sess *Session) {
+	if sess == nil || o.config == nil || !o.config.Session.Report.Enabled {
+		return
+	}
+	paths, err := o.buildReport(sess)
+	if o.logger == nil {
+		return
+	}
+	if err != nil {
+		o.logger.Warn("failed to write session report", "session_id", sess.ID, "error", err)
+		return
+	}
+	o.logger.Info("session report written", "session_id", sess.ID, "path", paths[0])
+}
+
+// buildReport builds the report of sess and writes it, returning the
+// paths written.
+func (o *Orchestrator) buildReport(sess *Session) ([]string, error) {
+	data, err := json.Marshal(sess)
+	if err != nil {
+		return nil, err
+	}
+	r, err := report.Build(data, report.WithCommitCounter(o.countBranchCommits))
+	if err != nil {
+		return nil, err
+	}
+	return report.Write(report.Dir(o.baseDir), r, o.config.Session.Report.HTML)
+}
+
+// countBranchCommits counts the commits on branch since it left the main
+// branch.
+func (o *Orchestrator) countBranchCommits(branch string) (int, error) {
+	return o.wt.CountCommitsBetween(o.baseDir, o.wt.FindMainBranch(), branch)
+}
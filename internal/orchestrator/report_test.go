@@ -0,0 +1,48 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/report"
+)
+
+func TestOrchestrator_WriteReport(t *testing.T) {
+	baseDir := t.TempDir()
+	cfg := config.Default()
+	cfg.Session.Report.HTML = true
+	o := &Orchestrator{baseDir: baseDir, config: cfg}
+	sess := &Session{
+		ID:      "s1",
+		Name:    "docs",
+		Created: time.Now(),
+		Instances: []*Instance{
+			{ID: "i1", Task: "write docs", Status: StatusCompleted, Metrics: &Metrics{Cost: 0.25}},
+		},
+	}
+
+	o.writeReport(sess)
+	dir := report.Dir(baseDir)
+	data, err := os.ReadFile(report.Path(dir, "s1", ".md"))
+	if err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+	if !strings.Contains(string(data), "| write docs | - | completed |") {
+		t.Errorf("report missing the instance:\n%s", data)
+	}
+	if _, err := os.Stat(report.Path(dir, "s1", ".html")); err != nil {
+		t.Errorf("HTML report not written with session.report.html: %v", err)
+	}
+
+	disabled := config.Default()
+	disabled.Session.Report.Enabled = false
+	off := &Orchestrator{baseDir: t.TempDir(), config: disabled}
+	off.writeReport(sess)
+	if _, err := os.Stat(filepath.Join(off.baseDir, ".claudio")); !os.IsNotExist(err) {
+		t.Errorf("report written without session.report.enabled: %v", err)
+	}
+}
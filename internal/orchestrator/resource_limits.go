@@ -0,0 +1,19 @@
+package orchestrator
+
+import "github.com/Iron-Ham/claudio/internal/instance/process"
+
+// initResourceLimits sets up instance.resource_limits. Instances are not
+// limited when it is disabled.
+func (o *Orchestrator) initResourceLimits() {
+	rl := o.config.Instance.ResourceLimits
+	if !rl.Enabled {
+		return
+	}
+	o.resources = process.NewController(process.Limits{
+		Nice:         rl.Nice,
+		GoMaxProcs:   rl.GoMaxProcs,
+		CPUPercent:   rl.CPUPercent,
+		MemoryMB:     rl.MemoryMB,
+		CgroupParent: rl.CgroupParent,
+	})
+}
@@ -0,0 +1,26 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+)
+
+func TestInitResourceLimits(t *testing.T) {
+	cfg := config.Default()
+	o := &Orchestrator{config: cfg}
+	o.initResourceLimits()
+	if o.resources != nil {
+		t.Error("resource limits set up while disabled")
+	}
+
+	cfg.Instance.ResourceLimits.Enabled = true
+	o = &Orchestrator{config: cfg}
+	o.initResourceLimits()
+	if o.resources == nil {
+		t.Fatal("resource limits not set up while enabled")
+	}
+	if env := o.resources.Env("inst-1"); len(env) != 1 {
+		t.Errorf("Env() = %v, want a GOMAXPROCS hint", env)
+	}
+}
@@ -2,11 +2,13 @@
 //
 // This package tracks retry attempts per task, determines whether tasks
 // should be retried based on configuration, and maintains retry history
-// for debugging and auditing purposes.
+// for debugging and auditing purposes. A [Strategy] decides how each retry
+// differs from the attempt before it.
 package retry
 
 import (
 	"sync"
+	"time"
 )
 
 // TaskState tracks retry attempts for a task.
@@ -17,6 +19,12 @@ type TaskState struct {
 	LastError    string `json:"last_error,omitempty"`
 	CommitCounts []int  `json:"commit_counts,omitempty"` // Commits per attempt (for debugging)
 	Succeeded    bool   `json:"succeeded,omitempty"`     // True if task eventually succeeded
+
+	// LastDiff is the uncommitted diff the last failed attempt left in its
+	// worktree, for the next attempt's prompt
+	LastDiff string `json:"last_diff,omitempty"`
+	// NotBefore is when the next attempt may start, after backoff
+	NotBefore *time.Time `json:"not_before,omitempty"`
 }
 
 // Manager manages retry state for tasks.
@@ -114,6 +122,53 @@ func (m *Manager) SetLastError(taskID string, errMsg string) {
 	state.LastError = errMsg
 }
 
+// SetLastDiff sets the uncommitted diff left by the last failed attempt.
+func (m *Manager) SetLastDiff(taskID string, diff string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, exists := m.states[taskID]
+	if !exists {
+		return
+	}
+	state.LastDiff = diff
+}
+
+// ScheduleRetry sets the earliest time the next attempt of a task may start.
+func (m *Manager) ScheduleRetry(taskID string, at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, exists := m.states[taskID]
+	if !exists {
+		return
+	}
+	state.NotBefore = &at
+}
+
+// RetryWait returns how long the next attempt of a task must still wait,
+// or 0 when it may start now.
+func (m *Manager) RetryWait(taskID string, now time.Time) time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	state, exists := m.states[taskID]
+	if !exists || state.NotBefore == nil || !now.Before(*state.NotBefore) {
+		return 0
+	}
+	return state.NotBefore.Sub(now)
+}
+
+// IsFinalAttempt reports whether the next attempt of a task is a retry and
+// its last one: it has failed as many times as it may be retried.
+func (m *Manager) IsFinalAttempt(taskID string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	state, exists := m.states[taskID]
+	return exists && !state.Succeeded && state.RetryCount > 0 && state.RetryCount == state.MaxRetries
+}
+
 // GetFailedTasks returns the IDs of all tasks that have exhausted their retries
 // without succeeding.
 func (m *Manager) GetFailedTasks() []string {
@@ -167,13 +222,7 @@ func (m *Manager) GetAllStates() map[string]*TaskState {
 
 	result := make(map[string]*TaskState, len(m.states))
 	for k, v := range m.states {
-		stateCopy := *v
-		// Copy the slice to avoid sharing
-		if v.CommitCounts != nil {
-			stateCopy.CommitCounts = make([]int, len(v.CommitCounts))
-			copy(stateCopy.CommitCounts, v.CommitCounts)
-		}
-		result[k] = &stateCopy
+		result[k] = copyState(v)
 	}
 	return result
 }
@@ -187,12 +236,21 @@ func (m *Manager) LoadStates(states map[string]*TaskState) {
 	m.states = make(map[string]*TaskState, len(states))
 	for k, v := range states {
 		if v != nil {
-			stateCopy := *v
-			if v.CommitCounts != nil {
-				stateCopy.CommitCounts = make([]int, len(v.CommitCounts))
-				copy(stateCopy.CommitCounts, v.CommitCounts)
-			}
-			m.states[k] = &stateCopy
+			m.states[k] = copyState(v)
 		}
 	}
 }
+
+// copyState returns a copy of state that shares no memory with it.
+func copyState(state *TaskState) *TaskState {
+	stateCopy := *state
+	if state.CommitCounts != nil {
+		stateCopy.CommitCounts = make([]int, len(state.CommitCounts))
+		copy(stateCopy.CommitCounts, state.CommitCounts)
+	}
+	if state.NotBefore != nil {
+		notBefore := *state.NotBefore
+		stateCopy.NotBefore = &notBefore
+	}
+	return &stateCopy
+}
@@ -4,6 +4,7 @@ import (
 	"sort"
 	"sync"
 	"testing"
+	"time"
 )
 
 func TestNewManager(t *testing.T) {
@@ -634,3 +635,48 @@ func TestMaxRetriesExhausted(t *testing.T) {
 		t.Errorf("GetFailedTasks() = %v, want [%s]", failed, taskID)
 	}
 }
+
+func TestRetryScheduling(t *testing.T) {
+	m := NewManager()
+	now := time.Now()
+
+	if wait := m.RetryWait("unknown", now); wait != 0 {
+		t.Errorf("RetryWait() for unknown task = %v, want 0", wait)
+	}
+
+	m.GetOrCreateState("task-1", 2)
+	m.RecordAttempt("task-1", false)
+	m.SetLastDiff("task-1", "+added\n")
+	m.ScheduleRetry("task-1", now.Add(time.Minute))
+
+	if wait := m.RetryWait("task-1", now); wait != time.Minute {
+		t.Errorf("RetryWait() before NotBefore = %v, want 1m", wait)
+	}
+	if wait := m.RetryWait("task-1", now.Add(time.Minute)); wait != 0 {
+		t.Errorf("RetryWait() at NotBefore = %v, want 0", wait)
+	}
+	if m.IsFinalAttempt("task-1") {
+		t.Error("IsFinalAttempt() = true with a retry to spare")
+	}
+
+	m.RecordAttempt("task-1", false)
+	if !m.IsFinalAttempt("task-1") {
+		t.Error("IsFinalAttempt() = false after exhausting the retries before the last")
+	}
+
+	// Persisted state carries the schedule and diff without sharing them
+	states := m.GetAllStates()
+	if got := states["task-1"]; got.LastDiff != "+added\n" || got.NotBefore == nil || !got.NotBefore.Equal(now.Add(time.Minute)) {
+		t.Errorf("GetAllStates() = %+v, want the diff and schedule", got)
+	}
+	*states["task-1"].NotBefore = now.Add(time.Hour)
+	if wait := m.RetryWait("task-1", now); wait != time.Minute {
+		t.Errorf("RetryWait() after modifying a copy = %v, want 1m", wait)
+	}
+
+	restored := NewManager()
+	restored.LoadStates(states)
+	if wait := restored.RetryWait("task-1", now); wait != time.Hour {
+		t.Errorf("RetryWait() after LoadStates = %v, want 1h", wait)
+	}
+}
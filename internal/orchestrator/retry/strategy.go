@@ -0,0 +1,40 @@
+package retry
+
+import "time"
+
+// Strategy decides how a failed task is retried: how long to wait before
+// each retry, whether the next attempt is told what went wrong, and which
+// model runs the last attempt. The zero Strategy retries immediately with
+// the original prompt and model.
+type Strategy struct {
+	// Backoff is the wait before the first retry. It doubles for each
+	// retry after that (0 = retry immediately).
+	Backoff time.Duration
+	// MaxBackoff caps the wait (0 = no cap).
+	MaxBackoff time.Duration
+	// Augment adds the previous attempt's failure reason and the changes it
+	// left behind to the next attempt's prompt.
+	Augment bool
+	// FinalModel is the model the last attempt runs on ("" = the usual one).
+	FinalModel string
+}
+
+// Delay returns the wait before retry number retry (1 for the first
+// retry): Backoff doubled for each retry after the first, capped at
+// MaxBackoff.
+func (s Strategy) Delay(retry int) time.Duration {
+	if s.Backoff <= 0 || retry < 1 {
+		return 0
+	}
+	delay := s.Backoff
+	for i := 1; i < retry; i++ {
+		if s.MaxBackoff > 0 && delay >= s.MaxBackoff {
+			break
+		}
+		delay *= 2
+	}
+	if s.MaxBackoff > 0 && delay > s.MaxBackoff {
+		delay = s.MaxBackoff
+	}
+	return delay
+}
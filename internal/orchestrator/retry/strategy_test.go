@@ -0,0 +1,29 @@
+package retry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStrategyDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy Strategy
+		retry    int
+		want     time.Duration
+	}{
+		{"no backoff", Strategy{}, 3, 0},
+		{"first retry", Strategy{Backoff: 10 * time.Second}, 1, 10 * time.Second},
+		{"doubles per retry", Strategy{Backoff: 10 * time.Second}, 3, 40 * time.Second},
+		{"capped", Strategy{Backoff: 10 * time.Second, MaxBackoff: 25 * time.Second}, 3, 25 * time.Second},
+		{"cap below backoff", Strategy{Backoff: 10 * time.Second, MaxBackoff: 5 * time.Second}, 1, 5 * time.Second},
+		{"not a retry", Strategy{Backoff: 10 * time.Second}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.strategy.Delay(tt.retry); got != tt.want {
+				t.Errorf("Delay(%d) = %v, want %v", tt.retry, got, tt.want)
+			}
+		})
+	}
+}
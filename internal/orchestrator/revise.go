@@ -0,0 +1,260 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+)
+
+// PlanRevision reports what RevisePlan changed in the session.
+type PlanRevision struct {
+	// Diff is the task-level diff from the running plan to the revised one.
+	Diff PlanDiff
+
+	// Cancelled maps tasks whose running instance was stopped to that
+	// instance ID: removed tasks, and modified tasks that restart with
+	// their new definition.
+	Cancelled map[string]string
+
+	// Retained lists completed tasks the revision removed or modified.
+	// Their work is kept: they stay in the plan, completed, with their
+	// original definition.
+	Retained []string
+
+	// Reset lists failed tasks the revision modified. They are queued
+	// again and will run with their new definition.
+	Reset []string
+}
+
+// ErrNoPlan is returned by RevisePlan when the session has no plan yet.
+var ErrNoPlan = errors.New("session has no plan to revise")
+
+// RevisePlan merges a revised plan into the running session without
+// restarting completed work:
+//
+//   - Added tasks are queued; they run once their dependencies complete.
+//   - Removed tasks are dropped from the queue, and their running instances
+//     stopped. Completed ones are retained.
+//   - Modified tasks take their new definition unless already completed.
+//     They are queued again, so running ones are stopped and restart, and
+//     failed ones get a fresh set of retries.
+//
+// The merged plan keeps the session's plan ID, takes the revised plan's
+// objective, summary, insights, constraints, and env, and has its
+// dependency graph and execution order recomputed. New and redefined tasks
+// may not depend on a task that failed. If the merged plan is invalid, the
+// session is left unchanged and an error is returned. Only execution
+// backends that implement TaskReviser can take a revision; an error from
+// the backend leaves the session plan unchanged, but queue changes made
+// before it are kept. The updated plan is saved and a plan.revised event
+// published.
+func (c *Coordinator) RevisePlan(revised *PlanSpec) (*PlanRevision, error) {
+	if revised == nil {
+		return nil, fmt.Errorf("revised plan is nil")
+	}
+
+	c.addTaskMu.Lock()
+	defer c.addTaskMu.Unlock()
+
+	session := c.Session()
+	if session == nil || session.Plan == nil {
+		return nil, ErrNoPlan
+	}
+	if session.Phase != PhaseExecuting {
+		return nil, fmt.Errorf("can only revise the plan during execution (current: %s)", session.Phase)
+	}
+
+	c.mu.RLock()
+	current, err := ClonePlan(session.Plan)
+	completed := make(map[string]bool, len(session.CompletedTasks))
+	for _, id := range session.CompletedTasks {
+		completed[id] = true
+	}
+	failed := slices.Clone(session.FailedTasks)
+	running := maps.Clone(session.TaskToInstance)
+	runner := c.pipelineRunner
+	usePipeline := c.usePipeline && runner != nil
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	reviser, ok := runner.(TaskReviser)
+	if !usePipeline || !ok {
+		return nil, fmt.Errorf("execution backend does not support revising the plan")
+	}
+
+	merged, revision := mergeRevisedPlan(current, revised, completed)
+	if err := c.manager.ValidatePlanWithLogging(merged); err != nil {
+		return nil, fmt.Errorf("revised plan: %w", err)
+	}
+
+	// Tasks to queue: added ones, and redefined ones that have not
+	// completed. The latter replace their queued definition.
+	queue := make(map[string]bool)
+	var drop []string
+	for _, task := range revision.Diff.Added {
+		queue[task.ID] = true
+	}
+	for _, change := range revision.Diff.Changed {
+		if !completed[change.Task.ID] {
+			queue[change.Task.ID] = true
+			drop = append(drop, change.Task.ID)
+		}
+	}
+	for _, task := range revision.Diff.Removed {
+		if !completed[task.ID] {
+			drop = append(drop, task.ID)
+		}
+	}
+
+	// Failed tasks with a new definition get a fresh start; failed tasks
+	// that were removed no longer count against the plan.
+	var stillFailed []string
+	for _, id := range failed {
+		switch {
+		case queue[id]:
+			revision.Reset = append(revision.Reset, id)
+		case GetTaskByID(merged, id) != nil:
+			stillFailed = append(stillFailed, id)
+		}
+	}
+	for _, task := range merged.Tasks {
+		if !queue[task.ID] {
+			continue
+		}
+		for _, dep := range task.DependsOn {
+			if slices.Contains(stillFailed, dep) {
+				return nil, ErrInvalidDependency{TaskID: task.ID, DependencyID: dep, Reason: "dependency task failed"}
+			}
+		}
+	}
+
+	// Drop the queued tasks that were removed or redefined, then queue the
+	// new definitions and the added tasks in dependency order.
+	for _, id := range drop {
+		if err := reviser.RemoveTask(id); err != nil {
+			return nil, err
+		}
+		if instanceID, ok := running[id]; ok {
+			revision.Cancelled[id] = instanceID
+		}
+	}
+	for _, group := range merged.ExecutionOrder {
+		for _, id := range group {
+			if !queue[id] {
+				continue
+			}
+			if err := reviser.AddTask(*GetTaskByID(merged, id)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	c.mu.Lock()
+	for id := range revision.Cancelled {
+		delete(session.TaskToInstance, id)
+		delete(session.TaskProgress, id)
+	}
+	for _, id := range revision.Reset {
+		delete(session.TaskRetries, id)
+	}
+	session.FailedTasks = stillFailed
+	if session.FailedTasks == nil {
+		session.FailedTasks = make([]string, 0)
+	}
+	session.Plan = merged
+	session.Objective = merged.Objective
+	c.mu.Unlock()
+
+	for taskID, instanceID := range revision.Cancelled {
+		if inst := c.orch.GetInstance(instanceID); inst != nil {
+			if err := c.orch.StopInstance(inst); err != nil {
+				c.logger.Warn("failed to stop instance of revised task",
+					"task_id", taskID, "instance_id", instanceID, "error", err)
+			}
+		}
+	}
+
+	if err := c.orch.SaveSession(); err != nil {
+		c.logger.Warn("failed to save session after revising plan", "error", err)
+	}
+
+	added, removed, modified := revision.Diff.taskIDs()
+	c.logger.Info("plan revised",
+		"plan_id", merged.ID,
+		"added", len(added),
+		"removed", len(removed),
+		"modified", len(modified),
+		"cancelled", len(revision.Cancelled),
+		"retained", len(revision.Retained),
+	)
+	if bus := c.eventBus(); bus != nil {
+		cancelled := slices.Sorted(maps.Keys(revision.Cancelled))
+		bus.Publish(event.NewPlanRevisedEvent(added, removed, modified, cancelled))
+	}
+	return revision, nil
+}
+
+// mergeRevisedPlan builds the plan that results from revising current,
+// keeping the original definitions of completed tasks. The returned
+// revision has its Diff and Retained set.
+func mergeRevisedPlan(current, revised *PlanSpec, completed map[string]bool) (*PlanSpec, *PlanRevision) {
+	diff := DiffPlans(current, revised)
+
+	merged := &PlanSpec{
+		ID:              current.ID,
+		Objective:       revised.Objective,
+		Summary:         revised.Summary,
+		Insights:        revised.Insights,
+		Constraints:     revised.Constraints,
+		Env:             revised.Env,
+		DependencyGraph: make(map[string][]string),
+		CreatedAt:       current.CreatedAt,
+	}
+	if merged.Objective == "" {
+		merged.Objective = current.Objective
+	}
+
+	changed := make(map[string]bool, len(diff.Changed))
+	for _, change := range diff.Changed {
+		changed[change.Task.ID] = true
+	}
+
+	revision := &PlanRevision{Diff: diff, Cancelled: make(map[string]string)}
+	for _, task := range revised.Tasks {
+		if changed[task.ID] && completed[task.ID] {
+			task = *GetTaskByID(current, task.ID)
+			revision.Retained = append(revision.Retained, task.ID)
+		}
+		merged.Tasks = append(merged.Tasks, task)
+	}
+	for _, task := range diff.Removed {
+		if completed[task.ID] {
+			merged.Tasks = append(merged.Tasks, task)
+			revision.Retained = append(revision.Retained, task.ID)
+		}
+	}
+	for _, task := range merged.Tasks {
+		merged.DependencyGraph[task.ID] = task.DependsOn
+	}
+	merged.ExecutionOrder = calculateExecutionOrder(merged.Tasks, merged.DependencyGraph)
+	return merged, revision
+}
+
+// taskIDs returns the IDs of the added, removed, and changed tasks.
+func (d PlanDiff) taskIDs() (added, removed, changed []string) {
+	for _, task := range d.Added {
+		added = append(added, task.ID)
+	}
+	for _, task := range d.Removed {
+		removed = append(removed, task.ID)
+	}
+	for _, change := range d.Changed {
+		changed = append(changed, change.Task.ID)
+	}
+	return added, removed, changed
+}
@@ -0,0 +1,240 @@
+package orchestrator
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/instance"
+	"github.com/Iron-Ham/claudio/internal/logging"
+)
+
+// revisingRunner is an ExecutionRunner that accepts and drops tasks while it
+// runs.
+type revisingRunner struct {
+	addingRunner
+	removed []string
+}
+
+func (r *revisingRunner) RemoveTask(taskID string) error {
+	r.removed = append(r.removed, taskID)
+	return nil
+}
+
+// newRevisionTestCoordinator returns a coordinator mid-execution on the
+// pipeline: task-1 completed, task-2 running on instance-2, task-3 failed,
+// and task-4 waiting on task-2.
+func newRevisionTestCoordinator(t *testing.T) (*Coordinator, *revisingRunner, *[]event.PlanRevisedEvent) {
+	t.Helper()
+	session := NewUltraPlanSession("Test objective", DefaultUltraPlanConfig())
+	session.Plan = &PlanSpec{
+		ID:        "test-plan",
+		Objective: "Test objective",
+		Tasks: []PlannedTask{
+			{ID: "task-1", Title: "Task 1", Description: "Do task 1"},
+			{ID: "task-2", Title: "Task 2", Description: "Do task 2", DependsOn: []string{"task-1"}},
+			{ID: "task-3", Title: "Task 3", Description: "Do task 3"},
+			{ID: "task-4", Title: "Task 4", Description: "Do task 4", DependsOn: []string{"task-2"}},
+		},
+	}
+	if err := recalculatePlan(session.Plan); err != nil {
+		t.Fatal(err)
+	}
+	session.Phase = PhaseExecuting
+	session.CompletedTasks = []string{"task-1"}
+	session.FailedTasks = []string{"task-3"}
+	session.TaskToInstance["task-2"] = "instance-2"
+	session.TaskRetries = map[string]*TaskRetryState{"task-3": {TaskID: "task-3", RetryCount: 2}}
+
+	bus := event.NewBus()
+	var revised []event.PlanRevisedEvent
+	bus.Subscribe("plan.revised", func(e event.Event) { revised = append(revised, e.(event.PlanRevisedEvent)) })
+
+	orch := &Orchestrator{
+		eventBus:   bus,
+		claudioDir: t.TempDir(),
+		session:    &Session{Instances: []*Instance{{ID: "instance-2", Status: StatusWorking}}},
+		instances: map[string]*instance.Manager{
+			"instance-2": instance.NewManagerWithDeps(instance.ManagerOptions{ID: "instance-2"}),
+		},
+	}
+	c := &Coordinator{
+		manager: NewUltraPlanManager(orch, nil, session, logging.NopLogger()),
+		orch:    orch,
+		logger:  logging.NopLogger(),
+	}
+	runner := &revisingRunner{}
+	c.SetPipelineRunner(runner)
+	return c, runner, &revised
+}
+
+func TestCoordinator_RevisePlan(t *testing.T) {
+	c, runner, events := newRevisionTestCoordinator(t)
+
+	revision, err := c.RevisePlan(&PlanSpec{
+		ID:        "revised-plan",
+		Objective: "Revised objective",
+		Tasks: []PlannedTask{
+			{ID: "task-1", Title: "Task 1", Description: "Redo task 1"},
+			{ID: "task-3", Title: "Task 3", Description: "Do task 3 more carefully"},
+			{ID: "task-4", Title: "Task 4", Description: "Do task 4", DependsOn: []string{"task-5"}},
+			{ID: "task-5", Title: "Task 5", Description: "Do task 5", DependsOn: []string{"task-1"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RevisePlan() error = %v", err)
+	}
+	session := c.Session()
+
+	// The queue drops the removed task and the redefined unfinished ones,
+	// then takes the new definitions and the added task in dependency order.
+	if !slices.Equal(runner.removed, []string{"task-3", "task-4", "task-2"}) {
+		t.Errorf("removed from queue = %v, want task-3, task-4, task-2", runner.removed)
+	}
+	var queued []string
+	for _, task := range runner.added {
+		queued = append(queued, task.ID)
+	}
+	if !slices.Equal(queued, []string{"task-3", "task-5", "task-4"}) {
+		t.Errorf("queued = %v, want task-3, task-5, task-4", queued)
+	}
+	if runner.added[0].Description != "Do task 3 more carefully" {
+		t.Errorf("queued task-3 = %+v, want the revised definition", runner.added[0])
+	}
+
+	// The running removed task is cancelled: its instance is stopped and
+	// released.
+	if revision.Cancelled["task-2"] != "instance-2" || len(revision.Cancelled) != 1 {
+		t.Errorf("Cancelled = %v, want task-2 -> instance-2", revision.Cancelled)
+	}
+	if inst := c.orch.GetInstance("instance-2"); inst.Status != StatusCompleted {
+		t.Errorf("instance-2 status = %s, want it stopped", inst.Status)
+	}
+	if _, ok := session.TaskToInstance["task-2"]; ok {
+		t.Error("cancelled task should be removed from TaskToInstance")
+	}
+	if session.GetTask("task-2") != nil {
+		t.Error("removed task should not be in the plan")
+	}
+
+	// The completed task keeps its original definition.
+	if !slices.Equal(revision.Retained, []string{"task-1"}) {
+		t.Errorf("Retained = %v, want [task-1]", revision.Retained)
+	}
+	if got := session.GetTask("task-1").Description; got != "Do task 1" {
+		t.Errorf("completed task description = %q, want the original", got)
+	}
+
+	// The failed task is reset and takes its new definition.
+	if !slices.Equal(revision.Reset, []string{"task-3"}) {
+		t.Errorf("Reset = %v, want [task-3]", revision.Reset)
+	}
+	if len(session.FailedTasks) != 0 {
+		t.Errorf("FailedTasks = %v, want none", session.FailedTasks)
+	}
+	if _, ok := session.TaskRetries["task-3"]; ok {
+		t.Error("reset task should have no retry state")
+	}
+
+	// The plan is rebuilt around the session's plan ID.
+	if session.Plan.ID != "test-plan" || session.Objective != "Revised objective" {
+		t.Errorf("plan ID = %q, objective = %q; want test-plan and the revised objective", session.Plan.ID, session.Objective)
+	}
+	if !slices.Equal(session.Plan.DependencyGraph["task-4"], []string{"task-5"}) {
+		t.Errorf("DependencyGraph[task-4] = %v", session.Plan.DependencyGraph["task-4"])
+	}
+
+	if len(*events) != 1 {
+		t.Fatalf("plan.revised events = %d, want 1", len(*events))
+	}
+	if e := (*events)[0]; !slices.Equal(e.Added, []string{"task-5"}) || !slices.Equal(e.Removed, []string{"task-2"}) ||
+		!slices.Equal(e.Modified, []string{"task-1", "task-3", "task-4"}) || !slices.Equal(e.Cancelled, []string{"task-2"}) {
+		t.Errorf("plan.revised event = %+v", e)
+	}
+}
+
+func TestCoordinator_RevisePlan_RetainsRemovedCompletedTask(t *testing.T) {
+	c, runner, _ := newRevisionTestCoordinator(t)
+
+	revision, err := c.RevisePlan(&PlanSpec{
+		Tasks: []PlannedTask{
+			{ID: "task-2", Title: "Task 2", Description: "Do task 2", DependsOn: []string{"task-1"}},
+			{ID: "task-3", Title: "Task 3", Description: "Do task 3"},
+			{ID: "task-4", Title: "Task 4", Description: "Do task 4", DependsOn: []string{"task-2"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RevisePlan() error = %v", err)
+	}
+	session := c.Session()
+
+	if !slices.Equal(revision.Retained, []string{"task-1"}) || session.GetTask("task-1") == nil {
+		t.Errorf("Retained = %v, want task-1 kept in the plan so its dependents can run", revision.Retained)
+	}
+	// Unchanged running and failed tasks are left alone.
+	if len(runner.removed) != 0 || len(runner.added) != 0 {
+		t.Errorf("queue changes = -%v +%v, want none", runner.removed, runner.added)
+	}
+	if len(revision.Cancelled) != 0 || session.TaskToInstance["task-2"] != "instance-2" {
+		t.Errorf("Cancelled = %v, want the unchanged running task kept", revision.Cancelled)
+	}
+	if !slices.Equal(session.FailedTasks, []string{"task-3"}) {
+		t.Errorf("FailedTasks = %v, want [task-3]", session.FailedTasks)
+	}
+	if session.Objective != "Test objective" {
+		t.Errorf("Objective = %q, want the original when the revision has none", session.Objective)
+	}
+}
+
+func TestCoordinator_RevisePlan_Rejected(t *testing.T) {
+	c, runner, events := newRevisionTestCoordinator(t)
+	session := c.Session()
+	original := session.Plan
+
+	for _, tc := range []struct {
+		name  string
+		tasks []PlannedTask
+	}{
+		// task-4 still depends on task-2, which the revision removes
+		{"dependency on a removed task", []PlannedTask{
+			{ID: "task-1", Title: "Task 1", Description: "Do task 1"},
+			{ID: "task-3", Title: "Task 3", Description: "Do task 3"},
+			{ID: "task-4", Title: "Task 4", Description: "Do task 4", DependsOn: []string{"task-2"}},
+		}},
+		// task-5 waits on task-3, which failed and is unchanged
+		{"dependency on a failed task", []PlannedTask{
+			{ID: "task-1", Title: "Task 1", Description: "Do task 1"},
+			{ID: "task-2", Title: "Task 2", Description: "Do task 2", DependsOn: []string{"task-1"}},
+			{ID: "task-3", Title: "Task 3", Description: "Do task 3"},
+			{ID: "task-4", Title: "Task 4", Description: "Do task 4", DependsOn: []string{"task-2"}},
+			{ID: "task-5", Title: "Task 5", Description: "Do task 5", DependsOn: []string{"task-3"}},
+		}},
+	} {
+		if _, err := c.RevisePlan(&PlanSpec{Tasks: tc.tasks}); err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+	if session.Plan != original || session.TaskToInstance["task-2"] != "instance-2" {
+		t.Error("a rejected revision should leave the plan and running tasks alone")
+	}
+	if len(runner.removed) != 0 || len(runner.added) != 0 || len(*events) != 0 {
+		t.Errorf("a rejected revision changed the queue (-%v +%v) or published events", runner.removed, runner.added)
+	}
+
+	if _, err := c.RevisePlan(nil); err == nil {
+		t.Error("RevisePlan(nil) should error")
+	}
+	c.SetPipelineRunner(&mockExecutionRunner{})
+	if _, err := c.RevisePlan(&PlanSpec{Tasks: original.Tasks}); err == nil {
+		t.Error("expected error from a runner that cannot drop tasks")
+	}
+	session.Phase = PhaseSynthesis
+	if _, err := c.RevisePlan(&PlanSpec{Tasks: original.Tasks}); err == nil {
+		t.Error("expected error outside execution")
+	}
+	session.Plan = nil
+	if _, err := c.RevisePlan(&PlanSpec{}); !errors.Is(err, ErrNoPlan) {
+		t.Errorf("RevisePlan() without a plan error = %v, want ErrNoPlan", err)
+	}
+}
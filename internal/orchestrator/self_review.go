@@ -0,0 +1,75 @@
+package orchestrator
+
+import (
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/prompt"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+)
+
+// SelfReviewSection returns the prompt section asking a task to review its
+// own work before completing, or "" when ultraplan.self_review is disabled.
+func (o *Orchestrator) SelfReviewSection() string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	if o.config == nil || !o.config.Ultraplan.SelfReview {
+		return ""
+	}
+	return prompt.SelfReviewSection()
+}
+
+// recordSelfReview stores the self-review from a task's completion file in
+// the session, so synthesis can look at low-confidence tasks first. Tasks
+// that wrote no self-review, or an invalid one, are left unrecorded.
+func (c *Coordinator) recordSelfReview(taskID, worktreePath string) {
+	if worktreePath == "" {
+		return
+	}
+	completion, err := ParseTaskCompletionFile(worktreePath)
+	if err != nil || completion.SelfReview == nil {
+		return
+	}
+	review := *completion.SelfReview
+	if !review.Valid() {
+		c.logger.Warn("ignoring self-review with invalid confidence",
+			"task_id", taskID,
+			"confidence", review.Confidence,
+		)
+		return
+	}
+
+	session := c.Session()
+	if session == nil {
+		return
+	}
+	c.mu.Lock()
+	if session.TaskSelfReviews == nil {
+		session.TaskSelfReviews = make(map[string]types.SelfReview)
+	}
+	session.TaskSelfReviews[taskID] = review
+	c.mu.Unlock()
+
+	c.logger.Info("task self-review recorded",
+		"task_id", taskID,
+		"confidence", review.Confidence,
+		"concerns", len(review.Concerns),
+	)
+}
+
+// onBridgeTaskCompleted records the self-review of a task completed by the
+// pipeline backend. Must run in its own goroutine (dispatched from the
+// event handler).
+func (c *Coordinator) onBridgeTaskCompleted(e event.BridgeTaskCompletedEvent) {
+	if !e.Success {
+		return
+	}
+	session := c.Session()
+	if session == nil || session.GetTask(e.TaskID) == nil {
+		return
+	}
+	inst := c.orch.GetInstance(e.InstanceID)
+	if inst == nil {
+		return
+	}
+	c.recordSelfReview(e.TaskID, inst.WorktreePath)
+}
@@ -0,0 +1,50 @@
+package orchestrator
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+)
+
+func TestOrchestrator_SelfReviewSection(t *testing.T) {
+	o := &Orchestrator{config: config.Default()}
+	if section := o.SelfReviewSection(); section != "" {
+		t.Errorf("SelfReviewSection() with self_review off = %q, want empty", section)
+	}
+
+	o.config.Ultraplan.SelfReview = true
+	if section := o.SelfReviewSection(); !strings.Contains(section, "## Self-Review Before Completing") {
+		t.Errorf("SelfReviewSection() = %q, want the self-review section", section)
+	}
+}
+
+func TestCoordinator_RecordSelfReview(t *testing.T) {
+	c := newTestCoordinatorForPhaseAdapter(t)
+	session := c.Session()
+
+	writeCompletion := func(t *testing.T, body string) string {
+		t.Helper()
+		dir := t.TempDir()
+		if err := os.WriteFile(TaskCompletionFilePath(dir), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+
+	c.recordSelfReview("task-1", writeCompletion(t, `{"task_id": "task-1", "status": "complete",
+		"self_review": {"confidence": 2, "concerns": ["edge cases untested"]}}`))
+	review, ok := session.TaskSelfReviews["task-1"]
+	if !ok || review.Confidence != 2 || len(review.Concerns) != 1 {
+		t.Errorf("TaskSelfReviews[task-1] = %+v, %v; want confidence 2 with one concern", review, ok)
+	}
+
+	c.recordSelfReview("task-2", writeCompletion(t, `{"task_id": "task-2", "status": "complete",
+		"self_review": {"confidence": 9}}`))
+	c.recordSelfReview("task-2", writeCompletion(t, `{"task_id": "task-2", "status": "complete"}`))
+	c.recordSelfReview("task-2", t.TempDir())
+	if review, ok := session.TaskSelfReviews["task-2"]; ok {
+		t.Errorf("TaskSelfReviews[task-2] = %+v, want no invalid or missing self-review recorded", review)
+	}
+}
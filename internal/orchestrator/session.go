@@ -7,9 +7,13 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Iron-Ham/claudio/internal/orchestrator/escalation"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/workflows/adversarial"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/workflows/ralph"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/workflows/tripleshot"
+	"github.com/Iron-Ham/claudio/internal/pr"
+	"github.com/Iron-Ham/claudio/internal/reaper"
 )
 
 // InstanceStatus represents the current state of an AI backend instance
@@ -40,6 +44,11 @@ type Metrics struct {
 	APICalls     int        `json:"api_calls"`
 	StartTime    *time.Time `json:"start_time,omitempty"`
 	EndTime      *time.Time `json:"end_time,omitempty"`
+
+	// ToolUses and LastTool come from Claude Code hook events. They count
+	// from when this process started receiving them, so they aren't saved.
+	ToolUses int    `json:"-"`
+	LastTool string `json:"-"`
 }
 
 // TotalTokens returns the sum of input and output tokens
@@ -88,6 +97,41 @@ type Instance struct {
 	ClaudeSessionID string     `json:"claude_session_id,omitempty"`
 	LastActiveAt    *time.Time `json:"last_active_at,omitempty"` // Last time output was detected
 	InterruptedAt   *time.Time `json:"interrupted_at,omitempty"` // When session was interrupted (if applicable)
+	ParkedAt        *time.Time `json:"parked_at,omitempty"`      // When the instance was parked (see ParkInstance); nil unless parked
+
+	// Bootstrap records worktree bootstrap timing; nil until bootstrap has run
+	Bootstrap *BootstrapTiming `json:"bootstrap,omitempty"`
+
+	// Backend names the backend this instance runs when it differs from the
+	// session default (e.g., "tool" for scripted plan tasks); empty otherwise
+	Backend string `json:"backend,omitempty"`
+
+	// Node is the worker node a self-hosted backend instance was placed on;
+	// empty when node placement is not configured
+	Node string `json:"node,omitempty"`
+
+	// RepoDir is the checkout the instance's worktree and branch belong to,
+	// for plan tasks in another repository (ultraplan.repos); empty for the
+	// session's repository
+	RepoDir string `json:"repo_dir,omitempty"`
+
+	// Escalation records the recovery steps tried after the instance last
+	// stalled; nil if it never stalled
+	Escalation *escalation.State `json:"escalation,omitempty"`
+}
+
+// BootstrapTiming records how worktree bootstrapping went for an instance
+type BootstrapTiming struct {
+	LinkedDirs  []string `json:"linked_dirs,omitempty"`  // Shared directories symlinked from the main repo
+	CommandsRun int      `json:"commands_run,omitempty"` // Bootstrap commands that succeeded
+	LinkMs      int64    `json:"link_ms"`                // Time spent linking shared directories
+	CommandMs   int64    `json:"command_ms"`             // Time spent running bootstrap commands
+	Error       string   `json:"error,omitempty"`        // Failure reason, if bootstrap did not finish
+}
+
+// Duration returns the total bootstrap time
+func (b *BootstrapTiming) Duration() time.Duration {
+	return time.Duration(b.LinkMs+b.CommandMs) * time.Millisecond
 }
 
 // GetID returns the instance ID (satisfies prworkflow.InstanceInfo).
@@ -125,6 +169,10 @@ const (
 
 // Session represents a Claudio work session
 type Session struct {
+	// FormatVersion is the session.json layout this file was written in
+	// (see migrate.SessionVersion)
+	FormatVersion int `json:"format_version,omitempty"`
+
 	ID        string      `json:"id"`
 	Name      string      `json:"name"`
 	BaseRepo  string      `json:"base_repo"`
@@ -162,6 +210,20 @@ type Session struct {
 	InterruptedAt   *time.Time    `json:"interrupted_at,omitempty"`   // When session was interrupted
 	RecoveredAt     *time.Time    `json:"recovered_at,omitempty"`     // When session was last recovered
 	RecoveryAttempt int           `json:"recovery_attempt,omitempty"` // Number of recovery attempts
+
+	// BranchCleanups records the branches and worktrees removed after the
+	// session's PRs merged, oldest first
+	BranchCleanups []reaper.Record `json:"branch_cleanups,omitempty"`
+
+	// PendingPRs are pull requests whose branches were pushed but which were
+	// not created because gh was missing; `claudio pr create --pending`
+	// creates them
+	PendingPRs []pr.Pending `json:"pending_prs,omitempty"`
+
+	// SentinelProtocol is the completion-file protocol the session's
+	// instances were prompted with (see types.SentinelProtocolVersion);
+	// zero for sessions created before it was recorded, which used version 1
+	SentinelProtocol int `json:"sentinel_protocol,omitempty"`
 }
 
 // NewSession creates a new session with a generated ID
@@ -176,6 +238,8 @@ func NewSession(name, baseRepo string) *Session {
 		BaseRepo:  baseRepo,
 		Created:   time.Now(),
 		Instances: make([]*Instance, 0),
+
+		SentinelProtocol: types.SentinelProtocolVersion,
 	}
 }
 
@@ -0,0 +1,407 @@
+package session
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/Iron-Ham/claudio/internal/session"
+	"github.com/klauspost/compress/zstd"
+)
+
+// ArchiveDir is the directory under .claudio holding session archives.
+const ArchiveDir = "archive"
+
+// archiveExt is the file extension of a session archive.
+const archiveExt = ".tar.zst"
+
+// ErrArchiveNotFound is returned by Archiver.Restore when a session has no
+// archive.
+var ErrArchiveNotFound = errors.New("archive not found")
+
+// ArchivePolicy decides which sessions are archived and how many archives
+// are kept. Zero values disable the corresponding rule.
+type ArchivePolicy struct {
+	// After archives a cleanly stopped session once none of its files has
+	// changed for this long
+	After time.Duration
+	// KeepLast keeps at most this many archives, newest first
+	KeepLast int
+	// MaxTotalBytes deletes the oldest archives until the rest fit
+	MaxTotalBytes int64
+}
+
+// ArchiveInfo describes a session archive on disk.
+type ArchiveInfo struct {
+	SessionID  string
+	Path       string
+	Size       int64
+	ArchivedAt time.Time
+}
+
+// ArchiveRun reports what Archiver.Run did.
+type ArchiveRun struct {
+	Archived []*ArchiveInfo
+	Pruned   []*ArchiveInfo
+}
+
+// Archiver compresses the directories of completed sessions into
+// .claudio/archive/<session-id>.tar.zst and prunes old archives.
+type Archiver struct {
+	baseDir string
+	policy  ArchivePolicy
+	logger  *logging.Logger
+	now     func() time.Time
+}
+
+// NewArchiver creates an archiver for the sessions of the repository at
+// baseDir. The logger may be nil.
+func NewArchiver(baseDir string, policy ArchivePolicy, logger *logging.Logger) *Archiver {
+	return &Archiver{baseDir: baseDir, policy: policy, logger: logger, now: time.Now}
+}
+
+// archiveDir returns the directory archives are written to.
+func (a *Archiver) archiveDir() string {
+	return filepath.Join(a.baseDir, ".claudio", ArchiveDir)
+}
+
+// Run archives every session the policy makes eligible, then prunes the
+// archives. A session that fails to archive is logged and left in place.
+func (a *Archiver) Run() (*ArchiveRun, error) {
+	run := &ArchiveRun{}
+	if a.policy.After > 0 {
+		ids, err := a.eligibleSessions()
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			info, err := a.Archive(id)
+			if err != nil {
+				if a.logger != nil {
+					a.logger.Warn("failed to archive session", "session_id", id, "error", err)
+				}
+				continue
+			}
+			run.Archived = append(run.Archived, info)
+		}
+	}
+
+	pruned, err := a.Prune()
+	if err != nil {
+		return run, err
+	}
+	run.Pruned = pruned
+	return run, nil
+}
+
+// eligibleSessions lists the sessions that are not running, were stopped
+// cleanly, and have not changed for the policy's After duration. Sessions
+// that crashed are left alone so they can still be recovered.
+func (a *Archiver) eligibleSessions() ([]string, error) {
+	sessionsDir := session.GetSessionsDir(a.baseDir)
+	entries, err := os.ReadDir(sessionsDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	cutoff := a.now().Add(-a.policy.After)
+	var ids []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(sessionsDir, entry.Name())
+		if _, locked := session.IsLocked(dir); locked {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, session.SessionFileName))
+		if err != nil {
+			continue
+		}
+		var sess struct {
+			CleanShutdown bool `json:"clean_shutdown"`
+		}
+		if json.Unmarshal(data, &sess) != nil || !sess.CleanShutdown {
+			continue
+		}
+		if modified, err := lastModified(dir); err != nil || modified.After(cutoff) {
+			continue
+		}
+		ids = append(ids, entry.Name())
+	}
+	return ids, nil
+}
+
+// lastModified returns the newest modification time of the files in dir.
+func lastModified(dir string) (time.Time, error) {
+	var latest time.Time
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+		return nil
+	})
+	return latest, err
+}
+
+// Archive compresses the session's directory (session state, logs, mailbox,
+// queue state, transcripts) into an archive and removes the directory. It
+// refuses a running session and replaces an earlier archive of the same
+// session.
+func (a *Archiver) Archive(sessionID string) (*ArchiveInfo, error) {
+	if err := validArchiveID(sessionID); err != nil {
+		return nil, err
+	}
+	dir := session.GetSessionDir(a.baseDir, sessionID)
+	if _, err := os.Stat(filepath.Join(dir, session.SessionFileName)); err != nil {
+		return nil, fmt.Errorf("no session %s: %w", sessionID, err)
+	}
+	if lock, locked := session.IsLocked(dir); locked {
+		return nil, fmt.Errorf("session %s is running (PID %d)", sessionID, lock.PID)
+	}
+
+	if err := os.MkdirAll(a.archiveDir(), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	target := filepath.Join(a.archiveDir(), sessionID+archiveExt)
+
+	// Write beside the target and rename, so an interrupted archive never
+	// leaves a truncated file behind
+	tmp := target + ".tmp"
+	saved, err := writeArchive(tmp, dir)
+	if err == nil {
+		err = os.Rename(tmp, target)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return nil, fmt.Errorf("archive session %s: %w", sessionID, err)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("archive session %s: remove session directory: %w", sessionID, err)
+	}
+
+	info, err := archiveInfo(target)
+	if err != nil {
+		return nil, err
+	}
+	if a.logger != nil {
+		a.logger.Info("session archived", "session_id", sessionID, "path", target, "bytes", info.Size,
+			"deduplicated_bytes", saved)
+	}
+	return info, nil
+}
+
+// writeArchive writes every regular file in dir except the session lock to
+// a zstd-compressed tarball at path, storing chunks shared between large
+// files once. It returns how many bytes deduplication saved.
+func writeArchive(path, dir string) (saved int64, err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	zw, err := zstd.NewWriter(f)
+	if err != nil {
+		return 0, err
+	}
+	tw := tar.NewWriter(zw)
+	cw := newChunkWriter()
+
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() || d.Name() == session.LockFileName {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		modTime := time.Now()
+		if info, err := d.Info(); err == nil {
+			modTime = info.ModTime()
+		}
+		return cw.writeFile(tw, filepath.ToSlash(rel), data, modTime)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := tw.Close(); err != nil {
+		return 0, err
+	}
+	return cw.saved, zw.Close()
+}
+
+// archiveInfo describes the archive at path.
+func archiveInfo(path string) (*ArchiveInfo, error) {
+	st, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	return &ArchiveInfo{
+		SessionID:  strings.TrimSuffix(filepath.Base(path), archiveExt),
+		Path:       path,
+		Size:       st.Size(),
+		ArchivedAt: st.ModTime(),
+	}, nil
+}
+
+// Archives lists the session archives, newest first.
+func (a *Archiver) Archives() ([]*ArchiveInfo, error) {
+	entries, err := os.ReadDir(a.archiveDir())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archives: %w", err)
+	}
+	var archives []*ArchiveInfo
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !strings.HasSuffix(entry.Name(), archiveExt) {
+			continue
+		}
+		info, err := archiveInfo(filepath.Join(a.archiveDir(), entry.Name()))
+		if err != nil {
+			continue
+		}
+		archives = append(archives, info)
+	}
+	slices.SortFunc(archives, func(x, y *ArchiveInfo) int {
+		return y.ArchivedAt.Compare(x.ArchivedAt)
+	})
+	return archives, nil
+}
+
+// Prune deletes archives beyond the policy's KeepLast and, oldest first,
+// until the rest fit in MaxTotalBytes. It returns the deleted archives.
+func (a *Archiver) Prune() ([]*ArchiveInfo, error) {
+	archives, err := a.Archives()
+	if err != nil {
+		return nil, err
+	}
+
+	keep := len(archives)
+	if a.policy.KeepLast > 0 && keep > a.policy.KeepLast {
+		keep = a.policy.KeepLast
+	}
+	if a.policy.MaxTotalBytes > 0 {
+		var total int64
+		for i, info := range archives[:keep] {
+			total += info.Size
+			if total > a.policy.MaxTotalBytes {
+				keep = i
+				break
+			}
+		}
+	}
+
+	var pruned []*ArchiveInfo
+	for _, info := range archives[keep:] {
+		if err := os.Remove(info.Path); err != nil {
+			return pruned, fmt.Errorf("failed to prune archive %s: %w", info.SessionID, err)
+		}
+		pruned = append(pruned, info)
+	}
+	if len(pruned) > 0 && a.logger != nil {
+		a.logger.Info("session archives pruned", "count", len(pruned))
+	}
+	return pruned, nil
+}
+
+// Restore unpacks the archive of sessionID into destDir/<session-id> for
+// inspection and returns that directory. The archive is kept, and the
+// restored directory is not a session Claudio lists or attaches to.
+func (a *Archiver) Restore(sessionID, destDir string) (string, error) {
+	if err := validArchiveID(sessionID); err != nil {
+		return "", err
+	}
+	f, err := os.Open(filepath.Join(a.archiveDir(), sessionID+archiveExt))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("%w: %s", ErrArchiveNotFound, sessionID)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	zr, err := zstd.NewReader(f)
+	if err != nil {
+		return "", fmt.Errorf("read archive: %w", err)
+	}
+	defer zr.Close()
+
+	target := filepath.Join(destDir, sessionID)
+	tr := tar.NewReader(zr)
+	cr := newChunkReader()
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("read archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := validSnapshotPath(hdr.Name); err != nil {
+			return "", err
+		}
+		if hdr.Size > maxSnapshotFileSize {
+			return "", fmt.Errorf("archive entry %s is too large (%d bytes)", hdr.Name, hdr.Size)
+		}
+		p := filepath.Join(target, filepath.FromSlash(hdr.Name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			return "", fmt.Errorf("restore %s: %w", hdr.Name, err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return "", fmt.Errorf("read archive %s: %w", hdr.Name, err)
+		}
+		if data, err = cr.readFile(hdr, data); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(p, data, 0644); err != nil {
+			return "", fmt.Errorf("restore %s: %w", hdr.Name, err)
+		}
+		_ = os.Chtimes(p, hdr.ModTime, hdr.ModTime)
+	}
+	return target, nil
+}
+
+// validArchiveID rejects session IDs that would escape the archive or
+// sessions directory.
+func validArchiveID(id string) error {
+	if id == "" || strings.ContainsAny(id, `/\`) || id == "." || id == ".." {
+		return fmt.Errorf("invalid session ID %q", id)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package session
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	rootsession "github.com/Iron-Ham/claudio/internal/session"
+)
+
+// writeArchivableSession writes a session directory last changed at modTime.
+func writeArchivableSession(t *testing.T, baseDir, id string, cleanShutdown bool, modTime time.Time) string {
+	t.Helper()
+	dir := rootsession.GetSessionDir(baseDir, id)
+	state := `{"id":"` + id + `","instances":[]}`
+	if cleanShutdown {
+		state = `{"id":"` + id + `","instances":[],"clean_shutdown":true}`
+	}
+	writeSessionFile(t, dir, rootsession.SessionFileName, state)
+	writeSessionFile(t, dir, "debug.log", "log line\n")
+	writeSessionFile(t, dir, "mailbox/broadcast/index.jsonl", `{"id":"m1"}`+"\n")
+	writeSessionFile(t, dir, "transcripts/inst-1.cast", "cast\n")
+	err := filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		return os.Chtimes(p, modTime, modTime)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestArchiver_RunAndRestore(t *testing.T) {
+	baseDir := t.TempDir()
+	now := time.Now()
+	old := now.Add(-10 * 24 * time.Hour)
+
+	doneDir := writeArchivableSession(t, baseDir, "done", true, old)
+	crashedDir := writeArchivableSession(t, baseDir, "crashed", false, old)
+	recentDir := writeArchivableSession(t, baseDir, "recent", true, now)
+
+	a := NewArchiver(baseDir, ArchivePolicy{After: 7 * 24 * time.Hour}, nil)
+	run, err := a.Run()
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(run.Archived) != 1 || run.Archived[0].SessionID != "done" {
+		t.Fatalf("Run() archived %+v, want only the cleanly stopped idle session", run.Archived)
+	}
+	if _, err := os.Stat(doneDir); !os.IsNotExist(err) {
+		t.Errorf("archived session directory still exists: %v", err)
+	}
+	for _, dir := range []string{crashedDir, recentDir} {
+		if _, err := os.Stat(dir); err != nil {
+			t.Errorf("session %s should not be archived: %v", filepath.Base(dir), err)
+		}
+	}
+
+	dest := t.TempDir()
+	restored, err := a.Restore("done", dest)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if restored != filepath.Join(dest, "done") {
+		t.Errorf("Restore() = %q, want %q", restored, filepath.Join(dest, "done"))
+	}
+	for _, rel := range []string{rootsession.SessionFileName, "debug.log", "mailbox/broadcast/index.jsonl", "transcripts/inst-1.cast"} {
+		if _, err := os.Stat(filepath.Join(restored, filepath.FromSlash(rel))); err != nil {
+			t.Errorf("restored archive is missing %s: %v", rel, err)
+		}
+	}
+
+	if _, err := a.Restore("crashed", dest); !errors.Is(err, ErrArchiveNotFound) {
+		t.Errorf("Restore() of an unarchived session error = %v, want ErrArchiveNotFound", err)
+	}
+}
+
+func TestArchiver_ArchiveRefusesRunningSession(t *testing.T) {
+	baseDir := t.TempDir()
+	dir := writeArchivableSession(t, baseDir, "live", true, time.Now())
+	lock, err := rootsession.AcquireLock(dir, "live", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = lock.Release() }()
+
+	if _, err := NewArchiver(baseDir, ArchivePolicy{}, nil).Archive("live"); err == nil {
+		t.Error("Archive() of a running session should fail")
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Errorf("running session directory was removed: %v", err)
+	}
+}
+
+func TestArchiver_Prune(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		policy     ArchivePolicy
+		wantKept   []string
+		wantPruned int
+	}{
+		{"no limits", ArchivePolicy{}, []string{"s3", "s2", "s1"}, 0},
+		{"keep last", ArchivePolicy{KeepLast: 2}, []string{"s3", "s2"}, 1},
+		{"max total size", ArchivePolicy{MaxTotalBytes: 250}, []string{"s3", "s2"}, 1},
+		{"both", ArchivePolicy{KeepLast: 2, MaxTotalBytes: 150}, []string{"s3"}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseDir := t.TempDir()
+			archiveDir := filepath.Join(baseDir, ".claudio", ArchiveDir)
+			for i, id := range []string{"s1", "s2", "s3"} {
+				p := filepath.Join(archiveDir, id+archiveExt)
+				writeSessionFile(t, archiveDir, id+archiveExt, string(make([]byte, 100)))
+				at := now.Add(time.Duration(i-3) * time.Hour)
+				if err := os.Chtimes(p, at, at); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			a := NewArchiver(baseDir, tt.policy, nil)
+			pruned, err := a.Prune()
+			if err != nil {
+				t.Fatalf("Prune() error = %v", err)
+			}
+			if len(pruned) != tt.wantPruned {
+				t.Errorf("Prune() pruned %d archives, want %d", len(pruned), tt.wantPruned)
+			}
+			archives, err := a.Archives()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var kept []string
+			for _, info := range archives {
+				kept = append(kept, info.SessionID)
+			}
+			if len(kept) != len(tt.wantKept) {
+				t.Fatalf("kept %v, want %v", kept, tt.wantKept)
+			}
+			for i := range kept {
+				if kept[i] != tt.wantKept[i] {
+					t.Errorf("kept %v, want %v", kept, tt.wantKept)
+					break
+				}
+			}
+		})
+	}
+}
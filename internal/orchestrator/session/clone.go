@@ -0,0 +1,106 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/grouptypes"
+	"github.com/Iron-Ham/claudio/internal/session"
+)
+
+// CloneOptions configures Manager.CloneSession.
+type CloneOptions struct {
+	// SessionID is the new session's ID. A random one is generated if empty.
+	SessionID string
+
+	// Name names the new session. The source session's name is kept if empty.
+	Name string
+}
+
+// CloneSession copies the session sourceID into a new multi-session session
+// in the manager's repository, so a recurring workflow can be re-run without
+// setting it up again. The clone keeps the source's base repository, a new
+// pending instance for each source instance's task, the instance groups, and
+// the context file. Instance state (worktrees, branches, status, metrics) and
+// ultra-plan state are not copied. The new session is saved but not locked.
+func (m *Manager) CloneSession(sourceID string, opts CloneOptions) (*SessionData, error) {
+	if sourceID == "" {
+		return nil, fmt.Errorf("clone session: source session ID is required")
+	}
+	src := NewManager(Config{BaseDir: m.baseDir, SessionID: sourceID, Logger: m.logger, Backend: m.backend})
+	srcSess, err := src.LoadSession()
+	if err != nil {
+		return nil, fmt.Errorf("clone session %s: %w", sourceID, err)
+	}
+
+	id := opts.SessionID
+	if id == "" {
+		id = generateID()
+	}
+	if id == sourceID || session.SessionExists(m.baseDir, id) {
+		return nil, fmt.Errorf("clone session %s: %w: %s", sourceID, ErrSessionExists, id)
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = srcSess.Name
+	}
+	clone := NewSessionData(name, srcSess.BaseRepo)
+	clone.ID = id
+
+	// Instances get new IDs, so groups are rewritten to refer to them
+	instanceIDs := make(map[string]string, len(srcSess.Instances))
+	for _, inst := range srcSess.Instances {
+		cloned := NewInstanceData(inst.Task)
+		instanceIDs[inst.ID] = cloned.ID
+		clone.Instances = append(clone.Instances, cloned)
+	}
+	for _, g := range srcSess.ValidateGroups() {
+		clone.Groups = append(clone.Groups, cloneGroup(g, instanceIDs, clone.Created))
+	}
+
+	dst := NewManager(Config{BaseDir: m.baseDir, SessionID: id, Logger: m.logger, Backend: m.backend})
+	if err := dst.Init(); err != nil {
+		return nil, err
+	}
+	if err := dst.SaveSession(clone); err != nil {
+		return nil, err
+	}
+
+	ctx, err := os.ReadFile(src.ContextFilePath())
+	switch {
+	case err == nil:
+		if err := dst.WriteContext(string(ctx)); err != nil {
+			return nil, err
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, fmt.Errorf("clone session %s: read context file: %w", sourceID, err)
+	}
+
+	if m.logger != nil {
+		m.logger.Info("session cloned",
+			"source_session_id", sourceID,
+			"session_id", id,
+			"instance_count", len(clone.Instances),
+		)
+	}
+	return clone, nil
+}
+
+// cloneGroup copies g and its sub-groups as pending groups whose instance
+// IDs are mapped through instanceIDs.
+func cloneGroup(g *InstanceGroup, instanceIDs map[string]string, created time.Time) *InstanceGroup {
+	c := g.Clone()
+	c.Phase = grouptypes.GroupPhasePending
+	c.Created = created
+	for i, id := range c.Instances {
+		c.Instances[i] = instanceIDs[id]
+	}
+	for i, sg := range g.SubGroups {
+		c.SubGroups[i] = cloneGroup(sg, instanceIDs, created)
+	}
+	return c
+}
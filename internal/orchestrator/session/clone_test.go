@@ -0,0 +1,112 @@
+package session
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/grouptypes"
+)
+
+func TestManager_CloneSession(t *testing.T) {
+	baseDir := t.TempDir()
+
+	src := NewManager(Config{BaseDir: baseDir, SessionID: "weekly-bumps"})
+	sess, err := src.CreateSession("Weekly bumps", baseDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = src.ReleaseLock() }()
+
+	api := NewInstanceData("Bump deps in api")
+	api.WorktreePath = "/tmp/wt/api"
+	api.Branch = "bump-api"
+	api.Status = "completed"
+	api.Metrics = &MetricsData{InputTokens: 100}
+	web := NewInstanceData("Bump deps in web")
+	sess.Instances = append(sess.Instances, api, web)
+	group := grouptypes.NewInstanceGroup("g1", "Services")
+	group.Phase = grouptypes.GroupPhaseCompleted
+	group.Instances = []string{api.ID, web.ID}
+	sess.Groups = []*InstanceGroup{group}
+	if err := src.SaveSession(sess); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.WriteContext("# Session Context\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	mgr := NewManager(Config{BaseDir: baseDir})
+	clone, err := mgr.CloneSession("weekly-bumps", CloneOptions{SessionID: "weekly-bumps-2"})
+	if err != nil {
+		t.Fatalf("CloneSession() error = %v", err)
+	}
+
+	if clone.ID != "weekly-bumps-2" || clone.Name != "Weekly bumps" || clone.BaseRepo != baseDir {
+		t.Errorf("clone = %q %q %q, want weekly-bumps-2, the source name and base repo", clone.ID, clone.Name, clone.BaseRepo)
+	}
+	if len(clone.Instances) != 2 {
+		t.Fatalf("clone has %d instances, want 2", len(clone.Instances))
+	}
+	got := clone.Instances[0]
+	if got.Task != "Bump deps in api" || got.Status != "pending" || got.WorktreePath != "" || got.Branch != "" || got.Metrics != nil {
+		t.Errorf("cloned instance = %+v, want only the task copied", got)
+	}
+	if got.ID == api.ID {
+		t.Error("cloned instance kept the source instance ID")
+	}
+	if len(clone.Groups) != 1 {
+		t.Fatalf("clone has %d groups, want 1", len(clone.Groups))
+	}
+	if g := clone.Groups[0]; g.Phase != grouptypes.GroupPhasePending ||
+		len(g.Instances) != 2 || g.Instances[0] != got.ID || g.Instances[1] != clone.Instances[1].ID {
+		t.Errorf("cloned group = %+v, want a pending group of the cloned instances", g)
+	}
+
+	dst := NewManager(Config{BaseDir: baseDir, SessionID: "weekly-bumps-2"})
+	loaded, err := dst.LoadSession()
+	if err != nil {
+		t.Fatalf("LoadSession() of clone error = %v", err)
+	}
+	if len(loaded.Instances) != 2 {
+		t.Errorf("saved clone has %d instances, want 2", len(loaded.Instances))
+	}
+	if ctx, err := os.ReadFile(dst.ContextFilePath()); err != nil || string(ctx) != "# Session Context\n" {
+		t.Errorf("clone context = %q, %v; want the source context", ctx, err)
+	}
+	if dst.HasLock() {
+		t.Error("clone should not be locked")
+	}
+
+	// The source is unchanged
+	reloaded, err := src.LoadSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Instances[0].Status != "completed" {
+		t.Errorf("source instance status = %q, want completed", reloaded.Instances[0].Status)
+	}
+
+	t.Run("generated ID and name", func(t *testing.T) {
+		clone, err := mgr.CloneSession("weekly-bumps", CloneOptions{Name: "Bumps again"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if clone.ID == "" || clone.ID == "weekly-bumps" || clone.Name != "Bumps again" {
+			t.Errorf("clone ID %q, name %q", clone.ID, clone.Name)
+		}
+	})
+
+	t.Run("existing target", func(t *testing.T) {
+		_, err := mgr.CloneSession("weekly-bumps", CloneOptions{SessionID: "weekly-bumps-2"})
+		if !errors.Is(err, ErrSessionExists) {
+			t.Errorf("CloneSession() error = %v, want ErrSessionExists", err)
+		}
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		if _, err := mgr.CloneSession("nope", CloneOptions{}); err == nil {
+			t.Error("CloneSession() of a missing session should fail")
+		}
+	})
+}
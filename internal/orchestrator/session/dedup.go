@@ -0,0 +1,194 @@
+package session
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Session archives store large files as content-defined chunks. Instances
+// working on the same repository often print the same build and test output,
+// so their transcripts share long runs of bytes; a chunk already written for
+// one file is referenced by every later file instead of being stored again.
+//
+// A deduplicated file is one tar entry carrying the dedupPAXKey record,
+// whose value is the length of the manifest at the start of the entry's
+// data. The manifest has one "<hash> <length>" line per chunk, in file
+// order; the chunks not seen earlier in the archive follow it, in the same
+// order. Entries without the record are plain files, so archives written
+// before deduplication still restore.
+const (
+	// dedupPAXKey marks a deduplicated tar entry.
+	dedupPAXKey = "CLAUDIO.dedup"
+
+	// dedupMinFileSize is the smallest file stored as chunks; smaller files
+	// gain little and are written plain.
+	dedupMinFileSize = 16 << 10
+
+	// chunkMinSize and chunkMaxSize bound a chunk. Boundaries otherwise fall
+	// where the rolling hash matches chunkMask, about every 2 KiB, so they
+	// follow the content and realign after an insertion (such as a frame
+	// timestamp) instead of shifting every later chunk.
+	chunkMinSize = 512
+	chunkMaxSize = 16 << 10
+	chunkMask    = uint64(1<<11-1) << 53
+
+	// chunkHashLen is how many bytes of a chunk's SHA-256 identify it.
+	chunkHashLen = 16
+)
+
+// chunkKey identifies a chunk by its truncated SHA-256.
+type chunkKey [chunkHashLen]byte
+
+func newChunkKey(chunk []byte) chunkKey {
+	sum := sha256.Sum256(chunk)
+	return chunkKey(sum[:chunkHashLen])
+}
+
+// gearTable holds the per-byte values of the rolling gear hash, generated
+// with splitmix64 from a fixed seed so chunk boundaries never change
+// between builds.
+var gearTable = func() (table [256]uint64) {
+	seed := uint64(0x9E3779B97F4A7C15)
+	for i := range table {
+		seed += 0x9E3779B97F4A7C15
+		z := seed
+		z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+		z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+		table[i] = z ^ (z >> 31)
+	}
+	return table
+}()
+
+// chunkBoundary returns the length of the chunk at the start of data.
+func chunkBoundary(data []byte) int {
+	if len(data) <= chunkMinSize {
+		return len(data)
+	}
+	limit := min(len(data), chunkMaxSize)
+	var h uint64
+	for i := range limit {
+		h = h<<1 + gearTable[data[i]]
+		if i >= chunkMinSize && h&chunkMask == 0 {
+			return i + 1
+		}
+	}
+	return limit
+}
+
+// splitChunks splits data at content-defined boundaries.
+func splitChunks(data []byte) [][]byte {
+	var chunks [][]byte
+	for len(data) > 0 {
+		n := chunkBoundary(data)
+		chunks = append(chunks, data[:n])
+		data = data[n:]
+	}
+	return chunks
+}
+
+// chunkWriter writes archive entries, storing each chunk of a large file
+// only the first time the archive sees it.
+type chunkWriter struct {
+	seen  map[chunkKey]bool
+	saved int64 // Bytes not written because an earlier entry holds them
+}
+
+func newChunkWriter() *chunkWriter {
+	return &chunkWriter{seen: make(map[chunkKey]bool)}
+}
+
+// writeFile writes data as the entry name, deduplicated if it is large
+// enough.
+func (w *chunkWriter) writeFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	if len(data) < dedupMinFileSize {
+		return writeTarFile(tw, name, data, modTime)
+	}
+
+	var manifest, fresh bytes.Buffer
+	for _, chunk := range splitChunks(data) {
+		key := newChunkKey(chunk)
+		fmt.Fprintf(&manifest, "%x %d\n", key, len(chunk))
+		if w.seen[key] {
+			w.saved += int64(len(chunk))
+			continue
+		}
+		w.seen[key] = true
+		fresh.Write(chunk)
+	}
+
+	hdr := &tar.Header{
+		Name:       name,
+		Mode:       0644,
+		Size:       int64(manifest.Len() + fresh.Len()),
+		ModTime:    modTime,
+		PAXRecords: map[string]string{dedupPAXKey: strconv.Itoa(manifest.Len())},
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("write archive %s: %w", name, err)
+	}
+	for _, part := range [][]byte{manifest.Bytes(), fresh.Bytes()} {
+		if _, err := tw.Write(part); err != nil {
+			return fmt.Errorf("write archive %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// chunkReader rebuilds deduplicated entries, remembering the chunks of
+// every entry it has read.
+type chunkReader struct {
+	chunks map[chunkKey][]byte
+}
+
+func newChunkReader() *chunkReader {
+	return &chunkReader{chunks: make(map[chunkKey][]byte)}
+}
+
+// readFile returns the content of the entry hdr whose data is payload.
+func (r *chunkReader) readFile(hdr *tar.Header, payload []byte) ([]byte, error) {
+	value, ok := hdr.PAXRecords[dedupPAXKey]
+	if !ok {
+		return payload, nil
+	}
+	manifestLen, err := strconv.Atoi(value)
+	if err != nil || manifestLen < 0 || manifestLen > len(payload) {
+		return nil, fmt.Errorf("archive entry %s has a bad chunk manifest length %q", hdr.Name, value)
+	}
+
+	manifest, fresh := payload[:manifestLen], payload[manifestLen:]
+	var out []byte
+	for line := range bytes.Lines(manifest) {
+		var hash string
+		var n int
+		if _, err := fmt.Sscanf(string(line), "%s %d", &hash, &n); err != nil {
+			return nil, fmt.Errorf("archive entry %s has a bad chunk manifest: %w", hdr.Name, err)
+		}
+		b, err := hex.DecodeString(hash)
+		if err != nil || len(b) != chunkHashLen {
+			return nil, fmt.Errorf("archive entry %s has a bad chunk hash %q", hdr.Name, hash)
+		}
+		key := chunkKey(b)
+
+		chunk, ok := r.chunks[key]
+		if !ok {
+			if n < 0 || n > len(fresh) {
+				return nil, fmt.Errorf("archive entry %s is missing chunk %s", hdr.Name, hash)
+			}
+			chunk, fresh = fresh[:n], fresh[n:]
+			if newChunkKey(chunk) != key {
+				return nil, fmt.Errorf("archive entry %s has a corrupt chunk %s", hdr.Name, hash)
+			}
+			r.chunks[key] = chunk
+		}
+		if len(out)+len(chunk) > maxSnapshotFileSize {
+			return nil, fmt.Errorf("archive entry %s is too large", hdr.Name)
+		}
+		out = append(out, chunk...)
+	}
+	return out, nil
+}
@@ -0,0 +1,131 @@
+package session
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// randomBytes returns n bytes that compress poorly, like real build output
+// after zstd has had its pass.
+func randomBytes(n int) []byte {
+	r := rand.New(rand.NewPCG(1, 2))
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(r.UintN(256))
+	}
+	return b
+}
+
+func TestChunkWriter_RoundTrip(t *testing.T) {
+	shared := randomBytes(256 << 10)
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{"transcripts/inst-1.cast", append([]byte("[0.5, \"o\", \"inst-1\"]\n"), shared...)},
+		{"transcripts/inst-2.cast", append(append([]byte("[12.25, \"o\", \"inst-2 started later\"]\n"), shared...), "done\n"...)},
+		{"debug.log", []byte("small file\n")},
+	}
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	cw := newChunkWriter()
+	for _, f := range files {
+		if err := cw.writeFile(tw, f.name, f.data, time.Now()); err != nil {
+			t.Fatalf("writeFile(%s) error = %v", f.name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if cw.saved < int64(len(shared))*9/10 {
+		t.Errorf("saved = %d bytes, want most of the %d shared bytes", cw.saved, len(shared))
+	}
+
+	tr := tar.NewReader(&buf)
+	cr := newChunkReader()
+	for _, f := range files {
+		hdr, err := tr.Next()
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		payload, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.name == "transcripts/inst-2.cast" && hdr.Size > int64(len(f.data))/4 {
+			t.Errorf("%s entry is %d bytes, want it to reference the shared chunks", f.name, hdr.Size)
+		}
+		got, err := cr.readFile(hdr, payload)
+		if err != nil {
+			t.Fatalf("readFile(%s) error = %v", f.name, err)
+		}
+		if !bytes.Equal(got, f.data) {
+			t.Errorf("%s restored %d bytes that differ from the %d written", f.name, len(got), len(f.data))
+		}
+	}
+}
+
+func TestChunkReader_RejectsCorruptChunk(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := newChunkWriter().writeFile(tw, "big.log", randomBytes(dedupMinFileSize), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tr := tar.NewReader(&buf)
+	hdr, err := tr.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := io.ReadAll(tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload[len(payload)-1] ^= 0xff
+	if _, err := newChunkReader().readFile(hdr, payload); err == nil {
+		t.Error("readFile() of a corrupt chunk should fail")
+	}
+}
+
+func TestArchiver_DeduplicatesTranscripts(t *testing.T) {
+	baseDir := t.TempDir()
+	old := time.Now().Add(-10 * 24 * time.Hour)
+	dir := writeArchivableSession(t, baseDir, "done", true, old)
+
+	shared := string(randomBytes(512 << 10))
+	writeSessionFile(t, dir, "transcripts/inst-1.cast", "[1.0, \"o\", \"a\"]\n"+shared)
+	writeSessionFile(t, dir, "transcripts/inst-2.cast", "[2.0, \"o\", \"b\"]\n"+shared)
+
+	a := NewArchiver(baseDir, ArchivePolicy{}, nil)
+	info, err := a.Archive("done")
+	if err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	if info.Size > int64(len(shared))*3/2 {
+		t.Errorf("archive is %d bytes, want the %d shared bytes stored once", info.Size, len(shared))
+	}
+
+	restored, err := a.Restore("done", t.TempDir())
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	for _, rel := range []string{"transcripts/inst-1.cast", "transcripts/inst-2.cast"} {
+		data, err := os.ReadFile(filepath.Join(restored, filepath.FromSlash(rel)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data) != len(shared)+len("[1.0, \"o\", \"a\"]\n") || string(data[len(data)-len(shared):]) != shared {
+			t.Errorf("restored %s does not match the original", rel)
+		}
+	}
+}
@@ -14,6 +14,9 @@
 //   - [SessionData]: Serializable session state for persistence
 //   - [InstanceData]: Instance information for persistence
 //   - [MetricsData]: Instance resource usage metrics
+//   - [SnapshotManifest]: Contents of a session snapshot tarball
+//   - [CloneOptions]: New ID and name for a cloned session
+//   - [Archiver]: Compresses completed sessions and prunes old archives
 //
 // # Session Modes
 //
@@ -75,6 +78,39 @@
 //	}
 //	defer mgr.ReleaseLock()
 //
+// # Snapshots
+//
+// [Manager.Snapshot] writes a multi-session session's state files to a
+// gzipped tarball, and [RestoreSnapshot] unpacks one into another checkout
+// of the repository, rewriting paths under the original repository:
+//
+//	manifest, err := mgr.Snapshot(f)
+//	...
+//	manifest, err = session.RestoreSnapshot(f, "/path/to/checkout", session.RestoreOptions{})
+//
+// # Archives
+//
+// [Archiver] compresses the directories of cleanly stopped, idle sessions
+// into .claudio/archive/<session-id>.tar.zst per an [ArchivePolicy], prunes
+// old archives, and unpacks one for inspection:
+//
+//	a := session.NewArchiver("/path/to/repo", session.ArchivePolicy{After: 14 * 24 * time.Hour}, logger)
+//	run, err := a.Run()
+//	...
+//	dir, err := a.Restore("weekly-bumps", "/tmp/inspect")
+//
+// Large files are split into content-defined chunks, and a chunk already in
+// the archive is referenced instead of stored again, so instances that
+// printed the same build output don't each pay for it.
+//
+// # Cloning
+//
+// [Manager.CloneSession] starts a new session from an existing one's setup,
+// for workflows that recur. Instance tasks, groups and the context file are
+// copied; worktrees, branches, status and metrics are not:
+//
+//	clone, err := mgr.CloneSession("weekly-bumps", session.CloneOptions{Name: "Weekly bumps"})
+//
 // # Context Files
 //
 // The manager also handles context files that help backend instances
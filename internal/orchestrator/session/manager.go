@@ -23,6 +23,22 @@ type Manager struct {
 	sessionID  string // Current session ID (empty for legacy single-session mode)
 	logger     *logging.Logger
 	lock       *session.Lock
+	backend    Backend
+}
+
+// Backend is a second store for session state, such as the SQLite history
+// database in internal/store. The session file stays the source of truth
+// for a running session: the backend receives every save, and serves
+// sessions whose file no longer exists.
+type Backend interface {
+	// SaveSession records the session's current state.
+	SaveSession(sess *SessionData) error
+
+	// LoadSession returns the last recorded state of a session.
+	LoadSession(sessionID string) (*SessionData, error)
+
+	// DeleteSession marks a session deleted.
+	DeleteSession(sessionID string) error
 }
 
 // Config holds configuration options for creating a Manager.
@@ -30,6 +46,7 @@ type Config struct {
 	BaseDir   string
 	SessionID string          // Optional: for multi-session support
 	Logger    *logging.Logger // Optional: for structured logging
+	Backend   Backend         // Optional: also persist sessions here
 }
 
 // NewManager creates a new session Manager with the given configuration.
@@ -40,6 +57,7 @@ func NewManager(cfg Config) *Manager {
 		baseDir:    cfg.BaseDir,
 		claudioDir: claudioDir,
 		logger:     cfg.Logger,
+		backend:    cfg.Backend,
 	}
 
 	// Set up session-specific directory if using multi-session mode
@@ -155,6 +173,9 @@ func (m *Manager) LoadSession() (*SessionData, error) {
 	sessionFile := m.SessionFilePath()
 
 	data, err := os.ReadFile(sessionFile)
+	if os.IsNotExist(err) && m.backend != nil && m.sessionID != "" {
+		return m.loadFromBackend()
+	}
 	if err != nil {
 		if m.logger != nil {
 			m.logger.Error("failed to read session file",
@@ -191,6 +212,29 @@ func (m *Manager) LoadSession() (*SessionData, error) {
 	return &sess, nil
 }
 
+// loadFromBackend loads the session from the backend when its file is gone.
+func (m *Manager) loadFromBackend() (*SessionData, error) {
+	sess, err := m.backend.LoadSession(m.sessionID)
+	if err != nil {
+		if m.logger != nil {
+			m.logger.Error("failed to load session from backend",
+				"session_id", m.sessionID,
+				"error", err,
+			)
+		}
+		return nil, fmt.Errorf("failed to load session from backend: %w", err)
+	}
+
+	if m.logger != nil {
+		m.logger.Info("session loaded from backend",
+			"session_id", sess.ID,
+			"instance_count", len(sess.Instances),
+		)
+	}
+
+	return sess, nil
+}
+
 // LoadSessionWithLock acquires a lock and then loads the session.
 // This is the recommended way to load a session in multi-session mode.
 func (m *Manager) LoadSessionWithLock() (*SessionData, error) {
@@ -230,6 +274,16 @@ func (m *Manager) SaveSession(sess *SessionData) error {
 		m.logger.Debug("session saved", "file_path", sessionFile)
 	}
 
+	// The file is saved; a backend failure only costs history
+	if m.backend != nil {
+		if err := m.backend.SaveSession(sess); err != nil && m.logger != nil {
+			m.logger.Warn("failed to save session to backend",
+				"session_id", sess.ID,
+				"error", err,
+			)
+		}
+	}
+
 	return nil
 }
 
@@ -304,6 +358,15 @@ func (m *Manager) DeleteSession() error {
 		return fmt.Errorf("failed to delete session file: %w", err)
 	}
 
+	if m.backend != nil && m.sessionID != "" {
+		if err := m.backend.DeleteSession(m.sessionID); err != nil && m.logger != nil {
+			m.logger.Warn("failed to delete session from backend",
+				"session_id", m.sessionID,
+				"error", err,
+			)
+		}
+	}
+
 	if m.logger != nil {
 		m.logger.Info("session deleted", "session_id", m.sessionID)
 	}
@@ -316,6 +379,16 @@ func (m *Manager) SetLogger(logger *logging.Logger) {
 	m.logger = logger
 }
 
+// SetBackend sets or clears the backend sessions are also persisted to.
+func (m *Manager) SetBackend(b Backend) {
+	m.backend = b
+}
+
+// Backend returns the backend sessions are also persisted to, or nil.
+func (m *Manager) Backend() Backend {
+	return m.backend
+}
+
 // Logger returns the current logger, or nil if logging is disabled.
 func (m *Manager) Logger() *logging.Logger {
 	return m.logger
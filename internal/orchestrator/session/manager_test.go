@@ -313,6 +313,82 @@ func TestManager_DeleteSession(t *testing.T) {
 	}
 }
 
+// memBackend is an in-memory Backend for tests.
+type memBackend struct {
+	sessions map[string]*SessionData
+	deleted  map[string]bool
+}
+
+func newMemBackend() *memBackend {
+	return &memBackend{sessions: make(map[string]*SessionData), deleted: make(map[string]bool)}
+}
+
+func (b *memBackend) SaveSession(sess *SessionData) error {
+	b.sessions[sess.ID] = sess
+	return nil
+}
+
+func (b *memBackend) LoadSession(id string) (*SessionData, error) {
+	sess, ok := b.sessions[id]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return sess, nil
+}
+
+func (b *memBackend) DeleteSession(id string) error {
+	b.deleted[id] = true
+	return nil
+}
+
+func TestManager_Backend(t *testing.T) {
+	tempDir := t.TempDir()
+	backend := newMemBackend()
+
+	mgr := NewManager(Config{
+		BaseDir:   tempDir,
+		SessionID: "test-session",
+		Backend:   backend,
+	})
+	sess, err := mgr.CreateSession("Test", tempDir)
+	if err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	if backend.sessions["test-session"] != sess {
+		t.Fatal("CreateSession() should save the session to the backend")
+	}
+	if err := mgr.ReleaseLock(); err != nil {
+		t.Fatalf("ReleaseLock() error = %v", err)
+	}
+
+	// Without a session file, the session is served by the backend
+	if err := os.Remove(mgr.SessionFilePath()); err != nil {
+		t.Fatalf("remove session file: %v", err)
+	}
+	loaded, err := mgr.LoadSession()
+	if err != nil {
+		t.Fatalf("LoadSession() error = %v", err)
+	}
+	if loaded.ID != "test-session" || loaded.Name != "Test" {
+		t.Errorf("LoadSession() = %s %q, want test-session \"Test\"", loaded.ID, loaded.Name)
+	}
+
+	if err := mgr.DeleteSession(); err != nil {
+		t.Fatalf("DeleteSession() error = %v", err)
+	}
+	if !backend.deleted["test-session"] {
+		t.Error("DeleteSession() should mark the session deleted in the backend")
+	}
+}
+
+func TestManager_LoadSession_NoBackend(t *testing.T) {
+	mgr := NewManager(Config{BaseDir: t.TempDir(), SessionID: "missing"})
+
+	if _, err := mgr.LoadSession(); err == nil {
+		t.Error("LoadSession() should fail without a session file or backend")
+	}
+}
+
 func TestManager_WriteAndLoadContext(t *testing.T) {
 	tempDir := t.TempDir()
 
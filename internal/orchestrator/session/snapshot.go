@@ -0,0 +1,332 @@
+package session
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/session"
+	"github.com/Iron-Ham/claudio/internal/session/migrate"
+)
+
+// SnapshotManifestName is the first entry of a snapshot tarball.
+const SnapshotManifestName = "snapshot.json"
+
+// SnapshotVersion is the snapshot format this release writes and reads.
+const SnapshotVersion = 1
+
+// snapshotMailboxDir is the session subdirectory holding inter-instance mail.
+const snapshotMailboxDir = "mailbox"
+
+// maxSnapshotFileSize bounds a single restored file, so a corrupt tarball
+// cannot fill the disk.
+const maxSnapshotFileSize = 256 << 20
+
+// ErrSessionExists is returned by RestoreSnapshot when the snapshot's session
+// already exists and RestoreOptions.Force is not set.
+var ErrSessionExists = errors.New("session already exists")
+
+// SnapshotManifest describes a session snapshot.
+type SnapshotManifest struct {
+	FormatVersion int       `json:"format_version"`
+	SessionID     string    `json:"session_id"`
+	BaseRepo      string    `json:"base_repo"` // Repository the session ran in
+	CreatedAt     time.Time `json:"created_at"`
+	Files         []string  `json:"files"` // Slash-separated, relative to the session directory
+}
+
+// Snapshot writes the session's state to w as a gzipped tarball: session.json
+// (including task retry and consolidation state), every task queue state file,
+// and the mailbox. Logs, locks and upgrade backups are left out. It requires
+// multi-session mode.
+func (m *Manager) Snapshot(w io.Writer) (*SnapshotManifest, error) {
+	if m.sessionDir == "" {
+		return nil, fmt.Errorf("snapshots require a multi-session session")
+	}
+	files, err := snapshotFiles(m.sessionDir)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &SnapshotManifest{
+		FormatVersion: SnapshotVersion,
+		SessionID:     m.sessionID,
+		BaseRepo:      m.baseDir,
+		CreatedAt:     time.Now().UTC(),
+		Files:         files,
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal snapshot manifest: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	if err := writeTarFile(tw, SnapshotManifestName, data, manifest.CreatedAt); err != nil {
+		return nil, err
+	}
+	for _, rel := range files {
+		p := filepath.Join(m.sessionDir, filepath.FromSlash(rel))
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot %s: %w", rel, err)
+		}
+		modTime := manifest.CreatedAt
+		if info, err := os.Stat(p); err == nil {
+			modTime = info.ModTime()
+		}
+		if err := writeTarFile(tw, rel, data, modTime); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("write snapshot: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("write snapshot: %w", err)
+	}
+
+	if m.logger != nil {
+		m.logger.Info("session snapshot written", "session_id", m.sessionID, "files", len(files))
+	}
+	return manifest, nil
+}
+
+// snapshotFiles lists the state files of the session in dir, sorted by path.
+func snapshotFiles(dir string) ([]string, error) {
+	if _, err := os.Stat(filepath.Join(dir, session.SessionFileName)); err != nil {
+		return nil, fmt.Errorf("no session in %s: %w", dir, err)
+	}
+	var files []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if rel == migrate.BackupsDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		switch {
+		case rel == session.SessionFileName,
+			d.Name() == migrate.QueueFileName,
+			strings.HasPrefix(rel, snapshotMailboxDir+"/"):
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan session directory: %w", err)
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+func writeTarFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("write snapshot %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("write snapshot %s: %w", name, err)
+	}
+	return nil
+}
+
+// RestoreOptions configures RestoreSnapshot.
+type RestoreOptions struct {
+	// Force replaces a session with the same ID that exists in baseDir.
+	Force bool
+}
+
+// RestoreSnapshot unpacks a snapshot written by Manager.Snapshot into
+// baseDir's .claudio/sessions/<session-id>. Paths under the snapshot's
+// original repository (the base repo and instance worktrees) are rewritten
+// to point into baseDir. Worktrees themselves are not part of a snapshot.
+// A running session is never replaced, and an existing one only with Force.
+func RestoreSnapshot(r io.Reader, baseDir string, opts RestoreOptions) (*SnapshotManifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+	tr := tar.NewReader(gz)
+
+	manifest, err := readSnapshotManifest(tr)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionDir := session.GetSessionDir(baseDir, manifest.SessionID)
+	if lock, locked := session.IsLocked(sessionDir); locked {
+		return nil, fmt.Errorf("session %s is running (PID %d); stop it before restoring", manifest.SessionID, lock.PID)
+	}
+	if session.SessionExists(baseDir, manifest.SessionID) && !opts.Force {
+		return nil, fmt.Errorf("%w: %s", ErrSessionExists, manifest.SessionID)
+	}
+
+	// Unpack beside the session directory, then swap it in, so a failed
+	// restore leaves any existing session untouched
+	staging := sessionDir + ".restore"
+	if err := os.RemoveAll(staging); err != nil {
+		return nil, fmt.Errorf("clear restore directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(staging) }()
+
+	listed := make(map[string]bool, len(manifest.Files))
+	for _, f := range manifest.Files {
+		listed[f] = true
+	}
+	rewrite := pathRewriter(manifest.BaseRepo, baseDir)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read snapshot: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || !listed[hdr.Name] {
+			return nil, fmt.Errorf("snapshot entry %q is not in its manifest", hdr.Name)
+		}
+		if err := validSnapshotPath(hdr.Name); err != nil {
+			return nil, err
+		}
+		if hdr.Size > maxSnapshotFileSize {
+			return nil, fmt.Errorf("snapshot entry %s is too large (%d bytes)", hdr.Name, hdr.Size)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("read snapshot %s: %w", hdr.Name, err)
+		}
+		if path.Base(hdr.Name) == session.SessionFileName || path.Base(hdr.Name) == migrate.QueueFileName {
+			if data, err = rewrite(data); err != nil {
+				return nil, fmt.Errorf("restore %s: %w", hdr.Name, err)
+			}
+		}
+		target := filepath.Join(staging, filepath.FromSlash(hdr.Name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, fmt.Errorf("restore %s: %w", hdr.Name, err)
+		}
+		if err := os.WriteFile(target, data, 0644); err != nil {
+			return nil, fmt.Errorf("restore %s: %w", hdr.Name, err)
+		}
+		_ = os.Chtimes(target, hdr.ModTime, hdr.ModTime)
+		delete(listed, hdr.Name)
+	}
+	if len(listed) > 0 {
+		return nil, fmt.Errorf("snapshot is truncated: %d file(s) missing", len(listed))
+	}
+
+	if err := os.RemoveAll(sessionDir); err != nil {
+		return nil, fmt.Errorf("replace session %s: %w", manifest.SessionID, err)
+	}
+	if err := os.Rename(staging, sessionDir); err != nil {
+		return nil, fmt.Errorf("replace session %s: %w", manifest.SessionID, err)
+	}
+	return manifest, nil
+}
+
+// readSnapshotManifest reads and checks the manifest, the first tar entry.
+func readSnapshotManifest(tr *tar.Reader) (*SnapshotManifest, error) {
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	}
+	if hdr.Name != SnapshotManifestName {
+		return nil, fmt.Errorf("not a session snapshot: first entry is %q", hdr.Name)
+	}
+	data, err := io.ReadAll(io.LimitReader(tr, maxSnapshotFileSize))
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot manifest: %w", err)
+	}
+	var manifest SnapshotManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parse snapshot manifest: %w", err)
+	}
+	if manifest.FormatVersion > SnapshotVersion {
+		return nil, fmt.Errorf("snapshot format %d is newer than this release reads (%d); upgrade Claudio",
+			manifest.FormatVersion, SnapshotVersion)
+	}
+	if manifest.SessionID == "" || strings.ContainsAny(manifest.SessionID, `/\`) || manifest.SessionID == "." || manifest.SessionID == ".." {
+		return nil, fmt.Errorf("snapshot has an invalid session ID %q", manifest.SessionID)
+	}
+	return &manifest, nil
+}
+
+// validSnapshotPath rejects entries that would escape the session directory.
+func validSnapshotPath(name string) error {
+	if name == "" || path.IsAbs(name) || strings.Contains(name, `\`) || path.Clean(name) != name ||
+		name == ".." || strings.HasPrefix(name, "../") {
+		return fmt.Errorf("snapshot entry %q has an invalid path", name)
+	}
+	return nil
+}
+
+// pathRewriter returns a function that rewrites every JSON string equal to
+// oldBase, or under it, to the same location under newBase. The returned
+// function leaves data unchanged when the two bases are equal.
+func pathRewriter(oldBase, newBase string) func([]byte) ([]byte, error) {
+	if oldBase == "" || oldBase == newBase {
+		return func(data []byte) ([]byte, error) { return data, nil }
+	}
+	prefix := oldBase + string(filepath.Separator)
+	var walk func(v any) any
+	walk = func(v any) any {
+		switch v := v.(type) {
+		case string:
+			if v == oldBase {
+				return newBase
+			}
+			if rest, ok := strings.CutPrefix(v, prefix); ok {
+				return filepath.Join(newBase, rest)
+			}
+			return v
+		case map[string]any:
+			for k, e := range v {
+				v[k] = walk(e)
+			}
+			return v
+		case []any:
+			for i, e := range v {
+				v[i] = walk(e)
+			}
+			return v
+		default:
+			return v
+		}
+	}
+	return func(data []byte) ([]byte, error) {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber() // Keep large integers and formatting of numbers exact
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return nil, fmt.Errorf("parse: %w", err)
+		}
+		return json.MarshalIndent(walk(v), "", "  ")
+	}
+}
@@ -0,0 +1,168 @@
+package session
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rootsession "github.com/Iron-Ham/claudio/internal/session"
+)
+
+// writeSessionFile writes content to rel inside dir, creating parents.
+func writeSessionFile(t *testing.T, dir, rel, content string) {
+	t.Helper()
+	p := filepath.Join(dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	srcRepo := t.TempDir()
+	mgr := NewManager(Config{BaseDir: srcRepo, SessionID: "sess-1"})
+	if err := mgr.Init(); err != nil {
+		t.Fatal(err)
+	}
+	wtPath := filepath.Join(srcRepo, ".claudio", "worktrees", "abc")
+	sess := NewSessionData("moving", srcRepo)
+	sess.ID = "sess-1"
+	sess.Instances = append(sess.Instances, &InstanceData{ID: "abc", WorktreePath: wtPath, Task: "task"})
+	sess.UltraPlan = map[string]any{
+		"task_retries":  map[string]any{"t1": map[string]any{"retry_count": 2}},
+		"consolidation": map[string]any{"phase": "complete", "worktree": wtPath},
+	}
+	if err := mgr.SaveSession(sess); err != nil {
+		t.Fatal(err)
+	}
+	dir := mgr.SessionDir()
+	writeSessionFile(t, dir, "team/taskqueue-state.json", `{"format_version":1,"tasks":{},"order":[]}`)
+	writeSessionFile(t, dir, "mailbox/broadcast/index.jsonl", `{"id":"m1","body":"hi"}`+"\n")
+	writeSessionFile(t, dir, "backups/upgrade-1/session.json", "{}")
+	writeSessionFile(t, dir, "instance.log", "log output")
+	writeSessionFile(t, dir, rootsession.LockFileName, "{}")
+
+	var buf bytes.Buffer
+	manifest, err := mgr.Snapshot(&buf)
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	wantFiles := []string{"mailbox/broadcast/index.jsonl", "session.json", "team/taskqueue-state.json"}
+	if strings.Join(manifest.Files, ",") != strings.Join(wantFiles, ",") {
+		t.Errorf("Snapshot() files = %v, want %v", manifest.Files, wantFiles)
+	}
+
+	dstRepo := t.TempDir()
+	snapshot := buf.Bytes()
+	restored, err := RestoreSnapshot(bytes.NewReader(snapshot), dstRepo, RestoreOptions{})
+	if err != nil {
+		t.Fatalf("RestoreSnapshot() error = %v", err)
+	}
+	if restored.SessionID != "sess-1" || restored.BaseRepo != srcRepo {
+		t.Errorf("restored manifest = %+v", restored)
+	}
+
+	loaded, err := NewManager(Config{BaseDir: dstRepo, SessionID: "sess-1"}).LoadSession()
+	if err != nil {
+		t.Fatalf("LoadSession() after restore error = %v", err)
+	}
+	wantWT := filepath.Join(dstRepo, ".claudio", "worktrees", "abc")
+	if loaded.BaseRepo != dstRepo || loaded.Instances[0].WorktreePath != wantWT {
+		t.Errorf("paths not rewritten: base_repo = %q, worktree_path = %q", loaded.BaseRepo, loaded.Instances[0].WorktreePath)
+	}
+	up := loaded.UltraPlan.(map[string]any)
+	if got := up["consolidation"].(map[string]any)["worktree"]; got != wantWT {
+		t.Errorf("consolidation worktree = %v, want %q", got, wantWT)
+	}
+	if got := up["task_retries"].(map[string]any)["t1"].(map[string]any)["retry_count"]; got != float64(2) {
+		t.Errorf("retry_count = %v, want 2", got)
+	}
+	dstDir := rootsession.GetSessionDir(dstRepo, "sess-1")
+	if data, err := os.ReadFile(filepath.Join(dstDir, "mailbox", "broadcast", "index.jsonl")); err != nil || !strings.Contains(string(data), `"m1"`) {
+		t.Errorf("mailbox not restored: %q, %v", data, err)
+	}
+	for _, skipped := range []string{"instance.log", "backups", rootsession.LockFileName} {
+		if _, err := os.Stat(filepath.Join(dstDir, skipped)); !os.IsNotExist(err) {
+			t.Errorf("%s should not be restored", skipped)
+		}
+	}
+
+	if _, err := RestoreSnapshot(bytes.NewReader(snapshot), dstRepo, RestoreOptions{}); !errors.Is(err, ErrSessionExists) {
+		t.Errorf("RestoreSnapshot() over an existing session error = %v, want ErrSessionExists", err)
+	}
+	if _, err := RestoreSnapshot(bytes.NewReader(snapshot), dstRepo, RestoreOptions{Force: true}); err != nil {
+		t.Errorf("RestoreSnapshot() with Force error = %v", err)
+	}
+}
+
+func TestSnapshot_LegacyMode(t *testing.T) {
+	mgr := NewManager(Config{BaseDir: t.TempDir()})
+	if _, err := mgr.Snapshot(&bytes.Buffer{}); err == nil {
+		t.Error("Snapshot() in legacy mode should fail")
+	}
+}
+
+func TestRestoreSnapshot_Rejects(t *testing.T) {
+	build := func(entries ...[2]string) []byte {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		for _, e := range entries {
+			if err := tw.WriteHeader(&tar.Header{Name: e[0], Mode: 0644, Size: int64(len(e[1])), Typeflag: tar.TypeReg}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := tw.Write([]byte(e[1])); err != nil {
+				t.Fatal(err)
+			}
+		}
+		_ = tw.Close()
+		_ = gz.Close()
+		return buf.Bytes()
+	}
+	manifest := func(m SnapshotManifest) string {
+		data, _ := json.Marshal(m)
+		return string(data)
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{"not gzip", []byte("plain"), "read snapshot"},
+		{"no manifest", build([2]string{"session.json", "{}"}), "not a session snapshot"},
+		{"newer format", build([2]string{SnapshotManifestName, manifest(SnapshotManifest{FormatVersion: SnapshotVersion + 1, SessionID: "s"})}), "newer"},
+		{"bad session id", build([2]string{SnapshotManifestName, manifest(SnapshotManifest{FormatVersion: 1, SessionID: "../x"})}), "invalid session ID"},
+		{"unlisted entry", build(
+			[2]string{SnapshotManifestName, manifest(SnapshotManifest{FormatVersion: 1, SessionID: "s", Files: []string{"session.json"}})},
+			[2]string{"../escape.json", "{}"},
+		), "not in its manifest"},
+		{"escaping entry", build(
+			[2]string{SnapshotManifestName, manifest(SnapshotManifest{FormatVersion: 1, SessionID: "s", Files: []string{"../escape"}})},
+			[2]string{"../escape", "{}"},
+		), "invalid path"},
+		{"truncated", build(
+			[2]string{SnapshotManifestName, manifest(SnapshotManifest{FormatVersion: 1, SessionID: "s", Files: []string{"session.json"}})},
+		), "truncated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := t.TempDir()
+			_, err := RestoreSnapshot(bytes.NewReader(tt.data), base, RestoreOptions{})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("RestoreSnapshot() error = %v, want %q", err, tt.wantErr)
+			}
+			if rootsession.SessionExists(base, "s") {
+				t.Error("a rejected snapshot should not create the session")
+			}
+		})
+	}
+}
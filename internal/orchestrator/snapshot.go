@@ -0,0 +1,249 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// StateSnapshot is an immutable copy of session state, published by the
+// Orchestrator whenever instances or groups change. Read-only consumers such
+// as the TUI render from snapshots, so drawing a frame never takes
+// orchestrator locks or reads fields that orchestrator goroutines are writing.
+//
+// The session in a snapshot is a deep copy, workflow sessions included, and
+// nothing reachable from it is shared with the live session. Consumers must
+// still treat it as read-only: changes go through Orchestrator methods, which
+// publish a new snapshot.
+type StateSnapshot struct {
+	Version uint64   // Increases with every publish; 0 means nothing was published yet
+	Session *Session // Copy of the session; nil before a session is started or loaded
+}
+
+// Instance returns the snapshot of the instance with the given ID, or nil.
+func (s *StateSnapshot) Instance(id string) *Instance {
+	if s == nil || s.Session == nil {
+		return nil
+	}
+	return s.Session.GetInstance(id)
+}
+
+// emptySnapshot is returned before the first publish so callers never see nil.
+var emptySnapshot = &StateSnapshot{}
+
+// snapshotStore holds the latest published snapshot. The zero value is ready
+// to use; loads are lock-free.
+type snapshotStore struct {
+	current atomic.Pointer[StateSnapshot]
+
+	mu      sync.Mutex // Serializes publishers so versions are stored in order
+	version uint64
+	groups  uint64 // groupsFingerprint of the session in the current snapshot
+}
+
+// publish stores a copy of sess as the latest snapshot. The caller must
+// prevent concurrent writes to sess for the duration of the copy, normally
+// by holding the Orchestrator's mutex.
+func (st *snapshotStore) publish(sess *Session) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	// Fingerprint before copying: a group change in between lands in the
+	// copy and only costs one extra publish, rather than being missed.
+	st.groups = sess.groupsFingerprint()
+	st.version++
+	st.current.Store(&StateSnapshot{
+		Version: st.version,
+		Session: sess.snapshotCopy(),
+	})
+}
+
+// groupsChanged reports whether the groups of sess differ from those in the
+// latest snapshot.
+func (st *snapshotStore) groupsChanged(sess *Session) bool {
+	fp := sess.groupsFingerprint()
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return fp != st.groups
+}
+
+// load returns the latest snapshot, or an empty one if none was published.
+func (st *snapshotStore) load() *StateSnapshot {
+	if snap := st.current.Load(); snap != nil {
+		return snap
+	}
+	return emptySnapshot
+}
+
+// Snapshot returns the most recently published state snapshot without taking
+// orchestrator locks. It never returns nil.
+func (o *Orchestrator) Snapshot() *StateSnapshot {
+	return o.snapshots.load()
+}
+
+// PublishSnapshot publishes a fresh snapshot of the session. Components that
+// change instances or groups outside the Orchestrator's own methods call it
+// so snapshot readers see the change without waiting for the next save.
+func (o *Orchestrator) PublishSnapshot() {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	o.publishSnapshot()
+}
+
+// RefreshSnapshot publishes a fresh snapshot only if the session's groups
+// changed since the last one. Groups are the part of the session that
+// workflows and the TUI edit in place rather than through Orchestrator
+// methods; checking them is cheap, so a UI tick can call this where
+// PublishSnapshot would copy the whole session every time.
+func (o *Orchestrator) RefreshSnapshot() {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	if o.snapshots.groupsChanged(o.session) {
+		o.publishSnapshot()
+	}
+}
+
+// publishSnapshot publishes a snapshot of o.session and refreshes the status
+// file from it. Callers must hold o.mu or otherwise own the session, as
+// saveSession does.
+func (o *Orchestrator) publishSnapshot() {
+	o.snapshots.publish(o.session)
+	o.writeStatusFile()
+}
+
+// groupsFingerprint returns a hash of the session's group tree: the fields
+// that change after a group is created, and the same groups in a different
+// order. It returns 0 for a nil session.
+func (s *Session) groupsFingerprint() uint64 {
+	if s == nil {
+		return 0
+	}
+	h := fnv.New64a()
+	s.groupsMu.RLock()
+	for _, g := range s.Groups {
+		hashGroup(h, g)
+	}
+	s.groupsMu.RUnlock()
+	return h.Sum64()
+}
+
+// hashGroup writes g and its sub-groups to h for groupsFingerprint.
+func hashGroup(h hash.Hash64, g *InstanceGroup) {
+	if g == nil {
+		_, _ = h.Write([]byte{0})
+		return
+	}
+	_, _ = fmt.Fprintf(h, "%q %q %q %q %d %q %q %d{", g.ID, g.Name, g.Phase, g.ParentID,
+		g.ExecutionOrder, g.Instances, g.DependsOn, len(g.SubGroups))
+	for _, sg := range g.SubGroups {
+		hashGroup(h, sg)
+	}
+	_, _ = h.Write([]byte{'}'})
+}
+
+// snapshotCopy returns a deep copy of the session for a StateSnapshot.
+//
+// Exported fields are copied by value with reflection, so fields added to
+// Session later are carried without touching this method; the struct itself
+// can't be copied directly because it holds groupsMu. Slices, maps, and
+// pointers are then replaced with copies: instances and groups with their
+// own copy methods, workflow sessions and cleanup records with a JSON round
+// trip, the same encoding that persists them.
+func (s *Session) snapshotCopy() *Session {
+	if s == nil {
+		return nil
+	}
+
+	cp := &Session{}
+	src, dst := reflect.ValueOf(s).Elem(), reflect.ValueOf(cp).Elem()
+
+	s.groupsMu.RLock()
+	for i := range src.NumField() {
+		if src.Type().Field(i).IsExported() {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+	if s.Groups != nil {
+		cp.Groups = make([]*InstanceGroup, len(s.Groups))
+		for i, g := range s.Groups {
+			cp.Groups[i] = g.Clone()
+		}
+	}
+	s.groupsMu.RUnlock()
+
+	if s.Instances != nil {
+		cp.Instances = make([]*Instance, len(s.Instances))
+		for i, inst := range s.Instances {
+			cp.Instances[i] = inst.snapshotCopy()
+		}
+	}
+	cp.UltraPlan = cloneJSON(s.UltraPlan)
+	cp.TripleShots = cloneJSON(s.TripleShots)
+	cp.AdversarialSessions = cloneJSON(s.AdversarialSessions)
+	cp.RalphSessions = cloneJSON(s.RalphSessions)
+	cp.BranchCleanups = cloneJSON(s.BranchCleanups)
+	cp.PendingPRs = cloneJSON(s.PendingPRs)
+	cp.LastActiveAt = cloneTime(s.LastActiveAt)
+	cp.InterruptedAt = cloneTime(s.InterruptedAt)
+	cp.RecoveredAt = cloneTime(s.RecoveredAt)
+
+	return cp
+}
+
+// snapshotCopy returns a deep copy of the instance without its runtime
+// output buffer, which snapshot readers get from the output manager instead.
+func (i *Instance) snapshotCopy() *Instance {
+	if i == nil {
+		return nil
+	}
+
+	cp := *i
+	cp.Output = nil
+	cp.FilesModified = slices.Clone(i.FilesModified)
+	cp.DependsOn = slices.Clone(i.DependsOn)
+	cp.Dependents = slices.Clone(i.Dependents)
+	cp.LastActiveAt = cloneTime(i.LastActiveAt)
+	cp.InterruptedAt = cloneTime(i.InterruptedAt)
+	cp.ParkedAt = cloneTime(i.ParkedAt)
+	if i.Metrics != nil {
+		m := *i.Metrics
+		m.StartTime = cloneTime(i.Metrics.StartTime)
+		m.EndTime = cloneTime(i.Metrics.EndTime)
+		cp.Metrics = &m
+	}
+	if i.Bootstrap != nil {
+		b := *i.Bootstrap
+		b.LinkedDirs = slices.Clone(i.Bootstrap.LinkedDirs)
+		cp.Bootstrap = &b
+	}
+	cp.Escalation = i.Escalation.Clone()
+	return &cp
+}
+
+// cloneJSON deep-copies v by encoding and decoding it. Session state is
+// always JSON-encodable, as saveSession relies on; if encoding fails anyway
+// the zero value is returned rather than sharing v with snapshot readers.
+func cloneJSON[T any](v T) T {
+	var cp T
+	data, err := json.Marshal(v)
+	if err != nil {
+		return cp
+	}
+	_ = json.Unmarshal(data, &cp)
+	return cp
+}
+
+// cloneTime returns a copy of t, or nil if t is nil.
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
@@ -0,0 +1,234 @@
+package orchestrator
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/workflows/adversarial"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/workflows/ralph"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/workflows/tripleshot"
+	"github.com/Iron-Ham/claudio/internal/pr"
+	"github.com/Iron-Ham/claudio/internal/reaper"
+)
+
+func newSnapshotTestSession() *Session {
+	sess := NewSession("snap", "/repo")
+	sess.Instances = append(sess.Instances, &Instance{
+		ID:            "inst-1",
+		Status:        StatusWorking,
+		FilesModified: []string{"a.go"},
+		Metrics:       &Metrics{InputTokens: 10},
+		Output:        []byte("output"),
+	})
+	group := NewInstanceGroupWithID("group-1", "Group 1")
+	group.AddInstance("inst-1")
+	sess.AddGroup(group)
+	return sess
+}
+
+func TestOrchestrator_Snapshot_BeforePublish(t *testing.T) {
+	o := &Orchestrator{}
+
+	snap := o.Snapshot()
+	if snap == nil {
+		t.Fatal("Snapshot() returned nil")
+	}
+	if snap.Version != 0 || snap.Session != nil {
+		t.Errorf("Snapshot() = %+v, want empty snapshot", snap)
+	}
+	if snap.Instance("inst-1") != nil {
+		t.Error("Instance() on empty snapshot should return nil")
+	}
+}
+
+func TestOrchestrator_Snapshot_IsolatedFromLiveSession(t *testing.T) {
+	sess := newSnapshotTestSession()
+	o := &Orchestrator{session: sess}
+
+	o.PublishSnapshot()
+	snap := o.Snapshot()
+
+	// Mutate the live session after publishing
+	live := sess.Instances[0]
+	live.Status = StatusCompleted
+	live.FilesModified[0] = "b.go"
+	live.Metrics.InputTokens = 99
+	sess.GetGroup("group-1").AddInstance("inst-2")
+	sess.AddGroup(NewInstanceGroupWithID("group-2", "Group 2"))
+
+	inst := snap.Instance("inst-1")
+	if inst == nil {
+		t.Fatal("snapshot is missing inst-1")
+	}
+	if inst == live {
+		t.Fatal("snapshot shares the live instance")
+	}
+	if inst.Status != StatusWorking {
+		t.Errorf("snapshot Status = %s, want %s", inst.Status, StatusWorking)
+	}
+	if inst.FilesModified[0] != "a.go" {
+		t.Errorf("snapshot FilesModified = %v, want [a.go]", inst.FilesModified)
+	}
+	if inst.Metrics.InputTokens != 10 {
+		t.Errorf("snapshot InputTokens = %d, want 10", inst.Metrics.InputTokens)
+	}
+	if inst.Output != nil {
+		t.Error("snapshot should not carry the output buffer")
+	}
+
+	groups := snap.Session.GetGroups()
+	if len(groups) != 1 {
+		t.Fatalf("snapshot has %d groups, want 1", len(groups))
+	}
+	if len(groups[0].Instances) != 1 {
+		t.Errorf("snapshot group instances = %v, want [inst-1]", groups[0].Instances)
+	}
+}
+
+func TestSession_SnapshotCopy_SharesNothing(t *testing.T) {
+	now := time.Now()
+	sess := newSnapshotTestSession()
+	sess.FormatVersion = 3
+	sess.SentinelProtocol = 2
+	sess.LastActiveAt = &now
+	sess.UltraPlan = &UltraPlanSession{
+		ID:             "plan-1",
+		TaskToInstance: map[string]string{"task-1": "inst-1"},
+	}
+	sess.TripleShots = []*tripleshot.Session{{ID: "ts-1"}}
+	sess.AdversarialSessions = []*adversarial.Session{{ID: "adv-1"}}
+	sess.RalphSessions = []*ralph.Session{{Prompt: "loop"}}
+	sess.BranchCleanups = []reaper.Record{{LocalBranches: []string{"b"}}}
+	sess.PendingPRs = []pr.Pending{{Labels: []string{"l"}}}
+
+	cp := sess.snapshotCopy()
+
+	src, dst := reflect.ValueOf(sess).Elem(), reflect.ValueOf(cp).Elem()
+	for i := range src.NumField() {
+		field := src.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		sv, dv := src.Field(i), dst.Field(i)
+		switch sv.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map:
+			if sv.IsNil() {
+				continue
+			}
+			if dv.IsNil() {
+				t.Errorf("copy dropped %s", field.Name)
+			} else if sv.UnsafePointer() == dv.UnsafePointer() {
+				t.Errorf("copy shares %s with the live session", field.Name)
+			}
+		default:
+			if !reflect.DeepEqual(sv.Interface(), dv.Interface()) {
+				t.Errorf("copy %s = %v, want %v", field.Name, dv.Interface(), sv.Interface())
+			}
+		}
+	}
+
+	sess.UltraPlan.TaskToInstance["task-1"] = "inst-2"
+	sess.TripleShots[0].ID = "ts-2"
+	if got := cp.UltraPlan.TaskToInstance["task-1"]; got != "inst-1" {
+		t.Errorf("copy TaskToInstance[task-1] = %q, want inst-1", got)
+	}
+	if got := cp.TripleShots[0].ID; got != "ts-1" {
+		t.Errorf("copy TripleShots[0].ID = %q, want ts-1", got)
+	}
+}
+
+func TestOrchestrator_SetInstanceStatus_PublishesSnapshot(t *testing.T) {
+	o := &Orchestrator{session: newSnapshotTestSession()}
+	o.PublishSnapshot()
+	before := o.Snapshot()
+
+	if !o.SetInstanceStatus("inst-1", StatusWaitingInput) {
+		t.Fatal("SetInstanceStatus() = false, want true")
+	}
+
+	after := o.Snapshot()
+	if after.Version <= before.Version {
+		t.Errorf("Version = %d after change, want > %d", after.Version, before.Version)
+	}
+	if got := after.Instance("inst-1").Status; got != StatusWaitingInput {
+		t.Errorf("published Status = %s, want %s", got, StatusWaitingInput)
+	}
+	if got := before.Instance("inst-1").Status; got != StatusWorking {
+		t.Errorf("earlier snapshot Status = %s, want %s", got, StatusWorking)
+	}
+}
+
+func TestOrchestrator_RefreshSnapshot(t *testing.T) {
+	sess := newSnapshotTestSession()
+	o := &Orchestrator{session: sess}
+
+	o.RefreshSnapshot()
+	first := o.Snapshot().Version
+	if first == 0 {
+		t.Fatal("RefreshSnapshot() should publish a session that was never published")
+	}
+
+	// Nothing changed, or only something the Orchestrator publishes itself
+	sess.Instances[0].Status = StatusCompleted
+	o.RefreshSnapshot()
+	if got := o.Snapshot().Version; got != first {
+		t.Errorf("Version = %d without a group change, want %d", got, first)
+	}
+
+	// Groups edited in place are picked up
+	for _, change := range []func(){
+		func() { sess.GetGroup("group-1").AddInstance("inst-2") },
+		func() { sess.GetGroup("group-1").Phase = GroupPhaseExecuting },
+		func() { sess.GetGroup("group-1").AddSubGroup(NewInstanceGroupWithID("sub-1", "Sub 1")) },
+		func() { sess.GetGroup("sub-1").AddInstance("inst-3") },
+		func() { sess.AddGroup(NewInstanceGroupWithID("group-2", "Group 2")) },
+	} {
+		before := o.Snapshot().Version
+		change()
+		o.RefreshSnapshot()
+		if got := o.Snapshot().Version; got != before+1 {
+			t.Errorf("Version = %d after a group change, want %d", got, before+1)
+		}
+	}
+	if o.Snapshot().Session.GetGroup("sub-1") == nil {
+		t.Error("refreshed snapshot should include the new sub-group")
+	}
+}
+
+func TestOrchestrator_Snapshot_ConcurrentReaders(t *testing.T) {
+	o := &Orchestrator{session: newSnapshotTestSession()}
+	o.PublishSnapshot()
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if inst := o.Snapshot().Instance("inst-1"); inst != nil {
+					_ = inst.Status
+					_ = len(inst.FilesModified)
+				}
+			}
+		}()
+	}
+
+	statuses := []InstanceStatus{StatusWorking, StatusWaitingInput, StatusCompleted}
+	for i := range 200 {
+		o.SetInstanceStatus("inst-1", statuses[i%len(statuses)])
+	}
+	close(done)
+	wg.Wait()
+
+	if v := o.Snapshot().Version; v != 201 {
+		t.Errorf("Version = %d, want 201", v)
+	}
+}
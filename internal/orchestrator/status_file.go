@@ -0,0 +1,172 @@
+package orchestrator
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/util"
+)
+
+// StatusFileName is the sidecar status file kept in the .claudio directory.
+// Shell prompts, tmux status bars, and editors read it to show claudio status
+// without talking to claudio.
+const StatusFileName = "status.json"
+
+// statusFileVersion is bumped when StatusFile changes incompatibly.
+const statusFileVersion = 1
+
+// statusTaskMaxLen bounds the task text of waiting instances in the status file.
+const statusTaskMaxLen = 80
+
+// StatusFile is the machine-readable status written to .claudio/status.json.
+// When several sessions share a repository, the file reflects whichever
+// session changed most recently; SessionID and PID identify it.
+type StatusFile struct {
+	Version     int              `json:"version"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	SessionID   string           `json:"session_id"`
+	SessionName string           `json:"session_name,omitempty"`
+	PID         int              `json:"pid"`             // Process writing the file; stale if no longer running
+	Phase       string           `json:"phase,omitempty"` // Ultraplan phase, empty outside ultraplan
+	Instances   int              `json:"instances"`
+	Counts      map[string]int   `json:"counts"`  // Instances per status
+	Waiting     []StatusInstance `json:"waiting"` // Instances waiting for user input
+	Cost        float64          `json:"cost"`    // Estimated session cost in USD
+}
+
+// StatusInstance identifies an instance in the status file.
+type StatusInstance struct {
+	ID   string `json:"id"`
+	Task string `json:"task"`
+}
+
+// buildStatusFile summarizes a snapshot for the status file. UpdatedAt is
+// left zero so unchanged states compare equal.
+func buildStatusFile(snap *StateSnapshot) StatusFile {
+	sess := snap.Session
+	status := StatusFile{
+		Version:     statusFileVersion,
+		SessionID:   sess.ID,
+		SessionName: sess.Name,
+		PID:         os.Getpid(),
+		Instances:   len(sess.Instances),
+		Counts:      make(map[string]int),
+		Waiting:     []StatusInstance{},
+	}
+	if sess.UltraPlan != nil {
+		status.Phase = string(sess.UltraPlan.Phase)
+	}
+	for _, inst := range sess.Instances {
+		status.Counts[string(inst.Status)]++
+		if inst.Status == StatusWaitingInput {
+			status.Waiting = append(status.Waiting, StatusInstance{
+				ID:   inst.ID,
+				Task: util.TruncateString(inst.Task, statusTaskMaxLen),
+			})
+		}
+		if inst.Metrics != nil {
+			status.Cost += inst.Metrics.Cost
+		}
+	}
+	return status
+}
+
+// statusWriter keeps the status file in sync with published snapshots.
+// The zero value is ready to use.
+type statusWriter struct {
+	mu   sync.Mutex
+	last []byte // Last written status without UpdatedAt, to skip unchanged writes
+}
+
+// write updates the status file in dir from snap. Writes are skipped when
+// nothing but the timestamp would change, and are atomic (temp file plus
+// rename) so readers never see a partial file.
+func (w *statusWriter) write(dir string, snap *StateSnapshot) error {
+	if dir == "" || snap.Session == nil {
+		return nil
+	}
+
+	status := buildStatusFile(snap)
+	key, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if bytes.Equal(key, w.last) {
+		return nil
+	}
+
+	status.UpdatedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	target := filepath.Join(dir, StatusFileName)
+	tmp := target + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, target); err != nil {
+		_ = os.Remove(tmp) // best-effort cleanup
+		return err
+	}
+	w.last = key
+	return nil
+}
+
+// remove deletes the status file in dir if it still describes sessionID, so
+// an exiting session never removes the status of another running session.
+func (w *statusWriter) remove(dir, sessionID string) error {
+	if dir == "" {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.last = nil
+
+	target := filepath.Join(dir, StatusFileName)
+	data, err := os.ReadFile(target)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var status StatusFile
+	if err := json.Unmarshal(data, &status); err == nil && status.SessionID != sessionID {
+		return nil
+	}
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// writeStatusFile refreshes .claudio/status.json from the latest snapshot.
+// Failures are logged and never interrupt the state change that caused them.
+func (o *Orchestrator) writeStatusFile() {
+	if o.observing {
+		return
+	}
+	if err := o.status.write(o.claudioDir, o.snapshots.load()); err != nil && o.logger != nil {
+		o.logger.Warn("failed to write status file", "error", err)
+	}
+}
+
+// removeStatusFile removes .claudio/status.json when the session ends.
+func (o *Orchestrator) removeStatusFile(sessionID string) {
+	if err := o.status.remove(o.claudioDir, sessionID); err != nil && o.logger != nil {
+		o.logger.Warn("failed to remove status file", "error", err)
+	}
+}
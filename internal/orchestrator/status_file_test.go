@@ -0,0 +1,95 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readStatusFile(t *testing.T, dir string) StatusFile {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, StatusFileName))
+	if err != nil {
+		t.Fatalf("read status file: %v", err)
+	}
+	var status StatusFile
+	if err := json.Unmarshal(data, &status); err != nil {
+		t.Fatalf("parse status file: %v", err)
+	}
+	return status
+}
+
+func TestOrchestrator_PublishSnapshot_WritesStatusFile(t *testing.T) {
+	dir := t.TempDir()
+	sess := newSnapshotTestSession()
+	sess.Instances = append(sess.Instances, &Instance{
+		ID:      "inst-2",
+		Task:    "Answer my question",
+		Status:  StatusWaitingInput,
+		Metrics: &Metrics{Cost: 0.25},
+	})
+	sess.Instances[0].Metrics.Cost = 1.5
+	sess.UltraPlan = &UltraPlanSession{Phase: PhaseExecuting}
+	o := &Orchestrator{session: sess, claudioDir: dir}
+
+	o.PublishSnapshot()
+
+	status := readStatusFile(t, dir)
+	if status.SessionID != sess.ID || status.PID != os.Getpid() {
+		t.Errorf("status identifies session %q pid %d, want %q pid %d", status.SessionID, status.PID, sess.ID, os.Getpid())
+	}
+	if status.Phase != string(PhaseExecuting) {
+		t.Errorf("Phase = %q, want %q", status.Phase, PhaseExecuting)
+	}
+	if status.Instances != 2 || status.Counts["working"] != 1 || status.Counts["waiting_input"] != 1 {
+		t.Errorf("Instances = %d, Counts = %v", status.Instances, status.Counts)
+	}
+	if len(status.Waiting) != 1 || status.Waiting[0].ID != "inst-2" {
+		t.Errorf("Waiting = %+v, want inst-2", status.Waiting)
+	}
+	if status.Cost != 1.75 {
+		t.Errorf("Cost = %v, want 1.75", status.Cost)
+	}
+	if status.UpdatedAt.IsZero() {
+		t.Error("UpdatedAt not set")
+	}
+	if _, err := os.Stat(filepath.Join(dir, StatusFileName+".tmp")); !os.IsNotExist(err) {
+		t.Error("temporary status file left behind")
+	}
+}
+
+func TestOrchestrator_StatusFile_SkipsUnchangedAndTracksChanges(t *testing.T) {
+	dir := t.TempDir()
+	o := &Orchestrator{session: newSnapshotTestSession(), claudioDir: dir}
+	o.PublishSnapshot()
+	first := readStatusFile(t, dir)
+
+	// Republishing the same state must not rewrite the file
+	o.PublishSnapshot()
+	if again := readStatusFile(t, dir); !again.UpdatedAt.Equal(first.UpdatedAt) {
+		t.Error("unchanged state rewrote the status file")
+	}
+
+	o.SetInstanceStatus("inst-1", StatusWaitingInput)
+	if changed := readStatusFile(t, dir); len(changed.Waiting) != 1 {
+		t.Errorf("Waiting = %+v after status change, want inst-1", changed.Waiting)
+	}
+}
+
+func TestStatusWriter_RemoveOnlyOwnSession(t *testing.T) {
+	dir := t.TempDir()
+	o := &Orchestrator{session: newSnapshotTestSession(), claudioDir: dir}
+	o.PublishSnapshot()
+	path := filepath.Join(dir, StatusFileName)
+
+	o.removeStatusFile("another-session")
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("status file of a different session was removed: %v", err)
+	}
+
+	o.removeStatusFile(o.session.ID)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("status file still present after removing own session: %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package orchestrator
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/mailbox"
+)
+
+// TaskProposal is a follow-up task an instance proposed during execution,
+// queued until a human approves or rejects it. Approving it adds it to the
+// running plan under its ID.
+type TaskProposal struct {
+	ID                   string    `json:"id"`   // "proposal-N", also the task ID once approved
+	From                 string    `json:"from"` // Task whose instance proposed it
+	ProposedAt           time.Time `json:"proposed_at"`
+	mailbox.TaskProposal           // Title, description, files, and dependencies
+}
+
+// onMailboxMessage queues the task proposed in a MessageTaskProposal
+// message to the coordinator. Other messages are ignored.
+func (c *Coordinator) onMailboxMessage(me event.MailboxMessageEvent) {
+	if me.MessageType != string(mailbox.MessageTaskProposal) || me.To != mailbox.CoordinatorRecipient {
+		return
+	}
+	p, err := mailbox.ParseTaskProposal(me.Body)
+	if err != nil {
+		c.logger.Warn("ignoring task proposal", "from", me.From, "error", err)
+		return
+	}
+	if _, err := c.ProposeTask(me.From, p); err != nil {
+		c.logger.Warn("failed to queue task proposal", "from", me.From, "error", err)
+	}
+}
+
+// ProposeTask queues a follow-up task proposed by from for approval, saves
+// the session, and publishes a task.proposed event. Returns the proposal's
+// ID.
+func (c *Coordinator) ProposeTask(from string, p mailbox.TaskProposal) (string, error) {
+	session := c.Session()
+	if session == nil {
+		return "", fmt.Errorf("no ultraplan session")
+	}
+	if p.Title == "" {
+		return "", fmt.Errorf("task proposal requires a title")
+	}
+
+	c.mu.Lock()
+	session.ProposalCount++
+	proposal := &TaskProposal{
+		ID:           fmt.Sprintf("proposal-%d", session.ProposalCount),
+		From:         from,
+		ProposedAt:   time.Now(),
+		TaskProposal: p,
+	}
+	session.TaskProposals = append(session.TaskProposals, proposal)
+	c.mu.Unlock()
+
+	if err := c.orch.SaveSession(); err != nil {
+		c.logger.Warn("failed to save session after task proposal", "proposal_id", proposal.ID, "error", err)
+	}
+	c.logger.Info("task proposed", "proposal_id", proposal.ID, "from", from, "title", p.Title)
+	if bus := c.eventBus(); bus != nil {
+		bus.Publish(event.NewTaskProposedEvent(proposal.ID, from, p.Title))
+	}
+	return proposal.ID, nil
+}
+
+// PendingTaskProposals returns the proposals awaiting approval, oldest
+// first.
+func (c *Coordinator) PendingTaskProposals() []*TaskProposal {
+	session := c.Session()
+	if session == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(session.TaskProposals)
+}
+
+// ApproveTaskProposal adds the proposed task to the running plan with
+// AddTask and removes the proposal from the queue. The proposal stays queued
+// when the task cannot be added, for instance because execution has ended.
+func (c *Coordinator) ApproveTaskProposal(id string) error {
+	proposal := c.taskProposal(id)
+	if proposal == nil {
+		return fmt.Errorf("no task proposal %s", id)
+	}
+	task := PlannedTask{
+		ID:          proposal.ID,
+		Title:       proposal.Title,
+		Description: proposal.Description,
+		Files:       slices.Clone(proposal.Files),
+	}
+	if err := c.AddTask(task, proposal.DependsOn); err != nil {
+		return err
+	}
+	c.removeTaskProposal(id)
+	c.logger.Info("task proposal approved", "proposal_id", id)
+	return c.orch.SaveSession()
+}
+
+// RejectTaskProposal drops a proposal from the queue.
+func (c *Coordinator) RejectTaskProposal(id string) error {
+	if !c.removeTaskProposal(id) {
+		return fmt.Errorf("no task proposal %s", id)
+	}
+	c.logger.Info("task proposal rejected", "proposal_id", id)
+	return c.orch.SaveSession()
+}
+
+// taskProposal returns the queued proposal id, or nil.
+func (c *Coordinator) taskProposal(id string) *TaskProposal {
+	session := c.Session()
+	if session == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, p := range session.TaskProposals {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+// removeTaskProposal removes proposal id from the queue and reports whether
+// it was queued.
+func (c *Coordinator) removeTaskProposal(id string) bool {
+	session := c.Session()
+	if session == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := len(session.TaskProposals)
+	session.TaskProposals = slices.DeleteFunc(session.TaskProposals, func(p *TaskProposal) bool { return p.ID == id })
+	return len(session.TaskProposals) < n
+}
@@ -0,0 +1,73 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/mailbox"
+)
+
+func TestCoordinator_TaskProposals(t *testing.T) {
+	c, added := newAddTaskTestCoordinator(t)
+	session := c.Session()
+	session.Phase = PhaseExecuting
+
+	var proposed []event.TaskProposedEvent
+	c.eventBus().Subscribe("task.proposed", func(e event.Event) {
+		proposed = append(proposed, e.(event.TaskProposedEvent))
+	})
+
+	msg, err := mailbox.NewTaskProposalMessage("t1", mailbox.TaskProposal{
+		Title:     "Remove dead code",
+		Files:     []string{"old.go"},
+		DependsOn: []string{"t1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.onMailboxMessage(mailbox.NewMailboxMessageEvent(msg))
+	c.onMailboxMessage(event.NewMailboxMessageEvent("t1", mailbox.CoordinatorRecipient, string(mailbox.MessageTaskProposal), "Files: a.go"))
+	c.onMailboxMessage(event.NewMailboxMessageEvent("t1", mailbox.BroadcastRecipient, string(mailbox.MessageDiscovery), "Title: not a proposal"))
+	if _, err := c.ProposeTask("t2", mailbox.TaskProposal{Title: "Rename helper"}); err != nil {
+		t.Fatalf("ProposeTask: %v", err)
+	}
+
+	pending := c.PendingTaskProposals()
+	if len(pending) != 2 || pending[0].ID != "proposal-1" || pending[0].From != "t1" || pending[1].ID != "proposal-2" {
+		t.Fatalf("pending = %+v, want proposal-1 from t1 and proposal-2", pending)
+	}
+	if len(proposed) != 2 || proposed[0].Title != "Remove dead code" {
+		t.Errorf("task.proposed events = %+v", proposed)
+	}
+
+	if err := c.ApproveTaskProposal("proposal-1"); err != nil {
+		t.Fatalf("ApproveTaskProposal: %v", err)
+	}
+	task := session.GetTask("proposal-1")
+	if task == nil || task.Title != "Remove dead code" || len(task.DependsOn) != 1 || task.Files[0] != "old.go" {
+		t.Errorf("added task = %+v", task)
+	}
+	if len(*added) != 1 {
+		t.Errorf("task.added events = %d, want 1", len(*added))
+	}
+
+	if err := c.RejectTaskProposal("proposal-2"); err != nil {
+		t.Fatalf("RejectTaskProposal: %v", err)
+	}
+	if session.GetTask("proposal-2") != nil || len(c.PendingTaskProposals()) != 0 {
+		t.Error("rejected proposal should be dropped without adding a task")
+	}
+	if err := c.ApproveTaskProposal("proposal-2"); err == nil {
+		t.Error("expected error approving a rejected proposal")
+	}
+
+	// A proposal that cannot be added stays queued
+	id, _ := c.ProposeTask("t2", mailbox.TaskProposal{Title: "Late follow-up"})
+	session.Phase = PhaseSynthesis
+	if err := c.ApproveTaskProposal(id); err == nil {
+		t.Error("expected error approving after execution")
+	}
+	if len(c.PendingTaskProposals()) != 1 {
+		t.Error("proposal should stay queued when approval fails")
+	}
+}
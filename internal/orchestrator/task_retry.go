@@ -0,0 +1,79 @@
+package orchestrator
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/prompt"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/retry"
+)
+
+// maxRetryDiffBytes bounds the uncommitted diff kept from a failed attempt,
+// which is persisted with the session and shown to the retry.
+const maxRetryDiffBytes = 16 << 10
+
+// retryStrategy returns how failed tasks are retried (ultraplan.retry).
+func (c *Coordinator) retryStrategy() retry.Strategy {
+	if c.orch == nil || c.orch.config == nil {
+		return retry.Strategy{}
+	}
+	cfg := c.orch.config.Ultraplan.Retry
+	return retry.Strategy{
+		Backoff:    time.Duration(cfg.BackoffSeconds) * time.Second,
+		MaxBackoff: time.Duration(cfg.MaxBackoffSeconds) * time.Second,
+		Augment:    cfg.AugmentPrompt,
+		FinalModel: cfg.FinalAttemptModel,
+	}
+}
+
+// RetrySection returns the prompt section telling a retried task why its
+// previous attempt failed and what it left uncommitted, or "" for a first
+// attempt or when ultraplan.retry.augment_prompt is disabled.
+func (c *Coordinator) RetrySection(taskID string) string {
+	if !c.retryStrategy().Augment {
+		return ""
+	}
+	state := c.retryManager.GetState(taskID)
+	if state == nil || state.RetryCount == 0 || state.Succeeded {
+		return ""
+	}
+	return prompt.RetrySection(state.RetryCount+1, state.MaxRetries+1, state.LastError, state.LastDiff)
+}
+
+// RetryModel returns the model a task's next attempt runs on: the
+// ultraplan.retry.final_attempt_model for its last retry, "" otherwise.
+func (c *Coordinator) RetryModel(taskID string) string {
+	model := c.retryStrategy().FinalModel
+	if model == "" || !c.retryManager.IsFinalAttempt(taskID) {
+		return ""
+	}
+	return model
+}
+
+// TaskAttempt returns the attempt a task's next instance makes: 0 for its
+// first run, N for its Nth retry.
+func (c *Coordinator) TaskAttempt(taskID string) int {
+	state := c.retryManager.GetState(taskID)
+	if state == nil {
+		return 0
+	}
+	return state.RetryCount
+}
+
+// RetryWait returns how long a failed task must still wait before its next
+// attempt, or 0 when it may start now.
+func (c *Coordinator) RetryWait(taskID string) time.Duration {
+	return c.retryManager.RetryWait(taskID, time.Now())
+}
+
+// truncateRetryDiff cuts diff to maxRetryDiffBytes on a line boundary.
+func truncateRetryDiff(diff string) string {
+	if len(diff) <= maxRetryDiffBytes {
+		return diff
+	}
+	cut := diff[:maxRetryDiffBytes]
+	if i := strings.LastIndexByte(cut, '\n'); i > 0 {
+		cut = cut[:i+1]
+	}
+	return cut + "... (diff truncated)\n"
+}
@@ -0,0 +1,95 @@
+package orchestrator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/retry"
+)
+
+func retryTestCoordinator(cfg config.RetryConfig) *Coordinator {
+	appCfg := config.Default()
+	appCfg.Ultraplan.Retry = cfg
+	ultraCfg := DefaultUltraPlanConfig()
+	ultraCfg.MaxTaskRetries = 2
+	return &Coordinator{
+		orch:         &Orchestrator{config: appCfg},
+		manager:      NewUltraPlanManager(nil, nil, &UltraPlanSession{Config: ultraCfg}, nil),
+		logger:       logging.NopLogger(),
+		retryManager: retry.NewManager(),
+	}
+}
+
+func TestCoordinator_RetryFailedTask(t *testing.T) {
+	c := retryTestCoordinator(config.RetryConfig{
+		BackoffSeconds:    30,
+		MaxBackoffSeconds: 45,
+		AugmentPrompt:     true,
+		FinalAttemptModel: "opus",
+	})
+	tracker := &coordinatorRetryTracker{c: c}
+
+	if got := c.RetrySection("task-1"); got != "" {
+		t.Errorf("RetrySection() before any failure = %q, want empty", got)
+	}
+
+	tracker.IncrementRetry("task-1")
+	tracker.RecordFailure("task-1", "unmet completion criteria: file README.md does not exist", "Untracked file: notes.md\n")
+
+	if wait := c.RetryWait("task-1"); wait <= 25*time.Second || wait > 30*time.Second {
+		t.Errorf("RetryWait() after first failure = %v, want about 30s", wait)
+	}
+	section := c.RetrySection("task-1")
+	for _, want := range []string{"attempt 2 of 3", "file README.md does not exist", "Untracked file: notes.md"} {
+		if !strings.Contains(section, want) {
+			t.Errorf("RetrySection() missing %q:\n%s", want, section)
+		}
+	}
+	if got := c.RetryModel("task-1"); got != "" {
+		t.Errorf("RetryModel() before the last retry = %q, want empty", got)
+	}
+
+	tracker.IncrementRetry("task-1")
+	if wait := c.RetryWait("task-1"); wait <= 40*time.Second || wait > 45*time.Second {
+		t.Errorf("RetryWait() after second failure = %v, want the 45s cap", wait)
+	}
+	if got := c.RetryModel("task-1"); got != "opus" {
+		t.Errorf("RetryModel() for the last retry = %q, want opus", got)
+	}
+}
+
+func TestCoordinator_RetryDisabled(t *testing.T) {
+	c := retryTestCoordinator(config.RetryConfig{})
+	tracker := &coordinatorRetryTracker{c: c}
+	tracker.IncrementRetry("task-1")
+	tracker.IncrementRetry("task-1")
+
+	if wait := c.RetryWait("task-1"); wait != 0 {
+		t.Errorf("RetryWait() without backoff = %v, want 0", wait)
+	}
+	if got := c.RetrySection("task-1"); got != "" {
+		t.Errorf("RetrySection() without augment_prompt = %q, want empty", got)
+	}
+	if got := c.RetryModel("task-1"); got != "" {
+		t.Errorf("RetryModel() without final_attempt_model = %q, want empty", got)
+	}
+}
+
+func TestTruncateRetryDiff(t *testing.T) {
+	short := "+one line\n"
+	if got := truncateRetryDiff(short); got != short {
+		t.Errorf("truncateRetryDiff(short) = %q, want it unchanged", got)
+	}
+
+	long := strings.Repeat("+a line of the diff\n", maxRetryDiffBytes/10)
+	got := truncateRetryDiff(long)
+	if !strings.HasSuffix(got, "+a line of the diff\n... (diff truncated)\n") {
+		t.Errorf("truncateRetryDiff(long) does not end on a whole line: %q", got[len(got)-60:])
+	}
+	if len(got) > maxRetryDiffBytes+len("... (diff truncated)\n") {
+		t.Errorf("truncateRetryDiff(long) is %d bytes, want at most %d", len(got), maxRetryDiffBytes)
+	}
+}
@@ -0,0 +1,52 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/tracing"
+)
+
+// tracingStopTimeout bounds how long stopping tracing waits to export the
+// spans still buffered.
+const tracingStopTimeout = 5 * time.Second
+
+// startTracing installs the OpenTelemetry exporter configured by tracing,
+// so ultra-plan coordinators and bridges export their spans. It only runs
+// for sessions with their own directory, and is a no-op when tracing is
+// disabled or already running. A setup failure is logged and the session
+// continues untraced. Caller must hold o.mu.
+func (o *Orchestrator) startTracing() {
+	if o.sessionDir == "" || o.tracer != nil {
+		return
+	}
+	p, err := tracing.Start(context.Background(), o.config.Tracing, o.sessionDir,
+		tracing.WithAttributes(tracing.SessionID.String(o.sessionID)))
+	if err != nil {
+		if o.logger != nil {
+			o.logger.Warn("tracing disabled", "error", err)
+		}
+		return
+	}
+	if p == nil {
+		return
+	}
+	o.tracer = p
+	if o.logger != nil {
+		o.logger.Info("tracing enabled", "exporter", o.config.Tracing.Exporter)
+	}
+}
+
+// stopTracingLocked exports buffered spans and uninstalls the exporter if
+// tracing is running. Caller must hold o.mu.
+func (o *Orchestrator) stopTracingLocked() {
+	if o.tracer == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), tracingStopTimeout)
+	defer cancel()
+	if err := o.tracer.Shutdown(ctx); err != nil && o.logger != nil {
+		o.logger.Warn("failed to export traces", "error", err)
+	}
+	o.tracer = nil
+}
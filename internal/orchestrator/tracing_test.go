@@ -0,0 +1,41 @@
+package orchestrator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/tracing"
+)
+
+func TestOrchestrator_Tracing(t *testing.T) {
+	cfg := config.Default()
+	o := &Orchestrator{sessionID: "s1", sessionDir: t.TempDir(), config: cfg}
+
+	o.startTracing()
+	if o.tracer != nil {
+		t.Fatal("tracing should not start when tracing.enabled is false")
+	}
+
+	cfg.Tracing.Enabled = true
+	cfg.Tracing.Exporter = "file"
+	o.startTracing()
+	if o.tracer == nil {
+		t.Fatal("tracing should start when enabled")
+	}
+	_, span := tracing.Tracer().Start(context.Background(), "ultraplan")
+	span.End()
+
+	o.stopTracingLocked()
+	if o.tracer != nil {
+		t.Error("tracer should be cleared after stop")
+	}
+	o.stopTracingLocked() // no-op when stopped
+
+	data, err := os.ReadFile(filepath.Join(o.sessionDir, tracing.FileName))
+	if err != nil || len(data) == 0 {
+		t.Errorf("spans should be flushed to %s on stop: %v", tracing.FileName, err)
+	}
+}
@@ -9,11 +9,25 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/mailbox"
 )
 
 // TaskCompletionFileName is the sentinel file that tasks write when complete.
 const TaskCompletionFileName = ".claudio-task-complete.json"
 
+// Sentinel protocol versions. The sentinel protocol is the set of completion
+// files instances are prompted to write and the fields they carry. Sessions
+// record the version their instances were prompted with, so a session whose
+// in-flight instances write files this build cannot read is refused rather
+// than left waiting on them.
+const (
+	// SentinelProtocolVersion is the protocol this build prompts with.
+	SentinelProtocolVersion = 1
+	// MinSentinelProtocolVersion is the oldest protocol this build reads.
+	MinSentinelProtocolVersion = 1
+)
+
 // FlexibleString can unmarshal from either a string or a string array.
 // When unmarshaling a string array, the elements are joined with newlines.
 // This handles both simple string notes and structured array notes.
@@ -58,6 +72,41 @@ type TaskCompletionFile struct {
 	Issues       []string       `json:"issues,omitempty"`       // Blocking issues or concerns found
 	Suggestions  []string       `json:"suggestions,omitempty"`  // Integration suggestions for other tasks
 	Dependencies []string       `json:"dependencies,omitempty"` // Runtime dependencies added
+
+	// Follow-up work found outside the task's scope, proposed to the
+	// coordinator for approval
+	ProposedTasks []mailbox.TaskProposal `json:"proposed_tasks,omitempty"`
+
+	// Written when ultraplan.self_review is enabled
+	SelfReview *SelfReview `json:"self_review,omitempty"`
+}
+
+// Confidence bounds for SelfReview.Confidence.
+const (
+	MinConfidence = 1 // The task expects its work to need fixing
+	MaxConfidence = 5 // The task reviewed its diff and its tests pass
+
+	// LowConfidence is the highest score synthesis reviews first.
+	LowConfidence = 2
+)
+
+// SelfReview is a task's review of its own work before completing, with a
+// confidence score that synthesis uses to decide what to look at first.
+type SelfReview struct {
+	Confidence int      `json:"confidence"`          // MinConfidence to MaxConfidence
+	TestsRun   []string `json:"tests_run,omitempty"` // Test commands run, with their outcome
+	Concerns   []string `json:"concerns,omitempty"`  // What a reviewer should check
+}
+
+// Valid reports whether the confidence score is within bounds.
+func (r SelfReview) Valid() bool {
+	return r.Confidence >= MinConfidence && r.Confidence <= MaxConfidence
+}
+
+// IsLow reports whether the task's confidence is low enough that synthesis
+// should review it first.
+func (r SelfReview) IsLow() bool {
+	return r.Valid() && r.Confidence <= LowConfidence
 }
 
 // AggregatedTaskContext holds the aggregated context from all task completion files.
@@ -131,6 +180,7 @@ type VerificationResult struct {
 	CommandsRun    []VerificationStep `json:"commands_run"`
 	OverallSuccess bool               `json:"overall_success"`
 	Summary        string             `json:"summary,omitempty"` // Brief summary of verification outcome
+	Native         bool               `json:"native,omitempty"`  // Run by Claudio from ultraplan.verify.commands rather than reported by the consolidator
 }
 
 // VerificationStep represents a single verification command and its result.
@@ -138,7 +188,12 @@ type VerificationStep struct {
 	Name    string `json:"name"`    // e.g., "build", "lint", "test"
 	Command string `json:"command"` // Actual command run
 	Success bool   `json:"success"`
-	Output  string `json:"output,omitempty"` // Truncated output on failure
+	Output  string `json:"output,omitempty"`  // Truncated output on failure
+	Outcome string `json:"outcome,omitempty"` // "flaky-pass" when a failed step passed on an isolated re-run, "quarantined" when it failed again only in known-flaky tests
+	Cached  bool   `json:"cached,omitempty"`  // The re-run result came from the verification cache for an identical tree
+
+	FlakyTests       []string `json:"flaky_tests,omitempty"`       // Go tests that failed and then passed on the re-run
+	QuarantinedTests []string `json:"quarantined_tests,omitempty"` // Go tests that failed again but already flaked this session
 }
 
 // GroupConsolidationCompletionFileName is the sentinel file that per-group
@@ -159,6 +214,18 @@ type GroupConsolidationCompletionFile struct {
 	IssuesForNextGroup []string               `json:"issues_for_next_group,omitempty"` // Warnings/concerns to pass forward
 }
 
+// ConflictResolutionCompletionFileName is the sentinel file that conflict
+// resolver instances write when they are done with a conflicted cherry-pick.
+const ConflictResolutionCompletionFileName = ".claudio-conflict-resolution-complete.json"
+
+// ConflictResolutionCompletionFile is written by a conflict resolver instance
+// started during group consolidation.
+type ConflictResolutionCompletionFile struct {
+	Status        string               `json:"status"` // "resolved", "failed"
+	FilesResolved []ConflictResolution `json:"files_resolved,omitempty"`
+	Notes         string               `json:"notes,omitempty"` // Why it failed, or anything the next group should know
+}
+
 // GetNotes returns the consolidator's observations about the consolidated code.
 // This method enables GroupConsolidationCompletionFile to satisfy prompt.GroupContextLike interface.
 func (g *GroupConsolidationCompletionFile) GetNotes() string { return g.Notes }
@@ -216,3 +283,23 @@ func ParseGroupConsolidationCompletionFile(worktreePath string) (*GroupConsolida
 
 	return &completion, nil
 }
+
+// ConflictResolutionCompletionFilePath returns the full path to the conflict resolution completion file.
+func ConflictResolutionCompletionFilePath(worktreePath string) string {
+	return filepath.Join(worktreePath, ConflictResolutionCompletionFileName)
+}
+
+// ParseConflictResolutionCompletionFile reads and parses a conflict resolution completion file.
+func ParseConflictResolutionCompletionFile(worktreePath string) (*ConflictResolutionCompletionFile, error) {
+	data, err := os.ReadFile(ConflictResolutionCompletionFilePath(worktreePath))
+	if err != nil {
+		return nil, err
+	}
+
+	var completion ConflictResolutionCompletionFile
+	if err := json.Unmarshal(data, &completion); err != nil {
+		return nil, fmt.Errorf("failed to parse conflict resolution completion JSON: %w", err)
+	}
+
+	return &completion, nil
+}
@@ -162,6 +162,62 @@ func TestTaskCompletionFile_NotesAsArray(t *testing.T) {
 	}
 }
 
+func TestTaskCompletionFile_SelfReview(t *testing.T) {
+	jsonInput := `{
+		"task_id": "task-123",
+		"status": "complete",
+		"summary": "Test",
+		"self_review": {
+			"confidence": 2,
+			"tests_run": ["go test ./... (pass)"],
+			"concerns": ["retry path is untested"]
+		}
+	}`
+
+	var parsed TaskCompletionFile
+	if err := json.Unmarshal([]byte(jsonInput), &parsed); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if parsed.SelfReview == nil {
+		t.Fatal("SelfReview = nil, want parsed self-review")
+	}
+	if parsed.SelfReview.Confidence != 2 || len(parsed.SelfReview.TestsRun) != 1 || len(parsed.SelfReview.Concerns) != 1 {
+		t.Errorf("SelfReview = %+v", parsed.SelfReview)
+	}
+
+	var without TaskCompletionFile
+	if err := json.Unmarshal([]byte(`{"task_id": "task-123", "status": "complete"}`), &without); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if without.SelfReview != nil {
+		t.Errorf("SelfReview = %+v, want nil when omitted", without.SelfReview)
+	}
+}
+
+func TestSelfReview_ValidAndIsLow(t *testing.T) {
+	tests := []struct {
+		confidence int
+		valid      bool
+		low        bool
+	}{
+		{0, false, false},
+		{1, true, true},
+		{2, true, true},
+		{3, true, false},
+		{5, true, false},
+		{6, false, false},
+	}
+	for _, tt := range tests {
+		r := SelfReview{Confidence: tt.confidence}
+		if got := r.Valid(); got != tt.valid {
+			t.Errorf("SelfReview{%d}.Valid() = %v, want %v", tt.confidence, got, tt.valid)
+		}
+		if got := r.IsLow(); got != tt.low {
+			t.Errorf("SelfReview{%d}.IsLow() = %v, want %v", tt.confidence, got, tt.low)
+		}
+	}
+}
+
 func TestAggregatedTaskContext_HasContent(t *testing.T) {
 	tests := []struct {
 		name    string
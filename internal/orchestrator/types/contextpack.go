@@ -0,0 +1,98 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ContextPackFileName is the file a task's context pack is written to, at the
+// root of its worktree.
+const ContextPackFileName = ".claudio-context-pack.md"
+
+// ContextPack lists the code and docs a task needs, chosen during planning.
+// Before the task starts they are copied into ContextPackFileName in its
+// worktree, so the instance reads them directly instead of searching.
+type ContextPack struct {
+	// Excerpts are line ranges of source files.
+	Excerpts []Excerpt `json:"excerpts,omitempty"`
+
+	// Interfaces are definitions to include whole, as "path:Name" where path
+	// is a repo-relative file or glob pattern (e.g. "internal/auth/*.go:Store").
+	Interfaces []string `json:"interfaces,omitempty"`
+
+	// Docs are repo-relative documentation files to include whole.
+	Docs []string `json:"docs,omitempty"`
+}
+
+// Excerpt is a line range of a repo-relative file.
+type Excerpt struct {
+	Path string `json:"path"`
+
+	// StartLine and EndLine are 1-based and inclusive. Zero StartLine means
+	// the start of the file; zero EndLine means its end.
+	StartLine int `json:"start_line,omitempty"`
+	EndLine   int `json:"end_line,omitempty"`
+
+	// Reason says why the task needs the excerpt.
+	Reason string `json:"reason,omitempty"`
+}
+
+// IsEmpty reports whether p lists nothing.
+func (p *ContextPack) IsEmpty() bool {
+	return p == nil || len(p.Excerpts) == 0 && len(p.Interfaces) == 0 && len(p.Docs) == 0
+}
+
+// Clone returns a deep copy of p.
+func (p *ContextPack) Clone() *ContextPack {
+	if p == nil {
+		return nil
+	}
+	return &ContextPack{
+		Excerpts:   append([]Excerpt(nil), p.Excerpts...),
+		Interfaces: append([]string(nil), p.Interfaces...),
+		Docs:       append([]string(nil), p.Docs...),
+	}
+}
+
+// Validate checks that p is well-formed, without looking at any worktree.
+func (p *ContextPack) Validate() error {
+	if p == nil {
+		return nil
+	}
+	for _, e := range p.Excerpts {
+		if err := validatePackFile(e.Path); err != nil {
+			return fmt.Errorf("context pack excerpt %q: %w", e.Path, err)
+		}
+		if e.StartLine < 0 || e.EndLine < 0 || e.EndLine > 0 && e.EndLine < e.StartLine {
+			return fmt.Errorf("context pack excerpt %q: invalid line range %d-%d", e.Path, e.StartLine, e.EndLine)
+		}
+	}
+	for _, iface := range p.Interfaces {
+		path, name := SplitSymbol(iface)
+		if path == "" {
+			return fmt.Errorf("context pack interface %q: want path:Name", iface)
+		}
+		if !symbolNamePattern.MatchString(name) {
+			return fmt.Errorf("context pack interface %q: %q is not an identifier", iface, name)
+		}
+		if err := validateCriteriaPath(path); err != nil {
+			return fmt.Errorf("context pack interface %q: %w", iface, err)
+		}
+	}
+	for _, doc := range p.Docs {
+		if err := validatePackFile(doc); err != nil {
+			return fmt.Errorf("context pack doc %q: %w", doc, err)
+		}
+	}
+	return nil
+}
+
+// validatePackFile checks a repo-relative file path, which unlike criteria
+// paths may not be a glob pattern.
+func validatePackFile(path string) error {
+	if strings.ContainsAny(path, "*?[") {
+		return errors.New("must be a file, not a pattern")
+	}
+	return validateCriteriaPath(path)
+}
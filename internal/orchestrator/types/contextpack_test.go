@@ -0,0 +1,70 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestContextPack_IsEmpty(t *testing.T) {
+	var nilPack *ContextPack
+	if !nilPack.IsEmpty() {
+		t.Error("nil pack should be empty")
+	}
+	if !(&ContextPack{}).IsEmpty() {
+		t.Error("zero pack should be empty")
+	}
+	if (&ContextPack{Docs: []string{"README.md"}}).IsEmpty() {
+		t.Error("pack with a doc should not be empty")
+	}
+}
+
+func TestContextPack_Clone(t *testing.T) {
+	orig := &ContextPack{Excerpts: []Excerpt{{Path: "a.go", StartLine: 1, EndLine: 5}}, Docs: []string{"README.md"}}
+	clone := orig.Clone()
+	clone.Excerpts[0].Path = "b.go"
+	clone.Docs[0] = "CHANGELOG.md"
+	if orig.Excerpts[0].Path != "a.go" || orig.Docs[0] != "README.md" {
+		t.Error("Clone should not share slices with the original")
+	}
+
+	var nilPack *ContextPack
+	if nilPack.Clone() != nil {
+		t.Error("Clone of nil should be nil")
+	}
+}
+
+func TestContextPack_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pack    *ContextPack
+		wantErr string
+	}{
+		{"nil", nil, ""},
+		{"valid", &ContextPack{
+			Excerpts:   []Excerpt{{Path: "internal/auth/store.go", StartLine: 10, EndLine: 40}, {Path: "go.mod"}},
+			Interfaces: []string{"internal/auth/*.go:Store"},
+			Docs:       []string{"docs/auth.md"},
+		}, ""},
+		{"excerpt pattern", &ContextPack{Excerpts: []Excerpt{{Path: "*.go"}}}, "not a pattern"},
+		{"excerpt absolute", &ContextPack{Excerpts: []Excerpt{{Path: "/etc/passwd"}}}, "relative to the repository root"},
+		{"excerpt reversed range", &ContextPack{Excerpts: []Excerpt{{Path: "a.go", StartLine: 9, EndLine: 3}}}, "invalid line range"},
+		{"excerpt negative line", &ContextPack{Excerpts: []Excerpt{{Path: "a.go", StartLine: -1}}}, "invalid line range"},
+		{"interface without path", &ContextPack{Interfaces: []string{"Store"}}, "want path:Name"},
+		{"interface bad name", &ContextPack{Interfaces: []string{"a.go:Store()"}}, "not an identifier"},
+		{"doc escapes repo", &ContextPack{Docs: []string{"../notes.md"}}, "relative to the repository root"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.pack.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// TestPatternPlaceholder is replaced with a test pattern in
+// CompletionCriteria.TestCommand.
+const TestPatternPlaceholder = "{pattern}"
+
+// symbolNamePattern matches the names a symbol criterion may check.
+var symbolNamePattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// definitionKeywords introduce a definition in the languages symbols are
+// recognized in: Go, Python, Rust, Swift, Kotlin, Java, and
+// JavaScript/TypeScript.
+const definitionKeywords = `func|function|type|class|struct|interface|enum|trait|protocol|def|fn|const|var|let|val`
+
+// definitionModifiers may precede a definition keyword.
+const definitionModifiers = `export|default|pub(?:\([^)]*\))?|public|private|protected|internal|static|final|open|abstract|async|unsafe`
+
+// CompletionCriteria are machine-checkable conditions a task's worktree must
+// meet before the task counts as complete, so verification does not rest on
+// the instance's own report alone.
+type CompletionCriteria struct {
+	// Files are repo-relative paths or glob patterns that must match at least
+	// one existing file.
+	Files []string `json:"files,omitempty"`
+
+	// Symbols must be defined in the worktree, either as "Name" (searched
+	// across the repository) or "path:Name" where path is a repo-relative
+	// file or glob pattern.
+	Symbols []string `json:"symbols,omitempty"`
+
+	// Tests are test name patterns; the tests matching each pattern must
+	// pass.
+	Tests []string `json:"tests,omitempty"`
+
+	// TestCommand runs the tests for one pattern, with {pattern} replaced by
+	// the shell-quoted pattern (e.g. "go test ./... -run {pattern}"). Empty
+	// picks a command from the repository's build files.
+	TestCommand string `json:"test_command,omitempty"`
+}
+
+// IsEmpty reports whether c declares no criteria.
+func (c *CompletionCriteria) IsEmpty() bool {
+	return c == nil || len(c.Files) == 0 && len(c.Symbols) == 0 && len(c.Tests) == 0
+}
+
+// Clone returns a deep copy of c.
+func (c *CompletionCriteria) Clone() *CompletionCriteria {
+	if c == nil {
+		return nil
+	}
+	return &CompletionCriteria{
+		Files:       append([]string(nil), c.Files...),
+		Symbols:     append([]string(nil), c.Symbols...),
+		Tests:       append([]string(nil), c.Tests...),
+		TestCommand: c.TestCommand,
+	}
+}
+
+// SplitSymbol splits a Symbols entry into its path pattern ("" for the whole
+// repository) and symbol name.
+func SplitSymbol(symbol string) (path, name string) {
+	if i := strings.LastIndex(symbol, ":"); i >= 0 {
+		return strings.TrimSpace(symbol[:i]), strings.TrimSpace(symbol[i+1:])
+	}
+	return "", strings.TrimSpace(symbol)
+}
+
+// SymbolDefinition returns a pattern matching a source line that defines
+// name, such as "func Name(", "type Name struct" or "export class Name".
+func SymbolDefinition(name string) *regexp.Regexp {
+	return regexp.MustCompile(`^\s*(?:(?:` + definitionModifiers + `)\s+)*(?:` +
+		definitionKeywords + `)\s+(?:\([^)]*\)\s*)?` + regexp.QuoteMeta(name) + `\b`)
+}
+
+// Validate checks that c is well-formed, without looking at any worktree.
+func (c *CompletionCriteria) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for _, pattern := range c.Files {
+		if err := validateCriteriaPath(pattern); err != nil {
+			return fmt.Errorf("criteria file %q: %w", pattern, err)
+		}
+	}
+	for _, symbol := range c.Symbols {
+		path, name := SplitSymbol(symbol)
+		if !symbolNamePattern.MatchString(name) {
+			return fmt.Errorf("criteria symbol %q: %q is not an identifier", symbol, name)
+		}
+		if path != "" {
+			if err := validateCriteriaPath(path); err != nil {
+				return fmt.Errorf("criteria symbol %q: %w", symbol, err)
+			}
+		}
+	}
+	for _, pattern := range c.Tests {
+		if strings.TrimSpace(pattern) == "" {
+			return errors.New("criteria test pattern is empty")
+		}
+	}
+	return nil
+}
+
+// validateCriteriaPath checks a repo-relative path or glob pattern.
+func validateCriteriaPath(pattern string) error {
+	switch {
+	case strings.TrimSpace(pattern) == "":
+		return errors.New("path is empty")
+	case filepath.IsAbs(pattern) || !filepath.IsLocal(filepath.Clean(pattern)):
+		return errors.New("path must be relative to the repository root")
+	}
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return fmt.Errorf("bad glob pattern: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompletionCriteria_IsEmpty(t *testing.T) {
+	var nilCriteria *CompletionCriteria
+	if !nilCriteria.IsEmpty() {
+		t.Error("nil criteria should be empty")
+	}
+	if !(&CompletionCriteria{TestCommand: "make test"}).IsEmpty() {
+		t.Error("criteria with only a test command should be empty")
+	}
+	if (&CompletionCriteria{Symbols: []string{"Login"}}).IsEmpty() {
+		t.Error("criteria with a symbol should not be empty")
+	}
+}
+
+func TestCompletionCriteria_Clone(t *testing.T) {
+	orig := &CompletionCriteria{Files: []string{"a.go"}, Tests: []string{"TestA"}, TestCommand: "go test {pattern}"}
+	clone := orig.Clone()
+	clone.Files[0] = "b.go"
+	if orig.Files[0] != "a.go" {
+		t.Error("Clone should not share slices with the original")
+	}
+	if clone.TestCommand != orig.TestCommand {
+		t.Errorf("TestCommand = %q, want %q", clone.TestCommand, orig.TestCommand)
+	}
+
+	var nilCriteria *CompletionCriteria
+	if nilCriteria.Clone() != nil {
+		t.Error("Clone of nil should be nil")
+	}
+}
+
+func TestSplitSymbol(t *testing.T) {
+	tests := []struct {
+		symbol   string
+		wantPath string
+		wantName string
+	}{
+		{"Login", "", "Login"},
+		{"internal/auth/login.go:Login", "internal/auth/login.go", "Login"},
+		{"internal/auth/*.go: Login ", "internal/auth/*.go", "Login"},
+	}
+	for _, tt := range tests {
+		path, name := SplitSymbol(tt.symbol)
+		if path != tt.wantPath || name != tt.wantName {
+			t.Errorf("SplitSymbol(%q) = (%q, %q), want (%q, %q)", tt.symbol, path, name, tt.wantPath, tt.wantName)
+		}
+	}
+}
+
+func TestCompletionCriteria_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria *CompletionCriteria
+		wantErr  string
+	}{
+		{"nil", nil, ""},
+		{"valid", &CompletionCriteria{
+			Files:   []string{"internal/auth/login.go", "docs/*.md"},
+			Symbols: []string{"Login", "internal/auth/*.go:Session"},
+			Tests:   []string{"TestLogin"},
+		}, ""},
+		{"absolute file", &CompletionCriteria{Files: []string{"/etc/passwd"}}, "relative"},
+		{"file outside repo", &CompletionCriteria{Files: []string{"../other/main.go"}}, "relative"},
+		{"bad glob", &CompletionCriteria{Files: []string{"internal/[auth"}}, "glob"},
+		{"symbol not an identifier", &CompletionCriteria{Symbols: []string{"auth.Login"}}, "identifier"},
+		{"symbol path outside repo", &CompletionCriteria{Symbols: []string{"../x.go:Login"}}, "relative"},
+		{"empty test pattern", &CompletionCriteria{Tests: []string{" "}}, "empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.criteria.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want one mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// TaskDraftFileName is where a parked task writes a draft of its completion
+// report: what it has done so far and what is left, in the format of
+// TaskCompletionFileName with status "in_progress".
+const TaskDraftFileName = ".claudio-task-draft.json"
+
+// ResumeContextFileName is where the context a resumed task is given about
+// where it left off is written, at the root of its worktree.
+const ResumeContextFileName = ".claudio-resume-context.md"
+
+// TaskDraftFilePath returns the full path to the task draft file for a given worktree.
+func TaskDraftFilePath(worktreePath string) string {
+	return filepath.Join(worktreePath, TaskDraftFileName)
+}
+
+// ParseTaskDraftFile reads and parses a task draft file.
+func ParseTaskDraftFile(worktreePath string) (*TaskCompletionFile, error) {
+	data, err := os.ReadFile(TaskDraftFilePath(worktreePath))
+	if err != nil {
+		return nil, err
+	}
+
+	var draft TaskCompletionFile
+	if err := json.Unmarshal(data, &draft); err != nil {
+		return nil, fmt.Errorf("failed to parse task draft JSON: %w", err)
+	}
+	return &draft, nil
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ProgressFileName is where a running task writes a summary of its progress
+// when asked (ultraplan.progress_summary_minutes), at the root of its
+// worktree. Each summary replaces the last.
+const ProgressFileName = ".claudio-progress.json"
+
+// ProgressSummary is a running task's account of how far it has got, given
+// to its next attempt so a retry or restart continues the work.
+type ProgressSummary struct {
+	Summary       string   `json:"summary"`
+	Done          []string `json:"done,omitempty"`
+	Remaining     []string `json:"remaining,omitempty"`
+	FilesModified []string `json:"files_modified,omitempty"`
+	Notes         string   `json:"notes,omitempty"`
+
+	// RecordedAt is when the task wrote the summary; set by Claudio from
+	// the file's modification time
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// ProgressFilePath returns the full path to the progress file for a given worktree.
+func ProgressFilePath(worktreePath string) string {
+	return filepath.Join(worktreePath, ProgressFileName)
+}
+
+// ParseProgressFile reads and parses a progress file. RecordedAt is the
+// file's modification time.
+func ParseProgressFile(worktreePath string) (*ProgressSummary, error) {
+	path := ProgressFilePath(worktreePath)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var summary ProgressSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, fmt.Errorf("failed to parse progress JSON: %w", err)
+	}
+	summary.RecordedAt = info.ModTime()
+	return &summary, nil
+}
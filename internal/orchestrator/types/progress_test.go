@@ -0,0 +1,41 @@
+package types
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestParseProgressFile(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := ParseProgressFile(dir); !os.IsNotExist(err) {
+		t.Errorf("ParseProgressFile() without a file error = %v, want not exist", err)
+	}
+
+	body := `{"summary": "Handler done", "done": ["handler"], "remaining": ["tests"], "files_modified": ["api.go"]}`
+	if err := os.WriteFile(ProgressFilePath(dir), []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := os.Chtimes(ProgressFilePath(dir), modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := ParseProgressFile(dir)
+	if err != nil {
+		t.Fatalf("ParseProgressFile() error = %v", err)
+	}
+	if summary.Summary != "Handler done" || len(summary.Remaining) != 1 || summary.FilesModified[0] != "api.go" {
+		t.Errorf("ParseProgressFile() = %+v", summary)
+	}
+	if !summary.RecordedAt.Equal(modTime) {
+		t.Errorf("RecordedAt = %v, want the file's modification time %v", summary.RecordedAt, modTime)
+	}
+
+	if err := os.WriteFile(ProgressFilePath(dir), []byte(`{"summary": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseProgressFile(dir); err == nil {
+		t.Error("ParseProgressFile() of a partly written file should fail")
+	}
+}
@@ -2,20 +2,28 @@ package orchestrator
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/Iron-Ham/claudio/internal/ai"
+	"github.com/Iron-Ham/claudio/internal/drift"
 	"github.com/Iron-Ham/claudio/internal/issue"
 	"github.com/Iron-Ham/claudio/internal/logging"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/retry"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/verify"
+	"github.com/Iron-Ham/claudio/internal/ultraplan/decomposition"
 )
 
 // UltraPlanPhase represents the current phase of an ultra-plan session
@@ -66,15 +74,26 @@ type StepInfo struct {
 
 // PlannedTask represents a single decomposed task from the planning phase
 type PlannedTask struct {
-	ID            string         `json:"id"`
-	Title         string         `json:"title"`
-	Description   string         `json:"description"`     // Detailed task prompt for child session
-	Files         []string       `json:"files,omitempty"` // Expected files to be modified
-	DependsOn     []string       `json:"depends_on"`      // Task IDs this depends on
-	Priority      int            `json:"priority"`        // Execution priority (lower = earlier)
-	EstComplexity TaskComplexity `json:"est_complexity"`
-	IssueURL      string         `json:"issue_url,omitempty"` // External issue tracker URL (GitHub, Linear, Notion, etc.)
-	NoCode        bool           `json:"no_code,omitempty"`   // Task doesn't require code changes (verification/testing tasks)
+	ID            string                    `json:"id"`
+	Title         string                    `json:"title"`
+	Description   string                    `json:"description"`     // Detailed task prompt for child session
+	Files         []string                  `json:"files,omitempty"` // Expected files to be modified
+	DependsOn     []string                  `json:"depends_on"`      // Task IDs this depends on
+	Priority      int                       `json:"priority"`        // Execution priority (lower = earlier)
+	EstComplexity TaskComplexity            `json:"est_complexity"`
+	IssueURL      string                    `json:"issue_url,omitempty"`    // External issue tracker URL (GitHub, Linear, Notion, etc.)
+	NoCode        bool                      `json:"no_code,omitempty"`      // Task doesn't require code changes (verification/testing tasks)
+	Repo          string                    `json:"repo,omitempty"`         // Repository for multi-repo pipelines ("" = primary repo)
+	Contracts     []string                  `json:"contracts,omitempty"`    // Repo-relative artifacts forwarded to dependent teams
+	Backend       string                    `json:"backend,omitempty"`      // Executor for this task ("" = session default, "tool" = run Command)
+	Command       string                    `json:"command,omitempty"`      // Shell command run by the "tool" backend
+	Criteria      *types.CompletionCriteria `json:"criteria,omitempty"`     // Checks the verifier runs before accepting the task
+	ContextPack   *types.ContextPack        `json:"context_pack,omitempty"` // Code and docs copied into the worktree before the task starts
+	Model         string                    `json:"model,omitempty"`        // Model for this task ("" = by complexity or the execution model)
+
+	// TimeoutPolicy names the instance.timeout_policies entry for this task
+	// ("" = match a policy by EstComplexity)
+	TimeoutPolicy string `json:"timeout_policy,omitempty"`
 }
 
 // GetID returns the task's unique identifier.
@@ -105,6 +124,22 @@ func (t *PlannedTask) GetPriority() int { return t.Priority }
 // This method enables PlannedTask to satisfy the prompt.PlannedTaskLike interface.
 func (t *PlannedTask) GetEstComplexity() string { return string(t.EstComplexity) }
 
+// IsNoCode reports whether the task needs no code changes.
+// This method enables PlannedTask to satisfy the phase.PlannedTaskData interface.
+func (t *PlannedTask) IsNoCode() bool { return t.NoCode }
+
+// GetBackend returns the executor selected for this task ("" = session default).
+func (t *PlannedTask) GetBackend() string { return t.Backend }
+
+// GetCommand returns the shell command run by the "tool" backend.
+func (t *PlannedTask) GetCommand() string { return t.Command }
+
+// GetCriteria returns the completion criteria the verifier checks for this task.
+func (t *PlannedTask) GetCriteria() *types.CompletionCriteria { return t.Criteria }
+
+// GetContextPack returns the code and docs selected for this task during planning.
+func (t *PlannedTask) GetContextPack() *types.ContextPack { return t.ContextPack }
+
 // PlanSpec represents the output of the planning phase
 type PlanSpec struct {
 	ID              string              `json:"id"`
@@ -115,9 +150,50 @@ type PlanSpec struct {
 	ExecutionOrder  [][]string          `json:"execution_order"`  // Groups of parallelizable tasks
 	Insights        []string            `json:"insights"`         // Key findings from exploration
 	Constraints     []string            `json:"constraints"`      // Identified constraints/risks
+	Env             map[string]string   `json:"env,omitempty"`    // Variables exported to and described for every task
 	CreatedAt       time.Time           `json:"created_at"`
 }
 
+// ContentHash returns a short, stable hash of the plan's tasks. It covers the
+// fields that define each task's work (not priorities, estimates, or the
+// plan's own metadata) and ignores task order, so re-parsing or replaying the
+// same plan yields the same hash. It is used as the plan ID and to name task
+// branches deterministically.
+func (p *PlanSpec) ContentHash() string {
+	type taskContent struct {
+		ID          string   `json:"id"`
+		Title       string   `json:"title"`
+		Description string   `json:"description"`
+		Files       []string `json:"files"`
+		DependsOn   []string `json:"depends_on"`
+		NoCode      bool     `json:"no_code"`
+		Repo        string   `json:"repo"`
+		Backend     string   `json:"backend"`
+		Command     string   `json:"command"`
+	}
+	tasks := make([]taskContent, len(p.Tasks))
+	for i, t := range p.Tasks {
+		deps := slices.Clone(t.DependsOn)
+		slices.Sort(deps)
+		tasks[i] = taskContent{
+			ID:          t.ID,
+			Title:       t.Title,
+			Description: t.Description,
+			Files:       t.Files,
+			DependsOn:   deps,
+			NoCode:      t.NoCode,
+			Repo:        t.Repo,
+			Backend:     t.Backend,
+			Command:     t.Command,
+		}
+	}
+	slices.SortFunc(tasks, func(a, b taskContent) int { return strings.Compare(a.ID, b.ID) })
+
+	data, _ := json.Marshal(tasks)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:6])
+}
+
 // PlannedTaskLike is an interface that PlannedTask satisfies.
 // This enables the prompt package to work with tasks via interface without
 // creating an import cycle. The prompt package can define its own compatible
@@ -175,19 +251,85 @@ type UltraPlanConfig struct {
 
 	// Consolidation settings
 	ConsolidationMode ConsolidationMode `json:"consolidation_mode,omitempty"` // "stacked" or "single"
+	MergeQueue        bool              `json:"merge_queue,omitempty"`        // Consolidate with the merge queue: rerere, additive conflict resolution, reordering
+	ConflictResolver  bool              `json:"conflict_resolver,omitempty"`  // Hand cherry-pick conflicts to a resolver instance instead of failing
 	CreateDraftPRs    bool              `json:"create_draft_prs"`             // Create PRs as drafts
 	PRLabels          []string          `json:"pr_labels,omitempty"`          // Labels to add to PRs
 	BranchPrefix      string            `json:"branch_prefix,omitempty"`      // Branch prefix for consolidated branches
+	MaxBehindCommits  int               `json:"max_behind_commits,omitempty"` // Commits behind origin's main before PRs are stale (0 = no check)
+	AutoRebase        bool              `json:"auto_rebase,omitempty"`        // Rebase stale branches and re-verify before opening PRs
 
 	// Task verification settings
-	MaxTaskRetries         int  `json:"max_task_retries,omitempty"` // Max retry attempts for tasks with no commits (default: 3)
-	RequireVerifiedCommits bool `json:"require_verified_commits"`   // If true, tasks must produce commits to be marked successful (default: true)
+	MaxTaskRetries         int  `json:"max_task_retries,omitempty"`   // Max retry attempts for tasks with no commits (default: 3)
+	RequireVerifiedCommits bool `json:"require_verified_commits"`     // If true, tasks must produce commits to be marked successful (default: true)
+	FreshVerification      bool `json:"fresh_verification,omitempty"` // Re-run verification commands instead of using cached results for the same tree
+
+	// Verify lists the commands run in each group's consolidated worktree
+	// after the group is consolidated
+	Verify verify.GroupConfig `json:"verify,omitempty"`
 
 	// Pipeline-based execution (Orchestration 2.0)
 	UsePipeline bool `json:"use_pipeline,omitempty"` // Use Pipeline-based execution instead of legacy ExecutionOrchestrator
 
 	// Spec-driven planning
 	SpecURL string `json:"spec_url,omitempty"` // URL or description of an existing spec to convert instead of open-ended planning
+
+	// Models selects the model each phase and task runs on
+	Models PhaseModels `json:"models,omitempty"`
+
+	// GroupApproval pauses after each execution group is consolidated until
+	// the next group is approved (see Coordinator.ApproveGroup)
+	GroupApproval bool `json:"group_approval,omitempty"`
+
+	// SynthesisReviewers is how many reviewers with different focuses review
+	// in parallel during synthesis, their issues merged by vote (0 or 1 = a
+	// single synthesis instance)
+	SynthesisReviewers int `json:"synthesis_reviewers,omitempty"`
+}
+
+// PhaseModels selects the model each ultraplan phase runs on. Empty values
+// keep the backend's configured model.
+type PhaseModels struct {
+	Planning      string            `json:"planning,omitempty"`      // Planning and multi-pass plan selection
+	Execution     string            `json:"execution,omitempty"`     // Task instances
+	Synthesis     string            `json:"synthesis,omitempty"`     // Synthesis review
+	Revision      string            `json:"revision,omitempty"`      // Revision instances
+	Consolidation string            `json:"consolidation,omitempty"` // Consolidation instances
+	ByComplexity  map[string]string `json:"by_complexity,omitempty"` // Execution model by task EstComplexity
+}
+
+// ForPhase returns the model for instances started during phase, or "" for
+// the backend's configured model.
+func (m PhaseModels) ForPhase(phase UltraPlanPhase) string {
+	switch phase {
+	case PhasePlanning, PhasePlanSelection:
+		return m.Planning
+	case PhaseExecuting:
+		return m.Execution
+	case PhaseSynthesis:
+		return m.Synthesis
+	case PhaseRevision:
+		return m.Revision
+	case PhaseConsolidating:
+		return m.Consolidation
+	default:
+		return ""
+	}
+}
+
+// ForTask returns the model for task: the task's own Model, else the model
+// for its estimated complexity, else the execution model.
+func (m PhaseModels) ForTask(task *PlannedTask) string {
+	if task == nil {
+		return m.Execution
+	}
+	if task.Model != "" {
+		return task.Model
+	}
+	if model := m.ByComplexity[string(task.EstComplexity)]; model != "" {
+		return model
+	}
+	return m.Execution
 }
 
 // DefaultUltraPlanConfig returns the default configuration
@@ -203,6 +345,7 @@ func DefaultUltraPlanConfig() UltraPlanConfig {
 		CreateDraftPRs:         true,
 		PRLabels:               []string{"ultraplan"},
 		BranchPrefix:           "", // Uses config.Branch.Prefix if empty
+		MaxBehindCommits:       20,
 		MaxTaskRetries:         3,
 		RequireVerifiedCommits: true,
 		UsePipeline:            true, // Default to Orchestration 2.0 pipeline execution
@@ -216,6 +359,7 @@ type RevisionIssue struct {
 	Files       []string `json:"files,omitempty"`      // Files affected by the issue
 	Severity    string   `json:"severity,omitempty"`   // "critical", "major", "minor"
 	Suggestion  string   `json:"suggestion,omitempty"` // Suggested fix
+	Reviewers   []string `json:"reviewers,omitempty"`  // Focuses of the parallel synthesis reviewers that reported it
 }
 
 // PlanScore represents the evaluation of a single candidate plan
@@ -371,6 +515,26 @@ type GroupDecisionState struct {
 	AwaitingDecision bool     `json:"awaiting_decision"` // True when paused for user input
 }
 
+// GroupApprovalState is a pause after an execution group was consolidated,
+// until a human approves starting the next group. It summarizes what the
+// group changed.
+type GroupApprovalState struct {
+	GroupIndex         int      `json:"group_index"`                     // The group that was consolidated
+	Branch             string   `json:"branch,omitempty"`                // Its consolidated branch
+	TasksConsolidated  []string `json:"tasks_consolidated,omitempty"`    // Tasks merged into the branch
+	FilesChanged       []string `json:"files_changed,omitempty"`         // Files the group changed
+	VerificationPassed bool     `json:"verification_passed"`             // Build, lint, and tests passed on the branch
+	Verification       string   `json:"verification,omitempty"`          // The consolidator's verification summary
+	Notes              string   `json:"notes,omitempty"`                 // The consolidator's observations
+	IssuesForNextGroup []string `json:"issues_for_next_group,omitempty"` // Concerns passed to the next group
+}
+
+// ApprovalID is how the control API names this gate: "group-N", N being
+// the 1-based number of the group waiting to start.
+func (g *GroupApprovalState) ApprovalID() string {
+	return fmt.Sprintf("group-%d", g.GroupIndex+2)
+}
+
 // UltraPlanSession represents an ultra-plan orchestration session
 type UltraPlanSession struct {
 	ID            string          `json:"id"`
@@ -388,6 +552,17 @@ type UltraPlanSession struct {
 	PlanManagerID         string       `json:"plan_manager_id,omitempty"`        // Instance ID of the coordinator-manager
 	SelectedPlanIndex     int          `json:"selected_plan_index,omitempty"`    // Index of selected plan (-1 if merged)
 
+	// Human feedback rounds on the draft plan (see Coordinator.RefinePlan)
+	PlanRefinement *PlanRefinement `json:"plan_refinement,omitempty"`
+
+	// Parallel tasks predicted to conflict, found when the plan was set
+	// (see Coordinator.predictPlanConflicts)
+	PlanConflicts *decomposition.Report `json:"plan_conflicts,omitempty"`
+
+	// Instance IDs of the parallel synthesis reviewers, in focus order
+	// (see UltraPlanConfig.SynthesisReviewers)
+	SynthesisReviewerIDs []string `json:"synthesis_reviewer_ids,omitempty"`
+
 	SynthesisID     string            `json:"synthesis_id,omitempty"`     // Instance ID of the synthesis reviewer
 	RevisionID      string            `json:"revision_id,omitempty"`      // Instance ID of the current revision coordinator
 	ConsolidationID string            `json:"consolidation_id,omitempty"` // Instance ID of the consolidation agent
@@ -441,8 +616,39 @@ type UltraPlanSession struct {
 	// Group decision state (set when group has mix of success/failure)
 	GroupDecision *GroupDecisionState `json:"group_decision,omitempty"`
 
+	// Pause before the next group, when ultraplan.group_approval is enabled
+	// (see Coordinator.awaitGroupApproval)
+	GroupApproval *GroupApprovalState `json:"group_approval,omitempty"`
+
+	// Follow-up tasks instances proposed during execution, awaiting approval
+	// (see Coordinator.ProposeTask)
+	TaskProposals []*TaskProposal `json:"task_proposals,omitempty"`
+	// Proposals received so far, approved or not; numbers proposal IDs
+	ProposalCount int `json:"proposal_count,omitempty"`
+
 	// Verified commit counts per task (populated after task completion)
 	TaskCommitCounts map[string]int `json:"task_commit_counts,omitempty"`
+
+	// Self-reviews from task completion files, when ultraplan.self_review
+	// is enabled (see Coordinator.recordSelfReview)
+	TaskSelfReviews map[string]types.SelfReview `json:"task_self_reviews,omitempty"`
+
+	// Latest progress summaries of unfinished tasks, given to their next
+	// attempt (see Coordinator.watchProgress)
+	TaskProgress map[string]types.ProgressSummary `json:"task_progress,omitempty"`
+
+	// Commits that landed on the base branch while the plan ran (see
+	// Orchestrator.startDriftWatch)
+	BaseDrift *BaseDriftState `json:"base_drift,omitempty"`
+}
+
+// BaseDriftState records the base ref an ultra-plan started from and the
+// latest drift detected on it since.
+type BaseDriftState struct {
+	Ref        string        `json:"ref"`              // Watched ref, e.g. "origin/main"
+	Branch     string        `json:"branch,omitempty"` // Branch fetched before each check ("" = no remote)
+	BaseCommit string        `json:"base_commit"`      // Commit Ref pointed at when execution started
+	Latest     *drift.Report `json:"latest,omitempty"` // Most recent drift report; supersedes earlier ones
 }
 
 // NewUltraPlanSession creates a new ultra-plan session
@@ -512,6 +718,10 @@ func (s *UltraPlanSession) GetReadyTasks() []string {
 	if s.GroupDecision != nil && s.GroupDecision.AwaitingDecision {
 		return nil
 	}
+	// Nor while the next group waits for approval
+	if s.GroupApproval != nil {
+		return nil
+	}
 
 	// Build set of started/completed tasks
 	startedOrCompleted := make(map[string]bool)
@@ -979,6 +1189,7 @@ func (m *UltraPlanManager) MarkTaskComplete(taskID string) {
 	}
 	m.session.CompletedTasks = append(m.session.CompletedTasks, taskID)
 	delete(m.session.TaskToInstance, taskID)
+	delete(m.session.TaskProgress, taskID)
 	task := m.session.GetTask(taskID)
 	m.mu.Unlock()
 
@@ -1031,12 +1242,48 @@ func (m *UltraPlanManager) MarkTaskFailed(taskID string, reason string) {
 	})
 }
 
-// AssignTaskToInstance records the mapping from task to instance
+// TaskInstances returns a copy of the mapping from running tasks to their
+// instances.
+func (m *UltraPlanManager) TaskInstances() map[string]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return maps.Clone(m.session.TaskToInstance)
+}
+
+// RecordTaskProgress stores a task's progress summary unless the one
+// already stored was written at the same time or later, and reports whether
+// it was stored.
+func (m *UltraPlanManager) RecordTaskProgress(taskID string, summary types.ProgressSummary) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if prev, ok := m.session.TaskProgress[taskID]; ok && !summary.RecordedAt.After(prev.RecordedAt) {
+		return false
+	}
+	if m.session.TaskProgress == nil {
+		m.session.TaskProgress = make(map[string]types.ProgressSummary)
+	}
+	m.session.TaskProgress[taskID] = summary
+	return true
+}
+
+// TaskProgress returns the latest progress summary recorded for a task.
+func (m *UltraPlanManager) TaskProgress(taskID string) (types.ProgressSummary, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	summary, ok := m.session.TaskProgress[taskID]
+	return summary, ok
+}
+
+// AssignTaskToInstance records the mapping from task to instance and applies
+// the task's timeout policy to the instance
 func (m *UltraPlanManager) AssignTaskToInstance(taskID, instanceID string) {
 	m.mu.Lock()
 	m.session.TaskToInstance[taskID] = instanceID
+	task := m.session.GetTask(taskID)
 	m.mu.Unlock()
 
+	m.orch.applyTaskTimeoutPolicy(instanceID, task)
+
 	m.logger.Info("task assigned to instance",
 		"task_id", taskID,
 		"instance_id", instanceID,
@@ -1064,10 +1311,11 @@ func ParsePlanFromOutput(output string, objective string) (*PlanSpec, error) {
 
 	// Parse the JSON
 	var rawPlan struct {
-		Summary     string        `json:"summary"`
-		Tasks       []PlannedTask `json:"tasks"`
-		Insights    []string      `json:"insights"`
-		Constraints []string      `json:"constraints"`
+		Summary     string            `json:"summary"`
+		Tasks       []PlannedTask     `json:"tasks"`
+		Insights    []string          `json:"insights"`
+		Constraints []string          `json:"constraints"`
+		Env         map[string]string `json:"env"`
 	}
 
 	if err := json.Unmarshal([]byte(jsonStr), &rawPlan); err != nil {
@@ -1080,15 +1328,16 @@ func ParsePlanFromOutput(output string, objective string) (*PlanSpec, error) {
 
 	// Build the PlanSpec
 	plan := &PlanSpec{
-		ID:              generateID(),
 		Objective:       objective,
 		Summary:         rawPlan.Summary,
 		Tasks:           rawPlan.Tasks,
 		Insights:        rawPlan.Insights,
 		Constraints:     rawPlan.Constraints,
+		Env:             rawPlan.Env,
 		DependencyGraph: make(map[string][]string),
 		CreatedAt:       time.Now(),
 	}
+	plan.ID = plan.ContentHash()
 
 	// Build dependency graph
 	for _, task := range plan.Tasks {
@@ -1101,8 +1350,8 @@ func ParsePlanFromOutput(output string, objective string) (*PlanSpec, error) {
 	return plan, nil
 }
 
-// ParsePlanFromFile reads and parses a plan from a JSON file.
-// It supports two formats:
+// ParsePlanFromFile reads and parses a plan from a JSON file, or a YAML file
+// when its extension is .yaml or .yml. It supports two formats:
 //  1. Root-level format: {"summary": "...", "tasks": [...]}
 //  2. Nested format: {"plan": {"summary": "...", "tasks": [...]}}
 //
@@ -1114,27 +1363,39 @@ func ParsePlanFromFile(filepath string, objective string) (*PlanSpec, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read plan file: %w", err)
 	}
+	if PlanFormatForPath(filepath) == PlanFormatYAML {
+		if data, err = yamlToJSON(data); err != nil {
+			return nil, err
+		}
+	}
 
 	// flexibleTask handles alternative field names that the backend may generate
 	type flexibleTask struct {
-		ID            string   `json:"id"`
-		Title         string   `json:"title"`
-		Description   string   `json:"description"`
-		Files         []string `json:"files,omitempty"`
-		DependsOn     []string `json:"depends_on"`
-		Depends       []string `json:"depends"` // Alternative name
-		Priority      int      `json:"priority"`
-		EstComplexity string   `json:"est_complexity"`
-		Complexity    string   `json:"complexity"`          // Alternative name
-		IssueURL      string   `json:"issue_url,omitempty"` // External issue tracker URL
-		NoCode        bool     `json:"no_code,omitempty"`   // Task doesn't require code changes
+		ID            string                    `json:"id"`
+		Title         string                    `json:"title"`
+		Description   string                    `json:"description"`
+		Files         []string                  `json:"files,omitempty"`
+		DependsOn     []string                  `json:"depends_on"`
+		Depends       []string                  `json:"depends"` // Alternative name
+		Priority      int                       `json:"priority"`
+		EstComplexity string                    `json:"est_complexity"`
+		Complexity    string                    `json:"complexity"`             // Alternative name
+		IssueURL      string                    `json:"issue_url,omitempty"`    // External issue tracker URL
+		NoCode        bool                      `json:"no_code,omitempty"`      // Task doesn't require code changes
+		Backend       string                    `json:"backend,omitempty"`      // Executor for this task
+		Command       string                    `json:"command,omitempty"`      // Shell command for the "tool" backend
+		Criteria      *types.CompletionCriteria `json:"criteria,omitempty"`     // Machine-checkable completion criteria
+		ContextPack   *types.ContextPack        `json:"context_pack,omitempty"` // Code and docs selected for the task
+		Model         string                    `json:"model,omitempty"`        // Model for the task
+		TimeoutPolicy string                    `json:"timeout_policy,omitempty"`
 	}
 
 	type planContent struct {
-		Summary     string         `json:"summary"`
-		Tasks       []flexibleTask `json:"tasks"`
-		Insights    []string       `json:"insights"`
-		Constraints []string       `json:"constraints"`
+		Summary     string            `json:"summary"`
+		Tasks       []flexibleTask    `json:"tasks"`
+		Insights    []string          `json:"insights"`
+		Constraints []string          `json:"constraints"`
+		Env         map[string]string `json:"env"`
 	}
 
 	// Try parsing as root-level format first
@@ -1186,20 +1447,27 @@ func ParsePlanFromFile(filepath string, objective string) (*PlanSpec, error) {
 			EstComplexity: TaskComplexity(complexity),
 			IssueURL:      ft.IssueURL,
 			NoCode:        ft.NoCode,
+			Backend:       ft.Backend,
+			Command:       ft.Command,
+			Criteria:      ft.Criteria,
+			ContextPack:   ft.ContextPack,
+			Model:         ft.Model,
+			TimeoutPolicy: ft.TimeoutPolicy,
 		}
 	}
 
 	// Build the PlanSpec
 	plan := &PlanSpec{
-		ID:              generateID(),
 		Objective:       objective,
 		Summary:         rawPlan.Summary,
 		Tasks:           tasks,
 		Insights:        rawPlan.Insights,
 		Constraints:     rawPlan.Constraints,
+		Env:             rawPlan.Env,
 		DependencyGraph: make(map[string][]string),
 		CreatedAt:       time.Now(),
 	}
+	plan.ID = plan.ContentHash()
 
 	// Build dependency graph
 	for _, task := range plan.Tasks {
@@ -1323,6 +1591,34 @@ func ValidatePlan(plan *PlanSpec) error {
 		}
 	}
 
+	// Check per-task executor selection
+	for _, task := range plan.Tasks {
+		if task.Backend != "" && !slices.Contains(ai.ValidTaskBackends(), strings.ToLower(task.Backend)) {
+			return fmt.Errorf("task %s uses unknown backend %q", task.ID, task.Backend)
+		}
+		if ai.IsDeterministic(task.Backend) && strings.TrimSpace(task.Command) == "" {
+			return fmt.Errorf("task %s uses the %s backend but has no command", task.ID, ai.BackendToolRunner)
+		}
+	}
+
+	// Check declared completion criteria
+	for _, task := range plan.Tasks {
+		if err := task.Criteria.Validate(); err != nil {
+			return fmt.Errorf("task %s: %w", task.ID, err)
+		}
+	}
+
+	// Check context packs
+	for _, task := range plan.Tasks {
+		if err := task.ContextPack.Validate(); err != nil {
+			return fmt.Errorf("task %s: %w", task.ID, err)
+		}
+	}
+
+	if err := ValidatePlanEnv(plan.Env); err != nil {
+		return err
+	}
+
 	// Check for cycles by verifying all tasks appear in execution order
 	if plan.ExecutionOrder != nil {
 		scheduledTasks := 0
@@ -1442,6 +1738,7 @@ type ConsolidationCompletionFile struct {
 	Mode             string                   `json:"mode"`   // "stacked" or "single"
 	GroupResults     []GroupConsolidationInfo `json:"group_results"`
 	PRsCreated       []PRInfo                 `json:"prs_created"`
+	PendingPRs       []PendingPRInfo          `json:"pending_prs,omitempty"` // PRs left to create when gh is unavailable
 	SynthesisContext *SynthesisCompletionFile `json:"synthesis_context,omitempty"`
 	TotalCommits     int                      `json:"total_commits"`
 	FilesChanged     []string                 `json:"files_changed"`
@@ -1463,6 +1760,16 @@ type PRInfo struct {
 	GroupIndex int    `json:"group_index"`
 }
 
+// PendingPRInfo describes a PR the consolidator pushed the branch for but
+// did not create
+type PendingPRInfo struct {
+	Branch     string `json:"branch"`
+	Base       string `json:"base"`
+	Title      string `json:"title"`
+	Body       string `json:"body"`
+	GroupIndex int    `json:"group_index"`
+}
+
 // ConsolidationCompletionFilePath returns the full path to the consolidation completion file
 func ConsolidationCompletionFilePath(worktreePath string) string {
 	return filepath.Join(worktreePath, ConsolidationCompletionFileName)
@@ -1532,8 +1839,13 @@ Write a JSON file with this structure:
   - "depends_on": IDs of tasks that must complete first (array of strings, empty for independent tasks)
   - "priority": Lower = higher priority within dependency level (number)
   - "est_complexity": "low", "medium", or "high" (string)
+  - "criteria": Checks the verifier runs before accepting the task: "files" that must exist, "symbols" that must be defined ("Name" or "path:Name"), and "tests" name patterns that must pass (object, optional)
+  - "context_pack": Code the task needs, copied into a file in its worktree before it starts so it does not have to search: "excerpts" of files as {"path", "start_line", "end_line", "reason"}, "interfaces" to include whole as "path:Name", and "docs" paths (object, optional)
+  - "repo": Name of the repository the task runs in, for plans spanning several repositories; tasks in the same repository share a team (string, optional)
+  - "contracts": Repository-relative paths of artifacts this task produces that tasks in other repositories depend on, such as API schemas (array of strings, optional)
 - "insights": Key findings about the codebase (array of strings)
 - "constraints": Risks or constraints to consider (array of strings)
+- "env": Shared values every task needs, such as a feature flag name or target API version, as NAME → value; exported into each task's environment (object of strings, optional)
 
 ## Guidelines
 
@@ -1691,10 +2003,15 @@ Write a JSON file with this structure:
   - "depends_on": IDs of tasks that must complete first, derived from the spec's dependency structure (array of strings, empty for independent tasks)
   - "priority": Lower = higher priority within dependency level (number)
   - "est_complexity": "low", "medium", or "high" (string)
+  - "criteria": Checks the verifier runs before accepting the task: "files" that must exist, "symbols" that must be defined ("Name" or "path:Name"), and "tests" name patterns that must pass (object, optional)
+  - "context_pack": Code the task needs, copied into a file in its worktree before it starts so it does not have to search: "excerpts" of files as {"path", "start_line", "end_line", "reason"}, "interfaces" to include whole as "path:Name", and "docs" paths (object, optional)
+  - "repo": Name of the repository the task runs in, for plans spanning several repositories; tasks in the same repository share a team (string, optional)
+  - "contracts": Repository-relative paths of artifacts this task produces that tasks in other repositories depend on, such as API schemas (array of strings, optional)
   - "issue_url": URL of the source task in the spec (string, optional)
   - "no_code": true for non-engineering tasks (boolean, optional)
 - "insights": Key architectural findings from codebase exploration (array of strings)
 - "constraints": Risks or constraints mentioned in the spec (array of strings)
+- "env": Shared values every task needs, such as a feature flag name or target API version, as NAME → value; exported into each task's environment (object of strings, optional)
 
 ## Guidelines
 
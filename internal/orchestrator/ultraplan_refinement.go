@@ -0,0 +1,440 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/ai"
+)
+
+// PlanFeedbackFileName is the file in the planner's worktree that holds the
+// human's feedback on a draft plan, along with the draft itself.
+const PlanFeedbackFileName = ".claudio-plan-feedback.md"
+
+// planRevisionMessage is sent to a running planner to point it at the feedback file.
+const planRevisionMessage = "The human reviewed your plan and requested changes. " +
+	"Read %s, revise the plan accordingly, and write the complete revised plan to %s."
+
+// PlanFeedbackKind classifies one piece of human feedback on a draft plan.
+type PlanFeedbackKind string
+
+const (
+	FeedbackSplit PlanFeedbackKind = "split" // Split a task into smaller tasks
+	FeedbackMerge PlanFeedbackKind = "merge" // Merge several tasks into one
+	FeedbackDrop  PlanFeedbackKind = "drop"  // Remove a task from the plan
+	FeedbackAvoid PlanFeedbackKind = "avoid" // Keep every task away from a path
+	FeedbackNote  PlanFeedbackKind = "note"  // Free-form instruction
+)
+
+// PlanFeedback is one structured request from the human reviewing a draft plan.
+type PlanFeedback struct {
+	Kind    PlanFeedbackKind `json:"kind"`
+	Targets []string         `json:"targets,omitempty"` // Task IDs, or paths for FeedbackAvoid
+	Text    string           `json:"text,omitempty"`    // Extra guidance, or the whole note
+}
+
+// String renders the feedback as an instruction for the planner.
+func (f PlanFeedback) String() string {
+	var s string
+	switch f.Kind {
+	case FeedbackSplit:
+		s = fmt.Sprintf("Split task %s into smaller, independently executable tasks.", strings.Join(f.Targets, ", "))
+	case FeedbackMerge:
+		s = fmt.Sprintf("Merge tasks %s into a single task.", strings.Join(f.Targets, ", "))
+	case FeedbackDrop:
+		s = fmt.Sprintf("Remove task %s from the plan.", strings.Join(f.Targets, ", "))
+	case FeedbackAvoid:
+		s = fmt.Sprintf("Do not modify %s; no task may touch it.", strings.Join(f.Targets, ", "))
+	default:
+		return f.Text
+	}
+	if f.Text != "" {
+		s += " " + f.Text
+	}
+	return s
+}
+
+// ParsePlanFeedback turns the human's review comments into structured
+// feedback. Entries are separated by newlines or semicolons. Entries starting
+// with split, merge, drop (or remove/delete), or avoid (or "don't touch") become
+// structured requests; anything else is kept as a note. Task references may be
+// task IDs or the 1-based task numbers shown in the plan editor, e.g.
+// "split task 3; don't touch pkg/api".
+func ParsePlanFeedback(input string, plan *PlanSpec) []PlanFeedback {
+	var feedback []PlanFeedback
+	for _, entry := range strings.FieldsFunc(input, func(r rune) bool { return r == '\n' || r == ';' }) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		feedback = append(feedback, parseFeedbackEntry(entry, plan))
+	}
+	return feedback
+}
+
+// parseFeedbackEntry parses one feedback entry.
+func parseFeedbackEntry(entry string, plan *PlanSpec) PlanFeedback {
+	words := strings.Fields(entry)
+	verb := strings.ToLower(words[0])
+	rest := words[1:]
+
+	switch verb {
+	case "don't", "dont", "do":
+		// "don't touch X" / "do not touch X"
+		if verb == "do" && len(rest) > 0 && strings.EqualFold(rest[0], "not") {
+			rest = rest[1:]
+		}
+		if len(rest) > 1 && strings.EqualFold(rest[0], "touch") {
+			return PlanFeedback{Kind: FeedbackAvoid, Targets: []string{rest[1]}, Text: strings.Join(rest[2:], " ")}
+		}
+	case "avoid":
+		if len(rest) > 0 {
+			return PlanFeedback{Kind: FeedbackAvoid, Targets: []string{rest[0]}, Text: strings.Join(rest[1:], " ")}
+		}
+	case "split", "drop", "remove", "delete":
+		rest = skipTaskWord(rest)
+		if len(rest) > 0 {
+			kind := FeedbackSplit
+			if verb != "split" {
+				kind = FeedbackDrop
+			}
+			return PlanFeedback{Kind: kind, Targets: []string{resolveTaskRef(rest[0], plan)}, Text: strings.Join(rest[1:], " ")}
+		}
+	case "merge":
+		// "merge task 2 and 3", "merge task-a, task-b: shared setup"
+		var targets []string
+		i := 0
+		for ; i < len(rest); i++ {
+			word := strings.TrimSuffix(rest[i], ",")
+			if strings.EqualFold(word, "task") || strings.EqualFold(word, "tasks") || strings.EqualFold(word, "and") {
+				continue
+			}
+			if ref, done := strings.CutSuffix(word, ":"); done {
+				targets = append(targets, resolveTaskRef(ref, plan))
+				i++
+				break
+			}
+			targets = append(targets, resolveTaskRef(word, plan))
+		}
+		if len(targets) > 1 {
+			return PlanFeedback{Kind: FeedbackMerge, Targets: targets, Text: strings.Join(rest[i:], " ")}
+		}
+	}
+	return PlanFeedback{Kind: FeedbackNote, Text: entry}
+}
+
+// skipTaskWord drops a leading "task" so "split task 3" and "split 3" parse alike.
+func skipTaskWord(words []string) []string {
+	if len(words) > 0 && strings.EqualFold(words[0], "task") {
+		return words[1:]
+	}
+	return words
+}
+
+// resolveTaskRef maps a task number shown in the plan editor to its task ID.
+// References that already name a task, or that don't resolve, are returned as is.
+func resolveTaskRef(ref string, plan *PlanSpec) string {
+	ref = strings.Trim(ref, ",.:#")
+	if plan == nil {
+		return ref
+	}
+	if slices.ContainsFunc(plan.Tasks, func(t PlannedTask) bool { return t.ID == ref }) {
+		return ref
+	}
+	if n, err := strconv.Atoi(ref); err == nil && n >= 1 && n <= len(plan.Tasks) {
+		return plan.Tasks[n-1].ID
+	}
+	return ref
+}
+
+// PlanRefinement tracks the rounds of human feedback on a draft plan.
+type PlanRefinement struct {
+	Active      bool                  `json:"active"`                 // A revision is in progress
+	RefinerID   string                `json:"refiner_id,omitempty"`   // Instance revising the plan
+	RequestedAt time.Time             `json:"requested_at,omitempty"` // When the current revision was requested
+	Rounds      []PlanRefinementRound `json:"rounds,omitempty"`
+}
+
+// PlanRefinementRound is one round of feedback and the draft it was given on.
+type PlanRefinementRound struct {
+	Feedback []PlanFeedback `json:"feedback"`
+	Previous *PlanSpec      `json:"previous"`
+	At       time.Time      `json:"at"`
+}
+
+// Draft returns the number of the current draft: 1 before any feedback.
+func (r *PlanRefinement) Draft() int {
+	if r == nil {
+		return 1
+	}
+	return len(r.Rounds) + 1
+}
+
+// LastRound returns the most recent feedback round, or nil.
+func (r *PlanRefinement) LastRound() *PlanRefinementRound {
+	if r == nil || len(r.Rounds) == 0 {
+		return nil
+	}
+	return &r.Rounds[len(r.Rounds)-1]
+}
+
+// BuildPlanFeedbackDocument builds the feedback file for the planner. It is
+// self-contained (objective, requested changes, the current draft, and the
+// output format) so a fresh instance can act on it when the original planner
+// is gone.
+func BuildPlanFeedbackDocument(objective string, plan *PlanSpec, feedback []PlanFeedback) string {
+	var b strings.Builder
+	b.WriteString("# Plan Feedback\n\n")
+	b.WriteString("A human reviewed the draft plan below and requested changes.\n\n")
+	b.WriteString("## Objective\n\n")
+	b.WriteString(objective)
+	b.WriteString("\n\n## Requested Changes\n\n")
+	for i, f := range feedback {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, f)
+	}
+
+	draft, _ := json.MarshalIndent(plan, "", "  ")
+	b.WriteString("\n## Current Draft\n\n```json\n")
+	b.Write(draft)
+	b.WriteString("\n```\n\n## Instructions\n\n")
+	b.WriteString("- Apply every requested change. Explore the codebase again only where a change requires it.\n")
+	b.WriteString("- Keep the IDs of tasks you don't change, so the revision can be compared with the current draft.\n")
+	b.WriteString("- Keep dependencies consistent: no task may depend on a removed task.\n")
+	fmt.Fprintf(&b, "- Write the complete revised plan to `%s` in the same JSON format as the current draft.\n", PlanFileName)
+	return b.String()
+}
+
+// RefinePlan sends human feedback on the draft plan back to the planner and
+// moves the session back to PhasePlanSelection until the revised plan
+// arrives. A running planner receives the feedback in its session, so it
+// keeps what it learned while exploring; otherwise a fresh instance is
+// started with the feedback document as its prompt. The TUI detects the
+// revised plan file and calls CompletePlanRefinement.
+func (c *Coordinator) RefinePlan(feedback []PlanFeedback) error {
+	if len(feedback) == 0 {
+		return errors.New("no feedback given")
+	}
+
+	session := c.Session()
+	c.mu.RLock()
+	plan := session.Plan
+	phase := session.Phase
+	active := session.PlanRefinement != nil && session.PlanRefinement.Active
+	c.mu.RUnlock()
+
+	if plan == nil {
+		return errors.New("no plan to refine")
+	}
+	if phase != PhaseRefresh {
+		return fmt.Errorf("plan can only be refined while awaiting approval (phase: %s)", phase)
+	}
+	if active {
+		return errors.New("a plan revision is already in progress")
+	}
+
+	plannerID := session.CoordinatorID
+	if session.Config.MultiPass && session.PlanManagerID != "" {
+		plannerID = session.PlanManagerID
+	}
+	doc := BuildPlanFeedbackDocument(session.Objective, plan, feedback)
+	refinerID, err := c.sendPlanFeedback(plannerID, doc)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	if session.PlanRefinement == nil {
+		session.PlanRefinement = &PlanRefinement{}
+	}
+	r := session.PlanRefinement
+	r.Rounds = append(r.Rounds, PlanRefinementRound{Feedback: feedback, Previous: plan, At: now})
+	r.Active = true
+	r.RefinerID = refinerID
+	r.RequestedAt = now
+	c.mu.Unlock()
+
+	c.logger.Info("plan refinement requested",
+		"refiner_id", refinerID,
+		"draft", r.Draft(),
+		"feedback_count", len(feedback),
+	)
+
+	// Persists the refinement state along with the phase
+	c.notifyPhaseChange(PhasePlanSelection)
+	return nil
+}
+
+// sendPlanFeedback delivers the feedback document to the planner and returns
+// the ID of the instance that will write the revised plan.
+func (c *Coordinator) sendPlanFeedback(plannerID, doc string) (string, error) {
+	inst := c.orch.GetInstance(plannerID)
+	mgr := c.orch.GetInstanceManager(plannerID)
+	if inst != nil && mgr != nil && mgr.Running() {
+		if err := os.WriteFile(filepath.Join(inst.WorktreePath, PlanFeedbackFileName), []byte(doc), 0644); err != nil {
+			return "", fmt.Errorf("failed to write plan feedback: %w", err)
+		}
+		// Remove the draft so the revised plan file can be detected
+		if err := os.Remove(PlanFilePath(inst.WorktreePath)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("failed to remove draft plan file: %w", err)
+		}
+		mgr.ClearTimeout()
+		c.orch.SetInstanceStatus(plannerID, StatusWorking)
+		mgr.SendInput([]byte(fmt.Sprintf(planRevisionMessage, PlanFeedbackFileName, PlanFileName) + "\r"))
+		return plannerID, nil
+	}
+
+	// The planner is gone (e.g. after a restart); hand the feedback to a new instance
+	refiner, err := c.orch.AddInstance(c.baseSession, doc)
+	if err != nil {
+		return "", fmt.Errorf("failed to create plan refinement instance: %w", err)
+	}
+	if group := c.baseSession.GetGroup(c.Session().GroupID); group != nil {
+		group.AddInstance(refiner.ID)
+	}
+	if err := c.orch.StartInstanceWithOverrides(refiner, ai.StartOptions{Model: c.phaseModel(PhasePlanning)}); err != nil {
+		return "", fmt.Errorf("failed to start plan refinement instance: %w", err)
+	}
+	return refiner.ID, nil
+}
+
+// CompletePlanRefinement installs the revised plan and returns the session to
+// PhaseRefresh for another review. An invalid plan is rejected and the
+// revision stays in progress; call CancelPlanRefinement to give up on it.
+func (c *Coordinator) CompletePlanRefinement(plan *PlanSpec) error {
+	if err := ValidatePlan(plan); err != nil {
+		return fmt.Errorf("invalid revised plan: %w", err)
+	}
+
+	session := c.Session()
+	c.mu.Lock()
+	if session.PlanRefinement != nil {
+		session.PlanRefinement.Active = false
+	}
+	c.mu.Unlock()
+
+	return c.SetPlan(plan)
+}
+
+// CancelPlanRefinement abandons the revision in progress and returns the
+// session to PhaseRefresh with the previous draft.
+func (c *Coordinator) CancelPlanRefinement() {
+	session := c.Session()
+	c.mu.Lock()
+	if session.PlanRefinement == nil || !session.PlanRefinement.Active {
+		c.mu.Unlock()
+		return
+	}
+	session.PlanRefinement.Active = false
+	c.mu.Unlock()
+
+	c.notifyPhaseChange(PhaseRefresh)
+}
+
+// TaskChange describes a task present in both drafts whose content changed.
+type TaskChange struct {
+	Task   PlannedTask // Task as it is in the revised plan
+	Fields []string    // Names of the fields that changed
+}
+
+// PlanDiff is the task-level difference between two drafts of a plan.
+type PlanDiff struct {
+	Added   []PlannedTask
+	Removed []PlannedTask
+	Changed []TaskChange
+}
+
+// Empty reports whether the drafts have the same tasks.
+func (d PlanDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffPlans compares two drafts by task ID. Added and changed tasks are in
+// the revised plan's order, removed tasks in the previous plan's order.
+func DiffPlans(prev, next *PlanSpec) PlanDiff {
+	var d PlanDiff
+	if prev == nil || next == nil {
+		return d
+	}
+
+	before := make(map[string]*PlannedTask, len(prev.Tasks))
+	for i := range prev.Tasks {
+		before[prev.Tasks[i].ID] = &prev.Tasks[i]
+	}
+	after := make(map[string]bool, len(next.Tasks))
+
+	for _, task := range next.Tasks {
+		after[task.ID] = true
+		old, ok := before[task.ID]
+		if !ok {
+			d.Added = append(d.Added, task)
+			continue
+		}
+		if fields := changedTaskFields(old, &task); len(fields) > 0 {
+			d.Changed = append(d.Changed, TaskChange{Task: task, Fields: fields})
+		}
+	}
+	for _, task := range prev.Tasks {
+		if !after[task.ID] {
+			d.Removed = append(d.Removed, task)
+		}
+	}
+	return d
+}
+
+// changedTaskFields lists the names of the fields that differ between two
+// versions of a task. Dependency order is ignored.
+func changedTaskFields(a, b *PlannedTask) []string {
+	var fields []string
+	if a.Title != b.Title {
+		fields = append(fields, "title")
+	}
+	if a.Description != b.Description {
+		fields = append(fields, "description")
+	}
+	if !slices.Equal(a.Files, b.Files) {
+		fields = append(fields, "files")
+	}
+	depsA, depsB := slices.Clone(a.DependsOn), slices.Clone(b.DependsOn)
+	slices.Sort(depsA)
+	slices.Sort(depsB)
+	if !slices.Equal(depsA, depsB) {
+		fields = append(fields, "depends_on")
+	}
+	if a.Priority != b.Priority {
+		fields = append(fields, "priority")
+	}
+	if a.EstComplexity != b.EstComplexity {
+		fields = append(fields, "complexity")
+	}
+	if a.NoCode != b.NoCode || a.Backend != b.Backend || a.Command != b.Command {
+		fields = append(fields, "executor")
+	}
+	if !reflect.DeepEqual(a.Criteria, b.Criteria) {
+		fields = append(fields, "criteria")
+	}
+	if !reflect.DeepEqual(a.ContextPack, b.ContextPack) {
+		fields = append(fields, "context pack")
+	}
+	if a.Repo != b.Repo {
+		fields = append(fields, "repo")
+	}
+	if !slices.Equal(a.Contracts, b.Contracts) {
+		fields = append(fields, "contracts")
+	}
+	if a.Model != b.Model || a.TimeoutPolicy != b.TimeoutPolicy {
+		fields = append(fields, "model")
+	}
+	if a.IssueURL != b.IssueURL {
+		fields = append(fields, "issue")
+	}
+	return fields
+}
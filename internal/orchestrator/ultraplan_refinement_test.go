@@ -0,0 +1,246 @@
+package orchestrator
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func refinementTestPlan() *PlanSpec {
+	return &PlanSpec{
+		Objective: "Add caching",
+		Summary:   "Cache API responses",
+		Tasks: []PlannedTask{
+			{ID: "task-1", Title: "Cache layer", Files: []string{"cache/cache.go"}, EstComplexity: ComplexityMedium},
+			{ID: "task-2", Title: "Wire API", DependsOn: []string{"task-1"}, Files: []string{"pkg/api/client.go"}},
+			{ID: "task-3", Title: "Docs", DependsOn: []string{"task-1"}},
+		},
+	}
+}
+
+func TestParsePlanFeedback(t *testing.T) {
+	plan := refinementTestPlan()
+
+	tests := []struct {
+		name  string
+		input string
+		want  []PlanFeedback
+	}{
+		{
+			name:  "split by task number",
+			input: "split task 2",
+			want:  []PlanFeedback{{Kind: FeedbackSplit, Targets: []string{"task-2"}}},
+		},
+		{
+			name:  "split by ID with guidance",
+			input: "split task-1 into read and write paths",
+			want:  []PlanFeedback{{Kind: FeedbackSplit, Targets: []string{"task-1"}, Text: "into read and write paths"}},
+		},
+		{
+			name:  "merge",
+			input: "merge task 2 and 3: they share setup",
+			want:  []PlanFeedback{{Kind: FeedbackMerge, Targets: []string{"task-2", "task-3"}, Text: "they share setup"}},
+		},
+		{
+			name:  "drop aliases",
+			input: "remove 3",
+			want:  []PlanFeedback{{Kind: FeedbackDrop, Targets: []string{"task-3"}}},
+		},
+		{
+			name:  "avoid path",
+			input: "don't touch pkg/api",
+			want:  []PlanFeedback{{Kind: FeedbackAvoid, Targets: []string{"pkg/api"}, Text: ""}},
+		},
+		{
+			name:  "do not touch",
+			input: "do not touch go.mod please",
+			want:  []PlanFeedback{{Kind: FeedbackAvoid, Targets: []string{"go.mod"}, Text: "please"}},
+		},
+		{
+			name:  "unknown task reference is kept",
+			input: "drop task 9",
+			want:  []PlanFeedback{{Kind: FeedbackDrop, Targets: []string{"9"}}},
+		},
+		{
+			name:  "notes and multiple entries",
+			input: "split 1; use the existing LRU package\n\n merge 2",
+			want: []PlanFeedback{
+				{Kind: FeedbackSplit, Targets: []string{"task-1"}},
+				{Kind: FeedbackNote, Text: "use the existing LRU package"},
+				{Kind: FeedbackNote, Text: "merge 2"}, // A merge needs two tasks
+			},
+		},
+		{
+			name:  "empty",
+			input: " ; \n",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParsePlanFeedback(tt.input, plan)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParsePlanFeedback(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+			for i := range got {
+				if got[i].Kind != tt.want[i].Kind || got[i].Text != tt.want[i].Text || !slices.Equal(got[i].Targets, tt.want[i].Targets) {
+					t.Errorf("entry %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPlanFeedback_String(t *testing.T) {
+	tests := []struct {
+		feedback PlanFeedback
+		want     string
+	}{
+		{PlanFeedback{Kind: FeedbackSplit, Targets: []string{"task-3"}}, "Split task task-3 into smaller, independently executable tasks."},
+		{PlanFeedback{Kind: FeedbackMerge, Targets: []string{"a", "b"}, Text: "Keep a's title."}, "Merge tasks a, b into a single task. Keep a's title."},
+		{PlanFeedback{Kind: FeedbackDrop, Targets: []string{"task-2"}}, "Remove task task-2 from the plan."},
+		{PlanFeedback{Kind: FeedbackAvoid, Targets: []string{"pkg/api"}}, "Do not modify pkg/api; no task may touch it."},
+		{PlanFeedback{Kind: FeedbackNote, Text: "Prefer small PRs"}, "Prefer small PRs"},
+	}
+	for _, tt := range tests {
+		if got := tt.feedback.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestBuildPlanFeedbackDocument(t *testing.T) {
+	doc := BuildPlanFeedbackDocument("Add caching", refinementTestPlan(), []PlanFeedback{
+		{Kind: FeedbackSplit, Targets: []string{"task-2"}},
+		{Kind: FeedbackAvoid, Targets: []string{"pkg/api"}},
+	})
+
+	for _, want := range []string{
+		"Add caching",
+		"1. Split task task-2",
+		"2. Do not modify pkg/api",
+		`"id": "task-1"`,
+		PlanFileName,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("document missing %q:\n%s", want, doc)
+		}
+	}
+}
+
+func TestPlanRefinement_Draft(t *testing.T) {
+	var r *PlanRefinement
+	if r.Draft() != 1 || r.LastRound() != nil {
+		t.Errorf("nil refinement: Draft() = %d, LastRound() = %v", r.Draft(), r.LastRound())
+	}
+
+	r = &PlanRefinement{Rounds: []PlanRefinementRound{{}, {Feedback: []PlanFeedback{{Kind: FeedbackNote}}}}}
+	if r.Draft() != 3 {
+		t.Errorf("Draft() = %d, want 3", r.Draft())
+	}
+	if r.LastRound() != &r.Rounds[1] {
+		t.Error("LastRound() did not return the latest round")
+	}
+}
+
+func TestDiffPlans(t *testing.T) {
+	prev := refinementTestPlan()
+	next := refinementTestPlan()
+	next.Tasks = []PlannedTask{
+		{ID: "task-1", Title: "Cache layer", Files: []string{"cache/cache.go"}, EstComplexity: ComplexityMedium},
+		{ID: "task-2a", Title: "Wire reads"},
+		{ID: "task-3", Title: "Docs and examples", DependsOn: []string{"task-1", "task-2a"}},
+	}
+
+	d := DiffPlans(prev, next)
+	if d.Empty() {
+		t.Fatal("Empty() = true, want changes")
+	}
+	if len(d.Added) != 1 || d.Added[0].ID != "task-2a" {
+		t.Errorf("Added = %+v, want task-2a", d.Added)
+	}
+	if len(d.Removed) != 1 || d.Removed[0].ID != "task-2" {
+		t.Errorf("Removed = %+v, want task-2", d.Removed)
+	}
+	if len(d.Changed) != 1 || d.Changed[0].Task.ID != "task-3" {
+		t.Fatalf("Changed = %+v, want task-3", d.Changed)
+	}
+	if want := []string{"title", "depends_on"}; !slices.Equal(d.Changed[0].Fields, want) {
+		t.Errorf("changed fields = %v, want %v", d.Changed[0].Fields, want)
+	}
+
+	// Dependency order alone is not a change
+	reordered := refinementTestPlan()
+	reordered.Tasks[1].DependsOn = []string{"task-1"}
+	if d := DiffPlans(refinementTestPlan(), reordered); !d.Empty() {
+		t.Errorf("DiffPlans of identical plans = %+v, want empty", d)
+	}
+	if d := DiffPlans(nil, next); !d.Empty() {
+		t.Errorf("DiffPlans(nil, plan) = %+v, want empty", d)
+	}
+}
+
+func TestCoordinator_RefinePlanRejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		session  *UltraPlanSession
+		feedback []PlanFeedback
+		wantErr  string
+	}{
+		{
+			name:     "no feedback",
+			session:  &UltraPlanSession{Phase: PhaseRefresh, Plan: refinementTestPlan()},
+			feedback: nil,
+			wantErr:  "no feedback",
+		},
+		{
+			name:     "no plan",
+			session:  &UltraPlanSession{Phase: PhaseRefresh},
+			feedback: []PlanFeedback{{Kind: FeedbackNote, Text: "x"}},
+			wantErr:  "no plan",
+		},
+		{
+			name:     "not awaiting approval",
+			session:  &UltraPlanSession{Phase: PhaseExecuting, Plan: refinementTestPlan()},
+			feedback: []PlanFeedback{{Kind: FeedbackNote, Text: "x"}},
+			wantErr:  "awaiting approval",
+		},
+		{
+			name: "revision in progress",
+			session: &UltraPlanSession{
+				Phase:          PhaseRefresh,
+				Plan:           refinementTestPlan(),
+				PlanRefinement: &PlanRefinement{Active: true},
+			},
+			feedback: []PlanFeedback{{Kind: FeedbackNote, Text: "x"}},
+			wantErr:  "already in progress",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCoordinatorForTesting(tt.session)
+			err := c.RefinePlan(tt.feedback)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("RefinePlan() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCoordinator_CompletePlanRefinementRejectsInvalidPlan(t *testing.T) {
+	session := &UltraPlanSession{
+		Phase:          PhasePlanSelection,
+		Plan:           refinementTestPlan(),
+		PlanRefinement: &PlanRefinement{Active: true},
+	}
+	c := NewCoordinatorForTesting(session)
+
+	if err := c.CompletePlanRefinement(&PlanSpec{}); err == nil {
+		t.Fatal("CompletePlanRefinement() with an empty plan succeeded, want error")
+	}
+	if !session.PlanRefinement.Active {
+		t.Error("rejected revision ended the refinement")
+	}
+}
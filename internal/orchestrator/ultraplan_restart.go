@@ -0,0 +1,188 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Iron-Ham/claudio/internal/instance"
+)
+
+// GroupRestartResult summarizes what RestartFromGroup discarded and kept.
+type GroupRestartResult struct {
+	FromGroup        int      // 0-based execution group that execution resumes from
+	ResetTasks       []string // Task IDs whose execution state was cleared
+	RemovedInstances []string // Instance IDs removed along with their worktrees and branches
+	DeletedBranches  []string // Consolidation branches deleted for groups >= FromGroup
+	KeptBranches     []string // Consolidated branches of earlier groups left intact
+}
+
+// RestartFromGroup discards all execution results for groups >= targetGroup
+// (0-based) and leaves the session ready to resume execution from that group.
+//
+// Unlike RetriggerGroup, which only resets state in a live session, this also
+// cleans up git: task instances, group consolidators, and synthesis/revision/
+// consolidation instances downstream of targetGroup are stopped and removed
+// together with their worktrees and branches, and the consolidated branches of
+// groups >= targetGroup are deleted. Consolidated branches of earlier groups
+// are kept, so the restarted group builds on them again.
+//
+// Execution is not restarted; callers resume it with StartExecution (or by
+// reattaching to the session). Cleanup failures do not stop the restart: the
+// state reset is always persisted, and any git errors are returned joined
+// alongside the result.
+func (c *Coordinator) RestartFromGroup(targetGroup int) (*GroupRestartResult, error) {
+	session := c.Session()
+	if session == nil || session.Plan == nil {
+		return nil, fmt.Errorf("no plan available")
+	}
+
+	switch session.Phase {
+	case PhasePlanning, PhasePlanSelection, PhaseRefresh:
+		return nil, fmt.Errorf("cannot restart from a group before execution has started (phase: %s)", session.Phase)
+	}
+
+	numGroups := len(session.Plan.ExecutionOrder)
+	if targetGroup < 0 || targetGroup >= numGroups {
+		return nil, fmt.Errorf("invalid target group %d (must be 0-%d)", targetGroup, numGroups-1)
+	}
+	if running := c.GetRunningTaskCount(); running > 0 {
+		return nil, fmt.Errorf("cannot restart while %d tasks are running", running)
+	}
+
+	result := &GroupRestartResult{FromGroup: targetGroup}
+	tasksToReset := make(map[string]bool)
+	for _, group := range session.Plan.ExecutionOrder[targetGroup:] {
+		for _, taskID := range group {
+			tasksToReset[taskID] = true
+			result.ResetTasks = append(result.ResetTasks, taskID)
+		}
+	}
+
+	// Collect everything downstream of targetGroup before the state reset
+	// forgets which instances and branches belonged to it.
+	c.mu.RLock()
+	var instanceIDs []string
+	for _, taskID := range result.ResetTasks {
+		if id := session.TaskToInstance[taskID]; id != "" {
+			instanceIDs = append(instanceIDs, id)
+		}
+	}
+	if targetGroup < len(session.GroupConsolidatorIDs) {
+		instanceIDs = append(instanceIDs, session.GroupConsolidatorIDs[targetGroup:]...)
+	}
+	instanceIDs = append(instanceIDs, session.SynthesisID, session.RevisionID, session.ConsolidationID)
+
+	var branches []string
+	if targetGroup < len(session.GroupConsolidatedBranches) {
+		branches = append(branches, session.GroupConsolidatedBranches[targetGroup:]...)
+		result.KeptBranches = slices.Clone(session.GroupConsolidatedBranches[:targetGroup])
+	} else {
+		result.KeptBranches = slices.Clone(session.GroupConsolidatedBranches)
+	}
+	if session.Consolidation != nil && targetGroup < len(session.Consolidation.GroupBranches) {
+		branches = append(branches, session.Consolidation.GroupBranches[targetGroup:]...)
+	}
+	c.mu.RUnlock()
+
+	c.logger.Info("restarting ultraplan from group",
+		"target_group", targetGroup,
+		"tasks_to_reset", len(tasksToReset),
+		"instances_to_remove", len(instanceIDs),
+		"branches_to_delete", len(branches),
+	)
+
+	// Reset session state exactly as a live retrigger does, then drop the
+	// worktree records of the discarded tasks so consolidation never sees them.
+	newExecutionCoordinatorAdapter(c).ResetStateForRetrigger(targetGroup, tasksToReset)
+	c.mu.Lock()
+	session.TaskWorktrees = slices.DeleteFunc(session.TaskWorktrees, func(tw TaskWorktreeInfo) bool {
+		return tasksToReset[tw.TaskID]
+	})
+	c.mu.Unlock()
+
+	var errs []error
+	removed, err := c.removeRestartInstances(instanceIDs)
+	result.RemovedInstances = removed
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, branch := range branches {
+		if branch == "" {
+			continue
+		}
+		if err := c.orch.wt.DeleteBranch(branch); err != nil {
+			c.logger.Warn("failed to delete consolidation branch during restart",
+				"branch", branch,
+				"error", err.Error(),
+			)
+			errs = append(errs, fmt.Errorf("delete branch %s: %w", branch, err))
+			continue
+		}
+		result.DeletedBranches = append(result.DeletedBranches, branch)
+	}
+
+	if err := c.orch.SaveSession(); err != nil {
+		return result, fmt.Errorf("failed to persist restart state: %w", err)
+	}
+	c.manager.emitEvent(CoordinatorEvent{
+		Type:    EventPhaseChange,
+		Message: fmt.Sprintf("Restarted from group %d", targetGroup+1),
+	})
+
+	return result, errors.Join(errs...)
+}
+
+// removeRestartInstances stops and removes the given instances (worktree,
+// branch, and session entry). IDs that are empty or no longer in the session
+// are skipped. Returns the IDs actually removed.
+func (c *Coordinator) removeRestartInstances(instanceIDs []string) ([]string, error) {
+	var toRemove []string
+	for _, id := range instanceIDs {
+		if id != "" && !slices.Contains(toRemove, id) && c.orch.GetInstance(id) != nil {
+			toRemove = append(toRemove, id)
+		}
+	}
+	if len(toRemove) == 0 {
+		return nil, nil
+	}
+
+	c.killDetachedTmuxSessions(toRemove)
+
+	var removed []string
+	var errs []error
+	for _, id := range toRemove {
+		if err := c.orch.RemoveInstance(c.baseSession, id, true); err != nil {
+			c.logger.Warn("failed to remove instance during restart",
+				"instance_id", id,
+				"error", err.Error(),
+			)
+			errs = append(errs, fmt.Errorf("remove instance %s: %w", id, err))
+			continue
+		}
+		removed = append(removed, id)
+	}
+	return removed, errors.Join(errs...)
+}
+
+// killDetachedTmuxSessions kills tmux sessions of the given instances that are
+// not managed by this process. Instances keep running in tmux after the TUI
+// detaches, and RemoveInstance can only stop instances it has a manager for.
+func (c *Coordinator) killDetachedTmuxSessions(instanceIDs []string) {
+	tmuxSessions, err := instance.ListClaudioTmuxSessionsWithSocket()
+	if err != nil {
+		return
+	}
+	for _, ts := range tmuxSessions {
+		_, instID := instance.ExtractSessionAndInstanceID(ts.SessionName)
+		if instID == "" || !slices.Contains(instanceIDs, instID) {
+			continue
+		}
+		if err := ts.KillCommand().Run(); err != nil {
+			c.logger.Warn("failed to kill tmux session during restart",
+				"session", ts.SessionName,
+				"error", err.Error(),
+			)
+		}
+	}
+}
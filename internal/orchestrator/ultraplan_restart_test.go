@@ -0,0 +1,50 @@
+package orchestrator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCoordinator_RestartFromGroup_Validation(t *testing.T) {
+	tests := []struct {
+		name        string
+		phase       UltraPlanPhase
+		targetGroup int
+		running     bool
+		noPlan      bool
+		wantErr     string
+	}{
+		{name: "no plan", phase: PhaseExecuting, noPlan: true, wantErr: "no plan available"},
+		{name: "planning phase", phase: PhasePlanning, wantErr: "before execution has started"},
+		{name: "plan selection phase", phase: PhasePlanSelection, wantErr: "before execution has started"},
+		{name: "negative group", phase: PhaseExecuting, targetGroup: -1, wantErr: "invalid target group"},
+		{name: "group out of range", phase: PhaseExecuting, targetGroup: 2, wantErr: "invalid target group"},
+		{name: "tasks running", phase: PhaseExecuting, targetGroup: 1, running: true, wantErr: "tasks are running"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCoordinatorForPhaseAdapter(t)
+			session := c.Session()
+			session.Phase = tt.phase
+			if tt.noPlan {
+				session.Plan = nil
+			}
+			if tt.running {
+				c.runningTasks["task-2"] = "inst-2"
+				c.runningCount = 1
+			}
+
+			result, err := c.RestartFromGroup(tt.targetGroup)
+			if err == nil {
+				t.Fatalf("RestartFromGroup(%d) error = nil, want %q", tt.targetGroup, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("RestartFromGroup(%d) error = %q, want it to contain %q", tt.targetGroup, err, tt.wantErr)
+			}
+			if result != nil {
+				t.Errorf("RestartFromGroup(%d) result = %+v, want nil on validation failure", tt.targetGroup, result)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package orchestrator
 import (
 	"encoding/json"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -146,6 +147,51 @@ func TestCalculateExecutionOrder(t *testing.T) {
 	}
 }
 
+func TestPlanSpec_ContentHash(t *testing.T) {
+	base := func() *PlanSpec {
+		return &PlanSpec{
+			Objective: "Add auth",
+			Tasks: []PlannedTask{
+				{ID: "task-1", Title: "Model", Description: "Create model", DependsOn: []string{}},
+				{ID: "task-2", Title: "API", Description: "Add API", DependsOn: []string{"task-1"}, Priority: 1},
+			},
+		}
+	}
+	want := base().ContentHash()
+	if len(want) != 12 {
+		t.Fatalf("ContentHash() = %q, want 12 hex characters", want)
+	}
+
+	reordered := base()
+	reordered.Tasks[0], reordered.Tasks[1] = reordered.Tasks[1], reordered.Tasks[0]
+	reordered.Objective = "Different wording"
+	reordered.Tasks[0].Priority = 5
+	if got := reordered.ContentHash(); got != want {
+		t.Errorf("ContentHash() changed with task order, objective, or priority: %q != %q", got, want)
+	}
+
+	changed := base()
+	changed.Tasks[1].Description = "Add REST API"
+	if got := changed.ContentHash(); got == want {
+		t.Error("ContentHash() did not change when a task description changed")
+	}
+}
+
+func TestParsePlanFromOutput_DeterministicID(t *testing.T) {
+	output := `<plan>{"tasks":[{"id":"task-1","title":"One","description":"Do one","depends_on":[]}]}</plan>`
+	first, err := ParsePlanFromOutput(output, "objective")
+	if err != nil {
+		t.Fatalf("ParsePlanFromOutput() error = %v", err)
+	}
+	second, err := ParsePlanFromOutput(output, "objective")
+	if err != nil {
+		t.Fatalf("ParsePlanFromOutput() error = %v", err)
+	}
+	if first.ID != second.ID || first.ID != first.ContentHash() {
+		t.Errorf("plan IDs = %q, %q; want both equal to ContentHash() %q", first.ID, second.ID, first.ContentHash())
+	}
+}
+
 func TestValidatePlan(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -184,6 +230,51 @@ func TestValidatePlan(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "tool task with command",
+			plan: &PlanSpec{
+				Tasks: []PlannedTask{
+					{ID: "task-1", DependsOn: []string{}, Backend: "tool", Command: "gofmt -w ."},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "tool task without command",
+			plan: &PlanSpec{
+				Tasks: []PlannedTask{
+					{ID: "task-1", DependsOn: []string{}, Backend: "tool"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "task with completion criteria",
+			plan: &PlanSpec{
+				Tasks: []PlannedTask{
+					{ID: "task-1", DependsOn: []string{}, Criteria: &types.CompletionCriteria{Files: []string{"auth/login.go"}, Tests: []string{"TestLogin"}}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "criteria path outside the repository",
+			plan: &PlanSpec{
+				Tasks: []PlannedTask{
+					{ID: "task-1", DependsOn: []string{}, Criteria: &types.CompletionCriteria{Files: []string{"/etc/passwd"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown task backend",
+			plan: &PlanSpec{
+				Tasks: []PlannedTask{
+					{ID: "task-1", DependsOn: []string{}, Backend: "gpt"},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1767,6 +1858,94 @@ func TestParsePlanFromFile_IssueURLAndNoCode(t *testing.T) {
 	}
 }
 
+func TestParsePlanFromFile_Criteria(t *testing.T) {
+	tmpFile := t.TempDir() + "/plan.json"
+	content := `{
+  "summary": "Plan with criteria",
+  "tasks": [
+    {
+      "id": "task-login",
+      "title": "Add login",
+      "description": "Implement login",
+      "depends_on": [],
+      "criteria": {
+        "files": ["internal/auth/login.go"],
+        "symbols": ["internal/auth/login.go:Login"],
+        "tests": ["TestLogin"],
+        "test_command": "go test ./internal/auth -run {pattern}"
+      }
+    },
+    {"id": "task-docs", "title": "Docs", "description": "Write docs", "depends_on": []}
+  ]
+}`
+	if err := writeTestFile(tmpFile, content); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	plan, err := ParsePlanFromFile(tmpFile, "Test criteria")
+	if err != nil {
+		t.Fatalf("ParsePlanFromFile failed: %v", err)
+	}
+
+	want := &types.CompletionCriteria{
+		Files:       []string{"internal/auth/login.go"},
+		Symbols:     []string{"internal/auth/login.go:Login"},
+		Tests:       []string{"TestLogin"},
+		TestCommand: "go test ./internal/auth -run {pattern}",
+	}
+	if !reflect.DeepEqual(plan.Tasks[0].Criteria, want) {
+		t.Errorf("Criteria = %+v, want %+v", plan.Tasks[0].Criteria, want)
+	}
+	if plan.Tasks[1].Criteria != nil {
+		t.Errorf("Criteria = %+v, want nil for a task without criteria", plan.Tasks[1].Criteria)
+	}
+}
+
+func TestParsePlanFromFile_ContextPack(t *testing.T) {
+	tmpFile := t.TempDir() + "/plan.json"
+	content := `{
+  "summary": "Plan with a context pack",
+  "tasks": [
+    {
+      "id": "task-login",
+      "title": "Add login",
+      "description": "Implement login",
+      "depends_on": [],
+      "context_pack": {
+        "excerpts": [{"path": "internal/auth/store.go", "start_line": 10, "end_line": 40, "reason": "Session storage"}],
+        "interfaces": ["internal/auth/store.go:Store"],
+        "docs": ["docs/auth.md"]
+      }
+    }
+  ]
+}`
+	if err := writeTestFile(tmpFile, content); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	plan, err := ParsePlanFromFile(tmpFile, "Test context pack")
+	if err != nil {
+		t.Fatalf("ParsePlanFromFile failed: %v", err)
+	}
+
+	want := &types.ContextPack{
+		Excerpts:   []types.Excerpt{{Path: "internal/auth/store.go", StartLine: 10, EndLine: 40, Reason: "Session storage"}},
+		Interfaces: []string{"internal/auth/store.go:Store"},
+		Docs:       []string{"docs/auth.md"},
+	}
+	if !reflect.DeepEqual(plan.Tasks[0].ContextPack, want) {
+		t.Errorf("ContextPack = %+v, want %+v", plan.Tasks[0].ContextPack, want)
+	}
+	if err := ValidatePlan(plan); err != nil {
+		t.Errorf("ValidatePlan() error = %v", err)
+	}
+
+	plan.Tasks[0].ContextPack.Docs = []string{"/etc/passwd"}
+	if err := ValidatePlan(plan); err == nil {
+		t.Error("ValidatePlan() should reject a doc outside the repository")
+	}
+}
+
 // TestParsePlanFromFile_EmptyTasks tests that empty tasks result in an error
 func TestParsePlanFromFile_EmptyTasks(t *testing.T) {
 	tmpFile := t.TempDir() + "/plan.json"
@@ -0,0 +1,137 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/flake"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+)
+
+// What happens to a group whose verification commands fail.
+const (
+	OnFailureFail  = "fail"  // The group fails
+	OnFailurePause = "pause" // Execution waits for approval before the next group
+)
+
+// maxStepOutput caps the output kept for a failed command. The end of the
+// output is kept, where compilers and test runners report failures.
+const maxStepOutput = 4000
+
+// Step is a project command run to verify a consolidated group.
+type Step struct {
+	Name    string `json:"name,omitempty"` // e.g. "test"; the command when empty
+	Command string `json:"command"`        // e.g. "go test ./..."
+}
+
+// GroupConfig lists the commands run in each group's consolidated worktree.
+type GroupConfig struct {
+	Steps          []Step `json:"steps,omitempty"`
+	AutoDetect     bool   `json:"auto_detect,omitempty"`     // Without Steps, run the commands DetectProject finds
+	OnFailure      string `json:"on_failure,omitempty"`      // OnFailureFail (default) or OnFailurePause
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty"` // Per command, 0 = no limit
+}
+
+// StepsFor returns the commands to run in dir: the configured ones or,
+// without any and with AutoDetect, those DetectProject derives from dir's
+// project files. projectType is set only for detected commands.
+func (g GroupConfig) StepsFor(dir string) (steps []Step, projectType string) {
+	if len(g.Steps) > 0 || !g.AutoDetect || dir == "" {
+		return g.Steps, ""
+	}
+	p := DetectProject(dir)
+	return p.Steps, p.Type
+}
+
+// PauseOnFailure reports whether a failed verification pauses execution
+// before the next group instead of failing the group.
+func (g GroupConfig) PauseOnFailure() bool {
+	return g.OnFailure == OnFailurePause
+}
+
+// CommandRunner runs verification commands in a worktree. Each command runs
+// through a flake.Detector, so a failure is re-run once and a command that
+// passes on the re-run counts as a flaky pass, as does one that fails again
+// only in Go tests already known to be flaky.
+type CommandRunner struct {
+	detector *flake.Detector
+	timeout  time.Duration
+}
+
+// NewCommandRunner creates a CommandRunner that stops each command after
+// timeout. A zero timeout means no limit.
+func NewCommandRunner(detector *flake.Detector, timeout time.Duration) *CommandRunner {
+	return &CommandRunner{detector: detector, timeout: timeout}
+}
+
+// Run runs steps in order in dir. Every step runs even after one fails, so
+// the result reports all of the failures at once.
+func (r *CommandRunner) Run(ctx context.Context, dir string, steps []Step) types.VerificationResult {
+	result := types.VerificationResult{
+		CommandsRun:    make([]types.VerificationStep, 0, len(steps)),
+		OverallSuccess: true,
+		Native:         true,
+	}
+	var failed, flaky []string
+	for _, s := range steps {
+		name := s.Name
+		if name == "" {
+			name = s.Command
+		}
+		res := r.runStep(ctx, dir, s.Command)
+
+		step := types.VerificationStep{
+			Name:             name,
+			Command:          s.Command,
+			Success:          res.Outcome.Passed(),
+			Cached:           res.Cached,
+			FlakyTests:       res.FlakyTests,
+			QuarantinedTests: res.QuarantinedTests,
+		}
+		switch {
+		case res.Outcome == flake.OutcomeFlakyPass || res.Outcome == flake.OutcomeQuarantined:
+			step.Outcome = string(res.Outcome)
+			flaky = append(flaky, name)
+		case !step.Success:
+			step.Output = tailOutput(res.Output)
+			if errors.Is(res.Err, context.DeadlineExceeded) && ctx.Err() == nil {
+				step.Output = fmt.Sprintf("timed out after %s\n%s", r.timeout, step.Output)
+			}
+			failed = append(failed, name)
+			result.OverallSuccess = false
+		}
+		result.CommandsRun = append(result.CommandsRun, step)
+	}
+
+	if len(failed) > 0 {
+		result.Summary = fmt.Sprintf("%d of %d commands failed: %s", len(failed), len(steps), strings.Join(failed, ", "))
+	} else {
+		result.Summary = fmt.Sprintf("%d commands passed", len(steps))
+	}
+	if len(flaky) > 0 {
+		result.Summary += fmt.Sprintf(" (flaky: %s)", strings.Join(flaky, ", "))
+	}
+	return result
+}
+
+// runStep runs one command, bounded by the runner's timeout.
+func (r *CommandRunner) runStep(ctx context.Context, dir, command string) flake.Result {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+	return r.detector.Run(ctx, dir, command)
+}
+
+// tailOutput returns the last maxStepOutput bytes of output.
+func tailOutput(output string) string {
+	output = strings.TrimRight(output, "\n")
+	if len(output) <= maxStepOutput {
+		return output
+	}
+	return "…" + output[len(output)-maxStepOutput:]
+}
@@ -0,0 +1,66 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/flake"
+)
+
+func TestCommandRunner_Run(t *testing.T) {
+	runs := map[string]int{}
+	run := func(ctx context.Context, dir, command string) ([]byte, error) {
+		runs[command]++
+		switch command {
+		case "go build ./...":
+			return []byte("ok\n"), nil
+		case "go test ./...":
+			// Fails on the first run only
+			if runs[command] == 1 {
+				return []byte("--- FAIL: TestRace\n"), errors.New("exit status 1")
+			}
+			return []byte("ok\n"), nil
+		case "sleep":
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return []byte(strings.Repeat("x", maxStepOutput) + "\nFAIL lint\n"), errors.New("exit status 1")
+	}
+	r := NewCommandRunner(flake.NewDetector(nil, "", "", flake.WithRunFunc(run)), 50*time.Millisecond)
+
+	t.Run("passing", func(t *testing.T) {
+		got := r.Run(context.Background(), t.TempDir(), []Step{{Name: "build", Command: "go build ./..."}, {Name: "test", Command: "go test ./..."}})
+		if !got.OverallSuccess || !got.Native {
+			t.Fatalf("Run() = %+v, want a native pass", got)
+		}
+		if got.CommandsRun[1].Outcome != string(flake.OutcomeFlakyPass) {
+			t.Errorf("test outcome = %q, want flaky-pass", got.CommandsRun[1].Outcome)
+		}
+		if got.Summary != "2 commands passed (flaky: test)" {
+			t.Errorf("Summary = %q", got.Summary)
+		}
+	})
+
+	t.Run("failing", func(t *testing.T) {
+		got := r.Run(context.Background(), t.TempDir(), []Step{{Command: "golangci-lint run"}, {Name: "slow", Command: "sleep"}, {Name: "build", Command: "go build ./..."}})
+		if got.OverallSuccess {
+			t.Fatal("OverallSuccess = true, want false")
+		}
+		if len(got.CommandsRun) != 3 || !got.CommandsRun[2].Success {
+			t.Errorf("CommandsRun = %+v, want every step run", got.CommandsRun)
+		}
+		lint := got.CommandsRun[0]
+		if lint.Name != "golangci-lint run" || !strings.HasSuffix(lint.Output, "FAIL lint") || len(lint.Output) > maxStepOutput+len("…") {
+			t.Errorf("lint step = %q with %d bytes of output, want the command as name and the output's tail", lint.Name, len(lint.Output))
+		}
+		if !strings.HasPrefix(got.CommandsRun[1].Output, "timed out after 50ms") {
+			t.Errorf("slow step output = %q, want a timeout", got.CommandsRun[1].Output)
+		}
+		if got.Summary != "2 of 3 commands failed: golangci-lint run, slow" {
+			t.Errorf("Summary = %q", got.Summary)
+		}
+	})
+}
@@ -0,0 +1,232 @@
+package verify
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+	"github.com/Iron-Ham/claudio/internal/worktree"
+)
+
+// criteriaTestTimeout bounds each test command a completion criterion runs.
+const criteriaTestTimeout = 10 * time.Minute
+
+// maxSymbolFileSize skips files too large to be hand-written source when
+// searching for a symbol definition.
+const maxSymbolFileSize = 1 << 20
+
+// testCommands picks a test command from the build file at the worktree
+// root, in order.
+var testCommands = []struct {
+	file    string
+	command string
+}{
+	{"go.mod", "go test ./... -run {pattern}"},
+	{"Cargo.toml", "cargo test {pattern}"},
+	{"pyproject.toml", "python -m pytest -k {pattern}"},
+	{"pytest.ini", "python -m pytest -k {pattern}"},
+	{"package.json", "npm test -- -t {pattern}"},
+}
+
+// verifyCriteria checks a task's completion criteria and fails result if any
+// are unmet. When retryable, the failure is retried while retries remain,
+// like a task that produced no commits.
+func (v *TaskVerifier) verifyCriteria(result TaskCompletionResult, worktreePath string, criteria *types.CompletionCriteria, retryable bool) TaskCompletionResult {
+	if criteria.IsEmpty() {
+		return result
+	}
+	unmet := v.CheckCriteria(context.Background(), worktreePath, criteria)
+	if len(unmet) == 0 {
+		v.logger.Debug("task met its completion criteria", "task_id", result.TaskID)
+		return result
+	}
+
+	taskID := result.TaskID
+	reason := "unmet completion criteria: " + strings.Join(unmet, "; ")
+	result.Success = false
+	if !retryable {
+		result.Error = reason
+		v.events.EmitFailure(taskID, fmt.Sprintf("Task %s failed: %s (not retried: tool commands are deterministic)", taskID, reason))
+		return result
+	}
+
+	maxRetries := v.retryTracker.GetMaxRetries(taskID)
+	if maxRetries == 0 {
+		maxRetries = v.config.MaxTaskRetries
+	}
+	v.retryTracker.RecordCommitCount(taskID, result.CommitCount)
+	if v.retryTracker.GetRetryCount(taskID) < maxRetries {
+		attempt := v.retryTracker.IncrementRetry(taskID)
+		v.recordFailedAttempt(taskID, worktreePath, reason)
+		result.NeedsRetry = true
+		result.Error = "criteria_unmet_retry"
+		v.events.EmitRetry(taskID, attempt, maxRetries, reason)
+		return result
+	}
+	result.Error = fmt.Sprintf("%s after %d attempts", reason, maxRetries)
+	v.events.EmitFailure(taskID, fmt.Sprintf("Task %s failed: %s after %d retry attempts", taskID, reason, maxRetries))
+	return result
+}
+
+// CheckCriteria evaluates criteria against the worktree and describes each
+// unmet criterion. It returns nil when every criterion is met.
+func (v *TaskVerifier) CheckCriteria(ctx context.Context, worktreePath string, criteria *types.CompletionCriteria) []string {
+	if criteria.IsEmpty() {
+		return nil
+	}
+
+	var unmet []string
+	for _, pattern := range criteria.Files {
+		if !pathExists(worktreePath, pattern) {
+			unmet = append(unmet, fmt.Sprintf("file %s does not exist", pattern))
+		}
+	}
+	for _, symbol := range criteria.Symbols {
+		if !symbolDefined(worktreePath, symbol) {
+			unmet = append(unmet, fmt.Sprintf("symbol %s is not defined", symbol))
+		}
+	}
+	for _, pattern := range criteria.Tests {
+		if err := v.runCriteriaTests(ctx, worktreePath, criteria.TestCommand, pattern); err != nil {
+			unmet = append(unmet, fmt.Sprintf("tests matching %s: %v", pattern, err))
+		}
+	}
+	return unmet
+}
+
+// pathExists reports whether a repo-relative path or glob pattern matches a
+// file in the worktree.
+func pathExists(worktreePath, pattern string) bool {
+	matches, err := filepath.Glob(filepath.Join(worktreePath, pattern))
+	return err == nil && len(matches) > 0
+}
+
+// symbolDefined reports whether a symbol criterion ("Name" or "path:Name") is
+// defined in the worktree.
+func symbolDefined(worktreePath, symbol string) bool {
+	path, name := types.SplitSymbol(symbol)
+	if name == "" {
+		return false
+	}
+	definition := types.SymbolDefinition(name)
+
+	if path != "" {
+		matches, _ := filepath.Glob(filepath.Join(worktreePath, path))
+		for _, match := range matches {
+			if fileDefines(match, definition) {
+				return true
+			}
+		}
+		return false
+	}
+
+	found := false
+	_ = filepath.WalkDir(worktreePath, func(path string, d fs.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			return nil // Continue on errors (permission denied, etc.)
+		case d.IsDir() && path != worktreePath && (skippedDirectories[d.Name()] || worktree.IsSubmoduleDir(path)):
+			return fs.SkipDir
+		case d.IsDir():
+			return nil
+		}
+		if fileDefines(path, definition) {
+			found = true
+			return fs.SkipAll
+		}
+		return nil
+	})
+	return found
+}
+
+// fileDefines reports whether any line of the file at path matches
+// definition. Directories, large files, and unreadable files never match.
+func fileDefines(path string, definition *regexp.Regexp) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() || info.Size() > maxSymbolFileSize {
+		return false
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSymbolFileSize)
+	for scanner.Scan() {
+		if definition.Match(scanner.Bytes()) {
+			return true
+		}
+	}
+	return false
+}
+
+// runCriteriaTests runs the tests matching pattern in the worktree, using
+// command or, when it is empty, the command for the repository's build
+// system.
+func (v *TaskVerifier) runCriteriaTests(ctx context.Context, worktreePath, command, pattern string) error {
+	if command == "" {
+		command = defaultTestCommand(worktreePath)
+		if command == "" {
+			return errors.New("no test command for this repository (set test_command)")
+		}
+	}
+	quoted := shellQuote(pattern)
+	if strings.Contains(command, types.TestPatternPlaceholder) {
+		command = strings.ReplaceAll(command, types.TestPatternPlaceholder, quoted)
+	} else {
+		command += " " + quoted
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, criteriaTestTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = worktreePath
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		err = fmt.Errorf("timed out after %s", criteriaTestTimeout)
+	}
+	v.logger.Warn("completion criteria tests failed",
+		"command", command,
+		"error", err,
+		"output", lastLines(string(output), 20))
+	return fmt.Errorf("%s failed: %w", command, err)
+}
+
+// defaultTestCommand returns the test command for the build file at the
+// worktree root, or "" when none is recognized.
+func defaultTestCommand(worktreePath string) string {
+	for _, tc := range testCommands {
+		if _, err := os.Stat(filepath.Join(worktreePath, tc.file)); err == nil {
+			return tc.command
+		}
+	}
+	return ""
+}
+
+// shellQuote quotes s as a single POSIX shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// lastLines returns at most the last n lines of s.
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,172 @@
+package verify
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+)
+
+// writeWorktreeFiles creates a worktree holding the given files.
+func writeWorktreeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestCheckCriteria(t *testing.T) {
+	dir := writeWorktreeFiles(t, map[string]string{
+		"internal/auth/login.go":  "package auth\n\nfunc Login() error { return nil }\n\nfunc (s *Store) Save() {}\n\ntype Session struct{}\n",
+		"web/src/api.ts":          "export async function fetchUser() {}\n",
+		"scripts/tool.py":         "def main():\n    pass\n",
+		"node_modules/x/index.js": "function Hidden() {}\n",
+	})
+	v := NewTaskVerifier(&mockWorktreeOps{}, newMockRetryTracker(), newMockEventEmitter())
+
+	tests := []struct {
+		name      string
+		criteria  *types.CompletionCriteria
+		wantUnmet []string
+	}{
+		{"nil", nil, nil},
+		{"files exist", &types.CompletionCriteria{Files: []string{"internal/auth/login.go", "web/src/*.ts"}}, nil},
+		{"file missing", &types.CompletionCriteria{Files: []string{"internal/auth/logout.go"}}, []string{"file internal/auth/logout.go"}},
+		{"symbols defined", &types.CompletionCriteria{Symbols: []string{
+			"Login", "Save", "Session", "fetchUser", "main", "internal/auth/*.go:Session",
+		}}, nil},
+		{"symbol in wrong file", &types.CompletionCriteria{Symbols: []string{"web/src/api.ts:Login"}}, []string{"symbol web/src/api.ts:Login"}},
+		{"symbol only referenced", &types.CompletionCriteria{Symbols: []string{"Store"}}, []string{"symbol Store"}},
+		{"symbol in skipped directory", &types.CompletionCriteria{Symbols: []string{"Hidden"}}, []string{"symbol Hidden"}},
+		{"tests pass", &types.CompletionCriteria{Tests: []string{"TestLogin"}, TestCommand: "test {pattern} = TestLogin"}, nil},
+		{"tests fail", &types.CompletionCriteria{Tests: []string{"TestLogin"}, TestCommand: "exit 1"}, []string{"tests matching TestLogin"}},
+		{"no test command", &types.CompletionCriteria{Tests: []string{"TestLogin"}}, []string{"no test command"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unmet := v.CheckCriteria(context.Background(), dir, tt.criteria)
+			if len(unmet) != len(tt.wantUnmet) {
+				t.Fatalf("CheckCriteria() = %q, want %d unmet", unmet, len(tt.wantUnmet))
+			}
+			for i, want := range tt.wantUnmet {
+				if !strings.Contains(unmet[i], want) {
+					t.Errorf("unmet[%d] = %q, want it to contain %q", i, unmet[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestRunCriteriaTests_QuotesPattern(t *testing.T) {
+	dir := t.TempDir()
+	v := NewTaskVerifier(&mockWorktreeOps{}, newMockRetryTracker(), newMockEventEmitter())
+
+	// Without a placeholder the quoted pattern is appended as one word
+	if err := v.runCriteriaTests(context.Background(), dir, "test", "it's a pattern"); err != nil {
+		t.Errorf("runCriteriaTests() error = %v, want the pattern passed as one argument", err)
+	}
+	if err := v.runCriteriaTests(context.Background(), dir, `test {pattern} = "it's"`, "it's"); err != nil {
+		t.Errorf("runCriteriaTests() error = %v, want {pattern} replaced", err)
+	}
+}
+
+func TestDefaultTestCommand(t *testing.T) {
+	if got := defaultTestCommand(t.TempDir()); got != "" {
+		t.Errorf("defaultTestCommand(empty) = %q, want empty", got)
+	}
+	dir := writeWorktreeFiles(t, map[string]string{"go.mod": "module example.com/x\n", "package.json": "{}"})
+	if got := defaultTestCommand(dir); !strings.HasPrefix(got, "go test") {
+		t.Errorf("defaultTestCommand(go module) = %q, want go test", got)
+	}
+}
+
+func TestVerifyTaskWork_Criteria(t *testing.T) {
+	met := &types.CompletionCriteria{Files: []string{"login.go"}}
+	unmet := &types.CompletionCriteria{Files: []string{"logout.go"}}
+	dir := writeWorktreeFiles(t, map[string]string{"login.go": "package auth\n"})
+	cfg := Config{RequireVerifiedCommits: true, MaxTaskRetries: 2}
+
+	t.Run("met criteria succeed", func(t *testing.T) {
+		v := NewTaskVerifier(&mockWorktreeOps{commitCount: 1}, newMockRetryTracker(), newMockEventEmitter(), WithConfig(cfg))
+
+		result := v.VerifyTaskWork("task-1", "inst-1", dir, "main", &TaskVerifyOptions{Criteria: met})
+		if !result.Success {
+			t.Errorf("result = %+v, want success", result)
+		}
+	})
+
+	t.Run("unmet criteria retry", func(t *testing.T) {
+		rt := newMockRetryTracker()
+		events := newMockEventEmitter()
+		v := NewTaskVerifier(&mockWorktreeOps{commitCount: 1}, rt, events, WithConfig(cfg))
+
+		result := v.VerifyTaskWork("task-1", "inst-1", dir, "main", &TaskVerifyOptions{Criteria: unmet})
+		if result.Success || !result.NeedsRetry {
+			t.Errorf("result = %+v, want a retry", result)
+		}
+		if len(events.retries) != 1 || !strings.Contains(events.retries[0].reason, "logout.go") {
+			t.Errorf("retries = %+v, want one naming the missing file", events.retries)
+		}
+	})
+
+	t.Run("unmet criteria fail once retries are exhausted", func(t *testing.T) {
+		rt := newMockRetryTracker()
+		rt.retryCounts["task-1"] = 2
+		events := newMockEventEmitter()
+		v := NewTaskVerifier(&mockWorktreeOps{commitCount: 1}, rt, events, WithConfig(cfg))
+
+		result := v.VerifyTaskWork("task-1", "inst-1", dir, "main", &TaskVerifyOptions{Criteria: unmet})
+		if result.Success || result.NeedsRetry {
+			t.Errorf("result = %+v, want final failure", result)
+		}
+		if len(events.failures) != 1 {
+			t.Errorf("expected 1 failure event, got %d", len(events.failures))
+		}
+	})
+
+	t.Run("checked even when commit verification is disabled", func(t *testing.T) {
+		v := NewTaskVerifier(&mockWorktreeOps{}, newMockRetryTracker(), newMockEventEmitter())
+
+		result := v.VerifyTaskWork("task-1", "inst-1", dir, "main", &TaskVerifyOptions{NoCode: true, Criteria: unmet})
+		if result.Success {
+			t.Errorf("result = %+v, want failure", result)
+		}
+	})
+
+	t.Run("not checked when commits are missing", func(t *testing.T) {
+		events := newMockEventEmitter()
+		v := NewTaskVerifier(&mockWorktreeOps{commitCount: 0}, newMockRetryTracker(), events, WithConfig(cfg))
+
+		result := v.VerifyTaskWork("task-1", "inst-1", dir, "main", &TaskVerifyOptions{Criteria: unmet})
+		if result.Error != "no_commits_retry" {
+			t.Errorf("Error = %q, want the no-commits retry", result.Error)
+		}
+	})
+
+	t.Run("tool tasks are not retried", func(t *testing.T) {
+		toolDir := writeWorktreeFiles(t, map[string]string{
+			TaskCompletionFileName: `{"task_id":"task-1","status":"complete","summary":"ran"}`,
+		})
+		rt := newMockRetryTracker()
+		v := NewTaskVerifier(&mockWorktreeOps{commitCount: 1}, rt, newMockEventEmitter(), WithConfig(cfg))
+
+		result := v.VerifyTaskWork("task-1", "inst-1", toolDir, "main", &TaskVerifyOptions{Deterministic: true, Criteria: unmet})
+		if result.Success || result.NeedsRetry {
+			t.Errorf("result = %+v, want final failure", result)
+		}
+		if rt.retryCounts["task-1"] != 0 {
+			t.Errorf("retry count = %d, want 0", rt.retryCounts["task-1"])
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package verify
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Project types reported by DetectProject.
+const (
+	ProjectGo     = "go"
+	ProjectRust   = "rust"
+	ProjectNode   = "node"
+	ProjectPython = "python"
+	ProjectMake   = "make"
+)
+
+// stepNames are the steps a project can define, in the order they run:
+// cheap checks first.
+var stepNames = []string{"build", "lint", "test"}
+
+// makeTargetPattern matches a Makefile rule for one of stepNames.
+var makeTargetPattern = regexp.MustCompile(`^(build|lint|test)\s*:([^=]|$)`)
+
+// Project is what DetectProject found in a repository.
+type Project struct {
+	Type  string // One of the Project constants; empty when nothing was recognized
+	Steps []Step // Build, lint, and test commands, each only when the project has one
+}
+
+// DetectProject derives build, lint, and test commands from the project
+// files in dir. The first of go.mod, Cargo.toml, package.json, and
+// pyproject.toml found sets the project type and its default commands. A
+// Makefile build, lint, or test target replaces the default for that step,
+// since a repository that has one usually runs its checks through it.
+func DetectProject(dir string) Project {
+	var p Project
+	commands := map[string]string{}
+
+	switch {
+	case exists(dir, "go.mod"):
+		p.Type = ProjectGo
+		commands["build"] = "go build ./..."
+		commands["lint"] = "go vet ./..."
+		commands["test"] = "go test ./..."
+	case exists(dir, "Cargo.toml"):
+		p.Type = ProjectRust
+		commands["build"] = "cargo build"
+		commands["lint"] = "cargo clippy"
+		commands["test"] = "cargo test"
+	case exists(dir, "package.json"):
+		p.Type = ProjectNode
+		commands = nodeCommands(dir)
+	case exists(dir, "pyproject.toml"):
+		p.Type = ProjectPython
+		commands["test"] = "python -m pytest"
+		if data, err := os.ReadFile(filepath.Join(dir, "pyproject.toml")); err == nil && strings.Contains(string(data), "ruff") {
+			commands["lint"] = "ruff check ."
+		}
+	}
+
+	if targets := makeTargets(dir); len(targets) > 0 {
+		if p.Type == "" {
+			p.Type = ProjectMake
+		}
+		for _, target := range targets {
+			commands[target] = "make " + target
+		}
+	}
+
+	for _, name := range stepNames {
+		if cmd := commands[name]; cmd != "" {
+			p.Steps = append(p.Steps, Step{Name: name, Command: cmd})
+		}
+	}
+	return p
+}
+
+// nodeCommands returns the package.json scripts for each step, run with the
+// package manager whose lockfile the project has.
+func nodeCommands(dir string) map[string]string {
+	commands := map[string]string{}
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return commands
+	}
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return commands
+	}
+
+	runner := "npm"
+	switch {
+	case exists(dir, "pnpm-lock.yaml"):
+		runner = "pnpm"
+	case exists(dir, "yarn.lock"):
+		runner = "yarn"
+	case exists(dir, "bun.lockb"), exists(dir, "bun.lock"):
+		runner = "bun"
+	}
+
+	for _, name := range stepNames {
+		script := pkg.Scripts[name]
+		// npm init's placeholder test script always fails
+		if script == "" || strings.Contains(script, "no test specified") {
+			continue
+		}
+		commands[name] = runner + " run " + name
+	}
+	return commands
+}
+
+// makeTargets returns which of stepNames the Makefile in dir defines.
+func makeTargets(dir string) []string {
+	f, err := os.Open(filepath.Join(dir, "Makefile"))
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = f.Close() }()
+
+	var targets []string
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		m := makeTargetPattern.FindStringSubmatch(scanner.Text())
+		if m == nil || seen[m[1]] {
+			continue
+		}
+		seen[m[1]] = true
+		targets = append(targets, m[1])
+	}
+	return targets
+}
+
+// exists reports whether dir holds a file named name.
+func exists(dir, name string) bool {
+	_, err := os.Stat(filepath.Join(dir, name))
+	return err == nil
+}
@@ -0,0 +1,74 @@
+package verify
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDetectProject(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		wantType string
+		want     []string // "name: command"
+	}{
+		{name: "unknown", files: map[string]string{"README.md": "hi"}},
+		{
+			name:     "go",
+			files:    map[string]string{"go.mod": "module example.com/x\n"},
+			wantType: ProjectGo,
+			want:     []string{"build: go build ./...", "lint: go vet ./...", "test: go test ./..."},
+		},
+		{
+			name:     "go with a Makefile",
+			files:    map[string]string{"go.mod": "module example.com/x\n", "Makefile": "BIN := x\n\ntest: build\n\tgo test -race ./...\n\nlint:\n\tgolangci-lint run\n\nTEST_FLAGS := -v\n"},
+			wantType: ProjectGo,
+			want:     []string{"build: go build ./...", "lint: make lint", "test: make test"},
+		},
+		{
+			name:     "rust",
+			files:    map[string]string{"Cargo.toml": "[package]\n"},
+			wantType: ProjectRust,
+			want:     []string{"build: cargo build", "lint: cargo clippy", "test: cargo test"},
+		},
+		{
+			name:     "node with pnpm",
+			files:    map[string]string{"package.json": `{"scripts": {"build": "tsc", "test": "vitest run"}}`, "pnpm-lock.yaml": ""},
+			wantType: ProjectNode,
+			want:     []string{"build: pnpm run build", "test: pnpm run test"},
+		},
+		{
+			name:     "node with placeholder test",
+			files:    map[string]string{"package.json": `{"scripts": {"lint": "eslint .", "test": "echo \"Error: no test specified\" && exit 1"}}`},
+			wantType: ProjectNode,
+			want:     []string{"lint: npm run lint"},
+		},
+		{
+			name:     "python with ruff",
+			files:    map[string]string{"pyproject.toml": "[tool.ruff]\nline-length = 100\n"},
+			wantType: ProjectPython,
+			want:     []string{"lint: ruff check .", "test: python -m pytest"},
+		},
+		{
+			name:     "makefile only",
+			files:    map[string]string{"Makefile": "build:\n\tcc -o x x.c\n"},
+			wantType: ProjectMake,
+			want:     []string{"build: make build"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := DetectProject(writeWorktreeFiles(t, tt.files))
+			if p.Type != tt.wantType {
+				t.Errorf("Type = %q, want %q", p.Type, tt.wantType)
+			}
+			var got []string
+			for _, s := range p.Steps {
+				got = append(got, s.Name+": "+s.Command)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Steps = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
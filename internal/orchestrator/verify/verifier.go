@@ -1,7 +1,8 @@
 // Package verify provides task verification logic for the orchestrator.
 //
 // This package encapsulates the verification of task completion, including
-// checking for completion files and validating that expected commits were produced.
+// checking for completion files and validating that expected commits were produced,
+// and runs the project commands that verify each consolidated group.
 package verify
 
 import (
@@ -61,6 +62,17 @@ type TaskVerifyOptions struct {
 	// NoCode indicates the task doesn't require code changes.
 	// When true, the task succeeds even without commits.
 	NoCode bool
+
+	// Deterministic indicates the task ran a scripted, non-LLM command (the
+	// "tool" backend). Its completion report is authoritative: any status
+	// other than "complete" fails the task, and failures are never retried
+	// since re-running the same command reproduces the same result.
+	Deterministic bool
+
+	// Criteria are the task's declared completion criteria, checked once the
+	// commit checks pass. Unmet criteria fail the task like missing commits
+	// do, with a retry while retries remain.
+	Criteria *types.CompletionCriteria
 }
 
 // RevisionCompletionFile represents the completion report from a revision task.
@@ -80,6 +92,9 @@ type WorktreeOperations interface {
 
 	// FindMainBranch returns the name of the main/master branch.
 	FindMainBranch() string
+
+	// GetUncommittedDiff returns the uncommitted changes in a worktree.
+	GetUncommittedDiff(worktreePath string) (string, error)
 }
 
 // RetryTracker tracks retry state for tasks.
@@ -95,6 +110,10 @@ type RetryTracker interface {
 
 	// GetMaxRetries returns the maximum retry count for a task.
 	GetMaxRetries(taskID string) int
+
+	// RecordFailure records why a task's attempt failed and the uncommitted
+	// changes it left behind, so the retry can be told what was tried.
+	RecordFailure(taskID, reason, diff string)
 }
 
 // EventEmitter emits verification events.
@@ -355,6 +374,27 @@ func (v *TaskVerifier) VerifyTaskWork(taskID, instanceID, worktreePath, baseBran
 		Success:    true,
 	}
 
+	if opts != nil && opts.Deterministic {
+		result = v.verifyDeterministicWork(result, worktreePath, baseBranch)
+		if result.Success {
+			result = v.verifyCriteria(result, worktreePath, opts.Criteria, false)
+		}
+		return result
+	}
+
+	result = v.verifyCommitWork(result, worktreePath, baseBranch, opts)
+	if result.Success && opts != nil {
+		result = v.verifyCriteria(result, worktreePath, opts.Criteria, true)
+	}
+	return result
+}
+
+// verifyCommitWork checks that a task produced commits, unless the
+// configuration, a no-code task, or a "complete" completion report waives
+// the requirement.
+func (v *TaskVerifier) verifyCommitWork(result TaskCompletionResult, worktreePath, baseBranch string, opts *TaskVerifyOptions) TaskCompletionResult {
+	taskID := result.TaskID
+
 	// Skip verification if not required
 	if !v.config.RequireVerifiedCommits {
 		return result
@@ -414,6 +454,7 @@ func (v *TaskVerifier) VerifyTaskWork(taskID, instanceID, worktreePath, baseBran
 		if currentRetries < maxRetries {
 			// Trigger retry
 			newRetryCount := v.retryTracker.IncrementRetry(taskID)
+			v.recordFailedAttempt(taskID, worktreePath, "task produced no commits")
 
 			result.Success = false
 			result.NeedsRetry = true
@@ -433,6 +474,51 @@ func (v *TaskVerifier) VerifyTaskWork(taskID, instanceID, worktreePath, baseBran
 	return result
 }
 
+// recordFailedAttempt records why a task's attempt failed, with the
+// uncommitted changes it left in worktreePath, for the retry's prompt.
+func (v *TaskVerifier) recordFailedAttempt(taskID, worktreePath, reason string) {
+	diff, err := v.wt.GetUncommittedDiff(worktreePath)
+	if err != nil {
+		v.logger.Debug("failed to capture uncommitted changes for retry",
+			"task_id", taskID,
+			"error", err)
+	}
+	v.retryTracker.RecordFailure(taskID, reason, diff)
+}
+
+// verifyDeterministicWork verifies a task run by a non-LLM executor. The
+// completion report decides the outcome; commits are counted for reporting
+// only, since a command that legitimately changed nothing is still a success.
+func (v *TaskVerifier) verifyDeterministicWork(result TaskCompletionResult, worktreePath, baseBranch string) TaskCompletionResult {
+	completion, err := v.FindAndParseTaskCompletionFile(worktreePath)
+	switch {
+	case err != nil:
+		result.Success = false
+		result.Error = fmt.Sprintf("tool task completion report unreadable: %v", err)
+	case completion.Status != "complete":
+		result.Success = false
+		result.Error = fmt.Sprintf("tool task %s: %s", completion.Status, completion.Summary)
+	}
+	if !result.Success {
+		v.events.EmitFailure(result.TaskID, fmt.Sprintf("Task %s failed: %s (not retried: tool commands are deterministic)", result.TaskID, result.Error))
+		return result
+	}
+
+	if baseBranch == "" {
+		baseBranch = v.wt.FindMainBranch()
+	}
+	commitCount, err := v.wt.CountCommitsBetween(worktreePath, baseBranch, "HEAD")
+	if err != nil {
+		v.events.EmitWarning(result.TaskID, fmt.Sprintf("Warning: could not count commits for task %s: %v", result.TaskID, err))
+		return result
+	}
+	result.CommitCount = commitCount
+	if commitCount == 0 {
+		v.logger.Debug("tool task made no changes", "task_id", result.TaskID)
+	}
+	return result
+}
+
 // TaskCompletionFilePath returns the full path to the task completion file for a given worktree.
 func TaskCompletionFilePath(worktreePath string) string {
 	return filepath.Join(worktreePath, TaskCompletionFileName)
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
@@ -14,6 +15,7 @@ type mockWorktreeOps struct {
 	commitCount    int
 	commitCountErr error
 	mainBranch     string
+	diff           string
 }
 
 func (m *mockWorktreeOps) CountCommitsBetween(_, _, _ string) (int, error) {
@@ -27,11 +29,17 @@ func (m *mockWorktreeOps) FindMainBranch() string {
 	return m.mainBranch
 }
 
+func (m *mockWorktreeOps) GetUncommittedDiff(_ string) (string, error) {
+	return m.diff, nil
+}
+
 // mockRetryTracker is a mock implementation of RetryTracker.
 type mockRetryTracker struct {
 	retryCounts  map[string]int
 	maxRetries   map[string]int
 	commitCounts map[string][]int
+	reasons      map[string]string
+	diffs        map[string]string
 }
 
 func newMockRetryTracker() *mockRetryTracker {
@@ -39,6 +47,8 @@ func newMockRetryTracker() *mockRetryTracker {
 		retryCounts:  make(map[string]int),
 		maxRetries:   make(map[string]int),
 		commitCounts: make(map[string][]int),
+		reasons:      make(map[string]string),
+		diffs:        make(map[string]string),
 	}
 }
 
@@ -59,6 +69,11 @@ func (m *mockRetryTracker) GetMaxRetries(taskID string) int {
 	return m.maxRetries[taskID]
 }
 
+func (m *mockRetryTracker) RecordFailure(taskID, reason, diff string) {
+	m.reasons[taskID] = reason
+	m.diffs[taskID] = diff
+}
+
 // mockEventEmitter is a mock implementation of EventEmitter.
 type mockEventEmitter struct {
 	warnings []string
@@ -508,7 +523,7 @@ func TestVerifyTaskWork_WithCommits(t *testing.T) {
 }
 
 func TestVerifyTaskWork_NoCommits_FirstRetry(t *testing.T) {
-	wt := &mockWorktreeOps{commitCount: 0}
+	wt := &mockWorktreeOps{commitCount: 0, diff: "Untracked file: notes.md\n"}
 	rt := newMockRetryTracker()
 	rt.maxRetries["task-1"] = 3
 	events := newMockEventEmitter()
@@ -533,6 +548,12 @@ func TestVerifyTaskWork_NoCommits_FirstRetry(t *testing.T) {
 	if len(events.retries) != 1 {
 		t.Errorf("expected 1 retry event, got %d", len(events.retries))
 	}
+	if rt.reasons["task-1"] != "task produced no commits" {
+		t.Errorf("expected failure reason to be recorded, got %q", rt.reasons["task-1"])
+	}
+	if rt.diffs["task-1"] != wt.diff {
+		t.Errorf("expected uncommitted diff %q to be recorded, got %q", wt.diff, rt.diffs["task-1"])
+	}
 }
 
 func TestVerifyTaskWork_NoCommits_MaxRetriesExhausted(t *testing.T) {
@@ -630,6 +651,62 @@ func TestVerifyTaskWork_UseDefaultMaxRetries(t *testing.T) {
 	}
 }
 
+func TestVerifyTaskWork_Deterministic(t *testing.T) {
+	writeCompletion := func(t *testing.T, status string) string {
+		t.Helper()
+		dir := t.TempDir()
+		data := `{"task_id":"task-1","status":"` + status + `","summary":"Ran tool command: gofmt -w ."}`
+		if err := os.WriteFile(filepath.Join(dir, TaskCompletionFileName), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+	opts := &TaskVerifyOptions{Deterministic: true}
+
+	t.Run("complete without commits succeeds", func(t *testing.T) {
+		events := newMockEventEmitter()
+		cfg := Config{RequireVerifiedCommits: true, MaxTaskRetries: 3}
+		v := NewTaskVerifier(&mockWorktreeOps{commitCount: 0}, newMockRetryTracker(), events, WithConfig(cfg))
+
+		result := v.VerifyTaskWork("task-1", "inst-1", writeCompletion(t, "complete"), "main", opts)
+		if !result.Success || result.NeedsRetry {
+			t.Errorf("result = %+v, want success without retry", result)
+		}
+		if len(events.retries) != 0 || len(events.failures) != 0 {
+			t.Errorf("unexpected events: retries=%v failures=%v", events.retries, events.failures)
+		}
+	})
+
+	t.Run("failed status fails without retry even when verification is disabled", func(t *testing.T) {
+		rt := newMockRetryTracker()
+		events := newMockEventEmitter()
+		v := NewTaskVerifier(&mockWorktreeOps{commitCount: 0}, rt, events)
+
+		result := v.VerifyTaskWork("task-1", "inst-1", writeCompletion(t, "failed"), "main", opts)
+		if result.Success || result.NeedsRetry {
+			t.Errorf("result = %+v, want final failure", result)
+		}
+		if !strings.Contains(result.Error, "gofmt") {
+			t.Errorf("Error = %q, want the completion summary", result.Error)
+		}
+		if rt.retryCounts["task-1"] != 0 {
+			t.Errorf("retry count = %d, want 0", rt.retryCounts["task-1"])
+		}
+		if len(events.failures) != 1 {
+			t.Errorf("expected 1 failure event, got %d", len(events.failures))
+		}
+	})
+
+	t.Run("missing completion report fails", func(t *testing.T) {
+		v := NewTaskVerifier(&mockWorktreeOps{commitCount: 2}, newMockRetryTracker(), newMockEventEmitter())
+
+		result := v.VerifyTaskWork("task-1", "inst-1", t.TempDir(), "main", opts)
+		if result.Success || result.NeedsRetry {
+			t.Errorf("result = %+v, want final failure", result)
+		}
+	})
+}
+
 func TestTaskCompletionFilePath(t *testing.T) {
 	path := TaskCompletionFilePath("/tmp/worktree")
 	expected := "/tmp/worktree/.claudio-task-complete.json"
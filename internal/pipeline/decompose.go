@@ -63,17 +63,28 @@ func Decompose(plan *ultraplan.PlanSpec, cfg DecomposeConfig) (*DecomposeResult,
 			teamSize = len(tasks)
 		}
 
+		name := fmt.Sprintf("Execution Team %d", i)
+		repo := tasks[0].Repo
+		if repo != "" {
+			name = fmt.Sprintf("%s (%s)", name, repo)
+		}
+
 		execTeams = append(execTeams, team.Spec{
 			ID:           fmt.Sprintf("exec-%d", i),
-			Name:         fmt.Sprintf("Execution Team %d", i),
+			Name:         name,
 			Role:         team.RoleExecution,
 			Tasks:        tasks,
 			TeamSize:     teamSize,
 			MinInstances: cfg.MinTeamInstances,
 			MaxInstances: cfg.MaxTeamInstances,
+			Repo:         repo,
 		})
 	}
 
+	if err := liftCrossTeamDeps(execTeams); err != nil {
+		return nil, err
+	}
+
 	result := &DecomposeResult{
 		ExecutionTeams: execTeams,
 	}
@@ -93,7 +104,10 @@ func Decompose(plan *ultraplan.PlanSpec, cfg DecomposeConfig) (*DecomposeResult,
 
 // groupByAffinity groups tasks by shared files and dependency edges
 // using union-find. Tasks that share at least one file, or that have a
-// direct dependency relationship, land in the same group.
+// direct dependency relationship, land in the same group. Tasks in different
+// repositories are never unioned: identical paths in two repos are distinct
+// files, and cross-repo dependencies are expressed as team dependencies by
+// liftCrossTeamDeps instead.
 //
 // Dependency unioning is essential because each team's TaskQueue can only
 // resolve dependencies within its own task set. If task B depends on task A
@@ -108,6 +122,11 @@ func groupByAffinity(tasks []ultraplan.PlannedTask) [][]string {
 
 	uf := newUnionFind(ids)
 
+	repoOf := make(map[string]string, len(tasks))
+	for _, t := range tasks {
+		repoOf[t.ID] = t.Repo
+	}
+
 	// Union tasks that share a dependency edge. This ensures all
 	// DependsOn references are resolvable within a single team's queue.
 	// Unknown dep IDs are skipped — upstream validation catches invalid
@@ -118,15 +137,19 @@ func groupByAffinity(tasks []ultraplan.PlannedTask) [][]string {
 			if _, ok := uf.parent[depID]; !ok {
 				continue
 			}
+			if repoOf[depID] != t.Repo {
+				continue
+			}
 			uf.Union(t.ID, depID)
 		}
 	}
 
-	// Build file → task ID index.
+	// Build (repo, file) → task ID index.
 	fileToTasks := make(map[string][]string)
 	for _, t := range tasks {
 		for _, f := range t.Files {
-			fileToTasks[f] = append(fileToTasks[f], t.ID)
+			key := t.Repo + "\x00" + f
+			fileToTasks[key] = append(fileToTasks[key], t.ID)
 		}
 	}
 
@@ -169,15 +192,17 @@ func splitOversized(groups [][]string, maxSize int) [][]string {
 
 // mergeUndersized merges groups smaller than minSize into the nearest
 // neighbor by shared file count. Groups that cannot be merged (no shared
-// files with any other group) are left as-is.
+// files with any other group in the same repository) are left as-is.
 func mergeUndersized(groups [][]string, tasks []ultraplan.PlannedTask, minSize int) [][]string {
 	if len(groups) <= 1 {
 		return groups
 	}
 
-	// Build task → files index.
+	// Build task → files and task → repo indexes.
 	taskFiles := make(map[string]map[string]bool)
+	taskRepo := make(map[string]string, len(tasks))
 	for _, t := range tasks {
+		taskRepo[t.ID] = t.Repo
 		s := make(map[string]bool, len(t.Files))
 		for _, f := range t.Files {
 			s[f] = true
@@ -226,6 +251,10 @@ func mergeUndersized(groups [][]string, tasks []ultraplan.PlannedTask, minSize i
 				if i == j {
 					continue
 				}
+				// Never merge across repositories.
+				if taskRepo[merged[i][0]] != taskRepo[merged[j][0]] {
+					continue
+				}
 				filesJ := groupFiles(merged[j])
 				shared := sharedFileCount(filesI, filesJ)
 				if shared > bestShared {
@@ -247,6 +276,92 @@ func mergeUndersized(groups [][]string, tasks []ultraplan.PlannedTask, minSize i
 	return merged
 }
 
+// liftCrossTeamDeps converts task dependencies that cross team boundaries into
+// team-level dependencies. A team's TaskQueue can only resolve DependsOn within
+// its own task set, so a dependency on a task in another team (which happens
+// for cross-repo edges, or when splitOversized separates a chain) is removed
+// from the task and replaced by a Spec.DependsOn edge on the owning team. The
+// team manager then keeps the dependent team blocked until its producer is done.
+//
+// Returns an error if the lifted edges form a cycle between teams, since such
+// a pipeline could never make progress.
+func liftCrossTeamDeps(teams []team.Spec) error {
+	owner := make(map[string]string)
+	for _, spec := range teams {
+		for _, t := range spec.Tasks {
+			owner[t.ID] = spec.ID
+		}
+	}
+
+	for i := range teams {
+		spec := &teams[i]
+		teamDeps := make(map[string]bool)
+		tasks := make([]ultraplan.PlannedTask, len(spec.Tasks))
+		for j, t := range spec.Tasks {
+			var local []string
+			for _, depID := range t.DependsOn {
+				depTeam, ok := owner[depID]
+				if ok && depTeam != spec.ID {
+					teamDeps[depTeam] = true
+					continue
+				}
+				local = append(local, depID)
+			}
+			if len(local) != len(t.DependsOn) {
+				t.DependsOn = local
+			}
+			tasks[j] = t
+		}
+		spec.Tasks = tasks
+		for dep := range teamDeps {
+			spec.DependsOn = append(spec.DependsOn, dep)
+		}
+		sort.Strings(spec.DependsOn)
+	}
+
+	return checkTeamCycles(teams)
+}
+
+// checkTeamCycles returns an error if the team dependency graph has a cycle.
+func checkTeamCycles(teams []team.Spec) error {
+	deps := make(map[string][]string, len(teams))
+	for _, spec := range teams {
+		deps[spec.ID] = spec.DependsOn
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(teams))
+
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch state[id] {
+		case visiting:
+			return fmt.Errorf("pipeline: cross-team dependency cycle involving team %q", id)
+		case visited:
+			return nil
+		}
+		state[id] = visiting
+		for _, dep := range deps[id] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[id] = visited
+		return nil
+	}
+
+	for _, spec := range teams {
+		if err := visit(spec.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // makePlanningTeam creates a planning team spec covering all tasks.
 func makePlanningTeam(plan *ultraplan.PlanSpec) *team.Spec {
 	return &team.Spec{
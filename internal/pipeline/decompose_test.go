@@ -690,3 +690,74 @@ func TestUnionFind_SingleElement(t *testing.T) {
 		t.Errorf("Find(only) = %q, want %q", uf.Find("only"), "only")
 	}
 }
+
+func TestDecompose_MultiRepo(t *testing.T) {
+	plan := &ultraplan.PlanSpec{
+		ID: "p1",
+		Tasks: []ultraplan.PlannedTask{
+			{ID: "api-1", Repo: "backend", Files: []string{"schema.go"}, Contracts: []string{"openapi.yaml"}},
+			{ID: "api-2", Repo: "backend", Files: []string{"handler.go"}, DependsOn: []string{"api-1"}},
+			// Same path as api-1 but in another repo: must not be unioned.
+			{ID: "web-1", Repo: "frontend", Files: []string{"schema.go"}, DependsOn: []string{"api-1"}},
+			{ID: "web-2", Repo: "frontend", Files: []string{"client.ts"}, DependsOn: []string{"web-1", "api-2"}},
+		},
+	}
+
+	result, err := Decompose(plan, DecomposeConfig{MinTeamSize: 2})
+	if err != nil {
+		t.Fatalf("Decompose: %v", err)
+	}
+	if len(result.ExecutionTeams) != 2 {
+		t.Fatalf("ExecutionTeams = %d, want 2", len(result.ExecutionTeams))
+	}
+
+	backend, frontend := result.ExecutionTeams[0], result.ExecutionTeams[1]
+	if backend.Repo != "backend" || frontend.Repo != "frontend" {
+		t.Fatalf("repos = %q, %q", backend.Repo, frontend.Repo)
+	}
+	if !strings.Contains(frontend.Name, "frontend") {
+		t.Errorf("frontend team name = %q, want repo suffix", frontend.Name)
+	}
+	if len(backend.DependsOn) != 0 {
+		t.Errorf("backend DependsOn = %v, want none", backend.DependsOn)
+	}
+	if len(frontend.DependsOn) != 1 || frontend.DependsOn[0] != backend.ID {
+		t.Errorf("frontend DependsOn = %v, want [%s]", frontend.DependsOn, backend.ID)
+	}
+
+	// Cross-repo task deps are lifted to the team; same-repo deps remain.
+	for _, task := range frontend.Tasks {
+		switch task.ID {
+		case "web-1":
+			if len(task.DependsOn) != 0 {
+				t.Errorf("web-1 DependsOn = %v, want none", task.DependsOn)
+			}
+		case "web-2":
+			if len(task.DependsOn) != 1 || task.DependsOn[0] != "web-1" {
+				t.Errorf("web-2 DependsOn = %v, want [web-1]", task.DependsOn)
+			}
+		}
+	}
+	// The original plan must not be mutated.
+	if len(plan.Tasks[3].DependsOn) != 2 {
+		t.Errorf("plan task DependsOn mutated: %v", plan.Tasks[3].DependsOn)
+	}
+}
+
+func TestDecompose_MultiRepoCycle(t *testing.T) {
+	plan := &ultraplan.PlanSpec{
+		ID: "p1",
+		Tasks: []ultraplan.PlannedTask{
+			{ID: "a", Repo: "one", DependsOn: []string{"b"}},
+			{ID: "b", Repo: "two", DependsOn: []string{"a"}},
+		},
+	}
+
+	_, err := Decompose(plan, DecomposeConfig{})
+	if err == nil {
+		t.Fatal("expected cycle error")
+	}
+	if !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("error = %q, want containing 'cycle'", err)
+	}
+}
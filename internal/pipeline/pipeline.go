@@ -10,6 +10,7 @@ import (
 	"github.com/Iron-Ham/claudio/internal/event"
 	"github.com/Iron-Ham/claudio/internal/logging"
 	"github.com/Iron-Ham/claudio/internal/team"
+	"github.com/Iron-Ham/claudio/internal/ultraplan"
 )
 
 // Pipeline orchestrates multi-phase team execution.
@@ -140,6 +141,30 @@ func (p *Pipeline) Manager(phase PipelinePhase) *team.Manager {
 	return p.managers[phase]
 }
 
+// AddTask adds a task to a running execution phase, on the team chosen by
+// team.Manager.AddTask. Returns the ID of that team.
+func (p *Pipeline) AddTask(task ultraplan.PlannedTask) (string, error) {
+	p.mu.RLock()
+	phase, m := p.phase, p.managers[PhaseExecution]
+	p.mu.RUnlock()
+	if phase != PhaseExecution || m == nil {
+		return "", fmt.Errorf("pipeline: cannot add task %q in phase %s", task.ID, phase)
+	}
+	return m.AddTask(task)
+}
+
+// RemoveTask drops an unfinished task from a running execution phase (see
+// team.Manager.RemoveTask).
+func (p *Pipeline) RemoveTask(taskID string) error {
+	p.mu.RLock()
+	phase, m := p.phase, p.managers[PhaseExecution]
+	p.mu.RUnlock()
+	if phase != PhaseExecution || m == nil {
+		return fmt.Errorf("pipeline: cannot remove task %q in phase %s", taskID, phase)
+	}
+	return m.RemoveTask(taskID)
+}
+
 // Running returns whether the pipeline is currently started.
 func (p *Pipeline) Running() bool {
 	p.mu.RLock()
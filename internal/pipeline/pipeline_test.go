@@ -189,6 +189,71 @@ func TestPipeline_ExecutionPhase(t *testing.T) {
 	}
 }
 
+func TestPipeline_AddTask(t *testing.T) {
+	p, bus := newTestPipeline(t, simplePlan())
+	_, _ = p.Decompose(DecomposeConfig{})
+
+	added := ultraplan.PlannedTask{ID: "t2", Title: "Task 2", DependsOn: []string{"t1"}}
+	if _, err := p.AddTask(added); err == nil {
+		t.Fatal("expected error before execution")
+	}
+	if err := p.RemoveTask("t1"); err == nil {
+		t.Fatal("expected error removing a task before execution")
+	}
+
+	phaseChanges := make(chan event.Event, 20)
+	bus.Subscribe("pipeline.phase_changed", func(e event.Event) {
+		phaseChanges <- e
+	})
+	completions := make(chan event.Event, 5)
+	bus.Subscribe("pipeline.completed", func(e event.Event) {
+		completions <- e
+	})
+
+	if err := p.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer func() { _ = p.Stop() }()
+	waitForPipelinePhase(t, phaseChanges, "execution", 2*time.Second)
+
+	// The team may not be working yet right after the phase change.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		_, err := p.AddTask(added)
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("AddTask: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// A task dropped again does not count against the team
+	if _, err := p.AddTask(ultraplan.PlannedTask{ID: "t3", Title: "Task 3"}); err != nil {
+		t.Fatalf("AddTask(t3): %v", err)
+	}
+	if err := p.RemoveTask("t3"); err != nil {
+		t.Fatalf("RemoveTask(t3): %v", err)
+	}
+
+	completeAllTeamTasks(t, p, PhaseExecution)
+
+	select {
+	case e := <-completions:
+		if !e.(event.PipelineCompletedEvent).Success {
+			t.Error("pipeline should have succeeded")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for pipeline completion")
+	}
+	for _, s := range p.Manager(PhaseExecution).AllStatuses() {
+		if s.TasksTotal != 2 {
+			t.Errorf("team %s has %d tasks, want 2", s.ID, s.TasksTotal)
+		}
+	}
+}
+
 func TestPipeline_AllPhases(t *testing.T) {
 	plan := simplePlan()
 	p, bus := newTestPipeline(t, plan)
@@ -271,6 +271,7 @@ func parsePlanFromOutput(output, objective string) (*orchestrator.PlanSpec, erro
 		Tasks       []orchestrator.PlannedTask `json:"tasks"`
 		Insights    []string                   `json:"insights"`
 		Constraints []string                   `json:"constraints"`
+		Env         map[string]string          `json:"env"`
 	}
 
 	if err := json.Unmarshal([]byte(output), &rawPlan); err != nil {
@@ -285,6 +286,7 @@ func parsePlanFromOutput(output, objective string) (*orchestrator.PlanSpec, erro
 		Tasks:           rawPlan.Tasks,
 		Insights:        rawPlan.Insights,
 		Constraints:     rawPlan.Constraints,
+		Env:             rawPlan.Env,
 		DependencyGraph: make(map[string][]string),
 		CreatedAt:       time.Now(),
 	}
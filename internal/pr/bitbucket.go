@@ -0,0 +1,210 @@
+package pr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrCredentialsMissing is returned by Bitbucket.CheckCLI when no API
+// credentials are set.
+var ErrCredentialsMissing = errors.New("API credentials not set")
+
+// Environment variables holding Bitbucket Cloud credentials: an access token,
+// or a username with an app password.
+const (
+	BitbucketTokenEnv       = "BITBUCKET_TOKEN"
+	BitbucketUsernameEnv    = "BITBUCKET_USERNAME"
+	BitbucketAppPasswordEnv = "BITBUCKET_APP_PASSWORD"
+)
+
+// bitbucketAPIURL is the Bitbucket Cloud REST API root.
+const bitbucketAPIURL = "https://api.bitbucket.org/2.0"
+
+// Bitbucket creates Bitbucket Cloud pull requests through the REST API. It
+// authenticates with BITBUCKET_TOKEN, or with BITBUCKET_USERNAME and
+// BITBUCKET_APP_PASSWORD. Reviewers are Bitbucket account IDs or {UUID}s;
+// labels are not supported by Bitbucket and are ignored.
+type Bitbucket struct {
+	remoteURL string
+	apiURL    string
+	client    *http.Client
+	getenv    func(string) string
+}
+
+// NewBitbucket creates a Bitbucket provider for the repository whose origin
+// is remoteURL.
+func NewBitbucket(remoteURL string) *Bitbucket {
+	return &Bitbucket{
+		remoteURL: remoteURL,
+		apiURL:    bitbucketAPIURL,
+		client:    &http.Client{Timeout: 30 * time.Second},
+		getenv:    os.Getenv,
+	}
+}
+
+// Name implements Provider.
+func (b *Bitbucket) Name() string { return ProviderBitbucket }
+
+// repo returns "workspace/repo" for a Bitbucket Cloud remote, or "".
+func (b *Bitbucket) repo() string {
+	host, path := remoteHostPath(b.remoteURL)
+	if host != "bitbucket.org" || strings.Count(path, "/") != 1 {
+		return ""
+	}
+	return path
+}
+
+// CheckCLI implements Provider. Bitbucket needs no CLI, only credentials and
+// a Bitbucket Cloud origin.
+func (b *Bitbucket) CheckCLI() error {
+	if b.repo() == "" {
+		return fmt.Errorf("origin %q is not a Bitbucket Cloud repository", b.remoteURL)
+	}
+	if b.getenv(BitbucketTokenEnv) == "" &&
+		(b.getenv(BitbucketUsernameEnv) == "" || b.getenv(BitbucketAppPasswordEnv) == "") {
+		return fmt.Errorf("%w: set %s, or %s and %s", ErrCredentialsMissing,
+			BitbucketTokenEnv, BitbucketUsernameEnv, BitbucketAppPasswordEnv)
+	}
+	return nil
+}
+
+// endpoint returns the pull requests API URL of the repository.
+func (b *Bitbucket) endpoint() string {
+	return fmt.Sprintf("%s/repositories/%s/pullrequests", b.apiURL, b.repo())
+}
+
+// bitbucketBranch is a branch reference in the pull request API.
+type bitbucketBranch struct {
+	Branch struct {
+		Name string `json:"name"`
+	} `json:"branch"`
+}
+
+// bitbucketReviewer identifies a reviewer by account ID or UUID.
+type bitbucketReviewer struct {
+	UUID      string `json:"uuid,omitempty"`
+	AccountID string `json:"account_id,omitempty"`
+}
+
+// bitbucketPullRequest is the request body that creates a pull request.
+type bitbucketPullRequest struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description,omitempty"`
+	Source      bitbucketBranch     `json:"source"`
+	Destination *bitbucketBranch    `json:"destination,omitempty"`
+	Draft       bool                `json:"draft,omitempty"`
+	Reviewers   []bitbucketReviewer `json:"reviewers,omitempty"`
+}
+
+// payload returns the JSON request body that creates opts.
+func (b *Bitbucket) payload(opts PROptions) []byte {
+	req := bitbucketPullRequest{
+		Title:       opts.Title,
+		Description: opts.Body,
+		Draft:       opts.Draft,
+	}
+	req.Source.Branch.Name = opts.Branch
+	if opts.Base != "" {
+		req.Destination = &bitbucketBranch{}
+		req.Destination.Branch.Name = opts.Base
+	}
+	for _, r := range opts.Reviewers {
+		if strings.HasPrefix(r, "{") {
+			req.Reviewers = append(req.Reviewers, bitbucketReviewer{UUID: r})
+		} else {
+			req.Reviewers = append(req.Reviewers, bitbucketReviewer{AccountID: r})
+		}
+	}
+	data, _ := json.Marshal(req) // Only strings and bools: cannot fail
+	return data
+}
+
+// Create implements Provider.
+func (b *Bitbucket) Create(opts PROptions) (string, error) {
+	if err := b.CheckCLI(); err != nil {
+		return "", fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, b.endpoint(), bytes.NewReader(b.payload(opts)))
+	if err != nil {
+		return "", fmt.Errorf("failed to create pull request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token := b.getenv(BitbucketTokenEnv); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else {
+		req.SetBasicAuth(b.getenv(BitbucketUsernameEnv), b.getenv(BitbucketAppPasswordEnv))
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to create pull request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read Bitbucket response: %w", err)
+	}
+
+	var result struct {
+		Links struct {
+			HTML struct {
+				Href string `json:"href"`
+			} `json:"html"`
+		} `json:"links"`
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	_ = json.Unmarshal(body, &result)
+	if resp.StatusCode != http.StatusCreated {
+		msg := result.Error.Message
+		if msg == "" {
+			msg = strings.TrimSpace(string(body))
+		}
+		return "", fmt.Errorf("failed to create pull request: Bitbucket returned %s: %s", resp.Status, msg)
+	}
+	if result.Links.HTML.Href == "" {
+		return "", fmt.Errorf("failed to create pull request: Bitbucket response has no pull request link")
+	}
+	return result.Links.HTML.Href, nil
+}
+
+// Command implements Provider with a curl command that reads the credentials
+// from the environment.
+func (b *Bitbucket) Command(opts PROptions) string {
+	auth := fmt.Sprintf(`-u "$%s:$%s"`, BitbucketUsernameEnv, BitbucketAppPasswordEnv)
+	if b.getenv(BitbucketTokenEnv) != "" {
+		auth = fmt.Sprintf(`-H "Authorization: Bearer $%s"`, BitbucketTokenEnv)
+	}
+	return fmt.Sprintf("curl -sS -X POST %s -H 'Content-Type: application/json' -d %s %s",
+		auth, shellQuote(string(b.payload(opts))), shellQuote(b.endpoint()))
+}
+
+// CompareURL implements Provider.
+func (b *Bitbucket) CompareURL(base, head string) string {
+	repo := b.repo()
+	if repo == "" || head == "" {
+		return ""
+	}
+	query := url.Values{"source": {head}}
+	if base != "" {
+		query.Set("dest", base)
+	}
+	return fmt.Sprintf("https://bitbucket.org/%s/pull-requests/new?%s", repo, query.Encode())
+}
+
+// Instructions implements Provider.
+func (b *Bitbucket) Instructions() string {
+	return fmt.Sprintf("through the Bitbucket REST API with curl (POST %s with a JSON title, description, "+
+		"source.branch.name and destination.branch.name, authenticating with $%s, or $%s and $%s; the response's links.html.href is the PR URL)",
+		b.endpoint(), BitbucketTokenEnv, BitbucketUsernameEnv, BitbucketAppPasswordEnv)
+}
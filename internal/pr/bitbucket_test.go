@@ -0,0 +1,93 @@
+package pr
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestBitbucket returns a Bitbucket provider for workspace/repo talking to
+// server with the given environment.
+func newTestBitbucket(server *httptest.Server, env map[string]string) *Bitbucket {
+	b := NewBitbucket("git@bitbucket.org:workspace/repo.git")
+	if server != nil {
+		b.apiURL = server.URL
+		b.client = server.Client()
+	}
+	b.getenv = func(k string) string { return env[k] }
+	return b
+}
+
+func TestBitbucket_Create(t *testing.T) {
+	var got bitbucketPullRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/workspace/repo/pullrequests" || r.Header.Get("Authorization") != "Bearer tok" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"type": "error", "error": {"message": "Unauthorized"}}`))
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 3, "links": {"html": {"href": "https://bitbucket.org/workspace/repo/pull-requests/3"}}}`))
+	}))
+	defer server.Close()
+
+	b := newTestBitbucket(server, map[string]string{BitbucketTokenEnv: "tok"})
+	url, err := b.Create(PROptions{
+		Title:     "feat: x",
+		Body:      "Summary",
+		Branch:    "claudio/task-1",
+		Base:      "main",
+		Draft:     true,
+		Reviewers: []string{"557058:abc", "{d2a0-uuid}"},
+		Labels:    []string{"ignored"},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if url != "https://bitbucket.org/workspace/repo/pull-requests/3" {
+		t.Errorf("Create() = %q", url)
+	}
+	if got.Title != "feat: x" || got.Source.Branch.Name != "claudio/task-1" || got.Destination == nil ||
+		got.Destination.Branch.Name != "main" || !got.Draft || len(got.Reviewers) != 2 ||
+		got.Reviewers[0].AccountID != "557058:abc" || got.Reviewers[1].UUID != "{d2a0-uuid}" {
+		t.Errorf("request = %+v", got)
+	}
+
+	b = newTestBitbucket(server, map[string]string{BitbucketUsernameEnv: "me", BitbucketAppPasswordEnv: "pw"})
+	if _, err := b.Create(PROptions{Title: "t", Branch: "b"}); err == nil || !strings.Contains(err.Error(), "Unauthorized") {
+		t.Errorf("Create() with rejected credentials = %v, want the API error message", err)
+	}
+}
+
+func TestBitbucket_CheckCLI(t *testing.T) {
+	b := newTestBitbucket(nil, map[string]string{BitbucketUsernameEnv: "me"})
+	if err := b.CheckCLI(); !errors.Is(err, ErrCredentialsMissing) {
+		t.Errorf("CheckCLI() without app password = %v, want ErrCredentialsMissing", err)
+	}
+	b = newTestBitbucket(nil, map[string]string{BitbucketUsernameEnv: "me", BitbucketAppPasswordEnv: "pw"})
+	if err := b.CheckCLI(); err != nil {
+		t.Errorf("CheckCLI() with app password = %v", err)
+	}
+	b.remoteURL = "git@github.com:owner/repo.git"
+	if err := b.CheckCLI(); err == nil {
+		t.Error("CheckCLI() with a non-Bitbucket origin should fail")
+	}
+}
+
+func TestBitbucket_CommandAndCompareURL(t *testing.T) {
+	b := newTestBitbucket(nil, nil)
+	opts := PROptions{Title: "it's", Branch: "fix", Base: "main"}
+	want := `curl -sS -X POST -u "$BITBUCKET_USERNAME:$BITBUCKET_APP_PASSWORD" -H 'Content-Type: application/json' ` +
+		`-d '{"title":"it'"'"'s","source":{"branch":{"name":"fix"}},"destination":{"branch":{"name":"main"}}}' ` +
+		`'https://api.bitbucket.org/2.0/repositories/workspace/repo/pullrequests'`
+	if got := b.Command(opts); got != want {
+		t.Errorf("Command() =\n%s\nwant\n%s", got, want)
+	}
+	if got := b.CompareURL("main", "claudio/x"); got != "https://bitbucket.org/workspace/repo/pull-requests/new?dest=main&source=claudio%2Fx" {
+		t.Errorf("CompareURL() = %q", got)
+	}
+}
@@ -0,0 +1,103 @@
+package pr
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ErrCLIMissing is returned by CheckCLI when the gh CLI, or the CLI of
+// another Provider, is not installed.
+var ErrCLIMissing = errors.New("CLI not found in PATH")
+
+// lookPath is exec.LookPath, replaced in tests.
+var lookPath = exec.LookPath
+
+// CheckCLI reports whether pull requests can be created with the gh CLI. It
+// returns an error wrapping ErrCLIMissing when gh is not on PATH.
+func CheckCLI() error {
+	if _, err := lookPath("gh"); err != nil {
+		return fmt.Errorf("gh %w: install it from https://cli.github.com", ErrCLIMissing)
+	}
+	return nil
+}
+
+// Pending is a pull request whose branch was pushed but which could not be
+// created, e.g. because gh was missing. It is recorded on the session so
+// `claudio pr create --pending` can create it later.
+type Pending struct {
+	Branch     string    `json:"branch"`
+	Base       string    `json:"base,omitempty"`
+	Title      string    `json:"title"`
+	Body       string    `json:"body,omitempty"`
+	Draft      bool      `json:"draft,omitempty"`
+	Reviewers  []string  `json:"reviewers,omitempty"`
+	Labels     []string  `json:"labels,omitempty"`
+	PlanID     string    `json:"plan_id,omitempty"`     // Ultra-plan whose consolidation skipped it
+	InstanceID string    `json:"instance_id,omitempty"` // Instance `claudio pr` skipped it for
+	Reason     string    `json:"reason"`
+	SkippedAt  time.Time `json:"skipped_at"`
+}
+
+// Options returns the options that create p.
+func (p Pending) Options() PROptions {
+	return PROptions{
+		Title:     p.Title,
+		Body:      p.Body,
+		Branch:    p.Branch,
+		Base:      p.Base,
+		Draft:     p.Draft,
+		Reviewers: p.Reviewers,
+		Labels:    p.Labels,
+	}
+}
+
+// Command returns a gh command line, quoted for POSIX shells, that creates p.
+func (p Pending) Command() string {
+	return ghCreateCommand(p.Options()).String()
+}
+
+// CompareURL returns the GitHub page that opens a pull request from head
+// into base, or "" when remoteURL is not a GitHub remote. An empty base
+// compares against the repository's default branch.
+func CompareURL(remoteURL, base, head string) string {
+	repo := githubRepo(remoteURL)
+	if repo == "" || head == "" {
+		return ""
+	}
+	spec := escapeBranch(head)
+	if base != "" {
+		spec = escapeBranch(base) + "..." + spec
+	}
+	return fmt.Sprintf("https://github.com/%s/compare/%s?expand=1", repo, spec)
+}
+
+// escapeBranch escapes a branch name for a URL path, keeping the slashes
+// GitHub expects in names like "claudio/task-1".
+func escapeBranch(branch string) string {
+	return strings.ReplaceAll(url.PathEscape(branch), "%2F", "/")
+}
+
+// githubRepo returns "owner/repo" for a GitHub remote URL in HTTPS, SSH, or
+// scp-like form, or "" for any other remote.
+func githubRepo(remoteURL string) string {
+	s := strings.TrimSpace(remoteURL)
+	for _, prefix := range []string{"https://github.com/", "http://github.com/", "ssh://git@github.com/", "git@github.com:"} {
+		if rest, ok := strings.CutPrefix(s, prefix); ok {
+			rest = strings.TrimSuffix(strings.TrimSuffix(rest, "/"), ".git")
+			if owner, name, ok := strings.Cut(rest, "/"); ok && owner != "" && name != "" && !strings.Contains(name, "/") {
+				return rest
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// shellQuote wraps s in single quotes for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
@@ -0,0 +1,55 @@
+package pr
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+)
+
+func TestCheckCLI(t *testing.T) {
+	orig := lookPath
+	t.Cleanup(func() { lookPath = orig })
+
+	lookPath = func(string) (string, error) { return "", exec.ErrNotFound }
+	if err := CheckCLI(); !errors.Is(err, ErrCLIMissing) {
+		t.Errorf("CheckCLI() without gh = %v, want ErrCLIMissing", err)
+	}
+
+	lookPath = func(string) (string, error) { return "/usr/bin/gh", nil }
+	if err := CheckCLI(); err != nil {
+		t.Errorf("CheckCLI() with gh = %v, want nil", err)
+	}
+}
+
+func TestPendingCommand(t *testing.T) {
+	p := Pending{
+		Branch: "claudio/plan-group-1",
+		Base:   "main",
+		Title:  "feat: it's done",
+		Body:   "Summary",
+		Draft:  true,
+		Labels: []string{"ultraplan"},
+	}
+	want := `gh pr create --head 'claudio/plan-group-1' --base 'main' --title 'feat: it'"'"'s done' --body 'Summary' --draft --label 'ultraplan'`
+	if got := p.Command(); got != want {
+		t.Errorf("Command() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCompareURL(t *testing.T) {
+	tests := []struct {
+		remote, base, head string
+		want               string
+	}{
+		{"git@github.com:Iron-Ham/claudio.git", "main", "feature/x", "https://github.com/Iron-Ham/claudio/compare/main...feature/x?expand=1"},
+		{"https://github.com/Iron-Ham/claudio", "", "fix", "https://github.com/Iron-Ham/claudio/compare/fix?expand=1"},
+		{"ssh://git@github.com/Iron-Ham/claudio.git/", "dev", "fix", "https://github.com/Iron-Ham/claudio/compare/dev...fix?expand=1"},
+		{"git@gitlab.com:Iron-Ham/claudio.git", "main", "fix", ""},
+		{"https://github.com/Iron-Ham", "main", "fix", ""},
+	}
+	for _, tt := range tests {
+		if got := CompareURL(tt.remote, tt.base, tt.head); got != tt.want {
+			t.Errorf("CompareURL(%q, %q, %q) = %q, want %q", tt.remote, tt.base, tt.head, got, tt.want)
+		}
+	}
+}
@@ -23,6 +23,7 @@ type PROptions struct {
 	Title     string
 	Body      string
 	Branch    string
+	Base      string // Branch to merge into; empty uses the repository default
 	Draft     bool
 	Reviewers []string
 	Labels    []string
@@ -191,27 +192,7 @@ func extractJSON(s string) string {
 
 // Create creates a GitHub PR using the gh CLI with full options support
 func Create(opts PROptions) (string, error) {
-	args := []string{"pr", "create",
-		"--title", opts.Title,
-		"--body", opts.Body,
-		"--head", opts.Branch,
-	}
-
-	if opts.Draft {
-		args = append(args, "--draft")
-	}
-
-	for _, reviewer := range opts.Reviewers {
-		args = append(args, "--reviewer", reviewer)
-	}
-
-	for _, label := range opts.Labels {
-		args = append(args, "--label", label)
-	}
-
-	cmd := exec.Command("gh", args...)
-
-	output, err := cmd.CombinedOutput()
+	output, err := runCLI("gh", ghCreateCommand(opts).args...)
 	if err != nil {
 		return "", fmt.Errorf("failed to create PR: %w\n%s", err, string(output))
 	}
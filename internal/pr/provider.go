@@ -0,0 +1,270 @@
+package pr
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+)
+
+// Provider names accepted by NewProvider (pr.provider in config).
+const (
+	ProviderAuto      = "auto"
+	ProviderGitHub    = "github"
+	ProviderGitLab    = "gitlab"
+	ProviderBitbucket = "bitbucket"
+)
+
+// Provider creates pull requests on a code forge. GitHub pull requests are
+// created with gh, GitLab merge requests with glab, and Bitbucket Cloud pull
+// requests through its REST API.
+type Provider interface {
+	// Name returns the provider name (ProviderGitHub, ProviderGitLab or
+	// ProviderBitbucket).
+	Name() string
+
+	// CheckCLI reports whether Create can run. The error wraps ErrCLIMissing
+	// when the provider's CLI is not installed, or ErrCredentialsMissing when
+	// its API credentials are not set.
+	CheckCLI() error
+
+	// Create creates the pull request and returns its URL.
+	Create(opts PROptions) (string, error)
+
+	// Command returns a command line, quoted for POSIX shells, that creates
+	// the pull request.
+	Command(opts PROptions) string
+
+	// CompareURL returns the web page that opens a pull request from head
+	// into base, or "" when the remote is not recognized. An empty base
+	// targets the repository's default branch.
+	CompareURL(base, head string) string
+
+	// Instructions completes the sentence "Create pull requests ..." for
+	// agent prompts, naming the tool that creates them.
+	Instructions() string
+}
+
+// runCLI runs a provider CLI and returns its combined output, replaced in
+// tests.
+var runCLI = func(name string, args ...string) ([]byte, error) {
+	return exec.Command(name, args...).CombinedOutput()
+}
+
+// NewProvider returns the named provider for the repository whose origin is
+// remoteURL. An empty name or ProviderAuto picks one with DetectProvider.
+func NewProvider(name, remoteURL string) (Provider, error) {
+	if name == "" || name == ProviderAuto {
+		name = DetectProvider(remoteURL)
+	}
+	switch name {
+	case ProviderGitHub:
+		return &GitHub{remoteURL: remoteURL}, nil
+	case ProviderGitLab:
+		return &GitLab{remoteURL: remoteURL}, nil
+	case ProviderBitbucket:
+		return NewBitbucket(remoteURL), nil
+	default:
+		return nil, fmt.Errorf("unknown PR provider %q", name)
+	}
+}
+
+// DetectProvider guesses the provider from the host of remoteURL: Bitbucket
+// for bitbucket.org, GitLab for any host containing "gitlab", and GitHub
+// otherwise. Self-hosted GitLab on other hosts needs pr.provider set.
+func DetectProvider(remoteURL string) string {
+	host, _ := remoteHostPath(remoteURL)
+	switch {
+	case host == "bitbucket.org":
+		return ProviderBitbucket
+	case strings.Contains(host, "gitlab"):
+		return ProviderGitLab
+	default:
+		return ProviderGitHub
+	}
+}
+
+// remoteHostPath splits a git remote URL in HTTPS, SSH, or scp-like form
+// into its host and repository path, without slashes or ".git" around the
+// path. Both are empty for a URL it cannot parse.
+func remoteHostPath(remoteURL string) (host, path string) {
+	s := strings.TrimSpace(remoteURL)
+	if strings.Contains(s, "://") {
+		u, err := url.Parse(s)
+		if err != nil {
+			return "", ""
+		}
+		host, path = u.Hostname(), u.Path
+	} else {
+		var ok bool
+		host, path, ok = strings.Cut(s, ":")
+		if !ok {
+			return "", ""
+		}
+		if _, h, ok := strings.Cut(host, "@"); ok {
+			host = h
+		}
+	}
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || path == "" {
+		return "", ""
+	}
+	return strings.ToLower(host), path
+}
+
+// cliCommand accumulates CLI arguments, keeping a shell-quoted copy of the
+// command line for display.
+type cliCommand struct {
+	args   []string
+	quoted []string
+}
+
+// newCLICommand starts a command line with name and fixed, unquoted args.
+func newCLICommand(name string, args ...string) *cliCommand {
+	return &cliCommand{args: args, quoted: append([]string{name}, args...)}
+}
+
+// flag appends a boolean flag.
+func (c *cliCommand) flag(name string) {
+	c.args = append(c.args, name)
+	c.quoted = append(c.quoted, name)
+}
+
+// opt appends a flag with a value.
+func (c *cliCommand) opt(name, value string) {
+	c.args = append(c.args, name, value)
+	c.quoted = append(c.quoted, name, shellQuote(value))
+}
+
+// String returns the command line quoted for POSIX shells.
+func (c *cliCommand) String() string {
+	return strings.Join(c.quoted, " ")
+}
+
+// ghCreateCommand returns the gh command that creates opts.
+func ghCreateCommand(opts PROptions) *cliCommand {
+	c := newCLICommand("gh", "pr", "create")
+	c.opt("--head", opts.Branch)
+	if opts.Base != "" {
+		c.opt("--base", opts.Base)
+	}
+	c.opt("--title", opts.Title)
+	c.opt("--body", opts.Body)
+	if opts.Draft {
+		c.flag("--draft")
+	}
+	for _, r := range opts.Reviewers {
+		c.opt("--reviewer", r)
+	}
+	for _, l := range opts.Labels {
+		c.opt("--label", l)
+	}
+	return c
+}
+
+// GitHub creates pull requests with the gh CLI.
+type GitHub struct {
+	remoteURL string
+}
+
+// Name implements Provider.
+func (g *GitHub) Name() string { return ProviderGitHub }
+
+// CheckCLI implements Provider.
+func (g *GitHub) CheckCLI() error { return CheckCLI() }
+
+// Create implements Provider.
+func (g *GitHub) Create(opts PROptions) (string, error) { return Create(opts) }
+
+// Command implements Provider.
+func (g *GitHub) Command(opts PROptions) string { return ghCreateCommand(opts).String() }
+
+// CompareURL implements Provider.
+func (g *GitHub) CompareURL(base, head string) string { return CompareURL(g.remoteURL, base, head) }
+
+// Instructions implements Provider.
+func (g *GitHub) Instructions() string { return "with `gh pr create`" }
+
+// GitLab creates merge requests with the glab CLI.
+type GitLab struct {
+	remoteURL string
+}
+
+// Name implements Provider.
+func (g *GitLab) Name() string { return ProviderGitLab }
+
+// CheckCLI implements Provider.
+func (g *GitLab) CheckCLI() error {
+	if _, err := lookPath("glab"); err != nil {
+		return fmt.Errorf("glab %w: install it from https://gitlab.com/gitlab-org/cli", ErrCLIMissing)
+	}
+	return nil
+}
+
+// createCommand returns the glab command that creates opts.
+func (g *GitLab) createCommand(opts PROptions) *cliCommand {
+	c := newCLICommand("glab", "mr", "create")
+	c.opt("--source-branch", opts.Branch)
+	if opts.Base != "" {
+		c.opt("--target-branch", opts.Base)
+	}
+	c.opt("--title", opts.Title)
+	c.opt("--description", opts.Body)
+	if opts.Draft {
+		c.flag("--draft")
+	}
+	for _, r := range opts.Reviewers {
+		c.opt("--reviewer", r)
+	}
+	for _, l := range opts.Labels {
+		c.opt("--label", l)
+	}
+	c.flag("--yes")
+	return c
+}
+
+// Create implements Provider.
+func (g *GitLab) Create(opts PROptions) (string, error) {
+	output, err := runCLI("glab", g.createCommand(opts).args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to create merge request: %w\n%s", err, string(output))
+	}
+	// glab prints progress before the merge request URL
+	if u := lastURL(string(output)); u != "" {
+		return u, nil
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Command implements Provider.
+func (g *GitLab) Command(opts PROptions) string { return g.createCommand(opts).String() }
+
+// CompareURL implements Provider.
+func (g *GitLab) CompareURL(base, head string) string {
+	host, path := remoteHostPath(g.remoteURL)
+	if host == "" || head == "" {
+		return ""
+	}
+	query := url.Values{"merge_request[source_branch]": {head}}
+	if base != "" {
+		query.Set("merge_request[target_branch]", base)
+	}
+	return fmt.Sprintf("https://%s/%s/-/merge_requests/new?%s", host, path, query.Encode())
+}
+
+// Instructions implements Provider.
+func (g *GitLab) Instructions() string {
+	return "as GitLab merge requests with `glab mr create` (use `--source-branch`, `--target-branch`, `--title`, `--description` and `--yes`)"
+}
+
+// lastURL returns the last http(s) URL that starts a line of output.
+func lastURL(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, "https://") || strings.HasPrefix(line, "http://") {
+			return strings.Fields(line)[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package pr
+
+import (
+	"errors"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestDetectProvider(t *testing.T) {
+	tests := []struct {
+		remote string
+		want   string
+	}{
+		{"git@github.com:Iron-Ham/claudio.git", ProviderGitHub},
+		{"https://gitlab.com/group/sub/project.git", ProviderGitLab},
+		{"ssh://git@gitlab.example.com:2222/team/app.git", ProviderGitLab},
+		{"git@bitbucket.org:workspace/repo.git", ProviderBitbucket},
+		{"https://user@bitbucket.org/workspace/repo.git", ProviderBitbucket},
+		{"/srv/git/repo.git", ProviderGitHub},
+		{"", ProviderGitHub},
+	}
+	for _, tt := range tests {
+		if got := DetectProvider(tt.remote); got != tt.want {
+			t.Errorf("DetectProvider(%q) = %q, want %q", tt.remote, got, tt.want)
+		}
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	for name, want := range map[string]string{
+		"":                ProviderGitLab,
+		ProviderAuto:      ProviderGitLab,
+		ProviderGitHub:    ProviderGitHub,
+		ProviderBitbucket: ProviderBitbucket,
+	} {
+		p, err := NewProvider(name, "git@gitlab.com:group/project.git")
+		if err != nil {
+			t.Fatalf("NewProvider(%q) error = %v", name, err)
+		}
+		if p.Name() != want {
+			t.Errorf("NewProvider(%q).Name() = %q, want %q", name, p.Name(), want)
+		}
+	}
+	if _, err := NewProvider("gitea", ""); err == nil {
+		t.Error("NewProvider() of an unknown provider should fail")
+	}
+}
+
+func TestGitLab(t *testing.T) {
+	origLook, origRun := lookPath, runCLI
+	t.Cleanup(func() { lookPath, runCLI = origLook, origRun })
+
+	g := &GitLab{remoteURL: "git@gitlab.com:group/sub/project.git"}
+	opts := PROptions{
+		Title:     "feat: it's done",
+		Body:      "Summary",
+		Branch:    "claudio/task-1",
+		Base:      "main",
+		Draft:     true,
+		Reviewers: []string{"alice"},
+		Labels:    []string{"ultraplan"},
+	}
+
+	want := `glab mr create --source-branch 'claudio/task-1' --target-branch 'main' --title 'feat: it'"'"'s done' --description 'Summary' --draft --reviewer 'alice' --label 'ultraplan' --yes`
+	if got := g.Command(opts); got != want {
+		t.Errorf("Command() =\n%s\nwant\n%s", got, want)
+	}
+
+	lookPath = func(string) (string, error) { return "", exec.ErrNotFound }
+	if err := g.CheckCLI(); !errors.Is(err, ErrCLIMissing) {
+		t.Errorf("CheckCLI() without glab = %v, want ErrCLIMissing", err)
+	}
+
+	var gotArgs []string
+	runCLI = func(name string, args ...string) ([]byte, error) {
+		gotArgs = append([]string{name}, args...)
+		return []byte("Creating merge request for claudio/task-1 into main in group/sub/project\n\nhttps://gitlab.com/group/sub/project/-/merge_requests/7\n"), nil
+	}
+	url, err := g.Create(opts)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if url != "https://gitlab.com/group/sub/project/-/merge_requests/7" {
+		t.Errorf("Create() = %q, want the merge request URL", url)
+	}
+	wantArgs := []string{"glab", "mr", "create", "--source-branch", "claudio/task-1", "--target-branch", "main",
+		"--title", "feat: it's done", "--description", "Summary", "--draft", "--reviewer", "alice", "--label", "ultraplan", "--yes"}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Errorf("glab args = %q, want %q", gotArgs, wantArgs)
+	}
+
+	wantURL := "https://gitlab.com/group/sub/project/-/merge_requests/new?merge_request%5Bsource_branch%5D=claudio%2Ftask-1&merge_request%5Btarget_branch%5D=main"
+	if got := g.CompareURL("main", "claudio/task-1"); got != wantURL {
+		t.Errorf("CompareURL() = %q, want %q", got, wantURL)
+	}
+}
@@ -0,0 +1,29 @@
+// Package reaper removes the branches and worktrees of work whose pull
+// requests have merged.
+//
+// After consolidation opens pull requests, the task and group branches they
+// were built from linger locally and on the remote. A [Reaper] watches the
+// pull requests of each [Target] through a [Forge] and, once every one of
+// them has merged and the [Policy] retention period has passed, removes the
+// target's worktrees, then its branches and each pull request's head branch.
+// Each cleanup is described by a [Record] for the session history.
+//
+// # Usage
+//
+//	r := reaper.New(reaper.NewGitHub(repoRoot), worktreeManager, reaper.Policy{
+//		Retain:          time.Hour,
+//		DeleteLocal:     true,
+//		RemoveWorktrees: true,
+//	})
+//
+//	go r.Watch(ctx, 2*time.Minute, currentTargets, func(rec reaper.Record) {
+//		session.BranchCleanups = append(session.BranchCleanups, rec)
+//	})
+//
+// # Safety
+//
+// Nothing is reaped until every pull request of a target has merged. A pull
+// request closed without merging abandons the target for good, since the
+// work may still be wanted. Worktrees with uncommitted changes are always
+// kept and listed in [Record.KeptWorktrees].
+package reaper
@@ -0,0 +1,90 @@
+package reaper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// PRState is the state of a pull request as reported by the forge.
+type PRState string
+
+const (
+	PROpen   PRState = "OPEN"
+	PRClosed PRState = "CLOSED"
+	PRMerged PRState = "MERGED"
+)
+
+// PullRequest is the part of a forge pull request the reaper needs.
+type PullRequest struct {
+	URL        string
+	State      PRState
+	HeadBranch string
+	MergedAt   time.Time
+}
+
+// Forge reports the state of pull requests.
+type Forge interface {
+	PullRequest(ctx context.Context, url string) (PullRequest, error)
+}
+
+// CommandExecutor runs a command in a directory and returns its standard
+// output. This allows for dependency injection in tests.
+type CommandExecutor func(ctx context.Context, dir, name string, args ...string) ([]byte, error)
+
+// defaultExecutor runs commands using os/exec.
+func defaultExecutor(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return out, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return out, err
+}
+
+// GitHub implements Forge using the gh CLI.
+type GitHub struct {
+	dir      string
+	executor CommandExecutor
+}
+
+// NewGitHub creates a GitHub forge that runs gh in dir, so the repository's
+// remote is used to resolve pull requests.
+func NewGitHub(dir string) *GitHub {
+	return NewGitHubWithExecutor(dir, defaultExecutor)
+}
+
+// NewGitHubWithExecutor creates a GitHub forge with a custom command executor
+// for testing.
+func NewGitHubWithExecutor(dir string, executor CommandExecutor) *GitHub {
+	return &GitHub{dir: dir, executor: executor}
+}
+
+// PullRequest looks up the pull request at url.
+func (g *GitHub) PullRequest(ctx context.Context, url string) (PullRequest, error) {
+	out, err := g.executor(ctx, g.dir, "gh", "pr", "view", url, "--json", "state,headRefName,mergedAt")
+	if err != nil {
+		return PullRequest{}, fmt.Errorf("reaper: gh pr view %s: %w", url, err)
+	}
+
+	var resp struct {
+		State       PRState   `json:"state"`
+		HeadRefName string    `json:"headRefName"`
+		MergedAt    time.Time `json:"mergedAt"`
+	}
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return PullRequest{}, fmt.Errorf("reaper: parse gh pr view output: %w", err)
+	}
+	return PullRequest{
+		URL:        url,
+		State:      resp.State,
+		HeadBranch: resp.HeadRefName,
+		MergedAt:   resp.MergedAt,
+	}, nil
+}
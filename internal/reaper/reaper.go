@@ -0,0 +1,247 @@
+package reaper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Policy decides what is removed once a target's pull requests have merged.
+type Policy struct {
+	// Retain is how long after the last merge branches and worktrees are kept,
+	// e.g. to make a revert easy. Zero reaps as soon as the merge is seen.
+	Retain time.Duration
+
+	// DeleteRemote deletes the target's branches on the remote.
+	DeleteRemote bool
+
+	// DeleteLocal deletes the target's local branches.
+	DeleteLocal bool
+
+	// RemoveWorktrees removes the target's worktrees. Worktrees with
+	// uncommitted changes are always kept.
+	RemoveWorktrees bool
+}
+
+// Git performs the branch and worktree operations the reaper needs.
+// *worktree.Manager satisfies it.
+type Git interface {
+	BranchExists(branch string) bool
+	DeleteBranch(branch string) error
+	DeleteRemoteBranch(branch string) error
+	Remove(path string) error
+	HasUncommittedChanges(path string) (bool, error)
+}
+
+// Target is a unit of work carried by one or more pull requests. It is
+// reaped once every one of its pull requests has merged.
+type Target struct {
+	// ID identifies the target in records, e.g. an ultra-plan session ID.
+	ID string
+
+	// PRURLs are the pull requests that carry the work.
+	PRURLs []string
+
+	// Branches are deleted in addition to each pull request's head branch,
+	// e.g. task branches that were consolidated into it.
+	Branches []string
+
+	// Worktrees are removed once the target is reaped.
+	Worktrees []string
+}
+
+// Record describes one reaped target, for the session history.
+type Record struct {
+	TargetID       string    `json:"target_id"`
+	PRURLs         []string  `json:"pr_urls"`
+	MergedAt       time.Time `json:"merged_at"`
+	ReapedAt       time.Time `json:"reaped_at"`
+	RemoteBranches []string  `json:"remote_branches,omitempty"`
+	LocalBranches  []string  `json:"local_branches,omitempty"`
+	Worktrees      []string  `json:"worktrees,omitempty"`
+	KeptWorktrees  []string  `json:"kept_worktrees,omitempty"`
+	Errors         []string  `json:"errors,omitempty"`
+}
+
+// Option configures a Reaper.
+type Option func(*Reaper)
+
+// WithClock sets the clock used for the retention policy.
+func WithClock(now func() time.Time) Option {
+	return func(r *Reaper) { r.now = now }
+}
+
+// Reaper watches pull requests and removes the branches and worktrees of
+// targets whose pull requests have all merged.
+type Reaper struct {
+	forge  Forge
+	git    Git
+	policy Policy
+	now    func() time.Time
+
+	mu   sync.Mutex
+	done map[string]bool // Targets already reaped or abandoned, by ID
+}
+
+// New creates a Reaper.
+func New(forge Forge, git Git, policy Policy, opts ...Option) *Reaper {
+	r := &Reaper{
+		forge:  forge,
+		git:    git,
+		policy: policy,
+		now:    time.Now,
+		done:   make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Sweep checks each target's pull requests once and reaps the targets that
+// are merged and past the retention period. Targets with a pull request that
+// was closed without merging are never reaped. Forge errors leave a target
+// to be checked again on the next sweep.
+func (r *Reaper) Sweep(ctx context.Context, targets []Target) []Record {
+	var records []Record
+	for _, t := range targets {
+		if ctx.Err() != nil {
+			break
+		}
+		if len(t.PRURLs) == 0 || r.isDone(t.ID) {
+			continue
+		}
+		if rec, ok := r.sweepTarget(ctx, t); ok {
+			records = append(records, rec)
+		}
+	}
+	return records
+}
+
+// Watch sweeps targets immediately and then every interval until ctx is
+// cancelled, passing each record to onReaped. targets is called before
+// every sweep so new pull requests are picked up.
+func (r *Reaper) Watch(ctx context.Context, interval time.Duration, targets func() []Target, onReaped func(Record)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, rec := range r.Sweep(ctx, targets()) {
+			onReaped(rec)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Reaper) isDone(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.done[id]
+}
+
+func (r *Reaper) markDone(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.done[id] = true
+}
+
+// sweepTarget reaps t if all of its pull requests are merged and the
+// retention period has passed since the last merge.
+func (r *Reaper) sweepTarget(ctx context.Context, t Target) (Record, bool) {
+	var (
+		heads    []string
+		mergedAt time.Time
+	)
+	for _, url := range t.PRURLs {
+		pr, err := r.forge.PullRequest(ctx, url)
+		if err != nil {
+			return Record{}, false
+		}
+		switch pr.State {
+		case PRMerged:
+		case PRClosed:
+			// Closed work may be reopened or salvaged; leave it alone
+			r.markDone(t.ID)
+			return Record{}, false
+		default:
+			return Record{}, false
+		}
+		if pr.HeadBranch != "" {
+			heads = append(heads, pr.HeadBranch)
+		}
+		if pr.MergedAt.After(mergedAt) {
+			mergedAt = pr.MergedAt
+		}
+	}
+
+	now := r.now()
+	if now.Sub(mergedAt) < r.policy.Retain {
+		return Record{}, false
+	}
+
+	r.markDone(t.ID)
+	return r.reap(t, heads, mergedAt, now), true
+}
+
+// reap removes t's worktrees and branches. Worktrees go first: git refuses
+// to delete a branch that is checked out in a worktree.
+func (r *Reaper) reap(t Target, heads []string, mergedAt, now time.Time) Record {
+	rec := Record{
+		TargetID: t.ID,
+		PRURLs:   slices.Clone(t.PRURLs),
+		MergedAt: mergedAt,
+		ReapedAt: now,
+	}
+	fail := func(op, name string, err error) {
+		rec.Errors = append(rec.Errors, fmt.Sprintf("%s %s: %v", op, name, err))
+	}
+
+	if r.policy.RemoveWorktrees {
+		for _, path := range t.Worktrees {
+			if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			dirty, err := r.git.HasUncommittedChanges(path)
+			if err != nil {
+				fail("check worktree", path, err)
+				continue
+			}
+			if dirty {
+				rec.KeptWorktrees = append(rec.KeptWorktrees, path)
+				continue
+			}
+			if err := r.git.Remove(path); err != nil {
+				fail("remove worktree", path, err)
+				continue
+			}
+			rec.Worktrees = append(rec.Worktrees, path)
+		}
+	}
+
+	branches := slices.Compact(slices.Sorted(slices.Values(append(heads, t.Branches...))))
+	for _, branch := range branches {
+		if r.policy.DeleteRemote {
+			if err := r.git.DeleteRemoteBranch(branch); err != nil {
+				fail("delete remote branch", branch, err)
+			} else {
+				rec.RemoteBranches = append(rec.RemoteBranches, branch)
+			}
+		}
+		if r.policy.DeleteLocal && r.git.BranchExists(branch) {
+			if err := r.git.DeleteBranch(branch); err != nil {
+				fail("delete branch", branch, err)
+			} else {
+				rec.LocalBranches = append(rec.LocalBranches, branch)
+			}
+		}
+	}
+	return rec
+}
@@ -0,0 +1,217 @@
+package reaper
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+type fakeForge struct {
+	prs   map[string]PullRequest
+	err   error
+	calls int
+}
+
+func (f *fakeForge) PullRequest(_ context.Context, url string) (PullRequest, error) {
+	f.calls++
+	if f.err != nil {
+		return PullRequest{}, f.err
+	}
+	return f.prs[url], nil
+}
+
+type fakeGit struct {
+	local   map[string]bool
+	dirty   map[string]bool
+	removed []string
+	remote  []string
+	deleted []string
+}
+
+func (g *fakeGit) BranchExists(branch string) bool { return g.local[branch] }
+
+func (g *fakeGit) DeleteBranch(branch string) error {
+	g.deleted = append(g.deleted, branch)
+	return nil
+}
+
+func (g *fakeGit) DeleteRemoteBranch(branch string) error {
+	if branch == "protected" {
+		return errors.New("refusing to delete")
+	}
+	g.remote = append(g.remote, branch)
+	return nil
+}
+
+func (g *fakeGit) Remove(path string) error {
+	g.removed = append(g.removed, path)
+	return nil
+}
+
+func (g *fakeGit) HasUncommittedChanges(path string) (bool, error) {
+	return g.dirty[path], nil
+}
+
+func merged(head string, at time.Time) PullRequest {
+	return PullRequest{State: PRMerged, HeadBranch: head, MergedAt: at}
+}
+
+func TestReaper_ReapsMergedTargets(t *testing.T) {
+	mergedAt := time.Unix(1000, 0)
+	now := mergedAt.Add(time.Hour)
+	clean, dirty, gone := t.TempDir(), t.TempDir(), filepath.Join(t.TempDir(), "gone")
+
+	forge := &fakeForge{prs: map[string]PullRequest{
+		"pr/1": merged("group-1", mergedAt),
+		"pr/2": merged("group-2", mergedAt.Add(-time.Minute)),
+	}}
+	git := &fakeGit{
+		local: map[string]bool{"group-1": true, "task-a": true, "task-b": true},
+		dirty: map[string]bool{dirty: true},
+	}
+	r := New(forge, git, Policy{DeleteRemote: true, DeleteLocal: true, RemoveWorktrees: true},
+		WithClock(func() time.Time { return now }))
+
+	target := Target{
+		ID:        "plan-1",
+		PRURLs:    []string{"pr/1", "pr/2"},
+		Branches:  []string{"task-a", "task-b", "group-1"},
+		Worktrees: []string{clean, dirty, gone},
+	}
+	records := r.Sweep(context.Background(), []Target{target})
+	if len(records) != 1 {
+		t.Fatalf("Sweep() = %d records, want 1", len(records))
+	}
+	rec := records[0]
+
+	if rec.TargetID != "plan-1" || !rec.MergedAt.Equal(mergedAt) || !rec.ReapedAt.Equal(now) {
+		t.Errorf("record = %+v, want plan-1 merged at the latest merge", rec)
+	}
+	if want := []string{"group-1", "task-a", "task-b"}; !slices.Equal(rec.LocalBranches, want) {
+		t.Errorf("LocalBranches = %v, want %v (group-2 has no local branch)", rec.LocalBranches, want)
+	}
+	if want := []string{"group-1", "group-2", "task-a", "task-b"}; !slices.Equal(rec.RemoteBranches, want) {
+		t.Errorf("RemoteBranches = %v, want %v", rec.RemoteBranches, want)
+	}
+	if !slices.Equal(rec.Worktrees, []string{clean}) || !slices.Equal(git.removed, []string{clean}) {
+		t.Errorf("Worktrees = %v, removed = %v, want only the clean worktree", rec.Worktrees, git.removed)
+	}
+	if !slices.Equal(rec.KeptWorktrees, []string{dirty}) {
+		t.Errorf("KeptWorktrees = %v, want the dirty worktree", rec.KeptWorktrees)
+	}
+
+	// A reaped target is not checked again
+	calls := forge.calls
+	if records := r.Sweep(context.Background(), []Target{target}); len(records) != 0 || forge.calls != calls {
+		t.Errorf("second Sweep() = %d records and %d forge calls, want none", len(records), forge.calls-calls)
+	}
+}
+
+func TestReaper_WaitsForEveryMergeAndRetention(t *testing.T) {
+	mergedAt := time.Unix(1000, 0)
+	now := mergedAt
+	forge := &fakeForge{prs: map[string]PullRequest{
+		"pr/1": merged("group-1", mergedAt),
+		"pr/2": {State: PROpen, HeadBranch: "group-2"},
+	}}
+	git := &fakeGit{local: map[string]bool{"group-1": true}}
+	r := New(forge, git, Policy{Retain: time.Hour, DeleteLocal: true},
+		WithClock(func() time.Time { return now }))
+	targets := []Target{{ID: "plan-1", PRURLs: []string{"pr/1", "pr/2"}}}
+
+	if records := r.Sweep(context.Background(), targets); len(records) != 0 {
+		t.Fatalf("Sweep() with an open PR = %d records, want 0", len(records))
+	}
+
+	forge.prs["pr/2"] = merged("group-2", mergedAt)
+	now = mergedAt.Add(59 * time.Minute)
+	if records := r.Sweep(context.Background(), targets); len(records) != 0 {
+		t.Fatalf("Sweep() within retention = %d records, want 0", len(records))
+	}
+
+	now = mergedAt.Add(time.Hour)
+	records := r.Sweep(context.Background(), targets)
+	if len(records) != 1 || !slices.Equal(records[0].LocalBranches, []string{"group-1"}) {
+		t.Fatalf("Sweep() after retention = %+v, want group-1 deleted", records)
+	}
+	if len(git.remote) != 0 {
+		t.Errorf("remote branches deleted = %v, want none without DeleteRemote", git.remote)
+	}
+}
+
+func TestReaper_ClosedAndUnreachablePRs(t *testing.T) {
+	forge := &fakeForge{prs: map[string]PullRequest{"pr/1": {State: PRClosed, HeadBranch: "group-1"}}}
+	git := &fakeGit{local: map[string]bool{"group-1": true}}
+	r := New(forge, git, Policy{DeleteLocal: true})
+	targets := []Target{{ID: "plan-1", PRURLs: []string{"pr/1"}}}
+
+	if records := r.Sweep(context.Background(), targets); len(records) != 0 {
+		t.Errorf("Sweep() of a closed PR = %d records, want 0", len(records))
+	}
+	forge.prs["pr/1"] = merged("group-1", time.Unix(1000, 0))
+	if records := r.Sweep(context.Background(), targets); len(records) != 0 {
+		t.Error("closed target was reaped after being abandoned")
+	}
+
+	// Forge errors leave the target to be checked again
+	forge = &fakeForge{err: errors.New("gh: not logged in")}
+	r = New(forge, git, Policy{DeleteLocal: true})
+	r.Sweep(context.Background(), targets)
+	forge.err = nil
+	forge.prs = map[string]PullRequest{"pr/1": merged("group-1", time.Unix(1000, 0))}
+	if records := r.Sweep(context.Background(), targets); len(records) != 1 {
+		t.Errorf("Sweep() after a forge error = %d records, want 1", len(records))
+	}
+}
+
+func TestReaper_RecordsErrors(t *testing.T) {
+	forge := &fakeForge{prs: map[string]PullRequest{"pr/1": merged("protected", time.Unix(1000, 0))}}
+	git := &fakeGit{}
+	r := New(forge, git, Policy{DeleteRemote: true})
+
+	records := r.Sweep(context.Background(), []Target{{ID: "plan-1", PRURLs: []string{"pr/1"}}})
+	if len(records) != 1 || len(records[0].Errors) != 1 || len(records[0].RemoteBranches) != 0 {
+		t.Errorf("Sweep() = %+v, want one error and no deleted branches", records)
+	}
+}
+
+func TestGitHub_PullRequest(t *testing.T) {
+	var gotArgs []string
+	g := NewGitHubWithExecutor("/repo", func(_ context.Context, dir, name string, args ...string) ([]byte, error) {
+		if dir != "/repo" || name != "gh" {
+			t.Errorf("ran %s in %s, want gh in /repo", name, dir)
+		}
+		gotArgs = args
+		return []byte(`{"state":"MERGED","headRefName":"claudio/group-1","mergedAt":"2026-01-02T03:04:05Z"}`), nil
+	})
+
+	pr, err := g.PullRequest(context.Background(), "https://github.com/o/r/pull/7")
+	if err != nil {
+		t.Fatalf("PullRequest() error = %v", err)
+	}
+	if want := []string{"pr", "view", "https://github.com/o/r/pull/7", "--json", "state,headRefName,mergedAt"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("args = %v, want %v", gotArgs, want)
+	}
+	want := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if pr.State != PRMerged || pr.HeadBranch != "claudio/group-1" || !pr.MergedAt.Equal(want) {
+		t.Errorf("PullRequest() = %+v", pr)
+	}
+
+	open := NewGitHubWithExecutor("", func(context.Context, string, string, ...string) ([]byte, error) {
+		return []byte(`{"state":"OPEN","headRefName":"b","mergedAt":null}`), nil
+	})
+	if pr, err := open.PullRequest(context.Background(), "u"); err != nil || pr.State != PROpen || !pr.MergedAt.IsZero() {
+		t.Errorf("PullRequest() of an open PR = (%+v, %v)", pr, err)
+	}
+
+	failing := NewGitHubWithExecutor("", func(context.Context, string, string, ...string) ([]byte, error) {
+		return nil, os.ErrPermission
+	})
+	if _, err := failing.PullRequest(context.Background(), "u"); !errors.Is(err, os.ErrPermission) {
+		t.Errorf("PullRequest() error = %v, want the executor error", err)
+	}
+}
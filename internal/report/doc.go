@@ -0,0 +1,24 @@
+// Package report summarizes a session as a Markdown or HTML document: its
+// objective and plan, the outcome, commits, and cost of each task, its pull
+// requests, a timeline of when each task ran, and why failed tasks failed.
+//
+// A report is built from a saved session (the content of session.json), so
+// it can be generated for a running session, when a session completes, or
+// later from a copy. The orchestrator writes one to .claudio/reports when an
+// ultraplan finishes or a session is stopped (session.report), and
+// `claudio report` prints it.
+//
+// For an ultraplan, each planned task is reported with the verified commit
+// count recorded for it, and cost is broken down by role: planning, tasks,
+// synthesis, revision, and consolidation. In other sessions each instance
+// is a task.
+//
+// # Usage
+//
+//	r, err := report.Build(sessionJSON, report.WithCommitCounter(count))
+//	if err != nil {
+//		return err
+//	}
+//	paths, err := report.Write(report.Dir(baseDir), r, false) // .md only
+//	fmt.Print(report.Markdown(r))
+package report
@@ -0,0 +1,208 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"time"
+
+	instmetrics "github.com/Iron-Ham/claudio/internal/instance/metrics"
+)
+
+// htmlTemplate is a self-contained page, so a report can be opened or
+// attached anywhere without its stylesheet.
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"cost":     instmetrics.FormatCost,
+	"tokens":   instmetrics.FormatTokens,
+	"datetime": func(t time.Time) string { return t.Local().Format(time.DateTime) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Session Report: {{.Name}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; max-width: 960px; margin: 2em auto; padding: 0 1em; color: #1f2328; }
+h1 { font-size: 1.6em; } h2 { font-size: 1.25em; margin-top: 1.8em; border-bottom: 1px solid #d0d7de; padding-bottom: .3em; }
+table { border-collapse: collapse; width: 100%; } th, td { padding: .35em .6em; border-bottom: 1px solid #d0d7de; text-align: left; }
+td.num, th.num { text-align: right; } .muted { color: #656d76; }
+.completed { color: #1a7f37; } .failed { color: #cf222e; } .unfinished { color: #9a6700; } .not-started { color: #656d76; }
+.timeline { position: relative; height: 1.2em; background: #f6f8fa; border-radius: 3px; }
+.bar { position: absolute; top: 0; bottom: 0; min-width: 2px; border-radius: 3px; background: #0969da; }
+.bar.completed { background: #2da44e; } .bar.failed { background: #cf222e; } .bar.unfinished { background: #bf8700; }
+</style>
+</head>
+<body>
+<h1>Session Report: {{.Name}}</h1>
+<ul>
+<li><strong>Session:</strong> <code>{{.SessionID}}</code></li>
+<li><strong>Started:</strong> {{datetime .Started}}</li>
+{{- if .Finished.IsZero}}
+<li><strong>Status:</strong> still running ({{.Elapsed}} so far)</li>
+{{- else}}
+<li><strong>Finished:</strong> {{datetime .Finished}} ({{.Elapsed}})</li>
+{{- end}}
+{{- if .Phase}}
+<li><strong>Phase:</strong> {{.Phase}}</li>
+{{- end}}
+<li><strong>Tasks:</strong> {{.Outcomes}}</li>
+<li><strong>Commits:</strong> {{.Commits}}</li>
+<li><strong>Cost:</strong> {{cost .Total.Cost}} ({{tokens .Total.Tokens}} tokens)</li>
+{{- if .Error}}
+<li><strong>Error:</strong> {{.Error}}</li>
+{{- end}}
+</ul>
+{{- if .Objective}}
+<h2>Objective</h2>
+<p>{{.Objective}}</p>
+{{- end}}
+{{- if or .PlanSummary .Groups}}
+<h2>Plan</h2>
+{{- if .PlanSummary}}
+<p>{{.PlanSummary}}</p>
+{{- end}}
+<ol>
+{{- range .Groups}}
+<li>{{range $i, $t := .}}{{if $i}}; {{end}}{{$t}}{{end}}</li>
+{{- end}}
+</ol>
+{{- end}}
+{{- if .Tasks}}
+<h2>Tasks</h2>
+<table>
+<tr><th>Task</th><th class="num">Group</th><th>Outcome</th><th class="num">Commits</th><th class="num">Tokens</th><th class="num">Cost</th><th class="num">Duration</th></tr>
+{{- range .Rows}}
+<tr><td>{{.Title}}</td><td class="num">{{.Group}}</td><td class="{{.Class}}">{{.Outcome}}</td><td class="num">{{.Commits}}</td><td class="num">{{tokens .Usage.Tokens}}</td><td class="num">{{cost .Usage.Cost}}</td><td class="num">{{.Duration}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Failures}}
+<h2>Failures</h2>
+<ul>
+{{- range .Failures}}
+<li><strong>{{.Title}}:</strong> {{if .Reason}}{{.Reason}}{{else}}no reason was recorded{{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .PRURLs}}
+<h2>Pull Requests</h2>
+<ul>
+{{- range .PRURLs}}
+<li><a href="{{.}}">{{.}}</a></li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Costs}}
+<h2>Cost</h2>
+<table>
+<tr><th>Role</th><th class="num">Instances</th><th class="num">Input</th><th class="num">Output</th><th class="num">Cache Read</th><th class="num">Cache Write</th><th class="num">Cost</th></tr>
+{{- range .Costs}}
+<tr><td>{{.Role}}</td><td class="num">{{.Instances}}</td><td class="num">{{tokens .InputTokens}}</td><td class="num">{{tokens .OutputTokens}}</td><td class="num">{{tokens .CacheRead}}</td><td class="num">{{tokens .CacheWrite}}</td><td class="num">{{cost .Cost}}</td></tr>
+{{- end}}
+{{- with .Total}}
+<tr><th>Total</th><th class="num">{{.Instances}}</th><th class="num">{{tokens .InputTokens}}</th><th class="num">{{tokens .OutputTokens}}</th><th class="num">{{tokens .CacheRead}}</th><th class="num">{{tokens .CacheWrite}}</th><th class="num">{{cost .Cost}}</th></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Bars}}
+<h2>Timeline</h2>
+<table>
+{{- range .Bars}}
+<tr><td>{{.Title}}</td><td style="width: 60%"><div class="timeline"><div class="bar {{.Class}}" style="left: {{.Left}}%; width: {{.Width}}%" title="{{.Duration}}"></div></div></td></tr>
+{{- end}}
+<tr><td></td><td class="muted">{{.StartClock}} – {{.EndClock}}</td></tr>
+</table>
+{{- end}}
+<p class="muted"><em>Generated {{datetime .GeneratedAt}}.</em></p>
+</body>
+</html>
+`))
+
+// htmlRow is a task as the HTML report shows it.
+type htmlRow struct {
+	Task
+	Group    string
+	Commits  string
+	Class    string
+	Duration string
+}
+
+// htmlBar is a task's bar on the HTML timeline, positioned in percent of
+// the session's span.
+type htmlBar struct {
+	Title    string
+	Class    string
+	Left     string
+	Width    string
+	Duration string
+}
+
+// HTML renders the report as a self-contained HTML page.
+func HTML(r *Report) (string, error) {
+	name := r.Name
+	if name == "" {
+		name = r.SessionID
+	}
+	data := struct {
+		*Report
+		Name       string
+		Elapsed    string
+		Outcomes   string
+		Commits    int
+		Rows       []htmlRow
+		Failures   []Task
+		Bars       []htmlBar
+		StartClock string
+		EndClock   string
+	}{
+		Report:     r,
+		Name:       name,
+		Elapsed:    formatDuration(r.End().Sub(r.Started)),
+		Outcomes:   outcomeSummary(r),
+		Commits:    r.Commits(),
+		Failures:   failures(r),
+		StartClock: r.Started.Local().Format("15:04"),
+		EndClock:   r.End().Local().Format("15:04"),
+	}
+
+	span := r.End().Sub(r.Started)
+	for _, t := range r.Tasks {
+		duration := taskDuration(r, t)
+		data.Rows = append(data.Rows, htmlRow{
+			Task:     t,
+			Group:    groupLabel(t.Group),
+			Commits:  commitsLabel(t.Commits),
+			Class:    outcomeClass(t.Outcome),
+			Duration: duration,
+		})
+		if t.Start.IsZero() || span <= 0 {
+			continue
+		}
+		end := t.End
+		if end.IsZero() {
+			end = r.End()
+		}
+		left := max(float64(t.Start.Sub(r.Started))/float64(span)*100, 0)
+		width := max(min(float64(end.Sub(t.Start))/float64(span)*100, 100-left), 0)
+		data.Bars = append(data.Bars, htmlBar{
+			Title:    t.Title,
+			Class:    outcomeClass(t.Outcome),
+			Left:     fmt.Sprintf("%.2f", left),
+			Width:    fmt.Sprintf("%.2f", width),
+			Duration: duration,
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render report: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// outcomeClass returns the CSS class of outcome.
+func outcomeClass(outcome Outcome) string {
+	if outcome == OutcomeNotStarted {
+		return "not-started"
+	}
+	return string(outcome)
+}
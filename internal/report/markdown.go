@@ -0,0 +1,227 @@
+package report
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	instmetrics "github.com/Iron-Ham/claudio/internal/instance/metrics"
+)
+
+// timelineWidth is the width of the timeline chart's bars, in characters.
+const timelineWidth = 40
+
+// Markdown renders the report as a Markdown document.
+func Markdown(r *Report) string {
+	var b strings.Builder
+
+	name := r.Name
+	if name == "" {
+		name = r.SessionID
+	}
+	fmt.Fprintf(&b, "# Session Report: %s\n\n", name)
+	fmt.Fprintf(&b, "- **Session:** `%s`\n", r.SessionID)
+	fmt.Fprintf(&b, "- **Started:** %s\n", r.Started.Local().Format(time.DateTime))
+	if r.Finished.IsZero() {
+		fmt.Fprintf(&b, "- **Status:** still running (%s so far)\n", formatDuration(r.End().Sub(r.Started)))
+	} else {
+		fmt.Fprintf(&b, "- **Finished:** %s (%s)\n", r.Finished.Local().Format(time.DateTime), formatDuration(r.Finished.Sub(r.Started)))
+	}
+	if r.Phase != "" {
+		fmt.Fprintf(&b, "- **Phase:** %s\n", r.Phase)
+	}
+	fmt.Fprintf(&b, "- **Tasks:** %s\n", outcomeSummary(r))
+	fmt.Fprintf(&b, "- **Commits:** %d\n", r.Commits())
+	fmt.Fprintf(&b, "- **Cost:** %s (%s tokens)\n", instmetrics.FormatCost(r.Total.Cost), instmetrics.FormatTokens(r.Total.Tokens()))
+	if r.Error != "" {
+		fmt.Fprintf(&b, "- **Error:** %s\n", oneLine(r.Error))
+	}
+
+	if r.Objective != "" {
+		b.WriteString("\n## Objective\n\n")
+		b.WriteString(strings.TrimSpace(r.Objective) + "\n")
+	}
+
+	if r.PlanSummary != "" || len(r.Groups) > 0 {
+		b.WriteString("\n## Plan\n\n")
+		if r.PlanSummary != "" {
+			b.WriteString(strings.TrimSpace(r.PlanSummary) + "\n\n")
+		}
+		for i, group := range r.Groups {
+			fmt.Fprintf(&b, "%d. %s\n", i+1, strings.Join(group, "; "))
+		}
+	}
+
+	if len(r.Tasks) > 0 {
+		b.WriteString("\n## Tasks\n\n")
+		b.WriteString("| Task | Group | Outcome | Commits | Tokens | Cost | Duration |\n")
+		b.WriteString("|------|------:|---------|--------:|-------:|-----:|---------:|\n")
+		for _, t := range r.Tasks {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s | %s |\n",
+				cell(t.Title), groupLabel(t.Group), t.Outcome, commitsLabel(t.Commits),
+				instmetrics.FormatTokens(t.Usage.Tokens()), instmetrics.FormatCost(t.Usage.Cost), taskDuration(r, t))
+		}
+	}
+
+	if failed := failures(r); len(failed) > 0 {
+		b.WriteString("\n## Failures\n\n")
+		for _, t := range failed {
+			reason := t.Reason
+			if reason == "" {
+				reason = "no reason was recorded"
+			}
+			fmt.Fprintf(&b, "- **%s:** %s\n", oneLine(t.Title), oneLine(reason))
+		}
+	}
+
+	if len(r.PRURLs) > 0 {
+		b.WriteString("\n## Pull Requests\n\n")
+		for _, url := range r.PRURLs {
+			fmt.Fprintf(&b, "- %s\n", url)
+		}
+	}
+
+	if len(r.Costs) > 0 {
+		b.WriteString("\n## Cost\n\n")
+		b.WriteString("| Role | Instances | Input | Output | Cache Read | Cache Write | Cost |\n")
+		b.WriteString("|------|----------:|------:|-------:|-----------:|------------:|-----:|\n")
+		for _, line := range append(slices.Clip(r.Costs), CostLine{Role: "**Total**", Usage: r.Total}) {
+			fmt.Fprintf(&b, "| %s | %d | %s | %s | %s | %s | %s |\n",
+				line.Role, line.Instances,
+				instmetrics.FormatTokens(line.InputTokens), instmetrics.FormatTokens(line.OutputTokens),
+				instmetrics.FormatTokens(line.CacheRead), instmetrics.FormatTokens(line.CacheWrite),
+				instmetrics.FormatCost(line.Cost))
+		}
+	}
+
+	if chart := timeline(r); chart != "" {
+		b.WriteString("\n## Timeline\n\n```\n")
+		b.WriteString(chart)
+		b.WriteString("```\n")
+	}
+
+	fmt.Fprintf(&b, "\n_Generated %s._\n", r.GeneratedAt.Local().Format(time.DateTime))
+	return b.String()
+}
+
+// timeline renders a chart of when each task ran, one row per started
+// task, with the session's span as the full width.
+func timeline(r *Report) string {
+	start, end := r.Started, r.End()
+	span := end.Sub(start)
+	if span <= 0 {
+		return ""
+	}
+
+	labelWidth := 0
+	for _, t := range r.Tasks {
+		if !t.Start.IsZero() {
+			labelWidth = max(labelWidth, len([]rune(title(t.Title, 30))))
+		}
+	}
+	if labelWidth == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, t := range r.Tasks {
+		if t.Start.IsZero() {
+			continue
+		}
+		from, to := barSpan(t, start, end)
+		bar := []rune(strings.Repeat(" ", timelineWidth))
+		for c := from; c < max(to, from+1); c++ {
+			bar[c] = '█'
+		}
+		fmt.Fprintf(&b, "%s │%s│ %s\n", pad(title(t.Title, 30), labelWidth), string(bar), t.Outcome)
+	}
+	fmt.Fprintf(&b, "%s  %s%*s\n", pad("", labelWidth), start.Local().Format("15:04"), timelineWidth-5, end.Local().Format("15:04"))
+	return b.String()
+}
+
+// barSpan returns the columns of the timeline a task's bar covers.
+func barSpan(t Task, start, end time.Time) (from, to int) {
+	span := end.Sub(start)
+	taskEnd := t.End
+	if taskEnd.IsZero() {
+		taskEnd = end
+	}
+	col := func(at time.Time) int {
+		c := int(float64(at.Sub(start)) / float64(span) * timelineWidth)
+		return min(max(c, 0), timelineWidth)
+	}
+	from, to = col(t.Start), col(taskEnd)
+	return min(from, timelineWidth-1), to
+}
+
+// outcomeSummary describes how many tasks ended each way, such as
+// "4 of 5 completed, 1 failed".
+func outcomeSummary(r *Report) string {
+	if len(r.Tasks) == 0 {
+		return "none"
+	}
+	parts := []string{fmt.Sprintf("%d of %d completed", r.Count(OutcomeCompleted), len(r.Tasks))}
+	for _, outcome := range []Outcome{OutcomeFailed, OutcomeUnfinished, OutcomeNotStarted} {
+		if n := r.Count(outcome); n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, outcome))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// failures returns the failed tasks.
+func failures(r *Report) []Task {
+	var failed []Task
+	for _, t := range r.Tasks {
+		if t.Outcome == OutcomeFailed {
+			failed = append(failed, t)
+		}
+	}
+	return failed
+}
+
+// taskDuration returns how long a task ran, "-" if it never started.
+func taskDuration(r *Report, t Task) string {
+	if t.Start.IsZero() {
+		return "-"
+	}
+	end := t.End
+	if end.IsZero() {
+		end = r.End()
+	}
+	return formatDuration(end.Sub(t.Start))
+}
+
+func formatDuration(d time.Duration) string {
+	return max(d, 0).Round(time.Second).String()
+}
+
+func groupLabel(group int) string {
+	if group == 0 {
+		return "-"
+	}
+	return fmt.Sprint(group)
+}
+
+func commitsLabel(commits int) string {
+	if commits < 0 {
+		return "-"
+	}
+	return fmt.Sprint(commits)
+}
+
+// pad pads s with spaces to width runes.
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-len([]rune(s)), 0))
+}
+
+// oneLine joins the lines of s with spaces.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// cell escapes s for a Markdown table cell.
+func cell(s string) string {
+	return strings.ReplaceAll(oneLine(s), "|", `\|`)
+}
@@ -0,0 +1,366 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DirName is the directory within .claudio that reports are written to.
+const DirName = "reports"
+
+// Dir returns the report directory of the repository at baseDir.
+func Dir(baseDir string) string {
+	return filepath.Join(baseDir, ".claudio", DirName)
+}
+
+// Outcome is how a task ended.
+type Outcome string
+
+const (
+	// OutcomeCompleted means the task finished its work.
+	OutcomeCompleted Outcome = "completed"
+	// OutcomeFailed means the task failed, stalled, or ran out of time.
+	OutcomeFailed Outcome = "failed"
+	// OutcomeUnfinished means the task was still running, waiting for
+	// input, paused, or interrupted when the report was generated.
+	OutcomeUnfinished Outcome = "unfinished"
+	// OutcomeNotStarted means no instance ever ran the task.
+	OutcomeNotStarted Outcome = "not started"
+)
+
+// Task is the outcome of one task: a planned task of an ultraplan, or an
+// instance of any other session.
+type Task struct {
+	ID         string
+	Title      string
+	Group      int // 1-based execution group, 0 outside a plan
+	InstanceID string
+	Branch     string
+	Outcome    Outcome
+	Reason     string // Why a failed task failed
+
+	// Commits is the number of commits on the task's branch, or -1 when
+	// it is unknown
+	Commits int
+
+	Usage Usage
+	Start time.Time // Zero if the task never started
+	End   time.Time // Zero if the task had not ended
+}
+
+// Usage is the tokens and cost of one or more instances.
+type Usage struct {
+	Instances    int
+	InputTokens  int64
+	OutputTokens int64
+	CacheRead    int64
+	CacheWrite   int64
+	Cost         float64
+}
+
+// Tokens returns the input and output tokens.
+func (u Usage) Tokens() int64 {
+	return u.InputTokens + u.OutputTokens
+}
+
+func (u *Usage) add(o Usage) {
+	u.Instances += o.Instances
+	u.InputTokens += o.InputTokens
+	u.OutputTokens += o.OutputTokens
+	u.CacheRead += o.CacheRead
+	u.CacheWrite += o.CacheWrite
+	u.Cost += o.Cost
+}
+
+// CostLine is the usage of the instances in one role of a session, such as
+// planning or consolidation.
+type CostLine struct {
+	Role string
+	Usage
+}
+
+// Report summarizes a session: what it set out to do, how each task went,
+// and what it cost.
+type Report struct {
+	SessionID string
+	Name      string
+
+	// Objective, PlanSummary, and Groups describe an ultraplan; they are
+	// empty for other sessions. Groups holds the task titles of each
+	// execution group.
+	Objective   string
+	PlanSummary string
+	Groups      [][]string
+	Phase       string
+	Error       string
+
+	Tasks  []Task
+	PRURLs []string
+
+	// Costs breaks Total down by role, in the order the roles run
+	Costs []CostLine
+	Total Usage
+
+	Started     time.Time
+	Finished    time.Time // When the last task ended; zero while any runs
+	GeneratedAt time.Time
+}
+
+// Count returns the number of tasks with outcome.
+func (r *Report) Count(outcome Outcome) int {
+	n := 0
+	for _, t := range r.Tasks {
+		if t.Outcome == outcome {
+			n++
+		}
+	}
+	return n
+}
+
+// Commits returns the commits of every task whose count is known.
+func (r *Report) Commits() int {
+	n := 0
+	for _, t := range r.Tasks {
+		n += max(t.Commits, 0)
+	}
+	return n
+}
+
+// End returns when the session ended, or when the report was generated if
+// a task was still running.
+func (r *Report) End() time.Time {
+	if r.Finished.IsZero() {
+		return r.GeneratedAt
+	}
+	return r.Finished
+}
+
+// Option configures Build.
+type Option func(*builder)
+
+// WithCommitCounter counts the commits of task branches that have no
+// verified count in the session, as ultraplan tasks do. Tasks whose count
+// fails are reported without one.
+func WithCommitCounter(count func(branch string) (int, error)) Option {
+	return func(b *builder) { b.countCommits = count }
+}
+
+// WithTime sets when the report is generated (default: now).
+func WithTime(t time.Time) Option {
+	return func(b *builder) { b.now = t }
+}
+
+type builder struct {
+	countCommits func(branch string) (int, error)
+	now          time.Time
+}
+
+// Build builds the report of the session saved as sessionJSON, the content
+// of a session.json file.
+func Build(sessionJSON []byte, opts ...Option) (*Report, error) {
+	b := builder{now: time.Now()}
+	for _, opt := range opts {
+		opt(&b)
+	}
+
+	var sess sessionData
+	if err := json.Unmarshal(sessionJSON, &sess); err != nil {
+		return nil, fmt.Errorf("failed to parse session: %w", err)
+	}
+
+	r := &Report{
+		SessionID:   sess.ID,
+		Name:        sess.Name,
+		Started:     sess.Created,
+		GeneratedAt: b.now,
+	}
+	up := sess.UltraPlan
+	if up != nil && up.Plan != nil {
+		b.planTasks(r, &sess)
+	} else {
+		b.instanceTasks(r, &sess)
+	}
+	if up != nil {
+		r.Objective = up.Objective
+		r.Phase = up.Phase
+		r.Error = up.Error
+		r.PRURLs = up.prURLs()
+	}
+	costs(r, &sess)
+
+	r.Finished = r.Started
+	for _, t := range r.Tasks {
+		if !t.Start.IsZero() && t.End.IsZero() {
+			r.Finished = time.Time{}
+			break
+		}
+		if t.End.After(r.Finished) {
+			r.Finished = t.End
+		}
+	}
+	if up != nil && up.CompletedAt != nil && !r.Finished.IsZero() && up.CompletedAt.After(r.Finished) {
+		r.Finished = *up.CompletedAt
+	}
+	return r, nil
+}
+
+// planTasks adds a task for each task of the session's plan, in execution
+// order.
+func (b *builder) planTasks(r *Report, sess *sessionData) {
+	up := sess.UltraPlan
+	r.PlanSummary = up.Plan.Summary
+
+	groupOf := make(map[string]int, len(up.Plan.Tasks))
+	titles := make(map[string]string, len(up.Plan.Tasks))
+	for _, task := range up.Plan.Tasks {
+		titles[task.ID] = task.Title
+	}
+	for i, group := range up.Plan.ExecutionOrder {
+		var names []string
+		for _, id := range group {
+			groupOf[id] = i + 1
+			names = append(names, titles[id])
+		}
+		r.Groups = append(r.Groups, names)
+	}
+
+	for _, pt := range up.Plan.Tasks {
+		task := Task{
+			ID:      pt.ID,
+			Title:   pt.Title,
+			Group:   groupOf[pt.ID],
+			Outcome: OutcomeNotStarted,
+			Commits: -1,
+		}
+		if inst := sess.instance(up.TaskToInstance[pt.ID]); inst != nil {
+			b.fromInstance(&task, inst)
+		}
+		if n, ok := up.TaskCommitCounts[pt.ID]; ok {
+			task.Commits = n
+		}
+
+		switch {
+		case slices.Contains(up.CompletedTasks, pt.ID):
+			task.Outcome, task.Reason = OutcomeCompleted, ""
+		case slices.Contains(up.FailedTasks, pt.ID):
+			task.Outcome = OutcomeFailed
+			if retry := up.TaskRetries[pt.ID]; retry != nil && retry.LastError != "" {
+				task.Reason = retry.LastError
+			} else if task.Reason == "" && task.Commits == 0 {
+				task.Reason = "produced no commits"
+			}
+		}
+		r.Tasks = append(r.Tasks, task)
+	}
+	slices.SortStableFunc(r.Tasks, func(a, b Task) int {
+		return cmpGroup(a.Group) - cmpGroup(b.Group)
+	})
+}
+
+// cmpGroup orders tasks outside any group after the grouped ones.
+func cmpGroup(group int) int {
+	if group == 0 {
+		return 1 << 30
+	}
+	return group
+}
+
+// instanceTasks adds a task for each instance of a session without a plan.
+func (b *builder) instanceTasks(r *Report, sess *sessionData) {
+	for _, inst := range sess.Instances {
+		task := Task{ID: inst.ID, Title: inst.name(), Commits: -1}
+		b.fromInstance(&task, inst)
+		r.Tasks = append(r.Tasks, task)
+	}
+}
+
+// fromInstance fills in what task's instance recorded: its outcome, usage,
+// and run time.
+func (b *builder) fromInstance(task *Task, inst *instanceData) {
+	task.InstanceID = inst.ID
+	task.Branch = inst.Branch
+	task.Outcome, task.Reason = outcomeOf(inst.Status)
+	if m := inst.Metrics; m != nil {
+		task.Usage = m.usage()
+		if m.StartTime != nil {
+			task.Start = *m.StartTime
+		}
+		if m.EndTime != nil && task.Outcome != OutcomeUnfinished {
+			task.End = *m.EndTime
+		}
+	}
+	if task.Branch != "" && b.countCommits != nil {
+		if n, err := b.countCommits(task.Branch); err == nil {
+			task.Commits = n
+		}
+	}
+}
+
+// outcomeOf maps an instance status to the outcome of its task.
+func outcomeOf(status string) (Outcome, string) {
+	switch status {
+	case "completed":
+		return OutcomeCompleted, ""
+	case "error":
+		return OutcomeFailed, "the instance exited with an error"
+	case "timeout":
+		return OutcomeFailed, "the instance exceeded its runtime limit"
+	case "stuck":
+		return OutcomeFailed, "the instance stopped responding"
+	case "pending", "preparing", "":
+		return OutcomeNotStarted, ""
+	default:
+		return OutcomeUnfinished, ""
+	}
+}
+
+// costs fills in the usage of every instance, by role.
+func costs(r *Report, sess *sessionData) {
+	roles := sess.roles()
+	byRole := make(map[string]*Usage)
+	for _, inst := range sess.Instances {
+		if inst.Metrics == nil {
+			continue
+		}
+		role := roles[inst.ID]
+		if role == "" {
+			role = roleTasks
+		}
+		if byRole[role] == nil {
+			byRole[role] = &Usage{}
+		}
+		u := inst.Metrics.usage()
+		byRole[role].add(u)
+		r.Total.add(u)
+	}
+	for _, role := range roleOrder {
+		if u := byRole[role]; u != nil {
+			r.Costs = append(r.Costs, CostLine{Role: role, Usage: *u})
+		}
+	}
+}
+
+// Roles of the instances of a session, in the order they run.
+const (
+	rolePlanning      = "Planning"
+	roleTasks         = "Tasks"
+	roleSynthesis     = "Synthesis"
+	roleRevision      = "Revision"
+	roleConsolidation = "Consolidation"
+)
+
+var roleOrder = []string{rolePlanning, roleTasks, roleSynthesis, roleRevision, roleConsolidation}
+
+// title returns the first line of s, shortened to n runes.
+func title(s string, n int) string {
+	s, _, _ = strings.Cut(strings.TrimSpace(s), "\n")
+	s = strings.TrimSpace(s)
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s
+}
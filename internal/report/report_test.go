@@ -0,0 +1,243 @@
+package report
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ultraplanSession is a saved ultraplan: three tasks in two groups, one
+// completed, one failed after a retry, and one never started, plus a
+// planner and a consolidator.
+const ultraplanSession = `{
+  "id": "sess-1",
+  "name": "auth",
+  "created": "2026-01-02T10:00:00Z",
+  "instances": [
+    {"id": "plan", "task": "Plan it", "status": "completed",
+     "metrics": {"input_tokens": 1000, "output_tokens": 500, "cost": 0.5,
+                 "start_time": "2026-01-02T10:00:00Z", "end_time": "2026-01-02T10:05:00Z"}},
+    {"id": "i1", "task": "Add login", "branch": "claudio/login", "status": "completed",
+     "metrics": {"input_tokens": 2000, "output_tokens": 1000, "cache_read": 300, "cost": 1.25,
+                 "start_time": "2026-01-02T10:05:00Z", "end_time": "2026-01-02T10:25:00Z"}},
+    {"id": "i2", "task": "Add logout", "branch": "claudio/logout", "status": "completed",
+     "metrics": {"input_tokens": 100, "output_tokens": 50, "cost": 0.1,
+                 "start_time": "2026-01-02T10:05:00Z", "end_time": "2026-01-02T10:15:00Z"}},
+    {"id": "cons", "task": "Consolidate", "status": "completed",
+     "metrics": {"input_tokens": 10, "output_tokens": 5, "cost": 0.05,
+                 "start_time": "2026-01-02T10:30:00Z", "end_time": "2026-01-02T10:40:00Z"}}
+  ],
+  "ultra_plan": {
+    "objective": "Add authentication",
+    "phase": "complete",
+    "coordinator_id": "plan",
+    "consolidation_id": "cons",
+    "plan": {
+      "summary": "Login first, then the docs.",
+      "tasks": [
+        {"id": "t1", "title": "Add login"},
+        {"id": "t2", "title": "Add logout"},
+        {"id": "t3", "title": "Document | auth"}
+      ],
+      "execution_order": [["t1", "t2"], ["t3"]]
+    },
+    "task_to_instance": {"t1": "i1", "t2": "i2"},
+    "completed_tasks": ["t1"],
+    "failed_tasks": ["t2"],
+    "task_commit_counts": {"t1": 3, "t2": 0},
+    "task_retries": {"t2": {"task_id": "t2", "last_error": "no commits after 2 attempts"}},
+    "completed_at": "2026-01-02T10:40:00Z",
+    "pr_urls": ["https://github.com/o/r/pull/1"],
+    "consolidation": {"pr_urls": ["https://github.com/o/r/pull/1", "https://github.com/o/r/pull/2"]}
+  }
+}`
+
+var generated = time.Date(2026, 1, 2, 11, 0, 0, 0, time.UTC)
+
+func TestBuild_Ultraplan(t *testing.T) {
+	r, err := Build([]byte(ultraplanSession), WithTime(generated))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Objective != "Add authentication" || r.PlanSummary == "" || len(r.Groups) != 2 {
+		t.Errorf("plan = %q, %q, %v", r.Objective, r.PlanSummary, r.Groups)
+	}
+	if len(r.Tasks) != 3 {
+		t.Fatalf("got %d tasks, want the 3 planned ones", len(r.Tasks))
+	}
+	t1, t2, t3 := r.Tasks[0], r.Tasks[1], r.Tasks[2]
+	if t1.Outcome != OutcomeCompleted || t1.Commits != 3 || t1.Group != 1 || t1.Usage.Cost != 1.25 {
+		t.Errorf("t1 = %+v", t1)
+	}
+	if t2.Outcome != OutcomeFailed || t2.Reason != "no commits after 2 attempts" {
+		t.Errorf("t2 = %+v, want failed with the retry error", t2)
+	}
+	if t3.Outcome != OutcomeNotStarted || t3.Commits != -1 || t3.Group != 2 {
+		t.Errorf("t3 = %+v, want not started", t3)
+	}
+
+	if len(r.PRURLs) != 2 {
+		t.Errorf("PRURLs = %v, want both pull requests once", r.PRURLs)
+	}
+	if got := r.Commits(); got != 3 {
+		t.Errorf("Commits() = %d, want 3", got)
+	}
+	if !r.Finished.Equal(time.Date(2026, 1, 2, 10, 40, 0, 0, time.UTC)) {
+		t.Errorf("Finished = %v, want the ultraplan's completion", r.Finished)
+	}
+
+	var roles []string
+	for _, line := range r.Costs {
+		roles = append(roles, line.Role)
+	}
+	if strings.Join(roles, ",") != "Planning,Tasks,Consolidation" {
+		t.Errorf("cost roles = %v", roles)
+	}
+	if r.Total.Instances != 4 || r.Total.InputTokens != 3110 {
+		t.Errorf("total = %+v, want all four instances", r.Total)
+	}
+}
+
+func TestBuild_Instances(t *testing.T) {
+	session := `{
+	  "id": "sess-2",
+	  "created": "2026-01-02T10:00:00Z",
+	  "instances": [
+	    {"id": "a", "task": "Fix the flaky test\nIt fails on CI", "branch": "claudio/a", "status": "completed",
+	     "metrics": {"cost": 0.5, "start_time": "2026-01-02T10:00:00Z", "end_time": "2026-01-02T10:10:00Z"}},
+	    {"id": "b", "task": "Refactor", "display_name": "Refactor config", "branch": "claudio/b", "status": "stuck",
+	     "metrics": {"cost": 0.25, "start_time": "2026-01-02T10:00:00Z"}}
+	  ]
+	}`
+	count := func(branch string) (int, error) {
+		if branch == "claudio/a" {
+			return 2, nil
+		}
+		return 0, errors.New("no such branch")
+	}
+	r, err := Build([]byte(session), WithTime(generated), WithCommitCounter(count))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Tasks) != 2 {
+		t.Fatalf("got %d tasks, want one per instance", len(r.Tasks))
+	}
+	if a := r.Tasks[0]; a.Title != "Fix the flaky test" || a.Commits != 2 || a.Outcome != OutcomeCompleted {
+		t.Errorf("a = %+v", a)
+	}
+	if b := r.Tasks[1]; b.Title != "Refactor config" || b.Commits != -1 || b.Outcome != OutcomeFailed || b.Reason == "" {
+		t.Errorf("b = %+v, want failed without a commit count", b)
+	}
+	if len(r.Costs) != 1 || r.Costs[0].Role != "Tasks" {
+		t.Errorf("costs = %+v, want every instance under Tasks", r.Costs)
+	}
+}
+
+func TestBuild_InvalidSession(t *testing.T) {
+	if _, err := Build([]byte("{")); err == nil {
+		t.Error("expected an error for a truncated session")
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	r, err := Build([]byte(ultraplanSession), WithTime(generated))
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := Markdown(r)
+	for _, want := range []string{
+		"# Session Report: auth",
+		"- **Tasks:** 1 of 3 completed, 1 failed, 1 not started",
+		"## Objective\n\nAdd authentication",
+		"1. Add login; Add logout",
+		`| Document \| auth | 2 | not started | - |`,
+		"- **Add logout:** no commits after 2 attempts",
+		"- https://github.com/o/r/pull/2",
+		"| **Total** | 4 |",
+		"## Timeline",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("report missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	r := &Report{
+		Started:  start,
+		Finished: start.Add(40 * time.Minute),
+		Tasks: []Task{
+			{Title: "first half", Start: start, End: start.Add(20 * time.Minute), Outcome: OutcomeCompleted},
+			{Title: "never ran", Outcome: OutcomeNotStarted},
+			{Title: "second half", Start: start.Add(20 * time.Minute), End: start.Add(40 * time.Minute), Outcome: OutcomeFailed},
+		},
+	}
+	lines := strings.Split(timeline(r), "\n")
+	if len(lines) != 4 { // Two bars, the axis, and the trailing newline
+		t.Fatalf("timeline = %q", lines)
+	}
+	half := strings.Repeat("█", timelineWidth/2)
+	empty := strings.Repeat(" ", timelineWidth/2)
+	if want := "first half  │" + half + empty + "│ completed"; lines[0] != want {
+		t.Errorf("first bar = %q, want %q", lines[0], want)
+	}
+	if want := "second half │" + empty + half + "│ failed"; lines[1] != want {
+		t.Errorf("second bar = %q, want %q", lines[1], want)
+	}
+}
+
+func TestHTML(t *testing.T) {
+	r, err := Build([]byte(ultraplanSession), WithTime(generated))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Objective = "<script>alert(1)</script>"
+	page, err := HTML(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"<title>Session Report: auth</title>",
+		`<td class="failed">failed</td>`,
+		`<a href="https://github.com/o/r/pull/1">`,
+		`class="bar completed" style="left: 12.50%; width: 50.00%"`,
+		"&lt;script&gt;",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page missing %q:\n%s", want, page)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	r, err := Build([]byte(ultraplanSession), WithTime(generated))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(t.TempDir(), "reports")
+
+	paths, err := Write(dir, r, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 1 || paths[0] != filepath.Join(dir, "sess-1.md") {
+		t.Errorf("paths = %v, want only the Markdown report", paths)
+	}
+
+	paths, err = Write(dir, r, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("paths = %v, want Markdown and HTML", paths)
+	}
+	data, err := os.ReadFile(paths[1])
+	if err != nil || !strings.HasPrefix(string(data), "<!DOCTYPE html>") {
+		t.Errorf("HTML report = %.40q, %v", data, err)
+	}
+}
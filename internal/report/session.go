@@ -0,0 +1,145 @@
+package report
+
+import (
+	"slices"
+	"time"
+)
+
+// sessionData is the part of a saved session a report is built from. It
+// mirrors orchestrator.Session and orchestrator.UltraPlanSession, which
+// this package cannot import.
+type sessionData struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Created   time.Time       `json:"created"`
+	Instances []*instanceData `json:"instances"`
+	UltraPlan *ultraPlanData  `json:"ultra_plan"`
+}
+
+type instanceData struct {
+	ID          string       `json:"id"`
+	Task        string       `json:"task"`
+	DisplayName string       `json:"display_name"`
+	Branch      string       `json:"branch"`
+	Status      string       `json:"status"`
+	Metrics     *metricsData `json:"metrics"`
+}
+
+type metricsData struct {
+	InputTokens  int64      `json:"input_tokens"`
+	OutputTokens int64      `json:"output_tokens"`
+	CacheRead    int64      `json:"cache_read"`
+	CacheWrite   int64      `json:"cache_write"`
+	Cost         float64    `json:"cost"`
+	StartTime    *time.Time `json:"start_time"`
+	EndTime      *time.Time `json:"end_time"`
+}
+
+type ultraPlanData struct {
+	Objective string `json:"objective"`
+	Phase     string `json:"phase"`
+	Error     string `json:"error"`
+	Plan      *struct {
+		Summary string `json:"summary"`
+		Tasks   []struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		} `json:"tasks"`
+		ExecutionOrder [][]string `json:"execution_order"`
+	} `json:"plan"`
+
+	TaskToInstance   map[string]string `json:"task_to_instance"`
+	CompletedTasks   []string          `json:"completed_tasks"`
+	FailedTasks      []string          `json:"failed_tasks"`
+	TaskCommitCounts map[string]int    `json:"task_commit_counts"`
+	TaskRetries      map[string]*struct {
+		LastError string `json:"last_error"`
+	} `json:"task_retries"`
+	CompletedAt *time.Time `json:"completed_at"`
+
+	PRURLs        []string `json:"pr_urls"`
+	Consolidation *struct {
+		PRURLs []string `json:"pr_urls"`
+	} `json:"consolidation"`
+
+	// Instances of the ultraplan's other roles
+	CoordinatorID        string   `json:"coordinator_id"`
+	PlanCoordinatorIDs   []string `json:"plan_coordinator_ids"`
+	PlanManagerID        string   `json:"plan_manager_id"`
+	SynthesisID          string   `json:"synthesis_id"`
+	RevisionID           string   `json:"revision_id"`
+	ConsolidationID      string   `json:"consolidation_id"`
+	GroupConsolidatorIDs []string `json:"group_consolidator_ids"`
+}
+
+// instance returns the instance with id, or nil.
+func (s *sessionData) instance(id string) *instanceData {
+	if id == "" {
+		return nil
+	}
+	for _, inst := range s.Instances {
+		if inst.ID == id {
+			return inst
+		}
+	}
+	return nil
+}
+
+// roles maps the instances of an ultraplan's planning, synthesis,
+// revision, and consolidation to their role. Every other instance runs a
+// task.
+func (s *sessionData) roles() map[string]string {
+	roles := make(map[string]string)
+	up := s.UltraPlan
+	if up == nil {
+		return roles
+	}
+	set := func(role string, ids ...string) {
+		for _, id := range ids {
+			if id != "" {
+				roles[id] = role
+			}
+		}
+	}
+	set(rolePlanning, up.CoordinatorID, up.PlanManagerID)
+	set(rolePlanning, up.PlanCoordinatorIDs...)
+	set(roleSynthesis, up.SynthesisID)
+	set(roleRevision, up.RevisionID)
+	set(roleConsolidation, up.ConsolidationID)
+	set(roleConsolidation, up.GroupConsolidatorIDs...)
+	return roles
+}
+
+// prURLs returns the ultraplan's pull requests, wherever consolidation
+// recorded them.
+func (u *ultraPlanData) prURLs() []string {
+	urls := append([]string(nil), u.PRURLs...)
+	if u.Consolidation != nil {
+		for _, url := range u.Consolidation.PRURLs {
+			if !slices.Contains(urls, url) {
+				urls = append(urls, url)
+			}
+		}
+	}
+	return urls
+}
+
+// name returns the instance's display name, or the first line of its
+// task.
+func (i *instanceData) name() string {
+	if i.DisplayName != "" {
+		return i.DisplayName
+	}
+	return title(i.Task, 80)
+}
+
+func (m *metricsData) usage() Usage {
+	return Usage{
+		Instances:    1,
+		InputTokens:  m.InputTokens,
+		OutputTokens: m.OutputTokens,
+		CacheRead:    m.CacheRead,
+		CacheWrite:   m.CacheWrite,
+		Cost:         m.Cost,
+	}
+}
@@ -0,0 +1,55 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Path returns the file a session's report is written to in dir, with ext
+// ".md" or ".html".
+func Path(dir, sessionID, ext string) string {
+	return filepath.Join(dir, sessionID+ext)
+}
+
+// Write writes r to dir as Markdown and, with withHTML set, as HTML,
+// replacing any earlier report of the session. It returns the paths
+// written.
+func Write(dir string, r *Report, withHTML bool) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("write report: %w", err)
+	}
+
+	path := Path(dir, r.SessionID, ".md")
+	if err := writeFile(path, Markdown(r)); err != nil {
+		return nil, err
+	}
+	paths := []string{path}
+	if !withHTML {
+		return paths, nil
+	}
+
+	page, err := HTML(r)
+	if err != nil {
+		return paths, err
+	}
+	path = Path(dir, r.SessionID, ".html")
+	if err := writeFile(path, page); err != nil {
+		return paths, err
+	}
+	return append(paths, path), nil
+}
+
+// writeFile writes content to path atomically, so a reader never sees a
+// partial report.
+func writeFile(path, content string) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0644); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp) // best-effort cleanup
+		return fmt.Errorf("write report: %w", err)
+	}
+	return nil
+}
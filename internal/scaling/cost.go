@@ -0,0 +1,100 @@
+package scaling
+
+import (
+	"sync"
+	"time"
+)
+
+// Default cost-awareness values.
+const (
+	defaultBurnRateWindow = 10 * time.Minute
+	defaultCostHorizon    = 15 * time.Minute
+
+	// minBurnRateSpan is the least time the samples must cover before a
+	// burn rate is reported. Over shorter spans a single metrics update
+	// would extrapolate to an absurd hourly rate.
+	minBurnRateSpan = time.Minute
+)
+
+// CostStatus is the spend the policy weighs alongside queue depth.
+type CostStatus struct {
+	// Spent is the session's total cost so far (USD).
+	Spent float64
+
+	// BurnRate is the recent spend velocity (USD per hour). Zero until
+	// enough metrics have been seen to measure it.
+	BurnRate float64
+}
+
+// costSample is the session total at a point in time.
+type costSample struct {
+	at    time.Time
+	total float64
+}
+
+// CostTracker derives session spend and burn rate from cumulative
+// per-instance cost updates, such as metrics.updated events.
+// It is safe for concurrent use.
+type CostTracker struct {
+	mu       sync.Mutex
+	window   time.Duration
+	costs    map[string]float64 // instanceID -> latest cumulative cost
+	total    float64
+	samples  []costSample // Oldest first; the first may predate the window
+	lastSeen time.Time
+}
+
+// NewCostTracker creates a CostTracker that measures burn rate over the
+// given window. A non-positive window uses the default of 10 minutes.
+func NewCostTracker(window time.Duration) *CostTracker {
+	if window <= 0 {
+		window = defaultBurnRateWindow
+	}
+	return &CostTracker{
+		window: window,
+		costs:  make(map[string]float64),
+	}
+}
+
+// Observe records an instance's cumulative cost (USD) at time at.
+func (t *CostTracker) Observe(instanceID string, cost float64, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.total += cost - t.costs[instanceID]
+	t.costs[instanceID] = cost
+	if at.Before(t.lastSeen) {
+		at = t.lastSeen // Keep samples ordered if events arrive out of order
+	}
+	t.lastSeen = at
+	t.samples = append(t.samples, costSample{at: at, total: t.total})
+
+	// Drop samples older than the window, keeping the newest of them as the
+	// baseline the rate is measured from.
+	cutoff := at.Add(-t.window)
+	drop := 0
+	for drop+1 < len(t.samples) && !t.samples[drop+1].at.After(cutoff) {
+		drop++
+	}
+	t.samples = t.samples[drop:]
+}
+
+// Status returns the current spend and burn rate.
+func (t *CostTracker) Status() CostStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s := CostStatus{Spent: t.total}
+	if len(t.samples) < 2 {
+		return s
+	}
+	first, last := t.samples[0], t.samples[len(t.samples)-1]
+	span := last.at.Sub(first.at)
+	if span < minBurnRateSpan {
+		return s
+	}
+	if delta := last.total - first.total; delta > 0 {
+		s.BurnRate = delta / span.Hours()
+	}
+	return s
+}
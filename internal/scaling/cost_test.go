@@ -0,0 +1,60 @@
+package scaling
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCostTracker(t *testing.T) {
+	tr := NewCostTracker(10 * time.Minute)
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if s := tr.Status(); s != (CostStatus{}) {
+		t.Errorf("empty Status() = %+v, want zero", s)
+	}
+
+	tr.Observe("a", 1, start)
+	tr.Observe("b", 2, start.Add(30*time.Second))
+	if s := tr.Status(); s.Spent != 3 || s.BurnRate != 0 {
+		t.Errorf("Status() after 30s = %+v, want spent 3 and no rate yet", s)
+	}
+
+	// Costs are cumulative per instance: a's update replaces its 1.
+	tr.Observe("a", 4, start.Add(6*time.Minute))
+	s := tr.Status()
+	if s.Spent != 6 {
+		t.Errorf("Spent = %v, want 6", s.Spent)
+	}
+	if want := 5.0 / 0.1; math.Abs(s.BurnRate-want) > 1e-9 {
+		t.Errorf("BurnRate = %v, want %v ($5 over 6m)", s.BurnRate, want)
+	}
+}
+
+func TestCostTracker_Window(t *testing.T) {
+	tr := NewCostTracker(10 * time.Minute)
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tr.Observe("a", 100, start) // An expensive start, then a quiet spell
+	tr.Observe("a", 100, start.Add(5*time.Minute))
+	tr.Observe("a", 101, start.Add(20*time.Minute))
+	tr.Observe("a", 102, start.Add(25*time.Minute))
+
+	// The rate is measured from the last sample before the window (5m),
+	// so the expensive start no longer counts.
+	if s := tr.Status(); math.Abs(s.BurnRate-6) > 1e-9 {
+		t.Errorf("BurnRate = %v, want 6 ($2 over 20m)", s.BurnRate)
+	}
+}
+
+func TestCostTracker_DecreasingCost(t *testing.T) {
+	tr := NewCostTracker(0)
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tr.Observe("a", 5, start)
+	tr.Observe("a", 1, start.Add(5*time.Minute)) // Instance restarted
+
+	if s := tr.Status(); s.Spent != 1 || s.BurnRate != 0 {
+		t.Errorf("Status() = %+v, want spent 1 and no negative rate", s)
+	}
+}
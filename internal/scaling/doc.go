@@ -10,6 +10,17 @@
 //   - [Policy]: Defines scaling rules (thresholds, cooldown, instance limits)
 //   - [Monitor]: Watches queue depth events on the event bus and applies the policy
 //   - [Decision]: The output of policy evaluation — scale up, scale down, or hold
+//   - [CostTracker]: Session spend and burn rate from metrics events
+//
+// # Cost Awareness
+//
+// With [WithBudgetCeiling] or [WithBurnRateLimit], the policy also weighs
+// spend. The Monitor feeds metrics.updated events to a CostTracker and
+// evaluates with [Policy.EvaluateWithCost]. A scale-up is limited to the
+// instances whose projected cost over the cost horizon (current spend plus
+// the per-instance burn rate times instance count) fits the ceiling, and
+// vetoed if none fit. When the burn rate exceeds the limit, the policy
+// scales down by one even if tasks are pending.
 //
 // # Usage
 //
@@ -19,6 +30,8 @@
 //	    scaling.WithScaleUpThreshold(2),
 //	    scaling.WithScaleDownThreshold(1),
 //	    scaling.WithCooldownPeriod(30 * time.Second),
+//	    scaling.WithBudgetCeiling(25),  // USD
+//	    scaling.WithBurnRateLimit(10),  // USD per hour
 //	)
 //
 //	monitor := scaling.NewMonitor(bus, policy)
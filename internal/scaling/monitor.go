@@ -9,14 +9,18 @@ import (
 )
 
 // Monitor watches queue depth events on the event bus and applies a scaling
-// policy to recommend instance count changes.
+// policy to recommend instance count changes. It also tracks the session's
+// spend from metrics events, for policies with a budget ceiling or burn rate
+// limit.
 type Monitor struct {
-	mu       sync.Mutex
-	bus      *event.Bus
-	policy   *Policy
-	handlers []func(Decision)
-	subID    string
-	cancel   context.CancelFunc
+	mu           sync.Mutex
+	bus          *event.Bus
+	policy       *Policy
+	costs        *CostTracker
+	handlers     []func(Decision)
+	subID        string
+	metricsSubID string
+	cancel       context.CancelFunc
 
 	// currentInstances is maintained by the monitor. The caller is expected
 	// to update it via SetCurrentInstances when instances actually change.
@@ -26,9 +30,13 @@ type Monitor struct {
 // NewMonitor creates a Monitor that evaluates the given policy whenever
 // a QueueDepthChangedEvent is received on the bus.
 func NewMonitor(bus *event.Bus, policy *Policy, initialInstances int) *Monitor {
+	policy.mu.Lock()
+	window := policy.burnRateWindow
+	policy.mu.Unlock()
 	return &Monitor{
 		bus:              bus,
 		policy:           policy,
+		costs:            NewCostTracker(window),
 		currentInstances: initialInstances,
 	}
 }
@@ -50,11 +58,22 @@ func (m *Monitor) SetCurrentInstances(n int) {
 	m.currentInstances = n
 }
 
-// Start subscribes to queue depth events and begins evaluating the policy.
-// It blocks until the context is cancelled or Stop is called.
+// CostStatus returns the session spend and burn rate seen so far.
+func (m *Monitor) CostStatus() CostStatus {
+	return m.costs.Status()
+}
+
+// Start subscribes to queue depth and metrics events and begins evaluating
+// the policy. It blocks until the context is cancelled or Stop is called.
 func (m *Monitor) Start(ctx context.Context) {
 	ctx, cancel := context.WithCancel(ctx)
 
+	metricsSubID := m.bus.Subscribe("metrics.updated", func(e event.Event) {
+		if me, ok := e.(event.MetricsUpdateEvent); ok {
+			m.costs.Observe(me.InstanceID, me.Cost, me.Timestamp())
+		}
+	})
+
 	subID := m.bus.Subscribe("queue.depth_changed", func(e event.Event) {
 		de, ok := e.(event.QueueDepthChangedEvent)
 		if !ok {
@@ -75,7 +94,7 @@ func (m *Monitor) Start(ctx context.Context) {
 		copy(handlers, m.handlers)
 		m.mu.Unlock()
 
-		decision := m.policy.Evaluate(status, current)
+		decision := m.policy.EvaluateWithCost(status, current, m.costs.Status())
 		if decision.Action != ActionNone {
 			m.bus.Publish(event.NewScalingDecisionEvent(
 				string(decision.Action), decision.Delta, decision.Reason, current,
@@ -88,6 +107,7 @@ func (m *Monitor) Start(ctx context.Context) {
 
 	m.mu.Lock()
 	m.subID = subID
+	m.metricsSubID = metricsSubID
 	m.cancel = cancel
 	m.mu.Unlock()
 
@@ -99,11 +119,15 @@ func (m *Monitor) Stop() {
 	m.mu.Lock()
 	cancel := m.cancel
 	subID := m.subID
+	metricsSubID := m.metricsSubID
 	m.mu.Unlock()
 
 	if subID != "" {
 		m.bus.Unsubscribe(subID)
 	}
+	if metricsSubID != "" {
+		m.bus.Unsubscribe(metricsSubID)
+	}
 	if cancel != nil {
 		cancel()
 	}
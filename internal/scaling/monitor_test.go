@@ -367,3 +367,44 @@ func TestMonitor_Stop_BeforeStart(t *testing.T) {
 
 // Compile-time interface check.
 var _ event.Event = event.ScalingDecisionEvent{}
+
+func TestMonitor_CostAwareVeto(t *testing.T) {
+	bus := event.NewBus()
+	policy := NewPolicy(
+		WithCooldownPeriod(0),
+		WithMaxInstances(10),
+		WithBudgetCeiling(5),
+	)
+	m := NewMonitor(bus, policy, 2)
+
+	var mu sync.Mutex
+	var decisions []Decision
+	m.OnDecision(func(d Decision) {
+		mu.Lock()
+		defer mu.Unlock()
+		decisions = append(decisions, d)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go m.Start(ctx)
+	time.Sleep(10 * time.Millisecond)
+
+	// The session has already spent its budget.
+	bus.Publish(event.NewMetricsUpdateEvent("inst-1", 1000, 500, 0, 0, 3.0, 4))
+	bus.Publish(event.NewMetricsUpdateEvent("inst-2", 1000, 500, 0, 0, 2.5, 4))
+	time.Sleep(10 * time.Millisecond)
+
+	if got := m.CostStatus().Spent; got != 5.5 {
+		t.Errorf("CostStatus().Spent = %v, want 5.5", got)
+	}
+
+	bus.Publish(event.NewQueueDepthChangedEvent(5, 0, 1, 0, 0, 10))
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(decisions) != 0 {
+		t.Errorf("decisions = %+v, want scale-up vetoed", decisions)
+	}
+}
@@ -48,6 +48,31 @@ func WithCooldownPeriod(d time.Duration) Option {
 	return func(p *Policy) { p.cooldownPeriod = d }
 }
 
+// WithBudgetCeiling makes scale-up cost-aware: a scale-up is reduced, or
+// vetoed, when the session's projected cost over the cost horizon would
+// exceed ceiling (USD). Zero disables the check.
+func WithBudgetCeiling(ceiling float64) Option {
+	return func(p *Policy) { p.budgetCeiling = ceiling }
+}
+
+// WithBurnRateLimit sets the spend velocity (USD per hour) above which the
+// policy recommends scaling down, even with pending work. Zero disables the
+// check.
+func WithBurnRateLimit(usdPerHour float64) Option {
+	return func(p *Policy) { p.burnRateLimit = usdPerHour }
+}
+
+// WithCostHorizon sets how far ahead the budget check projects the burn
+// rate.
+func WithCostHorizon(d time.Duration) Option {
+	return func(p *Policy) { p.costHorizon = d }
+}
+
+// WithBurnRateWindow sets the period a Monitor measures the burn rate over.
+func WithBurnRateWindow(d time.Duration) Option {
+	return func(p *Policy) { p.burnRateWindow = d }
+}
+
 // Policy defines the rules for elastic scaling decisions.
 // It is safe for concurrent use.
 type Policy struct {
@@ -58,6 +83,12 @@ type Policy struct {
 	scaleDownThreshold int
 	cooldownPeriod     time.Duration
 	lastDecisionTime   time.Time
+
+	// Cost awareness; disabled while budgetCeiling and burnRateLimit are 0.
+	budgetCeiling  float64
+	burnRateLimit  float64
+	costHorizon    time.Duration
+	burnRateWindow time.Duration
 }
 
 // NewPolicy creates a Policy with the given options.
@@ -69,6 +100,8 @@ func NewPolicy(opts ...Option) *Policy {
 		scaleUpThreshold:   defaultScaleUpThreshold,
 		scaleDownThreshold: defaultScaleDownThreshold,
 		cooldownPeriod:     defaultCooldownPeriod,
+		costHorizon:        defaultCostHorizon,
+		burnRateWindow:     defaultBurnRateWindow,
 	}
 	for _, opt := range opts {
 		opt(p)
@@ -78,7 +111,16 @@ func NewPolicy(opts ...Option) *Policy {
 
 // Evaluate inspects the queue status and current instance count, returning
 // a scaling decision. The cooldown period prevents rapid scaling thrash.
+// It ignores cost; see EvaluateWithCost.
 func (p *Policy) Evaluate(status taskqueue.QueueStatus, currentInstances int) Decision {
+	return p.EvaluateWithCost(status, currentInstances, CostStatus{})
+}
+
+// EvaluateWithCost is Evaluate with the session's spend taken into account.
+// When the burn rate exceeds the burn rate limit, it scales down by one
+// before considering queue depth. A scale-up is limited to the instances
+// whose projected cost fits the budget ceiling, and vetoed if none fit.
+func (p *Policy) EvaluateWithCost(status taskqueue.QueueStatus, currentInstances int, cost CostStatus) Decision {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -92,6 +134,16 @@ func (p *Policy) Evaluate(status taskqueue.QueueStatus, currentInstances int) De
 		}
 	}
 
+	// Spend velocity too high: shed an instance before adding any
+	if p.burnRateLimit > 0 && cost.BurnRate > p.burnRateLimit && currentInstances > p.minInstances {
+		p.lastDecisionTime = now
+		return Decision{
+			Action: ActionScaleDown,
+			Delta:  -1,
+			Reason: fmt.Sprintf("burn rate $%.2f/h exceeds limit $%.2f/h", cost.BurnRate, p.burnRateLimit),
+		}
+	}
+
 	// Scale up: pending tasks exceed threshold and there's more work than workers
 	if status.Pending > p.scaleUpThreshold && status.Pending > status.Running && currentInstances < p.maxInstances {
 		delta := status.Pending - status.Running
@@ -99,12 +151,24 @@ func (p *Policy) Evaluate(status taskqueue.QueueStatus, currentInstances int) De
 		if currentInstances+delta > p.maxInstances {
 			delta = p.maxInstances - currentInstances
 		}
+		reason := fmt.Sprintf("%d pending tasks with %d running (threshold: %d)", status.Pending, status.Running, p.scaleUpThreshold)
+		if affordable := p.affordableDelta(delta, currentInstances, cost); affordable < delta {
+			if affordable == 0 {
+				return Decision{
+					Action: ActionNone,
+					Reason: fmt.Sprintf("scale-up vetoed: projected cost $%.2f would exceed budget $%.2f",
+						p.projectedCost(currentInstances+delta, currentInstances, cost), p.budgetCeiling),
+				}
+			}
+			delta = affordable
+			reason += fmt.Sprintf(", limited by budget $%.2f", p.budgetCeiling)
+		}
 		if delta > 0 {
 			p.lastDecisionTime = now
 			return Decision{
 				Action: ActionScaleUp,
 				Delta:  delta,
-				Reason: fmt.Sprintf("%d pending tasks with %d running (threshold: %d)", status.Pending, status.Running, p.scaleUpThreshold),
+				Reason: reason,
 			}
 		}
 	}
@@ -129,3 +193,29 @@ func (p *Policy) Evaluate(status taskqueue.QueueStatus, currentInstances int) De
 		Reason: "no scaling needed",
 	}
 }
+
+// affordableDelta returns the largest scale-up, at most delta, whose
+// projected cost stays within the budget ceiling. Nothing is affordable once
+// the ceiling has been reached. Must be called with p.mu held.
+func (p *Policy) affordableDelta(delta, currentInstances int, cost CostStatus) int {
+	if p.budgetCeiling <= 0 {
+		return delta
+	}
+	if cost.Spent >= p.budgetCeiling {
+		return 0
+	}
+	for ; delta > 0; delta-- {
+		if p.projectedCost(currentInstances+delta, currentInstances, cost) <= p.budgetCeiling {
+			break
+		}
+	}
+	return delta
+}
+
+// projectedCost estimates the session's spend at the end of the cost
+// horizon if it runs n instances, assuming each spends at the current
+// per-instance burn rate. Must be called with p.mu held.
+func (p *Policy) projectedCost(n, currentInstances int, cost CostStatus) float64 {
+	perInstance := cost.BurnRate / float64(max(currentInstances, 1))
+	return cost.Spent + perInstance*float64(n)*p.costHorizon.Hours()
+}
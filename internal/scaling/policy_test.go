@@ -299,3 +299,113 @@ func TestAction_String(t *testing.T) {
 		}
 	}
 }
+
+func TestPolicy_EvaluateWithCost(t *testing.T) {
+	busy := taskqueue.QueueStatus{Pending: 6, Running: 2, Total: 10}
+
+	tests := []struct {
+		name             string
+		status           taskqueue.QueueStatus
+		currentInstances int
+		cost             CostStatus
+		options          []Option
+		wantAction       Action
+		wantDelta        int
+	}{
+		{
+			name:             "no cost options behaves like Evaluate",
+			status:           busy,
+			currentInstances: 2,
+			cost:             CostStatus{Spent: 100, BurnRate: 1000},
+			wantAction:       ActionScaleUp,
+			wantDelta:        4,
+		},
+		{
+			name:             "scale up within budget",
+			status:           busy,
+			currentInstances: 2,
+			// $2/h per instance, 6 instances for 1h: 10 + 12 = 22
+			cost:       CostStatus{Spent: 10, BurnRate: 4},
+			options:    []Option{WithBudgetCeiling(25), WithCostHorizon(time.Hour)},
+			wantAction: ActionScaleUp,
+			wantDelta:  4,
+		},
+		{
+			name:             "scale up limited by budget",
+			status:           busy,
+			currentInstances: 2,
+			// 10 + 2*n <= 18 allows n = 4, so delta 2
+			cost:       CostStatus{Spent: 10, BurnRate: 4},
+			options:    []Option{WithBudgetCeiling(18), WithCostHorizon(time.Hour)},
+			wantAction: ActionScaleUp,
+			wantDelta:  2,
+		},
+		{
+			name:             "scale up vetoed by budget",
+			status:           busy,
+			currentInstances: 2,
+			cost:             CostStatus{Spent: 10, BurnRate: 4},
+			options:          []Option{WithBudgetCeiling(15), WithCostHorizon(time.Hour)},
+			wantAction:       ActionNone,
+		},
+		{
+			name:             "scale up vetoed when budget already spent",
+			status:           busy,
+			currentInstances: 2,
+			cost:             CostStatus{Spent: 20},
+			options:          []Option{WithBudgetCeiling(20)},
+			wantAction:       ActionNone,
+		},
+		{
+			name:             "high burn rate scales down despite pending work",
+			status:           busy,
+			currentInstances: 4,
+			cost:             CostStatus{Spent: 10, BurnRate: 30},
+			options:          []Option{WithBurnRateLimit(20)},
+			wantAction:       ActionScaleDown,
+			wantDelta:        -1,
+		},
+		{
+			name:             "high burn rate at min instances holds",
+			status:           taskqueue.QueueStatus{Pending: 0, Running: 1, Total: 10},
+			currentInstances: 1,
+			cost:             CostStatus{BurnRate: 30},
+			options:          []Option{WithBurnRateLimit(20)},
+			wantAction:       ActionNone,
+		},
+		{
+			name:             "burn rate under limit scales up",
+			status:           busy,
+			currentInstances: 2,
+			cost:             CostStatus{BurnRate: 10},
+			options:          []Option{WithBurnRateLimit(20)},
+			wantAction:       ActionScaleUp,
+			wantDelta:        4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithCooldownPeriod(0)}, tt.options...)
+			p := NewPolicy(opts...)
+			d := p.EvaluateWithCost(tt.status, tt.currentInstances, tt.cost)
+			if d.Action != tt.wantAction || d.Delta != tt.wantDelta {
+				t.Errorf("EvaluateWithCost() = %s %+d (%s), want %s %+d", d.Action, d.Delta, d.Reason, tt.wantAction, tt.wantDelta)
+			}
+		})
+	}
+}
+
+func TestPolicy_EvaluateWithCost_VetoKeepsCooldownClear(t *testing.T) {
+	p := NewPolicy(WithCooldownPeriod(time.Hour), WithBudgetCeiling(1))
+	busy := taskqueue.QueueStatus{Pending: 6, Running: 2, Total: 10}
+
+	if d := p.EvaluateWithCost(busy, 2, CostStatus{Spent: 5}); d.Action != ActionNone {
+		t.Fatalf("first decision = %s, want none", d.Action)
+	}
+	// A veto is not a decision, so a later affordable scale-up is not held
+	// back by the cooldown.
+	if d := p.EvaluateWithCost(busy, 2, CostStatus{}); d.Action != ActionScaleUp {
+		t.Errorf("second decision = %s (%s), want scale_up", d.Action, d.Reason)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"syscall"
 	"time"
 
 	"github.com/Iron-Ham/claudio/internal/logging"
@@ -252,15 +251,3 @@ func CleanStaleLock(sessionDir string, logger *logging.Logger) (bool, error) {
 
 	return true, nil
 }
-
-// isProcessAlive checks if a process with the given PID is still running.
-func isProcessAlive(pid int) bool {
-	// On Unix, sending signal 0 checks if process exists without affecting it
-	process, err := os.FindProcess(pid)
-	if err != nil {
-		return false
-	}
-
-	err = process.Signal(syscall.Signal(0))
-	return err == nil
-}
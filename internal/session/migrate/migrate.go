@@ -0,0 +1,191 @@
+// Package migrate upgrades a session's on-disk state written by an older
+// claudio release, so the session can continue under this one instead of
+// being thrown away.
+//
+// Each state file records the layout it was written in as a top-level
+// format_version. Files without one predate versioning and are version 1.
+// Migrations operate on the decoded JSON document one version at a time, so
+// they do not depend on the current Go types. A session's retry state
+// (ultra_plan.task_retries) lives in session.json and migrates with it.
+//
+// Sessions also record the sentinel protocol their instances were prompted
+// with (see types.SentinelProtocolVersion). It cannot be migrated: instances
+// already running write the completion files they were told to write.
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+)
+
+// Kind identifies a versioned state file.
+type Kind string
+
+const (
+	// KindSession is session.json at the root of a session directory.
+	KindSession Kind = "session"
+	// KindQueue is a task queue's state file, anywhere in a session directory.
+	KindQueue Kind = "queue"
+)
+
+// Format versions written by this build.
+const (
+	SessionVersion = 1
+	QueueVersion   = 1
+)
+
+const (
+	// VersionKey is the top-level field recording a state file's format
+	// version.
+	VersionKey = "format_version"
+
+	// ProtocolKey is the session.json field recording the sentinel protocol
+	// the session's instances were prompted with.
+	ProtocolKey = "sentinel_protocol"
+
+	// QueueFileName is the name of a task queue's state file.
+	QueueFileName = "taskqueue-state.json"
+)
+
+var (
+	// ErrNewerVersion is returned for a state file written by a newer
+	// claudio than this one.
+	ErrNewerVersion = errors.New("written by a newer claudio")
+
+	// ErrNeedsUpgrade is returned for a state file that must be migrated
+	// before this build can read it.
+	ErrNeedsUpgrade = errors.New("needs upgrading")
+
+	// ErrIncompatibleProtocol is returned for a session whose instances were
+	// prompted with a sentinel protocol this build cannot read.
+	ErrIncompatibleProtocol = errors.New("incompatible sentinel protocol")
+)
+
+// Migration upgrades one kind of state file from version From to From+1.
+type Migration struct {
+	Kind    Kind
+	From    int
+	Summary string // One line describing the change, shown by the upgrade command
+	Apply   func(doc map[string]any) error
+}
+
+// migrations are applied in order. When a release changes a state file in a
+// way this build's decoders cannot read as-is, bump the kind's version and
+// add the migration from the previous one here.
+var migrations []Migration
+
+// versions maps each kind to the format version this build writes.
+var versions = map[Kind]int{
+	KindSession: SessionVersion,
+	KindQueue:   QueueVersion,
+}
+
+// CurrentVersion returns the format version of kind written by this build.
+func CurrentVersion(kind Kind) int {
+	return versions[kind]
+}
+
+// Check reports whether this build can read data, a state file of kind,
+// without migrating it.
+func Check(kind Kind, data []byte) error {
+	doc, err := decode(data)
+	if err != nil {
+		return fmt.Errorf("%s file: %w", kind, err)
+	}
+	v, cur := intField(doc, VersionKey, 1), CurrentVersion(kind)
+	switch {
+	case v > cur:
+		return fmt.Errorf("%s file %w (format version %d, this build reads up to %d); upgrade claudio",
+			kind, ErrNewerVersion, v, cur)
+	case v < cur:
+		return fmt.Errorf("%s file %w from format version %d to %d; run 'claudio sessions upgrade'",
+			kind, ErrNeedsUpgrade, v, cur)
+	}
+	return nil
+}
+
+// CheckProtocol reports whether this build reads the completion files of the
+// sentinel protocol recorded in data, a session.json file.
+func CheckProtocol(data []byte) error {
+	doc, err := decode(data)
+	if err != nil {
+		return fmt.Errorf("session file: %w", err)
+	}
+	p := intField(doc, ProtocolKey, 1)
+	if p < types.MinSentinelProtocolVersion || p > types.SentinelProtocolVersion {
+		return fmt.Errorf("%w: the session's instances were prompted with sentinel protocol %d, this build reads %d to %d; restart its unfinished tasks",
+			ErrIncompatibleProtocol, p, types.MinSentinelProtocolVersion, types.SentinelProtocolVersion)
+	}
+	return nil
+}
+
+// Migrate migrates data, a state file of kind, to the current version. It
+// returns the migrated file, its original version, and the summaries of the
+// migrations applied. Data already at the current version is returned
+// unchanged.
+func Migrate(kind Kind, data []byte) (out []byte, from int, applied []string, err error) {
+	doc, err := decode(data)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("%s file: %w", kind, err)
+	}
+	from, cur := intField(doc, VersionKey, 1), CurrentVersion(kind)
+	if from > cur {
+		return nil, from, nil, fmt.Errorf("%s file %w (format version %d, this build reads up to %d)",
+			kind, ErrNewerVersion, from, cur)
+	}
+	if from == cur {
+		return data, from, nil, nil
+	}
+
+	for v := from; v < cur; v++ {
+		m, ok := find(kind, v)
+		if !ok {
+			return nil, from, nil, fmt.Errorf("%s file: no migration from format version %d", kind, v)
+		}
+		if err := m.Apply(doc); err != nil {
+			return nil, from, nil, fmt.Errorf("%s file: migrate from format version %d: %w", kind, v, err)
+		}
+		applied = append(applied, m.Summary)
+	}
+	doc[VersionKey] = cur
+
+	out, err = json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, from, nil, fmt.Errorf("%s file: %w", kind, err)
+	}
+	return out, from, applied, nil
+}
+
+// find returns the migration of kind from version v.
+func find(kind Kind, v int) (Migration, bool) {
+	for _, m := range migrations {
+		if m.Kind == kind && m.From == v {
+			return m, true
+		}
+	}
+	return Migration{}, false
+}
+
+// decode parses a state file as a JSON object.
+func decode(data []byte) (map[string]any, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, errors.New("not a JSON object")
+	}
+	return doc, nil
+}
+
+// intField returns the integer field key of doc, or def when it is absent
+// or not a number.
+func intField(doc map[string]any, key string, def int) int {
+	if n, ok := doc[key].(float64); ok {
+		return int(n)
+	}
+	return def
+}
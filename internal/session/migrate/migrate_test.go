@@ -0,0 +1,112 @@
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// withQueueMigration makes this build write queue format version 2, migrating
+// version 1 by renaming "order" to "task_order".
+func withQueueMigration(t *testing.T) {
+	t.Helper()
+	oldMigrations, oldVersion := migrations, versions[KindQueue]
+	t.Cleanup(func() {
+		migrations = oldMigrations
+		versions[KindQueue] = oldVersion
+	})
+	versions[KindQueue] = 2
+	migrations = append(migrations, Migration{
+		Kind:    KindQueue,
+		From:    1,
+		Summary: "rename order to task_order",
+		Apply: func(doc map[string]any) error {
+			order, ok := doc["order"]
+			if !ok {
+				return fmt.Errorf("no order")
+			}
+			delete(doc, "order")
+			doc["task_order"] = order
+			return nil
+		},
+	})
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr error
+	}{
+		{"unversioned", `{"id": "abc"}`, nil},
+		{"current", `{"format_version": 1}`, nil},
+		{"newer", `{"format_version": 7}`, ErrNewerVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(KindSession, []byte(tt.data))
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("Check() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	if err := Check(KindSession, []byte(`[1, 2]`)); err == nil {
+		t.Error("Check() of a non-object should fail")
+	}
+
+	withQueueMigration(t)
+	if err := Check(KindQueue, []byte(`{"format_version": 1}`)); !errors.Is(err, ErrNeedsUpgrade) {
+		t.Errorf("Check() of an old queue file error = %v, want ErrNeedsUpgrade", err)
+	}
+}
+
+func TestCheckProtocol(t *testing.T) {
+	if err := CheckProtocol([]byte(`{"id": "abc"}`)); err != nil {
+		t.Errorf("session without a recorded protocol: %v", err)
+	}
+	if err := CheckProtocol([]byte(`{"sentinel_protocol": 1}`)); err != nil {
+		t.Errorf("current protocol: %v", err)
+	}
+	if err := CheckProtocol([]byte(`{"sentinel_protocol": 99}`)); !errors.Is(err, ErrIncompatibleProtocol) {
+		t.Errorf("newer protocol error = %v, want ErrIncompatibleProtocol", err)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	withQueueMigration(t)
+
+	out, from, applied, err := Migrate(KindQueue, []byte(`{"tasks": {}, "order": ["a", "b"]}`))
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if from != 1 || !reflect.DeepEqual(applied, []string{"rename order to task_order"}) {
+		t.Errorf("Migrate() from = %d, applied = %v", from, applied)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc[VersionKey] != float64(2) || doc["order"] != nil || !reflect.DeepEqual(doc["task_order"], []any{"a", "b"}) {
+		t.Errorf("migrated document = %v", doc)
+	}
+
+	current := []byte(`{"format_version": 2, "task_order": []}`)
+	out, _, applied, err = Migrate(KindQueue, current)
+	if err != nil || string(out) != string(current) || applied != nil {
+		t.Errorf("Migrate() of a current file = %s, %v, %v; want it unchanged", out, applied, err)
+	}
+
+	if _, _, _, err := Migrate(KindQueue, []byte(`{"format_version": 1}`)); err == nil {
+		t.Error("Migrate() should fail when a migration fails")
+	}
+	if _, _, _, err := Migrate(KindQueue, []byte(`{"format_version": 3}`)); !errors.Is(err, ErrNewerVersion) {
+		t.Errorf("Migrate() of a newer file error = %v, want ErrNewerVersion", err)
+	}
+
+	versions[KindQueue] = 3 // No migration from 2
+	if _, _, _, err := Migrate(KindQueue, current); err == nil {
+		t.Error("Migrate() should fail without a migration for a version")
+	}
+}
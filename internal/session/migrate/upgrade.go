@@ -0,0 +1,186 @@
+package migrate
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/session"
+)
+
+// BackupsDir is the directory within a session directory that holds the
+// original state files of each upgrade.
+const BackupsDir = "backups"
+
+// FileResult describes the upgrade of one state file.
+type FileResult struct {
+	Path    string   // Relative to the session directory
+	Kind    Kind     // Kind of state file
+	From    int      // Format version before the upgrade
+	To      int      // Format version after the upgrade
+	Applied []string // Summaries of the migrations applied
+}
+
+// Changed reports whether the file was migrated.
+func (f FileResult) Changed() bool {
+	return f.From != f.To
+}
+
+// Result describes the upgrade of a session directory.
+type Result struct {
+	Files []FileResult // Every state file found, migrated or not
+
+	// BackupDir holds copies of the files as they were before the upgrade.
+	// It is empty on a dry run or when nothing needed migrating.
+	BackupDir string
+
+	// ProtocolErr is set when the session's instances were prompted with a
+	// sentinel protocol this build cannot read. Migrating files does not fix
+	// it: the session's unfinished tasks must be restarted.
+	ProtocolErr error
+}
+
+// Changed returns the files that were (or on a dry run, would be) migrated.
+func (r *Result) Changed() []FileResult {
+	var changed []FileResult
+	for _, f := range r.Files {
+		if f.Changed() {
+			changed = append(changed, f)
+		}
+	}
+	return changed
+}
+
+// pendingFile is a migrated state file not yet written.
+type pendingFile struct {
+	path string // Absolute
+	data []byte
+	mode fs.FileMode
+}
+
+// UpgradeSession migrates every state file in sessionDir to the version this
+// build writes. Every file is migrated in memory before any is written, so a
+// file that cannot be migrated leaves the session untouched. The originals
+// of the files that change are first copied into a timestamped directory
+// under BackupsDir; copying them back undoes the upgrade. With dryRun,
+// UpgradeSession reports what would change without writing anything.
+//
+// The session must not be running.
+func UpgradeSession(sessionDir string, dryRun bool) (*Result, error) {
+	files, err := stateFiles(sessionDir)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	var pending []pendingFile
+	for _, f := range files {
+		path := filepath.Join(sessionDir, f.Path)
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("upgrade %s: %w", f.Path, err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("upgrade %s: %w", f.Path, err)
+		}
+		if f.Kind == KindSession {
+			result.ProtocolErr = CheckProtocol(data)
+		}
+
+		out, from, applied, err := Migrate(f.Kind, data)
+		if err != nil {
+			return nil, fmt.Errorf("upgrade %s: %w", f.Path, err)
+		}
+		f.From, f.To, f.Applied = from, CurrentVersion(f.Kind), applied
+		result.Files = append(result.Files, f)
+		if f.Changed() {
+			pending = append(pending, pendingFile{path: path, data: out, mode: info.Mode().Perm()})
+		}
+	}
+	if dryRun || len(pending) == 0 {
+		return result, nil
+	}
+
+	backupDir := filepath.Join(sessionDir, BackupsDir, "upgrade-"+time.Now().UTC().Format("20060102-150405"))
+	for _, p := range pending {
+		if err := backup(sessionDir, backupDir, p.path); err != nil {
+			return nil, err
+		}
+	}
+	result.BackupDir = backupDir
+
+	for _, p := range pending {
+		if err := writeAtomic(p.path, p.data, p.mode); err != nil {
+			return result, fmt.Errorf("upgrade %s (originals are in %s): %w", p.path, backupDir, err)
+		}
+	}
+	return result, nil
+}
+
+// stateFiles finds the versioned state files in sessionDir: its session.json
+// and any task queue state outside earlier backups, in lexical order.
+func stateFiles(sessionDir string) ([]FileResult, error) {
+	if _, err := os.Stat(filepath.Join(sessionDir, session.SessionFileName)); err != nil {
+		return nil, fmt.Errorf("no session in %s: %w", sessionDir, err)
+	}
+	files := []FileResult{{Path: session.SessionFileName, Kind: KindSession}}
+
+	var queues []FileResult
+	err := filepath.WalkDir(sessionDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && path == filepath.Join(sessionDir, BackupsDir) {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && d.Name() == QueueFileName {
+			rel, err := filepath.Rel(sessionDir, path)
+			if err != nil {
+				return err
+			}
+			queues = append(queues, FileResult{Path: rel, Kind: KindQueue})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find state files: %w", err)
+	}
+	return append(files, queues...), nil
+}
+
+// backup copies path, a file in sessionDir, to the same relative path under
+// backupDir.
+func backup(sessionDir, backupDir, path string) error {
+	rel, err := filepath.Rel(sessionDir, path)
+	if err != nil {
+		return fmt.Errorf("back up %s: %w", path, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("back up %s: %w", rel, err)
+	}
+	dst := filepath.Join(backupDir, rel)
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("back up %s: %w", rel, err)
+	}
+	if err := os.WriteFile(dst, data, 0644); err != nil {
+		return fmt.Errorf("back up %s: %w", rel, err)
+	}
+	return nil
+}
+
+// writeAtomic writes data to a temporary file and renames it over path.
+func writeAtomic(path string, data []byte, mode fs.FileMode) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, mode); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package migrate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSession(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestUpgradeSession(t *testing.T) {
+	withQueueMigration(t)
+	const oldQueue = `{"tasks": {}, "order": ["a"]}`
+	dir := writeSession(t, map[string]string{
+		"session.json":                  `{"id": "s1"}`,
+		"teams/t1/taskqueue-state.json": oldQueue,
+		"backups/old/" + QueueFileName:  oldQueue, // Earlier backups are left alone
+	})
+
+	dry, err := UpgradeSession(dir, true)
+	if err != nil {
+		t.Fatalf("dry run error = %v", err)
+	}
+	if len(dry.Files) != 2 || len(dry.Changed()) != 1 || dry.BackupDir != "" {
+		t.Fatalf("dry run = %+v, want session.json and one queue file to change", dry)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "teams/t1", QueueFileName)); string(data) != oldQueue {
+		t.Error("dry run should not write")
+	}
+
+	result, err := UpgradeSession(dir, false)
+	if err != nil {
+		t.Fatalf("UpgradeSession() error = %v", err)
+	}
+	changed := result.Changed()
+	if len(changed) != 1 || changed[0].Path != filepath.Join("teams", "t1", QueueFileName) ||
+		changed[0].From != 1 || changed[0].To != 2 || len(changed[0].Applied) != 1 {
+		t.Fatalf("changed = %+v", changed)
+	}
+	if result.ProtocolErr != nil {
+		t.Errorf("ProtocolErr = %v", result.ProtocolErr)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "teams/t1", QueueFileName))
+	if err != nil || !strings.Contains(string(data), `"task_order"`) || !strings.Contains(string(data), `"format_version": 2`) {
+		t.Errorf("migrated queue = %s, %v", data, err)
+	}
+	backup, err := os.ReadFile(filepath.Join(result.BackupDir, "teams/t1", QueueFileName))
+	if err != nil || string(backup) != oldQueue {
+		t.Errorf("backup = %s, %v; want the original", backup, err)
+	}
+	if _, err := os.Stat(filepath.Join(result.BackupDir, "session.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Error("unchanged files should not be backed up")
+	}
+
+	again, err := UpgradeSession(dir, false)
+	if err != nil || len(again.Changed()) != 0 {
+		t.Errorf("second upgrade = %+v, %v; want nothing to change", again, err)
+	}
+}
+
+func TestUpgradeSession_FailureLeavesFilesUntouched(t *testing.T) {
+	withQueueMigration(t)
+	dir := writeSession(t, map[string]string{
+		"session.json":       `{"id": "s1", "sentinel_protocol": 99}`,
+		"a/" + QueueFileName: `{"order": []}`,
+		"b/" + QueueFileName: `{"tasks": {}}`, // The test migration needs order
+	})
+
+	if _, err := UpgradeSession(dir, false); err == nil {
+		t.Fatal("UpgradeSession() should fail when a file cannot be migrated")
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "a", QueueFileName)); string(data) != `{"order": []}` {
+		t.Errorf("a migratable file was written despite the failure: %s", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, BackupsDir)); !errors.Is(err, os.ErrNotExist) {
+		t.Error("no backup should be made when the upgrade fails")
+	}
+
+	if err := os.Remove(filepath.Join(dir, "b", QueueFileName)); err != nil {
+		t.Fatal(err)
+	}
+	result, err := UpgradeSession(dir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(result.ProtocolErr, ErrIncompatibleProtocol) {
+		t.Errorf("ProtocolErr = %v, want ErrIncompatibleProtocol", result.ProtocolErr)
+	}
+}
+
+func TestUpgradeSession_NoSession(t *testing.T) {
+	if _, err := UpgradeSession(t.TempDir(), false); err == nil {
+		t.Error("UpgradeSession() without session.json should fail")
+	}
+}
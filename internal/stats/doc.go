@@ -0,0 +1,28 @@
+// Package stats provides a persistent, append-only store of outcome records
+// that outlives individual sessions.
+//
+// Features that want to measure behavior across many sessions (prompt
+// experiments, flaky verification detection, cost history) append small
+// [Record] values tagged with whatever dimensions they need, and later query
+// them back to build reports. Records are persisted as JSONL in a single file
+// under the repository's .claudio directory.
+//
+// # Usage
+//
+//	store := stats.NewStore(filepath.Join(repoRoot, ".claudio"))
+//
+//	err := store.Append(stats.Record{
+//		Kind:    "experiment.attempt",
+//		Subject: taskID,
+//		Tags:    map[string]string{"variant": "terse"},
+//		Values:  map[string]float64{"cost": 0.42},
+//	})
+//
+//	records, err := store.Query("experiment.attempt", nil)
+//
+// # Thread Safety
+//
+// All methods on [Store] are safe for concurrent use within a process. Writes
+// use O_APPEND so concurrent claudio processes appending to the same file do
+// not interleave partial lines.
+package stats
@@ -0,0 +1,114 @@
+package stats
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// fileName is the JSONL file within the store directory.
+const fileName = "stats.jsonl"
+
+// Record is a single outcome observation.
+type Record struct {
+	Time    time.Time          `json:"time"`
+	Kind    string             `json:"kind"`              // Record category, e.g. "experiment.attempt"
+	Session string             `json:"session,omitempty"` // Session that produced the record
+	Subject string             `json:"subject,omitempty"` // What the record is about (task ID, command, ...)
+	Tags    map[string]string  `json:"tags,omitempty"`    // Dimensions for grouping
+	Values  map[string]float64 `json:"values,omitempty"`  // Measurements
+}
+
+// Store persists records to an append-only JSONL file.
+type Store struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewStore creates a Store that keeps its data file in dir. The directory is
+// created lazily on first write.
+func NewStore(dir string) *Store {
+	return &Store{path: filepath.Join(dir, fileName)}
+}
+
+// Path returns the location of the store's data file.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Append persists a record. Kind is required; a zero Time is replaced with
+// the current time.
+func (s *Store) Append(r Record) error {
+	if r.Kind == "" {
+		return errors.New("stats: record Kind is required")
+	}
+	if r.Time.IsZero() {
+		r.Time = time.Now()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("stats: marshal record: %w", err)
+	}
+	data = append(data, '\n')
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("stats: create directory: %w", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("stats: open store: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("stats: write record: %w", err)
+	}
+	return f.Close()
+}
+
+// Query returns records of the given kind, in append order, for which match
+// returns true. An empty kind matches every kind; a nil match accepts every
+// record. A missing store file yields no records and no error. Malformed
+// lines (e.g. a torn write after a crash) are skipped.
+func (s *Store) Query(kind string, match func(Record) bool) ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("stats: open store: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var out []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue
+		}
+		if kind != "" && r.Kind != kind {
+			continue
+		}
+		if match != nil && !match(r) {
+			continue
+		}
+		out = append(out, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("stats: read store: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,118 @@
+package stats
+
+import (
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestStore_AppendAndQuery(t *testing.T) {
+	s := NewStore(t.TempDir())
+
+	records := []Record{
+		{Kind: "a", Subject: "t1", Tags: map[string]string{"v": "x"}, Values: map[string]float64{"cost": 1}},
+		{Kind: "b", Subject: "t2"},
+		{Kind: "a", Subject: "t3", Tags: map[string]string{"v": "y"}},
+	}
+	for _, r := range records {
+		if err := s.Append(r); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	all, err := s.Query("", nil)
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("Query all = %d records, want 3", len(all))
+	}
+	if all[0].Time.IsZero() {
+		t.Error("Append should fill in Time")
+	}
+
+	kindA, err := s.Query("a", nil)
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(kindA) != 2 || kindA[0].Subject != "t1" || kindA[1].Subject != "t3" {
+		t.Errorf("Query(a) = %+v", kindA)
+	}
+	if kindA[0].Values["cost"] != 1 {
+		t.Errorf("Values not round-tripped: %+v", kindA[0].Values)
+	}
+
+	onlyY, err := s.Query("a", func(r Record) bool { return r.Tags["v"] == "y" })
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(onlyY) != 1 || onlyY[0].Subject != "t3" {
+		t.Errorf("Query(a, v=y) = %+v", onlyY)
+	}
+}
+
+func TestStore_AppendRequiresKind(t *testing.T) {
+	s := NewStore(t.TempDir())
+	if err := s.Append(Record{}); err == nil {
+		t.Fatal("expected error for missing Kind")
+	}
+}
+
+func TestStore_QueryMissingFile(t *testing.T) {
+	s := NewStore(t.TempDir())
+	got, err := s.Query("", nil)
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Query on empty store = %d records, want 0", len(got))
+	}
+}
+
+func TestStore_SkipsMalformedLines(t *testing.T) {
+	s := NewStore(t.TempDir())
+	if err := s.Append(Record{Kind: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(s.Path(), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString("{torn\n"); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+	if err := s.Append(Record{Kind: "a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Query("a", nil)
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("Query = %d records, want 2", len(got))
+	}
+}
+
+func TestStore_ConcurrentAppend(t *testing.T) {
+	s := NewStore(t.TempDir())
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Go(func() {
+			if err := s.Append(Record{Kind: "c"}); err != nil {
+				t.Errorf("Append: %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	got, err := s.Query("c", nil)
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(got) != 20 {
+		t.Errorf("Query = %d records, want 20", len(got))
+	}
+}
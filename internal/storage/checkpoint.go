@@ -0,0 +1,275 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/Iron-Ham/claudio/internal/session"
+)
+
+// ManifestName is the file that completes a checkpoint. It is written after
+// every file it lists, so a reader that finds it can restore the session.
+const ManifestName = "checkpoint.json"
+
+// sessionsPrefix is the key prefix under which checkpoints are stored.
+const sessionsPrefix = "sessions/"
+
+// Manifest describes one checkpoint of a session directory.
+type Manifest struct {
+	SessionID string         `json:"session_id"`
+	CreatedAt time.Time      `json:"created_at"`
+	Files     []ManifestFile `json:"files"`
+}
+
+// ManifestFile is a file in a checkpoint.
+type ManifestFile struct {
+	Path    string    `json:"path"` // Slash-separated, relative to the session directory
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// SessionKey returns the key of a file in a session's checkpoint.
+func SessionKey(sessionID, rel string) string {
+	return sessionsPrefix + sessionID + "/" + rel
+}
+
+// Checkpointer copies a session directory to a Store. It uploads only the
+// files that changed since its previous checkpoint, then the manifest.
+type Checkpointer struct {
+	store     Store
+	sessionID string
+	dir       string
+	logger    *logging.Logger
+
+	mu       sync.Mutex
+	uploaded map[string]ManifestFile // relative path -> file as last uploaded
+}
+
+// Option configures a Checkpointer.
+type Option func(*Checkpointer)
+
+// WithLogger sets the logger for checkpoint failures.
+func WithLogger(logger *logging.Logger) Option {
+	return func(c *Checkpointer) {
+		c.logger = logger
+	}
+}
+
+// NewCheckpointer creates a Checkpointer for the session stored in dir.
+func NewCheckpointer(store Store, sessionID, dir string, opts ...Option) *Checkpointer {
+	c := &Checkpointer{
+		store:     store,
+		sessionID: sessionID,
+		dir:       dir,
+		uploaded:  make(map[string]ManifestFile),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Checkpoint uploads the session files that changed since the last call and
+// rewrites the manifest. It does nothing when no file changed. The lock
+// file and in-progress temporary files are never uploaded.
+func (c *Checkpointer) Checkpoint(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	files, err := scanSessionDir(c.dir)
+	if err != nil {
+		return err
+	}
+
+	changed := len(files) != len(c.uploaded)
+	listed := files[:0]
+	for _, f := range files {
+		prev, ok := c.uploaded[f.Path]
+		if ok && prev.Size == f.Size && prev.ModTime.Equal(f.ModTime) {
+			listed = append(listed, f)
+			continue
+		}
+		changed = true
+		data, err := os.ReadFile(filepath.Join(c.dir, filepath.FromSlash(f.Path)))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue // Removed since the scan
+		}
+		if err != nil {
+			return fmt.Errorf("checkpoint %s: %w", f.Path, err)
+		}
+		if err := c.store.Put(ctx, SessionKey(c.sessionID, f.Path), data); err != nil {
+			return err
+		}
+		c.uploaded[f.Path] = f
+		listed = append(listed, f)
+	}
+	if !changed {
+		return nil
+	}
+
+	manifest := Manifest{SessionID: c.sessionID, CreatedAt: time.Now().UTC(), Files: listed}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal checkpoint manifest: %w", err)
+	}
+	if err := c.store.Put(ctx, SessionKey(c.sessionID, ManifestName), data); err != nil {
+		return err
+	}
+
+	// Files removed locally stay in the store; the manifest no longer lists
+	// them, so Restore skips them.
+	current := make(map[string]bool, len(listed))
+	for _, f := range listed {
+		current[f.Path] = true
+	}
+	for p := range c.uploaded {
+		if !current[p] {
+			delete(c.uploaded, p)
+		}
+	}
+	return nil
+}
+
+// Run checkpoints every interval until ctx is cancelled, then makes a final
+// checkpoint so the store holds the session's last state. Failures are
+// logged and retried on the next tick.
+func (c *Checkpointer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			final, cancel := context.WithTimeout(context.Background(), s3Timeout)
+			c.checkpointAndLog(final)
+			cancel()
+			return
+		case <-ticker.C:
+			c.checkpointAndLog(ctx)
+		}
+	}
+}
+
+func (c *Checkpointer) checkpointAndLog(ctx context.Context) {
+	if err := c.Checkpoint(ctx); err != nil && c.logger != nil {
+		c.logger.Warn("session checkpoint failed", "session_id", c.sessionID, "error", err)
+	}
+}
+
+// scanSessionDir lists the regular files in dir that belong in a checkpoint,
+// sorted by path.
+func scanSessionDir(dir string) ([]ManifestFile, error) {
+	var files []ManifestFile
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		name := d.Name()
+		if name == session.LockFileName || name == ManifestName || strings.HasSuffix(name, tmpSuffix) {
+			return nil
+		}
+		info, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, ManifestFile{Path: filepath.ToSlash(rel), Size: info.Size(), ModTime: info.ModTime().UTC()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan session directory: %w", err)
+	}
+	slices.SortFunc(files, func(a, b ManifestFile) int { return strings.Compare(a.Path, b.Path) })
+	return files, nil
+}
+
+// ReadManifest returns the manifest of a session's latest checkpoint, or an
+// error wrapping ErrNotFound when the session has none.
+func ReadManifest(ctx context.Context, store Store, sessionID string) (*Manifest, error) {
+	data, err := store.Get(ctx, SessionKey(sessionID, ManifestName))
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse checkpoint manifest for %s: %w", sessionID, err)
+	}
+	return &m, nil
+}
+
+// ListCheckpoints returns the manifests of every checkpointed session, most
+// recent first.
+func ListCheckpoints(ctx context.Context, store Store) ([]*Manifest, error) {
+	keys, err := store.List(ctx, sessionsPrefix)
+	if err != nil {
+		return nil, err
+	}
+	var manifests []*Manifest
+	for _, key := range keys {
+		rest := strings.TrimPrefix(key, sessionsPrefix)
+		sessionID, name, ok := strings.Cut(rest, "/")
+		if !ok || name != ManifestName {
+			continue
+		}
+		m, err := ReadManifest(ctx, store, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, m)
+	}
+	slices.SortFunc(manifests, func(a, b *Manifest) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return manifests, nil
+}
+
+// Restore downloads a session's latest checkpoint into dir, creating it if
+// needed. Existing files are overwritten; others in dir are left alone.
+func Restore(ctx context.Context, store Store, sessionID, dir string) (*Manifest, error) {
+	m, err := ReadManifest(ctx, store, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range m.Files {
+		if err := validKey(f.Path); err != nil {
+			return nil, fmt.Errorf("restore %s: %w", sessionID, err)
+		}
+		data, err := store.Get(ctx, SessionKey(sessionID, f.Path))
+		if err != nil {
+			return nil, err
+		}
+		target := filepath.Join(dir, filepath.FromSlash(path.Clean(f.Path)))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, fmt.Errorf("restore %s: %w", f.Path, err)
+		}
+		tmp := target + tmpSuffix
+		if err := os.WriteFile(tmp, data, 0644); err != nil {
+			return nil, fmt.Errorf("restore %s: %w", f.Path, err)
+		}
+		if err := os.Rename(tmp, target); err != nil {
+			_ = os.Remove(tmp) // best-effort cleanup
+			return nil, fmt.Errorf("restore %s: %w", f.Path, err)
+		}
+		if !f.ModTime.IsZero() {
+			_ = os.Chtimes(target, f.ModTime, f.ModTime)
+		}
+	}
+	return m, nil
+}
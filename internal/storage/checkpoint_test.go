@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/session"
+)
+
+// countingStore counts Put calls.
+type countingStore struct {
+	Store
+	puts int
+}
+
+func (s *countingStore) Put(ctx context.Context, key string, data []byte) error {
+	s.puts++
+	return s.Store.Put(ctx, key, data)
+}
+
+func writeFile(t *testing.T, dir, rel, content string) {
+	t.Helper()
+	p := filepath.Join(dir, rel)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckpointer_CheckpointAndRestore(t *testing.T) {
+	ctx := context.Background()
+	local, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &countingStore{Store: local}
+
+	dir := t.TempDir()
+	writeFile(t, dir, "session.json", `{"id":"s1"}`)
+	writeFile(t, dir, "debug.log", "line 1\n")
+	writeFile(t, dir, "diag/profile.json", "{}")
+	writeFile(t, dir, session.LockFileName, `{"pid":1}`)
+
+	cp := NewCheckpointer(store, "s1", dir)
+	if err := cp.Checkpoint(ctx); err != nil {
+		t.Fatalf("Checkpoint() error = %v", err)
+	}
+	if store.puts != 4 { // three files and the manifest
+		t.Errorf("puts = %d, want 4", store.puts)
+	}
+
+	// Nothing changed: nothing is uploaded
+	if err := cp.Checkpoint(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if store.puts != 4 {
+		t.Errorf("puts after unchanged checkpoint = %d, want 4", store.puts)
+	}
+
+	// Only the changed file and the manifest are uploaded
+	writeFile(t, dir, "debug.log", "line 1\nline 2\n")
+	if err := cp.Checkpoint(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if store.puts != 6 {
+		t.Errorf("puts after one change = %d, want 6", store.puts)
+	}
+
+	restored := t.TempDir()
+	m, err := Restore(ctx, store, "s1", restored)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if len(m.Files) != 3 {
+		t.Errorf("manifest files = %v, want 3", m.Files)
+	}
+	data, err := os.ReadFile(filepath.Join(restored, "debug.log"))
+	if err != nil || string(data) != "line 1\nline 2\n" {
+		t.Errorf("restored debug.log = %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(restored, "diag", "profile.json")); err != nil {
+		t.Errorf("nested file not restored: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(restored, session.LockFileName)); !os.IsNotExist(err) {
+		t.Error("lock file should not be checkpointed")
+	}
+}
+
+func TestListCheckpoints(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"older", "newer"} {
+		dir := t.TempDir()
+		writeFile(t, dir, "session.json", "{}")
+		if err := NewCheckpointer(store, id, dir).Checkpoint(ctx); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond) // distinct CreatedAt
+	}
+
+	manifests, err := ListCheckpoints(ctx, store)
+	if err != nil {
+		t.Fatalf("ListCheckpoints() error = %v", err)
+	}
+	if len(manifests) != 2 || manifests[0].SessionID != "newer" || manifests[1].SessionID != "older" {
+		t.Errorf("ListCheckpoints() = %+v, want newer then older", manifests)
+	}
+
+	if _, err := Restore(ctx, store, "missing", t.TempDir()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Restore(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestCheckpointer_RunFinalCheckpoint(t *testing.T) {
+	store, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	writeFile(t, dir, "session.json", "{}")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		NewCheckpointer(store, "s1", dir).Run(ctx, time.Hour)
+		close(done)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+
+	if _, err := ReadManifest(context.Background(), store, "s1"); err != nil {
+		t.Errorf("final checkpoint missing: %v", err)
+	}
+}
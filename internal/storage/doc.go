@@ -0,0 +1,33 @@
+// Package storage checkpoints Claudio session state to durable storage.
+//
+// A session always runs from its local directory. When session.storage is
+// configured, the orchestrator periodically copies that directory (session
+// state, task queue state, audit and debug logs) to a [Store], so a session
+// on an ephemeral machine such as a CI runner outlives it. Later,
+// "claudio sessions restore" downloads the checkpoint on another machine,
+// where the session can be inspected or attached to.
+//
+//	{prefix}/sessions/{sessionID}/session.json
+//	{prefix}/sessions/{sessionID}/debug.log
+//	{prefix}/sessions/{sessionID}/checkpoint.json
+//
+// # Main Types
+//
+//   - [Store]: Flat key-value object store
+//   - [LocalStore]: Store backed by a directory (a mounted volume or CI cache)
+//   - [S3Store]: Store backed by an S3-compatible bucket, signed with AWS
+//     Signature Version 4
+//   - [Checkpointer]: Uploads the changed files of a session directory,
+//     then a [Manifest] listing them
+//
+// # Usage
+//
+//	store, err := storage.New(cfg.Session.Storage)
+//	cp := storage.NewCheckpointer(store, sessionID, sessionDir, storage.WithLogger(logger))
+//	go cp.Run(ctx, cfg.Session.Storage.CheckpointInterval())
+//
+//	manifest, err := storage.Restore(ctx, store, sessionID, session.GetSessionDir(baseDir, sessionID))
+//
+// The manifest is written last. A checkpoint interrupted part way leaves the
+// previous manifest in place, and every file it lists is still stored.
+package storage
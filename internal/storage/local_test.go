@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+)
+
+func TestLocalStore_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocal() error = %v", err)
+	}
+
+	if err := s.Put(ctx, "sessions/a/session.json", []byte("{}")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := s.Put(ctx, "sessions/b/session.json", []byte("{}")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	// A write interrupted before its rename is never listed
+	if err := os.WriteFile(filepath.Join(s.Root(), "sessions", "a", "debug.log"+tmpSuffix), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := s.Get(ctx, "sessions/a/session.json")
+	if err != nil || string(data) != "{}" {
+		t.Errorf("Get() = %q, %v", data, err)
+	}
+	if _, err := s.Get(ctx, "sessions/c/session.json"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(missing) error = %v, want ErrNotFound", err)
+	}
+
+	keys, err := s.List(ctx, "sessions/a")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if want := []string{"sessions/a/session.json"}; !slices.Equal(keys, want) {
+		t.Errorf("List(sessions/a) = %v, want %v", keys, want)
+	}
+	if keys, _ := s.List(ctx, "missing/"); len(keys) != 0 {
+		t.Errorf("List(missing/) = %v, want none", keys)
+	}
+
+	if err := s.Delete(ctx, "sessions/a/session.json"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := s.Delete(ctx, "sessions/a/session.json"); err != nil {
+		t.Errorf("Delete(missing) error = %v", err)
+	}
+}
+
+func TestLocalStore_RejectsEscapingKeys(t *testing.T) {
+	s, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"", "/etc/passwd", "../x", "a/../../x", `a\b`} {
+		if err := s.Put(context.Background(), key, nil); err == nil {
+			t.Errorf("Put(%q) should fail", key)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	s, err := New(config.SessionStorageConfig{})
+	if err != nil || s != nil {
+		t.Errorf("New(disabled) = %v, %v; want nil, nil", s, err)
+	}
+
+	dir := t.TempDir()
+	s, err = New(config.SessionStorageConfig{Backend: "local", Path: dir, Prefix: "ci"})
+	if err != nil {
+		t.Fatalf("New(local) error = %v", err)
+	}
+	if got := s.(*LocalStore).Root(); got != filepath.Join(dir, "ci") {
+		t.Errorf("Root() = %q, want %q", got, filepath.Join(dir, "ci"))
+	}
+
+	if _, err := New(config.SessionStorageConfig{Backend: "ftp"}); err == nil {
+		t.Error("New(unknown backend) should fail")
+	}
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+)
+
+// ErrNotFound is returned by Store.Get for keys that do not exist.
+var ErrNotFound = errors.New("storage: key not found")
+
+// Store is a flat key-value object store. Keys are slash-separated paths
+// relative to the store's root, such as "sessions/abc123/session.json".
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Put writes data under key, replacing any existing value. Readers see
+	// either the old value or the new one, never a partial write.
+	Put(ctx context.Context, key string, data []byte) error
+
+	// Get returns the value stored under key, or an error wrapping
+	// ErrNotFound.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// List returns the keys that start with prefix, sorted.
+	List(ctx context.Context, prefix string) ([]string, error)
+
+	// Delete removes key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// New returns the Store configured by session.storage, or nil when
+// checkpointing is disabled.
+func New(cfg config.SessionStorageConfig) (Store, error) {
+	switch cfg.Backend {
+	case "":
+		return nil, nil
+	case "local":
+		return NewLocal(filepath.Join(cfg.Path, filepath.FromSlash(strings.Trim(cfg.Prefix, "/"))))
+	case "s3":
+		region := cfg.Region
+		if region == "" {
+			region = os.Getenv("AWS_REGION")
+		}
+		return NewS3(S3Config{
+			Bucket:       cfg.Bucket,
+			Prefix:       cfg.Prefix,
+			Region:       region,
+			Endpoint:     cfg.Endpoint,
+			PathStyle:    cfg.PathStyle,
+			AccessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+		})
+	default:
+		return nil, fmt.Errorf("unknown session storage backend %q", cfg.Backend)
+	}
+}
+
+// validKey rejects keys that are empty, absolute, or escape the store root.
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) || path.Clean(key) != key ||
+		key == ".." || strings.HasPrefix(key, "../") {
+		return fmt.Errorf("storage: invalid key %q", key)
+	}
+	return nil
+}
+
+// joinKey joins non-empty key segments with slashes.
+func joinKey(parts ...string) string {
+	var kept []string
+	for _, p := range parts {
+		if p = strings.Trim(p, "/"); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, "/")
+}
@@ -0,0 +1,37 @@
+// Package store records Claudio sessions and their instance metrics in a
+// SQLite database.
+//
+// Session state lives in .claudio/sessions/{id}/session.json while a session
+// runs, and is removed when it is stopped. When session.database is enabled,
+// the orchestrator also saves every session state to a [Store], which keeps
+// it after the session ends. The history answers questions that span
+// sessions, such as the cost of each task over the last ten sessions.
+//
+// The store implements the orchestrator session manager's Backend, so a
+// session whose directory is gone can still be loaded from it. session.json
+// stays the source of truth for a running session.
+//
+// The database uses the pure Go modernc.org/sqlite driver, so the binary
+// still builds without cgo.
+//
+// # Main Types
+//
+//   - [Store]: Sessions and their instances, with their latest metrics
+//   - [SessionSummary]: A session with totals over its instances
+//   - [TaskCost]: The cost, tokens, and duration of one task
+//
+// # Usage
+//
+//	db, err := store.New(cfg.Session.Database, baseDir) // nil when disabled
+//	err = db.SaveSession(sessionData)
+//
+//	sessions, err := db.RecentSessions(10)
+//	costs, err := db.TaskCosts(10)
+//	recent, err := db.RecentSessionData(20) // Full session state, e.g. ultraplan plans
+//
+// # Schema
+//
+// The schema version is kept in PRAGMA user_version. Open applies the
+// migrations the database has not seen; append new ones to migrations and
+// never edit an applied one.
+package store
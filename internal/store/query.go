@@ -0,0 +1,151 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	orchsession "github.com/Iron-Ham/claudio/internal/orchestrator/session"
+)
+
+// SessionSummary totals a recorded session's instances.
+type SessionSummary struct {
+	ID           string
+	Name         string
+	BaseRepo     string
+	Created      time.Time
+	Updated      time.Time // Last save
+	Deleted      bool
+	Instances    int
+	InputTokens  int64
+	OutputTokens int64
+	Cost         float64
+}
+
+// TaskCost is the recorded usage of one instance's task.
+type TaskCost struct {
+	SessionID    string
+	SessionName  string
+	InstanceID   string
+	Task         string
+	Status       string
+	InputTokens  int64
+	OutputTokens int64
+	Cost         float64
+	APICalls     int
+	Duration     time.Duration // Zero until the instance has started and ended
+}
+
+// RecentSessions returns up to limit sessions, newest first, with their
+// instance totals. limit <= 0 returns every session.
+func (s *Store) RecentSessions(limit int) ([]SessionSummary, error) {
+	rows, err := s.db.Query(`
+		SELECT s.id, s.name, s.base_repo, s.created_at, s.updated_at, s.deleted_at,
+			COUNT(i.id), COALESCE(SUM(i.input_tokens), 0), COALESCE(SUM(i.output_tokens), 0),
+			COALESCE(SUM(i.cost), 0)
+		FROM sessions s
+		LEFT JOIN instances i ON i.session_id = s.id
+		GROUP BY s.id
+		ORDER BY s.created_at DESC, s.id
+		LIMIT ?`, sqlLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("store: query sessions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []SessionSummary
+	for rows.Next() {
+		var sum SessionSummary
+		var created, updated int64
+		var deleted sql.NullInt64
+		if err := rows.Scan(&sum.ID, &sum.Name, &sum.BaseRepo, &created, &updated, &deleted,
+			&sum.Instances, &sum.InputTokens, &sum.OutputTokens, &sum.Cost); err != nil {
+			return nil, fmt.Errorf("store: scan session: %w", err)
+		}
+		sum.Created = time.Unix(0, created)
+		sum.Updated = time.Unix(0, updated)
+		sum.Deleted = deleted.Valid
+		out = append(out, sum)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: query sessions: %w", err)
+	}
+	return out, nil
+}
+
+// TaskCosts returns the tasks of the last sessions sessions: newest
+// session first, and most expensive task first within a session.
+// sessions <= 0 covers every session.
+func (s *Store) TaskCosts(sessions int) ([]TaskCost, error) {
+	rows, err := s.db.Query(`
+		WITH recent AS (
+			SELECT id, name, created_at FROM sessions
+			ORDER BY created_at DESC, id
+			LIMIT ?
+		)
+		SELECT r.id, r.name, i.id, i.task, i.status, i.input_tokens, i.output_tokens,
+			i.cost, i.api_calls, i.started_at, i.ended_at
+		FROM recent r
+		JOIN instances i ON i.session_id = r.id
+		ORDER BY r.created_at DESC, r.id, i.cost DESC, i.created_at`, sqlLimit(sessions))
+	if err != nil {
+		return nil, fmt.Errorf("store: query task costs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []TaskCost
+	for rows.Next() {
+		var tc TaskCost
+		var started, ended sql.NullInt64
+		if err := rows.Scan(&tc.SessionID, &tc.SessionName, &tc.InstanceID, &tc.Task, &tc.Status,
+			&tc.InputTokens, &tc.OutputTokens, &tc.Cost, &tc.APICalls, &started, &ended); err != nil {
+			return nil, fmt.Errorf("store: scan task cost: %w", err)
+		}
+		if started.Valid && ended.Valid {
+			tc.Duration = fromNull(ended).Sub(fromNull(started))
+		}
+		out = append(out, tc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: query task costs: %w", err)
+	}
+	return out, nil
+}
+
+// RecentSessionData returns the last recorded state of up to limit
+// sessions, newest first, including deleted ones. limit <= 0 returns every
+// session.
+func (s *Store) RecentSessionData(limit int) ([]*orchsession.SessionData, error) {
+	rows, err := s.db.Query(`SELECT id, data FROM sessions ORDER BY created_at DESC, id LIMIT ?`, sqlLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("store: query session data: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []*orchsession.SessionData
+	for rows.Next() {
+		var id, data string
+		if err := rows.Scan(&id, &data); err != nil {
+			return nil, fmt.Errorf("store: scan session data: %w", err)
+		}
+		var sess orchsession.SessionData
+		if err := json.Unmarshal([]byte(data), &sess); err != nil {
+			return nil, fmt.Errorf("store: decode session %s: %w", id, err)
+		}
+		out = append(out, &sess)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: query session data: %w", err)
+	}
+	return out, nil
+}
+
+// sqlLimit converts a limit where <= 0 means none to SQLite's LIMIT, where
+// a negative value means none.
+func sqlLimit(n int) int {
+	if n <= 0 {
+		return -1
+	}
+	return n
+}
@@ -0,0 +1,260 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	orchsession "github.com/Iron-Ham/claudio/internal/orchestrator/session"
+
+	_ "modernc.org/sqlite" // Registers the "sqlite" driver (pure Go, no cgo)
+)
+
+// Ensure Store satisfies the session manager's backend interface.
+var _ orchsession.Backend = (*Store)(nil)
+
+// ErrNotFound is returned by LoadSession for sessions the store has never seen.
+var ErrNotFound = errors.New("store: session not found")
+
+// DefaultFile is the database file name used when session.database.path is unset.
+const DefaultFile = "history.db"
+
+// busyTimeout is how long a write waits for another process (a second
+// Claudio session in the same repository) to release the database.
+const busyTimeout = 5 * time.Second
+
+// migrations create and evolve the schema. migrations[i] moves the database
+// from version i to i+1 (tracked in PRAGMA user_version). Append only.
+var migrations = []string{
+	`CREATE TABLE sessions (
+		id         TEXT PRIMARY KEY,
+		name       TEXT NOT NULL,
+		base_repo  TEXT NOT NULL,
+		created_at INTEGER NOT NULL,
+		updated_at INTEGER NOT NULL,
+		deleted_at INTEGER,
+		data       TEXT NOT NULL
+	);
+	CREATE INDEX sessions_created_at ON sessions (created_at);
+	CREATE TABLE instances (
+		session_id    TEXT NOT NULL REFERENCES sessions (id) ON DELETE CASCADE,
+		id            TEXT NOT NULL,
+		task          TEXT NOT NULL,
+		status        TEXT NOT NULL,
+		branch        TEXT NOT NULL,
+		created_at    INTEGER NOT NULL,
+		started_at    INTEGER,
+		ended_at      INTEGER,
+		input_tokens  INTEGER NOT NULL DEFAULT 0,
+		output_tokens INTEGER NOT NULL DEFAULT 0,
+		cache_read    INTEGER NOT NULL DEFAULT 0,
+		cache_write   INTEGER NOT NULL DEFAULT 0,
+		cost          REAL NOT NULL DEFAULT 0,
+		api_calls     INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY (session_id, id)
+	);`,
+}
+
+// Store is a SQLite database of sessions and their instance metrics. It
+// implements the orchestrator session manager's Backend, and is safe for
+// concurrent use.
+type Store struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+// New opens the database configured by session.database, or returns nil
+// when it is disabled. A relative path is resolved against baseDir, and an
+// empty one defaults to .claudio/history.db in baseDir.
+func New(cfg config.SessionDatabaseConfig, baseDir string) (*Store, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	path := cfg.Path
+	if path == "" {
+		path = filepath.Join(baseDir, ".claudio", DefaultFile)
+	} else if !filepath.IsAbs(path) {
+		path = filepath.Join(baseDir, path)
+	}
+	return Open(path)
+}
+
+// Open opens the database at path, creating it and its directory if needed,
+// and brings its schema up to date.
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("store: create database directory: %w", err)
+	}
+
+	dsn := fmt.Sprintf("file:%s?_pragma=busy_timeout(%d)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)",
+		path, busyTimeout.Milliseconds())
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("store: open %s: %w", path, err)
+	}
+	// One connection serializes this process's writes; other processes
+	// wait on the busy timeout.
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(db); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("store: migrate %s: %w", path, err)
+	}
+	return &Store{db: db, now: time.Now}, nil
+}
+
+// migrate applies the migrations the database has not seen.
+func migrate(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("database schema version %d is newer than this Claudio (%d)", version, len(migrations))
+	}
+	for ; version < len(migrations); version++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[version]); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migration %d: %w", version+1, err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, version+1)); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// SaveSession records the session and the metrics of each of its
+// instances. Instances removed from the session keep their last recorded
+// row, so their cost stays in the history. Saving a deleted session
+// restores it.
+func (s *Store) SaveSession(sess *orchsession.SessionData) error {
+	if sess == nil {
+		return nil
+	}
+	data, err := json.Marshal(sess)
+	if err != nil {
+		return fmt.Errorf("store: encode session: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store: begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(`
+		INSERT INTO sessions (id, name, base_repo, created_at, updated_at, deleted_at, data)
+		VALUES (?, ?, ?, ?, ?, NULL, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			name = excluded.name,
+			base_repo = excluded.base_repo,
+			updated_at = excluded.updated_at,
+			deleted_at = NULL,
+			data = excluded.data`,
+		sess.ID, sess.Name, sess.BaseRepo, sess.Created.UnixNano(), s.now().UnixNano(), string(data))
+	if err != nil {
+		return fmt.Errorf("store: save session %s: %w", sess.ID, err)
+	}
+
+	for _, inst := range sess.Instances {
+		if inst == nil {
+			continue
+		}
+		m := inst.Metrics
+		if m == nil {
+			m = &orchsession.MetricsData{}
+		}
+		_, err = tx.Exec(`
+			INSERT INTO instances (session_id, id, task, status, branch, created_at, started_at, ended_at,
+				input_tokens, output_tokens, cache_read, cache_write, cost, api_calls)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (session_id, id) DO UPDATE SET
+				task = excluded.task,
+				status = excluded.status,
+				branch = excluded.branch,
+				started_at = excluded.started_at,
+				ended_at = excluded.ended_at,
+				input_tokens = excluded.input_tokens,
+				output_tokens = excluded.output_tokens,
+				cache_read = excluded.cache_read,
+				cache_write = excluded.cache_write,
+				cost = excluded.cost,
+				api_calls = excluded.api_calls`,
+			sess.ID, inst.ID, inst.Task, inst.Status, inst.Branch, inst.Created.UnixNano(),
+			nullTime(m.StartTime), nullTime(m.EndTime),
+			m.InputTokens, m.OutputTokens, m.CacheRead, m.CacheWrite, m.Cost, m.APICalls)
+		if err != nil {
+			return fmt.Errorf("store: save instance %s: %w", inst.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("store: commit session %s: %w", sess.ID, err)
+	}
+	return nil
+}
+
+// LoadSession returns the last recorded state of a session, including a
+// deleted one. It returns an error wrapping ErrNotFound for unknown sessions.
+func (s *Store) LoadSession(sessionID string) (*orchsession.SessionData, error) {
+	var data string
+	err := s.db.QueryRow(`SELECT data FROM sessions WHERE id = ?`, sessionID).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, sessionID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store: load session %s: %w", sessionID, err)
+	}
+
+	var sess orchsession.SessionData
+	if err := json.Unmarshal([]byte(data), &sess); err != nil {
+		return nil, fmt.Errorf("store: decode session %s: %w", sessionID, err)
+	}
+	return &sess, nil
+}
+
+// DeleteSession marks a session deleted. Its history is kept: it still
+// counts in RecentSessions and TaskCosts, flagged as deleted.
+func (s *Store) DeleteSession(sessionID string) error {
+	_, err := s.db.Exec(`UPDATE sessions SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`,
+		s.now().UnixNano(), sessionID)
+	if err != nil {
+		return fmt.Errorf("store: delete session %s: %w", sessionID, err)
+	}
+	return nil
+}
+
+// nullTime converts an optional time to a nullable column value.
+func nullTime(t *time.Time) sql.NullInt64 {
+	if t == nil || t.IsZero() {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: t.UnixNano(), Valid: true}
+}
+
+// fromNull converts a nullable column value back to a time.
+func fromNull(v sql.NullInt64) time.Time {
+	if !v.Valid {
+		return time.Time{}
+	}
+	return time.Unix(0, v.Int64)
+}
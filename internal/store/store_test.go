@@ -0,0 +1,238 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	orchsession "github.com/Iron-Ham/claudio/internal/orchestrator/session"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+// testSession returns a session created at created with one instance per cost.
+func testSession(id string, created time.Time, costs ...float64) *orchsession.SessionData {
+	sess := &orchsession.SessionData{ID: id, Name: "session " + id, BaseRepo: "/repo", Created: created}
+	for i, cost := range costs {
+		start := created.Add(time.Duration(i) * time.Minute)
+		end := start.Add(10 * time.Minute)
+		sess.Instances = append(sess.Instances, &orchsession.InstanceData{
+			ID:      id + "-" + string(rune('a'+i)),
+			Task:    "task " + string(rune('a'+i)),
+			Status:  "completed",
+			Created: start,
+			Metrics: &orchsession.MetricsData{
+				InputTokens:  1000,
+				OutputTokens: 500,
+				Cost:         cost,
+				APICalls:     3,
+				StartTime:    &start,
+				EndTime:      &end,
+			},
+		})
+	}
+	return sess
+}
+
+func TestNew_Disabled(t *testing.T) {
+	s, err := New(config.SessionDatabaseConfig{}, t.TempDir())
+	if err != nil || s != nil {
+		t.Fatalf("New(disabled) = %v, %v; want nil, nil", s, err)
+	}
+}
+
+func TestNew_DefaultPath(t *testing.T) {
+	base := t.TempDir()
+	s, err := New(config.SessionDatabaseConfig{Enabled: true}, base)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer func() { _ = s.Close() }()
+
+	if err := s.SaveSession(testSession("s1", time.Now())); err != nil {
+		t.Fatalf("SaveSession() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(base, ".claudio", DefaultFile)); err != nil {
+		t.Errorf("database should be at .claudio/%s: %v", DefaultFile, err)
+	}
+}
+
+func TestStore_SaveAndLoadSession(t *testing.T) {
+	s := openTestStore(t)
+	sess := testSession("s1", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), 0.5)
+
+	if err := s.SaveSession(sess); err != nil {
+		t.Fatalf("SaveSession() error = %v", err)
+	}
+	loaded, err := s.LoadSession("s1")
+	if err != nil {
+		t.Fatalf("LoadSession() error = %v", err)
+	}
+	if loaded.Name != sess.Name || len(loaded.Instances) != 1 || loaded.Instances[0].Metrics.Cost != 0.5 {
+		t.Errorf("LoadSession() = %+v, want the saved session", loaded)
+	}
+	if !loaded.Created.Equal(sess.Created) {
+		t.Errorf("Created = %v, want %v", loaded.Created, sess.Created)
+	}
+
+	if _, err := s.LoadSession("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("LoadSession(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestStore_SaveSession_UpdatesAndKeepsRemovedInstances(t *testing.T) {
+	s := openTestStore(t)
+	sess := testSession("s1", time.Now(), 1, 2)
+	if err := s.SaveSession(sess); err != nil {
+		t.Fatalf("SaveSession() error = %v", err)
+	}
+
+	// The first instance spends more, the second is removed from the session
+	sess.Instances[0].Metrics.Cost = 4
+	sess.Instances = sess.Instances[:1]
+	if err := s.SaveSession(sess); err != nil {
+		t.Fatalf("SaveSession() error = %v", err)
+	}
+
+	sums, err := s.RecentSessions(0)
+	if err != nil {
+		t.Fatalf("RecentSessions() error = %v", err)
+	}
+	if len(sums) != 1 {
+		t.Fatalf("RecentSessions() returned %d sessions, want 1", len(sums))
+	}
+	if sums[0].Instances != 2 || sums[0].Cost != 6 {
+		t.Errorf("summary = %d instances, $%.2f; want 2 instances, $6.00", sums[0].Instances, sums[0].Cost)
+	}
+}
+
+func TestStore_DeleteSession(t *testing.T) {
+	s := openTestStore(t)
+	if err := s.SaveSession(testSession("s1", time.Now(), 1)); err != nil {
+		t.Fatalf("SaveSession() error = %v", err)
+	}
+	if err := s.DeleteSession("s1"); err != nil {
+		t.Fatalf("DeleteSession() error = %v", err)
+	}
+
+	sums, err := s.RecentSessions(0)
+	if err != nil {
+		t.Fatalf("RecentSessions() error = %v", err)
+	}
+	if len(sums) != 1 || !sums[0].Deleted || sums[0].Cost != 1 {
+		t.Errorf("RecentSessions() = %+v, want the deleted session with its cost", sums)
+	}
+	if _, err := s.LoadSession("s1"); err != nil {
+		t.Errorf("LoadSession() of a deleted session error = %v", err)
+	}
+}
+
+func TestStore_RecentSessions(t *testing.T) {
+	s := openTestStore(t)
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i, id := range []string{"old", "mid", "new"} {
+		if err := s.SaveSession(testSession(id, base.Add(time.Duration(i)*time.Hour), float64(i+1))); err != nil {
+			t.Fatalf("SaveSession(%s) error = %v", id, err)
+		}
+	}
+
+	sums, err := s.RecentSessions(2)
+	if err != nil {
+		t.Fatalf("RecentSessions() error = %v", err)
+	}
+	if len(sums) != 2 || sums[0].ID != "new" || sums[1].ID != "mid" {
+		t.Fatalf("RecentSessions(2) = %+v, want new then mid", sums)
+	}
+	if sums[0].InputTokens != 1000 || sums[0].OutputTokens != 500 || sums[0].Cost != 3 {
+		t.Errorf("totals = %+v", sums[0])
+	}
+}
+
+func TestStore_TaskCosts(t *testing.T) {
+	s := openTestStore(t)
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	if err := s.SaveSession(testSession("old", base, 9)); err != nil {
+		t.Fatalf("SaveSession() error = %v", err)
+	}
+	if err := s.SaveSession(testSession("new", base.Add(time.Hour), 1, 3)); err != nil {
+		t.Fatalf("SaveSession() error = %v", err)
+	}
+
+	costs, err := s.TaskCosts(1)
+	if err != nil {
+		t.Fatalf("TaskCosts() error = %v", err)
+	}
+	if len(costs) != 2 {
+		t.Fatalf("TaskCosts(1) returned %d tasks, want 2", len(costs))
+	}
+	if costs[0].Task != "task b" || costs[0].Cost != 3 || costs[1].Task != "task a" {
+		t.Errorf("TaskCosts(1) = %+v, want the newest session's tasks, most expensive first", costs)
+	}
+	if costs[0].SessionName != "session new" || costs[0].Duration != 10*time.Minute || costs[0].APICalls != 3 {
+		t.Errorf("task cost = %+v", costs[0])
+	}
+
+	all, err := s.TaskCosts(0)
+	if err != nil {
+		t.Fatalf("TaskCosts(0) error = %v", err)
+	}
+	if len(all) != 3 || all[2].SessionID != "old" {
+		t.Errorf("TaskCosts(0) = %+v, want every task, newest session first", all)
+	}
+}
+
+func TestStore_RecentSessionData(t *testing.T) {
+	s := openTestStore(t)
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i, id := range []string{"old", "new"} {
+		if err := s.SaveSession(testSession(id, base.Add(time.Duration(i)*time.Hour), 1)); err != nil {
+			t.Fatalf("SaveSession(%s) error = %v", id, err)
+		}
+	}
+	if err := s.DeleteSession("new"); err != nil {
+		t.Fatalf("DeleteSession() error = %v", err)
+	}
+
+	sessions, err := s.RecentSessionData(0)
+	if err != nil {
+		t.Fatalf("RecentSessionData() error = %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].ID != "new" || sessions[1].ID != "old" {
+		t.Fatalf("RecentSessionData(0) = %v, want new then old, including the deleted one", sessions)
+	}
+	if inst := sessions[0].Instances[0]; inst.Metrics == nil || inst.Metrics.Cost != 1 {
+		t.Errorf("instance = %+v, want its metrics", inst)
+	}
+}
+
+func TestOpen_ReopensExistingDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if err := s.SaveSession(testSession("s1", time.Now(), 1)); err != nil {
+		t.Fatalf("SaveSession() error = %v", err)
+	}
+	_ = s.Close()
+
+	s, err = Open(path)
+	if err != nil {
+		t.Fatalf("reopen error = %v", err)
+	}
+	defer func() { _ = s.Close() }()
+	if _, err := s.LoadSession("s1"); err != nil {
+		t.Errorf("LoadSession() after reopen error = %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package taskqueue
+
+import (
+	"fmt"
+	"time"
+)
+
+// ResumeResult reports what ResumeFromCheckpoint did with each claimed task.
+type ResumeResult struct {
+	// Reattached lists tasks whose instance is still alive. They keep
+	// their claim and status, and the caller resumes monitoring them.
+	Reattached []string
+
+	// Released lists tasks whose instance is gone. They are back to
+	// pending, to be claimed and started again.
+	Released []string
+}
+
+// Heartbeat records that the instance holding taskID's claim is still
+// working on it. A non-empty commitSHA records the latest commit on the
+// task's branch; an empty one keeps the previous value.
+func (q *TaskQueue) Heartbeat(taskID, commitSHA string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	task, ok := q.tasks[taskID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+	if !isClaimedStatus(task.Status) {
+		return fmt.Errorf("%w: cannot heartbeat task %s in status %s", ErrInvalidTransition, taskID, task.Status)
+	}
+
+	cp := checkpointOf(task)
+	cp.LastHeartbeat = time.Now()
+	if commitSHA != "" {
+		cp.CommitSHA = commitSHA
+	}
+	task.Checkpoint = &cp
+	return nil
+}
+
+// RecordInstance records in taskID's checkpoint the instance working on it.
+// The claim may be held under another identifier: the bridge claims a task
+// for its team before it creates the instance that runs it.
+func (q *TaskQueue) RecordInstance(taskID, instanceID string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	task, ok := q.tasks[taskID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+	if !isClaimedStatus(task.Status) {
+		return fmt.Errorf("%w: cannot record an instance for task %s in status %s", ErrInvalidTransition, taskID, task.Status)
+	}
+
+	cp := checkpointOf(task)
+	cp.InstanceID = instanceID
+	cp.LastHeartbeat = time.Now()
+	task.Checkpoint = &cp
+	return nil
+}
+
+// ResumeFromCheckpoint reconciles a queue restored with LoadState against
+// the instances that survived a coordinator restart. alive is called with
+// the checkpoint of every claimed, awaiting-approval, or running task and
+// reports whether its instance (typically its tmux session) still exists.
+// Tasks with a live instance keep their claim and get a fresh heartbeat;
+// the rest are returned to pending without counting as a retry.
+func (q *TaskQueue) ResumeFromCheckpoint(alive func(Checkpoint) bool) ResumeResult {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var result ResumeResult
+	now := time.Now()
+	for _, id := range q.order {
+		task := q.tasks[id]
+		if !isClaimedStatus(task.Status) {
+			continue
+		}
+		cp := checkpointOf(task)
+		if cp.InstanceID != "" && alive(cp) {
+			cp.LastHeartbeat = now
+			task.Checkpoint = &cp
+			result.Reattached = append(result.Reattached, id)
+			continue
+		}
+		task.Status = TaskPending
+		task.ClaimedBy = ""
+		task.ClaimedAt = nil
+		task.Checkpoint = nil
+		delete(q.claims, id)
+		result.Released = append(result.Released, id)
+	}
+	q.markReady()
+	return result
+}
+
+// isClaimedStatus reports whether an instance holds a claim on a task in
+// status s.
+func isClaimedStatus(s TaskStatus) bool {
+	return s == TaskClaimed || s == TaskAwaitingApproval || s == TaskRunning
+}
+
+// checkpointOf returns a copy of task's checkpoint. State saved before
+// checkpoints existed has none, so one is built from the claim fields.
+func checkpointOf(task *QueuedTask) Checkpoint {
+	if task.Checkpoint != nil {
+		return *task.Checkpoint
+	}
+	cp := Checkpoint{InstanceID: task.ClaimedBy}
+	if task.ClaimedAt != nil {
+		cp.ClaimedAt = *task.ClaimedAt
+		cp.LastHeartbeat = *task.ClaimedAt
+	}
+	return cp
+}
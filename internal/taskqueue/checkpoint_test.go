@@ -0,0 +1,191 @@
+package taskqueue
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+)
+
+func TestClaimNext_SetsCheckpoint(t *testing.T) {
+	q := NewFromPlan(makePlan())
+
+	task, _ := q.ClaimNext("inst-1")
+	cp := task.Checkpoint
+	if cp == nil {
+		t.Fatal("claimed task has no checkpoint")
+	}
+	if cp.InstanceID != "inst-1" || !cp.ClaimedAt.Equal(*task.ClaimedAt) || !cp.LastHeartbeat.Equal(cp.ClaimedAt) {
+		t.Errorf("checkpoint = %+v, want instance inst-1 claimed and last seen at %v", cp, *task.ClaimedAt)
+	}
+
+	if err := q.Release(task.ID); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if got := q.GetTask(task.ID).Checkpoint; got != nil {
+		t.Errorf("released task checkpoint = %+v, want nil", got)
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	q := NewFromPlan(makePlan())
+	task, _ := q.ClaimNext("inst-1")
+	_ = q.MarkRunning(task.ID)
+
+	if err := q.Heartbeat(task.ID, "abc123"); err != nil {
+		t.Fatalf("Heartbeat: %v", err)
+	}
+	if err := q.Heartbeat(task.ID, ""); err != nil {
+		t.Fatalf("Heartbeat: %v", err)
+	}
+
+	cp := q.GetTask(task.ID).Checkpoint
+	if cp.CommitSHA != "abc123" {
+		t.Errorf("CommitSHA = %q, want abc123 kept by an empty heartbeat", cp.CommitSHA)
+	}
+	if cp.LastHeartbeat.Before(cp.ClaimedAt) {
+		t.Errorf("LastHeartbeat = %v, want not before ClaimedAt %v", cp.LastHeartbeat, cp.ClaimedAt)
+	}
+	// The copy returned at claim time is not changed by later heartbeats.
+	if task.Checkpoint.CommitSHA != "" {
+		t.Errorf("earlier copy CommitSHA = %q, want empty", task.Checkpoint.CommitSHA)
+	}
+}
+
+func TestHeartbeat_Errors(t *testing.T) {
+	q := NewFromPlan(makePlan())
+
+	if err := q.Heartbeat("nonexistent", ""); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Heartbeat(nonexistent) error = %v, want ErrTaskNotFound", err)
+	}
+	if err := q.Heartbeat("task-1", ""); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Heartbeat(pending) error = %v, want ErrInvalidTransition", err)
+	}
+}
+
+func TestRecordInstance(t *testing.T) {
+	q := NewFromPlan(makePlan())
+	task, _ := q.ClaimNext("bridge-team-a")
+
+	if err := q.RecordInstance(task.ID, "inst-7"); err != nil {
+		t.Fatalf("RecordInstance: %v", err)
+	}
+	got := q.GetTask(task.ID)
+	if got.Checkpoint.InstanceID != "inst-7" {
+		t.Errorf("checkpoint InstanceID = %q, want inst-7", got.Checkpoint.InstanceID)
+	}
+	if got.ClaimedBy != "bridge-team-a" {
+		t.Errorf("ClaimedBy = %q, want the claim kept by bridge-team-a", got.ClaimedBy)
+	}
+
+	if err := q.RecordInstance("nonexistent", "inst-7"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("RecordInstance(nonexistent) error = %v, want ErrTaskNotFound", err)
+	}
+	_ = q.Release(task.ID)
+	if err := q.RecordInstance(task.ID, "inst-7"); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("RecordInstance(pending) error = %v, want ErrInvalidTransition", err)
+	}
+}
+
+func TestResumeFromCheckpoint(t *testing.T) {
+	q := NewFromPlan(makePlan())
+	_, _ = q.ClaimNext("inst-1") // task-1
+	_ = q.MarkRunning("task-1")
+	_ = q.Heartbeat("task-1", "abc123")
+	_, _ = q.ClaimNext("inst-2") // task-3
+
+	dir := t.TempDir()
+	if err := q.SaveState(dir); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	loaded, err := LoadState(dir)
+	if err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+
+	var seen []Checkpoint
+	result := loaded.ResumeFromCheckpoint(func(cp Checkpoint) bool {
+		seen = append(seen, cp)
+		return cp.InstanceID == "inst-1"
+	})
+
+	if !slices.Equal(result.Reattached, []string{"task-1"}) || !slices.Equal(result.Released, []string{"task-3"}) {
+		t.Errorf("result = %+v, want task-1 reattached and task-3 released", result)
+	}
+	if len(seen) != 2 || seen[0].CommitSHA != "abc123" {
+		t.Errorf("alive called with %+v, want the persisted checkpoints", seen)
+	}
+
+	running := loaded.GetTask("task-1")
+	if running.Status != TaskRunning || running.ClaimedBy != "inst-1" || running.Checkpoint.CommitSHA != "abc123" {
+		t.Errorf("reattached task = %+v, want running on inst-1 with its checkpoint", running)
+	}
+	released := loaded.GetTask("task-3")
+	if released.Status != TaskPending || released.ClaimedBy != "" || released.Checkpoint != nil || released.RetryCount != 0 {
+		t.Errorf("released task = %+v, want pending with no claim or retry", released)
+	}
+	if next, _ := loaded.ClaimNext("inst-3"); next == nil || next.ID != "task-3" {
+		t.Errorf("ClaimNext after resume = %v, want task-3", next)
+	}
+}
+
+func TestResumeFromCheckpoint_LegacyState(t *testing.T) {
+	q := NewFromPlan(makePlan())
+	_, _ = q.ClaimNext("inst-1")
+
+	// State saved before checkpoints existed only has the claim fields.
+	q.mu.Lock()
+	q.tasks["task-1"].Checkpoint = nil
+	claimedAt := *q.tasks["task-1"].ClaimedAt
+	q.mu.Unlock()
+
+	var got Checkpoint
+	result := q.ResumeFromCheckpoint(func(cp Checkpoint) bool {
+		got = cp
+		return true
+	})
+	if !slices.Equal(result.Reattached, []string{"task-1"}) {
+		t.Errorf("Reattached = %v, want [task-1]", result.Reattached)
+	}
+	if got.InstanceID != "inst-1" || !got.ClaimedAt.Equal(claimedAt) {
+		t.Errorf("checkpoint = %+v, want one built from the claim", got)
+	}
+	if q.GetTask("task-1").Checkpoint == nil {
+		t.Error("reattached task has no checkpoint")
+	}
+}
+
+func TestEventQueue_ResumeFromCheckpoint(t *testing.T) {
+	bus := event.NewBus()
+	col := &eventCollector{}
+	bus.SubscribeAll(col.handler)
+
+	q := NewFromPlan(makeEventPlan())
+	eq := NewEventQueue(q, bus)
+	task, _ := eq.ClaimNext("inst-1")
+
+	*col = eventCollector{}
+
+	result := eq.ResumeFromCheckpoint(func(Checkpoint) bool { return false })
+	if !slices.Equal(result.Released, []string{task.ID}) {
+		t.Fatalf("Released = %v, want [%s]", result.Released, task.ID)
+	}
+
+	released := col.findByType("queue.task_released")
+	if len(released) != 1 {
+		t.Fatalf("expected 1 TaskReleasedEvent, got %d", len(released))
+	}
+	if re := released[0].(event.TaskReleasedEvent); re.Reason != "instance_lost" {
+		t.Errorf("TaskReleasedEvent.Reason = %q, want instance_lost", re.Reason)
+	}
+	if depth := col.findByType("queue.depth_changed"); len(depth) != 1 {
+		t.Errorf("expected 1 QueueDepthChangedEvent, got %d", len(depth))
+	}
+
+	*col = eventCollector{}
+	eq.ResumeFromCheckpoint(func(Checkpoint) bool { return true })
+	if col.count() != 0 {
+		t.Errorf("resume with nothing released published %d events, want 0", col.count())
+	}
+}
@@ -43,7 +43,8 @@ func (q *TaskQueue) unblockedBy(taskID string) []string {
 	return unblocked
 }
 
-// buildPriorityOrder computes the task ordering used for claim selection.
+// buildPriorityOrder computes the task ordering used for listing tasks and
+// for breaking ties between ready tasks of equal priority when claiming.
 // Tasks are ordered by execution group (topological level), then by priority
 // within each group. This preserves the natural dependency ordering while
 // respecting priority for tasks at the same level.
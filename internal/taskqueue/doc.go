@@ -11,8 +11,21 @@
 // Dependencies are tracked internally so that completing a task automatically
 // unblocks downstream tasks for claiming.
 //
+// Ready tasks are claimed in priority order (lower PlannedTask.Priority
+// first). So that a steady supply of urgent tasks can't starve the rest, a
+// ready task gains one priority level for every [DefaultPriorityAging] it
+// waits (see [TaskQueue.SetPriorityAging]). [TaskQueue.PreemptionCandidate]
+// finds a running task of much lower priority whose instance a long-waiting
+// task could take, and [TaskQueue.Preempt] returns it to pending; the
+// adaptive lead uses the pair when preemption is enabled.
+//
 // Queue state can be persisted to disk and restored, enabling crash recovery
-// during long-running plan executions.
+// during long-running plan executions. Each claimed task carries a
+// [Checkpoint] (instance, claim time, last heartbeat, latest commit), kept
+// fresh with [TaskQueue.Heartbeat]. After a restart,
+// [TaskQueue.ResumeFromCheckpoint] keeps the claims of tasks whose instance
+// is still running, so the coordinator reattaches to them, and returns the
+// rest to pending.
 //
 // Usage:
 //
@@ -4,12 +4,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"syscall"
 )
 
 const lockFileName = "taskqueue.lock"
 
-// FileLock provides cross-process mutual exclusion using flock(2).
+// FileLock provides cross-process mutual exclusion using flock(2), or
+// LockFileEx on Windows.
 // Used to protect queue state files when multiple Claudio processes
 // may be accessing the same session directory.
 type FileLock struct {
@@ -35,7 +35,7 @@ func (fl *FileLock) Lock() error {
 	}
 	fl.file = f
 
-	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+	if err := lockFile(f); err != nil {
 		_ = f.Close()
 		fl.file = nil
 		return fmt.Errorf("flock: %w", err)
@@ -51,13 +51,13 @@ func (fl *FileLock) TryLock() (bool, error) {
 		return false, fmt.Errorf("open lock file: %w", err)
 	}
 
-	err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
-	if err != nil {
+	locked, err := tryLockFile(f)
+	if err != nil || !locked {
 		_ = f.Close()
-		if err == syscall.EWOULDBLOCK {
-			return false, nil
+		if err != nil {
+			return false, fmt.Errorf("flock: %w", err)
 		}
-		return false, fmt.Errorf("flock: %w", err)
+		return false, nil
 	}
 
 	fl.file = f
@@ -70,7 +70,7 @@ func (fl *FileLock) Unlock() error {
 		return nil
 	}
 
-	if err := syscall.Flock(int(fl.file.Fd()), syscall.LOCK_UN); err != nil {
+	if err := unlockFile(fl.file); err != nil {
 		_ = fl.file.Close()
 		fl.file = nil
 		return fmt.Errorf("funlock: %w", err)
@@ -5,14 +5,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/Iron-Ham/claudio/internal/session/migrate"
 )
 
-const stateFileName = "taskqueue-state.json"
+const stateFileName = migrate.QueueFileName
 
 // persistedState is the serializable representation of the queue.
 type persistedState struct {
-	Tasks map[string]*QueuedTask `json:"tasks"`
-	Order []string               `json:"order"`
+	FormatVersion int                    `json:"format_version"`
+	Tasks         map[string]*QueuedTask `json:"tasks"`
+	Order         []string               `json:"order"`
 }
 
 // SaveState writes the queue state to a JSON file in the given directory.
@@ -28,8 +31,9 @@ func (q *TaskQueue) SaveState(dir string) error {
 
 	q.mu.Lock()
 	data, err := json.MarshalIndent(persistedState{
-		Tasks: q.tasks,
-		Order: q.order,
+		FormatVersion: migrate.QueueVersion,
+		Tasks:         q.tasks,
+		Order:         q.order,
 	}, "", "  ")
 	q.mu.Unlock()
 	if err != nil {
@@ -67,6 +71,9 @@ func LoadState(dir string) (*TaskQueue, error) {
 	if err != nil {
 		return nil, fmt.Errorf("read state file: %w", err)
 	}
+	if err := migrate.Check(migrate.KindQueue, data); err != nil {
+		return nil, err
+	}
 
 	var state persistedState
 	if err := json.Unmarshal(data, &state); err != nil {
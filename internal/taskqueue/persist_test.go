@@ -1,10 +1,13 @@
 package taskqueue
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/Iron-Ham/claudio/internal/session/migrate"
 )
 
 func TestSaveAndLoadState(t *testing.T) {
@@ -14,7 +17,7 @@ func TestSaveAndLoadState(t *testing.T) {
 	_, _ = q.ClaimNext("inst-1") // claims task-1
 	_ = q.MarkRunning("task-1")
 	_, _ = q.Complete("task-1")
-	_, _ = q.ClaimNext("inst-2") // claims task-3
+	_, _ = q.ClaimNext("inst-2") // claims task-2, which outranks task-3
 
 	dir := t.TempDir()
 	if err := q.SaveState(dir); err != nil {
@@ -37,14 +40,17 @@ func TestSaveAndLoadState(t *testing.T) {
 	if loaded.tasks["task-1"].Status != TaskCompleted {
 		t.Errorf("task-1 status = %s, want completed", loaded.tasks["task-1"].Status)
 	}
-	if loaded.tasks["task-3"].Status != TaskClaimed {
-		t.Errorf("task-3 status = %s, want claimed", loaded.tasks["task-3"].Status)
+	if loaded.tasks["task-2"].Status != TaskClaimed {
+		t.Errorf("task-2 status = %s, want claimed", loaded.tasks["task-2"].Status)
+	}
+	if loaded.tasks["task-2"].ClaimedBy != "inst-2" {
+		t.Errorf("task-2 ClaimedBy = %q, want inst-2", loaded.tasks["task-2"].ClaimedBy)
 	}
-	if loaded.tasks["task-3"].ClaimedBy != "inst-2" {
-		t.Errorf("task-3 ClaimedBy = %q, want inst-2", loaded.tasks["task-3"].ClaimedBy)
+	if loaded.tasks["task-3"].Status != TaskPending {
+		t.Errorf("task-3 status = %s, want pending", loaded.tasks["task-3"].Status)
 	}
-	if loaded.tasks["task-2"].Status != TaskPending {
-		t.Errorf("task-2 status = %s, want pending", loaded.tasks["task-2"].Status)
+	if loaded.tasks["task-3"].ReadyAt == nil {
+		t.Error("task-3 ReadyAt was not persisted")
 	}
 
 	// Verify order is preserved
@@ -102,6 +108,18 @@ func TestLoadState_InvalidJSON(t *testing.T) {
 	}
 }
 
+func TestLoadState_NewerVersion(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, stateFileName)
+	if err := os.WriteFile(path, []byte(`{"format_version": 99, "tasks": {}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadState(dir); !errors.Is(err, migrate.ErrNewerVersion) {
+		t.Errorf("LoadState error = %v, want ErrNewerVersion", err)
+	}
+}
+
 func TestLoadState_EmptyState(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, stateFileName)
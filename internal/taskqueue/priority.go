@@ -0,0 +1,157 @@
+package taskqueue
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultPriorityAging is how long a ready task waits before its priority
+// is raised by one level.
+const DefaultPriorityAging = time.Minute
+
+// maxPreemptions is how many times a task can be preempted. A task preempted
+// once runs to completion when claimed again, so two tasks never take turns
+// interrupting each other.
+const maxPreemptions = 1
+
+// Preemption is a ready task waiting for an instance and the running task
+// whose instance it should take.
+type Preemption struct {
+	// TaskID is the ready task waiting for an instance.
+	TaskID string
+
+	// VictimID is the lower-priority running task to pause.
+	VictimID string
+
+	// InstanceID is the instance running the victim.
+	InstanceID string
+}
+
+// SetPriorityAging sets how long a ready task waits before its priority is
+// raised by one level, so low-priority tasks aren't starved by a steady
+// supply of higher-priority ones. Zero or less disables aging.
+func (q *TaskQueue) SetPriorityAging(every time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.aging = every
+}
+
+// effectivePriority returns task's priority raised by one level for every
+// aging interval it has been ready without being claimed. Lower is more
+// urgent, as for PlannedTask.Priority.
+func (q *TaskQueue) effectivePriority(task *QueuedTask, now time.Time) int {
+	if q.aging <= 0 || task.ReadyAt == nil {
+		return task.Priority
+	}
+	return task.Priority - int(now.Sub(*task.ReadyAt)/q.aging)
+}
+
+// markReady records when each claimable task became ready. It is called
+// after every transition that can make a task claimable; a task keeps its
+// time across claims that end back in pending, so its aging continues.
+func (q *TaskQueue) markReady() {
+	now := q.now()
+	for _, id := range q.order {
+		task := q.tasks[id]
+		if task.ReadyAt == nil && q.isClaimable(task) {
+			task.ReadyAt = &now
+		}
+	}
+}
+
+// nextClaimable returns the claimable task accepted by accept (nil accepts
+// every task) with the best effective priority, the earliest in order among
+// equals, or nil.
+func (q *TaskQueue) nextClaimable(accept func(*QueuedTask) bool) *QueuedTask {
+	now := q.now()
+	var best *QueuedTask
+	bestPriority := 0
+	for _, id := range q.order {
+		task := q.tasks[id]
+		if !q.isClaimable(task) || (accept != nil && !accept(task)) {
+			continue
+		}
+		if p := q.effectivePriority(task, now); best == nil || p < bestPriority {
+			best, bestPriority = task, p
+		}
+	}
+	return best
+}
+
+// PreemptionCandidate returns the preemption that would start the most
+// urgent task left waiting: a task ready for at least wait whose priority is
+// better by at least minGap than that of a running task not yet preempted.
+// The victim is the running task with the worst priority, the most recently
+// claimed among equals, so the least work is interrupted. Base priorities
+// are compared, so aging never lets a task preempt the task that preempted
+// it. It reports false when there is no such pair.
+func (q *TaskQueue) PreemptionCandidate(wait time.Duration, minGap int) (Preemption, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.markReady()
+	now := q.now()
+
+	var waiting *QueuedTask
+	for _, id := range q.order {
+		task := q.tasks[id]
+		if !q.isClaimable(task) || task.ReadyAt == nil || now.Sub(*task.ReadyAt) < wait {
+			continue
+		}
+		if waiting == nil || task.Priority < waiting.Priority {
+			waiting = task
+		}
+	}
+	if waiting == nil {
+		return Preemption{}, false
+	}
+
+	var victim *QueuedTask
+	for _, id := range q.order {
+		task := q.tasks[id]
+		if task.Status != TaskRunning || task.Preemptions >= maxPreemptions ||
+			task.Priority-waiting.Priority < minGap {
+			continue
+		}
+		if victim == nil || task.Priority > victim.Priority ||
+			(task.Priority == victim.Priority && claimedAfter(task, victim)) {
+			victim = task
+		}
+	}
+	if victim == nil {
+		return Preemption{}, false
+	}
+	return Preemption{TaskID: waiting.ID, VictimID: victim.ID, InstanceID: victim.ClaimedBy}, true
+}
+
+// claimedAfter reports whether a was claimed after b.
+func claimedAfter(a, b *QueuedTask) bool {
+	return a.ClaimedAt != nil && b.ClaimedAt != nil && a.ClaimedAt.After(*b.ClaimedAt)
+}
+
+// Preempt returns a running task to pending so its instance can be given to
+// a more urgent task. Unlike Fail it does not count as a retry. The task's
+// ready time restarts, since it has had its turn, so it doesn't outrank the
+// task it made room for.
+func (q *TaskQueue) Preempt(taskID string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	task, ok := q.tasks[taskID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+	if task.Status != TaskRunning {
+		return fmt.Errorf("%w: cannot preempt task %s in status %s", ErrInvalidTransition, taskID, task.Status)
+	}
+
+	now := q.now()
+	task.Status = TaskPending
+	task.ClaimedBy = ""
+	task.ClaimedAt = nil
+	task.Checkpoint = nil
+	task.Preemptions++
+	task.ReadyAt = &now
+	delete(q.claims, taskID)
+	return nil
+}
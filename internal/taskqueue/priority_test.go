@@ -0,0 +1,228 @@
+package taskqueue
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/ultraplan"
+)
+
+// priorityQueue returns a queue of independent tasks with the given
+// priorities, in plan order, and a clock the test can advance.
+func priorityQueue(priorities map[string]int) (*TaskQueue, *time.Time) {
+	plan := &ultraplan.PlanSpec{ID: "p"}
+	for _, id := range []string{"a", "b", "c", "d"} {
+		if p, ok := priorities[id]; ok {
+			plan.Tasks = append(plan.Tasks, ultraplan.PlannedTask{ID: id, Title: id, Priority: p})
+		}
+	}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	q := NewFromPlan(plan)
+	q.now = func() time.Time { return now }
+	markReadyAt(q, now)
+	return q, &now
+}
+
+// markReadyAt resets the ready time of every pending task to t.
+func markReadyAt(q *TaskQueue, t time.Time) {
+	for _, task := range q.tasks {
+		if task.Status == TaskPending {
+			task.ReadyAt = &t
+		}
+	}
+}
+
+func claimID(t *testing.T, q *TaskQueue, instanceID string) string {
+	t.Helper()
+	task, err := q.ClaimNext(instanceID)
+	if err != nil {
+		t.Fatalf("ClaimNext(%s): %v", instanceID, err)
+	}
+	if task == nil {
+		return ""
+	}
+	return task.ID
+}
+
+func TestClaimNext_PrefersPriorityAcrossLevels(t *testing.T) {
+	q := NewFromPlan(&ultraplan.PlanSpec{ID: "p", Tasks: []ultraplan.PlannedTask{
+		{ID: "setup", Title: "setup"},
+		{ID: "docs", Title: "docs", Priority: 5},
+		{ID: "core", Title: "core", DependsOn: []string{"setup"}, Priority: 1},
+	}})
+
+	if got := claimID(t, q, "inst-1"); got != "setup" {
+		t.Fatalf("first claim = %q, want setup", got)
+	}
+	_ = q.MarkRunning("setup")
+	_, _ = q.Complete("setup")
+
+	// core is a level deeper than docs but more urgent
+	if got := claimID(t, q, "inst-2"); got != "core" {
+		t.Errorf("second claim = %q, want core", got)
+	}
+}
+
+func TestClaimNext_PriorityAging(t *testing.T) {
+	q, now := priorityQueue(map[string]int{"a": 3})
+	q.SetPriorityAging(time.Minute)
+
+	// a has waited three minutes when b becomes ready: both are at 0, and a
+	// comes first in plan order
+	*now = now.Add(3 * time.Minute)
+	q.tasks["b"] = &QueuedTask{PlannedTask: ultraplan.PlannedTask{ID: "b", Title: "b", DependsOn: []string{}}, Status: TaskPending}
+	q.order = append(q.order, "b")
+
+	if got := claimID(t, q, "inst-1"); got != "a" {
+		t.Errorf("claim = %q, want the aged task a", got)
+	}
+	if q.tasks["b"].ReadyAt == nil || !q.tasks["b"].ReadyAt.Equal(*now) {
+		t.Errorf("b ReadyAt = %v, want %v", q.tasks["b"].ReadyAt, *now)
+	}
+}
+
+func TestClaimNext_AgingDisabled(t *testing.T) {
+	q, now := priorityQueue(map[string]int{"a": 3, "b": 1})
+	q.SetPriorityAging(0)
+	*now = now.Add(time.Hour)
+	q.tasks["b"].ReadyAt = now
+
+	if got := claimID(t, q, "inst-1"); got != "b" {
+		t.Errorf("claim = %q, want b with aging disabled", got)
+	}
+}
+
+func TestClaimNext_RetryKeepsReadyTime(t *testing.T) {
+	q, now := priorityQueue(map[string]int{"a": 0})
+	ready := *q.tasks["a"].ReadyAt
+
+	claimID(t, q, "inst-1")
+	*now = now.Add(time.Minute)
+	if err := q.Fail("a", "boom"); err != nil {
+		t.Fatal(err)
+	}
+	if got := q.tasks["a"].ReadyAt; got == nil || !got.Equal(ready) {
+		t.Errorf("ReadyAt after retry = %v, want %v", got, ready)
+	}
+}
+
+func TestPreemptionCandidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		priorities map[string]int
+		running    []string // claimed in order, a minute apart
+		waited     time.Duration
+		minGap     int
+		want       Preemption
+		wantOK     bool
+	}{
+		{
+			name:       "waiting task outranks running task",
+			priorities: map[string]int{"a": 5, "b": 0},
+			running:    []string{"a"},
+			waited:     2 * time.Minute,
+			minGap:     2,
+			want:       Preemption{TaskID: "b", VictimID: "a", InstanceID: "inst-a"},
+			wantOK:     true,
+		},
+		{
+			name:       "not waited long enough",
+			priorities: map[string]int{"a": 5, "b": 0},
+			running:    []string{"a"},
+			waited:     30 * time.Second,
+			minGap:     2,
+		},
+		{
+			name:       "gap too small",
+			priorities: map[string]int{"a": 1, "b": 0},
+			running:    []string{"a"},
+			waited:     2 * time.Minute,
+			minGap:     2,
+		},
+		{
+			name:       "worst priority victim",
+			priorities: map[string]int{"a": 4, "b": 6, "c": 0},
+			running:    []string{"a", "b"},
+			waited:     2 * time.Minute,
+			minGap:     2,
+			want:       Preemption{TaskID: "c", VictimID: "b", InstanceID: "inst-b"},
+			wantOK:     true,
+		},
+		{
+			name:       "most recently claimed victim among equals",
+			priorities: map[string]int{"a": 5, "b": 5, "c": 0},
+			running:    []string{"a", "b"},
+			waited:     2 * time.Minute,
+			minGap:     2,
+			want:       Preemption{TaskID: "c", VictimID: "b", InstanceID: "inst-b"},
+			wantOK:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, now := priorityQueue(tt.priorities)
+			q.SetPriorityAging(0)
+			for _, id := range tt.running {
+				task := q.tasks[id]
+				claimedAt := *now
+				task.Status = TaskRunning
+				task.ClaimedBy = "inst-" + id
+				task.ClaimedAt = &claimedAt
+				q.claims[id] = task.ClaimedBy
+				*now = now.Add(time.Minute)
+			}
+			markReadyAt(q, *now)
+			*now = now.Add(tt.waited)
+
+			got, ok := q.PreemptionCandidate(time.Minute, tt.minGap)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("PreemptionCandidate() = %+v, %v; want %+v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestPreempt(t *testing.T) {
+	q, now := priorityQueue(map[string]int{"a": 5, "b": 0})
+	q.SetPriorityAging(time.Minute)
+	q.tasks["b"].Status = TaskCompleted // keep b out of the first claim
+	claimID(t, q, "inst-1")
+	_ = q.MarkRunning("a")
+	q.tasks["b"].Status = TaskPending
+	*now = now.Add(10 * time.Minute)
+
+	if err := q.Preempt("a"); err != nil {
+		t.Fatalf("Preempt() error = %v", err)
+	}
+	a := q.tasks["a"]
+	if a.Status != TaskPending || a.ClaimedBy != "" || a.Checkpoint != nil || a.RetryCount != 0 || a.Preemptions != 1 {
+		t.Errorf("preempted task = %+v, want pending, unclaimed, not retried, preempted once", a)
+	}
+	if _, ok := q.claims["a"]; ok {
+		t.Error("claim kept after preemption")
+	}
+
+	// The freed instance goes to b, not back to a
+	if got := claimID(t, q, "inst-1"); got != "b" {
+		t.Errorf("claim after preemption = %q, want b", got)
+	}
+
+	// A task is preempted once at most
+	claimID(t, q, "inst-2")
+	_ = q.MarkRunning("a")
+	q.tasks["c"] = &QueuedTask{PlannedTask: ultraplan.PlannedTask{ID: "c", Title: "c", DependsOn: []string{}}, Status: TaskPending}
+	q.order = append(q.order, "c")
+	*now = now.Add(time.Hour)
+	if p, ok := q.PreemptionCandidate(0, 1); ok {
+		t.Errorf("PreemptionCandidate() = %+v, want none for a task already preempted", p)
+	}
+
+	if err := q.Preempt("c"); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Preempt(pending) error = %v, want ErrInvalidTransition", err)
+	}
+	if err := q.Preempt("missing"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Preempt(missing) error = %v, want ErrTaskNotFound", err)
+	}
+}
@@ -16,6 +16,7 @@ const defaultMaxRetries = 2
 var (
 	ErrTaskNotFound      = errors.New("task not found")
 	ErrInvalidTransition = errors.New("invalid status transition")
+	ErrTaskExists        = errors.New("task already exists")
 )
 
 // TaskQueue manages a set of tasks with dependency-aware claiming.
@@ -25,6 +26,8 @@ type TaskQueue struct {
 	tasks  map[string]*QueuedTask // taskID -> task
 	claims map[string]string      // taskID -> instanceID
 	order  []string               // task IDs in priority/topological order
+	aging  time.Duration          // ready wait per priority level gained; 0 disables
+	now    func() time.Time       // clock, replaceable in tests
 }
 
 // NewFromPlan creates a TaskQueue from an Ultra-Plan specification.
@@ -34,23 +37,36 @@ func NewFromPlan(plan *ultraplan.PlanSpec) *TaskQueue {
 	tasks := make(map[string]*QueuedTask, len(plan.Tasks))
 	claims := make(map[string]string)
 	for i := range plan.Tasks {
-		pt := plan.Tasks[i]
-		if pt.DependsOn == nil {
-			pt.DependsOn = []string{}
-		}
-		tasks[pt.ID] = &QueuedTask{
-			PlannedTask: pt,
-			Status:      TaskPending,
-			MaxRetries:  defaultMaxRetries,
-		}
+		tasks[plan.Tasks[i].ID] = newQueuedTask(plan.Tasks[i])
 	}
 
 	order := buildPriorityOrder(tasks)
 
-	return &TaskQueue{
+	q := &TaskQueue{
 		tasks:  tasks,
 		claims: claims,
 		order:  order,
+		aging:  DefaultPriorityAging,
+		now:    time.Now,
+	}
+	q.markReady()
+	return q
+}
+
+// newQueuedTask wraps a planned task as a pending queue entry.
+func newQueuedTask(pt ultraplan.PlannedTask) *QueuedTask {
+	if pt.DependsOn == nil {
+		pt.DependsOn = []string{}
+	}
+	maxRetries := defaultMaxRetries
+	if pt.IsDeterministic() {
+		// Re-running a scripted command reproduces the same failure.
+		maxRetries = 0
+	}
+	return &QueuedTask{
+		PlannedTask: pt,
+		Status:      TaskPending,
+		MaxRetries:  maxRetries,
 	}
 }
 
@@ -63,17 +79,30 @@ func newFromTasks(tasks map[string]*QueuedTask, order []string) *TaskQueue {
 			claims[id] = task.ClaimedBy
 		}
 	}
-	return &TaskQueue{
+	q := &TaskQueue{
 		tasks:  tasks,
 		claims: claims,
 		order:  order,
+		aging:  DefaultPriorityAging,
+		now:    time.Now,
 	}
+	q.markReady()
+	return q
 }
 
 // ClaimNext returns the next claimable task for the given instance.
 // A task is claimable if it is pending and all its dependencies are completed.
+// Among claimable tasks the one with the best priority wins, after aging
+// raises the priority of tasks that have waited (see SetPriorityAging).
 // Returns nil with no error if no tasks are currently available.
 func (q *TaskQueue) ClaimNext(instanceID string) (*QueuedTask, error) {
+	return q.ClaimNextMatching(instanceID, nil)
+}
+
+// ClaimNextMatching is ClaimNext restricted to the tasks accept reports true
+// for. accept is called with the queue locked and must not call back into it
+// or modify the task; a nil accept matches every task.
+func (q *TaskQueue) ClaimNextMatching(instanceID string, accept func(*QueuedTask) bool) (*QueuedTask, error) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
@@ -81,20 +110,20 @@ func (q *TaskQueue) ClaimNext(instanceID string) (*QueuedTask, error) {
 		return nil, errors.New("instanceID must not be empty")
 	}
 
-	for _, id := range q.order {
-		task := q.tasks[id]
-		if q.isClaimable(task) {
-			now := time.Now()
-			task.Status = TaskClaimed
-			task.ClaimedBy = instanceID
-			task.ClaimedAt = &now
-			q.claims[task.ID] = instanceID
-			// Return a copy to avoid data races on the internal task pointer.
-			cp := *task
-			return &cp, nil
-		}
+	q.markReady()
+	task := q.nextClaimable(accept)
+	if task == nil {
+		return nil, nil
 	}
-	return nil, nil
+	now := q.now()
+	task.Status = TaskClaimed
+	task.ClaimedBy = instanceID
+	task.ClaimedAt = &now
+	task.Checkpoint = &Checkpoint{InstanceID: instanceID, ClaimedAt: now, LastHeartbeat: now}
+	q.claims[task.ID] = instanceID
+	// Return a copy to avoid data races on the internal task pointer.
+	cp := *task
+	return &cp, nil
 }
 
 // MarkRunning transitions a claimed task to the running state.
@@ -131,6 +160,7 @@ func (q *TaskQueue) Complete(taskID string) ([]string, error) {
 	task.CompletedAt = &now
 
 	unblocked := q.unblockedBy(taskID)
+	q.markReady()
 	return unblocked, nil
 }
 
@@ -156,7 +186,9 @@ func (q *TaskQueue) Fail(taskID, failureContext string) error {
 		task.Status = TaskPending
 		task.ClaimedBy = ""
 		task.ClaimedAt = nil
+		task.Checkpoint = nil
 		delete(q.claims, taskID)
+		q.markReady()
 	} else {
 		// Permanently failed
 		now := time.Now()
@@ -183,7 +215,9 @@ func (q *TaskQueue) Release(taskID string) error {
 	task.Status = TaskPending
 	task.ClaimedBy = ""
 	task.ClaimedAt = nil
+	task.Checkpoint = nil
 	delete(q.claims, taskID)
+	q.markReady()
 	return nil
 }
 
@@ -227,6 +261,59 @@ func (q *TaskQueue) IsComplete() bool {
 	return len(q.tasks) > 0
 }
 
+// AddTask adds a pending task to a queue that may already be in use, so work
+// discovered during execution can run without a new plan. Its dependencies
+// must be tasks of this queue that have not failed: a task waiting on a failed
+// task could never be claimed and would keep the queue from completing.
+func (q *TaskQueue) AddTask(pt ultraplan.PlannedTask) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if pt.ID == "" {
+		return errors.New("task ID must not be empty")
+	}
+	if _, ok := q.tasks[pt.ID]; ok {
+		return fmt.Errorf("%w: %s", ErrTaskExists, pt.ID)
+	}
+	for _, depID := range pt.DependsOn {
+		dep, ok := q.tasks[depID]
+		if !ok {
+			return fmt.Errorf("%w: dependency %s of %s", ErrTaskNotFound, depID, pt.ID)
+		}
+		if dep.Status == TaskFailed {
+			return fmt.Errorf("task %s depends on failed task %s", pt.ID, depID)
+		}
+	}
+
+	q.tasks[pt.ID] = newQueuedTask(pt)
+	q.order = buildPriorityOrder(q.tasks)
+	q.markReady()
+	return nil
+}
+
+// RemoveTask drops a task that has not completed, whatever its status, so a
+// revised plan can discard or redefine it. A claimed or running task loses its
+// claim; stopping the instance working on it is up to the caller. Tasks that
+// depend on it stay blocked until a task with its ID is added again.
+func (q *TaskQueue) RemoveTask(taskID string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	task, ok := q.tasks[taskID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+	if task.Status == TaskCompleted {
+		return fmt.Errorf("%w: cannot remove completed task %s", ErrInvalidTransition, taskID)
+	}
+
+	delete(q.tasks, taskID)
+	delete(q.claims, taskID)
+	q.order = buildPriorityOrder(q.tasks)
+	q.markReady()
+	return nil
+}
+
 // GetTask returns the task with the given ID, or nil if not found.
 func (q *TaskQueue) GetTask(taskID string) *QueuedTask {
 	q.mu.Lock()
@@ -285,10 +372,12 @@ func (q *TaskQueue) ReleaseStaleClaimed(cutoff time.Time) []string {
 			task.Status = TaskPending
 			task.ClaimedBy = ""
 			task.ClaimedAt = nil
+			task.Checkpoint = nil
 			delete(q.claims, task.ID)
 			released = append(released, task.ID)
 		}
 	}
+	q.markReady()
 	return released
 }
 
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/ultraplan"
 )
 
 // EventQueue wraps a TaskQueue and publishes events to an event bus
@@ -23,10 +24,16 @@ func NewEventQueue(q *TaskQueue, bus *event.Bus) *EventQueue {
 // ClaimNext claims the next available task and publishes a TaskClaimedEvent
 // and a QueueDepthChangedEvent.
 func (eq *EventQueue) ClaimNext(instanceID string) (*QueuedTask, error) {
+	return eq.ClaimNextMatching(instanceID, nil)
+}
+
+// ClaimNextMatching claims the next available task that accept reports true
+// for and publishes the same events as ClaimNext.
+func (eq *EventQueue) ClaimNextMatching(instanceID string, accept func(*QueuedTask) bool) (*QueuedTask, error) {
 	eq.mu.Lock()
 	defer eq.mu.Unlock()
 
-	task, err := eq.q.ClaimNext(instanceID)
+	task, err := eq.q.ClaimNextMatching(instanceID, accept)
 	if err != nil {
 		return nil, err
 	}
@@ -39,6 +46,33 @@ func (eq *EventQueue) ClaimNext(instanceID string) (*QueuedTask, error) {
 	return task, nil
 }
 
+// AddTask adds a task to the queue and publishes a QueueDepthChangedEvent,
+// which wakes claimers waiting for work.
+func (eq *EventQueue) AddTask(pt ultraplan.PlannedTask) error {
+	eq.mu.Lock()
+	defer eq.mu.Unlock()
+
+	if err := eq.q.AddTask(pt); err != nil {
+		return err
+	}
+	eq.publishDepth()
+	return nil
+}
+
+// RemoveTask removes a task from the queue and publishes a TaskRemovedEvent
+// and a QueueDepthChangedEvent.
+func (eq *EventQueue) RemoveTask(taskID string) error {
+	eq.mu.Lock()
+	defer eq.mu.Unlock()
+
+	if err := eq.q.RemoveTask(taskID); err != nil {
+		return err
+	}
+	eq.bus.Publish(event.NewTaskRemovedEvent(taskID))
+	eq.publishDepth()
+	return nil
+}
+
 // MarkRunning transitions a task to running and publishes a QueueDepthChangedEvent.
 func (eq *EventQueue) MarkRunning(taskID string) error {
 	eq.mu.Lock()
@@ -155,3 +189,52 @@ func (eq *EventQueue) ClaimStaleBefore(cutoff time.Time) []string {
 	}
 	return released
 }
+
+// Heartbeat records progress on a claimed task. It publishes no event.
+func (eq *EventQueue) Heartbeat(taskID, commitSHA string) error {
+	return eq.q.Heartbeat(taskID, commitSHA)
+}
+
+// RecordInstance records the instance working on a claimed task. It
+// publishes no event.
+func (eq *EventQueue) RecordInstance(taskID, instanceID string) error {
+	return eq.q.RecordInstance(taskID, instanceID)
+}
+
+// ResumeFromCheckpoint reattaches claimed tasks whose instance is alive and
+// releases the rest, publishing a TaskReleasedEvent for each released task
+// and a QueueDepthChangedEvent if any were released.
+func (eq *EventQueue) ResumeFromCheckpoint(alive func(Checkpoint) bool) ResumeResult {
+	eq.mu.Lock()
+	defer eq.mu.Unlock()
+
+	result := eq.q.ResumeFromCheckpoint(alive)
+
+	for _, id := range result.Released {
+		eq.bus.Publish(event.NewTaskReleasedEvent(id, "instance_lost"))
+	}
+	if len(result.Released) > 0 {
+		eq.publishDepth()
+	}
+	return result
+}
+
+// PreemptionCandidate returns the preemption that would start the most
+// urgent waiting task. It publishes no event.
+func (eq *EventQueue) PreemptionCandidate(wait time.Duration, minGap int) (Preemption, bool) {
+	return eq.q.PreemptionCandidate(wait, minGap)
+}
+
+// Preempt returns a running task to pending and publishes a
+// TaskReleasedEvent and a QueueDepthChangedEvent.
+func (eq *EventQueue) Preempt(taskID string) error {
+	eq.mu.Lock()
+	defer eq.mu.Unlock()
+
+	if err := eq.q.Preempt(taskID); err != nil {
+		return err
+	}
+	eq.bus.Publish(event.NewTaskReleasedEvent(taskID, "preempted"))
+	eq.publishDepth()
+	return nil
+}
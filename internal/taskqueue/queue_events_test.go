@@ -306,6 +306,33 @@ func TestEventQueue_Release_Error(t *testing.T) {
 	}
 }
 
+func TestEventQueue_RemoveTask(t *testing.T) {
+	bus := event.NewBus()
+	col := &eventCollector{}
+	bus.SubscribeAll(col.handler)
+
+	q := NewFromPlan(makeEventPlan())
+	eq := NewEventQueue(q, bus)
+
+	if err := eq.RemoveTask("nonexistent"); err == nil {
+		t.Error("expected error")
+	}
+	if col.count() != 0 {
+		t.Errorf("expected 0 events on error, got %d", col.count())
+	}
+
+	if err := eq.RemoveTask("t2"); err != nil {
+		t.Fatalf("RemoveTask: %v", err)
+	}
+	removed := col.findByType("queue.task_removed")
+	if len(removed) != 1 || removed[0].(event.TaskRemovedEvent).TaskID != "t2" {
+		t.Fatalf("TaskRemovedEvents = %v, want one for t2", removed)
+	}
+	if depth := col.findByType("queue.depth_changed"); len(depth) != 1 {
+		t.Fatalf("expected 1 QueueDepthChangedEvent, got %d", len(depth))
+	}
+}
+
 func TestEventQueue_Passthrough(t *testing.T) {
 	bus := event.NewBus()
 	q := NewFromPlan(makeEventPlan())
@@ -1,6 +1,7 @@
 package taskqueue
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -105,6 +106,23 @@ func TestNewFromPlan(t *testing.T) {
 	}
 }
 
+func TestNewFromPlan_ToolTaskNotRetried(t *testing.T) {
+	plan := &ultraplan.PlanSpec{
+		Tasks: []ultraplan.PlannedTask{
+			{ID: "llm", Title: "LLM task"},
+			{ID: "fmt", Title: "Format", Backend: "tool", Command: "gofmt -w ."},
+		},
+	}
+	q := NewFromPlan(plan)
+
+	if q.tasks["llm"].MaxRetries != defaultMaxRetries {
+		t.Errorf("llm MaxRetries = %d, want %d", q.tasks["llm"].MaxRetries, defaultMaxRetries)
+	}
+	if q.tasks["fmt"].MaxRetries != 0 {
+		t.Errorf("fmt MaxRetries = %d, want 0", q.tasks["fmt"].MaxRetries)
+	}
+}
+
 func TestClaimNext(t *testing.T) {
 	plan := makePlan()
 	q := NewFromPlan(plan)
@@ -168,6 +186,117 @@ func TestClaimNext_EmptyInstanceID(t *testing.T) {
 	}
 }
 
+func TestClaimNextMatching(t *testing.T) {
+	q := NewFromPlan(makePlan())
+
+	// task-1 is more urgent, but only tasks without files are accepted
+	noFiles := func(task *QueuedTask) bool { return len(task.Files) == 0 }
+	task, err := q.ClaimNextMatching("inst-1", noFiles)
+	if err != nil {
+		t.Fatalf("ClaimNextMatching: %v", err)
+	}
+	if task == nil || task.ID != "task-3" {
+		t.Fatalf("ClaimNextMatching = %v, want task-3", task)
+	}
+	if task.ClaimedBy != "inst-1" || q.claims["task-3"] != "inst-1" {
+		t.Errorf("task-3 not claimed by inst-1: %+v", task)
+	}
+
+	task, err = q.ClaimNextMatching("inst-2", noFiles)
+	if err != nil || task != nil {
+		t.Errorf("ClaimNextMatching with no match = %v, %v; want nil, nil", task, err)
+	}
+	if q.tasks["task-1"].Status != TaskPending {
+		t.Errorf("task-1 status = %s, want pending", q.tasks["task-1"].Status)
+	}
+}
+
+func TestAddTask(t *testing.T) {
+	q := NewFromPlan(makePlan())
+	claimID(t, q, "inst-1")
+	_ = q.MarkRunning("task-1")
+
+	if err := q.AddTask(ultraplan.PlannedTask{ID: "task-4", Title: "Follow-up", DependsOn: []string{"task-1"}}); err != nil {
+		t.Fatalf("AddTask: %v", err)
+	}
+	if q.IsComplete() {
+		t.Error("queue complete with a pending task")
+	}
+	if got := claimID(t, q, "inst-2"); got != "task-3" {
+		t.Errorf("claim = %q, want task-3 while task-4 waits for task-1", got)
+	}
+	if _, err := q.Complete("task-1"); err != nil {
+		t.Fatal(err)
+	}
+	task := q.GetTask("task-4")
+	if task == nil || task.Status != TaskPending || task.ReadyAt == nil || task.MaxRetries != defaultMaxRetries {
+		t.Errorf("task-4 = %+v, want pending, ready, with default retries", task)
+	}
+
+	tests := []struct {
+		name string
+		task ultraplan.PlannedTask
+		want error
+	}{
+		{"duplicate", ultraplan.PlannedTask{ID: "task-2"}, ErrTaskExists},
+		{"unknown dependency", ultraplan.PlannedTask{ID: "task-5", DependsOn: []string{"nope"}}, ErrTaskNotFound},
+	}
+	for _, tt := range tests {
+		if err := q.AddTask(tt.task); !errors.Is(err, tt.want) {
+			t.Errorf("%s: AddTask() error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	if err := q.AddTask(ultraplan.PlannedTask{}); err == nil {
+		t.Error("AddTask() without an ID should fail")
+	}
+
+	_ = q.SetMaxRetries("task-3", 0)
+	_ = q.Fail("task-3", "boom")
+	if err := q.AddTask(ultraplan.PlannedTask{ID: "task-6", DependsOn: []string{"task-3"}}); err == nil {
+		t.Error("AddTask() depending on a failed task should fail")
+	}
+}
+
+func TestRemoveTask(t *testing.T) {
+	q := NewFromPlan(makePlan())
+	claimID(t, q, "inst-1")
+	_ = q.MarkRunning("task-1")
+
+	if err := q.RemoveTask("task-1"); err != nil {
+		t.Fatalf("RemoveTask: %v", err)
+	}
+	if q.GetTask("task-1") != nil || len(q.GetInstanceTasks("inst-1")) != 0 {
+		t.Error("task-1 still queued or claimed after removal")
+	}
+	if got := claimID(t, q, "inst-2"); got != "task-3" {
+		t.Errorf("claim = %q, want task-3", got)
+	}
+	if got := claimID(t, q, "inst-3"); got != "" {
+		t.Errorf("claim = %q, want task-2 blocked on the removed task-1", got)
+	}
+
+	// Adding task-1 back unblocks its dependent once it completes
+	if err := q.AddTask(ultraplan.PlannedTask{ID: "task-1", Title: "First task, revised"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := claimID(t, q, "inst-3"); got != "task-1" {
+		t.Fatalf("claim = %q, want the re-added task-1", got)
+	}
+	if _, err := q.Complete("task-1"); err != nil {
+		t.Fatal(err)
+	}
+	if got := claimID(t, q, "inst-4"); got != "task-2" {
+		t.Errorf("claim = %q, want task-2", got)
+	}
+
+	if err := q.RemoveTask("task-1"); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("RemoveTask(completed) error = %v, want %v", err, ErrInvalidTransition)
+	}
+	if err := q.RemoveTask("nope"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("RemoveTask(unknown) error = %v, want %v", err, ErrTaskNotFound)
+	}
+}
+
 func TestClaimNext_ConcurrentClaims(t *testing.T) {
 	// Create a plan with many independent tasks
 	plan := &ultraplan.PlanSpec{
@@ -69,6 +69,38 @@ type QueuedTask struct {
 
 	// FailureContext contains error context from the most recent failure.
 	FailureContext string `json:"failure_context,omitempty"`
+
+	// Checkpoint records the progress of the current claim. It is set when
+	// the task is claimed and cleared when it returns to pending.
+	Checkpoint *Checkpoint `json:"checkpoint,omitempty"`
+
+	// ReadyAt is when the task's dependencies were first all met, from which
+	// priority aging counts. It is kept when a claim returns the task to
+	// pending, except after preemption.
+	ReadyAt *time.Time `json:"ready_at,omitempty"`
+
+	// Preemptions is how many times the task was paused to give its
+	// instance to a more urgent task.
+	Preemptions int `json:"preemptions,omitempty"`
+}
+
+// Checkpoint is the persisted progress of a claimed task, used by
+// ResumeFromCheckpoint to reattach a restarted coordinator to the instance
+// still working on it. A Checkpoint is never modified once attached to a
+// task; updates replace it, so copies returned by accessors stay valid.
+type Checkpoint struct {
+	// InstanceID is the instance working on the task.
+	InstanceID string `json:"instance_id"`
+
+	// ClaimedAt is when the instance claimed the task.
+	ClaimedAt time.Time `json:"claimed_at"`
+
+	// LastHeartbeat is when the instance was last seen working on the task.
+	LastHeartbeat time.Time `json:"last_heartbeat"`
+
+	// CommitSHA is the latest commit the instance made on the task's
+	// branch, empty until it commits.
+	CommitSHA string `json:"commit_sha,omitempty"`
 }
 
 // QueueStatus is a snapshot of the queue's current state counts.
@@ -21,6 +21,23 @@
 // manager checks if any blocked teams now have all dependencies satisfied
 // and starts those.
 //
+// # Work Stealing
+//
+// A team that has finished its own tasks can help the others through
+// [Router.StealTask] (also [Manager.StealTask]): it claims a ready task from
+// another working team with the same role and repo, leaving tasks whose files
+// that team is still working on. The task stays in its home team's queue and
+// each steal is routed to the home team as a [MessageTypeWorkSteal] message.
+// [Router.CanSteal] reports whether any such team still has unfinished work.
+//
+// # Adding Tasks
+//
+// [Manager.AddTask] adds a task to a running team's queue, so work found during
+// execution can run without a new plan. The task joins the team that owns its
+// unfinished dependencies, or the working team with the least work left when
+// they are all completed. [Manager.RemoveTask] drops an unfinished task from
+// the queue of the team that owns it, for plan revisions.
+//
 // # Event Integration
 //
 // All teams share a single [event.Bus]. Team lifecycle events
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"sync"
 
 	"github.com/Iron-Ham/claudio/internal/coordination"
@@ -120,6 +121,9 @@ func (m *Manager) buildAndRegisterTeamLocked(spec Spec) (*Team, error) {
 	if spec.MaxInstances > 0 {
 		opts = append(opts, coordination.WithMaxInstances(spec.MaxInstances))
 	}
+	if spec.Budget.MaxTotalCost > 0 {
+		opts = append(opts, coordination.WithBudgetCeiling(spec.Budget.MaxTotalCost))
+	}
 
 	hub, err := coordination.NewHub(coordination.Config{
 		Bus:        m.bus,
@@ -337,6 +341,169 @@ func (m *Manager) RouteMessage(msg InterTeamMessage) error {
 	return m.router.Route(msg)
 }
 
+// Dependents returns the IDs of teams that directly depend on the given team,
+// in insertion order.
+func (m *Manager) Dependents(teamID string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var out []string
+	for _, id := range m.order {
+		if slices.Contains(m.teams[id].spec.DependsOn, teamID) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// PublishContracts delivers contract artifacts from a team to every team that
+// directly depends on it. This is how multi-repo pipelines hand API schemas
+// from a producer repo to its consumers: dependents are still blocked when the
+// message arrives, so the artifacts are waiting in their mailboxes by the time
+// they start. Returns an error if the source team is unknown; a team with no
+// dependents is a no-op.
+func (m *Manager) PublishContracts(fromTeam string, artifacts []ContractArtifact) error {
+	if len(artifacts) == 0 {
+		return nil
+	}
+	src := m.Team(fromTeam)
+	if src == nil {
+		return fmt.Errorf("team: unknown team %q", fromTeam)
+	}
+
+	content := fmt.Sprintf("Contract artifacts from team %s", src.Spec().Name)
+	if repo := src.Spec().Repo; repo != "" {
+		content += fmt.Sprintf(" (repo %s)", repo)
+	}
+
+	var errs []error
+	for _, id := range m.Dependents(fromTeam) {
+		err := m.router.Route(InterTeamMessage{
+			FromTeam:  fromTeam,
+			ToTeam:    id,
+			Type:      MessageTypeContract,
+			Content:   content,
+			Priority:  PriorityImportant,
+			Artifacts: artifacts,
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// AddTask adds a task to a team's queue while the teams run, so work found
+// during execution can be picked up without a new plan. The task joins the
+// team that owns its unfinished dependencies, which must all belong to one
+// team. Dependencies owned by other teams must be completed already and are
+// dropped, since a queue only tracks its own tasks. A task whose dependencies
+// are all completed joins the working team with the fewest unfinished tasks.
+// Either way the team must work in the task's repo. Returns the ID of the
+// team the task joined.
+func (m *Manager) AddTask(task ultraplan.PlannedTask) (string, error) {
+	m.mu.RLock()
+	teams := make([]*Team, 0, len(m.order))
+	for _, id := range m.order {
+		teams = append(teams, m.teams[id])
+	}
+	m.mu.RUnlock()
+
+	owner := func(taskID string) (*Team, *taskqueue.QueuedTask) {
+		for _, t := range teams {
+			if qt := t.hub.TaskQueue().GetTask(taskID); qt != nil {
+				return t, qt
+			}
+		}
+		return nil, nil
+	}
+	if t, _ := owner(task.ID); t != nil {
+		return "", fmt.Errorf("team: task %q already exists in team %q", task.ID, t.Spec().ID)
+	}
+
+	var home *Team
+	for _, depID := range task.DependsOn {
+		t, dep := owner(depID)
+		switch {
+		case t == nil:
+			return "", fmt.Errorf("team: dependency %q of task %q not found", depID, task.ID)
+		case dep.Status == taskqueue.TaskCompleted:
+			continue
+		case dep.Status == taskqueue.TaskFailed:
+			return "", fmt.Errorf("team: task %q depends on failed task %q", task.ID, depID)
+		case home != nil && home != t:
+			return "", fmt.Errorf("team: task %q depends on unfinished tasks of teams %q and %q",
+				task.ID, home.Spec().ID, t.Spec().ID)
+		}
+		home = t
+	}
+
+	if home == nil {
+		best := -1
+		for _, t := range teams {
+			if t.Phase() != PhaseWorking || t.Spec().Repo != task.Repo {
+				continue
+			}
+			qs := t.hub.TaskQueue().Status()
+			if left := qs.Total - qs.Completed - qs.Failed; best < 0 || left < best {
+				home, best = t, left
+			}
+		}
+		if home == nil {
+			return "", fmt.Errorf("team: no working team for task %q", task.ID)
+		}
+	} else if home.Phase().IsTerminal() || home.Spec().Repo != task.Repo {
+		return "", fmt.Errorf("team: task %q cannot join team %q", task.ID, home.Spec().ID)
+	}
+
+	var deps []string
+	for _, depID := range task.DependsOn {
+		if home.hub.TaskQueue().GetTask(depID) != nil {
+			deps = append(deps, depID)
+		}
+	}
+	task.DependsOn = deps
+	if err := home.addTask(task); err != nil {
+		return "", fmt.Errorf("team: adding task %q to team %q: %w", task.ID, home.Spec().ID, err)
+	}
+	return home.Spec().ID, nil
+}
+
+// RemoveTask drops a task that has not completed from the queue of the team
+// that owns it (see taskqueue.TaskQueue.RemoveTask). The instance running it,
+// if any, is left for the caller to stop.
+func (m *Manager) RemoveTask(taskID string) error {
+	m.mu.RLock()
+	var owner *Team
+	for _, id := range m.order {
+		if t := m.teams[id]; t.hub.TaskQueue().GetTask(taskID) != nil {
+			owner = t
+			break
+		}
+	}
+	m.mu.RUnlock()
+
+	if owner == nil {
+		return fmt.Errorf("team: task %q not found", taskID)
+	}
+	if err := owner.removeTask(taskID); err != nil {
+		return fmt.Errorf("team: removing task %q from team %q: %w", taskID, owner.Spec().ID, err)
+	}
+	return nil
+}
+
+// StealTask claims a ready task from another team's queue for the idle team
+// thiefID. See Router.StealTask.
+func (m *Manager) StealTask(thiefID, claimID string) (*Team, *taskqueue.QueuedTask, error) {
+	return m.router.StealTask(thiefID, claimID)
+}
+
+// CanSteal reports whether the idle team thiefID may still find work to
+// steal. See Router.CanSteal.
+func (m *Manager) CanSteal(thiefID string) bool {
+	return m.router.CanSteal(thiefID)
+}
+
 // CompletedTasks returns copies of all tasks in terminal state (completed or
 // failed) across all teams. Used by the debate coordinator to find overlapping
 // file modifications.
@@ -821,3 +821,148 @@ func TestManager_AddTeamDynamic_DepsSatisfied(t *testing.T) {
 		t.Fatal("timed out waiting for dynamic added event")
 	}
 }
+
+func TestManager_PublishContracts(t *testing.T) {
+	m, _ := newTestManager(t)
+
+	backend := testSpec("backend", "Backend")
+	backend.Repo = "api"
+	frontend := testSpec("frontend", "Frontend", "backend")
+	frontend.Repo = "web"
+	for _, s := range []Spec{backend, frontend, testSpec("docs", "Docs")} {
+		if err := m.AddTeam(s); err != nil {
+			t.Fatalf("AddTeam(%s): %v", s.ID, err)
+		}
+	}
+
+	if got := m.Dependents("backend"); len(got) != 1 || got[0] != "frontend" {
+		t.Fatalf("Dependents(backend) = %v, want [frontend]", got)
+	}
+
+	artifacts := []ContractArtifact{{Repo: "api", Path: "openapi.yaml", Content: "openapi: 3.1.0"}}
+	if err := m.PublishContracts("backend", artifacts); err != nil {
+		t.Fatalf("PublishContracts: %v", err)
+	}
+
+	msgs, err := m.Team("frontend").Hub().Mailbox().Receive("any-instance")
+	if err != nil {
+		t.Fatalf("Receive: %v", err)
+	}
+	if len(msgs) != 1 {
+		t.Fatalf("frontend messages = %d, want 1", len(msgs))
+	}
+	if !strings.Contains(msgs[0].Body, "api:openapi.yaml") || !strings.Contains(msgs[0].Body, "openapi: 3.1.0") {
+		t.Errorf("body missing artifact: %q", msgs[0].Body)
+	}
+
+	docs, err := m.Team("docs").Hub().Mailbox().Receive("any-instance")
+	if err != nil {
+		t.Fatalf("Receive: %v", err)
+	}
+	if len(docs) != 0 {
+		t.Errorf("non-dependent team received %d messages, want 0", len(docs))
+	}
+
+	if err := m.PublishContracts("nope", artifacts); err == nil {
+		t.Error("expected error for unknown source team")
+	}
+	if err := m.PublishContracts("nope", nil); err != nil {
+		t.Errorf("empty artifacts should be a no-op, got %v", err)
+	}
+}
+
+func TestManager_AddTask(t *testing.T) {
+	m, _ := newTestManager(t)
+
+	beta := testSpec("beta", "Beta")
+	beta.Tasks = []ultraplan.PlannedTask{{ID: "b1", Title: "Handler"}, {ID: "b2", Title: "Models"}}
+	for _, s := range []Spec{testSpec("alpha", "Alpha"), beta} {
+		if err := m.AddTeam(s); err != nil {
+			t.Fatalf("AddTeam(%s): %v", s.ID, err)
+		}
+	}
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer func() { _ = m.Stop() }()
+
+	// Without dependencies the task joins the team with the least work left
+	if id, err := m.AddTask(ultraplan.PlannedTask{ID: "n1", Title: "Lint", Priority: 1}); err != nil || id != "alpha" {
+		t.Fatalf("AddTask(n1) = %q, %v; want alpha", id, err)
+	}
+	if got, _ := m.TeamStatus("alpha"); got.TasksTotal != 2 {
+		t.Errorf("alpha status = %+v, want 2 tasks", got)
+	}
+
+	if id, err := m.AddTask(ultraplan.PlannedTask{ID: "n2", Title: "Handler tests", DependsOn: []string{"b1"}}); err != nil || id != "beta" {
+		t.Fatalf("AddTask(n2) = %q, %v; want beta", id, err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		task ultraplan.PlannedTask
+	}{
+		{"duplicate", ultraplan.PlannedTask{ID: "b2"}},
+		{"unknown dependency", ultraplan.PlannedTask{ID: "x", DependsOn: []string{"nope"}}},
+		{"unfinished dependencies in two teams", ultraplan.PlannedTask{ID: "x", DependsOn: []string{"t-alpha", "b1"}}},
+		{"no team in repo", ultraplan.PlannedTask{ID: "x", Repo: "web"}},
+	} {
+		if _, err := m.AddTask(tc.task); err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+
+	// A completed dependency in another team is dropped
+	gate := m.Team("alpha").Hub().Gate()
+	if task, _ := gate.ClaimNext("bridge-alpha"); task == nil || task.ID != "t-alpha" {
+		t.Fatalf("alpha claim = %v, want t-alpha", task)
+	}
+	if _, err := gate.Complete("t-alpha"); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if id, err := m.AddTask(ultraplan.PlannedTask{ID: "n3", Title: "Wire up", DependsOn: []string{"t-alpha", "b1"}}); err != nil || id != "beta" {
+		t.Fatalf("AddTask(n3) = %q, %v; want beta", id, err)
+	}
+	if task := m.Team("beta").Hub().TaskQueue().GetTask("n3"); task == nil || len(task.DependsOn) != 1 || task.DependsOn[0] != "b1" {
+		t.Errorf("n3 = %+v, want it to depend on b1 only", task)
+	}
+}
+
+func TestManager_RemoveTask(t *testing.T) {
+	m, _ := newTestManager(t)
+
+	beta := testSpec("beta", "Beta")
+	beta.Tasks = []ultraplan.PlannedTask{{ID: "b1", Title: "Handler"}, {ID: "b2", Title: "Models"}}
+	for _, s := range []Spec{testSpec("alpha", "Alpha"), beta} {
+		if err := m.AddTeam(s); err != nil {
+			t.Fatalf("AddTeam(%s): %v", s.ID, err)
+		}
+	}
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer func() { _ = m.Stop() }()
+
+	if err := m.RemoveTask("b2"); err != nil {
+		t.Fatalf("RemoveTask(b2): %v", err)
+	}
+	if m.Team("beta").Hub().TaskQueue().GetTask("b2") != nil {
+		t.Error("b2 still queued after removal")
+	}
+	if got := m.Team("beta").Spec().Tasks; len(got) != 1 || got[0].ID != "b1" {
+		t.Errorf("beta spec tasks = %+v, want b1 only", got)
+	}
+
+	gate := m.Team("alpha").Hub().Gate()
+	if task, _ := gate.ClaimNext("bridge-alpha"); task == nil || task.ID != "t-alpha" {
+		t.Fatalf("alpha claim = %v, want t-alpha", task)
+	}
+	if _, err := gate.Complete("t-alpha"); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	for _, id := range []string{"t-alpha", "b2", "nope"} {
+		if err := m.RemoveTask(id); err == nil {
+			t.Errorf("RemoveTask(%s): expected error", id)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package team
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -99,8 +100,8 @@ func (r *Router) deliverToTeam(t *Team, msg InterTeamMessage) {
 	mbMsg := mailbox.Message{
 		From: fmt.Sprintf("team:%s", msg.FromTeam),
 		To:   mailbox.BroadcastRecipient,
-		Type: mailbox.MessageType(msg.Type),
-		Body: fmt.Sprintf("[%s] %s", msg.Priority, msg.Content),
+		Type: mailboxType(msg.Type),
+		Body: formatMessageBody(msg),
 	}
 
 	// Best-effort delivery — errors are ignored so that a single failed
@@ -116,6 +117,41 @@ func (r *Router) deliverToTeam(t *Team, msg InterTeamMessage) {
 	))
 }
 
+// mailboxType maps an inter-team message type onto the mailbox type used for
+// delivery. Contracts are delivered as discoveries so the bridge's context
+// injection (which filters on discoveries and warnings) includes them in the
+// prompts of the receiving team's instances. Work steals are status updates,
+// kept out of those prompts.
+func mailboxType(mt MessageType) mailbox.MessageType {
+	switch mt {
+	case MessageTypeContract:
+		return mailbox.MessageDiscovery
+	case MessageTypeWorkSteal:
+		return mailbox.MessageStatus
+	}
+	return mailbox.MessageType(mt)
+}
+
+// formatMessageBody renders an inter-team message as a mailbox body. Contract
+// artifacts are appended as fenced blocks so the receiving team's instances
+// see the full schema inline rather than a path into another repository.
+func formatMessageBody(msg InterTeamMessage) string {
+	if len(msg.Artifacts) == 0 {
+		return fmt.Sprintf("[%s] %s", msg.Priority, msg.Content)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "[%s] %s", msg.Priority, msg.Content)
+	for _, a := range msg.Artifacts {
+		label := a.Path
+		if a.Repo != "" {
+			label = a.Repo + ":" + a.Path
+		}
+		fmt.Fprintf(&sb, "\n\n--- %s ---\n```\n%s\n```", label, strings.TrimRight(a.Content, "\n"))
+	}
+	return sb.String()
+}
+
 // Messages returns a copy of all routed messages.
 func (r *Router) Messages() []InterTeamMessage {
 	r.mu.RLock()
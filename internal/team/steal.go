@@ -0,0 +1,123 @@
+package team
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Iron-Ham/claudio/internal/taskqueue"
+)
+
+// StealTask claims a ready task from another team's queue on behalf of the
+// idle team thiefID, so a team that has finished its own plan can help teams
+// that are still working. Only working teams with the same role and repo as
+// the thief are considered, the one with the most pending tasks first. A task
+// stays with its team when one of its files is claimed by that team's
+// running work (file affinity): its edits would conflict with, or depend on,
+// changes that are not merged yet.
+//
+// The task is claimed under claimID in the home team's queue, and must be
+// completed, failed, or released through the home team's Gate. Each steal is
+// routed to the home team as a MessageTypeWorkSteal message, which publishes
+// an InterTeamMessageEvent. Returns a nil task when there is nothing to steal.
+func (r *Router) StealTask(thiefID, claimID string) (*Team, *taskqueue.QueuedTask, error) {
+	thief := r.teams(thiefID)
+	if thief == nil {
+		return nil, nil, fmt.Errorf("router: team %q not found", thiefID)
+	}
+
+	for _, home := range r.stealTargets(thief) {
+		busy := claimedFiles(home)
+		task, err := home.Hub().Gate().ClaimNextMatching(claimID, func(task *taskqueue.QueuedTask) bool {
+			for _, f := range task.Files {
+				if busy[f] {
+					return false
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("router: steal from team %q: %w", home.Spec().ID, err)
+		}
+		if task == nil {
+			continue
+		}
+
+		// Best-effort, like every delivery: the claim already happened.
+		_ = r.Route(InterTeamMessage{
+			FromTeam: thiefID,
+			ToTeam:   home.Spec().ID,
+			Type:     MessageTypeWorkSteal,
+			Content:  fmt.Sprintf("Team %s took task %s (%s)", thief.Spec().Name, task.ID, task.Title),
+			Priority: PriorityInfo,
+		})
+		return home, task, nil
+	}
+	return nil, nil, nil
+}
+
+// CanSteal reports whether a team thiefID could steal from still has
+// unfinished tasks, including teams still blocked on their dependencies. An
+// idle team stops looking for work once it returns false.
+func (r *Router) CanSteal(thiefID string) bool {
+	thief := r.teams(thiefID)
+	if thief == nil {
+		return false
+	}
+	for _, id := range r.allTeams() {
+		t := r.teams(id)
+		if t == nil || !stealableFrom(thief, t) || t.Phase().IsTerminal() {
+			continue
+		}
+		if !t.Hub().TaskQueue().IsComplete() {
+			return true
+		}
+	}
+	return false
+}
+
+// stealTargets returns the working teams thief can steal from, the one with
+// the most pending tasks first.
+func (r *Router) stealTargets(thief *Team) []*Team {
+	type target struct {
+		team    *Team
+		pending int
+	}
+	var targets []target
+	for _, id := range r.allTeams() {
+		t := r.teams(id)
+		if t == nil || !stealableFrom(thief, t) || t.Phase() != PhaseWorking {
+			continue
+		}
+		targets = append(targets, target{t, t.Hub().TaskQueue().Status().Pending})
+	}
+	slices.SortStableFunc(targets, func(a, b target) int { return b.pending - a.pending })
+
+	out := make([]*Team, len(targets))
+	for i, t := range targets {
+		out[i] = t.team
+	}
+	return out
+}
+
+// stealableFrom reports whether thief can run home's tasks: another team
+// doing the same kind of work in the same repo.
+func stealableFrom(thief, home *Team) bool {
+	ts, hs := thief.Spec(), home.Spec()
+	return ts.ID != hs.ID && ts.Role == hs.Role && ts.Repo == hs.Repo
+}
+
+// claimedFiles returns the files of home's claimed and running tasks. It
+// reads the queue rather than the file lock registry, since a task is claimed
+// before its locks are taken.
+func claimedFiles(home *Team) map[string]bool {
+	busy := make(map[string]bool)
+	for _, task := range home.Hub().TaskQueue().AllTasks() {
+		switch task.Status {
+		case taskqueue.TaskClaimed, taskqueue.TaskAwaitingApproval, taskqueue.TaskRunning:
+			for _, f := range task.Files {
+				busy[f] = true
+			}
+		}
+	}
+	return busy
+}
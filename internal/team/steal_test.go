@@ -0,0 +1,85 @@
+package team
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+	"github.com/Iron-Ham/claudio/internal/ultraplan"
+)
+
+func TestManager_StealTask(t *testing.T) {
+	m, bus := newTestManager(t)
+
+	beta := testSpec("beta", "Beta")
+	beta.Tasks = []ultraplan.PlannedTask{
+		{ID: "b1", Title: "Handler", Files: []string{"api.go"}},
+		{ID: "b2", Title: "Handler tests", Files: []string{"api.go"}, Priority: 1},
+		{ID: "b3", Title: "Models", Files: []string{"models.go"}, Priority: 2},
+		{ID: "b4", Title: "Docs", Priority: 3},
+	}
+	gamma := testSpec("gamma", "Gamma")
+	gamma.Repo = "web"
+	for _, s := range []Spec{testSpec("alpha", "Alpha"), beta, gamma} {
+		if err := m.AddTeam(s); err != nil {
+			t.Fatalf("AddTeam(%s): %v", s.ID, err)
+		}
+	}
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer func() { _ = m.Stop() }()
+
+	var steals []event.InterTeamMessageEvent
+	bus.Subscribe("team.message", func(e event.Event) {
+		if ev, ok := e.(event.InterTeamMessageEvent); ok {
+			steals = append(steals, ev)
+		}
+	})
+
+	// Beta is working on api.go, so b2 stays with it
+	if task, _ := m.Team("beta").Hub().Gate().ClaimNext("bridge-beta"); task == nil || task.ID != "b1" {
+		t.Fatalf("beta claim = %v, want b1", task)
+	}
+
+	var stolen []string
+	for {
+		home, task, err := m.StealTask("alpha", "bridge-alpha")
+		if err != nil {
+			t.Fatalf("StealTask: %v", err)
+		}
+		if task == nil {
+			break
+		}
+		if home.Spec().ID != "beta" || task.ClaimedBy != "bridge-alpha" {
+			t.Errorf("stole %s from %s claimed by %s, want beta and bridge-alpha", task.ID, home.Spec().ID, task.ClaimedBy)
+		}
+		stolen = append(stolen, task.ID)
+	}
+	if len(stolen) != 2 || stolen[0] != "b3" || stolen[1] != "b4" {
+		t.Errorf("stolen = %v, want [b3 b4]", stolen)
+	}
+
+	if len(steals) != 2 {
+		t.Fatalf("messages = %d, want 2", len(steals))
+	}
+	if ev := steals[0]; ev.FromTeam != "alpha" || ev.ToTeam != "beta" || ev.MessageType != string(MessageTypeWorkSteal) {
+		t.Errorf("message = %+v, want a work_steal from alpha to beta", ev)
+	}
+
+	if !m.CanSteal("alpha") {
+		t.Error("CanSteal(alpha) = false while beta has unfinished tasks")
+	}
+
+	// Gamma works in another repo
+	if _, task, err := m.StealTask("gamma", "bridge-gamma"); task != nil || err != nil {
+		t.Errorf("StealTask(gamma) = %v, %v; want nothing from another repo", task, err)
+	}
+	if m.CanSteal("gamma") {
+		t.Error("CanSteal(gamma) = true with no team in its repo")
+	}
+
+	if _, _, err := m.StealTask("nope", "bridge-nope"); err == nil {
+		t.Error("expected error for unknown team")
+	}
+}
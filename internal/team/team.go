@@ -1,9 +1,11 @@
 package team
 
 import (
+	"slices"
 	"sync"
 
 	"github.com/Iron-Ham/claudio/internal/coordination"
+	"github.com/Iron-Ham/claudio/internal/ultraplan"
 )
 
 // Team wraps a coordination.Hub with team-specific metadata and budget tracking.
@@ -56,6 +58,32 @@ func (t *Team) setPhase(p Phase) Phase {
 	return prev
 }
 
+// addTask queues a task on the team's hub and records it in the spec. The
+// spec is updated after the queue so the lock is not held while the queue
+// publishes events.
+func (t *Team) addTask(pt ultraplan.PlannedTask) error {
+	if err := t.hub.EventQueue().AddTask(pt); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spec.Tasks = append(slices.Clone(t.spec.Tasks), pt)
+	return nil
+}
+
+// removeTask drops a task from the team's hub and its spec.
+func (t *Team) removeTask(taskID string) error {
+	if err := t.hub.EventQueue().RemoveTask(taskID); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spec.Tasks = slices.DeleteFunc(slices.Clone(t.spec.Tasks), func(pt ultraplan.PlannedTask) bool {
+		return pt.ID == taskID
+	})
+	return nil
+}
+
 // Status returns a read-only snapshot of the team's current state.
 func (t *Team) Status() Status {
 	t.mu.RLock()
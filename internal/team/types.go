@@ -105,6 +105,7 @@ type Spec struct {
 	MaxInstances int                     // Ceiling for scale-up (0 = unlimited)
 	Budget       TokenBudget             // Resource limits
 	DependsOn    []string                // Team IDs this team waits for
+	Repo         string                  // Repository this team works in ("" = primary repo)
 }
 
 // Validate checks that the spec has all required fields.
@@ -163,6 +164,14 @@ const (
 
 	// MessageTypeRequest indicates a team requesting information or action.
 	MessageTypeRequest MessageType = "request"
+
+	// MessageTypeContract indicates a team handing contract artifacts (API
+	// schemas, generated clients) to a dependent team, typically in another repo.
+	MessageTypeContract MessageType = "contract"
+
+	// MessageTypeWorkSteal records an idle team taking a ready task from
+	// another team's queue (see Router.StealTask).
+	MessageTypeWorkSteal MessageType = "work_steal"
 )
 
 // String returns the string representation of the message type.
@@ -192,15 +201,24 @@ func (mp MessagePriority) String() string {
 // BroadcastRecipient is the sentinel value for messages sent to all teams.
 const BroadcastRecipient = "broadcast"
 
+// ContractArtifact is a file produced by one team that another team consumes,
+// such as an API schema exported by a backend team for a frontend team.
+type ContractArtifact struct {
+	Repo    string // Repository the artifact was produced in
+	Path    string // Repo-relative path of the artifact
+	Content string // Artifact contents at the time of publication
+}
+
 // InterTeamMessage is a message routed between teams.
 type InterTeamMessage struct {
-	ID        string          // Unique message identifier
-	FromTeam  string          // Source team ID
-	ToTeam    string          // Destination team ID or BroadcastRecipient
-	Type      MessageType     // Message category
-	Content   string          // Message body
-	Priority  MessagePriority // Urgency level
-	Timestamp time.Time       // When the message was created
+	ID        string             // Unique message identifier
+	FromTeam  string             // Source team ID
+	ToTeam    string             // Destination team ID or BroadcastRecipient
+	Type      MessageType        // Message category
+	Content   string             // Message body
+	Priority  MessagePriority    // Urgency level
+	Timestamp time.Time          // When the message was created
+	Artifacts []ContractArtifact // Contract artifacts carried by the message
 }
 
 // IsBroadcast returns true if this message is addressed to all teams.
@@ -5,7 +5,6 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 )
 
@@ -67,17 +66,6 @@ func getDescendantPIDs(pid int) []int {
 	return descendants
 }
 
-// IsProcessAlive checks if a process with the given PID exists.
-// Uses kill(pid, 0) which checks for process existence without sending a signal.
-func IsProcessAlive(pid int) bool {
-	if pid <= 0 {
-		return false
-	}
-	// On Unix, kill with signal 0 checks process existence without sending a signal.
-	err := syscall.Kill(pid, 0)
-	return err == nil
-}
-
 // KillProcessTree sends SIGKILL to a process and all its descendants.
 // Descendants are killed first (bottom-up) to prevent orphaning.
 func KillProcessTree(pid int) {
@@ -91,13 +79,13 @@ func KillProcessTree(pid int) {
 	// Kill descendants bottom-up (deepest children first)
 	for i := len(descendants) - 1; i >= 0; i-- {
 		if IsProcessAlive(descendants[i]) {
-			_ = syscall.Kill(descendants[i], syscall.SIGKILL)
+			killProcess(descendants[i])
 		}
 	}
 
 	// Kill the root process
 	if IsProcessAlive(pid) {
-		_ = syscall.Kill(pid, syscall.SIGKILL)
+		killProcess(pid)
 	}
 }
 
@@ -0,0 +1,21 @@
+//go:build unix
+
+package tmux
+
+import "syscall"
+
+// IsProcessAlive checks if a process with the given PID exists.
+// Uses kill(pid, 0) which checks for process existence without sending a signal.
+func IsProcessAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	// On Unix, kill with signal 0 checks process existence without sending a signal.
+	err := syscall.Kill(pid, 0)
+	return err == nil
+}
+
+// killProcess sends SIGKILL to pid.
+func killProcess(pid int) {
+	_ = syscall.Kill(pid, syscall.SIGKILL)
+}
@@ -0,0 +1,36 @@
+// Package tracing exports OpenTelemetry spans for ultra-plan runs, so a
+// multi-hour run shows where its time went.
+//
+// When tracing.enabled is set, the orchestrator calls [Start] with each
+// session, which installs a tracer provider as the OpenTelemetry global.
+// Instrumented code gets its tracer from [Tracer] on each use, so spans go
+// to the current session's provider and are no-ops when tracing is off.
+//
+//	ultraplan                       whole run (Coordinator)
+//	└── ultraplan.executing         one span per phase (Coordinator)
+//	    ├── execution.start_task    worktree and instance launch (ExecutionOrchestrator)
+//	    ├── ultraplan.task          task run, start to verification (Coordinator)
+//	    └── bridge.task             task run by a pipeline team (Bridge)
+//
+// # Main Types
+//
+//   - [Provider]: The session's tracer provider and exporter ("otlp" over
+//     gRPC, or "file" for traces.jsonl in the session directory)
+//   - [Spans]: Spans that start and end in different calls, keyed by task ID
+//
+// Attribute keys such as [SessionID] and [TaskID] are shared by every
+// instrumented package.
+//
+// # Usage
+//
+//	p, err := tracing.Start(ctx, cfg.Tracing, sessionDir) // nil when disabled
+//	defer p.Shutdown(ctx)
+//
+//	ctx, span := tracing.Tracer().Start(ctx, "execution.start_task",
+//		trace.WithAttributes(tracing.TaskID.String(taskID)))
+//	defer func() { tracing.End(span, err) }()
+//
+//	var spans tracing.Spans
+//	spans.Start(ctx, taskID, "ultraplan.task", tracing.TaskID.String(taskID))
+//	spans.Fail(taskID, "verification failed")
+package tracing
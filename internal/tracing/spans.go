@@ -0,0 +1,98 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Spans holds spans that start and end in different calls, such as a task
+// span started when an instance launches and ended when its completion is
+// detected. Spans are keyed by an ID, typically the task ID. The zero value
+// is ready to use and safe for concurrent use.
+type Spans struct {
+	mu    sync.Mutex
+	spans map[string]trace.Span
+}
+
+// Start starts a span for key as a child of the span in ctx, ending any span
+// key already has. It returns ctx carrying the new span.
+func (s *Spans) Start(ctx context.Context, key, name string, attrs ...attribute.KeyValue) context.Context {
+	ctx, span := Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+
+	s.mu.Lock()
+	if s.spans == nil {
+		s.spans = make(map[string]trace.Span)
+	}
+	prev := s.spans[key]
+	s.spans[key] = span
+	s.mu.Unlock()
+
+	if prev != nil {
+		prev.End()
+	}
+	return ctx
+}
+
+// AddEvent records a named event on key's span, if it has one.
+func (s *Spans) AddEvent(key, name string, attrs ...attribute.KeyValue) {
+	s.mu.Lock()
+	span := s.spans[key]
+	s.mu.Unlock()
+
+	if span != nil {
+		span.AddEvent(name, trace.WithAttributes(attrs...))
+	}
+}
+
+// End ends key's span, if it has one, with the given attributes added.
+func (s *Spans) End(key string, attrs ...attribute.KeyValue) {
+	if span := s.take(key); span != nil {
+		span.SetAttributes(attrs...)
+		span.End()
+	}
+}
+
+// Fail ends key's span, if it has one, with an error status and reason.
+func (s *Spans) Fail(key, reason string, attrs ...attribute.KeyValue) {
+	if span := s.take(key); span != nil {
+		span.SetAttributes(attrs...)
+		span.SetStatus(codes.Error, reason)
+		span.End()
+	}
+}
+
+// FailAll ends every open span with an error status and reason, for example
+// when the work they cover is cancelled.
+func (s *Spans) FailAll(reason string) {
+	s.mu.Lock()
+	spans := s.spans
+	s.spans = nil
+	s.mu.Unlock()
+
+	for _, span := range spans {
+		span.SetStatus(codes.Error, reason)
+		span.End()
+	}
+}
+
+// End ends span, recording err on it with an error status when err is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// take removes and returns key's span.
+func (s *Spans) take(key string) trace.Span {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	span := s.spans[key]
+	delete(s.spans, key)
+	return span
+}
@@ -0,0 +1,150 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+)
+
+// instrumentationName is the instrumentation scope of every Claudio span.
+const instrumentationName = "github.com/Iron-Ham/claudio"
+
+// FileName is the file the "file" exporter writes spans to, in the session
+// directory.
+const FileName = "traces.jsonl"
+
+// Attribute keys recorded on Claudio spans.
+const (
+	SessionID  = attribute.Key("claudio.session.id")
+	Phase      = attribute.Key("claudio.phase")
+	TaskID     = attribute.Key("claudio.task.id")
+	TaskTitle  = attribute.Key("claudio.task.title")
+	InstanceID = attribute.Key("claudio.instance.id")
+	Group      = attribute.Key("claudio.group")
+	TeamID     = attribute.Key("claudio.team.id")
+)
+
+// Tracer returns the tracer for Claudio's spans. It is looked up on each
+// call, so spans go to the provider installed by the current session; with
+// no provider installed they are no-ops.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Provider exports the spans of one session. It is installed as the global
+// tracer provider until Shutdown.
+type Provider struct {
+	tp   *sdktrace.TracerProvider
+	prev trace.TracerProvider
+	file *os.File
+}
+
+// Option configures a Provider.
+type Option func(*options)
+
+type options struct {
+	exporter sdktrace.SpanExporter
+	attrs    []attribute.KeyValue
+}
+
+// WithExporter sends spans to exp instead of the exporter selected by the
+// configuration. Spans are exported synchronously as they end, so tests can
+// inspect them right away.
+func WithExporter(exp sdktrace.SpanExporter) Option {
+	return func(o *options) { o.exporter = exp }
+}
+
+// WithAttributes adds resource attributes, such as the session ID, to every
+// span.
+func WithAttributes(attrs ...attribute.KeyValue) Option {
+	return func(o *options) { o.attrs = append(o.attrs, attrs...) }
+}
+
+// Start installs a tracer provider configured by cfg, or returns nil when
+// tracing is disabled. The "file" exporter writes to sessionDir.
+func Start(ctx context.Context, cfg config.TracingConfig, sessionDir string, opts ...Option) (*Provider, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	p := &Provider{prev: otel.GetTracerProvider()}
+	var spanProcessor sdktrace.TracerProviderOption
+	switch {
+	case o.exporter != nil:
+		spanProcessor = sdktrace.WithSyncer(o.exporter)
+	case cfg.Exporter == "file":
+		if sessionDir == "" {
+			return nil, errors.New("tracing: the file exporter needs a session directory")
+		}
+		f, err := os.OpenFile(filepath.Join(sessionDir, FileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("tracing: open %s: %w", FileName, err)
+		}
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("tracing: create file exporter: %w", err)
+		}
+		p.file = f
+		spanProcessor = sdktrace.WithBatcher(exp)
+	default:
+		var clientOpts []otlptracegrpc.Option
+		if cfg.Endpoint != "" {
+			clientOpts = append(clientOpts, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+		}
+		// The connection is made lazily, so an unreachable collector does
+		// not delay the session; export failures are reported by the SDK.
+		exp, err := otlptracegrpc.New(ctx, clientOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("tracing: create otlp exporter: %w", err)
+		}
+		spanProcessor = sdktrace.WithBatcher(exp)
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = "claudio"
+	}
+	attrs := append([]attribute.KeyValue{attribute.String("service.name", serviceName)}, o.attrs...)
+
+	p.tp = sdktrace.NewTracerProvider(
+		spanProcessor,
+		sdktrace.WithResource(resource.NewSchemaless(attrs...)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(p.tp)
+	return p, nil
+}
+
+// Shutdown exports the spans still buffered, then restores the tracer
+// provider that was installed before Start. Spans that have not ended are
+// dropped.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if otel.GetTracerProvider() == p.tp {
+		otel.SetTracerProvider(p.prev)
+	}
+	err := p.tp.Shutdown(ctx)
+	if p.file != nil {
+		err = errors.Join(err, p.file.Close())
+	}
+	return err
+}
@@ -0,0 +1,172 @@
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+)
+
+// startTest installs a provider that records spans in memory until the test
+// ends.
+func startTest(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exp := tracetest.NewInMemoryExporter()
+	p, err := Start(context.Background(), config.TracingConfig{Enabled: true, SampleRatio: 1}, "", WithExporter(exp))
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = p.Shutdown(context.Background()) })
+	return exp
+}
+
+func TestStart_Disabled(t *testing.T) {
+	p, err := Start(context.Background(), config.TracingConfig{}, t.TempDir())
+	if err != nil || p != nil {
+		t.Fatalf("Start(disabled) = %v, %v; want nil, nil", p, err)
+	}
+}
+
+func TestStart_FileExporter(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.TracingConfig{Enabled: true, Exporter: "file", SampleRatio: 1}
+	p, err := Start(context.Background(), cfg, dir, WithAttributes(SessionID.String("sess-1")))
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	_, span := Tracer().Start(context.Background(), "planning")
+	span.End()
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	f, err := os.Open(filepath.Join(dir, FileName))
+	if err != nil {
+		t.Fatalf("open traces: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	var names []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var s struct{ Name string }
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			t.Fatalf("decode span: %v", err)
+		}
+		names = append(names, s.Name)
+	}
+	if len(names) != 1 || names[0] != "planning" {
+		t.Errorf("exported spans = %v, want [planning]", names)
+	}
+}
+
+func TestStart_FileExporterNeedsSessionDir(t *testing.T) {
+	if _, err := Start(context.Background(), config.TracingConfig{Enabled: true, Exporter: "file"}, ""); err == nil {
+		t.Error("Start() should fail without a session directory")
+	}
+}
+
+func TestProvider_ShutdownRestoresGlobal(t *testing.T) {
+	before := otel.GetTracerProvider()
+	p, err := Start(context.Background(), config.TracingConfig{Enabled: true, SampleRatio: 1}, "", WithExporter(tracetest.NewInMemoryExporter()))
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if otel.GetTracerProvider() == before {
+		t.Fatal("Start() should install its provider")
+	}
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if otel.GetTracerProvider() != before {
+		t.Error("Shutdown() should restore the previous provider")
+	}
+}
+
+func TestSpans(t *testing.T) {
+	exp := startTest(t)
+	var spans Spans
+
+	ctx, parent := Tracer().Start(context.Background(), "executing")
+	spans.Start(ctx, "task-1", "task", TaskID.String("task-1"))
+	spans.Start(ctx, "task-2", "task", TaskID.String("task-2"))
+	spans.Start(ctx, "task-3", "task", TaskID.String("task-3"))
+	spans.AddEvent("task-1", "sentinel detected")
+	spans.End("task-1", InstanceID.String("inst-1"))
+	spans.Fail("task-2", "verification failed")
+	spans.End("missing") // no-op
+	spans.FailAll("cancelled")
+	parent.End()
+
+	got := exp.GetSpans()
+	if len(got) != 4 {
+		t.Fatalf("exported %d spans, want 4", len(got))
+	}
+	byTask := make(map[string]tracetest.SpanStub)
+	for _, s := range got {
+		for _, a := range s.Attributes {
+			if a.Key == TaskID {
+				byTask[a.Value.AsString()] = s
+			}
+		}
+	}
+
+	one := byTask["task-1"]
+	if one.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Error("task span should be a child of the span in ctx")
+	}
+	if len(one.Events) != 1 || one.Events[0].Name != "sentinel detected" {
+		t.Errorf("events = %+v, want sentinel detected", one.Events)
+	}
+	if one.Status.Code == codes.Error {
+		t.Error("ended span should not have an error status")
+	}
+	if byTask["task-2"].Status.Code != codes.Error || byTask["task-2"].Status.Description != "verification failed" {
+		t.Errorf("failed span status = %+v", byTask["task-2"].Status)
+	}
+	if byTask["task-3"].Status.Description != "cancelled" {
+		t.Errorf("FailAll span status = %+v", byTask["task-3"].Status)
+	}
+}
+
+func TestSpans_StartReplacesOpenSpan(t *testing.T) {
+	exp := startTest(t)
+	var spans Spans
+
+	spans.Start(context.Background(), "task-1", "attempt 1")
+	spans.Start(context.Background(), "task-1", "attempt 2")
+	if n := len(exp.GetSpans()); n != 1 {
+		t.Fatalf("restarting a key should end its span, exported %d", n)
+	}
+	spans.End("task-1")
+	if got := exp.GetSpans(); len(got) != 2 || got[1].Name != "attempt 2" {
+		t.Errorf("spans = %+v", got)
+	}
+}
+
+func TestEnd(t *testing.T) {
+	exp := startTest(t)
+
+	_, ok := Tracer().Start(context.Background(), "ok")
+	End(ok, nil)
+	_, bad := Tracer().Start(context.Background(), "bad")
+	End(bad, errors.New("worktree exists"))
+
+	got := exp.GetSpans()
+	if got[0].Status.Code == codes.Error {
+		t.Error("End(nil) should not set an error status")
+	}
+	if got[1].Status.Code != codes.Error || len(got[1].Events) != 1 {
+		t.Errorf("End(err) = status %+v, events %+v; want error status and an exception event", got[1].Status, got[1].Events)
+	}
+}
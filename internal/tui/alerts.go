@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/tui/desktop"
+	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
+	"github.com/Iron-Ham/claudio/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// -----------------------------------------------------------------------------
+// Input Alerts
+// -----------------------------------------------------------------------------
+
+// bellInterval is the least time between two bells for instances waiting
+// for input, so several instances stopping together ring once.
+const bellInterval = 5 * time.Second
+
+// maxAlertNames is how many instances a combined desktop notification
+// names before summarizing the rest.
+const maxAlertNames = 3
+
+// inputAlerts escalates instances waiting for input: a terminal bell when
+// they start waiting, then a desktop notification if they are still
+// waiting after tui.alerts.escalate_after_seconds.
+type inputAlerts struct {
+	cfg     config.TUIAlertsConfig
+	waiting map[string]time.Time // Instances not yet notified, by when they started waiting
+	bells   *desktop.Limiter
+	desktop *desktop.Limiter
+	notify  func(title, body string) error
+	now     func() time.Time
+}
+
+// newInputAlerts creates the alert state for cfg.
+func newInputAlerts(cfg config.TUIAlertsConfig) *inputAlerts {
+	return &inputAlerts{
+		cfg:     cfg,
+		waiting: make(map[string]time.Time),
+		bells:   desktop.NewLimiter(bellInterval),
+		desktop: desktop.NewLimiter(cfg.MinInterval()),
+		notify:  desktop.Notify,
+		now:     time.Now,
+	}
+}
+
+// inputAlertState returns the model's alert state, creating it from the
+// config on first use.
+func (m *Model) inputAlertState() *inputAlerts {
+	if m.alerts == nil {
+		m.alerts = newInputAlerts(config.Get().TUI.Alerts)
+	}
+	return m.alerts
+}
+
+// handleWaitingInput alerts the user that an instance started waiting for
+// input, and schedules the desktop notification. An instance the user is
+// typing into is not alerted.
+func (m *Model) handleWaitingInput(msg tuimsg.WaitingInputMsg) tea.Cmd {
+	if inst := m.activeInstance(); m.inputMode && inst != nil && inst.ID == msg.InstanceID {
+		return nil
+	}
+	a := m.inputAlertState()
+	now := a.now()
+
+	var cmds []tea.Cmd
+	if a.cfg.Bell {
+		if ok, _ := a.bells.Allow(now); ok {
+			cmds = append(cmds, tuimsg.RingBell())
+		}
+	}
+	if a.cfg.Desktop {
+		if _, pending := a.waiting[msg.InstanceID]; !pending {
+			a.waiting[msg.InstanceID] = now
+		}
+		cmds = append(cmds, scheduleAlertCheck(a.cfg.EscalateAfter()))
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleAlertCheck sends a desktop notification for the instances that
+// have waited past the escalation delay, combining them into one. Instances
+// no longer waiting are forgotten. When the rate limit holds the
+// notification back, or other instances are not yet due, another check is
+// scheduled.
+func (m *Model) handleAlertCheck() tea.Cmd {
+	a := m.inputAlertState()
+	now := a.now()
+	delay := a.cfg.EscalateAfter()
+
+	var due []*orchestrator.Instance
+	var next time.Duration
+	for id, since := range a.waiting {
+		inst := m.alertInstance(id)
+		if inst == nil || inst.Status != orchestrator.StatusWaitingInput {
+			delete(a.waiting, id)
+			continue
+		}
+		if wait := since.Add(delay).Sub(now); wait > 0 {
+			if next == 0 || wait < next {
+				next = wait
+			}
+			continue
+		}
+		due = append(due, inst)
+	}
+
+	if len(due) == 0 {
+		if next > 0 {
+			return scheduleAlertCheck(next)
+		}
+		return nil
+	}
+	if ok, wait := a.desktop.Allow(now); !ok {
+		return scheduleAlertCheck(wait)
+	}
+
+	for _, inst := range due {
+		delete(a.waiting, inst.ID)
+	}
+	title, body := alertMessage(m.sortedByCreation(due))
+	cmds := []tea.Cmd{sendDesktopNotification(a.notify, title, body)}
+	if next > 0 {
+		cmds = append(cmds, scheduleAlertCheck(next))
+	}
+	return tea.Batch(cmds...)
+}
+
+// alertInstance returns the session's instance with id, or nil.
+func (m *Model) alertInstance(id string) *orchestrator.Instance {
+	if m.session == nil {
+		return nil
+	}
+	return m.session.GetInstance(id)
+}
+
+// sortedByCreation orders instances as the sidebar lists them, so a
+// combined notification is stable.
+func (m *Model) sortedByCreation(instances []*orchestrator.Instance) []*orchestrator.Instance {
+	if m.session == nil || len(instances) < 2 {
+		return instances
+	}
+	ordered := make([]*orchestrator.Instance, 0, len(instances))
+	for _, inst := range m.session.Instances {
+		for _, due := range instances {
+			if due == inst {
+				ordered = append(ordered, inst)
+			}
+		}
+	}
+	return ordered
+}
+
+// alertMessage returns the desktop notification for instances waiting
+// for input.
+func alertMessage(instances []*orchestrator.Instance) (title, body string) {
+	if len(instances) == 1 {
+		inst := instances[0]
+		return "Claudio: input needed", alertName(inst, 80) + " is waiting for input"
+	}
+	names := make([]string, 0, maxAlertNames)
+	for _, inst := range instances[:min(len(instances), maxAlertNames)] {
+		names = append(names, alertName(inst, 40))
+	}
+	body = strings.Join(names, ", ")
+	if extra := len(instances) - maxAlertNames; extra > 0 {
+		body += fmt.Sprintf(" and %d more", extra)
+	}
+	return "Claudio: input needed", fmt.Sprintf("%d instances are waiting for input: %s", len(instances), body)
+}
+
+// alertName returns the first line of the instance's name, truncated to
+// maxLen runes.
+func alertName(inst *orchestrator.Instance, maxLen int) string {
+	name, _, _ := strings.Cut(strings.TrimSpace(inst.EffectiveName()), "\n")
+	return util.TruncateString(name, maxLen)
+}
+
+// scheduleAlertCheck returns a command that checks the waiting instances
+// after d.
+func scheduleAlertCheck(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return tuimsg.AlertCheckMsg{}
+	})
+}
+
+// sendDesktopNotification returns a command that shows a desktop
+// notification off the UI goroutine.
+func sendDesktopNotification(notify func(title, body string) error, title, body string) tea.Cmd {
+	return func() tea.Msg {
+		return tuimsg.DesktopNotifiedMsg{Err: notify(title, body)}
+	}
+}
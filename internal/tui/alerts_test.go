@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
+)
+
+// newAlertsTestModel returns a model with instances a, b, and c, alert
+// state on a fake clock, and the desktop notifications it sends.
+func newAlertsTestModel(now *time.Time) (Model, *[]string) {
+	m := newDashboardTestModel(3)
+	m.alerts = newInputAlerts(config.TUIAlertsConfig{
+		Bell:                 true,
+		Desktop:              true,
+		EscalateAfterSeconds: 30,
+		MinIntervalSeconds:   60,
+	})
+	m.alerts.now = func() time.Time { return *now }
+	var sent []string
+	m.alerts.notify = func(title, body string) error {
+		sent = append(sent, body)
+		return nil
+	}
+	return m, &sent
+}
+
+// startWaiting marks the instance waiting for input and delivers its alert.
+func startWaiting(m *Model, id string) {
+	m.session.GetInstance(id).Status = orchestrator.StatusWaitingInput
+	m.handleWaitingInput(tuimsg.WaitingInputMsg{InstanceID: id})
+}
+
+func TestInputAlerts_EscalateAndRateLimit(t *testing.T) {
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	now := start
+	m, sent := newAlertsTestModel(&now)
+
+	startWaiting(&m, "a")
+	now = start.Add(20 * time.Second)
+	startWaiting(&m, "b")
+	if cmd := m.handleAlertCheck(); cmd == nil {
+		t.Fatal("a check before any instance is due should schedule another")
+	}
+	if len(*sent) != 0 || len(m.alerts.waiting) != 2 {
+		t.Fatalf("sent %v with %d waiting; want nothing yet", *sent, len(m.alerts.waiting))
+	}
+
+	// a is due at 30s; b is not yet
+	now = start.Add(30 * time.Second)
+	m.handleAlertCheck()
+	if _, ok := m.alerts.waiting["a"]; ok {
+		t.Error("a should be notified once it is due")
+	}
+
+	// b is due at 50s, but the rate limit holds it until 90s
+	now = start.Add(50 * time.Second)
+	m.handleAlertCheck()
+	if _, ok := m.alerts.waiting["b"]; !ok {
+		t.Fatal("b should wait for the rate limit")
+	}
+
+	now = start.Add(90 * time.Second)
+	cmd := m.handleAlertCheck()
+	if cmd == nil {
+		t.Fatal("b should be notified after the interval")
+	}
+	if msg, ok := cmd().(tuimsg.DesktopNotifiedMsg); !ok || msg.Err != nil {
+		t.Fatalf("notification command returned %#v", msg)
+	}
+	if len(*sent) != 1 || (*sent)[0] != "task b is waiting for input" {
+		t.Errorf("sent %q", *sent)
+	}
+}
+
+func TestInputAlerts_ForgetsInstancesNoLongerWaiting(t *testing.T) {
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	m, sent := newAlertsTestModel(&now)
+
+	startWaiting(&m, "a")
+	m.session.GetInstance("a").Status = orchestrator.StatusWorking
+	now = now.Add(time.Minute)
+	if cmd := m.handleAlertCheck(); cmd != nil {
+		t.Error("nothing should be scheduled once no instance is waiting")
+	}
+	if len(*sent) != 0 || len(m.alerts.waiting) != 0 {
+		t.Errorf("sent %v with %d waiting; want a forgotten", *sent, len(m.alerts.waiting))
+	}
+}
+
+func TestInputAlerts_SkipsInstanceBeingTypedInto(t *testing.T) {
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	m, _ := newAlertsTestModel(&now)
+	m.activeTab = 0
+	m.inputMode = true
+
+	if cmd := m.handleWaitingInput(tuimsg.WaitingInputMsg{InstanceID: "a"}); cmd != nil {
+		t.Error("the instance in input mode should not be alerted")
+	}
+	if cmd := m.handleWaitingInput(tuimsg.WaitingInputMsg{InstanceID: "b"}); cmd == nil {
+		t.Error("other instances should still be alerted")
+	}
+}
+
+func TestAlertMessage(t *testing.T) {
+	var instances []*orchestrator.Instance
+	for _, name := range []string{"fix auth\nwith details", "docs", "tests", "lint"} {
+		instances = append(instances, &orchestrator.Instance{Task: name})
+	}
+	if _, body := alertMessage(instances[:1]); body != "fix auth is waiting for input" {
+		t.Errorf("single body = %q", body)
+	}
+	_, body := alertMessage(instances)
+	if !strings.HasPrefix(body, "4 instances are waiting for input: fix auth, docs, tests and 1 more") {
+		t.Errorf("combined body = %q", body)
+	}
+}
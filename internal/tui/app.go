@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/diag"
 	"github.com/Iron-Ham/claudio/internal/event"
 	"github.com/Iron-Ham/claudio/internal/instance"
 	"github.com/Iron-Ham/claudio/internal/instance/detect"
@@ -18,9 +19,11 @@ import (
 	"github.com/Iron-Ham/claudio/internal/orchestrator/workflows/tripleshot"
 	"github.com/Iron-Ham/claudio/internal/tui/command"
 	"github.com/Iron-Ham/claudio/internal/tui/filter"
+	"github.com/Iron-Ham/claudio/internal/tui/keymap"
 	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
 	"github.com/Iron-Ham/claudio/internal/tui/panel"
 	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/Iron-Ham/claudio/internal/tui/theme"
 	"github.com/Iron-Ham/claudio/internal/tui/update"
 	"github.com/Iron-Ham/claudio/internal/tui/view"
 	"github.com/Iron-Ham/claudio/internal/ultraplan"
@@ -237,10 +240,22 @@ func (a *App) Run() error {
 	// Shutdown() stops instances but preserves session state for potential resume.
 	defer func() { _ = a.orchestrator.Shutdown() }()
 
-	a.program = tea.NewProgram(
-		a.model,
-		tea.WithAltScreen(),
-	)
+	keys, err := keymap.New(config.Get().TUI.Keys)
+	if err != nil {
+		a.model.errorMessage = fmt.Sprintf("Invalid tui.keys, using the default keys: %v", err)
+	} else {
+		a.model.keys = keys
+	}
+
+	// Rebuild state the previous run only held in memory. This happens before
+	// the program copies the model and before live events are subscribed to.
+	a.model.replayFileClaims()
+
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+	if config.Get().TUI.Mouse {
+		opts = append(opts, tea.WithMouseCellMotion())
+	}
+	a.program = tea.NewProgram(a.model, opts...)
 
 	// Set up signal handling for graceful shutdown
 	// This ensures session state is preserved when the process is terminated
@@ -321,6 +336,16 @@ func (a *App) Run() error {
 	})
 	subscriptionIDs = append(subscriptionIDs, subID)
 
+	// Subscribe to instances waiting for input (bell and desktop alerts)
+	subID = eventBus.Subscribe("instance.waiting_input", func(e event.Event) {
+		waitingEvent, ok := e.(event.InstanceWaitingInputEvent)
+		if !ok {
+			return
+		}
+		a.program.Send(tuimsg.WaitingInputMsg{InstanceID: waitingEvent.InstanceID})
+	})
+	subscriptionIDs = append(subscriptionIDs, subID)
+
 	// Subscribe to pipeline lifecycle events
 	subID = eventBus.Subscribe("pipeline.phase_changed", func(e event.Event) {
 		pe, ok := e.(event.PipelinePhaseChangedEvent)
@@ -408,7 +433,86 @@ func (a *App) Run() error {
 	})
 	subscriptionIDs = append(subscriptionIDs, subID)
 
-	_, err := a.program.Run()
+	subID = eventBus.Subscribe("bridge.placement_failed", func(e event.Event) {
+		pe, ok := e.(event.BridgePlacementFailedEvent)
+		if !ok {
+			return
+		}
+		a.program.Send(tuimsg.BridgePlacementFailedMsg{
+			TeamID:  pe.TeamID,
+			TaskID:  pe.TaskID,
+			Backend: pe.Backend,
+			Reason:  pe.Reason,
+			Waiting: pe.Waiting,
+		})
+	})
+	subscriptionIDs = append(subscriptionIDs, subID)
+
+	// Subscribe to mailbox guard events
+	subID = eventBus.Subscribe("mailbox.message_flagged", func(e event.Event) {
+		fe, ok := e.(event.MailboxMessageFlaggedEvent)
+		if !ok {
+			return
+		}
+		a.program.Send(tuimsg.MailboxMessageFlaggedMsg{
+			MessageID: fe.MessageID,
+			From:      fe.From,
+			To:        fe.To,
+			Flags:     fe.Flags,
+			Held:      fe.Held,
+		})
+	})
+	subscriptionIDs = append(subscriptionIDs, subID)
+
+	// Subscribe to task proposals awaiting approval
+	subID = eventBus.Subscribe("task.proposed", func(e event.Event) {
+		pe, ok := e.(event.TaskProposedEvent)
+		if !ok {
+			return
+		}
+		a.program.Send(tuimsg.TaskProposedMsg{
+			ProposalID: pe.ProposalID,
+			From:       pe.From,
+			Title:      pe.Title,
+		})
+	})
+	subscriptionIDs = append(subscriptionIDs, subID)
+
+	// Subscribe to base branch drift on a running plan
+	subID = eventBus.Subscribe("plan.base_drift", func(e event.Event) {
+		de, ok := e.(event.BaseDriftEvent)
+		if !ok {
+			return
+		}
+		a.program.Send(tuimsg.BaseDriftMsg{
+			Ref:           de.Ref,
+			Commits:       de.Commits,
+			AffectedTasks: de.AffectedTasks,
+			Replanned:     de.Replanned,
+		})
+	})
+	subscriptionIDs = append(subscriptionIDs, subID)
+
+	// Subscribe to file claims for the files panel
+	subID = eventBus.Subscribe("filelock.claimed", func(e event.Event) {
+		ce, ok := e.(event.FileClaimEvent)
+		if !ok {
+			return
+		}
+		a.program.Send(tuimsg.FileClaimMsg{InstanceID: ce.InstanceID, FilePath: ce.FilePath, Claimed: true})
+	})
+	subscriptionIDs = append(subscriptionIDs, subID)
+
+	subID = eventBus.Subscribe("filelock.released", func(e event.Event) {
+		re, ok := e.(event.FileReleaseEvent)
+		if !ok {
+			return
+		}
+		a.program.Send(tuimsg.FileClaimMsg{InstanceID: re.InstanceID, FilePath: re.FilePath})
+	})
+	subscriptionIDs = append(subscriptionIDs, subID)
+
+	_, err = a.program.Run()
 
 	// Clean up signal handler
 	signal.Stop(sigChan)
@@ -432,10 +536,10 @@ func (m Model) Init() tea.Cmd {
 	// Apply theme from config at startup
 	// The styles package initializes with the default theme, but we need to
 	// respect the user's saved preference from config.
+	// Config validation reports an unknown theme or bad colors, and the
+	// active theme is left as is then.
 	cfg := config.Get()
-	if cfg.TUI.Theme != "" && styles.IsValidTheme(cfg.TUI.Theme) {
-		styles.SetActiveTheme(styles.ThemeName(cfg.TUI.Theme))
-	}
+	_ = theme.Apply(cfg.TUI.Theme, cfg.TUI.Colors)
 
 	// Schedule ultra-plan initialization if needed
 	if m.ultraPlan != nil && m.ultraPlan.Coordinator != nil {
@@ -468,24 +572,23 @@ func (m Model) Init() tea.Cmd {
 
 // Update handles messages and updates the model
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer diag.Default.Since("tui.update", time.Now())
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleKeypress(msg)
 
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
 	case tea.WindowSizeMsg:
 		wasReady := m.ready
 		m.width = msg.Width
 		m.height = msg.Height
 		m.ready = true
 
-		// Calculate the content area dimensions and resize tmux sessions
-		// Use the configured sidebar width to ensure tmux panels match the UI layout
-		cfg := config.Get()
-		contentWidth, contentHeight := CalculateContentDimensionsWithSidebarWidth(
-			m.width, m.height, cfg.TUI.SidebarWidth)
-		if m.orchestrator != nil && contentWidth > 0 && contentHeight > 0 {
-			m.orchestrator.ResizeAllInstances(contentWidth, contentHeight)
-		}
+		// Instances are resized to the new output area on a later tick, once
+		// the size has settled (see syncInstanceSize)
 
 		// Ensure active instance is still visible after resize
 		m.ensureActiveVisible()
@@ -514,10 +617,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Auto-dismiss info/error messages after timeout
 		m.autoDismissMessages()
 
+		// Advance transcript playback
+		if m.replay != nil {
+			m.replay.advance(time.Time(msg))
+		}
+
+		// Sample token usage for the dashboard's sparklines
+		m.recordUsage(time.Time(msg))
+
 		// Build commands for this tick
 		var cmds []tea.Cmd
 		cmds = append(cmds, tuimsg.Tick())
 
+		// Pick up group edits in the state snapshot that View renders from
+		if cmd := tuimsg.RefreshSnapshot(m.orchestrator); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
 		// Dispatch async commands to check tripleshot completion files
 		// This avoids blocking the UI with file I/O
 		cmds = append(cmds, m.dispatchTripleShotCompletionChecks()...)
@@ -559,6 +675,29 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Update ultraplan group collapse state when current group changes
 		m.updateGroupCollapseState()
 
+		// Keep instance panes the size of the output area they're shown in
+		if cmd := m.syncInstanceSize(time.Time(msg)); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+		// Reload uncommitted files of instances with new activity for the files panel
+		cmds = append(cmds, m.dispatchFileChangeChecks(time.Time(msg))...)
+
+		// Follow the owner's changes to an observed session
+		if cmd := m.dispatchObserveRefresh(time.Time(msg)); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+		// Read new session log entries for the log pane
+		if cmd := m.dispatchLogTail(time.Time(msg)); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+		// Read the conflicted files again for the conflict view
+		if cmd := m.dispatchConflictRefresh(time.Time(msg)); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
 		return m, tea.Batch(cmds...)
 
 	case tuimsg.UltraPlanInitMsg:
@@ -613,10 +752,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		update.HandleTimeout(m.newUpdateContext(), msg)
 		return m, nil
 
+	case tuimsg.BridgePlacementFailedMsg:
+		update.HandleBridgePlacementFailed(m.newUpdateContext(), msg)
+		return m, nil
+
+	case tuimsg.MailboxMessageFlaggedMsg:
+		update.HandleMailboxMessageFlagged(m.newUpdateContext(), msg)
+		return m, nil
+
+	case tuimsg.TaskProposedMsg:
+		update.HandleTaskProposed(m.newUpdateContext(), msg)
+		return m, nil
+
+	case tuimsg.BaseDriftMsg:
+		update.HandleBaseDrift(m.newUpdateContext(), msg)
+		return m, nil
+
 	case tuimsg.BellMsg:
 		// Terminal bell detected in a tmux session - forward it to the parent terminal
 		return m, tuimsg.RingBell()
 
+	case tuimsg.WaitingInputMsg:
+		return m, m.handleWaitingInput(msg)
+
+	case tuimsg.AlertCheckMsg:
+		return m, m.handleAlertCheck()
+
+	case tuimsg.DesktopNotifiedMsg:
+		if msg.Err != nil && m.logger != nil {
+			m.logger.Debug("desktop notification failed", "error", msg.Err)
+		}
+		return m, nil
+
 	case tuimsg.TaskAddedMsg:
 		// Delegate to update handler for async task addition
 		update.HandleTaskAdded(m.newUpdateContext(), msg)
@@ -781,6 +948,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle async plan file check result (single-pass mode)
 		return m.handlePlanFileCheckResult(msg)
 
+	case tuimsg.PlanRefinementFileCheckResultMsg:
+		// Handle the revised plan after human feedback
+		return m.handlePlanRefinementFileCheckResult(msg)
+
 	case tuimsg.MultiPassPlanFileCheckResultMsg:
 		// Handle async multi-pass plan file check result
 		return m.handleMultiPassPlanFileCheckResult(msg)
@@ -854,6 +1025,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tuimsg.DiffLoadedMsg:
 		return m.handleDiffLoaded(msg)
 
+	case tuimsg.TranscriptLoadedMsg:
+		return m.handleTranscriptLoaded(msg)
+
+	case tuimsg.TranscriptSearchMsg:
+		return m.handleTranscriptSearch(msg)
+
+	case tuimsg.ClipboardCopiedMsg:
+		return m.handleClipboardCopied(msg)
+
+	case tuimsg.FileClaimMsg:
+		m.handleFileClaim(msg)
+		return m, nil
+
+	case tuimsg.FileChangesLoadedMsg:
+		m.handleFileChangesLoaded(msg)
+		return m, nil
+
+	case tuimsg.LogTailedMsg:
+		m.handleLogTailed(msg)
+		return m, nil
+
+	case tuimsg.ObservedSessionMsg:
+		m.handleObservedSession(msg)
+		return m, nil
+
+	case tuimsg.ConflictsLoadedMsg:
+		m.handleConflictsLoaded(msg)
+		return m, nil
+
+	case tuimsg.ConflictEditedMsg:
+		return m.handleConflictEdited(msg)
+
 	// Pipeline and team orchestration messages
 	case tuimsg.PipelinePhaseChangedMsg:
 		m.ensurePipeline().UpdatePhase(msg.PipelineID, msg.CurrentPhase)
@@ -920,6 +1123,32 @@ func (m *Model) applyCommandResult(result command.Result) {
 	if result.ShowStats != nil {
 		// Toggle stats
 		m.showStats = !m.showStats
+		if m.showStats {
+			m.showFiles = false
+		}
+	}
+	if result.ShowFiles != nil {
+		m.toggleFilesPanel()
+	}
+	if result.LogFilter != nil {
+		m.setLogFilter(*result.LogFilter)
+	} else if result.ShowLogs != nil {
+		m.toggleLogPane()
+	}
+	if result.ShowDashboard != nil {
+		m.openDashboard()
+	}
+	if result.SplitWith != nil {
+		m.openSplit(*result.SplitWith)
+	}
+	if result.Search != nil {
+		m.openGrep(result.Search, result.SearchTranscripts)
+	}
+	if result.ShowConflicts != nil {
+		m.openConflictView()
+	}
+	if result.ShowDeps != nil {
+		m.openDepsView()
 	}
 	if result.ShowDiff != nil {
 		m.showDiff = *result.ShowDiff
@@ -1060,40 +1289,51 @@ func (m *Model) updateOutputs() {
 				if m.outputManager.SetOutput(inst.ID, string(output)) {
 					// Update scroll position (auto-scroll if enabled)
 					m.updateOutputScroll(inst.ID)
+					// New output may mean new edits
+					m.markFilesStale(inst.ID)
 				}
 			}
 
 			// Update instance status based on detected waiting state
-			// Check when working OR waiting for input (to detect completion after waiting)
-			if inst.Status == orchestrator.StatusWorking || inst.Status == orchestrator.StatusWaitingInput {
+			// Check when working OR waiting for input (to detect completion after waiting).
+			// An observed session's statuses are its owner's to change.
+			if m.observer == nil && (inst.Status == orchestrator.StatusWorking || inst.Status == orchestrator.StatusWaitingInput) {
 				m.updateInstanceStatus(inst, mgr)
 			}
 		}
 	}
 }
 
-// updateInstanceStatus updates an instance's status based on detected waiting state
+// updateInstanceStatus updates an instance's status based on detected waiting
+// state. The change goes through the orchestrator so it is made under its lock
+// and published to snapshot readers.
 func (m *Model) updateInstanceStatus(inst *orchestrator.Instance, mgr *instance.Manager) {
-	state := mgr.CurrentState()
 	previousStatus := inst.Status
+	status := previousStatus
 
-	switch state {
+	switch mgr.CurrentState() {
 	case detect.StateWaitingPermission, detect.StateWaitingQuestion, detect.StateWaitingInput:
-		inst.Status = orchestrator.StatusWaitingInput
+		status = orchestrator.StatusWaitingInput
 	case detect.StateCompleted:
-		inst.Status = orchestrator.StatusCompleted
-		// If just completed (status changed), check completion action
-		if previousStatus != orchestrator.StatusCompleted {
-			m.handleInstanceCompleted(inst)
-		}
+		status = orchestrator.StatusCompleted
 	case detect.StateError:
-		inst.Status = orchestrator.StatusError
+		status = orchestrator.StatusError
 	case detect.StateWorking:
 		// If currently marked as waiting but now working, go back to working
-		if inst.Status == orchestrator.StatusWaitingInput {
-			inst.Status = orchestrator.StatusWorking
+		if previousStatus == orchestrator.StatusWaitingInput {
+			status = orchestrator.StatusWorking
 		}
 	}
+
+	if status == previousStatus {
+		return
+	}
+	m.orchestrator.SetInstanceStatus(inst.ID, status)
+
+	// If just completed, check completion action
+	if status == orchestrator.StatusCompleted {
+		m.handleInstanceCompleted(inst)
+	}
 }
 
 // handleInstanceCompleted handles post-completion actions based on config
@@ -1156,11 +1396,19 @@ func (m Model) View() string {
 	if !m.ready {
 		return "Loading..."
 	}
+	defer diag.Default.Since("tui.view", time.Now())
 
 	if m.quitting {
 		return "Goodbye!\n"
 	}
 
+	// Render from the latest published snapshot so drawing never races with
+	// orchestrator goroutines updating live instances and groups. m is a
+	// copy, so swapping the session here does not leak into Update.
+	if snap := m.snapshotSession(); snap != nil {
+		m.session = snap
+	}
+
 	var b strings.Builder
 
 	// Header - unified header shows all active workflows
@@ -1210,6 +1458,12 @@ func (m Model) View() string {
 	mainArea := lipgloss.JoinHorizontal(lipgloss.Top, sidebarStyled, " ", contentStyled)
 	b.WriteString(mainArea)
 
+	// Log pane, below every view
+	if m.logPaneHeight() > 0 {
+		b.WriteString("\n")
+		b.WriteString(m.renderLogPane())
+	}
+
 	// Info or error message if any
 	if m.infoMessage != "" {
 		b.WriteString("\n")
@@ -1260,10 +1514,23 @@ func (m Model) renderUnifiedHeader() string {
 
 	// Build mode indicator state
 	modeState := &view.ModeIndicatorState{
-		CommandMode: m.commandMode,
-		FilterMode:  m.filterMode,
-		InputMode:   m.inputMode,
-		AddingTask:  m.addingTask,
+		CommandMode:   m.commandMode,
+		FilterMode:    m.filterMode,
+		SelectMode:    m.selection != nil,
+		ReplayMode:    m.replay != nil,
+		DashboardMode: m.dashboard != nil,
+		SplitMode:     m.split != nil,
+		GrepMode:      m.grep != nil,
+		ConflictMode:  m.conflicts != nil,
+		DepsMode:      m.deps != nil,
+		InputMode:     m.inputMode,
+		AddingTask:    m.addingTask,
+		Observer:      m.observer != nil,
+	}
+	if m.inputMode {
+		if inst := m.activeInstance(); inst != nil {
+			modeState.InputTarget = inst.EffectiveName()
+		}
 	}
 
 	// Get the workflow status and mode indicator
@@ -1341,6 +1608,30 @@ func (m Model) renderContent(width int) string {
 		return m.renderHelpPanel(width)
 	}
 
+	if m.replay != nil {
+		return m.renderReplay(width)
+	}
+
+	if m.dashboard != nil {
+		return m.renderDashboard(width)
+	}
+
+	if m.split != nil {
+		return m.renderSplit(width)
+	}
+
+	if m.grep != nil {
+		return m.renderGrep(width)
+	}
+
+	if m.conflicts != nil {
+		return m.renderConflicts(width)
+	}
+
+	if m.deps != nil {
+		return m.renderDeps(width)
+	}
+
 	if m.showDiff {
 		return m.renderDiffPanel(width)
 	}
@@ -1349,6 +1640,10 @@ func (m Model) renderContent(width int) string {
 		return m.renderStatsPanel(width)
 	}
 
+	if m.showFiles {
+		return m.renderFilesPanel(width)
+	}
+
 	if m.filterMode {
 		return m.renderFilterPanel(width)
 	}
@@ -1382,6 +1677,19 @@ func (m Model) renderInstance(inst *orchestrator.Instance, width int) string {
 		AutoScrollEnabled: m.isOutputAutoScroll(inst.ID),
 		HasNewOutput:      m.hasNewOutput(inst.ID),
 	}
+	if sim, ok := m.outputManager.SimilarOutput(inst.ID); ok {
+		renderState.SimilarTo = sim.InstanceID
+		renderState.SimilarScore = sim.Score
+	}
+
+	// While selecting, render the frozen snapshot so lines don't move under the cursor
+	if sel := m.selection; sel != nil && sel.instanceID == inst.ID {
+		renderState.OutputLines = sel.lines
+		renderState.ScrollOffset = sel.scroll
+		renderState.AutoScrollEnabled = false
+		renderState.HasNewOutput = false
+		renderState.Selection = sel.renderSelection()
+	}
 
 	instanceView := view.NewInstanceView(width, m.getOutputMaxLines())
 	return instanceView.RenderWithSession(inst, renderState, m.session)
@@ -1444,7 +1752,7 @@ func (m Model) renderAddTask(width int) string {
 // buildTemplateItems converts filtered templates to view template items.
 // Uses view.BuildTemplateItems for the conversion logic.
 func (m Model) buildTemplateItems() []view.TemplateItem {
-	templates := FilterTemplates(m.templateFilter)
+	templates := m.availableTemplates(m.templateFilter)
 	viewTemplates := make([]view.Template, len(templates))
 	for i, t := range templates {
 		viewTemplates[i] = view.Template{
@@ -1490,6 +1798,7 @@ func (m Model) renderHelpPanel(width int) string {
 		Height:       m.height - 4,
 		ScrollOffset: m.helpScroll,
 		Theme:        styles.NewTheme(),
+		HelpSections: panel.HelpSections(m.keyBindings()),
 	}
 
 	content := helpPanel.Render(state)
@@ -1529,18 +1838,38 @@ func (m Model) calculateExtraFooterLines() int {
 		extra += 2
 	}
 
+	// The log pane sits between the main area and the footer
+	extra += m.logPaneHeight()
+
 	return extra
 }
 
 // buildHelpBarState creates the view.HelpBarState from the current model state.
 func (m Model) buildHelpBarState() *view.HelpBarState {
-	return &view.HelpBarState{
+	state := &view.HelpBarState{
 		CommandMode:   m.commandMode,
 		CommandBuffer: m.commandBuffer,
 		InputMode:     m.inputMode,
 		ShowDiff:      m.showDiff,
 		FilterMode:    m.filterMode,
+		SelectMode:    m.selection != nil,
+		ReplayMode:    m.replay != nil,
+		DashboardMode: m.dashboard != nil,
+		SplitMode:     m.split != nil,
+		GrepMode:      m.grep != nil,
+		ConflictMode:  m.conflicts != nil,
+		DepsMode:      m.deps != nil,
+	}
+	if m.split != nil {
+		state.SplitSearching = m.split.searching
+	}
+	if m.selection != nil {
+		state.SelectedLines = m.selection.count()
+	}
+	if m.replay != nil {
+		state.ReplayPlaying = m.replay.playing
 	}
+	return state
 }
 
 // renderCommandModeHelp renders the help bar when in command mode.
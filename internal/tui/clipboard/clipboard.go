@@ -0,0 +1,95 @@
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// maxOSC52Bytes caps the text sent via OSC 52. Several terminals (and tmux)
+// drop sequences much larger than this, so bigger selections rely on the
+// native clipboard command alone.
+const maxOSC52Bytes = 100_000
+
+// nativeCommand is a clipboard helper that reads the text to copy on stdin.
+type nativeCommand struct {
+	name string
+	args []string
+}
+
+// nativeCommands are tried in order; the first one found on PATH is used.
+var nativeCommands = []nativeCommand{
+	{name: "pbcopy"},
+	{name: "wl-copy"},
+	{name: "xclip", args: []string{"-selection", "clipboard"}},
+	{name: "xsel", args: []string{"--clipboard", "--input"}},
+}
+
+// Wrapper functions for the environment and exec to allow testing
+var (
+	getenv      = os.Getenv
+	lookPath    = exec.LookPath
+	runWithText = func(name string, args []string, text string) error {
+		cmd := exec.Command(name, args...)
+		cmd.Stdin = strings.NewReader(text)
+		return cmd.Run()
+	}
+)
+
+// ErrEmpty is returned when there is nothing to copy after stripping ANSI.
+var ErrEmpty = errors.New("clipboard: nothing to copy")
+
+// Copy strips ANSI escape sequences from text and places it on the system
+// clipboard. It writes an OSC 52 sequence to term (the terminal's output)
+// and pipes the text to the first available native clipboard command.
+//
+// The returned method describes what was used, e.g. "OSC 52 + pbcopy". An
+// error is returned only when neither mechanism could be attempted.
+func Copy(term io.Writer, text string) (string, error) {
+	text = ansi.Strip(text)
+	if text == "" {
+		return "", ErrEmpty
+	}
+
+	var methods []string
+	if term != nil && len(text) <= maxOSC52Bytes {
+		if _, err := io.WriteString(term, osc52(text)); err == nil {
+			methods = append(methods, "OSC 52")
+		}
+	}
+
+	var nativeErr error
+	for _, c := range nativeCommands {
+		if _, err := lookPath(c.name); err != nil {
+			continue
+		}
+		if nativeErr = runWithText(c.name, c.args, text); nativeErr == nil {
+			methods = append(methods, c.name)
+		}
+		break
+	}
+
+	if len(methods) == 0 {
+		if nativeErr != nil {
+			return "", fmt.Errorf("clipboard: %w", nativeErr)
+		}
+		return "", errors.New("clipboard: no OSC 52 terminal output or clipboard command available")
+	}
+	return strings.Join(methods, " + "), nil
+}
+
+// osc52 returns the sequence that sets the system clipboard to text. Inside
+// tmux the plain sequence is handled by tmux itself (set-clipboard), and a
+// passthrough-wrapped copy is appended for setups where that is disabled.
+func osc52(text string) string {
+	seq := ansi.SetSystemClipboard(text)
+	if getenv("TMUX") != "" {
+		seq += ansi.TmuxPassthrough(seq)
+	}
+	return seq
+}
@@ -0,0 +1,104 @@
+package clipboard
+
+import (
+	"encoding/base64"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// stubEnv replaces the environment and exec hooks for the duration of a test.
+func stubEnv(t *testing.T, tmux string, available map[string]bool, run func(name string, args []string, text string) error) {
+	t.Helper()
+	origGetenv, origLookPath, origRun := getenv, lookPath, runWithText
+	t.Cleanup(func() {
+		getenv, lookPath, runWithText = origGetenv, origLookPath, origRun
+	})
+
+	getenv = func(key string) string {
+		if key == "TMUX" {
+			return tmux
+		}
+		return ""
+	}
+	lookPath = func(name string) (string, error) {
+		if available[name] {
+			return "/usr/bin/" + name, nil
+		}
+		return "", exec.ErrNotFound
+	}
+	runWithText = run
+}
+
+func TestCopy_StripsANSIAndUsesBoth(t *testing.T) {
+	var gotName, gotText string
+	stubEnv(t, "", map[string]bool{"xclip": true}, func(name string, args []string, text string) error {
+		gotName, gotText = name, text
+		return nil
+	})
+
+	var term strings.Builder
+	method, err := Copy(&term, "\x1b[31merror:\x1b[0m build failed\nline two")
+	if err != nil {
+		t.Fatalf("Copy() error = %v", err)
+	}
+	if method != "OSC 52 + xclip" {
+		t.Errorf("method = %q, want %q", method, "OSC 52 + xclip")
+	}
+	if gotName != "xclip" || gotText != "error: build failed\nline two" {
+		t.Errorf("native copy = (%q, %q)", gotName, gotText)
+	}
+
+	want := base64.StdEncoding.EncodeToString([]byte("error: build failed\nline two"))
+	if seq := term.String(); seq != "\x1b]52;c;"+want+"\x07" {
+		t.Errorf("OSC 52 sequence = %q", seq)
+	}
+}
+
+func TestCopy_TmuxPassthrough(t *testing.T) {
+	stubEnv(t, "/tmp/tmux-1000/default,123,0", nil, nil)
+
+	var term strings.Builder
+	method, err := Copy(&term, "hello")
+	if err != nil {
+		t.Fatalf("Copy() error = %v", err)
+	}
+	if method != "OSC 52" {
+		t.Errorf("method = %q, want OSC 52", method)
+	}
+	if !strings.Contains(term.String(), "\x1bPtmux;") {
+		t.Errorf("expected tmux passthrough wrapper, got %q", term.String())
+	}
+}
+
+func TestCopy_NativeFailureWithoutTerminal(t *testing.T) {
+	stubEnv(t, "", map[string]bool{"pbcopy": true}, func(string, []string, string) error {
+		return errors.New("exit status 1")
+	})
+
+	if _, err := Copy(nil, "hello"); err == nil {
+		t.Error("Copy() expected error when no method succeeds")
+	}
+}
+
+func TestCopy_Empty(t *testing.T) {
+	stubEnv(t, "", nil, nil)
+
+	if _, err := Copy(&strings.Builder{}, "\x1b[0m"); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Copy() error = %v, want ErrEmpty", err)
+	}
+}
+
+func TestCopy_LargeTextSkipsOSC52(t *testing.T) {
+	stubEnv(t, "", map[string]bool{"wl-copy": true}, func(string, []string, string) error { return nil })
+
+	var term strings.Builder
+	method, err := Copy(&term, strings.Repeat("x", maxOSC52Bytes+1))
+	if err != nil {
+		t.Fatalf("Copy() error = %v", err)
+	}
+	if method != "wl-copy" || term.Len() != 0 {
+		t.Errorf("method = %q, terminal bytes = %d; want wl-copy only", method, term.Len())
+	}
+}
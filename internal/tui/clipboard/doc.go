@@ -0,0 +1,23 @@
+// Package clipboard copies text from the Claudio TUI to the system clipboard.
+//
+// Claudio often runs inside tmux, sometimes nested inside another tmux over
+// SSH, where terminal mouse selection picks up sidebar borders and wrapped
+// lines. This package copies plain text without relying on the mouse:
+//
+//  1. An OSC 52 escape sequence is written to the terminal. Modern terminals
+//     (iTerm2, kitty, WezTerm, Alacritty, Windows Terminal) set the clipboard
+//     from it, including over SSH. Inside tmux the sequence is also wrapped
+//     in a DCS passthrough so it reaches the outer terminal when tmux's
+//     set-clipboard option is off.
+//
+//  2. The text is piped to the first available native clipboard command
+//     (pbcopy, wl-copy, xclip, xsel), which covers terminals without OSC 52
+//     support when Claudio runs on the local machine.
+//
+// ANSI escape sequences are stripped before copying, so captured output
+// pastes as plain text.
+//
+// # Usage
+//
+//	method, err := clipboard.Copy(os.Stdout, text)
+package clipboard
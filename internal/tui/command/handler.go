@@ -4,9 +4,11 @@ package command
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/Iron-Ham/claudio/internal/audit"
 	"github.com/Iron-Ham/claudio/internal/instance"
 	"github.com/Iron-Ham/claudio/internal/logging"
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
@@ -14,6 +16,7 @@ import (
 	"github.com/Iron-Ham/claudio/internal/orchestrator/workflows/ralph"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/workflows/tripleshot"
 	"github.com/Iron-Ham/claudio/internal/tui/msg"
+	"github.com/Iron-Ham/claudio/internal/tui/search"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -69,8 +72,34 @@ type Result struct {
 	TeaCmd tea.Cmd
 
 	// State changes (use pointers to distinguish "not set" from "set to false/zero")
-	ShowHelp    *bool
-	ShowStats   *bool
+	ShowHelp      *bool
+	ShowStats     *bool
+	ShowFiles     *bool
+	ShowDashboard *bool
+
+	// SplitWith is the ID of the instance to compare side by side with the
+	// active one
+	SplitWith *string
+
+	// ShowLogs toggles the log pane; with LogFilter set, it opens the pane
+	// showing only the entries that pass the filter
+	ShowLogs  *bool
+	LogFilter *logging.LogFilter
+
+	// Search is the pattern to search every instance's output for
+	Search *regexp.Regexp
+
+	// SearchTranscripts is set when recorded transcripts are searched too,
+	// by TeaCmd
+	SearchTranscripts bool
+
+	// ShowConflicts opens the view of the conflicts a paused consolidation
+	// is waiting on
+	ShowConflicts *bool
+
+	// ShowDeps opens the plan's task dependency graph
+	ShowDeps *bool
+
 	ShowDiff    *bool
 	Quitting    *bool
 	AddingTask  *bool
@@ -157,6 +186,8 @@ type CommandFlagInfo struct {
 type Handler struct {
 	commands    map[string]commandFunc
 	argCommands map[string]commandArgFunc // Commands that accept arguments
+	overrides   map[string]bool           // Commands recorded in the audit log when run
+	observable  map[string]bool           // Commands available while observing a session read-only
 	categories  []CommandCategory
 	flags       []CommandFlagInfo
 }
@@ -174,6 +205,8 @@ func New() *Handler {
 	h := &Handler{
 		commands:    make(map[string]commandFunc),
 		argCommands: make(map[string]commandArgFunc),
+		overrides:   make(map[string]bool),
+		observable:  make(map[string]bool),
 	}
 	h.registerCommands()
 	h.buildCategories()
@@ -200,9 +233,19 @@ func (h *Handler) Execute(cmd string, deps Dependencies) Result {
 		return Result{}
 	}
 
+	// Note the instance the command acts on before it runs: commands such as
+	// :remove change the active instance
+	target := ""
+	if inst := deps.ActiveInstance(); inst != nil {
+		target = inst.ID
+	}
+
 	// Look up exact command match first
 	if fn, ok := h.commands[cmd]; ok {
-		return fn(deps)
+		if !h.available(cmd, deps) {
+			return observerResult(cmd)
+		}
+		return h.recordOverride(cmd, cmd, target, fn(deps), deps)
 	}
 
 	// Check for arg-based commands (command word + optional arguments)
@@ -216,7 +259,10 @@ func (h *Handler) Execute(cmd string, deps Dependencies) Result {
 
 	// Look up arg-based command
 	if fn, ok := h.argCommands[cmdWord]; ok {
-		return fn(deps, args)
+		if !h.available(cmdWord, deps) {
+			return observerResult(cmdWord)
+		}
+		return h.recordOverride(cmdWord, cmd, target, fn(deps, args), deps)
 	}
 
 	// Unknown command
@@ -225,6 +271,38 @@ func (h *Handler) Execute(cmd string, deps Dependencies) Result {
 	}
 }
 
+// available reports whether the command name may run: every command does,
+// except that an observer of a session another process runs may only run
+// commands that change nothing.
+func (h *Handler) available(name string, deps Dependencies) bool {
+	orch := deps.GetOrchestrator()
+	return orch == nil || !orch.Observing() || h.observable[name]
+}
+
+// observerResult reports a command refused while observing.
+func observerResult(name string) Result {
+	return Result{ErrorMessage: fmt.Sprintf(":%s is not available while observing (read-only)", name)}
+}
+
+// recordOverride records a successfully run override command against the
+// instance it targeted in the session's audit log, together with the message
+// it produced, and returns result unchanged.
+func (h *Handler) recordOverride(name, cmd, instanceID string, result Result, deps Dependencies) Result {
+	if !h.overrides[name] || result.ErrorMessage != "" {
+		return result
+	}
+	orch := deps.GetOrchestrator()
+	if orch == nil {
+		return result
+	}
+	detail := ":" + cmd
+	if result.InfoMessage != "" {
+		detail += " (" + result.InfoMessage + ")"
+	}
+	orch.RecordOperatorAction(audit.ActionOverride, instanceID, "", detail)
+	return result
+}
+
 // registerCommands sets up all command mappings.
 func (h *Handler) registerCommands() {
 	// Instance control commands
@@ -258,6 +336,12 @@ func (h *Handler) registerCommands() {
 	h.commands["m"] = cmdStats
 	h.commands["metrics"] = cmdStats
 	h.commands["stats"] = cmdStats
+	h.commands["files"] = cmdFiles
+	h.commands["replay"] = cmdReplay
+	h.commands["dashboard"] = cmdDashboard
+	h.argCommands["split"] = cmdSplit
+	h.argCommands["grep"] = cmdGrep
+	h.argCommands["logs"] = cmdLogs
 	h.commands["f"] = cmdFilter
 	h.commands["F"] = cmdFilter
 	h.commands["filter"] = cmdFilter
@@ -269,6 +353,9 @@ func (h *Handler) registerCommands() {
 
 	// Ultraplan commands
 	h.commands["cancel"] = cmdUltraPlanCancel
+	h.commands["conflicts"] = cmdConflicts
+	h.commands["deps"] = cmdDeps
+	h.argCommands["release"] = cmdRelease
 	h.argCommands["ultraplan"] = cmdUltraPlan
 	h.argCommands["up"] = cmdUltraPlan
 
@@ -298,6 +385,24 @@ func (h *Handler) registerCommands() {
 		return executeGroupCommand(args, deps)
 	}
 
+	// Commands that change the course of the session are audited as overrides
+	for _, name := range []string{
+		"s", "start", "e", "exit", "p", "pause", "restart",
+		"D", "remove", "D!", "remove!", "kill", "C", "clear",
+		"r", "pr", "cancel", "release", "adversarial-retry", "cancel-ralph", "ralph-cancel", "group",
+	} {
+		h.overrides[name] = true
+	}
+
+	// Commands that only show the session are available to observers
+	for _, name := range []string{
+		"d", "diff", "m", "metrics", "stats", "files", "replay", "dashboard",
+		"split", "grep", "logs", "f", "F", "filter", "tmux", "deps",
+		"h", "help", "q", "quit",
+	} {
+		h.observable[name] = true
+	}
+
 	// Help commands
 	h.commands["h"] = cmdHelp
 	h.commands["help"] = cmdHelp
@@ -336,6 +441,12 @@ func (h *Handler) buildCategories() {
 			Commands: []CommandInfo{
 				{ShortKey: "d", LongKey: "diff", Description: "Toggle diff preview panel", Category: "view"},
 				{ShortKey: "m", LongKey: "stats", Description: "Toggle metrics panel", Category: "view"},
+				{ShortKey: "", LongKey: "files", Description: "Toggle files panel (claims, edits, conflicts)", Category: "view"},
+				{ShortKey: "", LongKey: "replay", Description: "Replay the selected instance's recorded transcript", Category: "view"},
+				{ShortKey: "", LongKey: "dashboard", Description: "Show all instances as a grid of tiles", Category: "view"},
+				{ShortKey: "", LongKey: "split [N]", Description: "Compare the selected instance side by side with instance N (default: the next one)", Category: "view"},
+				{ShortKey: "", LongKey: "grep [-t] PATTERN", Description: "Search every instance's output for a regex (-t: recorded transcripts too)", Category: "view"},
+				{ShortKey: "", LongKey: "logs [level=L] [instance=N] [phase=P]", Description: "Toggle the session log pane, or open it filtered", Category: "view"},
 				{ShortKey: "f", LongKey: "filter", Description: "Open filter panel", Category: "view"},
 			},
 		},
@@ -353,6 +464,9 @@ func (h *Handler) buildCategories() {
 				{ShortKey: "", LongKey: "pr --group=all", Description: "Create consolidated PR from all groups", Category: "utility"},
 				{ShortKey: "", LongKey: "pr --group=single", Description: "Create PR for current group only", Category: "utility"},
 				{ShortKey: "", LongKey: "cancel", Description: "Cancel ultra-plan execution", Category: "utility"},
+				{ShortKey: "", LongKey: "conflicts", Description: "Resolve the conflicts a paused consolidation is waiting on", Category: "utility"},
+				{ShortKey: "", LongKey: "deps", Description: "Show the plan's task dependency graph", Category: "utility"},
+				{ShortKey: "", LongKey: "release [ID]", Description: "Deliver a message the message guard held for review (default: list held messages)", Category: "utility"},
 				{ShortKey: "", LongKey: "tripleshot", Description: "Start triple-shot mode (3 parallel attempts + judge)", Category: "utility"},
 				{ShortKey: "", LongKey: "adversarial", Description: "Start adversarial mode (implementer + reviewer feedback loop)", Category: "utility"},
 				{ShortKey: "", LongKey: "adversarial-retry", Description: "Restart a stuck adversarial instance", Category: "utility"},
@@ -481,20 +595,25 @@ func cmdPause(deps Dependencies) Result {
 		return Result{ErrorMessage: "No orchestrator available"}
 	}
 
-	mgr := orch.GetInstanceManager(inst.ID)
-	if mgr == nil {
+	if orch.GetInstanceManager(inst.ID) == nil {
 		return Result{InfoMessage: "Instance has no manager"}
 	}
 
 	switch inst.Status {
 	case orchestrator.StatusPaused:
-		_ = mgr.Resume()
-		inst.Status = orchestrator.StatusWorking
+		parked := inst.ParkedAt != nil
+		if err := orch.UnpauseInstance(inst.ID); err != nil {
+			return Result{ErrorMessage: fmt.Sprintf("Failed to resume: %v", err)}
+		}
+		if parked {
+			return Result{InfoMessage: fmt.Sprintf("Resumed instance %s with where it left off", inst.ID)}
+		}
 		return Result{InfoMessage: fmt.Sprintf("Resumed instance %s", inst.ID)}
-	case orchestrator.StatusWorking:
-		_ = mgr.Pause()
-		inst.Status = orchestrator.StatusPaused
-		return Result{InfoMessage: fmt.Sprintf("Paused instance %s", inst.ID)}
+	case orchestrator.StatusWorking, orchestrator.StatusWaitingInput:
+		if err := orch.ParkInstance(inst.ID); err != nil {
+			return Result{ErrorMessage: fmt.Sprintf("Failed to pause: %v", err)}
+		}
+		return Result{InfoMessage: fmt.Sprintf("Paused instance %s; it will draft its progress and wait", inst.ID)}
 	default:
 		return Result{InfoMessage: "Instance is not in a pausable state"}
 	}
@@ -752,6 +871,159 @@ func cmdStats(_ Dependencies) Result {
 	return Result{ShowStats: &showStats}
 }
 
+func cmdFiles(_ Dependencies) Result {
+	showFiles := true
+	return Result{ShowFiles: &showFiles}
+}
+
+func cmdReplay(deps Dependencies) Result {
+	inst := deps.ActiveInstance()
+	if inst == nil {
+		return Result{InfoMessage: "No instance selected"}
+	}
+
+	orch := deps.GetOrchestrator()
+	if orch == nil {
+		return Result{ErrorMessage: "No orchestrator available"}
+	}
+
+	path := orch.TranscriptPath(inst.ID)
+	if path == "" {
+		return Result{ErrorMessage: "No transcript available: session has no directory"}
+	}
+
+	return Result{
+		InfoMessage: "Loading transcript...",
+		TeaCmd:      msg.LoadTranscriptAsync(path, inst.ID),
+	}
+}
+
+func cmdDashboard(_ Dependencies) Result {
+	showDashboard := true
+	return Result{ShowDashboard: &showDashboard}
+}
+
+// cmdSplit compares the active instance with another, given by sidebar
+// number, ID, or task name, or else the instance after it in the sidebar.
+func cmdSplit(deps Dependencies, args string) Result {
+	inst := deps.ActiveInstance()
+	if inst == nil {
+		return Result{InfoMessage: "No instance selected"}
+	}
+	session := deps.GetSession()
+	if session == nil || len(session.Instances) < 2 {
+		return Result{InfoMessage: "Split view needs at least two instances"}
+	}
+
+	var other *orchestrator.Instance
+	if args = strings.TrimSpace(args); args == "" {
+		for i, candidate := range session.Instances {
+			if candidate.ID == inst.ID {
+				other = session.Instances[(i+1)%len(session.Instances)]
+				break
+			}
+		}
+	} else {
+		orch := deps.GetOrchestrator()
+		if orch == nil {
+			return Result{ErrorMessage: "No orchestrator available"}
+		}
+		resolved, err := orch.ResolveInstanceReference(session, args)
+		if err != nil {
+			return Result{ErrorMessage: fmt.Sprintf("Cannot find instance: %v", err)}
+		}
+		other = resolved
+	}
+	if other == nil || other.ID == inst.ID {
+		return Result{ErrorMessage: "Choose a different instance to compare with (e.g., :split 2)"}
+	}
+
+	otherID := other.ID
+	return Result{SplitWith: &otherID}
+}
+
+// cmdGrep searches the output of every instance for a regular expression.
+// With -t, the instances' recorded transcripts are searched in the
+// background too.
+func cmdGrep(deps Dependencies, args string) Result {
+	transcripts := false
+	if rest, ok := strings.CutPrefix(args, "-t "); ok {
+		transcripts = true
+		args = strings.TrimSpace(rest)
+	}
+	if args == "" || args == "-t" {
+		return Result{ErrorMessage: "Usage: :grep [-t] PATTERN"}
+	}
+	re, err := regexp.Compile(args)
+	if err != nil {
+		return Result{ErrorMessage: fmt.Sprintf("Invalid pattern: %v", err)}
+	}
+
+	session := deps.GetSession()
+	if session == nil || len(session.Instances) == 0 {
+		return Result{InfoMessage: "No instances to search"}
+	}
+
+	result := Result{Search: re}
+	if transcripts {
+		orch := deps.GetOrchestrator()
+		if orch == nil || orch.TranscriptPath(session.Instances[0].ID) == "" {
+			return Result{ErrorMessage: "No transcripts available: session has no directory"}
+		}
+		ids := make([]string, len(session.Instances))
+		for i, inst := range session.Instances {
+			ids[i] = inst.ID
+		}
+		result.SearchTranscripts = true
+		result.TeaCmd = msg.SearchTranscriptsAsync(re, ids, orch.TranscriptPath, search.MaxHits)
+	}
+	return result
+}
+
+// logsUsage is the error shown for :logs arguments that don't parse.
+const logsUsage = "Usage: :logs [level=LEVEL] [instance=N] [phase=PHASE]"
+
+// cmdLogs toggles the log pane. With arguments, it opens the pane filtered
+// to a minimum level, an instance (by sidebar number, ID, or task name), or
+// a phase; the arguments replace any earlier filter.
+func cmdLogs(deps Dependencies, args string) Result {
+	showLogs := true
+	if args == "" {
+		return Result{ShowLogs: &showLogs}
+	}
+
+	var filter logging.LogFilter
+	for _, arg := range strings.Fields(args) {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || value == "" {
+			return Result{ErrorMessage: logsUsage}
+		}
+		switch key {
+		case "level":
+			level := strings.ToUpper(value)
+			if logging.LevelPriority(level) < 0 {
+				return Result{ErrorMessage: fmt.Sprintf("Invalid level %q: use debug, info, warn, or error", value)}
+			}
+			filter.MinLevel = level
+		case "instance":
+			orch := deps.GetOrchestrator()
+			if orch == nil {
+				return Result{ErrorMessage: "No orchestrator available"}
+			}
+			inst, err := orch.ResolveInstanceReference(deps.GetSession(), value)
+			if err != nil {
+				return Result{ErrorMessage: fmt.Sprintf("Cannot find instance: %v", err)}
+			}
+			filter.InstanceID = inst.ID
+		case "phase":
+			filter.Phase = value
+		default:
+			return Result{ErrorMessage: logsUsage}
+		}
+	}
+	return Result{ShowLogs: &showLogs, LogFilter: &filter}
+}
+
 func cmdFilter(_ Dependencies) Result {
 	filterMode := true
 	return Result{FilterMode: &filterMode}
@@ -773,7 +1045,11 @@ func cmdTmux(deps Dependencies) Result {
 		return Result{InfoMessage: "Instance has no manager"}
 	}
 
-	return Result{InfoMessage: "Attach with: " + mgr.AttachCommand()}
+	attach := mgr.AttachCommand()
+	if attach == "" {
+		return Result{InfoMessage: "Instance runs in a pty (instance.backend: pty) and cannot be attached to"}
+	}
+	return Result{InfoMessage: "Attach with: " + attach}
 }
 
 // cmdPRWithArgs handles the :pr command with optional arguments.
@@ -927,6 +1203,60 @@ func formatGroupPRMessage(mode prworkflow.GroupPRMode, targetGroupID *string, se
 	}
 }
 
+// cmdConflicts opens the conflict view of a consolidation paused on a
+// conflict.
+func cmdConflicts(deps Dependencies) Result {
+	if !deps.IsUltraPlanMode() {
+		return Result{ErrorMessage: "Not in ultraplan mode"}
+	}
+	coordinator := deps.GetUltraPlanCoordinator()
+	if coordinator == nil {
+		return Result{ErrorMessage: "No active ultraplan session"}
+	}
+	session := coordinator.Session()
+	if session == nil || session.Consolidation == nil || session.Consolidation.Phase != orchestrator.ConsolidationPaused {
+		return Result{ErrorMessage: "Consolidation is not paused on a conflict"}
+	}
+	showConflicts := true
+	return Result{ShowConflicts: &showConflicts}
+}
+
+// cmdDeps opens the dependency graph of the ultraplan's tasks.
+func cmdDeps(deps Dependencies) Result {
+	if !deps.IsUltraPlanMode() {
+		return Result{ErrorMessage: "Not in ultraplan mode"}
+	}
+	coordinator := deps.GetUltraPlanCoordinator()
+	if coordinator == nil {
+		return Result{ErrorMessage: "No active ultraplan session"}
+	}
+	if session := coordinator.Session(); session == nil || session.Plan == nil {
+		return Result{ErrorMessage: "No plan to show yet"}
+	}
+	showDeps := true
+	return Result{ShowDeps: &showDeps}
+}
+
+// cmdRelease delivers a message the message guard held for review
+// (ultraplan.message_guard: hold). Without an ID it lists the held messages.
+func cmdRelease(deps Dependencies, args string) Result {
+	orch := deps.GetOrchestrator()
+	if orch == nil {
+		return Result{ErrorMessage: "No orchestrator available"}
+	}
+	if args == "" {
+		held := orch.HeldMessages()
+		if len(held) == 0 {
+			return Result{InfoMessage: "No messages are held for review"}
+		}
+		return Result{InfoMessage: "Held messages: " + strings.Join(held, ", ") + " (:release ID to deliver one)"}
+	}
+	if err := orch.ReleaseMessage(args); err != nil {
+		return Result{ErrorMessage: fmt.Sprintf("Failed to release message: %v", err)}
+	}
+	return Result{InfoMessage: fmt.Sprintf("Released message %s", args)}
+}
+
 func cmdUltraPlanCancel(deps Dependencies) Result {
 	if !deps.IsUltraPlanMode() {
 		return Result{ErrorMessage: "Not in ultraplan mode"}
@@ -659,6 +659,14 @@ func TestStatsCommand(t *testing.T) {
 	}
 }
 
+func TestFilesCommand(t *testing.T) {
+	h := New()
+	result := h.Execute("files", newMockDeps())
+	if result.ShowFiles == nil || !*result.ShowFiles {
+		t.Error("expected ShowFiles to be set to true")
+	}
+}
+
 func TestFilterCommand(t *testing.T) {
 	tests := []struct {
 		name string
@@ -725,6 +733,180 @@ func TestDiffCommand(t *testing.T) {
 	})
 }
 
+func TestReplayCommand(t *testing.T) {
+	t.Run("no instance shows message", func(t *testing.T) {
+		h := New()
+		deps := newMockDeps()
+		deps.activeInstance = nil
+
+		result := h.Execute("replay", deps)
+		if result.InfoMessage != "No instance selected" {
+			t.Errorf("expected 'No instance selected', got %q", result.InfoMessage)
+		}
+	})
+
+	t.Run("no orchestrator shows error", func(t *testing.T) {
+		h := New()
+		deps := newMockDeps()
+		deps.activeInstance = &orchestrator.Instance{ID: "test-123"}
+		deps.orchestrator = nil
+
+		result := h.Execute("replay", deps)
+		if result.ErrorMessage != "No orchestrator available" {
+			t.Errorf("expected 'No orchestrator available', got %q", result.ErrorMessage)
+		}
+		if result.TeaCmd != nil {
+			t.Error("expected no command without an orchestrator")
+		}
+	})
+}
+
+func TestSplitCommand(t *testing.T) {
+	instances := []*orchestrator.Instance{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+
+	t.Run("defaults to the next instance", func(t *testing.T) {
+		deps := newMockDeps()
+		deps.session = &orchestrator.Session{Instances: instances}
+		deps.activeInstance = instances[2]
+
+		result := New().Execute("split", deps)
+		if result.SplitWith == nil || *result.SplitWith != "a" {
+			t.Errorf("SplitWith = %v, want the next instance a (wrapping)", result.SplitWith)
+		}
+	})
+
+	t.Run("resolves an instance reference", func(t *testing.T) {
+		deps := newMockDeps()
+		deps.orchestrator = &orchestrator.Orchestrator{}
+		deps.session = &orchestrator.Session{Instances: instances}
+		deps.activeInstance = instances[0]
+
+		result := New().Execute("split 3", deps)
+		if result.SplitWith == nil || *result.SplitWith != "c" {
+			t.Errorf("SplitWith = %v, want c", result.SplitWith)
+		}
+	})
+
+	t.Run("rejects the active instance", func(t *testing.T) {
+		deps := newMockDeps()
+		deps.orchestrator = &orchestrator.Orchestrator{}
+		deps.session = &orchestrator.Session{Instances: instances}
+		deps.activeInstance = instances[0]
+
+		result := New().Execute("split 1", deps)
+		if result.SplitWith != nil || result.ErrorMessage == "" {
+			t.Errorf("result = %+v, want an error comparing an instance with itself", result)
+		}
+	})
+
+	t.Run("needs two instances", func(t *testing.T) {
+		deps := newMockDeps()
+		deps.session = &orchestrator.Session{Instances: instances[:1]}
+		deps.activeInstance = instances[0]
+
+		result := New().Execute("split", deps)
+		if result.SplitWith != nil || result.InfoMessage == "" {
+			t.Errorf("result = %+v, want a message with a single instance", result)
+		}
+	})
+}
+
+func TestGrepCommand(t *testing.T) {
+	instances := []*orchestrator.Instance{{ID: "a"}, {ID: "b"}}
+
+	t.Run("searches output", func(t *testing.T) {
+		deps := newMockDeps()
+		deps.session = &orchestrator.Session{Instances: instances}
+
+		result := New().Execute("grep error: .+", deps)
+		if result.Search == nil || result.Search.String() != "error: .+" {
+			t.Fatalf("Search = %v, want the pattern", result.Search)
+		}
+		if result.SearchTranscripts || result.TeaCmd != nil {
+			t.Errorf("result = %+v, want no transcript search without -t", result)
+		}
+	})
+
+	t.Run("requires a pattern", func(t *testing.T) {
+		deps := newMockDeps()
+		deps.session = &orchestrator.Session{Instances: instances}
+
+		result := New().Execute("grep", deps)
+		if result.Search != nil || result.ErrorMessage != "Usage: :grep [-t] PATTERN" {
+			t.Errorf("result = %+v, want the usage", result)
+		}
+	})
+
+	t.Run("rejects an invalid pattern", func(t *testing.T) {
+		deps := newMockDeps()
+		deps.session = &orchestrator.Session{Instances: instances}
+
+		result := New().Execute("grep (unclosed", deps)
+		if result.Search != nil || result.ErrorMessage == "" {
+			t.Errorf("result = %+v, want an invalid pattern error", result)
+		}
+	})
+
+	t.Run("transcripts need a session directory", func(t *testing.T) {
+		deps := newMockDeps()
+		deps.session = &orchestrator.Session{Instances: instances}
+		deps.orchestrator = nil
+
+		result := New().Execute("grep -t error", deps)
+		if result.Search != nil || result.ErrorMessage == "" {
+			t.Errorf("result = %+v, want an error without an orchestrator", result)
+		}
+	})
+
+	t.Run("needs instances", func(t *testing.T) {
+		deps := newMockDeps()
+		deps.session = &orchestrator.Session{}
+
+		result := New().Execute("grep error", deps)
+		if result.Search != nil || result.InfoMessage != "No instances to search" {
+			t.Errorf("result = %+v, want a message without instances", result)
+		}
+	})
+}
+
+func TestLogsCommand(t *testing.T) {
+	instances := []*orchestrator.Instance{{ID: "a"}, {ID: "b"}}
+
+	t.Run("toggles without arguments", func(t *testing.T) {
+		result := New().Execute("logs", newMockDeps())
+		if result.ShowLogs == nil || result.LogFilter != nil {
+			t.Errorf("result = %+v, want a toggle without a filter", result)
+		}
+	})
+
+	t.Run("opens filtered", func(t *testing.T) {
+		deps := newMockDeps()
+		deps.orchestrator = &orchestrator.Orchestrator{}
+		deps.session = &orchestrator.Session{Instances: instances}
+
+		result := New().Execute("logs level=warn instance=2 phase=execution", deps)
+		if result.ShowLogs == nil || result.LogFilter == nil {
+			t.Fatalf("result = %+v, want the pane opened with a filter", result)
+		}
+		want := logging.LogFilter{MinLevel: logging.LevelWarn, InstanceID: "b", Phase: "execution"}
+		if *result.LogFilter != want {
+			t.Errorf("LogFilter = %+v, want %+v", *result.LogFilter, want)
+		}
+	})
+
+	t.Run("rejects bad arguments", func(t *testing.T) {
+		deps := newMockDeps()
+		deps.orchestrator = &orchestrator.Orchestrator{}
+		deps.session = &orchestrator.Session{Instances: instances}
+
+		for _, cmd := range []string{"logs level=loud", "logs instance=9", "logs warn", "logs color=red"} {
+			if result := New().Execute(cmd, deps); result.ShowLogs != nil || result.ErrorMessage == "" {
+				t.Errorf("%s: result = %+v, want an error", cmd, result)
+			}
+		}
+	})
+}
+
 func TestInstanceControlCommandsNoInstance(t *testing.T) {
 	// All instance control commands should return "No instance selected" when no instance
 	commands := []string{
@@ -873,7 +1055,7 @@ func TestAllCommandsRecognized(t *testing.T) {
 		"a", "add", "chain", "dep", "depends", "D", "remove", "kill", "C", "clear",
 		// View toggles
 		"d", "diff", "m", "metrics", "stats",
-		"f", "F", "filter",
+		"f", "F", "filter", "replay", "dashboard", "split", "logs",
 		// Utilities
 		"tmux", "r", "pr",
 		// Ultraplan
@@ -979,6 +1161,24 @@ func TestTmuxCommandNoOrchestrator(t *testing.T) {
 	})
 }
 
+func TestReleaseCommand(t *testing.T) {
+	h := New()
+	deps := newMockDeps()
+
+	deps.orchestrator = nil
+	if result := h.Execute("release msg-1", deps); result.ErrorMessage == "" {
+		t.Error("expected error without orchestrator")
+	}
+
+	deps.orchestrator = &orchestrator.Orchestrator{}
+	if result := h.Execute("release", deps); result.InfoMessage != "No messages are held for review" {
+		t.Errorf("release with no held messages: info=%q error=%q", result.InfoMessage, result.ErrorMessage)
+	}
+	if result := h.Execute("release msg-1", deps); result.ErrorMessage == "" {
+		t.Error("expected error releasing a message that is not held")
+	}
+}
+
 // TestRemoveCommandNoSession tests cmdRemove when session is nil
 func TestRemoveCommandNoSession(t *testing.T) {
 	t.Run("no session returns error", func(t *testing.T) {
@@ -1815,3 +2015,24 @@ func TestArgCommandsPrecedence(t *testing.T) {
 
 // Ensure mockDeps satisfies the interface at compile time
 var _ Dependencies = (*mockDeps)(nil)
+
+func TestExecute_ObserverOnlyRunsReadOnlyCommands(t *testing.T) {
+	h := New()
+	sess := orchestrator.NewSession("observed", "/repo")
+	deps := newMockDeps()
+	deps.session = sess
+	deps.orchestrator = orchestrator.NewObserverForTesting(sess)
+
+	for _, cmd := range []string{"start", "add", "remove", "kill", "q!", "group create x", "ultraplan task"} {
+		if result := h.Execute(cmd, deps); result.ErrorMessage == "" || result.TeaCmd != nil {
+			t.Errorf("Execute(%q) while observing = %+v, want it refused", cmd, result)
+		}
+	}
+
+	if result := h.Execute("help", deps); result.ShowHelp == nil {
+		t.Errorf("Execute(help) while observing = %+v, want help shown", result)
+	}
+	if result := h.Execute("quit", deps); result.Quitting == nil || !*result.Quitting {
+		t.Errorf("Execute(quit) while observing = %+v, want quit", result)
+	}
+}
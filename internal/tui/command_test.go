@@ -671,7 +671,7 @@ func TestHelpPanelContainsAllCommands(t *testing.T) {
 	m := testModel()
 	// Set terminal size large enough to show all help content without scrolling
 	m.width = 120
-	m.height = 200
+	m.height = 300
 
 	// Render the help panel
 	helpContent := m.renderHelpPanel(100)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/Iron-Ham/claudio/internal/config"
 	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/Iron-Ham/claudio/internal/tui/theme"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -111,6 +112,55 @@ func New() Model {
 					Type:        "int",
 					Category:    "tui",
 				},
+				{
+					Key:         "tui.confirm_destructive_input",
+					Label:       "Confirm Destructive Input",
+					Description: "In input mode, require Ctrl+C, Ctrl+D, and Ctrl+\\ to be pressed twice before they reach the instance",
+					Type:        "bool",
+					Category:    "tui",
+				},
+				{
+					Key:         "tui.require_input_modifier",
+					Label:       "Require Modifier for Input",
+					Description: "Only Alt+i enters input mode; plain [i] and [Enter] are ignored",
+					Type:        "bool",
+					Category:    "tui",
+				},
+				{
+					Key:         "tui.mouse",
+					Label:       "Mouse Support",
+					Description: "Scroll with the wheel, click to select instances, and drag over output to select lines (takes effect on restart)",
+					Type:        "bool",
+					Category:    "tui",
+				},
+				{
+					Key:         "tui.alerts.bell",
+					Label:       "Input Bell",
+					Description: "Ring the terminal bell when an instance starts waiting for input",
+					Type:        "bool",
+					Category:    "tui",
+				},
+				{
+					Key:         "tui.alerts.desktop",
+					Label:       "Desktop Notifications",
+					Description: "Show an OS notification for instances still waiting for input",
+					Type:        "bool",
+					Category:    "tui",
+				},
+				{
+					Key:         "tui.alerts.escalate_after_seconds",
+					Label:       "Notify After",
+					Description: "Seconds an instance waits before the desktop notification (0 = at once)",
+					Type:        "int",
+					Category:    "tui",
+				},
+				{
+					Key:         "tui.alerts.min_interval_seconds",
+					Label:       "Notify Interval",
+					Description: "Least seconds between desktop notifications",
+					Type:        "int",
+					Category:    "tui",
+				},
 			},
 		},
 		{
@@ -123,11 +173,133 @@ func New() Model {
 					Type:        "bool",
 					Category:    "session",
 				},
+				{
+					Key:         "session.storage.backend",
+					Label:       "Checkpoint Storage",
+					Description: "Where session checkpoints are written (empty = no checkpoints)",
+					Type:        "select",
+					Options:     config.ValidSessionStorageBackends(),
+					Category:    "session",
+				},
+				{
+					Key:         "session.storage.checkpoint_interval_seconds",
+					Label:       "Checkpoint Interval (seconds)",
+					Description: "How often changed session files are checkpointed",
+					Type:        "int",
+					Category:    "session",
+				},
+				{
+					Key:         "session.storage.path",
+					Label:       "Checkpoint Directory",
+					Description: "Directory for the local checkpoint backend (e.g., a CI cache)",
+					Type:        "string",
+					Category:    "session",
+				},
+				{
+					Key:         "session.storage.prefix",
+					Label:       "Checkpoint Prefix",
+					Description: "Prefix prepended to every checkpoint key",
+					Type:        "string",
+					Category:    "session",
+				},
+				{
+					Key:         "session.storage.bucket",
+					Label:       "S3 Bucket",
+					Description: "Bucket for the s3 checkpoint backend",
+					Type:        "string",
+					Category:    "session",
+				},
+				{
+					Key:         "session.storage.region",
+					Label:       "S3 Region",
+					Description: "Bucket region (default: $AWS_REGION, then us-east-1)",
+					Type:        "string",
+					Category:    "session",
+				},
+				{
+					Key:         "session.storage.endpoint",
+					Label:       "S3 Endpoint",
+					Description: "URL of an S3-compatible service such as MinIO or R2 (default: AWS)",
+					Type:        "string",
+					Category:    "session",
+				},
+				{
+					Key:         "session.storage.path_style",
+					Label:       "S3 Path-Style URLs",
+					Description: "Address the bucket in the URL path (needed by most S3-compatible services)",
+					Type:        "bool",
+					Category:    "session",
+				},
+				{
+					Key:         "session.database.enabled",
+					Label:       "Session History Database",
+					Description: "Record sessions and instance metrics in a SQLite database for 'claudio sessions history'",
+					Type:        "bool",
+					Category:    "session",
+				},
+				{
+					Key:         "session.database.path",
+					Label:       "History Database Path",
+					Description: "SQLite database file (empty = .claudio/history.db)",
+					Type:        "string",
+					Category:    "session",
+				},
+				{
+					Key:         "session.report.enabled",
+					Label:       "Session Reports",
+					Description: "Write a Markdown report to .claudio/reports when a session completes",
+					Type:        "bool",
+					Category:    "session",
+				},
+				{
+					Key:         "session.report.html",
+					Label:       "HTML Session Reports",
+					Description: "Also write each session report as HTML",
+					Type:        "bool",
+					Category:    "session",
+				},
+				{
+					Key:         "session.archive.after_days",
+					Label:       "Archive After (days)",
+					Description: "Compress completed sessions idle this many days into .claudio/archive (0 = never)",
+					Type:        "int",
+					Category:    "session",
+				},
+				{
+					Key:         "session.archive.keep_last",
+					Label:       "Archives to Keep",
+					Description: "Keep at most this many session archives (0 = no limit)",
+					Type:        "int",
+					Category:    "session",
+				},
+				{
+					Key:         "session.archive.max_total_mb",
+					Label:       "Archive Size Limit (MB)",
+					Description: "Delete the oldest archives past this total size (0 = no limit)",
+					Type:        "int",
+					Category:    "session",
+				},
 			},
 		},
 		{
 			Name: "Instance",
 			Items: []ConfigItem{
+				{
+					Key:         "instance.backend",
+					Label:       "Process Backend",
+					Description: "Run instances in tmux sessions, or directly in a pty without tmux",
+					Type:        "select",
+					Options:     config.ValidInstanceBackends(),
+					Category:    "instance",
+				},
+				{
+					Key:         "instance.output_format",
+					Label:       "Output Format",
+					Description: "Read Claude instance state from the rendered screen (text) or from stream-json events",
+					Type:        "select",
+					Options:     config.ValidInstanceOutputFormats(),
+					Category:    "instance",
+				},
 				{
 					Key:         "instance.output_buffer_size",
 					Label:       "Output Buffer Size",
@@ -177,6 +349,13 @@ func New() Model {
 					Type:        "bool",
 					Category:    "instance",
 				},
+				{
+					Key:         "instance.record_transcripts",
+					Label:       "Record Transcripts",
+					Description: "Record instance screens for replay with :replay",
+					Type:        "bool",
+					Category:    "instance",
+				},
 				{
 					Key:         "instance.tmux_history_limit",
 					Label:       "Tmux History Limit",
@@ -184,6 +363,104 @@ func New() Model {
 					Type:        "int",
 					Category:    "instance",
 				},
+				{
+					Key:         "instance.escalation.enabled",
+					Label:       "Stall Escalation",
+					Description: "Try recovery steps before marking a stalled instance stuck",
+					Type:        "bool",
+					Category:    "instance",
+				},
+				{
+					Key:         "instance.escalation.steps",
+					Label:       "Escalation Steps",
+					Description: "Comma-separated ladder: nudge,interview,interrupt,restart,mark_stuck",
+					Type:        "string",
+					Category:    "instance",
+				},
+				{
+					Key:         "instance.escalation.step_wait_seconds",
+					Label:       "Escalation Step Wait (s)",
+					Description: "Seconds to wait for new output after each escalation step",
+					Type:        "int",
+					Category:    "instance",
+				},
+				{
+					Key:         "instance.escalation.max_nudges",
+					Label:       "Max Nudges",
+					Description: "Times the nudge step is repeated before the next step",
+					Type:        "int",
+					Category:    "instance",
+				},
+				{
+					Key:         "instance.escalation.nudge_message",
+					Label:       "Nudge Message",
+					Description: "Message sent by the nudge step (empty = built-in)",
+					Type:        "string",
+					Category:    "instance",
+				},
+				{
+					Key:         "instance.escalation.interview_prompt",
+					Label:       "Interview Prompt",
+					Description: "Prompt sent by the diagnostic interview step (empty = built-in)",
+					Type:        "string",
+					Category:    "instance",
+				},
+				{
+					Key:         "instance.permission_policy.enabled",
+					Label:       "Permission Policy",
+					Description: "Approve permission prompts matching the policy's allow rules",
+					Type:        "bool",
+					Category:    "instance",
+				},
+				{
+					Key:         "instance.permission_policy.response",
+					Label:       "Permission Response",
+					Description: "Input sent to approve a permission prompt",
+					Type:        "string",
+					Category:    "instance",
+				},
+				{
+					Key:         "instance.resource_limits.enabled",
+					Label:       "Resource Limits",
+					Description: "Renice instances and limit their CPU and memory",
+					Type:        "bool",
+					Category:    "instance",
+				},
+				{
+					Key:         "instance.resource_limits.nice",
+					Label:       "Instance Niceness",
+					Description: "Niceness of instance processes (0-19)",
+					Type:        "int",
+					Category:    "instance",
+				},
+				{
+					Key:         "instance.resource_limits.gomaxprocs",
+					Label:       "Build GOMAXPROCS",
+					Description: "GOMAXPROCS for builds in instances (0 = CPUs / instances)",
+					Type:        "int",
+					Category:    "instance",
+				},
+				{
+					Key:         "instance.resource_limits.cpu_percent",
+					Label:       "Total CPU (%)",
+					Description: "CPU shared by all instances, 400 = 4 CPUs (0 = unlimited, Linux)",
+					Type:        "int",
+					Category:    "instance",
+				},
+				{
+					Key:         "instance.resource_limits.memory_mb",
+					Label:       "Total Memory (MiB)",
+					Description: "Memory shared by all instances (0 = unlimited, Linux)",
+					Type:        "int",
+					Category:    "instance",
+				},
+				{
+					Key:         "instance.resource_limits.cgroup_parent",
+					Label:       "Cgroup Parent",
+					Description: "cgroup v2 directory for instance cgroups (empty = Claudio's own)",
+					Type:        "string",
+					Category:    "instance",
+				},
 			},
 		},
 		{
@@ -261,6 +538,13 @@ func New() Model {
 					Type:        "bool",
 					Category:    "ai",
 				},
+				{
+					Key:         "ai.claude.hooks",
+					Label:       "Claude Hooks",
+					Description: "Install Claude Code hooks that report tool use, stops, and notifications to Claudio for state detection",
+					Type:        "bool",
+					Category:    "ai",
+				},
 			},
 		},
 		{
@@ -301,6 +585,22 @@ func New() Model {
 					Type:        "string",
 					Category:    "pr",
 				},
+				{
+					Key:         "pr.missing_cli",
+					Label:       "Missing gh CLI",
+					Description: "Without gh: push branches and record PRs to create later, or fail",
+					Type:        "select",
+					Options:     []string{"degrade", "fail"},
+					Category:    "pr",
+				},
+				{
+					Key:         "pr.provider",
+					Label:       "Provider",
+					Description: "Where PRs are created: github, gitlab, bitbucket, or auto from the remote",
+					Type:        "select",
+					Options:     config.ValidPRProviders(),
+					Category:    "pr",
+				},
 			},
 		},
 		{
@@ -332,6 +632,13 @@ func New() Model {
 					Type:        "bool",
 					Category:    "cleanup",
 				},
+				{
+					Key:         "cleanup.auto_gc",
+					Label:       "Auto GC on Start",
+					Description: "Remove orphaned tmux sessions and clean worktrees on start",
+					Type:        "bool",
+					Category:    "cleanup",
+				},
 				{
 					Key:         "cleanup.keep_remote_branches",
 					Label:       "Keep Remote Branches",
@@ -339,6 +646,20 @@ func New() Model {
 					Type:        "bool",
 					Category:    "cleanup",
 				},
+				{
+					Key:         "cleanup.reap_merged",
+					Label:       "Reap Merged Branches",
+					Description: "Delete task and group branches and worktrees after their PRs merge",
+					Type:        "bool",
+					Category:    "cleanup",
+				},
+				{
+					Key:         "cleanup.merged_retention_hours",
+					Label:       "Merged Retention (hrs)",
+					Description: "Hours to keep merged branches before reaping (0 = immediately)",
+					Type:        "int",
+					Category:    "cleanup",
+				},
 			},
 		},
 		{
@@ -365,6 +686,21 @@ func New() Model {
 					Type:        "int",
 					Category:    "resources",
 				},
+				{
+					Key:         "resources.instance_cost_limit",
+					Label:       "Cost Limit/Instance ($)",
+					Description: "Max cost per instance in USD (0 = no limit)",
+					Type:        "float",
+					Category:    "resources",
+				},
+				{
+					Key:         "resources.budget_action",
+					Label:       "Budget Action",
+					Description: "What happens to instances over a limit: pause or stop",
+					Type:        "select",
+					Options:     config.ValidBudgetActions(),
+					Category:    "resources",
+				},
 				{
 					Key:         "resources.show_metrics_in_sidebar",
 					Label:       "Show Metrics",
@@ -406,6 +742,20 @@ func New() Model {
 					Options:     []string{"stacked", "single"},
 					Category:    "ultraplan",
 				},
+				{
+					Key:         "ultraplan.merge_queue",
+					Label:       "Merge Queue",
+					Description: "Consolidate with rerere, resolve additive conflicts, and retry branches in another order",
+					Type:        "bool",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.conflict_resolver",
+					Label:       "Conflict Resolver",
+					Description: "Start an instance to resolve cherry-pick conflicts during group consolidation",
+					Type:        "bool",
+					Category:    "ultraplan",
+				},
 				{
 					Key:         "ultraplan.create_draft_prs",
 					Label:       "Create Draft PRs",
@@ -427,6 +777,36 @@ func New() Model {
 					Type:        "string",
 					Category:    "ultraplan",
 				},
+				{
+					Key:         "ultraplan.max_behind_commits",
+					Label:       "Max Behind Commits",
+					Description: "Commits the consolidated branch may trail main before PRs are stale (0 = off)",
+					Type:        "int",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.auto_rebase",
+					Label:       "Auto Rebase",
+					Description: "Rebase stale consolidated branches and re-verify before opening PRs",
+					Type:        "bool",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.drift_action",
+					Label:       "Drift Action",
+					Description: "When main gains commits mid-run: off, warn about affected tasks, or replan them",
+					Type:        "select",
+					Options:     []string{"off", "warn", "replan"},
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.conflict_prediction",
+					Label:       "Conflict Prediction",
+					Description: "Parallel tasks likely to conflict: off, warn in the plan view, or serialize tasks sharing files",
+					Type:        "select",
+					Options:     []string{"off", "warn", "serialize"},
+					Category:    "ultraplan",
+				},
 				{
 					Key:         "ultraplan.max_task_retries",
 					Label:       "Max Task Retries",
@@ -434,6 +814,69 @@ func New() Model {
 					Type:        "int",
 					Category:    "ultraplan",
 				},
+				{
+					Key:         "ultraplan.retry.backoff_seconds",
+					Label:       "Retry Backoff",
+					Description: "Seconds to wait before a task's first retry, doubling per retry (0 = immediately)",
+					Type:        "int",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.retry.max_backoff_seconds",
+					Label:       "Max Retry Backoff",
+					Description: "Cap on the wait between retries in seconds (0 = no cap)",
+					Type:        "int",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.retry.augment_prompt",
+					Label:       "Retry With Failure Context",
+					Description: "Tell retries why the previous attempt failed and what it left uncommitted",
+					Type:        "bool",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.retry.final_attempt_model",
+					Label:       "Final Attempt Model",
+					Description: "Model for a task's last retry, e.g. opus (empty = usual model)",
+					Type:        "string",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.models.planning",
+					Label:       "Planning Model",
+					Description: "Model for planning and plan selection (empty = backend default)",
+					Type:        "string",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.models.execution",
+					Label:       "Execution Model",
+					Description: "Model for task instances (empty = backend default)",
+					Type:        "string",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.models.synthesis",
+					Label:       "Synthesis Model",
+					Description: "Model for the synthesis review (empty = backend default)",
+					Type:        "string",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.models.revision",
+					Label:       "Revision Model",
+					Description: "Model for revision instances (empty = backend default)",
+					Type:        "string",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.models.consolidation",
+					Label:       "Consolidation Model",
+					Description: "Model for consolidation instances (empty = backend default)",
+					Type:        "string",
+					Category:    "ultraplan",
+				},
 				{
 					Key:         "ultraplan.require_verified_commits",
 					Label:       "Require Verified Commits",
@@ -441,6 +884,135 @@ func New() Model {
 					Type:        "bool",
 					Category:    "ultraplan",
 				},
+				{
+					Key:         "ultraplan.fresh_verification",
+					Label:       "Fresh Verification",
+					Description: "Re-run verification commands instead of using results cached for the same tree",
+					Type:        "bool",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.verify.auto_detect",
+					Label:       "Detect Verify Commands",
+					Description: "Without verify commands, run build/lint/test commands detected from go.mod, package.json, etc.",
+					Type:        "bool",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.verify.on_failure",
+					Label:       "Verify On Failure",
+					Description: "When a group verification command fails: fail the group, or pause before the next group",
+					Type:        "select",
+					Options:     []string{"fail", "pause"},
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.verify.timeout_seconds",
+					Label:       "Verify Timeout",
+					Description: "Seconds each group verification command may run (0 = no limit)",
+					Type:        "int",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.group_approval",
+					Label:       "Group Approval",
+					Description: "Pause after each group is consolidated until the next group is approved",
+					Type:        "bool",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.synthesis_reviewers",
+					Label:       "Synthesis Reviewers",
+					Description: "Parallel synthesis reviewers focused on correctness, tests, and architecture (1-3)",
+					Type:        "int",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.self_review",
+					Label:       "Task Self-Review",
+					Description: "End task prompts with a self-review and confidence score; synthesis checks low scores first",
+					Type:        "bool",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.progress_summary_minutes",
+					Label:       "Progress Summaries",
+					Description: "Minutes between asking running tasks for a progress summary, given to their next attempt (0 = never)",
+					Type:        "int",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.enforce_file_claims",
+					Label:       "Enforce File Claims",
+					Description: "Reject task commits that touch files claimed by another task (pre-commit hook)",
+					Type:        "bool",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.priority_aging_seconds",
+					Label:       "Priority Aging",
+					Description: "Seconds a ready task waits before its priority rises one level (0 = no aging)",
+					Type:        "int",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.preemption.enabled",
+					Label:       "Preemption",
+					Description: "Pause a low-priority running task when a much more urgent task waits too long",
+					Type:        "bool",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.preemption.wait_seconds",
+					Label:       "Preemption Wait",
+					Description: "Seconds a ready task waits for an instance before it may preempt",
+					Type:        "int",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.preemption.min_priority_gap",
+					Label:       "Preemption Priority Gap",
+					Description: "Priority levels a waiting task must be ahead of the task it preempts",
+					Type:        "int",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.scaling.budget_ceiling",
+					Label:       "Scaling Budget Ceiling ($)",
+					Description: "Veto team scale-ups whose projected cost exceeds this (0 = no ceiling)",
+					Type:        "float",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.scaling.burn_rate_limit",
+					Label:       "Scaling Burn Rate Limit ($/h)",
+					Description: "Scale teams down when spend per hour exceeds this (0 = no limit)",
+					Type:        "float",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.work_stealing",
+					Label:       "Work Stealing",
+					Description: "Let a team that has finished its tasks run ready tasks from other teams",
+					Type:        "bool",
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.message_guard",
+					Label:       "Message Guard",
+					Description: "Instruction-like messages between instances: strip the lines or hold for :release",
+					Type:        "select",
+					Options:     config.ValidMessageGuards(),
+					Category:    "ultraplan",
+				},
+				{
+					Key:         "ultraplan.placement.policy",
+					Label:       "Node Placement Policy",
+					Description: "How task instances are assigned to worker nodes: spread or pack",
+					Type:        "select",
+					Options:     []string{"spread", "pack"},
+					Category:    "ultraplan",
+				},
 				{
 					Key:         "ultraplan.notifications.enabled",
 					Label:       "Notifications",
@@ -589,6 +1161,41 @@ func New() Model {
 					Type:        "string",
 					Category:    "paths",
 				},
+				{
+					Key:         "paths.bootstrap.enabled",
+					Label:       "Worktree Bootstrap",
+					Description: "Link shared caches and run setup commands in new worktrees before instances start",
+					Type:        "bool",
+					Category:    "paths",
+				},
+				{
+					Key:         "paths.bootstrap.shared_dirs",
+					Label:       "Bootstrap Shared Dirs",
+					Description: "Directories symlinked from the main repo, e.g. node_modules (edit config.yaml to modify array)",
+					Type:        "string",
+					Category:    "paths",
+				},
+				{
+					Key:         "paths.bootstrap.commands",
+					Label:       "Bootstrap Commands",
+					Description: "Setup commands run in each new worktree (edit config.yaml to modify array)",
+					Type:        "string",
+					Category:    "paths",
+				},
+				{
+					Key:         "paths.bootstrap.env",
+					Label:       "Bootstrap Env",
+					Description: "KEY=VALUE environment for bootstrap commands (edit config.yaml to modify array)",
+					Type:        "string",
+					Category:    "paths",
+				},
+				{
+					Key:         "paths.bootstrap.timeout_seconds",
+					Label:       "Bootstrap Timeout",
+					Description: "Seconds allowed for bootstrap commands (0 = no limit)",
+					Type:        "int",
+					Category:    "paths",
+				},
 			},
 		},
 		{
@@ -625,6 +1232,181 @@ func New() Model {
 				},
 			},
 		},
+		{
+			Name: "Event Server",
+			Items: []ConfigItem{
+				{
+					Key:         "event_server.enabled",
+					Label:       "Enabled",
+					Description: "Stream events to dashboards over HTTP (Server-Sent Events)",
+					Type:        "bool",
+					Category:    "event_server",
+				},
+				{
+					Key:         "event_server.address",
+					Label:       "Address",
+					Description: "host:port to listen on",
+					Type:        "string",
+					Category:    "event_server",
+				},
+				{
+					Key:         "event_server.replay_size",
+					Label:       "Replay Size",
+					Description: "Recent events kept for reconnecting clients (0 = no replay)",
+					Type:        "int",
+					Category:    "event_server",
+				},
+			},
+		},
+		{
+			Name: "Webhooks",
+			Items: []ConfigItem{
+				{
+					Key:         "webhooks.timeout_seconds",
+					Label:       "Timeout",
+					Description: "Seconds each webhook request may take",
+					Type:        "int",
+					Category:    "webhooks",
+				},
+				{
+					Key:         "webhooks.events.phase_changed",
+					Label:       "Phase Changes",
+					Description: "Post when an ultraplan moves to a new phase",
+					Type:        "bool",
+					Category:    "webhooks",
+				},
+				{
+					Key:         "webhooks.events.task_failed",
+					Label:       "Task Failures",
+					Description: "Post when a planned task fails",
+					Type:        "bool",
+					Category:    "webhooks",
+				},
+				{
+					Key:         "webhooks.events.pr_opened",
+					Label:       "PRs Opened",
+					Description: "Post when a pull request is opened",
+					Type:        "bool",
+					Category:    "webhooks",
+				},
+				{
+					Key:         "webhooks.events.budget_warning",
+					Label:       "Budget Warning",
+					Description: "Post when the session reaches the cost warning threshold",
+					Type:        "bool",
+					Category:    "webhooks",
+				},
+				{
+					Key:         "webhooks.events.waiting_input",
+					Label:       "Waiting for Input",
+					Description: "Post when an instance is waiting for input",
+					Type:        "bool",
+					Category:    "webhooks",
+				},
+				{
+					Key:         "webhooks.templates.phase_changed",
+					Label:       "Phase Template",
+					Description: "Message for phase changes (Go template, empty = built-in)",
+					Type:        "string",
+					Category:    "webhooks",
+				},
+				{
+					Key:         "webhooks.templates.task_failed",
+					Label:       "Task Failure Template",
+					Description: "Message for task failures (Go template, empty = built-in)",
+					Type:        "string",
+					Category:    "webhooks",
+				},
+				{
+					Key:         "webhooks.templates.pr_opened",
+					Label:       "PR Template",
+					Description: "Message for opened PRs (Go template, empty = built-in)",
+					Type:        "string",
+					Category:    "webhooks",
+				},
+				{
+					Key:         "webhooks.templates.budget_warning",
+					Label:       "Budget Template",
+					Description: "Message for budget warnings (Go template, empty = built-in)",
+					Type:        "string",
+					Category:    "webhooks",
+				},
+				{
+					Key:         "webhooks.templates.waiting_input",
+					Label:       "Waiting Template",
+					Description: "Message for instances waiting for input (Go template, empty = built-in)",
+					Type:        "string",
+					Category:    "webhooks",
+				},
+			},
+		},
+		{
+			Name: "Control API",
+			Items: []ConfigItem{
+				{
+					Key:         "api.enabled",
+					Label:       "Enabled",
+					Description: "Serve the gRPC control API for external tools",
+					Type:        "bool",
+					Category:    "api",
+				},
+				{
+					Key:         "api.address",
+					Label:       "Address",
+					Description: "host:port to listen on",
+					Type:        "string",
+					Category:    "api",
+				},
+			},
+		},
+		{
+			Name: "Tracing",
+			Items: []ConfigItem{
+				{
+					Key:         "tracing.enabled",
+					Label:       "Enabled",
+					Description: "Export OpenTelemetry spans for phases and tasks",
+					Type:        "bool",
+					Category:    "tracing",
+				},
+				{
+					Key:         "tracing.exporter",
+					Label:       "Exporter",
+					Description: "otlp (gRPC collector) or file (traces.jsonl in the session directory)",
+					Type:        "select",
+					Options:     config.ValidTracingExporters(),
+					Category:    "tracing",
+				},
+				{
+					Key:         "tracing.endpoint",
+					Label:       "Endpoint",
+					Description: "OTLP collector host:port (empty = OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4317)",
+					Type:        "string",
+					Category:    "tracing",
+				},
+				{
+					Key:         "tracing.insecure",
+					Label:       "Insecure",
+					Description: "Connect to the collector without TLS",
+					Type:        "bool",
+					Category:    "tracing",
+				},
+				{
+					Key:         "tracing.sample_ratio",
+					Label:       "Sample Ratio",
+					Description: "Fraction of sessions traced (0-1)",
+					Type:        "float",
+					Category:    "tracing",
+				},
+				{
+					Key:         "tracing.service_name",
+					Label:       "Service Name",
+					Description: "service.name reported with every span",
+					Type:        "string",
+					Category:    "tracing",
+				},
+			},
+		},
 		{
 			Name: "Experimental",
 			Items: []ConfigItem{
@@ -635,6 +1417,14 @@ func New() Model {
 					Type:        "bool",
 					Category:    "experimental",
 				},
+				{
+					Key:         "experimental.git_backend",
+					Label:       "Git Backend",
+					Description: "How consolidation runs git: exec (git binary) or go-git (no binary needed)",
+					Type:        "select",
+					Options:     config.ValidGitBackends(),
+					Category:    "experimental",
+				},
 			},
 		},
 	}
@@ -813,7 +1603,7 @@ func (m *Model) handleEditingKeypress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 			// Apply theme change immediately for live preview
 			if item.Key == "tui.theme" {
-				styles.SetActiveTheme(styles.ThemeName(selectedValue))
+				_ = theme.Apply(selectedValue, viper.GetStringMapString("tui.colors"))
 			}
 		} else {
 			// Validate and apply text input
@@ -1224,22 +2014,61 @@ func (m *Model) resetCurrentToDefault() {
 		// Completion
 		"completion.default_action": defaults.Completion.DefaultAction,
 		// TUI
-		"tui.theme":                defaults.TUI.Theme,
-		"tui.auto_focus_on_input":  defaults.TUI.AutoFocusOnInput,
-		"tui.max_output_lines":     defaults.TUI.MaxOutputLines,
-		"tui.verbose_command_help": defaults.TUI.VerboseCommandHelp,
-		"tui.sidebar_width":        defaults.TUI.SidebarWidth,
+		"tui.theme":                         defaults.TUI.Theme,
+		"tui.auto_focus_on_input":           defaults.TUI.AutoFocusOnInput,
+		"tui.max_output_lines":              defaults.TUI.MaxOutputLines,
+		"tui.verbose_command_help":          defaults.TUI.VerboseCommandHelp,
+		"tui.sidebar_width":                 defaults.TUI.SidebarWidth,
+		"tui.confirm_destructive_input":     defaults.TUI.ConfirmDestructiveInput,
+		"tui.require_input_modifier":        defaults.TUI.RequireInputModifier,
+		"tui.mouse":                         defaults.TUI.Mouse,
+		"tui.alerts.bell":                   defaults.TUI.Alerts.Bell,
+		"tui.alerts.desktop":                defaults.TUI.Alerts.Desktop,
+		"tui.alerts.escalate_after_seconds": defaults.TUI.Alerts.EscalateAfterSeconds,
+		"tui.alerts.min_interval_seconds":   defaults.TUI.Alerts.MinIntervalSeconds,
 		// Session
-		"session.auto_start_on_add": defaults.Session.AutoStartOnAdd,
+		"session.auto_start_on_add":                   defaults.Session.AutoStartOnAdd,
+		"session.storage.backend":                     defaults.Session.Storage.Backend,
+		"session.storage.checkpoint_interval_seconds": defaults.Session.Storage.CheckpointIntervalSeconds,
+		"session.storage.path":                        defaults.Session.Storage.Path,
+		"session.storage.prefix":                      defaults.Session.Storage.Prefix,
+		"session.storage.bucket":                      defaults.Session.Storage.Bucket,
+		"session.storage.region":                      defaults.Session.Storage.Region,
+		"session.storage.endpoint":                    defaults.Session.Storage.Endpoint,
+		"session.storage.path_style":                  defaults.Session.Storage.PathStyle,
+		"session.database.enabled":                    defaults.Session.Database.Enabled,
+		"session.database.path":                       defaults.Session.Database.Path,
+		"session.report.enabled":                      defaults.Session.Report.Enabled,
+		"session.report.html":                         defaults.Session.Report.HTML,
+		"session.archive.after_days":                  defaults.Session.Archive.AfterDays,
+		"session.archive.keep_last":                   defaults.Session.Archive.KeepLast,
+		"session.archive.max_total_mb":                defaults.Session.Archive.MaxTotalMB,
 		// Instance
-		"instance.output_buffer_size":         defaults.Instance.OutputBufferSize,
-		"instance.capture_interval_ms":        defaults.Instance.CaptureIntervalMs,
-		"instance.tmux_width":                 defaults.Instance.TmuxWidth,
-		"instance.tmux_height":                defaults.Instance.TmuxHeight,
-		"instance.tmux_history_limit":         defaults.Instance.TmuxHistoryLimit,
-		"instance.activity_timeout_minutes":   defaults.Instance.ActivityTimeoutMinutes,
-		"instance.completion_timeout_minutes": defaults.Instance.CompletionTimeoutMinutes,
-		"instance.stale_detection":            defaults.Instance.StaleDetection,
+		"instance.backend":                       defaults.Instance.Backend,
+		"instance.output_format":                 defaults.Instance.OutputFormat,
+		"instance.output_buffer_size":            defaults.Instance.OutputBufferSize,
+		"instance.capture_interval_ms":           defaults.Instance.CaptureIntervalMs,
+		"instance.tmux_width":                    defaults.Instance.TmuxWidth,
+		"instance.tmux_height":                   defaults.Instance.TmuxHeight,
+		"instance.tmux_history_limit":            defaults.Instance.TmuxHistoryLimit,
+		"instance.activity_timeout_minutes":      defaults.Instance.ActivityTimeoutMinutes,
+		"instance.completion_timeout_minutes":    defaults.Instance.CompletionTimeoutMinutes,
+		"instance.stale_detection":               defaults.Instance.StaleDetection,
+		"instance.record_transcripts":            defaults.Instance.RecordTranscripts,
+		"instance.escalation.enabled":            defaults.Instance.Escalation.Enabled,
+		"instance.escalation.steps":              strings.Join(defaults.Instance.Escalation.Steps, ","),
+		"instance.escalation.step_wait_seconds":  defaults.Instance.Escalation.StepWaitSeconds,
+		"instance.escalation.max_nudges":         defaults.Instance.Escalation.MaxNudges,
+		"instance.escalation.nudge_message":      defaults.Instance.Escalation.NudgeMessage,
+		"instance.escalation.interview_prompt":   defaults.Instance.Escalation.InterviewPrompt,
+		"instance.permission_policy.enabled":     defaults.Instance.PermissionPolicy.Enabled,
+		"instance.permission_policy.response":    defaults.Instance.PermissionPolicy.Response,
+		"instance.resource_limits.enabled":       defaults.Instance.ResourceLimits.Enabled,
+		"instance.resource_limits.nice":          defaults.Instance.ResourceLimits.Nice,
+		"instance.resource_limits.gomaxprocs":    defaults.Instance.ResourceLimits.GoMaxProcs,
+		"instance.resource_limits.cpu_percent":   defaults.Instance.ResourceLimits.CPUPercent,
+		"instance.resource_limits.memory_mb":     defaults.Instance.ResourceLimits.MemoryMB,
+		"instance.resource_limits.cgroup_parent": defaults.Instance.ResourceLimits.CgroupParent,
 		// AI
 		"ai.backend":                     defaults.AI.Backend,
 		"ai.claude.command":              defaults.AI.Claude.Command,
@@ -1251,36 +2080,77 @@ func (m *Model) resetCurrentToDefault() {
 		"ai.claude.model":                defaults.AI.Claude.Model,
 		"ai.claude.append_system_prompt": defaults.AI.Claude.AppendSystemPrompt,
 		"ai.claude.native_worktree":      defaults.AI.Claude.NativeWorktree,
+		"ai.claude.hooks":                defaults.AI.Claude.Hooks,
 		// Pull Request
 		"pr.draft":             defaults.PR.Draft,
 		"pr.auto_rebase":       defaults.PR.AutoRebase,
 		"pr.use_ai":            defaults.PR.UseAI,
 		"pr.labels":            strings.Join(defaults.PR.Labels, ","),
 		"pr.reviewers.default": strings.Join(defaults.PR.Reviewers.Default, ","),
+		"pr.missing_cli":       defaults.PR.MissingCLI,
+		"pr.provider":          defaults.PR.Provider,
 		// Branch
 		"branch.prefix":     defaults.Branch.Prefix,
 		"branch.include_id": defaults.Branch.IncludeID,
 		// Cleanup
-		"cleanup.warn_on_stale":        defaults.Cleanup.WarnOnStale,
-		"cleanup.keep_remote_branches": defaults.Cleanup.KeepRemoteBranches,
+		"cleanup.warn_on_stale":          defaults.Cleanup.WarnOnStale,
+		"cleanup.auto_gc":                defaults.Cleanup.AutoGC,
+		"cleanup.keep_remote_branches":   defaults.Cleanup.KeepRemoteBranches,
+		"cleanup.reap_merged":            defaults.Cleanup.ReapMerged,
+		"cleanup.merged_retention_hours": defaults.Cleanup.MergedRetentionHours,
 		// Resources
 		"resources.cost_warning_threshold":   defaults.Resources.CostWarningThreshold,
 		"resources.cost_limit":               defaults.Resources.CostLimit,
 		"resources.token_limit_per_instance": defaults.Resources.TokenLimitPerInstance,
+		"resources.instance_cost_limit":      defaults.Resources.InstanceCostLimit,
+		"resources.budget_action":            defaults.Resources.BudgetAction,
 		"resources.show_metrics_in_sidebar":  defaults.Resources.ShowMetricsInSidebar,
 		// Ultraplan
-		"ultraplan.max_parallel":             defaults.Ultraplan.MaxParallel,
-		"ultraplan.multi_pass":               defaults.Ultraplan.MultiPass,
-		"ultraplan.adversarial":              defaults.Ultraplan.Adversarial,
-		"ultraplan.consolidation_mode":       defaults.Ultraplan.ConsolidationMode,
-		"ultraplan.create_draft_prs":         defaults.Ultraplan.CreateDraftPRs,
-		"ultraplan.pr_labels":                strings.Join(defaults.Ultraplan.PRLabels, ","),
-		"ultraplan.branch_prefix":            defaults.Ultraplan.BranchPrefix,
-		"ultraplan.max_task_retries":         defaults.Ultraplan.MaxTaskRetries,
-		"ultraplan.require_verified_commits": defaults.Ultraplan.RequireVerifiedCommits,
-		"ultraplan.notifications.enabled":    defaults.Ultraplan.Notifications.Enabled,
-		"ultraplan.notifications.use_sound":  defaults.Ultraplan.Notifications.UseSound,
-		"ultraplan.notifications.sound_path": defaults.Ultraplan.Notifications.SoundPath,
+		"ultraplan.max_parallel":                defaults.Ultraplan.MaxParallel,
+		"ultraplan.multi_pass":                  defaults.Ultraplan.MultiPass,
+		"ultraplan.adversarial":                 defaults.Ultraplan.Adversarial,
+		"ultraplan.consolidation_mode":          defaults.Ultraplan.ConsolidationMode,
+		"ultraplan.merge_queue":                 defaults.Ultraplan.MergeQueue,
+		"ultraplan.conflict_resolver":           defaults.Ultraplan.ConflictResolver,
+		"ultraplan.create_draft_prs":            defaults.Ultraplan.CreateDraftPRs,
+		"ultraplan.pr_labels":                   strings.Join(defaults.Ultraplan.PRLabels, ","),
+		"ultraplan.branch_prefix":               defaults.Ultraplan.BranchPrefix,
+		"ultraplan.max_behind_commits":          defaults.Ultraplan.MaxBehindCommits,
+		"ultraplan.auto_rebase":                 defaults.Ultraplan.AutoRebase,
+		"ultraplan.drift_action":                defaults.Ultraplan.DriftAction,
+		"ultraplan.conflict_prediction":         defaults.Ultraplan.ConflictPrediction,
+		"ultraplan.max_task_retries":            defaults.Ultraplan.MaxTaskRetries,
+		"ultraplan.retry.backoff_seconds":       defaults.Ultraplan.Retry.BackoffSeconds,
+		"ultraplan.retry.max_backoff_seconds":   defaults.Ultraplan.Retry.MaxBackoffSeconds,
+		"ultraplan.retry.augment_prompt":        defaults.Ultraplan.Retry.AugmentPrompt,
+		"ultraplan.retry.final_attempt_model":   defaults.Ultraplan.Retry.FinalAttemptModel,
+		"ultraplan.models.planning":             defaults.Ultraplan.Models.Planning,
+		"ultraplan.models.execution":            defaults.Ultraplan.Models.Execution,
+		"ultraplan.models.synthesis":            defaults.Ultraplan.Models.Synthesis,
+		"ultraplan.models.revision":             defaults.Ultraplan.Models.Revision,
+		"ultraplan.models.consolidation":        defaults.Ultraplan.Models.Consolidation,
+		"ultraplan.require_verified_commits":    defaults.Ultraplan.RequireVerifiedCommits,
+		"ultraplan.fresh_verification":          defaults.Ultraplan.FreshVerification,
+		"ultraplan.verify.auto_detect":          defaults.Ultraplan.Verify.AutoDetect,
+		"ultraplan.verify.on_failure":           defaults.Ultraplan.Verify.OnFailure,
+		"ultraplan.verify.timeout_seconds":      defaults.Ultraplan.Verify.TimeoutSeconds,
+		"ultraplan.group_approval":              defaults.Ultraplan.GroupApproval,
+		"ultraplan.synthesis_reviewers":         defaults.Ultraplan.SynthesisReviewers,
+		"ultraplan.self_review":                 defaults.Ultraplan.SelfReview,
+		"ultraplan.progress_summary_minutes":    defaults.Ultraplan.ProgressSummaryMinutes,
+		"ultraplan.enforce_file_claims":         defaults.Ultraplan.EnforceFileClaims,
+		"ultraplan.priority_aging_seconds":      defaults.Ultraplan.PriorityAgingSeconds,
+		"ultraplan.preemption.enabled":          defaults.Ultraplan.Preemption.Enabled,
+		"ultraplan.preemption.wait_seconds":     defaults.Ultraplan.Preemption.WaitSeconds,
+		"ultraplan.preemption.min_priority_gap": defaults.Ultraplan.Preemption.MinPriorityGap,
+		"ultraplan.scaling.budget_ceiling":      defaults.Ultraplan.Scaling.BudgetCeiling,
+		"ultraplan.scaling.burn_rate_limit":     defaults.Ultraplan.Scaling.BurnRateLimit,
+		"ultraplan.work_stealing":               defaults.Ultraplan.WorkStealing,
+		"ultraplan.message_guard":               defaults.Ultraplan.MessageGuard,
+		"ultraplan.placement.policy":            defaults.Ultraplan.Placement.Policy,
+		"ultraplan.notifications.enabled":       defaults.Ultraplan.Notifications.Enabled,
+		"ultraplan.notifications.use_sound":     defaults.Ultraplan.Notifications.UseSound,
+		"ultraplan.notifications.sound_path":    defaults.Ultraplan.Notifications.SoundPath,
 		// Plan
 		"plan.output_format": defaults.Plan.OutputFormat,
 		"plan.multi_pass":    defaults.Plan.MultiPass,
@@ -1300,13 +2170,44 @@ func (m *Model) resetCurrentToDefault() {
 		"paths.sparse_checkout.cone_mode":      defaults.Paths.SparseCheckout.ConeMode,
 		"paths.sparse_checkout.directories":    strings.Join(defaults.Paths.SparseCheckout.Directories, ","),
 		"paths.sparse_checkout.always_include": strings.Join(defaults.Paths.SparseCheckout.AlwaysInclude, ","),
+		"paths.bootstrap.enabled":              defaults.Paths.Bootstrap.Enabled,
+		"paths.bootstrap.shared_dirs":          strings.Join(defaults.Paths.Bootstrap.SharedDirs, ","),
+		"paths.bootstrap.commands":             strings.Join(defaults.Paths.Bootstrap.Commands, ","),
+		"paths.bootstrap.env":                  strings.Join(defaults.Paths.Bootstrap.Env, ","),
+		"paths.bootstrap.timeout_seconds":      defaults.Paths.Bootstrap.TimeoutSeconds,
 		// Logging
 		"logging.enabled":     defaults.Logging.Enabled,
 		"logging.level":       defaults.Logging.Level,
 		"logging.max_size_mb": defaults.Logging.MaxSizeMB,
 		"logging.max_backups": defaults.Logging.MaxBackups,
+		// Event Server
+		"event_server.enabled":              defaults.EventServer.Enabled,
+		"event_server.address":              defaults.EventServer.Address,
+		"event_server.replay_size":          defaults.EventServer.ReplaySize,
+		"webhooks.timeout_seconds":          defaults.Webhooks.TimeoutSeconds,
+		"webhooks.events.phase_changed":     defaults.Webhooks.Events.PhaseChanged,
+		"webhooks.events.task_failed":       defaults.Webhooks.Events.TaskFailed,
+		"webhooks.events.pr_opened":         defaults.Webhooks.Events.PROpened,
+		"webhooks.events.budget_warning":    defaults.Webhooks.Events.BudgetWarning,
+		"webhooks.events.waiting_input":     defaults.Webhooks.Events.WaitingInput,
+		"webhooks.templates.phase_changed":  defaults.Webhooks.Templates.PhaseChanged,
+		"webhooks.templates.task_failed":    defaults.Webhooks.Templates.TaskFailed,
+		"webhooks.templates.pr_opened":      defaults.Webhooks.Templates.PROpened,
+		"webhooks.templates.budget_warning": defaults.Webhooks.Templates.BudgetWarning,
+		"webhooks.templates.waiting_input":  defaults.Webhooks.Templates.WaitingInput,
+		// Control API
+		"api.enabled": defaults.API.Enabled,
+		"api.address": defaults.API.Address,
+		// Tracing
+		"tracing.enabled":      defaults.Tracing.Enabled,
+		"tracing.exporter":     defaults.Tracing.Exporter,
+		"tracing.endpoint":     defaults.Tracing.Endpoint,
+		"tracing.insecure":     defaults.Tracing.Insecure,
+		"tracing.sample_ratio": defaults.Tracing.SampleRatio,
+		"tracing.service_name": defaults.Tracing.ServiceName,
 		// Experimental
 		"experimental.subprocess_mode": defaults.Experimental.SubprocessMode,
+		"experimental.git_backend":     defaults.Experimental.GitBackend,
 	}
 
 	if defaultVal, ok := defaultValues[item.Key]; ok {
@@ -1317,7 +2218,7 @@ func (m *Model) resetCurrentToDefault() {
 		// Apply theme change immediately when resetting theme
 		if item.Key == "tui.theme" {
 			if themeName, ok := defaultVal.(string); ok {
-				styles.SetActiveTheme(styles.ThemeName(themeName))
+				_ = theme.Apply(themeName, viper.GetStringMapString("tui.colors"))
 			}
 		}
 	}
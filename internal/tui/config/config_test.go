@@ -300,8 +300,24 @@ func TestTUIConfigCoversAllConfigFields(t *testing.T) {
 	// KEEP THIS LIST MINIMAL - only truly uneditable types belong here.
 	excludedKeys := map[string]string{
 		// Complex types that cannot be edited with the simple TUI editor
-		"pr.template":          "multi-line template requires a full text editor",
-		"pr.reviewers.by_path": "nested map type requires structured editor",
+		"ai.cli_backends":                  "list of backend structs requires structured editor",
+		"pr.template":                      "multi-line template requires a full text editor",
+		"pr.reviewers.by_path":             "nested map type requires structured editor",
+		"experiments":                      "list of structs with templates requires structured editor",
+		"ultraplan.placement.nodes":        "list of node structs requires structured editor",
+		"ultraplan.templates":              "list of template structs requires structured editor",
+		"ultraplan.models.by_complexity":   "map of complexity to model requires structured editor",
+		"ultraplan.repos":                  "map of repository names to paths requires structured editor",
+		"ultraplan.verify.commands":        "list of command structs requires structured editor",
+		"instance.timeout_policies":        "list of policy structs requires structured editor",
+		"instance.permission_policy.rules": "list of rule structs requires structured editor",
+		"tui.keys":                         "nested map of key bindings requires structured editor",
+		"tui.colors":                       "map of color overrides requires structured editor",
+		"webhooks.endpoints":               "list of endpoint structs whose URLs hold secrets",
+		"logging.sinks":                    "list of sink structs requires structured editor",
+		"logging.rate_limits":              "list of rate limit structs requires structured editor",
+		// Secrets that should not be displayed on screen
+		"api.token": "bearer token for the control API; set through CLAUDIO_API_TOKEN",
 	}
 
 	// Get all keys from the TUI config
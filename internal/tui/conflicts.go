@@ -0,0 +1,258 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/audit"
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/tui/keymap"
+	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
+	"github.com/Iron-Ham/claudio/internal/tui/view"
+	"github.com/Iron-Ham/claudio/internal/worktree"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// -----------------------------------------------------------------------------
+// Consolidation Conflict View
+// -----------------------------------------------------------------------------
+
+// conflictRefreshInterval is the least time between reads of the conflicted
+// files while the conflict view is open, so files resolved elsewhere (by a
+// resolver instance or in a shell) drop off the list.
+const conflictRefreshInterval = 2 * time.Second
+
+// conflictView is the state of the consolidation conflict view.
+type conflictView struct {
+	files    []worktree.ConflictedFile
+	selected int
+	scroll   int // Hunk lines of the selected file scrolled past
+	err      error
+
+	loading  bool
+	loadedAt time.Time
+}
+
+// pausedConsolidation returns the consolidation state when it is paused on a
+// conflict, or nil.
+func (m Model) pausedConsolidation() *orchestrator.ConsolidatorState {
+	if m.ultraPlan == nil || m.ultraPlan.Coordinator == nil {
+		return nil
+	}
+	session := m.ultraPlan.Coordinator.Session()
+	if session == nil || session.Consolidation == nil ||
+		session.Consolidation.Phase != orchestrator.ConsolidationPaused ||
+		session.Consolidation.ConflictWorktree == "" {
+		return nil
+	}
+	return session.Consolidation
+}
+
+// openConflictView shows the files a paused consolidation is waiting on, or
+// reports why it can't. They are read on the next tick.
+func (m *Model) openConflictView() {
+	if m.pausedConsolidation() == nil {
+		m.errorMessage = "Consolidation is not paused on a conflict"
+		return
+	}
+	m.conflicts = &conflictView{}
+}
+
+// loadConflicts reads the conflicted files again.
+func (m *Model) loadConflicts(now time.Time) tea.Cmd {
+	c := m.conflicts
+	c.loading = true
+	c.loadedAt = now
+	return tuimsg.LoadConflictsAsync(m.ultraPlan.Coordinator)
+}
+
+// dispatchConflictRefresh reads the conflicted files again while the view
+// is open, at most once per conflictRefreshInterval.
+func (m *Model) dispatchConflictRefresh(now time.Time) tea.Cmd {
+	c := m.conflicts
+	if c == nil || c.loading || now.Sub(c.loadedAt) < conflictRefreshInterval {
+		return nil
+	}
+	if m.pausedConsolidation() == nil {
+		// Resumed or cancelled elsewhere
+		m.conflicts = nil
+		return nil
+	}
+	return m.loadConflicts(now)
+}
+
+// handleConflictsLoaded shows the files read, keeping the selected file
+// selected while it is still conflicted.
+func (m *Model) handleConflictsLoaded(msg tuimsg.ConflictsLoadedMsg) {
+	c := m.conflicts
+	if c == nil {
+		return
+	}
+	c.loading = false
+	c.err = msg.Err
+	if msg.Err != nil {
+		return
+	}
+
+	selectedPath := ""
+	if c.selected < len(c.files) {
+		selectedPath = c.files[c.selected].Path
+	}
+	c.files = msg.Files
+	if i := slices.IndexFunc(c.files, func(f worktree.ConflictedFile) bool { return f.Path == selectedPath }); i >= 0 {
+		c.selected = i
+		return
+	}
+	c.selected = max(min(c.selected, len(c.files)-1), 0)
+	c.scroll = 0
+}
+
+// handleConflictEdited reads the conflicts again once the editor exits.
+func (m Model) handleConflictEdited(msg tuimsg.ConflictEditedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.errorMessage = fmt.Sprintf("Editor failed on %s: %v", msg.File, msg.Err)
+	}
+	if m.conflicts == nil || m.pausedConsolidation() == nil {
+		return m, nil
+	}
+	return m, m.loadConflicts(time.Now())
+}
+
+// handleConflictInput handles keyboard input in the conflict view.
+func (m Model) handleConflictInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	c := m.conflicts
+	action := m.resolveKey(keymap.Conflicts, msg)
+
+	switch action {
+	case keymap.Close:
+		m.conflicts = nil
+		return m, nil
+
+	case keymap.ToggleLogs:
+		m.toggleLogPane()
+		return m, nil
+
+	case keymap.Resume:
+		if m.resumeConsolidation() {
+			m.conflicts = nil
+		}
+		return m, nil
+
+	case keymap.StartResolver:
+		inst, err := m.ultraPlan.Coordinator.StartConflictResolver()
+		if err != nil {
+			m.errorMessage = fmt.Sprintf("Failed to start a conflict resolver: %v", err)
+			return m, nil
+		}
+		m.infoMessage = fmt.Sprintf("Started %s to resolve the conflicts; resume once it is done", inst.EffectiveName())
+		m.orchestrator.RecordOperatorAction(audit.ActionOverride, inst.ID, "", "started a conflict resolver instance for the paused consolidation")
+		return m, nil
+	}
+
+	if len(c.files) == 0 {
+		return m, nil
+	}
+	file := c.files[c.selected]
+	last := len(c.files) - 1
+	hunkRows := view.ConflictHunkRows(len(c.files), m.conflictViewHeight())
+	maxScroll := max(len(view.ConflictHunkLines(file))-hunkRows, 0)
+
+	switch action {
+	case keymap.ScrollDown:
+		c.selected = min(c.selected+1, last)
+		c.scroll = 0
+
+	case keymap.ScrollUp:
+		c.selected = max(c.selected-1, 0)
+		c.scroll = 0
+
+	case keymap.HalfPageDown:
+		c.scroll = min(c.scroll+max(hunkRows/2, 1), maxScroll)
+
+	case keymap.HalfPageUp:
+		c.scroll = max(c.scroll-max(hunkRows/2, 1), 0)
+
+	case keymap.TakeOurs, keymap.TakeTheirs:
+		side := worktree.ConflictOurs
+		if action == keymap.TakeTheirs {
+			side = worktree.ConflictTheirs
+		}
+		if err := m.ultraPlan.Coordinator.ResolveConsolidationConflict(file.Path, side); err != nil {
+			m.errorMessage = fmt.Sprintf("Failed to resolve %s: %v", file.Path, err)
+			return m, nil
+		}
+		m.infoMessage = fmt.Sprintf("Kept %s for %s", side, file.Path)
+		m.orchestrator.RecordOperatorAction(audit.ActionOverride, "", "", fmt.Sprintf("resolved consolidation conflict in %s by keeping %s", file.Path, side))
+		return m, m.loadConflicts(time.Now())
+
+	case keymap.MarkResolved:
+		if len(file.Hunks) > 0 {
+			m.errorMessage = fmt.Sprintf("%s still has conflict markers", file.Path)
+			return m, nil
+		}
+		if err := m.ultraPlan.Coordinator.MarkConsolidationConflictResolved(file.Path); err != nil {
+			m.errorMessage = fmt.Sprintf("Failed to mark %s resolved: %v", file.Path, err)
+			return m, nil
+		}
+		m.infoMessage = fmt.Sprintf("Marked %s resolved", file.Path)
+		m.orchestrator.RecordOperatorAction(audit.ActionOverride, "", "", fmt.Sprintf("resolved consolidation conflict in %s by editing it", file.Path))
+		return m, m.loadConflicts(time.Now())
+
+	case keymap.OpenEditor:
+		if state := m.pausedConsolidation(); state != nil {
+			return m, tuimsg.EditConflictCmd(state.ConflictWorktree, file.Path)
+		}
+	}
+
+	return m, nil
+}
+
+// resumeConsolidation resumes a paused consolidation, reporting whether it
+// did.
+func (m *Model) resumeConsolidation() bool {
+	state := m.pausedConsolidation()
+	if state == nil {
+		return false
+	}
+	// Capture worktree path before resume clears it
+	conflictWorktree := state.ConflictWorktree
+	if err := m.ultraPlan.Coordinator.ResumeConsolidation(); err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to resume consolidation: %v", err)
+		return false
+	}
+	m.infoMessage = "Resuming consolidation..."
+	// Log user decision
+	if m.logger != nil {
+		m.logger.Info("user decision",
+			"decision_type", "resume_consolidation",
+			"conflict_worktree", conflictWorktree,
+		)
+	}
+	m.orchestrator.RecordOperatorAction(audit.ActionOverride, "", "", "resumed consolidation after resolving conflicts in "+conflictWorktree)
+	return true
+}
+
+// conflictViewHeight returns the rows the conflict view is rendered in.
+func (m Model) conflictViewHeight() int {
+	return m.mainAreaHeight(m.calculateExtraFooterLines())
+}
+
+// renderConflicts renders the conflict view.
+func (m Model) renderConflicts(width int) string {
+	c := m.conflicts
+	state := view.ConflictViewState{
+		Files:    c.files,
+		Selected: c.selected,
+		Scroll:   c.scroll,
+		Loading:  c.loading,
+	}
+	if paused := m.pausedConsolidation(); paused != nil {
+		state.TaskID = paused.ConflictTaskID
+		state.Worktree = paused.ConflictWorktree
+	}
+	if c.err != nil {
+		state.Err = "Cannot read the conflicts: " + c.err.Error()
+	}
+	return view.RenderConflicts(state, width, m.conflictViewHeight())
+}
@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
+	"github.com/Iron-Ham/claudio/internal/tui/view"
+	"github.com/Iron-Ham/claudio/internal/worktree"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newConflictTestModel returns a model whose ultraplan consolidation is
+// paused on a conflict in task-2.
+func newConflictTestModel() Model {
+	session := orchestrator.NewUltraPlanSession("objective", orchestrator.DefaultUltraPlanConfig())
+	session.Phase = orchestrator.PhaseConsolidating
+	session.Consolidation = &orchestrator.ConsolidatorState{
+		Phase:            orchestrator.ConsolidationPaused,
+		ConflictTaskID:   "task-2",
+		ConflictWorktree: "/tmp/conflict",
+		ConflictFiles:    []string{"a.go", "b.go"},
+	}
+	return Model{
+		width:     120,
+		height:    40,
+		ultraPlan: &view.UltraPlanState{Coordinator: orchestrator.NewCoordinatorForTesting(session)},
+	}
+}
+
+func conflictKey(m Model, key string) (Model, tea.Cmd) {
+	result, cmd := m.handleConflictInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	return result.(Model), cmd
+}
+
+func TestConflictView(t *testing.T) {
+	m := newConflictTestModel()
+	m.openConflictView()
+	if m.conflicts == nil {
+		t.Fatalf("openConflictView() did not open the view: %s", m.errorMessage)
+	}
+	if cmd := m.dispatchConflictRefresh(time.Now()); cmd == nil || !m.conflicts.loading {
+		t.Fatal("dispatchConflictRefresh() did not read the conflicts on opening")
+	}
+
+	m.handleConflictsLoaded(tuimsg.ConflictsLoadedMsg{Files: []worktree.ConflictedFile{
+		{Path: "a.go", Hunks: []worktree.ConflictHunk{{Line: 3, Ours: []string{"x"}, Theirs: []string{"y"}}}},
+		{Path: "b.go", Hunks: []worktree.ConflictHunk{{Line: 9, Ours: []string{"p"}, Theirs: []string{"q"}}}},
+	}})
+	m, _ = conflictKey(m, "j")
+	if m.conflicts.selected != 1 {
+		t.Fatalf("selected = %d after j, want 1", m.conflicts.selected)
+	}
+	if out := m.renderConflicts(120); !strings.Contains(out, "task-2") || !strings.Contains(out, "@@ line 9") {
+		t.Errorf("view:\n%s", out)
+	}
+
+	// A file with markers left can't be marked resolved
+	m, _ = conflictKey(m, "a")
+	if !strings.Contains(m.errorMessage, "still has conflict markers") {
+		t.Errorf("errorMessage = %q after marking b.go resolved", m.errorMessage)
+	}
+
+	// a.go was resolved elsewhere: b.go stays selected
+	m.handleConflictsLoaded(tuimsg.ConflictsLoadedMsg{Files: []worktree.ConflictedFile{{Path: "b.go"}}})
+	if m.conflicts.selected != 0 || m.conflicts.files[0].Path != "b.go" {
+		t.Errorf("selected = %d of %+v, want b.go", m.conflicts.selected, m.conflicts.files)
+	}
+
+	m, _ = conflictKey(m, "q")
+	if m.conflicts != nil {
+		t.Error("q did not close the view")
+	}
+}
+
+func TestConflictView_NotPaused(t *testing.T) {
+	m := newConflictTestModel()
+	m.ultraPlan.Coordinator.Session().Consolidation.Phase = orchestrator.ConsolidationMergingTasks
+	m.openConflictView()
+	if m.conflicts != nil || m.errorMessage == "" {
+		t.Errorf("conflicts = %+v, errorMessage = %q; want an error and no view", m.conflicts, m.errorMessage)
+	}
+
+	// Resumed elsewhere while open: the view closes on the next refresh
+	m.conflicts = &conflictView{}
+	if cmd := m.dispatchConflictRefresh(time.Now()); cmd != nil || m.conflicts != nil {
+		t.Error("view stayed open after consolidation resumed")
+	}
+}
@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/tui/keymap"
+	"github.com/Iron-Ham/claudio/internal/tui/view/dashboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// -----------------------------------------------------------------------------
+// Instance Dashboard
+// -----------------------------------------------------------------------------
+
+// instanceDashboard is the state of the dashboard grid of all instances.
+type instanceDashboard struct {
+	selected int // Index of the selected tile, as in session.Instances
+}
+
+// recordUsage samples every instance's token usage for the dashboard's
+// sparklines. It runs on every tick, dashboard open or not, so sparklines
+// have history as soon as the dashboard opens.
+func (m *Model) recordUsage(now time.Time) {
+	if m.session == nil {
+		return
+	}
+	if m.usageHistory == nil {
+		m.usageHistory = dashboard.NewHistory()
+	}
+	usage := make(map[string]dashboard.Usage, len(m.session.Instances))
+	for _, inst := range m.session.Instances {
+		var u dashboard.Usage
+		if inst.Metrics != nil {
+			u = dashboard.Usage{Tokens: inst.Metrics.TotalTokens(), Cost: inst.Metrics.Cost}
+		}
+		usage[inst.ID] = u
+	}
+	m.usageHistory.Record(now, usage)
+}
+
+// openDashboard shows the dashboard with the active instance selected.
+// Background instances normally have their output capture paused; it is
+// resumed while the dashboard is open so every tile's last line is current.
+func (m *Model) openDashboard() {
+	if m.instanceCount() == 0 {
+		m.infoMessage = "No instances to show"
+		return
+	}
+	m.dashboard = &instanceDashboard{selected: m.activeTab}
+	if m.orchestrator == nil {
+		return
+	}
+	for _, inst := range m.session.Instances {
+		if mgr := m.orchestrator.GetInstanceManager(inst.ID); mgr != nil {
+			_ = mgr.Resume()
+		}
+	}
+}
+
+// closeDashboard hides the dashboard, switching to its selected instance
+// when open is set, and pauses the capture of background instances again.
+func (m *Model) closeDashboard(open bool) {
+	selected := m.dashboard.selected
+	m.dashboard = nil
+	if open {
+		m.switchToInstance(selected)
+		m.ensureActiveVisible()
+	}
+	if m.session == nil {
+		return
+	}
+	active := m.activeInstance()
+	for _, inst := range m.session.Instances {
+		if inst != active {
+			m.pauseInstance(inst.ID)
+		}
+	}
+}
+
+// handleDashboardInput handles keyboard input while the dashboard is open.
+func (m Model) handleDashboardInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	d := m.dashboard
+	count := m.instanceCount()
+	cols := dashboard.Columns(m.dashboardWidth())
+	// Instances may have been removed since the dashboard opened
+	d.selected = max(min(d.selected, count-1), 0)
+
+	switch m.resolveKey(keymap.Dashboard, msg) {
+	case keymap.Close:
+		m.closeDashboard(false)
+
+	case keymap.Open:
+		m.closeDashboard(true)
+
+	case keymap.Left:
+		d.selected = dashboard.Move(d.selected, count, cols, dashboard.Left)
+
+	case keymap.Right:
+		d.selected = dashboard.Move(d.selected, count, cols, dashboard.Right)
+
+	case keymap.ScrollUp:
+		d.selected = dashboard.Move(d.selected, count, cols, dashboard.Up)
+
+	case keymap.ScrollDown:
+		d.selected = dashboard.Move(d.selected, count, cols, dashboard.Down)
+
+	case keymap.Top:
+		d.selected = 0
+
+	case keymap.Bottom:
+		d.selected = max(count-1, 0)
+
+	case keymap.ToggleLogs:
+		m.toggleLogPane()
+	}
+
+	return m, nil
+}
+
+// dashboardWidth returns the width of the content area the dashboard is
+// rendered in.
+func (m Model) dashboardWidth() int {
+	sidebarWidth := CalculateEffectiveSidebarWidthWithConfig(m.width, config.Get().TUI.SidebarWidth)
+	return m.width - sidebarWidth - 3 // 3 for gap between panels
+}
+
+// renderDashboard renders every instance as a tile of the dashboard grid.
+func (m Model) renderDashboard(width int) string {
+	state := dashboard.State{Selected: m.dashboard.selected}
+	if m.session != nil {
+		// Instances may have been removed since the dashboard opened
+		state.Selected = min(state.Selected, len(m.session.Instances)-1)
+		for _, inst := range m.session.Instances {
+			tile := dashboard.Tile{
+				Instance: inst,
+				LastLine: dashboard.LastLine(m.outputManager.GetFilteredLines(inst.ID)),
+			}
+			if m.usageHistory != nil {
+				tile.TokenRates = m.usageHistory.TokenRates(inst.ID)
+			}
+			state.Tiles = append(state.Tiles, tile)
+		}
+	}
+	return dashboard.Render(state, width, m.mainAreaHeight(m.calculateExtraFooterLines()))
+}
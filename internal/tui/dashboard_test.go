@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/tui/view/dashboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newDashboardTestModel(instances int) Model {
+	session := &orchestrator.Session{}
+	for i := range instances {
+		session.Instances = append(session.Instances, &orchestrator.Instance{
+			ID:     string(rune('a' + i)),
+			Task:   "task " + string(rune('a'+i)),
+			Status: orchestrator.StatusWorking,
+		})
+	}
+	m := NewModel(&orchestrator.Orchestrator{}, session, nil)
+	m.height = 40
+	m.width = 200
+	return m
+}
+
+func dashboardKey(m Model, key tea.KeyMsg) Model {
+	result, _ := m.handleDashboardInput(key)
+	return result.(Model)
+}
+
+func TestOpenDashboard(t *testing.T) {
+	m := newDashboardTestModel(3)
+	m.activeTab = 1
+	m.openDashboard()
+	if m.dashboard == nil || m.dashboard.selected != 1 {
+		t.Fatalf("dashboard = %+v, want the active instance selected", m.dashboard)
+	}
+
+	empty := newDashboardTestModel(0)
+	empty.openDashboard()
+	if empty.dashboard != nil {
+		t.Error("dashboard should not open without instances")
+	}
+	if empty.infoMessage == "" {
+		t.Error("expected a message when there are no instances")
+	}
+}
+
+func TestHandleDashboardInput(t *testing.T) {
+	m := newDashboardTestModel(5)
+	m.openDashboard()
+	cols := dashboard.Columns(m.dashboardWidth())
+	if cols < 2 {
+		t.Fatalf("Columns = %d, want a grid at least 2 wide for this test", cols)
+	}
+
+	m = dashboardKey(m, runeKey("l"))
+	if m.dashboard.selected != 1 {
+		t.Errorf("after l, selected = %d, want 1", m.dashboard.selected)
+	}
+	m = dashboardKey(m, tea.KeyMsg{Type: tea.KeyDown})
+	if want := min(1+cols, 4); m.dashboard.selected != want {
+		t.Errorf("after down, selected = %d, want %d", m.dashboard.selected, want)
+	}
+	m = dashboardKey(m, runeKey("g"))
+	if m.dashboard.selected != 0 {
+		t.Errorf("after g, selected = %d, want 0", m.dashboard.selected)
+	}
+	m = dashboardKey(m, runeKey("G"))
+	if m.dashboard.selected != 4 {
+		t.Errorf("after G, selected = %d, want 4", m.dashboard.selected)
+	}
+
+	m = dashboardKey(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.dashboard != nil {
+		t.Error("dashboard should close on Esc")
+	}
+	if m.activeTab != 0 {
+		t.Errorf("activeTab = %d, Esc should not switch instances", m.activeTab)
+	}
+}
+
+func TestHandleDashboardInput_EnterOpensInstance(t *testing.T) {
+	m := newDashboardTestModel(3)
+	m.openDashboard()
+	m.dashboard.selected = 2
+
+	m = dashboardKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.dashboard != nil {
+		t.Error("dashboard should close on Enter")
+	}
+	if m.activeTab != 2 {
+		t.Errorf("activeTab = %d, want the selected instance 2", m.activeTab)
+	}
+}
+
+func TestHandleDashboardInput_ClampsToRemovedInstances(t *testing.T) {
+	m := newDashboardTestModel(3)
+	m.openDashboard()
+	m.dashboard.selected = 2
+	m.session.Instances = m.session.Instances[:1]
+
+	m = dashboardKey(m, runeKey("l"))
+	if m.dashboard.selected != 0 {
+		t.Errorf("selected = %d, want 0 after instances were removed", m.dashboard.selected)
+	}
+}
+
+func TestHandleKeypress_RoutesToDashboard(t *testing.T) {
+	m := newDashboardTestModel(2)
+	m.openDashboard()
+
+	result, _ := m.handleKeypress(runeKey(":"))
+	updated := result.(Model)
+	if updated.commandMode {
+		t.Error("keys should be handled by the dashboard, not normal mode")
+	}
+	if updated.dashboard == nil {
+		t.Error("dashboard should still be open")
+	}
+}
+
+func TestRecordUsageAndRenderDashboard(t *testing.T) {
+	m := newDashboardTestModel(2)
+	inst := m.session.Instances[0]
+	inst.Metrics = &orchestrator.Metrics{}
+
+	start := time.Now()
+	for i := range 3 {
+		inst.Metrics.InputTokens = int64(i * 1000)
+		m.recordUsage(start.Add(time.Duration(i) * dashboard.SampleInterval))
+	}
+	if got := m.usageHistory.TokenRates(inst.ID); len(got) != 2 || got[1] != 1000 {
+		t.Errorf("TokenRates = %v, want two samples of 1000", got)
+	}
+
+	m.outputManager.SetOutput(inst.ID, "building\nrunning tests\n")
+	m.openDashboard()
+	out := m.renderDashboard(m.dashboardWidth())
+	for _, want := range []string{"2 instances", "task a", "task b", "running tests"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("renderDashboard() missing %q:\n%s", want, out)
+		}
+	}
+}
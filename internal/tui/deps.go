@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/tui/keymap"
+	"github.com/Iron-Ham/claudio/internal/tui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// -----------------------------------------------------------------------------
+// Task Dependency Graph
+// -----------------------------------------------------------------------------
+
+// depsView is the state of the task dependency graph.
+type depsView struct {
+	selected string // ID of the selected task
+}
+
+// planSession returns the ultraplan session when it has a plan, or nil.
+func (m Model) planSession() *orchestrator.UltraPlanSession {
+	if m.ultraPlan == nil || m.ultraPlan.Coordinator == nil {
+		return nil
+	}
+	session := m.ultraPlan.Coordinator.Session()
+	if session == nil || session.Plan == nil {
+		return nil
+	}
+	return session
+}
+
+// openDepsView shows the plan's dependency graph with the first task that
+// has not finished selected, or reports why it can't.
+func (m *Model) openDepsView() {
+	session := m.planSession()
+	if session == nil {
+		m.errorMessage = "No plan to show yet"
+		return
+	}
+	order := view.TaskGraphOrder(session.Plan)
+	if len(order) == 0 {
+		m.errorMessage = "The plan has no tasks"
+		return
+	}
+	selected := order[0]
+	for _, id := range order {
+		if !slices.Contains(session.CompletedTasks, id) {
+			selected = id
+			break
+		}
+	}
+	m.deps = &depsView{selected: selected}
+}
+
+// handleDepsInput handles keyboard input in the dependency graph.
+func (m Model) handleDepsInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	d := m.deps
+	action := m.resolveKey(keymap.Deps, msg)
+
+	switch action {
+	case keymap.Close:
+		m.deps = nil
+		return m, nil
+
+	case keymap.ToggleLogs:
+		m.toggleLogPane()
+		return m, nil
+	}
+
+	session := m.planSession()
+	if session == nil {
+		return m, nil
+	}
+	order := view.TaskGraphOrder(session.Plan)
+	if len(order) == 0 {
+		return m, nil
+	}
+	// The plan may have changed under the selection
+	idx := max(slices.Index(order, d.selected), 0)
+
+	switch action {
+	case keymap.ScrollDown:
+		d.selected = order[min(idx+1, len(order)-1)]
+
+	case keymap.ScrollUp:
+		d.selected = order[max(idx-1, 0)]
+
+	case keymap.Top:
+		d.selected = order[0]
+
+	case keymap.Bottom:
+		d.selected = order[len(order)-1]
+
+	case keymap.Open:
+		m.openTaskInstance(session, order[idx])
+	}
+
+	return m, nil
+}
+
+// openTaskInstance closes the dependency graph and shows the instance that
+// runs or ran a task: its current instance, or the one that worked in the
+// task's worktree once it has finished.
+func (m *Model) openTaskInstance(session *orchestrator.UltraPlanSession, taskID string) {
+	instanceID := session.TaskToInstance[taskID]
+	if instanceID == "" {
+		for _, tw := range session.TaskWorktrees {
+			if tw.TaskID != taskID {
+				continue
+			}
+			for _, inst := range m.session.Instances {
+				if inst.WorktreePath == tw.WorktreePath {
+					instanceID = inst.ID
+				}
+			}
+		}
+	}
+	if instanceID == "" {
+		m.infoMessage = fmt.Sprintf("Task %s has no instance yet", taskID)
+		return
+	}
+
+	idx := slices.IndexFunc(m.session.Instances, func(inst *orchestrator.Instance) bool { return inst.ID == instanceID })
+	if idx < 0 {
+		m.infoMessage = fmt.Sprintf("The instance of task %s was removed", taskID)
+		return
+	}
+	m.deps = nil
+	for groupIdx, group := range session.Plan.ExecutionOrder {
+		if slices.Contains(group, taskID) {
+			m.ultraPlan.SetGroupExpanded(groupIdx)
+		}
+	}
+	m.switchToInstance(idx)
+	m.ensureActiveVisible()
+}
+
+// renderDeps renders the dependency graph.
+func (m Model) renderDeps(width int) string {
+	return view.RenderTaskGraph(view.TaskGraphState{
+		Session:  m.planSession(),
+		Selected: m.deps.selected,
+	}, width, m.mainAreaHeight(m.calculateExtraFooterLines()))
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/tui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newDepsTestModel returns a model running a plan where setup is complete,
+// api is running in inst-api, and ui is pending.
+func newDepsTestModel() Model {
+	session := orchestrator.NewUltraPlanSession("objective", orchestrator.DefaultUltraPlanConfig())
+	session.Phase = orchestrator.PhaseExecuting
+	session.Plan = &orchestrator.PlanSpec{
+		Tasks: []orchestrator.PlannedTask{
+			{ID: "setup", Title: "Scaffold"},
+			{ID: "api", Title: "API", DependsOn: []string{"setup"}},
+			{ID: "ui", Title: "UI", DependsOn: []string{"setup"}},
+		},
+		ExecutionOrder: [][]string{{"setup"}, {"api", "ui"}},
+	}
+	session.CompletedTasks = []string{"setup"}
+	session.TaskToInstance["api"] = "inst-api"
+	session.TaskWorktrees = []orchestrator.TaskWorktreeInfo{{TaskID: "setup", WorktreePath: "/tmp/wt-setup"}}
+
+	return Model{
+		width:        120,
+		height:       40,
+		orchestrator: &orchestrator.Orchestrator{},
+		session: &orchestrator.Session{Instances: []*orchestrator.Instance{
+			{ID: "other"},
+			{ID: "inst-setup", WorktreePath: "/tmp/wt-setup"},
+			{ID: "inst-api"},
+		}},
+		ultraPlan: &view.UltraPlanState{Coordinator: orchestrator.NewCoordinatorForTesting(session)},
+	}
+}
+
+func depsKey(m Model, msg tea.KeyMsg) Model {
+	result, _ := m.handleDepsInput(msg)
+	return result.(Model)
+}
+
+func TestDepsView(t *testing.T) {
+	m := newDepsTestModel()
+	m.openDepsView()
+	if m.deps == nil || m.deps.selected != "api" {
+		t.Fatalf("openDepsView() = %+v (%s), want api selected as the first unfinished task", m.deps, m.errorMessage)
+	}
+	if out := m.renderDeps(120); !strings.Contains(out, "Task Dependencies") || !strings.Contains(out, "Unblocks: none") {
+		t.Errorf("view:\n%s", out)
+	}
+
+	// ui has not started
+	m = depsKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = depsKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.deps == nil || !strings.Contains(m.infoMessage, "no instance yet") {
+		t.Errorf("Enter on a pending task: deps = %+v, infoMessage = %q", m.deps, m.infoMessage)
+	}
+
+	m = depsKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	m = depsKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.deps != nil || m.activeTab != 2 {
+		t.Errorf("Enter on api: deps = %+v, activeTab = %d, want closed on inst-api", m.deps, m.activeTab)
+	}
+
+	// A finished task's instance is found by its worktree
+	m.openDepsView()
+	m = depsKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	m = depsKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.deps != nil || m.activeTab != 1 {
+		t.Errorf("Enter on setup: deps = %+v, activeTab = %d, want closed on inst-setup", m.deps, m.activeTab)
+	}
+
+	m.openDepsView()
+	m = depsKey(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.deps != nil {
+		t.Error("Esc did not close the view")
+	}
+}
+
+func TestDepsView_NoPlan(t *testing.T) {
+	m := newDepsTestModel()
+	m.ultraPlan.Coordinator.Session().Plan = nil
+	m.openDepsView()
+	if m.deps != nil || m.errorMessage == "" {
+		t.Errorf("deps = %+v, errorMessage = %q; want an error and no view", m.deps, m.errorMessage)
+	}
+}
@@ -0,0 +1,86 @@
+package desktop
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnsupported is returned when no notification command is available.
+var ErrUnsupported = errors.New("desktop notifications are not supported on this system")
+
+// Wrapper functions for the platform and exec to allow testing
+var (
+	goos     = runtime.GOOS
+	lookPath = exec.LookPath
+	run      = func(name string, args ...string) error {
+		return exec.Command(name, args...).Run()
+	}
+)
+
+// Notify shows a notification with title and body.
+func Notify(title, body string) error {
+	name, args, err := command(title, body)
+	if err != nil {
+		return err
+	}
+	if _, err := lookPath(name); err != nil {
+		return fmt.Errorf("%w: %s not found", ErrUnsupported, name)
+	}
+	if err := run(name, args...); err != nil {
+		return fmt.Errorf("desktop notification: %s: %w", name, err)
+	}
+	return nil
+}
+
+// command returns the command that shows the notification on this
+// platform.
+func command(title, body string) (string, []string, error) {
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(body), appleScriptString(title))
+		return "osascript", []string{"-e", script}, nil
+	case "linux", "freebsd", "openbsd", "netbsd", "dragonfly":
+		return "notify-send", []string{"--app-name=Claudio", title, body}, nil
+	}
+	return "", nil, fmt.Errorf("%w (%s)", ErrUnsupported, goos)
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// Limiter allows at most one notification per interval.
+type Limiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+// NewLimiter returns a Limiter allowing one notification per interval.
+func NewLimiter(interval time.Duration) *Limiter {
+	return &Limiter{interval: interval}
+}
+
+// Allow reports whether a notification may be shown at now, and if so
+// records it. When it may not, the returned duration is how long until
+// one may.
+func (l *Limiter) Allow(now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.last.IsZero() {
+		if wait := l.last.Add(l.interval).Sub(now); wait > 0 {
+			return false, wait
+		}
+	}
+	l.last = now
+	return true, 0
+}
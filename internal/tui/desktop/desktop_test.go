@@ -0,0 +1,82 @@
+package desktop
+
+import (
+	"errors"
+	"os/exec"
+	"slices"
+	"testing"
+	"time"
+)
+
+// stubPlatform replaces the platform and exec hooks for the duration of a
+// test, recording the command run.
+func stubPlatform(t *testing.T, platform string, available bool) *[]string {
+	t.Helper()
+	origGOOS, origLookPath, origRun := goos, lookPath, run
+	t.Cleanup(func() {
+		goos, lookPath, run = origGOOS, origLookPath, origRun
+	})
+
+	goos = platform
+	lookPath = func(name string) (string, error) {
+		if available {
+			return "/usr/bin/" + name, nil
+		}
+		return "", exec.ErrNotFound
+	}
+	var ran []string
+	run = func(name string, args ...string) error {
+		ran = append([]string{name}, args...)
+		return nil
+	}
+	return &ran
+}
+
+func TestNotify_MacOS(t *testing.T) {
+	ran := stubPlatform(t, "darwin", true)
+	if err := Notify("Claudio", `fix "auth" \ tests`); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"osascript", "-e", `display notification "fix \"auth\" \\ tests" with title "Claudio"`}
+	if !slices.Equal(*ran, want) {
+		t.Errorf("ran %q, want %q", *ran, want)
+	}
+}
+
+func TestNotify_Linux(t *testing.T) {
+	ran := stubPlatform(t, "linux", true)
+	if err := Notify("Claudio", "waiting"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"notify-send", "--app-name=Claudio", "Claudio", "waiting"}
+	if !slices.Equal(*ran, want) {
+		t.Errorf("ran %q, want %q", *ran, want)
+	}
+}
+
+func TestNotify_Unsupported(t *testing.T) {
+	stubPlatform(t, "linux", false)
+	if err := Notify("Claudio", "waiting"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Notify() without notify-send = %v, want ErrUnsupported", err)
+	}
+	stubPlatform(t, "windows", true)
+	if err := Notify("Claudio", "waiting"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Notify() on windows = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	l := NewLimiter(time.Minute)
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	if ok, _ := l.Allow(start); !ok {
+		t.Fatal("first notification should be allowed")
+	}
+	ok, wait := l.Allow(start.Add(20 * time.Second))
+	if ok || wait != 40*time.Second {
+		t.Errorf("Allow() within the interval = %v, %v; want false, 40s", ok, wait)
+	}
+	if ok, _ := l.Allow(start.Add(time.Minute)); !ok {
+		t.Error("notification after the interval should be allowed")
+	}
+}
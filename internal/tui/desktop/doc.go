@@ -0,0 +1,19 @@
+// Package desktop shows operating system notifications, so an instance
+// waiting for input is noticed while the terminal running Claudio is in
+// the background.
+//
+// On macOS the notification is posted with osascript (display
+// notification); on Linux and the BSDs with notify-send. Other platforms,
+// and systems without the command, return an error wrapping
+// [ErrUnsupported].
+//
+// A [Limiter] spaces notifications out: callers fold whatever happened
+// while it was closed into the next notification instead of posting one
+// per event.
+//
+// # Usage
+//
+//	if err := desktop.Notify("Claudio", "auth-fix is waiting for input"); err != nil {
+//		logger.Debug("desktop notification failed", "error", err)
+//	}
+package desktop
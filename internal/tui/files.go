@@ -0,0 +1,165 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/event"
+	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
+	"github.com/Iron-Ham/claudio/internal/tui/panel"
+	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fileRefreshInterval is the least time between git status runs for one
+// instance. Output arrives many times a second while an instance works; the
+// files panel only needs to catch up with its edits every so often.
+const fileRefreshInterval = 2 * time.Second
+
+// fileWatch keeps the files panel current. Claims come straight from
+// filelock events. An instance's uncommitted files are reloaded only after
+// something suggests they changed, a claim or release by the instance or new
+// output from it, rather than by rescanning every worktree.
+type fileWatch struct {
+	activity *panel.FileActivity
+	stale    map[string]bool      // instance ID -> uncommitted files need reloading
+	loading  map[string]bool      // instance ID -> reload in flight
+	loadedAt map[string]time.Time // instance ID -> when the last reload was dispatched
+}
+
+func newFileWatch() *fileWatch {
+	return &fileWatch{
+		activity: panel.NewFileActivity(),
+		stale:    make(map[string]bool),
+		loading:  make(map[string]bool),
+		loadedAt: make(map[string]time.Time),
+	}
+}
+
+// ensureFileWatch lazily initializes and returns the file watch.
+func (m *Model) ensureFileWatch() *fileWatch {
+	if m.files == nil {
+		m.files = newFileWatch()
+	}
+	return m.files
+}
+
+// markFilesStale records that an instance may have changed files since its
+// uncommitted files were last loaded.
+func (m *Model) markFilesStale(instanceID string) {
+	if m.files != nil {
+		m.files.stale[instanceID] = true
+	}
+}
+
+// toggleFilesPanel shows or hides the files panel. Opening it reloads every
+// instance's uncommitted files once; after that, only instances with new
+// activity are reloaded.
+func (m *Model) toggleFilesPanel() {
+	m.showFiles = !m.showFiles
+	if !m.showFiles {
+		return
+	}
+	m.showStats = false
+	w := m.ensureFileWatch()
+	if m.session != nil {
+		for _, inst := range m.session.Instances {
+			w.stale[inst.ID] = true
+		}
+	}
+}
+
+// replayFileClaims rebuilds the files panel's claims from the session's event
+// journal, so claims made before a restart still show. The journal is
+// replayed onto a private bus: only this state is rebuilt, and subscribers of
+// the session's bus don't act on the events a second time.
+func (m *Model) replayFileClaims() {
+	if m.orchestrator == nil {
+		return
+	}
+	bus := event.NewBus()
+	bus.Subscribe("filelock.claimed", func(e event.Event) {
+		if ce, ok := e.(event.FileClaimEvent); ok {
+			m.handleFileClaim(tuimsg.FileClaimMsg{InstanceID: ce.InstanceID, FilePath: ce.FilePath, Claimed: true})
+		}
+	})
+	bus.Subscribe("filelock.released", func(e event.Event) {
+		if re, ok := e.(event.FileReleaseEvent); ok {
+			m.handleFileClaim(tuimsg.FileClaimMsg{InstanceID: re.InstanceID, FilePath: re.FilePath})
+		}
+	})
+	n, err := m.orchestrator.ReplayEvents(bus, time.Time{})
+	if m.logger == nil {
+		return
+	}
+	if err != nil {
+		m.logger.Debug("event journal not replayed", "error", err)
+		return
+	}
+	m.logger.Debug("event journal replayed", "events", n)
+}
+
+// handleFileClaim records a filelock claim or release.
+func (m *Model) handleFileClaim(msg tuimsg.FileClaimMsg) {
+	w := m.ensureFileWatch()
+	if msg.Claimed {
+		w.activity.Claim(msg.InstanceID, msg.FilePath)
+	} else {
+		w.activity.Release(msg.InstanceID, msg.FilePath)
+	}
+	w.stale[msg.InstanceID] = true
+}
+
+// handleFileChangesLoaded records an instance's uncommitted files. Failed
+// loads, such as for a worktree that was just removed, keep the previous
+// files.
+func (m *Model) handleFileChangesLoaded(msg tuimsg.FileChangesLoadedMsg) {
+	w := m.ensureFileWatch()
+	delete(w.loading, msg.InstanceID)
+	if msg.Err != nil {
+		if m.logger != nil {
+			m.logger.Debug("failed to load uncommitted files", "instance_id", msg.InstanceID, "error", msg.Err)
+		}
+		return
+	}
+	w.activity.SetChanges(msg.InstanceID, msg.Files)
+}
+
+// dispatchFileChangeChecks reloads the uncommitted files of stale instances
+// while the files panel is visible, at most once per fileRefreshInterval for
+// each instance.
+func (m *Model) dispatchFileChangeChecks(now time.Time) []tea.Cmd {
+	if !m.showFiles || m.files == nil || m.session == nil {
+		return nil
+	}
+	w := m.files
+	var cmds []tea.Cmd
+	for _, inst := range m.session.Instances {
+		if !w.stale[inst.ID] || w.loading[inst.ID] || inst.WorktreePath == "" {
+			continue
+		}
+		if now.Sub(w.loadedAt[inst.ID]) < fileRefreshInterval {
+			continue
+		}
+		delete(w.stale, inst.ID)
+		w.loading[inst.ID] = true
+		w.loadedAt[inst.ID] = now
+		cmds = append(cmds, tuimsg.LoadFileChangesAsync(m.orchestrator, inst.WorktreePath, inst.ID))
+	}
+	return cmds
+}
+
+// renderFilesPanel renders the files panel.
+func (m Model) renderFilesPanel(width int) string {
+	state := &panel.RenderState{
+		Width:  width,
+		Height: m.height,
+		Theme:  styles.NewTheme(),
+	}
+	if m.session != nil {
+		state.Instances = m.session.Instances
+	}
+	if m.files != nil {
+		state.FileActivity = m.files.activity
+	}
+	return panel.NewFilesPanel().RenderWithBox(state, styles.ContentBox)
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
+)
+
+func newFilesTestModel() Model {
+	return Model{
+		session: &orchestrator.Session{Instances: []*orchestrator.Instance{
+			{ID: "inst-1", WorktreePath: "/wt/1"},
+			{ID: "inst-2", WorktreePath: "/wt/2"},
+		}},
+	}
+}
+
+func TestFilesPanel_ReloadsOnlyOnActivity(t *testing.T) {
+	m := newFilesTestModel()
+	now := time.Now()
+
+	if cmds := m.dispatchFileChangeChecks(now); len(cmds) != 0 {
+		t.Fatalf("dispatched %d reloads with the panel hidden, want none", len(cmds))
+	}
+
+	m.toggleFilesPanel()
+	if cmds := m.dispatchFileChangeChecks(now); len(cmds) != 2 {
+		t.Fatalf("opening the panel dispatched %d reloads, want one per instance", len(cmds))
+	}
+	m.handleFileChangesLoaded(tuimsg.FileChangesLoadedMsg{InstanceID: "inst-1", Files: []string{"a.go"}})
+	m.handleFileChangesLoaded(tuimsg.FileChangesLoadedMsg{InstanceID: "inst-2"})
+
+	later := now.Add(fileRefreshInterval)
+	if cmds := m.dispatchFileChangeChecks(later); len(cmds) != 0 {
+		t.Fatalf("dispatched %d reloads without activity, want none", len(cmds))
+	}
+
+	// A claim by inst-2 makes it stale, but the reload waits for the interval
+	m.handleFileClaim(tuimsg.FileClaimMsg{InstanceID: "inst-2", FilePath: "a.go", Claimed: true})
+	if cmds := m.dispatchFileChangeChecks(now.Add(fileRefreshInterval / 2)); len(cmds) != 0 {
+		t.Fatal("reload should wait for fileRefreshInterval")
+	}
+	if cmds := m.dispatchFileChangeChecks(later); len(cmds) != 1 {
+		t.Fatalf("dispatched %d reloads after a claim, want 1", len(cmds))
+	}
+
+	if conflicts := m.files.activity.Conflicts(); len(conflicts) != 1 || conflicts[0].EditedBy != "inst-1" {
+		t.Errorf("Conflicts() = %+v, want inst-1 editing inst-2's claim", conflicts)
+	}
+}
+
+func TestFilesPanel_FailedLoadKeepsChanges(t *testing.T) {
+	m := newFilesTestModel()
+	m.handleFileChangesLoaded(tuimsg.FileChangesLoadedMsg{InstanceID: "inst-1", Files: []string{"a.go"}})
+	m.handleFileChangesLoaded(tuimsg.FileChangesLoadedMsg{InstanceID: "inst-1", Err: errors.New("no worktree")})
+
+	if !m.files.activity.Changed("inst-1", "a.go") {
+		t.Error("a failed load should keep the previous changes")
+	}
+}
+
+func TestFilesPanel_ToggleClosesStats(t *testing.T) {
+	m := newFilesTestModel()
+	m.showStats = true
+	m.toggleFilesPanel()
+	if !m.showFiles || m.showStats {
+		t.Errorf("showFiles = %v, showStats = %v; want the files panel to replace stats", m.showFiles, m.showStats)
+	}
+	m.toggleFilesPanel()
+	if m.showFiles {
+		t.Error("second toggle should hide the files panel")
+	}
+}
@@ -0,0 +1,175 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/Iron-Ham/claudio/internal/instance/capture"
+	"github.com/Iron-Ham/claudio/internal/tui/keymap"
+	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
+	"github.com/Iron-Ham/claudio/internal/tui/search"
+	"github.com/Iron-Ham/claudio/internal/tui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// -----------------------------------------------------------------------------
+// Session-wide Search
+// -----------------------------------------------------------------------------
+
+// grepResults is the state of the :grep results list. Output hits come
+// first, in sidebar order; transcript hits are added when their background
+// search completes.
+type grepResults struct {
+	pattern   *regexp.Regexp
+	hits      []search.Hit
+	selected  int
+	truncated bool
+
+	searchingTranscripts bool
+	transcripts          map[string]*capture.Transcript // By instance ID, to replay a hit
+}
+
+// openGrep searches every instance's output for re and shows the results.
+// With transcripts set, transcript hits arrive later in a
+// TranscriptSearchMsg.
+func (m *Model) openGrep(re *regexp.Regexp, transcripts bool) {
+	g := &grepResults{pattern: re, searchingTranscripts: transcripts}
+	if m.session != nil {
+		for _, inst := range m.session.Instances {
+			// Search one past the cap to know whether it was reached
+			limit := search.MaxHits + 1 - len(g.hits)
+			if limit <= 0 {
+				break
+			}
+			g.hits = append(g.hits, search.Lines(inst.ID, m.outputManager.GetFilteredLines(inst.ID), re, limit)...)
+		}
+	}
+	if len(g.hits) > search.MaxHits {
+		g.hits, g.truncated = g.hits[:search.MaxHits], true
+	}
+	m.grep = g
+}
+
+// handleTranscriptSearch adds the hits of a transcript search to the
+// results it was started for.
+func (m Model) handleTranscriptSearch(msg tuimsg.TranscriptSearchMsg) (tea.Model, tea.Cmd) {
+	g := m.grep
+	if g == nil || !g.searchingTranscripts || g.pattern.String() != msg.Pattern {
+		return m, nil
+	}
+	g.searchingTranscripts = false
+	g.transcripts = msg.Transcripts
+	if msg.Err != nil {
+		if m.logger != nil {
+			m.logger.Error("failed to search transcripts", "error", msg.Err)
+		}
+		m.errorMessage = fmt.Sprintf("Failed to search a transcript: %v", msg.Err)
+	}
+
+	room := search.MaxHits - len(g.hits)
+	if len(msg.Hits) > room {
+		msg.Hits, g.truncated = msg.Hits[:room], true
+	}
+	g.hits = append(g.hits, msg.Hits...)
+	return m, nil
+}
+
+// openGrepHit closes the results and shows the selected hit: the output of
+// its instance scrolled to the line, or for a transcript hit, a replay of
+// the transcript from the frame the line first appeared in.
+func (m *Model) openGrepHit() {
+	g := m.grep
+	m.grep = nil
+	if len(g.hits) == 0 || m.session == nil {
+		return
+	}
+	hit := g.hits[g.selected]
+
+	idx := -1
+	for i, inst := range m.session.Instances {
+		if inst.ID == hit.InstanceID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		m.infoMessage = "That instance was removed"
+		return
+	}
+	m.switchToInstance(idx)
+	m.ensureActiveVisible()
+
+	if hit.Transcript {
+		m.replay = &transcriptReplay{
+			instanceID: hit.InstanceID,
+			transcript: g.transcripts[hit.InstanceID],
+			frame:      hit.Frame,
+		}
+		return
+	}
+	// Center the line in the output
+	m.outputManager.ScrollToTop(hit.InstanceID)
+	m.outputManager.Scroll(hit.InstanceID, hit.Line-m.getOutputMaxLines()/2, m.getOutputMaxLines())
+}
+
+// handleGrepInput handles keyboard input in the :grep results.
+func (m Model) handleGrepInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	g := m.grep
+	last := max(len(g.hits)-1, 0)
+	page := max(m.grepListLines()/2, 1)
+
+	switch m.resolveKey(keymap.Grep, msg) {
+	case keymap.Close:
+		m.grep = nil
+
+	case keymap.Open:
+		m.openGrepHit()
+
+	case keymap.ScrollDown:
+		g.selected = min(g.selected+1, last)
+
+	case keymap.ScrollUp:
+		g.selected = max(g.selected-1, 0)
+
+	case keymap.HalfPageDown:
+		g.selected = min(g.selected+page, last)
+
+	case keymap.HalfPageUp:
+		g.selected = max(g.selected-page, 0)
+
+	case keymap.Top:
+		g.selected = 0
+
+	case keymap.Bottom:
+		g.selected = last
+
+	case keymap.ToggleLogs:
+		m.toggleLogPane()
+	}
+
+	return m, nil
+}
+
+// grepListLines returns how many results the list shows at once.
+func (m Model) grepListLines() int {
+	return view.SearchListLines(m.mainAreaHeight(m.calculateExtraFooterLines()))
+}
+
+// renderGrep renders the :grep results.
+func (m Model) renderGrep(width int) string {
+	g := m.grep
+	names := make(map[string]string)
+	if m.session != nil {
+		for _, inst := range m.session.Instances {
+			names[inst.ID] = inst.EffectiveName()
+		}
+	}
+	return view.RenderSearch(view.SearchState{
+		Pattern:              g.pattern.String(),
+		Hits:                 g.hits,
+		Names:                names,
+		Selected:             g.selected,
+		Truncated:            g.truncated,
+		SearchingTranscripts: g.searchingTranscripts,
+	}, width, m.mainAreaHeight(m.calculateExtraFooterLines()))
+}
@@ -0,0 +1,140 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/instance/capture"
+	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
+	"github.com/Iron-Ham/claudio/internal/tui/search"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newGrepTestModel returns a model whose instances a and c each have 200
+// lines of output, every 50th of them an error.
+func newGrepTestModel() Model {
+	m := newDashboardTestModel(3)
+	for _, id := range []string{"a", "c"} {
+		var out strings.Builder
+		for i := range 200 {
+			if i%50 == 40 {
+				fmt.Fprintf(&out, "%s error %d\n", id, i)
+			} else {
+				fmt.Fprintf(&out, "%s line %d\n", id, i)
+			}
+		}
+		m.outputManager.SetOutput(id, out.String())
+	}
+	return m
+}
+
+func grepKey(m Model, key tea.KeyMsg) Model {
+	result, _ := m.handleGrepInput(key)
+	return result.(Model)
+}
+
+func TestOpenGrep(t *testing.T) {
+	m := newGrepTestModel()
+	m.openGrep(regexp.MustCompile(`error \d+`), false)
+
+	if m.grep == nil || len(m.grep.hits) != 8 {
+		t.Fatalf("grep = %+v, want 8 hits", m.grep)
+	}
+	if h := m.grep.hits[0]; h.InstanceID != "a" || h.Line != 40 {
+		t.Errorf("first hit = %+v, want a:40", h)
+	}
+	if h := m.grep.hits[4]; h.InstanceID != "c" || h.Line != 40 {
+		t.Errorf("fifth hit = %+v, want c:40, after the hits of a", h)
+	}
+	if view := m.renderGrep(120); !strings.Contains(view, "8 matches in 2 instances") {
+		t.Errorf("results missing summary:\n%s", view)
+	}
+}
+
+func TestOpenGrep_Truncates(t *testing.T) {
+	m := newGrepTestModel()
+	for _, id := range []string{"a", "b", "c"} {
+		m.outputManager.SetOutput(id, strings.Repeat("x\n", search.MaxHits))
+	}
+	m.openGrep(regexp.MustCompile(`x`), false)
+	if len(m.grep.hits) != search.MaxHits || !m.grep.truncated {
+		t.Errorf("got %d hits, truncated = %v; want %d, truncated", len(m.grep.hits), m.grep.truncated, search.MaxHits)
+	}
+}
+
+func TestHandleGrepInput_JumpsToHit(t *testing.T) {
+	m := newGrepTestModel()
+	m.openGrep(regexp.MustCompile(`error \d+`), false)
+
+	m = grepKey(m, runeKey("G"))
+	m = grepKey(m, runeKey("k"))
+	if m.grep.selected != 6 {
+		t.Fatalf("selected = %d, want 6 after G k", m.grep.selected)
+	}
+	m = grepKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.grep != nil {
+		t.Error("results still open after Enter")
+	}
+	if m.activeTab != 2 {
+		t.Errorf("active tab = %d, want instance c", m.activeTab)
+	}
+	// c's third error is line 140; it should be in view, away from the bottom
+	offset := m.outputManager.GetScrollOffset("c")
+	if maxLines := m.getOutputMaxLines(); offset > 140 || offset+maxLines <= 140 {
+		t.Errorf("offset %d with %d lines does not show line 140", offset, maxLines)
+	}
+	if m.outputManager.IsAutoScroll("c") {
+		t.Error("jumping to a hit left auto-scroll on")
+	}
+}
+
+func TestHandleGrepInput_Close(t *testing.T) {
+	m := newGrepTestModel()
+	m.openGrep(regexp.MustCompile(`error`), false)
+	m = grepKey(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.grep != nil || m.activeTab != 0 {
+		t.Errorf("after Esc, grep = %+v, active tab = %d", m.grep, m.activeTab)
+	}
+}
+
+func TestHandleTranscriptSearch(t *testing.T) {
+	m := newGrepTestModel()
+	re := regexp.MustCompile(`error \d+`)
+	m.openGrep(re, true)
+	if view := m.renderGrep(120); !strings.Contains(view, "searching transcripts") {
+		t.Errorf("results missing progress:\n%s", view)
+	}
+
+	tr := &capture.Transcript{Frames: []capture.Frame{
+		{At: 0, Screen: "b starting"},
+		{At: 3 * time.Second, Screen: "b starting\nb error 7"},
+	}}
+	hits := search.Transcript("b", tr, re, search.MaxHits)
+
+	// Results of an earlier search are ignored
+	result, _ := m.Update(tuimsg.TranscriptSearchMsg{Pattern: "other", Hits: hits})
+	m = result.(Model)
+	if len(m.grep.hits) != 8 || !m.grep.searchingTranscripts {
+		t.Fatalf("a stale search changed the results: %+v", m.grep)
+	}
+
+	result, _ = m.Update(tuimsg.TranscriptSearchMsg{
+		Pattern:     re.String(),
+		Hits:        hits,
+		Transcripts: map[string]*capture.Transcript{"b": tr},
+	})
+	m = result.(Model)
+	if len(m.grep.hits) != 9 || m.grep.searchingTranscripts {
+		t.Fatalf("grep = %+v, want the transcript hit added", m.grep)
+	}
+
+	m = grepKey(m, runeKey("G"))
+	m = grepKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.activeTab != 1 || m.replay == nil || m.replay.frame != 1 {
+		t.Errorf("active tab = %d, replay = %+v; want b replayed from frame 1", m.activeTab, m.replay)
+	}
+}
@@ -3,10 +3,12 @@ package tui
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/Iron-Ham/claudio/internal/audit"
 	"github.com/Iron-Ham/claudio/internal/config"
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/group"
@@ -14,6 +16,7 @@ import (
 	"github.com/Iron-Ham/claudio/internal/tui/command"
 	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
 	"github.com/Iron-Ham/claudio/internal/tui/view"
+	"github.com/Iron-Ham/claudio/internal/ultraplan"
 	"github.com/Iron-Ham/claudio/internal/util"
 )
 
@@ -108,6 +111,8 @@ func (m *Model) initInlineUltraPlanMode(result command.Result) {
 		ultraCfg.PRLabels = appCfg.Ultraplan.PRLabels
 	}
 	ultraCfg.BranchPrefix = appCfg.Ultraplan.BranchPrefix
+	ultraCfg.MaxBehindCommits = appCfg.Ultraplan.MaxBehindCommits
+	ultraCfg.AutoRebase = appCfg.Ultraplan.AutoRebase
 	ultraCfg.MaxTaskRetries = appCfg.Ultraplan.MaxTaskRetries
 	ultraCfg.RequireVerifiedCommits = appCfg.Ultraplan.RequireVerifiedCommits
 
@@ -234,7 +239,7 @@ func (m *Model) initInlineUltraPlanMode(result command.Result) {
 	m.addingTask = true
 	m.taskInput = ""
 	m.taskInputCursor = 0
-	m.infoMessage = "Enter ultraplan objective:"
+	m.infoMessage = "Enter ultraplan objective (type / for templates):"
 }
 
 // toggleGroupedView toggles the grouped instance view on/off
@@ -457,7 +462,7 @@ func (m *Model) handleMultiPlanObjectiveSubmit(objective string) {
 		}
 
 		// Set task name to identify the strategy
-		inst.Task = fmt.Sprintf("Planning (%s)", strategy)
+		m.orchestrator.SetInstanceTask(inst.ID, fmt.Sprintf("Planning (%s)", strategy))
 
 		// Store the instance ID
 		session.PlanningInstanceIDs = append(session.PlanningInstanceIDs, inst.ID)
@@ -647,7 +652,7 @@ func (m *Model) startInlineMultiPlanManagerForSession(session *InlinePlanSession
 		return
 	}
 
-	inst.Task = "Plan Manager (evaluating)"
+	m.orchestrator.SetInstanceTask(inst.ID, "Plan Manager (evaluating)")
 	session.PlanManagerInstanceID = inst.ID
 
 	// Add to the multiplan group
@@ -860,6 +865,31 @@ func (m *Model) handleUltraPlanObjectiveSubmit(objective string) {
 	if session.UltraPlanConfig != nil {
 		cfg = *session.UltraPlanConfig
 	}
+	if session.ObjectiveTemplate != "" {
+		templates, loadErrs := m.objectiveTemplates()
+		for _, err := range loadErrs {
+			if m.logger != nil {
+				m.logger.Warn("skipping objective template", "error", err)
+			}
+		}
+		if tmpl, err := ultraplan.FindTemplate(templates, session.ObjectiveTemplate); err == nil {
+			// A template with variables was given name=value arguments
+			// in place of its prefix and is expanded from them
+			if tmpl.HasVariables() {
+				values, rest, err := ultraplan.ParseVariableArgs(objective)
+				if err == nil {
+					objective, err = tmpl.Expand(rest, values)
+				}
+				if err != nil {
+					m.errorMessage = err.Error()
+					return
+				}
+			}
+			tmpl.Apply(&cfg)
+		} else if m.logger != nil {
+			m.logger.Warn("objective template not found", "template", session.ObjectiveTemplate, "error", err)
+		}
+	}
 
 	// Remove the session - we're transitioning to ultraplan mode
 	m.inlinePlan.RemoveSession(session.GroupID)
@@ -1028,7 +1058,7 @@ func (m *Model) syncPlanTasksToInstances() error {
 			}
 
 			// Update instance metadata
-			inst.Task = task.Title
+			m.orchestrator.SetInstanceTask(inst.ID, task.Title)
 
 			// Add to group
 			gm.MoveInstanceToGroup(inst.ID, planGroup.ID)
@@ -1083,11 +1113,13 @@ func (m *Model) startInlinePlanExecution() error {
 			// Set up dependencies
 			task := orchestrator.GetTaskByID(plan, taskID)
 			if task != nil {
+				dependsOn := slices.Clone(inst.DependsOn)
 				for _, depTaskID := range task.DependsOn {
 					if depInstID, exists := session.TaskToInstance[depTaskID]; exists {
-						inst.DependsOn = append(inst.DependsOn, depInstID)
+						dependsOn = append(dependsOn, depInstID)
 					}
 				}
+				m.orchestrator.SetInstanceDependsOn(inst.ID, dependsOn)
 			}
 
 			// Start the instance (orchestrator will handle dependency waiting)
@@ -1129,6 +1161,7 @@ func (m *Model) confirmInlinePlanAndExecute() error {
 			"task_count", len(session.Plan.Tasks),
 			"objective", session.Objective)
 	}
+	m.orchestrator.RecordOperatorAction(audit.ActionApprove, "", "", fmt.Sprintf("approved plan (%d tasks)", len(session.Plan.Tasks)))
 
 	return nil
 }
@@ -1,11 +1,13 @@
 package tui
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
 	"github.com/Iron-Ham/claudio/internal/tui/view"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 // Helper to create a session-based InlinePlanState for tests
@@ -794,3 +796,58 @@ func TestCollapsePlannersToSubGroup_PlannerNotInMainGroup(t *testing.T) {
 func newTestGroupViewState() *view.GroupViewState {
 	return view.NewGroupViewState()
 }
+
+func TestUltraPlanObjectiveTemplatePicker(t *testing.T) {
+	m := Model{inlinePlan: NewInlinePlanState()}
+	if got := m.availableTemplates(""); len(got) != len(TaskTemplates) {
+		t.Fatalf("availableTemplates() without ultraplan = %d templates, want task templates", len(got))
+	}
+
+	session := &InlinePlanSession{AwaitingObjective: true, IsUltraPlan: true}
+	m.inlinePlan.AddSession("tmp", session)
+	m.showTemplates = true
+	m.taskInput = "/upg"
+	m.templateFilter = "upg"
+
+	templates := m.availableTemplates(m.templateFilter)
+	if len(templates) != 1 || templates[0].Command != "upgrade" {
+		t.Fatalf("availableTemplates(upg) = %+v, want the upgrade objective template", templates)
+	}
+
+	result, _ := m.handleTemplateDropdown(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+
+	if session.ObjectiveTemplate != "upgrade" {
+		t.Errorf("ObjectiveTemplate = %q, want upgrade", session.ObjectiveTemplate)
+	}
+	if m.taskInput != "Upgrade: " {
+		t.Errorf("taskInput = %q, want template prefix", m.taskInput)
+	}
+	if !strings.Contains(m.templateSuffix, "Verification requirements:") {
+		t.Errorf("templateSuffix = %q, want verification requirements", m.templateSuffix)
+	}
+}
+
+func TestUltraPlanObjectiveTemplatePicker_Variables(t *testing.T) {
+	m := Model{inlinePlan: NewInlinePlanState()}
+	session := &InlinePlanSession{AwaitingObjective: true, IsUltraPlan: true}
+	m.inlinePlan.AddSession("tmp", session)
+	m.showTemplates = true
+	m.taskInput = "/coverage"
+	m.templateFilter = "coverage"
+
+	result, _ := m.handleTemplateDropdown(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.taskInput != "package= target=80 " || m.templateSuffix != "" {
+		t.Errorf("taskInput = %q, templateSuffix = %q; want the variable arguments and no suffix", m.taskInput, m.templateSuffix)
+	}
+
+	// A missing value keeps the session waiting for its objective
+	m.handleUltraPlanObjectiveSubmit("package= target=80")
+	if !strings.Contains(m.errorMessage, "needs a value for package") {
+		t.Errorf("errorMessage = %q, want the missing package", m.errorMessage)
+	}
+	if m.inlinePlan.GetAwaitingObjectiveSession() != session {
+		t.Error("session no longer awaits its objective")
+	}
+}
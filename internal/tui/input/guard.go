@@ -0,0 +1,63 @@
+package input
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ConfirmWindow is how long a destructive key stays armed in input mode: a
+// second press within the window forwards it to the instance.
+const ConfirmWindow = 2 * time.Second
+
+// destructiveKeys are keys that interrupt or end the backend session when
+// forwarded in input mode, mapped to their display names.
+var destructiveKeys = map[tea.KeyType]string{
+	tea.KeyCtrlC:         "Ctrl+C",
+	tea.KeyCtrlD:         "Ctrl+D",
+	tea.KeyCtrlBackslash: "Ctrl+\\",
+}
+
+// KeyGuard asks for confirmation before destructive keys are forwarded in
+// input mode, so a key meant for the TUI doesn't kill the backend. The zero
+// value is ready to use.
+type KeyGuard struct {
+	pending tea.KeyType
+	armedAt time.Time
+}
+
+// Check reports whether msg should be forwarded to the instance. The first
+// press of a destructive key is held back and returns a prompt to show; a
+// second press of the same key within ConfirmWindow is forwarded. Any other
+// key disarms the guard and is forwarded.
+func (g *KeyGuard) Check(msg tea.KeyMsg, now time.Time) (forward bool, prompt string) {
+	name, destructive := destructiveKeys[msg.Type]
+	if !destructive {
+		g.Reset()
+		return true, ""
+	}
+
+	if !g.armedAt.IsZero() && g.pending == msg.Type && now.Sub(g.armedAt) <= ConfirmWindow {
+		g.Reset()
+		return true, ""
+	}
+
+	g.pending = msg.Type
+	g.armedAt = now
+	return false, fmt.Sprintf("Press %s again to send it to the instance (Ctrl+] returns to the TUI)", name)
+}
+
+// Reset disarms the guard.
+func (g *KeyGuard) Reset() {
+	*g = KeyGuard{}
+}
+
+// EnterKeyLabel returns the key that enters input mode, for help text.
+// With requireModifier, plain [i] and [Enter] no longer enter input mode.
+func EnterKeyLabel(requireModifier bool) string {
+	if requireModifier {
+		return "Alt+i"
+	}
+	return "i"
+}
@@ -0,0 +1,84 @@
+package input
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestKeyGuard_Check(t *testing.T) {
+	now := time.Unix(1000, 0)
+	ctrlC := tea.KeyMsg{Type: tea.KeyCtrlC}
+
+	t.Run("regular keys are forwarded", func(t *testing.T) {
+		var g KeyGuard
+		forward, prompt := g.Check(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}, now)
+		if !forward || prompt != "" {
+			t.Errorf("Check(q) = (%v, %q), want (true, \"\")", forward, prompt)
+		}
+	})
+
+	t.Run("second press within window is forwarded", func(t *testing.T) {
+		var g KeyGuard
+		forward, prompt := g.Check(ctrlC, now)
+		if forward {
+			t.Fatal("first Ctrl+C was forwarded")
+		}
+		if !strings.Contains(prompt, "Ctrl+C again") {
+			t.Errorf("prompt = %q, want confirmation for Ctrl+C", prompt)
+		}
+		if forward, _ := g.Check(ctrlC, now.Add(ConfirmWindow)); !forward {
+			t.Error("second Ctrl+C within the window was held back")
+		}
+		// Confirming disarms the guard
+		if forward, _ := g.Check(ctrlC, now.Add(ConfirmWindow)); forward {
+			t.Error("third Ctrl+C was forwarded without a new confirmation")
+		}
+	})
+
+	t.Run("expired confirmation re-arms", func(t *testing.T) {
+		var g KeyGuard
+		g.Check(ctrlC, now)
+		if forward, prompt := g.Check(ctrlC, now.Add(ConfirmWindow+time.Millisecond)); forward || prompt == "" {
+			t.Errorf("late Ctrl+C = (%v, %q), want held back with prompt", forward, prompt)
+		}
+	})
+
+	t.Run("other key in between disarms", func(t *testing.T) {
+		var g KeyGuard
+		g.Check(ctrlC, now)
+		g.Check(tea.KeyMsg{Type: tea.KeyEnter}, now)
+		if forward, _ := g.Check(ctrlC, now); forward {
+			t.Error("Ctrl+C after another key was forwarded")
+		}
+	})
+
+	t.Run("different destructive key does not confirm", func(t *testing.T) {
+		var g KeyGuard
+		g.Check(ctrlC, now)
+		forward, prompt := g.Check(tea.KeyMsg{Type: tea.KeyCtrlD}, now)
+		if forward || !strings.Contains(prompt, "Ctrl+D") {
+			t.Errorf("Check(Ctrl+D) = (%v, %q), want held back with Ctrl+D prompt", forward, prompt)
+		}
+	})
+
+	t.Run("reset disarms", func(t *testing.T) {
+		var g KeyGuard
+		g.Check(ctrlC, now)
+		g.Reset()
+		if forward, _ := g.Check(ctrlC, now); forward {
+			t.Error("Ctrl+C after Reset was forwarded")
+		}
+	})
+}
+
+func TestEnterKeyLabel(t *testing.T) {
+	if got := EnterKeyLabel(false); got != "i" {
+		t.Errorf("EnterKeyLabel(false) = %q, want %q", got, "i")
+	}
+	if got := EnterKeyLabel(true); got != "Alt+i" {
+		t.Errorf("EnterKeyLabel(true) = %q, want %q", got, "Alt+i")
+	}
+}
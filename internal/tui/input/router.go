@@ -31,6 +31,29 @@ const (
 
 	// ModeUltraPlan handles ultra-plan specific controls.
 	ModeUltraPlan
+
+	// ModeSelect is visual-line selection of output for copying (triggered by 'v').
+	ModeSelect
+
+	// ModeReplay scrubs through a recorded instance transcript (triggered by ':replay').
+	ModeReplay
+
+	// ModeDashboard navigates the grid of all instances (triggered by ':dashboard').
+	ModeDashboard
+
+	// ModeSplit compares two instances side by side (triggered by ':split').
+	ModeSplit
+
+	// ModeGrep lists the output lines of every instance matching a search
+	// (triggered by ':grep').
+	ModeGrep
+
+	// ModeConflicts resolves the conflicts a paused consolidation is waiting
+	// on (triggered by ':conflicts').
+	ModeConflicts
+
+	// ModeDeps shows the plan's task dependency graph (triggered by ':deps').
+	ModeDeps
 )
 
 // String returns the string representation of the mode.
@@ -50,6 +73,20 @@ func (m Mode) String() string {
 		return "plan-editor"
 	case ModeUltraPlan:
 		return "ultra-plan"
+	case ModeSelect:
+		return "select"
+	case ModeReplay:
+		return "replay"
+	case ModeDashboard:
+		return "dashboard"
+	case ModeSplit:
+		return "split"
+	case ModeGrep:
+		return "grep"
+	case ModeConflicts:
+		return "conflicts"
+	case ModeDeps:
+		return "deps"
 	default:
 		return "unknown"
 	}
@@ -293,6 +330,20 @@ func (r *Router) effectiveMode() Mode {
 	switch r.mode {
 	case ModeFilter:
 		return ModeFilter
+	case ModeSelect:
+		return ModeSelect
+	case ModeReplay:
+		return ModeReplay
+	case ModeDashboard:
+		return ModeDashboard
+	case ModeSplit:
+		return ModeSplit
+	case ModeGrep:
+		return ModeGrep
+	case ModeConflicts:
+		return ModeConflicts
+	case ModeDeps:
+		return ModeDeps
 	case ModeInput:
 		return ModeInput
 	case ModeTaskInput:
@@ -314,7 +365,7 @@ func (r *Router) effectiveMode() Mode {
 // ShouldExitModeOnEscape returns true if the current mode should exit on Escape.
 func (r *Router) ShouldExitModeOnEscape() bool {
 	switch r.mode {
-	case ModeCommand, ModeFilter, ModeTaskInput:
+	case ModeCommand, ModeFilter, ModeSelect, ModeReplay, ModeDashboard, ModeSplit, ModeGrep, ModeConflicts, ModeDeps, ModeTaskInput:
 		return true
 	default:
 		return false
@@ -358,6 +409,41 @@ func (r *Router) TransitionToFilter() {
 	r.mode = ModeFilter
 }
 
+// TransitionToSelect enters output selection mode.
+func (r *Router) TransitionToSelect() {
+	r.mode = ModeSelect
+}
+
+// TransitionToReplay enters transcript replay mode.
+func (r *Router) TransitionToReplay() {
+	r.mode = ModeReplay
+}
+
+// TransitionToDashboard enters the instance dashboard.
+func (r *Router) TransitionToDashboard() {
+	r.mode = ModeDashboard
+}
+
+// TransitionToSplit enters the split comparison view.
+func (r *Router) TransitionToSplit() {
+	r.mode = ModeSplit
+}
+
+// TransitionToGrep enters the :grep results list.
+func (r *Router) TransitionToGrep() {
+	r.mode = ModeGrep
+}
+
+// TransitionToConflicts enters the consolidation conflict view.
+func (r *Router) TransitionToConflicts() {
+	r.mode = ModeConflicts
+}
+
+// TransitionToDeps enters the task dependency graph.
+func (r *Router) TransitionToDeps() {
+	r.mode = ModeDeps
+}
+
 // TransitionToInput enters input mode (tmux forwarding).
 func (r *Router) TransitionToInput() {
 	r.mode = ModeInput
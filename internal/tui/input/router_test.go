@@ -18,6 +18,10 @@ func TestMode_String(t *testing.T) {
 		{ModeTaskInput, "task-input"},
 		{ModePlanEditor, "plan-editor"},
 		{ModeUltraPlan, "ultra-plan"},
+		{ModeSelect, "select"},
+		{ModeReplay, "replay"},
+		{ModeDashboard, "dashboard"},
+		{ModeSplit, "split"},
 		{Mode(999), "unknown"},
 	}
 
@@ -228,6 +232,10 @@ func TestRouter_Transitions(t *testing.T) {
 	}{
 		{"TransitionToCommand", r.TransitionToCommand, ModeCommand},
 		{"TransitionToFilter", r.TransitionToFilter, ModeFilter},
+		{"TransitionToSelect", r.TransitionToSelect, ModeSelect},
+		{"TransitionToReplay", r.TransitionToReplay, ModeReplay},
+		{"TransitionToDashboard", r.TransitionToDashboard, ModeDashboard},
+		{"TransitionToSplit", r.TransitionToSplit, ModeSplit},
 		{"TransitionToInput", r.TransitionToInput, ModeInput},
 		{"TransitionToTaskInput", r.TransitionToTaskInput, ModeTaskInput},
 		{"TransitionToNormal", r.TransitionToNormal, ModeNormal},
@@ -256,6 +264,7 @@ func TestRouter_TransitionClearsBuffer(t *testing.T) {
 		{"TransitionToCommand", (*Router).TransitionToCommand, true},
 		{"TransitionToTaskInput", (*Router).TransitionToTaskInput, true},
 		{"TransitionToFilter", (*Router).TransitionToFilter, false},
+		{"TransitionToSelect", (*Router).TransitionToSelect, false},
 		{"TransitionToInput", (*Router).TransitionToInput, false},
 	}
 
@@ -287,6 +296,10 @@ func TestRouter_ShouldExitModeOnEscape(t *testing.T) {
 		{ModeTaskInput, true},
 		{ModePlanEditor, false},
 		{ModeUltraPlan, false},
+		{ModeSelect, true},
+		{ModeReplay, true},
+		{ModeDashboard, true},
+		{ModeSplit, true},
 	}
 
 	for _, tt := range tests {
@@ -314,6 +327,10 @@ func TestRouter_ShouldExitModeOnCtrlBracket(t *testing.T) {
 		{ModeTaskInput, false},
 		{ModePlanEditor, false},
 		{ModeUltraPlan, false},
+		{ModeSelect, false},
+		{ModeReplay, false},
+		{ModeDashboard, false},
+		{ModeSplit, false},
 	}
 
 	for _, tt := range tests {
@@ -341,6 +358,10 @@ func TestRouter_IsBufferedMode(t *testing.T) {
 		{ModeTaskInput, true},
 		{ModePlanEditor, false},
 		{ModeUltraPlan, false},
+		{ModeSelect, false},
+		{ModeReplay, false},
+		{ModeDashboard, false},
+		{ModeSplit, false},
 	}
 
 	for _, tt := range tests {
@@ -368,6 +389,10 @@ func TestRouter_IsForwardingMode(t *testing.T) {
 		{ModeTaskInput, false},
 		{ModePlanEditor, false},
 		{ModeUltraPlan, false},
+		{ModeSelect, false},
+		{ModeReplay, false},
+		{ModeDashboard, false},
+		{ModeSplit, false},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,64 @@
+package input
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// interruptKeys are keys that interrupt or end the backend, audited on their
+// own rather than as part of a line.
+var interruptKeys = map[tea.KeyType]string{
+	tea.KeyCtrlC:         "<Ctrl+C>",
+	tea.KeyCtrlD:         "<Ctrl+D>",
+	tea.KeyCtrlBackslash: "<Ctrl+\\>",
+	tea.KeyEsc:           "<Esc>",
+}
+
+// Transcript collects what is typed in input mode so it can be audited one
+// submitted line at a time instead of per keystroke. The zero value is ready
+// to use.
+type Transcript struct {
+	line []rune
+}
+
+// Observe records a key forwarded to the instance. It returns the text to
+// audit and true when the key submits the line (Enter) or interrupts the
+// backend; otherwise it returns false. An empty submitted line is reported
+// as "<Enter>", since confirming a prompt is an intervention too.
+func (t *Transcript) Observe(msg tea.KeyMsg) (string, bool) {
+	if name, ok := interruptKeys[msg.Type]; ok {
+		text := t.Flush()
+		if text != "" {
+			text += " "
+		}
+		return text + name, true
+	}
+
+	switch msg.Type {
+	case tea.KeyRunes:
+		t.line = append(t.line, msg.Runes...)
+	case tea.KeySpace:
+		t.line = append(t.line, ' ')
+	case tea.KeyBackspace:
+		if len(t.line) > 0 {
+			t.line = t.line[:len(t.line)-1]
+		}
+	case tea.KeyEnter:
+		if text := t.Flush(); text != "" {
+			return text, true
+		}
+		return "<Enter>", true
+	}
+	return "", false
+}
+
+// Flush returns the text typed since the last submitted line and clears it.
+func (t *Transcript) Flush() string {
+	text := string(t.line)
+	t.line = nil
+	return text
+}
+
+// Reset discards any unsubmitted text.
+func (t *Transcript) Reset() {
+	*t = Transcript{}
+}
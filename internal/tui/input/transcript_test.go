@@ -0,0 +1,55 @@
+package input
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTranscript_Observe(t *testing.T) {
+	var tr Transcript
+	keys := []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("fix")},
+		{Type: tea.KeySpace},
+		{Type: tea.KeyRunes, Runes: []rune("itt")},
+		{Type: tea.KeyBackspace},
+		{Type: tea.KeyUp},
+	}
+	for _, k := range keys {
+		if text, ok := tr.Observe(k); ok {
+			t.Fatalf("Observe(%v) = %q, want nothing before Enter", k, text)
+		}
+	}
+
+	if text, ok := tr.Observe(tea.KeyMsg{Type: tea.KeyEnter}); !ok || text != "fix it" {
+		t.Errorf("Observe(Enter) = %q, %v; want %q", text, ok, "fix it")
+	}
+	if text, ok := tr.Observe(tea.KeyMsg{Type: tea.KeyEnter}); !ok || text != "<Enter>" {
+		t.Errorf("Observe(Enter) on empty line = %q, %v; want <Enter>", text, ok)
+	}
+
+	tr.Observe(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("stop")})
+	if text, ok := tr.Observe(tea.KeyMsg{Type: tea.KeyCtrlC}); !ok || text != "stop <Ctrl+C>" {
+		t.Errorf("Observe(Ctrl+C) = %q, %v; want the pending text and the key", text, ok)
+	}
+	if text, ok := tr.Observe(tea.KeyMsg{Type: tea.KeyEsc}); !ok || text != "<Esc>" {
+		t.Errorf("Observe(Esc) = %q, %v; want <Esc>", text, ok)
+	}
+}
+
+func TestTranscript_FlushAndReset(t *testing.T) {
+	var tr Transcript
+	tr.Observe(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("half typed")})
+	if got := tr.Flush(); got != "half typed" {
+		t.Errorf("Flush() = %q, want %q", got, "half typed")
+	}
+	if got := tr.Flush(); got != "" {
+		t.Errorf("second Flush() = %q, want empty", got)
+	}
+
+	tr.Observe(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	tr.Reset()
+	if got := tr.Flush(); got != "" {
+		t.Errorf("Flush() after Reset = %q, want empty", got)
+	}
+}
@@ -3,12 +3,16 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/Iron-Ham/claudio/internal/audit"
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	"github.com/Iron-Ham/claudio/internal/tui/input"
+	"github.com/Iron-Ham/claudio/internal/tui/keymap"
 	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
 	"github.com/Iron-Ham/claudio/internal/tui/view"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
 )
 
 // -----------------------------------------------------------------------------
@@ -26,6 +30,41 @@ func (m Model) handleKeypress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleFilterInput(msg)
 	}
 
+	// Handle select mode - choosing output lines to copy
+	if m.selection != nil {
+		return m.handleSelectInput(msg)
+	}
+
+	// Handle replay mode - scrubbing through a recorded transcript
+	if m.replay != nil {
+		return m.handleReplayInput(msg)
+	}
+
+	// Handle dashboard - navigating the grid of instances
+	if m.dashboard != nil {
+		return m.handleDashboardInput(msg)
+	}
+
+	// Handle split view - comparing two instances side by side
+	if m.split != nil {
+		return m.handleSplitInput(msg)
+	}
+
+	// Handle :grep results - picking a match to jump to
+	if m.grep != nil {
+		return m.handleGrepInput(msg)
+	}
+
+	// Handle the conflict view - resolving a paused consolidation
+	if m.conflicts != nil {
+		return m.handleConflictInput(msg)
+	}
+
+	// Handle the task dependency graph
+	if m.deps != nil {
+		return m.handleDepsInput(msg)
+	}
+
 	// Handle input mode - forward keys to the active instance's tmux session
 	if m.inputMode {
 		return m.handleInputMode(msg)
@@ -54,9 +93,23 @@ func (m Model) handleInputMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Ctrl+] exits input mode (traditional telnet escape)
 	if msg.Type == tea.KeyCtrlCloseBracket {
 		m.inputMode = false
+		m.inputGuard.Reset()
+		if text := m.inputAudit.Flush(); text != "" {
+			if inst := m.activeInstance(); inst != nil {
+				m.orchestrator.RecordOperatorAction(audit.ActionInput, inst.ID, "", text+" (not submitted)")
+			}
+		}
 		return m, nil
 	}
 
+	// Hold back keys that would interrupt or end the backend until confirmed
+	if viper.GetBool("tui.confirm_destructive_input") {
+		if forward, prompt := m.inputGuard.Check(msg, time.Now()); !forward {
+			m.infoMessage = prompt
+			return m, nil
+		}
+	}
+
 	// Forward the key to the active instance's tmux session
 	if inst := m.activeInstance(); inst != nil {
 		mgr := m.orchestrator.GetInstanceManager(inst.ID)
@@ -70,6 +123,9 @@ func (m Model) handleInputMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			} else {
 				input.SendKeyToTmux(mgr, msg)
 			}
+			if text, ok := m.inputAudit.Observe(msg); ok {
+				m.orchestrator.RecordOperatorAction(audit.ActionInput, inst.ID, "", text)
+			}
 		}
 	}
 	return m, nil
@@ -442,6 +498,9 @@ func (m Model) handleGroupCommand(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case GroupActionForceStart:
 			m.infoMessage = "Force-starting next group"
 		case GroupActionDismissGroup:
+			if m.refuseWhileObserving("dismiss groups") {
+				return m, nil
+			}
 			// Remove all instances in the group asynchronously to avoid blocking the TUI
 			if len(result.InstanceIDs) > 0 {
 				m.infoMessage = fmt.Sprintf("Dismissing %d instance(s)...", len(result.InstanceIDs))
@@ -457,84 +516,139 @@ func (m Model) handleGroupCommand(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleNormalModeKey handles individual key presses in normal mode.
 func (m Model) handleNormalModeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case ":":
+	switch m.resolveKey(keymap.Normal, msg) {
+	case keymap.Command:
 		// Enter command mode (vim-style)
 		m.commandMode = true
 		m.commandBuffer = ""
 		return m, nil
 
-	case "?":
+	case keymap.Help:
 		m.showHelp = !m.showHelp
 		if !m.showHelp {
 			m.helpScroll = 0
 		}
 		return m, nil
 
-	case "tab", "l":
+	case keymap.ToggleLogs:
+		m.toggleLogPane()
+		return m, nil
+
+	case keymap.NextInstance:
 		return m.handleNextInstance()
 
-	case "shift+tab", "h":
+	case keymap.PrevInstance:
 		return m.handlePrevInstance()
 
-	case "enter", "i":
+	case keymap.EnterInput:
+		if m.refuseWhileObserving("send input") {
+			return m, nil
+		}
+		if viper.GetBool("tui.require_input_modifier") {
+			m.infoMessage = "Press Alt+i to enter input mode (tui.require_input_modifier is on)"
+			return m, nil
+		}
 		return m.handleEnterInputMode()
 
-	case "esc":
+	case keymap.EnterInputAlt:
+		if m.refuseWhileObserving("send input") {
+			return m, nil
+		}
+		return m.handleEnterInputMode()
+
+	case keymap.Close:
 		return m.handleEscape()
 
-	case "j", "down":
+	case keymap.ScrollDown:
 		return m.handleScrollDown()
 
-	case "k", "up":
+	case keymap.ScrollUp:
 		return m.handleScrollUp()
 
-	case "J":
+	case keymap.SidebarDown:
 		return m.handleSidebarScrollDown()
 
-	case "K":
+	case keymap.SidebarUp:
 		return m.handleSidebarScrollUp()
 
-	case "ctrl+u":
+	case keymap.HalfPageUp:
 		return m.handleHalfPageUp()
 
-	case "ctrl+d":
+	case keymap.HalfPageDown:
 		return m.handleHalfPageDown()
 
-	case "ctrl+b":
+	case keymap.PageUp:
 		return m.handleFullPageUp()
 
-	case "ctrl+f":
+	case keymap.PageDown:
 		return m.handleFullPageDown()
 
-	case "ctrl+r":
+	case keymap.Restart:
+		if m.refuseWhileObserving("restart instances") {
+			return m, nil
+		}
 		return m.handleRestartInstance()
 
-	case "ctrl+k":
+	case keymap.Kill:
+		if m.refuseWhileObserving("kill instances") {
+			return m, nil
+		}
 		return m.handleKillInstance()
 
-	case "0":
+	case keymap.Top:
 		return m.handleGoToTop()
 
-	case "g":
+	case keymap.GroupPrefix:
 		// Enter group command mode (for gc, gn, gp, etc.)
 		if m.sidebarMode == view.SidebarModeGrouped && m.session != nil && m.session.HasGroups() {
 			m.inputRouter.SetGroupCommandPending(true)
 		}
 		return m, nil
 
-	case "G":
+	case keymap.Bottom:
 		return m.handleGoToBottom()
 
-	case "d":
+	case keymap.ToggleGraph:
 		// Toggle dependency graph view
 		m.toggleGraphView()
 		return m, nil
+
+	case keymap.SelectOutput:
+		// Select output lines to copy (visual-line style)
+		return m.handleEnterSelectMode()
 	}
 
 	return m, nil
 }
 
+// keyBindings returns the model's keymap.
+func (m Model) keyBindings() *keymap.Keymap {
+	if m.keys == nil {
+		return defaultKeys
+	}
+	return m.keys
+}
+
+// resolveKey returns the action msg is bound to in ctx, or no action while
+// msg is part of a chord still being typed.
+func (m *Model) resolveKey(ctx keymap.Context, msg tea.KeyMsg) keymap.Action {
+	action, pending := m.keyBindings().Resolve(m.keyChord, ctx, msg.String())
+	m.keyChord = pending
+	return action
+}
+
+// resolveOverlayKey is resolveKey for a context tried before another's, as
+// the ultraplan keys are before normal mode's. A key ctx doesn't bind leaves
+// a chord pending in the other context as it was. pending reports whether
+// msg started or continued a chord of ctx, which the caller swallows.
+func (m *Model) resolveOverlayKey(ctx keymap.Context, msg tea.KeyMsg) (action keymap.Action, pending bool) {
+	action, chord := m.keyBindings().Resolve(m.keyChord, ctx, msg.String())
+	if action != "" || len(chord.Keys) > 0 || m.keyChord.Context == ctx {
+		m.keyChord = chord
+	}
+	return action, len(chord.Keys) > 0
+}
+
 // -----------------------------------------------------------------------------
 // Normal Mode Key Handlers
 // -----------------------------------------------------------------------------
@@ -639,6 +753,8 @@ func (m Model) handleEnterInputMode() (tea.Model, tea.Cmd) {
 		mgr := m.orchestrator.GetInstanceManager(inst.ID)
 		if mgr != nil && mgr.TmuxSessionExists() {
 			m.inputMode = true
+			m.inputGuard.Reset()
+			m.inputAudit.Reset()
 		}
 	}
 	return m, nil
@@ -1177,9 +1293,19 @@ func (m Model) handleTemplateDropdown(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		TaskInputCursor:  m.taskInputCursor,
 	}
 
+	// Remember which objective template an ultraplan objective was built
+	// from, so its consolidation mode can be applied on submission
+	if msg.Type == tea.KeyEnter || msg.Type == tea.KeyTab {
+		if session := m.awaitingUltraPlanObjective(); session != nil {
+			if templates := m.availableTemplates(m.templateFilter); m.templateSelected < len(templates) {
+				session.ObjectiveTemplate = templates[m.templateSelected].Command
+			}
+		}
+	}
+
 	// Create handler with filter function adapter
 	filterFunc := func(filter string) []view.Template {
-		templates := FilterTemplates(filter)
+		templates := m.availableTemplates(filter)
 		result := make([]view.Template, len(templates))
 		for i, t := range templates {
 			result[i] = view.Template{
@@ -1223,6 +1349,20 @@ func (m Model) CurrentInputMode() input.Mode {
 	switch {
 	case m.filterMode:
 		return input.ModeFilter
+	case m.selection != nil:
+		return input.ModeSelect
+	case m.replay != nil:
+		return input.ModeReplay
+	case m.dashboard != nil:
+		return input.ModeDashboard
+	case m.split != nil:
+		return input.ModeSplit
+	case m.grep != nil:
+		return input.ModeGrep
+	case m.conflicts != nil:
+		return input.ModeConflicts
+	case m.deps != nil:
+		return input.ModeDeps
 	case m.inputMode:
 		return input.ModeInput
 	case m.addingTask:
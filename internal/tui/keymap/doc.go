@@ -0,0 +1,49 @@
+// Package keymap maps the TUI's keys to actions, so users can rebind them.
+//
+// Each view that takes keys is a [Context] with its own bindings: normal
+// mode, output selection, the dashboard, the split view, :grep results,
+// the consolidation conflict view, the task dependency graph, and the
+// ultraplan keys with their group decisions and group navigation. Handlers
+// ask the keymap which [Action] a key press resolves to instead of matching
+// key strings themselves, and the help overlay lists the bindings as they
+// are.
+//
+// The ultraplan keys are tried before normal mode's in an ultraplan
+// session, so a key both bind must do the same action in each; [New]
+// rejects bindings where an ultraplan key would hide a normal mode one.
+//
+// A key is written as Bubble Tea names it ("j", "G", "ctrl+d", "shift+tab",
+// "esc") or "space". Keys separated by spaces form a chord, pressed one
+// after another: "g g" is g pressed twice. A key that starts a chord can't
+// also be bound on its own in the same context.
+//
+// Users override bindings per context and action in config:
+//
+//	tui:
+//	  keys:
+//	    normal:
+//	      top: ["g g", "0"]
+//	      group_prefix: [";"]
+//	    ultraplan:
+//	      group_prefix: [";"]
+//	    split:
+//	      search: ["ctrl+f"]
+//
+// Overriding an action replaces its default keys; actions not mentioned keep
+// theirs.
+//
+// # Main Types
+//
+//   - [Keymap]: The bindings of every context
+//   - [Pending]: A chord partly typed
+//
+// # Usage
+//
+//	km, err := keymap.New(cfg.TUI.Keys)
+//
+//	action, pending := km.Resolve(pending, keymap.Normal, msg.String())
+//	switch action {
+//	case keymap.ScrollDown:
+//		...
+//	}
+package keymap
@@ -0,0 +1,497 @@
+package keymap
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Context is a view with its own key bindings.
+type Context string
+
+// Contexts with bindings, in help order.
+const (
+	Normal    Context = "normal"
+	Select    Context = "select"
+	Dashboard Context = "dashboard"
+	Split     Context = "split"
+	Grep      Context = "grep"
+	Conflicts Context = "conflicts"
+	Deps      Context = "deps"
+
+	// UltraPlan keys are tried before normal mode's in an ultraplan
+	// session, and UltraPlanGate keys before both while the plan waits on
+	// a group decision or approval. UltraPlanGroups replaces both while
+	// navigating the plan's groups.
+	UltraPlan       Context = "ultraplan"
+	UltraPlanGate   Context = "ultraplan_gate"
+	UltraPlanGroups Context = "ultraplan_groups"
+)
+
+// Contexts lists every context, in help order.
+var Contexts = []Context{Normal, Select, Dashboard, Split, Grep, Conflicts, Deps, UltraPlan, UltraPlanGate, UltraPlanGroups}
+
+// overlays maps a context to the contexts whose keys are tried before its
+// own. A key bound in an overlay hides the base context's binding, so the
+// two must agree on it.
+var overlays = map[Context][]Context{
+	Normal: {UltraPlan, UltraPlanGate},
+}
+
+// Action is what a key does. The same action may be bound in several
+// contexts, each handling it in its own way.
+type Action string
+
+// Actions, named as in config.
+const (
+	Command          Action = "command"
+	Help             Action = "help"
+	NextInstance     Action = "next_instance"
+	PrevInstance     Action = "prev_instance"
+	EnterInput       Action = "enter_input"
+	EnterInputAlt    Action = "enter_input_alt"
+	Close            Action = "close"
+	Open             Action = "open"
+	ScrollDown       Action = "scroll_down"
+	ScrollUp         Action = "scroll_up"
+	SidebarDown      Action = "sidebar_down"
+	SidebarUp        Action = "sidebar_up"
+	HalfPageDown     Action = "half_page_down"
+	HalfPageUp       Action = "half_page_up"
+	PageDown         Action = "page_down"
+	PageUp           Action = "page_up"
+	Top              Action = "top"
+	Bottom           Action = "bottom"
+	GroupPrefix      Action = "group_prefix"
+	ToggleGraph      Action = "toggle_graph"
+	SelectOutput     Action = "select"
+	Kill             Action = "kill"
+	Copy             Action = "copy"
+	SwapEnds         Action = "swap_ends"
+	RestartSelection Action = "restart_selection"
+	Left             Action = "left"
+	Right            Action = "right"
+	FocusOther       Action = "focus_other"
+	Search           Action = "search"
+	NextMatch        Action = "next_match"
+	PrevMatch        Action = "prev_match"
+	Restart          Action = "restart"
+	ToggleLogs       Action = "toggle_logs"
+	TakeOurs         Action = "take_ours"
+	TakeTheirs       Action = "take_theirs"
+	MarkResolved     Action = "mark_resolved"
+	OpenEditor       Action = "open_editor"
+	StartResolver    Action = "start_resolver"
+	Resume           Action = "resume"
+	TogglePlan       Action = "toggle_plan"
+	ParsePlan        Action = "parse_plan"
+	Execute          Action = "execute"
+	EditPlan         Action = "edit_plan"
+	OpenPR           Action = "open_pr"
+	ShowDeps         Action = "show_deps"
+	ReviewConflicts  Action = "review_conflicts"
+	Consolidate      Action = "consolidate"
+	Retrigger        Action = "retrigger"
+	Approve          Action = "approve"
+	Reject           Action = "reject"
+	ContinuePartial  Action = "continue_partial"
+	RetryFailed      Action = "retry_failed"
+	ApproveGroup     Action = "approve_group"
+	Cancel           Action = "cancel"
+	ToggleGroup      Action = "toggle_group"
+	ExpandAll        Action = "expand_all"
+	CollapseAll      Action = "collapse_all"
+)
+
+// Binding is the keys bound to an action in a context.
+type Binding struct {
+	Action      Action
+	Keys        []string
+	Description string
+}
+
+// defaults holds each context's default bindings, in help order.
+var defaults = map[Context][]Binding{
+	Normal: {
+		{NextInstance, []string{"tab", "l"}, "Next instance"},
+		{PrevInstance, []string{"shift+tab", "h"}, "Previous instance"},
+		{ScrollDown, []string{"j", "down"}, "Scroll output down"},
+		{ScrollUp, []string{"k", "up"}, "Scroll output up"},
+		{SidebarDown, []string{"J"}, "Scroll sidebar down (view only)"},
+		{SidebarUp, []string{"K"}, "Scroll sidebar up (view only)"},
+		{HalfPageDown, []string{"ctrl+d"}, "Scroll half a page down"},
+		{HalfPageUp, []string{"ctrl+u"}, "Scroll half a page up"},
+		{PageDown, []string{"ctrl+f"}, "Scroll a page down"},
+		{PageUp, []string{"ctrl+b"}, "Scroll a page up"},
+		{Top, []string{"0"}, "Jump to top"},
+		{Bottom, []string{"G"}, "Jump to bottom"},
+		{Command, []string{":"}, "Enter command mode"},
+		{Help, []string{"?"}, "Toggle help"},
+		{EnterInput, []string{"enter", "i"}, "Enter input mode (talk to AI backend)"},
+		{EnterInputAlt, []string{"alt+i"}, "Enter input mode, even with tui.require_input_modifier"},
+		{SelectOutput, []string{"v"}, "Start selecting output lines"},
+		{ToggleGraph, []string{"d"}, "Toggle dependency graph sidebar"},
+		{GroupPrefix, []string{"g"}, "Start a group command"},
+		{Restart, []string{"ctrl+r"}, "Restart instance"},
+		{Kill, []string{"ctrl+k"}, "Kill instance"},
+		{Close, []string{"esc"}, "Close the diff panel"},
+		{ToggleLogs, []string{"L"}, "Toggle the session log pane"},
+	},
+	Select: {
+		{ScrollDown, []string{"j", "down"}, "Extend selection down"},
+		{ScrollUp, []string{"k", "up"}, "Extend selection up"},
+		{HalfPageDown, []string{"ctrl+d"}, "Extend selection half a page down"},
+		{HalfPageUp, []string{"ctrl+u"}, "Extend selection half a page up"},
+		{PageDown, []string{"ctrl+f"}, "Extend selection a page down"},
+		{PageUp, []string{"ctrl+b"}, "Extend selection a page up"},
+		{Top, []string{"g", "0"}, "Extend selection to the top"},
+		{Bottom, []string{"G"}, "Extend selection to the bottom"},
+		{SwapEnds, []string{"o"}, "Jump to the other end"},
+		{RestartSelection, []string{"V"}, "Restart the selection at the cursor"},
+		{Copy, []string{"y", "enter"}, "Copy selection to clipboard (OSC 52 / pbcopy / xclip)"},
+		{Close, []string{"esc", "q", "v", "ctrl+c"}, "Cancel selection"},
+	},
+	Dashboard: {
+		{Left, []string{"h", "left", "shift+tab"}, "Previous tile"},
+		{Right, []string{"l", "right", "tab"}, "Next tile"},
+		{ScrollUp, []string{"k", "up"}, "Tile above"},
+		{ScrollDown, []string{"j", "down"}, "Tile below"},
+		{Top, []string{"g"}, "First tile"},
+		{Bottom, []string{"G"}, "Last tile"},
+		{Open, []string{"enter"}, "Open the selected instance"},
+		{Close, []string{"esc", "q", "ctrl+c"}, "Close dashboard"},
+		{ToggleLogs, []string{"L"}, "Toggle the session log pane"},
+	},
+	Split: {
+		{FocusOther, []string{"tab", "shift+tab"}, "Switch pane"},
+		{Left, []string{"h", "left"}, "Focus the left pane"},
+		{Right, []string{"l", "right"}, "Focus the right pane"},
+		{ScrollDown, []string{"j", "down"}, "Scroll the focused pane down"},
+		{ScrollUp, []string{"k", "up"}, "Scroll the focused pane up"},
+		{HalfPageDown, []string{"ctrl+d", "pgdown"}, "Scroll the focused pane half a page down"},
+		{HalfPageUp, []string{"ctrl+u", "pgup"}, "Scroll the focused pane half a page up"},
+		{Top, []string{"g"}, "Top of the focused pane"},
+		{Bottom, []string{"G"}, "Bottom of the focused pane (follows new output)"},
+		{Search, []string{"/"}, "Search both panes"},
+		{NextMatch, []string{"n"}, "Next match in both panes"},
+		{PrevMatch, []string{"N"}, "Previous match in both panes"},
+		{Open, []string{"enter"}, "Open the focused instance"},
+		{Close, []string{"esc", "q", "ctrl+c"}, "Close split view"},
+		{ToggleLogs, []string{"L"}, "Toggle the session log pane"},
+	},
+	Grep: {
+		{ScrollDown, []string{"j", "down"}, "Next result"},
+		{ScrollUp, []string{"k", "up"}, "Previous result"},
+		{HalfPageDown, []string{"ctrl+d", "pgdown"}, "Half a page of results down"},
+		{HalfPageUp, []string{"ctrl+u", "pgup"}, "Half a page of results up"},
+		{Top, []string{"g"}, "First result"},
+		{Bottom, []string{"G"}, "Last result"},
+		{Open, []string{"enter"}, "Jump to the result"},
+		{Close, []string{"esc", "q", "ctrl+c"}, "Close search results"},
+		{ToggleLogs, []string{"L"}, "Toggle the session log pane"},
+	},
+	Conflicts: {
+		{ScrollDown, []string{"j", "down"}, "Next file"},
+		{ScrollUp, []string{"k", "up"}, "Previous file"},
+		{HalfPageDown, []string{"ctrl+d", "pgdown"}, "Scroll the hunks down"},
+		{HalfPageUp, []string{"ctrl+u", "pgup"}, "Scroll the hunks up"},
+		{TakeOurs, []string{"o"}, "Keep ours (the consolidated work) for the file"},
+		{TakeTheirs, []string{"t"}, "Keep theirs (the task's commit) for the file"},
+		{OpenEditor, []string{"e"}, "Edit the file in $EDITOR"},
+		{MarkResolved, []string{"a"}, "Mark the edited file resolved"},
+		{StartResolver, []string{"c"}, "Start an instance to resolve the conflicts"},
+		{Resume, []string{"r"}, "Resume consolidation"},
+		{Close, []string{"esc", "q", "ctrl+c"}, "Close the conflict view"},
+		{ToggleLogs, []string{"L"}, "Toggle the session log pane"},
+	},
+	Deps: {
+		{ScrollDown, []string{"j", "down"}, "Next task"},
+		{ScrollUp, []string{"k", "up"}, "Previous task"},
+		{Top, []string{"g", "home"}, "First task"},
+		{Bottom, []string{"G", "end"}, "Last task"},
+		{Open, []string{"enter"}, "Jump to the task's instance"},
+		{Close, []string{"esc", "q", "ctrl+c"}, "Close the dependency graph"},
+		{ToggleLogs, []string{"L"}, "Toggle the session log pane"},
+	},
+	UltraPlan: {
+		{NextInstance, []string{"tab", "l"}, "Next instance, across phases"},
+		{PrevInstance, []string{"shift+tab", "h"}, "Previous instance, across phases"},
+		{TogglePlan, []string{"P"}, "Toggle the plan view"},
+		{ParsePlan, []string{"p"}, "Parse the plan from the planner's output (planning)"},
+		{Execute, []string{"e"}, "Start execution once the plan is ready"},
+		{EditPlan, []string{"E"}, "Edit the plan before execution"},
+		{GroupPrefix, []string{"g"}, "Navigate the plan's groups"},
+		{ShowDeps, []string{"D"}, "Show the task dependency graph"},
+		{Approve, []string{"y"}, "Approve a task proposed by an instance"},
+		{Reject, []string{"n"}, "Reject a task proposed by an instance"},
+		{Consolidate, []string{"s"}, "Finish synthesis and start consolidation"},
+		{ReviewConflicts, []string{"x"}, "Review the conflicts of a paused consolidation"},
+		{Resume, []string{"r"}, "Resume a paused consolidation"},
+		{OpenPR, []string{"o"}, "Open the first pull request in a browser"},
+		{Retrigger, []string{"R"}, "Re-run from a group, chosen by its number"},
+		{Close, []string{"esc"}, "Cancel choosing a group to re-run"},
+	},
+	UltraPlanGate: {
+		{ContinuePartial, []string{"c"}, "Continue with the group's successful tasks"},
+		{RetryFailed, []string{"r"}, "Retry the group's failed tasks"},
+		{ApproveGroup, []string{"a"}, "Start the next group"},
+		{Cancel, []string{"q"}, "Cancel the ultraplan"},
+	},
+	UltraPlanGroups: {
+		{ScrollUp, []string{"k", "up"}, "Previous group"},
+		{ScrollDown, []string{"j", "down"}, "Next group"},
+		{ToggleGroup, []string{"enter", "space"}, "Collapse or expand the group"},
+		{Right, []string{"l", "right"}, "Expand the group"},
+		{Left, []string{"h", "left"}, "Collapse the group"},
+		{ExpandAll, []string{"e"}, "Expand all groups"},
+		{CollapseAll, []string{"c"}, "Collapse all groups"},
+		{Close, []string{"g", "esc"}, "Stop navigating groups"},
+	},
+}
+
+// Keymap holds the bindings of every context. It is immutable once built,
+// so a Model can share it between copies.
+type Keymap struct {
+	bindings map[Context][]Binding
+	actions  map[Context]map[string]Action // Key sequence to action
+	prefixes map[Context]map[string]bool   // Key sequences that start a chord
+}
+
+// Default returns the keymap with the default bindings.
+func Default() *Keymap {
+	km, err := New(nil)
+	if err != nil {
+		panic("keymap: invalid default bindings: " + err.Error())
+	}
+	return km
+}
+
+// New returns the default keymap with overrides applied. overrides maps a
+// context to actions and the keys that replace their default keys, as in
+// the tui.keys config. An empty key list unbinds an action. It returns an
+// error for an unknown context or action, keys bound twice in a context, or
+// a key an overlay binds to another action than its base context does.
+func New(overrides map[string]map[string][]string) (*Keymap, error) {
+	km := &Keymap{
+		bindings: make(map[Context][]Binding, len(defaults)),
+		actions:  make(map[Context]map[string]Action, len(defaults)),
+		prefixes: make(map[Context]map[string]bool, len(defaults)),
+	}
+	for ctx, bindings := range defaults {
+		km.bindings[ctx] = slices.Clone(bindings)
+	}
+
+	for name, actions := range overrides {
+		ctx := Context(name)
+		bindings, ok := km.bindings[ctx]
+		if !ok {
+			return nil, fmt.Errorf("unknown key context %q", name)
+		}
+		for action, keys := range actions {
+			i := slices.IndexFunc(bindings, func(b Binding) bool { return b.Action == Action(action) })
+			if i < 0 {
+				return nil, fmt.Errorf("unknown action %q in %s keys", action, name)
+			}
+			bindings[i].Keys = slices.Clone(keys)
+		}
+	}
+
+	for _, ctx := range Contexts {
+		if err := km.index(ctx); err != nil {
+			return nil, err
+		}
+	}
+	for _, base := range Contexts {
+		for _, overlay := range overlays[base] {
+			if err := km.checkOverlay(base, overlay); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return km, nil
+}
+
+// checkOverlay rejects keys of overlay that would hide a different action
+// of base: the same key bound to another action, or a key that is bound in
+// one context and starts a chord in the other.
+func (k *Keymap) checkOverlay(base, overlay Context) error {
+	for _, seq := range slices.Sorted(maps.Keys(k.actions[overlay])) {
+		action := k.actions[overlay][seq]
+		key := strings.ReplaceAll(seq, sep, " ")
+		if other, ok := k.actions[base][seq]; ok && other != action {
+			return fmt.Errorf("%s keys: %q is bound to %s, which hides %s in %s keys", overlay, key, action, other, base)
+		}
+		if k.prefixes[base][seq] {
+			return fmt.Errorf("%s keys: %q is bound to %s, which hides chords starting with it in %s keys", overlay, key, action, base)
+		}
+	}
+	for _, seq := range slices.Sorted(maps.Keys(k.prefixes[overlay])) {
+		if other, ok := k.actions[base][seq]; ok {
+			return fmt.Errorf("%s keys: a chord starting with %q hides %s in %s keys", overlay, strings.ReplaceAll(seq, sep, " "), other, base)
+		}
+	}
+	return nil
+}
+
+// index builds the lookup tables of ctx, rejecting conflicting keys.
+func (k *Keymap) index(ctx Context) error {
+	actions := make(map[string]Action)
+	bound := make(map[string]string) // Sequence to the key as written
+	for _, b := range k.bindings[ctx] {
+		for _, key := range b.Keys {
+			seq, err := parseKey(key)
+			if err != nil {
+				return fmt.Errorf("%s keys, %s: %w", ctx, b.Action, err)
+			}
+			if other, ok := actions[seq]; ok && other != b.Action {
+				return fmt.Errorf("%s keys: %q is bound to both %s and %s", ctx, key, other, b.Action)
+			}
+			actions[seq] = b.Action
+			bound[seq] = key
+		}
+	}
+
+	prefixes := make(map[string]bool)
+	for seq := range actions {
+		parts := strings.Split(seq, sep)
+		for n := 1; n < len(parts); n++ {
+			prefixes[strings.Join(parts[:n], sep)] = true
+		}
+	}
+	for seq := range prefixes {
+		if action, ok := actions[seq]; ok {
+			return fmt.Errorf("%s keys: %q is bound to %s and also starts a chord", ctx, bound[seq], action)
+		}
+	}
+
+	k.actions[ctx] = actions
+	k.prefixes[ctx] = prefixes
+	return nil
+}
+
+// sep joins the keys of a chord in lookup tables; it can't be part of a key.
+const sep = "\x00"
+
+// parseKey returns the lookup form of a key as written in config: the keys
+// of a chord joined by sep, with "space" as Bubble Tea reports it.
+func parseKey(key string) (string, error) {
+	parts := strings.Fields(key)
+	if len(parts) == 0 {
+		return "", fmt.Errorf("empty key")
+	}
+	for i, part := range parts {
+		if part == "space" {
+			parts[i] = " "
+		}
+	}
+	return strings.Join(parts, sep), nil
+}
+
+// Bindings returns the bindings of ctx, in help order.
+func (k *Keymap) Bindings(ctx Context) []Binding {
+	return slices.Clone(k.bindings[ctx])
+}
+
+// Keys returns the keys bound to action in ctx, as written in config.
+func (k *Keymap) Keys(ctx Context, action Action) []string {
+	for _, b := range k.bindings[ctx] {
+		if b.Action == action {
+			return slices.Clone(b.Keys)
+		}
+	}
+	return nil
+}
+
+// Pending is a chord partly typed in a context. The zero value has no keys
+// pending.
+type Pending struct {
+	Context Context
+	Keys    []string
+}
+
+// Resolve returns the action key resolves to in ctx, pressed after the keys
+// of p. When key continues a chord that isn't complete, the action is empty
+// and the returned Pending holds the keys so far. A key that breaks off a
+// chord is resolved on its own.
+func (k *Keymap) Resolve(p Pending, ctx Context, key string) (Action, Pending) {
+	if p.Context == ctx && len(p.Keys) > 0 {
+		keys := append(slices.Clone(p.Keys), key)
+		seq := strings.Join(keys, sep)
+		if action, ok := k.actions[ctx][seq]; ok {
+			return action, Pending{}
+		}
+		if k.prefixes[ctx][seq] {
+			return "", Pending{Context: ctx, Keys: keys}
+		}
+	}
+	if action, ok := k.actions[ctx][key]; ok {
+		return action, Pending{}
+	}
+	if k.prefixes[ctx][key] {
+		return "", Pending{Context: ctx, Keys: []string{key}}
+	}
+	return "", Pending{}
+}
+
+// keyLabels are the help labels of keys not shown as written.
+var keyLabels = map[string]string{
+	" ":      "Space",
+	"space":  "Space",
+	"up":     "↑",
+	"down":   "↓",
+	"left":   "←",
+	"right":  "→",
+	"enter":  "Enter",
+	"esc":    "Esc",
+	"tab":    "Tab",
+	"pgup":   "PgUp",
+	"pgdown": "PgDn",
+}
+
+// Label returns how key is shown in help: "ctrl+d" as "Ctrl+D", "down" as
+// "↓", and a chord of single characters run together, as "gg".
+func Label(key string) string {
+	parts := strings.Fields(key)
+	runTogether := true
+	for i, part := range parts {
+		runTogether = runTogether && utf8.RuneCountInString(part) == 1
+		parts[i] = labelPart(part)
+	}
+	if runTogether {
+		return strings.Join(parts, "")
+	}
+	return strings.Join(parts, " ")
+}
+
+// labelPart returns the label of one key of a chord.
+func labelPart(key string) string {
+	if label, ok := keyLabels[key]; ok {
+		return label
+	}
+	mods := strings.Split(key, "+")
+	if len(mods) == 1 {
+		return key
+	}
+	for i, mod := range mods[:len(mods)-1] {
+		mods[i] = strings.ToUpper(mod[:1]) + mod[1:]
+	}
+	last := mods[len(mods)-1]
+	if label, ok := keyLabels[last]; ok {
+		last = label
+	} else if mods[0] == "Ctrl" {
+		last = strings.ToUpper(last)
+	}
+	mods[len(mods)-1] = last
+	return strings.Join(mods, "+")
+}
+
+// Labels returns the labels of keys joined for help, as "j/↓".
+func Labels(keys []string) string {
+	labels := make([]string, len(keys))
+	for i, key := range keys {
+		labels[i] = Label(key)
+	}
+	return strings.Join(labels, "/")
+}
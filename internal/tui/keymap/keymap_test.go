@@ -0,0 +1,142 @@
+package keymap
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefault(t *testing.T) {
+	km := Default()
+	tests := []struct {
+		ctx  Context
+		key  string
+		want Action
+	}{
+		{Normal, "j", ScrollDown},
+		{Normal, "down", ScrollDown},
+		{Normal, "0", Top},
+		{Normal, "g", GroupPrefix},
+		{Select, "g", Top},
+		{Split, "/", Search},
+		{Split, "N", PrevMatch},
+		{Dashboard, "tab", Right},
+		{Normal, "x", ""},
+		{UltraPlan, "P", TogglePlan},
+		{UltraPlanGate, "r", RetryFailed},
+		{UltraPlanGroups, " ", ToggleGroup},
+	}
+	for _, tt := range tests {
+		if got, _ := km.Resolve(Pending{}, tt.ctx, tt.key); got != tt.want {
+			t.Errorf("Resolve(%s, %q) = %q, want %q", tt.ctx, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestNew_Overrides(t *testing.T) {
+	km, err := New(map[string]map[string][]string{
+		"normal": {"scroll_down": {"ctrl+n"}, "toggle_graph": {}},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got, _ := km.Resolve(Pending{}, Normal, "ctrl+n"); got != ScrollDown {
+		t.Errorf("ctrl+n = %q, want scroll_down", got)
+	}
+	// The override replaces the default keys
+	if got, _ := km.Resolve(Pending{}, Normal, "j"); got != "" {
+		t.Errorf("j = %q, want unbound", got)
+	}
+	if got, _ := km.Resolve(Pending{}, Normal, "d"); got != "" {
+		t.Errorf("d = %q, want unbound after an empty override", got)
+	}
+	// Other contexts keep their keys
+	if got, _ := km.Resolve(Pending{}, Select, "j"); got != ScrollDown {
+		t.Errorf("select j = %q, want scroll_down", got)
+	}
+	// The defaults are not changed
+	if got, _ := Default().Resolve(Pending{}, Normal, "j"); got != ScrollDown {
+		t.Errorf("default j = %q after an override, want scroll_down", got)
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]map[string][]string
+		want      string
+	}{
+		{"unknown context", map[string]map[string][]string{"insert": {"top": {"g"}}}, "unknown key context"},
+		{"unknown action", map[string]map[string][]string{"normal": {"fly": {"f"}}}, "unknown action"},
+		{"empty key", map[string]map[string][]string{"normal": {"top": {" "}}}, "empty key"},
+		{"bound twice", map[string]map[string][]string{"normal": {"top": {"j"}}}, "bound to both"},
+		{"chord prefix bound", map[string]map[string][]string{"normal": {"top": {"g g"}}}, "starts a chord"},
+		{"hidden by ultraplan", map[string]map[string][]string{"normal": {"restart": {"p"}}}, "hides restart in normal keys"},
+		{"hidden by ultraplan gate", map[string]map[string][]string{"ultraplan_gate": {"cancel": {"v"}}}, "hides select in normal keys"},
+		{"ultraplan hides a chord", map[string]map[string][]string{"normal": {"top": {"g g"}, "group_prefix": {";"}}}, "hides chords starting with it"},
+		{"ultraplan chord hides a key", map[string]map[string][]string{"ultraplan": {"show_deps": {"d d"}}}, "hides toggle_graph"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.overrides)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("New() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolve_Chords(t *testing.T) {
+	km, err := New(map[string]map[string][]string{
+		"normal":    {"top": {"g g"}, "group_prefix": {";"}, "toggle_graph": {"space d"}},
+		"ultraplan": {"group_prefix": {";"}},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	action, p := km.Resolve(Pending{}, Normal, "g")
+	if action != "" || len(p.Keys) != 1 {
+		t.Fatalf("g = %q, %+v; want a pending chord", action, p)
+	}
+	if action, p = km.Resolve(p, Normal, "g"); action != Top || len(p.Keys) != 0 {
+		t.Errorf("g g = %q, %+v; want top", action, p)
+	}
+
+	// "space" in config matches the space key
+	_, p = km.Resolve(Pending{}, Normal, " ")
+	if action, _ = km.Resolve(p, Normal, "d"); action != ToggleGraph {
+		t.Errorf("space d = %q, want toggle_graph", action)
+	}
+
+	// A key that breaks off a chord is resolved on its own
+	_, p = km.Resolve(Pending{}, Normal, "g")
+	if action, p = km.Resolve(p, Normal, "j"); action != ScrollDown || len(p.Keys) != 0 {
+		t.Errorf("g j = %q, %+v; want scroll_down", action, p)
+	}
+
+	// A chord pending in another context is ignored
+	_, p = km.Resolve(Pending{}, Normal, "g")
+	if action, _ = km.Resolve(p, Select, "g"); action != Top {
+		t.Errorf("select g after a normal g = %q, want top", action)
+	}
+}
+
+func TestLabel(t *testing.T) {
+	tests := map[string]string{
+		"j":         "j",
+		"down":      "↓",
+		"ctrl+d":    "Ctrl+D",
+		"shift+tab": "Shift+Tab",
+		"alt+i":     "Alt+i",
+		"g g":       "gg",
+		"space d":   "Space d",
+	}
+	for key, want := range tests {
+		if got := Label(key); got != want {
+			t.Errorf("Label(%q) = %q, want %q", key, got, want)
+		}
+	}
+	if got := Labels([]string{"j", "down"}); got != "j/↓" {
+		t.Errorf("Labels = %q, want j/↓", got)
+	}
+}
@@ -1,12 +1,19 @@
 package tui
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/tui/input"
+	"github.com/Iron-Ham/claudio/internal/tui/keymap"
+	"github.com/Iron-Ham/claudio/internal/tui/panel"
 	"github.com/Iron-Ham/claudio/internal/tui/view"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
 )
 
 func TestNewGroupKeyHandler(t *testing.T) {
@@ -1021,3 +1028,164 @@ func createTestSessionWithSubgroups() *orchestrator.Session {
 		},
 	}
 }
+
+func TestHandleInputMode_ConfirmsDestructiveKeys(t *testing.T) {
+	viper.Set("tui.confirm_destructive_input", true)
+	defer viper.Set("tui.confirm_destructive_input", false)
+
+	m := testModel()
+	m.inputMode = true
+
+	result, _ := m.handleKeypress(tea.KeyMsg{Type: tea.KeyCtrlC})
+	m = result.(Model)
+	if !m.inputMode {
+		t.Fatal("Ctrl+C left input mode")
+	}
+	if m.infoMessage == "" {
+		t.Error("expected a confirmation prompt after the first Ctrl+C")
+	}
+
+	// Ctrl+] exits and disarms the pending confirmation
+	result, _ = m.handleKeypress(tea.KeyMsg{Type: tea.KeyCtrlCloseBracket})
+	m = result.(Model)
+	if m.inputMode {
+		t.Error("Ctrl+] did not exit input mode")
+	}
+	if m.inputGuard != (input.KeyGuard{}) {
+		t.Error("exiting input mode did not reset the key guard")
+	}
+}
+
+func TestHandleNormalModeKey_RequireInputModifier(t *testing.T) {
+	viper.Set("tui.require_input_modifier", true)
+	defer viper.Set("tui.require_input_modifier", false)
+
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("i")},
+		{Type: tea.KeyEnter},
+	} {
+		m := testModel()
+		result, _ := m.handleKeypress(key)
+		if got := result.(Model).infoMessage; !strings.Contains(got, "Alt+i") {
+			t.Errorf("%s: infoMessage = %q, want Alt+i hint", key, got)
+		}
+	}
+}
+
+func TestHandleNormalModeKey_UserKeymap(t *testing.T) {
+	keys, err := keymap.New(map[string]map[string][]string{
+		"normal":    {"top": {"g g"}, "group_prefix": {";"}, "scroll_up": {"ctrl+p"}},
+		"ultraplan": {"group_prefix": {";"}},
+	})
+	if err != nil {
+		t.Fatalf("keymap.New() error = %v", err)
+	}
+	m := newDashboardTestModel(1)
+	m.keys = keys
+	var out strings.Builder
+	for i := range 200 {
+		fmt.Fprintf(&out, "line %d\n", i)
+	}
+	m.outputManager.SetOutput("a", out.String())
+	m.scrollOutputToBottom("a")
+	bottom := m.outputManager.GetScrollOffset("a")
+
+	press := func(m Model, msg tea.KeyMsg) Model {
+		result, _ := m.handleNormalModeKey(msg)
+		return result.(Model)
+	}
+
+	m = press(m, tea.KeyMsg{Type: tea.KeyCtrlP})
+	if got := m.outputManager.GetScrollOffset("a"); got != bottom-1 {
+		t.Errorf("offset after ctrl+p = %d, want %d", got, bottom-1)
+	}
+	// The default key no longer scrolls
+	m = press(m, runeKey("k"))
+	if got := m.outputManager.GetScrollOffset("a"); got != bottom-1 {
+		t.Errorf("offset after k = %d, want %d", got, bottom-1)
+	}
+
+	m = press(m, runeKey("g"))
+	if got := m.outputManager.GetScrollOffset("a"); got != bottom-1 || len(m.keyChord.Keys) != 1 {
+		t.Errorf("after g: offset = %d, chord = %v; want the chord pending", got, m.keyChord.Keys)
+	}
+	m = press(m, runeKey("g"))
+	if got := m.outputManager.GetScrollOffset("a"); got != 0 {
+		t.Errorf("offset after g g = %d, want 0", got)
+	}
+
+	// The help overlay shows the keys as bound
+	sections := panel.HelpSections(m.keyBindings())
+	if nav := sections[0]; !slices.Contains(nav.Items, panel.HelpItem{Key: "gg", Description: "Jump to top"}) {
+		t.Errorf("navigation help = %+v, want top on gg", nav.Items)
+	}
+	if title := sections[2].Title; title != "Group Commands (; prefix)" {
+		t.Errorf("group commands title = %q, want the ; prefix", title)
+	}
+}
+
+func TestHandleUltraPlanKeypress_UserKeymap(t *testing.T) {
+	keys, err := keymap.New(map[string]map[string][]string{
+		"normal":           {"top": {"z z"}},
+		"ultraplan":        {"toggle_plan": {"ctrl+p"}},
+		"ultraplan_groups": {"close": {"q"}},
+	})
+	if err != nil {
+		t.Fatalf("keymap.New() error = %v", err)
+	}
+	session := orchestrator.NewUltraPlanSession("objective", orchestrator.DefaultUltraPlanConfig())
+	session.Phase = orchestrator.PhaseExecuting
+	session.Plan = &orchestrator.PlanSpec{
+		Tasks:          []orchestrator.PlannedTask{{ID: "task-1", Title: "Task"}},
+		ExecutionOrder: [][]string{{"task-1"}},
+	}
+	m := newDashboardTestModel(1)
+	m.keys = keys
+	m.ultraPlan = &view.UltraPlanState{Coordinator: orchestrator.NewCoordinatorForTesting(session), SelectedGroupIdx: -1}
+	var out strings.Builder
+	for i := range 200 {
+		fmt.Fprintf(&out, "line %d\n", i)
+	}
+	m.outputManager.SetOutput("a", out.String())
+	m.scrollOutputToBottom("a")
+
+	press := func(m Model, msg tea.KeyMsg) Model {
+		result, _ := m.handleNormalMode(msg)
+		return result.(Model)
+	}
+
+	m = press(m, tea.KeyMsg{Type: tea.KeyCtrlP})
+	if !m.ultraPlan.ShowPlanView {
+		t.Error("ctrl+p should toggle the plan view")
+	}
+	m = press(m, runeKey("P"))
+	if !m.ultraPlan.ShowPlanView {
+		t.Error("P should no longer toggle the plan view")
+	}
+
+	// A normal mode chord isn't broken off by the ultraplan keys tried first
+	m = press(m, runeKey("z"))
+	m = press(m, runeKey("z"))
+	if got := m.outputManager.GetScrollOffset("a"); got != 0 {
+		t.Errorf("offset after z z = %d, want 0", got)
+	}
+
+	m = press(m, runeKey("g"))
+	if !m.ultraPlan.GroupNavMode {
+		t.Fatal("g should start group navigation")
+	}
+	if !strings.Contains(m.infoMessage, "q exit") {
+		t.Errorf("infoMessage = %q, want the rebound exit key", m.infoMessage)
+	}
+	m = press(m, runeKey("q"))
+	if m.ultraPlan.GroupNavMode {
+		t.Error("q should stop group navigation")
+	}
+
+	// The help overlay lists the ultraplan keys as bound
+	sections := panel.HelpSections(m.keyBindings())
+	i := slices.IndexFunc(sections, func(s panel.HelpSection) bool { return s.Title == "Ultraplan" })
+	if i < 0 || !slices.Contains(sections[i].Items, panel.HelpItem{Key: "Ctrl+P", Description: "Toggle the plan view"}) {
+		t.Errorf("help sections = %+v, want the plan view on Ctrl+P", sections)
+	}
+}
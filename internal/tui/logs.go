@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"errors"
+	"io/fs"
+	"slices"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/logging"
+	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
+	"github.com/Iron-Ham/claudio/internal/tui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// logTailInterval is the least time between reads of the session log
+	// while the log pane is open.
+	logTailInterval = 500 * time.Millisecond
+
+	// logInitialBytes is how far back from the end the log is read when the
+	// pane first opens, so a long session's log isn't parsed in full.
+	logInitialBytes = 256 * 1024
+
+	// maxLogEntries is the number of entries the pane keeps; older ones are
+	// dropped.
+	maxLogEntries = 2000
+
+	// maxLogPaneHeight is the height of the log pane on a tall terminal. It
+	// takes at most a third of the terminal.
+	maxLogPaneHeight = 12
+)
+
+// logPane tails the session's debug log for the log pane. Entries are kept
+// unfiltered so a new filter applies to what was already read.
+type logPane struct {
+	open    bool
+	path    string
+	offset  int64 // Where the next read starts; negative before the first
+	entries []logging.Entry
+	filter  logging.LogFilter
+	err     error
+
+	loading  bool
+	loadedAt time.Time
+}
+
+// toggleLogPane shows or hides the log pane, keeping what it has read and
+// its filter for the next time it opens.
+func (m *Model) toggleLogPane() {
+	if m.logs != nil && m.logs.open {
+		m.logs.open = false
+		return
+	}
+	m.openLogPane()
+}
+
+// openLogPane shows the log pane, or reports why it can't be shown.
+func (m *Model) openLogPane() bool {
+	if m.logs == nil {
+		path := ""
+		if m.orchestrator != nil {
+			path = m.orchestrator.LogPath()
+		}
+		if path == "" {
+			m.errorMessage = "No log available: session has no directory"
+			return false
+		}
+		m.logs = &logPane{path: path, offset: -logInitialBytes}
+	}
+	m.logs.open = true
+	return true
+}
+
+// setLogFilter opens the log pane showing only the entries that pass filter.
+func (m *Model) setLogFilter(filter logging.LogFilter) {
+	if m.openLogPane() {
+		m.logs.filter = filter
+	}
+}
+
+// dispatchLogTail reads what was written to the log since the last read,
+// while the log pane is open, at most once per logTailInterval.
+func (m *Model) dispatchLogTail(now time.Time) tea.Cmd {
+	l := m.logs
+	if l == nil || !l.open || l.loading || now.Sub(l.loadedAt) < logTailInterval {
+		return nil
+	}
+	l.loading = true
+	l.loadedAt = now
+	return tuimsg.TailLogAsync(l.path, l.offset)
+}
+
+// handleLogTailed appends newly read entries, dropping the oldest beyond
+// maxLogEntries. A log that was rotated starts over.
+func (m *Model) handleLogTailed(msg tuimsg.LogTailedMsg) {
+	l := m.logs
+	if l == nil || msg.Path != l.path {
+		return
+	}
+	l.loading = false
+	l.err = msg.Err
+	if msg.Err != nil {
+		return
+	}
+	if msg.Offset < l.offset {
+		l.entries = nil
+	}
+	l.offset = msg.Offset
+	l.entries = append(l.entries, msg.Entries...)
+	if over := len(l.entries) - maxLogEntries; over > 0 {
+		l.entries = slices.Clone(l.entries[over:])
+	}
+}
+
+// logPaneHeight returns the rows the log pane takes below the main area,
+// or 0 while it is closed.
+func (m Model) logPaneHeight() int {
+	if m.logs == nil || !m.logs.open {
+		return 0
+	}
+	return max(min(maxLogPaneHeight, m.height/3), 3)
+}
+
+// renderLogPane renders the log pane across the full width.
+func (m Model) renderLogPane() string {
+	l := m.logs
+	state := view.LogPaneState{
+		Entries: l.entries,
+		Filter:  l.filter,
+		Names:   make(map[string]string),
+	}
+	if m.session != nil {
+		for _, inst := range m.session.Instances {
+			state.Names[inst.ID] = inst.EffectiveName()
+		}
+	}
+	switch {
+	case errors.Is(l.err, fs.ErrNotExist):
+		state.Err = "No log file yet. Logs are written when logging.enabled is on."
+	case l.err != nil:
+		state.Err = "Cannot read the log: " + l.err.Error()
+	}
+	return view.RenderLogPane(state, m.width, m.logPaneHeight())
+}
@@ -11,9 +11,11 @@ import (
 	"github.com/Iron-Ham/claudio/internal/tui/command"
 	"github.com/Iron-Ham/claudio/internal/tui/filter"
 	"github.com/Iron-Ham/claudio/internal/tui/input"
+	"github.com/Iron-Ham/claudio/internal/tui/keymap"
 	"github.com/Iron-Ham/claudio/internal/tui/output"
 	"github.com/Iron-Ham/claudio/internal/tui/styles"
 	"github.com/Iron-Ham/claudio/internal/tui/view"
+	"github.com/Iron-Ham/claudio/internal/tui/view/dashboard"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -47,7 +49,7 @@ type PlanEditorState struct {
 	selectedTaskIdx int
 
 	// editingField indicates which field is being edited (empty if not editing)
-	// Valid values: 'title', 'description', 'files', 'depends_on', 'priority', 'complexity'
+	// Valid values: 'title', 'description', 'files', 'depends_on', 'priority', 'complexity', 'split', 'feedback'
 	editingField string
 
 	// editBuffer holds the current edit buffer content when editing a field
@@ -73,6 +75,9 @@ type PlanEditorState struct {
 
 	// pendingConfirmDelete tracks the task ID awaiting deletion confirmation for started instances
 	pendingConfirmDelete string
+
+	// marked holds the task IDs marked for merging
+	marked map[string]bool
 }
 
 // InlinePlanSession holds state for a single inline plan session.
@@ -101,6 +106,10 @@ type InlinePlanSession struct {
 	// UltraPlanConfig holds the config for ultraplan mode (when IsUltraPlan is true)
 	UltraPlanConfig *orchestrator.UltraPlanConfig
 
+	// ObjectiveTemplate is the name of the objective template picked from the
+	// "/" dropdown while entering an ultraplan objective (empty if none)
+	ObjectiveTemplate string
+
 	// AwaitingPlanCreation indicates we're waiting for the planning instance to generate a plan
 	AwaitingPlanCreation bool
 
@@ -252,6 +261,13 @@ type Model struct {
 	width  int
 	height int
 
+	// Instance pane sizing (see syncInstanceSize). paneSize is the size last
+	// applied to instances; pendingPaneSize is the output area size waiting
+	// out the debounce since pendingPaneSince.
+	paneSize         paneSize
+	pendingPaneSize  paneSize
+	pendingPaneSince time.Time
+
 	// Ultra-plan mode (nil if not in ultra-plan mode)
 	ultraPlan *UltraPlanState
 
@@ -305,10 +321,12 @@ type Model struct {
 	startingAdversarial bool // When true, taskInput will start an adversarial session
 
 	errorMessage   string
-	infoMessage    string    // Non-error status message
-	messageSetAt   time.Time // When the current message was set (for auto-dismiss)
-	lastMessageKey string    // Used to detect message changes (concatenation of both messages)
-	inputMode      bool      // When true, all keys are forwarded to the active instance's tmux session
+	infoMessage    string           // Non-error status message
+	messageSetAt   time.Time        // When the current message was set (for auto-dismiss)
+	lastMessageKey string           // Used to detect message changes (concatenation of both messages)
+	inputMode      bool             // When true, all keys are forwarded to the active instance's tmux session
+	inputGuard     input.KeyGuard   // Holds back destructive keys in input mode until pressed twice
+	inputAudit     input.Transcript // Collects typed text in input mode for the audit log
 
 	// Command mode state (vim-style ex commands with ':' prefix)
 	commandMode   bool   // When true, we're typing a command after ':'
@@ -349,11 +367,56 @@ type Model struct {
 	// Resource metrics display
 	showStats bool // When true, show the stats panel
 
+	// Files panel: which instance is touching which files
+	showFiles bool       // When true, show the files panel
+	files     *fileWatch // Claims and uncommitted files (nil until the first claim or :files)
+
+	// Log pane below the main area, tailing the session log (nil until :logs)
+	logs *logPane
+
+	// Observer mode: refresh state for a session another process runs
+	// (nil unless the session is only observed)
+	observer *observerState
+
 	// Filter state
 	filterMode   bool // Whether filter mode is active
 	outputFilter *filter.Filter
+
+	// Output selection state (non-nil while selecting lines to copy)
+	selection *outputSelection
+
+	// Transcript replay state (non-nil while replaying a recorded transcript)
+	replay *transcriptReplay
+
+	// Dashboard grid state (non-nil while the dashboard is open) and the
+	// usage samples its sparklines are drawn from
+	dashboard    *instanceDashboard
+	usageHistory *dashboard.History
+
+	// Split comparison state (non-nil while comparing two instances)
+	split *splitView
+
+	// :grep results (non-nil while the results list is open)
+	grep *grepResults
+
+	// Consolidation conflict view (non-nil while it is open)
+	conflicts *conflictView
+
+	// Task dependency graph (non-nil while it is open)
+	deps *depsView
+
+	// Bell and desktop notification state for instances waiting for input
+	// (created on first use)
+	alerts *inputAlerts
+
+	// Key bindings (nil means the defaults) and the chord being typed
+	keys     *keymap.Keymap
+	keyChord keymap.Pending
 }
 
+// defaultKeys is the keymap of models without one.
+var defaultKeys = keymap.Default()
+
 // IsUltraPlanMode returns true if the model is in ultra-plan mode
 func (m Model) IsUltraPlanMode() bool {
 	return m.ultraPlan != nil
@@ -616,16 +679,21 @@ func NewModel(orch *orchestrator.Orchestrator, session *orchestrator.Session, lo
 	outputManager := output.NewManager()
 	outputManager.SetFilterFunc(outputFilter.Apply)
 
-	return Model{
+	m := Model{
 		orchestrator:   orch,
 		session:        session,
 		logger:         tuiLogger,
 		startTime:      time.Now(),
 		commandHandler: command.New(),
 		inputRouter:    input.NewRouter(),
+		keys:           defaultKeys,
 		outputManager:  outputManager,
 		outputFilter:   outputFilter,
 	}
+	if orch != nil && orch.Observing() {
+		m.observer = &observerState{}
+	}
+	return m
 }
 
 // InputRouter returns the input router for this model.
@@ -644,6 +712,20 @@ func (m *Model) syncRouterState() {
 	switch {
 	case m.filterMode:
 		m.inputRouter.SetMode(input.ModeFilter)
+	case m.selection != nil:
+		m.inputRouter.SetMode(input.ModeSelect)
+	case m.replay != nil:
+		m.inputRouter.SetMode(input.ModeReplay)
+	case m.dashboard != nil:
+		m.inputRouter.SetMode(input.ModeDashboard)
+	case m.split != nil:
+		m.inputRouter.SetMode(input.ModeSplit)
+	case m.grep != nil:
+		m.inputRouter.SetMode(input.ModeGrep)
+	case m.conflicts != nil:
+		m.inputRouter.SetMode(input.ModeConflicts)
+	case m.deps != nil:
+		m.inputRouter.SetMode(input.ModeDeps)
 	case m.inputMode:
 		m.inputRouter.SetMode(input.ModeInput)
 	case m.addingTask:
@@ -677,6 +759,16 @@ func (m *Model) syncRouterState() {
 	m.inputRouter.Buffer = m.commandBuffer
 }
 
+// snapshotSession returns the session copy from the orchestrator's latest
+// published state snapshot, or nil when there is no orchestrator or nothing
+// has been published yet.
+func (m Model) snapshotSession() *orchestrator.Session {
+	if m.orchestrator == nil {
+		return nil
+	}
+	return m.orchestrator.Snapshot().Session
+}
+
 // activeInstance returns the currently focused instance
 func (m Model) activeInstance() *orchestrator.Instance {
 	if m.session == nil || len(m.session.Instances) == 0 {
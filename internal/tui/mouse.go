@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/tui/view"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/viper"
+)
+
+// -----------------------------------------------------------------------------
+// Mouse Support
+// -----------------------------------------------------------------------------
+
+// mouseScrollLines is how many output lines one wheel step scrolls.
+const mouseScrollLines = 3
+
+// handleMouse handles mouse events, reported when tui.mouse is enabled. The
+// wheel scrolls the sidebar or output under the pointer, clicking the
+// sidebar selects an instance, and pressing over output starts selecting
+// lines, which dragging extends, for [y] to copy.
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if !viper.GetBool("tui.mouse") || !m.ready || m.commandMode || m.addingTask {
+		return m, nil
+	}
+
+	// A drag continues wherever the pointer goes
+	if sel := m.selection; sel != nil && sel.dragging {
+		switch msg.Action {
+		case tea.MouseActionMotion:
+			row, _ := m.outputRow(msg.Y)
+			sel.moveCursor(sel.scroll+row-sel.cursor, m.getOutputMaxLines())
+		case tea.MouseActionRelease:
+			sel.dragging = false
+		}
+		return m, nil
+	}
+
+	sidebarWidth := CalculateEffectiveSidebarWidthWithConfig(m.width, config.Get().TUI.SidebarWidth)
+	inSidebar := msg.X < sidebarWidth
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp, tea.MouseButtonWheelDown:
+		up := msg.Button == tea.MouseButtonWheelUp
+		if inSidebar {
+			if up {
+				return m.handleSidebarScrollUp()
+			}
+			return m.handleSidebarScrollDown()
+		}
+		m.scrollOutputWithWheel(up)
+
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+		if inSidebar {
+			m.clickSidebar(msg.Y)
+		} else {
+			m.pressOutput(msg.Y)
+		}
+	}
+	return m, nil
+}
+
+// scrollOutputWithWheel scrolls the active instance's output, or the frozen
+// view while selecting, one wheel step.
+func (m *Model) scrollOutputWithWheel(up bool) {
+	if !m.outputShown() {
+		return
+	}
+	if sel := m.selection; sel != nil {
+		delta := mouseScrollLines
+		if up {
+			delta = -delta
+		}
+		maxScroll := max(len(sel.lines)-m.getOutputMaxLines(), 0)
+		sel.scroll = max(min(sel.scroll+delta, maxScroll), 0)
+		return
+	}
+	if id := m.activeInstance().ID; up {
+		m.scrollOutputUp(id, mouseScrollLines)
+	} else {
+		m.scrollOutputDown(id, mouseScrollLines)
+	}
+}
+
+// clickSidebar switches to the instance shown at screen row y of the
+// sidebar, if any. The triple-shot sidebar has no instance rows to click.
+func (m *Model) clickSidebar(y int) {
+	if m.IsTripleShotMode() || m.selection != nil || m.inputMode {
+		return
+	}
+	sidebarWidth := CalculateEffectiveSidebarWidthWithConfig(m.width, config.Get().TUI.SidebarWidth)
+	// The sidebar's border and padding come before its first line
+	line := y - lipgloss.Height(m.renderUnifiedHeader()) - 2
+	idx := view.NewSidebarView().InstanceAt(m, sidebarWidth, m.mainAreaHeight(m.calculateExtraFooterLines()), line)
+	if idx >= 0 {
+		m.switchToInstance(idx)
+	}
+}
+
+// pressOutput starts selecting at the output line at screen row y, as
+// select mode does from the keyboard, and starts a drag that extends the
+// selection until the button is released.
+func (m *Model) pressOutput(y int) {
+	if !m.outputShown() || m.inputMode {
+		return
+	}
+	row, ok := m.outputRow(y)
+	if !ok {
+		return
+	}
+
+	inst := m.activeInstance()
+	sel := m.selection
+	if sel == nil {
+		lines := m.outputManager.GetFilteredLines(inst.ID)
+		maxLines := m.getOutputMaxLines()
+		sel = &outputSelection{
+			instanceID: inst.ID,
+			lines:      lines,
+			scroll:     min(m.outputManager.GetScrollOffset(inst.ID), max(len(lines)-maxLines, 0)),
+		}
+	}
+	line := sel.scroll + row
+	if line >= len(sel.lines) {
+		return
+	}
+	sel.anchor, sel.cursor = line, line
+	sel.dragging = true
+	m.selection = sel
+}
+
+// outputRow returns the row of screen row y within the active instance's
+// output box, counted from its first line; ok is false when y is outside
+// the box. Rows above or below the box are negative or past its last line.
+func (m Model) outputRow(y int) (row int, ok bool) {
+	maxLines := m.getOutputMaxLines()
+	content := m.renderContent(m.width - CalculateEffectiveSidebarWidthWithConfig(m.width, config.Get().TUI.SidebarWidth) - 3)
+	// The output box, with its border, ends the instance view
+	boxTop := lipgloss.Height(m.renderUnifiedHeader()) + lipgloss.Height(content) - maxLines - 2
+	row = y - boxTop - 1
+	return row, row >= 0 && row < maxLines
+}
+
+// outputShown reports whether the content area shows the active instance's
+// output rather than a panel or another view.
+func (m Model) outputShown() bool {
+	if m.showHelp || m.replay != nil || m.dashboard != nil || m.split != nil || m.grep != nil || m.conflicts != nil || m.deps != nil ||
+		m.showDiff || m.showStats || m.showFiles || m.filterMode {
+		return false
+	}
+	if m.IsUltraPlanMode() && (m.IsPlanEditorActive() || m.ultraPlan.ShowPlanView) {
+		return false
+	}
+	return m.activeInstance() != nil
+}
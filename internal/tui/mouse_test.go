@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/spf13/viper"
+)
+
+// newMouseTestModel returns a ready model with mouse support on and 200
+// lines of output, "out 000" to "out 199", on the active instance a.
+func newMouseTestModel(t *testing.T) Model {
+	t.Helper()
+	viper.Set("tui.mouse", true)
+	t.Cleanup(func() { viper.Set("tui.mouse", false) })
+
+	m := newDashboardTestModel(3)
+	m.ready = true
+	var out strings.Builder
+	for i := range 200 {
+		fmt.Fprintf(&out, "out %03d\n", i)
+	}
+	m.outputManager.SetOutput("a", out.String())
+	m.scrollOutputToBottom("a")
+	return m
+}
+
+// screenRow returns the row of the rendered screen containing text.
+func screenRow(t *testing.T, m Model, text string) int {
+	t.Helper()
+	for i, line := range strings.Split(ansi.Strip(m.View()), "\n") {
+		if strings.Contains(line, text) {
+			return i
+		}
+	}
+	t.Fatalf("%q is not on screen", text)
+	return -1
+}
+
+func sendMouse(m Model, msg tea.MouseMsg) Model {
+	result, _ := m.handleMouse(msg)
+	return result.(Model)
+}
+
+func TestHandleMouse_ClickSidebarSelectsInstance(t *testing.T) {
+	m := newMouseTestModel(t)
+	m = sendMouse(m, tea.MouseMsg{X: 5, Y: screenRow(t, m, "task c"), Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if m.activeTab != 2 {
+		t.Errorf("activeTab = %d, want 2 after clicking instance c", m.activeTab)
+	}
+
+	// Clicking the sidebar title selects nothing
+	m = sendMouse(m, tea.MouseMsg{X: 5, Y: screenRow(t, m, "Instances"), Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if m.activeTab != 2 {
+		t.Errorf("activeTab = %d, want 2 after clicking the title", m.activeTab)
+	}
+}
+
+func TestHandleMouse_WheelScrollsOutput(t *testing.T) {
+	m := newMouseTestModel(t)
+	before := m.outputManager.GetScrollOffset("a")
+	m = sendMouse(m, tea.MouseMsg{X: 100, Y: 20, Button: tea.MouseButtonWheelUp, Action: tea.MouseActionPress})
+	if got := m.outputManager.GetScrollOffset("a"); got != before-mouseScrollLines {
+		t.Errorf("scroll offset = %d, want %d", got, before-mouseScrollLines)
+	}
+	if m.isOutputAutoScroll("a") {
+		t.Error("scrolling up with the wheel should stop following output")
+	}
+}
+
+func TestHandleMouse_DragSelectsLines(t *testing.T) {
+	m := newMouseTestModel(t)
+	m = sendMouse(m, tea.MouseMsg{X: 100, Y: screenRow(t, m, "out 190"), Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if m.selection == nil || m.selection.anchor != 190 || m.selection.cursor != 190 {
+		t.Fatalf("selection = %+v, want it started at line 190", m.selection)
+	}
+
+	y := screenRow(t, m, "out 195")
+	m = sendMouse(m, tea.MouseMsg{X: 100, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionMotion})
+	m = sendMouse(m, tea.MouseMsg{X: 100, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionRelease})
+	if got := m.selection.text(); got != "out 190\nout 191\nout 192\nout 193\nout 194\nout 195" {
+		t.Errorf("selected text = %q, want lines 190-195", got)
+	}
+	if m.selection.dragging {
+		t.Error("releasing the button should end the drag")
+	}
+
+	// Motion after the release no longer moves the cursor
+	m = sendMouse(m, tea.MouseMsg{X: 100, Y: y - 3, Action: tea.MouseActionMotion})
+	if m.selection.cursor != 195 {
+		t.Errorf("cursor = %d, want 195 after the drag ended", m.selection.cursor)
+	}
+}
+
+func TestHandleMouse_Disabled(t *testing.T) {
+	m := newMouseTestModel(t)
+	viper.Set("tui.mouse", false)
+	m = sendMouse(m, tea.MouseMsg{X: 5, Y: screenRow(t, m, "task c"), Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if m.activeTab != 0 {
+		t.Errorf("activeTab = %d, want 0 with mouse support off", m.activeTab)
+	}
+}
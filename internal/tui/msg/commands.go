@@ -7,17 +7,25 @@
 package msg
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"os/exec"
+	"regexp"
 	"runtime"
+	"strings"
 	"time"
 
+	"github.com/Iron-Ham/claudio/internal/instance/capture"
+	"github.com/Iron-Ham/claudio/internal/logging"
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/workflows/adversarial"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/workflows/ralph"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/workflows/tripleshot"
+	"github.com/Iron-Ham/claudio/internal/tui/clipboard"
 	"github.com/Iron-Ham/claudio/internal/tui/output"
+	"github.com/Iron-Ham/claudio/internal/tui/search"
 	"github.com/Iron-Ham/claudio/internal/tui/view"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/viper"
@@ -31,6 +39,34 @@ func Tick() tea.Cmd {
 	})
 }
 
+// RefreshSnapshot returns a command that, off the UI goroutine, publishes a
+// fresh orchestrator state snapshot if the session's groups changed. The tick
+// uses it to pick up group edits made outside the orchestrator's own methods,
+// which publish on every change, so that rendering never has to take
+// orchestrator locks itself.
+func RefreshSnapshot(orch *orchestrator.Orchestrator) tea.Cmd {
+	if orch == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		orch.RefreshSnapshot()
+		return nil
+	}
+}
+
+// ResizeInstances returns a command that resizes every running instance's
+// tmux pane, and the size used for new instances, to width x height cells.
+// Each resize runs a tmux command, so they happen off the UI goroutine.
+func ResizeInstances(orch *orchestrator.Orchestrator, width, height int) tea.Cmd {
+	if orch == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		orch.ResizeAllInstances(width, height)
+		return nil
+	}
+}
+
 // RingBell returns a command that outputs a terminal bell character.
 // This forwards bells from tmux sessions to the parent terminal.
 func RingBell() tea.Cmd {
@@ -42,6 +78,16 @@ func RingBell() tea.Cmd {
 	}
 }
 
+// CopyToClipboard returns a command that copies text to the system clipboard.
+// The OSC 52 sequence is written directly to stdout like RingBell, and native
+// clipboard commands run off the UI goroutine.
+func CopyToClipboard(text string, lines int) tea.Cmd {
+	return func() tea.Msg {
+		method, err := clipboard.Copy(os.Stdout, text)
+		return ClipboardCopiedMsg{Lines: lines, Method: method, Err: err}
+	}
+}
+
 // NotifyUser returns a command that notifies the user via bell and optional sound.
 // Used to alert the user when ultraplan needs input (e.g., plan ready, synthesis ready).
 func NotifyUser() tea.Cmd {
@@ -241,6 +287,119 @@ func LoadDiffAsync(o *orchestrator.Orchestrator, worktreePath string, instanceID
 	}
 }
 
+// LoadTranscriptAsync returns a command that reads an instance's recorded
+// transcript asynchronously.
+func LoadTranscriptAsync(path string, instanceID string) tea.Cmd {
+	return func() tea.Msg {
+		t, err := capture.ReadTranscript(path)
+		return TranscriptLoadedMsg{
+			InstanceID: instanceID,
+			Transcript: t,
+			Err:        err,
+		}
+	}
+}
+
+// SearchTranscriptsAsync returns a command that searches the recorded
+// transcripts of the given instances for re, in order, for up to limit hits.
+// Instances without a transcript are skipped.
+func SearchTranscriptsAsync(re *regexp.Regexp, instanceIDs []string, transcriptPath func(instanceID string) string, limit int) tea.Cmd {
+	return func() tea.Msg {
+		result := TranscriptSearchMsg{
+			Pattern:     re.String(),
+			Transcripts: make(map[string]*capture.Transcript),
+		}
+		for _, id := range instanceIDs {
+			if len(result.Hits) >= limit {
+				break
+			}
+			t, err := capture.ReadTranscript(transcriptPath(id))
+			if err != nil {
+				if !errors.Is(err, fs.ErrNotExist) && result.Err == nil {
+					result.Err = err
+				}
+				continue
+			}
+			hits := search.Transcript(id, t, re, limit-len(result.Hits))
+			if len(hits) > 0 {
+				result.Hits = append(result.Hits, hits...)
+				result.Transcripts[id] = t
+			}
+		}
+		return result
+	}
+}
+
+// LoadFileChangesAsync returns a command that lists the files with
+// uncommitted changes in an instance's worktree.
+func LoadFileChangesAsync(o *orchestrator.Orchestrator, worktreePath string, instanceID string) tea.Cmd {
+	return func() tea.Msg {
+		if o == nil {
+			return FileChangesLoadedMsg{
+				InstanceID: instanceID,
+				Err:        fmt.Errorf("orchestrator is nil"),
+			}
+		}
+		files, err := o.GetUncommittedFiles(worktreePath)
+		return FileChangesLoadedMsg{
+			InstanceID: instanceID,
+			Files:      files,
+			Err:        err,
+		}
+	}
+}
+
+// RefreshObservedSessionAsync returns a command that reads the file of the
+// session o observes, to follow what its owner changed.
+func RefreshObservedSessionAsync(o *orchestrator.Orchestrator) tea.Cmd {
+	return func() tea.Msg {
+		sess, err := o.ReadObservedSession()
+		return ObservedSessionMsg{Session: sess, Err: err}
+	}
+}
+
+// TailLogAsync returns a command that reads the entries written to the
+// session log at path from offset on (see logging.ReadEntries).
+func TailLogAsync(path string, offset int64) tea.Cmd {
+	return func() tea.Msg {
+		entries, next, err := logging.ReadEntries(path, offset)
+		return LogTailedMsg{
+			Path:    path,
+			Entries: entries,
+			Offset:  next,
+			Err:     err,
+		}
+	}
+}
+
+// LoadConflictsAsync returns a command that reads the files still
+// conflicted in the worktree a paused consolidation is waiting on.
+func LoadConflictsAsync(coord *orchestrator.Coordinator) tea.Cmd {
+	return func() tea.Msg {
+		files, err := coord.ConsolidationConflicts()
+		return ConflictsLoadedMsg{Files: files, Err: err}
+	}
+}
+
+// EditConflictCmd returns a command that suspends the TUI to edit file in
+// dir with the user's editor ($VISUAL, then $EDITOR, then vi).
+func EditConflictCmd(dir, file string) tea.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	// The editor may carry arguments, as in "code --wait"
+	args := append(strings.Fields(editor), file)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = dir
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return ConflictEditedMsg{File: file, Err: err}
+	})
+}
+
 // CreateTripleShotStubsAsync returns a command that creates stub instances for all three
 // tripleshot attempts. This is the fast first phase - it creates instance metadata
 // immediately so the UI can show "Preparing" status while worktrees are created.
@@ -438,6 +597,44 @@ func CheckPlanFileAsync(
 	}
 }
 
+// CheckPlanRefinementFileAsync returns a command that checks for the revised
+// plan while a plan refinement is in progress.
+func CheckPlanRefinementFileAsync(
+	orc *orchestrator.Orchestrator,
+	ultraPlan *view.UltraPlanState,
+) tea.Cmd {
+	return func() tea.Msg {
+		if ultraPlan == nil || ultraPlan.Coordinator == nil {
+			return nil
+		}
+
+		session := ultraPlan.Coordinator.Session()
+		if session == nil || session.Phase != orchestrator.PhasePlanSelection {
+			return nil
+		}
+		refinement := session.PlanRefinement
+		if refinement == nil || !refinement.Active {
+			return nil
+		}
+
+		inst := orc.GetInstance(refinement.RefinerID)
+		if inst == nil {
+			return nil
+		}
+
+		plan, err := orchestrator.ParsePlanFromFile(orchestrator.PlanFilePath(inst.WorktreePath), session.Objective)
+		if err != nil {
+			// Not written yet, or only partially written
+			return nil
+		}
+
+		return PlanRefinementFileCheckResultMsg{
+			Plan:       plan,
+			InstanceID: inst.ID,
+		}
+	}
+}
+
 // CheckMultiPassPlanFilesAsync returns commands that check for plan files from multi-pass coordinators.
 // Each returned command checks one coordinator's plan file asynchronously.
 func CheckMultiPassPlanFilesAsync(
@@ -1,9 +1,12 @@
 package msg
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	"github.com/Iron-Ham/claudio/internal/tui/view"
 	"github.com/spf13/viper"
 )
@@ -43,6 +46,26 @@ func TestTick(t *testing.T) {
 	}
 }
 
+func TestRefreshSnapshot(t *testing.T) {
+	if cmd := RefreshSnapshot(nil); cmd != nil {
+		t.Error("RefreshSnapshot(nil) should return nil command")
+	}
+
+	orch := &orchestrator.Orchestrator{}
+	before := orch.Snapshot().Version
+
+	cmd := RefreshSnapshot(orch)
+	if cmd == nil {
+		t.Fatal("RefreshSnapshot() returned nil command")
+	}
+	if result := cmd(); result != nil {
+		t.Errorf("RefreshSnapshot() returned %v, want nil", result)
+	}
+	if after := orch.Snapshot().Version; after != before {
+		t.Errorf("snapshot Version = %d with nothing changed, want %d", after, before)
+	}
+}
+
 func TestRingBell(t *testing.T) {
 	cmd := RingBell()
 
@@ -616,3 +639,43 @@ func TestLoadDiffAsync(t *testing.T) {
 		}
 	})
 }
+
+func TestLoadFileChangesAsync(t *testing.T) {
+	cmd := LoadFileChangesAsync(nil, "/path/to/worktree", "test-instance-id")
+	if cmd == nil {
+		t.Fatal("LoadFileChangesAsync() returned nil command")
+	}
+
+	filesMsg, ok := cmd().(FileChangesLoadedMsg)
+	if !ok {
+		t.Fatalf("LoadFileChangesAsync()() returned %T, want FileChangesLoadedMsg", cmd())
+	}
+	if filesMsg.Err == nil {
+		t.Error("expected error when orchestrator is nil")
+	}
+	if filesMsg.InstanceID != "test-instance-id" {
+		t.Errorf("InstanceID = %q, want %q", filesMsg.InstanceID, "test-instance-id")
+	}
+}
+
+func TestTailLogAsync(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debug.log")
+	line := `{"level":"INFO","msg":"started"}` + "\n"
+	if err := os.WriteFile(path, []byte(line), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tailMsg, ok := TailLogAsync(path, 0)().(LogTailedMsg)
+	if !ok {
+		t.Fatal("TailLogAsync()() did not return a LogTailedMsg")
+	}
+	if tailMsg.Err != nil || tailMsg.Path != path {
+		t.Fatalf("LogTailedMsg = %+v", tailMsg)
+	}
+	if len(tailMsg.Entries) != 1 || tailMsg.Entries[0].Msg != "started" {
+		t.Errorf("Entries = %+v, want the one entry", tailMsg.Entries)
+	}
+	if tailMsg.Offset != int64(len(line)) {
+		t.Errorf("Offset = %d, want %d", tailMsg.Offset, len(line))
+	}
+}
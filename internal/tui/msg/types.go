@@ -4,9 +4,13 @@ import (
 	"time"
 
 	"github.com/Iron-Ham/claudio/internal/instance"
+	"github.com/Iron-Ham/claudio/internal/instance/capture"
+	"github.com/Iron-Ham/claudio/internal/logging"
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/workflows/adversarial"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/workflows/tripleshot"
+	"github.com/Iron-Ham/claudio/internal/tui/search"
+	"github.com/Iron-Ham/claudio/internal/worktree"
 )
 
 // TickMsg is sent periodically to drive UI updates and polling.
@@ -45,6 +49,21 @@ type BellMsg struct {
 	InstanceID string
 }
 
+// WaitingInputMsg signals that an instance started waiting for input.
+type WaitingInputMsg struct {
+	InstanceID string
+}
+
+// AlertCheckMsg asks the model to send desktop notifications for instances
+// that have waited for input past the escalation delay.
+type AlertCheckMsg struct{}
+
+// DesktopNotifiedMsg is sent when a desktop notification has been shown,
+// or failed to be.
+type DesktopNotifiedMsg struct {
+	Err error
+}
+
 // TaskAddedMsg is sent when async task addition completes.
 type TaskAddedMsg struct {
 	Instance *orchestrator.Instance
@@ -100,6 +119,76 @@ type DiffLoadedMsg struct {
 	Err         error
 }
 
+// TranscriptLoadedMsg is sent when async transcript loading completes.
+type TranscriptLoadedMsg struct {
+	InstanceID string
+	Transcript *capture.Transcript
+	Err        error
+}
+
+// TranscriptSearchMsg is sent when searching the recorded transcripts of
+// instances completes. Transcripts holds the transcripts with hits, by
+// instance ID, to replay from a hit; Err is the first failure to read a
+// transcript other than one not being recorded.
+type TranscriptSearchMsg struct {
+	Pattern     string
+	Hits        []search.Hit
+	Transcripts map[string]*capture.Transcript
+	Err         error
+}
+
+// FileClaimMsg signals that an instance claimed or released a file.
+type FileClaimMsg struct {
+	InstanceID string
+	FilePath   string
+	Claimed    bool // false for a release
+}
+
+// FileChangesLoadedMsg is sent when async loading of an instance's
+// uncommitted files completes.
+type FileChangesLoadedMsg struct {
+	InstanceID string
+	Files      []string
+	Err        error
+}
+
+// ObservedSessionMsg is sent when the file of a session observed read-only
+// has been read again. Session is the state its owner last saved.
+type ObservedSessionMsg struct {
+	Session *orchestrator.Session
+	Err     error
+}
+
+// LogTailedMsg is sent when the session log has been read for the log
+// pane. Offset is where the next read continues.
+type LogTailedMsg struct {
+	Path    string
+	Entries []logging.Entry
+	Offset  int64
+	Err     error
+}
+
+// ConflictsLoadedMsg is sent when the files still conflicted in a paused
+// consolidation's worktree have been read for the conflict view.
+type ConflictsLoadedMsg struct {
+	Files []worktree.ConflictedFile
+	Err   error
+}
+
+// ConflictEditedMsg is sent when the editor opened on a conflicted file
+// exits.
+type ConflictEditedMsg struct {
+	File string
+	Err  error
+}
+
+// ClipboardCopiedMsg is sent when copying selected output to the clipboard completes.
+type ClipboardCopiedMsg struct {
+	Lines  int    // Number of lines copied
+	Method string // How the text was copied (e.g. "OSC 52 + pbcopy")
+	Err    error
+}
+
 // UltraPlanInitMsg signals that ultra-plan mode should initialize.
 type UltraPlanInitMsg struct{}
 
@@ -198,6 +287,13 @@ type PlanFileCheckResultMsg struct {
 	Err          error
 }
 
+// PlanRefinementFileCheckResultMsg contains a revised plan written by the
+// planner after the human requested changes to the draft.
+type PlanRefinementFileCheckResultMsg struct {
+	Plan       *orchestrator.PlanSpec
+	InstanceID string
+}
+
 // MultiPassPlanFileCheckResultMsg contains the result of async multi-pass plan file checking.
 // Returned for each coordinator that has a new plan file detected.
 type MultiPassPlanFileCheckResultMsg struct {
@@ -356,6 +452,43 @@ type BridgeTaskActivityMsg struct {
 	Success    bool // only meaningful when Started is false
 }
 
+// MailboxMessageFlaggedMsg signals that the mailbox guard flagged an
+// inter-instance message as instruction-like content.
+type MailboxMessageFlaggedMsg struct {
+	MessageID string
+	From      string
+	To        string
+	Flags     []string
+	Held      bool // true if the message is withheld from prompts pending review
+}
+
+// TaskProposedMsg signals that an instance proposed a follow-up task that
+// waits for user approval before joining the running plan.
+type TaskProposedMsg struct {
+	ProposalID string
+	From       string
+	Title      string
+}
+
+// BaseDriftMsg signals that the base branch of the running plan gained
+// commits after the plan started.
+type BaseDriftMsg struct {
+	Ref           string
+	Commits       int
+	AffectedTasks []string // Remaining tasks whose files were changed
+	Replanned     bool     // Affected tasks will be told what changed when they start
+}
+
+// BridgePlacementFailedMsg signals that a bridge could not place a task
+// instance on a worker node.
+type BridgePlacementFailedMsg struct {
+	TeamID  string
+	TaskID  string
+	Backend string
+	Reason  string
+	Waiting bool // true if the task is waiting for node capacity rather than failed
+}
+
 // --- Teamwire callback bridge messages ---
 // These messages are produced by teamwire.TeamCoordinator callbacks and delivered
 // to the Bubble Tea event loop via a buffered channel (see ListenTeamwireEvents).
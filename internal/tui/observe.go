@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"errors"
+	"io/fs"
+	"time"
+
+	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// observeRefreshInterval is the least time between reads of an observed
+// session's file.
+const observeRefreshInterval = time.Second
+
+// observerState tracks following a session another process runs. The TUI
+// shows it as its owner last saved it and changes nothing.
+type observerState struct {
+	ended bool // The session file is gone: the owner stopped the session
+
+	loading  bool
+	loadedAt time.Time
+}
+
+// dispatchObserveRefresh reads the observed session's file again, at most
+// once per observeRefreshInterval, until the session ends.
+func (m *Model) dispatchObserveRefresh(now time.Time) tea.Cmd {
+	o := m.observer
+	if o == nil || o.ended || o.loading || now.Sub(o.loadedAt) < observeRefreshInterval {
+		return nil
+	}
+	o.loading = true
+	o.loadedAt = now
+	return tuimsg.RefreshObservedSessionAsync(m.orchestrator)
+}
+
+// handleObservedSession applies the owner's latest session state. A file
+// that can't be parsed is most likely being written and is read again on
+// the next refresh; one that is gone means the owner stopped the session.
+func (m *Model) handleObservedSession(msg tuimsg.ObservedSessionMsg) {
+	o := m.observer
+	if o == nil {
+		return
+	}
+	o.loading = false
+	if errors.Is(msg.Err, fs.ErrNotExist) {
+		o.ended = true
+		m.infoMessage = "Observed session has ended"
+		return
+	}
+	if msg.Err != nil {
+		return
+	}
+	m.orchestrator.ApplyObservedSession(msg.Session)
+
+	// The owner may have removed instances
+	if n := len(m.session.Instances); m.activeTab >= n {
+		m.activeTab = max(n-1, 0)
+	}
+	m.ensureActiveVisible()
+}
+
+// refuseWhileObserving reports whether the session is only observed, and
+// if so tells the user that action is not available.
+func (m *Model) refuseWhileObserving(action string) bool {
+	if m.observer == nil {
+		return false
+	}
+	m.infoMessage = "Observing read-only: cannot " + action
+	return true
+}
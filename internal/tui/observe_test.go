@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestObserverMode(t *testing.T) {
+	sess := &orchestrator.Session{Name: "owned"}
+	m := NewModel(orchestrator.NewObserverForTesting(sess), sess, nil)
+	m.width, m.height = 120, 40
+	if m.observer == nil {
+		t.Fatal("NewModel() of an observed session is not in observer mode")
+	}
+	if header := m.renderUnifiedHeader(); !strings.Contains(header, "OBSERVER") {
+		t.Errorf("header has no observer badge:\n%s", header)
+	}
+
+	// Keys that would change the session are refused
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("i")},
+		{Type: tea.KeyCtrlR},
+		{Type: tea.KeyCtrlK},
+	} {
+		result, _ := m.handleNormalModeKey(key)
+		got := result.(Model)
+		if got.inputMode || !strings.Contains(got.infoMessage, "read-only") {
+			t.Errorf("%s: inputMode = %v, info %q, want it refused", key, got.inputMode, got.infoMessage)
+		}
+	}
+
+	// The session file is read again at most once per interval
+	now := time.Now()
+	if m.dispatchObserveRefresh(now) == nil {
+		t.Fatal("dispatchObserveRefresh() = nil on the first tick")
+	}
+	if m.dispatchObserveRefresh(now.Add(2*observeRefreshInterval)) != nil {
+		t.Error("dispatchObserveRefresh() while a read is in flight")
+	}
+
+	m.handleObservedSession(tuimsg.ObservedSessionMsg{Session: &orchestrator.Session{Name: "renamed"}})
+	if sess.Name != "renamed" {
+		t.Errorf("session name = %q after refresh, want the owner's", sess.Name)
+	}
+
+	m.handleObservedSession(tuimsg.ObservedSessionMsg{Err: errors.New("unexpected end of JSON input")})
+	if m.observer.ended {
+		t.Error("a partly written session file ended observing")
+	}
+	m.handleObservedSession(tuimsg.ObservedSessionMsg{Err: fs.ErrNotExist})
+	if !m.observer.ended || m.dispatchObserveRefresh(now.Add(time.Hour)) != nil {
+		t.Error("observing continued after the session file was removed")
+	}
+}
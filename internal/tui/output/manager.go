@@ -53,6 +53,10 @@ type Manager struct {
 
 	// filterVersion is incremented when filter settings change, invalidating all caches
 	filterVersion uint64
+
+	// fingerprints caches the chunk hashes of each instance's raw output,
+	// keyed by output version, for SimilarOutput.
+	fingerprints map[string]fingerprint
 }
 
 // NewManager creates a new output Manager with initialized maps.
@@ -64,6 +68,7 @@ func NewManager() *Manager {
 		hasNewOutput:   make(map[string]bool),
 		filteredCache:  make(map[string]cacheEntry),
 		outputVersions: make(map[string]uint64),
+		fingerprints:   make(map[string]fingerprint),
 	}
 }
 
@@ -139,6 +144,7 @@ func (m *Manager) Clear(instanceID string) {
 	delete(m.hasNewOutput, instanceID)
 	delete(m.filteredCache, instanceID)
 	delete(m.outputVersions, instanceID)
+	delete(m.fingerprints, instanceID)
 }
 
 // ClearAll removes all output and state for all instances.
@@ -151,6 +157,7 @@ func (m *Manager) ClearAll() {
 	m.hasNewOutput = make(map[string]bool)
 	m.filteredCache = make(map[string]cacheEntry)
 	m.outputVersions = make(map[string]uint64)
+	m.fingerprints = make(map[string]fingerprint)
 }
 
 // Scroll adjusts the scroll position by delta lines.
@@ -0,0 +1,150 @@
+package output
+
+import (
+	"hash/fnv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// SimilarityThreshold is the minimum fraction of shared output chunks for
+	// two instances' outputs to be reported as similar.
+	SimilarityThreshold = 0.8
+
+	// minSimilarityChunks is the minimum number of distinct chunks an output
+	// needs before it is compared, so short startup banners don't match.
+	minSimilarityChunks = 20
+
+	// maxFingerprintLines bounds fingerprinting to the tail of the output,
+	// where build logs and failures that instances share end up.
+	maxFingerprintLines = 2000
+)
+
+// Similarity describes another instance whose output closely matches.
+type Similarity struct {
+	InstanceID string  // Instance with the most similar output
+	Score      float64 // Fraction of distinct chunks the outputs share (0-1)
+}
+
+// fingerprint is the set of chunk hashes of one instance's output.
+type fingerprint struct {
+	version uint64
+	chunks  map[uint64]struct{}
+}
+
+// fingerprintOutput hashes each line of the output tail into a chunk set.
+// Lines are compared after stripping ANSI sequences and surrounding space,
+// and digit runs are collapsed so timestamps, durations, and PIDs don't
+// make otherwise identical logs look different. Blank lines are skipped.
+func fingerprintOutput(output string) map[uint64]struct{} {
+	lines := strings.Split(output, "\n")
+	if len(lines) > maxFingerprintLines {
+		lines = lines[len(lines)-maxFingerprintLines:]
+	}
+
+	chunks := make(map[uint64]struct{}, len(lines))
+	h := fnv.New64a()
+	for _, line := range lines {
+		line = normalizeChunk(line)
+		if line == "" {
+			continue
+		}
+		h.Reset()
+		_, _ = h.Write([]byte(line))
+		chunks[h.Sum64()] = struct{}{}
+	}
+	return chunks
+}
+
+// normalizeChunk strips styling and collapses each run of digits to "0".
+func normalizeChunk(line string) string {
+	line = strings.TrimSpace(ansi.Strip(line))
+	if line == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	b.Grow(len(line))
+	inDigits := false
+	for _, r := range line {
+		if r >= '0' && r <= '9' {
+			if !inDigits {
+				b.WriteByte('0')
+			}
+			inDigits = true
+			continue
+		}
+		inDigits = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// chunkSimilarity returns the Jaccard index of two chunk sets.
+func chunkSimilarity(a, b map[uint64]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	shared := 0
+	for h := range a {
+		if _, ok := b[h]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// fingerprintLocked returns the chunk set for an instance's current output,
+// recomputing it only when the output changed. Caller must hold a write lock.
+func (m *Manager) fingerprintLocked(instanceID string) map[uint64]struct{} {
+	version := m.outputVersions[instanceID]
+	if fp, ok := m.fingerprints[instanceID]; ok && fp.version == version {
+		return fp.chunks
+	}
+	chunks := fingerprintOutput(m.outputs[instanceID])
+	m.fingerprints[instanceID] = fingerprint{version: version, chunks: chunks}
+	return chunks
+}
+
+// SimilarOutput returns the other instance whose output shares the most
+// chunks with instanceID's output, if the share reaches SimilarityThreshold.
+// It helps spot instances that fail identically, such as several workers
+// hitting the same build error. Fingerprints are cached per output version,
+// so repeated calls only rehash outputs that changed. Ties go to the
+// instance with the lowest ID.
+func (m *Manager) SimilarOutput(instanceID string) (Similarity, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.outputs[instanceID] == "" {
+		return Similarity{}, false
+	}
+	own := m.fingerprintLocked(instanceID)
+	if len(own) < minSimilarityChunks {
+		return Similarity{}, false
+	}
+
+	var best Similarity
+	for id, out := range m.outputs {
+		if id == instanceID || out == "" {
+			continue
+		}
+		other := m.fingerprintLocked(id)
+		if len(other) < minSimilarityChunks {
+			continue
+		}
+		score := chunkSimilarity(own, other)
+		if score > best.Score || (score == best.Score && score > 0 && id < best.InstanceID) {
+			best = Similarity{InstanceID: id, Score: score}
+		}
+	}
+
+	if best.Score < SimilarityThreshold {
+		return Similarity{}, false
+	}
+	return best, true
+}
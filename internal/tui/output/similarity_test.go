@@ -0,0 +1,90 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// buildLog returns n distinct log lines, each prefixed with prefix.
+func buildLog(prefix string, n int) string {
+	lines := make([]string, n)
+	for i := range n {
+		lines[i] = fmt.Sprintf("%s step %c done", prefix, 'a'+rune(i%26)) + strings.Repeat("!", i/26)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestNormalizeChunk(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"   ", ""},
+		{"  ok  ", "ok"},
+		{"\x1b[31mFAIL\x1b[0m pkg", "FAIL pkg"},
+		{"took 1.234s at 12:05:59", "took 0.0s at 0:0:0"},
+		{"pid 48213", "pid 0"},
+	}
+	for _, tt := range tests {
+		if got := normalizeChunk(tt.in); got != tt.want {
+			t.Errorf("normalizeChunk(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestChunkSimilarity(t *testing.T) {
+	a := fingerprintOutput("one\ntwo\nthree\nfour")
+	b := fingerprintOutput("one\ntwo\nthree\nfive")
+
+	if got := chunkSimilarity(a, a); got != 1 {
+		t.Errorf("chunkSimilarity(a, a) = %v, want 1", got)
+	}
+	// 3 shared chunks out of 5 distinct
+	if got := chunkSimilarity(a, b); got != 0.6 {
+		t.Errorf("chunkSimilarity(a, b) = %v, want 0.6", got)
+	}
+	if got := chunkSimilarity(a, nil); got != 0 {
+		t.Errorf("chunkSimilarity(a, nil) = %v, want 0", got)
+	}
+}
+
+func TestSimilarOutput(t *testing.T) {
+	m := NewManager()
+	log := buildLog("build", 40)
+
+	m.SetOutput("inst-a", log+"\nFAIL at 10:01:02")
+	m.SetOutput("inst-b", log+"\nFAIL at 10:03:44")
+	m.SetOutput("inst-c", buildLog("test", 40))
+	m.SetOutput("inst-short", "FAIL")
+
+	sim, ok := m.SimilarOutput("inst-a")
+	if !ok {
+		t.Fatal("SimilarOutput(inst-a) found no similar output")
+	}
+	if sim.InstanceID != "inst-b" || sim.Score != 1 {
+		t.Errorf("SimilarOutput(inst-a) = %+v, want inst-b with score 1", sim)
+	}
+
+	if sim, ok := m.SimilarOutput("inst-c"); ok {
+		t.Errorf("SimilarOutput(inst-c) = %+v, want none", sim)
+	}
+	if _, ok := m.SimilarOutput("inst-short"); ok {
+		t.Error("SimilarOutput(inst-short) matched output below the chunk minimum")
+	}
+	if _, ok := m.SimilarOutput("missing"); ok {
+		t.Error("SimilarOutput(missing) matched an instance without output")
+	}
+
+	// Diverging output drops below the threshold once the fingerprint refreshes
+	m.SetOutput("inst-b", buildLog("deploy", 40))
+	if sim, ok := m.SimilarOutput("inst-a"); ok {
+		t.Errorf("SimilarOutput(inst-a) after change = %+v, want none", sim)
+	}
+
+	m.Clear("inst-b")
+	if _, ok := m.fingerprints["inst-b"]; ok {
+		t.Error("Clear() kept the fingerprint of a cleared instance")
+	}
+}
@@ -0,0 +1,262 @@
+// Package panel provides interfaces and types for TUI panel rendering.
+package panel
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// FileActivity records which instance is touching which files: the advisory
+// claims instances hold (from filelock events) and the files each instance
+// has uncommitted changes to (from git status of its worktree). It is
+// updated incrementally as events arrive; it never rescans on its own.
+type FileActivity struct {
+	claims  map[string]string   // file path -> claiming instance ID
+	changes map[string][]string // instance ID -> sorted files with uncommitted changes
+}
+
+// NewFileActivity creates an empty FileActivity.
+func NewFileActivity() *FileActivity {
+	return &FileActivity{
+		claims:  make(map[string]string),
+		changes: make(map[string][]string),
+	}
+}
+
+// FileConflict is a file one instance has claimed while another instance
+// has uncommitted changes to it.
+type FileConflict struct {
+	Path      string
+	ClaimedBy string // Instance holding the claim
+	EditedBy  string // Instance editing the file without the claim
+}
+
+// Claim records that instanceID claimed filePath.
+func (a *FileActivity) Claim(instanceID, filePath string) {
+	a.claims[cleanFilePath(filePath)] = instanceID
+}
+
+// Release records that instanceID released filePath. Releases by an
+// instance that does not hold the claim are ignored.
+func (a *FileActivity) Release(instanceID, filePath string) {
+	p := cleanFilePath(filePath)
+	if a.claims[p] == instanceID {
+		delete(a.claims, p)
+	}
+}
+
+// SetChanges replaces the files instanceID has uncommitted changes to.
+func (a *FileActivity) SetChanges(instanceID string, files []string) {
+	if len(files) == 0 {
+		delete(a.changes, instanceID)
+		return
+	}
+	cleaned := make([]string, len(files))
+	for i, f := range files {
+		cleaned[i] = cleanFilePath(f)
+	}
+	slices.Sort(cleaned)
+	a.changes[instanceID] = slices.Compact(cleaned)
+}
+
+// Owner returns the instance claiming filePath, if any.
+func (a *FileActivity) Owner(filePath string) (string, bool) {
+	owner, ok := a.claims[cleanFilePath(filePath)]
+	return owner, ok
+}
+
+// Changed reports whether instanceID has uncommitted changes to filePath.
+func (a *FileActivity) Changed(instanceID, filePath string) bool {
+	_, found := slices.BinarySearch(a.changes[instanceID], cleanFilePath(filePath))
+	return found
+}
+
+// Files returns the files instanceID has claimed or changed, sorted.
+func (a *FileActivity) Files(instanceID string) []string {
+	files := slices.Clone(a.changes[instanceID])
+	for p, owner := range a.claims {
+		if owner == instanceID {
+			files = append(files, p)
+		}
+	}
+	slices.Sort(files)
+	return slices.Compact(files)
+}
+
+// Conflicts returns the files changed by an instance other than the one
+// claiming them, sorted by path.
+func (a *FileActivity) Conflicts() []FileConflict {
+	var conflicts []FileConflict
+	for instanceID, files := range a.changes {
+		for _, f := range files {
+			if owner, ok := a.claims[f]; ok && owner != instanceID {
+				conflicts = append(conflicts, FileConflict{Path: f, ClaimedBy: owner, EditedBy: instanceID})
+			}
+		}
+	}
+	slices.SortFunc(conflicts, func(x, y FileConflict) int {
+		if c := strings.Compare(x.Path, y.Path); c != 0 {
+			return c
+		}
+		return strings.Compare(x.EditedBy, y.EditedBy)
+	})
+	return conflicts
+}
+
+// cleanFilePath normalizes a repository-relative path so claims and git
+// status output name the same file the same way.
+func cleanFilePath(p string) string {
+	return path.Clean(filepath.ToSlash(p))
+}
+
+// FilesPanel renders a file-tree view of which instance is touching which
+// files, with conflicts between claims and actual edits listed first.
+type FilesPanel struct {
+	height int
+}
+
+// NewFilesPanel creates a new FilesPanel.
+func NewFilesPanel() *FilesPanel {
+	return &FilesPanel{}
+}
+
+// Render produces the files panel output.
+func (p *FilesPanel) Render(state *RenderState) string {
+	if err := state.ValidateBasic(); err != nil {
+		return "[files panel: render error]"
+	}
+
+	var b strings.Builder
+	b.WriteString(p.style(state, "Files in Progress", Theme.Primary))
+	b.WriteString("\n\n")
+
+	activity := state.FileActivity
+	if activity == nil {
+		activity = NewFileActivity()
+	}
+
+	// Label instances by sidebar number, as the stats panel does
+	labels := make(map[string]string, len(state.Instances))
+	for i, inst := range state.Instances {
+		labels[inst.ID] = fmt.Sprintf("[%d] %s", i+1, inst.EffectiveName())
+	}
+	label := func(id string) string {
+		if l, ok := labels[id]; ok {
+			return l
+		}
+		return id
+	}
+
+	var lines []string
+	if conflicts := activity.Conflicts(); len(conflicts) > 0 {
+		lines = append(lines, p.style(state, fmt.Sprintf("Conflicts (%d)", len(conflicts)), Theme.Error))
+		for _, c := range conflicts {
+			lines = append(lines, p.style(state,
+				fmt.Sprintf("  %s: claimed by %s, edited by %s", c.Path, label(c.ClaimedBy), label(c.EditedBy)),
+				Theme.Warning))
+		}
+		lines = append(lines, "")
+	}
+
+	shown := 0
+	for _, inst := range state.Instances {
+		files := activity.Files(inst.ID)
+		if len(files) == 0 {
+			continue
+		}
+		shown++
+		lines = append(lines, p.style(state, label(inst.ID), Theme.Secondary))
+		lines = append(lines, p.fileTree(state, activity, inst, files, label)...)
+		lines = append(lines, "")
+	}
+	if shown == 0 {
+		lines = append(lines, p.style(state, "No instance has claimed or changed any files", Theme.Muted), "")
+	}
+
+	// Keep the panel within the content area
+	maxLines := max(state.Height-8, 5)
+	if len(lines) > maxLines {
+		hidden := len(lines) - maxLines + 1
+		lines = append(lines[:maxLines-1], p.style(state, fmt.Sprintf("... %d more lines", hidden), Theme.Muted))
+	}
+	b.WriteString(strings.Join(lines, "\n"))
+	b.WriteString("\n")
+	b.WriteString(p.style(state, "Press [:files] to close this view", Theme.Muted))
+
+	p.height = strings.Count(b.String(), "\n") + 1
+	return b.String()
+}
+
+// fileTree renders an instance's files as an indented directory tree, each
+// file marked with whether it is claimed and changed.
+func (p *FilesPanel) fileTree(state *RenderState, activity *FileActivity, inst *orchestrator.Instance, files []string, label func(string) string) []string {
+	var lines []string
+	var prevDirs []string
+	for _, f := range files {
+		parts := strings.Split(f, "/")
+		dirs := parts[:len(parts)-1]
+
+		common := 0
+		for common < len(dirs) && common < len(prevDirs) && dirs[common] == prevDirs[common] {
+			common++
+		}
+		for depth := common; depth < len(dirs); depth++ {
+			lines = append(lines, "  "+strings.Repeat("  ", depth)+p.style(state, dirs[depth]+"/", Theme.Muted))
+		}
+		prevDirs = dirs
+
+		indent := "  " + strings.Repeat("  ", len(dirs))
+		lines = append(lines, indent+parts[len(parts)-1]+"  "+p.fileMark(state, activity, inst.ID, f, label))
+	}
+	return lines
+}
+
+// fileMark describes an instance's relation to one of its files.
+func (p *FilesPanel) fileMark(state *RenderState, activity *FileActivity, instanceID, f string, label func(string) string) string {
+	owner, claimed := activity.Owner(f)
+	changed := activity.Changed(instanceID, f)
+	switch {
+	case claimed && owner != instanceID:
+		return p.style(state, "modified, claimed by "+label(owner), Theme.Warning)
+	case claimed && changed:
+		return p.style(state, "claimed, modified", Theme.Secondary)
+	case claimed:
+		var others []string
+		for _, other := range state.Instances {
+			if other.ID != instanceID && activity.Changed(other.ID, f) {
+				others = append(others, label(other.ID))
+			}
+		}
+		if len(others) > 0 {
+			return p.style(state, "claimed, edited by "+strings.Join(others, ", "), Theme.Warning)
+		}
+		return p.style(state, "claimed", Theme.Muted)
+	default:
+		return p.style(state, "modified", Theme.Muted)
+	}
+}
+
+// style renders text with a theme style, or plain when there is no theme.
+func (p *FilesPanel) style(state *RenderState, text string, pick func(Theme) lipgloss.Style) string {
+	if state.Theme == nil {
+		return text
+	}
+	return pick(state.Theme).Render(text)
+}
+
+// RenderWithBox renders the files panel and wraps it in a styled box.
+func (p *FilesPanel) RenderWithBox(state *RenderState, boxStyle lipgloss.Style) string {
+	content := p.Render(state)
+	return boxStyle.Width(state.Width - 4).Render(content)
+}
+
+// Height returns the rendered height of the panel.
+func (p *FilesPanel) Height() int {
+	return p.height
+}
@@ -0,0 +1,110 @@
+package panel
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+)
+
+func TestFileActivity(t *testing.T) {
+	a := NewFileActivity()
+	a.Claim("inst-1", "internal/api/client.go")
+	a.Claim("inst-1", "./internal/api/server.go")
+	a.SetChanges("inst-1", []string{"internal/api/client.go", "README.md"})
+	a.SetChanges("inst-2", []string{"internal/api/server.go"})
+
+	if owner, ok := a.Owner("internal/api/server.go"); !ok || owner != "inst-1" {
+		t.Errorf("Owner() = %q, %v; want inst-1 for a claim made with a ./ path", owner, ok)
+	}
+	want := []string{"README.md", "internal/api/client.go", "internal/api/server.go"}
+	if got := a.Files("inst-1"); !slices.Equal(got, want) {
+		t.Errorf("Files(inst-1) = %v, want %v", got, want)
+	}
+	wantConflicts := []FileConflict{{Path: "internal/api/server.go", ClaimedBy: "inst-1", EditedBy: "inst-2"}}
+	if got := a.Conflicts(); !slices.Equal(got, wantConflicts) {
+		t.Errorf("Conflicts() = %v, want %v", got, wantConflicts)
+	}
+
+	a.Release("inst-2", "internal/api/server.go")
+	if _, ok := a.Owner("internal/api/server.go"); !ok {
+		t.Error("Release() by a non-owner should keep the claim")
+	}
+	a.Release("inst-1", "internal/api/server.go")
+	if got := a.Conflicts(); len(got) != 0 {
+		t.Errorf("Conflicts() after release = %v, want none", got)
+	}
+
+	a.SetChanges("inst-1", nil)
+	if a.Changed("inst-1", "README.md") {
+		t.Error("SetChanges(nil) should clear the instance's changes")
+	}
+}
+
+func TestFilesPanel_Render(t *testing.T) {
+	a := NewFileActivity()
+	a.Claim("inst-1", "internal/api/client.go")
+	a.Claim("inst-1", "internal/api/server.go")
+	a.SetChanges("inst-1", []string{"internal/api/client.go"})
+	a.SetChanges("inst-2", []string{"internal/api/server.go", "docs/api.md"})
+
+	state := &RenderState{
+		Width:  100,
+		Height: 40,
+		Instances: []*orchestrator.Instance{
+			{ID: "inst-1", Task: "Build client"},
+			{ID: "inst-2", Task: "Write docs"},
+			{ID: "inst-3", Task: "Idle"},
+		},
+		FileActivity: a,
+	}
+	got := NewFilesPanel().Render(state)
+
+	for _, want := range []string{
+		"Files in Progress",
+		"Conflicts (1)",
+		"internal/api/server.go: claimed by [1] Build client, edited by [2] Write docs",
+		"[1] Build client",
+		"  internal/\n    api/\n      client.go  claimed, modified\n      server.go  claimed, edited by [2] Write docs",
+		"server.go  modified, claimed by [1] Build client",
+		"  docs/\n    api.md  modified",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "[3] Idle") {
+		t.Error("Render() should skip instances without files")
+	}
+}
+
+func TestFilesPanel_RenderEmpty(t *testing.T) {
+	state := &RenderState{Width: 80, Height: 24}
+	got := NewFilesPanel().Render(state)
+	if !strings.Contains(got, "No instance has claimed or changed any files") {
+		t.Errorf("Render() = %q, want the empty message", got)
+	}
+	if got := NewFilesPanel().Render(&RenderState{}); !strings.Contains(got, "render error") {
+		t.Errorf("Render() of invalid state = %q, want render error", got)
+	}
+}
+
+func TestFilesPanel_RenderTruncates(t *testing.T) {
+	a := NewFileActivity()
+	var files []string
+	for i := range 30 {
+		files = append(files, "pkg/file"+strings.Repeat("x", i)+".go")
+	}
+	a.SetChanges("inst-1", files)
+	state := &RenderState{
+		Width:        80,
+		Height:       20,
+		Instances:    []*orchestrator.Instance{{ID: "inst-1", Task: "Many files"}},
+		FileActivity: a,
+	}
+	got := NewFilesPanel().Render(state)
+	if !strings.Contains(got, "more lines") {
+		t.Errorf("Render() should truncate long trees:\n%s", got)
+	}
+}
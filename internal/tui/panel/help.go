@@ -3,7 +3,10 @@ package panel
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/tui/keymap"
 )
 
 // HelpPanel renders the help overlay with keybindings and scrolling support.
@@ -127,30 +130,40 @@ func (p *HelpPanel) Height() int {
 
 // DefaultHelpSections returns the default Claudio help sections.
 func DefaultHelpSections() []HelpSection {
-	return []HelpSection{
+	return HelpSections(keymap.Default())
+}
+
+// HelpSections returns the Claudio help sections with the keys of km.
+func HelpSections(km *keymap.Keymap) []HelpSection {
+	isNavigation := func(a keymap.Action) bool { return slices.Contains(navigationActions, a) }
+	sections := []HelpSection{
 		{
 			Title: "Navigation",
-			Items: []HelpItem{
-				{Key: "Tab/l  Shift+Tab/h", Description: "Next / Previous instance"},
-				{Key: "j/↓  k/↑", Description: "Scroll output down / up"},
-				{Key: "J  K", Description: "Scroll sidebar down / up (view only)"},
-				{Key: "Ctrl+D/U  Ctrl+F/B", Description: "Scroll half / full page"},
-				{Key: "0  G", Description: "Jump to top / bottom"},
-			},
+			Items: keymapItems(km, keymap.Normal, isNavigation),
 		},
 		{
-			Title: "Group Commands (g prefix)",
+			Title: "Keys",
+			Items: keymapItems(km, keymap.Normal, func(a keymap.Action) bool { return !isNavigation(a) }),
+		},
+	}
+	if keys := km.Keys(keymap.Normal, keymap.GroupPrefix); len(keys) > 0 {
+		prefix := keymap.Label(keys[0])
+		sections = append(sections, HelpSection{
+			Title: fmt.Sprintf("Group Commands (%s prefix)", prefix),
 			Items: []HelpItem{
-				{Key: "gc", Description: "Collapse/expand current group"},
-				{Key: "gC", Description: "Collapse/expand all groups"},
-				{Key: "gn", Description: "Jump to next group"},
-				{Key: "gp", Description: "Jump to previous group"},
-				{Key: "gs", Description: "Skip current group (mark pending as skipped)"},
-				{Key: "gr", Description: "Retry failed tasks in current group"},
-				{Key: "gf", Description: "Force-start next group (ignore dependencies)"},
-				{Key: "gq", Description: "Dismiss all instances in current group"},
+				{Key: prefix + "c", Description: "Collapse/expand current group"},
+				{Key: prefix + "C", Description: "Collapse/expand all groups"},
+				{Key: prefix + "n", Description: "Jump to next group"},
+				{Key: prefix + "p", Description: "Jump to previous group"},
+				{Key: prefix + "s", Description: "Skip current group (mark pending as skipped)"},
+				{Key: prefix + "r", Description: "Retry failed tasks in current group"},
+				{Key: prefix + "f", Description: "Force-start next group (ignore dependencies)"},
+				{Key: prefix + "q", Description: "Dismiss all instances in current group"},
 			},
-		},
+		})
+	}
+
+	return append(sections, []HelpSection{
 		{
 			Title: "Instance Control",
 			Items: []HelpItem{
@@ -204,8 +217,23 @@ func DefaultHelpSections() []HelpSection {
 				{Key: ":up --multi-pass", Description: "Ultraplan with multi-pass planning (3 strategies)"},
 				{Key: ":up --plan <file>", Description: "Load ultraplan from existing plan file"},
 				{Key: ":cancel", Description: "Cancel ultraplan execution"},
+				{Key: ":conflicts", Description: "Resolve the conflicts a paused consolidation is waiting on"},
+				{Key: ":deps", Description: "Show the plan's task dependency graph"},
+				{Key: ":release [ID]", Description: "Deliver a message held by the message guard, or list them"},
 			},
 		},
+		{
+			Title: "Ultraplan",
+			Items: keymapItems(km, keymap.UltraPlan, nil),
+		},
+		{
+			Title: "Ultraplan Group Decisions (failure or approval)",
+			Items: keymapItems(km, keymap.UltraPlanGate, nil),
+		},
+		{
+			Title: "Ultraplan Group Navigation",
+			Items: keymapItems(km, keymap.UltraPlanGroups, nil),
+		},
 		{
 			Title: "Group Management",
 			Items: []HelpItem{
@@ -223,6 +251,12 @@ func DefaultHelpSections() []HelpSection {
 			Items: []HelpItem{
 				{Key: ":d  :diff", Description: "Toggle diff preview panel"},
 				{Key: ":m  :stats", Description: "Toggle metrics panel"},
+				{Key: ":files", Description: "Toggle files panel (claims, edits, conflicts)"},
+				{Key: ":replay", Description: "Replay the selected instance's recorded transcript"},
+				{Key: ":dashboard", Description: "Show all instances as a grid of tiles"},
+				{Key: ":split [N]", Description: "Compare the selected instance side by side with instance N"},
+				{Key: ":grep [-t] PATTERN", Description: "Search every instance's output (-t: transcripts too)"},
+				{Key: ":logs [level=L] [instance=N] [phase=P]", Description: "Toggle the session log pane, or open it filtered"},
 				{Key: ":f  :filter", Description: "Open filter panel"},
 				{Key: ":tmux", Description: "Show tmux attach command"},
 				{Key: ":r  :pr", Description: "Show PR creation command"},
@@ -234,10 +268,43 @@ func DefaultHelpSections() []HelpSection {
 		{
 			Title: "Input Mode",
 			Items: []HelpItem{
-				{Key: "i  Enter", Description: "Enter input mode (talk to AI backend)"},
 				{Key: "Ctrl+]", Description: "Exit input mode"},
 			},
 		},
+		{
+			Title: "Select Mode (copy output)",
+			Items: keymapItems(km, keymap.Select, nil),
+		},
+		{
+			Title: "Replay Mode (recorded transcripts)",
+			Items: []HelpItem{
+				{Key: "Space  p", Description: "Play / pause"},
+				{Key: "h/l  ←/→", Description: "Previous / next frame"},
+				{Key: "H/L", Description: "Jump back / forward 10 seconds"},
+				{Key: "g/G", Description: "First / last frame"},
+				{Key: "Esc  q", Description: "Close replay"},
+			},
+		},
+		{
+			Title: "Dashboard (all instances)",
+			Items: keymapItems(km, keymap.Dashboard, nil),
+		},
+		{
+			Title: "Split View (compare two instances)",
+			Items: keymapItems(km, keymap.Split, nil),
+		},
+		{
+			Title: "Search Results (:grep)",
+			Items: keymapItems(km, keymap.Grep, nil),
+		},
+		{
+			Title: "Conflict View (:conflicts)",
+			Items: keymapItems(km, keymap.Conflicts, nil),
+		},
+		{
+			Title: "Dependency Graph (:deps)",
+			Items: keymapItems(km, keymap.Deps, nil),
+		},
 		{
 			Title: "Session",
 			Items: []HelpItem{
@@ -247,5 +314,26 @@ func DefaultHelpSections() []HelpSection {
 				{Key: "?", Description: "Quick toggle help"},
 			},
 		},
+	}...)
+}
+
+// navigationActions are the normal mode actions listed under Navigation;
+// the rest are listed under Keys.
+var navigationActions = []keymap.Action{
+	keymap.NextInstance, keymap.PrevInstance,
+	keymap.ScrollDown, keymap.ScrollUp, keymap.SidebarDown, keymap.SidebarUp,
+	keymap.HalfPageDown, keymap.HalfPageUp, keymap.PageDown, keymap.PageUp,
+	keymap.Top, keymap.Bottom,
+}
+
+// keymapItems returns a help item for each action bound in ctx that include
+// accepts, or every action when include is nil.
+func keymapItems(km *keymap.Keymap, ctx keymap.Context, include func(keymap.Action) bool) []HelpItem {
+	var items []HelpItem
+	for _, b := range km.Bindings(ctx) {
+		if len(b.Keys) > 0 && (include == nil || include(b.Action)) {
+			items = append(items, HelpItem{Key: keymap.Labels(b.Keys), Description: b.Description})
+		}
 	}
+	return items
 }
@@ -18,7 +18,7 @@ func TestHelpPanel_Render(t *testing.T) {
 			name: "renders with default sections",
 			state: &RenderState{
 				Width:  80,
-				Height: 120, // Large enough to show all sections (increased for Adversarial Mode)
+				Height: 300, // Large enough to show all sections, one line per key binding
 			},
 			contains: []string{
 				"Claudio Help",
@@ -29,6 +29,7 @@ func TestHelpPanel_Render(t *testing.T) {
 				"Adversarial Mode",
 				"View Commands",
 				"Input Mode",
+				"Ultraplan Group Navigation",
 				"Session",
 			},
 			notEmpty: true,
@@ -192,6 +193,7 @@ func TestDefaultHelpSections(t *testing.T) {
 		"Group Management",
 		"View Commands",
 		"Input Mode",
+		"Select Mode (copy output)",
 		"Session",
 	}
 
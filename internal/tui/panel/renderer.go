@@ -161,6 +161,10 @@ type RenderState struct {
 	// TotalAPICalls is the aggregated API call count across all instances.
 	// Used by the stats panel for API usage display.
 	TotalAPICalls int
+
+	// FileActivity records the files each instance has claimed or changed.
+	// Used by the files panel to show who is touching what.
+	FileActivity *FileActivity
 }
 
 // Validate checks that the RenderState has valid values for rendering.
@@ -1,6 +1,10 @@
 package tui
 
 import (
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/experiment"
 	"github.com/Iron-Ham/claudio/internal/logging"
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/bridgewire"
@@ -19,15 +23,36 @@ func registerPipelineFactory(coordinator *orchestrator.Coordinator, orch *orches
 				orch.SetInstanceStatus(instanceID, orchestrator.StatusFinishing)
 			},
 		})
+		experiments, err := experiment.FromConfig(config.Get().Experiments)
+		if err != nil && logger != nil {
+			logger.Warn("ignoring invalid prompt experiments", "error", err)
+		}
+		placer, err := bridgewire.PlacerFromConfig(config.Get().Ultraplan.Placement)
+		if err != nil {
+			return nil, err
+		}
 		return bridgewire.NewPipelineRunner(bridgewire.PipelineRunnerConfig{
-			Orch:        deps.Orch,
-			Session:     deps.Session,
-			Verifier:    deps.Verifier,
-			Plan:        deps.Plan,
-			Bus:         orch.EventBus(),
-			Logger:      logger,
-			Recorder:    recorder,
-			MaxParallel: deps.MaxParallel,
+			Orch:              deps.Orch,
+			Session:           deps.Session,
+			Verifier:          deps.Verifier,
+			Plan:              deps.Plan,
+			Bus:               orch.EventBus(),
+			Logger:            logger,
+			Recorder:          recorder,
+			MaxParallel:       deps.MaxParallel,
+			Experiments:       experiments,
+			Placer:            placer,
+			EnforceFileClaims: config.Get().Ultraplan.EnforceFileClaims,
+			PriorityAging:     time.Duration(config.Get().Ultraplan.PriorityAgingSeconds) * time.Second,
+			Preemption:        config.Get().Ultraplan.Preemption,
+			Scaling:           config.Get().Ultraplan.Scaling,
+			WorkStealing:      config.Get().Ultraplan.WorkStealing,
+			Repos:             config.Get().Ultraplan.Repos,
+			MessageGuard:      config.Get().Ultraplan.MessageGuard,
+			RetrySection:      deps.RetrySection,
+			ProgressSection:   deps.ProgressSection,
+			TaskModel:         deps.TaskModel,
+			Resume:            deps.Resume,
 		})
 	})
 }
@@ -2,11 +2,15 @@ package tui
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/Iron-Ham/claudio/internal/audit"
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	"github.com/Iron-Ham/claudio/internal/tui/view"
+	"github.com/Iron-Ham/claudio/internal/tui/view/planedit"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -29,7 +33,10 @@ func (m Model) buildPlanEditorViewState() *view.PlanEditorState {
 		ShowValidationPanel:    m.planEditor.showValidationPanel,
 		ValidationScrollOffset: m.planEditor.validationScrollOffset,
 		TasksInCycle:           m.planEditor.tasksInCycle,
+		Marked:                 m.planEditor.marked,
 		CanConfirm:             m.canConfirmPlan(),
+		CanRefine:              m.canRefinePlan(),
+		Draft:                  m.planDraftForEditor(),
 	}
 }
 
@@ -47,6 +54,7 @@ func (m Model) renderPlanEditorView(width int) string {
 		Width:                          width,
 		Height:                         m.height,
 		SelectedTaskValidationMessages: m.getValidationMessagesForSelectedTask(),
+		Diff:                           m.planDiffForEditor(plan),
 	})
 }
 
@@ -91,8 +99,11 @@ func (m Model) handlePlanEditorNavigationMode(msg tea.KeyMsg, plan *orchestrator
 		return true, m, nil
 
 	case "s":
-		// Save current edits to plan file
-		if err := m.savePlanToFile(plan); err != nil {
+		// Save current edits to plan file, refusing plans that fail validation
+		if err := m.validatePlanForSave(plan); err != nil {
+			m.errorMessage = fmt.Sprintf("Cannot save plan: %v", err)
+			m.planEditor.showValidationPanel = true
+		} else if err := m.savePlanToFile(plan); err != nil {
 			m.errorMessage = fmt.Sprintf("Failed to save plan: %v", err)
 		} else {
 			m.infoMessage = "Plan saved"
@@ -110,6 +121,7 @@ func (m Model) handlePlanEditorNavigationMode(msg tea.KeyMsg, plan *orchestrator
 			if err := m.startPlanExecution(); err != nil {
 				m.errorMessage = fmt.Sprintf("Failed to start execution: %v", err)
 			} else {
+				m.orchestrator.RecordOperatorAction(audit.ActionApprove, "", "", fmt.Sprintf("approved plan (%d tasks)", len(plan.Tasks)))
 				m.exitPlanEditor()
 				m.infoMessage = "Execution started"
 			}
@@ -209,6 +221,7 @@ func (m Model) handlePlanEditorNavigationMode(msg tea.KeyMsg, plan *orchestrator
 						if m.logger != nil {
 							m.logger.Info("user approved plan", "task_count", len(plan.Tasks))
 						}
+						m.orchestrator.RecordOperatorAction(audit.ActionApprove, "", "", fmt.Sprintf("approved plan (%d tasks)", len(plan.Tasks)))
 					}
 				}
 			}
@@ -271,6 +284,45 @@ func (m Model) handlePlanEditorNavigationMode(msg tea.KeyMsg, plan *orchestrator
 		}
 		return true, m, nil
 
+	case "R":
+		// Request changes from the planner (ultra-plan review only)
+		if !m.canRefinePlan() {
+			m.errorMessage = "Plan changes can only be requested while reviewing an ultra-plan draft"
+			return true, m, nil
+		}
+		m.planEditor.editingField = "feedback"
+		m.planEditor.editBuffer = ""
+		m.planEditor.editCursor = 0
+		return true, m, nil
+
+	case "S":
+		// Split task at markers typed into its description
+		m.startEditingField("split", plan)
+		return true, m, nil
+
+	case "m":
+		// Mark or unmark task for merging
+		m.toggleMergeMark(plan)
+		return true, m, nil
+
+	case "M":
+		// Merge marked tasks (and the selected one)
+		if err := m.mergeMarkedTasks(plan); err != nil {
+			m.errorMessage = fmt.Sprintf("Failed to merge tasks: %v", err)
+		} else {
+			m.updatePlanValidation()
+		}
+		return true, m, nil
+
+	case "[", "]":
+		// Move task to an earlier or later execution group
+		if err := m.moveTaskGroup(plan, key == "]"); err != nil {
+			m.errorMessage = fmt.Sprintf("Failed to move task: %v", err)
+		} else {
+			m.updatePlanValidation()
+		}
+		return true, m, nil
+
 	case "J":
 		// Move task down (swap with next)
 		if err := m.moveTaskDown(plan); err != nil {
@@ -300,6 +352,20 @@ func (m Model) handlePlanEditorEditMode(msg tea.KeyMsg, plan *orchestrator.PlanS
 		return true, m, nil
 
 	case "enter":
+		// Feedback goes to the planner instead of editing the plan
+		if m.planEditor.editingField == "feedback" {
+			m.submitPlanFeedback(plan)
+			return true, m, nil
+		}
+		// Splitting replaces the task rather than editing a field
+		if m.planEditor.editingField == "split" {
+			if err := m.splitSelectedTask(plan); err != nil {
+				m.errorMessage = fmt.Sprintf("Failed to split task: %v", err)
+			} else {
+				m.updatePlanValidation()
+			}
+			return true, m, nil
+		}
 		// Confirm edit and save
 		if err := m.confirmFieldEdit(plan); err != nil {
 			m.errorMessage = fmt.Sprintf("Failed to save: %v", err)
@@ -441,7 +507,7 @@ func (m *Model) startEditingField(field string, plan *orchestrator.PlanSpec) {
 	switch field {
 	case "title":
 		m.planEditor.editBuffer = task.Title
-	case "description":
+	case "description", "split":
 		m.planEditor.editBuffer = task.Description
 	case "files":
 		m.planEditor.editBuffer = strings.Join(task.Files, ", ")
@@ -502,8 +568,11 @@ func (m *Model) confirmFieldEdit(plan *orchestrator.PlanSpec) error {
 	}
 
 	// Log the edit if successful
-	if err == nil && m.logger != nil {
-		m.logger.Info("user edited plan", "changes_made", editedField)
+	if err == nil {
+		if m.logger != nil {
+			m.logger.Info("user edited plan", "changes_made", editedField)
+		}
+		m.orchestrator.RecordOperatorAction(audit.ActionTaskEdit, "", taskID, fmt.Sprintf("set %s to %q", editedField, value))
 	}
 
 	m.cancelFieldEdit()
@@ -530,12 +599,15 @@ func (m *Model) cycleTaskComplexity(plan *orchestrator.PlanSpec) {
 		nextComplexity = orchestrator.ComplexityLow
 	}
 
-	_ = orchestrator.UpdateTaskComplexity(plan, task.ID, nextComplexity)
+	if err := orchestrator.UpdateTaskComplexity(plan, task.ID, nextComplexity); err != nil {
+		return
+	}
 
 	// Log complexity change
 	if m.logger != nil {
 		m.logger.Info("user edited plan", "changes_made", "complexity")
 	}
+	m.orchestrator.RecordOperatorAction(audit.ActionTaskEdit, "", task.ID, "set complexity to "+string(nextComplexity))
 }
 
 // deleteSelectedTask removes the currently selected task
@@ -554,6 +626,7 @@ func (m *Model) deleteSelectedTask(plan *orchestrator.PlanSpec) error {
 	if m.logger != nil {
 		m.logger.Info("user edited plan", "changes_made", "task_deleted")
 	}
+	m.orchestrator.RecordOperatorAction(audit.ActionTaskEdit, "", taskID, "deleted task")
 
 	// Adjust selection if needed
 	if m.planEditor.selectedTaskIdx >= len(plan.Tasks) && len(plan.Tasks) > 0 {
@@ -600,6 +673,7 @@ func (m *Model) addNewTaskAfterCurrent(plan *orchestrator.PlanSpec) error {
 	if m.logger != nil {
 		m.logger.Info("user edited plan", "changes_made", "task_added")
 	}
+	m.orchestrator.RecordOperatorAction(audit.ActionTaskEdit, "", newID, "added task after "+afterTaskID)
 
 	// Move selection to new task
 	m.planEditor.selectedTaskIdx++
@@ -621,6 +695,7 @@ func (m *Model) moveTaskUp(plan *orchestrator.PlanSpec) error {
 	if err != nil {
 		return err
 	}
+	m.orchestrator.RecordOperatorAction(audit.ActionTaskEdit, "", taskID, "moved task up")
 
 	m.planEditor.selectedTaskIdx--
 	return nil
@@ -637,11 +712,132 @@ func (m *Model) moveTaskDown(plan *orchestrator.PlanSpec) error {
 	if err != nil {
 		return err
 	}
+	m.orchestrator.RecordOperatorAction(audit.ActionTaskEdit, "", taskID, "moved task down")
 
 	m.planEditor.selectedTaskIdx++
 	return nil
 }
 
+// splitSelectedTask splits the selected task at the split markers in the
+// edit buffer and exits edit mode. On error the buffer is kept so the
+// markers can be fixed.
+func (m *Model) splitSelectedTask(plan *orchestrator.PlanSpec) error {
+	if m.planEditor.selectedTaskIdx >= len(plan.Tasks) {
+		m.cancelFieldEdit()
+		return nil
+	}
+
+	taskID := plan.Tasks[m.planEditor.selectedTaskIdx].ID
+	ids, err := planedit.Split(plan, taskID, m.planEditor.editBuffer)
+	if err != nil {
+		return err
+	}
+
+	if m.logger != nil {
+		m.logger.Info("user edited plan", "changes_made", "task_split")
+	}
+	m.orchestrator.RecordOperatorAction(audit.ActionTaskEdit, "", taskID, "split task into "+strings.Join(ids, ", "))
+	m.cancelFieldEdit()
+	m.infoMessage = fmt.Sprintf("Split %s into %d tasks", taskID, len(ids))
+	return nil
+}
+
+// toggleMergeMark marks or unmarks the selected task for merging.
+func (m *Model) toggleMergeMark(plan *orchestrator.PlanSpec) {
+	if m.planEditor.selectedTaskIdx >= len(plan.Tasks) {
+		return
+	}
+
+	taskID := plan.Tasks[m.planEditor.selectedTaskIdx].ID
+	if m.planEditor.marked[taskID] {
+		delete(m.planEditor.marked, taskID)
+		return
+	}
+	if m.planEditor.marked == nil {
+		m.planEditor.marked = make(map[string]bool)
+	}
+	m.planEditor.marked[taskID] = true
+}
+
+// mergeMarkedTasks merges the marked tasks and the selected task into one,
+// selects it, and clears the marks.
+func (m *Model) mergeMarkedTasks(plan *orchestrator.PlanSpec) error {
+	taskIDs := slices.Collect(maps.Keys(m.planEditor.marked))
+	if m.planEditor.selectedTaskIdx < len(plan.Tasks) {
+		if selected := plan.Tasks[m.planEditor.selectedTaskIdx].ID; !m.planEditor.marked[selected] {
+			taskIDs = append(taskIDs, selected)
+		}
+	}
+	if len(taskIDs) < 2 {
+		return fmt.Errorf("mark tasks to merge with [m] first")
+	}
+
+	mergedID, err := planedit.Merge(plan, taskIDs)
+	if err != nil {
+		return err
+	}
+
+	if m.logger != nil {
+		m.logger.Info("user edited plan", "changes_made", "tasks_merged")
+	}
+	m.orchestrator.RecordOperatorAction(audit.ActionTaskEdit, "", mergedID, fmt.Sprintf("merged %d tasks", len(taskIDs)))
+	m.planEditor.marked = nil
+	for i := range plan.Tasks {
+		if plan.Tasks[i].ID == mergedID {
+			m.planEditor.selectedTaskIdx = i
+			break
+		}
+	}
+	m.planEditorEnsureVisible(plan)
+	m.infoMessage = fmt.Sprintf("Merged %d tasks into %s", len(taskIDs), mergedID)
+	return nil
+}
+
+// moveTaskGroup moves the selected task to the next execution group (later)
+// or to an earlier one by changing its dependencies.
+func (m *Model) moveTaskGroup(plan *orchestrator.PlanSpec, later bool) error {
+	if m.planEditor.selectedTaskIdx >= len(plan.Tasks) {
+		return fmt.Errorf("no task selected")
+	}
+
+	taskID := plan.Tasks[m.planEditor.selectedTaskIdx].ID
+	var changed []string
+	var err error
+	if later {
+		changed, err = planedit.MoveToLaterGroup(plan, taskID)
+	} else {
+		changed, err = planedit.MoveToEarlierGroup(plan, taskID)
+	}
+	if err != nil {
+		return err
+	}
+
+	group := orchestrator.GetExecutionGroupForTask(plan, taskID) + 1
+	var detail string
+	if later {
+		detail = fmt.Sprintf("moved to group %d (now depends on %s)", group, strings.Join(changed, ", "))
+	} else {
+		detail = fmt.Sprintf("moved to group %d (no longer depends on %s)", group, strings.Join(changed, ", "))
+	}
+
+	if m.logger != nil {
+		m.logger.Info("user edited plan", "changes_made", "execution_group")
+	}
+	m.orchestrator.RecordOperatorAction(audit.ActionTaskEdit, "", taskID, detail)
+	m.infoMessage = fmt.Sprintf("%s %s", taskID, detail)
+	return nil
+}
+
+// validatePlanForSave checks the plan with the ultraplan validator and
+// returns an error if it has validation errors.
+func (m *Model) validatePlanForSave(plan *orchestrator.PlanSpec) error {
+	result, err := planedit.Validate(plan)
+	if err != nil {
+		return err
+	}
+	return planedit.SaveError(result)
+}
+
 // planEditorMoveCursor moves the edit cursor by delta positions
 func (m *Model) planEditorMoveCursor(delta int) {
 	runes := []rune(m.planEditor.editBuffer)
@@ -745,6 +941,66 @@ func (m *Model) canStartExecution() bool {
 	return session.Phase == orchestrator.PhaseRefresh
 }
 
+// canRefinePlan returns true if the ultra-plan draft can be sent back to the
+// planner with feedback.
+func (m *Model) canRefinePlan() bool {
+	if m.planEditor != nil && m.planEditor.inlineMode {
+		return false
+	}
+	return m.canStartExecution()
+}
+
+// submitPlanFeedback sends the feedback in the edit buffer to the planner and
+// closes the editor until the revised draft arrives.
+func (m *Model) submitPlanFeedback(plan *orchestrator.PlanSpec) {
+	feedback := orchestrator.ParsePlanFeedback(m.planEditor.editBuffer, plan)
+	if len(feedback) == 0 {
+		m.cancelFieldEdit()
+		return
+	}
+	if err := m.ultraPlan.Coordinator.RefinePlan(feedback); err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to request plan changes: %v", err)
+		return
+	}
+
+	if m.logger != nil {
+		m.logger.Info("user requested plan changes", "feedback_count", len(feedback))
+	}
+	m.orchestrator.RecordOperatorAction(audit.ActionReject, "", "", "requested plan changes: "+m.planEditor.editBuffer)
+	m.exitPlanEditor()
+	m.infoMessage = fmt.Sprintf("Sent %d change request(s) to the planner. The revised draft opens here when it is ready.", len(feedback))
+}
+
+// planDiffForEditor returns the changes between the previous draft and the
+// current plan, or nil if the plan has not been revised.
+func (m Model) planDiffForEditor(plan *orchestrator.PlanSpec) *orchestrator.PlanDiff {
+	if m.planEditor == nil || m.planEditor.inlineMode || m.ultraPlan == nil || m.ultraPlan.Coordinator == nil {
+		return nil
+	}
+	session := m.ultraPlan.Coordinator.Session()
+	if session == nil {
+		return nil
+	}
+	round := session.PlanRefinement.LastRound()
+	if round == nil || session.PlanRefinement.Active {
+		return nil
+	}
+	diff := orchestrator.DiffPlans(round.Previous, plan)
+	return &diff
+}
+
+// planDraftForEditor returns the draft number of the ultra-plan being edited.
+func (m Model) planDraftForEditor() int {
+	if m.planEditor == nil || m.planEditor.inlineMode || m.ultraPlan == nil || m.ultraPlan.Coordinator == nil {
+		return 0
+	}
+	session := m.ultraPlan.Coordinator.Session()
+	if session == nil {
+		return 0
+	}
+	return session.PlanRefinement.Draft()
+}
+
 // startPlanExecution triggers execution of the plan
 func (m *Model) startPlanExecution() error {
 	// Inline plan mode
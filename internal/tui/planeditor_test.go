@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/tui/view"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -1868,3 +1869,187 @@ func TestInlinePlanSession_AwaitingObjective_Differentiation(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlePlanEditorKeypress_RequestChanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		phase     orchestrator.UltraPlanPhase
+		wantField string
+	}{
+		{name: "awaiting approval opens feedback input", phase: orchestrator.PhaseRefresh, wantField: "feedback"},
+		{name: "other phases are rejected", phase: orchestrator.PhaseExecuting, wantField: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &orchestrator.UltraPlanSession{Phase: tt.phase, Plan: createTestPlanForTUI()}
+			m := Model{
+				planEditor: createTestPlanEditorState(),
+				ultraPlan:  &view.UltraPlanState{Coordinator: orchestrator.NewCoordinatorForTesting(session)},
+			}
+
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}}
+			handled, model, _ := m.handlePlanEditorKeypress(msg)
+			if !handled {
+				t.Fatal("expected R to be handled")
+			}
+			got := model.(Model)
+			if got.planEditor.editingField != tt.wantField {
+				t.Errorf("editingField = %q, want %q", got.planEditor.editingField, tt.wantField)
+			}
+			if tt.wantField == "" && got.errorMessage == "" {
+				t.Error("expected an error message when changes can't be requested")
+			}
+		})
+	}
+}
+
+func TestPlanDiffForEditor(t *testing.T) {
+	previous := createTestPlanForTUI()
+	current := createTestPlanForTUI()
+	current.Tasks = current.Tasks[:3]
+
+	session := &orchestrator.UltraPlanSession{
+		Phase: orchestrator.PhaseRefresh,
+		Plan:  current,
+	}
+	m := Model{
+		planEditor: createTestPlanEditorState(),
+		ultraPlan:  &view.UltraPlanState{Coordinator: orchestrator.NewCoordinatorForTesting(session)},
+	}
+
+	if diff := m.planDiffForEditor(current); diff != nil {
+		t.Errorf("planDiffForEditor() before any feedback = %+v, want nil", diff)
+	}
+	if draft := m.planDraftForEditor(); draft != 1 {
+		t.Errorf("planDraftForEditor() = %d, want 1", draft)
+	}
+
+	session.PlanRefinement = &orchestrator.PlanRefinement{
+		Rounds: []orchestrator.PlanRefinementRound{{Previous: previous}},
+	}
+	diff := m.planDiffForEditor(current)
+	if diff == nil || len(diff.Removed) != 1 || diff.Removed[0].ID != "task-4" {
+		t.Errorf("planDiffForEditor() = %+v, want task-4 removed", diff)
+	}
+	if draft := m.planDraftForEditor(); draft != 2 {
+		t.Errorf("planDraftForEditor() = %d, want 2", draft)
+	}
+
+	// No diff while the planner is still revising
+	session.PlanRefinement.Active = true
+	if diff := m.planDiffForEditor(current); diff != nil {
+		t.Errorf("planDiffForEditor() during revision = %+v, want nil", diff)
+	}
+}
+
+func TestMergeMarkedTasks(t *testing.T) {
+	plan := createTestPlanForTUI()
+	m := Model{planEditor: createTestPlanEditorState()}
+
+	// Mark task-2, then merge it with the selected task-4.
+	m.planEditor.selectedTaskIdx = 1
+	m.toggleMergeMark(plan)
+	m.planEditor.selectedTaskIdx = 3
+	if err := m.mergeMarkedTasks(plan); err != nil {
+		t.Fatalf("mergeMarkedTasks() error = %v", err)
+	}
+
+	if len(plan.Tasks) != 3 {
+		t.Fatalf("expected 3 tasks after merge, got %d", len(plan.Tasks))
+	}
+	if plan.Tasks[1].ID != "task-2" || plan.Tasks[1].Title != "Core Features + Documentation" {
+		t.Errorf("expected merged task-2 at index 1, got %s %q", plan.Tasks[1].ID, plan.Tasks[1].Title)
+	}
+	if m.planEditor.selectedTaskIdx != 1 {
+		t.Errorf("expected selection on the merged task, got %d", m.planEditor.selectedTaskIdx)
+	}
+	if len(m.planEditor.marked) != 0 {
+		t.Errorf("expected marks cleared, got %v", m.planEditor.marked)
+	}
+}
+
+func TestMergeMarkedTasks_NeedsTwo(t *testing.T) {
+	plan := createTestPlanForTUI()
+	m := Model{planEditor: createTestPlanEditorState()}
+
+	m.toggleMergeMark(plan)
+	if err := m.mergeMarkedTasks(plan); err == nil {
+		t.Error("expected an error merging only the selected task")
+	}
+
+	// Toggling again unmarks.
+	m.toggleMergeMark(plan)
+	if m.planEditor.marked["task-1"] {
+		t.Error("expected task-1 to be unmarked")
+	}
+}
+
+func TestSplitSelectedTask(t *testing.T) {
+	plan := createTestPlanForTUI()
+	m := Model{planEditor: createTestPlanEditorState()}
+	m.planEditor.selectedTaskIdx = 2
+
+	m.startEditingField("split", plan)
+	if m.planEditor.editBuffer != "Write tests" {
+		t.Fatalf("expected split buffer to hold the description, got %q", m.planEditor.editBuffer)
+	}
+
+	// Without a marker the edit stays open so it can be fixed.
+	if err := m.splitSelectedTask(plan); err == nil {
+		t.Fatal("expected an error splitting without a marker")
+	}
+	if m.planEditor.editingField != "split" {
+		t.Error("expected split edit to stay open after an error")
+	}
+
+	m.planEditor.editBuffer = "Write unit tests || Write integration tests"
+	if err := m.splitSelectedTask(plan); err != nil {
+		t.Fatalf("splitSelectedTask() error = %v", err)
+	}
+	if len(plan.Tasks) != 5 || plan.Tasks[3].ID != "task-3-part2" {
+		t.Errorf("expected task-3-part2 after task-3, got %d tasks", len(plan.Tasks))
+	}
+	if m.planEditor.editingField != "" {
+		t.Error("expected edit mode to end after splitting")
+	}
+}
+
+func TestMoveTaskGroup(t *testing.T) {
+	plan := createTestPlanForTUI()
+	m := Model{planEditor: createTestPlanEditorState()}
+	m.planEditor.selectedTaskIdx = 3 // task-4, grouped with task-2
+
+	if err := m.moveTaskGroup(plan, true); err != nil {
+		t.Fatalf("moveTaskGroup(later) error = %v", err)
+	}
+	if group := orchestrator.GetExecutionGroupForTask(plan, "task-4"); group != 2 {
+		t.Errorf("expected task-4 in group 2, got %d", group)
+	}
+
+	if err := m.moveTaskGroup(plan, false); err != nil {
+		t.Fatalf("moveTaskGroup(earlier) error = %v", err)
+	}
+	if group := orchestrator.GetExecutionGroupForTask(plan, "task-4"); group != 1 {
+		t.Errorf("expected task-4 back in group 1, got %d", group)
+	}
+
+	m.planEditor.selectedTaskIdx = 0
+	if err := m.moveTaskGroup(plan, false); err == nil {
+		t.Error("expected an error moving a first-group task earlier")
+	}
+}
+
+func TestValidatePlanForSave(t *testing.T) {
+	plan := createTestPlanForTUI()
+	m := Model{planEditor: createTestPlanEditorState()}
+
+	if err := m.validatePlanForSave(plan); err != nil {
+		t.Errorf("expected valid plan to pass, got %v", err)
+	}
+
+	plan.Tasks[2].DependsOn = []string{"task-9"}
+	if err := m.validatePlanForSave(plan); err == nil {
+		t.Error("expected a dependency on a missing task to block saving")
+	}
+}
@@ -0,0 +1,168 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/instance/capture"
+	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
+	"github.com/Iron-Ham/claudio/internal/tui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// -----------------------------------------------------------------------------
+// Transcript Replay Mode
+// -----------------------------------------------------------------------------
+
+// replaySeekStep is how far H/L jump through a transcript.
+const replaySeekStep = 10 * time.Second
+
+// transcriptReplay is the state of replaying a recorded instance transcript.
+// Playback follows wall time from when it was (re)started, so it keeps the
+// recording's pacing regardless of how often ticks arrive.
+type transcriptReplay struct {
+	instanceID string
+	transcript *capture.Transcript
+	frame      int           // Index of the frame on screen
+	playing    bool          // Whether the replay advances on each tick
+	playFrom   time.Duration // Transcript offset playback started from
+	playStart  time.Time     // Wall time playback started
+}
+
+// position returns the offset of the frame on screen.
+func (r *transcriptReplay) position() time.Duration {
+	return r.transcript.Frames[r.frame].At
+}
+
+// seekFrame shows frame idx, clamped to the transcript. Seeking while
+// playing continues playback from the new frame.
+func (r *transcriptReplay) seekFrame(idx int, now time.Time) {
+	r.frame = max(0, min(idx, len(r.transcript.Frames)-1))
+	if r.playing {
+		r.playFrom, r.playStart = r.position(), now
+	}
+}
+
+// seekTime shows the frame on screen delta from the current position.
+func (r *transcriptReplay) seekTime(delta time.Duration, now time.Time) {
+	target := r.position() + delta
+	idx := r.transcript.FrameAt(target)
+	// FrameAt never moves past the frame at target; when seeking forward
+	// between frames, show the next one so the jump always makes progress.
+	if delta > 0 && idx == r.frame {
+		idx++
+	}
+	r.seekFrame(idx, now)
+}
+
+// togglePlay starts or pauses playback. Starting on the last frame
+// replays from the beginning.
+func (r *transcriptReplay) togglePlay(now time.Time) {
+	if r.playing {
+		r.playing = false
+		return
+	}
+	if r.frame == len(r.transcript.Frames)-1 {
+		r.frame = 0
+	}
+	r.playing = true
+	r.playFrom, r.playStart = r.position(), now
+}
+
+// advance moves playback to the frame due at now, pausing at the end.
+func (r *transcriptReplay) advance(now time.Time) {
+	if !r.playing {
+		return
+	}
+	r.frame = r.transcript.FrameAt(r.playFrom + now.Sub(r.playStart))
+	if r.frame == len(r.transcript.Frames)-1 {
+		r.playing = false
+	}
+}
+
+// handleTranscriptLoaded enters replay mode with a loaded transcript.
+func (m Model) handleTranscriptLoaded(msg tuimsg.TranscriptLoadedMsg) (tea.Model, tea.Cmd) {
+	// Clear "Loading transcript..." message since operation completed
+	m.infoMessage = ""
+
+	if msg.Err != nil {
+		if errors.Is(msg.Err, fs.ErrNotExist) {
+			m.infoMessage = "No transcript recorded for this instance (enable instance.record_transcripts)"
+			return m, nil
+		}
+		if m.logger != nil {
+			m.logger.Error("failed to load transcript", "instance_id", msg.InstanceID, "error", msg.Err)
+		}
+		m.errorMessage = fmt.Sprintf("Failed to load transcript: %v", msg.Err)
+		return m, nil
+	}
+
+	if msg.Transcript == nil || len(msg.Transcript.Frames) == 0 {
+		m.infoMessage = "Transcript has no frames yet"
+		return m, nil
+	}
+
+	m.replay = &transcriptReplay{
+		instanceID: msg.InstanceID,
+		transcript: msg.Transcript,
+	}
+	return m, nil
+}
+
+// handleReplayInput handles keyboard input in transcript replay mode.
+func (m Model) handleReplayInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	r := m.replay
+	now := time.Now()
+
+	switch msg.String() {
+	case "esc", "q", "ctrl+c":
+		m.replay = nil
+
+	case " ", "p":
+		r.togglePlay(now)
+
+	case "l", "right":
+		r.seekFrame(r.frame+1, now)
+
+	case "h", "left":
+		r.seekFrame(r.frame-1, now)
+
+	case "L", "shift+right":
+		r.seekTime(replaySeekStep, now)
+
+	case "H", "shift+left":
+		r.seekTime(-replaySeekStep, now)
+
+	case "g", "0":
+		r.seekFrame(0, now)
+
+	case "G", "$":
+		r.seekFrame(len(r.transcript.Frames)-1, now)
+	}
+
+	return m, nil
+}
+
+// renderReplay renders the frame on screen in replay mode.
+func (m Model) renderReplay(width int) string {
+	r := m.replay
+	name := r.instanceID
+	if m.session != nil {
+		if inst := m.session.GetInstance(r.instanceID); inst != nil {
+			name = inst.EffectiveName()
+		}
+	}
+
+	return view.RenderReplay(view.ReplayState{
+		InstanceName: name,
+		Screen:       r.transcript.Frames[r.frame].Screen,
+		Frame:        r.frame,
+		Frames:       len(r.transcript.Frames),
+		Position:     r.position(),
+		Duration:     r.transcript.Duration(),
+		RecordedAt:   r.transcript.Start(),
+		Playing:      r.playing,
+	}, width, m.getOutputMaxLines())
+}
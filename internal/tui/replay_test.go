@@ -0,0 +1,205 @@
+package tui
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/instance/capture"
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// testTranscript returns a transcript with one frame every second.
+func testTranscript(frames int) *capture.Transcript {
+	t := &capture.Transcript{Header: capture.TranscriptHeader{Version: 2, Width: 80, Height: 24}}
+	for i := range frames {
+		t.Frames = append(t.Frames, capture.Frame{
+			At:     time.Duration(i) * time.Second,
+			Screen: fmt.Sprintf("frame %d", i),
+		})
+	}
+	return t
+}
+
+func newReplayTestModel(frames int) Model {
+	m := NewModel(nil, &orchestrator.Session{}, nil)
+	m.height = 40
+	m.width = 120
+	m.replay = &transcriptReplay{instanceID: "inst-1", transcript: testTranscript(frames)}
+	return m
+}
+
+func replayKey(m Model, key tea.KeyMsg) Model {
+	result, _ := m.handleReplayInput(key)
+	return result.(Model)
+}
+
+func runeKey(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestTranscriptReplay_Seeking(t *testing.T) {
+	m := newReplayTestModel(30)
+
+	m = replayKey(m, runeKey("l"))
+	m = replayKey(m, tea.KeyMsg{Type: tea.KeyRight})
+	if m.replay.frame != 2 {
+		t.Errorf("after l, right: frame = %d, want 2", m.replay.frame)
+	}
+
+	m = replayKey(m, runeKey("L"))
+	if m.replay.frame != 12 {
+		t.Errorf("after L: frame = %d, want 12", m.replay.frame)
+	}
+
+	m = replayKey(m, runeKey("H"))
+	m = replayKey(m, runeKey("H"))
+	if m.replay.frame != 0 {
+		t.Errorf("after H past the start: frame = %d, want 0", m.replay.frame)
+	}
+
+	m = replayKey(m, runeKey("G"))
+	if m.replay.frame != 29 {
+		t.Errorf("after G: frame = %d, want 29", m.replay.frame)
+	}
+
+	m = replayKey(m, runeKey("l"))
+	if m.replay.frame != 29 {
+		t.Errorf("after l on the last frame: frame = %d, want 29", m.replay.frame)
+	}
+
+	m = replayKey(m, runeKey("g"))
+	if m.replay.frame != 0 {
+		t.Errorf("after g: frame = %d, want 0", m.replay.frame)
+	}
+}
+
+func TestTranscriptReplay_SeekTimeSkipsGaps(t *testing.T) {
+	r := &transcriptReplay{transcript: &capture.Transcript{Frames: []capture.Frame{
+		{At: 0}, {At: time.Minute}, {At: 2 * time.Minute},
+	}}}
+
+	// The next frame is past the seek step; L still moves to it.
+	r.seekTime(replaySeekStep, time.Now())
+	if r.frame != 1 {
+		t.Errorf("frame = %d, want 1", r.frame)
+	}
+}
+
+func TestTranscriptReplay_Playback(t *testing.T) {
+	r := &transcriptReplay{transcript: testTranscript(10)}
+	start := time.Now()
+
+	r.togglePlay(start)
+	r.advance(start.Add(3500 * time.Millisecond))
+	if r.frame != 3 || !r.playing {
+		t.Errorf("after 3.5s: frame=%d playing=%v, want 3 and true", r.frame, r.playing)
+	}
+
+	r.togglePlay(start.Add(4 * time.Second))
+	r.advance(start.Add(time.Minute))
+	if r.frame != 3 {
+		t.Errorf("paused replay advanced to frame %d", r.frame)
+	}
+
+	// Resuming continues from the frame on screen, not from where playback began.
+	resume := start.Add(2 * time.Minute)
+	r.togglePlay(resume)
+	r.advance(resume.Add(2 * time.Second))
+	if r.frame != 5 {
+		t.Errorf("after resuming for 2s: frame = %d, want 5", r.frame)
+	}
+
+	r.advance(resume.Add(time.Hour))
+	if r.frame != 9 || r.playing {
+		t.Errorf("at the end: frame=%d playing=%v, want 9 and false", r.frame, r.playing)
+	}
+
+	// Playing from the last frame starts over.
+	r.togglePlay(resume.Add(time.Hour))
+	if r.frame != 0 || !r.playing {
+		t.Errorf("replay from end: frame=%d playing=%v, want 0 and true", r.frame, r.playing)
+	}
+}
+
+func TestHandleReplayInput_EscapeExits(t *testing.T) {
+	m := newReplayTestModel(3)
+
+	m = replayKey(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.replay != nil {
+		t.Error("replay should be cleared on Esc")
+	}
+}
+
+func TestHandleKeypress_RoutesToReplayMode(t *testing.T) {
+	m := newReplayTestModel(3)
+
+	// ':' would enter command mode in normal mode; in replay mode it is ignored
+	result, _ := m.handleKeypress(runeKey(":"))
+	updated := result.(Model)
+	if updated.commandMode {
+		t.Error("keys should be handled by replay mode, not normal mode")
+	}
+	if updated.replay == nil {
+		t.Error("replay should still be active")
+	}
+}
+
+func TestHandleTranscriptLoaded(t *testing.T) {
+	t.Run("enters replay mode", func(t *testing.T) {
+		m := newReplayTestModel(1)
+		m.replay = nil
+		m.infoMessage = "Loading transcript..."
+
+		result, _ := m.handleTranscriptLoaded(tuimsg.TranscriptLoadedMsg{InstanceID: "inst-1", Transcript: testTranscript(4)})
+		m = result.(Model)
+		if m.replay == nil || m.replay.instanceID != "inst-1" || m.replay.frame != 0 {
+			t.Fatalf("replay = %+v, want inst-1 at frame 0", m.replay)
+		}
+		if m.infoMessage != "" {
+			t.Errorf("infoMessage = %q, want it cleared", m.infoMessage)
+		}
+	})
+
+	t.Run("missing transcript", func(t *testing.T) {
+		m := newReplayTestModel(1)
+		m.replay = nil
+
+		err := fmt.Errorf("capture: open transcript: %w", fs.ErrNotExist)
+		result, _ := m.handleTranscriptLoaded(tuimsg.TranscriptLoadedMsg{InstanceID: "inst-1", Err: err})
+		m = result.(Model)
+		if m.replay != nil {
+			t.Error("replay should not start without a transcript")
+		}
+		if !strings.Contains(m.infoMessage, "instance.record_transcripts") {
+			t.Errorf("infoMessage = %q, want a hint to enable recording", m.infoMessage)
+		}
+	})
+
+	t.Run("empty transcript", func(t *testing.T) {
+		m := newReplayTestModel(1)
+		m.replay = nil
+
+		result, _ := m.handleTranscriptLoaded(tuimsg.TranscriptLoadedMsg{InstanceID: "inst-1", Transcript: testTranscript(0)})
+		if result.(Model).replay != nil {
+			t.Error("replay should not start without frames")
+		}
+	})
+}
+
+func TestRenderReplay(t *testing.T) {
+	m := newReplayTestModel(5)
+	m.replay.frame = 2
+
+	out := m.renderReplay(100)
+	if !strings.Contains(out, "frame 2") {
+		t.Errorf("render should show the frame on screen, got:\n%s", out)
+	}
+	if !strings.Contains(out, "frame 3/5") {
+		t.Errorf("render should show the frame position, got:\n%s", out)
+	}
+}
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// resizeDebounce is how long the output area must keep the same size before
+// instances are resized to it. Dragging a terminal edge produces a burst of
+// WindowSizeMsgs; resizing every tmux session on each one makes Claude redraw
+// repeatedly for sizes that are gone a moment later.
+const resizeDebounce = 150 * time.Millisecond
+
+// paneSize is the size of an instance's tmux pane in cells.
+type paneSize struct {
+	width, height int
+}
+
+// outputAreaSize returns the size of the text area inside the output box the
+// instance view renders, so instances wrap and scroll as the viewer shows
+// them.
+//
+// The height comes from the stable layout: the terminal, the log pane, and
+// the active instance's header. Footer lines that come and go, such as info
+// and error messages or verbose command help, are left out, so showing a
+// message for a few seconds doesn't resize every instance twice. While one
+// is shown the viewer scrolls the last lines of the pane instead.
+func (m Model) outputAreaSize() paneSize {
+	cfg := config.Get()
+	contentWidth, _ := CalculateContentDimensionsWithSidebarWidth(m.width, m.height, cfg.TUI.SidebarWidth)
+	height := max(m.mainAreaHeight(m.logPaneHeight())-m.calculateInstanceOverhead(), 5)
+	return paneSize{width: contentWidth, height: height}
+}
+
+// syncInstanceSize resizes instances to the output area once its size has
+// been stable for resizeDebounce. It is called on every tick, so changes to
+// the output area that don't come from the terminal, such as the log pane or
+// the sidebar width, are picked up as well. It returns nil when nothing
+// needs resizing.
+func (m *Model) syncInstanceSize(now time.Time) tea.Cmd {
+	if !m.ready || m.orchestrator == nil {
+		return nil
+	}
+	want := m.outputAreaSize()
+	if want.width <= 0 || want.height <= 0 || want == m.paneSize {
+		return nil
+	}
+	if want != m.pendingPaneSize {
+		m.pendingPaneSize = want
+		m.pendingPaneSince = now
+		return nil
+	}
+	if now.Sub(m.pendingPaneSince) < resizeDebounce {
+		return nil
+	}
+	m.paneSize = want
+	return tuimsg.ResizeInstances(m.orchestrator, want.width, want.height)
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+)
+
+func TestSyncInstanceSize_Debounces(t *testing.T) {
+	m := Model{
+		orchestrator: &orchestrator.Orchestrator{},
+		ready:        true,
+		width:        120,
+		height:       40,
+	}
+	start := time.Now()
+
+	if cmd := m.syncInstanceSize(start); cmd != nil {
+		t.Fatal("first sight of a new size should wait out the debounce")
+	}
+	if cmd := m.syncInstanceSize(start.Add(resizeDebounce / 2)); cmd != nil {
+		t.Fatal("size should not be applied before the debounce elapses")
+	}
+
+	// The terminal is resized again mid-debounce: the wait restarts
+	m.width = 140
+	if cmd := m.syncInstanceSize(start.Add(resizeDebounce)); cmd != nil {
+		t.Fatal("a size change should restart the debounce")
+	}
+	if cmd := m.syncInstanceSize(start.Add(2 * resizeDebounce)); cmd == nil {
+		t.Fatal("stable size should be applied after the debounce")
+	}
+	if want := m.outputAreaSize(); m.paneSize != want {
+		t.Errorf("paneSize = %+v, want %+v", m.paneSize, want)
+	}
+
+	if cmd := m.syncInstanceSize(start.Add(3 * resizeDebounce)); cmd != nil {
+		t.Error("applied size should not be resent")
+	}
+}
+
+func TestOutputAreaSize_MatchesInstanceView(t *testing.T) {
+	m := Model{width: 120, height: 40}
+
+	size := m.outputAreaSize()
+	wantWidth, _ := CalculateContentDimensions(120, 40)
+	if size.width != wantWidth {
+		t.Errorf("width = %d, want %d", size.width, wantWidth)
+	}
+	if size.height != m.getOutputMaxLines() {
+		t.Errorf("height = %d, want getOutputMaxLines() = %d", size.height, m.getOutputMaxLines())
+	}
+}
+
+func TestOutputAreaSize_IgnoresFooterMessages(t *testing.T) {
+	m := Model{width: 120, height: 40}
+	want := m.outputAreaSize()
+
+	m.infoMessage = "Paused instance inst-1"
+	if got := m.outputAreaSize(); got != want {
+		t.Errorf("outputAreaSize() with an info message = %+v, want %+v", got, want)
+	}
+	m.infoMessage = ""
+	m.errorMessage = "Failed to resume"
+	if got := m.outputAreaSize(); got != want {
+		t.Errorf("outputAreaSize() with an error message = %+v, want %+v", got, want)
+	}
+}
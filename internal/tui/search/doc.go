@@ -0,0 +1,18 @@
+// Package search finds the lines matching a regular expression across the
+// output of every instance in a session.
+//
+// The TUI's :grep command searches each instance's captured output and,
+// optionally, its recorded transcript, which keeps lines that have since
+// scrolled out of the capture buffer. Each [Hit] records where its line
+// is, so the TUI can jump to the instance and scroll to the line, or
+// replay the transcript from the frame the line first appeared in.
+//
+// Matching ignores ANSI styling. A transcript records whole screens, so a
+// line usually appears in many frames; it is reported once, at the first
+// frame showing it.
+//
+// # Usage
+//
+//	hits := search.Lines(inst.ID, lines, re, search.MaxHits)
+//	hits = append(hits, search.Transcript(inst.ID, transcript, re, search.MaxHits-len(hits))...)
+package search
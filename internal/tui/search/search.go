@@ -0,0 +1,88 @@
+package search
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/instance/capture"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// MaxHits caps the hits of one search, so a pattern matching nearly every
+// line stays quick to search and render.
+const MaxHits = 1000
+
+// Hit is a line matching a search.
+type Hit struct {
+	InstanceID string
+
+	// Line is the index of the line in the instance's output, or in the
+	// screen of Frame for a transcript hit
+	Line int
+
+	// Text is the line without ANSI styling; Match is the byte range of the
+	// first match in it
+	Text  string
+	Match [2]int
+
+	// Transcript is set for a hit in the instance's recorded transcript.
+	// Frame is the first frame showing the line, recorded At its offset.
+	Transcript bool
+	Frame      int
+	At         time.Duration
+}
+
+// Lines returns up to limit hits among an instance's output lines, in
+// order.
+func Lines(instanceID string, lines []string, re *regexp.Regexp, limit int) []Hit {
+	var hits []Hit
+	for i, line := range lines {
+		if len(hits) >= limit {
+			break
+		}
+		if hit, ok := match(re, line); ok {
+			hit.InstanceID = instanceID
+			hit.Line = i
+			hits = append(hits, hit)
+		}
+	}
+	return hits
+}
+
+// Transcript returns up to limit hits among the screens of an instance's
+// recorded transcript, in the order they first appeared. A line shown in
+// several frames is reported once, at its first frame.
+func Transcript(instanceID string, t *capture.Transcript, re *regexp.Regexp, limit int) []Hit {
+	var hits []Hit
+	seen := make(map[string]bool)
+	for f, frame := range t.Frames {
+		for i, line := range strings.Split(frame.Screen, "\n") {
+			if len(hits) >= limit {
+				return hits
+			}
+			hit, ok := match(re, line)
+			if !ok || seen[hit.Text] {
+				continue
+			}
+			seen[hit.Text] = true
+			hit.InstanceID = instanceID
+			hit.Line = i
+			hit.Transcript = true
+			hit.Frame = f
+			hit.At = frame.At
+			hits = append(hits, hit)
+		}
+	}
+	return hits
+}
+
+// match reports whether line, without ANSI styling, matches re.
+func match(re *regexp.Regexp, line string) (Hit, bool) {
+	text := strings.TrimRight(ansi.Strip(line), " \r")
+	loc := re.FindStringIndex(text)
+	if loc == nil {
+		return Hit{}, false
+	}
+	return Hit{Text: text, Match: [2]int{loc[0], loc[1]}}, true
+}
@@ -0,0 +1,54 @@
+package search
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/instance/capture"
+)
+
+func TestLines(t *testing.T) {
+	lines := []string{
+		"building",
+		"\x1b[31merror: missing import\x1b[0m",
+		"ok",
+		"error: timeout   ",
+	}
+	hits := Lines("a", lines, regexp.MustCompile(`error: (\w+)`), MaxHits)
+	if len(hits) != 2 {
+		t.Fatalf("got %d hits, want 2: %+v", len(hits), hits)
+	}
+	if h := hits[0]; h.InstanceID != "a" || h.Line != 1 || h.Text != "error: missing import" || h.Match != [2]int{0, 14} {
+		t.Errorf("first hit = %+v, want line 1 without ANSI styling", h)
+	}
+	if h := hits[1]; h.Line != 3 || h.Text != "error: timeout" || h.Transcript {
+		t.Errorf("second hit = %+v, want line 3 with trailing spaces trimmed", h)
+	}
+
+	if hits := Lines("a", lines, regexp.MustCompile(`.`), 2); len(hits) != 2 {
+		t.Errorf("got %d hits with a limit of 2", len(hits))
+	}
+}
+
+func TestTranscript(t *testing.T) {
+	tr := &capture.Transcript{Frames: []capture.Frame{
+		{At: 0, Screen: "$ make\nbuilding"},
+		{At: time.Second, Screen: "$ make\nbuilding\nFAIL: TestA"},
+		{At: 2 * time.Second, Screen: "building\nFAIL: TestA\nFAIL: TestB"},
+	}}
+	hits := Transcript("a", tr, regexp.MustCompile(`FAIL`), MaxHits)
+	if len(hits) != 2 {
+		t.Fatalf("got %d hits, want each failing line once: %+v", len(hits), hits)
+	}
+	if h := hits[0]; !h.Transcript || h.Frame != 1 || h.At != time.Second || h.Line != 2 || h.Text != "FAIL: TestA" {
+		t.Errorf("first hit = %+v, want TestA at frame 1", h)
+	}
+	if h := hits[1]; h.Frame != 2 || h.Text != "FAIL: TestB" {
+		t.Errorf("second hit = %+v, want TestB at frame 2", h)
+	}
+
+	if hits := Transcript("a", tr, regexp.MustCompile(`FAIL`), 1); len(hits) != 1 {
+		t.Errorf("got %d hits with a limit of 1", len(hits))
+	}
+}
@@ -0,0 +1,159 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/tui/keymap"
+	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
+	"github.com/Iron-Ham/claudio/internal/tui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// -----------------------------------------------------------------------------
+// Output Selection Mode
+// -----------------------------------------------------------------------------
+
+// outputSelection is the state of visual-line selection over an instance's
+// output. The output lines are snapshotted when selection starts so the
+// selected range does not shift while the instance keeps producing output.
+type outputSelection struct {
+	instanceID string
+	lines      []string // Filtered output lines at the time selection started
+	anchor     int      // Line where the selection started
+	cursor     int      // Line the cursor is on; the selection spans anchor..cursor
+	scroll     int      // Scroll offset of the frozen output view
+	dragging   bool     // Whether the mouse button is held, moving the cursor
+}
+
+// bounds returns the first and last selected line indices (inclusive).
+func (s *outputSelection) bounds() (start, end int) {
+	return min(s.anchor, s.cursor), max(s.anchor, s.cursor)
+}
+
+// count returns the number of selected lines.
+func (s *outputSelection) count() int {
+	start, end := s.bounds()
+	return end - start + 1
+}
+
+// text returns the selected lines joined with newlines.
+func (s *outputSelection) text() string {
+	start, end := s.bounds()
+	return strings.Join(s.lines[start:end+1], "\n")
+}
+
+// moveCursor moves the cursor by delta lines, clamped to the output, and
+// scrolls the frozen view so the cursor stays visible.
+func (s *outputSelection) moveCursor(delta, visibleLines int) {
+	s.cursor = max(0, min(s.cursor+delta, len(s.lines)-1))
+	if s.cursor < s.scroll {
+		s.scroll = s.cursor
+	}
+	if visibleLines > 0 && s.cursor >= s.scroll+visibleLines {
+		s.scroll = s.cursor - visibleLines + 1
+	}
+}
+
+// renderSelection returns the highlight range for the view.
+func (s *outputSelection) renderSelection() *view.LineSelection {
+	start, end := s.bounds()
+	return &view.LineSelection{Start: start, End: end, Cursor: s.cursor}
+}
+
+// handleEnterSelectMode starts selecting output lines of the active instance.
+// The cursor starts on the last visible line, which is usually the output
+// the user wants to copy.
+func (m Model) handleEnterSelectMode() (tea.Model, tea.Cmd) {
+	inst := m.activeInstance()
+	if inst == nil {
+		return m, nil
+	}
+
+	lines := m.outputManager.GetFilteredLines(inst.ID)
+	if len(lines) == 0 {
+		m.infoMessage = "No output to select"
+		return m, nil
+	}
+
+	maxLines := m.getOutputMaxLines()
+	scroll := min(m.outputManager.GetScrollOffset(inst.ID), max(len(lines)-maxLines, 0))
+	cursor := min(scroll+maxLines, len(lines)) - 1
+
+	m.selection = &outputSelection{
+		instanceID: inst.ID,
+		lines:      lines,
+		anchor:     cursor,
+		cursor:     cursor,
+		scroll:     scroll,
+	}
+	return m, nil
+}
+
+// handleSelectInput handles keyboard input in output selection mode.
+func (m Model) handleSelectInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	sel := m.selection
+	visible := m.getOutputMaxLines()
+
+	switch m.resolveKey(keymap.Select, msg) {
+	case keymap.Close:
+		m.selection = nil
+		return m, nil
+
+	case keymap.Copy:
+		text, count := sel.text(), sel.count()
+		m.selection = nil
+		m.infoMessage = "Copying..."
+		return m, tuimsg.CopyToClipboard(text, count)
+
+	case keymap.SwapEnds:
+		sel.anchor, sel.cursor = sel.cursor, sel.anchor
+		sel.moveCursor(0, visible)
+
+	case keymap.RestartSelection:
+		// Restart the selection at the cursor
+		sel.anchor = sel.cursor
+
+	case keymap.ScrollDown:
+		sel.moveCursor(1, visible)
+
+	case keymap.ScrollUp:
+		sel.moveCursor(-1, visible)
+
+	case keymap.HalfPageDown:
+		sel.moveCursor(visible/2, visible)
+
+	case keymap.HalfPageUp:
+		sel.moveCursor(-visible/2, visible)
+
+	case keymap.PageDown:
+		sel.moveCursor(visible, visible)
+
+	case keymap.PageUp:
+		sel.moveCursor(-visible, visible)
+
+	case keymap.Top:
+		sel.moveCursor(-len(sel.lines), visible)
+
+	case keymap.Bottom:
+		sel.moveCursor(len(sel.lines), visible)
+	}
+
+	return m, nil
+}
+
+// handleClipboardCopied reports the result of copying selected output.
+func (m Model) handleClipboardCopied(msg tuimsg.ClipboardCopiedMsg) (tea.Model, tea.Cmd) {
+	m.infoMessage = ""
+
+	if msg.Err != nil {
+		if m.logger != nil {
+			m.logger.Warn("failed to copy output to clipboard", "error", msg.Err)
+		}
+		m.errorMessage = fmt.Sprintf("Copy failed: %v", msg.Err)
+		return m, nil
+	}
+
+	m.infoMessage = fmt.Sprintf("Copied %d line(s) to clipboard (%s)", msg.Lines, msg.Method)
+	return m, nil
+}
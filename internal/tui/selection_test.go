@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
+	"github.com/Iron-Ham/claudio/internal/tui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newSelectionTestModel(sel *outputSelection) Model {
+	m := NewModel(nil, &orchestrator.Session{}, nil)
+	m.height = 40
+	m.selection = sel
+	return m
+}
+
+func numberedLines(n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = string(rune('a' + i%26))
+	}
+	return lines
+}
+
+func TestOutputSelection_BoundsAndText(t *testing.T) {
+	sel := &outputSelection{lines: []string{"one", "two", "three", "four"}, anchor: 2, cursor: 1}
+
+	start, end := sel.bounds()
+	if start != 1 || end != 2 {
+		t.Errorf("bounds() = (%d, %d), want (1, 2)", start, end)
+	}
+	if sel.count() != 2 {
+		t.Errorf("count() = %d, want 2", sel.count())
+	}
+	if got := sel.text(); got != "two\nthree" {
+		t.Errorf("text() = %q, want %q", got, "two\nthree")
+	}
+}
+
+func TestOutputSelection_MoveCursorKeepsCursorVisible(t *testing.T) {
+	sel := &outputSelection{lines: numberedLines(50), anchor: 10, cursor: 10, scroll: 5}
+
+	sel.moveCursor(20, 10)
+	if sel.cursor != 30 || sel.scroll != 21 {
+		t.Errorf("after moving down: cursor=%d scroll=%d, want 30 and 21", sel.cursor, sel.scroll)
+	}
+
+	sel.moveCursor(-100, 10)
+	if sel.cursor != 0 || sel.scroll != 0 {
+		t.Errorf("after moving past top: cursor=%d scroll=%d, want 0 and 0", sel.cursor, sel.scroll)
+	}
+
+	sel.moveCursor(100, 10)
+	if sel.cursor != 49 {
+		t.Errorf("after moving past bottom: cursor=%d, want 49", sel.cursor)
+	}
+}
+
+func TestHandleSelectInput(t *testing.T) {
+	t.Run("extend and swap ends", func(t *testing.T) {
+		m := newSelectionTestModel(&outputSelection{lines: numberedLines(20), anchor: 5, cursor: 5})
+
+		result, _ := m.handleSelectInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+		result, _ = result.(Model).handleSelectInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+		m = result.(Model)
+		if m.selection.count() != 3 {
+			t.Fatalf("count() = %d, want 3", m.selection.count())
+		}
+
+		result, _ = m.handleSelectInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+		m = result.(Model)
+		if m.selection.cursor != 5 || m.selection.anchor != 7 {
+			t.Errorf("after o: anchor=%d cursor=%d, want 7 and 5", m.selection.anchor, m.selection.cursor)
+		}
+	})
+
+	t.Run("escape cancels", func(t *testing.T) {
+		m := newSelectionTestModel(&outputSelection{lines: numberedLines(5), anchor: 1, cursor: 3})
+
+		result, cmd := m.handleSelectInput(tea.KeyMsg{Type: tea.KeyEsc})
+		if result.(Model).selection != nil {
+			t.Error("selection should be cleared on Esc")
+		}
+		if cmd != nil {
+			t.Error("Esc should not copy")
+		}
+	})
+
+	t.Run("yank exits and copies", func(t *testing.T) {
+		m := newSelectionTestModel(&outputSelection{lines: numberedLines(5), anchor: 1, cursor: 3})
+
+		result, cmd := m.handleSelectInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+		if result.(Model).selection != nil {
+			t.Error("selection should be cleared after copying")
+		}
+		if cmd == nil {
+			t.Error("y should return a clipboard command")
+		}
+	})
+}
+
+func TestHandleKeypress_RoutesToSelectMode(t *testing.T) {
+	m := newSelectionTestModel(&outputSelection{lines: numberedLines(5), anchor: 2, cursor: 2})
+
+	// ':' would enter command mode in normal mode; in select mode it is ignored
+	result, _ := m.handleKeypress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(":")})
+	updated := result.(Model)
+	if updated.commandMode {
+		t.Error("keys should be handled by select mode, not normal mode")
+	}
+	if updated.selection == nil {
+		t.Error("selection should still be active")
+	}
+}
+
+func TestHandleKeypress_SelectModeInUltraPlan(t *testing.T) {
+	session := orchestrator.NewUltraPlanSession("objective", orchestrator.DefaultUltraPlanConfig())
+	session.Phase = orchestrator.PhaseExecuting
+	session.Plan = &orchestrator.PlanSpec{
+		Tasks:          []orchestrator.PlannedTask{{ID: "task-1", Title: "Task"}},
+		ExecutionOrder: [][]string{{"task-1"}},
+	}
+	m := newDashboardTestModel(1)
+	m.ultraPlan = &view.UltraPlanState{Coordinator: orchestrator.NewCoordinatorForTesting(session)}
+	m.outputManager.SetOutput("a", "one\ntwo\nthree\n")
+
+	result, _ := m.handleKeypress(runeKey("v"))
+	m = result.(Model)
+	if m.selection == nil {
+		t.Fatal("v in an ultraplan session should enter select mode")
+	}
+	if m.ultraPlan.ShowPlanView {
+		t.Error("v should not toggle the plan view")
+	}
+
+	m.selection = nil
+	result, _ = m.handleKeypress(runeKey("P"))
+	if m = result.(Model); !m.ultraPlan.ShowPlanView {
+		t.Error("P should toggle the plan view")
+	}
+}
+
+func TestHandleClipboardCopied(t *testing.T) {
+	m := newSelectionTestModel(nil)
+
+	result, _ := m.handleClipboardCopied(tuimsg.ClipboardCopiedMsg{Lines: 3, Method: "OSC 52"})
+	if got := result.(Model).infoMessage; got != "Copied 3 line(s) to clipboard (OSC 52)" {
+		t.Errorf("infoMessage = %q", got)
+	}
+
+	result, _ = m.handleClipboardCopied(tuimsg.ClipboardCopiedMsg{Err: errors.New("no clipboard")})
+	if got := result.(Model).errorMessage; got != "Copy failed: no clipboard" {
+		t.Errorf("errorMessage = %q", got)
+	}
+}
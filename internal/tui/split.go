@@ -0,0 +1,267 @@
+package tui
+
+import (
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/tui/keymap"
+	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/Iron-Ham/claudio/internal/tui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// -----------------------------------------------------------------------------
+// Split Comparison View
+// -----------------------------------------------------------------------------
+
+// splitView is the state of comparing two instances side by side. Each pane
+// scrolls on its own; the search query is shared, and n/N move both panes
+// to their next match at once.
+type splitView struct {
+	ids    [2]string // Left and right instance IDs
+	offset [2]int    // Scroll offset of each pane
+	follow [2]bool   // Whether each pane stays at the bottom as output arrives
+	match  [2]int    // Line of each pane's current search match, or -1
+	focus  int       // Pane that receives scroll keys
+
+	query     string
+	searching bool   // Whether the query is being typed
+	prevQuery string // Query restored when typing is cancelled
+}
+
+// openSplit compares the active instance with otherID side by side. The
+// capture of both is resumed, as background instances are normally paused.
+func (m *Model) openSplit(otherID string) {
+	active := m.activeInstance()
+	if active == nil {
+		return
+	}
+	m.split = &splitView{
+		ids:    [2]string{active.ID, otherID},
+		follow: [2]bool{true, true},
+		match:  [2]int{-1, -1},
+	}
+	if m.orchestrator == nil {
+		return
+	}
+	for _, id := range m.split.ids {
+		if mgr := m.orchestrator.GetInstanceManager(id); mgr != nil {
+			_ = mgr.Resume()
+		}
+	}
+}
+
+// closeSplit leaves the split view, switching to the focused instance when
+// open is set, and pauses the capture of the instance no longer shown.
+func (m *Model) closeSplit(open bool) {
+	s := m.split
+	m.split = nil
+	if open && m.session != nil {
+		for i, inst := range m.session.Instances {
+			if inst.ID == s.ids[s.focus] {
+				m.switchToInstance(i)
+				m.ensureActiveVisible()
+				break
+			}
+		}
+	}
+	active := m.activeInstance()
+	for _, id := range s.ids {
+		if active == nil || id != active.ID {
+			m.pauseInstance(id)
+		}
+	}
+}
+
+// splitOutputLines returns how many output lines each split pane shows.
+func (m Model) splitOutputLines() int {
+	return view.SplitOutputLines(m.mainAreaHeight(m.calculateExtraFooterLines()))
+}
+
+// splitLines returns the output lines shown in pane i.
+func (m Model) splitLines(i int) []string {
+	return m.outputManager.GetFilteredLines(m.split.ids[i])
+}
+
+// splitOffset returns the scroll offset of pane i, resolving a pane that
+// follows output to the bottom.
+func (m Model) splitOffset(i int) int {
+	maxOffset := max(len(m.splitLines(i))-m.splitOutputLines(), 0)
+	if m.split.follow[i] {
+		return maxOffset
+	}
+	return min(m.split.offset[i], maxOffset)
+}
+
+// scrollSplit moves pane i to offset, clamped to its output. A pane moved to
+// the bottom follows new output again.
+func (m *Model) scrollSplit(i, offset int) {
+	maxOffset := max(len(m.splitLines(i))-m.splitOutputLines(), 0)
+	m.split.offset[i] = max(min(offset, maxOffset), 0)
+	m.split.follow[i] = m.split.offset[i] == maxOffset
+}
+
+// jumpSplitMatch moves each pane to its next search match, or its previous
+// one when backward is set, wrapping around its output, and centers it.
+// Panes without a match stay where they are.
+func (m *Model) jumpSplitMatch(backward bool) {
+	visible := m.splitOutputLines()
+	for i := range m.split.ids {
+		matches := view.SearchMatches(m.splitLines(i), m.split.query)
+		if len(matches) == 0 {
+			m.split.match[i] = -1
+			continue
+		}
+		from := m.split.match[i]
+		if from < 0 {
+			// Start from the top of the view, or below its bottom going back
+			from = m.splitOffset(i) - 1
+			if backward {
+				from = m.splitOffset(i) + visible
+			}
+		}
+
+		next := matches[0]
+		if backward {
+			next = matches[len(matches)-1]
+			for j := len(matches) - 1; j >= 0; j-- {
+				if matches[j] < from {
+					next = matches[j]
+					break
+				}
+			}
+		} else {
+			for _, line := range matches {
+				if line > from {
+					next = line
+					break
+				}
+			}
+		}
+		m.split.match[i] = next
+		m.scrollSplit(i, next-visible/2)
+	}
+}
+
+// handleSplitInput handles keyboard input in the split view.
+func (m Model) handleSplitInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	s := m.split
+	if s.searching {
+		return m.handleSplitSearchInput(msg)
+	}
+
+	focus := s.focus
+	page := max(m.splitOutputLines()/2, 1)
+
+	switch m.resolveKey(keymap.Split, msg) {
+	case keymap.Close:
+		m.closeSplit(false)
+
+	case keymap.Open:
+		m.closeSplit(true)
+
+	case keymap.FocusOther:
+		s.focus = 1 - s.focus
+
+	case keymap.Left:
+		s.focus = 0
+
+	case keymap.Right:
+		s.focus = 1
+
+	case keymap.ScrollDown:
+		m.scrollSplit(focus, m.splitOffset(focus)+1)
+
+	case keymap.ScrollUp:
+		m.scrollSplit(focus, m.splitOffset(focus)-1)
+
+	case keymap.HalfPageDown:
+		m.scrollSplit(focus, m.splitOffset(focus)+page)
+
+	case keymap.HalfPageUp:
+		m.scrollSplit(focus, m.splitOffset(focus)-page)
+
+	case keymap.Top:
+		m.scrollSplit(focus, 0)
+
+	case keymap.Bottom:
+		s.follow[focus] = true
+
+	case keymap.Search:
+		s.searching = true
+		s.prevQuery = s.query
+		s.query = ""
+
+	case keymap.NextMatch:
+		m.jumpSplitMatch(false)
+
+	case keymap.PrevMatch:
+		m.jumpSplitMatch(true)
+
+	case keymap.ToggleLogs:
+		m.toggleLogPane()
+	}
+
+	return m, nil
+}
+
+// handleSplitSearchInput handles typing the split view's search query.
+// Enter moves both panes to their first match.
+func (m Model) handleSplitSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	s := m.split
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		s.searching = false
+		s.query = s.prevQuery
+
+	case tea.KeyEnter:
+		s.searching = false
+		s.match = [2]int{-1, -1}
+		if s.query != "" {
+			m.jumpSplitMatch(false)
+		}
+
+	case tea.KeyBackspace:
+		if runes := []rune(s.query); len(runes) > 0 {
+			s.query = string(runes[:len(runes)-1])
+		}
+
+	case tea.KeySpace:
+		s.query += " "
+
+	case tea.KeyRunes:
+		s.query += string(msg.Runes)
+	}
+
+	return m, nil
+}
+
+// renderSplit renders the split comparison view.
+func (m Model) renderSplit(width int) string {
+	s := m.split
+	var insts [2]*orchestrator.Instance
+	for i, id := range s.ids {
+		if m.session != nil {
+			insts[i] = m.session.GetInstance(id)
+		}
+		if insts[i] == nil {
+			return styles.ContentBox.Width(width - 4).Render(
+				"An instance in the comparison was removed.\n\nPress [Esc] to close the split view.")
+		}
+	}
+
+	pane := func(i int) view.SplitPane {
+		return view.SplitPane{
+			Instance:     insts[i],
+			Lines:        m.splitLines(i),
+			ScrollOffset: m.splitOffset(i),
+			Match:        s.match[i],
+			Focused:      s.focus == i,
+		}
+	}
+	return view.RenderSplit(view.SplitState{
+		Left:      pane(0),
+		Right:     pane(1),
+		Query:     s.query,
+		Searching: s.searching,
+	}, width, m.mainAreaHeight(m.calculateExtraFooterLines()))
+}
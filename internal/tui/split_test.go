@@ -0,0 +1,157 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newSplitTestModel returns a model comparing instances a and b, each with
+// 100 lines of output; every tenth line of a and every 25th of b mention
+// "match".
+func newSplitTestModel() Model {
+	m := newDashboardTestModel(3)
+	for _, id := range []string{"a", "b"} {
+		every := 10
+		if id == "b" {
+			every = 25
+		}
+		var out strings.Builder
+		for i := range 100 {
+			if i%every == 5 {
+				fmt.Fprintf(&out, "%s line %d match\n", id, i)
+			} else {
+				fmt.Fprintf(&out, "%s line %d\n", id, i)
+			}
+		}
+		m.outputManager.SetOutput(id, out.String())
+	}
+	m.openSplit("b")
+	return m
+}
+
+func splitKey(m Model, key tea.KeyMsg) Model {
+	result, _ := m.handleSplitInput(key)
+	return result.(Model)
+}
+
+func splitSearch(m Model, query string) Model {
+	m = splitKey(m, runeKey("/"))
+	m = splitKey(m, runeKey(query))
+	return splitKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func TestOpenSplit(t *testing.T) {
+	m := newSplitTestModel()
+	if m.split == nil || m.split.ids != [2]string{"a", "b"} {
+		t.Fatalf("split = %+v, want a and b", m.split)
+	}
+	// Both panes start at the bottom of their output
+	for i := range 2 {
+		if got, want := m.splitOffset(i), len(m.splitLines(i))-m.splitOutputLines(); got != want {
+			t.Errorf("pane %d offset = %d, want %d", i, got, want)
+		}
+	}
+}
+
+func TestHandleSplitInput_IndependentScrolling(t *testing.T) {
+	m := newSplitTestModel()
+	bottom := m.splitOffset(1)
+
+	m = splitKey(m, runeKey("g"))
+	if m.splitOffset(0) != 0 {
+		t.Errorf("after g, left offset = %d, want 0", m.splitOffset(0))
+	}
+	if m.splitOffset(1) != bottom {
+		t.Errorf("scrolling the left pane moved the right one to %d", m.splitOffset(1))
+	}
+
+	m = splitKey(m, runeKey("j"))
+	if m.splitOffset(0) != 1 {
+		t.Errorf("after j, left offset = %d, want 1", m.splitOffset(0))
+	}
+
+	m = splitKey(m, tea.KeyMsg{Type: tea.KeyTab})
+	m = splitKey(m, runeKey("k"))
+	if m.split.focus != 1 || m.splitOffset(1) != bottom-1 || m.splitOffset(0) != 1 {
+		t.Errorf("after Tab k, focus = %d, offsets = %d, %d", m.split.focus, m.splitOffset(0), m.splitOffset(1))
+	}
+
+	m = splitKey(m, runeKey("G"))
+	if !m.split.follow[1] {
+		t.Error("G should follow output again")
+	}
+}
+
+func TestHandleSplitInput_SynchronizedSearch(t *testing.T) {
+	m := newSplitTestModel()
+	m = splitKey(m, runeKey("g"))
+	m = splitKey(m, runeKey("l"))
+	m = splitKey(m, runeKey("g"))
+
+	m = splitSearch(m, "match")
+	if m.split.searching || m.split.query != "match" {
+		t.Fatalf("split = %+v, want the query applied", m.split)
+	}
+	if m.split.match != [2]int{5, 5} {
+		t.Errorf("first matches = %v, want [5 5]", m.split.match)
+	}
+
+	// n moves each pane to its own next match
+	m = splitKey(m, runeKey("n"))
+	if m.split.match != [2]int{15, 30} {
+		t.Errorf("after n, matches = %v, want [15 30]", m.split.match)
+	}
+	m = splitKey(m, runeKey("N"))
+	m = splitKey(m, runeKey("N"))
+	if m.split.match != [2]int{95, 80} {
+		t.Errorf("after N N, matches = %v, want to wrap to [95 80]", m.split.match)
+	}
+	if off := m.splitOffset(0); off > 95 || off+m.splitOutputLines() <= 95 {
+		t.Errorf("left pane offset = %d, want its match on line 95 visible", off)
+	}
+}
+
+func TestHandleSplitInput_CancelSearch(t *testing.T) {
+	m := newSplitTestModel()
+	m = splitSearch(m, "match")
+
+	m = splitKey(m, runeKey("/"))
+	m = splitKey(m, runeKey("other"))
+	m = splitKey(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	if m.split.query != "othe" {
+		t.Errorf("query = %q, want %q after backspace", m.split.query, "othe")
+	}
+	m = splitKey(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.split == nil || m.split.searching || m.split.query != "match" {
+		t.Errorf("Esc while typing should restore the previous query, split = %+v", m.split)
+	}
+}
+
+func TestHandleSplitInput_Close(t *testing.T) {
+	m := newSplitTestModel()
+	m = splitKey(m, runeKey("l"))
+	m = splitKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.split != nil {
+		t.Error("Enter should close the split view")
+	}
+	if m.activeTab != 1 {
+		t.Errorf("activeTab = %d, want the focused instance 1", m.activeTab)
+	}
+
+	m = newSplitTestModel()
+	m = splitKey(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.split != nil || m.activeTab != 0 {
+		t.Errorf("Esc should close without switching, split = %+v, activeTab = %d", m.split, m.activeTab)
+	}
+}
+
+func TestRenderSplit_RemovedInstance(t *testing.T) {
+	m := newSplitTestModel()
+	m.session.Instances = m.session.Instances[:1]
+	if out := m.renderSplit(100); !strings.Contains(out, "removed") {
+		t.Errorf("renderSplit() = %q, want a note that an instance was removed", out)
+	}
+}
@@ -147,7 +147,7 @@ func (t *ThemeFile) Validate() error {
 		if color == "" {
 			return fmt.Errorf("color '%s' is required", name)
 		}
-		if !isValidHexColor(color) {
+		if !IsValidHexColor(color) {
 			return fmt.Errorf("color '%s' has invalid format: %s (expected #RGB or #RRGGBB)", name, color)
 		}
 	}
@@ -182,7 +182,7 @@ func (t *ThemeFile) Validate() error {
 	}
 
 	for name, color := range optionalColors {
-		if color != "" && !isValidHexColor(color) {
+		if color != "" && !IsValidHexColor(color) {
 			return fmt.Errorf("color '%s' has invalid format: %s (expected #RGB or #RRGGBB)", name, color)
 		}
 	}
@@ -190,8 +190,8 @@ func (t *ThemeFile) Validate() error {
 	return nil
 }
 
-// isValidHexColor checks if a string is a valid hex color.
-func isValidHexColor(color string) bool {
+// IsValidHexColor checks if a string is a valid hex color (#RGB or #RRGGBB).
+func IsValidHexColor(color string) bool {
 	return hexColorRegex.MatchString(color)
 }
 
@@ -31,9 +31,9 @@ func TestIsValidHexColor(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := isValidHexColor(tt.color)
+			got := IsValidHexColor(tt.color)
 			if got != tt.expected {
-				t.Errorf("isValidHexColor(%q) = %v, want %v", tt.color, got, tt.expected)
+				t.Errorf("IsValidHexColor(%q) = %v, want %v", tt.color, got, tt.expected)
 			}
 		})
 	}
@@ -479,7 +479,7 @@ func TestDiscoverCustomThemes(t *testing.T) {
 	defer ClearCustomThemes()
 
 	// Create a valid theme file
-	validTheme := `name: "Solarized"
+	validTheme := `name: "Solarized Custom"
 version: "1"
 colors:
   primary: "#268BD2"
@@ -491,7 +491,7 @@ colors:
   text: "#FDF6E3"
   border: "#073642"
 `
-	if err := os.WriteFile(filepath.Join(tmpDir, "solarized.yaml"), []byte(validTheme), 0o644); err != nil {
+	if err := os.WriteFile(filepath.Join(tmpDir, "solarized-custom.yaml"), []byte(validTheme), 0o644); err != nil {
 		t.Fatalf("Failed to write test file: %v", err)
 	}
 
@@ -514,8 +514,8 @@ colors:
 	loaded, errs := DiscoverCustomThemes()
 
 	// Check that valid theme was loaded
-	if !slices.Contains(loaded, "solarized") {
-		t.Errorf("Expected 'solarized' to be loaded, got: %v", loaded)
+	if !slices.Contains(loaded, "solarized-custom") {
+		t.Errorf("Expected 'solarized-custom' to be loaded, got: %v", loaded)
 	}
 
 	// Check that invalid theme produced an error
@@ -524,13 +524,13 @@ colors:
 	}
 
 	// Check theme is registered
-	if !IsCustomTheme("solarized") {
-		t.Error("Expected 'solarized' to be registered as custom theme")
+	if !IsCustomTheme("solarized-custom") {
+		t.Error("Expected 'solarized-custom' to be registered as custom theme")
 	}
 
 	// Verify we can use the theme
-	if !IsValidTheme("solarized") {
-		t.Error("Expected 'solarized' to be valid after discovery")
+	if !IsValidTheme("solarized-custom") {
+		t.Error("Expected 'solarized-custom' to be valid after discovery")
 	}
 }
 
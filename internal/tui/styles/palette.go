@@ -25,6 +25,10 @@ const (
 	ThemeCatppuccin     ThemeName = "catppuccin"      // Catppuccin Mocha pastel theme
 	ThemeSynthwave      ThemeName = "synthwave"       // Synthwave '84 retro neon
 	ThemeAyu            ThemeName = "ayu"             // Ayu Dark clean theme
+	ThemeDark           ThemeName = "dark"            // Alias of default
+	ThemeLight          ThemeName = "light"           // Dark text on a light terminal
+	ThemeHighContrast   ThemeName = "high-contrast"   // Pure, saturated colors on black
+	ThemeSolarized      ThemeName = "solarized"       // Alias of solarized-dark
 )
 
 // BuiltinThemes returns all built-in theme names.
@@ -44,6 +48,10 @@ func BuiltinThemes() []string {
 		string(ThemeCatppuccin),
 		string(ThemeSynthwave),
 		string(ThemeAyu),
+		string(ThemeDark),
+		string(ThemeLight),
+		string(ThemeHighContrast),
+		string(ThemeSolarized),
 	}
 }
 
@@ -749,6 +757,96 @@ func AyuPalette() *ColorPalette {
 	}
 }
 
+// LightPalette returns a palette for terminals with a light background.
+// Colors are darkened so text stays readable on white.
+func LightPalette() *ColorPalette {
+	return &ColorPalette{
+		Primary:   lipgloss.Color("#6D28D9"), // Violet-700
+		Secondary: lipgloss.Color("#047857"), // Emerald-700
+		Warning:   lipgloss.Color("#B45309"), // Amber-700
+		Error:     lipgloss.Color("#B91C1C"), // Red-700
+		Muted:     lipgloss.Color("#6B7280"), // Gray-500
+		Surface:   lipgloss.Color("#F3F4F6"), // Gray-100
+		Text:      lipgloss.Color("#111827"), // Gray-900
+		Border:    lipgloss.Color("#9CA3AF"), // Gray-400
+
+		StatusWorking:     lipgloss.Color("#047857"), // Green
+		StatusPending:     lipgloss.Color("#6B7280"), // Gray
+		StatusPreparing:   lipgloss.Color("#1D4ED8"), // Blue - async setup
+		StatusInput:       lipgloss.Color("#B45309"), // Amber
+		StatusPaused:      lipgloss.Color("#1D4ED8"), // Blue
+		StatusComplete:    lipgloss.Color("#6D28D9"), // Violet
+		StatusError:       lipgloss.Color("#B91C1C"), // Red
+		StatusCreatingPR:  lipgloss.Color("#BE185D"), // Pink
+		StatusFinishing:   lipgloss.Color("#0F766E"), // Teal
+		StatusStuck:       lipgloss.Color("#C2410C"), // Orange
+		StatusTimeout:     lipgloss.Color("#B91C1C"), // Red
+		StatusInterrupted: lipgloss.Color("#A16207"), // Yellow
+
+		DiffAdd:     lipgloss.Color("#15803D"), // Green
+		DiffRemove:  lipgloss.Color("#B91C1C"), // Red
+		DiffHeader:  lipgloss.Color("#1D4ED8"), // Blue
+		DiffHunk:    lipgloss.Color("#6D28D9"), // Violet
+		DiffContext: lipgloss.Color("#6B7280"), // Gray
+
+		SearchMatchBg:   lipgloss.Color("#FEF08A"), // Light yellow
+		SearchMatchFg:   lipgloss.Color("#111827"), // Gray-900
+		SearchCurrentBg: lipgloss.Color("#C2410C"), // Dark orange
+		SearchCurrentFg: lipgloss.Color("#FFFFFF"), // White
+
+		Blue:   lipgloss.Color("#1D4ED8"),
+		Yellow: lipgloss.Color("#A16207"),
+		Purple: lipgloss.Color("#6D28D9"),
+		Pink:   lipgloss.Color("#BE185D"),
+		Orange: lipgloss.Color("#C2410C"),
+	}
+}
+
+// HighContrastPalette returns a palette of pure, saturated colors on black
+// for low-vision users and washed-out displays.
+func HighContrastPalette() *ColorPalette {
+	return &ColorPalette{
+		Primary:   lipgloss.Color("#00FFFF"), // Cyan
+		Secondary: lipgloss.Color("#00FF00"), // Green
+		Warning:   lipgloss.Color("#FFFF00"), // Yellow
+		Error:     lipgloss.Color("#FF5555"), // Red
+		Muted:     lipgloss.Color("#D0D0D0"), // Light gray
+		Surface:   lipgloss.Color("#000000"), // Black
+		Text:      lipgloss.Color("#FFFFFF"), // White
+		Border:    lipgloss.Color("#FFFFFF"), // White
+
+		StatusWorking:     lipgloss.Color("#00FF00"), // Green
+		StatusPending:     lipgloss.Color("#D0D0D0"), // Gray
+		StatusPreparing:   lipgloss.Color("#00FFFF"), // Cyan - async setup
+		StatusInput:       lipgloss.Color("#FFFF00"), // Yellow
+		StatusPaused:      lipgloss.Color("#00FFFF"), // Cyan
+		StatusComplete:    lipgloss.Color("#FF80FF"), // Magenta
+		StatusError:       lipgloss.Color("#FF5555"), // Red
+		StatusCreatingPR:  lipgloss.Color("#FF80FF"), // Magenta
+		StatusFinishing:   lipgloss.Color("#00FFFF"), // Cyan
+		StatusStuck:       lipgloss.Color("#FFA500"), // Orange
+		StatusTimeout:     lipgloss.Color("#FF5555"), // Red
+		StatusInterrupted: lipgloss.Color("#FFFF00"), // Yellow
+
+		DiffAdd:     lipgloss.Color("#00FF00"), // Green
+		DiffRemove:  lipgloss.Color("#FF5555"), // Red
+		DiffHeader:  lipgloss.Color("#00FFFF"), // Cyan
+		DiffHunk:    lipgloss.Color("#FF80FF"), // Magenta
+		DiffContext: lipgloss.Color("#D0D0D0"), // Gray
+
+		SearchMatchBg:   lipgloss.Color("#FFFF00"), // Yellow
+		SearchMatchFg:   lipgloss.Color("#000000"), // Black
+		SearchCurrentBg: lipgloss.Color("#FF80FF"), // Magenta
+		SearchCurrentFg: lipgloss.Color("#000000"), // Black
+
+		Blue:   lipgloss.Color("#55AAFF"),
+		Yellow: lipgloss.Color("#FFFF00"),
+		Purple: lipgloss.Color("#FF80FF"),
+		Pink:   lipgloss.Color("#FF80FF"),
+		Orange: lipgloss.Color("#FFA500"),
+	}
+}
+
 // GetPalette returns the color palette for the given theme name.
 // Checks custom themes first, then falls back to built-in themes.
 // Returns the default palette for unknown theme names.
@@ -768,7 +866,7 @@ func GetPalette(name ThemeName) *ColorPalette {
 		return NordPalette()
 	case ThemeClaudeCode:
 		return ClaudeCodePalette()
-	case ThemeSolarizedDark:
+	case ThemeSolarizedDark, ThemeSolarized:
 		return SolarizedDarkPalette()
 	case ThemeSolarizedLight:
 		return SolarizedLightPalette()
@@ -786,6 +884,10 @@ func GetPalette(name ThemeName) *ColorPalette {
 		return SynthwavePalette()
 	case ThemeAyu:
 		return AyuPalette()
+	case ThemeLight:
+		return LightPalette()
+	case ThemeHighContrast:
+		return HighContrastPalette()
 	default:
 		return DefaultPalette()
 	}
@@ -8,15 +8,16 @@ import (
 func TestValidThemes(t *testing.T) {
 	themes := ValidThemes()
 
-	if len(themes) != 14 {
-		t.Errorf("ValidThemes() returned %d themes, want 14", len(themes))
+	if len(themes) != 18 {
+		t.Errorf("ValidThemes() returned %d themes, want 18", len(themes))
 	}
 
 	expected := []string{
 		"default", "monokai", "dracula", "nord",
 		"claude-code", "solarized-dark", "solarized-light", "one-dark",
 		"github-dark", "gruvbox", "tokyo-night", "catppuccin",
-		"synthwave", "ayu",
+		"synthwave", "ayu", "dark", "light",
+		"high-contrast", "solarized",
 	}
 	for _, want := range expected {
 		if !slices.Contains(themes, want) {
@@ -45,6 +46,10 @@ func TestIsValidTheme(t *testing.T) {
 		{"catppuccin theme", "catppuccin", true},
 		{"synthwave theme", "synthwave", true},
 		{"ayu theme", "ayu", true},
+		{"dark theme", "dark", true},
+		{"light theme", "light", true},
+		{"high-contrast theme", "high-contrast", true},
+		{"solarized theme", "solarized", true},
 		{"invalid theme", "invalid", false},
 		{"empty string", "", false},
 		{"case sensitive", "Default", false},
@@ -79,6 +84,10 @@ func TestThemeNameConstants(t *testing.T) {
 		{ThemeCatppuccin, "catppuccin"},
 		{ThemeSynthwave, "synthwave"},
 		{ThemeAyu, "ayu"},
+		{ThemeDark, "dark"},
+		{ThemeLight, "light"},
+		{ThemeHighContrast, "high-contrast"},
+		{ThemeSolarized, "solarized"},
 	}
 
 	for _, tt := range tests {
@@ -112,6 +121,8 @@ func TestPalettes(t *testing.T) {
 		{"catppuccin", CatppuccinPalette, "#89B4FA", "#A6E3A1", "#1E1E2E"},
 		{"synthwave", SynthwavePalette, "#FF7EDB", "#72F1B8", "#262335"},
 		{"ayu", AyuPalette, "#39BAE6", "#7FD962", "#0D1017"},
+		{"light", LightPalette, "#6D28D9", "#047857", "#F3F4F6"},
+		{"high-contrast", HighContrastPalette, "#00FFFF", "#00FF00", "#000000"},
 	}
 
 	for _, tt := range tests {
@@ -152,6 +163,10 @@ func TestGetPalette(t *testing.T) {
 		{ThemeCatppuccin, "#89B4FA"},
 		{ThemeSynthwave, "#FF7EDB"},
 		{ThemeAyu, "#39BAE6"},
+		{ThemeDark, "#A78BFA"},
+		{ThemeLight, "#6D28D9"},
+		{ThemeHighContrast, "#00FFFF"},
+		{ThemeSolarized, "#268BD2"},
 		{"unknown", "#A78BFA"}, // Should fall back to default
 	}
 
@@ -184,6 +199,10 @@ func TestPaletteColorConsistency(t *testing.T) {
 		CatppuccinPalette(),
 		SynthwavePalette(),
 		AyuPalette(),
+		DefaultPalette(),
+		LightPalette(),
+		HighContrastPalette(),
+		SolarizedDarkPalette(),
 	}
 
 	for i, p := range palettes {
@@ -148,6 +148,12 @@ var (
 			Background(BlueColor).
 			Padding(0, 1)
 
+	ModeBadgeSelect = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(TextColor).
+			Background(SecondaryColor).
+			Padding(0, 1)
+
 	// Output area
 	OutputArea = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
@@ -21,6 +21,7 @@ type ThemedStyles struct {
 	BlueColor   lipgloss.Color
 	YellowColor lipgloss.Color
 	PurpleColor lipgloss.Color
+	OrangeColor lipgloss.Color
 
 	// Status colors
 	StatusWorking     lipgloss.Color
@@ -71,6 +72,7 @@ type ThemedStyles struct {
 	ModeBadgeSearch  lipgloss.Style
 	ModeBadgeFilter  lipgloss.Style
 	ModeBadgeDiff    lipgloss.Style
+	ModeBadgeSelect  lipgloss.Style
 
 	// Output area
 	OutputArea lipgloss.Style
@@ -151,6 +153,7 @@ func NewThemedStyles(p *ColorPalette) *ThemedStyles {
 		BlueColor:   p.Blue,
 		YellowColor: p.Yellow,
 		PurpleColor: p.Purple,
+		OrangeColor: p.Orange,
 
 		// Status colors
 		StatusWorking:     p.StatusWorking,
@@ -259,6 +262,12 @@ func NewThemedStyles(p *ColorPalette) *ThemedStyles {
 		Background(p.Blue).
 		Padding(0, 1)
 
+	s.ModeBadgeSelect = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(p.Text).
+		Background(p.Secondary).
+		Padding(0, 1)
+
 	s.OutputArea = lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(p.Border)
@@ -474,8 +483,14 @@ func init() {
 // Note: This function is not thread-safe. It is designed to be called only
 // from the Bubble Tea event loop, which runs on a single goroutine.
 func SetActiveTheme(name ThemeName) {
-	palette := GetPalette(name)
-	activeTheme = NewThemedStyles(palette)
+	SetActivePalette(GetPalette(name))
+}
+
+// SetActivePalette makes p the active palette, for palettes that are not a
+// named theme as is, such as a theme with user color overrides applied.
+// Like SetActiveTheme, it is not thread-safe.
+func SetActivePalette(p *ColorPalette) {
+	activeTheme = NewThemedStyles(p)
 	syncGlobalStyles()
 }
 
@@ -558,6 +573,7 @@ func syncGlobalStyles() {
 	ModeBadgeSearch = activeTheme.ModeBadgeSearch
 	ModeBadgeFilter = activeTheme.ModeBadgeFilter
 	ModeBadgeDiff = activeTheme.ModeBadgeDiff
+	ModeBadgeSelect = activeTheme.ModeBadgeSelect
 
 	// Update output area
 	OutputArea = activeTheme.OutputArea
@@ -106,6 +106,7 @@ func TestThemedStyles_StylesCanRender(t *testing.T) {
 		"DropdownItemSelected": func() string { return s.DropdownItemSelected.Render("test") },
 		"ModeBadgeNormal":      func() string { return s.ModeBadgeNormal.Render("test") },
 		"ModeBadgeInput":       func() string { return s.ModeBadgeInput.Render("test") },
+		"ModeBadgeSelect":      func() string { return s.ModeBadgeSelect.Render("test") },
 	}
 
 	for name, renderFunc := range styles {
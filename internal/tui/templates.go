@@ -1,5 +1,10 @@
 package tui
 
+import (
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/ultraplan"
+)
+
 // TaskTemplate represents a task template that can be selected via "/" dropdown
 type TaskTemplate struct {
 	Command     string // The slash command (e.g., "test", "docs")
@@ -127,23 +132,81 @@ Important: Process PRs one at a time to avoid conflicts between dependent PRs. I
 
 // FilterTemplates returns templates that match the given filter string
 func FilterTemplates(filter string) []TaskTemplate {
+	return filterTemplateList(TaskTemplates, filter)
+}
+
+// filterTemplateList returns the templates whose command or name contains filter.
+func filterTemplateList(templates []TaskTemplate, filter string) []TaskTemplate {
 	if filter == "" {
-		return TaskTemplates
+		return templates
 	}
 
 	var matches []TaskTemplate
 	filterLower := toLower(filter)
-
-	for _, t := range TaskTemplates {
+	for _, t := range templates {
 		// Match against command or name
 		if contains(toLower(t.Command), filterLower) || contains(toLower(t.Name), filterLower) {
 			matches = append(matches, t)
 		}
 	}
-
 	return matches
 }
 
+// ObjectiveTaskTemplates converts ultraplan objective templates for the "/"
+// dropdown. Selecting one fills the input with the template's prefix and
+// appends its constraints, verification requirements, and planning hints on
+// submission. A template with variables fills the input with name=value
+// arguments instead, and is expanded from them on submission.
+func ObjectiveTaskTemplates(templates []ultraplan.ObjectiveTemplate) []TaskTemplate {
+	out := make([]TaskTemplate, len(templates))
+	for i, t := range templates {
+		out[i] = TaskTemplate{
+			Command:     t.Name,
+			Name:        t.Description,
+			Description: t.Prefix,
+			Suffix:      t.Suffix(),
+		}
+		if t.HasVariables() {
+			out[i].Description = t.VariableSkeleton()
+			out[i].Suffix = ""
+		}
+	}
+	return out
+}
+
+// objectiveTemplates returns the objective templates of the config and of
+// the repository's template directories, with those that failed to load.
+func (m Model) objectiveTemplates() ([]ultraplan.ObjectiveTemplate, []error) {
+	baseDir := ""
+	if m.orchestrator != nil {
+		baseDir = m.orchestrator.BaseDir()
+	}
+	return ultraplan.LoadTemplates(config.Get(), baseDir)
+}
+
+// availableTemplates returns the "/" dropdown templates matching filter:
+// objective templates while an ultraplan objective is being entered, task
+// templates otherwise.
+func (m Model) availableTemplates(filter string) []TaskTemplate {
+	if m.awaitingUltraPlanObjective() != nil {
+		templates, _ := m.objectiveTemplates()
+		return filterTemplateList(ObjectiveTaskTemplates(templates), filter)
+	}
+	return FilterTemplates(filter)
+}
+
+// awaitingUltraPlanObjective returns the inline ultraplan session waiting for
+// its objective, or nil.
+func (m Model) awaitingUltraPlanObjective() *InlinePlanSession {
+	if m.inlinePlan == nil {
+		return nil
+	}
+	if session := m.inlinePlan.GetAwaitingObjectiveSession(); session != nil && session.IsUltraPlan {
+		return session
+	}
+	return nil
+}
+
 // toLower converts a string to lowercase (simple ASCII implementation)
 func toLower(s string) string {
 	result := make([]byte, len(s))
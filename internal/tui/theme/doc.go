@@ -0,0 +1,26 @@
+// Package theme picks the TUI's colors from config and hands them to
+// renderers.
+//
+// A theme is one of the palettes in package styles, chosen by name with
+// tui.theme: the built-in themes, custom themes from the themes directory,
+// and the named themes "dark", "light", "high-contrast", and "solarized".
+// tui.colors then overrides single colors of that palette:
+//
+//	tui:
+//	  theme: light
+//	  colors:
+//	    primary: "#0055CC"
+//	    status_working: "#008800"
+//
+// [Apply] makes the result the active palette. Renderers ask [Current] for
+// a style by what it shows, such as [Theme.Success] or [Theme.Selected],
+// rather than inlining colors, so they follow the active theme.
+//
+// # Usage
+//
+//	if err := theme.Apply(cfg.TUI.Theme, cfg.TUI.Colors); err != nil {
+//		...
+//	}
+//
+//	icon := theme.Current().Success().Render("✓")
+package theme
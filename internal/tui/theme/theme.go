@@ -0,0 +1,157 @@
+package theme
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// colorFields maps the color names tui.colors accepts to palette fields.
+var colorFields = map[string]func(p *styles.ColorPalette) *lipgloss.Color{
+	"primary":   func(p *styles.ColorPalette) *lipgloss.Color { return &p.Primary },
+	"secondary": func(p *styles.ColorPalette) *lipgloss.Color { return &p.Secondary },
+	"warning":   func(p *styles.ColorPalette) *lipgloss.Color { return &p.Warning },
+	"error":     func(p *styles.ColorPalette) *lipgloss.Color { return &p.Error },
+	"muted":     func(p *styles.ColorPalette) *lipgloss.Color { return &p.Muted },
+	"surface":   func(p *styles.ColorPalette) *lipgloss.Color { return &p.Surface },
+	"text":      func(p *styles.ColorPalette) *lipgloss.Color { return &p.Text },
+	"border":    func(p *styles.ColorPalette) *lipgloss.Color { return &p.Border },
+
+	"status_working":     func(p *styles.ColorPalette) *lipgloss.Color { return &p.StatusWorking },
+	"status_pending":     func(p *styles.ColorPalette) *lipgloss.Color { return &p.StatusPending },
+	"status_preparing":   func(p *styles.ColorPalette) *lipgloss.Color { return &p.StatusPreparing },
+	"status_input":       func(p *styles.ColorPalette) *lipgloss.Color { return &p.StatusInput },
+	"status_paused":      func(p *styles.ColorPalette) *lipgloss.Color { return &p.StatusPaused },
+	"status_complete":    func(p *styles.ColorPalette) *lipgloss.Color { return &p.StatusComplete },
+	"status_error":       func(p *styles.ColorPalette) *lipgloss.Color { return &p.StatusError },
+	"status_creating_pr": func(p *styles.ColorPalette) *lipgloss.Color { return &p.StatusCreatingPR },
+	"status_finishing":   func(p *styles.ColorPalette) *lipgloss.Color { return &p.StatusFinishing },
+	"status_stuck":       func(p *styles.ColorPalette) *lipgloss.Color { return &p.StatusStuck },
+	"status_timeout":     func(p *styles.ColorPalette) *lipgloss.Color { return &p.StatusTimeout },
+	"status_interrupted": func(p *styles.ColorPalette) *lipgloss.Color { return &p.StatusInterrupted },
+
+	"diff_add":     func(p *styles.ColorPalette) *lipgloss.Color { return &p.DiffAdd },
+	"diff_remove":  func(p *styles.ColorPalette) *lipgloss.Color { return &p.DiffRemove },
+	"diff_header":  func(p *styles.ColorPalette) *lipgloss.Color { return &p.DiffHeader },
+	"diff_hunk":    func(p *styles.ColorPalette) *lipgloss.Color { return &p.DiffHunk },
+	"diff_context": func(p *styles.ColorPalette) *lipgloss.Color { return &p.DiffContext },
+
+	"search_match_bg":   func(p *styles.ColorPalette) *lipgloss.Color { return &p.SearchMatchBg },
+	"search_match_fg":   func(p *styles.ColorPalette) *lipgloss.Color { return &p.SearchMatchFg },
+	"search_current_bg": func(p *styles.ColorPalette) *lipgloss.Color { return &p.SearchCurrentBg },
+	"search_current_fg": func(p *styles.ColorPalette) *lipgloss.Color { return &p.SearchCurrentFg },
+
+	"blue":   func(p *styles.ColorPalette) *lipgloss.Color { return &p.Blue },
+	"yellow": func(p *styles.ColorPalette) *lipgloss.Color { return &p.Yellow },
+	"purple": func(p *styles.ColorPalette) *lipgloss.Color { return &p.Purple },
+	"pink":   func(p *styles.ColorPalette) *lipgloss.Color { return &p.Pink },
+	"orange": func(p *styles.ColorPalette) *lipgloss.Color { return &p.Orange },
+}
+
+// ColorKeys returns the color names tui.colors accepts, sorted.
+func ColorKeys() []string {
+	keys := make([]string, 0, len(colorFields))
+	for key := range colorFields {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// ValidateColors checks that every override names a known color and is a
+// hex color (#RGB or #RRGGBB).
+func ValidateColors(colors map[string]string) error {
+	keys := make([]string, 0, len(colors))
+	for key := range colors {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		if _, ok := colorFields[key]; !ok {
+			return fmt.Errorf("unknown color %q (valid colors: %s)", key, strings.Join(ColorKeys(), ", "))
+		}
+		if !styles.IsValidHexColor(colors[key]) {
+			return fmt.Errorf("color %q has invalid value %q (expected #RGB or #RRGGBB)", key, colors[key])
+		}
+	}
+	return nil
+}
+
+// Palette returns the palette of the named theme with colors overriding its
+// colors. An empty name is the default theme.
+func Palette(name string, colors map[string]string) (*styles.ColorPalette, error) {
+	if name != "" && !styles.IsValidTheme(name) {
+		return nil, fmt.Errorf("unknown theme %q", name)
+	}
+	if err := ValidateColors(colors); err != nil {
+		return nil, err
+	}
+
+	p := styles.GetPalette(styles.ThemeName(name))
+	for key, value := range colors {
+		*colorFields[key](p) = lipgloss.Color(value)
+	}
+	return p, nil
+}
+
+// Apply makes the named theme, with colors overriding its colors, the
+// active palette. The active palette is left alone on error.
+func Apply(name string, colors map[string]string) error {
+	p, err := Palette(name, colors)
+	if err != nil {
+		return err
+	}
+	styles.SetActivePalette(p)
+	return nil
+}
+
+// Theme gives renderers styles from a palette by what they show.
+type Theme struct {
+	s *styles.ThemedStyles
+}
+
+// Current returns the active theme. Take it when rendering rather than
+// keeping it, since the active theme changes when the user picks another.
+func Current() Theme {
+	return Theme{s: styles.GetActiveTheme()}
+}
+
+// Running is the style for work in progress.
+func (t Theme) Running() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(t.s.BlueColor)
+}
+
+// Success is the style for work that completed.
+func (t Theme) Success() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(t.s.GreenColor)
+}
+
+// Failure is the style for work that failed, and for conflicts and errors.
+func (t Theme) Failure() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(t.s.RedColor)
+}
+
+// Attention is the style for things waiting on the user or coming next.
+func (t Theme) Attention() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(t.s.YellowColor)
+}
+
+// Review is the style for phases where plans or results are reviewed.
+func (t Theme) Review() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(t.s.OrangeColor)
+}
+
+// Accent is the style for combined results, such as merged plans and
+// synthesis.
+func (t Theme) Accent() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(t.s.PurpleColor)
+}
+
+// Selected is the style for the selected row of a list.
+func (t Theme) Selected() lipgloss.Style {
+	return lipgloss.NewStyle().Background(t.s.PrimaryColor).Foreground(t.s.TextColor)
+}
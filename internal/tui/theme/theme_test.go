@@ -0,0 +1,98 @@
+package theme
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestPalette_NamedThemes(t *testing.T) {
+	tests := map[string]lipgloss.Color{
+		"":              styles.DefaultPalette().Primary,
+		"dark":          styles.DefaultPalette().Primary,
+		"light":         styles.LightPalette().Primary,
+		"high-contrast": styles.HighContrastPalette().Primary,
+		"solarized":     styles.SolarizedDarkPalette().Primary,
+		"nord":          styles.NordPalette().Primary,
+	}
+	for name, want := range tests {
+		p, err := Palette(name, nil)
+		if err != nil {
+			t.Fatalf("Palette(%q) error = %v", name, err)
+		}
+		if p.Primary != want {
+			t.Errorf("Palette(%q).Primary = %q, want %q", name, p.Primary, want)
+		}
+	}
+}
+
+func TestPalette_Overrides(t *testing.T) {
+	p, err := Palette("light", map[string]string{"primary": "#FF8800", "status_working": "#0F0"})
+	if err != nil {
+		t.Fatalf("Palette() error = %v", err)
+	}
+	if p.Primary != "#FF8800" || p.StatusWorking != "#0F0" {
+		t.Errorf("overrides not applied: primary %q, status_working %q", p.Primary, p.StatusWorking)
+	}
+	if p.Secondary != styles.LightPalette().Secondary {
+		t.Errorf("Secondary = %q, want the light theme's", p.Secondary)
+	}
+}
+
+func TestPalette_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		theme  string
+		colors map[string]string
+		want   string
+	}{
+		{"unknown theme", "neon", nil, "unknown theme"},
+		{"unknown color", "", map[string]string{"sky": "#00F"}, "unknown color"},
+		{"bad value", "", map[string]string{"primary": "orange"}, "invalid value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Palette(tt.theme, tt.colors)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Palette() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorKeys_CoverPalette(t *testing.T) {
+	// Every key sets a different palette field
+	p := &styles.ColorPalette{}
+	seen := make(map[*lipgloss.Color]string)
+	for _, key := range ColorKeys() {
+		field := colorFields[key](p)
+		if other, ok := seen[field]; ok {
+			t.Errorf("%q and %q set the same color", key, other)
+		}
+		seen[field] = key
+	}
+}
+
+func TestApply(t *testing.T) {
+	t.Cleanup(func() { styles.SetActiveTheme(styles.ThemeDefault) })
+
+	if err := Apply("high-contrast", map[string]string{"orange": "#FF00FF"}); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if styles.PrimaryColor != styles.HighContrastPalette().Primary {
+		t.Errorf("styles.PrimaryColor = %q, want the high-contrast primary", styles.PrimaryColor)
+	}
+	if got := Current().Review().GetForeground(); got != lipgloss.Color("#FF00FF") {
+		t.Errorf("Review() foreground = %v, want the overridden orange", got)
+	}
+
+	// A bad config leaves the active theme alone
+	if err := Apply("neon", nil); err == nil {
+		t.Fatal("Apply(neon) should fail")
+	}
+	if styles.PrimaryColor != styles.HighContrastPalette().Primary {
+		t.Errorf("styles.PrimaryColor = %q after a failed Apply, want it unchanged", styles.PrimaryColor)
+	}
+}
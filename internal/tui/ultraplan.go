@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/Iron-Ham/claudio/internal/audit"
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/tui/keymap"
 	tuimsg "github.com/Iron-Ham/claudio/internal/tui/msg"
 	"github.com/Iron-Ham/claudio/internal/tui/view"
 	tea "github.com/charmbracelet/bubbletea"
@@ -67,6 +69,16 @@ func (m *Model) checkForPhaseNotification() {
 	if m.ultraPlan.NotifiedGroupDecision {
 		m.ultraPlan.NotifiedGroupDecision = false
 	}
+
+	// Check for the next group awaiting approval, once per pause
+	if session.GroupApproval != nil {
+		if !m.ultraPlan.NotifiedGroupApproval {
+			m.ultraPlan.NeedsNotification = true
+			m.ultraPlan.NotifiedGroupApproval = true
+		}
+		return
+	}
+	m.ultraPlan.NotifiedGroupApproval = false
 }
 
 // createUltraplanView creates a new ultraplan view with the current model state
@@ -86,6 +98,7 @@ func (m *Model) createUltraplanView() *view.UltraplanView {
 			return m.isInstanceSelected(instanceID)
 		},
 		InputMode: m.inputMode,
+		Keys:      m.keyBindings(),
 	}
 	return view.NewUltraplanView(ctx)
 }
@@ -410,10 +423,18 @@ func (m Model) handleUltraPlanKeypress(msg tea.KeyMsg) (bool, tea.Model, tea.Cmd
 		return false, m, nil
 	}
 
-	// Group decision handling takes priority
-	if session.GroupDecision != nil && session.GroupDecision.AwaitingDecision {
-		switch msg.String() {
-		case "c":
+	// Group decision and approval keys take priority
+	awaitingDecision := session.GroupDecision != nil && session.GroupDecision.AwaitingDecision
+	if gate := session.GroupApproval; awaitingDecision || gate != nil {
+		action, pending := m.resolveOverlayKey(keymap.UltraPlanGate, msg)
+		if pending {
+			return true, m, nil
+		}
+		switch action {
+		case keymap.ContinuePartial:
+			if !awaitingDecision {
+				break
+			}
 			// Continue with partial work (successful tasks only)
 			if err := m.ultraPlan.Coordinator.ResumeWithPartialWork(); err != nil {
 				m.errorMessage = fmt.Sprintf("Failed to continue: %v", err)
@@ -425,10 +446,14 @@ func (m Model) handleUltraPlanKeypress(msg tea.KeyMsg) (bool, tea.Model, tea.Cmd
 						"decision_type", "group_partial_failure",
 						"choice", "continue_partial")
 				}
+				m.orchestrator.RecordOperatorAction(audit.ActionOverride, "", "", "continued past partial group failure with successful tasks only")
 			}
 			return true, m, nil
 
-		case "r":
+		case keymap.RetryFailed:
+			if !awaitingDecision {
+				break
+			}
 			// Retry failed tasks
 			if err := m.ultraPlan.Coordinator.RetryFailedTasks(); err != nil {
 				m.errorMessage = fmt.Sprintf("Failed to retry: %v", err)
@@ -440,18 +465,47 @@ func (m Model) handleUltraPlanKeypress(msg tea.KeyMsg) (bool, tea.Model, tea.Cmd
 						"decision_type", "group_partial_failure",
 						"choice", "retry_failed")
 				}
+				m.orchestrator.RecordOperatorAction(audit.ActionOverride, "", "", "retried failed tasks after partial group failure")
 			}
 			return true, m, nil
 
-		case "q":
+		case keymap.ApproveGroup:
+			// Approval before the next group (ultraplan.group_approval)
+			if gate == nil {
+				break
+			}
+			if err := m.ultraPlan.Coordinator.ApproveGroup(); err != nil {
+				m.errorMessage = fmt.Sprintf("Failed to approve: %v", err)
+			} else {
+				m.infoMessage = fmt.Sprintf("Starting group %d...", gate.GroupIndex+2)
+				if m.logger != nil {
+					m.logger.Info("user decision",
+						"decision_type", "group_approval",
+						"choice", "approve")
+				}
+				m.orchestrator.RecordOperatorAction(audit.ActionApprove, "", "", fmt.Sprintf("approved starting group %d", gate.GroupIndex+2))
+			}
+			return true, m, nil
+
+		case keymap.Cancel:
 			// Cancel the ultraplan
 			m.ultraPlan.Coordinator.Cancel()
 			m.infoMessage = "Ultraplan cancelled"
-			// Log user decision
-			if m.logger != nil {
-				m.logger.Info("user decision",
-					"decision_type", "group_partial_failure",
-					"choice", "cancel")
+			if awaitingDecision {
+				// Log user decision
+				if m.logger != nil {
+					m.logger.Info("user decision",
+						"decision_type", "group_partial_failure",
+						"choice", "cancel")
+				}
+				m.orchestrator.RecordOperatorAction(audit.ActionOverride, "", "", "cancelled ultraplan after partial group failure")
+			} else {
+				if m.logger != nil {
+					m.logger.Info("user decision",
+						"decision_type", "group_approval",
+						"choice", "cancel")
+				}
+				m.orchestrator.RecordOperatorAction(audit.ActionOverride, "", "", fmt.Sprintf("cancelled ultraplan instead of starting group %d", gate.GroupIndex+2))
 			}
 			return true, m, nil
 		}
@@ -459,9 +513,9 @@ func (m Model) handleUltraPlanKeypress(msg tea.KeyMsg) (bool, tea.Model, tea.Cmd
 
 	// Handle retrigger mode - number keys select group to retrigger
 	if m.ultraPlan.RetriggerMode {
-		switch msg.String() {
+		switch key := msg.String(); key {
 		case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9":
-			groupNum := int(msg.String()[0] - '0')
+			groupNum := int(key[0] - '0')
 			if err := m.ultraPlan.Coordinator.RetriggerGroup(groupNum); err != nil {
 				m.errorMessage = fmt.Sprintf("Failed to retrigger: %v", err)
 				// Log the failure for debugging
@@ -478,13 +532,14 @@ func (m Model) handleUltraPlanKeypress(msg tea.KeyMsg) (bool, tea.Model, tea.Cmd
 						"decision_type", "retrigger_group",
 						"target_group", groupNum)
 				}
+				m.orchestrator.RecordOperatorAction(audit.ActionOverride, "", "", fmt.Sprintf("re-triggered execution from group %d", groupNum))
 			}
 			m.ultraPlan.RetriggerMode = false
 			return true, m, nil
-		case "esc", "escape":
+		}
+		if action, _ := m.resolveOverlayKey(keymap.UltraPlan, msg); action == keymap.Close {
 			m.ultraPlan.RetriggerMode = false
 			m.infoMessage = ""
-			return true, m, nil
 		}
 		// Don't process other keys while in retrigger mode
 		return true, m, nil
@@ -493,8 +548,12 @@ func (m Model) handleUltraPlanKeypress(msg tea.KeyMsg) (bool, tea.Model, tea.Cmd
 	// Handle group navigation mode keys
 	if m.ultraPlan.GroupNavMode && session.Plan != nil {
 		numGroups := len(session.Plan.ExecutionOrder)
-		switch msg.String() {
-		case "up", "k":
+		action, pending := m.resolveOverlayKey(keymap.UltraPlanGroups, msg)
+		if pending {
+			return true, m, nil
+		}
+		switch action {
+		case keymap.ScrollUp:
 			// Navigate to previous group
 			if m.ultraPlan.SelectedGroupIdx > 0 {
 				m.ultraPlan.SelectedGroupIdx--
@@ -503,7 +562,7 @@ func (m Model) handleUltraPlanKeypress(msg tea.KeyMsg) (bool, tea.Model, tea.Cmd
 			}
 			return true, m, nil
 
-		case "down", "j":
+		case keymap.ScrollDown:
 			// Navigate to next group
 			if m.ultraPlan.SelectedGroupIdx < numGroups-1 {
 				m.ultraPlan.SelectedGroupIdx++
@@ -512,7 +571,7 @@ func (m Model) handleUltraPlanKeypress(msg tea.KeyMsg) (bool, tea.Model, tea.Cmd
 			}
 			return true, m, nil
 
-		case "enter", " ":
+		case keymap.ToggleGroup:
 			// Toggle collapse for selected group (considering default-collapsed state)
 			groupIdx := m.ultraPlan.SelectedGroupIdx
 			isCurrentlyCollapsed := m.ultraPlan.IsGroupCollapsed(groupIdx, session.CurrentGroup)
@@ -523,17 +582,17 @@ func (m Model) handleUltraPlanKeypress(msg tea.KeyMsg) (bool, tea.Model, tea.Cmd
 			}
 			return true, m, nil
 
-		case "right", "l":
+		case keymap.Right:
 			// Expand selected group
 			m.ultraPlan.SetGroupExpanded(m.ultraPlan.SelectedGroupIdx)
 			return true, m, nil
 
-		case "left", "h":
+		case keymap.Left:
 			// Collapse selected group
 			m.ultraPlan.SetGroupCollapsed(m.ultraPlan.SelectedGroupIdx)
 			return true, m, nil
 
-		case "e":
+		case keymap.ExpandAll:
 			// Expand all groups
 			for i := range numGroups {
 				m.ultraPlan.SetGroupExpanded(i)
@@ -541,7 +600,7 @@ func (m Model) handleUltraPlanKeypress(msg tea.KeyMsg) (bool, tea.Model, tea.Cmd
 			m.infoMessage = "All groups expanded"
 			return true, m, nil
 
-		case "c":
+		case keymap.CollapseAll:
 			// Collapse all groups (only in group nav mode, not awaiting decision)
 			for i := range numGroups {
 				m.ultraPlan.SetGroupCollapsed(i)
@@ -549,7 +608,7 @@ func (m Model) handleUltraPlanKeypress(msg tea.KeyMsg) (bool, tea.Model, tea.Cmd
 			m.infoMessage = "All groups collapsed"
 			return true, m, nil
 
-		case "g", "esc", "escape":
+		case keymap.Close:
 			// Exit group navigation mode
 			m.ultraPlan.GroupNavMode = false
 			m.ultraPlan.SelectedGroupIdx = -1
@@ -558,26 +617,69 @@ func (m Model) handleUltraPlanKeypress(msg tea.KeyMsg) (bool, tea.Model, tea.Cmd
 		}
 	}
 
-	switch msg.String() {
-	case "g":
+	action, pending := m.resolveOverlayKey(keymap.UltraPlan, msg)
+	if pending {
+		return true, m, nil
+	}
+	switch action {
+	case keymap.Approve, keymap.Reject:
+		// Task proposals from running instances (oldest first)
+		if session.Phase != orchestrator.PhaseExecuting {
+			return false, m, nil
+		}
+		proposals := m.ultraPlan.Coordinator.PendingTaskProposals()
+		if len(proposals) == 0 {
+			return false, m, nil
+		}
+		proposal := proposals[0]
+		if action == keymap.Approve {
+			if err := m.ultraPlan.Coordinator.ApproveTaskProposal(proposal.ID); err != nil {
+				m.errorMessage = fmt.Sprintf("Failed to approve proposal: %v", err)
+			} else {
+				m.infoMessage = fmt.Sprintf("Added task %s: %s", proposal.ID, proposal.Title)
+				if m.logger != nil {
+					m.logger.Info("user decision",
+						"decision_type", "task_proposal",
+						"proposal_id", proposal.ID,
+						"choice", "approve")
+				}
+				m.orchestrator.RecordOperatorAction(audit.ActionApprove, "", proposal.ID, fmt.Sprintf("approved task %q proposed by %s", proposal.Title, proposal.From))
+			}
+			return true, m, nil
+		}
+		if err := m.ultraPlan.Coordinator.RejectTaskProposal(proposal.ID); err != nil {
+			m.errorMessage = fmt.Sprintf("Failed to reject proposal: %v", err)
+		} else {
+			m.infoMessage = fmt.Sprintf("Rejected proposed task: %s", proposal.Title)
+			if m.logger != nil {
+				m.logger.Info("user decision",
+					"decision_type", "task_proposal",
+					"proposal_id", proposal.ID,
+					"choice", "reject")
+			}
+			m.orchestrator.RecordOperatorAction(audit.ActionReject, "", proposal.ID, fmt.Sprintf("rejected task %q proposed by %s", proposal.Title, proposal.From))
+		}
+		return true, m, nil
+
+	case keymap.GroupPrefix:
 		// Enter group navigation mode (when plan is available)
 		if session.Plan != nil && len(session.Plan.ExecutionOrder) > 0 {
 			m.ultraPlan.GroupNavMode = true
 			if m.ultraPlan.SelectedGroupIdx < 0 {
 				m.ultraPlan.SelectedGroupIdx = 0
 			}
-			m.infoMessage = "Group nav: ↑↓ select, enter toggle, e expand all, c collapse all, g/esc exit"
+			m.infoMessage = "Group nav: " + m.groupNavHint()
 		}
 		return true, m, nil
 
-	case "v":
+	case keymap.TogglePlan:
 		// Toggle plan view (only when plan is available)
 		if session.Plan != nil {
 			m.ultraPlan.ShowPlanView = !m.ultraPlan.ShowPlanView
 		}
 		return true, m, nil
 
-	case "p":
+	case keymap.ParsePlan:
 		// Parse plan from file or coordinator output (only during planning phase)
 		if session.Phase == orchestrator.PhasePlanning {
 			if session.CoordinatorID != "" {
@@ -598,13 +700,14 @@ func (m Model) handleUltraPlanKeypress(msg tea.KeyMsg) (bool, tea.Model, tea.Cmd
 		}
 		return true, m, nil
 
-	case "e":
+	case keymap.Execute:
 		// Start execution (only during refresh phase when plan is ready)
 		if session.Phase == orchestrator.PhaseRefresh && session.Plan != nil {
 			// Validate plan before starting execution
 			validation := orchestrator.ValidatePlanForEditor(session.Plan)
 			if validation.HasErrors() {
-				m.errorMessage = fmt.Sprintf("Cannot execute: plan has %d validation error(s). Press [E] to review.", validation.ErrorCount)
+				m.errorMessage = fmt.Sprintf("Cannot execute: plan has %d validation error(s). Press [%s] to review.",
+					validation.ErrorCount, keymap.Labels(m.keyBindings().Keys(keymap.UltraPlan, keymap.EditPlan)))
 				return true, m, nil
 			}
 			if err := m.ultraPlan.Coordinator.StartExecution(); err != nil {
@@ -615,7 +718,7 @@ func (m Model) handleUltraPlanKeypress(msg tea.KeyMsg) (bool, tea.Model, tea.Cmd
 		}
 		return true, m, nil
 
-	case "E":
+	case keymap.EditPlan:
 		// Enter plan editor (only during refresh phase when plan is ready)
 		if session.Phase == orchestrator.PhaseRefresh && session.Plan != nil {
 			m.enterPlanEditor()
@@ -623,24 +726,24 @@ func (m Model) handleUltraPlanKeypress(msg tea.KeyMsg) (bool, tea.Model, tea.Cmd
 		}
 		return true, m, nil
 
-	// NOTE: Cancel execution ('c') is now handled via command mode (:cancel)
+	// NOTE: Cancel execution is handled via command mode (:cancel)
 	// to prevent accidental cancellation from stray keypresses. See executeCommand() in app.go.
 
-	case "tab", "l":
+	case keymap.NextInstance:
 		// Navigate to next navigable instance across all phases
 		if m.navigateToNextInstance(1) {
 			m.infoMessage = ""
 		}
 		return true, m, nil
 
-	case "shift+tab", "h":
+	case keymap.PrevInstance:
 		// Navigate to previous navigable instance across all phases
 		if m.navigateToNextInstance(-1) {
 			m.infoMessage = ""
 		}
 		return true, m, nil
 
-	case "o":
+	case keymap.OpenPR:
 		// Open first PR URL in browser (when PRs have been created)
 		if len(session.PRUrls) > 0 {
 			prURL := session.PRUrls[0]
@@ -652,29 +755,28 @@ func (m Model) handleUltraPlanKeypress(msg tea.KeyMsg) (bool, tea.Model, tea.Cmd
 		}
 		return true, m, nil
 
-	case "r":
+	case keymap.Resume:
 		// Resume paused consolidation
-		if session.Phase == orchestrator.PhaseConsolidating &&
-			session.Consolidation != nil &&
-			session.Consolidation.Phase == orchestrator.ConsolidationPaused {
-			// Capture worktree path before resume clears it
-			conflictWorktree := session.Consolidation.ConflictWorktree
-			if err := m.ultraPlan.Coordinator.ResumeConsolidation(); err != nil {
-				m.errorMessage = fmt.Sprintf("Failed to resume consolidation: %v", err)
-			} else {
-				m.infoMessage = "Resuming consolidation..."
-				// Log user decision
-				if m.logger != nil {
-					m.logger.Info("user decision",
-						"decision_type", "resume_consolidation",
-						"conflict_worktree", conflictWorktree,
-					)
-				}
-			}
+		if session.Phase == orchestrator.PhaseConsolidating {
+			m.resumeConsolidation()
 		}
 		return true, m, nil
 
-	case "s":
+	case keymap.ShowDeps:
+		// Show the task dependency graph (when plan is available)
+		if session.Plan != nil {
+			m.openDepsView()
+		}
+		return true, m, nil
+
+	case keymap.ReviewConflicts:
+		// Review the conflicts of a paused consolidation
+		if session.Phase == orchestrator.PhaseConsolidating && m.pausedConsolidation() != nil {
+			m.openConflictView()
+			return true, m, nil
+		}
+
+	case keymap.Consolidate:
 		// Signal synthesis is done, proceed to consolidation
 		if session.Phase == orchestrator.PhaseSynthesis {
 			if err := m.ultraPlan.Coordinator.TriggerConsolidation(); err != nil {
@@ -685,17 +787,19 @@ func (m Model) handleUltraPlanKeypress(msg tea.KeyMsg) (bool, tea.Model, tea.Cmd
 				if m.logger != nil {
 					m.logger.Info("user approved synthesis")
 				}
+				m.orchestrator.RecordOperatorAction(audit.ActionApprove, "", "", "approved synthesis and proceeded to consolidation")
 			}
 		}
 		return true, m, nil
 
-	case "R":
-		// Enter re-trigger mode (capital R to prevent accidental triggers)
+	case keymap.Retrigger:
+		// Enter re-trigger mode (capital R by default to prevent accidental triggers)
 		// Available when: complete, failed, or executing with no running tasks
 		if m.canRetriggerGroup(session) {
 			numGroups := len(session.Plan.ExecutionOrder)
 			m.ultraPlan.RetriggerMode = true
-			m.infoMessage = fmt.Sprintf("Enter group number (0-%d) to re-trigger, or Esc to cancel", numGroups-1)
+			m.infoMessage = fmt.Sprintf("Enter group number (0-%d) to re-trigger, or %s to cancel",
+				numGroups-1, keymap.Labels(m.keyBindings().Keys(keymap.UltraPlan, keymap.Close)))
 		} else {
 			m.errorMessage = "Cannot re-trigger: execution in progress or no plan available"
 		}
@@ -705,6 +809,17 @@ func (m Model) handleUltraPlanKeypress(msg tea.KeyMsg) (bool, tea.Model, tea.Cmd
 	return false, m, nil
 }
 
+// groupNavHint lists the group navigation keys, as bound, for the info
+// line shown on entering group navigation.
+func (m Model) groupNavHint() string {
+	keys := func(action keymap.Action) string {
+		return keymap.Labels(m.keyBindings().Keys(keymap.UltraPlanGroups, action))
+	}
+	return fmt.Sprintf("%s/%s select, %s toggle, %s expand all, %s collapse all, %s exit",
+		keys(keymap.ScrollUp), keys(keymap.ScrollDown), keys(keymap.ToggleGroup),
+		keys(keymap.ExpandAll), keys(keymap.CollapseAll), keys(keymap.Close))
+}
+
 // canRetriggerGroup returns true if group re-triggering is currently allowed
 func (m *Model) canRetriggerGroup(session *orchestrator.UltraPlanSession) bool {
 	if session == nil || session.Plan == nil {
@@ -820,6 +935,7 @@ func (m *Model) handlePlanManagerCompletion(inst *orchestrator.Instance) bool {
 		} else {
 			m.infoMessage = fmt.Sprintf("%s: %d tasks in %d groups. Auto-starting execution...",
 				decisionDesc, len(plan.Tasks), len(plan.ExecutionOrder))
+			m.recordPlanAutoApproved(plan)
 		}
 	}
 
@@ -839,6 +955,12 @@ func (m *Model) handleUltraPlanCoordinatorCompletion(inst *orchestrator.Instance
 		return false
 	}
 
+	// The planner revising a draft is handled by the refinement file check;
+	// its completion must not be mistaken for the end of planning.
+	if r := session.PlanRefinement; r != nil && r.Active && r.RefinerID == inst.ID {
+		return true
+	}
+
 	// Multi-pass mode: dispatch to specialized handlers based on phase
 	if session.Config.MultiPass {
 		// During planning phase, check if this is one of the multi-pass coordinators
@@ -896,6 +1018,7 @@ func (m *Model) handleUltraPlanCoordinatorCompletion(inst *orchestrator.Instance
 		} else {
 			m.infoMessage = fmt.Sprintf("Plan ready: %d tasks in %d groups. Auto-starting execution...",
 				len(plan.Tasks), len(plan.ExecutionOrder))
+			m.recordPlanAutoApproved(plan)
 		}
 	}
 
@@ -1060,16 +1183,9 @@ func (m *Model) handlePlanFileCheckResult(msg tuimsg.PlanFileCheckResultMsg) (te
 		return m, nil
 	}
 
-	// Stop the coordinator instance (it's done its job)
-	if msg.InstanceID != "" {
-		inst := m.orchestrator.GetInstance(msg.InstanceID)
-		if inst != nil {
-			_ = m.orchestrator.StopInstance(inst)
-		}
-	}
-
 	// Determine whether to open plan editor or auto-start execution
 	if session.Config.Review || !session.Config.AutoApprove {
+		// The coordinator stays up so review feedback can be sent back to it
 		// Enter plan editor for interactive review
 		m.enterPlanEditor()
 		m.infoMessage = fmt.Sprintf("Plan detected: %d tasks in %d groups. Review and press [enter] to execute, or [esc] to cancel.",
@@ -1078,18 +1194,64 @@ func (m *Model) handlePlanFileCheckResult(msg tuimsg.PlanFileCheckResultMsg) (te
 		m.ultraPlan.NeedsNotification = true
 		m.ultraPlan.LastNotifiedPhase = orchestrator.PhaseRefresh
 	} else {
+		// Stop the coordinator instance (it's done its job)
+		if msg.InstanceID != "" {
+			inst := m.orchestrator.GetInstance(msg.InstanceID)
+			if inst != nil {
+				_ = m.orchestrator.StopInstance(inst)
+			}
+		}
+
 		// Auto-start execution
 		if err := m.ultraPlan.Coordinator.StartExecution(); err != nil {
 			m.errorMessage = fmt.Sprintf("Plan detected but failed to auto-start: %v", err)
 		} else {
 			m.infoMessage = fmt.Sprintf("Plan detected: %d tasks in %d groups. Auto-starting execution...",
 				len(msg.Plan.Tasks), len(msg.Plan.ExecutionOrder))
+			m.recordPlanAutoApproved(msg.Plan)
 		}
 	}
 
 	return m, nil
 }
 
+// handlePlanRefinementFileCheckResult installs a plan the planner revised
+// after human feedback and reopens the plan editor, which shows what changed
+// since the previous draft.
+func (m *Model) handlePlanRefinementFileCheckResult(msg tuimsg.PlanRefinementFileCheckResultMsg) (tea.Model, tea.Cmd) {
+	if msg.Plan == nil || m.ultraPlan == nil || m.ultraPlan.Coordinator == nil {
+		return m, nil
+	}
+
+	session := m.ultraPlan.Coordinator.Session()
+	if session == nil || session.PlanRefinement == nil || !session.PlanRefinement.Active {
+		return m, nil
+	}
+
+	if err := m.ultraPlan.Coordinator.CompletePlanRefinement(msg.Plan); err != nil {
+		// Keep the previous draft so the human can send new feedback or approve it
+		m.ultraPlan.Coordinator.CancelPlanRefinement()
+		m.enterPlanEditor()
+		m.errorMessage = fmt.Sprintf("Planner returned an unusable revision, keeping the previous draft: %v", err)
+		return m, nil
+	}
+
+	m.enterPlanEditor()
+	diff := orchestrator.DiffPlans(session.PlanRefinement.LastRound().Previous, msg.Plan)
+	m.infoMessage = fmt.Sprintf("Draft %d ready: %d added, %d removed, %d changed. Review and press [enter] to execute, or [R] to request more changes.",
+		session.PlanRefinement.Draft(), len(diff.Added), len(diff.Removed), len(diff.Changed))
+	m.ultraPlan.NeedsNotification = true
+	m.ultraPlan.LastNotifiedPhase = orchestrator.PhaseRefresh
+
+	if m.logger != nil {
+		m.logger.Info("plan revision received",
+			"draft", session.PlanRefinement.Draft(),
+			"task_count", len(msg.Plan.Tasks),
+		)
+	}
+	return m, nil
+}
+
 // handleMultiPassPlanFileCheckResult handles the async result of checking for multi-pass plan files.
 // This processes one coordinator's plan at a time and triggers the plan manager when all are collected.
 func (m *Model) handleMultiPassPlanFileCheckResult(msg tuimsg.MultiPassPlanFileCheckResultMsg) (tea.Model, tea.Cmd) {
@@ -1203,6 +1365,7 @@ func (m *Model) handlePlanManagerFileCheckResult(msg tuimsg.PlanManagerFileCheck
 		} else {
 			m.infoMessage = fmt.Sprintf("%s: %d tasks in %d groups. Auto-starting execution...",
 				decisionDesc, len(msg.Plan.Tasks), len(msg.Plan.ExecutionOrder))
+			m.recordPlanAutoApproved(msg.Plan)
 		}
 	}
 
@@ -1227,5 +1390,15 @@ func (m *Model) dispatchUltraPlanFileChecks() []tea.Cmd {
 	// Dispatch async check for plan manager file
 	cmds = append(cmds, tuimsg.CheckPlanManagerFileAsync(m.orchestrator, m.outputManager, m.ultraPlan))
 
+	// Dispatch async check for a plan revised after human feedback
+	cmds = append(cmds, tuimsg.CheckPlanRefinementFileAsync(m.orchestrator, m.ultraPlan))
+
 	return cmds
 }
+
+// recordPlanAutoApproved records in the audit log that a plan started
+// without review because auto-approve is on.
+func (m *Model) recordPlanAutoApproved(plan *orchestrator.PlanSpec) {
+	m.orchestrator.RecordOperatorAction(audit.ActionAutoApprove, "", "",
+		fmt.Sprintf("plan started without review (%d tasks)", len(plan.Tasks)))
+}
@@ -2,6 +2,7 @@ package update
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/Iron-Ham/claudio/internal/instance"
 	"github.com/Iron-Ham/claudio/internal/logging"
@@ -139,6 +140,49 @@ func HandleTimeout(ctx Context, m msg.TimeoutMsg) {
 	ctx.SetInfoMessage(fmt.Sprintf("Instance %s is %s - use Ctrl+R to restart or Ctrl+K to kill", inst.ID, statusText))
 }
 
+// HandleMailboxMessageFlagged surfaces a guard-flagged mailbox message so a
+// human can review what one instance tried to pass to another.
+func HandleMailboxMessageFlagged(ctx Context, m msg.MailboxMessageFlaggedMsg) {
+	action := "suspicious lines were stripped"
+	if m.Held {
+		action = "held for review, :release " + m.MessageID + " to deliver it"
+	}
+	ctx.SetInfoMessage(fmt.Sprintf("Message %s from %s to %s flagged (%s): %s",
+		m.MessageID, m.From, m.To, strings.Join(m.Flags, ", "), action))
+}
+
+// HandleTaskProposed tells the user an instance proposed a follow-up task and
+// how to decide on it.
+func HandleTaskProposed(ctx Context, m msg.TaskProposedMsg) {
+	ctx.SetInfoMessage(fmt.Sprintf("Task proposed by %s: %s - [y] approve, [n] reject", m.From, m.Title))
+}
+
+// HandleBaseDrift warns that the running plan's base branch moved. It is an
+// error when remaining tasks touch the changed files, since their work is
+// likely to conflict at consolidation.
+func HandleBaseDrift(ctx Context, m msg.BaseDriftMsg) {
+	if len(m.AffectedTasks) == 0 {
+		ctx.SetInfoMessage(fmt.Sprintf("%s gained %d commit(s) since the plan started; no remaining task touches the changed files", m.Ref, m.Commits))
+		return
+	}
+	action := "expect conflicts at consolidation"
+	if m.Replanned {
+		action = "tasks that start from now on will be told what changed"
+	}
+	ctx.SetErrorMessage(fmt.Sprintf("%s gained %d commit(s) touching files of task(s) %s - %s",
+		m.Ref, m.Commits, strings.Join(m.AffectedTasks, ", "), action))
+}
+
+// HandleBridgePlacementFailed surfaces a task that could not be placed on a
+// worker node, distinguishing a full inventory from one that can never fit.
+func HandleBridgePlacementFailed(ctx Context, m msg.BridgePlacementFailedMsg) {
+	if m.Waiting {
+		ctx.SetInfoMessage(fmt.Sprintf("Task %s waiting for worker node capacity", m.TaskID))
+		return
+	}
+	ctx.SetErrorMessage(fmt.Sprintf("Task %s could not be placed: %s", m.TaskID, m.Reason))
+}
+
 // HandleTaskAdded processes a TaskAddedMsg when async task addition completes.
 // It clears pending messages, switches to the new task, and logs the event.
 // If session.auto_start_on_add is enabled (default), the instance is started automatically.
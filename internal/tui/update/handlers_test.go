@@ -2,6 +2,7 @@ package update
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/Iron-Ham/claudio/internal/instance"
@@ -503,6 +504,101 @@ func TestHandlePRComplete_NilOrchestrator(t *testing.T) {
 	}
 }
 
+func TestHandleMailboxMessageFlagged(t *testing.T) {
+	tests := []struct {
+		name     string
+		held     bool
+		wantInfo string
+	}{
+		{"stripped", false, "Message msg-1 from inst-1 to broadcast flagged (override, role): suspicious lines were stripped"},
+		{"held", true, "Message msg-1 from inst-1 to broadcast flagged (override, role): held for review, :release msg-1 to deliver it"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newMockContext()
+			HandleMailboxMessageFlagged(ctx, msg.MailboxMessageFlaggedMsg{
+				MessageID: "msg-1",
+				From:      "inst-1",
+				To:        "broadcast",
+				Flags:     []string{"override", "role"},
+				Held:      tt.held,
+			})
+			if ctx.infoMessage != tt.wantInfo {
+				t.Errorf("infoMessage = %q, want %q", ctx.infoMessage, tt.wantInfo)
+			}
+		})
+	}
+}
+
+func TestHandleTaskProposed(t *testing.T) {
+	ctx := newMockContext()
+	HandleTaskProposed(ctx, msg.TaskProposedMsg{ProposalID: "proposal-1", From: "t2", Title: "Remove dead helper"})
+	want := "Task proposed by t2: Remove dead helper - [y] approve, [n] reject"
+	if ctx.infoMessage != want {
+		t.Errorf("infoMessage = %q, want %q", ctx.infoMessage, want)
+	}
+}
+
+func TestHandleBaseDrift(t *testing.T) {
+	t.Run("no affected tasks", func(t *testing.T) {
+		ctx := newMockContext()
+		HandleBaseDrift(ctx, msg.BaseDriftMsg{Ref: "origin/main", Commits: 2})
+		want := "origin/main gained 2 commit(s) since the plan started; no remaining task touches the changed files"
+		if ctx.infoMessage != want {
+			t.Errorf("infoMessage = %q, want %q", ctx.infoMessage, want)
+		}
+		if ctx.errorMessage != "" {
+			t.Errorf("errorMessage = %q, want empty", ctx.errorMessage)
+		}
+	})
+
+	t.Run("affected tasks", func(t *testing.T) {
+		ctx := newMockContext()
+		HandleBaseDrift(ctx, msg.BaseDriftMsg{Ref: "origin/main", Commits: 1, AffectedTasks: []string{"task-2", "task-4"}})
+		want := "origin/main gained 1 commit(s) touching files of task(s) task-2, task-4 - expect conflicts at consolidation"
+		if ctx.errorMessage != want {
+			t.Errorf("errorMessage = %q, want %q", ctx.errorMessage, want)
+		}
+	})
+
+	t.Run("replanned", func(t *testing.T) {
+		ctx := newMockContext()
+		HandleBaseDrift(ctx, msg.BaseDriftMsg{Ref: "origin/main", Commits: 1, AffectedTasks: []string{"task-2"}, Replanned: true})
+		if !strings.Contains(ctx.errorMessage, "will be told what changed") {
+			t.Errorf("errorMessage = %q, want replan notice", ctx.errorMessage)
+		}
+	})
+}
+
+func TestHandleBridgePlacementFailed(t *testing.T) {
+	t.Run("waiting", func(t *testing.T) {
+		ctx := newMockContext()
+		HandleBridgePlacementFailed(ctx, msg.BridgePlacementFailedMsg{
+			TaskID:  "task-1",
+			Reason:  "bridge: no node has free capacity",
+			Waiting: true,
+		})
+		if want := "Task task-1 waiting for worker node capacity"; ctx.infoMessage != want {
+			t.Errorf("infoMessage = %q, want %q", ctx.infoMessage, want)
+		}
+		if ctx.errorMessage != "" {
+			t.Errorf("errorMessage = %q, want empty", ctx.errorMessage)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		ctx := newMockContext()
+		HandleBridgePlacementFailed(ctx, msg.BridgePlacementFailedMsg{
+			TaskID: "task-1",
+			Reason: `bridge: no node serves backend "vllm"`,
+		})
+		if want := `Task task-1 could not be placed: bridge: no node serves backend "vllm"`; ctx.errorMessage != want {
+			t.Errorf("errorMessage = %q, want %q", ctx.errorMessage, want)
+		}
+	})
+}
+
 func TestHandleTimeout_UnknownType(t *testing.T) {
 	// Test case where an unknown timeout type is provided
 	// This exercises the default case in the switch statement
@@ -0,0 +1,144 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/Iron-Ham/claudio/internal/tui/theme"
+	"github.com/Iron-Ham/claudio/internal/worktree"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// maxConflictFileRows caps the file list of the conflict view, leaving the
+// rest of the height to the selected file's hunks.
+const maxConflictFileRows = 8
+
+// ConflictViewState holds the state needed to render the conflict view.
+type ConflictViewState struct {
+	// TaskID is the task whose commits conflicted
+	TaskID string
+
+	// Worktree is where the cherry-pick is paused
+	Worktree string
+
+	// Files are the files still conflicted, with their hunks
+	Files []worktree.ConflictedFile
+
+	// Selected is the index of the selected file
+	Selected int
+
+	// Scroll is how many lines of the selected file's hunks are scrolled past
+	Scroll int
+
+	// Loading indicates the files are being read
+	Loading bool
+
+	// Err is why the conflicts could not be read, if they couldn't
+	Err string
+}
+
+// ConflictHunkLines returns the lines the hunks of file render to, for the
+// model to bound scrolling by.
+func ConflictHunkLines(file worktree.ConflictedFile) []string {
+	if file.Err != nil {
+		return []string{styles.Muted.Render("Cannot read the file: " + file.Err.Error())}
+	}
+	if len(file.Hunks) == 0 {
+		return []string{styles.Muted.Render("No conflict markers: deleted on one side, or edited. Keep a side, or mark it resolved.")}
+	}
+
+	ours := styles.DiffAdd
+	theirs := theme.Current().Accent()
+	var lines []string
+	for i, h := range file.Hunks {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, styles.Muted.Render(fmt.Sprintf("@@ line %d", h.Line)))
+		lines = append(lines, ours.Render("<<<<<<< ours "+conflictLabel(h.OursLabel)))
+		for _, l := range h.Ours {
+			lines = append(lines, ours.Render("│ ")+l)
+		}
+		lines = append(lines, styles.Muted.Render("======="))
+		for _, l := range h.Theirs {
+			lines = append(lines, theirs.Render("│ ")+l)
+		}
+		lines = append(lines, theirs.Render(">>>>>>> theirs "+conflictLabel(h.TheirsLabel)))
+	}
+	return lines
+}
+
+// conflictLabel returns a hunk side's label in parentheses, or "" for none.
+func conflictLabel(label string) string {
+	if label == "" {
+		return ""
+	}
+	return "(" + label + ")"
+}
+
+// ConflictHunkRows returns how many hunk lines fit within height with files
+// listed above them.
+func ConflictHunkRows(files, height int) int {
+	// Header and blank line, the file list, and a blank line
+	return max(height-3-min(files, maxConflictFileRows), 1)
+}
+
+// RenderConflicts renders the conflict view: the files still conflicted in
+// a paused consolidation, and the hunks of the selected one with our side
+// and their side colored apart.
+func RenderConflicts(state ConflictViewState, width, height int) string {
+	var b strings.Builder
+
+	title := "Consolidation Conflict"
+	if state.TaskID != "" {
+		title += ": " + state.TaskID
+	}
+	b.WriteString(styles.Title.UnsetMarginBottom().Render(title) + "  " +
+		styles.Muted.Render(ansi.Truncate(state.Worktree, max(width-ansi.StringWidth(title)-6, 0), "…")))
+	b.WriteString("\n\n")
+
+	switch {
+	case state.Err != "":
+		b.WriteString(theme.Current().Failure().Render(state.Err))
+		return b.String()
+	case state.Loading && len(state.Files) == 0:
+		b.WriteString(styles.Muted.Render("Reading conflicts…"))
+		return b.String()
+	case len(state.Files) == 0:
+		b.WriteString(theme.Current().Success().Render("All conflicts resolved.") + " " +
+			styles.Muted.Render("Resume consolidation to continue the cherry-pick."))
+		return b.String()
+	}
+
+	rows := min(len(state.Files), maxConflictFileRows)
+	start := max(min(state.Selected-rows/2, len(state.Files)-rows), 0)
+	for i := start; i < start+rows; i++ {
+		f := state.Files[i]
+		hunks := "no hunks"
+		if n := len(f.Hunks); n > 0 {
+			hunks = fmt.Sprintf("%d hunk", n)
+			if n > 1 {
+				hunks += "s"
+			}
+		}
+		line := fmt.Sprintf("  ✗ %s  ", f.Path)
+		if i == state.Selected {
+			b.WriteString(theme.Current().Selected().Render(ansi.Truncate(line+hunks, width-4, "…")))
+		} else {
+			b.WriteString(ansi.Truncate(line+styles.Muted.Render(hunks), width-4, "…"))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	lines := ConflictHunkLines(state.Files[state.Selected])
+	hunkRows := ConflictHunkRows(len(state.Files), height)
+	scroll := max(min(state.Scroll, len(lines)-hunkRows), 0)
+	lines = lines[scroll:min(scroll+hunkRows, len(lines))]
+	for i, l := range lines {
+		lines[i] = ansi.Truncate(l, width-2, "…")
+	}
+	b.WriteString(strings.Join(lines, "\n"))
+	return b.String()
+}
@@ -0,0 +1,72 @@
+package view
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/worktree"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRenderConflicts(t *testing.T) {
+	files := []worktree.ConflictedFile{
+		{Path: "api/auth.go", Hunks: []worktree.ConflictHunk{
+			{Line: 12, OursLabel: "HEAD", TheirsLabel: "abc123 (Add tokens)", Ours: []string{"return nil"}, Theirs: []string{"return token"}},
+			{Line: 40, OursLabel: "HEAD", TheirsLabel: "abc123 (Add tokens)", Ours: []string{"a"}, Theirs: []string{"b"}},
+		}},
+		{Path: "README.md"},
+	}
+
+	t.Run("selected file's hunks", func(t *testing.T) {
+		out := ansi.Strip(RenderConflicts(ConflictViewState{
+			TaskID:   "task-3",
+			Worktree: "/tmp/conflict",
+			Files:    files,
+		}, 80, 40))
+		for _, want := range []string{
+			"Consolidation Conflict: task-3", "/tmp/conflict",
+			"✗ api/auth.go  2 hunks", "✗ README.md  no hunks",
+			"@@ line 12", "<<<<<<< ours (HEAD)", "│ return nil", "│ return token",
+			">>>>>>> theirs (abc123 (Add tokens))", "@@ line 40",
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("view missing %q:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("scrolled within the height", func(t *testing.T) {
+		out := ansi.Strip(RenderConflicts(ConflictViewState{Files: files, Scroll: 6}, 80, 8))
+		if strings.Contains(out, "@@ line 12") || !strings.Contains(out, "@@ line 40") {
+			t.Errorf("scrolled view:\n%s", out)
+		}
+		if lines := strings.Count(out, "\n") + 1; lines > 8 {
+			t.Errorf("view is %d lines, want at most 8", lines)
+		}
+	})
+
+	t.Run("file without markers", func(t *testing.T) {
+		out := ansi.Strip(RenderConflicts(ConflictViewState{Files: files, Selected: 1}, 80, 20))
+		if !strings.Contains(out, "No conflict markers") {
+			t.Errorf("view:\n%s", out)
+		}
+	})
+
+	t.Run("states", func(t *testing.T) {
+		tests := []struct {
+			state ConflictViewState
+			want  string
+		}{
+			{ConflictViewState{}, "All conflicts resolved."},
+			{ConflictViewState{Loading: true}, "Reading conflicts…"},
+			{ConflictViewState{Err: "Cannot read the conflicts: boom"}, "boom"},
+			{ConflictViewState{Files: []worktree.ConflictedFile{{Path: "x", Err: errors.New("denied")}}}, "Cannot read the file: denied"},
+		}
+		for _, tt := range tests {
+			if out := ansi.Strip(RenderConflicts(tt.state, 80, 20)); !strings.Contains(out, tt.want) {
+				t.Errorf("view missing %q:\n%s", tt.want, out)
+			}
+		}
+	})
+}
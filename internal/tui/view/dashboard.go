@@ -70,57 +70,22 @@ func (dv *DashboardView) RenderSidebar(state DashboardState, width, height int)
 		b.WriteString("\n")
 		b.WriteString(styles.Muted.Render("Press [:a] to add"))
 	} else {
-		// Calculate available lines for content (not slots - actual lines!)
-		// Reserve: 1 for title, 1 for blank line, 1 for add hint, 2 for scroll indicators, plus border padding
-		reservedLines := 6
-		if isAddingTask {
-			reservedLines += 2 // "New Task" entry takes 2 lines
-		}
-		availableLines := max(height-reservedLines, 3) // Minimum to show at least a few lines
-
 		scrollOffset := state.SidebarScrollOffset()
-		hasMoreAbove := scrollOffset > 0
 
 		// Show scroll up indicator if there are instances above (counts as 1 line)
-		if hasMoreAbove {
+		if scrollOffset > 0 {
 			scrollUp := styles.Muted.Render(fmt.Sprintf("▲ %d more above", scrollOffset))
 			b.WriteString(scrollUp)
 			b.WriteString("\n")
-			availableLines-- // Account for scroll indicator line
 		}
 
-		// Reserve space for scroll down indicator (1 line)
-		availableLines--
-
-		// Track actual lines used and where we stop rendering
-		linesUsed := 0
-		lastRenderedIdx := scrollOffset - 1 // Will be updated as we render
-
-		// Render visible instances, tracking actual line usage
-		activeTab := -1 // No instance highlighted when adding
-		if !isAddingTask {
-			activeTab = state.ActiveTab()
-		}
-		intelligentNaming := state.IntelligentNamingEnabled()
-
-		for i := scrollOffset; i < instanceCount; i++ {
-			inst := session.Instances[i]
-			renderedContent := dv.renderSidebarInstance(i, inst, activeTab, width, intelligentNaming)
-
-			// Calculate how many lines this item will take
-			itemLines := strings.Count(renderedContent, "\n") + 1
-
-			// Check if adding this item would exceed available lines
-			if linesUsed+itemLines > availableLines {
-				break
-			}
-
-			// Render the item
-			b.WriteString(renderedContent)
+		// Render visible instances
+		entries := dv.layoutSidebar(state, width, height)
+		for _, entry := range entries {
+			b.WriteString(entry.content)
 			b.WriteString("\n")
-			linesUsed += itemLines
-			lastRenderedIdx = i
 		}
+		lastRenderedIdx := scrollOffset + len(entries) - 1
 
 		// Show scroll down indicator if there are more instances
 		hasMoreBelow := lastRenderedIdx < instanceCount-1
@@ -154,6 +119,71 @@ func (dv *DashboardView) RenderSidebar(state DashboardState, width, height int)
 	return styles.Sidebar.Width(width - 2).Render(b.String())
 }
 
+// sidebarEntry is one item of the sidebar list as rendered.
+type sidebarEntry struct {
+	content     string
+	instanceIdx int // Index in session.Instances, or -1 for a group header
+}
+
+// fitSidebarEntries renders items from first on, with render, for as long
+// as they fit in availableLines lines.
+func fitSidebarEntries(first, count, availableLines int, render func(i int) sidebarEntry) []sidebarEntry {
+	var entries []sidebarEntry
+	linesUsed := 0
+	for i := first; i < count; i++ {
+		entry := render(i)
+
+		// Calculate how many lines this item will take
+		itemLines := strings.Count(entry.content, "\n") + 1
+
+		// Check if adding this item would exceed available lines
+		if linesUsed+itemLines > availableLines {
+			break
+		}
+		entries = append(entries, entry)
+		linesUsed += itemLines
+	}
+	return entries
+}
+
+// layoutSidebar renders the instances visible in the flat sidebar, starting
+// at the scroll offset.
+func (dv *DashboardView) layoutSidebar(state DashboardState, width, height int) []sidebarEntry {
+	session := state.Session()
+	if session == nil {
+		return nil
+	}
+	isAddingTask := state.IsAddingTask()
+
+	// Calculate available lines for content (not slots - actual lines!)
+	// Reserve: 1 for title, 1 for blank line, 1 for add hint, 2 for scroll indicators, plus border padding
+	reservedLines := 6
+	if isAddingTask {
+		reservedLines += 2 // "New Task" entry takes 2 lines
+	}
+	availableLines := max(height-reservedLines, 3) // Minimum to show at least a few lines
+
+	scrollOffset := state.SidebarScrollOffset()
+	if scrollOffset > 0 {
+		availableLines-- // Account for scroll up indicator line
+	}
+	// Reserve space for scroll down indicator (1 line)
+	availableLines--
+
+	activeTab := -1 // No instance highlighted when adding
+	if !isAddingTask {
+		activeTab = state.ActiveTab()
+	}
+	intelligentNaming := state.IntelligentNamingEnabled()
+
+	return fitSidebarEntries(scrollOffset, len(session.Instances), availableLines, func(i int) sidebarEntry {
+		return sidebarEntry{
+			content:     dv.renderSidebarInstance(i, session.Instances[i], activeTab, width, intelligentNaming),
+			instanceIdx: i,
+		}
+	})
+}
+
 // renderEnhancedStatusLine renders a status indicator line with additional context.
 // Shows status abbreviation plus optional context info (duration, cost, files).
 func renderEnhancedStatusLine(inst *orchestrator.Instance, statusColor lipgloss.Color, indent int, maxWidth int) string {
@@ -0,0 +1,25 @@
+// Package dashboard renders every instance of a session at once, as a grid
+// of tiles.
+//
+// The TUI normally shows one instance's output at a time. The dashboard
+// (opened with :dashboard) gives an overview instead: each tile shows an
+// instance's status badge, its task, the last line of its output, and a
+// sparkline of its recent token usage alongside its total tokens and cost.
+// Arrow keys or h/j/k/l move between tiles and Enter opens the selected
+// instance.
+//
+// # Main Types
+//
+//   - [Tile]: The data one tile is rendered from
+//   - [State]: The tiles and the selected tile
+//   - [History]: Per-instance usage samples the sparklines are drawn from
+//
+// # Usage
+//
+//	history := dashboard.NewHistory()
+//	history.Record(now, usage) // on every tick
+//
+//	cols := dashboard.Columns(width)
+//	selected = dashboard.Move(selected, len(tiles), cols, dashboard.Down)
+//	out := dashboard.Render(dashboard.State{Tiles: tiles, Selected: selected}, width, height)
+package dashboard
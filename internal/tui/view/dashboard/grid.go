@@ -0,0 +1,182 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+
+	instmetrics "github.com/Iron-Ham/claudio/internal/instance/metrics"
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Layout of the grid
+const (
+	MinTileWidth = 36 // Narrowest a tile gets before the grid drops a column
+	MaxColumns   = 4
+	tileLines    = 5             // Content lines of a tile
+	tileHeight   = tileLines + 2 // Including the border
+	tileFrame    = 4             // Border and padding columns of a tile
+	sparkWidth   = 16            // Widest a tile's sparkline gets
+)
+
+// Tile is the data one tile is rendered from.
+type Tile struct {
+	Instance *orchestrator.Instance
+
+	// LastLine is the last non-blank line of the instance's output
+	LastLine string
+
+	// TokenRates is the instance's recent token usage per sample, oldest
+	// first, as returned by History.TokenRates
+	TokenRates []float64
+}
+
+// State is what the dashboard renders.
+type State struct {
+	Tiles    []Tile
+	Selected int // Index of the selected tile
+}
+
+// Columns returns how many tiles fit side by side in width.
+func Columns(width int) int {
+	return max(1, min(width/MinTileWidth, MaxColumns))
+}
+
+// Direction is a move between tiles.
+type Direction int
+
+// Directions of Move.
+const (
+	Left Direction = iota
+	Right
+	Up
+	Down
+)
+
+// Move returns the tile reached from selected by moving dir in a grid of
+// count tiles laid out cols wide. Moves off the edge of the grid stay put,
+// except that left and right wrap between rows.
+func Move(selected, count, cols int, dir Direction) int {
+	if count == 0 {
+		return 0
+	}
+	next := selected
+	switch dir {
+	case Left:
+		next--
+	case Right:
+		next++
+	case Up:
+		next -= cols
+	case Down:
+		next += cols
+		// Moving down into a partial last row lands on its last tile
+		if next >= count && selected/cols < (count-1)/cols {
+			next = count - 1
+		}
+	}
+	if next < 0 || next >= count {
+		return selected
+	}
+	return next
+}
+
+// Render renders the tiles as a grid filling width, scrolled so that the
+// selected tile is visible within height lines.
+func Render(state State, width, height int) string {
+	var b strings.Builder
+	header := styles.SidebarTitle.Render("Dashboard") + "  " +
+		styles.Muted.Render(fmt.Sprintf("%d instances", len(state.Tiles)))
+	b.WriteString(header)
+	b.WriteString("\n")
+
+	if len(state.Tiles) == 0 {
+		b.WriteString(styles.Muted.Render("No instances"))
+		return b.String()
+	}
+
+	cols := Columns(width)
+	tileWidth := width/cols - 1
+	rows := (len(state.Tiles) + cols - 1) / cols
+	visibleRows := max(1, (height-2)/tileHeight)
+	firstRow := max(0, state.Selected/cols-visibleRows+1)
+	lastRow := min(rows, firstRow+visibleRows)
+
+	for row := firstRow; row < lastRow; row++ {
+		tiles := make([]string, 0, cols)
+		for i := row * cols; i < min((row+1)*cols, len(state.Tiles)); i++ {
+			tiles = append(tiles, renderTile(i, state.Tiles[i], i == state.Selected, tileWidth))
+		}
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, tiles...))
+		b.WriteString("\n")
+	}
+
+	if firstRow > 0 || lastRow < rows {
+		b.WriteString(styles.Muted.Render(fmt.Sprintf("rows %d-%d of %d", firstRow+1, lastRow, rows)))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// renderTile renders the tile of instance i, bordered in the primary color
+// when selected.
+func renderTile(i int, tile Tile, selected bool, width int) string {
+	inst := tile.Instance
+	inner := max(width-tileFrame, 1)
+	statusColor := styles.StatusColor(string(inst.Status))
+
+	name := fmt.Sprintf("%d %s", i+1, oneLine(inst.EffectiveName()))
+	nameStyle := styles.Muted
+	if selected {
+		nameStyle = lipgloss.NewStyle().Bold(true).Foreground(styles.TextColor)
+	}
+	badge := lipgloss.NewStyle().Foreground(statusColor).Render(
+		styles.StatusIcon(string(inst.Status)) + " " + strings.ReplaceAll(string(inst.Status), "_", " "))
+
+	lastLine := tile.LastLine
+	if lastLine == "" {
+		lastLine = "(no output)"
+	}
+
+	usage := styles.Muted.Render("no usage yet")
+	if m := inst.Metrics; m != nil && (m.TotalTokens() > 0 || m.Cost > 0) {
+		usage = styles.Muted.Render(instmetrics.FormatTokens(m.TotalTokens())+" "+instmetrics.FormatCost(m.Cost)) + " " +
+			lipgloss.NewStyle().Foreground(statusColor).Render(Sparkline(tile.TokenRates, min(sparkWidth, inner/2)))
+	}
+
+	lines := []string{
+		nameStyle.Render(ansi.Truncate(name, inner, "…")),
+		badge,
+		styles.Muted.Render(ansi.Truncate(oneLine(inst.Task), inner, "…")),
+		ansi.Truncate(lastLine, inner, "…"),
+		usage,
+	}
+
+	border := styles.BorderColor
+	if selected {
+		border = styles.PrimaryColor
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Padding(0, 1).
+		Width(width - 2).
+		Render(strings.Join(lines, "\n"))
+}
+
+// oneLine collapses whitespace, including newlines, to single spaces.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// LastLine returns the last non-blank line of output with ANSI escapes
+// removed, for a tile's LastLine.
+func LastLine(lines []string) string {
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(ansi.Strip(lines[i])); line != "" {
+			return line
+		}
+	}
+	return ""
+}
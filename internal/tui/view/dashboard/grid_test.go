@@ -0,0 +1,133 @@
+package dashboard
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestColumns(t *testing.T) {
+	tests := []struct {
+		width int
+		want  int
+	}{
+		{10, 1},
+		{MinTileWidth * 2, 2},
+		{MinTileWidth*3 - 1, 2},
+		{1000, MaxColumns},
+	}
+	for _, tt := range tests {
+		if got := Columns(tt.width); got != tt.want {
+			t.Errorf("Columns(%d) = %d, want %d", tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestMove(t *testing.T) {
+	// 7 tiles, 3 wide:
+	//   0 1 2
+	//   3 4 5
+	//   6
+	tests := []struct {
+		name     string
+		selected int
+		dir      Direction
+		want     int
+	}{
+		{"right", 0, Right, 1},
+		{"right wraps to next row", 2, Right, 3},
+		{"right at end stays", 6, Right, 6},
+		{"left wraps to previous row", 3, Left, 2},
+		{"left at start stays", 0, Left, 0},
+		{"down", 1, Down, 4},
+		{"down into partial row", 5, Down, 6},
+		{"down from last row stays", 6, Down, 6},
+		{"up", 4, Up, 1},
+		{"up from first row stays", 2, Up, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Move(tt.selected, 7, 3, tt.dir); got != tt.want {
+				t.Errorf("Move(%d, %v) = %d, want %d", tt.selected, tt.dir, got, tt.want)
+			}
+		})
+	}
+	if got := Move(0, 0, 3, Down); got != 0 {
+		t.Errorf("Move with no tiles = %d, want 0", got)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		want   string
+	}{
+		{"empty", nil, 4, "    "},
+		{"padded", []float64{0, 10}, 4, "  ▁█"},
+		{"scaled to peak", []float64{0, 1, 5, 10}, 4, "▁▃▅█"},
+		{"keeps the latest", []float64{10, 10, 0, 10}, 2, "▁█"},
+		{"no width", []float64{1}, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sparkline(tt.values, tt.width); got != tt.want {
+				t.Errorf("Sparkline(%v, %d) = %q, want %q", tt.values, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLastLine(t *testing.T) {
+	lines := []string{"first", "\x1b[32mRunning tests\x1b[0m  ", "", "   "}
+	if got := LastLine(lines); got != "Running tests" {
+		t.Errorf("LastLine() = %q, want %q", got, "Running tests")
+	}
+	if got := LastLine(nil); got != "" {
+		t.Errorf("LastLine(nil) = %q, want empty", got)
+	}
+}
+
+func TestRender(t *testing.T) {
+	tiles := []Tile{
+		{
+			Instance: &orchestrator.Instance{
+				ID: "a", Task: "Fix the login\nflow", Status: orchestrator.StatusWorking,
+				Metrics: &orchestrator.Metrics{InputTokens: 1500, Cost: 0.25},
+			},
+			LastLine:   "Editing auth.go",
+			TokenRates: []float64{0, 500, 1000},
+		},
+		{Instance: &orchestrator.Instance{ID: "b", Task: "Write docs", Status: orchestrator.StatusCompleted}},
+		{Instance: &orchestrator.Instance{ID: "c", Task: "Add tests", Status: orchestrator.StatusPending}},
+	}
+
+	out := ansi.Strip(Render(State{Tiles: tiles, Selected: 1}, MinTileWidth*2, 100))
+	for _, want := range []string{
+		"3 instances", "1 Fix the login flow", "working", "Editing auth.go", "1.5K", "$0.25",
+		"completed", "(no output)", "no usage yet", "3 Add tests",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Render() missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "rows") {
+		t.Errorf("Render() shows a scroll hint when every row fits:\n%s", out)
+	}
+
+	// Only one row of tiles fits; the selected tile's row is shown
+	out = ansi.Strip(Render(State{Tiles: tiles, Selected: 2}, MinTileWidth*2, tileHeight+2))
+	if !strings.Contains(out, "3 Add tests") || strings.Contains(out, "Write docs") {
+		t.Errorf("Render() should scroll to the selected row:\n%s", out)
+	}
+	if !strings.Contains(out, "rows 2-2 of 2") {
+		t.Errorf("Render() missing scroll hint:\n%s", out)
+	}
+
+	if out := ansi.Strip(Render(State{}, 80, 20)); !strings.Contains(out, "No instances") {
+		t.Errorf("Render() with no tiles = %q", out)
+	}
+}
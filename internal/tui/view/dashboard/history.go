@@ -0,0 +1,66 @@
+package dashboard
+
+import "time"
+
+// SampleInterval is how often History takes a sample, and MaxSamples how
+// many it keeps for each instance, so sparklines cover the last four
+// minutes.
+const (
+	SampleInterval = 5 * time.Second
+	MaxSamples     = 48
+)
+
+// Usage is an instance's cumulative usage at the time of a sample.
+type Usage struct {
+	Tokens int64
+	Cost   float64
+}
+
+// History keeps recent usage samples of each instance. It is not safe for
+// concurrent use; the TUI records and reads it on the update goroutine.
+type History struct {
+	last   time.Time
+	series map[string][]Usage
+}
+
+// NewHistory creates an empty History.
+func NewHistory() *History {
+	return &History{series: make(map[string][]Usage)}
+}
+
+// Record takes a sample of every instance in usage, keyed by instance ID,
+// if SampleInterval has passed since the last one. Instances missing from
+// usage have been removed and their samples are dropped.
+func (h *History) Record(now time.Time, usage map[string]Usage) {
+	if !h.last.IsZero() && now.Sub(h.last) < SampleInterval {
+		return
+	}
+	h.last = now
+	for id := range h.series {
+		if _, ok := usage[id]; !ok {
+			delete(h.series, id)
+		}
+	}
+	for id, u := range usage {
+		s := append(h.series[id], u)
+		if len(s) > MaxSamples {
+			s = s[len(s)-MaxSamples:]
+		}
+		h.series[id] = s
+	}
+}
+
+// TokenRates returns the tokens an instance used between consecutive
+// samples, oldest first.
+func (h *History) TokenRates(instanceID string) []float64 {
+	s := h.series[instanceID]
+	if len(s) < 2 {
+		return nil
+	}
+	rates := make([]float64, len(s)-1)
+	for i := 1; i < len(s); i++ {
+		// Usage only grows; a drop means metrics were reset
+		rates[i-1] = float64(max(s[i].Tokens-s[i-1].Tokens, 0))
+	}
+	return rates
+}
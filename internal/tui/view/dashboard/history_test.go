@@ -0,0 +1,40 @@
+package dashboard
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestHistory_TokenRates(t *testing.T) {
+	h := NewHistory()
+	start := time.Now()
+	h.Record(start, map[string]Usage{"a": {Tokens: 100}, "b": {Tokens: 10}})
+	// Too soon after the last sample to take another
+	h.Record(start.Add(time.Second), map[string]Usage{"a": {Tokens: 900}, "b": {Tokens: 900}})
+	h.Record(start.Add(SampleInterval), map[string]Usage{"a": {Tokens: 400}, "b": {Tokens: 60}})
+	h.Record(start.Add(2*SampleInterval), map[string]Usage{"a": {Tokens: 300}})
+
+	if got, want := h.TokenRates("a"), []float64{300, 0}; !slices.Equal(got, want) {
+		t.Errorf("TokenRates(a) = %v, want %v", got, want)
+	}
+	if got := h.TokenRates("b"); got != nil {
+		t.Errorf("TokenRates(b) = %v, want nil after the instance was removed", got)
+	}
+}
+
+func TestHistory_KeepsMaxSamples(t *testing.T) {
+	h := NewHistory()
+	start := time.Now()
+	for i := range MaxSamples + 10 {
+		h.Record(start.Add(time.Duration(i)*SampleInterval), map[string]Usage{"a": {Tokens: int64(i * i)}})
+	}
+	rates := h.TokenRates("a")
+	if len(rates) != MaxSamples-1 {
+		t.Fatalf("len(TokenRates) = %d, want %d", len(rates), MaxSamples-1)
+	}
+	// The last sample is (MaxSamples+9)^2, the one before (MaxSamples+8)^2
+	if got, want := rates[len(rates)-1], float64(2*(MaxSamples+8)+1); got != want {
+		t.Errorf("latest rate = %v, want %v", got, want)
+	}
+}
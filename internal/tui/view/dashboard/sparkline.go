@@ -0,0 +1,34 @@
+package dashboard
+
+import "strings"
+
+// sparkBlocks are the levels of a sparkline, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders the last width values as a line of block characters
+// scaled to the largest of them; only zero uses the lowest block, so any
+// activity shows. Shorter series are padded on the left so the most recent
+// value is always at the right edge.
+func Sparkline(values []float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	peak := 0.0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Repeat(" ", width-len(values)))
+	for _, v := range values {
+		level := 0
+		if peak > 0 && v > 0 {
+			level = 1 + int(v/peak*float64(len(sparkBlocks)-2)+0.5)
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
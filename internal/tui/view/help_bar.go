@@ -1,8 +1,10 @@
 package view
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/Iron-Ham/claudio/internal/tui/input"
 	"github.com/Iron-Ham/claudio/internal/tui/styles"
 	"github.com/spf13/viper"
 )
@@ -24,6 +26,35 @@ type HelpBarState struct {
 
 	// FilterMode indicates whether filter mode is active
 	FilterMode bool
+
+	// SelectMode indicates whether output selection mode is active
+	SelectMode bool
+
+	// SelectedLines is the number of output lines currently selected
+	SelectedLines int
+
+	// ReplayMode indicates whether transcript replay mode is active
+	ReplayMode bool
+
+	// ReplayPlaying indicates whether the replay is playing
+	ReplayPlaying bool
+
+	// DashboardMode indicates whether the instance dashboard is open
+	DashboardMode bool
+
+	// SplitMode indicates whether the split comparison view is open, and
+	// SplitSearching whether its search query is being typed
+	SplitMode      bool
+	SplitSearching bool
+
+	// GrepMode indicates whether the :grep results are open
+	GrepMode bool
+
+	// ConflictMode indicates whether the consolidation conflict view is open
+	ConflictMode bool
+
+	// DepsMode indicates whether the task dependency graph is open
+	DepsMode bool
 }
 
 // HelpBarView handles rendering of help bars for different modes.
@@ -125,6 +156,85 @@ func (v *HelpBarView) RenderHelp(state *HelpBarState) string {
 		return styles.HelpBar.Render(badge + "  " + help)
 	}
 
+	if state.DashboardMode {
+		badge := styles.ModeBadgeSelect.Render("DASHBOARD")
+		help := styles.HelpKey.Render("[h/j/k/l]") + " move  " +
+			styles.HelpKey.Render("[g/G]") + " first/last  " +
+			styles.HelpKey.Render("[Enter]") + " open  " +
+			styles.HelpKey.Render("[Esc]") + " close"
+		return styles.HelpBar.Render(badge + "  " + help)
+	}
+
+	if state.SplitMode {
+		badge := styles.ModeBadgeSelect.Render("SPLIT")
+		if state.SplitSearching {
+			help := styles.HelpKey.Render("[Enter]") + " search both  " +
+				styles.HelpKey.Render("[Esc]") + " cancel"
+			return styles.HelpBar.Render(badge + "  " + help)
+		}
+		help := styles.HelpKey.Render("[Tab]") + " pane  " +
+			styles.HelpKey.Render("[j/k]") + " scroll  " +
+			styles.HelpKey.Render("[/]") + " search  " +
+			styles.HelpKey.Render("[n/N]") + " next/prev  " +
+			styles.HelpKey.Render("[Enter]") + " open  " +
+			styles.HelpKey.Render("[Esc]") + " close"
+		return styles.HelpBar.Render(badge + "  " + help)
+	}
+
+	if state.GrepMode {
+		badge := styles.ModeBadgeSelect.Render("GREP")
+		help := styles.HelpKey.Render("[j/k]") + " move  " +
+			styles.HelpKey.Render("[g/G]") + " first/last  " +
+			styles.HelpKey.Render("[Enter]") + " jump  " +
+			styles.HelpKey.Render("[Esc]") + " close"
+		return styles.HelpBar.Render(badge + "  " + help)
+	}
+
+	if state.ConflictMode {
+		badge := styles.ModeBadgeSelect.Render("CONFLICTS")
+		help := styles.HelpKey.Render("[j/k]") + " file  " +
+			styles.HelpKey.Render("[o/t]") + " ours/theirs  " +
+			styles.HelpKey.Render("[e]") + " edit  " +
+			styles.HelpKey.Render("[a]") + " mark resolved  " +
+			styles.HelpKey.Render("[c]") + " resolver  " +
+			styles.HelpKey.Render("[r]") + " resume  " +
+			styles.HelpKey.Render("[Esc]") + " close"
+		return styles.HelpBar.Render(badge + "  " + help)
+	}
+
+	if state.DepsMode {
+		badge := styles.ModeBadgeSelect.Render("DEPS")
+		help := styles.HelpKey.Render("[j/k]") + " task  " +
+			styles.HelpKey.Render("[g/G]") + " first/last  " +
+			styles.HelpKey.Render("[Enter]") + " jump to instance  " +
+			styles.HelpKey.Render("[Esc]") + " close"
+		return styles.HelpBar.Render(badge + "  " + help)
+	}
+
+	if state.SelectMode {
+		badge := styles.ModeBadgeSelect.Render("SELECT")
+		help := styles.Secondary.Render(fmt.Sprintf("%d line(s)", state.SelectedLines)) + "  " +
+			styles.HelpKey.Render("[j/k]") + " extend  " +
+			styles.HelpKey.Render("[o]") + " other end  " +
+			styles.HelpKey.Render("[y/Enter]") + " copy  " +
+			styles.HelpKey.Render("[Esc]") + " cancel"
+		return styles.HelpBar.Render(badge + "  " + help)
+	}
+
+	if state.ReplayMode {
+		badge := styles.ModeBadgeSelect.Render("REPLAY")
+		play := " play"
+		if state.ReplayPlaying {
+			play = " pause"
+		}
+		help := styles.HelpKey.Render("[Space]") + play + "  " +
+			styles.HelpKey.Render("[h/l]") + " frame  " +
+			styles.HelpKey.Render("[H/L]") + " ±10s  " +
+			styles.HelpKey.Render("[g/G]") + " start/end  " +
+			styles.HelpKey.Render("[Esc]") + " close"
+		return styles.HelpBar.Render(badge + "  " + help)
+	}
+
 	// Normal mode - show NORMAL badge
 	badge := styles.ModeBadgeNormal.Render("NORMAL")
 
@@ -132,7 +242,7 @@ func (v *HelpBarView) RenderHelp(state *HelpBarState) string {
 		styles.HelpKey.Render("[:]") + " cmd",
 		styles.HelpKey.Render("[j/k]") + " scroll",
 		styles.HelpKey.Render("[Tab]") + " switch",
-		styles.HelpKey.Render("[i]") + " input",
+		styles.HelpKey.Render(inputKeyHint()) + " input",
 		styles.HelpKey.Render("[?]") + " help",
 		styles.HelpKey.Render("[:q]") + " quit",
 	}
@@ -182,3 +292,9 @@ func RenderHelp(state *HelpBarState) string {
 func RenderTripleShotHelp(state *HelpBarState) string {
 	return helpBarView.RenderTripleShotHelp(state)
 }
+
+// inputKeyHint returns the bracketed key that enters input mode, honoring
+// tui.require_input_modifier.
+func inputKeyHint() string {
+	return "[" + input.EnterKeyLabel(viper.GetBool("tui.require_input_modifier")) + "]"
+}
@@ -86,6 +86,37 @@ func TestRenderHelp(t *testing.T) {
 			},
 			contains: []string{"FILTER", "toggle"},
 		},
+		{
+			name: "select mode shows selection help",
+			state: &HelpBarState{
+				SelectMode:    true,
+				SelectedLines: 3,
+			},
+			contains: []string{"SELECT", "3 line(s)", "copy", "cancel"},
+		},
+		{
+			name: "replay mode shows replay help",
+			state: &HelpBarState{
+				ReplayMode:    true,
+				ReplayPlaying: true,
+			},
+			contains: []string{"REPLAY", "pause", "frame", "close"},
+		},
+		{
+			name:     "dashboard mode shows dashboard help",
+			state:    &HelpBarState{DashboardMode: true},
+			contains: []string{"DASHBOARD", "move", "open", "close"},
+		},
+		{
+			name:     "split mode shows split help",
+			state:    &HelpBarState{SplitMode: true},
+			contains: []string{"SPLIT", "pane", "search", "next/prev"},
+		},
+		{
+			name:     "split search shows search help",
+			state:    &HelpBarState{SplitMode: true, SplitSearching: true},
+			contains: []string{"SPLIT", "search both", "cancel"},
+		},
 		{
 			name:     "normal mode shows default keys with NORMAL badge",
 			state:    &HelpBarState{},
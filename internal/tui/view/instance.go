@@ -11,6 +11,7 @@ import (
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	"github.com/Iron-Ham/claudio/internal/tui/styles"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // InstanceView handles rendering of a single instance's detail view.
@@ -53,6 +54,21 @@ type RenderState struct {
 	HasNewOutput bool
 	// GroupedViewEnabled indicates if the grouped view mode is active
 	GroupedViewEnabled bool
+	// Selection highlights output lines selected for copying (nil when not selecting)
+	Selection *LineSelection
+	// SimilarTo is the ID of another instance whose output is near-identical,
+	// shown in the header to flag instances failing the same way ("" = none)
+	SimilarTo string
+	// SimilarScore is the fraction of output SimilarTo shares with this instance (0-1)
+	SimilarScore float64
+}
+
+// LineSelection is a range of output lines selected in select mode.
+// Indices refer to the rendered output lines, before scrolling.
+type LineSelection struct {
+	Start  int // First selected line (inclusive)
+	End    int // Last selected line (inclusive)
+	Cursor int // Line the selection cursor is on
 }
 
 // GroupContext holds information about an instance's group membership.
@@ -87,7 +103,7 @@ func (v *InstanceView) RenderWithSession(inst *orchestrator.Instance, state Rend
 	var b strings.Builder
 
 	// Render header (branch info)
-	b.WriteString(v.RenderHeader(inst))
+	b.WriteString(v.renderHeader(inst, similarOutputLabel(state, session)))
 	b.WriteString("\n")
 
 	// Render group status header if in grouped mode
@@ -130,9 +146,20 @@ func (v *InstanceView) RenderWithSession(inst *orchestrator.Instance, state Rend
 // RenderHeader renders the instance header with branch info and additional context.
 // Status is displayed in the sidebar, so we only show branch and context here.
 func (v *InstanceView) RenderHeader(inst *orchestrator.Instance) string {
+	return v.renderHeader(inst, "")
+}
+
+// renderHeader renders the instance header, adding a hint when another
+// instance's output is near-identical to this one's.
+func (v *InstanceView) renderHeader(inst *orchestrator.Instance, similarOutput string) string {
 	var parts []string
 	parts = append(parts, fmt.Sprintf("Branch: %s", inst.Branch))
 
+	// Add the worker node for placed pipeline instances
+	if inst.Node != "" {
+		parts = append(parts, fmt.Sprintf("Node: %s", inst.Node))
+	}
+
 	// Add files modified count if any
 	if len(inst.FilesModified) > 0 {
 		parts = append(parts, fmt.Sprintf("%d files modified", len(inst.FilesModified)))
@@ -147,6 +174,15 @@ func (v *InstanceView) RenderHeader(inst *orchestrator.Instance) string {
 		}
 	}
 
+	// Show what the escalation ladder already tried after a stall
+	if esc := FormatEscalation(inst); esc != "" {
+		parts = append(parts, esc)
+	}
+
+	if similarOutput != "" {
+		parts = append(parts, "Similar to: "+similarOutput)
+	}
+
 	info := strings.Join(parts, " | ")
 	return styles.InstanceInfo.Render(info)
 }
@@ -359,6 +395,37 @@ func (v *InstanceView) RenderDependencies(inst *orchestrator.Instance, session *
 	return b.String()
 }
 
+// FormatEscalation summarizes the escalation ladder steps tried on a stalled
+// instance, e.g. "Escalation: nudge → interview (in progress)". Returns ""
+// if the instance never stalled.
+func FormatEscalation(inst *orchestrator.Instance) string {
+	esc := inst.Escalation
+	if esc == nil || len(esc.Attempts) == 0 {
+		return ""
+	}
+	outcome := string(esc.Outcome)
+	if esc.Active {
+		outcome = "in progress"
+	}
+	return fmt.Sprintf("Escalation: %s (%s)", strings.Join(esc.Steps(), " → "), outcome)
+}
+
+// similarOutputLabel names the instance with near-identical output and the
+// share of output they have in common, e.g. "fix auth (92%)". The instance ID
+// is used when the session is unavailable or doesn't know the instance.
+func similarOutputLabel(state RenderState, session *orchestrator.Session) string {
+	if state.SimilarTo == "" {
+		return ""
+	}
+	name := state.SimilarTo
+	if session != nil {
+		if other := session.GetInstance(state.SimilarTo); other != nil && other.EffectiveName() != "" {
+			name = truncateTask(other.EffectiveName(), 30)
+		}
+	}
+	return fmt.Sprintf("%s (%.0f%%)", name, state.SimilarScore*100)
+}
+
 // truncateTask truncates a task description to maxLen characters.
 func truncateTask(task string, maxLen int) string {
 	// Remove newlines
@@ -471,6 +538,11 @@ func (v *InstanceView) FormatMetrics(metrics *orchestrator.Metrics) string {
 		parts = append(parts, fmt.Sprintf("%d API calls", metrics.APICalls))
 	}
 
+	// Tool calls reported by hooks
+	if metrics.ToolUses > 0 {
+		parts = append(parts, fmt.Sprintf("%d tool calls (last: %s)", metrics.ToolUses, metrics.LastTool))
+	}
+
 	// Duration
 	if duration := metrics.Duration(); duration > 0 {
 		parts = append(parts, FormatDuration(duration))
@@ -509,7 +581,7 @@ func (v *InstanceView) RenderStatusBanner(isRunning, inputMode bool) string {
 		Padding(0, 1).
 		Render("RUNNING")
 	return runningBanner + "  " + styles.Muted.Render("Press ") +
-		styles.HelpKey.Render("[i]") + styles.Muted.Render(" to interact  ") +
+		styles.HelpKey.Render(inputKeyHint()) + styles.Muted.Render(" to interact  ") +
 		styles.HelpKey.Render("[:tmux]") + styles.Muted.Render(" for tmux attach cmd")
 }
 
@@ -530,7 +602,7 @@ func (v *InstanceView) RenderOutput(instanceID string, state RenderState) string
 			output = "No output matches current filters."
 		}
 
-		outputBox := styles.OutputArea.
+		outputBox := outputAreaStyle(state.InputMode).
 			Width(v.Width - 4).
 			Height(v.MaxOutputLines).
 			Render(output)
@@ -562,6 +634,10 @@ func (v *InstanceView) RenderOutput(instanceID string, state RenderState) string
 		visibleLines = lines[startLine:endLine]
 	}
 
+	if state.Selection != nil {
+		visibleLines = highlightSelection(visibleLines, startLine, state.Selection)
+	}
+
 	visibleOutput := strings.Join(visibleLines, "\n")
 
 	// Build scroll indicator
@@ -574,7 +650,7 @@ func (v *InstanceView) RenderOutput(instanceID string, state RenderState) string
 		b.WriteString("\n")
 	}
 
-	outputBox := styles.OutputArea.
+	outputBox := outputAreaStyle(state.InputMode).
 		Width(v.Width - 4).
 		Height(maxLines).
 		Render(visibleOutput)
@@ -584,6 +660,37 @@ func (v *InstanceView) RenderOutput(instanceID string, state RenderState) string
 	return b.String()
 }
 
+// outputAreaStyle returns the output box style. In input mode the border is
+// thick and warning-colored so it's obvious keystrokes go to the instance.
+func outputAreaStyle(inputMode bool) lipgloss.Style {
+	if inputMode {
+		return styles.OutputArea.
+			Border(lipgloss.ThickBorder()).
+			BorderForeground(styles.WarningColor)
+	}
+	return styles.OutputArea
+}
+
+// highlightSelection returns a copy of the visible lines with the selected
+// range highlighted. firstLine is the output index of visible[0]. Selected
+// lines are rendered without their own ANSI styling so the highlight is
+// uniform, which also matches the plain text that will be copied.
+func highlightSelection(visible []string, firstLine int, sel *LineSelection) []string {
+	out := make([]string, len(visible))
+	for i, line := range visible {
+		idx := firstLine + i
+		switch {
+		case idx == sel.Cursor:
+			out[i] = styles.SearchCurrentMatch.Render(ansi.Strip(line))
+		case idx >= sel.Start && idx <= sel.End:
+			out[i] = styles.SearchMatch.Render(ansi.Strip(line))
+		default:
+			out[i] = line
+		}
+	}
+	return out
+}
+
 // getMaxScroll calculates the maximum scroll offset for the given total lines.
 func (v *InstanceView) getMaxScroll(totalLines int) int {
 	return max(totalLines-v.MaxOutputLines, 0)
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/escalation"
 )
 
 func TestCalculateOverheadLines(t *testing.T) {
@@ -661,3 +662,79 @@ func TestRenderWithSessionGroupedMode(t *testing.T) {
 		}
 	})
 }
+
+func TestHighlightSelection(t *testing.T) {
+	visible := []string{"line 10", "\x1b[31mline 11\x1b[0m", "line 12", "line 13"}
+	sel := &LineSelection{Start: 11, End: 12, Cursor: 12}
+
+	got := highlightSelection(visible, 10, sel)
+
+	if len(got) != len(visible) {
+		t.Fatalf("highlightSelection() returned %d lines, want %d", len(got), len(visible))
+	}
+	if got[0] != visible[0] || got[3] != visible[3] {
+		t.Errorf("unselected lines changed: %q", got)
+	}
+	if strings.Contains(got[1], "\x1b[31m") {
+		t.Errorf("selected line kept its original styling: %q", got[1])
+	}
+	if !strings.Contains(got[1], "line 11") || !strings.Contains(got[2], "line 12") {
+		t.Errorf("selected lines lost their text: %q", got)
+	}
+	if visible[1] != "\x1b[31mline 11\x1b[0m" {
+		t.Error("highlightSelection() modified its input")
+	}
+}
+
+func TestRenderWithSessionSimilarOutput(t *testing.T) {
+	v := NewInstanceView(120, 20)
+	inst := &orchestrator.Instance{ID: "inst1", Branch: "feature-branch", Task: "Fix login"}
+	other := &orchestrator.Instance{ID: "inst2", Task: "Fix signup"}
+	session := &orchestrator.Session{Instances: []*orchestrator.Instance{inst, other}}
+
+	result := v.RenderWithSession(inst, RenderState{}, session)
+	if strings.Contains(result, "Similar to") {
+		t.Errorf("Should not show similarity hint without a similar instance, got: %s", result)
+	}
+
+	state := RenderState{SimilarTo: "inst2", SimilarScore: 0.92}
+	result = v.RenderWithSession(inst, state, session)
+	if !strings.Contains(result, "Similar to: Fix signup (92%)") {
+		t.Errorf("Should name the similar instance, got: %s", result)
+	}
+
+	// Without a session the instance ID is shown
+	result = v.Render(inst, state)
+	if !strings.Contains(result, "Similar to: inst2 (92%)") {
+		t.Errorf("Should fall back to the instance ID, got: %s", result)
+	}
+}
+
+func TestFormatEscalation(t *testing.T) {
+	inst := &orchestrator.Instance{ID: "inst1"}
+	if got := FormatEscalation(inst); got != "" {
+		t.Errorf("FormatEscalation() without escalation = %q, want empty", got)
+	}
+
+	inst.Escalation = &escalation.State{
+		Active: true,
+		Attempts: []escalation.Attempt{
+			{Step: escalation.StepNudge},
+			{Step: escalation.StepInterview},
+		},
+	}
+	if got, want := FormatEscalation(inst), "Escalation: nudge → interview (in progress)"; got != want {
+		t.Errorf("FormatEscalation() = %q, want %q", got, want)
+	}
+
+	inst.Escalation.Active = false
+	inst.Escalation.Outcome = escalation.OutcomeRecovered
+	if got, want := FormatEscalation(inst), "Escalation: nudge → interview (recovered)"; got != want {
+		t.Errorf("FormatEscalation() = %q, want %q", got, want)
+	}
+
+	result := NewInstanceView(160, 20).Render(inst, RenderState{})
+	if !strings.Contains(result, "Escalation: nudge → interview (recovered)") {
+		t.Errorf("header should show the escalation ladder, got: %s", result)
+	}
+}
@@ -0,0 +1,143 @@
+package view
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/Iron-Ham/claudio/internal/tui/theme"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// LogPaneState holds the state needed to render the log pane.
+type LogPaneState struct {
+	// Entries are the session log's entries, oldest first, before filtering
+	Entries []logging.Entry
+
+	// Filter selects the entries shown
+	Filter logging.LogFilter
+
+	// Names maps instance IDs to display names
+	Names map[string]string
+
+	// Err is why the log cannot be read, if it can't
+	Err string
+}
+
+// RenderLogPane renders the newest entries that pass the filter below a
+// title rule, filling height lines.
+func RenderLogPane(state LogPaneState, width, height int) string {
+	name := func(id string) string {
+		if n, ok := state.Names[id]; ok {
+			return n
+		}
+		return id
+	}
+
+	title := " Logs "
+	if summary := logFilterSummary(state.Filter, name); summary != "" {
+		title += "(" + summary + ") "
+	}
+	title = ansi.Truncate(title, max(width-2, 0), "…")
+	rule := styles.Muted.Render("─" + title + strings.Repeat("─", max(width-1-ansi.StringWidth(title), 0)))
+
+	rows := max(height-1, 0)
+	var lines []string
+	if state.Err != "" {
+		lines = []string{styles.Muted.Render(state.Err)}
+	} else {
+		for i := len(state.Entries) - 1; i >= 0 && len(lines) < rows; i-- {
+			if e := &state.Entries[i]; state.Filter.Match(e) {
+				lines = append(lines, ansi.Truncate(formatLogLine(e, name), width, "…"))
+			}
+		}
+		if len(lines) == 0 {
+			lines = []string{styles.Muted.Render("No matching log entries yet.")}
+		}
+		// Collected newest first
+		slices.Reverse(lines)
+	}
+	if len(lines) > rows {
+		lines = lines[:rows]
+	}
+
+	content := rule
+	if rows > 0 {
+		content += "\n" + strings.Join(lines, "\n")
+	}
+	return lipgloss.NewStyle().Width(width).Height(height).MaxHeight(height).Render(content)
+}
+
+// logFilterSummary describes the criteria of filter, or "" for none.
+func logFilterSummary(filter logging.LogFilter, name func(string) string) string {
+	var parts []string
+	if filter.MinLevel != "" {
+		parts = append(parts, "level≥"+filter.MinLevel)
+	}
+	if filter.InstanceID != "" {
+		parts = append(parts, "instance="+name(filter.InstanceID))
+	}
+	if filter.Phase != "" {
+		parts = append(parts, "phase="+filter.Phase)
+	}
+	if filter.Pattern != nil {
+		parts = append(parts, "/"+filter.Pattern.String()+"/")
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatLogLine renders an entry as one line: time, colored level, message,
+// then its instance, phase, and extra fields.
+func formatLogLine(e *logging.Entry, name func(string) string) string {
+	var b strings.Builder
+	if !e.Time.IsZero() {
+		b.WriteString(styles.Muted.Render(e.Time.Local().Format("15:04:05")))
+		b.WriteString(" ")
+	}
+	if e.Level != "" {
+		level := strings.ToUpper(e.Level)
+		b.WriteString(logLevelStyle(level).Render(fmt.Sprintf("%-5s", level)))
+		b.WriteString(" ")
+	}
+	b.WriteString(e.Msg)
+
+	var fields []string
+	if e.InstanceID != "" {
+		fields = append(fields, "instance="+name(e.InstanceID))
+	}
+	if e.Phase != "" {
+		fields = append(fields, "phase="+e.Phase)
+	}
+	keys := make([]string, 0, len(e.Extra))
+	for k := range e.Extra {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fields = append(fields, fmt.Sprintf("%s=%v", k, e.Extra[k]))
+	}
+	if len(fields) > 0 {
+		b.WriteString(" ")
+		b.WriteString(styles.Muted.Render(strings.Join(fields, " ")))
+	}
+	return b.String()
+}
+
+// logLevelStyle returns the style a level is shown in.
+func logLevelStyle(level string) lipgloss.Style {
+	t := theme.Current()
+	switch level {
+	case logging.LevelError:
+		return t.Failure()
+	case logging.LevelWarn:
+		return t.Attention()
+	case logging.LevelInfo:
+		return t.Running()
+	default:
+		return styles.Muted
+	}
+}
@@ -0,0 +1,71 @@
+package view
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/logging"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRenderLogPane(t *testing.T) {
+	entries := []logging.Entry{
+		{Level: logging.LevelDebug, Msg: "polling"},
+		{Level: logging.LevelWarn, Msg: "slow capture", InstanceID: "a1"},
+		{Level: logging.LevelError, Msg: "merge failed", InstanceID: "b2", Phase: "consolidation"},
+		{Level: logging.LevelInfo, Msg: "instance started", InstanceID: "a1", Extra: map[string]any{"pid": 42}},
+	}
+	names := map[string]string{"a1": "auth"}
+
+	t.Run("newest entries fill the pane", func(t *testing.T) {
+		out := ansi.Strip(RenderLogPane(LogPaneState{Entries: entries, Names: names}, 60, 3))
+		lines := strings.Split(out, "\n")
+		if len(lines) != 3 || !strings.Contains(lines[0], "Logs") {
+			t.Fatalf("pane = %q, want a title and two entries", lines)
+		}
+		if !strings.Contains(lines[1], "ERROR merge failed instance=b2 phase=consolidation") {
+			t.Errorf("line 1 = %q", lines[1])
+		}
+		if !strings.Contains(lines[2], "INFO  instance started instance=auth pid=42") {
+			t.Errorf("line 2 = %q", lines[2])
+		}
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		state := LogPaneState{
+			Entries: entries,
+			Names:   names,
+			Filter:  logging.LogFilter{MinLevel: logging.LevelWarn, InstanceID: "a1"},
+		}
+		out := ansi.Strip(RenderLogPane(state, 60, 5))
+		if !strings.Contains(out, "level≥WARN instance=auth") {
+			t.Errorf("title does not describe the filter: %q", out)
+		}
+		if !strings.Contains(out, "slow capture") || strings.Contains(out, "merge failed") || strings.Contains(out, "instance started") {
+			t.Errorf("pane = %q, want only the warning from auth", out)
+		}
+	})
+
+	t.Run("nothing matches", func(t *testing.T) {
+		state := LogPaneState{Entries: entries, Filter: logging.LogFilter{Phase: "planning"}}
+		if out := ansi.Strip(RenderLogPane(state, 60, 4)); !strings.Contains(out, "No matching log entries") {
+			t.Errorf("pane = %q", out)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		out := ansi.Strip(RenderLogPane(LogPaneState{Err: "no log file"}, 60, 4))
+		if !strings.Contains(out, "no log file") {
+			t.Errorf("pane = %q", out)
+		}
+	})
+
+	t.Run("long lines are truncated", func(t *testing.T) {
+		long := []logging.Entry{{Level: logging.LevelInfo, Msg: strings.Repeat("x", 200)}}
+		for _, line := range strings.Split(RenderLogPane(LogPaneState{Entries: long}, 40, 2), "\n") {
+			if w := ansi.StringWidth(line); w > 40 {
+				t.Errorf("line width = %d, want at most 40", w)
+			}
+		}
+	})
+}
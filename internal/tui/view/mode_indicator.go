@@ -13,13 +13,44 @@ type ModeIndicatorState struct {
 	// FilterMode indicates filter mode is active
 	FilterMode bool
 
+	// SelectMode indicates output selection mode is active
+	SelectMode bool
+
+	// ReplayMode indicates transcript replay mode is active
+	ReplayMode bool
+
+	// DashboardMode indicates the instance dashboard is open
+	DashboardMode bool
+
+	// SplitMode indicates the split comparison view is open
+	SplitMode bool
+
+	// GrepMode indicates the :grep results are open
+	GrepMode bool
+
+	// ConflictMode indicates the consolidation conflict view is open
+	ConflictMode bool
+
+	// DepsMode indicates the task dependency graph is open
+	DepsMode bool
+
 	// InputMode indicates input forwarding mode is active
 	InputMode bool
 
+	// InputTarget names the instance receiving keystrokes in input mode
+	InputTarget string
+
 	// AddingTask indicates task input mode is active
 	AddingTask bool
+
+	// Observer indicates the session is run by another process and only
+	// observed, shown when no other mode is active
+	Observer bool
 }
 
+// maxInputTargetLen caps the instance name shown in the input mode indicator.
+const maxInputTargetLen = 24
+
 // ModeInfo contains display information for a mode.
 type ModeInfo struct {
 	// Label is the text shown in the indicator
@@ -52,8 +83,12 @@ func (v *ModeIndicatorView) GetModeInfo(state *ModeIndicatorState) *ModeInfo {
 	// High-priority modes that change keyboard behavior come first
 
 	if state.InputMode {
+		label := "INPUT"
+		if state.InputTarget != "" {
+			label += " → " + truncate(state.InputTarget, maxInputTargetLen)
+		}
 		return &ModeInfo{
-			Label: "INPUT",
+			Label: label,
 			Style: lipgloss.NewStyle().
 				Bold(true).
 				Foreground(styles.TextColor).
@@ -75,6 +110,90 @@ func (v *ModeIndicatorView) GetModeInfo(state *ModeIndicatorState) *ModeInfo {
 		}
 	}
 
+	if state.SelectMode {
+		return &ModeInfo{
+			Label: "SELECT",
+			Style: lipgloss.NewStyle().
+				Bold(true).
+				Foreground(styles.TextColor).
+				Background(styles.SecondaryColor).
+				Padding(0, 1),
+			IsHighPriority: false,
+		}
+	}
+
+	if state.ReplayMode {
+		return &ModeInfo{
+			Label: "REPLAY",
+			Style: lipgloss.NewStyle().
+				Bold(true).
+				Foreground(styles.TextColor).
+				Background(styles.SecondaryColor).
+				Padding(0, 1),
+			IsHighPriority: false,
+		}
+	}
+
+	if state.DashboardMode {
+		return &ModeInfo{
+			Label: "DASHBOARD",
+			Style: lipgloss.NewStyle().
+				Bold(true).
+				Foreground(styles.TextColor).
+				Background(styles.SecondaryColor).
+				Padding(0, 1),
+			IsHighPriority: false,
+		}
+	}
+
+	if state.SplitMode {
+		return &ModeInfo{
+			Label: "SPLIT",
+			Style: lipgloss.NewStyle().
+				Bold(true).
+				Foreground(styles.TextColor).
+				Background(styles.SecondaryColor).
+				Padding(0, 1),
+			IsHighPriority: false,
+		}
+	}
+
+	if state.GrepMode {
+		return &ModeInfo{
+			Label: "GREP",
+			Style: lipgloss.NewStyle().
+				Bold(true).
+				Foreground(styles.TextColor).
+				Background(styles.SecondaryColor).
+				Padding(0, 1),
+			IsHighPriority: false,
+		}
+	}
+
+	if state.ConflictMode {
+		return &ModeInfo{
+			Label: "CONFLICTS",
+			Style: lipgloss.NewStyle().
+				Bold(true).
+				Foreground(styles.TextColor).
+				Background(styles.SecondaryColor).
+				Padding(0, 1),
+			IsHighPriority: false,
+		}
+	}
+
+	if state.DepsMode {
+		return &ModeInfo{
+			Label: "DEPS",
+			Style: lipgloss.NewStyle().
+				Bold(true).
+				Foreground(styles.TextColor).
+				Background(styles.SecondaryColor).
+				Padding(0, 1),
+			IsHighPriority: false,
+		}
+	}
+
 	if state.CommandMode {
 		return &ModeInfo{
 			Label: "COMMAND",
@@ -99,6 +218,18 @@ func (v *ModeIndicatorView) GetModeInfo(state *ModeIndicatorState) *ModeInfo {
 		}
 	}
 
+	if state.Observer {
+		return &ModeInfo{
+			Label: "OBSERVER",
+			Style: lipgloss.NewStyle().
+				Bold(true).
+				Foreground(styles.TextColor).
+				Background(styles.BlueColor).
+				Padding(0, 1),
+			IsHighPriority: false,
+		}
+	}
+
 	// Normal mode - no indicator needed
 	return nil
 }
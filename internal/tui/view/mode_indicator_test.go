@@ -38,6 +38,26 @@ func TestModeIndicatorView_GetModeInfo_InputMode(t *testing.T) {
 	}
 }
 
+func TestModeIndicatorView_GetModeInfo_InputTarget(t *testing.T) {
+	v := NewModeIndicatorView()
+
+	info := v.GetModeInfo(&ModeIndicatorState{InputMode: true, InputTarget: "api-worker"})
+	if info == nil || info.Label != "INPUT → api-worker" {
+		t.Fatalf("GetModeInfo Label = %v, want %q", info, "INPUT → api-worker")
+	}
+
+	long := strings.Repeat("x", maxInputTargetLen+10)
+	info = v.GetModeInfo(&ModeIndicatorState{InputMode: true, InputTarget: long})
+	if !strings.HasSuffix(info.Label, "...") || len([]rune(info.Label)) != len([]rune("INPUT → "))+maxInputTargetLen {
+		t.Errorf("long target not truncated: %q", info.Label)
+	}
+
+	// The target is only shown in input mode
+	if info := v.GetModeInfo(&ModeIndicatorState{FilterMode: true, InputTarget: "api-worker"}); info.Label != "FILTER" {
+		t.Errorf("GetModeInfo Label = %q, want %q", info.Label, "FILTER")
+	}
+}
+
 func TestModeIndicatorView_GetModeInfo_FilterMode(t *testing.T) {
 	v := NewModeIndicatorView()
 	state := &ModeIndicatorState{FilterMode: true}
@@ -51,6 +71,19 @@ func TestModeIndicatorView_GetModeInfo_FilterMode(t *testing.T) {
 	}
 }
 
+func TestModeIndicatorView_GetModeInfo_SelectMode(t *testing.T) {
+	v := NewModeIndicatorView()
+	state := &ModeIndicatorState{SelectMode: true}
+	info := v.GetModeInfo(state)
+
+	if info == nil {
+		t.Fatal("GetModeInfo for select mode should not return nil")
+	}
+	if info.Label != "SELECT" {
+		t.Errorf("GetModeInfo Label = %q, want %q", info.Label, "SELECT")
+	}
+}
+
 func TestModeIndicatorView_GetModeInfo_CommandMode(t *testing.T) {
 	v := NewModeIndicatorView()
 	state := &ModeIndicatorState{CommandMode: true}
@@ -190,6 +223,10 @@ func TestModeIndicatorView_AllModes_HaveNonEmptyLabels(t *testing.T) {
 	}{
 		{"InputMode", &ModeIndicatorState{InputMode: true}},
 		{"FilterMode", &ModeIndicatorState{FilterMode: true}},
+		{"SelectMode", &ModeIndicatorState{SelectMode: true}},
+		{"ReplayMode", &ModeIndicatorState{ReplayMode: true}},
+		{"DashboardMode", &ModeIndicatorState{DashboardMode: true}},
+		{"SplitMode", &ModeIndicatorState{SplitMode: true}},
 		{"CommandMode", &ModeIndicatorState{CommandMode: true}},
 		{"AddingTask", &ModeIndicatorState{AddingTask: true}},
 	}
@@ -0,0 +1,21 @@
+// Package planedit provides the structural edits of the plan editor: the
+// operations that reshape a plan rather than change a single task field.
+//
+// The plan editor (see the tui and view packages) lets the user review an
+// ultra-plan before execution. Field edits (title, description, files,
+// dependencies) go through the orchestrator's plan editing functions
+// directly. This package adds the edits that need more than one step:
+//
+//   - [Split]: Splits a task at markers typed into its description
+//   - [Merge]: Merges several tasks into one, keeping it where the first was
+//   - [MoveToLaterGroup] and [MoveToEarlierGroup]: Move a task between
+//     execution groups by adding or dropping dependencies
+//   - [Validate]: Validates the edited plan with [ultraplan.ValidatePlan]
+//     before it is saved
+//   - [RenderGroups]: Renders the plan's execution groups
+//
+// Execution groups are derived from dependencies, so a task cannot be placed
+// in a group directly. Moving it later makes it depend on the other tasks in
+// its group; moving it earlier drops the dependencies that hold it in place.
+// Both report the dependencies they changed so the editor can tell the user.
+package planedit
@@ -0,0 +1,182 @@
+package planedit
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+)
+
+// SplitMarker separates the parts of a description passed to Split.
+const SplitMarker = "||"
+
+// ParseSplit removes the split markers from text and returns the resulting
+// description with the byte offsets where it is split. Every part must have
+// non-blank content.
+func ParseSplit(text string) (string, []int, error) {
+	parts := strings.Split(text, SplitMarker)
+	if len(parts) < 2 {
+		return "", nil, fmt.Errorf("mark where to split with %q", SplitMarker)
+	}
+
+	var points []int
+	offset := 0
+	for i, part := range parts {
+		if strings.TrimSpace(part) == "" {
+			return "", nil, fmt.Errorf("part %d of the split is empty", i+1)
+		}
+		if i > 0 {
+			points = append(points, offset)
+		}
+		offset += len(part)
+	}
+	return strings.Join(parts, ""), points, nil
+}
+
+// Split splits a task into one task per part of text, which is the task's
+// description with SplitMarker between the parts. The first part keeps the
+// task's ID and dependencies; each later part depends on the one before it,
+// and tasks that depended on the original depend on the last part. Returns
+// the IDs of the parts. The plan is unchanged if the split fails.
+func Split(plan *orchestrator.PlanSpec, taskID, text string) ([]string, error) {
+	desc, points, err := ParseSplit(text)
+	if err != nil {
+		return nil, err
+	}
+	task := orchestrator.GetTaskByID(plan, taskID)
+	if task == nil {
+		return nil, orchestrator.ErrTaskNotFound{TaskID: taskID}
+	}
+
+	original := task.Description
+	task.Description = desc
+	ids, err := orchestrator.SplitTask(plan, taskID, points)
+	if err != nil {
+		task.Description = original
+		return nil, err
+	}
+	return ids, nil
+}
+
+// MergeTitle returns the title Merge gives the merged task: the titles of
+// the merged tasks joined with " + ".
+func MergeTitle(plan *orchestrator.PlanSpec, taskIDs []string) string {
+	var titles []string
+	for _, id := range taskIDs {
+		if task := orchestrator.GetTaskByID(plan, id); task != nil {
+			titles = append(titles, task.Title)
+		}
+	}
+	return strings.Join(titles, " + ")
+}
+
+// Merge combines tasks into one that keeps the ID of the first task in plan
+// order and takes that task's place in the list. The merged task has the
+// union of the tasks' files and outside dependencies, and tasks that
+// depended on any of them depend on it instead. Returns the merged task's ID.
+func Merge(plan *orchestrator.PlanSpec, taskIDs []string) (string, error) {
+	if plan == nil {
+		return "", errors.New("plan is nil")
+	}
+
+	for _, id := range taskIDs {
+		if orchestrator.GetTaskByID(plan, id) == nil {
+			return "", orchestrator.ErrTaskNotFound{TaskID: id}
+		}
+	}
+
+	// Merge in plan order so the first task listed gives its ID and position.
+	var ordered []string
+	for _, task := range plan.Tasks {
+		if slices.Contains(taskIDs, task.ID) {
+			ordered = append(ordered, task.ID)
+		}
+	}
+	if len(ordered) < 2 {
+		return "", orchestrator.ErrCannotMerge{Reason: "need at least 2 tasks to merge"}
+	}
+	position := slices.IndexFunc(plan.Tasks, func(t orchestrator.PlannedTask) bool { return t.ID == ordered[0] })
+
+	mergedID, err := orchestrator.MergeTasks(plan, ordered, MergeTitle(plan, ordered))
+	if err != nil {
+		return "", err
+	}
+
+	// MergeTasks appends the merged task; move it back to where the first was.
+	last := len(plan.Tasks) - 1
+	merged := plan.Tasks[last]
+	plan.Tasks = slices.Insert(plan.Tasks[:last], position, merged)
+	return mergedID, nil
+}
+
+// MoveToLaterGroup moves a task to the execution group after its current one
+// by making it depend on the other tasks in its group. Returns the added
+// dependencies. A task alone in its group cannot move later.
+func MoveToLaterGroup(plan *orchestrator.PlanSpec, taskID string) ([]string, error) {
+	task, group, err := taskAndGroup(plan, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	var peers []string
+	for _, id := range plan.ExecutionOrder[group] {
+		if id != taskID {
+			peers = append(peers, id)
+		}
+	}
+	if len(peers) == 0 {
+		return nil, fmt.Errorf("task %s is the only task in group %d", taskID, group+1)
+	}
+
+	deps := append(slices.Clone(task.DependsOn), peers...)
+	if err := orchestrator.UpdateTaskDependencies(plan, taskID, deps); err != nil {
+		return nil, err
+	}
+	return peers, nil
+}
+
+// MoveToEarlierGroup moves a task to an earlier execution group by dropping
+// its dependencies on tasks in the group just before its own. Returns the
+// dropped dependencies. A task in the first group cannot move earlier.
+func MoveToEarlierGroup(plan *orchestrator.PlanSpec, taskID string) ([]string, error) {
+	task, group, err := taskAndGroup(plan, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if group == 0 {
+		return nil, fmt.Errorf("task %s is already in the first group", taskID)
+	}
+
+	previous := plan.ExecutionOrder[group-1]
+	var kept, dropped []string
+	for _, dep := range task.DependsOn {
+		if slices.Contains(previous, dep) {
+			dropped = append(dropped, dep)
+		} else {
+			kept = append(kept, dep)
+		}
+	}
+	if len(dropped) == 0 {
+		return nil, fmt.Errorf("task %s has no dependencies in group %d", taskID, group)
+	}
+
+	if err := orchestrator.UpdateTaskDependencies(plan, taskID, kept); err != nil {
+		return nil, err
+	}
+	return dropped, nil
+}
+
+// taskAndGroup returns a task and the index of its execution group.
+func taskAndGroup(plan *orchestrator.PlanSpec, taskID string) (*orchestrator.PlannedTask, int, error) {
+	task := orchestrator.GetTaskByID(plan, taskID)
+	if task == nil {
+		return nil, -1, orchestrator.ErrTaskNotFound{TaskID: taskID}
+	}
+	group := orchestrator.GetExecutionGroupForTask(plan, taskID)
+	if group == -1 {
+		return nil, -1, fmt.Errorf("task %s is not in any execution group", taskID)
+	}
+	return task, group, nil
+}
@@ -0,0 +1,199 @@
+package planedit
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+)
+
+// newTestPlan returns a plan with groups [task-1 task-2] [task-3] [task-4].
+func newTestPlan(t *testing.T) *orchestrator.PlanSpec {
+	t.Helper()
+	plan := &orchestrator.PlanSpec{
+		ID: "test-plan",
+		Tasks: []orchestrator.PlannedTask{
+			{ID: "task-1", Title: "Task 1", Description: "Do task 1", Files: []string{"a.go"}},
+			{ID: "task-2", Title: "Task 2", Description: "Do task 2", Files: []string{"b.go"}},
+			{ID: "task-3", Title: "Task 3", Description: "Do task 3", DependsOn: []string{"task-1"}},
+			{ID: "task-4", Title: "Task 4", Description: "Do task 4", DependsOn: []string{"task-3", "task-2"}},
+		},
+	}
+	// Recalculate the groups through a no-op dependency update.
+	if err := orchestrator.UpdateTaskDependencies(plan, "task-1", nil); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	return plan
+}
+
+func taskIDs(plan *orchestrator.PlanSpec) []string {
+	var ids []string
+	for _, task := range plan.Tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func TestParseSplit(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		wantDesc   string
+		wantPoints []int
+		wantErr    bool
+	}{
+		{"two parts", "Add model. || Add handler.", "Add model.  Add handler.", []int{11}, false},
+		{"three parts", "a||b||c", "abc", []int{1, 2}, false},
+		{"no marker", "Add model.", "", nil, true},
+		{"empty part", "Add model. ||  ", "", nil, true},
+		{"leading marker", "|| Add model.", "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc, points, err := ParseSplit(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSplit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if desc != tt.wantDesc || !slices.Equal(points, tt.wantPoints) {
+				t.Errorf("ParseSplit() = %q, %v; want %q, %v", desc, points, tt.wantDesc, tt.wantPoints)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	plan := newTestPlan(t)
+
+	ids, err := Split(plan, "task-3", "Write the model. || Wire the handler.")
+	if err != nil {
+		t.Fatalf("Split() error = %v", err)
+	}
+	if !slices.Equal(ids, []string{"task-3", "task-3-part2"}) {
+		t.Fatalf("Split() = %v", ids)
+	}
+
+	first := orchestrator.GetTaskByID(plan, "task-3")
+	second := orchestrator.GetTaskByID(plan, "task-3-part2")
+	if first.Description != "Write the model." || second.Description != "Wire the handler." {
+		t.Errorf("descriptions = %q, %q", first.Description, second.Description)
+	}
+	if !slices.Equal(second.DependsOn, []string{"task-3"}) {
+		t.Errorf("second part DependsOn = %v, want [task-3]", second.DependsOn)
+	}
+	if deps := orchestrator.GetTaskByID(plan, "task-4").DependsOn; !slices.Contains(deps, "task-3-part2") {
+		t.Errorf("dependent DependsOn = %v, want it to include the last part", deps)
+	}
+}
+
+func TestSplit_ErrorLeavesPlanUnchanged(t *testing.T) {
+	plan := newTestPlan(t)
+
+	if _, err := Split(plan, "task-3", "no markers here"); err == nil {
+		t.Error("Split() without markers should error")
+	}
+	if _, err := Split(plan, "missing", "a || b"); err == nil {
+		t.Error("Split() of a missing task should error")
+	}
+
+	// The generated ID collides with an existing task.
+	plan.Tasks = append(plan.Tasks, orchestrator.PlannedTask{ID: "task-3-part2", Title: "Taken"})
+	if _, err := Split(plan, "task-3", "a || b"); err == nil {
+		t.Fatal("Split() with a colliding ID should error")
+	}
+	if got := orchestrator.GetTaskByID(plan, "task-3").Description; got != "Do task 3" {
+		t.Errorf("description after failed split = %q, want it restored", got)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	plan := newTestPlan(t)
+
+	// IDs are merged in plan order regardless of the order given.
+	mergedID, err := Merge(plan, []string{"task-2", "task-1"})
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if mergedID != "task-1" {
+		t.Errorf("merged ID = %q, want task-1", mergedID)
+	}
+	if got := taskIDs(plan); !slices.Equal(got, []string{"task-1", "task-3", "task-4"}) {
+		t.Errorf("tasks = %v, want the merged task in the first one's place", got)
+	}
+
+	merged := orchestrator.GetTaskByID(plan, "task-1")
+	if merged.Title != "Task 1 + Task 2" {
+		t.Errorf("merged title = %q", merged.Title)
+	}
+	files := slices.Sorted(slices.Values(merged.Files))
+	if !slices.Equal(files, []string{"a.go", "b.go"}) {
+		t.Errorf("merged files = %v", files)
+	}
+	if deps := orchestrator.GetTaskByID(plan, "task-4").DependsOn; slices.Contains(deps, "task-2") {
+		t.Errorf("dependent still depends on a merged-away task: %v", deps)
+	}
+}
+
+func TestMerge_Errors(t *testing.T) {
+	plan := newTestPlan(t)
+
+	if _, err := Merge(plan, []string{"task-1"}); err == nil {
+		t.Error("Merge() of one task should error")
+	}
+	if _, err := Merge(plan, []string{"task-1", "task-1"}); err == nil {
+		t.Error("Merge() of a task with itself should error")
+	}
+	if _, err := Merge(plan, []string{"task-1", "missing"}); err == nil {
+		t.Error("Merge() with a missing task should error")
+	}
+	if _, err := Merge(nil, []string{"task-1", "task-2"}); err == nil {
+		t.Error("Merge() on a nil plan should error")
+	}
+	if len(plan.Tasks) != 4 {
+		t.Errorf("plan has %d tasks after failed merges, want 4", len(plan.Tasks))
+	}
+}
+
+func TestMoveToLaterGroup(t *testing.T) {
+	plan := newTestPlan(t)
+
+	added, err := MoveToLaterGroup(plan, "task-2")
+	if err != nil {
+		t.Fatalf("MoveToLaterGroup() error = %v", err)
+	}
+	if !slices.Equal(added, []string{"task-1"}) {
+		t.Errorf("added deps = %v, want [task-1]", added)
+	}
+	if group := orchestrator.GetExecutionGroupForTask(plan, "task-2"); group != 1 {
+		t.Errorf("task-2 group = %d, want 1", group)
+	}
+
+	// task-4 is alone in the last group.
+	if _, err := MoveToLaterGroup(plan, "task-4"); err == nil {
+		t.Error("MoveToLaterGroup() of a task alone in its group should error")
+	}
+	if _, err := MoveToLaterGroup(plan, "missing"); err == nil {
+		t.Error("MoveToLaterGroup() of a missing task should error")
+	}
+}
+
+func TestMoveToEarlierGroup(t *testing.T) {
+	plan := newTestPlan(t)
+
+	dropped, err := MoveToEarlierGroup(plan, "task-4")
+	if err != nil {
+		t.Fatalf("MoveToEarlierGroup() error = %v", err)
+	}
+	if !slices.Equal(dropped, []string{"task-3"}) {
+		t.Errorf("dropped deps = %v, want [task-3]", dropped)
+	}
+	if deps := orchestrator.GetTaskByID(plan, "task-4").DependsOn; !slices.Equal(deps, []string{"task-2"}) {
+		t.Errorf("task-4 DependsOn = %v, want [task-2]", deps)
+	}
+	if group := orchestrator.GetExecutionGroupForTask(plan, "task-4"); group != 1 {
+		t.Errorf("task-4 group = %d, want 1", group)
+	}
+
+	if _, err := MoveToEarlierGroup(plan, "task-1"); err == nil {
+		t.Error("MoveToEarlierGroup() of a first-group task should error")
+	}
+}
@@ -0,0 +1,64 @@
+package planedit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxGroupLines bounds the execution groups section.
+const maxGroupLines = 6
+
+// selectedTaskStyle highlights the selected task in the group list.
+var selectedTaskStyle = lipgloss.NewStyle().
+	Foreground(styles.PrimaryColor).
+	Bold(true)
+
+// RenderGroups renders the plan's execution groups, one line per group,
+// with the selected task highlighted and marked tasks prefixed with "+".
+func RenderGroups(plan *orchestrator.PlanSpec, selectedID string, marked map[string]bool, width int) string {
+	if plan == nil || len(plan.ExecutionOrder) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(styles.SidebarTitle.Render("Execution Groups"))
+	b.WriteString("\n")
+
+	var lines []string
+	for i, group := range plan.ExecutionOrder {
+		prefix := fmt.Sprintf("  %d │ ", i+1)
+		budget := width - lipgloss.Width(prefix) - 4
+
+		var ids []string
+		used := 0
+		for j, id := range group {
+			label := id
+			if marked[id] {
+				label = "+" + label
+			}
+			// Leave room for the ", … N more" suffix.
+			if used+len(label) > budget-10 && j < len(group)-1 {
+				ids = append(ids, styles.Muted.Render(fmt.Sprintf("… %d more", len(group)-j)))
+				break
+			}
+			used += len(label) + 2
+			if id == selectedID {
+				label = selectedTaskStyle.Render(label)
+			}
+			ids = append(ids, label)
+		}
+		lines = append(lines, styles.Muted.Render(prefix)+strings.Join(ids, ", "))
+	}
+
+	if len(lines) > maxGroupLines {
+		hidden := len(lines) - maxGroupLines + 1
+		lines = append(lines[:maxGroupLines-1], styles.Muted.Render(fmt.Sprintf("  … %d more groups", hidden)))
+	}
+	b.WriteString(strings.Join(lines, "\n"))
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,41 @@
+package planedit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+)
+
+func TestRenderGroups(t *testing.T) {
+	plan := newTestPlan(t)
+
+	out := RenderGroups(plan, "task-3", map[string]bool{"task-2": true}, 80)
+	for _, want := range []string{"Execution Groups", "1 │ task-1, +task-2", "2 │ task-3", "3 │ task-4"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("RenderGroups() missing %q:\n%s", want, out)
+		}
+	}
+
+	if out := RenderGroups(&orchestrator.PlanSpec{}, "", nil, 80); out != "" {
+		t.Errorf("RenderGroups() without groups = %q, want empty", out)
+	}
+}
+
+func TestRenderGroups_Truncates(t *testing.T) {
+	plan := &orchestrator.PlanSpec{}
+	for i := range 20 {
+		plan.Tasks = append(plan.Tasks, orchestrator.PlannedTask{ID: strings.Repeat("t", 5) + string(rune('a'+i))})
+	}
+	if err := orchestrator.UpdateTaskDependencies(plan, plan.Tasks[0].ID, nil); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	out := RenderGroups(plan, "", nil, 40)
+	if !strings.Contains(out, "more") {
+		t.Errorf("RenderGroups() of a wide group should truncate:\n%s", out)
+	}
+	if strings.Contains(out, plan.Tasks[19].ID) {
+		t.Errorf("RenderGroups() should not show every task:\n%s", out)
+	}
+}
@@ -0,0 +1,72 @@
+package planedit
+
+import (
+	"fmt"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/ultraplan"
+)
+
+// Validate runs ultraplan.ValidatePlan on the edited plan.
+func Validate(plan *orchestrator.PlanSpec) (*ultraplan.ValidationResult, error) {
+	if plan == nil {
+		return ultraplan.ValidatePlan(nil)
+	}
+	return ultraplan.ValidatePlan(toUltraplan(plan))
+}
+
+// SaveError returns an error describing why a plan with this validation
+// result must not be saved, or nil if it has no errors.
+func SaveError(result *ultraplan.ValidationResult) error {
+	if result == nil || result.IsValid {
+		return nil
+	}
+	for _, msg := range result.Messages {
+		if !msg.IsError() {
+			continue
+		}
+		if msg.TaskID != "" {
+			return fmt.Errorf("%d validation error(s), first: [%s] %s", result.ErrorCount, msg.TaskID, msg.Message)
+		}
+		return fmt.Errorf("%d validation error(s), first: %s", result.ErrorCount, msg.Message)
+	}
+	return fmt.Errorf("%d validation error(s)", result.ErrorCount)
+}
+
+// toUltraplan converts an orchestrator plan to the ultraplan type for
+// validation. Slices are shared, not copied; the result must not be modified.
+func toUltraplan(plan *orchestrator.PlanSpec) *ultraplan.PlanSpec {
+	tasks := make([]ultraplan.PlannedTask, len(plan.Tasks))
+	for i, t := range plan.Tasks {
+		tasks[i] = ultraplan.PlannedTask{
+			ID:            t.ID,
+			Title:         t.Title,
+			Description:   t.Description,
+			Files:         t.Files,
+			DependsOn:     t.DependsOn,
+			Priority:      t.Priority,
+			EstComplexity: ultraplan.TaskComplexity(t.EstComplexity),
+			TimeoutPolicy: t.TimeoutPolicy,
+			IssueURL:      t.IssueURL,
+			NoCode:        t.NoCode,
+			Repo:          t.Repo,
+			Backend:       t.Backend,
+			Command:       t.Command,
+			Criteria:      t.Criteria,
+			ContextPack:   t.ContextPack,
+		}
+	}
+
+	return &ultraplan.PlanSpec{
+		ID:              plan.ID,
+		Objective:       plan.Objective,
+		Summary:         plan.Summary,
+		Tasks:           tasks,
+		DependencyGraph: plan.DependencyGraph,
+		ExecutionOrder:  plan.ExecutionOrder,
+		Insights:        plan.Insights,
+		Constraints:     plan.Constraints,
+		Env:             plan.Env,
+		CreatedAt:       plan.CreatedAt,
+	}
+}
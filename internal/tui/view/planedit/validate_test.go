@@ -0,0 +1,45 @@
+package planedit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+)
+
+func TestValidate(t *testing.T) {
+	plan := newTestPlan(t)
+
+	result, err := Validate(plan)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if err := SaveError(result); err != nil {
+		t.Errorf("SaveError() on a valid plan = %v", err)
+	}
+
+	plan.Tasks[2].DependsOn = []string{"task-9"}
+	result, err = Validate(plan)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	saveErr := SaveError(result)
+	if saveErr == nil {
+		t.Fatal("SaveError() should reject a dependency on a missing task")
+	}
+	if !strings.Contains(saveErr.Error(), "task-3") {
+		t.Errorf("SaveError() = %q, want it to name the task", saveErr)
+	}
+}
+
+func TestValidate_Empty(t *testing.T) {
+	for _, plan := range []*orchestrator.PlanSpec{nil, {ID: "empty"}} {
+		result, err := Validate(plan)
+		if err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		if SaveError(result) == nil {
+			t.Errorf("SaveError() should reject plan %+v", plan)
+		}
+	}
+}
@@ -2,10 +2,13 @@ package view
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/Iron-Ham/claudio/internal/tui/view/planedit"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -72,8 +75,17 @@ type PlanEditorState struct {
 	// TasksInCycle contains task IDs that are part of a dependency cycle
 	TasksInCycle map[string]bool
 
+	// Marked contains task IDs marked for merging
+	Marked map[string]bool
+
 	// CanConfirm indicates whether the plan can be confirmed (no validation errors)
 	CanConfirm bool
+
+	// CanRefine indicates whether changes can be requested from the planner
+	CanRefine bool
+
+	// Draft is the ultra-plan draft number (1 before any feedback, 0 for inline plans)
+	Draft int
 }
 
 // PlanEditorRenderParams contains all parameters needed to render the plan editor view.
@@ -92,8 +104,17 @@ type PlanEditorRenderParams struct {
 
 	// ValidationMessages are messages for the currently selected task
 	SelectedTaskValidationMessages []orchestrator.ValidationMessage
+
+	// Diff holds the changes since the previous draft (nil if not revised)
+	Diff *orchestrator.PlanDiff
 }
 
+// maxPlanDiffLines bounds the "changes since last draft" section.
+const maxPlanDiffLines = 8
+
+// maxPlanEnvLines bounds the plan environment section.
+const maxPlanEnvLines = 5
+
 // PlanEditorView handles rendering of the plan editor interface.
 // It provides methods for rendering the main view, validation panel, and help bar.
 type PlanEditorView struct{}
@@ -134,10 +155,45 @@ func (v *PlanEditorView) Render(params PlanEditorRenderParams) string {
 
 	// Plan summary header
 	b.WriteString(styles.SidebarTitle.Render("Plan Editor"))
+	if state.Draft > 1 {
+		b.WriteString(styles.Muted.Render(fmt.Sprintf(" (draft %d)", state.Draft)))
+	}
 	b.WriteString("  ")
 	b.WriteString(v.renderValidationSummary(state))
 	b.WriteString("\n\n")
 
+	// Feedback input for the planner
+	if state.EditingField == "feedback" {
+		b.WriteString(styles.SidebarTitle.Render("Request Changes"))
+		b.WriteString("\n")
+		b.WriteString(renderEditBuffer(state.EditBuffer, state.EditCursor))
+		b.WriteString("\n")
+		b.WriteString(styles.Muted.Render("e.g. split task 3; merge 4 and 5; drop 6; don't touch pkg/api  [enter] send  [esc] cancel"))
+		b.WriteString("\n\n")
+	}
+
+	// Description input for splitting the selected task
+	if state.EditingField == "split" {
+		b.WriteString(styles.SidebarTitle.Render("Split Task"))
+		b.WriteString("\n")
+		b.WriteString(renderEditBuffer(state.EditBuffer, state.EditCursor))
+		b.WriteString("\n")
+		b.WriteString(styles.Muted.Render(fmt.Sprintf("type %s where each new task starts  [enter] split  [esc] cancel", planedit.SplitMarker)))
+		b.WriteString("\n\n")
+	}
+
+	// Changes since the previous draft
+	if params.Diff != nil {
+		b.WriteString(renderPlanDiff(*params.Diff, state.Draft-1, width))
+		b.WriteString("\n")
+	}
+
+	// Variables exported to every task
+	if len(plan.Env) > 0 {
+		b.WriteString(renderPlanEnv(plan.Env, width))
+		b.WriteString("\n")
+	}
+
 	// Task list with validation indicators
 	b.WriteString(styles.SidebarTitle.Render("Tasks"))
 	b.WriteString("\n")
@@ -172,8 +228,11 @@ func (v *PlanEditorView) Render(params PlanEditorRenderParams) string {
 			statusIcon = complexityIndicator(task.EstComplexity)
 		}
 
-		// Build task line
+		// Build task line, with a "+" on tasks marked for merging
 		taskNum := fmt.Sprintf("%d.", i+1)
+		if state.Marked[task.ID] {
+			taskNum = "+" + taskNum
+		}
 		titleLen := width - 12 // Account for numbering, icons, and padding
 		title := truncate(task.Title, titleLen)
 
@@ -202,6 +261,16 @@ func (v *PlanEditorView) Render(params PlanEditorRenderParams) string {
 		b.WriteString(styles.Muted.Render(fmt.Sprintf("  ↓ %d more below\n", remaining)))
 	}
 
+	// Execution groups, with the selected task highlighted
+	var selectedID string
+	if selectedIdx >= 0 && selectedIdx < len(plan.Tasks) {
+		selectedID = plan.Tasks[selectedIdx].ID
+	}
+	if groups := planedit.RenderGroups(plan, selectedID, state.Marked, width); groups != "" {
+		b.WriteString("\n")
+		b.WriteString(groups)
+	}
+
 	// Selected task details
 	if selectedIdx >= 0 && selectedIdx < len(plan.Tasks) {
 		task := &plan.Tasks[selectedIdx]
@@ -257,6 +326,9 @@ func (v *PlanEditorView) RenderHelp(state *PlanEditorState, width int) string {
 
 	keys = append(keys, "[↑↓] select task")
 	keys = append(keys, "[e] edit")
+	keys = append(keys, "[S] split")
+	keys = append(keys, "[m/M] mark/merge")
+	keys = append(keys, "[[/]] group")
 
 	// Show confirm status based on validation
 	if state != nil && state.CanConfirm {
@@ -265,12 +337,78 @@ func (v *PlanEditorView) RenderHelp(state *PlanEditorState, width int) string {
 		keys = append(keys, styles.Muted.Render("[enter] blocked"))
 	}
 
+	if state != nil && state.CanRefine {
+		keys = append(keys, "[R] request changes")
+	}
+
 	keys = append(keys, "[v] toggle validation")
 	keys = append(keys, "[esc] exit")
 
 	return styles.HelpBar.Width(width).Render(badge + "  " + strings.Join(keys, "  "))
 }
 
+// renderEditBuffer renders a single-line edit buffer with a block cursor.
+func renderEditBuffer(buffer string, cursor int) string {
+	runes := []rune(buffer)
+	cursor = max(0, min(cursor, len(runes)))
+	return "> " + string(runes[:cursor]) + "█" + string(runes[cursor:])
+}
+
+// renderPlanDiff renders the task-level changes since the previous draft.
+func renderPlanDiff(diff orchestrator.PlanDiff, prevDraft int, width int) string {
+	var b strings.Builder
+	b.WriteString(styles.SidebarTitle.Render(fmt.Sprintf("Changes Since Draft %d", prevDraft)))
+	b.WriteString("\n")
+
+	if diff.Empty() {
+		b.WriteString(styles.Muted.Render("  No task changes"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	var lines []string
+	titleLen := width - 12
+	for _, task := range diff.Added {
+		lines = append(lines, styles.DiffAdd.Render(fmt.Sprintf("  + %s %s", task.ID, truncate(task.Title, titleLen))))
+	}
+	for _, task := range diff.Removed {
+		lines = append(lines, styles.DiffRemove.Render(fmt.Sprintf("  - %s %s", task.ID, truncate(task.Title, titleLen))))
+	}
+	for _, change := range diff.Changed {
+		fields := strings.Join(change.Fields, ", ")
+		lines = append(lines, validationWarningStyle.Render(fmt.Sprintf("  ~ %s %s", change.Task.ID,
+			truncate(change.Task.Title, titleLen-len(fields)-3))+" "+styles.Muted.Render("("+fields+")")))
+	}
+
+	if len(lines) > maxPlanDiffLines {
+		hidden := len(lines) - maxPlanDiffLines + 1
+		lines = append(lines[:maxPlanDiffLines-1], styles.Muted.Render(fmt.Sprintf("  … %d more", hidden)))
+	}
+	b.WriteString(strings.Join(lines, "\n"))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// renderPlanEnv renders the variables the plan exports to every task, sorted
+// by name.
+func renderPlanEnv(env map[string]string, width int) string {
+	var b strings.Builder
+	b.WriteString(styles.SidebarTitle.Render("Environment"))
+	b.WriteString("\n")
+
+	var lines []string
+	for _, name := range slices.Sorted(maps.Keys(env)) {
+		lines = append(lines, "  "+name+styles.Muted.Render("="+truncate(env[name], width-len(name)-5)))
+	}
+	if len(lines) > maxPlanEnvLines {
+		hidden := len(lines) - maxPlanEnvLines + 1
+		lines = append(lines[:maxPlanEnvLines-1], styles.Muted.Render(fmt.Sprintf("  … %d more", hidden)))
+	}
+	b.WriteString(strings.Join(lines, "\n"))
+	b.WriteString("\n")
+	return b.String()
+}
+
 // renderValidationPanel renders the validation feedback panel at the bottom of the editor.
 func (v *PlanEditorView) renderValidationPanel(state *PlanEditorState, width int, maxHeight int) string {
 	if state == nil || state.Validation == nil {
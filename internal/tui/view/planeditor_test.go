@@ -0,0 +1,81 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+)
+
+func TestRenderPlanDiff(t *testing.T) {
+	diff := orchestrator.PlanDiff{
+		Added:   []orchestrator.PlannedTask{{ID: "task-5", Title: "Split reads"}},
+		Removed: []orchestrator.PlannedTask{{ID: "task-2", Title: "Wire API"}},
+		Changed: []orchestrator.TaskChange{{
+			Task:   orchestrator.PlannedTask{ID: "task-3", Title: "Docs"},
+			Fields: []string{"title", "depends_on"},
+		}},
+	}
+
+	got := renderPlanDiff(diff, 1, 80)
+	for _, want := range []string{
+		"Changes Since Draft 1",
+		"+ task-5 Split reads",
+		"- task-2 Wire API",
+		"~ task-3 Docs",
+		"(title, depends_on)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("renderPlanDiff() missing %q:\n%s", want, got)
+		}
+	}
+
+	if got := renderPlanDiff(orchestrator.PlanDiff{}, 2, 80); !strings.Contains(got, "No task changes") {
+		t.Errorf("renderPlanDiff() of empty diff = %q, want no-changes note", got)
+	}
+}
+
+func TestRenderPlanDiff_Truncates(t *testing.T) {
+	var diff orchestrator.PlanDiff
+	for range maxPlanDiffLines + 3 {
+		diff.Added = append(diff.Added, orchestrator.PlannedTask{ID: "t", Title: "x"})
+	}
+
+	got := renderPlanDiff(diff, 1, 80)
+	if !strings.Contains(got, "… 4 more") {
+		t.Errorf("renderPlanDiff() = %q, want overflow indicator", got)
+	}
+	if lines := strings.Count(got, "\n"); lines != maxPlanDiffLines+1 {
+		t.Errorf("renderPlanDiff() has %d lines, want %d", lines, maxPlanDiffLines+1)
+	}
+}
+
+func TestRenderPlanEnv(t *testing.T) {
+	got := renderPlanEnv(map[string]string{"FEATURE_FLAG": "new-checkout", "API_VERSION": "v3"}, 80)
+	if !strings.Contains(got, "Environment") {
+		t.Errorf("renderPlanEnv() missing title:\n%s", got)
+	}
+	api, flag := strings.Index(got, "API_VERSION=v3"), strings.Index(got, "FEATURE_FLAG=new-checkout")
+	if api < 0 || flag < 0 || api > flag {
+		t.Errorf("renderPlanEnv() = %q, want both variables sorted by name", got)
+	}
+
+	env := make(map[string]string)
+	for i := range maxPlanEnvLines + 2 {
+		env[fmt.Sprintf("VAR_%d", i)] = "x"
+	}
+	if got := renderPlanEnv(env, 80); !strings.Contains(got, "… 3 more") {
+		t.Errorf("renderPlanEnv() = %q, want overflow indicator", got)
+	}
+}
+
+func TestPlanEditorRenderHelp_RequestChanges(t *testing.T) {
+	v := NewPlanEditorView()
+	if got := v.RenderHelp(&PlanEditorState{CanRefine: true}, 200); !strings.Contains(got, "[R] request changes") {
+		t.Errorf("RenderHelp() = %q, want request-changes key", got)
+	}
+	if got := v.RenderHelp(&PlanEditorState{}, 200); strings.Contains(got, "[R]") {
+		t.Errorf("RenderHelp() = %q, want no request-changes key", got)
+	}
+}
@@ -0,0 +1,96 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ReplayState holds the state needed to render a transcript replay.
+type ReplayState struct {
+	// InstanceName names the instance whose transcript is replayed
+	InstanceName string
+
+	// Screen is the contents of the frame on screen
+	Screen string
+
+	// Frame is the index of the frame on screen; Frames is the frame count
+	Frame  int
+	Frames int
+
+	// Position is the offset of the frame on screen; Duration is the
+	// offset of the last frame
+	Position time.Duration
+	Duration time.Duration
+
+	// RecordedAt is when the recording started
+	RecordedAt time.Time
+
+	// Playing indicates the replay advances in real time
+	Playing bool
+}
+
+// RenderReplay renders a recorded frame with a header and a timeline.
+// The frame is cut to its last maxLines lines so the prompt and most
+// recent output stay visible.
+func RenderReplay(state ReplayState, width, maxLines int) string {
+	var b strings.Builder
+
+	status := "⏸ paused"
+	if state.Playing {
+		status = "▶ playing"
+	}
+	header := styles.Title.Render("Replay: "+state.InstanceName) + "  " +
+		styles.Secondary.Render(status) + "  " +
+		styles.Muted.Render(fmt.Sprintf("%s / %s  frame %d/%d",
+			formatReplayOffset(state.Position), formatReplayOffset(state.Duration),
+			state.Frame+1, state.Frames))
+	if !state.RecordedAt.IsZero() {
+		header += "  " + styles.Muted.Render("recorded "+state.RecordedAt.Format("2006-01-02 15:04"))
+	}
+	b.WriteString(header)
+	b.WriteString("\n")
+	b.WriteString(renderReplayTimeline(state.Frame, state.Frames, width-4))
+	b.WriteString("\n")
+
+	lines := strings.Split(state.Screen, "\n")
+	if maxLines > 0 && len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+	}
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, max(width-6, 0), "")
+	}
+
+	b.WriteString(styles.OutputArea.
+		Width(width - 4).
+		Height(maxLines).
+		Render(strings.Join(lines, "\n")))
+	return b.String()
+}
+
+// renderReplayTimeline renders a bar of the given width filled up to the
+// frame on screen.
+func renderReplayTimeline(frame, frames, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	filled := width
+	if frames > 1 {
+		filled = (frame*(width-1))/(frames-1) + 1
+	}
+	filled = max(0, min(filled, width))
+	return styles.Primary.Render(strings.Repeat("━", filled)) +
+		styles.Muted.Render(strings.Repeat("─", width-filled))
+}
+
+// formatReplayOffset formats an offset as m:ss, or h:mm:ss from an hour.
+func formatReplayOffset(d time.Duration) string {
+	secs := int(d / time.Second)
+	if secs >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", secs/3600, secs/60%60, secs%60)
+	}
+	return fmt.Sprintf("%d:%02d", secs/60, secs%60)
+}
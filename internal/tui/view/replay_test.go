@@ -0,0 +1,68 @@
+package view
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestFormatReplayOffset(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0:00"},
+		{1500 * time.Millisecond, "0:01"},
+		{75 * time.Second, "1:15"},
+		{time.Hour + 2*time.Minute + 3*time.Second, "1:02:03"},
+	}
+	for _, tt := range tests {
+		if got := formatReplayOffset(tt.d); got != tt.want {
+			t.Errorf("formatReplayOffset(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestRenderReplayTimeline(t *testing.T) {
+	tests := []struct {
+		frame, frames int
+		wantFilled    int
+	}{
+		{0, 5, 1},
+		{4, 5, 10},
+		{0, 1, 10},
+	}
+	for _, tt := range tests {
+		bar := ansi.Strip(renderReplayTimeline(tt.frame, tt.frames, 10))
+		if got := strings.Count(bar, "━"); got != tt.wantFilled {
+			t.Errorf("frame %d/%d: filled = %d, want %d", tt.frame, tt.frames, got, tt.wantFilled)
+		}
+		if got := len([]rune(bar)); got != 10 {
+			t.Errorf("frame %d/%d: width = %d, want 10", tt.frame, tt.frames, got)
+		}
+	}
+}
+
+func TestRenderReplay_ShowsLastLines(t *testing.T) {
+	state := ReplayState{
+		InstanceName: "worker",
+		Screen:       "line 1\nline 2\nline 3\nline 4",
+		Frame:        1,
+		Frames:       3,
+		Position:     5 * time.Second,
+		Duration:     90 * time.Second,
+		Playing:      true,
+	}
+
+	out := ansi.Strip(RenderReplay(state, 80, 2))
+	for _, want := range []string{"Replay: worker", "playing", "0:05 / 1:30", "frame 2/3", "line 3", "line 4"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("render missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "line 1") {
+		t.Errorf("render should cut the screen to the last 2 lines:\n%s", out)
+	}
+}
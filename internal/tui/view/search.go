@@ -0,0 +1,114 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/tui/search"
+	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/Iron-Ham/claudio/internal/tui/theme"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// maxSearchNameWidth caps the instance name column of search results.
+const maxSearchNameWidth = 20
+
+// SearchState holds the state needed to render :grep results.
+type SearchState struct {
+	// Pattern is the regular expression searched for
+	Pattern string
+
+	// Hits are the results; Names maps their instance IDs to display names
+	Hits  []search.Hit
+	Names map[string]string
+
+	// Selected is the index of the selected hit
+	Selected int
+
+	// Truncated indicates the search stopped at search.MaxHits
+	Truncated bool
+
+	// SearchingTranscripts indicates transcripts are still being searched
+	SearchingTranscripts bool
+}
+
+// SearchListLines returns how many results fit within height, for the
+// model to page by.
+func SearchListLines(height int) int {
+	// The header and a blank line come before the list
+	return max(height-2, 1)
+}
+
+// RenderSearch renders :grep results as a list of matching lines, each
+// with its instance and position, keeping the selected one in view.
+func RenderSearch(state SearchState, width, height int) string {
+	var b strings.Builder
+
+	instances := make(map[string]bool)
+	for _, hit := range state.Hits {
+		instances[hit.InstanceID] = true
+	}
+	summary := fmt.Sprintf("%d matches in %d instances", len(state.Hits), len(instances))
+	if state.Truncated {
+		summary = fmt.Sprintf("first %d matches in %d instances", len(state.Hits), len(instances))
+	}
+	if state.SearchingTranscripts {
+		summary += ", searching transcripts…"
+	}
+	b.WriteString(styles.Title.UnsetMarginBottom().Render("Search: /"+state.Pattern+"/") + "  " + styles.Muted.Render(summary))
+	b.WriteString("\n\n")
+
+	if len(state.Hits) == 0 {
+		if !state.SearchingTranscripts {
+			b.WriteString(styles.Muted.Render("No output matches."))
+		}
+		return b.String()
+	}
+
+	nameWidth := 0
+	name := func(id string) string {
+		if n, ok := state.Names[id]; ok {
+			return n
+		}
+		return id // The instance was removed
+	}
+	for _, hit := range state.Hits {
+		nameWidth = max(nameWidth, ansi.StringWidth(name(hit.InstanceID)))
+	}
+	nameWidth = min(nameWidth, maxSearchNameWidth)
+
+	rows := SearchListLines(height)
+	start := max(min(state.Selected-rows/2, len(state.Hits)-rows), 0)
+	end := min(start+rows, len(state.Hits))
+	for i := start; i < end; i++ {
+		b.WriteString(renderSearchHit(state.Hits[i], name(state.Hits[i].InstanceID), nameWidth, width, i == state.Selected))
+		if i < end-1 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// renderSearchHit renders one result: the instance, where the line is (its
+// line number, or the transcript offset it first appeared at), and the line
+// with its match highlighted.
+func renderSearchHit(hit search.Hit, name string, nameWidth, width int, selected bool) string {
+	name = ansi.Truncate(name, nameWidth, "…")
+	name += strings.Repeat(" ", nameWidth-ansi.StringWidth(name))
+	where := fmt.Sprintf(":%-5d", hit.Line+1)
+	if hit.Transcript {
+		where = "@" + formatReplayOffset(hit.At)
+		where += strings.Repeat(" ", max(6-len(where), 0))
+	}
+	prefix := fmt.Sprintf("  %s %s  ", name, where)
+
+	textWidth := max(width-4-ansi.StringWidth(prefix), 0)
+	if selected {
+		return theme.Current().Selected().Render(ansi.Truncate(prefix+hit.Text, width-4, "…"))
+	}
+
+	text := hit.Text[:hit.Match[0]] +
+		styles.SearchMatch.Render(hit.Text[hit.Match[0]:hit.Match[1]]) +
+		hit.Text[hit.Match[1]:]
+	return styles.Muted.Render(prefix) + ansi.Truncate(text, textWidth, "…")
+}
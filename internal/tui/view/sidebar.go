@@ -107,6 +107,43 @@ func (sv *SidebarView) RenderSidebar(state DashboardState, width, height int) st
 	}
 }
 
+// InstanceAt returns the index in session.Instances of the instance shown at
+// line of the sidebar rendered by RenderSidebar with the same arguments, or
+// -1 when no instance is shown there. line counts from the sidebar's title,
+// inside its border and padding. The dependency graph has no instance rows.
+func (sv *SidebarView) InstanceAt(state DashboardState, width, height, line int) int {
+	session := state.Session()
+	if session == nil || len(session.Instances) == 0 {
+		return -1
+	}
+	entries := func() []sidebarEntry { return sv.dashboard.layoutSidebar(state, width, height) }
+	if ss, ok := state.(SidebarState); ok {
+		switch ss.SidebarMode() {
+		case SidebarModeGraph:
+			return -1
+		case SidebarModeGrouped:
+			if ss.GroupViewState() != nil && session.HasGroups() {
+				items := enrichAdversarialRoundInfo(FlattenGroupsForDisplay(session, ss.GroupViewState()), ss.AdversarialStateData())
+				entries = func() []sidebarEntry { return sv.layoutGroupedSidebar(ss, items, width, height) }
+			}
+		}
+	}
+
+	// The title, then the scroll up indicator when scrolled, precede the entries
+	row := 1
+	if state.SidebarScrollOffset() > 0 {
+		row++
+	}
+	for _, entry := range entries() {
+		lines := strings.Count(entry.content, "\n") + 1
+		if line >= row && line < row+lines {
+			return entry.instanceIdx
+		}
+		row += lines
+	}
+	return -1
+}
+
 // RenderGroupedSidebar renders the sidebar with groups.
 func (sv *SidebarView) RenderGroupedSidebar(state SidebarState, width, height int) string {
 	var b strings.Builder
@@ -119,14 +156,6 @@ func (sv *SidebarView) RenderGroupedSidebar(state SidebarState, width, height in
 	groupState := state.GroupViewState()
 	isAddingTask := state.IsAddingTask()
 
-	// Calculate available lines for content (not slots - actual lines!)
-	// Reserve: 1 for title, 1 for blank line, 1 for hint, 2 for scroll indicators, plus padding
-	reservedLines := 6
-	if isAddingTask {
-		reservedLines += 2 // "New Task" entry takes 2 lines
-	}
-	availableLines := max(height-reservedLines, 5)
-
 	// Get flattened items for display
 	items := FlattenGroupsForDisplay(session, groupState)
 
@@ -140,65 +169,21 @@ func (sv *SidebarView) RenderGroupedSidebar(state SidebarState, width, height in
 	} else {
 		// Calculate scroll offset for grouped view
 		scrollOffset := state.SidebarScrollOffset()
-		hasMoreAbove := scrollOffset > 0
 
 		// Show scroll up indicator (counts as 1 line)
-		if hasMoreAbove {
+		if scrollOffset > 0 {
 			scrollUp := styles.Muted.Render(fmt.Sprintf("\u25b2 %d more above", scrollOffset))
 			b.WriteString(scrollUp)
 			b.WriteString("\n")
-			availableLines-- // Account for scroll indicator line
 		}
 
-		// Reserve space for scroll down indicator (1 line)
-		availableLines--
-
-		// Track actual lines used and where we stop rendering
-		linesUsed := 0
-		lastRenderedIdx := scrollOffset - 1 // Will be updated as we render
-
-		// Render visible items, tracking actual line usage
-		// ActiveTab returns the index in session.Instances, so compare with AbsoluteIdx
-		// When adding a task, no instance should be highlighted (use -1 to match nothing)
-		activeInstanceIdx := -1
-		if !isAddingTask {
-			activeInstanceIdx = state.ActiveTab()
-		}
-
-		for i := scrollOffset; i < len(items); i++ {
-			item := items[i]
-
-			// Calculate how many lines this item will take
-			var itemLines int
-			var renderedContent string
-
-			switch v := item.(type) {
-			case GroupHeaderItem:
-				indent := strings.Repeat("  ", v.Depth)
-				// Use RenderGroupHeaderItem which supports RoundInfo display
-				header := RenderGroupHeaderItem(v, width-len(indent))
-				renderedContent = indent + header
-				// Count lines: header can be multi-line due to word wrapping
-				itemLines = strings.Count(renderedContent, "\n") + 1 // +1 for the line itself
-
-			case GroupedInstance:
-				isActive := v.AbsoluteIdx == activeInstanceIdx
-				renderedContent = RenderGroupedInstance(v, isActive, width)
-				// RenderGroupedInstance returns 2 lines (name + status with internal \n)
-				itemLines = strings.Count(renderedContent, "\n") + 1
-			}
-
-			// Check if adding this item would exceed available lines
-			if linesUsed+itemLines > availableLines {
-				break
-			}
-
-			// Render the item
-			b.WriteString(renderedContent)
+		// Render visible items
+		entries := sv.layoutGroupedSidebar(state, items, width, height)
+		for _, entry := range entries {
+			b.WriteString(entry.content)
 			b.WriteString("\n")
-			linesUsed += itemLines
-			lastRenderedIdx = i
 		}
+		lastRenderedIdx := scrollOffset + len(entries) - 1
 
 		// Show scroll down indicator if there are more items
 		hasMoreBelow := lastRenderedIdx < len(items)-1
@@ -236,6 +221,49 @@ func (sv *SidebarView) RenderGroupedSidebar(state SidebarState, width, height in
 	return styles.Sidebar.Width(width - 2).Render(b.String())
 }
 
+// layoutGroupedSidebar renders the items of the grouped sidebar visible from
+// the scroll offset on.
+func (sv *SidebarView) layoutGroupedSidebar(state SidebarState, items []any, width, height int) []sidebarEntry {
+	isAddingTask := state.IsAddingTask()
+
+	// Calculate available lines for content (not slots - actual lines!)
+	// Reserve: 1 for title, 1 for blank line, 1 for hint, 2 for scroll indicators, plus padding
+	reservedLines := 6
+	if isAddingTask {
+		reservedLines += 2 // "New Task" entry takes 2 lines
+	}
+	availableLines := max(height-reservedLines, 5)
+
+	scrollOffset := state.SidebarScrollOffset()
+	if scrollOffset > 0 {
+		availableLines-- // Account for scroll up indicator line
+	}
+	// Reserve space for scroll down indicator (1 line)
+	availableLines--
+
+	// ActiveTab returns the index in session.Instances, so compare with AbsoluteIdx
+	// When adding a task, no instance should be highlighted (use -1 to match nothing)
+	activeInstanceIdx := -1
+	if !isAddingTask {
+		activeInstanceIdx = state.ActiveTab()
+	}
+
+	return fitSidebarEntries(scrollOffset, len(items), availableLines, func(i int) sidebarEntry {
+		switch v := items[i].(type) {
+		case GroupHeaderItem:
+			indent := strings.Repeat("  ", v.Depth)
+			// Use RenderGroupHeaderItem which supports RoundInfo display
+			header := RenderGroupHeaderItem(v, width-len(indent))
+			return sidebarEntry{content: indent + header, instanceIdx: -1}
+
+		case GroupedInstance:
+			isActive := v.AbsoluteIdx == activeInstanceIdx
+			return sidebarEntry{content: RenderGroupedInstance(v, isActive, width), instanceIdx: v.AbsoluteIdx}
+		}
+		return sidebarEntry{instanceIdx: -1}
+	})
+}
+
 // GroupNavigator handles keyboard navigation for the grouped sidebar view.
 type GroupNavigator struct {
 	session    *orchestrator.Session
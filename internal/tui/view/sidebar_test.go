@@ -7,6 +7,7 @@ import (
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	"github.com/Iron-Ham/claudio/internal/orchestrator/workflows/adversarial"
 	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // mockSidebarState implements SidebarState for testing grouped views.
@@ -204,6 +205,67 @@ func TestSidebarView_CollapsedGroup(t *testing.T) {
 	}
 }
 
+func TestSidebarView_InstanceAt(t *testing.T) {
+	session := &orchestrator.Session{
+		Instances: []*orchestrator.Instance{
+			{ID: "inst-1", Task: "Setup auth", Status: orchestrator.StatusCompleted},
+			{ID: "inst-2", Task: "Create migrations", Status: orchestrator.StatusCompleted},
+			{ID: "inst-3", Task: "Auth service", Status: orchestrator.StatusWorking},
+		},
+		Groups: []*orchestrator.InstanceGroup{
+			{ID: "group-1", Name: "Group 1: Setup", Instances: []string{"inst-1", "inst-2"}},
+			{ID: "group-2", Name: "Group 2: Core", Instances: []string{"inst-3"}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		mode   SidebarMode
+		scroll int
+	}{
+		{"flat", SidebarModeFlat, 0},
+		{"flat scrolled", SidebarModeFlat, 1},
+		{"grouped", SidebarModeGrouped, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &mockSidebarState{
+				session:             session,
+				sidebarScrollOffset: tt.scroll,
+				sidebarMode:         tt.mode,
+				groupViewState:      NewGroupViewState(),
+			}
+			sv := NewSidebarView()
+			rendered := strings.Split(ansi.Strip(sv.RenderSidebar(state, 40, 25)), "\n")
+
+			// Every rendered line naming an instance's task maps to that
+			// instance; lines are offset by the border and padding
+			found := 0
+			for i, line := range rendered {
+				for idx, inst := range session.Instances {
+					if strings.Contains(line, inst.Task) {
+						found++
+						if got := sv.InstanceAt(state, 40, 25, i-2); got != idx {
+							t.Errorf("InstanceAt(line %d %q) = %d, want %d", i-2, line, got, idx)
+						}
+					}
+				}
+				if strings.Contains(line, "Group 1") || strings.Contains(line, "more above") {
+					if got := sv.InstanceAt(state, 40, 25, i-2); got != -1 {
+						t.Errorf("InstanceAt(line %d %q) = %d, want -1", i-2, line, got)
+					}
+				}
+			}
+			if want := len(session.Instances) - tt.scroll; found != want {
+				t.Errorf("found %d instance rows, want %d:\n%s", found, want, strings.Join(rendered, "\n"))
+			}
+			if got := sv.InstanceAt(state, 40, 25, 0); got != -1 {
+				t.Errorf("InstanceAt(title) = %d, want -1", got)
+			}
+		})
+	}
+}
+
 func TestSidebarView_GroupNavHints(t *testing.T) {
 	session := &orchestrator.Session{
 		Instances: []*orchestrator.Instance{
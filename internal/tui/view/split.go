@@ -0,0 +1,141 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	instmetrics "github.com/Iron-Ham/claudio/internal/instance/metrics"
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// splitPaneOverhead is the lines of a split pane other than output: the
+// name and status lines and the output box border.
+const splitPaneOverhead = 4
+
+// SplitPane is one side of the split comparison view.
+type SplitPane struct {
+	Instance *orchestrator.Instance
+
+	// Lines is the instance's (filtered) output
+	Lines []string
+
+	// ScrollOffset is the index of the first visible line
+	ScrollOffset int
+
+	// Match is the line of the current search match, or -1
+	Match int
+
+	// Focused indicates the pane receives scroll keys
+	Focused bool
+}
+
+// SplitState holds the state needed to render the split comparison view.
+type SplitState struct {
+	Left, Right SplitPane
+
+	// Query is the search shared by both panes; lines containing it are
+	// highlighted in each
+	Query string
+
+	// Searching indicates the query is being typed
+	Searching bool
+}
+
+// SplitOutputLines returns how many output lines each pane shows within
+// height, for the model to scroll by.
+func SplitOutputLines(height int) int {
+	// One line below the panes is kept for the search bar
+	return max(height-splitPaneOverhead-1, 1)
+}
+
+// SearchMatches returns the indexes of the lines containing query, ignoring
+// case and ANSI styling.
+func SearchMatches(lines []string, query string) []int {
+	if query == "" {
+		return nil
+	}
+	query = strings.ToLower(query)
+	var matches []int
+	for i, line := range lines {
+		if strings.Contains(strings.ToLower(ansi.Strip(line)), query) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// RenderSplit renders two instances side by side, each with its status and
+// output, above a search bar.
+func RenderSplit(state SplitState, width, height int) string {
+	paneWidth := (width - 1) / 2
+	outputLines := SplitOutputLines(height)
+	left := renderSplitPane(state.Left, state.Query, paneWidth, outputLines)
+	right := renderSplitPane(state.Right, state.Query, paneWidth, outputLines)
+
+	var b strings.Builder
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, left, " ", right))
+	b.WriteString("\n")
+	switch {
+	case state.Searching:
+		b.WriteString(styles.SearchPrompt.Render("/") + styles.SearchInput.Render(state.Query) + "█")
+	case state.Query != "":
+		b.WriteString(styles.SearchPrompt.Render("/") + styles.SearchInput.Render(state.Query) +
+			styles.SearchInfo.Render(fmt.Sprintf("matches: %d left, %d right",
+				len(SearchMatches(state.Left.Lines, state.Query)),
+				len(SearchMatches(state.Right.Lines, state.Query)))))
+	}
+	return b.String()
+}
+
+// renderSplitPane renders one side of the split view in width columns.
+func renderSplitPane(pane SplitPane, query string, width, outputLines int) string {
+	inst := pane.Instance
+	inner := max(width-2, 1) // Inside the output box border
+
+	nameStyle := styles.Muted
+	if pane.Focused {
+		nameStyle = lipgloss.NewStyle().Bold(true).Foreground(styles.PrimaryColor)
+	}
+	name := nameStyle.Render(ansi.Truncate(inst.EffectiveName(), inner, "…"))
+
+	statusColor := styles.StatusColor(string(inst.Status))
+	status := lipgloss.NewStyle().Foreground(statusColor).Render(
+		styles.StatusIcon(string(inst.Status)) + " " + strings.ReplaceAll(string(inst.Status), "_", " "))
+	if m := inst.Metrics; m != nil && (m.TotalTokens() > 0 || m.Cost > 0) {
+		status += styles.Muted.Render(fmt.Sprintf("  %s  %s",
+			instmetrics.FormatTokens(m.TotalTokens()), instmetrics.FormatCost(m.Cost)))
+	}
+	if total := len(pane.Lines); total > outputLines {
+		status += styles.Muted.Render(fmt.Sprintf("  lines %d-%d/%d",
+			pane.ScrollOffset+1, min(pane.ScrollOffset+outputLines, total), total))
+	}
+
+	start := max(min(pane.ScrollOffset, len(pane.Lines)), 0)
+	end := min(start+outputLines, len(pane.Lines))
+	visible := make([]string, 0, end-start)
+	lowerQuery := strings.ToLower(query)
+	for i := start; i < end; i++ {
+		line := ansi.Truncate(pane.Lines[i], inner, "")
+		if query != "" {
+			plain := ansi.Strip(line)
+			switch {
+			case i == pane.Match:
+				line = styles.SearchCurrentMatch.Render(plain)
+			case strings.Contains(strings.ToLower(ansi.Strip(pane.Lines[i])), lowerQuery):
+				line = styles.SearchMatch.Render(plain)
+			}
+		}
+		visible = append(visible, line)
+	}
+
+	box := styles.OutputArea
+	if pane.Focused {
+		box = box.BorderForeground(styles.PrimaryColor)
+	}
+	output := box.Width(width - 2).Height(outputLines).Render(strings.Join(visible, "\n"))
+
+	return lipgloss.NewStyle().Width(width).Render(name + "\n" + ansi.Truncate(status, width, "…") + "\n" + output)
+}
@@ -0,0 +1,63 @@
+package view
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestSearchMatches(t *testing.T) {
+	lines := []string{"go test ./...", "\x1b[31mFAIL\x1b[0m pkg", "ok pkg", "fail again"}
+	if got, want := SearchMatches(lines, "fail"), []int{1, 3}; !slices.Equal(got, want) {
+		t.Errorf("SearchMatches(fail) = %v, want %v", got, want)
+	}
+	if got := SearchMatches(lines, ""); got != nil {
+		t.Errorf("SearchMatches with no query = %v, want nil", got)
+	}
+}
+
+func TestRenderSplit(t *testing.T) {
+	state := SplitState{
+		Left: SplitPane{
+			Instance: &orchestrator.Instance{ID: "a", Task: "Left task", Status: orchestrator.StatusWorking},
+			Lines:    []string{"building", "error: left broke"},
+			Match:    -1,
+			Focused:  true,
+		},
+		Right: SplitPane{
+			Instance: &orchestrator.Instance{
+				ID: "b", Task: "Right task", Status: orchestrator.StatusCompleted,
+				Metrics: &orchestrator.Metrics{InputTokens: 2000, Cost: 0.5},
+			},
+			Lines: []string{"line 0", "line 1", "error: right broke", "line 3", "line 4"},
+			Match: 2,
+			// Scrolled down a line
+			ScrollOffset: 1,
+		},
+		Query: "error",
+	}
+
+	out := ansi.Strip(RenderSplit(state, 100, 8))
+	for _, want := range []string{
+		"Left task", "working", "error: left broke",
+		"Right task", "completed", "2.0K", "$0.50", "lines 2-4/5", "error: right broke",
+		"/error", "1 left, 1 right",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("RenderSplit() missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "line 0") {
+		t.Errorf("RenderSplit() should scroll the right pane past line 0:\n%s", out)
+	}
+
+	// Panes sit side by side: the tasks share a line
+	for line := range strings.SplitSeq(out, "\n") {
+		if strings.Contains(line, "Left task") && !strings.Contains(line, "Right task") {
+			t.Errorf("panes should be side by side, got line %q", line)
+		}
+	}
+}
@@ -130,6 +130,21 @@ func ConsolidationPhaseDesc(phase orchestrator.ConsolidationPhase) string {
 	return ultraplan.ConsolidationPhaseDesc(phase)
 }
 
+// TaskGraphState holds the state needed to render the task dependency graph.
+// This is an alias to the implementation in the ultraplan subpackage.
+type TaskGraphState = ultraplan.GraphState
+
+// RenderTaskGraph renders the plan's task dependency graph.
+func RenderTaskGraph(state TaskGraphState, width, height int) string {
+	return ultraplan.RenderGraph(state, width, height)
+}
+
+// TaskGraphOrder returns the plan's task IDs in the order the dependency
+// graph's selection moves in.
+func TaskGraphOrder(plan *orchestrator.PlanSpec) []string {
+	return ultraplan.GraphOrder(plan)
+}
+
 // OpenURL opens the given URL in the default browser.
 func OpenURL(url string) error {
 	return ultraplan.OpenURL(url)
@@ -0,0 +1,106 @@
+package ultraplan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/Iron-Ham/claudio/internal/tui/theme"
+	"github.com/Iron-Ham/claudio/internal/ultraplan/decomposition"
+)
+
+const (
+	// maxMatrixTasks is how many tasks the conflict matrix shows before
+	// leaving the rest to the pair list.
+	maxMatrixTasks = 12
+	// maxConflictPairs is how many conflicting pairs are listed.
+	maxConflictPairs = 8
+	// matrixLabelWidth is the widest task ID shown in matrix row labels.
+	matrixLabelWidth = 20
+)
+
+// conflictSymbol returns the matrix cell for a conflict level, styled by
+// how likely the conflict is.
+func conflictSymbol(level decomposition.Level) string {
+	switch level {
+	case decomposition.LevelFile:
+		return theme.Current().Failure().Render("F")
+	case decomposition.LevelPackage:
+		return theme.Current().Attention().Render("P")
+	case decomposition.LevelImport:
+		return styles.Muted.Render("I")
+	default:
+		return styles.Muted.Render("·")
+	}
+}
+
+// renderConflictSection renders the parallel tasks predicted to conflict as
+// a matrix of the tasks involved, followed by why each pair may conflict and
+// the dependencies added to serialize them. It returns "" when nothing was
+// predicted or serialized.
+func renderConflictSection(report *decomposition.Report) string {
+	if report == nil || (len(report.Conflicts) == 0 && len(report.Serialized) == 0) {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(styles.SidebarTitle.Render("Conflict Risk"))
+	b.WriteString("\n")
+
+	if len(report.Conflicts) > 0 {
+		b.WriteString(fmt.Sprintf("%d parallel pairs may conflict (%d share files)\n",
+			len(report.Conflicts), report.Count(decomposition.LevelFile)))
+		b.WriteString(styles.Muted.Render("F shared file · P same package · I import"))
+		b.WriteString("\n\n")
+		b.WriteString(renderConflictMatrix(report))
+		b.WriteString("\n")
+
+		for i, c := range report.Conflicts {
+			if i == maxConflictPairs {
+				b.WriteString(styles.Muted.Render(fmt.Sprintf("  … and %d more\n", len(report.Conflicts)-i)))
+				break
+			}
+			b.WriteString(fmt.Sprintf("  %s %s × %s: %s\n", conflictSymbol(c.Level), c.A, c.B, c.Reason()))
+		}
+	}
+
+	for _, d := range report.Serialized {
+		b.WriteString(theme.Current().Accent().Render(
+			fmt.Sprintf("  ⇢ Serialized: %s now waits for %s (shared %s)", d.Task, d.DependsOn, d.Level)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderConflictMatrix renders the conflict levels between the tasks
+// involved in a conflict. Rows are labeled with the task's number and ID;
+// columns with its number alone, to stay narrow.
+func renderConflictMatrix(report *decomposition.Report) string {
+	ids := report.Involved()
+	if len(ids) > maxMatrixTasks {
+		ids = ids[:maxMatrixTasks]
+	}
+	labelWidth := 0
+	for _, id := range ids {
+		labelWidth = max(labelWidth, len([]rune(truncate(id, matrixLabelWidth))))
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Repeat(" ", labelWidth+6))
+	for i := range ids {
+		b.WriteString(fmt.Sprintf("%3d", i+1))
+	}
+	b.WriteString("\n")
+	for i, a := range ids {
+		b.WriteString(fmt.Sprintf("  %2d  %s", i+1, padToWidth(truncate(a, matrixLabelWidth), labelWidth)))
+		for j, other := range ids {
+			cell := styles.Muted.Render("─")
+			if i != j {
+				cell = conflictSymbol(report.Level(a, other))
+			}
+			b.WriteString("  " + cell)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
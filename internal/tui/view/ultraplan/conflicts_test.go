@@ -0,0 +1,42 @@
+package ultraplan
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/ultraplan/decomposition"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRenderConflictSection(t *testing.T) {
+	if got := renderConflictSection(nil); got != "" {
+		t.Errorf("nil report rendered %q", got)
+	}
+	if got := renderConflictSection(&decomposition.Report{Tasks: []string{"t1"}}); got != "" {
+		t.Errorf("report without conflicts rendered %q", got)
+	}
+
+	report := &decomposition.Report{
+		Tasks: []string{"t1", "t2", "t3"},
+		Conflicts: []decomposition.Conflict{
+			{A: "t1", B: "t2", Level: decomposition.LevelFile, Files: []string{"api.go"}},
+			{A: "t1", B: "t3", Level: decomposition.LevelImport, Packages: []string{"api imports store"}},
+		},
+		Serialized: []decomposition.Dependency{{Task: "t3", DependsOn: "t2", Level: decomposition.LevelFile}},
+	}
+	got := ansi.Strip(renderConflictSection(report))
+
+	for _, want := range []string{
+		"2 parallel pairs may conflict (1 share files)",
+		"         1  2  3\n",
+		"   1  t1  ─  F  I\n",
+		"   2  t2  F  ─  ·\n",
+		"F t1 × t2: both change api.go",
+		"I t1 × t3: api imports store",
+		"Serialized: t3 now waits for t2 (shared file)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("section missing %q:\n%s", want, got)
+		}
+	}
+}
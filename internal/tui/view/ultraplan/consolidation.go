@@ -6,7 +6,7 @@ import (
 
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	"github.com/Iron-Ham/claudio/internal/tui/styles"
-	"github.com/charmbracelet/lipgloss"
+	"github.com/Iron-Ham/claudio/internal/tui/theme"
 )
 
 // ConsolidationRenderer handles rendering of the consolidation phase sidebar.
@@ -41,7 +41,18 @@ func (c *ConsolidationRenderer) RenderSidebar(width int, height int) string {
 	phaseDesc := ConsolidationPhaseDesc(state.Phase)
 	statusLine := fmt.Sprintf("%s %s", phaseIcon, phaseDesc)
 	b.WriteString(statusLine)
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+
+	// Freshness against the target branch
+	if freshness := ConsolidationFreshness(state); freshness != "" {
+		freshnessStyle := styles.Muted
+		if state.Stale {
+			freshnessStyle = styles.Warning
+		}
+		b.WriteString(freshnessStyle.Render(truncate(freshness, width-4)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	// Progress: Groups
 	if state.TotalGroups > 0 {
@@ -88,7 +99,7 @@ func (c *ConsolidationRenderer) RenderSidebar(width int, height int) string {
 
 	// Conflict info (if paused)
 	if state.Phase == orchestrator.ConsolidationPaused && len(state.ConflictFiles) > 0 {
-		b.WriteString(lipgloss.NewStyle().Foreground(styles.RedColor).Render("⚠ Conflict Detected"))
+		b.WriteString(theme.Current().Failure().Render("⚠ Conflict Detected"))
 		b.WriteString("\n\n")
 		b.WriteString(styles.Muted.Render("Files:"))
 		b.WriteString("\n")
@@ -105,13 +116,13 @@ func (c *ConsolidationRenderer) RenderSidebar(width int, height int) string {
 			b.WriteString("\n")
 		}
 		b.WriteString("\n")
-		b.WriteString(styles.Muted.Render("Press [r] to resume"))
+		b.WriteString(styles.Muted.Render("Press [x] to review conflicts, [r] to resume"))
 		b.WriteString("\n")
 	}
 
 	// Error message
 	if state.Error != "" {
-		b.WriteString(lipgloss.NewStyle().Foreground(styles.RedColor).Render("Error:"))
+		b.WriteString(theme.Current().Failure().Render("Error:"))
 		b.WriteString("\n")
 		errDisplay := truncate(state.Error, width-4)
 		b.WriteString(styles.Muted.Render(errDisplay))
@@ -121,6 +132,26 @@ func (c *ConsolidationRenderer) RenderSidebar(width int, height int) string {
 	return styles.Sidebar.Width(width - 2).Render(b.String())
 }
 
+// ConsolidationFreshness describes how far the consolidated work trails its
+// target branch, or returns "" when the freshness check did not run.
+func ConsolidationFreshness(state *orchestrator.ConsolidatorState) string {
+	if state == nil || state.TargetBranch == "" {
+		return ""
+	}
+	if state.BehindBy == 0 {
+		return "Up to date with " + state.TargetBranch
+	}
+
+	line := fmt.Sprintf("%d behind %s", state.BehindBy, state.TargetBranch)
+	switch {
+	case state.Rebasing:
+		line += " (rebasing)"
+	case state.Stale:
+		line = "⚠ " + line
+	}
+	return line
+}
+
 // ConsolidationPhaseIcon returns an icon for the consolidation phase.
 func ConsolidationPhaseIcon(phase orchestrator.ConsolidationPhase) string {
 	switch phase {
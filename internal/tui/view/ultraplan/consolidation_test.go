@@ -0,0 +1,46 @@
+package ultraplan
+
+import (
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+)
+
+func TestConsolidationFreshness(t *testing.T) {
+	tests := []struct {
+		name  string
+		state *orchestrator.ConsolidatorState
+		want  string
+	}{
+		{"nil state", nil, ""},
+		{"not checked", &orchestrator.ConsolidatorState{}, ""},
+		{
+			"up to date",
+			&orchestrator.ConsolidatorState{TargetBranch: "origin/main"},
+			"Up to date with origin/main",
+		},
+		{
+			"behind within threshold",
+			&orchestrator.ConsolidatorState{TargetBranch: "origin/main", BehindBy: 3},
+			"3 behind origin/main",
+		},
+		{
+			"stale",
+			&orchestrator.ConsolidatorState{TargetBranch: "origin/main", BehindBy: 40, Stale: true},
+			"⚠ 40 behind origin/main",
+		},
+		{
+			"stale and rebasing",
+			&orchestrator.ConsolidatorState{TargetBranch: "origin/main", BehindBy: 40, Stale: true, Rebasing: true},
+			"40 behind origin/main (rebasing)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConsolidationFreshness(tt.state); got != tt.want {
+				t.Errorf("ConsolidationFreshness() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
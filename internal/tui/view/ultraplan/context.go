@@ -2,6 +2,7 @@ package ultraplan
 
 import (
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/tui/keymap"
 )
 
 // RenderContext provides the necessary context for rendering ultraplan views.
@@ -21,6 +22,9 @@ type RenderContext struct {
 	// InputMode indicates whether input forwarding mode is active.
 	// Used by help bar rendering to show appropriate mode badge.
 	InputMode bool
+
+	// Keys are the key bindings the help bar shows. Nil uses the defaults.
+	Keys *keymap.Keymap
 }
 
 // State holds ultra-plan specific UI state.
@@ -32,6 +36,7 @@ type State struct {
 	LastNotifiedPhase      orchestrator.UltraPlanPhase     // Prevent duplicate notifications for same phase
 	LastConsolidationPhase orchestrator.ConsolidationPhase // Track consolidation phase for pause detection
 	NotifiedGroupDecision  bool                            // Prevent repeated notifications while awaiting group decision
+	NotifiedGroupApproval  bool                            // Prevent repeated notifications while the next group awaits approval
 
 	// Phase-aware navigation state
 	NavigableInstances []string // Ordered list of navigable instance IDs
@@ -14,6 +14,7 @@
 //   - [ConsolidationRenderer]: Consolidation phase sidebar rendering
 //   - [HelpRenderer]: Context-sensitive help bar rendering
 //   - [SidebarRenderer]: Sidebar section composition
+//   - [RenderGraph]: Task dependency graph with the critical path highlighted
 //
 // # Design Philosophy
 //
@@ -0,0 +1,546 @@
+package ultraplan
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/Iron-Ham/claudio/internal/tui/theme"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// graphBoxHeight is a task box's rows: its border, its status and ID, and
+	// its title.
+	graphBoxHeight = 4
+	// graphBandHeight is the rows between two rows of boxes that edges are
+	// routed through.
+	graphBandHeight = 3
+	// graphGap is the columns between two boxes in a row.
+	graphGap = 2
+	// minGraphBoxWidth and maxGraphBoxWidth bound a box's width; a group
+	// that doesn't fit at the minimum wraps onto more rows.
+	minGraphBoxWidth = 14
+	maxGraphBoxWidth = 28
+	// graphChromeRows is the rows around the graph: the title, the legend,
+	// and the selected task's details.
+	graphChromeRows = 6
+)
+
+// TaskStatus is where a planned task is in execution.
+type TaskStatus int
+
+// Task statuses, as shown in the dependency graph.
+const (
+	TaskPending TaskStatus = iota
+	TaskRunning
+	TaskComplete
+	TaskFailed
+)
+
+// String returns the status as shown in the graph's details.
+func (s TaskStatus) String() string {
+	switch s {
+	case TaskRunning:
+		return "running"
+	case TaskComplete:
+		return "complete"
+	case TaskFailed:
+		return "failed"
+	default:
+		return "pending"
+	}
+}
+
+// icon returns the status icon the task list also uses.
+func (s TaskStatus) icon() string {
+	switch s {
+	case TaskRunning:
+		return "⟳"
+	case TaskComplete:
+		return "✓"
+	case TaskFailed:
+		return "✗"
+	default:
+		return "○"
+	}
+}
+
+// style returns the status's color.
+func (s TaskStatus) style() lipgloss.Style {
+	switch s {
+	case TaskRunning:
+		return theme.Current().Running()
+	case TaskComplete:
+		return theme.Current().Success()
+	case TaskFailed:
+		return theme.Current().Failure()
+	default:
+		return styles.Muted
+	}
+}
+
+// TaskStatusOf returns where a task of session is in execution.
+func TaskStatusOf(session *orchestrator.UltraPlanSession, taskID string) TaskStatus {
+	switch {
+	case slices.Contains(session.CompletedTasks, taskID):
+		return TaskComplete
+	case slices.Contains(session.FailedTasks, taskID):
+		return TaskFailed
+	case session.TaskToInstance[taskID] != "":
+		return TaskRunning
+	default:
+		return TaskPending
+	}
+}
+
+// GraphOrder returns the IDs of plan's tasks in the order the dependency
+// graph lays them out: by execution group, then within the group. It is the
+// order the graph's selection moves in.
+func GraphOrder(plan *orchestrator.PlanSpec) []string {
+	if plan == nil {
+		return nil
+	}
+	tasks := planTasks(plan)
+	var ids []string
+	for _, group := range plan.ExecutionOrder {
+		for _, id := range group {
+			if tasks[id] != nil {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// planTasks returns plan's tasks by ID.
+func planTasks(plan *orchestrator.PlanSpec) map[string]*orchestrator.PlannedTask {
+	tasks := make(map[string]*orchestrator.PlannedTask, len(plan.Tasks))
+	for i := range plan.Tasks {
+		tasks[plan.Tasks[i].ID] = &plan.Tasks[i]
+	}
+	return tasks
+}
+
+// complexityWeight is how long a task of the given complexity is taken to
+// run when finding the critical path.
+func complexityWeight(c orchestrator.TaskComplexity) int {
+	switch c {
+	case orchestrator.ComplexityLow:
+		return 1
+	case orchestrator.ComplexityHigh:
+		return 3
+	default:
+		return 2
+	}
+}
+
+// CriticalPath returns the longest chain of dependent tasks in plan, first
+// task first, with each task weighted by its estimated complexity. It is the
+// chain that bounds how soon the plan can finish however many tasks run in
+// parallel.
+func CriticalPath(plan *orchestrator.PlanSpec) []string {
+	order := GraphOrder(plan)
+	tasks := planTasks(plan)
+	length := make(map[string]int, len(order))
+	prev := make(map[string]string, len(order))
+	end := ""
+	// Groups are in dependency order, so each dependency is seen first
+	for _, id := range order {
+		task := tasks[id]
+		for _, dep := range task.DependsOn {
+			if l, ok := length[dep]; ok && l > length[id] {
+				length[id] = l
+				prev[id] = dep
+			}
+		}
+		length[id] += complexityWeight(task.EstComplexity)
+		if end == "" || length[id] > length[end] {
+			end = id
+		}
+	}
+
+	var path []string
+	for id := end; id != ""; id = prev[id] {
+		path = append(path, id)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// GraphState holds the state needed to render the dependency graph.
+type GraphState struct {
+	// Session holds the plan and where each task is in execution
+	Session *orchestrator.UltraPlanSession
+
+	// Selected is the ID of the selected task
+	Selected string
+}
+
+// graphNode is a task box placed on the canvas.
+type graphNode struct {
+	task     *orchestrator.PlannedTask
+	row      int
+	x        int // Column of the box's left border
+	status   TaskStatus
+	critical bool
+}
+
+// center returns the column edges leave and enter the box at.
+func (n *graphNode) center(boxWidth int) int {
+	return n.x + boxWidth/2
+}
+
+// RenderGraph renders the plan's tasks as boxes, one row per execution group
+// (wrapped when a group is wider than width), with edges from each task to
+// the tasks in the row below that depend on it. Boxes are colored by task
+// status; the critical path has double borders and highlighted edges. The
+// graph scrolls to keep the selected task in view, and the selected task's
+// dependencies are listed below it, including those in rows further up that
+// have no edge drawn.
+func RenderGraph(state GraphState, width, height int) string {
+	var b strings.Builder
+	title := "Task Dependencies"
+
+	session := state.Session
+	if session == nil || session.Plan == nil || len(GraphOrder(session.Plan)) == 0 {
+		b.WriteString(styles.Title.UnsetMarginBottom().Render(title))
+		b.WriteString("\n\n")
+		b.WriteString(styles.Muted.Render("No plan yet."))
+		return b.String()
+	}
+	plan := session.Plan
+	tasks := planTasks(plan)
+
+	path := CriticalPath(plan)
+	weight := 0
+	for _, id := range path {
+		weight += complexityWeight(tasks[id].EstComplexity)
+	}
+	critical := fmt.Sprintf("critical path (weight %d): %s", weight, strings.Join(path, " → "))
+	b.WriteString(styles.Title.UnsetMarginBottom().Render(title) + "  " +
+		theme.Current().Accent().Render(ansi.Truncate(critical, max(width-ansi.StringWidth(title)-2, 0), "…")))
+	b.WriteString("\n")
+	b.WriteString(ansi.Truncate(graphLegend(), width, "…"))
+	b.WriteString("\n\n")
+
+	lines, selectedRow := renderGraphCanvas(plan, session, path, state.Selected, width)
+	rows := max(height-graphChromeRows, graphBoxHeight)
+	start := 0
+	if len(lines) > rows {
+		top := selectedRow * (graphBoxHeight + graphBandHeight)
+		start = max(min(top-(rows-graphBoxHeight)/2, len(lines)-rows), 0)
+		lines = lines[start : start+rows]
+	}
+	b.WriteString(strings.Join(lines, "\n"))
+	b.WriteString("\n\n")
+	b.WriteString(renderGraphDetails(session, state.Selected, width))
+	return b.String()
+}
+
+// graphLegend returns the status colors as a key.
+func graphLegend() string {
+	var parts []string
+	for _, s := range []TaskStatus{TaskPending, TaskRunning, TaskComplete, TaskFailed} {
+		parts = append(parts, s.style().Render(s.icon()+" "+s.String()))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// renderGraphDetails renders the selected task's title, status, and the
+// tasks it depends on and unblocks.
+func renderGraphDetails(session *orchestrator.UltraPlanSession, selected string, width int) string {
+	task := session.GetTask(selected)
+	if task == nil {
+		return styles.Muted.Render("Select a task with j/k.")
+	}
+	status := TaskStatusOf(session, task.ID)
+	head := status.style().Render(status.icon()+" "+task.ID) + "  " + task.Title +
+		styles.Muted.Render(fmt.Sprintf("  (%s, %s)", status, task.EstComplexity))
+
+	var unblocks []string
+	for _, t := range session.Plan.Tasks {
+		if slices.Contains(t.DependsOn, task.ID) {
+			unblocks = append(unblocks, t.ID)
+		}
+	}
+	deps := "Depends on: " + listOrNone(task.DependsOn) + "  ·  Unblocks: " + listOrNone(unblocks)
+	return ansi.Truncate(head, width, "…") + "\n" + styles.Muted.Render(ansi.Truncate(deps, width, "…"))
+}
+
+// listOrNone joins ids, or returns "none".
+func listOrNone(ids []string) string {
+	if len(ids) == 0 {
+		return "none"
+	}
+	return strings.Join(ids, ", ")
+}
+
+// graphBoxWidth returns the width of each box: as wide as fits the widest
+// group in width, within the bounds.
+func graphBoxWidth(plan *orchestrator.PlanSpec, width int) int {
+	widest := 1
+	for _, group := range plan.ExecutionOrder {
+		widest = max(widest, len(group))
+	}
+	return min(max((width+graphGap)/widest-graphGap, minGraphBoxWidth), maxGraphBoxWidth)
+}
+
+// layoutGraph places the plan's tasks in rows, one or more per execution
+// group, each row centered in width.
+func layoutGraph(plan *orchestrator.PlanSpec, session *orchestrator.UltraPlanSession, path []string, boxWidth, width int) (map[string]*graphNode, [][]*graphNode) {
+	perRow := max((width+graphGap)/(boxWidth+graphGap), 1)
+	byID := planTasks(plan)
+	nodes := make(map[string]*graphNode)
+	var rows [][]*graphNode
+	for _, group := range plan.ExecutionOrder {
+		var tasks []*orchestrator.PlannedTask
+		for _, id := range group {
+			if task := byID[id]; task != nil {
+				tasks = append(tasks, task)
+			}
+		}
+		for chunk := range slices.Chunk(tasks, perRow) {
+			used := len(chunk)*(boxWidth+graphGap) - graphGap
+			x := max((width-used)/2, 0)
+			var row []*graphNode
+			for _, task := range chunk {
+				n := &graphNode{
+					task:     task,
+					row:      len(rows),
+					x:        x,
+					status:   TaskStatusOf(session, task.ID),
+					critical: slices.Contains(path, task.ID),
+				}
+				nodes[task.ID] = n
+				row = append(row, n)
+				x += boxWidth + graphGap
+			}
+			rows = append(rows, row)
+		}
+	}
+	return nodes, rows
+}
+
+// renderGraphCanvas draws the graph and returns its lines and the row of
+// boxes the selected task is in.
+func renderGraphCanvas(plan *orchestrator.PlanSpec, session *orchestrator.UltraPlanSession, path []string, selected string, width int) ([]string, int) {
+	boxWidth := graphBoxWidth(plan, width)
+	nodes, rows := layoutGraph(plan, session, path, boxWidth, width)
+
+	c := newGraphCanvas(width, len(rows)*(graphBoxHeight+graphBandHeight)-graphBandHeight)
+	for _, n := range nodes {
+		c.drawBox(n, boxWidth, n.task.ID == selected)
+	}
+
+	// Edges between adjacent rows, critical ones last so they stay
+	// highlighted where they share a segment
+	critical := make(map[[2]string]bool)
+	for i := 1; i < len(path); i++ {
+		critical[[2]string{path[i-1], path[i]}] = true
+	}
+	for _, pass := range []bool{false, true} {
+		for _, id := range GraphOrder(plan) {
+			child := nodes[id]
+			for _, dep := range child.task.DependsOn {
+				parent, ok := nodes[dep]
+				if !ok || parent.row != child.row-1 || critical[[2]string{dep, id}] != pass {
+					continue
+				}
+				top := parent.row*(graphBoxHeight+graphBandHeight) + graphBoxHeight
+				c.drawEdge(top, parent.center(boxWidth), child.center(boxWidth), pass)
+			}
+		}
+	}
+
+	selectedRow := 0
+	if n, ok := nodes[selected]; ok {
+		selectedRow = n.row
+	}
+	return c.lines(), selectedRow
+}
+
+// Directions an edge leaves a canvas cell in, combined into box-drawing
+// characters.
+const (
+	edgeUp = 1 << iota
+	edgeDown
+	edgeLeft
+	edgeRight
+)
+
+// edgeRunes maps the directions of the edges through a cell to the
+// character drawn there.
+var edgeRunes = map[int]rune{
+	edgeUp | edgeDown:                        '│',
+	edgeLeft | edgeRight:                     '─',
+	edgeUp | edgeRight:                       '└',
+	edgeUp | edgeLeft:                        '┘',
+	edgeDown | edgeRight:                     '┌',
+	edgeDown | edgeLeft:                      '┐',
+	edgeUp | edgeDown | edgeRight:            '├',
+	edgeUp | edgeDown | edgeLeft:             '┤',
+	edgeLeft | edgeRight | edgeDown:          '┬',
+	edgeLeft | edgeRight | edgeUp:            '┴',
+	edgeUp | edgeDown | edgeLeft | edgeRight: '┼',
+}
+
+// graphCell is one column of a canvas line.
+type graphCell struct {
+	r     rune // 0 for the second column of a wide rune
+	edges int  // Directions of edges through the cell; drawn when r is ' '
+	style *lipgloss.Style
+}
+
+// graphCanvas is a grid of cells the graph is drawn on.
+type graphCanvas struct {
+	cells [][]graphCell
+}
+
+// newGraphCanvas creates a blank canvas.
+func newGraphCanvas(width, height int) *graphCanvas {
+	c := &graphCanvas{cells: make([][]graphCell, max(height, 0))}
+	for y := range c.cells {
+		c.cells[y] = make([]graphCell, width)
+		for x := range c.cells[y] {
+			c.cells[y][x].r = ' '
+		}
+	}
+	return c
+}
+
+// set draws r at (x, y), ignoring cells off the canvas.
+func (c *graphCanvas) set(x, y int, r rune, style *lipgloss.Style) {
+	if y < 0 || y >= len(c.cells) || x < 0 || x >= len(c.cells[y]) {
+		return
+	}
+	c.cells[y][x] = graphCell{r: r, style: style}
+}
+
+// text draws s from (x, y), at most maxWidth columns wide.
+func (c *graphCanvas) text(x, y int, s string, maxWidth int, style *lipgloss.Style) {
+	for _, r := range ansi.Truncate(s, maxWidth, "…") {
+		c.set(x, y, r, style)
+		if ansi.StringWidth(string(r)) == 2 {
+			x++
+			c.set(x, y, 0, style)
+		}
+		x++
+	}
+}
+
+// drawBox draws a task's box: its status icon and ID, then its title.
+func (c *graphCanvas) drawBox(n *graphNode, width int, selected bool) {
+	border := n.status.style()
+	corners := []rune("┌┐└┘─│")
+	if n.critical {
+		corners = []rune("╔╗╚╝═║")
+		border = border.Bold(true)
+	}
+	y := n.row * (graphBoxHeight + graphBandHeight)
+	right := n.x + width - 1
+
+	c.set(n.x, y, corners[0], &border)
+	c.set(right, y, corners[1], &border)
+	c.set(n.x, y+graphBoxHeight-1, corners[2], &border)
+	c.set(right, y+graphBoxHeight-1, corners[3], &border)
+	for x := n.x + 1; x < right; x++ {
+		c.set(x, y, corners[4], &border)
+		c.set(x, y+graphBoxHeight-1, corners[4], &border)
+	}
+
+	content := lipgloss.NewStyle()
+	if selected {
+		content = theme.Current().Selected()
+	}
+	inner := width - 2
+	for line := 1; line < graphBoxHeight-1; line++ {
+		c.set(n.x, y+line, corners[5], &border)
+		c.set(right, y+line, corners[5], &border)
+		for x := n.x + 1; x < right; x++ {
+			c.set(x, y+line, ' ', &content)
+		}
+	}
+	head := content
+	if !selected {
+		head = n.status.style()
+	}
+	c.text(n.x+1, y+1, " "+n.status.icon()+" "+n.task.ID, inner, &head)
+	c.text(n.x+1, y+2, " "+n.task.Title, inner, &content)
+}
+
+// drawEdge routes an edge through the band starting at line top: down from
+// the parent's column, across to the child's, and down into the child.
+func (c *graphCanvas) drawEdge(top, from, to int, critical bool) {
+	style := styles.Muted
+	if critical {
+		style = theme.Current().Accent().Bold(true)
+	}
+	mid := top + 1
+	c.addEdge(from, top, edgeUp|edgeDown, &style)
+	switch {
+	case from == to:
+		c.addEdge(from, mid, edgeUp|edgeDown, &style)
+	case from < to:
+		c.addEdge(from, mid, edgeUp|edgeRight, &style)
+		for x := from + 1; x < to; x++ {
+			c.addEdge(x, mid, edgeLeft|edgeRight, &style)
+		}
+		c.addEdge(to, mid, edgeLeft|edgeDown, &style)
+	default:
+		c.addEdge(from, mid, edgeUp|edgeLeft, &style)
+		for x := to + 1; x < from; x++ {
+			c.addEdge(x, mid, edgeLeft|edgeRight, &style)
+		}
+		c.addEdge(to, mid, edgeRight|edgeDown, &style)
+	}
+	c.set(to, top+2, '▼', &style)
+}
+
+// addEdge adds the directions of an edge through (x, y).
+func (c *graphCanvas) addEdge(x, y, edges int, style *lipgloss.Style) {
+	if y < 0 || y >= len(c.cells) || x < 0 || x >= len(c.cells[y]) {
+		return
+	}
+	cell := &c.cells[y][x]
+	cell.edges |= edges
+	cell.style = style
+}
+
+// lines renders the canvas, one string per line, styling runs of cells
+// that share a style together.
+func (c *graphCanvas) lines() []string {
+	out := make([]string, len(c.cells))
+	for y, row := range c.cells {
+		var b, run strings.Builder
+		var runStyle *lipgloss.Style
+		flush := func() {
+			if runStyle != nil {
+				b.WriteString(runStyle.Render(run.String()))
+			} else {
+				b.WriteString(run.String())
+			}
+			run.Reset()
+		}
+		for _, cell := range row {
+			if cell.r == 0 {
+				continue
+			}
+			r := cell.r
+			if r == ' ' && cell.edges != 0 {
+				r = edgeRunes[cell.edges]
+			}
+			if cell.style != runStyle {
+				flush()
+				runStyle = cell.style
+			}
+			run.WriteRune(r)
+		}
+		flush()
+		out[y] = strings.TrimRight(b.String(), " ")
+	}
+	return out
+}
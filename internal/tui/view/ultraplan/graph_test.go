@@ -0,0 +1,106 @@
+package ultraplan
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// graphTestSession returns a session running a diamond-shaped plan:
+// setup → (api, ui) → docs, where api is the long branch.
+func graphTestSession() *orchestrator.UltraPlanSession {
+	return &orchestrator.UltraPlanSession{
+		Plan: &orchestrator.PlanSpec{
+			Tasks: []orchestrator.PlannedTask{
+				{ID: "setup", Title: "Scaffold the module", EstComplexity: orchestrator.ComplexityLow},
+				{ID: "api", Title: "Add the API handlers", DependsOn: []string{"setup"}, EstComplexity: orchestrator.ComplexityHigh},
+				{ID: "ui", Title: "Add the settings page", DependsOn: []string{"setup"}, EstComplexity: orchestrator.ComplexityLow},
+				{ID: "docs", Title: "Document the endpoints", DependsOn: []string{"api", "ui", "setup"}, EstComplexity: orchestrator.ComplexityMedium},
+			},
+			ExecutionOrder: [][]string{{"setup"}, {"api", "ui"}, {"docs"}},
+		},
+		CompletedTasks: []string{"setup"},
+		FailedTasks:    []string{"ui"},
+		TaskToInstance: map[string]string{"api": "inst-2"},
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	plan := graphTestSession().Plan
+	if got := CriticalPath(plan); !slices.Equal(got, []string{"setup", "api", "docs"}) {
+		t.Errorf("CriticalPath() = %v, want setup → api → docs", got)
+	}
+	if got := GraphOrder(plan); !slices.Equal(got, []string{"setup", "api", "ui", "docs"}) {
+		t.Errorf("GraphOrder() = %v", got)
+	}
+	if got := CriticalPath(&orchestrator.PlanSpec{}); got != nil {
+		t.Errorf("CriticalPath() of an empty plan = %v, want nil", got)
+	}
+}
+
+func TestTaskStatusOf(t *testing.T) {
+	session := graphTestSession()
+	for id, want := range map[string]TaskStatus{"setup": TaskComplete, "api": TaskRunning, "ui": TaskFailed, "docs": TaskPending} {
+		if got := TaskStatusOf(session, id); got != want {
+			t.Errorf("TaskStatusOf(%q) = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestRenderGraph(t *testing.T) {
+	out := ansi.Strip(RenderGraph(GraphState{Session: graphTestSession(), Selected: "docs"}, 80, 40))
+	lines := strings.Split(out, "\n")
+
+	for _, want := range []string{
+		"critical path (weight 6): setup → api → docs",
+		"╔", "┌", // critical and other boxes
+		"✓ setup", "⟳ api", "✗ ui", "○ docs",
+		"┴", "▼", // edges fanning out of setup and into docs
+		"Depends on: api, ui, setup",
+		"Unblocks: none",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("RenderGraph() missing %q:\n%s", want, out)
+		}
+	}
+	for _, l := range lines {
+		if w := ansi.StringWidth(l); w > 80 {
+			t.Errorf("line %q is %d columns wide, want at most 80", l, w)
+		}
+	}
+
+	// A short view scrolls to the selected task
+	short := ansi.Strip(RenderGraph(GraphState{Session: graphTestSession(), Selected: "docs"}, 60, 12))
+	if !strings.Contains(short, "○ docs") || strings.Contains(short, "✓ setup") {
+		t.Errorf("RenderGraph() with little height does not show the selected task alone:\n%s", short)
+	}
+
+	if out := ansi.Strip(RenderGraph(GraphState{Session: &orchestrator.UltraPlanSession{}}, 60, 40)); !strings.Contains(out, "No plan yet") {
+		t.Errorf("RenderGraph() without a plan = %q", out)
+	}
+}
+
+func TestRenderGraph_WrapsWideGroups(t *testing.T) {
+	session := &orchestrator.UltraPlanSession{Plan: &orchestrator.PlanSpec{}}
+	var group []string
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		session.Plan.Tasks = append(session.Plan.Tasks, orchestrator.PlannedTask{ID: id, Title: "Task " + id})
+		group = append(group, id)
+	}
+	session.Plan.ExecutionOrder = [][]string{group}
+
+	// Three minimum-width boxes fit in 50 columns
+	out := ansi.Strip(RenderGraph(GraphState{Session: session, Selected: "a"}, 50, 40))
+	rows := 0
+	for l := range strings.SplitSeq(out, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(l), "╔") || strings.HasPrefix(strings.TrimSpace(l), "┌") {
+			rows++
+		}
+	}
+	if rows != 2 {
+		t.Errorf("five tasks in 50 columns drew %d rows of boxes, want 2:\n%s", rows, out)
+	}
+}
@@ -5,9 +5,15 @@ import (
 	"strings"
 
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/tui/input"
+	"github.com/Iron-Ham/claudio/internal/tui/keymap"
 	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/spf13/viper"
 )
 
+// defaultKeys are the bindings shown when the context has none.
+var defaultKeys = keymap.Default()
+
 // HelpRenderer handles rendering of the context-sensitive help bar.
 type HelpRenderer struct {
 	ctx *RenderContext
@@ -42,21 +48,30 @@ func (h *HelpRenderer) Render() string {
 	// Group navigation mode takes highest priority (when active)
 	if h.ctx.UltraPlan.GroupNavMode && session.Plan != nil {
 		badge := styles.ModeBadgeNormal.Render("GROUP NAV")
-		keys = append(keys, "[↑↓/jk] select group")
-		keys = append(keys, "[enter/space] toggle")
-		keys = append(keys, "[←→/hl] collapse/expand")
-		keys = append(keys, "[e] expand all")
-		keys = append(keys, "[c] collapse all")
-		keys = append(keys, "[g/esc] exit")
+		keys = h.appendKeys(keys, keymap.UltraPlanGroups, "select group", keymap.ScrollUp, keymap.ScrollDown)
+		keys = h.appendKey(keys, keymap.UltraPlanGroups, keymap.ToggleGroup, "toggle")
+		keys = h.appendKeys(keys, keymap.UltraPlanGroups, "collapse/expand", keymap.Left, keymap.Right)
+		keys = h.appendKey(keys, keymap.UltraPlanGroups, keymap.ExpandAll, "expand all")
+		keys = h.appendKey(keys, keymap.UltraPlanGroups, keymap.CollapseAll, "collapse all")
+		keys = h.appendKey(keys, keymap.UltraPlanGroups, keymap.Close, "exit")
 		return styles.HelpBar.Width(h.ctx.Width).Render(badge + "  " + strings.Join(keys, "  "))
 	}
 
 	// Group decision mode takes priority
 	if session.GroupDecision != nil && session.GroupDecision.AwaitingDecision {
 		badge := styles.ModeBadgeInput.Render("DECISION")
-		keys = append(keys, "[c] continue partial")
-		keys = append(keys, "[r] retry failed")
-		keys = append(keys, "[q] cancel")
+		keys = h.appendKey(keys, keymap.UltraPlanGate, keymap.ContinuePartial, "continue partial")
+		keys = h.appendKey(keys, keymap.UltraPlanGate, keymap.RetryFailed, "retry failed")
+		keys = h.appendKey(keys, keymap.UltraPlanGate, keymap.Cancel, "cancel")
+		keys = append(keys, "[↑↓] nav")
+		return styles.HelpBar.Width(h.ctx.Width).Render(badge + "  " + strings.Join(keys, "  "))
+	}
+
+	// Approval before the next group also takes priority
+	if gate := session.GroupApproval; gate != nil {
+		badge := styles.ModeBadgeInput.Render("APPROVAL")
+		keys = h.appendKey(keys, keymap.UltraPlanGate, keymap.ApproveGroup, fmt.Sprintf("approve → group %d", gate.GroupIndex+2))
+		keys = h.appendKey(keys, keymap.UltraPlanGate, keymap.Cancel, "cancel")
 		keys = append(keys, "[↑↓] nav")
 		return styles.HelpBar.Width(h.ctx.Width).Render(badge + "  " + strings.Join(keys, "  "))
 	}
@@ -65,71 +80,106 @@ func (h *HelpRenderer) Render() string {
 	keys = append(keys, "[:q] quit")
 	keys = append(keys, "[↑↓] nav")
 
+	inputModeKey := "[" + input.EnterKeyLabel(viper.GetBool("tui.require_input_modifier")) + "] input mode"
+
 	// Phase-specific keys
 	switch session.Phase {
 	case orchestrator.PhasePlanning:
-		keys = append(keys, "[p] parse plan")
-		keys = append(keys, "[i] input mode")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.ParsePlan, "parse plan")
+		keys = append(keys, inputModeKey)
 		keys = append(keys, "[:restart] restart step")
 
 	case orchestrator.PhasePlanSelection:
-		keys = append(keys, "[v] toggle plan view")
-		keys = append(keys, "[i] input mode")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.TogglePlan, "toggle plan view")
+		keys = append(keys, inputModeKey)
 		keys = append(keys, "[:restart] restart step")
 
 	case orchestrator.PhaseRefresh:
-		keys = append(keys, "[e] start execution")
-		keys = append(keys, "[E] edit plan")
-		keys = append(keys, "[g] group nav")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.Execute, "start execution")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.EditPlan, "edit plan")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.GroupPrefix, "group nav")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.ShowDeps, "deps")
 
 	case orchestrator.PhaseExecuting:
-		keys = append(keys, "[tab] next task")
-		keys = append(keys, "[g] group nav")
-		keys = append(keys, "[i] input mode")
-		keys = append(keys, "[v] toggle plan view")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.NextInstance, "next task")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.GroupPrefix, "group nav")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.ShowDeps, "deps")
+		keys = append(keys, inputModeKey)
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.TogglePlan, "toggle plan view")
 		keys = append(keys, "[:restart] restart task")
 		keys = append(keys, "[:cancel] cancel")
+		if len(session.TaskProposals) > 0 {
+			keys = h.appendKeys(keys, keymap.UltraPlan, "task proposal", keymap.Approve, keymap.Reject)
+		}
 
 	case orchestrator.PhaseSynthesis:
-		keys = append(keys, "[i] input mode")
-		keys = append(keys, "[v] toggle plan view")
-		keys = append(keys, "[g] group nav")
+		keys = append(keys, inputModeKey)
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.TogglePlan, "toggle plan view")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.GroupPrefix, "group nav")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.ShowDeps, "deps")
 		keys = append(keys, "[:restart] restart synthesis")
 		if session.SynthesisAwaitingApproval {
-			keys = append(keys, "[s] approve → proceed")
+			keys = h.appendKey(keys, keymap.UltraPlan, keymap.Consolidate, "approve → proceed")
 		} else {
-			keys = append(keys, "[s] skip → consolidate")
+			keys = h.appendKey(keys, keymap.UltraPlan, keymap.Consolidate, "skip → consolidate")
 		}
 
 	case orchestrator.PhaseRevision:
-		keys = append(keys, "[tab] next instance")
-		keys = append(keys, "[i] input mode")
-		keys = append(keys, "[v] toggle plan view")
-		keys = append(keys, "[g] group nav")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.NextInstance, "next instance")
+		keys = append(keys, inputModeKey)
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.TogglePlan, "toggle plan view")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.GroupPrefix, "group nav")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.ShowDeps, "deps")
 		keys = append(keys, "[:restart] restart revision")
 		if session.Revision != nil {
 			keys = append(keys, fmt.Sprintf("round %d/%d", session.Revision.RevisionRound, session.Revision.MaxRevisions))
 		}
 
 	case orchestrator.PhaseConsolidating:
-		keys = append(keys, "[i] input mode")
-		keys = append(keys, "[v] toggle plan view")
-		keys = append(keys, "[g] group nav")
+		keys = append(keys, inputModeKey)
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.TogglePlan, "toggle plan view")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.GroupPrefix, "group nav")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.ShowDeps, "deps")
 		keys = append(keys, "[:restart] restart consolidation")
 		if session.Consolidation != nil && session.Consolidation.Phase == orchestrator.ConsolidationPaused {
-			keys = append(keys, "[r] resume")
+			keys = h.appendKey(keys, keymap.UltraPlan, keymap.ReviewConflicts, "conflicts")
+			keys = h.appendKey(keys, keymap.UltraPlan, keymap.Resume, "resume")
 		}
 
 	case orchestrator.PhaseComplete, orchestrator.PhaseFailed:
-		keys = append(keys, "[v] view plan")
-		keys = append(keys, "[g] group nav")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.TogglePlan, "view plan")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.GroupPrefix, "group nav")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.ShowDeps, "deps")
 		if len(session.PRUrls) > 0 {
-			keys = append(keys, "[o] open PR")
+			keys = h.appendKey(keys, keymap.UltraPlan, keymap.OpenPR, "open PR")
 		}
-		keys = append(keys, "[R] re-trigger group")
+		keys = h.appendKey(keys, keymap.UltraPlan, keymap.Retrigger, "re-trigger group")
 	}
 
 	// Add mode badge for ultraplan mode
 	badge := styles.ModeBadgeNormal.Render("ULTRAPLAN")
 	return styles.HelpBar.Width(h.ctx.Width).Render(badge + "  " + strings.Join(keys, "  "))
 }
+
+// appendKey appends the help item of action in ctx to keys, labeled with
+// its keys as bound, or returns keys as is when the action is unbound.
+func (h *HelpRenderer) appendKey(keys []string, ctx keymap.Context, action keymap.Action, desc string) []string {
+	return h.appendKeys(keys, ctx, desc, action)
+}
+
+// appendKeys appends one help item for actions in ctx, labeled with all
+// their keys as bound. It returns keys as is when none is bound.
+func (h *HelpRenderer) appendKeys(keys []string, ctx keymap.Context, desc string, actions ...keymap.Action) []string {
+	km := h.ctx.Keys
+	if km == nil {
+		km = defaultKeys
+	}
+	var bound []string
+	for _, action := range actions {
+		bound = append(bound, km.Keys(ctx, action)...)
+	}
+	if len(bound) == 0 {
+		return keys
+	}
+	return append(keys, "["+keymap.Labels(bound)+"] "+desc)
+}
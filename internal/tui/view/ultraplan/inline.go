@@ -6,7 +6,7 @@ import (
 
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	"github.com/Iron-Ham/claudio/internal/tui/styles"
-	"github.com/charmbracelet/lipgloss"
+	"github.com/Iron-Ham/claudio/internal/tui/theme"
 )
 
 // InlineRenderer handles rendering of ultraplan content for inline display within a group.
@@ -47,7 +47,16 @@ func (i *InlineRenderer) Render(width int, maxLines int) string {
 	// ========== GROUP DECISION SECTION (if awaiting decision) ==========
 	if session.GroupDecision != nil && session.GroupDecision.AwaitingDecision {
 		b.WriteString(indent)
-		b.WriteString(lipgloss.NewStyle().Foreground(styles.YellowColor).Bold(true).Render("⚠ DECISION NEEDED"))
+		b.WriteString(theme.Current().Attention().Bold(true).Render("⚠ DECISION NEEDED"))
+		b.WriteString("\n")
+		lineCount++
+		if lineCount >= maxLines {
+			return b.String()
+		}
+	}
+	if session.GroupApproval != nil {
+		b.WriteString(indent)
+		b.WriteString(theme.Current().Attention().Bold(true).Render("⏸ APPROVAL NEEDED"))
 		b.WriteString("\n")
 		lineCount++
 		if lineCount >= maxLines {
@@ -187,10 +196,7 @@ func (i *InlineRenderer) Render(width int, maxLines int) string {
 			b.WriteString(indent)
 			if isGroupSelected {
 				// Highlight selected group
-				groupHeader = lipgloss.NewStyle().
-					Background(styles.PrimaryColor).
-					Foreground(styles.TextColor).
-					Render(groupHeader)
+				groupHeader = theme.Current().Selected().Render(groupHeader)
 				b.WriteString(groupHeader)
 			} else if !executionStarted {
 				b.WriteString(styles.Muted.Render(groupHeader))
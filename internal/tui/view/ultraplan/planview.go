@@ -3,10 +3,12 @@ package ultraplan
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/Iron-Ham/claudio/internal/estimate"
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	"github.com/Iron-Ham/claudio/internal/tui/styles"
-	"github.com/charmbracelet/lipgloss"
+	"github.com/Iron-Ham/claudio/internal/tui/theme"
 )
 
 // PlanViewRenderer handles rendering of the detailed plan view.
@@ -38,7 +40,7 @@ func (p *PlanViewRenderer) Render(width int) string {
 		b.WriteString("\n")
 
 		if session.SelectedPlanIndex == -1 {
-			mergedStyle := lipgloss.NewStyle().Foreground(styles.PurpleColor)
+			mergedStyle := theme.Current().Accent()
 			b.WriteString(mergedStyle.Render("⚡ Merged from multiple strategies"))
 			b.WriteString("\n")
 			strategyNames := orchestrator.GetMultiPassStrategyNames()
@@ -58,7 +60,7 @@ func (p *PlanViewRenderer) Render(width int) string {
 			if session.SelectedPlanIndex < len(strategyNames) {
 				strategyName = strategyNames[session.SelectedPlanIndex]
 			}
-			selectedStyle := lipgloss.NewStyle().Foreground(styles.GreenColor)
+			selectedStyle := theme.Current().Success()
 			b.WriteString(selectedStyle.Render(fmt.Sprintf("✓ Strategy: %s (selected)", strategyName)))
 			b.WriteString("\n")
 		}
@@ -91,22 +93,46 @@ func (p *PlanViewRenderer) Render(width int) string {
 		b.WriteString("\n")
 	}
 
-	// Tasks by execution order
+	// Parallel tasks predicted to conflict
+	if section := renderConflictSection(session.PlanConflicts); section != "" {
+		b.WriteString(section)
+		b.WriteString("\n")
+	}
+
+	// Tasks by execution order, with estimates from past sessions
+	estimator := p.ctx.UltraPlan.Coordinator.TaskEstimator()
+	var total estimate.Estimate
 	b.WriteString(styles.SidebarTitle.Render("Execution Order"))
 	b.WriteString("\n")
 	for groupIdx, group := range plan.ExecutionOrder {
-		b.WriteString(fmt.Sprintf("\nGroup %d (parallel):\n", groupIdx+1))
+		var lines strings.Builder
+		var groupEst estimate.Estimate
+		var durations []time.Duration
 		for _, taskID := range group {
 			task := session.GetTask(taskID)
 			if task != nil {
+				est := estimator.EstimateTask(task)
+				groupEst = groupEst.Add(est)
+				durations = append(durations, est.Duration)
 				complexity := ComplexityIndicator(task.EstComplexity)
-				b.WriteString(fmt.Sprintf("  [%s] %s %s\n", task.ID, complexity, task.Title))
+				lines.WriteString(fmt.Sprintf("  [%s] %s %s %s\n", task.ID, complexity, task.Title, styles.Muted.Render(est.String())))
 				if len(task.Files) > 0 {
-					b.WriteString(fmt.Sprintf("      Files: %s\n", strings.Join(task.Files, ", ")))
+					lines.WriteString(fmt.Sprintf("      Files: %s\n", strings.Join(task.Files, ", ")))
 				}
 			}
 		}
+		groupEst.Duration = estimate.GroupDuration(durations, session.Config.MaxParallel)
+		total = total.Add(groupEst)
+		b.WriteString(fmt.Sprintf("\nGroup %d (parallel, %s):\n", groupIdx+1, groupEst))
+		b.WriteString(lines.String())
 	}
+	basis := "default estimates"
+	if n := estimator.Samples(); n > 0 {
+		basis = fmt.Sprintf("from %d past tasks", n)
+	}
+	b.WriteString("\n")
+	b.WriteString(styles.Muted.Render(fmt.Sprintf("Estimated: %s (%s)", total, basis)))
+	b.WriteString("\n")
 
 	return styles.OutputArea.Width(width - 2).Render(b.String())
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/Iron-Ham/claudio/internal/tui/theme"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -48,6 +49,22 @@ func (s *SidebarRenderer) Render(width int, height int) string {
 		lineCount += 12
 	}
 
+	// ========== GROUP APPROVAL SECTION (if the next group awaits approval) ==========
+	if session.GroupApproval != nil {
+		approvalContent := s.renderGroupApprovalSection(session.GroupApproval, width-4)
+		b.WriteString(approvalContent)
+		b.WriteString("\n\n")
+		lineCount += strings.Count(approvalContent, "\n") + 2
+	}
+
+	// ========== TASK PROPOSALS SECTION (if instances proposed follow-up work) ==========
+	if session.Phase == orchestrator.PhaseExecuting && len(session.TaskProposals) > 0 {
+		proposalContent := s.renderTaskProposalsSection(session.TaskProposals, width-4)
+		b.WriteString(proposalContent)
+		b.WriteString("\n\n")
+		lineCount += strings.Count(proposalContent, "\n") + 2
+	}
+
 	// ========== PLANNING SECTION ==========
 	planningComplete := session.Phase != orchestrator.PhasePlanning && session.Phase != orchestrator.PhasePlanSelection
 	planningStatus := s.status.GetPhaseSectionStatus(orchestrator.PhasePlanning, session)
@@ -155,22 +172,20 @@ func (s *SidebarRenderer) Render(width int, height int) string {
 func (s *SidebarRenderer) renderGroupDecisionSection(decision *orchestrator.GroupDecisionState, maxWidth int) string {
 	var b strings.Builder
 
-	warningStyle := lipgloss.NewStyle().
-		Foreground(styles.YellowColor).
-		Bold(true)
+	warningStyle := theme.Current().Attention().Bold(true)
 	b.WriteString(warningStyle.Render("⚠ PARTIAL GROUP FAILURE"))
 	b.WriteString("\n\n")
 
 	b.WriteString(fmt.Sprintf("Group %d has mixed results:\n", decision.GroupIndex+1))
 
 	if len(decision.SucceededTasks) > 0 {
-		successStyle := lipgloss.NewStyle().Foreground(styles.GreenColor)
+		successStyle := theme.Current().Success()
 		b.WriteString(successStyle.Render(fmt.Sprintf("  ✓ %d task(s) succeeded", len(decision.SucceededTasks))))
 		b.WriteString("\n")
 	}
 
 	if len(decision.FailedTasks) > 0 {
-		failStyle := lipgloss.NewStyle().Foreground(styles.RedColor)
+		failStyle := theme.Current().Failure()
 		b.WriteString(failStyle.Render(fmt.Sprintf("  ✗ %d task(s) failed", len(decision.FailedTasks))))
 		b.WriteString("\n")
 
@@ -202,6 +217,93 @@ func (s *SidebarRenderer) renderGroupDecisionSection(decision *orchestrator.Grou
 	return b.String()
 }
 
+// maxApprovalFiles is how many changed files the group approval section
+// lists.
+const maxApprovalFiles = 5
+
+// renderGroupApprovalSection renders what a consolidated group changed while
+// the next group waits for approval.
+func (s *SidebarRenderer) renderGroupApprovalSection(gate *orchestrator.GroupApprovalState, maxWidth int) string {
+	var b strings.Builder
+
+	b.WriteString(theme.Current().Attention().Bold(true).Render(fmt.Sprintf("⏸ GROUP %d READY FOR REVIEW", gate.GroupIndex+1)))
+	b.WriteString("\n\n")
+
+	if gate.Branch != "" {
+		b.WriteString(styles.Muted.Render(truncate(gate.Branch, maxWidth)))
+		b.WriteString("\n")
+	}
+	b.WriteString(fmt.Sprintf("%d task(s), %d file(s) changed\n", len(gate.TasksConsolidated), len(gate.FilesChanged)))
+	for i, file := range gate.FilesChanged {
+		if i == maxApprovalFiles {
+			b.WriteString(styles.Muted.Render(fmt.Sprintf("    ... +%d more", len(gate.FilesChanged)-i)))
+			b.WriteString("\n")
+			break
+		}
+		b.WriteString(styles.Muted.Render("    " + truncate(file, maxWidth-4)))
+		b.WriteString("\n")
+	}
+
+	if gate.VerificationPassed {
+		b.WriteString(theme.Current().Success().Render("  ✓ Verification passed"))
+	} else {
+		b.WriteString(theme.Current().Failure().Render("  ✗ Verification failed"))
+	}
+	b.WriteString("\n")
+	if gate.Verification != "" {
+		b.WriteString(styles.Muted.Render("    " + truncate(gate.Verification, maxWidth-4)))
+		b.WriteString("\n")
+	}
+	if gate.Notes != "" {
+		b.WriteString(styles.Muted.Render("  " + truncate(gate.Notes, maxWidth-2)))
+		b.WriteString("\n")
+	}
+	for _, issue := range gate.IssuesForNextGroup {
+		b.WriteString(theme.Current().Attention().Render("  ! " + truncate(issue, maxWidth-4)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.SidebarTitle.Render("Choose action:"))
+	b.WriteString("\n")
+	b.WriteString(styles.Muted.Render(fmt.Sprintf("  [a] Approve and start group %d", gate.GroupIndex+2)))
+	b.WriteString("\n")
+	b.WriteString(styles.Muted.Render("  [q] Cancel ultraplan"))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// maxListedProposals is how many pending task proposals the sidebar lists.
+const maxListedProposals = 3
+
+// renderTaskProposalsSection renders follow-up tasks proposed by instances.
+// The [y]/[n] keys act on the oldest one, which is listed first.
+func (s *SidebarRenderer) renderTaskProposalsSection(proposals []*orchestrator.TaskProposal, maxWidth int) string {
+	var b strings.Builder
+
+	b.WriteString(theme.Current().Attention().Bold(true).Render(fmt.Sprintf("? TASK PROPOSALS (%d)", len(proposals))))
+	b.WriteString("\n\n")
+
+	for i, p := range proposals {
+		if i == maxListedProposals {
+			b.WriteString(styles.Muted.Render(fmt.Sprintf("  ... +%d more", len(proposals)-i)))
+			b.WriteString("\n")
+			break
+		}
+		b.WriteString("  " + truncate(p.Title, maxWidth-2))
+		b.WriteString("\n")
+		b.WriteString(styles.Muted.Render("    from " + truncate(p.From, maxWidth-9)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.Muted.Render("  [y] Approve first  [n] Reject first"))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
 // renderExecutionSection renders the execution phase section.
 func (s *SidebarRenderer) renderExecutionSection(b *strings.Builder, session *orchestrator.UltraPlanSession, width int, availableLines int) int {
 	lineCount := 0
@@ -250,10 +352,7 @@ func (s *SidebarRenderer) renderExecutionSection(b *strings.Builder, session *or
 		// Apply styling based on state
 		if isGroupSelected {
 			// Highlight selected group
-			groupHeader = lipgloss.NewStyle().
-				Background(styles.PrimaryColor).
-				Foreground(styles.TextColor).
-				Render(groupHeader)
+			groupHeader = theme.Current().Selected().Render(groupHeader)
 		} else if !executionStarted {
 			groupHeader = styles.Muted.Render(groupHeader)
 		}
@@ -509,13 +608,13 @@ func (s *SidebarRenderer) renderMultiPassPlanningSection(b *strings.Builder, ses
 				switch inst.Status {
 				case orchestrator.StatusWorking:
 					statusIcon = "⟳"
-					statusStyle = lipgloss.NewStyle().Foreground(styles.BlueColor)
+					statusStyle = theme.Current().Running()
 				case orchestrator.StatusCompleted:
 					statusIcon = "✓"
-					statusStyle = lipgloss.NewStyle().Foreground(styles.GreenColor)
+					statusStyle = theme.Current().Success()
 				case orchestrator.StatusError, orchestrator.StatusStuck, orchestrator.StatusTimeout:
 					statusIcon = "✗"
-					statusStyle = lipgloss.NewStyle().Foreground(styles.RedColor)
+					statusStyle = theme.Current().Failure()
 				case orchestrator.StatusPending:
 					statusIcon = "○"
 					statusStyle = styles.Muted
@@ -540,10 +639,7 @@ func (s *SidebarRenderer) renderMultiPassPlanningSection(b *strings.Builder, ses
 		strategyLine := fmt.Sprintf("  %s %s", statusStyle.Render(statusIcon), strategy)
 
 		if isSelected {
-			b.WriteString(lipgloss.NewStyle().
-				Background(styles.PrimaryColor).
-				Foreground(styles.TextColor).
-				Render(strategyLine))
+			b.WriteString(theme.Current().Selected().Render(strategyLine))
 		} else {
 			b.WriteString(strategyLine)
 		}
@@ -554,7 +650,7 @@ func (s *SidebarRenderer) renderMultiPassPlanningSection(b *strings.Builder, ses
 	if lineCount < availableLines {
 		plansCountLine := fmt.Sprintf("  %d/%d plans ready", plansReady, totalCoordinators)
 		if plansReady == totalCoordinators {
-			b.WriteString(lipgloss.NewStyle().Foreground(styles.GreenColor).Render(plansCountLine))
+			b.WriteString(theme.Current().Success().Render(plansCountLine))
 		} else {
 			b.WriteString(styles.Muted.Render(plansCountLine))
 		}
@@ -563,7 +659,7 @@ func (s *SidebarRenderer) renderMultiPassPlanningSection(b *strings.Builder, ses
 	}
 
 	if session.Phase == orchestrator.PhasePlanSelection && lineCount < availableLines {
-		b.WriteString(lipgloss.NewStyle().Foreground(styles.YellowColor).Bold(true).Render("  Manager comparing plans..."))
+		b.WriteString(theme.Current().Attention().Bold(true).Render("  Manager comparing plans..."))
 		b.WriteString("\n")
 		lineCount++
 
@@ -575,13 +671,13 @@ func (s *SidebarRenderer) renderMultiPassPlanningSection(b *strings.Builder, ses
 				switch inst.Status {
 				case orchestrator.StatusWorking:
 					managerIcon = "⟳"
-					managerStyle = lipgloss.NewStyle().Foreground(styles.BlueColor)
+					managerStyle = theme.Current().Running()
 				case orchestrator.StatusCompleted:
 					managerIcon = "✓"
-					managerStyle = lipgloss.NewStyle().Foreground(styles.GreenColor)
+					managerStyle = theme.Current().Success()
 				case orchestrator.StatusError:
 					managerIcon = "✗"
-					managerStyle = lipgloss.NewStyle().Foreground(styles.RedColor)
+					managerStyle = theme.Current().Failure()
 				default:
 					managerIcon = "○"
 					managerStyle = styles.Muted
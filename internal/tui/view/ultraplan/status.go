@@ -5,7 +5,7 @@ import (
 	"strings"
 
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
-	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/Iron-Ham/claudio/internal/tui/theme"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -65,23 +65,23 @@ func PhaseToString(phase orchestrator.UltraPlanPhase) string {
 func PhaseStyle(phase orchestrator.UltraPlanPhase) lipgloss.Style {
 	switch phase {
 	case orchestrator.PhasePlanning:
-		return lipgloss.NewStyle().Foreground(styles.BlueColor)
+		return theme.Current().Running()
 	case orchestrator.PhasePlanSelection:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Bold(true)
+		return theme.Current().Review().Bold(true)
 	case orchestrator.PhaseRefresh:
-		return lipgloss.NewStyle().Foreground(styles.YellowColor)
+		return theme.Current().Attention()
 	case orchestrator.PhaseExecuting:
-		return lipgloss.NewStyle().Foreground(styles.BlueColor).Bold(true)
+		return theme.Current().Running().Bold(true)
 	case orchestrator.PhaseSynthesis:
-		return lipgloss.NewStyle().Foreground(styles.PurpleColor)
+		return theme.Current().Accent()
 	case orchestrator.PhaseRevision:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Bold(true)
+		return theme.Current().Review().Bold(true)
 	case orchestrator.PhaseConsolidating:
-		return lipgloss.NewStyle().Foreground(styles.YellowColor).Bold(true)
+		return theme.Current().Attention().Bold(true)
 	case orchestrator.PhaseComplete:
-		return lipgloss.NewStyle().Foreground(styles.GreenColor)
+		return theme.Current().Success()
 	case orchestrator.PhaseFailed:
-		return lipgloss.NewStyle().Foreground(styles.RedColor)
+		return theme.Current().Failure()
 	default:
 		return lipgloss.NewStyle()
 	}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
 	"github.com/Iron-Ham/claudio/internal/tui/styles"
+	"github.com/Iron-Ham/claudio/internal/tui/theme"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -34,7 +35,7 @@ func (t *TaskRenderer) RenderExecutionTaskLine(session *orchestrator.UltraPlanSe
 	for _, ct := range session.CompletedTasks {
 		if ct == task.ID {
 			statusIcon = "✓"
-			statusStyle = lipgloss.NewStyle().Foreground(styles.GreenColor)
+			statusStyle = theme.Current().Success()
 			break
 		}
 	}
@@ -43,7 +44,7 @@ func (t *TaskRenderer) RenderExecutionTaskLine(session *orchestrator.UltraPlanSe
 		for _, ft := range session.FailedTasks {
 			if ft == task.ID {
 				statusIcon = "✗"
-				statusStyle = lipgloss.NewStyle().Foreground(styles.RedColor)
+				statusStyle = theme.Current().Failure()
 				break
 			}
 		}
@@ -51,7 +52,7 @@ func (t *TaskRenderer) RenderExecutionTaskLine(session *orchestrator.UltraPlanSe
 
 	if statusIcon == "" && instanceID != "" {
 		statusIcon = "⟳"
-		statusStyle = lipgloss.NewStyle().Foreground(styles.BlueColor)
+		statusStyle = theme.Current().Running()
 	}
 
 	if statusIcon == "" {
@@ -73,10 +74,7 @@ func (t *TaskRenderer) RenderExecutionTaskLine(session *orchestrator.UltraPlanSe
 	line := fmt.Sprintf("    %s %s", statusStyle.Render(statusIcon), title)
 
 	if selected {
-		line = lipgloss.NewStyle().
-			Background(styles.PrimaryColor).
-			Foreground(styles.TextColor).
-			Render(line)
+		line = theme.Current().Selected().Render(line)
 	} else if !navigable {
 		line = styles.Muted.Render(line)
 	}
@@ -92,15 +90,11 @@ func (t *TaskRenderer) renderWrappedTaskLine(title, statusIcon string, firstLine
 	// that would break the background color when selectedStyle wraps the line.
 	if firstLineLen <= 0 || maxWidth <= 6 {
 		line := fmt.Sprintf("    %s %s", statusIcon, truncate(title, 3))
-		selectedStyle := lipgloss.NewStyle().
-			Background(styles.PrimaryColor).
-			Foreground(styles.TextColor)
+		selectedStyle := theme.Current().Selected()
 		return ExecutionTaskResult{Content: selectedStyle.Render(line), LineCount: 1}
 	}
 
-	selectedStyle := lipgloss.NewStyle().
-		Background(styles.PrimaryColor).
-		Foreground(styles.TextColor)
+	selectedStyle := theme.Current().Selected()
 
 	remaining := []rune(title)
 	var lines []string
@@ -150,13 +144,13 @@ func (t *TaskRenderer) RenderPhaseInstanceLine(inst *orchestrator.Instance, name
 		switch inst.Status {
 		case orchestrator.StatusWorking:
 			statusIcon = "⟳"
-			statusStyle = lipgloss.NewStyle().Foreground(styles.BlueColor)
+			statusStyle = theme.Current().Running()
 		case orchestrator.StatusCompleted, orchestrator.StatusWaitingInput:
 			statusIcon = "✓"
-			statusStyle = lipgloss.NewStyle().Foreground(styles.GreenColor)
+			statusStyle = theme.Current().Success()
 		case orchestrator.StatusError, orchestrator.StatusStuck, orchestrator.StatusTimeout:
 			statusIcon = "✗"
-			statusStyle = lipgloss.NewStyle().Foreground(styles.RedColor)
+			statusStyle = theme.Current().Failure()
 		case orchestrator.StatusPending:
 			statusIcon = "○"
 			statusStyle = styles.Muted
@@ -169,10 +163,7 @@ func (t *TaskRenderer) RenderPhaseInstanceLine(inst *orchestrator.Instance, name
 	line := fmt.Sprintf("  %s %s", statusStyle.Render(statusIcon), name)
 
 	if selected {
-		line = lipgloss.NewStyle().
-			Background(styles.PrimaryColor).
-			Foreground(styles.TextColor).
-			Render(line)
+		line = theme.Current().Selected().Render(line)
 	} else if !navigable {
 		line = styles.Muted.Render(line)
 	}
@@ -192,13 +183,13 @@ func (t *TaskRenderer) RenderGroupConsolidatorLine(inst *orchestrator.Instance,
 		switch inst.Status {
 		case orchestrator.StatusCompleted:
 			statusIcon = "✓"
-			statusStyle = lipgloss.NewStyle().Foreground(styles.GreenColor)
+			statusStyle = theme.Current().Success()
 		case orchestrator.StatusError:
 			statusIcon = "✗"
-			statusStyle = lipgloss.NewStyle().Foreground(styles.RedColor)
+			statusStyle = theme.Current().Failure()
 		case orchestrator.StatusWorking, orchestrator.StatusWaitingInput:
 			statusIcon = "⟳"
-			statusStyle = lipgloss.NewStyle().Foreground(styles.BlueColor)
+			statusStyle = theme.Current().Running()
 		default:
 			statusIcon = "○"
 			statusStyle = styles.Muted
@@ -211,10 +202,7 @@ func (t *TaskRenderer) RenderGroupConsolidatorLine(inst *orchestrator.Instance,
 	line := fmt.Sprintf("    %s %s", statusStyle.Render(statusIcon), title)
 
 	if selected {
-		line = lipgloss.NewStyle().
-			Background(styles.PrimaryColor).
-			Foreground(styles.TextColor).
-			Render(line)
+		line = theme.Current().Selected().Render(line)
 	} else if !navigable {
 		line = styles.Muted.Render(line)
 	}
@@ -0,0 +1,307 @@
+package decomposition
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/drift"
+)
+
+// Level is how likely two parallel tasks are to conflict. Higher levels are
+// more likely.
+type Level int
+
+const (
+	// LevelNone means nothing links the two tasks.
+	LevelNone Level = iota
+	// LevelImport means a package one task changes imports a package the
+	// other changes.
+	LevelImport
+	// LevelPackage means both tasks change Go files in the same package.
+	LevelPackage
+	// LevelFile means both tasks change the same file.
+	LevelFile
+)
+
+// String returns the level's name.
+func (l Level) String() string {
+	switch l {
+	case LevelImport:
+		return "import"
+	case LevelPackage:
+		return "package"
+	case LevelFile:
+		return "file"
+	default:
+		return "none"
+	}
+}
+
+// Task is a plan task as far as conflict prediction is concerned.
+type Task struct {
+	ID        string
+	Repo      string   // Repository the task changes ("" = the primary repository)
+	Files     []string // Repository-relative files, directories, or globs the task expects to change
+	DependsOn []string
+}
+
+// Conflict is a pair of parallel tasks that may conflict.
+type Conflict struct {
+	// A and B are the task IDs, A earlier in plan order.
+	A     string `json:"a"`
+	B     string `json:"b"`
+	Level Level  `json:"level"`
+	// Files are the file entries the tasks share, for LevelFile.
+	Files []string `json:"files,omitempty"`
+	// Packages are the packages the tasks share, for LevelPackage, or the
+	// import edges between them ("a imports b"), for LevelImport.
+	Packages []string `json:"packages,omitempty"`
+}
+
+// Reason describes what links the two tasks.
+func (c Conflict) Reason() string {
+	switch c.Level {
+	case LevelFile:
+		return "both change " + strings.Join(c.Files, ", ")
+	case LevelPackage:
+		return "both change package " + strings.Join(c.Packages, ", ")
+	case LevelImport:
+		return strings.Join(c.Packages, ", ")
+	default:
+		return ""
+	}
+}
+
+// Dependency is a dependency added by Serialize: Task waits for DependsOn.
+type Dependency struct {
+	Task      string `json:"task"`
+	DependsOn string `json:"depends_on"`
+	Level     Level  `json:"level"`
+}
+
+// Report is the outcome of Predict.
+type Report struct {
+	// Tasks are the IDs of every task analyzed, in plan order.
+	Tasks []string `json:"tasks"`
+	// Conflicts are the pairs of parallel tasks that may conflict, most
+	// likely first, then in plan order.
+	Conflicts []Conflict `json:"conflicts,omitempty"`
+	// Serialized are the dependencies added to the plan to keep the most
+	// likely conflicts from running in parallel, if any were.
+	Serialized []Dependency `json:"serialized,omitempty"`
+}
+
+// Level returns the conflict level of tasks a and b, in either order.
+func (r *Report) Level(a, b string) Level {
+	if r == nil {
+		return LevelNone
+	}
+	for _, c := range r.Conflicts {
+		if (c.A == a && c.B == b) || (c.A == b && c.B == a) {
+			return c.Level
+		}
+	}
+	return LevelNone
+}
+
+// Involved returns the IDs of the tasks in any conflict, in plan order.
+func (r *Report) Involved() []string {
+	if r == nil {
+		return nil
+	}
+	var ids []string
+	for _, id := range r.Tasks {
+		if slices.ContainsFunc(r.Conflicts, func(c Conflict) bool { return c.A == id || c.B == id }) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Count returns how many conflicts are at level or above.
+func (r *Report) Count(level Level) int {
+	if r == nil {
+		return 0
+	}
+	n := 0
+	for _, c := range r.Conflicts {
+		if c.Level >= level {
+			n++
+		}
+	}
+	return n
+}
+
+// Predict rates every pair of tasks that can run in parallel by how likely
+// they are to conflict. tasks must be in plan order. Tasks in different
+// repositories never conflict. A nil graph skips import links.
+func Predict(tasks []Task, graph *ImportGraph) *Report {
+	report := &Report{}
+	deps := closure(tasks)
+	packages := make([][]string, len(tasks))
+	for i, t := range tasks {
+		report.Tasks = append(report.Tasks, t.ID)
+		packages[i] = goPackages(t.Files)
+	}
+
+	for i, a := range tasks {
+		for j := i + 1; j < len(tasks); j++ {
+			b := tasks[j]
+			if a.Repo != b.Repo || deps[a.ID][b.ID] || deps[b.ID][a.ID] {
+				continue
+			}
+			if c, ok := compare(a, b, packages[i], packages[j], graph); ok {
+				report.Conflicts = append(report.Conflicts, c)
+			}
+		}
+	}
+	slices.SortStableFunc(report.Conflicts, func(x, y Conflict) int {
+		return int(y.Level) - int(x.Level)
+	})
+	return report
+}
+
+// compare rates one pair of tasks, reporting false when nothing links them.
+func compare(a, b Task, pkgsA, pkgsB []string, graph *ImportGraph) (Conflict, bool) {
+	c := Conflict{A: a.ID, B: b.ID}
+	for _, fa := range a.Files {
+		for _, fb := range b.Files {
+			if overlaps(fa, fb) {
+				c.Files = appendNew(c.Files, shorter(fa, fb))
+			}
+		}
+	}
+	if len(c.Files) > 0 {
+		c.Level = LevelFile
+		return c, true
+	}
+
+	for _, p := range pkgsA {
+		if slices.Contains(pkgsB, p) {
+			c.Packages = append(c.Packages, p)
+		}
+	}
+	if len(c.Packages) > 0 {
+		c.Level = LevelPackage
+		return c, true
+	}
+
+	if a.Repo != "" {
+		// The import graph is of the primary repository
+		return c, false
+	}
+	for _, pa := range pkgsA {
+		for _, pb := range pkgsB {
+			if graph.Imports(pa, pb) {
+				c.Packages = append(c.Packages, fmt.Sprintf("%s imports %s", pa, pb))
+			}
+			if graph.Imports(pb, pa) {
+				c.Packages = append(c.Packages, fmt.Sprintf("%s imports %s", pb, pa))
+			}
+		}
+	}
+	if len(c.Packages) > 0 {
+		c.Level = LevelImport
+		return c, true
+	}
+	return c, false
+}
+
+// Serialize returns the dependencies that keep every pair of tasks
+// conflicting at level or above from running in parallel: the later
+// task in plan order waits for the earlier one. A pair already ordered by
+// an earlier added dependency gets none. tasks must be in an order where
+// every task follows its dependencies, such as the flattened execution
+// order, so the added dependencies cannot form a cycle.
+func Serialize(tasks []Task, report *Report, level Level) []Dependency {
+	if report == nil {
+		return nil
+	}
+	order := make(map[string]int, len(tasks))
+	for i, t := range tasks {
+		order[t.ID] = i
+	}
+	graph := make([]Task, len(tasks))
+	copy(graph, tasks)
+
+	var added []Dependency
+	for _, c := range report.Conflicts {
+		if c.Level < level {
+			continue
+		}
+		first, second := c.A, c.B
+		if order[second] < order[first] {
+			first, second = second, first
+		}
+		deps := closure(graph)
+		if deps[second][first] || deps[first][second] {
+			continue
+		}
+		i := order[second]
+		graph[i].DependsOn = append(slices.Clone(graph[i].DependsOn), first)
+		added = append(added, Dependency{Task: second, DependsOn: first, Level: c.Level})
+	}
+	return added
+}
+
+// closure returns, for each task, the set of tasks it depends on directly
+// or transitively.
+func closure(tasks []Task) map[string]map[string]bool {
+	direct := make(map[string][]string, len(tasks))
+	for _, t := range tasks {
+		direct[t.ID] = t.DependsOn
+	}
+	all := make(map[string]map[string]bool, len(tasks))
+	for _, t := range tasks {
+		seen := make(map[string]bool)
+		stack := slices.Clone(direct[t.ID])
+		for len(stack) > 0 {
+			id := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			stack = append(stack, direct[id]...)
+		}
+		all[t.ID] = seen
+	}
+	return all
+}
+
+// overlaps reports whether two task file entries can name the same file.
+func overlaps(a, b string) bool {
+	return drift.Matches(a, b) || drift.Matches(b, a)
+}
+
+// shorter returns the broader of two overlapping entries, which names what
+// they share.
+func shorter(a, b string) string {
+	if len(b) < len(a) {
+		return b
+	}
+	return a
+}
+
+// goPackages returns the directories of the Go files among entries.
+func goPackages(entries []string) []string {
+	var pkgs []string
+	for _, e := range entries {
+		e = strings.TrimPrefix(path.Clean(strings.TrimSpace(e)), "./")
+		if strings.HasSuffix(e, ".go") {
+			pkgs = appendNew(pkgs, path.Dir(e))
+		}
+	}
+	return pkgs
+}
+
+func appendNew(list []string, items ...string) []string {
+	for _, item := range items {
+		if !slices.Contains(list, item) {
+			list = append(list, item)
+		}
+	}
+	return list
+}
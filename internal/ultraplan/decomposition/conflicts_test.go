@@ -0,0 +1,93 @@
+package decomposition
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPredict(t *testing.T) {
+	graph := &ImportGraph{imports: map[string]map[string]bool{
+		"internal/api": {"internal/store": true},
+	}}
+	tasks := []Task{
+		{ID: "t1", Files: []string{"internal/api/handler.go", "README.md"}},
+		{ID: "t2", Files: []string{"internal/api/routes.go"}},
+		{ID: "t3", Files: []string{"internal/store/db.go"}},
+		{ID: "t4", Files: []string{"docs/"}},
+		{ID: "t5", Files: []string{"README.md", "docs/guide.md"}},
+		{ID: "t6", Files: []string{"internal/api/handler.go"}, DependsOn: []string{"t1"}},
+		{ID: "t7", Repo: "web", Files: []string{"README.md"}},
+	}
+
+	report := Predict(tasks, graph)
+
+	tests := []struct {
+		a, b string
+		want Level
+	}{
+		{"t1", "t5", LevelFile},
+		{"t4", "t5", LevelFile},
+		{"t1", "t2", LevelPackage},
+		{"t2", "t3", LevelImport},
+		{"t1", "t3", LevelImport},
+		{"t3", "t4", LevelNone},
+		{"t1", "t6", LevelNone}, // t6 depends on t1
+		{"t2", "t6", LevelPackage},
+		{"t1", "t7", LevelNone}, // other repository
+	}
+	for _, tt := range tests {
+		if got := report.Level(tt.a, tt.b); got != tt.want {
+			t.Errorf("Level(%s, %s) = %s, want %s", tt.a, tt.b, got, tt.want)
+		}
+		if got := report.Level(tt.b, tt.a); got != tt.want {
+			t.Errorf("Level(%s, %s) = %s, want %s", tt.b, tt.a, got, tt.want)
+		}
+	}
+
+	if first := report.Conflicts[0]; first.Level != LevelFile || first.A != "t1" || first.B != "t5" {
+		t.Errorf("first conflict = %+v, want t1/t5 on files", first)
+	}
+	if got := report.Count(LevelPackage); got != 4 {
+		t.Errorf("Count(LevelPackage) = %d, want 4", got)
+	}
+	if got := report.Involved(); !slices.Equal(got, []string{"t1", "t2", "t3", "t4", "t5", "t6"}) {
+		t.Errorf("Involved() = %v", got)
+	}
+}
+
+func TestPredict_SharedDirectoryNamesDirectory(t *testing.T) {
+	report := Predict([]Task{
+		{ID: "a", Files: []string{"docs/guide.md"}},
+		{ID: "b", Files: []string{"docs"}},
+	}, nil)
+	if len(report.Conflicts) != 1 || !slices.Equal(report.Conflicts[0].Files, []string{"docs"}) {
+		t.Errorf("conflicts = %+v, want one on docs", report.Conflicts)
+	}
+}
+
+func TestSerialize(t *testing.T) {
+	tasks := []Task{
+		{ID: "t1", Files: []string{"a.go"}},
+		{ID: "t2", Files: []string{"a.go", "b.go"}},
+		{ID: "t3", Files: []string{"b.go"}},
+		{ID: "t4", Files: []string{"c.go"}},
+	}
+	report := Predict(tasks, nil)
+
+	got := Serialize(tasks, report, LevelFile)
+	want := []Dependency{
+		{Task: "t2", DependsOn: "t1", Level: LevelFile},
+		{Task: "t3", DependsOn: "t2", Level: LevelFile},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Serialize() = %+v, want %+v", got, want)
+	}
+	// t1 and t3 only share the root package, which is below LevelFile, and
+	// t4 shares no file with anyone
+	if report.Level("t1", "t3") != LevelPackage {
+		t.Errorf("Level(t1, t3) = %s, want package", report.Level("t1", "t3"))
+	}
+	if tasks[1].DependsOn != nil {
+		t.Error("Serialize modified its input")
+	}
+}
@@ -0,0 +1,33 @@
+// Package decomposition predicts which tasks of a plan are likely to
+// conflict when they run in parallel, before execution starts.
+//
+// Tasks that run in the same execution group work in separate worktrees and
+// only meet at consolidation, where overlapping changes become merge
+// conflicts. [Predict] compares every pair of tasks that can run in parallel
+// (neither depends on the other, directly or transitively) and rates the
+// pair by the strongest signal it finds:
+//
+//   - [LevelFile]: both tasks list the same file, a file in a directory the
+//     other lists, or a file matching the other's glob.
+//   - [LevelPackage]: both tasks change Go files in the same package.
+//   - [LevelImport]: a package one task changes imports a package the other
+//     changes, per an [ImportGraph] of the repository.
+//
+// [Serialize] turns the conflicts at or above a level into dependencies, so
+// the later task in plan order waits for the earlier one.
+//
+// # Usage
+//
+//	graph, err := decomposition.LoadImportGraph(repoDir)
+//	if err != nil {
+//		graph = nil // predict from files and packages alone
+//	}
+//	report := decomposition.Predict(tasks, graph)
+//	for _, c := range report.Conflicts {
+//		log.Printf("%s and %s may conflict (%s)", c.A, c.B, c.Level)
+//	}
+//	deps := decomposition.Serialize(tasks, report, decomposition.LevelFile)
+//
+// The package works on its own [Task] type so both the orchestrator's plan
+// and the ultraplan package's can use it.
+package decomposition
@@ -0,0 +1,117 @@
+package decomposition
+
+import (
+	"bufio"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ImportGraph records which of a Go module's packages import which, by
+// repository-relative directory.
+type ImportGraph struct {
+	imports map[string]map[string]bool
+}
+
+// Imports reports whether the package in directory pkg imports the package
+// in directory dep. It is false for a nil graph.
+func (g *ImportGraph) Imports(pkg, dep string) bool {
+	if g == nil {
+		return false
+	}
+	return g.imports[pkg][dep]
+}
+
+// LoadImportGraph reads the imports of every Go file in the module rooted
+// at root, keeping those of the module's own packages. A repository without
+// a go.mod at its root yields an empty graph. Vendored, testdata, and hidden
+// directories are skipped, as are files that do not parse.
+func LoadImportGraph(root string) (*ImportGraph, error) {
+	g := &ImportGraph{imports: make(map[string]map[string]bool)}
+	module, err := modulePath(filepath.Join(root, "go.mod"))
+	if err != nil || module == "" {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return g, err
+	}
+
+	fset := token.NewFileSet()
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != root && skipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, p, nil, parser.ImportsOnly)
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(root, filepath.Dir(p))
+		if err != nil {
+			return nil
+		}
+		pkg := filepath.ToSlash(rel)
+		for _, spec := range file.Imports {
+			imp, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			if dep, ok := moduleDir(module, imp); ok && dep != pkg {
+				if g.imports[pkg] == nil {
+					g.imports[pkg] = make(map[string]bool)
+				}
+				g.imports[pkg][dep] = true
+			}
+		}
+		return nil
+	})
+	return g, err
+}
+
+// modulePath reads the module path declared in a go.mod file.
+func modulePath(goMod string) (string, error) {
+	f, err := os.Open(goMod)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`), nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+// moduleDir returns the repository-relative directory of an import path
+// inside module.
+func moduleDir(module, imp string) (string, bool) {
+	if imp == module {
+		return ".", true
+	}
+	if rest, ok := strings.CutPrefix(imp, module+"/"); ok {
+		return path.Clean(rest), true
+	}
+	return "", false
+}
+
+func skipDir(name string) bool {
+	return name == "vendor" || name == "testdata" || name == "node_modules" ||
+		strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
@@ -0,0 +1,66 @@
+package decomposition
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoadImportGraph(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod":                "module example.com/app\n\ngo 1.22\n",
+		"main.go":               "package main\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/app/internal/api\"\n)\n",
+		"internal/api/api.go":   "package api\n\nimport \"example.com/app/internal/store\"\n",
+		"internal/store/db.go":  "package store\n",
+		"internal/broken/x.go":  "package broken\n\nimport (\n",
+		"vendor/dep/dep.go":     "package dep\n\nimport \"example.com/app/internal/store\"\n",
+		"internal/api/api_test": "not go",
+	})
+
+	g, err := LoadImportGraph(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		pkg, dep string
+		want     bool
+	}{
+		{".", "internal/api", true},
+		{"internal/api", "internal/store", true},
+		{"internal/store", "internal/api", false},
+		{"vendor/dep", "internal/store", false},
+		{".", "fmt", false},
+	} {
+		if got := g.Imports(tt.pkg, tt.dep); got != tt.want {
+			t.Errorf("Imports(%q, %q) = %v, want %v", tt.pkg, tt.dep, got, tt.want)
+		}
+	}
+}
+
+func TestLoadImportGraph_NoModule(t *testing.T) {
+	g, err := LoadImportGraph(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Imports(".", "x") {
+		t.Error("empty graph reports an import")
+	}
+	var nilGraph *ImportGraph
+	if nilGraph.Imports("a", "b") {
+		t.Error("nil graph reports an import")
+	}
+}
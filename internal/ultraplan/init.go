@@ -2,9 +2,12 @@
 package ultraplan
 
 import (
+	"maps"
+
 	"github.com/Iron-Ham/claudio/internal/config"
 	"github.com/Iron-Ham/claudio/internal/logging"
 	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/verify"
 )
 
 // BuildConfigFromFile creates an UltraPlanConfig initialized from the application
@@ -29,11 +32,20 @@ func BuildConfigFromFile() orchestrator.UltraPlanConfig {
 //   - MultiPass: enable multi-pass planning
 //   - Adversarial: enable adversarial review mode per task
 //   - ConsolidationMode: "stacked" or "single" PR mode
+//   - MergeQueue: consolidate with the merge queue
+//   - ConflictResolver: hand cherry-pick conflicts to a resolver instance
 //   - CreateDraftPRs: create PRs as drafts
 //   - PRLabels: labels to add to created PRs
 //   - BranchPrefix: prefix for ultraplan branches
+//   - MaxBehindCommits: freshness threshold checked before opening PRs
+//   - AutoRebase: rebase stale consolidated branches before opening PRs
 //   - MaxTaskRetries: retry attempts for tasks with no commits
 //   - RequireVerifiedCommits: require tasks to produce commits
+//   - FreshVerification: bypass the verification results cache
+//   - Verify: commands run in each group's consolidated worktree, or detected
+//   - Models: the model for each phase and task complexity
+//   - GroupApproval: pause for approval between execution groups
+//   - SynthesisReviewers: parallel synthesis reviewers
 func BuildConfigFromAppConfig(cfg *config.Config) orchestrator.UltraPlanConfig {
 	ultraCfg := orchestrator.DefaultUltraPlanConfig()
 
@@ -50,6 +62,8 @@ func BuildConfigFromAppConfig(cfg *config.Config) orchestrator.UltraPlanConfig {
 		ultraCfg.ConsolidationMode = orchestrator.ConsolidationMode(cfg.Ultraplan.ConsolidationMode)
 	}
 
+	ultraCfg.MergeQueue = cfg.Ultraplan.MergeQueue
+	ultraCfg.ConflictResolver = cfg.Ultraplan.ConflictResolver
 	ultraCfg.CreateDraftPRs = cfg.Ultraplan.CreateDraftPRs
 
 	if len(cfg.Ultraplan.PRLabels) > 0 {
@@ -57,8 +71,29 @@ func BuildConfigFromAppConfig(cfg *config.Config) orchestrator.UltraPlanConfig {
 	}
 
 	ultraCfg.BranchPrefix = cfg.Ultraplan.BranchPrefix
+	ultraCfg.MaxBehindCommits = cfg.Ultraplan.MaxBehindCommits
+	ultraCfg.AutoRebase = cfg.Ultraplan.AutoRebase
 	ultraCfg.MaxTaskRetries = cfg.Ultraplan.MaxTaskRetries
 	ultraCfg.RequireVerifiedCommits = cfg.Ultraplan.RequireVerifiedCommits
+	ultraCfg.FreshVerification = cfg.Ultraplan.FreshVerification
+	ultraCfg.Verify = verify.GroupConfig{
+		AutoDetect:     cfg.Ultraplan.Verify.AutoDetect,
+		OnFailure:      cfg.Ultraplan.Verify.OnFailure,
+		TimeoutSeconds: cfg.Ultraplan.Verify.TimeoutSeconds,
+	}
+	for _, cmd := range cfg.Ultraplan.Verify.Commands {
+		ultraCfg.Verify.Steps = append(ultraCfg.Verify.Steps, verify.Step{Name: cmd.Name, Command: cmd.Command})
+	}
+	ultraCfg.Models = orchestrator.PhaseModels{
+		Planning:      cfg.Ultraplan.Models.Planning,
+		Execution:     cfg.Ultraplan.Models.Execution,
+		Synthesis:     cfg.Ultraplan.Models.Synthesis,
+		Revision:      cfg.Ultraplan.Models.Revision,
+		Consolidation: cfg.Ultraplan.Models.Consolidation,
+		ByComplexity:  maps.Clone(cfg.Ultraplan.Models.ByComplexity),
+	}
+	ultraCfg.GroupApproval = cfg.Ultraplan.GroupApproval
+	ultraCfg.SynthesisReviewers = cfg.Ultraplan.SynthesisReviewers
 
 	return ultraCfg
 }
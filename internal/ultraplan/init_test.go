@@ -138,6 +138,23 @@ func TestBuildConfigFromAppConfig(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "applies freshness settings from config",
+			cfg: &config.Config{
+				Ultraplan: config.UltraplanConfig{
+					MaxBehindCommits: 5,
+					AutoRebase:       true,
+				},
+			},
+			validate: func(t *testing.T, got orchestrator.UltraPlanConfig) {
+				if got.MaxBehindCommits != 5 {
+					t.Errorf("MaxBehindCommits = %d, want 5", got.MaxBehindCommits)
+				}
+				if !got.AutoRebase {
+					t.Error("AutoRebase = false, want true")
+				}
+			},
+		},
 		{
 			name: "applies MaxTaskRetries from config",
 			cfg: &config.Config{
@@ -164,6 +181,113 @@ func TestBuildConfigFromAppConfig(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "applies FreshVerification from config",
+			cfg: &config.Config{
+				Ultraplan: config.UltraplanConfig{
+					FreshVerification: true,
+				},
+			},
+			validate: func(t *testing.T, got orchestrator.UltraPlanConfig) {
+				if !got.FreshVerification {
+					t.Error("FreshVerification = false, want true")
+				}
+			},
+		},
+		{
+			name: "applies Models from config",
+			cfg: &config.Config{
+				Ultraplan: config.UltraplanConfig{
+					Models: config.ModelsConfig{
+						Planning:     "opus",
+						Execution:    "sonnet",
+						Synthesis:    "opus",
+						ByComplexity: map[string]string{"high": "opus"},
+					},
+				},
+			},
+			validate: func(t *testing.T, got orchestrator.UltraPlanConfig) {
+				if got.Models.Planning != "opus" || got.Models.Execution != "sonnet" || got.Models.Synthesis != "opus" {
+					t.Errorf("Models = %+v, want planning/synthesis opus and execution sonnet", got.Models)
+				}
+				if got.Models.ByComplexity["high"] != "opus" {
+					t.Errorf("Models.ByComplexity[high] = %q, want %q", got.Models.ByComplexity["high"], "opus")
+				}
+			},
+		},
+		{
+			name: "applies GroupApproval from config",
+			cfg: &config.Config{
+				Ultraplan: config.UltraplanConfig{
+					GroupApproval: true,
+				},
+			},
+			validate: func(t *testing.T, got orchestrator.UltraPlanConfig) {
+				if !got.GroupApproval {
+					t.Error("GroupApproval = false, want true")
+				}
+			},
+		},
+		{
+			name: "applies SynthesisReviewers from config",
+			cfg: &config.Config{
+				Ultraplan: config.UltraplanConfig{
+					SynthesisReviewers: 3,
+				},
+			},
+			validate: func(t *testing.T, got orchestrator.UltraPlanConfig) {
+				if got.SynthesisReviewers != 3 {
+					t.Errorf("SynthesisReviewers = %d, want 3", got.SynthesisReviewers)
+				}
+			},
+		},
+		{
+			name: "applies MergeQueue from config",
+			cfg: &config.Config{
+				Ultraplan: config.UltraplanConfig{
+					MergeQueue: true,
+				},
+			},
+			validate: func(t *testing.T, got orchestrator.UltraPlanConfig) {
+				if !got.MergeQueue {
+					t.Error("MergeQueue = false, want true")
+				}
+			},
+		},
+		{
+			name: "applies ConflictResolver from config",
+			cfg: &config.Config{
+				Ultraplan: config.UltraplanConfig{
+					ConflictResolver: true,
+				},
+			},
+			validate: func(t *testing.T, got orchestrator.UltraPlanConfig) {
+				if !got.ConflictResolver {
+					t.Error("ConflictResolver = false, want true")
+				}
+			},
+		},
+		{
+			name: "applies Verify from config",
+			cfg: &config.Config{
+				Ultraplan: config.UltraplanConfig{
+					Verify: config.GroupVerifyConfig{
+						Commands:       []config.VerifyCommandConfig{{Name: "test", Command: "go test ./..."}},
+						AutoDetect:     true,
+						OnFailure:      "pause",
+						TimeoutSeconds: 300,
+					},
+				},
+			},
+			validate: func(t *testing.T, got orchestrator.UltraPlanConfig) {
+				if len(got.Verify.Steps) != 1 || got.Verify.Steps[0].Name != "test" || got.Verify.Steps[0].Command != "go test ./..." {
+					t.Errorf("Verify.Steps = %+v, want the configured command", got.Verify.Steps)
+				}
+				if !got.Verify.AutoDetect || !got.Verify.PauseOnFailure() || got.Verify.TimeoutSeconds != 300 {
+					t.Errorf("Verify = %+v, want detection and pause on failure with a 300s timeout", got.Verify)
+				}
+			},
+		},
 		{
 			name: "applies Adversarial from config",
 			cfg: &config.Config{
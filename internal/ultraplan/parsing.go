@@ -30,10 +30,11 @@ func ParsePlanFromOutput(output string, objective string) (*PlanSpec, error) {
 
 	// Parse the JSON
 	var rawPlan struct {
-		Summary     string        `json:"summary"`
-		Tasks       []PlannedTask `json:"tasks"`
-		Insights    []string      `json:"insights"`
-		Constraints []string      `json:"constraints"`
+		Summary     string            `json:"summary"`
+		Tasks       []PlannedTask     `json:"tasks"`
+		Insights    []string          `json:"insights"`
+		Constraints []string          `json:"constraints"`
+		Env         map[string]string `json:"env"`
 	}
 
 	if err := json.Unmarshal([]byte(jsonStr), &rawPlan); err != nil {
@@ -46,15 +47,16 @@ func ParsePlanFromOutput(output string, objective string) (*PlanSpec, error) {
 
 	// Build the PlanSpec
 	plan := &PlanSpec{
-		ID:              generateID(),
 		Objective:       objective,
 		Summary:         rawPlan.Summary,
 		Tasks:           rawPlan.Tasks,
 		Insights:        rawPlan.Insights,
 		Constraints:     rawPlan.Constraints,
+		Env:             rawPlan.Env,
 		DependencyGraph: make(map[string][]string),
 		CreatedAt:       time.Now(),
 	}
+	plan.ID = plan.ContentHash()
 
 	// Build dependency graph
 	for _, task := range plan.Tasks {
@@ -95,10 +97,11 @@ func ParsePlanFromFile(filePath string, objective string) (*PlanSpec, error) {
 	}
 
 	type planContent struct {
-		Summary     string         `json:"summary"`
-		Tasks       []flexibleTask `json:"tasks"`
-		Insights    []string       `json:"insights"`
-		Constraints []string       `json:"constraints"`
+		Summary     string            `json:"summary"`
+		Tasks       []flexibleTask    `json:"tasks"`
+		Insights    []string          `json:"insights"`
+		Constraints []string          `json:"constraints"`
+		Env         map[string]string `json:"env"`
 	}
 
 	// Try parsing as root-level format first
@@ -149,15 +152,16 @@ func ParsePlanFromFile(filePath string, objective string) (*PlanSpec, error) {
 
 	// Build the PlanSpec
 	plan := &PlanSpec{
-		ID:              generateID(),
 		Objective:       objective,
 		Summary:         rawPlan.Summary,
 		Tasks:           tasks,
 		Insights:        rawPlan.Insights,
 		Constraints:     rawPlan.Constraints,
+		Env:             rawPlan.Env,
 		DependencyGraph: make(map[string][]string),
 		CreatedAt:       time.Now(),
 	}
+	plan.ID = plan.ContentHash()
 
 	// Build dependency graph
 	for _, task := range plan.Tasks {
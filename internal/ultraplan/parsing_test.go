@@ -66,6 +66,22 @@ Done!`
 	}
 }
 
+func TestParsePlanFromOutput_DeterministicID(t *testing.T) {
+	output := `<plan>{"tasks":[{"id":"task-1","title":"One","description":"Do one","depends_on":[]}]}</plan>`
+
+	first, err := ParsePlanFromOutput(output, "objective")
+	if err != nil {
+		t.Fatalf("ParsePlanFromOutput() error = %v", err)
+	}
+	second, err := ParsePlanFromOutput(output, "other objective")
+	if err != nil {
+		t.Fatalf("ParsePlanFromOutput() error = %v", err)
+	}
+	if first.ID != second.ID || first.ID != first.ContentHash() {
+		t.Errorf("plan IDs = %q, %q; want both equal to ContentHash() %q", first.ID, second.ID, first.ContentHash())
+	}
+}
+
 func TestParsePlanFromOutput_NoPlanTag(t *testing.T) {
 	output := "No plan here, just some text"
 
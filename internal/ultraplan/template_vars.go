@@ -0,0 +1,233 @@
+package ultraplan
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// VariableType is the type of an objective template variable, which decides
+// the values it accepts.
+type VariableType string
+
+// Template variable types.
+const (
+	VariableString VariableType = "string" // Any text
+	VariableInt    VariableType = "int"    // A whole number
+	VariableBool   VariableType = "bool"   // true or false
+	VariablePath   VariableType = "path"   // A relative path inside the repository
+	VariableChoice VariableType = "choice" // One of the variable's options
+)
+
+// TemplateVariable is a typed value an objective template is filled in with.
+// A variable without a default is required.
+type TemplateVariable struct {
+	Name        string       `yaml:"name"`        // Used as {{name}} and given as --var name=value
+	Type        VariableType `yaml:"type"`        // Empty means string
+	Description string       `yaml:"description"` // Shown in listings and missing-value errors
+	Default     string       `yaml:"default"`     // Used when no value is given
+	Options     []string     `yaml:"options"`     // Allowed values of a choice variable
+}
+
+var (
+	// templateNamePattern matches a template name usable as a CLI argument
+	templateNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	// variableNamePattern matches a variable name
+	variableNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	// placeholderPattern matches a {{name}} placeholder, spaces allowed
+	placeholderPattern = regexp.MustCompile(`\{\{\s*([a-z][a-z0-9_]*)\s*\}\}`)
+	// assignmentPattern matches the start of a name=value variable argument
+	assignmentPattern = regexp.MustCompile(`^([a-z][a-z0-9_]*)=`)
+)
+
+// validate reports a bad name, type, or default.
+func (v TemplateVariable) validate() error {
+	if !variableNamePattern.MatchString(v.Name) {
+		return fmt.Errorf("variable name %q must start with a lowercase letter and contain only lowercase letters, digits, and underscores", v.Name)
+	}
+	switch v.Type {
+	case "", VariableString, VariableInt, VariableBool, VariablePath:
+	case VariableChoice:
+		if len(v.Options) == 0 {
+			return fmt.Errorf("choice variable %s has no options", v.Name)
+		}
+	default:
+		return fmt.Errorf("variable %s has unknown type %q", v.Name, v.Type)
+	}
+	if v.Default != "" {
+		if _, err := v.Check(v.Default); err != nil {
+			return fmt.Errorf("default of %w", err)
+		}
+	}
+	return nil
+}
+
+// Check returns value in its canonical form for the variable's type, or an
+// error when the type doesn't accept it. Paths are cleaned and must stay
+// inside the repository.
+func (v TemplateVariable) Check(value string) (string, error) {
+	switch v.Type {
+	case VariableInt:
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return "", fmt.Errorf("variable %s must be a whole number, got %q", v.Name, value)
+		}
+		return strconv.Itoa(n), nil
+	case VariableBool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return "", fmt.Errorf("variable %s must be true or false, got %q", v.Name, value)
+		}
+		return strconv.FormatBool(b), nil
+	case VariablePath:
+		if value == "" || filepath.IsAbs(value) {
+			return "", fmt.Errorf("variable %s must be a path relative to the repository, got %q", v.Name, value)
+		}
+		clean := filepath.Clean(value)
+		if clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+			return "", fmt.Errorf("variable %s must be a path inside the repository, got %q", v.Name, value)
+		}
+		return filepath.ToSlash(clean), nil
+	case VariableChoice:
+		if !slices.Contains(v.Options, value) {
+			return "", fmt.Errorf("variable %s must be one of %s, got %q", v.Name, strings.Join(v.Options, ", "), value)
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
+
+// Usage describes the variable for listings, e.g. "target=<int> (default 80)".
+func (v TemplateVariable) Usage() string {
+	kind := string(v.Type)
+	switch {
+	case v.Type == VariableChoice:
+		kind = strings.Join(v.Options, "|")
+	case kind == "":
+		kind = string(VariableString)
+	}
+	usage := fmt.Sprintf("%s=<%s>", v.Name, kind)
+	if v.Default != "" {
+		return usage + fmt.Sprintf(" (default %s)", v.Default)
+	}
+	return usage + " (required)"
+}
+
+// ResolveValues checks values against the template's variables and returns
+// the value of every variable, defaults filled in. The error names any value
+// for an unknown variable, then every required variable without a value.
+func (t ObjectiveTemplate) ResolveValues(values map[string]string) (map[string]string, error) {
+	for name := range values {
+		if !slices.ContainsFunc(t.Variables, func(v TemplateVariable) bool { return v.Name == name }) {
+			names := make([]string, len(t.Variables))
+			for i, v := range t.Variables {
+				names[i] = v.Name
+			}
+			if len(names) == 0 {
+				return nil, fmt.Errorf("template %s takes no variables, got %s", t.Name, name)
+			}
+			return nil, fmt.Errorf("template %s has no variable %s (variables: %s)", t.Name, name, strings.Join(names, ", "))
+		}
+	}
+
+	resolved := make(map[string]string, len(t.Variables))
+	var missing []string
+	for _, v := range t.Variables {
+		value, ok := values[v.Name]
+		if !ok || value == "" {
+			value = v.Default
+		}
+		if value == "" {
+			missing = append(missing, fmt.Sprintf("%s (%s)", v.Name, v.Description))
+			continue
+		}
+		checked, err := v.Check(value)
+		if err != nil {
+			return nil, err
+		}
+		resolved[v.Name] = checked
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("template %s needs a value for %s", t.Name, strings.Join(missing, ", "))
+	}
+	return resolved, nil
+}
+
+// VariableSkeleton returns the template's variables as name=value arguments,
+// defaults filled in, for the user to complete (e.g. "package= target=80 ").
+func (t ObjectiveTemplate) VariableSkeleton() string {
+	var sb strings.Builder
+	for _, v := range t.Variables {
+		sb.WriteString(v.Name + "=" + quoteVariableValue(v.Default) + " ")
+	}
+	return sb.String()
+}
+
+// quoteVariableValue quotes value when ParseVariableArgs would otherwise
+// split it.
+func quoteVariableValue(value string) string {
+	if strings.ContainsAny(value, " \t\"") {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// ParseVariableArgs splits the name=value arguments at the start of text from
+// the objective after them. A value with spaces is double-quoted, e.g.
+// `to="new lib" also update the docs`.
+func ParseVariableArgs(text string) (map[string]string, string, error) {
+	values := make(map[string]string)
+	rest := strings.TrimSpace(text)
+	for {
+		m := assignmentPattern.FindStringSubmatch(rest)
+		if m == nil {
+			return values, rest, nil
+		}
+		name := m[1]
+		rest = rest[len(m[0]):]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return nil, "", fmt.Errorf("unterminated quoted value for %s", name)
+			}
+			value, _ = strconv.Unquote(quoted)
+			rest = rest[len(quoted):]
+		} else {
+			end := strings.IndexAny(rest, " \t\n")
+			if end < 0 {
+				end = len(rest)
+			}
+			value, rest = rest[:end], rest[end:]
+		}
+		values[name] = value
+		rest = strings.TrimSpace(rest)
+	}
+}
+
+// fillPlaceholders replaces each {{name}} placeholder in text with its value.
+func fillPlaceholders(text string, values map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(p string) string {
+		if value, ok := values[placeholderPattern.FindStringSubmatch(p)[1]]; ok {
+			return value
+		}
+		return p
+	})
+}
+
+// fillAll returns texts with their placeholders filled in.
+func fillAll(texts []string, values map[string]string) []string {
+	if len(texts) == 0 {
+		return texts
+	}
+	out := make([]string, len(texts))
+	for i, text := range texts {
+		out[i] = fillPlaceholders(text, values)
+	}
+	return out
+}
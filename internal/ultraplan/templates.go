@@ -0,0 +1,428 @@
+package ultraplan
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"gopkg.in/yaml.v3"
+)
+
+// ObjectiveTemplate is a reusable shape for an ultraplan objective. It wraps
+// the user's objective with a prefix, constraints the plan must respect,
+// verification requirements the work must meet, and planning hints, and may
+// pick the consolidation mode that suits the kind of change. A template with
+// a goal expands to a full objective on its own, filled in with the values of
+// its typed variables.
+type ObjectiveTemplate struct {
+	Name              string             // Selects the template (e.g. "upgrade")
+	Description       string             // One-line summary shown in listings and the TUI picker
+	Prefix            string             // Placed before the user's objective
+	Goal              string             // Objective text placed before the user's objective
+	Constraints       []string           // Constraints added to the objective
+	Verification      []string           // Checks that must pass before the work is complete
+	Hints             []string           // Planning hints added to the objective
+	Variables         []TemplateVariable // Values the {{name}} placeholders are filled in with
+	ConsolidationMode string             // "stacked" or "single"; empty keeps the configured mode
+	Source            string             // "built-in", "config", or the file the template was read from
+}
+
+// templateFile is the YAML form of an objective template file, with the keys
+// of a template in config.yaml.
+type templateFile struct {
+	Name              string             `yaml:"name"`
+	Description       string             `yaml:"description"`
+	Prefix            string             `yaml:"prefix"`
+	Goal              string             `yaml:"goal"`
+	Constraints       []string           `yaml:"constraints"`
+	Verification      []string           `yaml:"verification"`
+	Hints             []string           `yaml:"hints"`
+	Variables         []TemplateVariable `yaml:"variables"`
+	ConsolidationMode string             `yaml:"consolidation_mode"`
+}
+
+// BuiltinTemplates returns the objective templates that ship with claudio.
+func BuiltinTemplates() []ObjectiveTemplate {
+	templates := builtinTemplates()
+	for i := range templates {
+		templates[i].Source = "built-in"
+	}
+	return templates
+}
+
+func builtinTemplates() []ObjectiveTemplate {
+	return []ObjectiveTemplate{
+		{
+			Name:        "feature",
+			Description: "Add a feature with tests and docs",
+			Prefix:      "Feature: ",
+			Constraints: []string{
+				"Follow the architecture and conventions of the surrounding code",
+				"Keep existing public APIs backward compatible unless the objective says otherwise",
+			},
+			Verification: []string{
+				"Tests cover the new behavior, including error paths",
+				"User-facing documentation and the changelog describe the feature",
+				"The build, linter, and full test suite pass",
+			},
+			ConsolidationMode: string(orchestrator.ModeStackedPRs),
+		},
+		{
+			Name:        "rename",
+			Description: "Large mechanical rename across the codebase",
+			Prefix:      "Rename: ",
+			Constraints: []string{
+				"Change names only; do not change behavior",
+				"Split tasks by directory so no two tasks edit the same file",
+				"Update references in comments, documentation, configuration, and tests",
+			},
+			Verification: []string{
+				"A search of the whole repository finds no remaining references to the old name",
+				"The build and full test suite pass",
+			},
+			ConsolidationMode: string(orchestrator.ModeSinglePR),
+		},
+		{
+			Name:        "upgrade",
+			Description: "Dependency upgrade with call-site fixes",
+			Prefix:      "Upgrade: ",
+			Constraints: []string{
+				"Upgrade only the named dependency; leave unrelated dependencies at their current versions",
+				"Adapt call sites to the breaking changes listed in the dependency's release notes",
+				"Regenerate lock files with the project's package manager, never by hand",
+			},
+			Verification: []string{
+				"The build and full test suite pass with the new version",
+				"No deprecation warnings introduced by the upgrade remain",
+			},
+			ConsolidationMode: string(orchestrator.ModeSinglePR),
+		},
+		{
+			Name:        "coverage",
+			Description: "Add test coverage for a package",
+			Goal:        "Add test coverage for the {{package}} package until at least {{target}}% of its statements are covered",
+			Constraints: []string{
+				"Change only tests and test helpers, unless a bug blocks a test; report such bugs instead of working around them",
+				"Do not delete, skip, or weaken existing tests",
+			},
+			Verification: []string{
+				"Statement coverage of {{package}} is at least {{target}}%",
+				"The full test suite passes",
+			},
+			Hints: []string{
+				"Split tasks by source file in {{package}} so each task writes its own test file",
+				"Cover exported behavior and error paths before internal helpers",
+			},
+			Variables: []TemplateVariable{
+				{Name: "package", Type: VariablePath, Description: "Package directory to cover"},
+				{Name: "target", Type: VariableInt, Description: "Coverage goal in percent", Default: "80"},
+			},
+			ConsolidationMode: string(orchestrator.ModeSinglePR),
+		},
+		{
+			Name:        "migrate",
+			Description: "Migrate from one library to another",
+			Goal:        "Migrate from {{from}} to {{to}}",
+			Constraints: []string{
+				"Keep behavior unchanged; the migration must not change what users see",
+				"Remove {{from}} from the dependency manifest once nothing uses it",
+			},
+			Verification: []string{
+				"A search of the whole repository finds no remaining use of {{from}}",
+				"The build and full test suite pass",
+			},
+			Hints: []string{
+				"Start with one task that adds {{to}} and any shared adapter the call sites need",
+				"Split call-site changes by package or directory so no two tasks edit the same file",
+			},
+			Variables: []TemplateVariable{
+				{Name: "from", Type: VariableString, Description: "Library being replaced"},
+				{Name: "to", Type: VariableString, Description: "Library replacing it"},
+			},
+			ConsolidationMode: string(orchestrator.ModeSinglePR),
+		},
+	}
+}
+
+// Templates returns the built-in templates followed by the templates defined
+// in cfg. A configured template with a built-in's name replaces it in place.
+func Templates(cfg *config.Config) []ObjectiveTemplate {
+	templates := BuiltinTemplates()
+	if cfg == nil {
+		return templates
+	}
+
+	for _, tc := range cfg.Ultraplan.Templates {
+		t := ObjectiveTemplate{
+			Name:              tc.Name,
+			Description:       tc.Description,
+			Prefix:            tc.Prefix,
+			Goal:              tc.Goal,
+			Constraints:       tc.Constraints,
+			Verification:      tc.Verification,
+			Hints:             tc.Hints,
+			ConsolidationMode: tc.ConsolidationMode,
+			Source:            "config",
+		}
+		for _, vc := range tc.Variables {
+			t.Variables = append(t.Variables, TemplateVariable{
+				Name:        vc.Name,
+				Type:        VariableType(vc.Type),
+				Description: vc.Description,
+				Default:     vc.Default,
+				Options:     vc.Options,
+			})
+		}
+		templates = mergeTemplate(templates, t)
+	}
+	return templates
+}
+
+// mergeTemplate replaces the template named like t in place, or appends t.
+func mergeTemplate(templates []ObjectiveTemplate, t ObjectiveTemplate) []ObjectiveTemplate {
+	for i := range templates {
+		if templates[i].Name == t.Name {
+			templates[i] = t
+			return templates
+		}
+	}
+	return append(templates, t)
+}
+
+// TemplateDirs returns the directories objective template files are read
+// from, in order: templates in the user's config directory, then
+// .claudio/templates in the repository at baseDir.
+func TemplateDirs(baseDir string) []string {
+	dirs := []string{filepath.Join(config.ConfigDir(), "templates")}
+	if baseDir != "" {
+		dirs = append(dirs, filepath.Join(baseDir, ".claudio", "templates"))
+	}
+	return dirs
+}
+
+// LoadTemplates returns the templates of Templates followed by those read
+// from the template directories of the repository at baseDir, where a
+// template replaces an earlier one with its name. Templates that can't be read
+// or are invalid are left out and returned as errors.
+func LoadTemplates(cfg *config.Config, baseDir string) ([]ObjectiveTemplate, []error) {
+	var errs []error
+	var templates []ObjectiveTemplate
+	for _, t := range Templates(cfg) {
+		if err := t.Validate(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		templates = append(templates, t)
+	}
+
+	for _, dir := range TemplateDirs(baseDir) {
+		files, dirErrs := LoadTemplateDir(dir)
+		errs = append(errs, dirErrs...)
+		for _, t := range files {
+			templates = mergeTemplate(templates, t)
+		}
+	}
+	return templates, errs
+}
+
+// LoadTemplateDir reads each .yaml or .yml file in dir as an objective
+// template, named after the file unless it sets a name. A missing directory
+// holds no templates. Files that can't be read or hold an invalid template
+// are skipped and returned as errors.
+func LoadTemplateDir(dir string) ([]ObjectiveTemplate, []error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, []error{fmt.Errorf("reading templates directory: %w", err)}
+	}
+
+	var templates []ObjectiveTemplate
+	var errs []error
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		t, err := loadTemplateFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("template %s: %w", path, err))
+			continue
+		}
+		templates = append(templates, t)
+	}
+	return templates, errs
+}
+
+// loadTemplateFile reads and validates one objective template file.
+func loadTemplateFile(path string) (ObjectiveTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ObjectiveTemplate{}, err
+	}
+	var f templateFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return ObjectiveTemplate{}, err
+	}
+	if f.Name == "" {
+		f.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	t := ObjectiveTemplate{
+		Name:              f.Name,
+		Description:       f.Description,
+		Prefix:            f.Prefix,
+		Goal:              f.Goal,
+		Constraints:       f.Constraints,
+		Verification:      f.Verification,
+		Hints:             f.Hints,
+		Variables:         f.Variables,
+		ConsolidationMode: f.ConsolidationMode,
+		Source:            path,
+	}
+	if err := t.Validate(); err != nil {
+		return ObjectiveTemplate{}, err
+	}
+	return t, nil
+}
+
+// FindTemplate returns the template with the given name. The error lists the
+// available names when there is no such template.
+func FindTemplate(templates []ObjectiveTemplate, name string) (ObjectiveTemplate, error) {
+	names := make([]string, len(templates))
+	for i, t := range templates {
+		if t.Name == name {
+			return t, nil
+		}
+		names[i] = t.Name
+	}
+	return ObjectiveTemplate{}, fmt.Errorf("unknown objective template %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// Validate reports the first problem with the template: a bad name or
+// consolidation mode, an invalid variable or default, or a placeholder naming
+// no variable.
+func (t ObjectiveTemplate) Validate() error {
+	if !templateNamePattern.MatchString(t.Name) {
+		return fmt.Errorf("template name %q must contain only lowercase letters, digits, and hyphens", t.Name)
+	}
+	if t.ConsolidationMode != "" && t.ConsolidationMode != string(orchestrator.ModeStackedPRs) && t.ConsolidationMode != string(orchestrator.ModeSinglePR) {
+		return fmt.Errorf("template %s: consolidation mode %q must be 'stacked' or 'single'", t.Name, t.ConsolidationMode)
+	}
+
+	seen := make(map[string]bool, len(t.Variables))
+	for _, v := range t.Variables {
+		if err := v.validate(); err != nil {
+			return fmt.Errorf("template %s: %w", t.Name, err)
+		}
+		if seen[v.Name] {
+			return fmt.Errorf("template %s: variable %s is declared twice", t.Name, v.Name)
+		}
+		seen[v.Name] = true
+	}
+	for _, name := range t.placeholders() {
+		if !seen[name] {
+			return fmt.Errorf("template %s: placeholder {{%s}} names no variable", t.Name, name)
+		}
+	}
+	return nil
+}
+
+// texts returns every text of the template that may hold placeholders.
+func (t ObjectiveTemplate) texts() []string {
+	texts := []string{t.Prefix, t.Goal}
+	texts = append(texts, t.Constraints...)
+	texts = append(texts, t.Verification...)
+	return append(texts, t.Hints...)
+}
+
+// placeholders returns the variable names used in the template's
+// placeholders, each once.
+func (t ObjectiveTemplate) placeholders() []string {
+	var names []string
+	for _, text := range t.texts() {
+		for _, m := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+			if !slices.Contains(names, m[1]) {
+				names = append(names, m[1])
+			}
+		}
+	}
+	return names
+}
+
+// HasVariables reports whether the template expands on its own and takes
+// variable values: it has a goal or variables.
+func (t ObjectiveTemplate) HasVariables() bool {
+	return t.Goal != "" || len(t.Variables) > 0
+}
+
+// Suffix returns the constraints, verification requirements, and planning
+// hints as text to append to an objective, or "" when the template has none.
+func (t ObjectiveTemplate) Suffix() string {
+	var sb strings.Builder
+	writeSection(&sb, "Constraints:", t.Constraints)
+	writeSection(&sb, "Verification requirements:", t.Verification)
+	writeSection(&sb, "Planning hints:", t.Hints)
+	return sb.String()
+}
+
+// writeSection appends a titled list to sb, or nothing for an empty list.
+func writeSection(sb *strings.Builder, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	sb.WriteString("\n\n" + title)
+	for _, item := range items {
+		sb.WriteString("\n- " + item)
+	}
+}
+
+// Objective returns objective wrapped with the template's prefix and suffix.
+// Templates with variables are expanded with Expand instead.
+func (t ObjectiveTemplate) Objective(objective string) string {
+	return t.Prefix + strings.TrimSpace(objective) + t.Suffix()
+}
+
+// Expand returns the full objective the template makes of objective and the
+// variable values: the prefix, the goal followed by objective, and the
+// suffix, with each {{name}} placeholder replaced by its variable's value.
+// Values are checked against their variable's type, and variables without a
+// value take their default.
+func (t ObjectiveTemplate) Expand(objective string, values map[string]string) (string, error) {
+	resolved, err := t.ResolveValues(values)
+	if err != nil {
+		return "", err
+	}
+
+	body := fillPlaceholders(t.Goal, resolved)
+	if objective = strings.TrimSpace(objective); objective != "" {
+		if body != "" {
+			body += "\n\n"
+		}
+		body += objective
+	}
+	if body == "" {
+		return "", fmt.Errorf("template %s needs an objective", t.Name)
+	}
+
+	expanded := t
+	expanded.Prefix = fillPlaceholders(t.Prefix, resolved)
+	expanded.Constraints = fillAll(t.Constraints, resolved)
+	expanded.Verification = fillAll(t.Verification, resolved)
+	expanded.Hints = fillAll(t.Hints, resolved)
+	return expanded.Prefix + body + expanded.Suffix(), nil
+}
+
+// Apply applies the template's settings to cfg.
+func (t ObjectiveTemplate) Apply(cfg *orchestrator.UltraPlanConfig) {
+	if t.ConsolidationMode != "" {
+		cfg.ConsolidationMode = orchestrator.ConsolidationMode(t.ConsolidationMode)
+	}
+}
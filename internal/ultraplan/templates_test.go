@@ -0,0 +1,226 @@
+package ultraplan
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/config"
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+)
+
+func TestTemplates_MergesConfig(t *testing.T) {
+	cfg := config.Default()
+	cfg.Ultraplan.Templates = []config.ObjectiveTemplateConfig{
+		{Name: "upgrade", Description: "Team upgrade", Prefix: "Bump: "},
+		{Name: "migration", Description: "Schema migration"},
+	}
+
+	templates := Templates(cfg)
+	builtins := BuiltinTemplates()
+	if len(templates) != len(builtins)+1 {
+		t.Fatalf("Templates() returned %d templates, want %d", len(templates), len(builtins)+1)
+	}
+
+	upgrade, err := FindTemplate(templates, "upgrade")
+	if err != nil {
+		t.Fatalf("FindTemplate(upgrade) error = %v", err)
+	}
+	if upgrade.Prefix != "Bump: " {
+		t.Errorf("configured upgrade template Prefix = %q, want override", upgrade.Prefix)
+	}
+	if templates[len(templates)-1].Name != "migration" {
+		t.Errorf("last template = %q, want new template appended", templates[len(templates)-1].Name)
+	}
+
+	if _, err := FindTemplate(templates, "nope"); err == nil || !strings.Contains(err.Error(), "migration") {
+		t.Errorf("FindTemplate(nope) error = %v, want list of available templates", err)
+	}
+}
+
+func TestObjectiveTemplate_Objective(t *testing.T) {
+	tmpl := ObjectiveTemplate{
+		Prefix:       "Upgrade: ",
+		Constraints:  []string{"Only this dependency"},
+		Verification: []string{"Tests pass"},
+	}
+
+	got := tmpl.Objective("  lodash to v5 ")
+	want := "Upgrade: lodash to v5\n\nConstraints:\n- Only this dependency\n\nVerification requirements:\n- Tests pass"
+	if got != want {
+		t.Errorf("Objective() = %q, want %q", got, want)
+	}
+
+	if got := (ObjectiveTemplate{}).Objective("x"); got != "x" {
+		t.Errorf("empty template Objective() = %q, want unchanged objective", got)
+	}
+}
+
+func TestObjectiveTemplate_Apply(t *testing.T) {
+	cfg := orchestrator.DefaultUltraPlanConfig()
+
+	ObjectiveTemplate{}.Apply(&cfg)
+	if cfg.ConsolidationMode != orchestrator.ModeStackedPRs {
+		t.Errorf("ConsolidationMode = %q, want configured mode kept", cfg.ConsolidationMode)
+	}
+
+	ObjectiveTemplate{ConsolidationMode: "single"}.Apply(&cfg)
+	if cfg.ConsolidationMode != orchestrator.ModeSinglePR {
+		t.Errorf("ConsolidationMode = %q, want %q", cfg.ConsolidationMode, orchestrator.ModeSinglePR)
+	}
+}
+
+func TestBuiltinTemplates_Valid(t *testing.T) {
+	for _, tmpl := range BuiltinTemplates() {
+		if err := tmpl.Validate(); err != nil {
+			t.Errorf("built-in template %s: %v", tmpl.Name, err)
+		}
+	}
+}
+
+func TestObjectiveTemplate_Expand(t *testing.T) {
+	coverage, err := FindTemplate(BuiltinTemplates(), "coverage")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := coverage.Expand("Focus on token refresh", map[string]string{"package": "./internal/auth/"})
+	if err != nil {
+		t.Fatalf("Expand() error = %v", err)
+	}
+	for _, want := range []string{
+		"Add test coverage for the internal/auth package until at least 80% of its statements are covered\n\nFocus on token refresh",
+		"- Statement coverage of internal/auth is at least 80%",
+		"\n\nPlanning hints:\n- Split tasks by source file in internal/auth",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expand() missing %q:\n%s", want, got)
+		}
+	}
+
+	for _, tt := range []struct {
+		values  map[string]string
+		wantErr string
+	}{
+		{map[string]string{}, "needs a value for package (Package directory to cover)"},
+		{map[string]string{"package": "x", "target": "high"}, "must be a whole number"},
+		{map[string]string{"package": "../elsewhere"}, "inside the repository"},
+		{map[string]string{"package": "x", "pkg": "y"}, "has no variable pkg (variables: package, target)"},
+	} {
+		if _, err := coverage.Expand("", tt.values); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Expand(%v) error = %v, want %q", tt.values, err, tt.wantErr)
+		}
+	}
+
+	if _, err := (ObjectiveTemplate{Name: "feature"}).Expand(" ", nil); err == nil {
+		t.Error("Expand() of a template without a goal accepted an empty objective")
+	}
+}
+
+func TestObjectiveTemplate_Validate(t *testing.T) {
+	for _, tmpl := range []ObjectiveTemplate{
+		{Name: "Bad Name"},
+		{Name: "x", Goal: "Cover {{pkg}}"},
+		{Name: "x", Variables: []TemplateVariable{{Name: "n", Type: VariableInt, Default: "many"}}},
+		{Name: "x", Variables: []TemplateVariable{{Name: "level", Type: VariableChoice}}},
+		{Name: "x", Variables: []TemplateVariable{{Name: "n"}, {Name: "n"}}},
+	} {
+		if err := tmpl.Validate(); err == nil {
+			t.Errorf("Validate(%+v) accepted an invalid template", tmpl)
+		}
+	}
+}
+
+func TestTemplateVariable_Check(t *testing.T) {
+	for _, tt := range []struct {
+		v     TemplateVariable
+		value string
+		want  string
+	}{
+		{TemplateVariable{Type: VariableInt}, " 07", "7"},
+		{TemplateVariable{Type: VariableBool}, "yes", ""},
+		{TemplateVariable{Type: VariableBool}, "T", "true"},
+		{TemplateVariable{Type: VariablePath}, "a/../b/", "b"},
+		{TemplateVariable{Type: VariablePath}, "/etc", ""},
+		{TemplateVariable{Type: VariableChoice, Options: []string{"unit", "e2e"}}, "e2e", "e2e"},
+		{TemplateVariable{Type: VariableChoice, Options: []string{"unit", "e2e"}}, "smoke", ""},
+		{TemplateVariable{}, "any text", "any text"},
+	} {
+		got, err := tt.v.Check(tt.value)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("Check(%s, %q) = %q, want an error", tt.v.Type, tt.value, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Check(%s, %q) = %q, %v; want %q", tt.v.Type, tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseVariableArgs(t *testing.T) {
+	values, rest, err := ParseVariableArgs(`  from=moment to="date fns" also=  Update the docs too`)
+	if err != nil {
+		t.Fatalf("ParseVariableArgs() error = %v", err)
+	}
+	if values["from"] != "moment" || values["to"] != "date fns" || values["also"] != "" || len(values) != 3 {
+		t.Errorf("values = %v", values)
+	}
+	if rest != "Update the docs too" {
+		t.Errorf("rest = %q", rest)
+	}
+
+	if _, _, err := ParseVariableArgs(`to="unterminated`); err == nil {
+		t.Error("ParseVariableArgs() accepted an unterminated quote")
+	}
+
+	tmpl := ObjectiveTemplate{Variables: []TemplateVariable{{Name: "to", Default: "date fns"}, {Name: "from"}}}
+	if got := tmpl.VariableSkeleton(); got != `to="date fns" from= ` {
+		t.Errorf("VariableSkeleton() = %q", got)
+	}
+}
+
+func TestLoadTemplates_Dirs(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	repo := t.TempDir()
+	userDir, repoDir := TemplateDirs(repo)[0], TemplateDirs(repo)[1]
+	for dir, files := range map[string]map[string]string{
+		userDir: {
+			"flags.yaml": "description: Remove a feature flag\ngoal: Remove the {{flag}} feature flag\nvariables:\n  - name: flag\n",
+			"notes.txt":  "ignored",
+		},
+		repoDir: {
+			"flags.yml":  "description: Project flags\ngoal: Remove {{flag}} everywhere\nvariables:\n  - name: flag\n",
+			"broken.yml": "goal: Uses {{missing}}\n",
+		},
+	} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		for name, data := range files {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	templates, errs := LoadTemplates(config.Default(), repo)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "broken.yml") {
+		t.Errorf("errs = %v, want the broken template", errs)
+	}
+	flags, err := FindTemplate(templates, "flags")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flags.Description != "Project flags" || flags.Source != filepath.Join(repoDir, "flags.yml") {
+		t.Errorf("flags = %+v, want the repository's template over the user's", flags)
+	}
+	if got, err := flags.Expand("", map[string]string{"flag": "new_ui"}); err != nil || got != "Remove new_ui everywhere" {
+		t.Errorf("Expand() = %q, %v", got, err)
+	}
+	if len(templates) != len(BuiltinTemplates())+1 {
+		t.Errorf("LoadTemplates() returned %d templates, want the built-ins and flags", len(templates))
+	}
+}
@@ -15,7 +15,13 @@
 // designed to be used by the orchestrator and other packages.
 package ultraplan
 
-import "time"
+import (
+	"time"
+
+	"github.com/Iron-Ham/claudio/internal/ai"
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
+)
 
 // -----------------------------------------------------------------------------
 // Plan Phase Enums
@@ -176,6 +182,15 @@ type PlannedTask struct {
 	// High complexity tasks may benefit from being split.
 	EstComplexity TaskComplexity `json:"est_complexity"`
 
+	// TimeoutPolicy optionally names the instance.timeout_policies entry
+	// that sets this task's timeouts and escalation. Empty selects a policy
+	// by EstComplexity.
+	TimeoutPolicy string `json:"timeout_policy,omitempty"`
+
+	// Model optionally names the model this task runs on. Empty selects the
+	// model configured for its EstComplexity, else the execution model.
+	Model string `json:"model,omitempty"`
+
 	// IssueURL optionally links to an external issue tracker URL.
 	// Supports GitHub Issues, Linear, Notion, and other trackers.
 	// When set, the issue will be auto-closed upon task completion.
@@ -190,6 +205,36 @@ type PlannedTask struct {
 	// When true, the task will be considered successful even if it produces no commits.
 	// Use this for verification, testing, or documentation-only tasks.
 	NoCode bool `json:"no_code,omitempty"`
+
+	// Repo optionally names the repository this task runs in for multi-repo
+	// pipelines (e.g., "backend", "frontend"). Empty means the session's
+	// primary repository. Tasks in different repos never share a team, and
+	// cross-repo DependsOn edges become team-level dependencies.
+	Repo string `json:"repo,omitempty"`
+
+	// Contracts lists repo-relative paths of artifacts this task produces for
+	// consumers in other repositories (e.g., "api/openapi.yaml"). On success
+	// their contents are forwarded to dependent teams as contract messages.
+	Contracts []string `json:"contracts,omitempty"`
+
+	// Backend optionally selects the executor for this task (e.g., "tool").
+	// Empty means the session's default AI backend. The "tool" backend runs
+	// Command instead of an LLM, for mechanical work such as codemods.
+	Backend string `json:"backend,omitempty"`
+
+	// Command is the shell command run in the task's worktree when Backend is
+	// "tool". Its changes are committed with the task title as the message.
+	Command string `json:"command,omitempty"`
+
+	// Criteria are machine-checkable conditions (files that must exist,
+	// symbols that must be defined, tests that must pass) the verifier checks
+	// before accepting the task, on top of the instance's own report.
+	Criteria *types.CompletionCriteria `json:"criteria,omitempty"`
+
+	// ContextPack lists file excerpts, interface definitions, and docs the
+	// task needs. They are copied into a file in the task's worktree before
+	// it starts, and the prompt points at it.
+	ContextPack *types.ContextPack `json:"context_pack,omitempty"`
 }
 
 // HasDependencies returns true if this task depends on other tasks.
@@ -197,6 +242,12 @@ func (t *PlannedTask) HasDependencies() bool {
 	return len(t.DependsOn) > 0
 }
 
+// IsDeterministic returns true if this task runs a scripted command rather
+// than an LLM, so re-running it after a failure would fail the same way.
+func (t *PlannedTask) IsDeterministic() bool {
+	return ai.IsDeterministic(t.Backend)
+}
+
 // HasFiles returns true if this task has expected files specified.
 func (t *PlannedTask) HasFiles() bool {
 	return len(t.Files) > 0
@@ -216,7 +267,7 @@ func (t *PlannedTask) HasFiles() bool {
 // and represent the same relationship in different formats for different uses.
 type PlanSpec struct {
 	// ID uniquely identifies this plan.
-	// Generated automatically during plan creation.
+	// Parsed plans use their ContentHash, so the same plan keeps the same ID.
 	ID string `json:"id"`
 
 	// Objective is the original user request that spawned this plan.
@@ -250,6 +301,11 @@ type PlanSpec struct {
 	// Used to inform task execution and synthesis review.
 	Constraints []string `json:"constraints"`
 
+	// Env holds plan-wide variables, such as a feature flag name or target
+	// API version. Each is exported into every task instance's environment
+	// and listed in its prompt.
+	Env map[string]string `json:"env,omitempty"`
+
 	// CreatedAt is the timestamp when this plan was created.
 	CreatedAt time.Time `json:"created_at"`
 }
@@ -274,6 +330,27 @@ func (p *PlanSpec) GetTask(taskID string) *PlannedTask {
 	return nil
 }
 
+// ContentHash returns a short, stable hash of the plan's tasks. It delegates
+// to the orchestrator's PlanSpec.ContentHash, so a plan's ID and task branch
+// names survive conversion between the two types.
+func (p *PlanSpec) ContentHash() string {
+	tasks := make([]orchestrator.PlannedTask, len(p.Tasks))
+	for i, t := range p.Tasks {
+		tasks[i] = orchestrator.PlannedTask{
+			ID:          t.ID,
+			Title:       t.Title,
+			Description: t.Description,
+			Files:       t.Files,
+			DependsOn:   t.DependsOn,
+			NoCode:      t.NoCode,
+			Repo:        t.Repo,
+			Backend:     t.Backend,
+			Command:     t.Command,
+		}
+	}
+	return (&orchestrator.PlanSpec{Tasks: tasks}).ContentHash()
+}
+
 // -----------------------------------------------------------------------------
 // Validation Types
 // -----------------------------------------------------------------------------
@@ -2,7 +2,12 @@ package ultraplan
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
+
+	"github.com/Iron-Ham/claudio/internal/ai"
+	"github.com/Iron-Ham/claudio/internal/orchestrator"
 )
 
 // ValidatePlan performs comprehensive validation of a PlanSpec.
@@ -47,6 +52,48 @@ func ValidatePlan(spec *PlanSpec) (*ValidationResult, error) {
 		result.Messages = append(result.Messages, msg)
 	}
 
+	// Validate per-task executor selection
+	backendMessages := ValidateTaskBackends(spec.Tasks)
+	for _, msg := range backendMessages {
+		if msg.IsError() {
+			result.IsValid = false
+			result.ErrorCount++
+		} else if msg.IsWarning() {
+			result.WarningCount++
+		}
+		result.Messages = append(result.Messages, msg)
+	}
+
+	// Validate declared completion criteria
+	criteriaMessages := ValidateTaskCriteria(spec.Tasks)
+	for _, msg := range criteriaMessages {
+		if msg.IsError() {
+			result.IsValid = false
+			result.ErrorCount++
+		}
+		result.Messages = append(result.Messages, msg)
+	}
+
+	// Validate context packs
+	packMessages := ValidateTaskContextPacks(spec.Tasks)
+	for _, msg := range packMessages {
+		if msg.IsError() {
+			result.IsValid = false
+			result.ErrorCount++
+		}
+		result.Messages = append(result.Messages, msg)
+	}
+
+	// Validate plan-level environment variables
+	envMessages := ValidatePlanEnv(spec.Env)
+	for _, msg := range envMessages {
+		if msg.IsError() {
+			result.IsValid = false
+			result.ErrorCount++
+		}
+		result.Messages = append(result.Messages, msg)
+	}
+
 	// Validate task files for conflicts
 	fileMessages := ValidateTaskFiles(spec)
 	for _, msg := range fileMessages {
@@ -173,6 +220,110 @@ func ValidateTaskDependencies(tasks []PlannedTask) []ValidationMessage {
 	return messages
 }
 
+// ValidateTaskBackends validates per-task backend selection.
+// It checks for:
+// - Unknown backend names (errors)
+// - "tool" tasks without a command (errors)
+// - Commands on tasks that are not run by the "tool" backend (warnings)
+func ValidateTaskBackends(tasks []PlannedTask) []ValidationMessage {
+	var messages []ValidationMessage
+
+	for _, task := range tasks {
+		if task.Backend != "" && !slices.Contains(ai.ValidTaskBackends(), strings.ToLower(task.Backend)) {
+			messages = append(messages, ValidationMessage{
+				Severity:   SeverityError,
+				Message:    fmt.Sprintf("Unknown backend '%s'", task.Backend),
+				TaskID:     task.ID,
+				Field:      "backend",
+				Suggestion: fmt.Sprintf("Use one of: %s (or omit for the session default)", strings.Join(ai.ValidTaskBackends(), ", ")),
+			})
+			continue
+		}
+
+		hasCommand := strings.TrimSpace(task.Command) != ""
+		if task.IsDeterministic() && !hasCommand {
+			messages = append(messages, ValidationMessage{
+				Severity:   SeverityError,
+				Message:    "Tool task has no command",
+				TaskID:     task.ID,
+				Field:      "command",
+				Suggestion: "Set the shell command the tool runner should execute",
+			})
+		} else if !task.IsDeterministic() && hasCommand {
+			messages = append(messages, ValidationMessage{
+				Severity:   SeverityWarning,
+				Message:    "Command is ignored unless the task uses the tool backend",
+				TaskID:     task.ID,
+				Field:      "command",
+				Suggestion: "Set backend to \"tool\" or remove the command",
+			})
+		}
+	}
+
+	return messages
+}
+
+// ValidateTaskCriteria checks that each task's completion criteria can be
+// evaluated: repo-relative paths, valid glob patterns, identifier symbol
+// names, and non-empty test patterns.
+func ValidateTaskCriteria(tasks []PlannedTask) []ValidationMessage {
+	var messages []ValidationMessage
+
+	for _, task := range tasks {
+		if err := task.Criteria.Validate(); err != nil {
+			messages = append(messages, ValidationMessage{
+				Severity:   SeverityError,
+				Message:    err.Error(),
+				TaskID:     task.ID,
+				Field:      "criteria",
+				Suggestion: "Use repo-relative paths and plain identifiers",
+			})
+		}
+	}
+
+	return messages
+}
+
+// ValidateTaskContextPacks checks that each task's context pack can be built:
+// repo-relative file paths, ordered line ranges, and path:Name interfaces.
+func ValidateTaskContextPacks(tasks []PlannedTask) []ValidationMessage {
+	var messages []ValidationMessage
+
+	for _, task := range tasks {
+		if err := task.ContextPack.Validate(); err != nil {
+			messages = append(messages, ValidationMessage{
+				Severity:   SeverityError,
+				Message:    err.Error(),
+				TaskID:     task.ID,
+				Field:      "context_pack",
+				Suggestion: "Use repo-relative file paths, line ranges with start <= end, and path:Name interfaces",
+			})
+		}
+	}
+
+	return messages
+}
+
+// ValidatePlanEnv checks the variables a plan exports to its tasks.
+// Returns an error for each name a shell cannot export or the plan may not
+// override (see orchestrator.ValidatePlanEnvVar).
+func ValidatePlanEnv(env map[string]string) []ValidationMessage {
+	var messages []ValidationMessage
+
+	for _, name := range slices.Sorted(maps.Keys(env)) {
+		if err := orchestrator.ValidatePlanEnvVar(name, env[name]); err != nil {
+			messages = append(messages, ValidationMessage{
+				Severity:   SeverityError,
+				Message:    err.Error(),
+				Field:      "env",
+				Suggestion: "Rename or remove the variable",
+			})
+		}
+	}
+
+	return messages
+}
+
 // ValidateTaskFiles checks for file conflicts between tasks.
 // Returns warnings for files modified by multiple parallel tasks.
 func ValidateTaskFiles(spec *PlanSpec) []ValidationMessage {
@@ -2,6 +2,8 @@ package ultraplan
 
 import (
 	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/orchestrator/types"
 )
 
 func TestValidatePlan_NilPlan(t *testing.T) {
@@ -157,6 +159,101 @@ func TestValidateTaskDependencies_HighComplexity(t *testing.T) {
 	}
 }
 
+func TestValidateTaskBackends(t *testing.T) {
+	tests := []struct {
+		name     string
+		task     PlannedTask
+		severity ValidationSeverity
+		field    string
+	}{
+		{"default backend", PlannedTask{ID: "t"}, "", ""},
+		{"claude backend", PlannedTask{ID: "t", Backend: "claude"}, "", ""},
+		{"tool with command", PlannedTask{ID: "t", Backend: "tool", Command: "gofmt -w ."}, "", ""},
+		{"unknown backend", PlannedTask{ID: "t", Backend: "gpt"}, SeverityError, "backend"},
+		{"tool without command", PlannedTask{ID: "t", Backend: "tool", Command: "  "}, SeverityError, "command"},
+		{"command without tool", PlannedTask{ID: "t", Command: "make fmt"}, SeverityWarning, "command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages := ValidateTaskBackends([]PlannedTask{tt.task})
+			if tt.severity == "" {
+				if len(messages) != 0 {
+					t.Errorf("expected no messages, got %+v", messages)
+				}
+				return
+			}
+			if len(messages) != 1 {
+				t.Fatalf("expected 1 message, got %+v", messages)
+			}
+			if messages[0].Severity != tt.severity || messages[0].Field != tt.field {
+				t.Errorf("message = %+v, want severity %q on field %q", messages[0], tt.severity, tt.field)
+			}
+		})
+	}
+}
+
+func TestValidatePlan_Env(t *testing.T) {
+	spec := &PlanSpec{
+		Tasks: []PlannedTask{{ID: "task-1", Title: "Task 1", Description: "First"}},
+		Env:   map[string]string{"FEATURE_FLAG": "on", "BAD-NAME": "x", "PATH": "/bin"},
+	}
+
+	result, err := ValidatePlan(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.IsValid || result.ErrorCount != 2 {
+		t.Fatalf("ValidatePlan() = %+v, want 2 env errors", result)
+	}
+	for _, msg := range result.Messages {
+		if msg.Field != "env" {
+			t.Errorf("message = %+v, want field env", msg)
+		}
+	}
+}
+
+func TestValidateTaskCriteria(t *testing.T) {
+	tasks := []PlannedTask{
+		{ID: "ok", Criteria: &types.CompletionCriteria{Files: []string{"auth/login.go"}, Symbols: []string{"Login"}}},
+		{ID: "none"},
+		{ID: "outside", Criteria: &types.CompletionCriteria{Files: []string{"../secrets.txt"}}},
+		{ID: "dotted", Criteria: &types.CompletionCriteria{Symbols: []string{"auth.Login"}}},
+	}
+
+	messages := ValidateTaskCriteria(tasks)
+	if len(messages) != 2 {
+		t.Fatalf("ValidateTaskCriteria() = %+v, want 2 messages", messages)
+	}
+	for i, want := range []string{"outside", "dotted"} {
+		if messages[i].TaskID != want || messages[i].Field != "criteria" || !messages[i].IsError() {
+			t.Errorf("messages[%d] = %+v, want a criteria error for %s", i, messages[i], want)
+		}
+	}
+}
+
+func TestValidateTaskContextPacks(t *testing.T) {
+	tasks := []PlannedTask{
+		{ID: "ok", ContextPack: &types.ContextPack{
+			Excerpts:   []types.Excerpt{{Path: "auth/store.go", StartLine: 1, EndLine: 20}},
+			Interfaces: []string{"auth/store.go:Store"},
+		}},
+		{ID: "none"},
+		{ID: "range", ContextPack: &types.ContextPack{Excerpts: []types.Excerpt{{Path: "auth/store.go", StartLine: 20, EndLine: 1}}}},
+		{ID: "bare", ContextPack: &types.ContextPack{Interfaces: []string{"Store"}}},
+	}
+
+	messages := ValidateTaskContextPacks(tasks)
+	if len(messages) != 2 {
+		t.Fatalf("ValidateTaskContextPacks() = %+v, want 2 messages", messages)
+	}
+	for i, want := range []string{"range", "bare"} {
+		if messages[i].TaskID != want || messages[i].Field != "context_pack" || !messages[i].IsError() {
+			t.Errorf("messages[%d] = %+v, want a context_pack error for %s", i, messages[i], want)
+		}
+	}
+}
+
 func TestDetectDependencyCycle_NoCycle(t *testing.T) {
 	spec := &PlanSpec{
 		Tasks: []PlannedTask{
@@ -0,0 +1,210 @@
+package worktree
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Git backend names accepted by NewGitBackend.
+const (
+	// BackendExec runs the git binary (default).
+	BackendExec = "exec"
+	// BackendGoGit uses the pure Go go-git library, so no git binary is needed.
+	BackendGoGit = "go-git"
+)
+
+// GitBackend performs the branch, worktree, cherry-pick, and push operations
+// used to consolidate task branches. Manager delegates these operations to its
+// backend, so consolidation can run without an external git binary and tests
+// can substitute a fake.
+type GitBackend interface {
+	// Name returns the backend name (BackendExec or BackendGoGit).
+	Name() string
+
+	// MainBranch returns "main" if a local main branch exists, otherwise "master".
+	MainBranch() string
+
+	// CreateBranch creates branchName at baseBranch without checking it out.
+	CreateBranch(branchName, baseBranch string) error
+
+	// AddWorktree creates a linked worktree at path with branch checked out.
+	AddWorktree(path, branch string) error
+
+	// RemoveWorktree deletes the worktree at path and its administrative files.
+	RemoveWorktree(path string) error
+
+	// CommitsBetween returns the SHAs of commits reachable from head but not
+	// from base, oldest first, resolving both in the worktree at path.
+	CommitsBetween(path, base, head string) ([]string, error)
+
+	// CountCommitsBetween returns len(CommitsBetween(path, base, head)).
+	CountCommitsBetween(path, base, head string) (int, error)
+
+	// CherryPick applies commit on top of HEAD in the worktree at path. A
+	// conflict is reported as a *CherryPickConflictError.
+	CherryPick(path, commit string) error
+
+	// AbortCherryPick abandons a cherry-pick stopped by a conflict.
+	AbortCherryPick(path string) error
+
+	// Push pushes HEAD of the worktree at path to the same branch on origin
+	// and sets it as the upstream. If force is true the push uses a lease.
+	Push(path string, force bool) error
+}
+
+// NewGitBackend returns the named backend for the repository at repoDir. An
+// empty name selects BackendExec.
+func NewGitBackend(name, repoDir string) (GitBackend, error) {
+	switch name {
+	case "", BackendExec:
+		return &execBackend{repoDir: repoDir}, nil
+	case BackendGoGit:
+		return &goGitBackend{repoDir: repoDir}, nil
+	default:
+		return nil, fmt.Errorf("unknown git backend %q (want %q or %q)", name, BackendExec, BackendGoGit)
+	}
+}
+
+// execBackend implements GitBackend by running the git binary.
+type execBackend struct {
+	repoDir string
+}
+
+func (b *execBackend) Name() string { return BackendExec }
+
+func (b *execBackend) MainBranch() string {
+	// Check if 'main' exists
+	cmd := exec.Command("git", "rev-parse", "--verify", "main")
+	cmd.Dir = b.repoDir
+	if err := cmd.Run(); err == nil {
+		return "main"
+	}
+
+	// Fall back to 'master'
+	return "master"
+}
+
+func (b *execBackend) CreateBranch(branchName, baseBranch string) error {
+	cmd := exec.Command("git", "branch", branchName, baseBranch)
+	cmd.Dir = b.repoDir
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create branch %s from %s: %w\n%s", branchName, baseBranch, err, string(output))
+	}
+
+	return nil
+}
+
+func (b *execBackend) AddWorktree(path, branch string) error {
+	cmd := exec.Command("git", "worktree", "add", path, branch)
+	cmd.Dir = b.repoDir
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create worktree from branch %s: %w\n%s", branch, err, string(output))
+	}
+
+	return nil
+}
+
+func (b *execBackend) RemoveWorktree(path string) error {
+	cmd := exec.Command("git", "worktree", "remove", "--force", path)
+	cmd.Dir = b.repoDir
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		// If worktree remove fails, try to clean up manually
+		_ = os.RemoveAll(path)
+
+		// Prune worktree references
+		pruneCmd := exec.Command("git", "worktree", "prune")
+		pruneCmd.Dir = b.repoDir
+		_ = pruneCmd.Run()
+
+		return fmt.Errorf("failed to remove worktree cleanly: %w\n%s", err, string(output))
+	}
+
+	return nil
+}
+
+func (b *execBackend) CommitsBetween(path, base, head string) ([]string, error) {
+	cmd := exec.Command("git", "rev-list", "--reverse", base+".."+head)
+	cmd.Dir = path
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits: %w", err)
+	}
+
+	lines := strings.TrimSpace(string(output))
+	if lines == "" {
+		return []string{}, nil
+	}
+
+	return strings.Split(lines, "\n"), nil
+}
+
+func (b *execBackend) CountCommitsBetween(path, base, head string) (int, error) {
+	cmd := exec.Command("git", "rev-list", "--count", base+".."+head)
+	cmd.Dir = path
+
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count commits between %s and %s: %w", base, head, err)
+	}
+
+	count := 0
+	_, _ = fmt.Sscanf(strings.TrimSpace(string(output)), "%d", &count)
+	return count, nil
+}
+
+func (b *execBackend) CherryPick(path, commit string) error {
+	cmd := exec.Command("git", "cherry-pick", commit)
+	cmd.Dir = path
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		// Check for conflicts
+		if strings.Contains(string(output), "CONFLICT") || strings.Contains(string(output), "could not apply") {
+			return &CherryPickConflictError{Commit: commit, Output: string(output)}
+		}
+		return fmt.Errorf("failed to cherry-pick commit %s: %w\n%s", commit, err, string(output))
+	}
+
+	return nil
+}
+
+func (b *execBackend) AbortCherryPick(path string) error {
+	cmd := exec.Command("git", "cherry-pick", "--abort")
+	cmd.Dir = path
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to abort cherry-pick: %w\n%s", err, string(output))
+	}
+
+	return nil
+}
+
+func (b *execBackend) Push(path string, force bool) error {
+	args := []string{"push", "-u", "origin", "HEAD"}
+	if force {
+		args = append(args, "--force-with-lease")
+	}
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = path
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to push: %w\n%s", err, string(output))
+	}
+
+	return nil
+}
+
+// asCherryPickConflict returns err as a *CherryPickConflictError, if it is one.
+func asCherryPickConflict(err error) (*CherryPickConflictError, bool) {
+	var conflict *CherryPickConflictError
+	ok := errors.As(err, &conflict)
+	return conflict, ok
+}
@@ -0,0 +1,187 @@
+package worktree
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/testutil"
+)
+
+// gitOutput runs git in dir and returns its trimmed output.
+func gitOutput(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func TestNewGitBackend(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "", want: BackendExec},
+		{name: BackendExec, want: BackendExec},
+		{name: BackendGoGit, want: BackendGoGit},
+		{name: "libgit2", wantErr: true},
+	}
+	for _, tt := range tests {
+		b, err := NewGitBackend(tt.name, t.TempDir())
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NewGitBackend(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && b.Name() != tt.want {
+			t.Errorf("NewGitBackend(%q).Name() = %q, want %q", tt.name, b.Name(), tt.want)
+		}
+	}
+}
+
+func TestManagerSetGitBackend(t *testing.T) {
+	testutil.SkipIfNoGit(t)
+
+	mgr, err := New(testutil.SetupTestRepo(t))
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	if got := mgr.GitBackend().Name(); got != BackendExec {
+		t.Errorf("default backend = %q, want %q", got, BackendExec)
+	}
+	b, _ := NewGitBackend(BackendGoGit, mgr.repoDir)
+	mgr.SetGitBackend(b)
+	if got := mgr.GitBackend().Name(); got != BackendGoGit {
+		t.Errorf("backend = %q, want %q", got, BackendGoGit)
+	}
+	mgr.SetGitBackend(nil)
+	if got := mgr.GitBackend().Name(); got != BackendExec {
+		t.Errorf("backend after SetGitBackend(nil) = %q, want %q", got, BackendExec)
+	}
+
+	if err := mgr.UseGitBackend(BackendGoGit); err != nil || mgr.GitBackend().Name() != BackendGoGit {
+		t.Errorf("UseGitBackend(%q) error = %v, backend = %q", BackendGoGit, err, mgr.GitBackend().Name())
+	}
+	if err := mgr.UseGitBackend("libgit2"); err == nil {
+		t.Error("UseGitBackend() of an unknown backend should fail")
+	}
+}
+
+// TestGoGitBackend_Consolidation runs the consolidation sequence (branch,
+// worktree, cherry-picks, count, push, remove) through the go-git backend and
+// checks the result with the git binary.
+func TestGoGitBackend_Consolidation(t *testing.T) {
+	testutil.SkipIfNoGit(t)
+
+	repoDir := testutil.SetupTestRepo(t)
+	testutil.CommitFile(t, repoDir, "shared.txt", "base\n", "Add shared file")
+	for _, task := range []struct{ branch, file, content string }{
+		{"task-a", "a.txt", "a\n"},
+		{"task-b", "b/nested.txt", "b\n"},
+		{"task-c", "shared.txt", "c\n"},
+	} {
+		gitOutput(t, repoDir, "checkout", "-q", "-b", task.branch, "main")
+		testutil.CommitFile(t, repoDir, task.file, task.content, "Add "+task.file)
+	}
+	gitOutput(t, repoDir, "checkout", "-q", "task-a")
+	testutil.CommitFile(t, repoDir, "shared.txt", "a\n", "Edit shared file")
+	gitOutput(t, repoDir, "checkout", "-q", "main")
+
+	mgr, err := New(repoDir)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	backend, err := NewGitBackend(BackendGoGit, repoDir)
+	if err != nil {
+		t.Fatalf("NewGitBackend() error = %v", err)
+	}
+	mgr.SetGitBackend(backend)
+
+	if got := mgr.FindMainBranch(); got != "main" {
+		t.Errorf("FindMainBranch() = %q, want main", got)
+	}
+	if err := mgr.CreateBranchFrom("consolidated", "main"); err != nil {
+		t.Fatalf("CreateBranchFrom() error = %v", err)
+	}
+	if err := mgr.CreateBranchFrom("consolidated", "main"); err == nil {
+		t.Error("CreateBranchFrom() of an existing branch should fail")
+	}
+
+	wtPath := filepath.Join(t.TempDir(), "consolidation")
+	if err := mgr.CreateWorktreeFromBranch(wtPath, "consolidated"); err != nil {
+		t.Fatalf("CreateWorktreeFromBranch() error = %v", err)
+	}
+	if got := gitOutput(t, wtPath, "rev-parse", "--abbrev-ref", "HEAD"); got != "consolidated" {
+		t.Errorf("worktree branch = %q, want consolidated", got)
+	}
+	if err := mgr.CreateWorktreeFromBranch(filepath.Join(t.TempDir(), "dup"), "main"); err == nil {
+		t.Error("CreateWorktreeFromBranch() of a checked-out branch should fail")
+	}
+
+	if n, err := mgr.CountCommitsBetween(wtPath, "main", "task-a"); err != nil || n != 2 {
+		t.Errorf("CountCommitsBetween(main, task-a) = %d, %v; want 2", n, err)
+	}
+	for _, branch := range []string{"task-a", "task-b", "task-a"} {
+		if err := mgr.CherryPickBranch(wtPath, branch); err != nil {
+			t.Fatalf("CherryPickBranch(%s) error = %v", branch, err)
+		}
+	}
+	if n, err := mgr.CountCommitsBetween(wtPath, "main", "HEAD"); err != nil || n != 3 {
+		t.Errorf("CountCommitsBetween(main, HEAD) = %d, %v; want 3 (re-picking task-a adds nothing)", n, err)
+	}
+	if status := gitOutput(t, wtPath, "status", "--porcelain"); status != "" {
+		t.Errorf("worktree not clean after cherry-picks:\n%s", status)
+	}
+	if got := gitOutput(t, wtPath, "log", "-1", "--format=%an %s"); got != "Claudio Test Add b/nested.txt" {
+		t.Errorf("last commit = %q, want the picked author and subject", got)
+	}
+
+	head := gitOutput(t, wtPath, "rev-parse", "HEAD")
+	err = mgr.CherryPickBranch(wtPath, "task-c")
+	var conflict *CherryPickConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("CherryPickBranch(task-c) error = %v, want *CherryPickConflictError", err)
+	}
+	if conflict.SourceBranch != "task-c" || !strings.Contains(conflict.Output, "shared.txt") {
+		t.Errorf("conflict = %+v, want task-c conflicting on shared.txt", conflict)
+	}
+	if err := mgr.AbortCherryPick(wtPath); err != nil {
+		t.Errorf("AbortCherryPick() error = %v", err)
+	}
+	if got := gitOutput(t, wtPath, "rev-parse", "HEAD"); got != head {
+		t.Error("a conflicting cherry-pick should not move HEAD")
+	}
+	if data, _ := os.ReadFile(filepath.Join(wtPath, "shared.txt")); string(data) != "a\n" {
+		t.Errorf("shared.txt = %q, want it untouched by the conflicting pick", data)
+	}
+
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	gitOutput(t, repoDir, "init", "-q", "--bare", remote)
+	gitOutput(t, repoDir, "remote", "add", "origin", remote)
+	if err := mgr.Push(wtPath, false); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	if got := gitOutput(t, remote, "rev-parse", "consolidated"); got != head {
+		t.Errorf("remote consolidated = %s, want %s", got, head)
+	}
+	if got := gitOutput(t, repoDir, "config", "branch.consolidated.remote"); got != "origin" {
+		t.Errorf("upstream remote = %q, want origin", got)
+	}
+
+	if err := mgr.Remove(wtPath); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := os.Stat(wtPath); !os.IsNotExist(err) {
+		t.Error("worktree directory still exists after Remove()")
+	}
+	if list := gitOutput(t, repoDir, "worktree", "list", "--porcelain"); strings.Contains(list, "consolidation") {
+		t.Errorf("git still lists the removed worktree:\n%s", list)
+	}
+}
@@ -0,0 +1,209 @@
+package worktree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// bootstrapWaitDelay bounds how long a cancelled bootstrap command may keep
+// its output pipes open before Wait gives up on it.
+const bootstrapWaitDelay = 2 * time.Second
+
+// BootstrapOptions configures the preparation steps run in a fresh worktree
+// before an instance starts, so it does not pay to rebuild dependency caches.
+type BootstrapOptions struct {
+	// SharedDirs are repository-relative directories (e.g. "node_modules",
+	// ".build") that are symlinked from the main repository into the worktree.
+	// Missing source directories and destinations that already exist are skipped.
+	SharedDirs []string
+
+	// Commands are shell commands run sequentially in the worktree via sh -c
+	// after SharedDirs are linked (e.g. "npm ci --prefer-offline").
+	Commands []string
+
+	// Env holds extra KEY=VALUE entries appended to the environment of
+	// Commands (e.g. "GOFLAGS=-mod=mod").
+	Env []string
+
+	// Timeout bounds the total time spent running Commands (0 = no limit).
+	Timeout time.Duration
+}
+
+// IsZero reports whether the options would do nothing.
+func (o BootstrapOptions) IsZero() bool {
+	return len(o.SharedDirs) == 0 && len(o.Commands) == 0
+}
+
+// BootstrapResult records what a bootstrap did and how long each phase took.
+type BootstrapResult struct {
+	Linked          []string      // SharedDirs that were symlinked
+	CommandsRun     int           // Commands that completed successfully
+	LinkDuration    time.Duration // Time spent linking shared directories
+	CommandDuration time.Duration // Time spent running commands
+}
+
+// Duration returns the total bootstrap time.
+func (r BootstrapResult) Duration() time.Duration {
+	return r.LinkDuration + r.CommandDuration
+}
+
+// Bootstrap prepares a freshly created worktree by linking shared cache
+// directories from the main repository and then running the configured
+// commands. It stops at the first failing command and returns the partial
+// result alongside the error; callers should treat failures as non-fatal
+// since bootstrapping is an optimization and the instance can still build
+// from scratch.
+func (m *Manager) Bootstrap(ctx context.Context, path string, opts BootstrapOptions) (BootstrapResult, error) {
+	var result BootstrapResult
+
+	linkStart := time.Now()
+	linked, err := m.linkSharedDirs(path, opts.SharedDirs)
+	result.Linked = linked
+	result.LinkDuration = time.Since(linkStart)
+	if err != nil {
+		return result, err
+	}
+
+	if len(opts.Commands) == 0 {
+		return result, nil
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	cmdStart := time.Now()
+	for _, command := range opts.Commands {
+		cmd := exec.CommandContext(ctx, "sh", "-c", command)
+		cmd.Dir = path
+		cmd.Env = append(os.Environ(), opts.Env...)
+		cmd.WaitDelay = bootstrapWaitDelay
+		configureBootstrapCmd(cmd)
+
+		output, err := cmd.CombinedOutput()
+		if m.logger != nil {
+			m.logger.Debug("bootstrap command", "path", path, "command", command,
+				"output", truncateOutput(string(output), 500))
+		}
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				err = ctxErr
+			}
+			result.CommandDuration = time.Since(cmdStart)
+			return result, fmt.Errorf("bootstrap command %q failed: %w\n%s",
+				command, err, truncateOutput(string(output), 2000))
+		}
+		result.CommandsRun++
+	}
+	result.CommandDuration = time.Since(cmdStart)
+
+	if m.logger != nil {
+		m.logger.Info("worktree bootstrapped", "path", path,
+			"linked", result.Linked, "commands", result.CommandsRun,
+			"duration", result.Duration())
+	}
+	return result, nil
+}
+
+// linkSharedDirs symlinks each shared directory from the main repository into
+// the worktree and excludes the links from git. Returns the directories linked.
+func (m *Manager) linkSharedDirs(path string, dirs []string) ([]string, error) {
+	var linked []string
+	for _, dir := range dirs {
+		rel := filepath.Clean(strings.TrimSuffix(dir, "/"))
+		if rel == "." || filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return linked, fmt.Errorf("shared dir %q must be a relative path inside the repository", dir)
+		}
+
+		src := filepath.Join(m.repoDir, rel)
+		if info, err := os.Stat(src); err != nil || !info.IsDir() {
+			// Nothing to share yet (e.g. node_modules not installed in the main repo).
+			continue
+		}
+		dst := filepath.Join(path, rel)
+		if _, err := os.Lstat(dst); err == nil {
+			// Tracked content or an earlier link; never clobber it.
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return linked, fmt.Errorf("create parent for shared dir %q: %w", dir, err)
+		}
+		if err := os.Symlink(src, dst); err != nil {
+			return linked, fmt.Errorf("link shared dir %q: %w", dir, err)
+		}
+		linked = append(linked, rel)
+	}
+
+	if len(linked) > 0 {
+		// A symlink is a file to git, so directory-only ignore patterns such as
+		// "node_modules/" do not match it. Exclude the links explicitly so they
+		// are never committed.
+		if err := m.excludePaths(linked); err != nil && m.logger != nil {
+			m.logger.Warn("failed to exclude shared dir links from git",
+				"path", path, "dirs", linked, "error", err)
+		}
+	}
+	return linked, nil
+}
+
+// excludePaths adds anchored patterns for the given repository-relative paths
+// to the shared info/exclude file, skipping patterns already present.
+// The exclude file lives in the common git directory, so it applies to the
+// main repository and every worktree.
+func (m *Manager) excludePaths(paths []string) error {
+	out, err := exec.Command("git", "-C", m.repoDir, "rev-parse", "--git-common-dir").Output()
+	if err != nil {
+		return fmt.Errorf("resolve git common dir: %w", err)
+	}
+	commonDir := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(m.repoDir, commonDir)
+	}
+	excludeFile := filepath.Join(commonDir, "info", "exclude")
+
+	data, err := os.ReadFile(excludeFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	existing := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		existing[strings.TrimSpace(line)] = true
+	}
+
+	var missing []string
+	for _, p := range paths {
+		pattern := "/" + filepath.ToSlash(p)
+		if !existing[pattern] {
+			missing = append(missing, pattern)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(excludeFile), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(excludeFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	block := "# claudio shared bootstrap dirs\n" + strings.Join(missing, "\n") + "\n"
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		block = "\n" + block
+	}
+	_, werr := f.WriteString(block)
+	if cerr := f.Close(); werr == nil {
+		werr = cerr
+	}
+	return werr
+}
@@ -0,0 +1,57 @@
+//go:build integration
+
+package worktree
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/testutil"
+)
+
+func TestManager_Bootstrap_ExcludesLinks(t *testing.T) {
+	testutil.SkipIfNoGit(t)
+
+	repoDir := testutil.SetupTestRepo(t)
+	m, err := New(repoDir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(repoDir, "node_modules"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	wtPath := filepath.Join(t.TempDir(), "wt")
+	if err := m.Create(wtPath, "bootstrap-test"); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	for range 2 {
+		if err := os.Remove(filepath.Join(wtPath, "node_modules")); err != nil && !os.IsNotExist(err) {
+			t.Fatal(err)
+		}
+		if _, err := m.Bootstrap(context.Background(), wtPath, BootstrapOptions{SharedDirs: []string{"node_modules"}}); err != nil {
+			t.Fatalf("Bootstrap() error = %v", err)
+		}
+	}
+
+	out, err := exec.Command("git", "-C", wtPath, "status", "--porcelain").Output()
+	if err != nil {
+		t.Fatalf("git status: %v", err)
+	}
+	if strings.Contains(string(out), "node_modules") {
+		t.Errorf("linked node_modules should be excluded from git, status:\n%s", out)
+	}
+
+	exclude, err := os.ReadFile(filepath.Join(repoDir, ".git", "info", "exclude"))
+	if err != nil {
+		t.Fatalf("read exclude: %v", err)
+	}
+	if n := strings.Count(string(exclude), "/node_modules\n"); n != 1 {
+		t.Errorf("exclude should contain /node_modules exactly once, got %d:\n%s", n, exclude)
+	}
+}
@@ -0,0 +1,111 @@
+package worktree
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBootstrapOptions_IsZero(t *testing.T) {
+	if !(BootstrapOptions{Env: []string{"A=1"}, Timeout: time.Second}).IsZero() {
+		t.Error("options without shared dirs or commands should be zero")
+	}
+	if (BootstrapOptions{Commands: []string{"true"}}).IsZero() {
+		t.Error("options with commands should not be zero")
+	}
+}
+
+func TestManager_Bootstrap(t *testing.T) {
+	repoDir := t.TempDir()
+	wtPath := t.TempDir()
+	m := &Manager{repoDir: repoDir}
+
+	// Shared dirs: one present in the repo, one missing, one already in the worktree.
+	if err := os.MkdirAll(filepath.Join(repoDir, "node_modules", "left-pad"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(repoDir, "vendor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(wtPath, "vendor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := m.Bootstrap(context.Background(), wtPath, BootstrapOptions{
+		SharedDirs: []string{"node_modules/", "missing", "vendor"},
+		Commands:   []string{`echo "$BOOT_VALUE" > boot.txt`, "test -d node_modules/left-pad"},
+		Env:        []string{"BOOT_VALUE=warm"},
+	})
+	if err != nil {
+		t.Fatalf("Bootstrap() error = %v", err)
+	}
+
+	if len(result.Linked) != 1 || result.Linked[0] != "node_modules" {
+		t.Errorf("Linked = %v, want [node_modules]", result.Linked)
+	}
+	if result.CommandsRun != 2 {
+		t.Errorf("CommandsRun = %d, want 2", result.CommandsRun)
+	}
+	if result.CommandDuration <= 0 {
+		t.Errorf("CommandDuration = %v, want > 0", result.CommandDuration)
+	}
+
+	info, err := os.Lstat(filepath.Join(wtPath, "node_modules"))
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("node_modules should be a symlink, got info=%v err=%v", info, err)
+	}
+	info, err = os.Lstat(filepath.Join(wtPath, "vendor"))
+	if err != nil || info.Mode()&os.ModeSymlink != 0 {
+		t.Errorf("existing vendor dir should be left alone, got info=%v err=%v", info, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(wtPath, "boot.txt"))
+	if err != nil {
+		t.Fatalf("command output file missing: %v", err)
+	}
+	if strings.TrimSpace(string(data)) != "warm" {
+		t.Errorf("boot.txt = %q, want %q (env not applied)", data, "warm")
+	}
+}
+
+func TestManager_Bootstrap_CommandFailure(t *testing.T) {
+	m := &Manager{repoDir: t.TempDir()}
+
+	result, err := m.Bootstrap(context.Background(), t.TempDir(), BootstrapOptions{
+		Commands: []string{"true", "echo boom; exit 3", "true"},
+	})
+	if err == nil {
+		t.Fatal("Bootstrap() should fail when a command fails")
+	}
+	if !strings.Contains(err.Error(), "boom") {
+		t.Errorf("error should include command output, got %v", err)
+	}
+	if result.CommandsRun != 1 {
+		t.Errorf("CommandsRun = %d, want 1 (stop at first failure)", result.CommandsRun)
+	}
+}
+
+func TestManager_Bootstrap_Timeout(t *testing.T) {
+	m := &Manager{repoDir: t.TempDir()}
+
+	_, err := m.Bootstrap(context.Background(), t.TempDir(), BootstrapOptions{
+		Commands: []string{"sleep 5"},
+		Timeout:  50 * time.Millisecond,
+	})
+	if err == nil || !strings.Contains(err.Error(), "deadline exceeded") {
+		t.Errorf("Bootstrap() error = %v, want deadline exceeded", err)
+	}
+}
+
+func TestManager_Bootstrap_RejectsEscapingSharedDir(t *testing.T) {
+	m := &Manager{repoDir: t.TempDir()}
+
+	for _, dir := range []string{"../outside", "/abs", "."} {
+		if _, err := m.Bootstrap(context.Background(), t.TempDir(), BootstrapOptions{SharedDirs: []string{dir}}); err == nil {
+			t.Errorf("Bootstrap() with shared dir %q should fail", dir)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+//go:build unix
+
+package worktree
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// configureBootstrapCmd runs a bootstrap command in its own process group and
+// makes cancellation kill the whole group, so a timed-out "npm ci" does not
+// leave children holding the output pipe open.
+func configureBootstrapCmd(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package worktree
+
+import "os/exec"
+
+// configureBootstrapCmd is a no-op on Windows; cancellation kills only the
+// direct child and bootstrapWaitDelay bounds the wait for its output.
+func configureBootstrapCmd(cmd *exec.Cmd) {}
@@ -0,0 +1,130 @@
+package worktree
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ConflictSide is the version of a conflicted file kept when resolving it
+// wholesale. During a cherry-pick, ours is the branch being built and theirs
+// is the commit being picked.
+type ConflictSide string
+
+const (
+	ConflictOurs   ConflictSide = "ours"
+	ConflictTheirs ConflictSide = "theirs"
+)
+
+// ConflictHunk is one region of a file between conflict markers.
+type ConflictHunk struct {
+	Line        int      // 1-based line of the opening <<<<<<< marker
+	OursLabel   string   // Text after <<<<<<<, usually HEAD
+	TheirsLabel string   // Text after >>>>>>>, usually the picked commit
+	Ours        []string // Lines on our side
+	Theirs      []string // Lines on their side
+}
+
+// ConflictedFile is a file git reports as unmerged, with the hunks still
+// marked in it. Err is set when the file could not be read; a file deleted
+// on one side has no hunks.
+type ConflictedFile struct {
+	Path  string
+	Hunks []ConflictHunk
+	Err   error
+}
+
+// Conflicts returns the unmerged files in the worktree at path with their
+// conflict hunks, in the order git lists them.
+func (m *Manager) Conflicts(path string) ([]ConflictedFile, error) {
+	files, err := m.GetConflictingFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	conflicts := make([]ConflictedFile, len(files))
+	for i, file := range files {
+		conflicts[i].Path = file
+		data, err := os.ReadFile(filepath.Join(path, file))
+		switch {
+		case os.IsNotExist(err):
+		case err != nil:
+			conflicts[i].Err = err
+		default:
+			conflicts[i].Hunks = ParseConflictHunks(data)
+		}
+	}
+	return conflicts, nil
+}
+
+// ParseConflictHunks returns the hunks marked in a conflicted file's
+// content. The base section of diff3-style markers is skipped, and an
+// unterminated hunk is dropped.
+func ParseConflictHunks(content []byte) []ConflictHunk {
+	const (
+		outside = iota
+		inOurs
+		inBase
+		inTheirs
+	)
+	var hunks []ConflictHunk
+	var cur ConflictHunk
+	state := outside
+	for i, line := range strings.Split(string(bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))), "\n") {
+		switch {
+		case state == outside && strings.HasPrefix(line, "<<<<<<<"):
+			cur = ConflictHunk{Line: i + 1, OursLabel: strings.TrimSpace(line[7:])}
+			state = inOurs
+		case state == inOurs && strings.HasPrefix(line, "|||||||"):
+			state = inBase
+		case (state == inOurs || state == inBase) && strings.HasPrefix(line, "======="):
+			state = inTheirs
+		case state == inTheirs && strings.HasPrefix(line, ">>>>>>>"):
+			cur.TheirsLabel = strings.TrimSpace(line[7:])
+			hunks = append(hunks, cur)
+			state = outside
+		case state == inOurs:
+			cur.Ours = append(cur.Ours, line)
+		case state == inTheirs:
+			cur.Theirs = append(cur.Theirs, line)
+		}
+	}
+	return hunks
+}
+
+// ResolveConflict resolves file in the worktree at path by keeping side's
+// version of it and marking it resolved. A file deleted on that side is
+// removed.
+func (m *Manager) ResolveConflict(path, file string, side ConflictSide) error {
+	if side != ConflictOurs && side != ConflictTheirs {
+		return fmt.Errorf("unknown conflict side %q", side)
+	}
+	checkout := exec.Command("git", "checkout", "--"+string(side), "--", file)
+	checkout.Dir = path
+	if output, err := checkout.CombinedOutput(); err != nil {
+		if !strings.Contains(string(output), "does not have "+string(side)) {
+			return fmt.Errorf("failed to take %s version of %s: %w\n%s", side, file, err, output)
+		}
+		// Deleted on that side
+		rm := exec.Command("git", "rm", "--quiet", "--", file)
+		rm.Dir = path
+		if output, err := rm.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to remove %s: %w\n%s", file, err, output)
+		}
+		return nil
+	}
+	return m.MarkConflictResolved(path, file)
+}
+
+// MarkConflictResolved stages file in the worktree at path, marking its
+// conflict resolved as edited.
+func (m *Manager) MarkConflictResolved(path, file string) error {
+	cmd := exec.Command("git", "add", "--", file)
+	cmd.Dir = path
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to mark %s resolved: %w\n%s", file, err, output)
+	}
+	return nil
+}
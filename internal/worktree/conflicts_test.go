@@ -0,0 +1,105 @@
+package worktree
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Iron-Ham/claudio/internal/testutil"
+)
+
+func TestParseConflictHunks(t *testing.T) {
+	content := "package a\n" +
+		"<<<<<<< HEAD\n" +
+		"ours 1\n" +
+		"ours 2\n" +
+		"=======\n" +
+		"theirs\n" +
+		">>>>>>> abc123 (Add theirs)\n" +
+		"between\n" +
+		"<<<<<<< HEAD\r\n" +
+		"||||||| base\r\n" +
+		"base\r\n" +
+		"=======\r\n" +
+		">>>>>>> def456\r\n" +
+		"<<<<<<< HEAD\n" +
+		"unterminated\n"
+
+	want := []ConflictHunk{
+		{Line: 2, OursLabel: "HEAD", TheirsLabel: "abc123 (Add theirs)", Ours: []string{"ours 1", "ours 2"}, Theirs: []string{"theirs"}},
+		{Line: 9, OursLabel: "HEAD", TheirsLabel: "def456"},
+	}
+	if got := ParseConflictHunks([]byte(content)); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseConflictHunks() = %+v, want %+v", got, want)
+	}
+}
+
+func TestResolveConflict(t *testing.T) {
+	testutil.SkipIfNoGit(t)
+
+	repoDir := testutil.SetupTestRepo(t)
+	mgr, err := New(repoDir)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		if out, err := cmd.CombinedOutput(); err != nil && args[0] != "cherry-pick" {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	testutil.CommitFile(t, repoDir, "a.txt", "base\n", "Add a")
+	testutil.CommitFile(t, repoDir, "b.txt", "base\n", "Add b")
+	testutil.CreateBranch(t, repoDir, "task")
+	writeBoth := func(content string) {
+		t.Helper()
+		for _, f := range []string{"a.txt", "b.txt"} {
+			if err := os.WriteFile(filepath.Join(repoDir, f), []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		git("commit", "-q", "-am", "Change to "+content)
+	}
+	writeBoth("main\n")
+	git("checkout", "-q", "task")
+	writeBoth("task\n")
+	git("checkout", "-q", "-")
+	git("cherry-pick", "task")
+
+	conflicts, err := mgr.Conflicts(repoDir)
+	if err != nil {
+		t.Fatalf("Conflicts() error = %v", err)
+	}
+	if len(conflicts) != 2 || conflicts[0].Path != "a.txt" || len(conflicts[0].Hunks) != 1 {
+		t.Fatalf("Conflicts() = %+v, want a.txt and b.txt with one hunk each", conflicts)
+	}
+	if h := conflicts[0].Hunks[0]; !reflect.DeepEqual(h.Ours, []string{"main"}) || !reflect.DeepEqual(h.Theirs, []string{"task"}) {
+		t.Errorf("a.txt hunk = %+v, want main against task", h)
+	}
+
+	if err := mgr.ResolveConflict(repoDir, "a.txt", ConflictTheirs); err != nil {
+		t.Fatalf("ResolveConflict() error = %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(repoDir, "a.txt")); string(data) != "task\n" {
+		t.Errorf("a.txt = %q after taking theirs", data)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, "b.txt"), []byte("both\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := mgr.MarkConflictResolved(repoDir, "b.txt"); err != nil {
+		t.Fatalf("MarkConflictResolved() error = %v", err)
+	}
+
+	files, err := mgr.GetConflictingFiles(repoDir)
+	if err != nil || len(files) != 0 {
+		t.Errorf("GetConflictingFiles() = %v, %v; want none left", files, err)
+	}
+	if err := mgr.ResolveConflict(repoDir, "a.txt", "mine"); err == nil {
+		t.Error("ResolveConflict(unknown side) error = nil")
+	}
+}
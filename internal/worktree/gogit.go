@@ -0,0 +1,593 @@
+package worktree
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// goGitBackend implements GitBackend with go-git, so it works on machines
+// without a git binary.
+//
+// Its cherry-pick merges whole files rather than lines: a file changed by the
+// picked commit applies cleanly only if HEAD still has the commit parent's
+// version of it, or already has the commit's version. Any other difference is
+// reported as a conflict, even where git would merge the edits. Nothing is
+// written for a conflicting commit, so there is no cherry-pick to abort.
+type goGitBackend struct {
+	repoDir string
+}
+
+func (b *goGitBackend) Name() string { return BackendGoGit }
+
+// open opens the repository or linked worktree containing dir.
+func (b *goGitBackend) open(dir string) (*git.Repository, error) {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{
+		DetectDotGit:          true,
+		EnableDotGitCommonDir: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository at %s: %w", dir, err)
+	}
+	return repo, nil
+}
+
+// resolve returns the commit a revision names in repo.
+func resolve(repo *git.Repository, rev string) (*object.Commit, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", rev, err)
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", rev, err)
+	}
+	return commit, nil
+}
+
+func (b *goGitBackend) MainBranch() string {
+	repo, err := b.open(b.repoDir)
+	if err == nil {
+		if _, err := repo.Reference(plumbing.NewBranchReferenceName("main"), false); err == nil {
+			return "main"
+		}
+	}
+	return "master"
+}
+
+func (b *goGitBackend) CreateBranch(branchName, baseBranch string) error {
+	repo, err := b.open(b.repoDir)
+	if err != nil {
+		return err
+	}
+	ref := plumbing.NewBranchReferenceName(branchName)
+	if _, err := repo.Reference(ref, false); err == nil {
+		return fmt.Errorf("failed to create branch %s from %s: branch already exists", branchName, baseBranch)
+	}
+	base, err := resolve(repo, baseBranch)
+	if err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", branchName, err)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(ref, base.Hash)); err != nil {
+		return fmt.Errorf("failed to create branch %s from %s: %w", branchName, baseBranch, err)
+	}
+	return nil
+}
+
+// commonDir returns the shared git directory of the repository at repoDir,
+// following the .git file of a linked worktree.
+func commonDir(repoDir string) (string, error) {
+	dotGit := filepath.Join(repoDir, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return dotGit, nil
+	}
+	gitDir, err := readGitDirFile(repoDir)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read commondir of %s: %w", gitDir, err)
+	}
+	dir := strings.TrimSpace(string(data))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(gitDir, dir)
+	}
+	return filepath.Clean(dir), nil
+}
+
+// readGitDirFile returns the administrative directory named by the .git file
+// of the linked worktree at path.
+func readGitDirFile(path string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(path, ".git"))
+	if err != nil {
+		return "", err
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return "", fmt.Errorf("%s/.git is not a gitdir file", path)
+	}
+	gitDir = filepath.FromSlash(strings.TrimSpace(gitDir))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(path, gitDir)
+	}
+	return filepath.Clean(gitDir), nil
+}
+
+// AddWorktree lays out a linked worktree the way `git worktree add` does: an
+// administrative directory under <common>/worktrees/<name>, a .git file in
+// path pointing at it, and a hard reset to populate the files and index.
+func (b *goGitBackend) AddWorktree(path, branch string) error {
+	fail := func(err error) error {
+		return fmt.Errorf("failed to create worktree from branch %s: %w", branch, err)
+	}
+
+	repo, err := b.open(b.repoDir)
+	if err != nil {
+		return fail(err)
+	}
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if err != nil {
+		return fail(err)
+	}
+	common, err := commonDir(b.repoDir)
+	if err != nil {
+		return fail(err)
+	}
+	if checkedOut, err := branchCheckedOut(common, ref.Name()); err != nil {
+		return fail(err)
+	} else if checkedOut != "" {
+		return fail(fmt.Errorf("branch is already checked out at %s", checkedOut))
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fail(err)
+	}
+	if entries, err := os.ReadDir(absPath); err == nil && len(entries) > 0 {
+		return fail(fmt.Errorf("%s already exists and is not empty", absPath))
+	}
+
+	adminDir, err := newAdminDir(common, filepath.Base(absPath))
+	if err != nil {
+		return fail(err)
+	}
+	// git writes these paths with forward slashes on Windows too
+	files := map[string]string{
+		filepath.Join(adminDir, "HEAD"):      "ref: " + ref.Name().String() + "\n",
+		filepath.Join(adminDir, "commondir"): "../..\n",
+		filepath.Join(adminDir, "gitdir"):    filepath.ToSlash(filepath.Join(absPath, ".git")) + "\n",
+		filepath.Join(absPath, ".git"):       "gitdir: " + filepath.ToSlash(adminDir) + "\n",
+	}
+	if err := os.MkdirAll(absPath, 0755); err != nil {
+		return fail(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			_ = os.RemoveAll(adminDir)
+			return fail(err)
+		}
+	}
+
+	wtRepo, err := b.open(absPath)
+	if err == nil {
+		var wt *git.Worktree
+		if wt, err = wtRepo.Worktree(); err == nil {
+			err = wt.Reset(&git.ResetOptions{Commit: ref.Hash(), Mode: git.HardReset})
+		}
+	}
+	if err != nil {
+		_ = os.RemoveAll(absPath)
+		_ = os.RemoveAll(adminDir)
+		return fail(err)
+	}
+	return nil
+}
+
+// newAdminDir creates a unique directory for a linked worktree named after
+// base under <common>/worktrees.
+func newAdminDir(common, base string) (string, error) {
+	root := filepath.Join(common, "worktrees")
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return "", err
+	}
+	for i := 0; ; i++ {
+		name := base
+		if i > 0 {
+			name = fmt.Sprintf("%s%d", base, i)
+		}
+		dir := filepath.Join(root, name)
+		if err := os.Mkdir(dir, 0755); err == nil {
+			return dir, nil
+		} else if !os.IsExist(err) {
+			return "", err
+		}
+	}
+}
+
+// branchCheckedOut returns the worktree that has ref checked out, or "".
+func branchCheckedOut(common string, ref plumbing.ReferenceName) (string, error) {
+	want := "ref: " + ref.String()
+	if head, err := os.ReadFile(filepath.Join(common, "HEAD")); err == nil && strings.TrimSpace(string(head)) == want {
+		return filepath.Dir(common), nil
+	}
+	entries, err := os.ReadDir(filepath.Join(common, "worktrees"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	for _, e := range entries {
+		adminDir := filepath.Join(common, "worktrees", e.Name())
+		head, err := os.ReadFile(filepath.Join(adminDir, "HEAD"))
+		if err != nil || strings.TrimSpace(string(head)) != want {
+			continue
+		}
+		gitFile, err := os.ReadFile(filepath.Join(adminDir, "gitdir"))
+		if err != nil {
+			continue
+		}
+		wtPath := filepath.Dir(filepath.FromSlash(strings.TrimSpace(string(gitFile))))
+		if _, err := os.Stat(wtPath); err == nil {
+			return wtPath, nil
+		}
+	}
+	return "", nil
+}
+
+func (b *goGitBackend) RemoveWorktree(path string) error {
+	adminDir, gitDirErr := readGitDirFile(path)
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("failed to remove worktree cleanly: %w", err)
+	}
+	if gitDirErr != nil {
+		return fmt.Errorf("failed to remove worktree cleanly: %w", gitDirErr)
+	}
+	if err := os.RemoveAll(adminDir); err != nil {
+		return fmt.Errorf("failed to remove worktree cleanly: %w", err)
+	}
+	return nil
+}
+
+// commitsBetween returns the commits reachable from head but not from base,
+// parents before children.
+func commitsBetween(repo *git.Repository, base, head string) ([]*object.Commit, error) {
+	baseCommit, err := resolve(repo, base)
+	if err != nil {
+		return nil, err
+	}
+	headCommit, err := resolve(repo, head)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[plumbing.Hash]bool)
+	iter := object.NewCommitPreorderIter(baseCommit, nil, nil)
+	err = iter.ForEach(func(c *object.Commit) error {
+		excluded[c.Hash] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Depth-first post-order walk, so each commit follows its parents.
+	var commits []*object.Commit
+	visited := make(map[plumbing.Hash]bool)
+	type frame struct {
+		commit *object.Commit
+		next   int
+	}
+	stack := []frame{{commit: headCommit}}
+	visited[headCommit.Hash] = true
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if excluded[top.commit.Hash] {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		if top.next < len(top.commit.ParentHashes) {
+			parent := top.commit.ParentHashes[top.next]
+			top.next++
+			if visited[parent] || excluded[parent] {
+				continue
+			}
+			visited[parent] = true
+			c, err := repo.CommitObject(parent)
+			if err != nil {
+				return nil, err
+			}
+			stack = append(stack, frame{commit: c})
+			continue
+		}
+		commits = append(commits, top.commit)
+		stack = stack[:len(stack)-1]
+	}
+	return commits, nil
+}
+
+func (b *goGitBackend) CommitsBetween(path, base, head string) ([]string, error) {
+	repo, err := b.open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits: %w", err)
+	}
+	commits, err := commitsBetween(repo, base, head)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits: %w", err)
+	}
+	shas := make([]string, len(commits))
+	for i, c := range commits {
+		shas[i] = c.Hash.String()
+	}
+	return shas, nil
+}
+
+func (b *goGitBackend) CountCommitsBetween(path, base, head string) (int, error) {
+	repo, err := b.open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count commits between %s and %s: %w", base, head, err)
+	}
+	commits, err := commitsBetween(repo, base, head)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count commits between %s and %s: %w", base, head, err)
+	}
+	return len(commits), nil
+}
+
+// findEntry returns the entry at name in tree, or nil if there is none.
+func findEntry(tree *object.Tree, name string) (*object.TreeEntry, error) {
+	entry, err := tree.FindEntry(name)
+	if errors.Is(err, object.ErrEntryNotFound) || errors.Is(err, object.ErrDirectoryNotFound) {
+		return nil, nil
+	}
+	return entry, err
+}
+
+// sameEntry reports whether two entries (nil meaning absent) are identical.
+func sameEntry(a, b *object.TreeEntry) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Hash == b.Hash && a.Mode == b.Mode
+}
+
+func (b *goGitBackend) CherryPick(path, commit string) error {
+	fail := func(err error) error {
+		return fmt.Errorf("failed to cherry-pick commit %s: %w", commit, err)
+	}
+
+	repo, err := b.open(path)
+	if err != nil {
+		return fail(err)
+	}
+	picked, err := resolve(repo, commit)
+	if err != nil {
+		return fail(err)
+	}
+	if picked.NumParents() != 1 {
+		return fail(fmt.Errorf("commit has %d parents; only single-parent commits can be cherry-picked", picked.NumParents()))
+	}
+	parent, err := picked.Parent(0)
+	if err != nil {
+		return fail(err)
+	}
+	head, err := resolve(repo, "HEAD")
+	if err != nil {
+		return fail(err)
+	}
+	baseTree, err := parent.Tree()
+	if err != nil {
+		return fail(err)
+	}
+	theirTree, err := picked.Tree()
+	if err != nil {
+		return fail(err)
+	}
+	ourTree, err := head.Tree()
+	if err != nil {
+		return fail(err)
+	}
+	changes, err := object.DiffTree(baseTree, theirTree)
+	if err != nil {
+		return fail(err)
+	}
+
+	names := make(map[string]bool)
+	for _, ch := range changes {
+		if ch.From.Name != "" {
+			names[ch.From.Name] = true
+		}
+		if ch.To.Name != "" {
+			names[ch.To.Name] = true
+		}
+	}
+
+	// Decide every path before touching the worktree, so a conflicting
+	// commit leaves it unchanged.
+	var deletes, writes []string
+	theirs := make(map[string]*object.TreeEntry)
+	var conflicts []string
+	for name := range names {
+		baseEntry, err := findEntry(baseTree, name)
+		if err != nil {
+			return fail(err)
+		}
+		theirEntry, err := findEntry(theirTree, name)
+		if err != nil {
+			return fail(err)
+		}
+		ourEntry, err := findEntry(ourTree, name)
+		if err != nil {
+			return fail(err)
+		}
+		switch {
+		case sameEntry(ourEntry, theirEntry):
+			// Already applied
+		case !sameEntry(ourEntry, baseEntry):
+			conflicts = append(conflicts, name)
+		case theirEntry == nil:
+			deletes = append(deletes, name)
+		default:
+			if theirEntry.Mode == filemode.Submodule {
+				return fail(fmt.Errorf("submodule change at %s is not supported by the %s backend", name, BackendGoGit))
+			}
+			theirs[name] = theirEntry
+			writes = append(writes, name)
+		}
+	}
+	if len(conflicts) > 0 {
+		slices.Sort(conflicts)
+		var out strings.Builder
+		for _, name := range conflicts {
+			fmt.Fprintf(&out, "CONFLICT (content): %s changed on both sides\n", name)
+		}
+		return &CherryPickConflictError{Commit: picked.Hash.String(), Output: out.String()}
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return fail(err)
+	}
+	slices.Sort(deletes)
+	for _, name := range deletes {
+		if _, err := wt.Remove(name); err != nil {
+			return fail(err)
+		}
+	}
+	slices.Sort(writes)
+	for _, name := range writes {
+		if err := writeEntry(repo, filepath.Join(path, filepath.FromSlash(name)), theirs[name]); err != nil {
+			return fail(err)
+		}
+		if err := wt.AddWithOptions(&git.AddOptions{Path: name, SkipStatus: true}); err != nil {
+			return fail(err)
+		}
+	}
+
+	author := picked.Author
+	_, err = wt.Commit(picked.Message, &git.CommitOptions{
+		Author:    &author,
+		Committer: committer(repo, author),
+	})
+	if errors.Is(err, git.ErrEmptyCommit) {
+		return nil // Every change was already on HEAD
+	}
+	if err != nil {
+		return fail(err)
+	}
+	return nil
+}
+
+// writeEntry writes the blob of entry to dst with the entry's file mode.
+func writeEntry(repo *git.Repository, dst string, entry *object.TreeEntry) error {
+	blob, err := repo.BlobObject(entry.Hash)
+	if err != nil {
+		return err
+	}
+	r, err := blob.Reader()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+	if entry.Mode == filemode.Symlink {
+		target, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(string(target), dst)
+	}
+
+	perm := os.FileMode(0644)
+	if entry.Mode == filemode.Executable {
+		perm = 0755
+	}
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// committer returns the configured user as a signature stamped now, falling
+// back to author's identity.
+func committer(repo *git.Repository, author object.Signature) *object.Signature {
+	sig := &object.Signature{Name: author.Name, Email: author.Email, When: time.Now()}
+	if cfg, err := repo.ConfigScoped(gitconfig.SystemScope); err == nil && cfg.User.Name != "" && cfg.User.Email != "" {
+		sig.Name = cfg.User.Name
+		sig.Email = cfg.User.Email
+	}
+	return sig
+}
+
+func (b *goGitBackend) AbortCherryPick(path string) error {
+	// CherryPick never leaves a partial pick behind.
+	return nil
+}
+
+func (b *goGitBackend) Push(path string, force bool) error {
+	fail := func(err error) error {
+		return fmt.Errorf("failed to push: %w", err)
+	}
+
+	repo, err := b.open(path)
+	if err != nil {
+		return fail(err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return fail(err)
+	}
+	if !head.Name().IsBranch() {
+		return fail(errors.New("HEAD is not on a branch"))
+	}
+
+	opts := &git.PushOptions{
+		RemoteName: "origin",
+		RefSpecs:   []gitconfig.RefSpec{gitconfig.RefSpec(head.Name() + ":" + head.Name())},
+	}
+	if force {
+		opts.ForceWithLease = &git.ForceWithLease{}
+	}
+	if err := repo.Push(opts); err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fail(err)
+	}
+
+	// Equivalent of `push -u`: track the pushed branch.
+	cfg, err := repo.Config()
+	if err != nil {
+		return fail(err)
+	}
+	branch := head.Name().Short()
+	cfg.Branches[branch] = &gitconfig.Branch{Name: branch, Remote: "origin", Merge: head.Name()}
+	if err := repo.SetConfig(cfg); err != nil {
+		return fail(err)
+	}
+	return nil
+}
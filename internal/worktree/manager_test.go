@@ -2,7 +2,9 @@ package worktree
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/Iron-Ham/claudio/internal/testutil"
@@ -739,6 +741,56 @@ func TestManagerCountCommitsBetween(t *testing.T) {
 	}
 }
 
+// TestManagerHistoryBetween tests ResolveCommit, CommitSummariesBetween, and
+// ChangedFilesBetween
+func TestManagerHistoryBetween(t *testing.T) {
+	testutil.SkipIfNoGit(t)
+
+	repoDir := testutil.SetupTestRepo(t)
+	mgr, err := New(repoDir)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	base, err := mgr.ResolveCommit("main")
+	if err != nil {
+		t.Fatalf("ResolveCommit() error = %v", err)
+	}
+	if _, err := mgr.ResolveCommit("no-such-branch"); err == nil {
+		t.Error("ResolveCommit() of a missing ref should fail")
+	}
+
+	worktreePath := filepath.Join(t.TempDir(), "test-worktree")
+	if err := mgr.Create(worktreePath, "feature"); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(worktreePath, "pkg"), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(worktreePath, "pkg", "a.go"), []byte("package pkg\n"), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	if err := mgr.CommitAll(worktreePath, "Add pkg"); err != nil {
+		t.Fatalf("CommitAll() error = %v", err)
+	}
+
+	commits, err := mgr.CommitSummariesBetween(base, "feature")
+	if err != nil {
+		t.Fatalf("CommitSummariesBetween() error = %v", err)
+	}
+	if len(commits) != 1 || !strings.HasSuffix(commits[0], " Add pkg") {
+		t.Errorf("CommitSummariesBetween() = %v, want one \"<sha> Add pkg\" entry", commits)
+	}
+
+	files, err := mgr.ChangedFilesBetween(base, "feature")
+	if err != nil {
+		t.Fatalf("ChangedFilesBetween() error = %v", err)
+	}
+	if len(files) != 1 || files[0] != "pkg/a.go" {
+		t.Errorf("ChangedFilesBetween() = %v, want [pkg/a.go]", files)
+	}
+}
+
 // TestManagerCopyLocalClaudeFiles tests the CopyLocalClaudeFiles method
 func TestManagerCopyLocalClaudeFiles(t *testing.T) {
 	testutil.SkipIfNoGit(t)
@@ -1013,6 +1065,46 @@ func TestIsCherryPickInProgress(t *testing.T) {
 	}
 }
 
+func TestCherryPickBranchAfter(t *testing.T) {
+	testutil.SkipIfNoGit(t)
+
+	repoDir := testutil.SetupTestRepo(t)
+	mgr, err := New(repoDir)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	git("checkout", "-q", "-b", "task")
+	for _, f := range []string{"one.txt", "two.txt", "three.txt"} {
+		testutil.CommitFile(t, repoDir, f, f+"\n", "Add "+f)
+	}
+	git("checkout", "-q", "-")
+	commits, err := mgr.GetCommitsBetween(repoDir, "HEAD", "task")
+	if err != nil || len(commits) != 3 {
+		t.Fatalf("GetCommitsBetween() = %v, %v; want 3 commits", commits, err)
+	}
+
+	if err := mgr.CherryPickBranchAfter(repoDir, "task", "0000000"); err == nil {
+		t.Error("CherryPickBranchAfter() with a commit not on the branch should fail")
+	}
+	if err := mgr.CherryPickBranchAfter(repoDir, "task", commits[0]); err != nil {
+		t.Fatalf("CherryPickBranchAfter() error = %v", err)
+	}
+	for f, want := range map[string]bool{"one.txt": false, "two.txt": true, "three.txt": true} {
+		if _, err := os.Stat(filepath.Join(repoDir, f)); (err == nil) != want {
+			t.Errorf("%s picked = %v, want %v", f, err == nil, want)
+		}
+	}
+}
+
 // TestGetConflictingFiles tests the GetConflictingFiles method
 func TestGetConflictingFiles(t *testing.T) {
 	testutil.SkipIfNoGit(t)
@@ -1032,3 +1124,30 @@ func TestGetConflictingFiles(t *testing.T) {
 		t.Errorf("GetConflictingFiles() returned %d files, want 0", len(files))
 	}
 }
+
+func TestManagerRemoteURL(t *testing.T) {
+	testutil.SkipIfNoGit(t)
+
+	repoDir := testutil.SetupTestRepo(t)
+	mgr, err := New(repoDir)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	if _, err := mgr.RemoteURL(); err == nil {
+		t.Error("RemoteURL() without an origin remote should fail")
+	}
+
+	cmd := exec.Command("git", "remote", "add", "origin", "git@github.com:Iron-Ham/claudio.git")
+	cmd.Dir = repoDir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git remote add: %v\n%s", err, out)
+	}
+	got, err := mgr.RemoteURL()
+	if err != nil {
+		t.Fatalf("RemoteURL() error = %v", err)
+	}
+	if got != "git@github.com:Iron-Ham/claudio.git" {
+		t.Errorf("RemoteURL() = %q", got)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/Iron-Ham/claudio/internal/logging"
@@ -16,6 +18,7 @@ type Manager struct {
 	logger             *logging.Logger
 	sparseCheckoutDirs []string // Directories to include in sparse checkout (nil = disabled)
 	coneMode           bool     // Whether to use cone mode for sparse checkout
+	backend            GitBackend
 }
 
 // SetLogger sets the logger for the worktree manager.
@@ -24,6 +27,32 @@ func (m *Manager) SetLogger(logger *logging.Logger) {
 	m.logger = logger
 }
 
+// SetGitBackend replaces the backend used for branch creation, consolidation
+// worktrees, cherry-picks, and pushes. A nil backend restores the default
+// BackendExec backend.
+func (m *Manager) SetGitBackend(backend GitBackend) {
+	if backend == nil {
+		backend = &execBackend{repoDir: m.repoDir}
+	}
+	m.backend = backend
+}
+
+// UseGitBackend selects a backend by name (see NewGitBackend) for the
+// manager's repository.
+func (m *Manager) UseGitBackend(name string) error {
+	backend, err := NewGitBackend(name, m.repoDir)
+	if err != nil {
+		return err
+	}
+	m.backend = backend
+	return nil
+}
+
+// GitBackend returns the backend the manager delegates to.
+func (m *Manager) GitBackend() GitBackend {
+	return m.backend
+}
+
 // SetSparseCheckoutConfig configures sparse checkout for new worktrees.
 // When directories is non-empty, sparse checkout will be applied automatically
 // when creating worktrees. Pass nil or empty to disable sparse checkout.
@@ -72,7 +101,7 @@ func New(repoDir string) (*Manager, error) {
 		return nil, fmt.Errorf("not a git repository: %s", repoDir)
 	}
 
-	return &Manager{repoDir: gitRoot}, nil
+	return &Manager{repoDir: gitRoot, backend: &execBackend{repoDir: gitRoot}}, nil
 }
 
 // Create creates a new worktree at the given path with a new branch.
@@ -158,28 +187,11 @@ func (m *Manager) CreateFromBranch(path, newBranch, baseBranch string) error {
 
 // Remove removes a worktree
 func (m *Manager) Remove(path string) error {
-	// First, try to remove the worktree
-	args := []string{"worktree", "remove", "--force", path}
-	cmd := exec.Command("git", args...)
-	cmd.Dir = m.repoDir
-
-	output, err := cmd.CombinedOutput()
-	if m.logger != nil {
-		m.logger.Debug("git command", "args", args, "output", truncateOutput(string(output), 500))
-	}
-	if err != nil {
+	if err := m.backend.RemoveWorktree(path); err != nil {
 		if m.logger != nil {
-			m.logger.Error("git command failed", "args", args, "error", err, "stderr", string(output))
+			m.logger.Error("failed to remove worktree", "path", path, "backend", m.backend.Name(), "error", err)
 		}
-		// If worktree remove fails, try to clean up manually
-		_ = os.RemoveAll(path)
-
-		// Prune worktree references
-		pruneCmd := exec.Command("git", "worktree", "prune")
-		pruneCmd.Dir = m.repoDir
-		_ = pruneCmd.Run()
-
-		return fmt.Errorf("failed to remove worktree cleanly: %w\n%s", err, string(output))
+		return err
 	}
 
 	if m.logger != nil {
@@ -202,7 +214,8 @@ func (m *Manager) List() ([]string, error) {
 	var worktrees []string
 	for _, line := range strings.Split(string(output), "\n") {
 		if strings.HasPrefix(line, "worktree ") {
-			path := strings.TrimPrefix(line, "worktree ")
+			// git prints forward slashes on Windows too
+			path := filepath.FromSlash(strings.TrimPrefix(line, "worktree "))
 			worktrees = append(worktrees, path)
 		}
 	}
@@ -210,6 +223,37 @@ func (m *Manager) List() ([]string, error) {
 	return worktrees, nil
 }
 
+// BranchExists reports whether a local branch with the given name exists
+func (m *Manager) BranchExists(branch string) bool {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	cmd.Dir = m.repoDir
+	return cmd.Run() == nil
+}
+
+// FindWorktreeForBranch returns the path of the worktree that has the branch
+// checked out, or "" if no worktree does.
+func (m *Manager) FindWorktreeForBranch(branch string) (string, error) {
+	cmd := exec.Command("git", "worktree", "list", "--porcelain")
+	cmd.Dir = m.repoDir
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to list worktrees: %w", err)
+	}
+
+	var path string
+	for _, line := range strings.Split(string(output), "\n") {
+		switch {
+		case strings.HasPrefix(line, "worktree "):
+			path = filepath.FromSlash(strings.TrimPrefix(line, "worktree "))
+		case line == "branch refs/heads/"+branch:
+			return path, nil
+		}
+	}
+
+	return "", nil
+}
+
 // GetBranch returns the branch for a worktree
 func (m *Manager) GetBranch(path string) (string, error) {
 	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
@@ -247,6 +291,34 @@ func (m *Manager) DeleteBranch(branch string) error {
 	return nil
 }
 
+// DeleteRemoteBranch deletes a branch on origin. A branch that is already
+// gone from the remote (e.g. deleted by the forge on merge) is not an error.
+func (m *Manager) DeleteRemoteBranch(branch string) error {
+	args := []string{"push", "origin", "--delete", branch}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = m.repoDir
+
+	output, err := cmd.CombinedOutput()
+	if m.logger != nil {
+		m.logger.Debug("git command", "args", args, "output", truncateOutput(string(output), 500))
+	}
+	if err != nil {
+		if strings.Contains(string(output), "remote ref does not exist") {
+			return nil
+		}
+		if m.logger != nil {
+			m.logger.Error("git command failed", "args", args, "error", err, "stderr", string(output))
+		}
+		return fmt.Errorf("failed to delete remote branch: %w\n%s", err, string(output))
+	}
+
+	if m.logger != nil {
+		m.logger.Info("remote branch deleted", "branch_name", branch)
+	}
+
+	return nil
+}
+
 // HasUncommittedChanges checks if a worktree has uncommitted changes
 func (m *Manager) HasUncommittedChanges(path string) (bool, error) {
 	args := []string{"status", "--porcelain"}
@@ -269,6 +341,68 @@ func (m *Manager) HasUncommittedChanges(path string) (bool, error) {
 	return hasChanges, nil
 }
 
+// GetUncommittedFiles returns the files with uncommitted changes in a
+// worktree: staged, unstaged, and untracked, sorted. Renamed files are
+// listed under their new path.
+func (m *Manager) GetUncommittedFiles(path string) ([]string, error) {
+	cmd := exec.Command("git", "status", "--porcelain", "-z", "--untracked-files=all")
+	cmd.Dir = path
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check status: %w", err)
+	}
+	return parseStatusPaths(string(output)), nil
+}
+
+// GetUncommittedDiff returns the uncommitted changes in a worktree: the
+// diff of tracked files against HEAD, followed by the names of untracked
+// files, whose contents are not included.
+func (m *Manager) GetUncommittedDiff(path string) (string, error) {
+	cmd := exec.Command("git", "diff", "HEAD")
+	cmd.Dir = path
+	diff, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff: %w", err)
+	}
+
+	cmd = exec.Command("git", "ls-files", "--others", "--exclude-standard")
+	cmd.Dir = path
+	untracked, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to list untracked files: %w", err)
+	}
+
+	var b strings.Builder
+	b.Write(diff)
+	for _, file := range strings.Split(strings.TrimSpace(string(untracked)), "\n") {
+		if file != "" {
+			b.WriteString("Untracked file: " + file + "\n")
+		}
+	}
+	return b.String(), nil
+}
+
+// parseStatusPaths extracts the paths from `git status --porcelain -z`
+// output. Each entry is "XY path"; renames and copies are followed by an
+// extra entry holding the original path, which is skipped.
+func parseStatusPaths(output string) []string {
+	entries := strings.Split(output, "\x00")
+	files := []string{}
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		files = append(files, entry[3:])
+		if entry[0] == 'R' || entry[0] == 'C' {
+			i++ // Skip the original path
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
 // CommitAll commits all changes in a worktree
 func (m *Manager) CommitAll(path, message string) error {
 	// Add all changes
@@ -345,19 +479,7 @@ func (m *Manager) GetChangedFiles(path string) ([]string, error) {
 
 // Push pushes the current branch to the remote
 func (m *Manager) Push(path string, force bool) error {
-	args := []string{"push", "-u", "origin", "HEAD"}
-	if force {
-		args = append(args, "--force-with-lease")
-	}
-
-	cmd := exec.Command("git", args...)
-	cmd.Dir = path
-
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to push: %w\n%s", err, string(output))
-	}
-
-	return nil
+	return m.backend.Push(path, force)
 }
 
 // RebaseOnMain rebases the current branch on main/master
@@ -462,17 +584,23 @@ func (m *Manager) GetBehindCount(path string) (int, error) {
 	return count, nil
 }
 
+// GetBranchBehindCount returns how many commits branch is behind origin/main.
+// Unlike GetBehindCount it does not need a worktree, so it can check branches
+// that are not checked out anywhere.
+func (m *Manager) GetBranchBehindCount(branch string) (int, error) {
+	mainBranch := m.findMainBranch()
+
+	// Fetch first
+	fetchCmd := exec.Command("git", "fetch", "origin", mainBranch)
+	fetchCmd.Dir = m.repoDir
+	_ = fetchCmd.Run() // Ignore error, might be offline
+
+	return m.CountCommitsBetween(m.repoDir, branch, "origin/"+mainBranch)
+}
+
 // findMainBranch returns the name of the main branch (main or master)
 func (m *Manager) findMainBranch() string {
-	// Check if 'main' exists
-	cmd := exec.Command("git", "rev-parse", "--verify", "main")
-	cmd.Dir = m.repoDir
-	if err := cmd.Run(); err == nil {
-		return "main"
-	}
-
-	// Fall back to 'master'
-	return "master"
+	return m.backend.MainBranch()
 }
 
 // FindMainBranch is the exported version of findMainBranch
@@ -480,6 +608,17 @@ func (m *Manager) FindMainBranch() string {
 	return m.findMainBranch()
 }
 
+// RemoteURL returns the URL of the origin remote
+func (m *Manager) RemoteURL() (string, error) {
+	cmd := exec.Command("git", "remote", "get-url", "origin")
+	cmd.Dir = m.repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get origin URL: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
 // BranchInfo contains information about a git branch
 type BranchInfo struct {
 	Name      string // Branch name (without refs/heads/ prefix)
@@ -544,24 +683,14 @@ func (m *Manager) ListBranches() ([]BranchInfo, error) {
 
 // CreateBranchFrom creates a new branch from a specified base branch (without creating a worktree)
 func (m *Manager) CreateBranchFrom(branchName, baseBranch string) error {
-	cmd := exec.Command("git", "branch", branchName, baseBranch)
-	cmd.Dir = m.repoDir
-
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to create branch %s from %s: %w\n%s", branchName, baseBranch, err, string(output))
-	}
-
-	return nil
+	return m.backend.CreateBranch(branchName, baseBranch)
 }
 
 // CreateWorktreeFromBranch creates a worktree from an existing branch.
 // If the repository has submodules, they are automatically initialized in the new worktree.
 func (m *Manager) CreateWorktreeFromBranch(path, branch string) error {
-	cmd := exec.Command("git", "worktree", "add", path, branch)
-	cmd.Dir = m.repoDir
-
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to create worktree from branch %s: %w\n%s", branch, err, string(output))
+	if err := m.backend.AddWorktree(path, branch); err != nil {
+		return err
 	}
 
 	// Initialize submodules if the repository has any
@@ -594,41 +723,79 @@ func (m *Manager) HasCommitsBeyond(path, baseBranch string) (bool, error) {
 
 // GetCommitsBetween returns the commit SHAs between base and head (exclusive of base)
 func (m *Manager) GetCommitsBetween(path, baseBranch, headBranch string) ([]string, error) {
-	cmd := exec.Command("git", "rev-list", "--reverse", baseBranch+".."+headBranch)
-	cmd.Dir = path
+	return m.backend.CommitsBetween(path, baseBranch, headBranch)
+}
 
+// CountCommitsBetween returns the number of commits between base and head branches.
+// This is more efficient than GetCommitsBetween when you only need the count.
+func (m *Manager) CountCommitsBetween(path, baseBranch, headBranch string) (int, error) {
+	return m.backend.CountCommitsBetween(path, baseBranch, headBranch)
+}
+
+// FetchBranch fetches branch from origin, updating origin/<branch>.
+func (m *Manager) FetchBranch(branch string) error {
+	cmd := exec.Command("git", "fetch", "origin", branch)
+	cmd.Dir = m.repoDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to fetch %s: %s: %w", branch, strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
+
+// ResolveCommit returns the full SHA of the commit ref points at.
+func (m *Manager) ResolveCommit(ref string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	cmd.Dir = m.repoDir
 	output, err := cmd.Output()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get commits: %w", err)
+		return "", fmt.Errorf("failed to resolve %s: %w", ref, err)
 	}
+	return strings.TrimSpace(string(output)), nil
+}
 
+// CommitSummariesBetween returns "<short sha> <subject>" for each commit
+// reachable from head but not from base, oldest first.
+func (m *Manager) CommitSummariesBetween(base, head string) ([]string, error) {
+	cmd := exec.Command("git", "log", "--reverse", "--format=%h %s", base+".."+head)
+	cmd.Dir = m.repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits between %s and %s: %w", base, head, err)
+	}
 	lines := strings.TrimSpace(string(output))
 	if lines == "" {
 		return []string{}, nil
 	}
-
 	return strings.Split(lines, "\n"), nil
 }
 
-// CountCommitsBetween returns the number of commits between base and head branches.
-// This is more efficient than GetCommitsBetween when you only need the count.
-func (m *Manager) CountCommitsBetween(path, baseBranch, headBranch string) (int, error) {
-	cmd := exec.Command("git", "rev-list", "--count", baseBranch+".."+headBranch)
-	cmd.Dir = path
-
+// ChangedFilesBetween returns the files changed on head since it diverged
+// from base.
+func (m *Manager) ChangedFilesBetween(base, head string) ([]string, error) {
+	cmd := exec.Command("git", "diff", "--name-only", base+"..."+head)
+	cmd.Dir = m.repoDir
 	output, err := cmd.Output()
 	if err != nil {
-		return 0, fmt.Errorf("failed to count commits between %s and %s: %w", baseBranch, headBranch, err)
+		return nil, fmt.Errorf("failed to list files changed between %s and %s: %w", base, head, err)
 	}
-
-	count := 0
-	_, _ = fmt.Sscanf(strings.TrimSpace(string(output)), "%d", &count)
-	return count, nil
+	files := strings.TrimSpace(string(output))
+	if files == "" {
+		return []string{}, nil
+	}
+	return strings.Split(files, "\n"), nil
 }
 
 // CherryPickBranch cherry-picks all commits from sourceBranch that aren't in the current branch
 // It cherry-picks commits one by one in order (oldest first)
 func (m *Manager) CherryPickBranch(path, sourceBranch string) error {
+	return m.CherryPickBranchAfter(path, sourceBranch, "")
+}
+
+// CherryPickBranchAfter cherry-picks the commits of sourceBranch that come
+// after afterCommit, to carry on with a branch once the conflict
+// CherryPickBranch stopped at is resolved and committed. An empty
+// afterCommit picks every commit.
+func (m *Manager) CherryPickBranchAfter(path, sourceBranch, afterCommit string) error {
 	mainBranch := m.findMainBranch()
 
 	// Get commits from source branch that are beyond main
@@ -636,6 +803,13 @@ func (m *Manager) CherryPickBranch(path, sourceBranch string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get commits from %s: %w", sourceBranch, err)
 	}
+	if afterCommit != "" {
+		i := slices.Index(commits, afterCommit)
+		if i < 0 {
+			return fmt.Errorf("commit %s is not on %s", afterCommit, sourceBranch)
+		}
+		commits = commits[i+1:]
+	}
 
 	if len(commits) == 0 {
 		return nil // Nothing to cherry-pick
@@ -643,19 +817,11 @@ func (m *Manager) CherryPickBranch(path, sourceBranch string) error {
 
 	// Cherry-pick each commit
 	for _, commit := range commits {
-		cmd := exec.Command("git", "cherry-pick", commit)
-		cmd.Dir = path
-
-		if output, err := cmd.CombinedOutput(); err != nil {
-			// Check for conflicts
-			if strings.Contains(string(output), "CONFLICT") || strings.Contains(string(output), "could not apply") {
-				return &CherryPickConflictError{
-					Commit:       commit,
-					SourceBranch: sourceBranch,
-					Output:       string(output),
-				}
+		if err := m.backend.CherryPick(path, commit); err != nil {
+			if conflict, ok := asCherryPickConflict(err); ok {
+				conflict.SourceBranch = sourceBranch
 			}
-			return fmt.Errorf("failed to cherry-pick commit %s: %w\n%s", commit, err, string(output))
+			return err
 		}
 	}
 
@@ -675,14 +841,7 @@ func (e *CherryPickConflictError) Error() string {
 
 // AbortCherryPick aborts an in-progress cherry-pick
 func (m *Manager) AbortCherryPick(path string) error {
-	cmd := exec.Command("git", "cherry-pick", "--abort")
-	cmd.Dir = path
-
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to abort cherry-pick: %w\n%s", err, string(output))
-	}
-
-	return nil
+	return m.backend.AbortCherryPick(path)
 }
 
 // ContinueCherryPick continues cherry-pick after conflict resolution
@@ -4,7 +4,10 @@ package worktree
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/Iron-Ham/claudio/internal/testutil"
@@ -268,6 +271,77 @@ func TestManager_HasUncommittedChanges(t *testing.T) {
 	}
 }
 
+func TestManager_GetUncommittedFiles(t *testing.T) {
+	testutil.SkipIfNoGit(t)
+
+	repoDir := testutil.SetupTestRepo(t)
+	mgr, err := New(repoDir)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	files, err := mgr.GetUncommittedFiles(repoDir)
+	if err != nil {
+		t.Fatalf("GetUncommittedFiles() error = %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("GetUncommittedFiles() = %v, want none for clean repo", files)
+	}
+
+	if err := os.MkdirAll(filepath.Join(repoDir, "pkg"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"pkg/new.go", "README.md"} {
+		if err := os.WriteFile(filepath.Join(repoDir, name), []byte("changed"), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	files, err = mgr.GetUncommittedFiles(repoDir)
+	if err != nil {
+		t.Fatalf("GetUncommittedFiles() error = %v", err)
+	}
+	want := []string{"README.md", "pkg/new.go"}
+	if !slices.Equal(files, want) {
+		t.Errorf("GetUncommittedFiles() = %v, want %v", files, want)
+	}
+}
+
+func TestManager_GetUncommittedDiff(t *testing.T) {
+	testutil.SkipIfNoGit(t)
+
+	repoDir := testutil.SetupTestRepo(t)
+	mgr, err := New(repoDir)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	diff, err := mgr.GetUncommittedDiff(repoDir)
+	if err != nil {
+		t.Fatalf("GetUncommittedDiff() error = %v", err)
+	}
+	if diff != "" {
+		t.Errorf("GetUncommittedDiff() = %q, want empty for clean repo", diff)
+	}
+
+	if err := os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, "new.txt"), []byte("new\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err = mgr.GetUncommittedDiff(repoDir)
+	if err != nil {
+		t.Fatalf("GetUncommittedDiff() error = %v", err)
+	}
+	for _, want := range []string{"diff --git a/README.md b/README.md", "+changed", "Untracked file: new.txt"} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("GetUncommittedDiff() missing %q in:\n%s", want, diff)
+		}
+	}
+}
+
 func TestManager_CommitAll(t *testing.T) {
 	testutil.SkipIfNoGit(t)
 
@@ -514,6 +588,51 @@ func TestManager_List(t *testing.T) {
 	}
 }
 
+func TestManager_BranchExistsAndFindWorktree(t *testing.T) {
+	testutil.SkipIfNoGit(t)
+
+	repoDir := testutil.SetupTestRepo(t)
+	mgr, err := New(repoDir)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	if mgr.BranchExists("claudio/plan-task") {
+		t.Error("BranchExists() = true before the branch was created")
+	}
+	if path, err := mgr.FindWorktreeForBranch("claudio/plan-task"); err != nil || path != "" {
+		t.Errorf("FindWorktreeForBranch() = %q, %v; want empty", path, err)
+	}
+
+	wtPath := filepath.Join(t.TempDir(), "wt")
+	if err := mgr.Create(wtPath, "claudio/plan-task"); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if !mgr.BranchExists("claudio/plan-task") {
+		t.Error("BranchExists() = false after the branch was created")
+	}
+	path, err := mgr.FindWorktreeForBranch("claudio/plan-task")
+	if err != nil {
+		t.Fatalf("FindWorktreeForBranch() error = %v", err)
+	}
+	want, _ := filepath.EvalSymlinks(wtPath)
+	got, _ := filepath.EvalSymlinks(path)
+	if got != want {
+		t.Errorf("FindWorktreeForBranch() = %q, want %q", path, wtPath)
+	}
+
+	if err := mgr.Remove(wtPath); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if path, _ := mgr.FindWorktreeForBranch("claudio/plan-task"); path != "" {
+		t.Errorf("FindWorktreeForBranch() after Remove = %q, want empty", path)
+	}
+	if !mgr.BranchExists("claudio/plan-task") {
+		t.Error("BranchExists() = false after removing only the worktree")
+	}
+}
+
 func TestManager_findMainBranch(t *testing.T) {
 	testutil.SkipIfNoGit(t)
 
@@ -740,3 +859,91 @@ func TestManager_CopyLocalClaudeFiles_PreservesPermissions(t *testing.T) {
 		t.Errorf("permissions not preserved: got %v, want %v", dstInfo.Mode(), srcInfo.Mode())
 	}
 }
+
+func TestManager_GetBranchBehindCount(t *testing.T) {
+	testutil.SkipIfNoGit(t)
+
+	repoDir := testutil.SetupTestRepo(t)
+	mgr, err := New(repoDir)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	remoteDir := filepath.Join(t.TempDir(), "origin.git")
+	for _, args := range [][]string{
+		{"clone", "--bare", repoDir, remoteDir},
+		{"remote", "add", "origin", remoteDir},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+
+	testutil.CreateBranch(t, repoDir, "feature")
+	testutil.CommitFile(t, repoDir, "a.txt", "a", "Advance main")
+	testutil.CommitFile(t, repoDir, "b.txt", "b", "Advance main again")
+
+	push := exec.Command("git", "push", "origin", "main")
+	push.Dir = repoDir
+	if output, err := push.CombinedOutput(); err != nil {
+		t.Fatalf("git push failed: %v\n%s", err, output)
+	}
+
+	behind, err := mgr.GetBranchBehindCount("feature")
+	if err != nil {
+		t.Fatalf("GetBranchBehindCount() error = %v", err)
+	}
+	if behind != 2 {
+		t.Errorf("GetBranchBehindCount() = %d, want 2", behind)
+	}
+
+	behind, err = mgr.GetBranchBehindCount("main")
+	if err != nil {
+		t.Fatalf("GetBranchBehindCount() error = %v", err)
+	}
+	if behind != 0 {
+		t.Errorf("GetBranchBehindCount(main) = %d, want 0", behind)
+	}
+}
+
+func TestManager_DeleteRemoteBranch(t *testing.T) {
+	testutil.SkipIfNoGit(t)
+
+	repoDir := testutil.SetupTestRepo(t)
+	mgr, err := New(repoDir)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	testutil.CreateBranch(t, repoDir, "feature")
+	remoteDir := filepath.Join(t.TempDir(), "origin.git")
+	for _, args := range [][]string{
+		{"clone", "--bare", repoDir, remoteDir},
+		{"remote", "add", "origin", remoteDir},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+
+	if err := mgr.DeleteRemoteBranch("feature"); err != nil {
+		t.Fatalf("DeleteRemoteBranch() error = %v", err)
+	}
+	check := exec.Command("git", "rev-parse", "--verify", "--quiet", "refs/heads/feature")
+	check.Dir = remoteDir
+	if check.Run() == nil {
+		t.Error("feature still exists on the remote")
+	}
+	if !mgr.BranchExists("feature") {
+		t.Error("DeleteRemoteBranch() deleted the local branch")
+	}
+
+	// Already deleted on the remote is not an error
+	if err := mgr.DeleteRemoteBranch("feature"); err != nil {
+		t.Errorf("DeleteRemoteBranch() of a missing branch error = %v", err)
+	}
+}
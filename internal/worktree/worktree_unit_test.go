@@ -1,6 +1,7 @@
 package worktree
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -253,3 +254,11 @@ func TestProviderTypeIsString(t *testing.T) {
 		t.Errorf("zero BranchInfo.IsMain = %v, want false", info.IsMain)
 	}
 }
+
+func TestParseStatusPaths(t *testing.T) {
+	output := " M b.go\x00R  new.go\x00old.go\x00?? a/c.go\x00"
+	want := []string{"a/c.go", "b.go", "new.go"}
+	if got := parseStatusPaths(output); !slices.Equal(got, want) {
+		t.Errorf("parseStatusPaths() = %v, want %v", got, want)
+	}
+}